@@ -172,10 +172,45 @@ const (
 	// precidence for the agent.
 	LoggingOverride = "LOGGING_OVERRIDE"
 
+	// LoggingFormat holds the log output format for the agent, "text"
+	// or "json", as last set in the model's logging-output-format
+	// config. LoggingFormatOverride, if set, is used instead, and
+	// model configuration is ignored.
+	LoggingFormat         = "LOGGING_FORMAT"
+	LoggingFormatOverride = "LOGGING_FORMAT_OVERRIDE"
+
+	// HookRuntime, if set to "container", causes a unit agent to run
+	// the hooks of charms that declare a hook-image in their metadata
+	// inside a container created from that image.
+	HookRuntime = "HOOK_RUNTIME"
+
 	LogSinkDBLoggerBufferSize    = "LOGSINK_DBLOGGER_BUFFER_SIZE"
 	LogSinkDBLoggerFlushInterval = "LOGSINK_DBLOGGER_FLUSH_INTERVAL"
 	LogSinkRateLimitBurst        = "LOGSINK_RATELIMIT_BURST"
 	LogSinkRateLimitRefill       = "LOGSINK_RATELIMIT_REFILL"
+
+	// IntrospectionPort, if set, causes a controller agent to serve
+	// the introspection and metrics endpoints on a dedicated port,
+	// authenticated with IntrospectionUsername and IntrospectionPassword
+	// rather than controller user credentials. The TLS certificate and
+	// key are read from IntrospectionCertFile and IntrospectionKeyFile
+	// if set, and otherwise the controller's certificate is used.
+	IntrospectionPort     = "INTROSPECTION_PORT"
+	IntrospectionCertFile = "INTROSPECTION_CERT_FILE"
+	IntrospectionKeyFile  = "INTROSPECTION_KEY_FILE"
+	IntrospectionUsername = "INTROSPECTION_USERNAME"
+	IntrospectionPassword = "INTROSPECTION_PASSWORD"
+
+	// MetricsPort, if set, causes a machine agent to serve its
+	// Prometheus metrics, and those of the unit agents on the
+	// machine, at /metrics on the given port. Requests must be
+	// authenticated with MetricsUsername and MetricsPassword. TLS is
+	// served if MetricsCertFile and MetricsKeyFile are set.
+	MetricsPort     = "METRICS_PORT"
+	MetricsCertFile = "METRICS_CERT_FILE"
+	MetricsKeyFile  = "METRICS_KEY_FILE"
+	MetricsUsername = "METRICS_USERNAME"
+	MetricsPassword = "METRICS_PASSWORD"
 )
 
 // The Config interface is the sole way that the agent gets access to the
@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logformat formats the log output of the agents, either as
+// plain text or as structured JSON records, so that agent logs can be
+// shipped to log aggregators that parse them.
+package logformat
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+)
+
+const (
+	// Text is the format in which each log entry is written as a
+	// line of plain text. It is the default format.
+	Text = "text"
+
+	// JSON is the format in which each log entry is written as a
+	// JSON object on a single line.
+	JSON = "json"
+)
+
+// Validate returns an error if the given format is not a known log
+// output format. The empty string is taken to mean Text.
+func Validate(format string) error {
+	switch format {
+	case "", Text, JSON:
+		return nil
+	}
+	return errors.NotValidf("log output format %q", format)
+}
+
+// Record is the JSON representation of a log entry.
+type Record struct {
+	Timestamp time.Time         `json:"timestamp"`
+	Level     string            `json:"level"`
+	Module    string            `json:"module"`
+	Location  string            `json:"location,omitempty"`
+	ModelUUID string            `json:"model-uuid,omitempty"`
+	Entity    string            `json:"entity,omitempty"`
+	Message   string            `json:"message"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// Formatter formats log entries in its current output format. Its
+// methods may be called concurrently.
+type Formatter struct {
+	mu        sync.Mutex
+	format    string
+	modelUUID string
+	entity    string
+}
+
+var defaultFormatter Formatter
+
+// Default returns the Formatter used for the log output of the agent
+// running in this process.
+func Default() *Formatter {
+	return &defaultFormatter
+}
+
+// SetFormat sets the output format, which must be valid.
+func (f *Formatter) SetFormat(format string) error {
+	if err := Validate(format); err != nil {
+		return errors.Trace(err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.format = format
+	return nil
+}
+
+// SetContext records the model and the entity of the agent that is
+// logging, which are included in each JSON record.
+func (f *Formatter) SetContext(modelUUID string, entity names.Tag) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.modelUUID = modelUUID
+	f.entity = ""
+	if entity != nil {
+		f.entity = entity.String()
+	}
+}
+
+// Format returns the given entry in the current output format. Entries
+// are formatted as text with the supplied function.
+func (f *Formatter) Format(entry loggo.Entry, text func(loggo.Entry) string) string {
+	f.mu.Lock()
+	format, modelUUID, entity := f.format, f.modelUUID, f.entity
+	f.mu.Unlock()
+	if format != JSON {
+		return text(entry)
+	}
+	record := Record{
+		Timestamp: entry.Timestamp.UTC(),
+		Level:     entry.Level.String(),
+		Module:    entry.Module,
+		ModelUUID: modelUUID,
+		Entity:    entity,
+		Message:   entry.Message,
+		Labels:    labels(entry.Module),
+	}
+	if entry.Filename != "" {
+		record.Location = fmt.Sprintf("%s:%d", entry.Filename, entry.Line)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		// This cannot happen for the types in Record, but the
+		// entry must not be lost.
+		return text(entry)
+	}
+	return string(data)
+}
+
+// labels returns the labels describing the source of the entries
+// logged by the given module. Charm hooks log to the modules
+// "unit.<unit name>.<hook name>" and "unit.<unit name>.juju-log".
+func labels(module string) map[string]string {
+	if !strings.HasPrefix(module, "unit.") {
+		return nil
+	}
+	parts := strings.SplitN(module, ".", 3)
+	if len(parts) != 3 {
+		return nil
+	}
+	return map[string]string{
+		"unit":   parts[1],
+		"source": parts[2],
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logformat_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent/logformat"
+)
+
+type formatterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&formatterSuite{})
+
+var entry = loggo.Entry{
+	Level:     loggo.WARNING,
+	Module:    "juju.worker.uniter",
+	Filename:  "uniter.go",
+	Line:      42,
+	Timestamp: time.Date(2017, 11, 1, 12, 0, 0, 0, time.FixedZone("", 3600)),
+	Message:   "something happened",
+}
+
+func text(entry loggo.Entry) string {
+	return "text: " + entry.Message
+}
+
+func (s *formatterSuite) TestValidate(c *gc.C) {
+	for _, format := range []string{"", "text", "json"} {
+		c.Check(logformat.Validate(format), jc.ErrorIsNil)
+	}
+	err := logformat.Validate("xml")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `log output format "xml" not valid`)
+}
+
+func (s *formatterSuite) TestDefaultText(c *gc.C) {
+	var f logformat.Formatter
+	c.Check(f.Format(entry, text), gc.Equals, "text: something happened")
+}
+
+func (s *formatterSuite) TestSetFormatInvalid(c *gc.C) {
+	var f logformat.Formatter
+	err := f.SetFormat("xml")
+	c.Check(err, gc.ErrorMatches, `log output format "xml" not valid`)
+	c.Check(f.Format(entry, text), gc.Equals, "text: something happened")
+}
+
+func (s *formatterSuite) TestJSON(c *gc.C) {
+	var f logformat.Formatter
+	c.Assert(f.SetFormat(logformat.JSON), jc.ErrorIsNil)
+	f.SetContext("deadbeef-0bad-400d-8000-4b1d0d06f00d", names.NewMachineTag("0"))
+
+	var record logformat.Record
+	err := json.Unmarshal([]byte(f.Format(entry, text)), &record)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(record, jc.DeepEquals, logformat.Record{
+		Timestamp: time.Date(2017, 11, 1, 11, 0, 0, 0, time.UTC),
+		Level:     "WARNING",
+		Module:    "juju.worker.uniter",
+		Location:  "uniter.go:42",
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Entity:    "machine-0",
+		Message:   "something happened",
+	})
+
+	c.Assert(f.SetFormat(logformat.Text), jc.ErrorIsNil)
+	c.Check(f.Format(entry, text), gc.Equals, "text: something happened")
+}
+
+func (s *formatterSuite) TestJSONUnitLabels(c *gc.C) {
+	var f logformat.Formatter
+	c.Assert(f.SetFormat(logformat.JSON), jc.ErrorIsNil)
+	unitEntry := entry
+	unitEntry.Module = "unit.mysql/0.db-relation-changed"
+
+	var record logformat.Record
+	err := json.Unmarshal([]byte(f.Format(unitEntry, text)), &record)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(record.Labels, jc.DeepEquals, map[string]string{
+		"unit":   "mysql/0",
+		"source": "db-relation-changed",
+	})
+	c.Check(record.ModelUUID, gc.Equals, "")
+	c.Check(record.Entity, gc.Equals, "")
+}
@@ -90,7 +90,7 @@ func (s *DiskManagerSuite) assertToolsContents(c *gc.C, t *coretools.Tools, file
 	for _, f := range files {
 		wantNames = append(wantNames, f.Header.Name)
 	}
-	wantNames = append(wantNames, agenttools.ToolsFile)
+	wantNames = append(wantNames, agenttools.ToolsFile, agenttools.ChecksumsFile)
 	dir := s.manager.(*agenttools.DiskManager).SharedToolsDir(t.Version)
 	assertDirNames(c, dir, wantNames)
 	expectedFileContents, err := json.Marshal(t)
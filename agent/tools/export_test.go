@@ -25,4 +25,5 @@ var (
 const (
 	ToolsFile      = toolsFile
 	GUIArchiveFile = guiArchiveFile
+	ChecksumsFile  = checksumsFile
 )
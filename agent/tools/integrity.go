@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tools
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	coretools "github.com/juju/juju/tools"
+)
+
+// TamperedError is returned by VerifyTools when the unpacked tools
+// do not match the tools metadata recorded by the controller, or
+// have changed since they were unpacked.
+type TamperedError struct {
+	reason string
+}
+
+// Error is part of the error interface.
+func (e *TamperedError) Error() string {
+	return "tools have been tampered with: " + e.reason
+}
+
+// IsTampered reports whether the cause of the given error is a
+// *TamperedError.
+func IsTampered(err error) bool {
+	_, ok := errors.Cause(err).(*TamperedError)
+	return ok
+}
+
+func tamperedf(format string, args ...interface{}) error {
+	return &TamperedError{reason: fmt.Sprintf(format, args...)}
+}
+
+// VerifyTools checks the integrity of the tools of the given version
+// unpacked within the dataDir directory. The checksum of the tarball
+// the tools were unpacked from must match that of the expected tools,
+// which should be the controller's metadata for the version, and each
+// unpacked file must be unchanged since it was unpacked.
+//
+// If the tools were unpacked without recording the checksums of their
+// files, VerifyTools returns an error satisfying errors.IsNotFound.
+func VerifyTools(dataDir string, vers version.Binary, expected *coretools.Tools) error {
+	tools, err := ReadTools(dataDir, vers)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if tools.SHA256 != expected.SHA256 {
+		return tamperedf("tarball sha256 mismatch, expected %s, got %s", expected.SHA256, tools.SHA256)
+	}
+
+	dir := SharedToolsDir(dataDir, vers)
+	checksumsData, err := ioutil.ReadFile(path.Join(dir, checksumsFile))
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("checksums for tools %s", vers)
+	} else if err != nil {
+		return errors.Annotate(err, "cannot read tools checksums")
+	}
+	var checksums map[string]string
+	if err := json.Unmarshal(checksumsData, &checksums); err != nil {
+		return errors.Annotatef(err, "invalid tools checksums in tools directory %q", dir)
+	}
+
+	fileNames := make([]string, 0, len(checksums))
+	for name := range checksums {
+		fileNames = append(fileNames, name)
+	}
+	sort.Strings(fileNames)
+	for _, name := range fileNames {
+		checksum, err := fileSHA256(path.Join(dir, name))
+		if os.IsNotExist(errors.Cause(err)) {
+			return tamperedf("%s is missing", name)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if checksum != checksums[name] {
+			return tamperedf("%s sha256 mismatch, expected %s, got %s", name, checksums[name], checksum)
+		}
+	}
+	return nil
+}
+
+// RecordChecksums records the checksums of the files of the tools of the
+// given version unpacked within the dataDir directory, for subsequent
+// verification by VerifyTools. It is intended for tools that were not
+// unpacked by UnpackTools, such as those installed when provisioning
+// a machine, which are then trusted as they are at the time of the
+// call.
+func RecordChecksums(dataDir string, vers version.Binary) error {
+	dir := SharedToolsDir(dataDir, vers)
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Annotate(err, "cannot read tools directory")
+	}
+	checksums := make(map[string]string)
+	for _, info := range infos {
+		name := info.Name()
+		if !info.Mode().IsRegular() || name == toolsFile || name == checksumsFile {
+			continue
+		}
+		checksum, err := fileSHA256(path.Join(dir, name))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		checksums[name] = checksum
+	}
+	return errors.Trace(writeChecksums(dir, checksums))
+}
+
+func writeChecksums(dir string, checksums map[string]string) error {
+	data, err := json.Marshal(checksums)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return ioutil.WriteFile(path.Join(dir, checksumsFile), data, 0644)
+}
+
+func fileSHA256(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", errors.Annotatef(err, "cannot read %q", name)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tools_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	agenttools "github.com/juju/juju/agent/tools"
+	"github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type IntegritySuite struct {
+	testing.BaseSuite
+	dataDir string
+	tools   *coretools.Tools
+}
+
+var _ = gc.Suite(&IntegritySuite{})
+
+func (s *IntegritySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dataDir = c.MkDir()
+
+	data, checksum := testing.TarGz(
+		testing.NewTarFile("jujuc", agenttools.DirPerm, "jujuc executable"),
+		testing.NewTarFile("jujud", agenttools.DirPerm, "jujud executable"),
+	)
+	s.tools = &coretools.Tools{
+		URL:     "http://foo/bar",
+		Version: version.MustParseBinary("1.2.3-quantal-amd64"),
+		Size:    int64(len(data)),
+		SHA256:  checksum,
+	}
+	err := agenttools.UnpackTools(s.dataDir, s.tools, bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *IntegritySuite) toolsFile(name string) string {
+	return filepath.Join(agenttools.SharedToolsDir(s.dataDir, s.tools.Version), name)
+}
+
+func (s *IntegritySuite) TestVerifyTools(c *gc.C) {
+	err := agenttools.VerifyTools(s.dataDir, s.tools.Version, s.tools)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *IntegritySuite) TestVerifyToolsTarballMismatch(c *gc.C) {
+	expected := *s.tools
+	expected.SHA256 = "1234"
+	err := agenttools.VerifyTools(s.dataDir, s.tools.Version, &expected)
+	c.Assert(err, jc.Satisfies, agenttools.IsTampered)
+	c.Assert(err, gc.ErrorMatches, "tools have been tampered with: tarball sha256 mismatch, expected 1234, got .*")
+}
+
+func (s *IntegritySuite) TestVerifyToolsFileChanged(c *gc.C) {
+	err := ioutil.WriteFile(s.toolsFile("jujud"), []byte("something else"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = agenttools.VerifyTools(s.dataDir, s.tools.Version, s.tools)
+	c.Assert(err, jc.Satisfies, agenttools.IsTampered)
+	c.Assert(err, gc.ErrorMatches, "tools have been tampered with: jujud sha256 mismatch, expected .*, got .*")
+}
+
+func (s *IntegritySuite) TestVerifyToolsFileMissing(c *gc.C) {
+	err := os.Remove(s.toolsFile("jujuc"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = agenttools.VerifyTools(s.dataDir, s.tools.Version, s.tools)
+	c.Assert(err, jc.Satisfies, agenttools.IsTampered)
+	c.Assert(err, gc.ErrorMatches, "tools have been tampered with: jujuc is missing")
+}
+
+func (s *IntegritySuite) TestVerifyToolsNoChecksums(c *gc.C) {
+	err := os.Remove(s.toolsFile(agenttools.ChecksumsFile))
+	c.Assert(err, jc.ErrorIsNil)
+	err = agenttools.VerifyTools(s.dataDir, s.tools.Version, s.tools)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(agenttools.IsTampered(err), jc.IsFalse)
+}
+
+func (s *IntegritySuite) TestRecordChecksums(c *gc.C) {
+	err := os.Remove(s.toolsFile(agenttools.ChecksumsFile))
+	c.Assert(err, jc.ErrorIsNil)
+	err = agenttools.RecordChecksums(s.dataDir, s.tools.Version)
+	c.Assert(err, jc.ErrorIsNil)
+	err = agenttools.VerifyTools(s.dataDir, s.tools.Version, s.tools)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = ioutil.WriteFile(s.toolsFile("jujud"), []byte("something else"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = agenttools.VerifyTools(s.dataDir, s.tools.Version, s.tools)
+	c.Assert(err, jc.Satisfies, agenttools.IsTampered)
+}
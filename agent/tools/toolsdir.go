@@ -26,6 +26,7 @@ const (
 	dirPerm        = 0755
 	guiArchiveFile = "downloaded-gui.txt"
 	toolsFile      = "downloaded-tools.txt"
+	checksumsFile  = "downloaded-tools-sha256.txt"
 )
 
 // SharedToolsDir returns the directory that is used to
@@ -97,6 +98,7 @@ func UnpackTools(dataDir string, tools *coretools.Tools, r io.Reader) (err error
 		return err
 	}
 	tr := tar.NewReader(f)
+	checksums := make(map[string]string)
 	for {
 		hdr, err := tr.Next()
 		if err == io.EOF {
@@ -112,9 +114,11 @@ func UnpackTools(dataDir string, tools *coretools.Tools, r io.Reader) (err error
 			return fmt.Errorf("bad file type %c in file %q in tools archive", hdr.Typeflag, hdr.Name)
 		}
 		name := path.Join(dir, hdr.Name)
-		if err := writeFile(name, os.FileMode(hdr.Mode&0777), tr); err != nil {
+		fileHash := sha256.New()
+		if err := writeFile(name, os.FileMode(hdr.Mode&0777), io.TeeReader(tr, fileHash)); err != nil {
 			return errors.Annotatef(err, "tar extract %q failed", name)
 		}
+		checksums[hdr.Name] = fmt.Sprintf("%x", fileHash.Sum(nil))
 	}
 	toolsMetadataData, err := json.Marshal(tools)
 	if err != nil {
@@ -124,6 +128,11 @@ func UnpackTools(dataDir string, tools *coretools.Tools, r io.Reader) (err error
 	if err != nil {
 		return err
 	}
+	// Record the checksum of each unpacked file, so that the
+	// agent can later verify that its binaries are unchanged.
+	if err := writeChecksums(dir, checksums); err != nil {
+		return err
+	}
 
 	// The tempdir is created with 0700, so we need to make it more
 	// accessable for juju-run.
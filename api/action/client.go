@@ -5,9 +5,12 @@ package action
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
 )
 
 // Client provides access to the action facade.
@@ -90,6 +93,89 @@ func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	return results, err
 }
 
+// ScheduleActions adds schedules for enqueueing actions on units, or
+// on every unit of applications, either once at a given time or
+// repeatedly according to a cron-like schedule.
+func (c *Client) ScheduleActions(arg params.ActionSchedules) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("scheduling actions on this version of Juju")
+	}
+	err := c.facade.FacadeCall("ScheduleActions", arg, &results)
+	return results, err
+}
+
+// ListActionSchedules returns all the action schedules in the model,
+// ordered by the time they are next due.
+func (c *Client) ListActionSchedules() (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("listing action schedules on this version of Juju")
+	}
+	err := c.facade.FacadeCall("ListActionSchedules", nil, &results)
+	return results, err
+}
+
+// CancelActionSchedules removes the action schedules with the given
+// ids. Actions already enqueued by them are not affected.
+func (c *Client) CancelActionSchedules(arg params.ActionScheduleIds) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("cancelling action schedules on this version of Juju")
+	}
+	err := c.facade.FacadeCall("CancelActionSchedules", arg, &results)
+	return results, err
+}
+
+// WatchActionLogs returns a StringsWatcher that notifies of the
+// messages logged by the given action while it runs. Each message is
+// a JSON encoded params.ActionMessage; the first event contains all
+// the messages logged so far.
+func (c *Client) WatchActionLogs(tag names.ActionTag) (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("watching action logs on this version of Juju")
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	err := c.facade.FacadeCall("WatchActionLogs", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// EnqueueActionRollouts adds rollouts that run actions on every unit
+// of applications in batches, and enqueues the first batch of each.
+func (c *Client) EnqueueActionRollouts(arg params.ActionRollouts) (params.ActionRolloutResults, error) {
+	results := params.ActionRolloutResults{}
+	if c.BestAPIVersion() < 5 {
+		return results, errors.NotSupportedf("action rollouts on this version of Juju")
+	}
+	err := c.facade.FacadeCall("EnqueueActionRollouts", arg, &results)
+	return results, err
+}
+
+// ListActionRollouts returns all the action rollouts in the model, in
+// the order they were added.
+func (c *Client) ListActionRollouts() (params.ActionRolloutResults, error) {
+	results := params.ActionRolloutResults{}
+	if c.BestAPIVersion() < 5 {
+		return results, errors.NotSupportedf("listing action rollouts on this version of Juju")
+	}
+	err := c.facade.FacadeCall("ListActionRollouts", nil, &results)
+	return results, err
+}
+
 // applicationsCharmActions is a batched query for the charm.Actions for a slice
 // of services by Entity.
 func (c *Client) applicationsCharmActions(arg params.Entities) (params.ApplicationsCharmActionsResults, error) {
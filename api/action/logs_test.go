@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type logsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&logsSuite{})
+
+const logsActionId = "01234567-89ab-cdef-0123-456789abcdef"
+
+func (s *logsSuite) TestWatchActionLogsError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Action")
+			c.Check(version, gc.Equals, 4)
+			c.Check(request, gc.Equals, "WatchActionLogs")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "action-" + logsActionId}},
+			})
+			*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+				Results: []params.StringsWatchResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.WatchActionLogs(names.NewActionTag(logsActionId))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *logsSuite) TestWatchActionLogsOldFacadeVersion(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.WatchActionLogs(names.NewActionTag(logsActionId))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type rolloutSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&rolloutSuite{})
+
+func (s *rolloutSuite) TestEnqueueActionRollouts(c *gc.C) {
+	args := params.ActionRollouts{
+		Rollouts: []params.ActionRollout{{
+			Application:   "application-mysql",
+			Name:          "restart",
+			MaxParallel:   1,
+			BatchDelay:    time.Minute,
+			StopOnFailure: true,
+		}},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Action")
+			c.Check(version, gc.Equals, 5)
+			c.Check(request, gc.Equals, "EnqueueActionRollouts")
+			c.Check(arg, jc.DeepEquals, args)
+			*(result.(*params.ActionRolloutResults)) = params.ActionRolloutResults{
+				Results: []params.ActionRolloutResult{{
+					Rollout: &params.ActionRollout{
+						Id:          "1",
+						Application: "application-mysql",
+						Name:        "restart",
+						Status:      "running",
+					},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := action.NewClient(apiCaller)
+	results, err := client.EnqueueActionRollouts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Rollout.Id, gc.Equals, "1")
+}
+
+func (s *rolloutSuite) TestListActionRollouts(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "ListActionRollouts")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.ActionRolloutResults)) = params.ActionRolloutResults{
+				Results: []params.ActionRolloutResult{{
+					Rollout: &params.ActionRollout{Id: "1"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := action.NewClient(apiCaller)
+	results, err := client.ListActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+}
+
+func (s *rolloutSuite) TestActionRolloutsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.EnqueueActionRollouts(params.ActionRollouts{})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.ListActionRollouts()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -6,6 +6,8 @@ package action
 import (
 	"time"
 
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -19,8 +21,14 @@ func (c *Client) RunOnAllMachines(commands string, timeout time.Duration) ([]par
 }
 
 // Run the Commands specified on the machines identified through the ids
-// provided in the machines, services and units slices.
+// provided in the machines, services and units slices. The Relation,
+// RemoteUnit, Environment and Stream options require version 6 of the
+// Action facade.
 func (c *Client) Run(run params.RunParams) ([]params.ActionResult, error) {
+	hasContext := run.Relation != "" || run.RemoteUnit != "" || len(run.Environment) > 0 || run.Stream
+	if hasContext && c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("running commands in a relation context, with environment variables or streamed output on this version of Juju")
+	}
 	var results params.ActionResults
 	err := c.facade.FacadeCall("Run", run, &results)
 	return results.Results, err
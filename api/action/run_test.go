@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type runSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&runSuite{})
+
+func (s *runSuite) TestRunHookContext(c *gc.C) {
+	run := params.RunParams{
+		Commands:    "relation-get",
+		Units:       []string{"mysql/0"},
+		Relation:    "db:1",
+		Environment: map[string]string{"DEBUG": "1"},
+		Stream:      true,
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Action")
+			c.Check(version, gc.Equals, 6)
+			c.Check(request, gc.Equals, "Run")
+			c.Check(arg, jc.DeepEquals, run)
+			*(result.(*params.ActionResults)) = params.ActionResults{
+				Results: []params.ActionResult{{
+					Action: &params.Action{Tag: "action-" + logsActionId},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := action.NewClient(apiCaller)
+	results, err := client.Run(run)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Action.Tag, gc.Equals, "action-"+logsActionId)
+}
+
+func (s *runSuite) TestRunHookContextOldFacadeVersion(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.Run(params.RunParams{
+		Commands: "relation-get",
+		Units:    []string{"mysql/0"},
+		Relation: "db:1",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type scheduleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) TestScheduleActions(c *gc.C) {
+	at := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	args := params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Receiver: "application-mysql",
+			Name:     "backup",
+			Spec:     "@daily",
+		}},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Action")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "ScheduleActions")
+			c.Check(arg, jc.DeepEquals, args)
+			*(result.(*params.ActionScheduleResults)) = params.ActionScheduleResults{
+				Results: []params.ActionScheduleResult{{
+					Schedule: &params.ActionSchedule{
+						Id:       "1",
+						Receiver: "application-mysql",
+						Name:     "backup",
+						Spec:     "@daily",
+						NextRun:  &at,
+					},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := action.NewClient(apiCaller)
+	results, err := client.ScheduleActions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Schedule.Id, gc.Equals, "1")
+}
+
+func (s *scheduleSuite) TestListActionSchedules(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "ListActionSchedules")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.ActionScheduleResults)) = params.ActionScheduleResults{
+				Results: []params.ActionScheduleResult{{
+					Schedule: &params.ActionSchedule{Id: "1"},
+				}, {
+					Schedule: &params.ActionSchedule{Id: "2"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := action.NewClient(apiCaller)
+	results, err := client.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+}
+
+func (s *scheduleSuite) TestCancelActionSchedules(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "CancelActionSchedules")
+			c.Check(arg, jc.DeepEquals, params.ActionScheduleIds{Ids: []string{"1"}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := action.NewClient(apiCaller)
+	results, err := client.CancelActionSchedules(params.ActionScheduleIds{Ids: []string{"1"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "boom")
+}
+
+func (s *scheduleSuite) TestActionSchedulesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.ScheduleActions(params.ActionSchedules{})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.ListActionSchedules()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.CancelActionSchedules(params.ActionScheduleIds{})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
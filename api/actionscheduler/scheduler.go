@@ -0,0 +1,35 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+)
+
+const apiName = "ActionScheduler"
+
+// Facade allows calls to "ActionScheduler" endpoints.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade returns a new "ActionScheduler" Facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{facade: base.NewFacadeCaller(caller, apiName)}
+}
+
+// RunDue calls "ActionScheduler.RunDue".
+func (f *Facade) RunDue() error {
+	return f.facade.FacadeCall("RunDue", nil, nil)
+}
+
+// AdvanceRollouts calls "ActionScheduler.AdvanceRollouts".
+func (f *Facade) AdvanceRollouts() error {
+	if f.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("action rollouts on this version of Juju")
+	}
+	return f.facade.FacadeCall("AdvanceRollouts", nil, nil)
+}
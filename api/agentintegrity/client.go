@@ -0,0 +1,36 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentintegrity provides a client for the AgentIntegrity
+// facade, which reports the outcome of each of a model's agents
+// checking the integrity of its binaries.
+package agentintegrity
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the agentintegrity API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the agentintegrity api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AgentIntegrity")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AgentIntegrity returns the most recent integrity report of each of
+// the model's agents.
+func (c *Client) AgentIntegrity() ([]params.AgentIntegrity, error) {
+	var result params.AgentIntegrityResult
+	if err := c.facade.FacadeCall("AgentIntegrity", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Agents, nil
+}
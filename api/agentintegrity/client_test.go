@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentintegrity_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/agentintegrity"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type AgentIntegritySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&AgentIntegritySuite{})
+
+func (s *AgentIntegritySuite) TestAgentIntegrity(c *gc.C) {
+	reports := []params.AgentIntegrity{{
+		Tag:     "machine-0",
+		Version: version.MustParseBinary("2.3.0-xenial-amd64"),
+		Status:  params.AgentIntegrityTampered,
+		Message: "jujud sha256 mismatch",
+		Updated: time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC),
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "AgentIntegrity")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AgentIntegrity")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.AgentIntegrityResult)) = params.AgentIntegrityResult{
+				Agents: reports,
+			}
+			return nil
+		})
+	client := agentintegrity.NewClient(apiCaller)
+	result, err := client.AgentIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, reports)
+}
+
+func (s *AgentIntegritySuite) TestAgentIntegrityError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		})
+	client := agentintegrity.NewClient(apiCaller)
+	_, err := client.AgentIntegrity()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
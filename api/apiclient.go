@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -43,9 +44,9 @@ import (
 // will run.
 const PingPeriod = 1 * time.Minute
 
-// pingTimeout defines how long a health check can take before we
-// consider it to have failed.
-const pingTimeout = 30 * time.Second
+// DefaultPingTimeout defines how long a health check can take before
+// we consider it to have failed, unless DialOpts.PingTimeout is set.
+const DefaultPingTimeout = 30 * time.Second
 
 // modelRoot is the prefix that all model API paths begin with.
 const modelRoot = "/model/"
@@ -91,6 +92,10 @@ type state struct {
 	// server does not report this during login.
 	serverVersion version.Number
 
+	// supportsIdempotencyKeys records whether the API server
+	// reported during login that it honours idempotency keys.
+	supportsIdempotencyKeys bool
+
 	// hostPorts is the API server addresses returned from Login,
 	// which the client may cache and use for failover.
 	hostPorts [][]network.HostPort
@@ -151,6 +156,25 @@ type state struct {
 	// bakeryClient holds the client that will be used to
 	// authorize macaroon based login requests.
 	bakeryClient *httpbakery.Client
+
+	// observerMu guards observer, interceptors and correlationId.
+	observerMu sync.Mutex
+
+	// observer, if not nil, is notified of every API call.
+	observer base.APICallObserver
+
+	// interceptors are called at each stage of every API call.
+	interceptors []base.APICallInterceptor
+
+	// correlationId, if not empty, is sent with every API call.
+	correlationId string
+
+	// healthMu guards health.
+	healthMu sync.Mutex
+
+	// health records the outcome of the most recent successful
+	// health check ping.
+	health base.ConnectionHealth
 }
 
 // RedirectError is returned from Open when the controller
@@ -237,13 +261,16 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 		// login because, when doing HTTP requests, we'll want
 		// to use the same username and password for authenticating
 		// those. If login fails, we discard the connection.
-		tag:          tagToString(info.Tag),
-		password:     info.Password,
-		macaroons:    info.Macaroons,
-		nonce:        info.Nonce,
-		tlsConfig:    dialResult.tlsConfig,
-		bakeryClient: bakeryClient,
-		modelTag:     info.ModelTag,
+		tag:           tagToString(info.Tag),
+		password:      info.Password,
+		macaroons:     info.Macaroons,
+		nonce:         info.Nonce,
+		tlsConfig:     dialResult.tlsConfig,
+		bakeryClient:  bakeryClient,
+		modelTag:      info.ModelTag,
+		observer:      opts.APICallObserver,
+		interceptors:  opts.APICallInterceptors,
+		correlationId: opts.CorrelationId,
 	}
 	if !info.SkipLogin {
 		if err := loginWithContext(ctx, st, info); err != nil {
@@ -255,11 +282,20 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 	st.broken = make(chan struct{})
 	st.closed = make(chan struct{})
 
+	pingPeriod := opts.PingPeriod
+	if pingPeriod <= 0 {
+		pingPeriod = PingPeriod
+	}
+	pingTimeout := opts.PingTimeout
+	if pingTimeout <= 0 {
+		pingTimeout = DefaultPingTimeout
+	}
 	go (&monitor{
 		clock:       opts.Clock,
 		ping:        st.Ping,
-		pingPeriod:  PingPeriod,
+		pingPeriod:  pingPeriod,
 		pingTimeout: pingTimeout,
+		recordPing:  st.recordPing,
 		closed:      st.closed,
 		dead:        client.Dead(),
 		broken:      st.broken,
@@ -498,6 +534,21 @@ func (s *state) Ping() error {
 	return s.APICall("Pinger", s.pingerFacadeVersion, "", "Ping", nil, nil)
 }
 
+// recordPing records the outcome of a successful health check ping.
+func (s *state) recordPing(at time.Time, rtt time.Duration) {
+	s.healthMu.Lock()
+	defer s.healthMu.Unlock()
+	s.health.LastPing = at
+	s.health.RTT = rtt
+}
+
+// ConnectionHealth implements base.ConnectionMonitor.
+func (s *state) ConnectionHealth() base.ConnectionHealth {
+	s.healthMu.Lock()
+	defer s.healthMu.Unlock()
+	return s.health
+}
+
 // apiPath returns the given API endpoint path relative
 // to the given model tag.
 func apiPath(modelTag names.ModelTag, path string) (string, error) {
@@ -914,13 +965,114 @@ var apiCallRetryStrategy = retry.LimitTime(10*time.Second,
 // object id, and the specific RPC method. It marshalls the Arguments, and will
 // unmarshall the result into the response object that is supplied.
 func (s *state) APICall(facade string, version int, id, method string, args, response interface{}) error {
+	return s.call(rpc.Request{
+		Type:    facade,
+		Version: version,
+		Id:      id,
+		Action:  method,
+	}, args, response)
+}
+
+// SupportsIdempotencyKeys implements base.IdempotentAPICaller.
+func (s *state) SupportsIdempotencyKeys() bool {
+	return s.supportsIdempotencyKeys
+}
+
+// IdempotentAPICall implements base.IdempotentAPICaller.
+func (s *state) IdempotentAPICall(key, facade string, version int, id, method string, args, response interface{}) error {
+	return s.call(rpc.Request{
+		Type:           facade,
+		Version:        version,
+		Id:             id,
+		Action:         method,
+		IdempotencyKey: key,
+	}, args, response)
+}
+
+// call places the given request, notifying any observer and
+// interceptors.
+func (s *state) call(req rpc.Request, args, response interface{}) error {
+	observer, interceptors, correlationId := s.callHooks()
+	req.CorrelationId = correlationId
+	if observer == nil && len(interceptors) == 0 {
+		return s.apiCall(req, args, response)
+	}
+	facade, version, id, method := req.Type, req.Version, req.Id, req.Action
+	info := base.APICallInfo{
+		Facade:        facade,
+		Version:       version,
+		Id:            id,
+		Request:       method,
+		CorrelationId: correlationId,
+	}
+	for _, interceptor := range interceptors {
+		interceptor.APICallStarted(info)
+	}
+	start := s.clock.Now()
+	err := s.apiCall(req, args, response)
+	duration := s.clock.Now().Sub(start)
+	for _, interceptor := range interceptors {
+		if err != nil {
+			interceptor.APICallFailed(info, err)
+		} else {
+			interceptor.APICallEnded(info, duration)
+		}
+	}
+	if observer == nil {
+		return err
+	}
+	record := base.APICallRecord{
+		Facade:   facade,
+		Version:  version,
+		Id:       id,
+		Request:  method,
+		Params:   base.SanitizePayload(args),
+		Duration: duration,
+		Error:    err,
+	}
+	if err == nil {
+		record.Response = base.SanitizePayload(response)
+	}
+	observer.APICallCompleted(record)
+	return err
+}
+
+// SetAPICallObserver implements base.ObservableAPICaller.
+func (s *state) SetAPICallObserver(observer base.APICallObserver) {
+	s.observerMu.Lock()
+	defer s.observerMu.Unlock()
+	s.observer = observer
+}
+
+// AddAPICallInterceptor implements base.TraceableAPICaller.
+func (s *state) AddAPICallInterceptor(interceptor base.APICallInterceptor) {
+	s.observerMu.Lock()
+	defer s.observerMu.Unlock()
+	// Copy the slice so that calls already in progress are
+	// unaffected.
+	interceptors := make([]base.APICallInterceptor, len(s.interceptors), len(s.interceptors)+1)
+	copy(interceptors, s.interceptors)
+	s.interceptors = append(interceptors, interceptor)
+}
+
+// SetCorrelationId implements base.TraceableAPICaller.
+func (s *state) SetCorrelationId(correlationId string) {
+	s.observerMu.Lock()
+	defer s.observerMu.Unlock()
+	s.correlationId = correlationId
+}
+
+// callHooks returns the observer, interceptors and correlation ID
+// to use for an API call.
+func (s *state) callHooks() (base.APICallObserver, []base.APICallInterceptor, string) {
+	s.observerMu.Lock()
+	defer s.observerMu.Unlock()
+	return s.observer, s.interceptors, s.correlationId
+}
+
+func (s *state) apiCall(req rpc.Request, args, response interface{}) error {
 	for a := retry.Start(apiCallRetryStrategy, s.clock); a.Next(); {
-		err := s.client.Call(rpc.Request{
-			Type:    facade,
-			Version: version,
-			Id:      id,
-			Action:  method,
-		}, args, response)
+		err := s.client.Call(req, args, response)
 		if params.ErrCode(err) != params.CodeRetry {
 			return errors.Trace(err)
 		}
@@ -25,6 +25,7 @@ import (
 	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/observer"
@@ -1035,6 +1036,83 @@ func (s *apiclientSuite) TestAPICallRetriesLimit(c *gc.C) {
 	})
 }
 
+func (s *apiclientSuite) TestAPICallObserver(c *gc.C) {
+	clock := &fakeClock{}
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: newRPCConnection(
+			&rpc.RequestError{Message: "hmm...", Code: params.CodeRetry},
+			nil,
+			errors.BadRequestf("boom"),
+		),
+		Clock: clock,
+	})
+	var records []base.APICallRecord
+	conn.(base.ObservableAPICaller).SetAPICallObserver(apiCallObserverFunc(func(record base.APICallRecord) {
+		records = append(records, record)
+	}))
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "machine-0"}}}
+	var result params.ErrorResults
+	err := conn.APICall("facade", 1, "id", "method", args, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	err = conn.APICall("facade", 2, "", "other", params.Creds{Password: "sekrit"}, nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	c.Assert(records, gc.HasLen, 2)
+	c.Check(records[0], jc.DeepEquals, base.APICallRecord{
+		Facade:   "facade",
+		Version:  1,
+		Id:       "id",
+		Request:  "method",
+		Params:   []byte(`{"entities":[{"tag":"machine-0"}]}`),
+		Response: []byte(`{"results":null}`),
+		Duration: 100 * time.Millisecond,
+	})
+	c.Check(records[1].Request, gc.Equals, "other")
+	c.Check(string(records[1].Params), gc.Equals, `{"auth-tag":"","nonce":"","password":"<redacted>"}`)
+	c.Check(records[1].Response, gc.IsNil)
+	c.Check(records[1].Error, gc.ErrorMatches, "boom")
+
+	conn.(base.ObservableAPICaller).SetAPICallObserver(nil)
+	err = conn.APICall("facade", 1, "id", "method", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 2)
+}
+
+func (s *apiclientSuite) TestAPICallInterceptors(c *gc.C) {
+	rpcConn := newRPCConnection(
+		&rpc.RequestError{Message: "hmm...", Code: params.CodeRetry},
+		nil,
+		errors.BadRequestf("boom"),
+	)
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: rpcConn,
+		Clock:         &fakeClock{},
+	})
+	var first, second recordingInterceptor
+	traceable := conn.(base.TraceableAPICaller)
+	traceable.AddAPICallInterceptor(&first)
+	traceable.AddAPICallInterceptor(&second)
+	traceable.SetCorrelationId("deadbeef")
+
+	err := conn.APICall("facade", 1, "id", "method", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	traceable.SetCorrelationId("")
+	err = conn.APICall("facade", 1, "", "other", nil, nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	expect := []string{
+		"started facade.method deadbeef",
+		"ended facade.method after 100ms",
+		"started facade.other ",
+		"failed facade.other: boom",
+	}
+	c.Check(first.events, jc.DeepEquals, expect)
+	c.Check(second.events, jc.DeepEquals, expect)
+	// The retried call is sent with the same correlation ID.
+	c.Check(rpcConn.correlationIds, jc.DeepEquals, []string{"deadbeef", "deadbeef", ""})
+}
+
 func (s *apiclientSuite) TestPing(c *gc.C) {
 	clock := &fakeClock{}
 	rpcConn := newRPCConnection()
@@ -1049,6 +1127,16 @@ func (s *apiclientSuite) TestPing(c *gc.C) {
 	}})
 }
 
+func (s *apiclientSuite) TestConnectionHealthBeforePing(c *gc.C) {
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: newRPCConnection(),
+		Clock:         &fakeClock{},
+	})
+	monitor, ok := conn.(base.ConnectionMonitor)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(monitor.ConnectionHealth(), jc.DeepEquals, base.ConnectionHealth{})
+}
+
 func (s *apiclientSuite) TestPingBroken(c *gc.C) {
 	conn := api.NewTestingState(api.TestingStateParams{
 		RPCConnection: newRPCConnection(errors.New("no biscuit")),
@@ -1120,8 +1208,32 @@ func newRPCConnection(errs ...error) *fakeRPCConnection {
 	return conn
 }
 
+type apiCallObserverFunc func(base.APICallRecord)
+
+func (f apiCallObserverFunc) APICallCompleted(record base.APICallRecord) {
+	f(record)
+}
+
+// recordingInterceptor records the stages of the API calls it sees.
+type recordingInterceptor struct {
+	events []string
+}
+
+func (r *recordingInterceptor) APICallStarted(info base.APICallInfo) {
+	r.events = append(r.events, fmt.Sprintf("started %s.%s %s", info.Facade, info.Request, info.CorrelationId))
+}
+
+func (r *recordingInterceptor) APICallEnded(info base.APICallInfo, duration time.Duration) {
+	r.events = append(r.events, fmt.Sprintf("ended %s.%s after %v", info.Facade, info.Request, duration))
+}
+
+func (r *recordingInterceptor) APICallFailed(info base.APICallInfo, err error) {
+	r.events = append(r.events, fmt.Sprintf("failed %s.%s: %v", info.Facade, info.Request, err))
+}
+
 type fakeRPCConnection struct {
-	stub testing.Stub
+	stub           testing.Stub
+	correlationIds []string
 }
 
 func (f *fakeRPCConnection) Dead() <-chan struct{} {
@@ -1134,6 +1246,7 @@ func (f *fakeRPCConnection) Close() error {
 
 func (f *fakeRPCConnection) Call(req rpc.Request, params, response interface{}) error {
 	f.stub.AddCall(req.Type+"."+req.Action, req.Version, params)
+	f.correlationIds = append(f.correlationIds, req.CorrelationId)
 	return f.stub.NextErr()
 }
 
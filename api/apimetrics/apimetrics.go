@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package apimetrics provides a prometheus.Collector that records the
+// latency and failures of the API calls made by an agent.
+package apimetrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/api/base"
+)
+
+const (
+	facadeLabel = "facade"
+	methodLabel = "method"
+)
+
+var callLabels = []string{facadeLabel, methodLabel}
+
+// CallMetrics is a base.APICallInterceptor that records metrics for
+// the API calls it sees, and a prometheus.Collector that collects
+// them.
+type CallMetrics struct {
+	callDuration *prometheus.SummaryVec
+	callErrors   *prometheus.CounterVec
+}
+
+// NewCallMetrics returns a new CallMetrics.
+func NewCallMetrics() *CallMetrics {
+	return &CallMetrics{
+		callDuration: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Namespace: "juju",
+			Name:      "api_client_request_duration_seconds",
+			Help:      "Time taken by successful API calls.",
+		}, callLabels),
+
+		callErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "juju",
+			Name:      "api_client_request_errors_total",
+			Help:      "Total number of failed API calls.",
+		}, callLabels),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *CallMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.callDuration.Describe(ch)
+	m.callErrors.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *CallMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.callDuration.Collect(ch)
+	m.callErrors.Collect(ch)
+}
+
+// APICallStarted is part of the base.APICallInterceptor interface.
+func (m *CallMetrics) APICallStarted(base.APICallInfo) {}
+
+// APICallEnded is part of the base.APICallInterceptor interface.
+func (m *CallMetrics) APICallEnded(info base.APICallInfo, duration time.Duration) {
+	m.callDuration.With(callLabelValues(info)).Observe(duration.Seconds())
+}
+
+// APICallFailed is part of the base.APICallInterceptor interface.
+func (m *CallMetrics) APICallFailed(info base.APICallInfo, err error) {
+	m.callErrors.With(callLabelValues(info)).Inc()
+}
+
+func callLabelValues(info base.APICallInfo) prometheus.Labels {
+	return prometheus.Labels{
+		facadeLabel: info.Facade,
+		methodLabel: info.Request,
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apimetrics_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/apimetrics"
+	"github.com/juju/juju/api/base"
+)
+
+type CallMetricsSuite struct {
+	testing.IsolationSuite
+	metrics *apimetrics.CallMetrics
+}
+
+var _ = gc.Suite(&CallMetricsSuite{})
+
+var _ base.APICallInterceptor = (*apimetrics.CallMetrics)(nil)
+
+func (s *CallMetricsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.metrics = apimetrics.NewCallMetrics()
+}
+
+func (s *CallMetricsSuite) TestDescribe(c *gc.C) {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		defer close(ch)
+		s.metrics.Describe(ch)
+	}()
+	var descs []*prometheus.Desc
+	for desc := range ch {
+		descs = append(descs, desc)
+	}
+	c.Assert(descs, gc.HasLen, 2)
+	c.Assert(descs[0].String(), gc.Matches, `.*fqName: "juju_api_client_request_duration_seconds".*`)
+	c.Assert(descs[1].String(), gc.Matches, `.*fqName: "juju_api_client_request_errors_total".*`)
+}
+
+func (s *CallMetricsSuite) TestCollect(c *gc.C) {
+	info := base.APICallInfo{
+		Facade:  "Uniter",
+		Version: 7,
+		Request: "Life",
+	}
+	s.metrics.APICallStarted(info)
+	s.metrics.APICallEnded(info, time.Second)
+	s.metrics.APICallStarted(info)
+	s.metrics.APICallEnded(info, 2*time.Second)
+	s.metrics.APICallStarted(info)
+	s.metrics.APICallFailed(info, errors.New("bewm"))
+
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		s.metrics.Collect(ch)
+	}()
+	var metrics []prometheus.Metric
+	for metric := range ch {
+		metrics = append(metrics, metric)
+	}
+	c.Assert(metrics, gc.HasLen, 2)
+
+	var duration, failures dto.Metric
+	c.Assert(metrics[0].Write(&duration), jc.ErrorIsNil)
+	c.Assert(metrics[1].Write(&failures), jc.ErrorIsNil)
+
+	labelpair := func(n, v string) *dto.LabelPair {
+		return &dto.LabelPair{Name: &n, Value: &v}
+	}
+	labels := []*dto.LabelPair{
+		labelpair("facade", "Uniter"),
+		labelpair("method", "Life"),
+	}
+	c.Check(duration.Label, jc.DeepEquals, labels)
+	c.Check(duration.Summary.GetSampleCount(), gc.Equals, uint64(2))
+	c.Check(duration.Summary.GetSampleSum(), gc.Equals, float64(3))
+	c.Check(failures.Label, jc.DeepEquals, labels)
+	c.Check(failures.Counter.GetValue(), gc.Equals, float64(1))
+}
@@ -8,18 +8,24 @@
 package application
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common/stream"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/watcher"
 )
 
 var logger = loggo.GetLogger("juju.api.application")
@@ -107,6 +113,11 @@ type DeployArgs struct {
 // Deploy obtains the charm, either locally or from the charm store, and deploys
 // it. Placement directives, if provided, specify the machine on which the charm
 // is deployed.
+//
+// If the model cannot satisfy the minimum Juju version required by the
+// charm, an error satisfying params.IsCodeCharmRequirementsNotMet is
+// returned. Well-known failures are reported as one of the typed
+// errors in this package, such as *CharmNotFoundError.
 func (c *Client) Deploy(args DeployArgs) error {
 	if len(args.AttachStorage) > 0 {
 		if args.NumUnits != 1 {
@@ -145,7 +156,127 @@ func (c *Client) Deploy(args DeployArgs) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return errors.Trace(results.OneError())
+	return errors.Trace(deployError(results.OneError()))
+}
+
+// DeployProgressKind identifies the kind of a DeployProgressEvent.
+type DeployProgressKind string
+
+const (
+	// DeployProgressMachine reports a change in the instance status
+	// of a machine hosting a unit of the application.
+	DeployProgressMachine DeployProgressKind = params.DeployProgressMachine
+
+	// DeployProgressUnitAgent reports a change in the agent status of
+	// a unit of the application, such as the charm being downloaded
+	// or the install hook running.
+	DeployProgressUnitAgent DeployProgressKind = params.DeployProgressUnitAgent
+
+	// DeployProgressUnitWorkload reports a change in the workload
+	// status of a unit of the application.
+	DeployProgressUnitWorkload DeployProgressKind = params.DeployProgressUnitWorkload
+
+	// DeployProgressStable is the final event of a successful
+	// deployment.
+	DeployProgressStable DeployProgressKind = params.DeployProgressStable
+
+	// DeployProgressFailed is the final event of a failed deployment,
+	// or of one whose progress could no longer be followed.
+	DeployProgressFailed DeployProgressKind = params.DeployProgressFailed
+)
+
+// DeployProgressEvent describes a single step in the progress of an
+// application deployment.
+type DeployProgressEvent struct {
+	// Kind identifies what the event reports.
+	Kind DeployProgressKind
+
+	// Entity is the machine, unit or application the event is about.
+	// It is nil if the progress stream failed.
+	Entity names.Tag
+
+	// Status and Message hold the entity's new status.
+	Status  status.Status
+	Message string
+
+	// Time is when the entity's status changed.
+	Time time.Time
+
+	// Err holds the reason the progress could no longer be followed,
+	// if it could not.
+	Err error
+}
+
+// DeployWithProgress deploys an application as Deploy does, and then
+// returns a channel on which the progress of the deployment is
+// delivered. The last event sent is of kind DeployProgressStable or
+// DeployProgressFailed, after which the channel is closed; callers
+// must read from the channel until it is closed.
+//
+// If the controller cannot stream deployment progress, an error is
+// returned and the application is not deployed.
+func (c *Client) DeployWithProgress(args DeployArgs) (<-chan DeployProgressEvent, error) {
+	// Start following progress before deploying, so that no events
+	// are missed.
+	source, err := stream.Open(c.st, "/deploy-progress", params.DeployProgressConfig{
+		Application: args.ApplicationName,
+		NumUnits:    args.NumUnits,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := c.Deploy(args); err != nil {
+		source.Close()
+		return nil, errors.Trace(err)
+	}
+
+	events := make(chan DeployProgressEvent)
+	go func() {
+		defer close(events)
+		defer source.Close()
+		for {
+			var apiEvent params.DeployProgressEvent
+			if err := source.ReadJSON(&apiEvent); err != nil {
+				events <- DeployProgressEvent{
+					Kind: DeployProgressFailed,
+					Err:  errors.Annotate(err, "reading deployment progress"),
+				}
+				return
+			}
+			event, err := deployProgressEventFromParams(apiEvent)
+			if err != nil {
+				events <- DeployProgressEvent{Kind: DeployProgressFailed, Err: errors.Trace(err)}
+				return
+			}
+			events <- event
+			if event.Kind == DeployProgressStable || event.Kind == DeployProgressFailed {
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+func deployProgressEventFromParams(apiEvent params.DeployProgressEvent) (DeployProgressEvent, error) {
+	event := DeployProgressEvent{
+		Kind:    DeployProgressKind(apiEvent.Kind),
+		Status:  status.Status(apiEvent.Status),
+		Message: apiEvent.Message,
+		Time:    apiEvent.Timestamp,
+	}
+	if apiEvent.Entity != "" {
+		tag, err := names.ParseTag(apiEvent.Entity)
+		if err != nil {
+			return event, errors.Trace(err)
+		}
+		event.Entity = tag
+	}
+	if event.Kind == DeployProgressFailed && event.Entity != nil {
+		event.Err = errors.Errorf("%s failed: %s", names.ReadableString(event.Entity), event.Message)
+	} else if event.Kind == DeployProgressFailed {
+		event.Err = errors.New(event.Message)
+	}
+	return event, nil
 }
 
 // GetCharmURL returns the charm URL the given service is
@@ -203,6 +334,54 @@ func (c *Client) GetConfig(appNames ...string) ([]map[string]interface{}, error)
 	return allSettings, nil
 }
 
+// GetConfigForSelector returns the application configuration settings
+// for each of the applications whose labels match the given selector,
+// e.g. "tier=frontend", keyed by application name.
+func (c *Client) GetConfigForSelector(selector string) (map[string]map[string]interface{}, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.New("this controller does not support label selectors")
+	}
+	var results params.ApplicationGetConfigResults
+	args := params.ApplicationGetConfigArgs{Selector: selector}
+	if err := c.facade.FacadeCall("GetConfig", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	allSettings := make(map[string]map[string]interface{})
+	for _, result := range results.Results {
+		tag, err := names.ParseApplicationTag(result.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "unable to get settings for %q", tag.Id())
+		}
+		allSettings[tag.Id()] = result.Config
+	}
+	return allSettings, nil
+}
+
+// GetConfigSchema returns the schema of the charm config options of
+// the given application, keyed by option name.
+func (c *Client) GetConfigSchema(application string) (map[string]params.ConfigOptionSchema, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.New("this controller does not support config schemas")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	var results params.ApplicationConfigSchemaResults
+	if err := c.facade.FacadeCall("GetConfigSchema", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Annotatef(err, "unable to get config schema for %q", application)
+	}
+	return results.Results[0].Schema, nil
+}
+
 // describeV5 will take the results of describeV4 from the apiserver
 // and remove the "default" boolean, and add in "source".
 // Mutates and returns the config map.
@@ -266,10 +445,22 @@ type SetCharmConfig struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]storage.Constraints `json:"storage-constraints,omitempty"`
+
+	// RollingUpgrade, if set, upgrades the application's units in
+	// batches rather than all at once. This field is only understood
+	// by Application facade version 8 and greater.
+	RollingUpgrade *params.RollingCharmUpgrade
 }
 
 // SetCharm sets the charm for a given service.
+//
+// If the model cannot satisfy the minimum Juju version required by the
+// charm, an error satisfying params.IsCodeCharmRequirementsNotMet is
+// returned.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
+	if cfg.RollingUpgrade != nil && c.BestAPIVersion() < 8 {
+		return errors.New("this controller does not support rolling charm upgrades")
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -299,10 +490,92 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceUnits:         cfg.ForceUnits,
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
+		RollingUpgrade:     cfg.RollingUpgrade,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
 
+// CharmUpgradeStatus returns the most recent rolling charm upgrade of
+// the given application.
+func (c *Client) CharmUpgradeStatus(application string) (params.CharmUpgradeStatus, error) {
+	if c.BestAPIVersion() < 8 {
+		return params.CharmUpgradeStatus{}, errors.New("this controller does not support rolling charm upgrades")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	var results params.CharmUpgradeStatusResults
+	if err := c.facade.FacadeCall("CharmUpgradeStatus", args, &results); err != nil {
+		return params.CharmUpgradeStatus{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.CharmUpgradeStatus{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.CharmUpgradeStatus{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// PauseCharmUpgrade stops the rolling charm upgrade of the given
+// application from starting another batch of units.
+func (c *Client) PauseCharmUpgrade(application string) error {
+	return c.changeCharmUpgrade("PauseCharmUpgrade", application)
+}
+
+// ResumeCharmUpgrade resumes the paused rolling charm upgrade of the
+// given application.
+func (c *Client) ResumeCharmUpgrade(application string) error {
+	return c.changeCharmUpgrade("ResumeCharmUpgrade", application)
+}
+
+// RollbackCharmUpgrade returns the units of the given application to
+// the charm they ran before its most recent rolling charm upgrade.
+func (c *Client) RollbackCharmUpgrade(application string) error {
+	return c.changeCharmUpgrade("RollbackCharmUpgrade", application)
+}
+
+func (c *Client) changeCharmUpgrade(method, application string) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.New("this controller does not support rolling charm upgrades")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CharmHistory returns up to limit of the most recent charm URLs and
+// config applied to the given application, newest first, along with
+// who made each change and when. All the recorded changes are returned
+// if limit is not positive.
+func (c *Client) CharmHistory(application string, limit int) ([]params.CharmHistoryEntry, error) {
+	if c.BestAPIVersion() < 9 {
+		return nil, errors.New("this controller does not support charm history")
+	}
+	args := params.ApplicationCharmHistoryArgs{
+		Args: []params.ApplicationCharmHistoryArg{{
+			Entity: params.Entity{Tag: names.NewApplicationTag(application).String()},
+			Limit:  limit,
+		}},
+	}
+	var results params.CharmHistoryResults
+	if err := c.facade.FacadeCall("CharmHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Entries, nil
+}
+
 // Update updates the application attributes, including charm URL,
 // minimum number of units, settings and constraints.
 func (c *Client) Update(args params.ApplicationUpdate) error {
@@ -347,7 +620,9 @@ type AddUnitsParams struct {
 }
 
 // AddUnits adds a given number of units to an application using the specified
-// placement directives to assign units to machines.
+// placement directives to assign units to machines. Well-known failures are
+// reported as one of the typed errors in this package, such as
+// *PlacementNotValidError.
 func (c *Client) AddUnits(args AddUnitsParams) ([]string, error) {
 	if len(args.AttachStorage) > 0 {
 		if args.NumUnits != 1 {
@@ -371,7 +646,7 @@ func (c *Client) AddUnits(args AddUnitsParams) ([]string, error) {
 		Placement:       args.Placement,
 		AttachStorage:   attachStorage,
 	}, results)
-	return results.Units, err
+	return results.Units, deployError(err)
 }
 
 // DestroyUnitsDeprecated decreases the number of units dedicated to an
@@ -395,6 +670,11 @@ type DestroyUnitsParams struct {
 	// DestroyStorage controls whether or not storage attached
 	// to the units will be destroyed.
 	DestroyStorage bool
+
+	// Force controls whether or not the applications' stop grace
+	// periods are overridden, so that the units' machines are torn
+	// down without waiting for their stop hooks.
+	Force bool
 }
 
 // DestroyUnits decreases the number of units dedicated to one or more
@@ -416,11 +696,15 @@ func (c *Client) DestroyUnits(in DestroyUnitsParams) ([]params.DestroyUnitResult
 		argsV5.Units = append(argsV5.Units, params.DestroyUnitParams{
 			UnitTag:        names.NewUnitTag(name).String(),
 			DestroyStorage: in.DestroyStorage,
+			Force:          in.Force,
 		})
 	}
 	if len(argsV5.Units) == 0 {
 		return allResults, nil
 	}
+	if in.Force && c.BestAPIVersion() < 12 {
+		return nil, errors.New("this controller does not support --force")
+	}
 
 	args := interface{}(argsV5)
 	if c.BestAPIVersion() < 5 {
@@ -473,6 +757,12 @@ type DestroyApplicationsParams struct {
 	// DestroyStorage controls whether or not storage attached
 	// to units of the applications will be destroyed.
 	DestroyStorage bool
+
+	// Selector, if set, selects further applications to destroy
+	// by label, e.g. "tier=frontend". The results for these
+	// follow those for Applications, and identify the application
+	// by its tag.
+	Selector string
 }
 
 // DestroyApplications destroys the given applications.
@@ -495,7 +785,13 @@ func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.Des
 			DestroyStorage: in.DestroyStorage,
 		})
 	}
-	if len(argsV5.Applications) == 0 {
+	if in.Selector != "" {
+		if c.BestAPIVersion() < 6 {
+			return nil, errors.New("this controller does not support label selectors")
+		}
+		argsV5.Selector = in.Selector
+		argsV5.DestroyStorage = in.DestroyStorage
+	} else if len(argsV5.Applications) == 0 {
 		return allResults, nil
 	}
 
@@ -517,13 +813,15 @@ func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.Des
 	if err := c.facade.FacadeCall("DestroyApplication", args, &result); err != nil {
 		return nil, errors.Trace(err)
 	}
-	if n := len(result.Results); n != len(argsV5.Applications) {
+	// Results for applications selected by label follow
+	// those for the applications named explicitly.
+	if n := len(result.Results); n < len(argsV5.Applications) || (in.Selector == "" && n > len(argsV5.Applications)) {
 		return nil, errors.Errorf("expected %d result(s), got %d", len(argsV5.Applications), n)
 	}
-	for i, result := range result.Results {
+	for i, result := range result.Results[:len(argsV5.Applications)] {
 		allResults[index[i]] = result
 	}
-	return allResults, nil
+	return append(allResults, result.Results[len(argsV5.Applications):]...), nil
 }
 
 // GetConstraints returns the constraints for the given applications.
@@ -570,6 +868,84 @@ func (c *Client) SetConstraints(application string, constraints constraints.Valu
 	return c.facade.FacadeCall("SetConstraints", params, nil)
 }
 
+// SetConstraintsForSelector specifies the constraints for each of the
+// applications whose labels match the given selector.
+func (c *Client) SetConstraintsForSelector(selector string, constraints constraints.Value) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.New("this controller does not support label selectors")
+	}
+	params := params.SetConstraints{
+		Selector:    selector,
+		Constraints: constraints,
+	}
+	return c.facade.FacadeCall("SetConstraints", params, nil)
+}
+
+// SetLabels replaces the labels of the given application.
+func (c *Client) SetLabels(application string, labels map[string]string) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.New("this controller does not support application labels")
+	}
+	args := params.ApplicationLabelsArgs{
+		Args: []params.ApplicationLabels{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Labels:         labels,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetLabels", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// SetStopGracePeriod sets how long the units of the given application
+// are given to stop when they are destroyed. Zero means that units wait
+// for their stop hooks to succeed, however long that takes.
+func (c *Client) SetStopGracePeriod(application string, period time.Duration) error {
+	if c.BestAPIVersion() < 12 {
+		return errors.New("this controller does not support stop grace periods")
+	}
+	args := params.ApplicationStopGracePeriods{
+		Args: []params.ApplicationStopGracePeriod{{
+			ApplicationTag:  names.NewApplicationTag(application).String(),
+			StopGracePeriod: period,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetStopGracePeriods", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// Labels returns the labels of each of the given applications.
+func (c *Client) Labels(applications ...string) ([]map[string]string, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.New("this controller does not support application labels")
+	}
+	var args params.Entities
+	for _, application := range applications {
+		args.Entities = append(args.Entities,
+			params.Entity{names.NewApplicationTag(application).String()})
+	}
+	var results params.ApplicationLabelsResults
+	if err := c.facade.FacadeCall("GetLabels", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(applications) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(applications), len(results.Results))
+	}
+	allLabels := make([]map[string]string, len(applications))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "unable to get labels for %q", applications[i])
+		}
+		allLabels[i] = result.Labels
+	}
+	return allLabels, nil
+}
+
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Expose(application string) error {
@@ -610,6 +986,302 @@ func (c *Client) Unset(application string, options []string) error {
 	return c.facade.FacadeCall("Unset", p, nil)
 }
 
+// AddBranch adds a branch in which application config changes can be
+// staged for a subset of units before being committed.
+func (c *Client) AddBranch(branch string) error {
+	return c.changeBranch("AddBranch", branch)
+}
+
+// CommitBranch applies the config changes staged in the branch to all
+// units of their applications, and removes the branch.
+func (c *Client) CommitBranch(branch string) error {
+	return c.changeBranch("CommitBranch", branch)
+}
+
+// AbortBranch removes the branch, discarding the config changes staged
+// in it.
+func (c *Client) AbortBranch(branch string) error {
+	return c.changeBranch("AbortBranch", branch)
+}
+
+func (c *Client) changeBranch(method, branch string) error {
+	if c.BestAPIVersion() < 10 {
+		return errors.New("this controller does not support branches")
+	}
+	args := params.BranchArgs{
+		Branches: []params.BranchArg{{BranchName: branch}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// TrackBranch assigns the given units to the branch, so that they see
+// the config changes staged in it for their applications.
+func (c *Client) TrackBranch(branch string, units ...string) error {
+	if c.BestAPIVersion() < 10 {
+		return errors.New("this controller does not support branches")
+	}
+	args := params.BranchTrackArg{
+		BranchName: branch,
+		Entities:   make([]params.Entity, len(units)),
+	}
+	for i, unit := range units {
+		if !names.IsValidUnit(unit) {
+			return errors.NotValidf("unit name %q", unit)
+		}
+		args.Entities[i].Tag = names.NewUnitTag(unit).String()
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("TrackBranch", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// BranchInfo returns the config changes staged in the branch, and the
+// units assigned to it.
+func (c *Client) BranchInfo(branch string) (params.BranchInfo, error) {
+	if c.BestAPIVersion() < 10 {
+		return params.BranchInfo{}, errors.New("this controller does not support branches")
+	}
+	args := params.BranchArgs{
+		Branches: []params.BranchArg{{BranchName: branch}},
+	}
+	var results params.BranchResults
+	if err := c.facade.FacadeCall("BranchInfo", args, &results); err != nil {
+		return params.BranchInfo{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.BranchInfo{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.BranchInfo{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// GetInBranch returns the configuration of an application as seen by
+// the units assigned to the branch.
+func (c *Client) GetInBranch(branch, application string) (*params.ApplicationGetResults, error) {
+	if c.BestAPIVersion() < 10 {
+		return nil, errors.New("this controller does not support branches")
+	}
+	var results params.ApplicationGetResults
+	args := params.ApplicationGet{
+		ApplicationName: application,
+		BranchName:      branch,
+	}
+	err := c.facade.FacadeCall("Get", args, &results)
+	return &results, err
+}
+
+// SetInBranch stages changes to an application's configuration
+// options in the branch.
+func (c *Client) SetInBranch(branch, application string, options map[string]string) error {
+	if c.BestAPIVersion() < 10 {
+		return errors.New("this controller does not support branches")
+	}
+	p := params.ApplicationSet{
+		ApplicationName: application,
+		Options:         options,
+		BranchName:      branch,
+	}
+	return c.facade.FacadeCall("Set", p, nil)
+}
+
+// UnsetInBranch stages the reset of an application's configuration
+// options in the branch.
+func (c *Client) UnsetInBranch(branch, application string, options []string) error {
+	if c.BestAPIVersion() < 10 {
+		return errors.New("this controller does not support branches")
+	}
+	p := params.ApplicationUnset{
+		ApplicationName: application,
+		Options:         options,
+		BranchName:      branch,
+	}
+	return c.facade.FacadeCall("Unset", p, nil)
+}
+
+// ScaleApplication sets the number of pods running the given
+// Kubernetes application.
+func (c *Client) ScaleApplication(application string, scale int) error {
+	_, err := c.scaleApplication(params.ScaleApplicationParams{
+		ApplicationTag: names.NewApplicationTag(application).String(),
+		Scale:          scale,
+	})
+	return err
+}
+
+// ChangeApplicationScale adds scaleChange pods to, or removes them
+// from, the given Kubernetes application, and returns the resulting
+// scale.
+func (c *Client) ChangeApplicationScale(application string, scaleChange int) (int, error) {
+	return c.scaleApplication(params.ScaleApplicationParams{
+		ApplicationTag: names.NewApplicationTag(application).String(),
+		ScaleChange:    scaleChange,
+	})
+}
+
+func (c *Client) scaleApplication(arg params.ScaleApplicationParams) (int, error) {
+	if c.BestAPIVersion() < 11 {
+		return 0, errors.New("this controller does not support scaling applications")
+	}
+	args := params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{arg},
+	}
+	var results params.ScaleApplicationResults
+	if err := c.facade.FacadeCall("ScaleApplications", args, &results); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return 0, err
+	}
+	return results.Results[0].Scale, nil
+}
+
+// GetPodSpec returns the current pod spec of the given Kubernetes
+// application.
+func (c *Client) GetPodSpec(application string) (params.PodSpec, error) {
+	if c.BestAPIVersion() < 11 {
+		return params.PodSpec{}, errors.New("this controller does not support pod specs")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.PodSpecResults
+	if err := c.facade.FacadeCall("GetPodSpec", args, &results); err != nil {
+		return params.PodSpec{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.PodSpec{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.PodSpec{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// SetPodSpec sets the pod spec of the given Kubernetes application,
+// starting a rollout of the new revision.
+func (c *Client) SetPodSpec(application, spec string) error {
+	if c.BestAPIVersion() < 11 {
+		return errors.New("this controller does not support pod specs")
+	}
+	args := params.SetPodSpecParams{
+		Specs: []params.EntityPodSpec{{
+			Tag:  names.NewApplicationTag(application).String(),
+			Spec: spec,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetPodSpec", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// PodSpecHistory returns up to limit of the most recent pod spec
+// revisions of the given Kubernetes application, newest first. All
+// the recorded revisions are returned if limit is not positive.
+func (c *Client) PodSpecHistory(application string, limit int) ([]params.PodSpec, error) {
+	if c.BestAPIVersion() < 11 {
+		return nil, errors.New("this controller does not support pod specs")
+	}
+	args := params.PodSpecHistoryArgs{
+		Args: []params.PodSpecHistoryArg{{
+			Entity: params.Entity{Tag: names.NewApplicationTag(application).String()},
+			Limit:  limit,
+		}},
+	}
+	var results params.PodSpecHistoryResults
+	if err := c.facade.FacadeCall("PodSpecHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Revisions, nil
+}
+
+// RolloutStatus returns the progress of the rollout of the current pod
+// spec revision of the given Kubernetes application.
+func (c *Client) RolloutStatus(application string) (params.RolloutStatus, error) {
+	if c.BestAPIVersion() < 11 {
+		return params.RolloutStatus{}, errors.New("this controller does not support rollout status")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.RolloutStatusResults
+	if err := c.facade.FacadeCall("RolloutStatus", args, &results); err != nil {
+		return params.RolloutStatus{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.RolloutStatus{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.RolloutStatus{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// WatchRolloutStatus returns a watcher that notifies of changes to the
+// pod spec, scale or rollout status of the given Kubernetes
+// application.
+func (c *Client) WatchRolloutStatus(application string) (watcher.NotifyWatcher, error) {
+	if c.BestAPIVersion() < 11 {
+		return nil, errors.New("this controller does not support rollout status")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchRolloutStatus", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// UnitContainerStatuses returns the status of the container running
+// each unit of the given Kubernetes application, as reported by the
+// cloud. Units whose container has not reported a status are omitted.
+func (c *Client) UnitContainerStatuses(application string) ([]params.UnitContainerStatus, error) {
+	if c.BestAPIVersion() < 11 {
+		return nil, errors.New("this controller does not support container statuses")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.UnitContainerStatusesResults
+	if err := c.facade.FacadeCall("UnitContainerStatuses", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Units, nil
+}
+
 // CharmRelations returns the application's charms relation names.
 func (c *Client) CharmRelations(application string) ([]string, error) {
 	var results params.ApplicationCharmRelationsResults
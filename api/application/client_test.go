@@ -4,14 +4,20 @@
 package application_test
 
 import (
+	"io"
+	"net/url"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/base"
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
@@ -19,6 +25,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -33,6 +40,34 @@ func newClient(f basetesting.APICallerFunc) *application.Client {
 	return application.NewClient(basetesting.BestVersionCaller{f, 5})
 }
 
+func newClientV6(f basetesting.APICallerFunc) *application.Client {
+	return application.NewClient(basetesting.BestVersionCaller{f, 6})
+}
+
+func newClientV7(f basetesting.APICallerFunc) *application.Client {
+	return application.NewClient(basetesting.BestVersionCaller{f, 7})
+}
+
+func newClientV8(f basetesting.APICallerFunc) *application.Client {
+	return application.NewClient(basetesting.BestVersionCaller{f, 8})
+}
+
+func newClientV9(f basetesting.APICallerFunc) *application.Client {
+	return application.NewClient(basetesting.BestVersionCaller{f, 9})
+}
+
+func newClientV10(f basetesting.APICallerFunc) *application.Client {
+	return application.NewClient(basetesting.BestVersionCaller{f, 10})
+}
+
+func newClientV11(f basetesting.APICallerFunc) *application.Client {
+	return application.NewClient(basetesting.BestVersionCaller{f, 11})
+}
+
+func newClientV12(f basetesting.APICallerFunc) *application.Client {
+	return application.NewClient(basetesting.BestVersionCaller{f, 12})
+}
+
 func newClientV4(f basetesting.APICallerFunc) *application.Client {
 	return application.NewClient(basetesting.BestVersionCaller{f, 4})
 }
@@ -127,6 +162,135 @@ func (s *applicationSuite) TestDeploy(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestDeployWithProgress(c *gc.C) {
+	t0 := time.Date(2017, 11, 1, 10, 0, 0, 0, time.UTC)
+	progress := &fakeProgressStream{events: []params.DeployProgressEvent{
+		{Kind: "machine", Entity: "machine-0", Status: "running", Timestamp: t0},
+		{Kind: "unit-agent", Entity: "unit-mysql-0", Status: "executing", Message: "running install hook", Timestamp: t0},
+		{Kind: "stable", Entity: "application-mysql", Timestamp: t0},
+	}}
+	var calls []string
+	caller := &progressCaller{
+		BestVersionCaller: basetesting.BestVersionCaller{
+			APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+				calls = append(calls, request)
+				*(response.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{}}}
+				return nil
+			},
+			BestVersion: 5,
+		},
+		calls:  &calls,
+		stream: progress,
+	}
+	client := application.NewClient(caller)
+
+	events, err := client.DeployWithProgress(application.DeployArgs{
+		CharmID:         charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-1")},
+		ApplicationName: "mysql",
+		NumUnits:        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, jc.DeepEquals, []string{"ConnectStream", "Deploy"})
+	c.Assert(caller.path, gc.Equals, "/deploy-progress")
+	c.Assert(caller.attrs, jc.DeepEquals, url.Values{"application": {"mysql"}, "units": {"1"}})
+
+	var received []application.DeployProgressEvent
+	for event := range events {
+		received = append(received, event)
+	}
+	c.Assert(received, jc.DeepEquals, []application.DeployProgressEvent{{
+		Kind:   application.DeployProgressMachine,
+		Entity: names.NewMachineTag("0"),
+		Status: status.Running,
+		Time:   t0,
+	}, {
+		Kind:    application.DeployProgressUnitAgent,
+		Entity:  names.NewUnitTag("mysql/0"),
+		Status:  status.Executing,
+		Message: "running install hook",
+		Time:    t0,
+	}, {
+		Kind:   application.DeployProgressStable,
+		Entity: names.NewApplicationTag("mysql"),
+		Time:   t0,
+	}})
+	c.Assert(progress.closed, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestDeployWithProgressFailed(c *gc.C) {
+	progress := &fakeProgressStream{events: []params.DeployProgressEvent{
+		{Kind: "failed", Entity: "unit-mysql-0", Status: "error", Message: `hook failed: "install"`},
+	}}
+	caller := &progressCaller{
+		BestVersionCaller: basetesting.BestVersionCaller{
+			APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+				*(response.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{}}}
+				return nil
+			},
+			BestVersion: 5,
+		},
+		stream: progress,
+	}
+	client := application.NewClient(caller)
+
+	events, err := client.DeployWithProgress(application.DeployArgs{
+		CharmID:         charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-1")},
+		ApplicationName: "mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	event := <-events
+	c.Assert(event.Kind, gc.Equals, application.DeployProgressFailed)
+	c.Assert(event.Err, gc.ErrorMatches, `unit mysql/0 failed: hook failed: "install"`)
+	_, ok := <-events
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestDeployWithProgressStreamBroken(c *gc.C) {
+	caller := &progressCaller{
+		BestVersionCaller: basetesting.BestVersionCaller{
+			APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+				*(response.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{}}}
+				return nil
+			},
+			BestVersion: 5,
+		},
+		stream: &fakeProgressStream{},
+	}
+	client := application.NewClient(caller)
+
+	events, err := client.DeployWithProgress(application.DeployArgs{
+		CharmID:         charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-1")},
+		ApplicationName: "mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	event := <-events
+	c.Assert(event.Kind, gc.Equals, application.DeployProgressFailed)
+	c.Assert(event.Err, gc.ErrorMatches, "reading deployment progress: EOF")
+}
+
+func (s *applicationSuite) TestDeployWithProgressNotSupported(c *gc.C) {
+	var calls []string
+	caller := &progressCaller{
+		BestVersionCaller: basetesting.BestVersionCaller{
+			APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+				calls = append(calls, request)
+				return nil
+			},
+			BestVersion: 5,
+		},
+		calls:      &calls,
+		connectErr: errors.New("404 not found"),
+	}
+	client := application.NewClient(caller)
+
+	_, err := client.DeployWithProgress(application.DeployArgs{
+		CharmID:         charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-1")},
+		ApplicationName: "mysql",
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot connect to /deploy-progress: 404 not found")
+	c.Assert(calls, jc.DeepEquals, []string{"ConnectStream"})
+}
+
 func (s *applicationSuite) TestDeployAttachStorageV4(c *gc.C) {
 	var called bool
 	client := application.NewClient(basetesting.BestVersionCaller{
@@ -225,6 +389,68 @@ func (s *applicationSuite) TestAddUnitsAttachStorageMultipleUnits(c *gc.C) {
 	c.Assert(called, jc.IsFalse)
 }
 
+func (s *applicationSuite) TestDeployTypedErrors(c *gc.C) {
+	for i, t := range []struct {
+		code  string
+		check func(error) bool
+	}{
+		{params.CodeQuotaLimitExceeded, application.IsQuotaLimitExceeded},
+		{params.CodeNotSupported, application.IsStorageNotSupported},
+		{params.CodePlacementNotValid, application.IsPlacementNotValid},
+		{params.CodeCharmNotFound, application.IsCharmNotFound},
+	} {
+		c.Logf("test %d: %s", i, t.code)
+		client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+			result := response.(*params.ErrorResults)
+			result.Results = []params.ErrorResult{{
+				Error: &params.Error{Code: t.code, Message: "boom"},
+			}}
+			return nil
+		})
+		err := client.Deploy(application.DeployArgs{
+			CharmID:  charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-1")},
+			NumUnits: 1,
+		})
+		c.Check(err, gc.ErrorMatches, "boom")
+		c.Check(err, jc.Satisfies, t.check)
+		c.Check(params.ErrCode(err), gc.Equals, t.code)
+	}
+}
+
+func (s *applicationSuite) TestDeployUntypedError(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{
+			Error: &params.Error{Code: params.CodeCharmRequirementsNotMet, Message: "boom"},
+		}}
+		return nil
+	})
+	err := client.Deploy(application.DeployArgs{
+		CharmID:  charmstore.CharmID{URL: charm.MustParseURL("cs:mysql-1")},
+		NumUnits: 1,
+	})
+	c.Assert(errors.Cause(err), gc.DeepEquals, &params.Error{
+		Code:    params.CodeCharmRequirementsNotMet,
+		Message: "boom",
+	})
+}
+
+func (s *applicationSuite) TestAddUnitsPlacementNotValid(c *gc.C) {
+	apiErr := &params.Error{Code: params.CodePlacementNotValid, Message: "boom"}
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		return apiErr
+	})
+	_, err := client.AddUnits(application.AddUnitsParams{
+		ApplicationName: "foo",
+		NumUnits:        1,
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(err, jc.Satisfies, application.IsPlacementNotValid)
+	placementErr, ok := errors.Cause(err).(*application.PlacementNotValidError)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(placementErr.Unwrap(), gc.Equals, apiErr)
+}
+
 func (s *applicationSuite) TestServiceGetCharmURL(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -441,6 +667,42 @@ func (s *applicationSuite) TestDestroyUnits(c *gc.C) {
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
 
+func (s *applicationSuite) TestDestroyUnitsForce(c *gc.C) {
+	client := newClientV12(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "DestroyUnit")
+		c.Assert(a, jc.DeepEquals, params.DestroyUnitsParams{
+			Units: []params.DestroyUnitParams{
+				{UnitTag: "unit-foo-0", Force: true},
+			},
+		})
+		out := response.(*params.DestroyUnitResults)
+		*out = params.DestroyUnitResults{[]params.DestroyUnitResult{{
+			Info: &params.DestroyUnitInfo{StopGracePeriodOverridden: true},
+		}}}
+		return nil
+	})
+	results, err := client.DestroyUnits(application.DestroyUnitsParams{
+		Units: []string{"foo/0"},
+		Force: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.DestroyUnitResult{{
+		Info: &params.DestroyUnitInfo{StopGracePeriodOverridden: true},
+	}})
+}
+
+func (s *applicationSuite) TestDestroyUnitsForceNotSupported(c *gc.C) {
+	client := newClientV11(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.DestroyUnits(application.DestroyUnitsParams{
+		Units: []string{"foo/0"},
+		Force: true,
+	})
+	c.Assert(err, gc.ErrorMatches, "this controller does not support --force")
+}
+
 func (s *applicationSuite) TestDestroyUnitsV4(c *gc.C) {
 	expectedResults := []params.DestroyUnitResult{{
 		Error: &params.Error{Message: "boo"},
@@ -914,3 +1176,662 @@ func (s *applicationSuite) TestGetConstraintsAPIv4(c *gc.C) {
 		fooConstraints, barConstraints,
 	})
 }
+
+func (s *applicationSuite) TestDestroyApplicationsSelector(c *gc.C) {
+	expectedResults := []params.DestroyApplicationResult{{
+		Info: &params.DestroyApplicationInfo{},
+	}, {
+		ApplicationTag: "application-bar",
+		Info:           &params.DestroyApplicationInfo{},
+	}}
+	client := newClientV6(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "DestroyApplication")
+		c.Assert(a, jc.DeepEquals, params.DestroyApplicationsParams{
+			Applications: []params.DestroyApplicationParams{
+				{ApplicationTag: "application-foo", DestroyStorage: true},
+			},
+			Selector:       "tier=frontend",
+			DestroyStorage: true,
+		})
+		out := response.(*params.DestroyApplicationResults)
+		*out = params.DestroyApplicationResults{expectedResults}
+		return nil
+	})
+	results, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications:   []string{"foo"},
+		Selector:       "tier=frontend",
+		DestroyStorage: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestDestroyApplicationsSelectorNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Selector: "tier=frontend",
+	})
+	c.Assert(err, gc.ErrorMatches, "this controller does not support label selectors")
+}
+
+func (s *applicationSuite) TestGetConfigForSelector(c *gc.C) {
+	fooConfig := map[string]interface{}{"title": "foo"}
+	client := newClientV6(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "GetConfig")
+		c.Assert(a, jc.DeepEquals, params.ApplicationGetConfigArgs{Selector: "tier=frontend"})
+		result := response.(*params.ApplicationGetConfigResults)
+		result.Results = []params.ConfigResult{
+			{Tag: "application-foo", Config: fooConfig},
+		}
+		return nil
+	})
+	results, err := client.GetConfigForSelector("tier=frontend")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, map[string]map[string]interface{}{
+		"foo": fooConfig,
+	})
+}
+
+func (s *applicationSuite) TestGetConfigSchema(c *gc.C) {
+	schema := map[string]params.ConfigOptionSchema{
+		"title": {Type: "string", Description: "the title", Default: "My Title"},
+	}
+	client := newClientV7(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "GetConfigSchema")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-foo"}},
+		})
+		result := response.(*params.ApplicationConfigSchemaResults)
+		result.Results = []params.ApplicationConfigSchemaResult{{Schema: schema}}
+		return nil
+	})
+	result, err := client.GetConfigSchema("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, schema)
+}
+
+func (s *applicationSuite) TestGetConfigSchemaError(c *gc.C) {
+	client := newClientV7(func(objType string, version int, id, request string, a, response interface{}) error {
+		result := response.(*params.ApplicationConfigSchemaResults)
+		result.Results = []params.ApplicationConfigSchemaResult{{
+			Error: &params.Error{Message: "boom"},
+		}}
+		return nil
+	})
+	_, err := client.GetConfigSchema("foo")
+	c.Assert(err, gc.ErrorMatches, `unable to get config schema for "foo": boom`)
+}
+
+func (s *applicationSuite) TestGetConfigSchemaNotSupported(c *gc.C) {
+	client := newClientV6(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.GetConfigSchema("foo")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support config schemas")
+}
+
+func (s *applicationSuite) TestSetConstraintsForSelector(c *gc.C) {
+	cons := constraints.MustParse("mem=4G")
+	called := false
+	client := newClientV6(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetConstraints")
+		c.Assert(a, jc.DeepEquals, params.SetConstraints{
+			Selector:    "tier=frontend",
+			Constraints: cons,
+		})
+		return nil
+	})
+	err := client.SetConstraintsForSelector("tier=frontend", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetLabels(c *gc.C) {
+	client := newClientV6(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "SetLabels")
+		c.Assert(a, jc.DeepEquals, params.ApplicationLabelsArgs{
+			Args: []params.ApplicationLabels{{
+				ApplicationTag: "application-foo",
+				Labels:         map[string]string{"tier": "frontend"},
+			}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := client.SetLabels("foo", map[string]string{"tier": "frontend"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestSetStopGracePeriod(c *gc.C) {
+	client := newClientV12(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "SetStopGracePeriods")
+		c.Assert(a, jc.DeepEquals, params.ApplicationStopGracePeriods{
+			Args: []params.ApplicationStopGracePeriod{{
+				ApplicationTag:  "application-foo",
+				StopGracePeriod: 5 * time.Minute,
+			}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := client.SetStopGracePeriod("foo", 5*time.Minute)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestSetStopGracePeriodNotSupported(c *gc.C) {
+	client := newClientV11(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	err := client.SetStopGracePeriod("foo", time.Minute)
+	c.Assert(err, gc.ErrorMatches, "this controller does not support stop grace periods")
+}
+
+func (s *applicationSuite) TestLabels(c *gc.C) {
+	client := newClientV6(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "GetLabels")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{"application-foo"}, {"application-bar"}},
+		})
+		result := response.(*params.ApplicationLabelsResults)
+		result.Results = []params.ApplicationLabelsResult{
+			{Labels: map[string]string{"tier": "frontend"}}, {},
+		}
+		return nil
+	})
+	labels, err := client.Labels("foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(labels, jc.DeepEquals, []map[string]string{
+		{"tier": "frontend"}, nil,
+	})
+}
+
+func (s *applicationSuite) TestLabelsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.Labels("foo")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support application labels")
+}
+
+func (s *applicationSuite) TestSetCharmRollingUpgrade(c *gc.C) {
+	upgrade := &params.RollingCharmUpgrade{BatchSize: 2, Readiness: "workload-active"}
+	called := false
+	client := newClientV8(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetCharm")
+		args, ok := a.(params.ApplicationSetCharm)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.RollingUpgrade, jc.DeepEquals, upgrade)
+		return nil
+	})
+	err := client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID:         charmstore.CharmID{URL: charm.MustParseURL("cs:foo-2")},
+		RollingUpgrade:  upgrade,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetCharmRollingUpgradeNotSupported(c *gc.C) {
+	client := newClientV7(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	err := client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID:         charmstore.CharmID{URL: charm.MustParseURL("cs:foo-2")},
+		RollingUpgrade:  &params.RollingCharmUpgrade{BatchSize: 1},
+	})
+	c.Assert(err, gc.ErrorMatches, "this controller does not support rolling charm upgrades")
+}
+
+func (s *applicationSuite) TestCharmUpgradeStatus(c *gc.C) {
+	upgradeStatus := params.CharmUpgradeStatus{
+		PreviousCharmURL: "cs:foo-1",
+		BatchSize:        1,
+		Readiness:        "workload-active",
+		Status:           "running",
+		Pending:          []string{"foo/1"},
+		Upgrading:        []string{"foo/0"},
+		Upgraded:         []string{},
+	}
+	client := newClientV8(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "CharmUpgradeStatus")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-foo"}},
+		})
+		result := response.(*params.CharmUpgradeStatusResults)
+		result.Results = []params.CharmUpgradeStatusResult{{Result: &upgradeStatus}}
+		return nil
+	})
+	result, err := client.CharmUpgradeStatus("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, upgradeStatus)
+}
+
+func (s *applicationSuite) TestChangeCharmUpgrade(c *gc.C) {
+	var calls []string
+	client := newClientV8(func(objType string, version int, id, request string, a, response interface{}) error {
+		calls = append(calls, request)
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-foo"}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := client.PauseCharmUpgrade("foo")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	err = client.ResumeCharmUpgrade("foo")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	err = client.RollbackCharmUpgrade("foo")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(calls, jc.DeepEquals, []string{
+		"PauseCharmUpgrade", "ResumeCharmUpgrade", "RollbackCharmUpgrade",
+	})
+}
+
+func (s *applicationSuite) TestCharmUpgradeNotSupported(c *gc.C) {
+	client := newClientV7(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.CharmUpgradeStatus("foo")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support rolling charm upgrades")
+	err = client.PauseCharmUpgrade("foo")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support rolling charm upgrades")
+}
+
+func (s *applicationSuite) TestCharmHistory(c *gc.C) {
+	entries := []params.CharmHistoryEntry{{
+		CharmURL:      "cs:foo-2",
+		ConfigVersion: 2,
+		Config:        map[string]interface{}{"title": "foo"},
+		User:          "bob",
+		Time:          time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	client := newClientV9(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "CharmHistory")
+		c.Assert(a, jc.DeepEquals, params.ApplicationCharmHistoryArgs{
+			Args: []params.ApplicationCharmHistoryArg{{
+				Entity: params.Entity{Tag: "application-foo"},
+				Limit:  10,
+			}},
+		})
+		result := response.(*params.CharmHistoryResults)
+		result.Results = []params.CharmHistoryResult{{Entries: entries}}
+		return nil
+	})
+	result, err := client.CharmHistory("foo", 10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entries)
+}
+
+func (s *applicationSuite) TestCharmHistoryError(c *gc.C) {
+	client := newClientV9(func(objType string, version int, id, request string, a, response interface{}) error {
+		result := response.(*params.CharmHistoryResults)
+		result.Results = []params.CharmHistoryResult{{
+			Error: &params.Error{Message: "boom"},
+		}}
+		return nil
+	})
+	_, err := client.CharmHistory("foo", 0)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestCharmHistoryNotSupported(c *gc.C) {
+	client := newClientV8(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.CharmHistory("foo", 0)
+	c.Assert(err, gc.ErrorMatches, "this controller does not support charm history")
+}
+
+func (s *applicationSuite) TestAddCommitAbortBranch(c *gc.C) {
+	var calls []string
+	client := newClientV10(func(objType string, version int, id, request string, a, response interface{}) error {
+		calls = append(calls, request)
+		c.Assert(a, jc.DeepEquals, params.BranchArgs{
+			Branches: []params.BranchArg{{BranchName: "canary"}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{}}
+		return nil
+	})
+	err := client.AddBranch("canary")
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.CommitBranch("canary")
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.AbortBranch("canary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, jc.DeepEquals, []string{"AddBranch", "CommitBranch", "AbortBranch"})
+}
+
+func (s *applicationSuite) TestTrackBranch(c *gc.C) {
+	client := newClientV10(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "TrackBranch")
+		c.Assert(a, jc.DeepEquals, params.BranchTrackArg{
+			BranchName: "canary",
+			Entities: []params.Entity{
+				{Tag: "unit-foo-0"},
+				{Tag: "unit-foo-1"},
+			},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := client.TrackBranch("canary", "foo/0", "foo/1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	err = client.TrackBranch("canary", "foo")
+	c.Assert(err, gc.ErrorMatches, `unit name "foo" not valid`)
+}
+
+func (s *applicationSuite) TestBranchInfo(c *gc.C) {
+	info := params.BranchInfo{
+		Name:          "canary",
+		CreatedBy:     "bob",
+		Config:        map[string]map[string]interface{}{"foo": {"title": "bar"}},
+		AssignedUnits: map[string][]string{"foo": {"foo/0"}},
+	}
+	client := newClientV10(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "BranchInfo")
+		c.Assert(a, jc.DeepEquals, params.BranchArgs{
+			Branches: []params.BranchArg{{BranchName: "canary"}},
+		})
+		result := response.(*params.BranchResults)
+		result.Results = []params.BranchResult{{Result: &info}}
+		return nil
+	})
+	result, err := client.BranchInfo("canary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, info)
+}
+
+func (s *applicationSuite) TestGetSetUnsetInBranch(c *gc.C) {
+	var calls []string
+	client := newClientV10(func(objType string, version int, id, request string, a, response interface{}) error {
+		calls = append(calls, request)
+		switch request {
+		case "Get":
+			c.Assert(a, jc.DeepEquals, params.ApplicationGet{
+				ApplicationName: "foo",
+				BranchName:      "canary",
+			})
+		case "Set":
+			c.Assert(a, jc.DeepEquals, params.ApplicationSet{
+				ApplicationName: "foo",
+				Options:         map[string]string{"title": "bar"},
+				BranchName:      "canary",
+			})
+		case "Unset":
+			c.Assert(a, jc.DeepEquals, params.ApplicationUnset{
+				ApplicationName: "foo",
+				Options:         []string{"title"},
+				BranchName:      "canary",
+			})
+		}
+		return nil
+	})
+	_, err := client.GetInBranch("canary", "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.SetInBranch("canary", "foo", map[string]string{"title": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.UnsetInBranch("canary", "foo", []string{"title"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(calls, jc.DeepEquals, []string{"Get", "Set", "Unset"})
+}
+
+func (s *applicationSuite) TestBranchesNotSupported(c *gc.C) {
+	client := newClientV9(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	err := client.AddBranch("canary")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support branches")
+	err = client.TrackBranch("canary", "foo/0")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support branches")
+	_, err = client.BranchInfo("canary")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support branches")
+	err = client.SetInBranch("canary", "foo", map[string]string{"title": "bar"})
+	c.Assert(err, gc.ErrorMatches, "this controller does not support branches")
+}
+
+func (s *applicationSuite) TestScaleApplication(c *gc.C) {
+	var args []params.ScaleApplicationParams
+	client := newClientV11(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "ScaleApplications")
+		arg := a.(params.ScaleApplicationsParams)
+		c.Assert(arg.Applications, gc.HasLen, 1)
+		args = append(args, arg.Applications[0])
+		result := response.(*params.ScaleApplicationResults)
+		result.Results = []params.ScaleApplicationResult{{Scale: 4}}
+		return nil
+	})
+	err := client.ScaleApplication("foo", 4)
+	c.Assert(err, jc.ErrorIsNil)
+	scale, err := client.ChangeApplicationScale("foo", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scale, gc.Equals, 4)
+	c.Assert(args, jc.DeepEquals, []params.ScaleApplicationParams{
+		{ApplicationTag: "application-foo", Scale: 4},
+		{ApplicationTag: "application-foo", ScaleChange: 2},
+	})
+}
+
+func (s *applicationSuite) TestScaleApplicationError(c *gc.C) {
+	client := newClientV11(func(objType string, version int, id, request string, a, response interface{}) error {
+		result := response.(*params.ScaleApplicationResults)
+		result.Results = []params.ScaleApplicationResult{{
+			Error: &params.Error{Message: "boom"},
+		}}
+		return nil
+	})
+	err := client.ScaleApplication("foo", 4)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestGetPodSpec(c *gc.C) {
+	spec := params.PodSpec{
+		Spec:     "containers: []",
+		Revision: 2,
+		User:     "bob",
+		Time:     time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	client := newClientV11(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "GetPodSpec")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-foo"}},
+		})
+		result := response.(*params.PodSpecResults)
+		result.Results = []params.PodSpecResult{{Result: &spec}}
+		return nil
+	})
+	result, err := client.GetPodSpec("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, spec)
+}
+
+func (s *applicationSuite) TestGetPodSpecError(c *gc.C) {
+	client := newClientV11(func(objType string, version int, id, request string, a, response interface{}) error {
+		result := response.(*params.PodSpecResults)
+		result.Results = []params.PodSpecResult{{
+			Error: &params.Error{Code: params.CodeNotFound, Message: "pod spec not found"},
+		}}
+		return nil
+	})
+	_, err := client.GetPodSpec("foo")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *applicationSuite) TestSetPodSpec(c *gc.C) {
+	var called bool
+	client := newClientV11(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetPodSpec")
+		c.Assert(a, jc.DeepEquals, params.SetPodSpecParams{
+			Specs: []params.EntityPodSpec{{Tag: "application-foo", Spec: "containers: []"}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{}}
+		return nil
+	})
+	err := client.SetPodSpec("foo", "containers: []")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestPodSpecHistory(c *gc.C) {
+	revisions := []params.PodSpec{
+		{Spec: "containers: [{name: foo}]", Revision: 2},
+		{Spec: "containers: []", Revision: 1},
+	}
+	client := newClientV11(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "PodSpecHistory")
+		c.Assert(a, jc.DeepEquals, params.PodSpecHistoryArgs{
+			Args: []params.PodSpecHistoryArg{{
+				Entity: params.Entity{Tag: "application-foo"},
+				Limit:  10,
+			}},
+		})
+		result := response.(*params.PodSpecHistoryResults)
+		result.Results = []params.PodSpecHistoryResult{{Revisions: revisions}}
+		return nil
+	})
+	result, err := client.PodSpecHistory("foo", 10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, revisions)
+}
+
+func (s *applicationSuite) TestRolloutStatus(c *gc.C) {
+	rollout := params.RolloutStatus{
+		Revision: 3,
+		State:    "progressing",
+		Message:  "1 of 2 pods updated",
+		Since:    time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	client := newClientV11(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "RolloutStatus")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-foo"}},
+		})
+		result := response.(*params.RolloutStatusResults)
+		result.Results = []params.RolloutStatusResult{{Result: &rollout}}
+		return nil
+	})
+	result, err := client.RolloutStatus("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, rollout)
+}
+
+func (s *applicationSuite) TestWatchRolloutStatusError(c *gc.C) {
+	client := newClientV11(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "WatchRolloutStatus")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-foo"}},
+		})
+		result := response.(*params.NotifyWatchResults)
+		result.Results = []params.NotifyWatchResult{{
+			Error: &params.Error{Message: "boom"},
+		}}
+		return nil
+	})
+	w, err := client.WatchRolloutStatus("foo")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(w, gc.IsNil)
+}
+
+func (s *applicationSuite) TestUnitContainerStatuses(c *gc.C) {
+	units := []params.UnitContainerStatus{{
+		UnitTag: "unit-foo-0",
+		Status:  params.EntityStatus{Status: status.Running, Info: "started"},
+	}}
+	client := newClientV11(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "UnitContainerStatuses")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-foo"}},
+		})
+		result := response.(*params.UnitContainerStatusesResults)
+		result.Results = []params.UnitContainerStatusesResult{{Units: units}}
+		return nil
+	})
+	result, err := client.UnitContainerStatuses("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, units)
+}
+
+func (s *applicationSuite) TestPodSpecCallsNotSupported(c *gc.C) {
+	client := newClientV10(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	err := client.ScaleApplication("foo", 2)
+	c.Assert(err, gc.ErrorMatches, "this controller does not support scaling applications")
+	_, err = client.ChangeApplicationScale("foo", 1)
+	c.Assert(err, gc.ErrorMatches, "this controller does not support scaling applications")
+	_, err = client.GetPodSpec("foo")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support pod specs")
+	err = client.SetPodSpec("foo", "containers: []")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support pod specs")
+	_, err = client.PodSpecHistory("foo", 0)
+	c.Assert(err, gc.ErrorMatches, "this controller does not support pod specs")
+	_, err = client.RolloutStatus("foo")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support rollout status")
+	_, err = client.WatchRolloutStatus("foo")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support rollout status")
+	_, err = client.UnitContainerStatuses("foo")
+	c.Assert(err, gc.ErrorMatches, "this controller does not support container statuses")
+}
+
+type progressCaller struct {
+	basetesting.BestVersionCaller
+	calls      *[]string
+	path       string
+	attrs      url.Values
+	stream     base.Stream
+	connectErr error
+}
+
+func (c *progressCaller) ConnectStream(path string, attrs url.Values) (base.Stream, error) {
+	if c.calls != nil {
+		*c.calls = append(*c.calls, "ConnectStream")
+	}
+	c.path = path
+	c.attrs = attrs
+	if c.connectErr != nil {
+		return nil, c.connectErr
+	}
+	return c.stream, nil
+}
+
+type fakeProgressStream struct {
+	base.Stream
+	events []params.DeployProgressEvent
+	closed bool
+}
+
+func (s *fakeProgressStream) ReadJSON(v interface{}) error {
+	if len(s.events) == 0 {
+		return io.EOF
+	}
+	*(v.(*params.DeployProgressEvent)) = s.events[0]
+	s.events = s.events[1:]
+	return nil
+}
+
+func (s *fakeProgressStream) Close() error {
+	s.closed = true
+	return nil
+}
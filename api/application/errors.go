@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// apiError holds an error returned by the API, which the typed errors
+// below refine. The original error is available through Unwrap, and
+// its code through ErrorCode.
+type apiError struct {
+	err error
+}
+
+// Error is part of the error interface.
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+// ErrorCode returns the code of the error returned by the API.
+func (e *apiError) ErrorCode() string {
+	return params.ErrCode(e.err)
+}
+
+// Unwrap returns the error returned by the API.
+func (e *apiError) Unwrap() error {
+	return e.err
+}
+
+// QuotaLimitExceededError is returned by Deploy and AddUnits when the
+// model or cloud does not have the capacity for the requested units.
+type QuotaLimitExceededError struct {
+	apiError
+}
+
+// StorageNotSupportedError is returned by Deploy and AddUnits when the
+// requested storage cannot be provided, for example because the pool's
+// provider cannot supply volumes, or storage cannot be added to the
+// kind of machine a unit is placed on.
+type StorageNotSupportedError struct {
+	apiError
+}
+
+// PlacementNotValidError is returned by Deploy and AddUnits when units
+// cannot be placed as directed, for example because the target
+// machine does not exist or the provider rejects the directive.
+type PlacementNotValidError struct {
+	apiError
+}
+
+// CharmNotFoundError is returned by Deploy when the charm has not been
+// added to the model.
+type CharmNotFoundError struct {
+	apiError
+}
+
+// IsQuotaLimitExceeded reports whether err is, or was caused by, a
+// *QuotaLimitExceededError.
+func IsQuotaLimitExceeded(err error) bool {
+	_, ok := errors.Cause(err).(*QuotaLimitExceededError)
+	return ok
+}
+
+// IsStorageNotSupported reports whether err is, or was caused by, a
+// *StorageNotSupportedError.
+func IsStorageNotSupported(err error) bool {
+	_, ok := errors.Cause(err).(*StorageNotSupportedError)
+	return ok
+}
+
+// IsPlacementNotValid reports whether err is, or was caused by, a
+// *PlacementNotValidError.
+func IsPlacementNotValid(err error) bool {
+	_, ok := errors.Cause(err).(*PlacementNotValidError)
+	return ok
+}
+
+// IsCharmNotFound reports whether err is, or was caused by, a
+// *CharmNotFoundError.
+func IsCharmNotFound(err error) bool {
+	_, ok := errors.Cause(err).(*CharmNotFoundError)
+	return ok
+}
+
+// deployError returns the typed error corresponding to the code of
+// err, an error returned by the Deploy or AddUnits API calls. Errors
+// with other codes are returned unchanged.
+//
+// The controller reports unsupported storage with the generic
+// CodeNotSupported, which Deploy and AddUnits return for nothing else.
+func deployError(err error) error {
+	if err == nil {
+		return nil
+	}
+	e := apiError{err: err}
+	switch params.ErrCode(err) {
+	case params.CodeQuotaLimitExceeded:
+		return &QuotaLimitExceededError{e}
+	case params.CodeNotSupported:
+		return &StorageNotSupportedError{e}
+	case params.CodePlacementNotValid:
+		return &PlacementNotValidError{e}
+	case params.CodeCharmNotFound:
+		return &CharmNotFoundError{e}
+	}
+	return err
+}
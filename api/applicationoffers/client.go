@@ -117,20 +117,11 @@ func offerParamsToDetails(offer params.ApplicationOfferAdminDetails) (*crossmode
 		Endpoints:              eps,
 	}
 	for _, oc := range offer.Connections {
-		modelTag, err := names.ParseModelTag(oc.SourceModelTag)
+		conn, err := offerConnectionFromParams(oc)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		result.Connections = append(result.Connections, crossmodel.OfferConnection{
-			SourceModelUUID: modelTag.Id(),
-			Username:        oc.Username,
-			Endpoint:        oc.Endpoint,
-			RelationId:      oc.RelationId,
-			Status:          relation.Status(oc.Status.Status),
-			Message:         oc.Status.Info,
-			Since:           oc.Status.Since,
-			IngressSubnets:  oc.IngressSubnets,
-		})
+		result.Connections = append(result.Connections, conn)
 	}
 	for _, u := range offer.Users {
 		result.Users = append(result.Users, crossmodel.OfferUserDetails{
@@ -142,6 +133,25 @@ func offerParamsToDetails(offer params.ApplicationOfferAdminDetails) (*crossmode
 	return result, nil
 }
 
+func offerConnectionFromParams(oc params.OfferConnection) (crossmodel.OfferConnection, error) {
+	modelTag, err := names.ParseModelTag(oc.SourceModelTag)
+	if err != nil {
+		return crossmodel.OfferConnection{}, errors.Trace(err)
+	}
+	return crossmodel.OfferConnection{
+		SourceModelUUID: modelTag.Id(),
+		Username:        oc.Username,
+		Endpoint:        oc.Endpoint,
+		RelationId:      oc.RelationId,
+		Status:          relation.Status(oc.Status.Status),
+		Message:         oc.Status.Info,
+		Since:           oc.Status.Since,
+		IngressSubnets:  oc.IngressSubnets,
+		Health:          crossmodel.OfferConnectionHealth(oc.Health),
+		LastSeen:        oc.LastSeen,
+	}, nil
+}
+
 // GrantOffer grants a user access to the specified offers.
 func (c *Client) GrantOffer(user, access string, offerURLs ...string) error {
 	return c.modifyOfferUser(params.GrantOfferAccess, user, access, offerURLs)
@@ -310,3 +320,189 @@ func (c *Client) DestroyOffers(offerURLs ...string) error {
 	}
 	return result.Combine()
 }
+
+// SetOfferIngressNetworks sets the networks from which consumers of the
+// specified offer may connect. An empty list allows any network
+// permitted by the model's firewall rules.
+func (c *Client) SetOfferIngressNetworks(offerURL string, cidrs []string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("SetOfferIngressNetworks on this controller")
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.SetOfferIngressNetworksArgs{
+		Args: []params.OfferIngressNetworks{{
+			OfferURL: offerURL,
+			CIDRs:    cidrs,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetOfferIngressNetworks", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// OfferIngressNetworks returns the networks from which consumers of the
+// specified offer may connect.
+func (c *Client) OfferIngressNetworks(offerURL string) ([]string, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("OfferIngressNetworks on this controller")
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := params.OfferURLs{OfferURLs: []string{offerURL}}
+	var results params.OfferIngressNetworksResults
+	if err := c.facade.FacadeCall("OfferIngressNetworks", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].CIDRs, nil
+}
+
+// OfferConsumers returns the connections made to the specified offer
+// by its consumers.
+func (c *Client) OfferConsumers(offerURL string) ([]crossmodel.OfferConnection, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("OfferConsumers on this controller")
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := params.OfferURLs{OfferURLs: []string{offerURL}}
+	var results params.OfferConsumersResults
+	if err := c.facade.FacadeCall("OfferConsumers", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	var consumers []crossmodel.OfferConnection
+	for _, oc := range results.Results[0].Consumers {
+		conn, err := offerConnectionFromParams(oc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		consumers = append(consumers, conn)
+	}
+	return consumers, nil
+}
+
+// RevokeConsumersArgs identifies the consumers of an offer to revoke.
+type RevokeConsumersArgs struct {
+	// OfferURL is the URL of the offer.
+	OfferURL string
+
+	// SourceModelUUIDs, if set, restricts the consumers to those
+	// connecting from the specified models.
+	SourceModelUUIDs []string
+
+	// Usernames, if set, restricts the consumers to those
+	// connecting as the specified users.
+	Usernames []string
+
+	// StaleOnly, if true, restricts the consumers to those which
+	// have not been heard from recently.
+	StaleOnly bool
+}
+
+// RevokeConsumers removes the relations of the selected consumers of
+// an offer, returning the ids of the relations removed, and reduces
+// the consuming users' access to the offer to read. If no models or
+// users are specified, all consumers of the offer are selected.
+func (c *Client) RevokeConsumers(args RevokeConsumersArgs) ([]int, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("RevokeConsumers on this controller")
+	}
+	if _, err := crossmodel.ParseOfferURL(args.OfferURL); err != nil {
+		return nil, errors.Trace(err)
+	}
+	arg := params.RevokeOfferConsumers{
+		OfferURL:  args.OfferURL,
+		Usernames: args.Usernames,
+		StaleOnly: args.StaleOnly,
+	}
+	for _, uuid := range args.SourceModelUUIDs {
+		if !names.IsValidModel(uuid) {
+			return nil, errors.NotValidf("model UUID %q", uuid)
+		}
+		arg.SourceModelTags = append(arg.SourceModelTags, names.NewModelTag(uuid).String())
+	}
+	var results params.RevokeOfferConsumersResults
+	err := c.facade.FacadeCall("RevokeConsumers", params.RevokeOfferConsumersArgs{
+		Args: []params.RevokeOfferConsumers{arg},
+	}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].RelationIds, nil
+}
+
+// StartOfferMigration starts the migration of the specified offer to a
+// new application. The offer continues to be provided by its current
+// application until CompleteOfferMigration is called, and in the
+// meantime its consumers are told of the migration and its deadline.
+func (c *Client) StartOfferMigration(offerURL string, migration crossmodel.OfferMigration) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("StartOfferMigration on this controller")
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.StartOfferMigrationArgs{
+		Args: []params.OfferMigration{{
+			OfferURL:        offerURL,
+			ApplicationName: migration.ApplicationName,
+			Endpoints:       migration.Endpoints,
+			Deadline:        migration.Deadline,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("StartOfferMigration", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CompleteOfferMigration switches the specified offer over to the
+// application of its pending migration.
+func (c *Client) CompleteOfferMigration(offerURL string) error {
+	return c.offerMigrationCall("CompleteOfferMigration", offerURL)
+}
+
+// AbortOfferMigration discards the pending migration of the specified
+// offer.
+func (c *Client) AbortOfferMigration(offerURL string) error {
+	return c.offerMigrationCall("AbortOfferMigration", offerURL)
+}
+
+func (c *Client) offerMigrationCall(method, offerURL string) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("%s on this controller", method)
+	}
+	if _, err := crossmodel.ParseOfferURL(offerURL); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.OfferURLs{OfferURLs: []string{offerURL}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -156,6 +156,8 @@ func (s *crossmodelMockSuite) TestList(c *gc.C) {
 						{SourceModelTag: testing.ModelTag.String(), Username: "fred", RelationId: 3,
 							Endpoint: "db", Status: params.EntityStatus{Status: "joined", Info: "message", Since: &since},
 							IngressSubnets: []string{"10.0.0.0/8"},
+							Health:         "healthy",
+							LastSeen:       &since,
 						},
 					},
 				}}
@@ -177,6 +179,8 @@ func (s *crossmodelMockSuite) TestList(c *gc.C) {
 			{SourceModelUUID: testing.ModelTag.Id(), Username: "fred", RelationId: 3,
 				Endpoint: "db", Status: "joined", Message: "message", Since: &since,
 				IngressSubnets: []string{"10.0.0.0/8"},
+				Health:         jujucrossmodel.OfferConnectionHealthy,
+				LastSeen:       &since,
 			},
 		},
 		Users: []jujucrossmodel.OfferUserDetails{
@@ -672,3 +676,206 @@ func (s *crossmodelMockSuite) TestDestroyOffers(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "fail")
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *crossmodelMockSuite) TestSetOfferIngressNetworks(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "ApplicationOffers")
+			c.Check(request, gc.Equals, "SetOfferIngressNetworks")
+			c.Check(a, jc.DeepEquals, params.SetOfferIngressNetworksArgs{
+				Args: []params.OfferIngressNetworks{{
+					OfferURL: "me/prod.app",
+					CIDRs:    []string{"10.0.0.0/8"},
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.SetOfferIngressNetworks("me/prod.app", []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestOfferIngressNetworks(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "OfferIngressNetworks")
+			c.Check(a, jc.DeepEquals, params.OfferURLs{OfferURLs: []string{"me/prod.app"}})
+			*(result.(*params.OfferIngressNetworksResults)) = params.OfferIngressNetworksResults{
+				Results: []params.OfferIngressNetworksResult{{
+					CIDRs: []string{"10.0.0.0/8"},
+				}},
+			}
+			return nil
+		},
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	cidrs, err := client.OfferIngressNetworks("me/prod.app")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8"})
+}
+
+func (s *crossmodelMockSuite) TestOfferIngressNetworksNotSupported(c *gc.C) {
+	client := applicationoffers.NewClient(basetesting.BestVersionCaller{BestVersion: 1})
+	err := client.SetOfferIngressNetworks("me/prod.app", []string{"10.0.0.0/8"})
+	c.Assert(err, gc.ErrorMatches, "SetOfferIngressNetworks on this controller not supported")
+	_, err = client.OfferIngressNetworks("me/prod.app")
+	c.Assert(err, gc.ErrorMatches, "OfferIngressNetworks on this controller not supported")
+}
+
+func (s *crossmodelMockSuite) TestOfferConsumers(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "OfferConsumers")
+			c.Check(a, jc.DeepEquals, params.OfferURLs{OfferURLs: []string{"me/prod.app"}})
+			*(result.(*params.OfferConsumersResults)) = params.OfferConsumersResults{
+				Results: []params.OfferConsumersResult{{
+					Consumers: []params.OfferConnection{{
+						SourceModelTag: testing.ModelTag.String(),
+						RelationId:     1,
+						Username:       "fred",
+						Endpoint:       "db",
+						Status:         params.EntityStatus{Status: "joined"},
+						IngressSubnets: []string{"10.0.0.0/8"},
+						Health:         "stale",
+					}},
+				}},
+			}
+			return nil
+		},
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	consumers, err := client.OfferConsumers("me/prod.app")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(consumers, jc.DeepEquals, []jujucrossmodel.OfferConnection{{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      1,
+		Username:        "fred",
+		Endpoint:        "db",
+		Status:          "joined",
+		IngressSubnets:  []string{"10.0.0.0/8"},
+		Health:          jujucrossmodel.OfferConnectionStale,
+	}})
+}
+
+func (s *crossmodelMockSuite) TestRevokeConsumers(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "RevokeConsumers")
+			c.Check(a, jc.DeepEquals, params.RevokeOfferConsumersArgs{
+				Args: []params.RevokeOfferConsumers{{
+					OfferURL:        "me/prod.app",
+					SourceModelTags: []string{testing.ModelTag.String()},
+					Usernames:       []string{"fred"},
+					StaleOnly:       true,
+				}},
+			})
+			*(result.(*params.RevokeOfferConsumersResults)) = params.RevokeOfferConsumersResults{
+				Results: []params.RevokeOfferConsumersResult{{
+					RelationIds: []int{1, 3},
+				}},
+			}
+			return nil
+		},
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	relationIds, err := client.RevokeConsumers(applicationoffers.RevokeConsumersArgs{
+		OfferURL:         "me/prod.app",
+		SourceModelUUIDs: []string{testing.ModelTag.Id()},
+		Usernames:        []string{"fred"},
+		StaleOnly:        true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(relationIds, jc.DeepEquals, []int{1, 3})
+}
+
+func (s *crossmodelMockSuite) TestRevokeConsumersInvalidModel(c *gc.C) {
+	client := applicationoffers.NewClient(basetesting.BestVersionCaller{BestVersion: 2})
+	_, err := client.RevokeConsumers(applicationoffers.RevokeConsumersArgs{
+		OfferURL:         "me/prod.app",
+		SourceModelUUIDs: []string{"foo"},
+	})
+	c.Assert(err, gc.ErrorMatches, `model UUID "foo" not valid`)
+}
+
+func (s *crossmodelMockSuite) TestOfferConsumersNotSupported(c *gc.C) {
+	client := applicationoffers.NewClient(basetesting.BestVersionCaller{BestVersion: 1})
+	_, err := client.OfferConsumers("me/prod.app")
+	c.Assert(err, gc.ErrorMatches, "OfferConsumers on this controller not supported")
+	_, err = client.RevokeConsumers(applicationoffers.RevokeConsumersArgs{OfferURL: "me/prod.app"})
+	c.Assert(err, gc.ErrorMatches, "RevokeConsumers on this controller not supported")
+}
+
+func (s *crossmodelMockSuite) TestStartOfferMigration(c *gc.C) {
+	deadline := time.Date(2017, 10, 2, 12, 0, 0, 0, time.UTC)
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "ApplicationOffers")
+			c.Check(request, gc.Equals, "StartOfferMigration")
+			c.Check(a, jc.DeepEquals, params.StartOfferMigrationArgs{
+				Args: []params.OfferMigration{{
+					OfferURL:        "me/prod.app",
+					ApplicationName: "app2",
+					Endpoints:       map[string]string{"db": "server"},
+					Deadline:        deadline,
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.StartOfferMigration("me/prod.app", jujucrossmodel.OfferMigration{
+		ApplicationName: "app2",
+		Endpoints:       map[string]string{"db": "server"},
+		Deadline:        deadline,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestCompleteAndAbortOfferMigration(c *gc.C) {
+	var requests []string
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			requests = append(requests, request)
+			c.Check(a, jc.DeepEquals, params.OfferURLs{OfferURLs: []string{"me/prod.app"}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "fail"}}},
+			}
+			return nil
+		},
+	}
+	client := applicationoffers.NewClient(apiCaller)
+	err := client.CompleteOfferMigration("me/prod.app")
+	c.Assert(err, gc.ErrorMatches, "fail")
+	err = client.AbortOfferMigration("me/prod.app")
+	c.Assert(err, gc.ErrorMatches, "fail")
+	c.Assert(requests, jc.DeepEquals, []string{"CompleteOfferMigration", "AbortOfferMigration"})
+}
+
+func (s *crossmodelMockSuite) TestOfferMigrationNotSupported(c *gc.C) {
+	client := applicationoffers.NewClient(basetesting.BestVersionCaller{BestVersion: 2})
+	err := client.StartOfferMigration("me/prod.app", jujucrossmodel.OfferMigration{})
+	c.Assert(err, gc.ErrorMatches, "StartOfferMigration on this controller not supported")
+	err = client.CompleteOfferMigration("me/prod.app")
+	c.Assert(err, gc.ErrorMatches, "CompleteOfferMigration on this controller not supported")
+	err = client.AbortOfferMigration("me/prod.app")
+	c.Assert(err, gc.ErrorMatches, "AbortOfferMigration on this controller not supported")
+}
@@ -0,0 +1,35 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides access to the AuditLog API facade, which
+// is used to query the controller's record of mutating API calls.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the audit log API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the audit log API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Events returns the audit events selected by the given arguments,
+// most recent first.
+func (c *Client) Events(args params.AuditEventsArgs) ([]params.AuditEvent, error) {
+	var result params.AuditEvents
+	if err := c.facade.FacadeCall("Events", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Events, nil
+}
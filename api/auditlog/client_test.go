@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestEvents(c *gc.C) {
+	after := time.Date(2017, 9, 1, 0, 0, 0, 0, time.UTC)
+	args := params.AuditEventsArgs{
+		UserTag: "user-bob",
+		After:   &after,
+		Limit:   10,
+	}
+	events := []params.AuditEvent{{
+		OriginName: "bob",
+		Operation:  "Application.Deploy",
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Events")
+			c.Check(a, jc.DeepEquals, args)
+			c.Assert(result, gc.FitsTypeOf, &params.AuditEvents{})
+			*(result.(*params.AuditEvents)) = params.AuditEvents{Events: events}
+			return nil
+		},
+	)
+	client := auditlog.NewClient(apiCaller)
+	result, err := client.Events(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, events)
+}
+
+func (s *clientSuite) TestEventsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Events(params.AuditEventsArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -19,3 +19,40 @@ func (c *Client) Create(notes string) (*params.BackupsMetadataResult, error) {
 	}
 	return &result, nil
 }
+
+// CreateIncremental sends a request to create a backup holding only
+// the changes made to juju's state since the most recent backup. It
+// returns the metadata associated with the resulting backup.
+func (c *Client) CreateIncremental(notes string) (*params.BackupsMetadataResult, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("incremental backups on this controller")
+	}
+	var result params.BackupsMetadataResult
+	args := params.BackupsCreateArgs{
+		Notes:       notes,
+		Incremental: true,
+	}
+	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
+
+// CreateToTarget sends a request to create a backup of juju's state
+// and stream the archive to the controller's backup target, rather
+// than storing it on the controller. The Target of the returned
+// metadata refers to the stored archive.
+func (c *Client) CreateToTarget(notes string) (*params.BackupsMetadataResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("streaming backups to a backup target on this controller")
+	}
+	var result params.BackupsMetadataResult
+	args := params.BackupsCreateArgs{
+		Notes:    notes,
+		ToTarget: true,
+	}
+	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
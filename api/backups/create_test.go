@@ -45,3 +45,61 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateIncremental(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+			c.Check(paramsIn, jc.DeepEquals, params.BackupsCreateArgs{
+				Notes:       "important",
+				Incremental: true,
+			})
+
+			if result, ok := resp.(*params.BackupsMetadataResult); ok {
+				*result = apiserverbackups.ResultFromMetadata(s.Meta)
+				result.Notes = "important"
+				result.Parent = "parent-id"
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.CreateIncremental("important")
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta := backupstesting.UpdateNotes(s.Meta, "important")
+	s.checkMetadataResult(c, result, meta)
+	c.Check(result.Parent, gc.Equals, "parent-id")
+}
+
+func (s *createSuite) TestCreateToTarget(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+			c.Check(paramsIn, jc.DeepEquals, params.BackupsCreateArgs{
+				Notes:    "important",
+				ToTarget: true,
+			})
+
+			if result, ok := resp.(*params.BackupsMetadataResult); ok {
+				*result = apiserverbackups.ResultFromMetadata(s.Meta)
+				result.Notes = "important"
+				result.Target = "s3://juju-backups/juju-backup.tar.gz"
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.CreateToTarget("important")
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta := backupstesting.UpdateNotes(s.Meta, "important")
+	s.checkMetadataResult(c, result, meta)
+	c.Check(result.Target, gc.Equals, "s3://juju-backups/juju-backup.tar.gz")
+}
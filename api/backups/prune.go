@@ -0,0 +1,24 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Prune removes the stored backups not retained by the retention
+// policy in args, and returns the metadata of the removed backups.
+// If args.DryRun is set, nothing is removed.
+func (c *Client) Prune(args params.BackupsPruneArgs) ([]params.BackupsMetadataResult, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("pruning backups on this controller")
+	}
+	var result params.BackupsPruneResult
+	if err := c.facade.FacadeCall("Prune", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Removed, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	apiserverbackups "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type pruneSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&pruneSuite{})
+
+func (s *pruneSuite) TestPrune(c *gc.C) {
+	args := params.BackupsPruneArgs{
+		KeepLast:  2,
+		KeepDaily: 7,
+		DryRun:    true,
+	}
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Prune")
+			c.Check(paramsIn, jc.DeepEquals, args)
+
+			if result, ok := resp.(*params.BackupsPruneResult); ok {
+				result.Removed = []params.BackupsMetadataResult{
+					apiserverbackups.ResultFromMetadata(s.Meta),
+				}
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	removed, err := s.client.Prune(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, gc.HasLen, 1)
+	s.checkMetadataResult(c, &removed[0], s.Meta)
+}
@@ -0,0 +1,29 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RestoreModel restores a single model from a controller backup into
+// the running controller, and returns the tag of the restored model.
+// The model is given the UUID and name in args if they are set.
+func (c *Client) RestoreModel(args params.BackupsRestoreModelArgs) (names.ModelTag, error) {
+	if c.BestAPIVersion() < 4 {
+		return names.ModelTag{}, errors.NotSupportedf("restoring a single model on this controller")
+	}
+	var result params.BackupsRestoreModelResult
+	if err := c.facade.FacadeCall("RestoreModel", args, &result); err != nil {
+		return names.ModelTag{}, errors.Trace(err)
+	}
+	tag, err := names.ParseModelTag(result.ModelTag)
+	if err != nil {
+		return names.ModelTag{}, errors.Trace(err)
+	}
+	return tag, nil
+}
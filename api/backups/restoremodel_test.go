@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type restoreModelSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&restoreModelSuite{})
+
+func (s *restoreModelSuite) TestRestoreModel(c *gc.C) {
+	modelTag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	args := params.BackupsRestoreModelArgs{
+		BackupId: "some-id",
+		ModelTag: modelTag.String(),
+		NewName:  "restored",
+	}
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "RestoreModel")
+			c.Check(paramsIn, jc.DeepEquals, args)
+
+			if result, ok := resp.(*params.BackupsRestoreModelResult); ok {
+				result.ModelTag = modelTag.String()
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	restored, err := s.client.RestoreModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(restored, gc.Equals, modelTag)
+}
@@ -0,0 +1,32 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base
+
+import (
+	"time"
+)
+
+// ConnectionHealth describes the health of an API connection, as
+// seen by the pings that keep it alive.
+type ConnectionHealth struct {
+	// RTT holds the round trip time of the most recent successful
+	// ping, or zero if there has been none.
+	RTT time.Duration
+
+	// LastPing holds the time at which the most recent successful
+	// ping completed, or the zero time if there has been none.
+	LastPing time.Time
+
+	// Redials holds the number of times the connection has been
+	// re-established after being lost.
+	Redials int
+}
+
+// ConnectionMonitor is optionally implemented by an APICallCloser
+// that can report the health of its connection.
+type ConnectionMonitor interface {
+	// ConnectionHealth returns the current health of the
+	// connection.
+	ConnectionHealth() ConnectionHealth
+}
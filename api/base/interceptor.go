@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base
+
+import (
+	"time"
+)
+
+// APICallInfo identifies a single API call passed to an
+// APICallInterceptor.
+type APICallInfo struct {
+	// Facade, Version, Id and Request identify the method
+	// being called.
+	Facade  string
+	Version int
+	Id      string
+	Request string
+
+	// CorrelationId holds the correlation ID sent with the call,
+	// if any.
+	CorrelationId string
+}
+
+// APICallInterceptor is called at each stage of the API calls made
+// by an APICaller. Unlike an APICallObserver, it sees calls as they
+// start, so it can be used to trace calls that never complete.
+// Its methods must not block.
+type APICallInterceptor interface {
+	// APICallStarted is called before the call is sent.
+	APICallStarted(APICallInfo)
+
+	// APICallEnded is called when the call returns successfully,
+	// with the time taken to make it.
+	APICallEnded(APICallInfo, time.Duration)
+
+	// APICallFailed is called when the call returns an error.
+	APICallFailed(APICallInfo, error)
+}
+
+// TraceableAPICaller is optionally implemented by an APICallCloser
+// whose calls can be traced.
+type TraceableAPICaller interface {
+	// AddAPICallInterceptor arranges for the given interceptor
+	// to be called for all subsequent calls.
+	AddAPICallInterceptor(APICallInterceptor)
+
+	// SetCorrelationId sets the correlation ID sent with all
+	// subsequent calls. The controller records it in the audit
+	// log, so that all the calls made for a single client
+	// operation can be found. An empty ID sends none.
+	SetCorrelationId(string)
+}
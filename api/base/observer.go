@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// APICallObserver is notified of API calls made by an APICaller.
+// It allows clients to inspect the traffic on a connection without
+// scraping log output.
+type APICallObserver interface {
+	// APICallCompleted is called once each call has returned,
+	// including any retries made on the caller's behalf. It must
+	// not block.
+	APICallCompleted(APICallRecord)
+}
+
+// ObservableAPICaller is optionally implemented by an APICallCloser
+// that can report the calls made through it to an APICallObserver.
+type ObservableAPICaller interface {
+	// SetAPICallObserver arranges for all subsequent calls to be
+	// reported to the given observer. A nil observer stops
+	// reporting.
+	SetAPICallObserver(APICallObserver)
+}
+
+// APICallRecord describes a single completed API call.
+type APICallRecord struct {
+	// Facade, Version, Id and Request identify the method
+	// that was called.
+	Facade  string
+	Version int
+	Id      string
+	Request string
+
+	// Params holds the JSON encoded call arguments, with any
+	// sensitive values redacted.
+	Params json.RawMessage
+
+	// Response holds the JSON encoded call results, with any
+	// sensitive values redacted. It is nil if the call failed.
+	Response json.RawMessage
+
+	// Duration holds how long the call took to complete.
+	Duration time.Duration
+
+	// Error holds the error returned by the call, if any.
+	Error error
+}
+
+// RedactedValue replaces the values of sensitive fields in the
+// payloads reported to an APICallObserver.
+const RedactedValue = "<redacted>"
+
+// sensitiveFields holds substrings of the JSON field names whose
+// values are redacted from observed payloads.
+var sensitiveFields = []string{
+	"password",
+	"secret",
+	"macaroon",
+	"private-key",
+	"credential",
+	"token",
+}
+
+// SanitizePayload returns the JSON encoding of the given API call
+// payload with the values of any sensitive fields redacted. It
+// returns nil if the payload is nil or cannot be encoded.
+func SanitizePayload(payload interface{}) json.RawMessage {
+	if payload == nil {
+		return nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	data, err = json.Marshal(redact(decoded))
+	if err != nil {
+		return nil
+	}
+	return json.RawMessage(data)
+}
+
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSensitiveField(key) {
+				v[key] = RedactedValue
+			} else {
+				v[key] = redact(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	}
+	return v
+}
+
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, field := range sensitiveFields {
+		if strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type observerSuite struct{}
+
+var _ = gc.Suite(&observerSuite{})
+
+func (*observerSuite) TestSanitizePayload(c *gc.C) {
+	payload := params.LoginRequest{
+		AuthTag:     "user-admin",
+		Credentials: "sekrit",
+		Nonce:       "nonce",
+	}
+	c.Assert(string(base.SanitizePayload(payload)), gc.Equals,
+		`{"auth-tag":"user-admin","credentials":"<redacted>","macaroons":"<redacted>","nonce":"nonce","user-data":""}`,
+	)
+}
+
+func (*observerSuite) TestSanitizePayloadNested(c *gc.C) {
+	payload := map[string]interface{}{
+		"entities": []interface{}{
+			map[string]interface{}{"tag": "machine-0", "password": "foo"},
+		},
+	}
+	c.Assert(string(base.SanitizePayload(payload)), gc.Equals,
+		`{"entities":[{"password":"<redacted>","tag":"machine-0"}]}`,
+	)
+}
+
+func (*observerSuite) TestSanitizePayloadNil(c *gc.C) {
+	c.Assert(base.SanitizePayload(nil), gc.IsNil)
+}
@@ -0,0 +1,307 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/httprequest"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon-bakery.v1/httpbakery"
+
+	"github.com/juju/juju/rpc"
+)
+
+var logger = loggo.GetLogger("juju.api.base")
+
+// DialFunc returns a new connection to the API.
+type DialFunc func() (APICallCloser, error)
+
+// RetryPolicy controls how calls made through an APICallCloser
+// returned by NewRetryingAPICaller are retried when the connection
+// to the controller is lost.
+type RetryPolicy struct {
+	// Attempts is the maximum number of times a call that is safe
+	// to repeat will be attempted.
+	Attempts int
+
+	// Delay is how long to wait before redialling after the
+	// connection has been lost.
+	Delay time.Duration
+
+	// Clock is used to wait between attempts.
+	Clock clock.Clock
+
+	// IsIdempotent reports whether the given facade method may be
+	// called more than once without ill effect. If it is nil,
+	// IsIdempotentCall is used.
+	IsIdempotent func(facade, method string) bool
+}
+
+// Validate returns an error if the policy cannot be used.
+func (p RetryPolicy) Validate() error {
+	if p.Attempts < 1 {
+		return errors.NotValidf("%d attempts", p.Attempts)
+	}
+	if p.Delay < 0 {
+		return errors.NotValidf("negative delay")
+	}
+	if p.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// idempotentPrefixes holds the prefixes of the names of facade
+// methods that, by convention, only read from the model.
+var idempotentPrefixes = []string{
+	"Find",
+	"Full",
+	"Get",
+	"List",
+	"Show",
+}
+
+// IsIdempotentCall reports whether the given facade method only reads
+// from the model, and so may safely be called again if the connection
+// is lost before its result arrives. It errs on the side of caution:
+// watcher methods are not considered idempotent, because watchers are
+// bound to the connection that created them.
+func IsIdempotentCall(facade, method string) bool {
+	if strings.HasSuffix(facade, "Watcher") {
+		return false
+	}
+	for _, prefix := range idempotentPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isConnectionBound reports whether the given facade method creates
+// or uses resources, such as watchers, that belong to the connection
+// the call was made on. Such calls are never repeated on another
+// connection.
+func isConnectionBound(facade, method string) bool {
+	return strings.HasSuffix(facade, "Watcher") || strings.HasPrefix(method, "Watch")
+}
+
+// IdempotentAPICaller is implemented by APICallers that can send an
+// idempotency key with a call. The controller runs calls carrying the
+// same key at most once, returning the outcome of the first call for
+// any repeats.
+type IdempotentAPICaller interface {
+	// SupportsIdempotencyKeys reports whether the controller
+	// honours idempotency keys.
+	SupportsIdempotencyKeys() bool
+
+	// IdempotentAPICall is like APICall, but sends the given
+	// idempotency key with the call.
+	IdempotentAPICall(key, facade string, version int, id, method string, args, response interface{}) error
+}
+
+// keyedCaller returns conn as an IdempotentAPICaller if the controller
+// it is connected to honours idempotency keys.
+func keyedCaller(conn APICallCloser) (IdempotentAPICaller, bool) {
+	keyed, ok := conn.(IdempotentAPICaller)
+	return keyed, ok && keyed.SupportsIdempotencyKeys()
+}
+
+// NewRetryingAPICaller returns an APICallCloser that makes calls on a
+// connection obtained from dial. If the connection is lost, the next
+// call is made on a new connection, and calls that were interrupted
+// are retried on a new connection according to the policy. Calls that
+// are not safe to repeat are sent with an idempotency key, so that the
+// controller runs them at most once; if the controller does not honour
+// keys, such calls return the error instead, since the controller may
+// already have acted on them. Calls bound to the connection, such as
+// those on watchers, are never retried.
+func NewRetryingAPICaller(dial DialFunc, policy RetryPolicy) (APICallCloser, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if policy.IsIdempotent == nil {
+		policy.IsIdempotent = IsIdempotentCall
+	}
+	conn, err := dial()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &retryingAPICaller{
+		dial:   dial,
+		policy: policy,
+		conn:   conn,
+	}, nil
+}
+
+type retryingAPICaller struct {
+	dial   DialFunc
+	policy RetryPolicy
+
+	mu      sync.Mutex
+	conn    APICallCloser
+	closed  bool
+	redials int
+}
+
+// connection returns the current connection, dialling a new one if
+// the previous one was lost.
+func (r *retryingAPICaller) connection() (APICallCloser, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil, errors.Trace(rpc.ErrShutdown)
+	}
+	if r.conn == nil {
+		conn, err := r.dial()
+		if err != nil {
+			return nil, errors.Annotate(err, "redialling API")
+		}
+		r.conn = conn
+		r.redials++
+	}
+	return r.conn, nil
+}
+
+// discard closes the given connection, if it is still current, so
+// that the next call dials a new one.
+func (r *retryingAPICaller) discard(conn APICallCloser) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conn == conn {
+		r.conn.Close()
+		r.conn = nil
+	}
+}
+
+// APICall is part of the APICaller interface.
+func (r *retryingAPICaller) APICall(facade string, version int, id, method string, args, response interface{}) error {
+	bound := isConnectionBound(facade, method)
+	idempotent := !bound && r.policy.IsIdempotent(facade, method)
+	// key is set once the call has been sent with an idempotency
+	// key; it must be sent with the same key on every attempt.
+	var key string
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		conn, err := r.connection()
+		if err == nil {
+			keyed, canKey := keyedCaller(conn)
+			switch {
+			case idempotent || bound:
+				err = conn.APICall(facade, version, id, method, args, response)
+			case canKey:
+				if key == "" {
+					key = utils.MustNewUUID().String()
+				}
+				err = keyed.IdempotentAPICall(key, facade, version, id, method, args, response)
+			case key == "":
+				err = conn.APICall(facade, version, id, method, args, response)
+			default:
+				// The new connection cannot guarantee that
+				// the call won't run twice.
+				return errors.Trace(lastErr)
+			}
+			if !rpc.IsShutdownErr(err) {
+				return err
+			}
+			r.discard(conn)
+		}
+		if !(idempotent || key != "") || attempt >= r.policy.Attempts {
+			return errors.Trace(err)
+		}
+		lastErr = err
+		logger.Debugf("retrying %s.%s after connection loss: %v", facade, method, err)
+		<-r.policy.Clock.After(r.policy.Delay)
+	}
+}
+
+// BestFacadeVersion is part of the APICaller interface.
+func (r *retryingAPICaller) BestFacadeVersion(facade string) int {
+	conn, err := r.connection()
+	if err != nil {
+		return 0
+	}
+	return conn.BestFacadeVersion(facade)
+}
+
+// ModelTag is part of the APICaller interface.
+func (r *retryingAPICaller) ModelTag() (names.ModelTag, bool) {
+	conn, err := r.connection()
+	if err != nil {
+		return names.ModelTag{}, false
+	}
+	return conn.ModelTag()
+}
+
+// HTTPClient is part of the APICaller interface.
+func (r *retryingAPICaller) HTTPClient() (*httprequest.Client, error) {
+	conn, err := r.connection()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return conn.HTTPClient()
+}
+
+// BakeryClient is part of the APICaller interface.
+func (r *retryingAPICaller) BakeryClient() *httpbakery.Client {
+	conn, err := r.connection()
+	if err != nil {
+		return nil
+	}
+	return conn.BakeryClient()
+}
+
+// ConnectStream is part of the StreamConnector interface.
+func (r *retryingAPICaller) ConnectStream(path string, attrs url.Values) (Stream, error) {
+	conn, err := r.connection()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return conn.ConnectStream(path, attrs)
+}
+
+// ConnectControllerStream is part of the ControllerStreamConnector interface.
+func (r *retryingAPICaller) ConnectControllerStream(path string, attrs url.Values, headers http.Header) (Stream, error) {
+	conn, err := r.connection()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return conn.ConnectControllerStream(path, attrs, headers)
+}
+
+// ConnectionHealth is part of the ConnectionMonitor interface. It
+// reports the health of the current connection, if that can be
+// determined, along with the number of times it has been redialled.
+func (r *retryingAPICaller) ConnectionHealth() ConnectionHealth {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var health ConnectionHealth
+	if monitor, ok := r.conn.(ConnectionMonitor); ok {
+		health = monitor.ConnectionHealth()
+	}
+	health.Redials = r.redials
+	return health
+}
+
+// Close is part of the APICallCloser interface.
+func (r *retryingAPICaller) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.conn = nil
+	return errors.Trace(err)
+}
@@ -0,0 +1,200 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/rpc"
+)
+
+type retrySuite struct {
+	dials int
+	calls []string
+	errs  []error
+	keyed bool
+	keys  []string
+}
+
+var _ = gc.Suite(&retrySuite{})
+
+func (s *retrySuite) SetUpTest(c *gc.C) {
+	s.dials = 0
+	s.calls = nil
+	s.errs = nil
+	s.keyed = false
+	s.keys = nil
+}
+
+func (s *retrySuite) dial() (base.APICallCloser, error) {
+	s.dials++
+	caller := basetesting.APICallerFunc(func(facade string, version int, id, method string, args, response interface{}) error {
+		s.calls = append(s.calls, facade+"."+method)
+		if len(s.errs) == 0 {
+			return nil
+		}
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return err
+	})
+	if s.keyed {
+		return keyedCaller{caller, &s.keys}, nil
+	}
+	return caller, nil
+}
+
+// keyedCaller is an APICallCloser connected to a controller
+// that honours idempotency keys.
+type keyedCaller struct {
+	basetesting.APICallerFunc
+	keys *[]string
+}
+
+func (keyedCaller) SupportsIdempotencyKeys() bool {
+	return true
+}
+
+func (k keyedCaller) IdempotentAPICall(key, facade string, version int, id, method string, args, response interface{}) error {
+	*k.keys = append(*k.keys, key)
+	return k.APICallerFunc(facade, version, id, method, args, response)
+}
+
+func (s *retrySuite) newCaller(c *gc.C) base.APICallCloser {
+	caller, err := base.NewRetryingAPICaller(s.dial, base.RetryPolicy{
+		Attempts: 3,
+		Clock:    clock.WallClock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return caller
+}
+
+func (s *retrySuite) TestValidate(c *gc.C) {
+	_, err := base.NewRetryingAPICaller(s.dial, base.RetryPolicy{Clock: clock.WallClock})
+	c.Assert(err, gc.ErrorMatches, "0 attempts not valid")
+	_, err = base.NewRetryingAPICaller(s.dial, base.RetryPolicy{Attempts: 1})
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+	c.Assert(s.dials, gc.Equals, 0)
+}
+
+func (s *retrySuite) TestIdempotentCallRetried(c *gc.C) {
+	caller := s.newCaller(c)
+	s.errs = []error{rpc.ErrShutdown, errors.Trace(rpc.ErrShutdown)}
+
+	err := caller.APICall("Application", 5, "", "Get", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, jc.DeepEquals, []string{"Application.Get", "Application.Get", "Application.Get"})
+	c.Assert(s.dials, gc.Equals, 3)
+}
+
+func (s *retrySuite) TestConnectionHealthCountsRedials(c *gc.C) {
+	caller := s.newCaller(c)
+	monitor, ok := caller.(base.ConnectionMonitor)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(monitor.ConnectionHealth(), jc.DeepEquals, base.ConnectionHealth{})
+
+	s.errs = []error{rpc.ErrShutdown, rpc.ErrShutdown}
+	err := caller.APICall("Application", 5, "", "Get", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(monitor.ConnectionHealth().Redials, gc.Equals, 2)
+}
+
+func (s *retrySuite) TestIdempotentCallRetryLimit(c *gc.C) {
+	caller := s.newCaller(c)
+	s.errs = []error{rpc.ErrShutdown, rpc.ErrShutdown, rpc.ErrShutdown}
+
+	err := caller.APICall("Client", 1, "", "FullStatus", nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, rpc.ErrShutdown)
+	c.Assert(s.calls, gc.HasLen, 3)
+}
+
+func (s *retrySuite) TestMutatingCallNotRetried(c *gc.C) {
+	caller := s.newCaller(c)
+	s.errs = []error{rpc.ErrShutdown}
+
+	err := caller.APICall("Application", 5, "", "AddUnits", nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, rpc.ErrShutdown)
+	c.Assert(s.calls, jc.DeepEquals, []string{"Application.AddUnits"})
+	c.Assert(s.dials, gc.Equals, 1)
+
+	// The next call is made on a new connection.
+	err = caller.APICall("Application", 5, "", "AddUnits", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.dials, gc.Equals, 2)
+}
+
+func (s *retrySuite) TestMutatingCallRetriedWithKey(c *gc.C) {
+	s.keyed = true
+	caller := s.newCaller(c)
+	s.errs = []error{rpc.ErrShutdown}
+
+	err := caller.APICall("Application", 5, "", "AddUnits", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, jc.DeepEquals, []string{"Application.AddUnits", "Application.AddUnits"})
+	c.Assert(s.keys, gc.HasLen, 2)
+	c.Assert(s.keys[0], gc.Not(gc.Equals), "")
+	c.Assert(s.keys[1], gc.Equals, s.keys[0])
+
+	// Each call gets its own key.
+	err = caller.APICall("Application", 5, "", "AddUnits", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.keys, gc.HasLen, 3)
+	c.Assert(s.keys[2], gc.Not(gc.Equals), s.keys[0])
+}
+
+func (s *retrySuite) TestMutatingCallNotRetriedWithoutKeySupport(c *gc.C) {
+	s.keyed = true
+	caller := s.newCaller(c)
+	s.errs = []error{rpc.ErrShutdown}
+	// The controller we reconnect to doesn't honour keys.
+	s.keyed = false
+
+	err := caller.APICall("Application", 5, "", "AddUnits", nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, rpc.ErrShutdown)
+	c.Assert(s.calls, jc.DeepEquals, []string{"Application.AddUnits"})
+	c.Assert(s.dials, gc.Equals, 2)
+}
+
+func (s *retrySuite) TestWatchCallsNotRetried(c *gc.C) {
+	s.keyed = true
+	caller := s.newCaller(c)
+	s.errs = []error{rpc.ErrShutdown, rpc.ErrShutdown}
+
+	err := caller.APICall("Application", 5, "", "WatchUnits", nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, rpc.ErrShutdown)
+	err = caller.APICall("StringsWatcher", 1, "1", "Next", nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, rpc.ErrShutdown)
+	c.Assert(s.calls, jc.DeepEquals, []string{"Application.WatchUnits", "StringsWatcher.Next"})
+	c.Assert(s.keys, gc.HasLen, 0)
+}
+
+func (s *retrySuite) TestOtherErrorsNotRetried(c *gc.C) {
+	caller := s.newCaller(c)
+	s.errs = []error{errors.New("boom")}
+
+	err := caller.APICall("Application", 5, "", "Get", nil, nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(s.calls, gc.HasLen, 1)
+	c.Assert(s.dials, gc.Equals, 1)
+}
+
+func (s *retrySuite) TestClose(c *gc.C) {
+	caller := s.newCaller(c)
+	c.Assert(caller.Close(), jc.ErrorIsNil)
+	err := caller.APICall("Application", 5, "", "Get", nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, rpc.ErrShutdown)
+	c.Assert(s.calls, gc.HasLen, 0)
+}
+
+func (s *retrySuite) TestIsIdempotentCall(c *gc.C) {
+	c.Check(base.IsIdempotentCall("Application", "GetConstraints"), jc.IsTrue)
+	c.Check(base.IsIdempotentCall("Client", "FullStatus"), jc.IsTrue)
+	c.Check(base.IsIdempotentCall("Application", "Deploy"), jc.IsFalse)
+	c.Check(base.IsIdempotentCall("Application", "AddUnits"), jc.IsFalse)
+	c.Check(base.IsIdempotentCall("AllWatcher", "Next"), jc.IsFalse)
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundle provides access to the bundle api facade.
+package bundle
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the bundle API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the bundle api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Bundle")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ExportBundle returns the YAML for a bundle that would deploy the
+// current model's applications, relations and machines. Any
+// application offers in the model are described in an overlay
+// following the bundle.
+func (c *Client) ExportBundle() (string, error) {
+	if c.BestAPIVersion() < 2 {
+		return "", errors.NotSupportedf("ExportBundle on this controller")
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// PlanBundle returns the changes that deploying the given bundle YAML
+// would make to the current model, without making them. If
+// mapMachines is true, the bundle's machines are mapped to the
+// model's machines with the same ids, rather than added.
+func (c *Client) PlanBundle(bundleYAML string, mapMachines bool) (params.BundlePlan, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.BundlePlan{}, errors.NotSupportedf("PlanBundle on this controller")
+	}
+	args := params.BundlePlanParams{
+		BundleDataYAML: bundleYAML,
+		MapMachines:    mapMachines,
+	}
+	var result params.BundlePlanResults
+	if err := c.facade.FacadeCall("PlanBundle", args, &result); err != nil {
+		return params.BundlePlan{}, errors.Trace(err)
+	}
+	if len(result.Errors) > 0 {
+		return params.BundlePlan{}, errors.Errorf("invalid bundle: %s", strings.Join(result.Errors, "; "))
+	}
+	return *result.Plan, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type bundleMockSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&bundleMockSuite{})
+
+func newClient(f basetesting.APICallerFunc) *bundle.Client {
+	return bundle.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: f,
+		BestVersion:   3,
+	})
+}
+
+func (s *bundleMockSuite) TestExportBundle(c *gc.C) {
+	client := newClient(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ExportBundle")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.StringResult)) = params.StringResult{
+				Result: "applications: {}\n",
+			}
+			return nil
+		},
+	)
+	out, err := client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "applications: {}\n")
+}
+
+func (s *bundleMockSuite) TestExportBundleError(c *gc.C) {
+	client := newClient(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.StringResult)) = params.StringResult{
+				Error: &params.Error{Message: "nothing to export as there are no applications"},
+			}
+			return nil
+		},
+	)
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "nothing to export as there are no applications")
+}
+
+func (s *bundleMockSuite) TestExportBundleCallError(c *gc.C) {
+	client := newClient(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *bundleMockSuite) TestExportBundleNotSupported(c *gc.C) {
+	client := bundle.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 1,
+	})
+	_, err := client.ExportBundle()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "ExportBundle on this controller not supported")
+}
+
+func (s *bundleMockSuite) TestPlanBundle(c *gc.C) {
+	client := newClient(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "PlanBundle")
+			c.Check(a, jc.DeepEquals, params.BundlePlanParams{
+				BundleDataYAML: "applications: {}",
+				MapMachines:    true,
+			})
+			*(result.(*params.BundlePlanResults)) = params.BundlePlanResults{
+				Plan: &params.BundlePlan{AddMachines: []string{"0"}},
+			}
+			return nil
+		},
+	)
+	plan, err := client.PlanBundle("applications: {}", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan, jc.DeepEquals, params.BundlePlan{AddMachines: []string{"0"}})
+}
+
+func (s *bundleMockSuite) TestPlanBundleVerificationErrors(c *gc.C) {
+	client := newClient(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.BundlePlanResults)) = params.BundlePlanResults{
+				Errors: []string{"first", "second"},
+			}
+			return nil
+		},
+	)
+	_, err := client.PlanBundle("applications: {}", false)
+	c.Assert(err, gc.ErrorMatches, "invalid bundle: first; second")
+}
+
+func (s *bundleMockSuite) TestPlanBundleNotSupported(c *gc.C) {
+	client := bundle.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 2,
+	})
+	_, err := client.PlanBundle("applications: {}", false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader
+
+import (
+	"github.com/juju/juju/api/base"
+)
+
+const apiName = "CharmUpgrader"
+
+// Facade allows calls to "CharmUpgrader" endpoints.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade returns a new "CharmUpgrader" Facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{facade: base.NewFacadeCaller(caller, apiName)}
+}
+
+// AdvanceCharmUpgrades calls "CharmUpgrader.AdvanceCharmUpgrades".
+func (f *Facade) AdvanceCharmUpgrades() error {
+	return f.facade.FacadeCall("AdvanceCharmUpgrades", nil, nil)
+}
@@ -35,7 +35,7 @@ import (
 type Client struct {
 	base.ClientFacade
 	facade base.FacadeCaller
-	st     *state
+	st     base.APICallCloser
 }
 
 // Status returns the status of the juju model.
@@ -428,7 +428,18 @@ func (c *Client) AddCharmWithAuthorization(curl *charm.URL, channel csparams.Cha
 // ResolveCharm resolves the best available charm URLs with series, for charm
 // locations without a series specified.
 func (c *Client) ResolveCharm(ref *charm.URL) (*charm.URL, error) {
-	args := params.ResolveCharms{References: []string{ref.String()}}
+	return c.ResolveCharmWithChannel(ref, csparams.NoChannel)
+}
+
+// ResolveCharmWithChannel resolves the best available charm URL with
+// series in the given charm store channel, which may name a track as
+// well as a risk, e.g. "8.0/stable". An empty channel resolves the
+// charm in the charm store's default channel.
+func (c *Client) ResolveCharmWithChannel(ref *charm.URL, channel csparams.Channel) (*charm.URL, error) {
+	args := params.ResolveCharms{
+		References: []string{ref.String()},
+		Channel:    string(channel),
+	}
 	result := new(params.ResolveCharmResults)
 	if err := c.facade.FacadeCall("ResolveCharms", args, result); err != nil {
 		return nil, err
@@ -439,16 +439,21 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	s.PatchValue(api.WebsocketDial, catcher.recordLocation)
 
 	params := common.DebugLogParams{
-		IncludeEntity: []string{"a", "b"},
-		IncludeModule: []string{"c", "d"},
-		ExcludeEntity: []string{"e", "f"},
-		ExcludeModule: []string{"g", "h"},
-		Limit:         100,
-		Backlog:       200,
-		Level:         loggo.ERROR,
-		Replay:        true,
-		NoTail:        true,
-		StartTime:     time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		IncludeEntity:   []string{"a", "b"},
+		IncludeModule:   []string{"c", "d"},
+		ExcludeEntity:   []string{"e", "f"},
+		ExcludeModule:   []string{"g", "h"},
+		IncludeMessage:  []string{"^i"},
+		ExcludeMessage:  []string{"j$"},
+		IncludeLabel:    []string{"kind=unit,application=k"},
+		ExcludeLabel:    []string{"kind=machine"},
+		Limit:           100,
+		Backlog:         200,
+		MaxBacklogBytes: 300,
+		Level:           loggo.ERROR,
+		Replay:          true,
+		NoTail:          true,
+		StartTime:       time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
 	}
 
 	client := s.APIState.Client()
@@ -460,16 +465,21 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 
 	values := connectURL.Query()
 	c.Assert(values, jc.DeepEquals, url.Values{
-		"includeEntity": params.IncludeEntity,
-		"includeModule": params.IncludeModule,
-		"excludeEntity": params.ExcludeEntity,
-		"excludeModule": params.ExcludeModule,
-		"maxLines":      {"100"},
-		"backlog":       {"200"},
-		"level":         {"ERROR"},
-		"replay":        {"true"},
-		"noTail":        {"true"},
-		"startTime":     {"2016-11-30T11:48:00.0000001Z"},
+		"includeEntity":   params.IncludeEntity,
+		"includeModule":   params.IncludeModule,
+		"excludeEntity":   params.ExcludeEntity,
+		"excludeModule":   params.ExcludeModule,
+		"includeMessage":  params.IncludeMessage,
+		"excludeMessage":  params.ExcludeMessage,
+		"includeLabel":    params.IncludeLabel,
+		"excludeLabel":    params.ExcludeLabel,
+		"maxLines":        {"100"},
+		"backlog":         {"200"},
+		"maxBacklogBytes": {"300"},
+		"level":           {"ERROR"},
+		"replay":          {"true"},
+		"noTail":          {"true"},
+		"startTime":       {"2016-11-30T11:48:00.0000001Z"},
 	})
 }
 
@@ -34,6 +34,22 @@ type DebugLogParams struct {
 	// ExcludeModule lists logging modules to exclude from the resposne. If a
 	// module is specified, all the submodules are also excluded.
 	ExcludeModule []string
+	// IncludeMessage lists regular expressions, one of which each log
+	// message must match to be included in the response. If none are
+	// set all messages are considered included.
+	IncludeMessage []string
+	// ExcludeMessage lists regular expressions; log messages matching
+	// any of them are excluded from the response.
+	ExcludeMessage []string
+	// IncludeLabel lists label selectors, one of which each line must
+	// match to be included in the response. A selector is a comma
+	// separated list of label=value pairs, all of which must match,
+	// e.g.: kind=unit,application=mysql. The labels are kind,
+	// application and version.
+	IncludeLabel []string
+	// ExcludeLabel lists label selectors; lines matching any of them
+	// are excluded from the response.
+	ExcludeLabel []string
 	// Limit defines the maximum number of lines to return. Once this many
 	// have been sent, the socket is closed.  If zero, all filtered lines are
 	// sent down the connection until the client closes the connection.
@@ -42,6 +58,9 @@ type DebugLogParams struct {
 	// starting filtering. If backlog is zero and replay is false, then there
 	// may be an initial delay until the next matching log message is written.
 	Backlog uint
+	// MaxBacklogBytes, if non-zero, limits the backlog to the most
+	// recent lines whose messages total at most this many bytes.
+	MaxBacklogBytes uint
 	// Level specifies the minimum logging level to be sent back in the response.
 	Level loggo.Level
 	// Replay tells the server to start at the start of the log file rather
@@ -57,10 +76,14 @@ type DebugLogParams struct {
 
 func (args DebugLogParams) URLQuery() url.Values {
 	attrs := url.Values{
-		"includeEntity": args.IncludeEntity,
-		"includeModule": args.IncludeModule,
-		"excludeEntity": args.ExcludeEntity,
-		"excludeModule": args.ExcludeModule,
+		"includeEntity":  args.IncludeEntity,
+		"includeModule":  args.IncludeModule,
+		"excludeEntity":  args.ExcludeEntity,
+		"excludeModule":  args.ExcludeModule,
+		"includeMessage": args.IncludeMessage,
+		"excludeMessage": args.ExcludeMessage,
+		"includeLabel":   args.IncludeLabel,
+		"excludeLabel":   args.ExcludeLabel,
 	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
@@ -74,6 +97,9 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if args.Backlog > 0 {
 		attrs.Set("backlog", fmt.Sprint(args.Backlog))
 	}
+	if args.MaxBacklogBytes > 0 {
+		attrs.Set("maxBacklogBytes", fmt.Sprint(args.MaxBacklogBytes))
+	}
 	if args.Level != loggo.UNSPECIFIED {
 		attrs.Set("level", fmt.Sprint(args.Level))
 	}
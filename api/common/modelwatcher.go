@@ -10,7 +10,7 @@ import (
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/sinkconfig"
 	"github.com/juju/juju/watcher"
 )
 
@@ -52,23 +52,22 @@ func (e *ModelWatcher) ModelConfig() (*config.Config, error) {
 }
 
 // WatchForLogForwardConfigChanges return a NotifyWatcher waiting for the
-// log forward syslog configuration to change.
+// log forward configuration to change.
 func (e *ModelWatcher) WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error) {
 	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
 	// For now, we'll piggyback off the ModelConfig API.
 	return e.WatchForModelConfigChanges()
 }
 
-// LogForwardConfig returns the current log forward syslog configuration.
-func (e *ModelWatcher) LogForwardConfig() (*syslog.RawConfig, bool, error) {
+// LogForwardConfig returns the current log forward configuration.
+func (e *ModelWatcher) LogForwardConfig() (*sinkconfig.Config, bool, error) {
 	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
 	// For now, we'll piggyback off the ModelConfig API.
 	modelConfig, err := e.ModelConfig()
 	if err != nil {
 		return nil, false, err
 	}
-	cfg, ok := modelConfig.LogFwdSyslog()
-	return cfg, ok, nil
+	return modelConfig.LogForwardConfig()
 }
 
 // UpdateStatusHookInterval returns the current update status hook interval.
@@ -81,3 +80,13 @@ func (e *ModelWatcher) UpdateStatusHookInterval() (time.Duration, error) {
 	}
 	return modelConfig.UpdateStatusHookInterval(), nil
 }
+
+// HookTimeout returns the maximum time a charm hook may run before it
+// is killed. Zero means hooks are never killed.
+func (e *ModelWatcher) HookTimeout() (time.Duration, error) {
+	modelConfig, err := e.ModelConfig()
+	if err != nil {
+		return 0, err
+	}
+	return modelConfig.HookTimeout(), nil
+}
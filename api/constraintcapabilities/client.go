@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package constraintcapabilities provides a client for the
+// ConstraintCapabilities facade, which describes the constraints
+// supported by a model's provider.
+package constraintcapabilities
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+// Client allows access to the constraintcapabilities API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the
+// constraintcapabilities api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ConstraintCapabilities")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ConstraintCapabilities describes, for each constraint attribute,
+// whether the model's provider supports it, which attributes it
+// conflicts with, and the values allowed for it.
+func (c *Client) ConstraintCapabilities() ([]constraints.AttributeCapability, error) {
+	var result params.ConstraintCapabilitiesResult
+	if err := c.facade.FacadeCall("ConstraintCapabilities", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	capabilities := make([]constraints.AttributeCapability, len(result.Capabilities))
+	for i, r := range result.Capabilities {
+		capabilities[i] = constraints.AttributeCapability{
+			Name:      r.Name,
+			Supported: r.Supported,
+			Strict:    r.Strict,
+			Conflicts: r.Conflicts,
+			Values:    r.Values,
+		}
+	}
+	return capabilities, nil
+}
+
+// Validator returns a constraints.Validator which validates constraints
+// as the model's provider would, without further calls to the
+// controller.
+func (c *Client) Validator() (constraints.Validator, error) {
+	capabilities, err := c.ConstraintCapabilities()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return constraints.NewValidatorFromCapabilities(capabilities), nil
+}
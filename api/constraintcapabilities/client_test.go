@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package constraintcapabilities_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/constraintcapabilities"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type ConstraintCapabilitiesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ConstraintCapabilitiesSuite{})
+
+func (s *ConstraintCapabilitiesSuite) apiCaller(c *gc.C) basetesting.APICallerFunc {
+	return basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ConstraintCapabilities")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ConstraintCapabilities")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ConstraintCapabilitiesResult)) = params.ConstraintCapabilitiesResult{
+				Capabilities: []params.ConstraintCapability{{
+					Name:      "arch",
+					Supported: true,
+					Values:    []string{"amd64"},
+				}, {
+					Name:      "tags",
+					Supported: false,
+				}, {
+					Name:   "image-id",
+					Strict: true,
+				}},
+			}
+			return nil
+		})
+}
+
+func (s *ConstraintCapabilitiesSuite) TestConstraintCapabilities(c *gc.C) {
+	client := constraintcapabilities.NewClient(s.apiCaller(c))
+	result, err := client.ConstraintCapabilities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []constraints.AttributeCapability{{
+		Name:      "arch",
+		Supported: true,
+		Values:    []string{"amd64"},
+	}, {
+		Name: "tags",
+	}, {
+		Name:   "image-id",
+		Strict: true,
+	}})
+}
+
+func (s *ConstraintCapabilitiesSuite) TestConstraintCapabilitiesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		})
+	client := constraintcapabilities.NewClient(apiCaller)
+	_, err := client.ConstraintCapabilities()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ConstraintCapabilitiesSuite) TestValidator(c *gc.C) {
+	client := constraintcapabilities.NewClient(s.apiCaller(c))
+	validator, err := client.Validator()
+	c.Assert(err, jc.ErrorIsNil)
+
+	unsupported, err := validator.Validate(constraints.MustParse("arch=amd64 tags=foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.DeepEquals, []string{"tags"})
+
+	_, err = validator.Validate(constraints.MustParse("arch=s390x"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: arch=s390x\nvalid values are: \\[amd64\\]")
+
+	_, err = validator.Validate(constraints.MustParse("image-id=ami-12345678"))
+	c.Assert(err, gc.ErrorMatches, `conflicting constraints: "image-id" is not supported by this cloud`)
+}
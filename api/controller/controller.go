@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/api/common/cloudspec"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/permission"
 )
@@ -279,16 +280,69 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// ValidateMigration runs the migration prechecks for the specified
+// model against the target controller without starting a migration,
+// and returns a report of anything that would prevent the migration
+// from succeeding.
+func (c *Client) ValidateMigration(spec MigrationSpec) (coremigration.ValidationReport, error) {
+	var report coremigration.ValidationReport
+	if c.BestAPIVersion() < 5 {
+		return report, errors.NotSupportedf("migration validation by this controller")
+	}
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	response := params.MigrationValidationResults{}
+	if err := c.facade.FacadeCall("ValidateMigration", args, &response); err != nil {
+		return report, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return report, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return report, errors.Trace(result.Error)
+	}
+	for _, blocker := range result.Blockers {
+		report.Blockers = append(report.Blockers, coremigration.ValidationBlocker{
+			Kind:    coremigration.BlockerKind(blocker.Kind),
+			Message: blocker.Message,
+		})
+	}
+	report.Warnings = result.Warnings
+	return report, nil
+}
+
+func migrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -300,19 +354,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:     string(macsJSON),
 			},
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -325,3 +367,43 @@ func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
 	}
 	return string(out), nil
 }
+
+// AddConfigWebhook registers a webhook with the controller, which is
+// consulted before config changes of the webhook's kinds are committed
+// in any of its models.
+func (c *Client) AddConfigWebhook(webhook params.ConfigWebhook) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("config webhooks by this controller")
+	}
+	args := params.ConfigWebhooks{Webhooks: []params.ConfigWebhook{webhook}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddConfigWebhooks", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveConfigWebhook removes the named webhook from the controller.
+func (c *Client) RemoveConfigWebhook(name string) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("config webhooks by this controller")
+	}
+	args := params.ConfigWebhookNames{Names: []string{name}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveConfigWebhooks", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ConfigWebhooks returns the webhooks registered with the controller.
+func (c *Client) ConfigWebhooks() ([]params.ConfigWebhook, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("config webhooks by this controller")
+	}
+	var result params.ConfigWebhooks
+	if err := c.facade.FacadeCall("ConfigWebhooks", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Webhooks, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -170,6 +171,145 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestValidateMigration(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationValidationResults)
+			*out = params.MigrationValidationResults{
+				Results: []params.MigrationValidationResult{{
+					Blockers: []params.MigrationValidationBlocker{
+						{Kind: "space-mismatch", Message: "no space"},
+					},
+					Warnings: []string{"careful"},
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	report, err := client.ValidateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report, jc.DeepEquals, coremigration.ValidationReport{
+		Blockers: []coremigration.ValidationBlocker{
+			{Kind: coremigration.BlockerSpaceMismatch, Message: "no space"},
+		},
+		Warnings: []string{"careful"},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ValidateMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestValidateMigrationError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.MigrationValidationResults)
+			*out = params.MigrationValidationResults{
+				Results: []params.MigrationValidationResult{{
+					Error: common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.ValidateMigration(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestValidateMigrationAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 4}
+	client := controller.NewClient(apiCaller)
+	_, err := client.ValidateMigration(makeSpec())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestAddConfigWebhook(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: common.ServerError(errors.AlreadyExistsf("config webhook %q", "policy"))}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	webhook := params.ConfigWebhook{
+		Name:  "policy",
+		URL:   "https://policy.example.com",
+		Kinds: []string{"model-config"},
+	}
+	err := client.AddConfigWebhook(webhook)
+	c.Assert(err, gc.ErrorMatches, `config webhook "policy" already exists`)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.AddConfigWebhooks", []interface{}{params.ConfigWebhooks{
+			Webhooks: []params.ConfigWebhook{webhook},
+		}}},
+	})
+}
+
+func (s *Suite) TestRemoveConfigWebhook(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.RemoveConfigWebhook("policy")
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.RemoveConfigWebhooks", []interface{}{params.ConfigWebhookNames{
+			Names: []string{"policy"},
+		}}},
+	})
+}
+
+func (s *Suite) TestConfigWebhooks(c *gc.C) {
+	webhooks := []params.ConfigWebhook{{
+		Name:  "policy",
+		URL:   "https://policy.example.com",
+		Kinds: []string{"model-config"},
+	}}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "ConfigWebhooks")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.ConfigWebhooks)) = params.ConfigWebhooks{Webhooks: webhooks}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	result, err := client.ConfigWebhooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, webhooks)
+}
+
+func (s *Suite) TestConfigWebhooksAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 5}
+	client := controller.NewClient(apiCaller)
+	err := client.AddConfigWebhook(params.ConfigWebhook{Name: "policy"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.RemoveConfigWebhook("policy")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.ConfigWebhooks()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
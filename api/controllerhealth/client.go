@@ -0,0 +1,37 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerhealth provides a client for the ControllerHealth
+// facade, which reports on the health of the controller machines.
+package controllerhealth
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the controllerhealth API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the controllerhealth
+// api. It must be connected to the controller.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ControllerHealth")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Health returns the health of each controller machine's mongo replica
+// set member and API server, and of the lease store shared by the
+// controllers.
+func (c *Client) Health() (params.ControllerHealthResult, error) {
+	var result params.ControllerHealthResult
+	if err := c.facade.FacadeCall("Health", nil, &result); err != nil {
+		return params.ControllerHealthResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
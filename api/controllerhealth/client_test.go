@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controllerhealth"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type ControllerHealthSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ControllerHealthSuite{})
+
+func (s *ControllerHealthSuite) TestHealth(c *gc.C) {
+	updated := time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC)
+	expected := params.ControllerHealthResult{
+		Machines: []params.ControllerMachineHealth{{
+			MachineTag: "machine-0",
+			ReplicaSet: &params.ReplicaSetMemberHealth{
+				Address: "10.0.0.1:37017",
+				State:   "PRIMARY",
+				Healthy: true,
+			},
+			APIServer: &params.APIServerLoad{
+				Connections: 10,
+				Updated:     updated,
+			},
+		}},
+		Leases: &params.LeaseStoreHealth{
+			GlobalTime: updated,
+			Leases:     5,
+		},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ControllerHealth")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Health")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ControllerHealthResult)) = expected
+			return nil
+		})
+	client := controllerhealth.NewClient(apiCaller)
+	health, err := client.Health()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health, jc.DeepEquals, expected)
+}
+
+func (s *ControllerHealthSuite) TestHealthError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		})
+	client := controllerhealth.NewClient(apiCaller)
+	_, err := client.Health()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
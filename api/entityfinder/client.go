@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package entityfinder provides a client for the EntityFinder
+// facade, which resolves partial entity names to tags.
+package entityfinder
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the entity finder API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the entity finder api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "EntityFinder")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Match holds an entity matched by FindEntities.
+type Match struct {
+	// Tag is the tag of the matched entity.
+	Tag names.Tag
+
+	// Score ranks the match; higher scores are better matches.
+	Score int
+}
+
+// FindEntities returns the entities in the model that best match the
+// given partial name, best match first. The query may be an
+// application name prefix, a machine id, instance id or host name, or
+// a unit name, which may contain glob wildcards. If any kinds are specified,
+// only entities with those tag kinds are matched. If limit is zero, a
+// default limit is used.
+func (c *Client) FindEntities(query string, limit int, kinds ...string) ([]Match, error) {
+	args := params.FindEntitiesArgs{
+		Queries: []params.FindEntitiesQuery{{
+			Query: query,
+			Kinds: kinds,
+			Limit: limit,
+		}},
+	}
+	var results params.FindEntitiesResults
+	if err := c.facade.FacadeCall("FindEntities", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	matches := make([]Match, len(results.Results[0].Matches))
+	for i, m := range results.Results[0].Matches {
+		tag, err := names.ParseTag(m.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		matches[i] = Match{Tag: tag, Score: m.Score}
+	}
+	return matches, nil
+}
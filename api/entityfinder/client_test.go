@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package entityfinder_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/entityfinder"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type EntityFinderSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&EntityFinderSuite{})
+
+func (s *EntityFinderSuite) TestFindEntities(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "EntityFinder")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "FindEntities")
+			c.Check(a, jc.DeepEquals, params.FindEntitiesArgs{
+				Queries: []params.FindEntitiesQuery{{
+					Query: "mysq",
+					Kinds: []string{"application", "unit"},
+					Limit: 5,
+				}},
+			})
+			*(result.(*params.FindEntitiesResults)) = params.FindEntitiesResults{
+				Results: []params.FindEntitiesResult{{
+					Matches: []params.EntityMatch{
+						{Tag: "application-mysql", Score: 80},
+						{Tag: "unit-mysql-0", Score: 80},
+					},
+				}},
+			}
+			return nil
+		})
+	client := entityfinder.NewClient(apiCaller)
+	matches, err := client.FindEntities("mysq", 5, "application", "unit")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(matches, jc.DeepEquals, []entityfinder.Match{
+		{Tag: names.NewApplicationTag("mysql"), Score: 80},
+		{Tag: names.NewUnitTag("mysql/0"), Score: 80},
+	})
+}
+
+func (s *EntityFinderSuite) TestFindEntitiesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.FindEntitiesResults)) = params.FindEntitiesResults{
+				Results: []params.FindEntitiesResult{{
+					Error: common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		})
+	client := entityfinder.NewClient(apiCaller)
+	_, err := client.FindEntities("mysq", 0)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
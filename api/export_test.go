@@ -45,13 +45,14 @@ type RPCConnection rpcConnection
 // SetServerAddress allows changing the URL to the internal API server
 // that AddLocalCharm uses in order to test NotImplementedError.
 func SetServerAddress(c *Client, scheme, addr string) {
-	c.st.serverScheme = scheme
-	c.st.addr = addr
+	st := c.st.(*state)
+	st.serverScheme = scheme
+	st.addr = addr
 }
 
 // ServerRoot is exported so that we can test the built URL.
 func ServerRoot(c *Client) string {
-	return c.st.serverRoot()
+	return c.st.(*state).serverRoot()
 }
 
 // UnderlyingConn returns the underlying transport connection.
@@ -11,35 +11,45 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"Action":                       6,
 	"ActionPruner":                 1,
+	"ActionScheduler":              2,
 	"Agent":                        2,
+	"AgentIntegrity":               1,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  5,
-	"ApplicationOffers":            1,
+	"Application":                  12,
+	"ApplicationLeadership":        1,
+	"ApplicationOffers":            3,
 	"ApplicationScaler":            1,
-	"Backups":                      1,
+	"AuditLog":                     1,
+	"Backups":                      4,
 	"Block":                        2,
-	"Bundle":                       1,
+	"Bundle":                       3,
 	"CharmRevisionUpdater":         2,
+	"CharmUpgrader":                1,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       1,
+	"Client":                       2,
 	"Cloud":                        2,
-	"Controller":                   4,
+	"ConstraintCapabilities":       1,
+	"Controller":                   6,
+	"ControllerHealth":             1,
 	"CrossController":              1,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
 	"DiskManager":                  2,
+	"EntityFinder":                 1,
 	"EntityWatcher":                2,
 	"ExternalControllerUpdater":    1,
 	"FanConfigurer":                1,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   4,
-	"FirewallRules":                1,
+	"Firewaller":                   5,
+	"FirewallRules":                2,
+	"HealthChecker":                1,
+	"HealthChecks":                 1,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
@@ -51,9 +61,9 @@ var facadeVersions = map[string]int{
 	"LeadershipService":            2,
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
-	"Logger":                       1,
+	"Logger":                       2,
 	"MachineActions":               1,
-	"MachineManager":               4,
+	"MachineManager":               6,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -64,39 +74,47 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
-	"ModelConfig":                  1,
-	"ModelManager":                 4,
+	"MigrationTarget":              2,
+	"ModelCheckpoint":              1,
+	"ModelConfig":                  2,
+	"ModelManager":                 5,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
+	"OfferConnectionPruner":        1,
 	"OfferStatusWatcher":           1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
-	"Provisioner":                  5,
+	"Provisioner":                  6,
 	"ProxyUpdater":                 1,
+	"PruneStats":                   1,
 	"Reboot":                       2,
+	"RegionLatency":                1,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
 	"RemoteRelations":              1,
+	"RemovalPlan":                  1,
 	"Resources":                    1,
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
 	"Singular":                     2,
-	"Spaces":                       3,
-	"SSHClient":                    2,
+	"SpaceDiscovery":               1,
+	"Spaces":                       4,
+	"SSHClient":                    3,
 	"StatusHistory":                2,
-	"Storage":                      4,
-	"StorageProvisioner":           4,
+	"StatusHistoryQuery":           1,
+	"Storage":                      8,
+	"StorageProvisioner":           5,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       7,
-	"Upgrader":                     1,
+	"Uniter":                       9,
+	"Upgrader":                     2,
 	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
+	"WaitFor":                      1,
 }
 
 // bestVersion tries to find the newest version in the version list that we can
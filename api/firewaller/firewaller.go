@@ -255,3 +255,48 @@ func (c *Client) FirewallRules(knownServices ...string) ([]params.FirewallRule,
 	}
 	return results.Rules, nil
 }
+
+// WatchEgressRules returns a StringsWatcher that notifies of changes to
+// the egress rules of the model's applications. The changes are the
+// names of the applications whose rules have changed.
+func (c *Client) WatchEgressRules() (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("egress rules on this version of Juju")
+	}
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchEgressRules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// EgressRule returns the CIDRs the outbound traffic of the specified
+// application is restricted to. It returns an error satisfying
+// errors.IsNotFound if the application's traffic is unrestricted.
+func (c *Client) EgressRule(tag names.ApplicationTag) ([]string, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("egress rules on this version of Juju")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	var results params.EgressRulesResults
+	if err := c.facade.FacadeCall("EgressRules", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			return nil, errors.NewNotFound(result.Error, "")
+		}
+		return nil, result.Error
+	}
+	return result.AllowedCIDRs, nil
+}
@@ -4,6 +4,7 @@
 package firewaller_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -246,3 +247,64 @@ func (s *firewallerSuite) TestFirewallRules(c *gc.C) {
 	c.Assert(result, gc.HasLen, 1)
 	c.Check(callCount, gc.Equals, 1)
 }
+
+func (s *firewallerSuite) TestEgressRule(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Firewaller")
+			c.Check(request, gc.Equals, "EgressRules")
+			c.Assert(arg, gc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-mysql"}},
+			})
+			*(result.(*params.EgressRulesResults)) = params.EgressRulesResults{
+				Results: []params.EgressRulesResult{{
+					AllowedCIDRs: []string{"10.0.0.0/8"},
+				}},
+			}
+			callCount++
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	cidrs, err := client.EgressRule(names.NewApplicationTag("mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8"})
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *firewallerSuite) TestEgressRuleNotFound(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.EgressRulesResults)) = params.EgressRulesResults{
+				Results: []params.EgressRulesResult{{
+					Error: &params.Error{Code: params.CodeNotFound, Message: "egress rule for application mysql not found"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.EgressRule(names.NewApplicationTag("mysql"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *firewallerSuite) TestEgressRuleNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client, err := firewaller.NewClient(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.EgressRule(names.NewApplicationTag("mysql"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.WatchEgressRules()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
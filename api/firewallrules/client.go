@@ -5,6 +5,7 @@ package firewallrules
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -52,3 +53,57 @@ func (c *Client) ListFirewallRules() ([]params.FirewallRule, error) {
 	}
 	return results.Rules, nil
 }
+
+// SetEgressRule creates or updates the egress rule for an application,
+// restricting the outbound traffic of the machines hosting its units
+// to the given CIDRs.
+func (c *Client) SetEgressRule(application string, allowedCidrs []string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("egress rules on this version of Juju")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.EgressRuleArgs{
+		Args: []params.EgressRule{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			AllowedCIDRs:   allowedCidrs,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetEgressRules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveEgressRule removes the egress rule for an application,
+// lifting any restriction on its outbound traffic.
+func (c *Client) RemoveEgressRule(application string) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("egress rules on this version of Juju")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveEgressRules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListEgressRules returns all the egress rules.
+func (c *Client) ListEgressRules() ([]params.EgressRule, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("egress rules on this version of Juju")
+	}
+	var results params.ListEgressRulesResults
+	if err := c.facade.FacadeCall("ListEgressRules", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Rules, nil
+}
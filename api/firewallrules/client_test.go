@@ -141,3 +141,86 @@ func (s *FirewallRulesSuite) TestListError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, "fail")
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *FirewallRulesSuite) TestSetEgressRule(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(request, gc.Equals, "SetEgressRules")
+			c.Check(a, jc.DeepEquals, params.EgressRuleArgs{
+				Args: []params.EgressRule{{
+					ApplicationTag: "application-mysql",
+					AllowedCIDRs:   []string{"10.0.0.0/8"},
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := firewallrules.NewClient(apiCaller)
+	err := client.SetEgressRule("mysql", []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FirewallRulesSuite) TestSetEgressRuleNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := firewallrules.NewClient(apiCaller)
+	err := client.SetEgressRule("mysql", []string{"10.0.0.0/8"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *FirewallRulesSuite) TestRemoveEgressRule(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(request, gc.Equals, "RemoveEgressRules")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-mysql"}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: common.ServerError(errors.New("fail")),
+				}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := firewallrules.NewClient(apiCaller)
+	err := client.RemoveEgressRule("mysql")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func (s *FirewallRulesSuite) TestListEgressRules(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(request, gc.Equals, "ListEgressRules")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ListEgressRulesResults)) = params.ListEgressRulesResults{
+				Rules: []params.EgressRule{{
+					ApplicationTag: "application-mysql",
+					AllowedCIDRs:   []string{"10.0.0.0/8"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := firewallrules.NewClient(apiCaller)
+	rules, err := client.ListEgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []params.EgressRule{{
+		ApplicationTag: "application-mysql",
+		AllowedCIDRs:   []string{"10.0.0.0/8"},
+	}})
+}
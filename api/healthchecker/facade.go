@@ -0,0 +1,40 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthchecker implements the client-side API facade used
+// by the healthchecker worker.
+package healthchecker
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Facade provides access to the HealthChecker API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side HealthChecker facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "HealthChecker"),
+	}
+}
+
+// SetHealthCheckResults records the results of running the health
+// checks declared by the unit's charm.
+func (f *Facade) SetHealthCheckResults(unitName string, results []params.HealthCheckResult) error {
+	args := params.SetHealthCheckResults{Units: []params.UnitHealthCheckResults{{
+		Tag:     names.NewUnitTag(unitName).String(),
+		Results: results,
+	}}}
+	var result params.ErrorResults
+	err := f.caller.FacadeCall("SetHealthCheckResults", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthchecker_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/healthchecker"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+func (s *facadeSuite) TestSetHealthCheckResults(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "HealthChecker")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				(*params.Error)(nil),
+			}},
+		}
+		return nil
+	})
+	facade := healthchecker.NewFacade(apiCaller)
+
+	now := time.Now()
+	results := []params.HealthCheckResult{{Check: "http", Healthy: true, Time: now}}
+	err := facade.SetHealthCheckResults("mysql/0", results)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCalls(c, []testing.StubCall{{
+		"SetHealthCheckResults", []interface{}{params.SetHealthCheckResults{
+			Units: []params.UnitHealthCheckResults{{
+				Tag:     "unit-mysql-0",
+				Results: results,
+			}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("blam")
+	})
+	facade := healthchecker.NewFacade(apiCaller)
+
+	err := facade.SetHealthCheckResults("mysql/0", nil)
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestInnerError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				&params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := healthchecker.NewFacade(apiCaller)
+
+	err := facade.SetHealthCheckResults("mysql/0", nil)
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
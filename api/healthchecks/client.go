@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthchecks provides access to the HealthChecks facade,
+// which reports the results of the health checks declared by units'
+// charms.
+package healthchecks
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the HealthChecks API facade.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Client backed by the supplied api caller.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "HealthChecks")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// History returns up to limit of the unit's most recent health check
+// results, newest first. All the recorded results are returned if
+// limit is not positive.
+func (c *Client) History(unitName string, limit int) ([]params.HealthCheckResult, error) {
+	if !names.IsValidUnit(unitName) {
+		return nil, errors.NotValidf("unit name %q", unitName)
+	}
+	args := params.HealthCheckHistoryArgs{
+		Units: []params.HealthCheckHistoryArg{{
+			Tag:   names.NewUnitTag(unitName).String(),
+			Limit: limit,
+		}},
+	}
+	var results params.HealthCheckHistoryResults
+	if err := c.facade.FacadeCall("HealthCheckHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Results, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthchecks_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/healthchecks"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestHistory(c *gc.C) {
+	now := time.Now()
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "HealthChecks")
+		c.Check(request, gc.Equals, "HealthCheckHistory")
+		c.Check(arg, jc.DeepEquals, params.HealthCheckHistoryArgs{
+			Units: []params.HealthCheckHistoryArg{{Tag: "unit-mysql-0", Limit: 5}},
+		})
+		*(result.(*params.HealthCheckHistoryResults)) = params.HealthCheckHistoryResults{
+			Results: []params.HealthCheckHistoryResult{{
+				Results: []params.HealthCheckResult{{Check: "tcp", Healthy: true, Time: now}},
+			}},
+		}
+		return nil
+	})
+	history, err := healthchecks.NewClient(apiCaller).History("mysql/0", 5)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []params.HealthCheckResult{{Check: "tcp", Healthy: true, Time: now}})
+}
+
+func (s *ClientSuite) TestHistoryError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.HealthCheckHistoryResults)) = params.HealthCheckHistoryResults{
+			Results: []params.HealthCheckHistoryResult{{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `unit "mysql/0" not found`,
+			}}},
+		}
+		return nil
+	})
+	_, err := healthchecks.NewClient(apiCaller).History("mysql/0", 0)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *ClientSuite) TestHistoryInvalidUnit(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := healthchecks.NewClient(apiCaller).History("mysql", 0)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
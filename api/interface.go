@@ -175,6 +175,29 @@ type DialOpts struct {
 	// Clock is used as a time source for retries.
 	// If it is nil, clock.WallClock will be used.
 	Clock clock.Clock
+
+	// PingPeriod is how often the connection is pinged to check
+	// that it is still alive. If it is zero, api.PingPeriod is
+	// used.
+	PingPeriod time.Duration
+
+	// PingTimeout is how long a ping may take before the
+	// connection is considered broken. If it is zero, a
+	// default of 30 seconds is used.
+	PingTimeout time.Duration
+
+	// APICallObserver, if not nil, is notified of every API call
+	// made on the connection, including those made to log in.
+	APICallObserver base.APICallObserver
+
+	// APICallInterceptors are called at each stage of every API
+	// call made on the connection.
+	APICallInterceptors []base.APICallInterceptor
+
+	// CorrelationId, if not empty, is sent with every API call
+	// made on the connection, and recorded by the controller in
+	// its audit log.
+	CorrelationId string
 }
 
 // IPAddrResolver implements a resolved from host name to the
@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// Reader provides access to the ApplicationLeadership facade, which
+// reports the leaders of a model's applications to clients with read
+// access to the model.
+type Reader struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewReader returns a new Reader backed by the supplied api caller.
+func NewReader(st base.APICallCloser) *Reader {
+	frontend, backend := base.NewClientFacade(st, "ApplicationLeadership")
+	return &Reader{ClientFacade: frontend, facade: backend}
+}
+
+// Leader returns the name of the unit leading the application. An
+// error satisfying params.IsCodeNotFound is returned if the
+// application has no leader.
+func (r *Reader) Leader(application string) (string, error) {
+	if !names.IsValidApplication(application) {
+		return "", errors.NotValidf("application name %q", application)
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	err := r.facade.FacadeCall("Leaders", args, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// WatchLeader returns a NotifyWatcher that notifies when the
+// application's leader changes.
+func (r *Reader) WatchLeader(application string) (watcher.NotifyWatcher, error) {
+	if !names.IsValidApplication(application) {
+		return nil, errors.NotValidf("application name %q", application)
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	err := r.facade.FacadeCall("WatchLeaders", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(r.facade.RawAPICaller(), result)
+	return w, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/leadership"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ReaderSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReaderSuite{})
+
+func (s *ReaderSuite) TestLeader(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ApplicationLeadership")
+		c.Check(request, gc.Equals, "Leaders")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Result: "mysql/1"}},
+		}
+		return nil
+	})
+	leader, err := leadership.NewReader(apiCaller).Leader("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(leader, gc.Equals, "mysql/1")
+}
+
+func (s *ReaderSuite) TestLeaderNotFound(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.StringResults)) = params.StringResults{
+			Results: []params.StringResult{{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `leader of application "mysql" not found`,
+			}}},
+		}
+		return nil
+	})
+	_, err := leadership.NewReader(apiCaller).Leader("mysql")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *ReaderSuite) TestLeaderInvalidApplication(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := leadership.NewReader(apiCaller).Leader("mysql/0")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ReaderSuite) TestWatchLeaderError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ApplicationLeadership")
+		c.Check(request, gc.Equals, "WatchLeaders")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	_, err := leadership.NewReader(apiCaller).WatchLeader("mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
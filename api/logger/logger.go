@@ -72,3 +72,28 @@ func (st *State) WatchLoggingConfig(agentTag names.Tag) (watcher.NotifyWatcher,
 	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// LoggingOutputFormat returns the format, "text" or "json", in which
+// the agent specified by agentTag should write its logs. Controllers
+// that predate structured logging always report "text".
+func (st *State) LoggingOutputFormat(agentTag names.Tag) (string, error) {
+	if st.facade.BestAPIVersion() < 2 {
+		return "text", nil
+	}
+	var results params.StringResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: agentTag.String()}},
+	}
+	err := st.facade.FacadeCall("LoggingOutputFormat", args, &results)
+	if err != nil {
+		return "", err
+	}
+	if len(results.Results) != 1 {
+		return "", fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if err := result.Error; err != nil {
+		return "", err
+	}
+	return result.Result, nil
+}
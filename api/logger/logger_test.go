@@ -77,3 +77,21 @@ func (s *loggerSuite) TestWatchLoggingConfig(c *gc.C) {
 	s.setLoggingConfig(c, loggingConfig)
 	wc.AssertOneChange()
 }
+
+func (s *loggerSuite) TestLoggingOutputFormat(c *gc.C) {
+	format, err := s.logger.LoggingOutputFormat(s.rawMachine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(format, gc.Equals, "text")
+
+	err = s.IAASModel.UpdateModelConfig(map[string]interface{}{"logging-output-format": "json"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	format, err = s.logger.LoggingOutputFormat(s.rawMachine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(format, gc.Equals, "json")
+}
+
+func (s *loggerSuite) TestLoggingOutputFormatWrongMachine(c *gc.C) {
+	format, err := s.logger.LoggingOutputFormat(names.NewMachineTag("42"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(format, gc.Equals, "")
+}
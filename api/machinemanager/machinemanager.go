@@ -135,3 +135,80 @@ func (client *Client) UpdateMachineSeries(machineName, series string, force bool
 	}
 	return results.OneError()
 }
+
+// RebuildMachine reprovisions the instance of the given machine with
+// a fresh image of the given series, or of its current series if the
+// series is empty, retaining the machine's attached volumes.
+func (client *Client) RebuildMachine(machineName, series string, force bool) error {
+	if client.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("RebuildMachine on this controller")
+	}
+	args := params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: names.NewMachineTag(machineName).String()},
+			Series: series,
+			Force:  force,
+		}},
+	}
+
+	results := new(params.ErrorResults)
+	err := client.facade.FacadeCall("RebuildMachine", args, results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// StartMachines starts the previously stopped instances of the given
+// machines.
+func (client *Client) StartMachines(machines ...string) ([]params.ErrorResult, error) {
+	return client.powerMachines("StartMachines", machines)
+}
+
+// StopMachines stops the instances of the given machines without
+// removing the machines from the model, so that they may be started
+// again later with StartMachines.
+func (client *Client) StopMachines(machines ...string) ([]params.ErrorResult, error) {
+	return client.powerMachines("StopMachines", machines)
+}
+
+// RebootMachines reboots the instances of the given machines.
+func (client *Client) RebootMachines(machines ...string) ([]params.ErrorResult, error) {
+	return client.powerMachines("RebootMachines", machines)
+}
+
+func (client *Client) powerMachines(method string, machines []string) ([]params.ErrorResult, error) {
+	if client.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("%s on this controller", method)
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, 0, len(machines)),
+	}
+	allResults := make([]params.ErrorResult, len(machines))
+	index := make([]int, 0, len(machines))
+	for i, machineId := range machines {
+		if !names.IsValidMachine(machineId) {
+			allResults[i].Error = &params.Error{
+				Message: errors.NotValidf("machine ID %q", machineId).Error(),
+			}
+			continue
+		}
+		index = append(index, i)
+		args.Entities = append(args.Entities, params.Entity{
+			Tag: names.NewMachineTag(machineId).String(),
+		})
+	}
+	if len(args.Entities) > 0 {
+		var result params.ErrorResults
+		if err := client.facade.FacadeCall(method, args, &result); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n := len(result.Results); n != len(args.Entities) {
+			return nil, errors.Errorf("expected %d result(s), got %d", len(args.Entities), n)
+		}
+		for i, result := range result.Results {
+			allResults[index[i]] = result
+		}
+	}
+	return allResults, nil
+}
@@ -210,3 +210,96 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *MachinemanagerSuite) TestStartMachines(c *gc.C) {
+	s.testPowerMachines(c, "StartMachines", (*machinemanager.Client).StartMachines)
+}
+
+func (s *MachinemanagerSuite) TestStopMachines(c *gc.C) {
+	s.testPowerMachines(c, "StopMachines", (*machinemanager.Client).StopMachines)
+}
+
+func (s *MachinemanagerSuite) TestRebootMachines(c *gc.C) {
+	s.testPowerMachines(c, "RebootMachines", (*machinemanager.Client).RebootMachines)
+}
+
+func (s *MachinemanagerSuite) testPowerMachines(
+	c *gc.C,
+	methodName string,
+	method func(*machinemanager.Client, ...string) ([]params.ErrorResult, error),
+) {
+	expectedResults := []params.ErrorResult{{
+		Error: &params.Error{Message: `machine ID "!" not valid`},
+	}, {
+		Error: &params.Error{Message: "boo"},
+	}, {}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(objType, gc.Equals, "MachineManager")
+			c.Assert(request, gc.Equals, methodName)
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{
+					{Tag: "machine-0"},
+					{Tag: "machine-1"},
+				},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: expectedResults[1:]}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	results, err := method(client, "!", "0", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *MachinemanagerSuite) TestStopMachinesNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.StopMachines("0")
+	c.Assert(err, gc.ErrorMatches, "StopMachines on this controller not supported")
+}
+
+func (s *MachinemanagerSuite) TestRebuildMachine(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(objType, gc.Equals, "MachineManager")
+			c.Assert(request, gc.Equals, "RebuildMachine")
+			c.Assert(a, jc.DeepEquals, params.UpdateSeriesArgs{
+				Args: []params.UpdateSeriesArg{{
+					Entity: params.Entity{Tag: "machine-0"},
+					Series: "xenial",
+					Force:  true,
+				}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "boo"},
+			}}}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	err := client.RebuildMachine("0", "xenial", true)
+	c.Assert(err, gc.ErrorMatches, "boo")
+}
+
+func (s *MachinemanagerSuite) TestRebuildMachineNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := machinemanager.NewClient(apiCaller)
+	err := client.RebuildMachine("0", "", false)
+	c.Assert(err, gc.ErrorMatches, "RebuildMachine on this controller not supported")
+}
@@ -150,6 +150,22 @@ func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 	}, nil
 }
 
+// ModelRequirements returns what the model to be migrated needs of
+// the target controller.
+func (c *Client) ModelRequirements() (migration.ModelRequirements, error) {
+	var reqs params.MigrationModelRequirements
+	err := c.caller.FacadeCall("ModelRequirements", nil, &reqs)
+	if err != nil {
+		return migration.ModelRequirements{}, errors.Trace(err)
+	}
+	return migration.ModelRequirements{
+		Cloud:            reqs.Cloud,
+		Features:         reqs.Features,
+		StorageProviders: reqs.StorageProviders,
+		BinariesSize:     reqs.BinariesSize,
+	}, nil
+}
+
 // Prechecks verifies that the source controller and model are healthy
 // and able to participate in a migration.
 func (c *Client) Prechecks() error {
@@ -196,6 +196,32 @@ func (s *ClientSuite) TestModelInfo(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestModelRequirements(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, v int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationModelRequirements)) = params.MigrationModelRequirements{
+			Cloud:            "aws",
+			Features:         []string{"storage"},
+			StorageProviders: []string{"ebs"},
+			BinariesSize:     1024,
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	reqs, err := client.ModelRequirements()
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.ModelRequirements", []interface{}{"", nil}},
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(reqs, jc.DeepEquals, migration.ModelRequirements{
+		Cloud:            "aws",
+		Features:         []string{"storage"},
+		StorageProviders: []string{"ebs"},
+		BinariesSize:     1024,
+	})
+}
+
 func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	var stub jujutesting.Stub
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
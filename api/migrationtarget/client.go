@@ -54,6 +54,46 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
+// CheckCompatibility asks the target controller whether it can host
+// the described model, returning a report of the outcome of each
+// check made.
+func (c *Client) CheckCompatibility(
+	model coremigration.ModelInfo,
+	reqs coremigration.ModelRequirements,
+) (coremigration.CompatibilityReport, error) {
+	var report coremigration.CompatibilityReport
+	if c.caller.BestAPIVersion() < 2 {
+		return report, errors.NotSupportedf("CheckCompatibility on this controller")
+	}
+	args := params.MigrationCompatibilityArgs{
+		Model: params.MigrationModelInfo{
+			UUID:                   model.UUID,
+			Name:                   model.Name,
+			OwnerTag:               model.Owner.String(),
+			AgentVersion:           model.AgentVersion,
+			ControllerAgentVersion: model.ControllerAgentVersion,
+		},
+		Requirements: params.MigrationModelRequirements{
+			Cloud:            reqs.Cloud,
+			Features:         reqs.Features,
+			StorageProviders: reqs.StorageProviders,
+			BinariesSize:     reqs.BinariesSize,
+		},
+	}
+	var result params.MigrationCompatibilityReport
+	if err := c.caller.FacadeCall("CheckCompatibility", args, &result); err != nil {
+		return report, errors.Trace(err)
+	}
+	for _, check := range result.Checks {
+		report.Checks = append(report.Checks, coremigration.CompatibilityCheck{
+			Name:    check.Name,
+			Status:  coremigration.CompatibilityStatus(check.Status),
+			Message: check.Message,
+		})
+	}
+	return report, nil
+}
+
 // Import takes a serialized model and imports it into the target
 // controller.
 func (c *Client) Import(bytes []byte) error {
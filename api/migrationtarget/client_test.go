@@ -77,6 +77,70 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestCheckCompatibility(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			*(result.(*params.MigrationCompatibilityReport)) = params.MigrationCompatibilityReport{
+				Checks: []params.MigrationCompatibilityCheck{
+					{Name: "version", Status: "ok"},
+					{Name: "capacity", Status: "failed", Message: "full"},
+				},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	report, err := client.CheckCompatibility(coremigration.ModelInfo{
+		UUID:                   "uuid",
+		Owner:                  ownerTag,
+		Name:                   "name",
+		AgentVersion:           vers,
+		ControllerAgentVersion: vers,
+	}, coremigration.ModelRequirements{
+		Cloud:            "aws",
+		Features:         []string{"storage"},
+		StorageProviders: []string{"ebs"},
+		BinariesSize:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, coremigration.CompatibilityReport{
+		Checks: []coremigration.CompatibilityCheck{
+			{Name: "version", Status: coremigration.CompatibilityOK},
+			{Name: "capacity", Status: coremigration.CompatibilityFailed, Message: "full"},
+		},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.CheckCompatibility", []interface{}{"", params.MigrationCompatibilityArgs{
+			Model: params.MigrationModelInfo{
+				UUID:                   "uuid",
+				Name:                   "name",
+				OwnerTag:               ownerTag.String(),
+				AgentVersion:           vers,
+				ControllerAgentVersion: vers,
+			},
+			Requirements: params.MigrationModelRequirements{
+				Cloud:            "aws",
+				Features:         []string{"storage"},
+				StorageProviders: []string{"ebs"},
+				BinariesSize:     1024,
+			},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestCheckCompatibilityNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.CheckCompatibility(coremigration.ModelInfo{}, coremigration.ModelRequirements{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -0,0 +1,100 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelcheckpoint provides a client for the ModelCheckpoint
+// facade, which records named checkpoints of a model's applications,
+// charms, config and relations.
+package modelcheckpoint
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the model checkpoint API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the model checkpoint api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelCheckpoint")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+func checkpointArgs(name string) params.ModelCheckpointNames {
+	return params.ModelCheckpointNames{Names: []string{name}}
+}
+
+// Create records the applications, charm URLs, charm config and
+// relations currently in the model as a checkpoint with the given
+// name.
+func (c *Client) Create(name string) (params.ModelCheckpoint, error) {
+	var results params.ModelCheckpointResults
+	if err := c.facade.FacadeCall("CreateCheckpoints", checkpointArgs(name), &results); err != nil {
+		return params.ModelCheckpoint{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ModelCheckpoint{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.ModelCheckpoint{}, errors.Trace(err)
+	}
+	return *results.Results[0].Result, nil
+}
+
+// List returns all of the checkpoints in the model, oldest first.
+func (c *Client) List() ([]params.ModelCheckpoint, error) {
+	var results params.ModelCheckpointResults
+	if err := c.facade.FacadeCall("ListCheckpoints", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	checkpoints := make([]params.ModelCheckpoint, len(results.Results))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Trace(result.Error)
+		}
+		checkpoints[i] = *result.Result
+	}
+	return checkpoints, nil
+}
+
+// Diff returns how the model has changed since the checkpoint with
+// the given name was created.
+func (c *Client) Diff(name string) (params.ModelCheckpointDiff, error) {
+	var results params.ModelCheckpointDiffResults
+	if err := c.facade.FacadeCall("DiffCheckpoints", checkpointArgs(name), &results); err != nil {
+		return params.ModelCheckpointDiff{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ModelCheckpointDiff{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.ModelCheckpointDiff{}, errors.Trace(err)
+	}
+	return *results.Results[0].Result, nil
+}
+
+// RollbackConfig restores the charm config of the model's
+// applications to that recorded in the checkpoint with the given
+// name. It fails without changing anything if any application in the
+// checkpoint has since been removed or had its charm changed.
+func (c *Client) RollbackConfig(name string) error {
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RollbackConfig", checkpointArgs(name), &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// Remove removes the checkpoint with the given name.
+func (c *Client) Remove(name string) error {
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveCheckpoints", checkpointArgs(name), &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
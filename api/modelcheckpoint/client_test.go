@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcheckpoint_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelcheckpoint"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type ModelCheckpointSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ModelCheckpointSuite{})
+
+func (s *ModelCheckpointSuite) TestCreate(c *gc.C) {
+	created := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelCheckpoint")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CreateCheckpoints")
+			c.Check(a, jc.DeepEquals, params.ModelCheckpointNames{Names: []string{"one"}})
+			*(result.(*params.ModelCheckpointResults)) = params.ModelCheckpointResults{
+				Results: []params.ModelCheckpointResult{{
+					Result: &params.ModelCheckpoint{Name: "one", Created: created},
+				}},
+			}
+			return nil
+		})
+	client := modelcheckpoint.NewClient(apiCaller)
+	checkpoint, err := client.Create("one")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checkpoint, jc.DeepEquals, params.ModelCheckpoint{Name: "one", Created: created})
+}
+
+func (s *ModelCheckpointSuite) TestCreateError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.ModelCheckpointResults)) = params.ModelCheckpointResults{
+				Results: []params.ModelCheckpointResult{{
+					Error: common.ServerError(errors.AlreadyExistsf(`checkpoint "one"`)),
+				}},
+			}
+			return nil
+		})
+	client := modelcheckpoint.NewClient(apiCaller)
+	_, err := client.Create("one")
+	c.Assert(err, jc.Satisfies, params.IsCodeAlreadyExists)
+}
+
+func (s *ModelCheckpointSuite) TestList(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "ListCheckpoints")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ModelCheckpointResults)) = params.ModelCheckpointResults{
+				Results: []params.ModelCheckpointResult{
+					{Result: &params.ModelCheckpoint{Name: "one"}},
+					{Result: &params.ModelCheckpoint{Name: "two"}},
+				},
+			}
+			return nil
+		})
+	client := modelcheckpoint.NewClient(apiCaller)
+	checkpoints, err := client.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checkpoints, jc.DeepEquals, []params.ModelCheckpoint{
+		{Name: "one"},
+		{Name: "two"},
+	})
+}
+
+func (s *ModelCheckpointSuite) TestDiff(c *gc.C) {
+	diff := params.ModelCheckpointDiff{
+		AddedApplications: []string{"dummy"},
+		ChangedConfig: []params.CheckpointConfigChange{{
+			Application:     "wordpress",
+			Key:             "blog-title",
+			CheckpointValue: "mine",
+		}},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "DiffCheckpoints")
+			c.Check(a, jc.DeepEquals, params.ModelCheckpointNames{Names: []string{"one"}})
+			*(result.(*params.ModelCheckpointDiffResults)) = params.ModelCheckpointDiffResults{
+				Results: []params.ModelCheckpointDiffResult{{Result: &diff}},
+			}
+			return nil
+		})
+	client := modelcheckpoint.NewClient(apiCaller)
+	result, err := client.Diff("one")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, diff)
+}
+
+func (s *ModelCheckpointSuite) TestRollbackConfig(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "RollbackConfig")
+			c.Check(a, jc.DeepEquals, params.ModelCheckpointNames{Names: []string{"one"}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: common.ServerError(errors.New("charms changed")),
+				}},
+			}
+			return nil
+		})
+	client := modelcheckpoint.NewClient(apiCaller)
+	err := client.RollbackConfig("one")
+	c.Assert(err, gc.ErrorMatches, "charms changed")
+}
+
+func (s *ModelCheckpointSuite) TestRemove(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "RemoveCheckpoints")
+			c.Check(a, jc.DeepEquals, params.ModelCheckpointNames{Names: []string{"one"}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		})
+	client := modelcheckpoint.NewClient(apiCaller)
+	err := client.Remove("one")
+	c.Assert(err, jc.ErrorIsNil)
+}
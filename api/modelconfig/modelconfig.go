@@ -7,8 +7,10 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
 )
 
 // Client provides methods that the Juju client command uses to interact
@@ -90,3 +92,42 @@ func (c *Client) SLALevel() (string, error) {
 	}
 	return result.Result, nil
 }
+
+// FanNetworking returns the FAN configuration of the model and the
+// method used to set up container networking.
+func (c *Client) FanNetworking() (network.FanConfig, string, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, "", errors.NotSupportedf("FanNetworking on this version of Juju")
+	}
+	var result params.FanNetworkingConfig
+	if err := c.facade.FacadeCall("FanNetworking", nil, &result); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	fans, err := networkingcommon.FanConfigResultToFanConfig(params.FanConfigResult{Fans: result.Fans})
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return fans, result.ContainerNetworkingMethod, nil
+}
+
+// SetFanNetworking sets the FAN configuration of the model and the
+// method used to set up container networking, returning the FAN overlay
+// segments each machine gets. If dryRun is true the configuration is
+// only validated and the model is left unchanged.
+func (c *Client) SetFanNetworking(fans network.FanConfig, method string, dryRun bool) ([]params.MachineFanOverlays, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("SetFanNetworking on this version of Juju")
+	}
+	args := params.SetFanNetworkingArgs{
+		Config: params.FanNetworkingConfig{
+			Fans:                      networkingcommon.FanConfigToFanConfigResult(fans).Fans,
+			ContainerNetworkingMethod: method,
+		},
+		DryRun: dryRun,
+	}
+	var result params.SetFanNetworkingResult
+	if err := c.facade.FacadeCall("SetFanNetworking", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Machines, nil
+}
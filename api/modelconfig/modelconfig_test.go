@@ -4,6 +4,7 @@
 package modelconfig_test
 
 import (
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -12,6 +13,7 @@ import (
 	"github.com/juju/juju/api/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
 )
 
 type modelconfigSuite struct {
@@ -180,3 +182,79 @@ func (s *modelconfigSuite) TestGetSupport(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(level, gc.Equals, "level")
 }
+
+func (s *modelconfigSuite) TestFanNetworking(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(request, gc.Equals, "FanNetworking")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.FanNetworkingConfig)) = params.FanNetworkingConfig{
+				Fans: []params.FanConfigEntry{{
+					Underlay: "10.0.0.0/16",
+					Overlay:  "252.0.0.0/8",
+				}},
+				ContainerNetworkingMethod: "fan",
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	fans, method, err := client.FanNetworking()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fans.String(), gc.Equals, "10.0.0.0/16=252.0.0.0/8")
+	c.Assert(method, gc.Equals, "fan")
+}
+
+func (s *modelconfigSuite) TestSetFanNetworking(c *gc.C) {
+	machines := []params.MachineFanOverlays{{
+		MachineTag: "machine-0",
+		Segments: []params.FanOverlaySegment{{
+			Subnet:  "10.0.5.0/24",
+			Overlay: "252.5.0.0/16",
+		}},
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelConfig")
+			c.Check(request, gc.Equals, "SetFanNetworking")
+			c.Check(a, jc.DeepEquals, params.SetFanNetworkingArgs{
+				Config: params.FanNetworkingConfig{
+					Fans: []params.FanConfigEntry{{
+						Underlay: "10.0.0.0/16",
+						Overlay:  "252.0.0.0/8",
+					}},
+					ContainerNetworkingMethod: "fan",
+				},
+				DryRun: true,
+			})
+			*(result.(*params.SetFanNetworkingResult)) = params.SetFanNetworkingResult{
+				Machines: machines,
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	fans, err := network.ParseFanConfig("10.0.0.0/16=252.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	client := modelconfig.NewClient(apiCaller)
+	result, err := client.SetFanNetworking(fans, "fan", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, machines)
+}
+
+func (s *modelconfigSuite) TestFanNetworkingNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 1,
+	}
+	client := modelconfig.NewClient(apiCaller)
+	_, _, err := client.FanNetworking()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.SetFanNetworking(nil, "local", false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
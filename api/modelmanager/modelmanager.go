@@ -372,6 +372,7 @@ func (c *Client) ModelDefaults() (config.ModelDefaultAttributes, error) {
 		setting := config.AttributeDefaultValues{
 			Default:    val.Default,
 			Controller: val.Controller,
+			Cloud:      val.Cloud,
 		}
 		for _, region := range val.Regions {
 			setting.Regions = append(setting.Regions, config.RegionDefaultValue{
@@ -384,10 +385,12 @@ func (c *Client) ModelDefaults() (config.ModelDefaultAttributes, error) {
 }
 
 // SetModelDefaults updates the specified default model config values.
+// If region is empty, the values are set for the whole cloud; if cloud
+// is also empty, they are set for the controller.
 func (c *Client) SetModelDefaults(cloud, region string, config map[string]interface{}) error {
-	var cloudTag string
-	if cloud != "" {
-		cloudTag = names.NewCloudTag(cloud).String()
+	cloudTag, err := c.modelDefaultsCloudTag(cloud, region)
+	if err != nil {
+		return errors.Trace(err)
 	}
 	args := params.SetModelDefaults{
 		Config: []params.ModelDefaultValues{{
@@ -397,7 +400,7 @@ func (c *Client) SetModelDefaults(cloud, region string, config map[string]interf
 		}},
 	}
 	var result params.ErrorResults
-	err := c.facade.FacadeCall("SetModelDefaults", args, &result)
+	err = c.facade.FacadeCall("SetModelDefaults", args, &result)
 	if err != nil {
 		return err
 	}
@@ -405,10 +408,12 @@ func (c *Client) SetModelDefaults(cloud, region string, config map[string]interf
 }
 
 // UnsetModelDefaults removes the specified default model config values.
+// If region is empty, the values are removed for the whole cloud; if
+// cloud is also empty, they are removed for the controller.
 func (c *Client) UnsetModelDefaults(cloud, region string, keys ...string) error {
-	var cloudTag string
-	if cloud != "" {
-		cloudTag = names.NewCloudTag(cloud).String()
+	cloudTag, err := c.modelDefaultsCloudTag(cloud, region)
+	if err != nil {
+		return errors.Trace(err)
 	}
 	args := params.UnsetModelDefaults{
 		Keys: []params.ModelUnsetKeys{{
@@ -418,9 +423,58 @@ func (c *Client) UnsetModelDefaults(cloud, region string, keys ...string) error
 		}},
 	}
 	var result params.ErrorResults
-	err := c.facade.FacadeCall("UnsetModelDefaults", args, &result)
+	err = c.facade.FacadeCall("UnsetModelDefaults", args, &result)
 	if err != nil {
 		return err
 	}
 	return result.OneError()
 }
+
+// modelDefaultsCloudTag returns the cloud tag to send when changing the
+// model defaults for the given cloud and region. Older controllers have
+// no cloud level defaults, and silently apply values for a cloud without
+// a region to the controller, so that is refused.
+func (c *Client) modelDefaultsCloudTag(cloud, region string) (string, error) {
+	if cloud == "" {
+		return "", nil
+	}
+	if region == "" && c.BestAPIVersion() < 5 {
+		return "", errors.NotSupportedf("cloud level model defaults on this controller")
+	}
+	return names.NewCloudTag(cloud).String(), nil
+}
+
+// EffectiveModelDefaults returns the default config values that a new
+// model in the given cloud region would be created with, along with the
+// level each value comes from. If region is empty, only the cloud,
+// controller and Juju defaults are considered.
+func (c *Client) EffectiveModelDefaults(cloud, region string) (config.ConfigValues, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("EffectiveModelDefaults on this controller")
+	}
+	args := params.CloudRegionSpecs{
+		Specs: []params.CloudRegionSpec{{
+			CloudTag:    names.NewCloudTag(cloud).String(),
+			CloudRegion: region,
+		}},
+	}
+	var results params.EffectiveModelDefaultsResults
+	if err := c.facade.FacadeCall("EffectiveModelDefaults", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	values := make(config.ConfigValues)
+	for name, val := range result.Config {
+		values[name] = config.ConfigValue{
+			Value:  val.Value,
+			Source: val.Source,
+		}
+	}
+	return values, nil
+}
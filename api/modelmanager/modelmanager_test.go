@@ -240,9 +240,13 @@ func (s *modelmanagerSuite) TestModelDefaults(c *gc.C) {
 			c.Assert(result, gc.FitsTypeOf, &params.ModelDefaultsResult{})
 			results := result.(*params.ModelDefaultsResult)
 			results.Config = map[string]params.ModelDefaults{
-				"foo": {"bar", "model", []params.RegionDefaults{{
-					"dummy-region",
-					"dummy-value"}}},
+				"foo": {
+					Default:    "bar",
+					Controller: "model",
+					Cloud:      "cloud",
+					Regions: []params.RegionDefaults{{
+						RegionName: "dummy-region",
+						Value:      "dummy-value"}}},
 			}
 			return nil
 		},
@@ -252,9 +256,13 @@ func (s *modelmanagerSuite) TestModelDefaults(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(result, jc.DeepEquals, config.ModelDefaultAttributes{
-		"foo": {"bar", "model", []config.RegionDefaultValue{{
-			"dummy-region",
-			"dummy-value"}}},
+		"foo": {
+			Default:    "bar",
+			Controller: "model",
+			Cloud:      "cloud",
+			Regions: []config.RegionDefaultValue{{
+				Name:  "dummy-region",
+				Value: "dummy-value"}}},
 	})
 }
 
@@ -326,6 +334,81 @@ func (s *modelmanagerSuite) TestUnsetModelDefaults(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *modelmanagerSuite) TestSetModelDefaultsCloud(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(request, gc.Equals, "SetModelDefaults")
+			c.Check(a, jc.DeepEquals, params.SetModelDefaults{
+				Config: []params.ModelDefaultValues{{
+					CloudTag: "cloud-mycloud",
+					Config:   map[string]interface{}{"some-name": "value"},
+				}}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: nil}},
+			}
+			called = true
+			return nil
+		},
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.SetModelDefaults("mycloud", "", map[string]interface{}{"some-name": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelmanagerSuite) TestSetModelDefaultsCloudNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.SetModelDefaults("mycloud", "", map[string]interface{}{"some-name": "value"})
+	c.Assert(err, gc.ErrorMatches, "cloud level model defaults on this controller not supported")
+	err = client.UnsetModelDefaults("mycloud", "", "some-name")
+	c.Assert(err, gc.ErrorMatches, "cloud level model defaults on this controller not supported")
+}
+
+func (s *modelmanagerSuite) TestEffectiveModelDefaults(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "EffectiveModelDefaults")
+			c.Check(a, jc.DeepEquals, params.CloudRegionSpecs{
+				Specs: []params.CloudRegionSpec{{
+					CloudTag:    "cloud-mycloud",
+					CloudRegion: "region",
+				}}})
+			c.Assert(result, gc.FitsTypeOf, &params.EffectiveModelDefaultsResults{})
+			*(result.(*params.EffectiveModelDefaultsResults)) = params.EffectiveModelDefaultsResults{
+				Results: []params.EffectiveModelDefaultsResult{{
+					Config: map[string]params.ConfigValue{
+						"foo": {Value: "bar", Source: "cloud"},
+					},
+				}},
+			}
+			return nil
+		},
+	}
+	client := modelmanager.NewClient(apiCaller)
+	values, err := client.EffectiveModelDefaults("mycloud", "region")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, config.ConfigValues{
+		"foo": {Value: "bar", Source: "cloud"},
+	})
+}
+
+func (s *modelmanagerSuite) TestEffectiveModelDefaultsNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	_, err := client.EffectiveModelDefaults("mycloud", "region")
+	c.Assert(err, gc.ErrorMatches, "EffectiveModelDefaults on this controller not supported")
+}
+
 func (s *modelmanagerSuite) TestModelStatus(c *gc.C) {
 	apiCaller := basetesting.BestVersionCaller{
 		BestVersion: 4,
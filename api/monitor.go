@@ -20,6 +20,10 @@ type monitor struct {
 	pingPeriod  time.Duration
 	pingTimeout time.Duration
 
+	// recordPing, if not nil, is called with the completion time and
+	// round trip time of each successful ping.
+	recordPing func(at time.Time, rtt time.Duration)
+
 	closed <-chan struct{}
 	dead   <-chan struct{}
 	broken chan<- struct{}
@@ -43,6 +47,7 @@ func (m *monitor) run() {
 }
 
 func (m *monitor) pingWithTimeout() bool {
+	start := m.clock.Now()
 	result := make(chan error, 1)
 	go func() {
 		// Note that result is buffered so that we don't leak this
@@ -53,8 +58,13 @@ func (m *monitor) pingWithTimeout() bool {
 	case err := <-result:
 		if err != nil {
 			logger.Debugf("health ping failed: %v", err)
+			return false
+		}
+		if m.recordPing != nil {
+			now := m.clock.Now()
+			m.recordPing(now, now.Sub(start))
 		}
-		return err == nil
+		return true
 	case <-m.clock.After(m.pingTimeout):
 		logger.Errorf("health ping timed out after %s", m.pingTimeout)
 		return false
@@ -96,6 +96,34 @@ func (s *MonitorSuite) TestPingsTimesOut(c *gc.C) {
 	assertEvent(c, s.broken)
 }
 
+func (s *MonitorSuite) TestPingRecorded(c *gc.C) {
+	type ping struct {
+		at  time.Time
+		rtt time.Duration
+	}
+	pings := make(chan ping, 1)
+	s.monitor.recordPing = func(at time.Time, rtt time.Duration) {
+		pings <- ping{at, rtt}
+	}
+	s.monitor.ping = func() error {
+		// Advance the clock only once this ping call is being waited on.
+		s.waitThenAdvance(c, 100*time.Millisecond)
+		return nil
+	}
+	go s.monitor.run()
+
+	s.waitThenAdvance(c, testPingPeriod)
+	select {
+	case p := <-pings:
+		c.Assert(p.at, gc.Equals, time.Time{}.Add(testPingPeriod+100*time.Millisecond))
+		c.Assert(p.rtt, gc.Equals, 100*time.Millisecond)
+	case <-time.After(jtesting.LongWait):
+		c.Fatal("timed out waiting for ping to be recorded")
+	}
+	close(s.closed)
+	assertEvent(c, s.broken)
+}
+
 func (s *MonitorSuite) waitForClock(c *gc.C) {
 	assertEvent(c, s.clock.Alarms())
 }
@@ -0,0 +1,31 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offerconnectionpruner
+
+import (
+	"time"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const apiName = "OfferConnectionPruner"
+
+// Facade allows calls to "OfferConnectionPruner" endpoints.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade returns a new "OfferConnectionPruner" Facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{facade: base.NewFacadeCaller(caller, apiName)}
+}
+
+// Prune calls "OfferConnectionPruner.Prune".
+func (f *Facade) Prune(maxStaleness time.Duration) error {
+	p := params.OfferConnectionPruneArgs{
+		MaxStaleness: maxStaleness,
+	}
+	return f.facade.FacadeCall("Prune", p, nil)
+}
@@ -204,6 +204,24 @@ func (m *Machine) MarkForRemoval() error {
 	return result.OneError()
 }
 
+// DrainUnits replaces the machine's principal units with new units
+// on other machines, and then destroys them. It is used when the
+// cloud is about to reclaim the machine's instance.
+func (m *Machine) DrainUnits() error {
+	if m.st.facade.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("DrainUnits on this controller")
+	}
+	var result params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("DrainUnits", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // Series returns the operating system series running on the machine.
 //
 // NOTE: Unlike state.Machine.Series(), this method returns an error
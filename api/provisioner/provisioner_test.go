@@ -240,6 +240,48 @@ func (s *provisionerSuite) TestMarkForRemoval(c *gc.C) {
 	c.Assert(removals, jc.SameContents, []string{"1"})
 }
 
+func (s *provisionerSuite) TestDrainUnits(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiMachine := s.assertGetOneMachine(c, machine.MachineTag())
+	err = apiMachine.DrainUnits()
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := machine.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, u := range units {
+		c.Check(u.Life(), gc.Not(gc.Equals), state.Alive)
+	}
+	units, err = wordpress.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.Not(gc.HasLen), 0)
+}
+
+func (s *provisionerSuite) TestDrainUnitsNotSupported(c *gc.C) {
+	apiCaller := apibasetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "Life")
+			*(result.(*params.LifeResults)) = params.LifeResults{
+				Results: []params.LifeResult{{Life: params.Alive}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	st := provisioner.NewState(apiCaller)
+	results, err := st.Machines(names.NewMachineTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	err = results[0].Machine.DrainUnits()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *provisionerSuite) TestRefreshAndLife(c *gc.C) {
 	// Create a fresh machine to test the complete scenario.
 	otherMachine, err := s.State.AddMachine("quantal", state.JobHostUnits)
@@ -0,0 +1,35 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package prunestats provides a client for the PruneStats facade,
+// which reports on the most recent pruning of a model's collections.
+package prunestats
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the prunestats API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the prunestats api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "PruneStats")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// PruneStats returns the outcome of the most recent pruning of each
+// of the model's collections.
+func (c *Client) PruneStats() ([]params.PruneStats, error) {
+	var result params.PruneStatsResult
+	if err := c.facade.FacadeCall("PruneStats", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Stats, nil
+}
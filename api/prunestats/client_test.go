@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package prunestats_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/prunestats"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type PruneStatsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&PruneStatsSuite{})
+
+func (s *PruneStatsSuite) TestPruneStats(c *gc.C) {
+	pruned := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "PruneStats")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "PruneStats")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.PruneStatsResult)) = params.PruneStatsResult{
+				Stats: []params.PruneStats{{
+					Collection:  "statuseshistory",
+					Time:        pruned,
+					Removed:     3000,
+					ReclaimedMB: 2,
+				}},
+			}
+			return nil
+		})
+	client := prunestats.NewClient(apiCaller)
+	stats, err := client.PruneStats()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats, jc.DeepEquals, []params.PruneStats{{
+		Collection:  "statuseshistory",
+		Time:        pruned,
+		Removed:     3000,
+		ReclaimedMB: 2,
+	}})
+}
+
+func (s *PruneStatsSuite) TestPruneStatsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		})
+	client := prunestats.NewClient(apiCaller)
+	_, err := client.PruneStats()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,44 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package regionlatency
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides methods for querying the latency between the
+// controller and the regions of its clouds.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new `Client` based on an existing authenticated API
+// connection.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "RegionLatency")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// RegionLatencies returns the latencies of the regions of the cloud
+// with the given tag, as measured from the controller, along with the
+// name of the nearest reachable region, if any.
+func (c *Client) RegionLatencies(tag names.CloudTag) (params.RegionLatencyResult, error) {
+	var results params.RegionLatencyResults
+	args := params.Entities{[]params.Entity{{tag.String()}}}
+	if err := c.facade.FacadeCall("RegionLatencies", args, &results); err != nil {
+		return params.RegionLatencyResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.RegionLatencyResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if results.Results[0].Error != nil {
+		return params.RegionLatencyResult{}, results.Results[0].Error
+	}
+	return results.Results[0], nil
+}
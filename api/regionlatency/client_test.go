@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package regionlatency_test
+
+import (
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/regionlatency"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type regionLatencySuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&regionLatencySuite{})
+
+func (s *regionLatencySuite) TestRegionLatencies(c *gc.C) {
+	probed := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "RegionLatency")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RegionLatencies")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "cloud-foo"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.RegionLatencyResults{})
+			results := result.(*params.RegionLatencyResults)
+			results.Results = []params.RegionLatencyResult{{
+				Regions: []params.RegionLatency{
+					{Region: "near", Latency: 20 * time.Millisecond},
+					{Region: "down", Error: &params.Error{Message: "connection refused"}},
+				},
+				Nearest:  "near",
+				ProbedAt: probed,
+			}}
+			return nil
+		},
+	)
+
+	client := regionlatency.NewClient(apiCaller)
+	result, err := client.RegionLatencies(names.NewCloudTag("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.RegionLatencyResult{
+		Regions: []params.RegionLatency{
+			{Region: "near", Latency: 20 * time.Millisecond},
+			{Region: "down", Error: &params.Error{Message: "connection refused"}},
+		},
+		Nearest:  "near",
+		ProbedAt: probed,
+	})
+}
+
+func (s *regionLatencySuite) TestRegionLatenciesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			results := result.(*params.RegionLatencyResults)
+			results.Results = []params.RegionLatencyResult{{
+				Error: &params.Error{Message: `cloud "foo" not found`, Code: params.CodeNotFound},
+			}}
+			return nil
+		},
+	)
+
+	client := regionlatency.NewClient(apiCaller)
+	_, err := client.RegionLatencies(names.NewCloudTag("foo"))
+	c.Assert(err, gc.ErrorMatches, `cloud "foo" not found`)
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package removalplan provides a client for the RemovalPlan facade,
+// which previews everything that would be removed along with a set
+// of applications, units and machines before removing them.
+package removalplan
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the removal plan API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the removal plan api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "RemovalPlan")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// PlanRemoval returns everything that would be removed, or affected,
+// by removing the given applications, units and machines. Nothing is
+// removed; pass the plan to ExecuteRemovalPlan once it is confirmed.
+func (c *Client) PlanRemoval(tags ...names.Tag) (params.RemovalPlan, error) {
+	args := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var result params.RemovalPlanResult
+	if err := c.facade.FacadeCall("PlanRemoval", args, &result); err != nil {
+		return params.RemovalPlan{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.RemovalPlan{}, errors.Trace(result.Error)
+	}
+	return *result.Result, nil
+}
+
+// ExecuteRemovalPlan removes the entities in a plan returned by
+// PlanRemoval. If the model has changed so that the plan no longer
+// describes what would be removed, an error is returned and nothing
+// is removed.
+func (c *Client) ExecuteRemovalPlan(plan params.RemovalPlan) error {
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("ExecuteRemovalPlan", plan, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package removalplan_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/removalplan"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type RemovalPlanSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&RemovalPlanSuite{})
+
+var testPlan = params.RemovalPlan{
+	Entities:     []params.Entity{{Tag: "application-wordpress"}},
+	Applications: []params.Entity{{Tag: "application-wordpress"}},
+	Units:        []params.Entity{{Tag: "unit-wordpress-0"}},
+	Machines:     []params.Entity{{Tag: "machine-0"}},
+	Offers:       []string{"wp"},
+}
+
+func (s *RemovalPlanSuite) TestPlanRemoval(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemovalPlan")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "PlanRemoval")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-wordpress"}},
+			})
+			plan := testPlan
+			*(result.(*params.RemovalPlanResult)) = params.RemovalPlanResult{Result: &plan}
+			return nil
+		})
+	client := removalplan.NewClient(apiCaller)
+	plan, err := client.PlanRemoval(names.NewApplicationTag("wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan, jc.DeepEquals, testPlan)
+}
+
+func (s *RemovalPlanSuite) TestPlanRemovalError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.RemovalPlanResult)) = params.RemovalPlanResult{
+				Error: common.ServerError(errors.New("machine 0 is required by the model")),
+			}
+			return nil
+		})
+	client := removalplan.NewClient(apiCaller)
+	_, err := client.PlanRemoval(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "machine 0 is required by the model")
+}
+
+func (s *RemovalPlanSuite) TestExecuteRemovalPlan(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "RemovalPlan")
+			c.Check(request, gc.Equals, "ExecuteRemovalPlan")
+			c.Check(a, jc.DeepEquals, testPlan)
+			*(result.(*params.ErrorResult)) = params.ErrorResult{}
+			return nil
+		})
+	client := removalplan.NewClient(apiCaller)
+	err := client.ExecuteRemovalPlan(testPlan)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RemovalPlanSuite) TestExecuteRemovalPlanError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.ErrorResult)) = params.ErrorResult{
+				Error: common.ServerError(errors.New("removal plan is out of date")),
+			}
+			return nil
+		})
+	client := removalplan.NewClient(apiCaller)
+	err := client.ExecuteRemovalPlan(testPlan)
+	c.Assert(err, gc.ErrorMatches, "removal plan is out of date")
+}
@@ -0,0 +1,234 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"net/url"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/charmrevisionupdater"
+	"github.com/juju/juju/api/cleaner"
+	"github.com/juju/juju/api/imagemetadata"
+	"github.com/juju/juju/api/instancepoller"
+	"github.com/juju/juju/api/reboot"
+	"github.com/juju/juju/api/unitassigner"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/api/upgrader"
+	"github.com/juju/juju/network"
+)
+
+// NewRetryingConnection returns a Connection whose API calls are made
+// through base.NewRetryingAPICaller: if the connection to the
+// controller is lost, calls are made on a new connection obtained
+// from dial, and interrupted calls are retried where the policy and
+// the controller allow.
+//
+// The returned connection's Broken channel is closed when it is closed,
+// or when a new connection cannot be dialled; the loss of a connection
+// that can be replaced does not break it. Other Connection methods
+// report on the most recently dialled connection.
+func NewRetryingConnection(dial func() (Connection, error), policy base.RetryPolicy) (Connection, error) {
+	rc := &retryingConnection{
+		broken: make(chan struct{}),
+	}
+	caller, err := base.NewRetryingAPICaller(func() (base.APICallCloser, error) {
+		conn, err := dial()
+		if err != nil {
+			rc.setBroken()
+			return nil, errors.Trace(err)
+		}
+		rc.mu.Lock()
+		defer rc.mu.Unlock()
+		rc.current = conn
+		return conn, nil
+	}, policy)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rc.APICallCloser = caller
+	return rc, nil
+}
+
+type retryingConnection struct {
+	// APICallCloser is the retrying caller through
+	// which all API calls are made.
+	base.APICallCloser
+
+	mu      sync.Mutex
+	current Connection
+
+	brokenOnce sync.Once
+	broken     chan struct{}
+}
+
+// conn returns the most recently dialled connection.
+func (rc *retryingConnection) conn() Connection {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.current
+}
+
+func (rc *retryingConnection) setBroken() {
+	rc.brokenOnce.Do(func() {
+		close(rc.broken)
+	})
+}
+
+// Close implements Connection.
+func (rc *retryingConnection) Close() error {
+	rc.setBroken()
+	return rc.APICallCloser.Close()
+}
+
+// ConnectionHealth implements base.ConnectionMonitor.
+func (rc *retryingConnection) ConnectionHealth() base.ConnectionHealth {
+	if monitor, ok := rc.APICallCloser.(base.ConnectionMonitor); ok {
+		return monitor.ConnectionHealth()
+	}
+	return base.ConnectionHealth{}
+}
+
+// Broken implements Connection.
+func (rc *retryingConnection) Broken() <-chan struct{} {
+	return rc.broken
+}
+
+// IsBroken implements Connection.
+func (rc *retryingConnection) IsBroken() bool {
+	select {
+	case <-rc.broken:
+		return true
+	default:
+		return false
+	}
+}
+
+// Addr implements Connection.
+func (rc *retryingConnection) Addr() string {
+	return rc.conn().Addr()
+}
+
+// IPAddr implements Connection.
+func (rc *retryingConnection) IPAddr() string {
+	return rc.conn().IPAddr()
+}
+
+// APIHostPorts implements Connection.
+func (rc *retryingConnection) APIHostPorts() [][]network.HostPort {
+	return rc.conn().APIHostPorts()
+}
+
+// PublicDNSName implements Connection.
+func (rc *retryingConnection) PublicDNSName() string {
+	return rc.conn().PublicDNSName()
+}
+
+// Login implements Connection. Connections are dialled already logged
+// in, so this only makes sense for the current connection; new
+// connections will still log in as dial does.
+func (rc *retryingConnection) Login(name names.Tag, password, nonce string, ms []macaroon.Slice) error {
+	return rc.conn().Login(name, password, nonce, ms)
+}
+
+// ServerVersion implements Connection.
+func (rc *retryingConnection) ServerVersion() (version.Number, bool) {
+	return rc.conn().ServerVersion()
+}
+
+// ControllerTag implements Connection.
+func (rc *retryingConnection) ControllerTag() names.ControllerTag {
+	return rc.conn().ControllerTag()
+}
+
+// Ping implements Connection.
+func (rc *retryingConnection) Ping() error {
+	return rc.conn().Ping()
+}
+
+// AllFacadeVersions implements Connection.
+func (rc *retryingConnection) AllFacadeVersions() map[string][]int {
+	return rc.conn().AllFacadeVersions()
+}
+
+// AuthTag implements Connection.
+func (rc *retryingConnection) AuthTag() names.Tag {
+	return rc.conn().AuthTag()
+}
+
+// ModelAccess implements Connection.
+func (rc *retryingConnection) ModelAccess() string {
+	return rc.conn().ModelAccess()
+}
+
+// ControllerAccess implements Connection.
+func (rc *retryingConnection) ControllerAccess() string {
+	return rc.conn().ControllerAccess()
+}
+
+// CookieURL implements Connection.
+func (rc *retryingConnection) CookieURL() *url.URL {
+	return rc.conn().CookieURL()
+}
+
+// Client implements Connection.
+func (rc *retryingConnection) Client() *Client {
+	frontend, backend := base.NewClientFacade(rc, "Client")
+	return &Client{ClientFacade: frontend, facade: backend, st: rc}
+}
+
+// Uniter implements Connection.
+func (rc *retryingConnection) Uniter() (*uniter.State, error) {
+	authTag := rc.AuthTag()
+	unitTag, ok := authTag.(names.UnitTag)
+	if !ok {
+		return nil, errors.Errorf("expected UnitTag, got %T %v", authTag, authTag)
+	}
+	return uniter.NewState(rc, unitTag), nil
+}
+
+// Upgrader implements Connection.
+func (rc *retryingConnection) Upgrader() *upgrader.State {
+	return upgrader.NewState(rc)
+}
+
+// Reboot implements Connection.
+func (rc *retryingConnection) Reboot() (reboot.State, error) {
+	switch tag := rc.AuthTag().(type) {
+	case names.MachineTag:
+		return reboot.NewState(rc, tag), nil
+	default:
+		return nil, errors.Errorf("expected names.MachineTag, got %T", tag)
+	}
+}
+
+// InstancePoller implements Connection.
+func (rc *retryingConnection) InstancePoller() *instancepoller.API {
+	return instancepoller.NewAPI(rc)
+}
+
+// CharmRevisionUpdater implements Connection.
+func (rc *retryingConnection) CharmRevisionUpdater() *charmrevisionupdater.State {
+	return charmrevisionupdater.NewState(rc)
+}
+
+// Cleaner implements Connection.
+func (rc *retryingConnection) Cleaner() *cleaner.API {
+	return cleaner.NewAPI(rc)
+}
+
+// MetadataUpdater implements Connection.
+func (rc *retryingConnection) MetadataUpdater() *imagemetadata.Client {
+	return imagemetadata.NewClient(rc)
+}
+
+// UnitAssigner implements Connection.
+func (rc *retryingConnection) UnitAssigner() unitassigner.API {
+	return unitassigner.New(rc)
+}
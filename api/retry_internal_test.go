@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/rpc"
+)
+
+var _ = gc.Suite(&RetryingConnectionSuite{})
+
+type RetryingConnectionSuite struct {
+	testing.IsolationSuite
+	dialed  []*stubConnection
+	dialErr error
+}
+
+func (s *RetryingConnectionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dialed = nil
+	s.dialErr = nil
+}
+
+func (s *RetryingConnectionSuite) dial() (Connection, error) {
+	if s.dialErr != nil {
+		return nil, s.dialErr
+	}
+	conn := &stubConnection{addr: fmt.Sprintf("10.0.0.%d:17070", len(s.dialed)+1)}
+	s.dialed = append(s.dialed, conn)
+	return conn, nil
+}
+
+func (s *RetryingConnectionSuite) newConnection(c *gc.C) Connection {
+	conn, err := NewRetryingConnection(s.dial, base.RetryPolicy{
+		Attempts: 2,
+		Clock:    clock.WallClock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return conn
+}
+
+func (s *RetryingConnectionSuite) TestCallsRedial(c *gc.C) {
+	conn := s.newConnection(c)
+	c.Assert(conn.Addr(), gc.Equals, "10.0.0.1:17070")
+	s.dialed[0].err = rpc.ErrShutdown
+
+	err := conn.APICall("Client", 1, "", "FullStatus", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.dialed, gc.HasLen, 2)
+	c.Assert(s.dialed[0].calls, jc.DeepEquals, []string{"Client.FullStatus"})
+	c.Assert(s.dialed[1].calls, jc.DeepEquals, []string{"Client.FullStatus"})
+	c.Assert(conn.Addr(), gc.Equals, "10.0.0.2:17070")
+	c.Assert(conn.IsBroken(), jc.IsFalse)
+	c.Assert(conn.(base.ConnectionMonitor).ConnectionHealth().Redials, gc.Equals, 1)
+}
+
+func (s *RetryingConnectionSuite) TestClientUsesRetryingCaller(c *gc.C) {
+	conn := s.newConnection(c)
+	s.dialed[0].err = rpc.ErrShutdown
+
+	_, err := conn.Client().Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.dialed, gc.HasLen, 2)
+}
+
+func (s *RetryingConnectionSuite) TestBrokenWhenRedialFails(c *gc.C) {
+	conn := s.newConnection(c)
+	s.dialed[0].err = rpc.ErrShutdown
+	s.dialErr = errors.New("no controllers")
+
+	err := conn.APICall("Client", 1, "", "FullStatus", nil, nil)
+	c.Assert(err, gc.ErrorMatches, "redialling API: no controllers")
+	c.Assert(conn.IsBroken(), jc.IsTrue)
+	select {
+	case <-conn.Broken():
+	default:
+		c.Fatalf("connection not broken")
+	}
+}
+
+func (s *RetryingConnectionSuite) TestClose(c *gc.C) {
+	conn := s.newConnection(c)
+	c.Assert(conn.IsBroken(), jc.IsFalse)
+	c.Assert(conn.Close(), jc.ErrorIsNil)
+	c.Assert(conn.IsBroken(), jc.IsTrue)
+	c.Assert(s.dialed[0].closed, jc.IsTrue)
+}
+
+// stubConnection is a Connection that records API calls, and fails
+// them with err. Methods not implemented here will panic.
+type stubConnection struct {
+	Connection
+	addr   string
+	calls  []string
+	err    error
+	closed bool
+}
+
+func (s *stubConnection) Addr() string {
+	return s.addr
+}
+
+func (s *stubConnection) APICall(facade string, version int, id, method string, args, response interface{}) error {
+	s.calls = append(s.calls, facade+"."+method)
+	return s.err
+}
+
+func (s *stubConnection) BestFacadeVersion(string) int {
+	return 1
+}
+
+func (s *stubConnection) Close() error {
+	s.closed = true
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spacediscovery
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const spaceDiscoveryFacade = "SpaceDiscovery"
+
+// Drift describes how a model's spaces and subnets differed from
+// those reported by its provider when they were reloaded.
+type Drift struct {
+	// AddedSpaces holds the names of the spaces added to the model.
+	AddedSpaces []string
+
+	// AddedSubnets holds the CIDRs of the subnets added to the model.
+	AddedSubnets []string
+
+	// MissingSpaces holds the names of the model's provider spaces
+	// that the provider no longer reports.
+	MissingSpaces []string
+
+	// MissingSubnets holds the CIDRs of the model's provider subnets
+	// that the provider no longer reports.
+	MissingSubnets []string
+}
+
+// API provides access to the space discovery API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side space discovery facade.
+func NewAPI(caller base.APICaller) *API {
+	return &API{
+		facade: base.NewFacadeCaller(caller, spaceDiscoveryFacade),
+	}
+}
+
+// ReloadSpaces reloads the model's spaces and subnets from its
+// provider, and returns how they had drifted.
+func (api *API) ReloadSpaces() (Drift, error) {
+	var result params.ReloadSpacesResult
+	if err := api.facade.FacadeCall("ReloadSpaces", nil, &result); err != nil {
+		return Drift{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return Drift{}, result.Error
+	}
+	return Drift{
+		AddedSpaces:    result.AddedSpaces,
+		AddedSubnets:   result.AddedSubnets,
+		MissingSpaces:  result.MissingSpaces,
+		MissingSubnets: result.MissingSubnets,
+	}, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spacediscovery_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/spacediscovery"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type spaceDiscoverySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&spaceDiscoverySuite{})
+
+func (s *spaceDiscoverySuite) TestReloadSpaces(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SpaceDiscovery")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ReloadSpaces")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ReloadSpacesResult{})
+		*(result.(*params.ReloadSpacesResult)) = params.ReloadSpacesResult{
+			AddedSpaces:    []string{"space2"},
+			AddedSubnets:   []string{"10.1.0.0/24"},
+			MissingSpaces:  []string{"space1"},
+			MissingSubnets: []string{"10.0.0.0/24"},
+		}
+		return nil
+	})
+	api := spacediscovery.NewAPI(apiCaller)
+	drift, err := api.ReloadSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drift, jc.DeepEquals, spacediscovery.Drift{
+		AddedSpaces:    []string{"space2"},
+		AddedSubnets:   []string{"10.1.0.0/24"},
+		MissingSpaces:  []string{"space1"},
+		MissingSubnets: []string{"10.0.0.0/24"},
+	})
+}
+
+func (s *spaceDiscoverySuite) TestReloadSpacesResultError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ReloadSpacesResult)) = params.ReloadSpacesResult{
+			Error: &params.Error{
+				Code:    params.CodeNotSupported,
+				Message: "spaces discovery in a non-networking environ not supported",
+			},
+		}
+		return nil
+	})
+	api := spacediscovery.NewAPI(apiCaller)
+	_, err := api.ReloadSpaces()
+	c.Assert(err, gc.ErrorMatches, "spaces discovery in a non-networking environ not supported")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *spaceDiscoverySuite) TestReloadSpacesCallError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	api := spacediscovery.NewAPI(apiCaller)
+	_, err := api.ReloadSpaces()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -84,3 +84,61 @@ func (api *API) ReloadSpaces() error {
 	}
 	return err
 }
+
+// RenameSpace renames the space called from to to, updating the
+// subnets, endpoint bindings and constraints that refer to it. The
+// result reports the applications whose bindings and constraints
+// refer to the space. If dryRun is true, only that impact is reported.
+func (api *API) RenameSpace(from, to string, dryRun bool) (params.SpaceChangeResult, error) {
+	if api.facade.BestAPIVersion() < 4 {
+		return params.SpaceChangeResult{}, errors.NewNotSupported(nil, "Controller does not support renaming spaces")
+	}
+	args := params.RenameSpacesParams{
+		Changes: []params.RenameSpaceParams{{
+			FromSpaceTag: names.NewSpaceTag(from).String(),
+			ToSpaceTag:   names.NewSpaceTag(to).String(),
+			DryRun:       dryRun,
+		}},
+	}
+	var response params.SpaceChangeResults
+	if err := api.facade.FacadeCall("RenameSpaces", args, &response); err != nil {
+		return params.SpaceChangeResult{}, errors.Trace(err)
+	}
+	return oneSpaceChangeResult(response)
+}
+
+// MoveSubnet moves the subnet with the given CIDR into the named
+// space. The result reports the applications whose bindings and
+// constraints refer to the subnet's current space, and the machines
+// that would be left without an address in it. The move is refused if
+// there are any such machines, unless force is true. If dryRun is
+// true, only the impact is reported.
+func (api *API) MoveSubnet(cidr, spaceName string, force, dryRun bool) (params.SpaceChangeResult, error) {
+	if api.facade.BestAPIVersion() < 4 {
+		return params.SpaceChangeResult{}, errors.NewNotSupported(nil, "Controller does not support moving subnets")
+	}
+	args := params.MoveSubnetsParams{
+		Args: []params.MoveSubnetParams{{
+			SubnetTag: names.NewSubnetTag(cidr).String(),
+			SpaceTag:  names.NewSpaceTag(spaceName).String(),
+			Force:     force,
+			DryRun:    dryRun,
+		}},
+	}
+	var response params.SpaceChangeResults
+	if err := api.facade.FacadeCall("MoveSubnets", args, &response); err != nil {
+		return params.SpaceChangeResult{}, errors.Trace(err)
+	}
+	return oneSpaceChangeResult(response)
+}
+
+func oneSpaceChangeResult(response params.SpaceChangeResults) (params.SpaceChangeResult, error) {
+	if len(response.Results) != 1 {
+		return params.SpaceChangeResult{}, errors.Errorf("expected 1 result, got %d", len(response.Results))
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return result, result.Error
+	}
+	return result, nil
+}
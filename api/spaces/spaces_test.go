@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/rand"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -167,3 +168,107 @@ func (s *SpacesSuite) TestListSpacesManyResults(c *gc.C) {
 func (s *SpacesSuite) TestListSpacesServerError(c *gc.C) {
 	s.testListSpaces(c, nil, errors.New("boom"), "boom")
 }
+
+func (s *SpacesSuite) TestRenameSpace(c *gc.C) {
+	var called bool
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Spaces")
+			c.Check(request, gc.Equals, "RenameSpaces")
+			c.Check(arg, jc.DeepEquals, params.RenameSpacesParams{
+				Changes: []params.RenameSpaceParams{{
+					FromSpaceTag: "space-db",
+					ToSpaceTag:   "space-database",
+					DryRun:       true,
+				}},
+			})
+			*(result.(*params.SpaceChangeResults)) = params.SpaceChangeResults{
+				Results: []params.SpaceChangeResult{{
+					ApplicationConstraints: []string{"mysql"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	api := spaces.NewAPI(apiCaller)
+	result, err := api.RenameSpace("db", "database", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result.ApplicationConstraints, jc.DeepEquals, []string{"mysql"})
+}
+
+func (s *SpacesSuite) TestRenameSpaceNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 3,
+	}
+	api := spaces.NewAPI(apiCaller)
+	_, err := api.RenameSpace("db", "database", false)
+	c.Assert(err, gc.ErrorMatches, "Controller does not support renaming spaces")
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
+
+func (s *SpacesSuite) TestMoveSubnet(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Spaces")
+			c.Check(request, gc.Equals, "MoveSubnets")
+			c.Check(arg, jc.DeepEquals, params.MoveSubnetsParams{
+				Args: []params.MoveSubnetParams{{
+					SubnetTag: "subnet-10.0.0.0/24",
+					SpaceTag:  "space-client",
+					Force:     true,
+				}},
+			})
+			*(result.(*params.SpaceChangeResults)) = params.SpaceChangeResults{
+				Results: []params.SpaceChangeResult{{
+					Machines: []string{"0"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	api := spaces.NewAPI(apiCaller)
+	result, err := api.MoveSubnet("10.0.0.0/24", "client", true, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Machines, jc.DeepEquals, []string{"0"})
+}
+
+func (s *SpacesSuite) TestMoveSubnetResultError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.SpaceChangeResults)) = params.SpaceChangeResults{
+				Results: []params.SpaceChangeResult{{
+					Machines: []string{"0"},
+					Error:    &params.Error{Message: "refused"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	api := spaces.NewAPI(apiCaller)
+	result, err := api.MoveSubnet("10.0.0.0/24", "client", false, false)
+	c.Assert(err, gc.ErrorMatches, "refused")
+	c.Assert(result.Machines, jc.DeepEquals, []string{"0"})
+}
+
+func (s *SpacesSuite) TestMoveSubnetNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 3,
+	}
+	api := spaces.NewAPI(apiCaller)
+	_, err := api.MoveSubnet("10.0.0.0/24", "client", false, false)
+	c.Assert(err, gc.ErrorMatches, "Controller does not support moving subnets")
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
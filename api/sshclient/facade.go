@@ -4,10 +4,16 @@
 package sshclient
 
 import (
+	"io"
+	"net"
+	"time"
+
+	"github.com/gorilla/websocket"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common/stream"
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -111,6 +117,229 @@ func (facade *Facade) Proxy() (bool, error) {
 	return out.UseProxy, nil
 }
 
+// ListManualHostKeys returns the SSH host keys stored for all manually
+// provisioned hosts in the model, keyed by host.
+func (facade *Facade) ListManualHostKeys() (map[string][]string, error) {
+	if facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("manual host keys on this controller")
+	}
+	var out params.SSHManualHostKeysResults
+	err := facade.caller.FacadeCall("ListManualHostKeys", nil, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	keys := make(map[string][]string)
+	for _, result := range out.Results {
+		if result.Error != nil {
+			return nil, errors.Trace(result.Error)
+		}
+		keys[result.Host] = result.PublicKeys
+	}
+	return keys, nil
+}
+
+// ManualHostKeys returns the SSH host keys stored for the manually
+// provisioned host provided, which may be a hostname or an address.
+// An error satisfying params.IsCodeNotFound is returned if no keys
+// are stored for the host.
+func (facade *Facade) ManualHostKeys(host string) ([]string, error) {
+	if facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("manual host keys on this controller")
+	}
+	args := params.SSHManualHosts{Hosts: []string{host}}
+	var out params.SSHManualHostKeysResults
+	err := facade.caller.FacadeCall("ManualHostKeys", args, &out)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != 1 {
+		return nil, countError(len(out.Results))
+	}
+	if err := out.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return out.Results[0].PublicKeys, nil
+}
+
+// SetManualHostKeys stores the SSH host keys of the manually
+// provisioned host provided, replacing any keys already stored.
+func (facade *Facade) SetManualHostKeys(host string, keys []string) error {
+	if facade.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("manual host keys on this controller")
+	}
+	args := params.SSHManualHostKeySet{
+		HostKeys: []params.SSHManualHostKeys{{
+			Host:       host,
+			PublicKeys: keys,
+		}},
+	}
+	var out params.ErrorResults
+	err := facade.caller.FacadeCall("SetManualHostKeys", args, &out)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return out.OneError()
+}
+
+// RemoveManualHostKeys removes the SSH host keys stored for the
+// manually provisioned hosts provided.
+func (facade *Facade) RemoveManualHostKeys(hosts ...string) error {
+	if facade.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("manual host keys on this controller")
+	}
+	args := params.SSHManualHosts{Hosts: hosts}
+	var out params.ErrorResults
+	err := facade.caller.FacadeCall("RemoveManualHostKeys", args, &out)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(out.Results) != len(hosts) {
+		return errors.Errorf("expected %d results, got %d", len(hosts), len(out.Results))
+	}
+	return out.Combine()
+}
+
+// Dial opens a connection to the SSH server of the target provided,
+// tunnelled through the controller over the API connection. This
+// allows SSH connections to be made to machines that have no address
+// the client can reach directly. The target may be provided as a
+// machine ID or unit name.
+func (facade *Facade) Dial(target string) (net.Conn, error) {
+	tag, err := targetToTag(target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	source, err := stream.Open(facade.caller.RawAPICaller(), "/ssh-proxy", params.SSHProxyConfig{
+		Target: tag.String(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	writer, ok := source.(messageWriter)
+	if !ok {
+		source.Close()
+		return nil, errors.Errorf("cannot write to ssh proxy stream of type %T", source)
+	}
+	return &proxyConn{
+		stream: source,
+		writer: writer,
+		target: tag,
+	}, nil
+}
+
+// messageWriter is implemented by streams that can send raw
+// websocket messages.
+type messageWriter interface {
+	WriteMessage(messageType int, data []byte) error
+}
+
+// proxyConn is a net.Conn that carries an SSH session as binary
+// messages over an ssh-proxy stream.
+type proxyConn struct {
+	stream base.Stream
+	writer messageWriter
+	target names.Tag
+
+	// reader holds the message currently being read, if any.
+	reader io.Reader
+}
+
+// Read is part of the net.Conn interface.
+func (c *proxyConn) Read(b []byte) (int, error) {
+	for {
+		if c.reader == nil {
+			messageType, r, err := c.stream.NextReader()
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return 0, io.EOF
+			}
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			if messageType != websocket.BinaryMessage {
+				continue
+			}
+			c.reader = r
+		}
+		n, err := c.reader.Read(b)
+		if err == io.EOF {
+			c.reader = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+// Write is part of the net.Conn interface.
+func (c *proxyConn) Write(b []byte) (int, error) {
+	if err := c.writer.WriteMessage(websocket.BinaryMessage, b); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return len(b), nil
+}
+
+// Close is part of the net.Conn interface.
+func (c *proxyConn) Close() error {
+	return c.stream.Close()
+}
+
+// LocalAddr is part of the net.Conn interface.
+func (c *proxyConn) LocalAddr() net.Addr {
+	return proxyAddr("client")
+}
+
+// RemoteAddr is part of the net.Conn interface. It returns the tag
+// of the target, as the address used by the controller is not known
+// to the client.
+func (c *proxyConn) RemoteAddr() net.Addr {
+	return proxyAddr(c.target.String())
+}
+
+// SetDeadline is part of the net.Conn interface.
+func (c *proxyConn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return errors.Trace(err)
+	}
+	return c.SetWriteDeadline(t)
+}
+
+// SetReadDeadline is part of the net.Conn interface.
+func (c *proxyConn) SetReadDeadline(t time.Time) error {
+	s, ok := c.stream.(interface {
+		SetReadDeadline(time.Time) error
+	})
+	if !ok {
+		return errors.NotSupportedf("read deadline")
+	}
+	return s.SetReadDeadline(t)
+}
+
+// SetWriteDeadline is part of the net.Conn interface.
+func (c *proxyConn) SetWriteDeadline(t time.Time) error {
+	s, ok := c.stream.(interface {
+		SetWriteDeadline(time.Time) error
+	})
+	if !ok {
+		return errors.NotSupportedf("write deadline")
+	}
+	return s.SetWriteDeadline(t)
+}
+
+// proxyAddr is the net.Addr of either end of a proxyConn.
+type proxyAddr string
+
+// Network is part of the net.Addr interface.
+func (proxyAddr) Network() string {
+	return "ssh-proxy"
+}
+
+// String is part of the net.Addr interface.
+func (a proxyAddr) String() string {
+	return string(a)
+}
+
 func targetToEntities(target string) (params.Entities, error) {
 	tag, err := targetToTag(target)
 	if err != nil {
@@ -4,12 +4,19 @@
 package sshclient_test
 
 import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/url"
+
+	"github.com/gorilla/websocket"
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/base"
 	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/sshclient"
 	"github.com/juju/juju/apiserver/common"
@@ -280,3 +287,238 @@ func (s *FacadeSuite) TestProxyError(c *gc.C) {
 	_, err := facade.Proxy()
 	c.Check(err, gc.ErrorMatches, "boom")
 }
+
+func (s *FacadeSuite) TestListManualHostKeys(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*result.(*params.SSHManualHostKeysResults) = params.SSHManualHostKeysResults{
+				Results: []params.SSHManualHostKeysResult{
+					{Host: "10.0.0.1", PublicKeys: []string{"rsa", "dsa"}},
+					{Host: "10.0.0.2", PublicKeys: []string{"ed25519"}},
+				},
+			}
+			return nil
+		},
+	}
+	facade := sshclient.NewFacade(apiCaller)
+	keys, err := facade.ListManualHostKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(keys, jc.DeepEquals, map[string][]string{
+		"10.0.0.1": {"rsa", "dsa"},
+		"10.0.0.2": {"ed25519"},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{{"SSHClient.ListManualHostKeys", []interface{}{nil}}})
+}
+
+func (s *FacadeSuite) TestManualHostKeys(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*result.(*params.SSHManualHostKeysResults) = params.SSHManualHostKeysResults{
+				Results: []params.SSHManualHostKeysResult{
+					{Host: "10.0.0.1", PublicKeys: []string{"rsa", "dsa"}},
+				},
+			}
+			return nil
+		},
+	}
+	facade := sshclient.NewFacade(apiCaller)
+	keys, err := facade.ManualHostKeys("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(keys, gc.DeepEquals, []string{"rsa", "dsa"})
+	stub.CheckCalls(c, []jujutesting.StubCall{{
+		"SSHClient.ManualHostKeys",
+		[]interface{}{params.SSHManualHosts{Hosts: []string{"10.0.0.1"}}},
+	}})
+}
+
+func (s *FacadeSuite) TestManualHostKeysNotFound(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*result.(*params.SSHManualHostKeysResults) = params.SSHManualHostKeysResults{
+				Results: []params.SSHManualHostKeysResult{
+					{Host: "10.0.0.1", Error: common.ServerError(errors.NotFoundf("keys"))},
+				},
+			}
+			return nil
+		},
+	}
+	facade := sshclient.NewFacade(apiCaller)
+	keys, err := facade.ManualHostKeys("10.0.0.1")
+	c.Check(keys, gc.IsNil)
+	c.Check(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *FacadeSuite) TestSetManualHostKeys(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*result.(*params.ErrorResults) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+	}
+	facade := sshclient.NewFacade(apiCaller)
+	err := facade.SetManualHostKeys("10.0.0.1", []string{"rsa"})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{{
+		"SSHClient.SetManualHostKeys",
+		[]interface{}{params.SSHManualHostKeySet{
+			HostKeys: []params.SSHManualHostKeys{{Host: "10.0.0.1", PublicKeys: []string{"rsa"}}},
+		}},
+	}})
+}
+
+func (s *FacadeSuite) TestRemoveManualHostKeys(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*result.(*params.ErrorResults) = params.ErrorResults{
+				Results: []params.ErrorResult{
+					{},
+					{Error: common.ServerError(errors.New("boom"))},
+				},
+			}
+			return nil
+		},
+	}
+	facade := sshclient.NewFacade(apiCaller)
+	err := facade.RemoveManualHostKeys("10.0.0.1", "10.0.0.2")
+	c.Check(err, gc.ErrorMatches, "boom")
+	stub.CheckCalls(c, []jujutesting.StubCall{{
+		"SSHClient.RemoveManualHostKeys",
+		[]interface{}{params.SSHManualHosts{Hosts: []string{"10.0.0.1", "10.0.0.2"}}},
+	}})
+}
+
+func (s *FacadeSuite) TestManualHostKeysNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s.%s", objType, request)
+			return nil
+		},
+	}
+	facade := sshclient.NewFacade(apiCaller)
+	_, err := facade.ListManualHostKeys()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = facade.ManualHostKeys("10.0.0.1")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = facade.SetManualHostKeys("10.0.0.1", []string{"rsa"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = facade.RemoveManualHostKeys("10.0.0.1")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *FacadeSuite) TestDial(c *gc.C) {
+	var stub jujutesting.Stub
+	stream := &mockStream{
+		stub: &stub,
+		messages: []mockMessage{
+			{websocket.BinaryMessage, "SSH-2.0-"},
+			{websocket.TextMessage, "ignored"},
+			{websocket.BinaryMessage, "OpenSSH"},
+		},
+	}
+	conn := &mockConnector{stub: &stub, stream: stream}
+	facade := sshclient.NewFacade(conn)
+
+	proxy, err := facade.Dial("foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(proxy.RemoteAddr().String(), gc.Equals, "unit-foo-0")
+	stub.CheckCall(c, 0, "ConnectStream", "/ssh-proxy", url.Values{
+		"target": []string{"unit-foo-0"},
+	})
+
+	_, err = proxy.Write([]byte("hello"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stream.written, gc.DeepEquals, []string{"hello"})
+
+	data, err := ioutil.ReadAll(proxy)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "SSH-2.0-OpenSSH")
+
+	err = proxy.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCallNames(c, "ConnectStream", "Close")
+}
+
+func (s *FacadeSuite) TestDialInvalidTarget(c *gc.C) {
+	var stub jujutesting.Stub
+	facade := sshclient.NewFacade(&mockConnector{stub: &stub})
+	_, err := facade.Dial("foo")
+	c.Check(err, gc.ErrorMatches, `target "foo" not valid`)
+	stub.CheckNoCalls(c)
+}
+
+func (s *FacadeSuite) TestDialError(c *gc.C) {
+	var stub jujutesting.Stub
+	stub.SetErrors(errors.New("boom"))
+	facade := sshclient.NewFacade(&mockConnector{stub: &stub})
+	_, err := facade.Dial("0")
+	c.Check(err, gc.ErrorMatches, "cannot connect to /ssh-proxy: boom")
+	stub.CheckCall(c, 0, "ConnectStream", "/ssh-proxy", url.Values{
+		"target": []string{"machine-0"},
+	})
+}
+
+type mockConnector struct {
+	apitesting.APICallerFunc
+	stub   *jujutesting.Stub
+	stream base.Stream
+}
+
+func (c *mockConnector) ConnectStream(path string, values url.Values) (base.Stream, error) {
+	c.stub.AddCall("ConnectStream", path, values)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return c.stream, nil
+}
+
+type mockMessage struct {
+	messageType int
+	data        string
+}
+
+// mockStream is a base.Stream that returns the given messages in
+// turn, and then reports that the connection was closed normally.
+type mockStream struct {
+	base.Stream
+	stub     *jujutesting.Stub
+	messages []mockMessage
+	written  []string
+}
+
+func (s *mockStream) NextReader() (int, io.Reader, error) {
+	if len(s.messages) == 0 {
+		return 0, nil, &websocket.CloseError{Code: websocket.CloseNormalClosure}
+	}
+	msg := s.messages[0]
+	s.messages = s.messages[1:]
+	return msg.messageType, bytes.NewReader([]byte(msg.data)), nil
+}
+
+func (s *mockStream) WriteMessage(messageType int, data []byte) error {
+	if messageType != websocket.BinaryMessage {
+		return errors.Errorf("unexpected message type %d", messageType)
+	}
+	s.written = append(s.written, string(data))
+	return nil
+}
+
+func (s *mockStream) Close() error {
+	s.stub.AddCall("Close")
+	return s.stub.NextErr()
+}
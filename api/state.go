@@ -139,6 +139,7 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 	if err != nil {
 		return errors.Trace(err)
 	}
+	st.supportsIdempotencyKeys = result.SupportsIdempotencyKeys
 	return nil
 }
 
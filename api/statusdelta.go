@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// StatusDelta returns the changes to the status of the juju model
+// since the version previously returned by StatusDelta on this
+// connection. Pass an empty since to get the full status. Use
+// ApplyStatusDelta to bring a previously returned status up to date.
+func (c *Client) StatusDelta(patterns []string, since string) (*params.FullStatusDelta, error) {
+	if c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("FullStatusDelta")
+	}
+	var result params.FullStatusDelta
+	p := params.StatusDeltaParams{
+		Patterns: patterns,
+		Since:    since,
+	}
+	if err := c.facade.FacadeCall("FullStatusDelta", p, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
+
+// ApplyStatusDelta updates the status with the changes in the delta.
+// If the delta holds the full status, the status is replaced.
+func ApplyStatusDelta(status *params.FullStatus, delta *params.FullStatusDelta) {
+	if delta.Full {
+		*status = params.FullStatus{}
+	}
+	if delta.Model != nil {
+		status.Model = *delta.Model
+	}
+
+	if status.Machines == nil {
+		status.Machines = make(map[string]params.MachineStatus)
+	}
+	for id, m := range delta.Machines {
+		status.Machines[id] = m
+	}
+	for _, id := range delta.RemovedMachines {
+		delete(status.Machines, id)
+	}
+
+	if status.Applications == nil {
+		status.Applications = make(map[string]params.ApplicationStatus)
+	}
+	for name, app := range delta.Applications {
+		status.Applications[name] = app
+	}
+	for _, name := range delta.RemovedApplications {
+		delete(status.Applications, name)
+	}
+
+	if status.RemoteApplications == nil {
+		status.RemoteApplications = make(map[string]params.RemoteApplicationStatus)
+	}
+	for name, app := range delta.RemoteApplications {
+		status.RemoteApplications[name] = app
+	}
+	for _, name := range delta.RemovedRemoteApplications {
+		delete(status.RemoteApplications, name)
+	}
+
+	if status.Offers == nil {
+		status.Offers = make(map[string]params.ApplicationOfferStatus)
+	}
+	for name, offer := range delta.Offers {
+		status.Offers[name] = offer
+	}
+	for _, name := range delta.RemovedOffers {
+		delete(status.Offers, name)
+	}
+
+	removed := make(map[int]bool)
+	for _, id := range delta.RemovedRelations {
+		removed[id] = true
+	}
+	changed := make(map[int]params.RelationStatus)
+	for _, rel := range delta.Relations {
+		changed[rel.Id] = rel
+	}
+	relations := make([]params.RelationStatus, 0, len(status.Relations)+len(delta.Relations))
+	for _, rel := range status.Relations {
+		if removed[rel.Id] {
+			continue
+		}
+		if update, ok := changed[rel.Id]; ok {
+			rel = update
+			delete(changed, rel.Id)
+		}
+		relations = append(relations, rel)
+	}
+	for _, rel := range delta.Relations {
+		if _, ok := changed[rel.Id]; ok {
+			relations = append(relations, rel)
+		}
+	}
+	status.Relations = relations
+}
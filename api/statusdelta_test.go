@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testing"
+)
+
+type statusDeltaSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&statusDeltaSuite{})
+
+func (s *statusDeltaSuite) TestStatusDelta(c *gc.C) {
+	client := s.APIState.Client()
+	machine := s.Factory.MakeMachine(c, nil)
+
+	first, err := client.StatusDelta(nil, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(first.Full, jc.IsTrue)
+	c.Assert(first.Model, gc.NotNil)
+	c.Assert(first.Machines, gc.HasLen, 1)
+
+	// Nothing has changed.
+	second, err := client.StatusDelta(nil, first.Version)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(second, jc.DeepEquals, &params.FullStatusDelta{Version: first.Version})
+
+	// Only the new machine is reported.
+	other := s.Factory.MakeMachine(c, nil)
+	third, err := client.StatusDelta(nil, second.Version)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(third.Full, jc.IsFalse)
+	c.Assert(third.Model, gc.IsNil)
+	c.Assert(third.Machines, gc.HasLen, 1)
+	c.Assert(third.Machines[other.Id()].Id, gc.Equals, other.Id())
+
+	// Removed machines are listed.
+	err = other.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	fourth, err := client.StatusDelta(nil, third.Version)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fourth.Machines, gc.HasLen, 0)
+	c.Assert(fourth.RemovedMachines, jc.DeepEquals, []string{other.Id()})
+
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines, gc.HasLen, 1)
+	c.Assert(status.Machines[machine.Id()].Id, gc.Equals, machine.Id())
+}
+
+func (s *statusDeltaSuite) TestStatusDeltaUnknownVersion(c *gc.C) {
+	delta, err := s.APIState.Client().StatusDelta(nil, "42:deadbeef")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(delta.Full, jc.IsTrue)
+	c.Assert(delta.Model, gc.NotNil)
+}
+
+type applyStatusDeltaSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&applyStatusDeltaSuite{})
+
+func (s *applyStatusDeltaSuite) TestApplyStatusDelta(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "old"},
+		Machines: map[string]params.MachineStatus{
+			"0": {Id: "0"},
+			"1": {Id: "1"},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {Charm: "cs:mysql-1"},
+		},
+		Relations: []params.RelationStatus{
+			{Id: 1, Key: "one"},
+			{Id: 2, Key: "two"},
+		},
+	}
+	api.ApplyStatusDelta(status, &params.FullStatusDelta{
+		Model: &params.ModelStatusInfo{Name: "new"},
+		Machines: map[string]params.MachineStatus{
+			"2": {Id: "2"},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {Charm: "cs:mysql-2"},
+		},
+		Relations: []params.RelationStatus{
+			{Id: 2, Key: "two-changed"},
+			{Id: 3, Key: "three"},
+		},
+		RemovedMachines:  []string{"1"},
+		RemovedRelations: []int{1},
+	})
+	c.Assert(status, jc.DeepEquals, &params.FullStatus{
+		Model: params.ModelStatusInfo{Name: "new"},
+		Machines: map[string]params.MachineStatus{
+			"0": {Id: "0"},
+			"2": {Id: "2"},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {Charm: "cs:mysql-2"},
+		},
+		RemoteApplications: map[string]params.RemoteApplicationStatus{},
+		Offers:             map[string]params.ApplicationOfferStatus{},
+		Relations: []params.RelationStatus{
+			{Id: 2, Key: "two-changed"},
+			{Id: 3, Key: "three"},
+		},
+	})
+}
+
+func (s *applyStatusDeltaSuite) TestApplyFullStatusDelta(c *gc.C) {
+	status := &params.FullStatus{
+		Machines: map[string]params.MachineStatus{
+			"0": {Id: "0"},
+		},
+	}
+	api.ApplyStatusDelta(status, &params.FullStatusDelta{
+		Full:  true,
+		Model: &params.ModelStatusInfo{Name: "model"},
+		Machines: map[string]params.MachineStatus{
+			"1": {Id: "1"},
+		},
+	})
+	c.Assert(status.Model.Name, gc.Equals, "model")
+	c.Assert(status.Machines, jc.DeepEquals, map[string]params.MachineStatus{
+		"1": {Id: "1"},
+	})
+	c.Assert(status.Relations, gc.HasLen, 0)
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package statushistoryquery provides a client for the
+// StatusHistoryQuery facade, which pages through the status history
+// of a model's units and machines over a time range.
+package statushistoryquery
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the statushistoryquery API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the statushistoryquery
+// api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "StatusHistoryQuery")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the page of status history selected by the query. To
+// fetch the following page, repeat the query with After set to the
+// page's Next cursor.
+func (c *Client) Query(q params.StatusHistoryQuery) (params.StatusHistoryPage, error) {
+	args := params.StatusHistoryQueries{
+		Queries: []params.StatusHistoryQuery{q},
+	}
+	var results params.StatusHistoryPageResults
+	if err := c.facade.FacadeCall("Query", args, &results); err != nil {
+		return params.StatusHistoryPage{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.StatusHistoryPage{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.StatusHistoryPage{}, errors.Trace(err)
+	}
+	return results.Results[0].Page, nil
+}
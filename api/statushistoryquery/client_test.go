@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statushistoryquery_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/statushistoryquery"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type StatusHistoryQuerySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&StatusHistoryQuerySuite{})
+
+func (s *StatusHistoryQuerySuite) TestQuery(c *gc.C) {
+	from := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	query := params.StatusHistoryQuery{
+		Tag:   "unit-mysql-0",
+		Kind:  "unit",
+		From:  from,
+		After: "previous",
+		Limit: 10,
+	}
+	page := params.StatusHistoryPage{
+		Statuses: []params.DetailedStatus{{
+			Status: "active",
+			Kind:   "workload",
+			Since:  &from,
+		}},
+		Next: "cursor",
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "StatusHistoryQuery")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Query")
+			c.Check(a, jc.DeepEquals, params.StatusHistoryQueries{
+				Queries: []params.StatusHistoryQuery{query},
+			})
+			*(result.(*params.StatusHistoryPageResults)) = params.StatusHistoryPageResults{
+				Results: []params.StatusHistoryPageResult{{Page: page}},
+			}
+			return nil
+		})
+	client := statushistoryquery.NewClient(apiCaller)
+	result, err := client.Query(query)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, page)
+}
+
+func (s *StatusHistoryQuerySuite) TestQueryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.StatusHistoryPageResults)) = params.StatusHistoryPageResults{
+				Results: []params.StatusHistoryPageResult{{
+					Error: &params.Error{Message: `unit "mysql/0" not found`, Code: params.CodeNotFound},
+				}},
+			}
+			return nil
+		})
+	client := statushistoryquery.NewClient(apiCaller)
+	_, err := client.Query(params.StatusHistoryQuery{Tag: "unit-mysql-0", Kind: "unit"})
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" not found`)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
@@ -90,6 +90,110 @@ func (c *Client) CreatePool(pname, provider string, attrs map[string]interface{}
 	return c.facade.FacadeCall("CreatePool", args, nil)
 }
 
+// UpdatePool replaces the configuration of the named pool. If provider
+// is empty, the pool's existing provider is retained.
+func (c *Client) UpdatePool(pname, provider string, attrs map[string]interface{}) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("updating storage pools on this juju controller")
+	}
+	args := params.StoragePoolArgs{
+		Pools: []params.StoragePool{{
+			Name:     pname,
+			Provider: provider,
+			Attrs:    attrs,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("UpdatePool", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemovePool removes the named pool. Pools that are in use by storage
+// in the model cannot be removed.
+func (c *Client) RemovePool(pname string) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("removing storage pools on this juju controller")
+	}
+	args := params.StoragePoolDeleteArgs{
+		Pools: []params.StoragePoolDeleteArg{{Name: pname}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemovePool", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// errCloudPoolsNotSupported is returned when the controller does
+// not support cloud default storage pools.
+var errCloudPoolsNotSupported = errors.NotSupportedf("cloud default storage pools on this juju controller")
+
+// ListCloudPools returns the default storage pools of the specified
+// cloud, which are inherited by all models on the cloud.
+func (c *Client) ListCloudPools(cloud string) ([]params.StoragePool, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errCloudPoolsNotSupported
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewCloudTag(cloud).String()}},
+	}
+	var results params.StoragePoolsResults
+	if err := c.facade.FacadeCall("ListCloudPools", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
+// CreateCloudPool creates a default storage pool for the specified
+// cloud, with the given parameters.
+func (c *Client) CreateCloudPool(cloud, pname, provider string, attrs map[string]interface{}) error {
+	if c.BestAPIVersion() < 5 {
+		return errCloudPoolsNotSupported
+	}
+	args := params.CloudStoragePools{
+		Pools: []params.CloudStoragePool{{
+			CloudTag: names.NewCloudTag(cloud).String(),
+			Pool: params.StoragePool{
+				Name:     pname,
+				Provider: provider,
+				Attrs:    attrs,
+			},
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("CreateCloudPools", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveCloudPool removes the named default storage pool from the
+// specified cloud.
+func (c *Client) RemoveCloudPool(cloud, pname string) error {
+	if c.BestAPIVersion() < 5 {
+		return errCloudPoolsNotSupported
+	}
+	args := params.CloudStoragePoolNames{
+		Pools: []params.CloudStoragePoolName{{
+			CloudTag: names.NewCloudTag(cloud).String(),
+			Name:     pname,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveCloudPools", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // ListVolumes lists volumes for desired machines.
 // If no machines provided, a list of all volumes is returned.
 func (c *Client) ListVolumes(machines []string) ([]params.VolumeDetailsListResult, error) {
@@ -154,6 +258,19 @@ func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.AddStor
 
 // Attach attaches existing storage to a unit.
 func (c *Client) Attach(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+	return c.callAttachmentIds("Attach", unitId, storageIds)
+}
+
+// ValidateAttach checks that existing storage could be attached to a
+// unit, without attaching it.
+func (c *Client) ValidateAttach(unitId string, storageIds []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("validating storage attachment on this juju controller")
+	}
+	return c.callAttachmentIds("ValidateAttach", unitId, storageIds)
+}
+
+func (c *Client) callAttachmentIds(method, unitId string, storageIds []string) ([]params.ErrorResult, error) {
 	in := params.StorageAttachmentIds{
 		make([]params.StorageAttachmentId, len(storageIds)),
 	}
@@ -170,7 +287,7 @@ func (c *Client) Attach(unitId string, storageIds []string) ([]params.ErrorResul
 		}
 	}
 	out := params.ErrorResults{}
-	if err := c.facade.FacadeCall("Attach", in, &out); err != nil {
+	if err := c.facade.FacadeCall(method, in, &out); err != nil {
 		return nil, errors.Trace(err)
 	}
 	if len(out.Results) != len(storageIds) {
@@ -182,6 +299,42 @@ func (c *Client) Attach(unitId string, storageIds []string) ([]params.ErrorResul
 	return out.Results, nil
 }
 
+// ListDetachedStorage returns the storage in the model that is not
+// attached to any unit, and so may be attached for reuse.
+func (c *Client) ListDetachedStorage() ([]params.DetachedStorageDetails, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("listing detached storage on this juju controller")
+	}
+	var results params.DetachedStorageResults
+	if err := c.facade.FacadeCall("ListDetachedStorage", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
+// Resize requests that the specified storage be grown to the given
+// size, in MiB. The storage's volume is resized in place by the
+// storage provisioner.
+func (c *Client) Resize(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("resizing storage on this juju controller")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.ResizeStorage{
+		Storage: []params.ResizeStorageInstance{{
+			Tag:  names.NewStorageTag(storageId).String(),
+			Size: size,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // Remove removes the specified storage entities from the model,
 // optionally destroying them.
 func (c *Client) Remove(storageIds []string, destroyAttachments, destroyStorage bool) ([]params.ErrorResult, error) {
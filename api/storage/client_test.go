@@ -289,6 +289,273 @@ func (s *storageMockSuite) TestCreatePoolFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
 
+func (s *storageMockSuite) TestListCloudPools(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ListCloudPools")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "cloud-foo"}},
+				})
+				results := result.(*params.StoragePoolsResults)
+				results.Results = []params.StoragePoolsResult{{
+					Result: []params.StoragePool{{Name: "fast", Provider: "loop"}},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	pools, err := client.ListCloudPools("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pools, jc.DeepEquals, []params.StoragePool{{Name: "fast", Provider: "loop"}})
+}
+
+func (s *storageMockSuite) TestCreateCloudPool(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(request, gc.Equals, "CreateCloudPools")
+				c.Check(a, jc.DeepEquals, params.CloudStoragePools{
+					Pools: []params.CloudStoragePool{{
+						CloudTag: "cloud-foo",
+						Pool: params.StoragePool{
+							Name:     "fast",
+							Provider: "loop",
+							Attrs:    map[string]interface{}{"a": "b"},
+						},
+					}},
+				})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.CreateCloudPool("foo", "fast", "loop", map[string]interface{}{"a": "b"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestRemoveCloudPool(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(request, gc.Equals, "RemoveCloudPools")
+				c.Check(a, jc.DeepEquals, params.CloudStoragePoolNames{
+					Pools: []params.CloudStoragePoolName{{CloudTag: "cloud-foo", Name: "fast"}},
+				})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.RemoveCloudPool("foo", "fast")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageMockSuite) TestCloudPoolsNotSupported(c *gc.C) {
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 4})
+	_, err := client.ListCloudPools("foo")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.CreateCloudPool("foo", "fast", "loop", nil)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.RemoveCloudPool("foo", "fast")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestListDetachedStorage(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ListDetachedStorage")
+				c.Check(a, gc.IsNil)
+				results := result.(*params.DetachedStorageResults)
+				results.Results = []params.DetachedStorageDetails{{
+					StorageTag:      "storage-pgdata-0",
+					StorageName:     "pgdata",
+					Kind:            params.StorageKindBlock,
+					LastApplication: "postgresql",
+					LastUnitTag:     "unit-postgresql-0",
+				}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	detached, err := client.ListDetachedStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(detached, jc.DeepEquals, []params.DetachedStorageDetails{{
+		StorageTag:      "storage-pgdata-0",
+		StorageName:     "pgdata",
+		Kind:            params.StorageKindBlock,
+		LastApplication: "postgresql",
+		LastUnitTag:     "unit-postgresql-0",
+	}})
+}
+
+func (s *storageMockSuite) TestValidateAttach(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(request, gc.Equals, "ValidateAttach")
+				c.Check(a, jc.DeepEquals, params.StorageAttachmentIds{
+					Ids: []params.StorageAttachmentId{{
+						StorageTag: "storage-pgdata-0",
+						UnitTag:    "unit-postgresql-1",
+					}},
+				})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.ValidateAttach("postgresql/1", []string{"pgdata/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{{Error: &params.Error{Message: "boom"}}})
+}
+
+func (s *storageMockSuite) TestDetachedStorageNotSupported(c *gc.C) {
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 5})
+	_, err := client.ListDetachedStorage()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.ValidateAttach("postgresql/1", []string{"pgdata/0"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "ResizeStorage")
+				c.Check(a, jc.DeepEquals, params.ResizeStorage{
+					Storage: []params.ResizeStorageInstance{{
+						Tag:  "storage-pgdata-0",
+						Size: 2048,
+					}},
+				})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("pgdata/0", 2048)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestResizeNotSupported(c *gc.C) {
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 6})
+	err := client.Resize("pgdata/0", 2048)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestUpdatePool(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "UpdatePool")
+				c.Check(a, jc.DeepEquals, params.StoragePoolArgs{
+					Pools: []params.StoragePool{{
+						Name:     "fast",
+						Provider: "loop",
+						Attrs:    map[string]interface{}{"iops": 200},
+					}},
+				})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.UpdatePool("fast", "loop", map[string]interface{}{"iops": 200})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storageMockSuite) TestUpdatePoolNotSupported(c *gc.C) {
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 7})
+	err := client.UpdatePool("fast", "loop", nil)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestRemovePool(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(request, gc.Equals, "RemovePool")
+				c.Check(a, jc.DeepEquals, params.StoragePoolDeleteArgs{
+					Pools: []params.StoragePoolDeleteArg{{Name: "fast"}},
+				})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.RemovePool("fast")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageMockSuite) TestRemovePoolNotSupported(c *gc.C) {
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 7})
+	err := client.RemovePool("fast")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *storageMockSuite) TestListVolumes(c *gc.C) {
 	var called bool
 	machines := []string{"0", "1"}
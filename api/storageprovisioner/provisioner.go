@@ -87,6 +87,16 @@ func (st *State) WatchFilesystems() (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchFilesystems")
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, including requests to
+// resize them.
+func (st *State) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotImplementedf("WatchVolumeResizes() (need V5+)")
+	}
+	return st.watchStorageEntities("WatchVolumeResizes")
+}
+
 func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -263,6 +273,29 @@ func (st *State) RemoveVolumeParams(tags []names.VolumeTag) ([]params.RemoveVolu
 	return results.Results, nil
 }
 
+// ResizeVolumeParams returns the parameters for growing the volumes
+// with the specified tags.
+func (st *State) ResizeVolumeParams(tags []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotImplementedf("ResizeVolumeParams() (need V5+)")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ResizeVolumeParamsResults
+	err := st.facade.FacadeCall("ResizeVolumeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -4,8 +4,7 @@
 package storageprovisioner_test
 
 import (
-	"errors"
-
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -71,6 +70,30 @@ func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	c.Check(callCount, gc.Equals, 1)
 }
 
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 5)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchVolumeResizes")
+		c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+		*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+			Results: []params.StringsWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		callCount++
+		return nil
+	}), 5}
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes()
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
 func (s *provisionerSuite) TestWatchFilesystems(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -431,6 +454,39 @@ func (s *provisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	}})
 }
 
+func (s *provisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 5)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ResizeVolumeParams")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.ResizeVolumeParamsResults{})
+		*(result.(*params.ResizeVolumeParamsResults)) = params.ResizeVolumeParamsResults{
+			Results: []params.ResizeVolumeParamsResult{{
+				Result: params.ResizeVolumeParams{
+					Provider: "foo",
+					VolumeId: "bar",
+					Size:     2048,
+				},
+			}},
+		}
+		return nil
+	}), 5}
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	volumeParams, err := st.ResizeVolumeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(volumeParams, jc.DeepEquals, []params.ResizeVolumeParamsResult{{
+		Result: params.ResizeVolumeParams{
+			Provider: "foo",
+			VolumeId: "bar",
+			Size:     2048,
+		},
+	}})
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -826,6 +882,19 @@ func (s *provisionerSuite) TestRemoveVolumeParamsClientError(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestResizeVolumeParamsNotImplemented(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	}), 4}
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.ResizeVolumeParams(nil)
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	_, err = st.WatchVolumeResizes()
+	c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *provisionerSuite) TestFilesystemParamsClientError(c *gc.C) {
 	s.testClientError(c, func(st *storageprovisioner.State) error {
 		_, err := st.FilesystemParams(nil)
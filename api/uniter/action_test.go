@@ -101,6 +101,31 @@ func (s *actionSuite) TestActionComplete(c *gc.C) {
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
 
+func (s *actionSuite) TestLogActionMessage(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.LogActionMessage(action.ActionTag(), "working on it")
+	c.Assert(err, jc.ErrorIsNil)
+
+	running, err := s.uniterSuite.wordpressUnit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, gc.HasLen, 1)
+	messages := running[0].Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "working on it")
+}
+
+func (s *actionSuite) TestLogActionMessageNotRunning(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.LogActionMessage(action.ActionTag(), "working on it")
+	c.Assert(err, gc.ErrorMatches, `cannot log message for action ".*": action is not running`)
+}
+
 func (s *actionSuite) TestActionFail(c *gc.C) {
 	completed, err := s.uniterSuite.wordpressUnit.CompletedActions()
 	c.Assert(err, jc.ErrorIsNil)
@@ -45,3 +45,4 @@ func CreateUnit(st *State, tag names.UnitTag) *Unit {
 }
 
 var NewStateV4 = newStateForVersionFn(4)
+var NewStateV8 = newStateForVersionFn(8)
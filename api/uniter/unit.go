@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -22,6 +24,7 @@ type Unit struct {
 	life         params.Life
 	resolvedMode params.ResolvedMode
 	series       string
+	stopDeadline *time.Time
 }
 
 // Tag returns the unit's tag.
@@ -54,6 +57,15 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// StopDeadline returns the time by which the dying unit must have
+// stopped, if it has a deadline.
+func (u *Unit) StopDeadline() (time.Time, bool) {
+	if u.stopDeadline == nil {
+		return time.Time{}, false
+	}
+	return *u.stopDeadline, true
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -77,6 +89,7 @@ func (u *Unit) Refresh() error {
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
 	u.series = result.Series
+	u.stopDeadline = result.StopDeadline
 	return nil
 }
 
@@ -760,6 +773,52 @@ func (u *Unit) AddStorage(constraints map[string][]params.StorageConstraints) er
 	return results.Combine()
 }
 
+// SetCharmStateSnapshot stores a snapshot of the unit's charm-local
+// state, replacing any it previously stored.
+func (u *Unit) SetCharmStateSnapshot(data []byte) error {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return errors.NotImplementedf("SetCharmStateSnapshot() (need V9+)")
+	}
+	args := params.EntityCharmStateSnapshots{
+		Entities: []params.EntityCharmStateSnapshot{
+			{Tag: u.tag.String(), Data: data},
+		},
+	}
+	var results params.ErrorResults
+	err := u.st.facade.FacadeCall("SetCharmStateSnapshots", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
+
+// CharmStateSnapshot returns the charm state snapshot last stored by
+// the named unit of the same application, which need no longer exist.
+func (u *Unit) CharmStateSnapshot(unitName string) ([]byte, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return nil, errors.NotImplementedf("CharmStateSnapshot() (need V9+)")
+	}
+	if !names.IsValidUnit(unitName) {
+		return nil, errors.NotValidf("unit name %q", unitName)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewUnitTag(unitName).String()}},
+	}
+	var results params.CharmStateSnapshotResults
+	err := u.st.facade.FacadeCall("CharmStateSnapshots", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Data, nil
+}
+
 // NetworkInfo returns network interfaces/addresses for specified bindings.
 func (u *Unit) NetworkInfo(bindings []string, relationId *int) (map[string]params.NetworkInfoResult, error) {
 	var results params.NetworkInfoResults
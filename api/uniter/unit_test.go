@@ -247,6 +247,28 @@ func (s *unitSuite) TestRefreshSeries(c *gc.C) {
 	c.Assert(s.apiUnit.Series(), gc.Equals, "xenial")
 }
 
+func (s *unitSuite) TestRefreshStopDeadline(c *gc.C) {
+	_, ok := s.apiUnit.StopDeadline()
+	c.Assert(ok, jc.IsFalse)
+
+	err := s.apiUnit.SetAgentStatus(status.Idle, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	op := s.wordpressUnit.DestroyOperation()
+	op.Force = true
+	err = s.State.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	expect, ok := s.wordpressUnit.StopDeadline()
+	c.Assert(ok, jc.IsTrue)
+
+	err = s.apiUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	deadline, ok := s.apiUnit.StopDeadline()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(deadline.Equal(expect), jc.IsTrue)
+}
+
 func (s *unitSuite) TestWatch(c *gc.C) {
 	c.Assert(s.apiUnit.Life(), gc.Equals, params.Alive)
 
@@ -505,6 +527,39 @@ func (s *unitSuite) TestNetworkInfo(c *gc.C) {
 	c.Assert(called, gc.Equals, 2)
 }
 
+func (s *unitSuite) TestCharmStateSnapshot(c *gc.C) {
+	_, err := s.apiUnit.CharmStateSnapshot("wordpress/0")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+
+	err = s.apiUnit.SetCharmStateSnapshot([]byte("state"))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := s.apiUnit.CharmStateSnapshot("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "state")
+
+	_, err = s.apiUnit.CharmStateSnapshot("mysql/0")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = s.apiUnit.CharmStateSnapshot("invalid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *unitSuite) TestCharmStateSnapshotNotImplemented(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.UnitRefreshResults)) = params.UnitRefreshResults{
+			Results: []params.UnitRefreshResult{{Life: params.Alive, Resolved: params.ResolvedNone, Series: "quantal"}}}
+		return nil
+	})
+	ut := names.NewUnitTag("mysql/0")
+	st := uniter.NewStateV8(apiCaller, ut)
+	unit, err := st.Unit(ut)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = unit.SetCharmStateSnapshot([]byte("state"))
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	_, err = unit.CharmStateSnapshot("mysql/1")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *unitSuite) TestConfigSettings(c *gc.C) {
 	// Make sure ConfigSettings returns an error when
 	// no charm URL is set, as its state counterpart does.
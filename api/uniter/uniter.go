@@ -70,12 +70,12 @@ func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *Stat
 	}
 }
 
-// newStateV7 creates a new client-side Uniter facade, version 7
-var newStateV7 = newStateForVersionFn(7)
+// newStateV9 creates a new client-side Uniter facade, version 9
+var newStateV9 = newStateForVersionFn(9)
 
 // NewState creates a new client-side Uniter facade.
 // Defined like this to allow patching during tests.
-var NewState = newStateV7
+var NewState = newStateV9
 
 // BestAPIVersion returns the API version that we were able to
 // determine is supported by both the client and the API Server.
@@ -260,6 +260,33 @@ func (st *State) ActionBegin(tag names.ActionTag) error {
 	return nil
 }
 
+// LogActionMessage logs a progress message for the specified action.
+func (st *State) LogActionMessage(tag names.ActionTag, message string) error {
+	if st.BestAPIVersion() < 8 {
+		return errors.NotImplementedf("LogActionMessage() (need V8+)")
+	}
+	var outcome params.ErrorResults
+
+	args := params.ActionMessageParams{
+		Messages: []params.ActionMessage{
+			{ActionTag: tag.String(), Message: message},
+		},
+	}
+
+	err := st.facade.FacadeCall("LogActionsMessages", args, &outcome)
+	if err != nil {
+		return err
+	}
+	if len(outcome.Results) != 1 {
+		return fmt.Errorf("expected 1 result, got %d", len(outcome.Results))
+	}
+	result := outcome.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
 // ActionFinish captures the structured output of an action.
 func (st *State) ActionFinish(tag names.ActionTag, status string, results map[string]interface{}, message string) error {
 	var outcome params.ErrorResults
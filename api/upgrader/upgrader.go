@@ -6,6 +6,7 @@ package upgrader
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"github.com/juju/version"
 
 	"github.com/juju/juju/api/base"
@@ -45,6 +46,29 @@ func (st *State) SetVersion(tag string, v version.Binary) error {
 	return results.OneError()
 }
 
+// SetToolsIntegrity reports the outcome of the agent with the given tag
+// checking the integrity of its binaries, which have the given version.
+// The status must be one of the params.AgentIntegrity* values.
+func (st *State) SetToolsIntegrity(tag string, v version.Binary, status, message string) error {
+	if st.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("reporting tools integrity to this juju controller")
+	}
+	var results params.ErrorResults
+	args := params.SetAgentIntegrityArgs{
+		Args: []params.AgentIntegrity{{
+			Tag:     tag,
+			Version: v,
+			Status:  status,
+			Message: message,
+		}},
+	}
+	err := st.facade.FacadeCall("SetToolsIntegrity", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 func (st *State) DesiredVersion(tag string) (version.Number, error) {
 	var results params.VersionResults
 	args := params.Entities{
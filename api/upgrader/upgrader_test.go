@@ -75,6 +75,21 @@ func (s *machineUpgraderSuite) TestSetVersion(c *gc.C) {
 	c.Check(agentTools.Version, gc.Equals, current)
 }
 
+func (s *machineUpgraderSuite) TestSetToolsIntegrity(c *gc.C) {
+	err := s.st.SetToolsIntegrity(s.rawMachine.Tag().String(), current, params.AgentIntegrityVerified, "")
+	c.Assert(err, jc.ErrorIsNil)
+	report, err := s.State.AgentIntegrity(s.rawMachine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Version, gc.Equals, current)
+	c.Check(report.Status, gc.Equals, state.AgentIntegrityVerified)
+}
+
+func (s *machineUpgraderSuite) TestSetToolsIntegrityWrongMachine(c *gc.C) {
+	err := s.st.SetToolsIntegrity("machine-42", current, params.AgentIntegrityVerified, "")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
 func (s *machineUpgraderSuite) TestToolsWrongMachine(c *gc.C) {
 	tools, err := s.st.Tools("machine-42")
 	c.Assert(err, gc.ErrorMatches, "permission denied")
@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package waitfor provides a client for the WaitFor facade, which
+// blocks until a condition on the entities in a model is satisfied.
+package waitfor
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the waitfor API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the waitfor api.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "WaitFor")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// WaitFor waits for up to the given timeout for the condition to be
+// satisfied. The controller may return before the timeout expires,
+// in which case the terms of the condition that are not yet
+// satisfied are returned.
+func (c *Client) WaitFor(condition string, timeout time.Duration) (satisfied bool, unsatisfied []string, _ error) {
+	args := params.WaitForParams{
+		Condition: condition,
+		Timeout:   timeout,
+	}
+	var result params.WaitForResult
+	if err := c.facade.FacadeCall("WaitFor", args, &result); err != nil {
+		return false, nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return false, nil, errors.Trace(result.Error)
+	}
+	return result.Satisfied, result.Unsatisfied, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/waitfor"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type WaitForSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&WaitForSuite{})
+
+func (s *WaitForSuite) TestWaitFor(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "WaitFor")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WaitFor")
+			c.Check(a, jc.DeepEquals, params.WaitForParams{
+				Condition: "application:mysql.units >= 3",
+				Timeout:   time.Minute,
+			})
+			*(result.(*params.WaitForResult)) = params.WaitForResult{
+				Unsatisfied: []string{"application:mysql.units >= 3"},
+			}
+			return nil
+		})
+	client := waitfor.NewClient(apiCaller)
+	satisfied, unsatisfied, err := client.WaitFor("application:mysql.units >= 3", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(satisfied, jc.IsFalse)
+	c.Assert(unsatisfied, jc.DeepEquals, []string{"application:mysql.units >= 3"})
+}
+
+func (s *WaitForSuite) TestWaitForSatisfied(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.WaitForResult)) = params.WaitForResult{Satisfied: true}
+			return nil
+		})
+	client := waitfor.NewClient(apiCaller)
+	satisfied, unsatisfied, err := client.WaitFor("machine:0.status == started", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(satisfied, jc.IsTrue)
+	c.Assert(unsatisfied, gc.HasLen, 0)
+}
+
+func (s *WaitForSuite) TestWaitForError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.WaitForResult)) = params.WaitForResult{
+				Error: common.ServerError(errors.NotValidf(`entity kind "model"`)),
+			}
+			return nil
+		})
+	client := waitfor.NewClient(apiCaller)
+	_, _, err := client.WaitFor("model:foo.status == active", time.Minute)
+	c.Assert(err, gc.ErrorMatches, `entity kind "model" not valid`)
+}
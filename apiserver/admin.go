@@ -155,6 +155,13 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		loginResult.Facades = filterFacades(a.srv.facades, append(filters, IsModelFacade)...)
 		apiRoot = restrictRoot(apiRoot, modelFacadesOnly)
 	}
+	if a.srv.callLimiter != nil && authResult.userLogin {
+		apiRoot = restrictRoot(apiRoot, a.srv.callLimiter.restrict(a.root.entity.Tag(), model.UUID()))
+	}
+	if a.root.entity != nil {
+		apiRoot = deduplicateRoot(apiRoot, a.root.state, a.root.entity.Tag())
+		loginResult.SupportsIdempotencyKeys = true
+	}
 
 	a.root.rpcConn.ServeRoot(apiRoot, serverError)
 
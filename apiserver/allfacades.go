@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/deployer"
 	"github.com/juju/juju/apiserver/facades/agent/diskmanager"
 	"github.com/juju/juju/apiserver/facades/agent/fanconfigurer"
+	"github.com/juju/juju/apiserver/facades/agent/healthchecker"
 	"github.com/juju/juju/apiserver/facades/agent/hostkeyreporter"
 	"github.com/juju/juju/apiserver/facades/agent/keyupdater"
 	"github.com/juju/juju/apiserver/facades/agent/leadership"
@@ -37,36 +38,51 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
 	"github.com/juju/juju/apiserver/facades/agent/upgrader"
 	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/facades/client/agentintegrity"
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/applicationleadership"
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
 	"github.com/juju/juju/apiserver/facades/client/backups" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/block"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/bundle"
-	"github.com/juju/juju/apiserver/facades/client/charms"     // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/client"     // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/cloud"      // ModelUser Read
+	"github.com/juju/juju/apiserver/facades/client/charms" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/client" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/cloud"  // ModelUser Read
+	"github.com/juju/juju/apiserver/facades/client/constraintcapabilities"
 	"github.com/juju/juju/apiserver/facades/client/controller" // ModelUser Admin (although some methods check for read only)
+	"github.com/juju/juju/apiserver/facades/client/controllerhealth"
+	"github.com/juju/juju/apiserver/facades/client/entityfinder"
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
+	"github.com/juju/juju/apiserver/facades/client/healthchecks"
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager"
-	"github.com/juju/juju/apiserver/facades/client/keymanager"     // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/machinemanager" // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/metricsdebug"   // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/modelconfig"    // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/modelmanager"   // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/keymanager"      // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"  // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/metricsdebug"    // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelcheckpoint" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelconfig"     // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelmanager"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/payloads"
+	"github.com/juju/juju/apiserver/facades/client/prunestats"
+	"github.com/juju/juju/apiserver/facades/client/regionlatency"
+	"github.com/juju/juju/apiserver/facades/client/removalplan"
 	"github.com/juju/juju/apiserver/facades/client/resources"
 	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/sshclient" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/statushistoryquery"
 	"github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
+	"github.com/juju/juju/apiserver/facades/client/waitfor"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
+	"github.com/juju/juju/apiserver/facades/controller/charmupgrader"
 	"github.com/juju/juju/apiserver/facades/controller/cleaner"
 	"github.com/juju/juju/apiserver/facades/controller/crosscontroller"
 	"github.com/juju/juju/apiserver/facades/controller/crossmodelrelations"
@@ -81,9 +97,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/migrationmaster"
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
+	"github.com/juju/juju/apiserver/facades/controller/offerconnectionpruner"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
+	"github.com/juju/juju/apiserver/facades/controller/spacediscovery"
 	"github.com/juju/juju/apiserver/facades/controller/statushistory"
 	"github.com/juju/juju/apiserver/facades/controller/undertaker"
 	"github.com/juju/juju/feature"
@@ -119,8 +137,15 @@ func AllFacades() *facade.Registry {
 	}
 
 	reg("Action", 2, action.NewActionAPI)
+	reg("Action", 3, action.NewActionAPIV3) // adds action schedules
+	reg("Action", 4, action.NewActionAPIV4) // adds WatchActionLogs
+	reg("Action", 5, action.NewActionAPIV5) // adds action rollouts
+	reg("Action", 6, action.NewActionAPIV6) // adds run hook context options
 	reg("ActionPruner", 1, actionpruner.NewAPI)
+	reg("ActionScheduler", 1, actionscheduler.NewAPIV1)
+	reg("ActionScheduler", 2, actionscheduler.NewAPI) // adds AdvanceRollouts
 	reg("Agent", 2, agent.NewAgentAPIV2)
+	reg("AgentIntegrity", 1, agentintegrity.NewFacade)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
 
@@ -131,34 +156,61 @@ func AllFacades() *facade.Registry {
 	reg("Application", 2, application.NewFacadeV4)
 	reg("Application", 3, application.NewFacadeV4)
 	reg("Application", 4, application.NewFacadeV4)
-	reg("Application", 5, application.NewFacade) // adds AttachStorage & UpdateApplicationSeries & SetRelationStatus
-
-	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
+	reg("Application", 5, application.NewFacadeV5)   // adds AttachStorage & UpdateApplicationSeries & SetRelationStatus
+	reg("Application", 6, application.NewFacadeV6)   // adds SetLabels & GetLabels, and label selectors
+	reg("Application", 7, application.NewFacadeV7)   // adds GetConfigSchema
+	reg("Application", 8, application.NewFacadeV8)   // adds rolling charm upgrades
+	reg("Application", 9, application.NewFacadeV9)   // adds CharmHistory
+	reg("Application", 10, application.NewFacadeV10) // adds branches
+	reg("Application", 11, application.NewFacadeV11) // adds Kubernetes scale, pod specs and rollout status
+	reg("Application", 12, application.NewFacade)    // adds stop grace periods
+
+	reg("ApplicationLeadership", 1, applicationleadership.NewFacade)
+	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPIV1)
+	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2) // Adds offer ingress networks.
+	reg("ApplicationOffers", 3, applicationoffers.NewOffersAPI)   // Adds offer migration.
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
+	reg("AuditLog", 1, auditlog.NewFacade)
 	reg("Backups", 1, backups.NewFacade)
+	reg("Backups", 2, backups.NewFacadeV2) // adds incremental backups and Prune
+	reg("Backups", 3, backups.NewFacadeV3) // adds streaming to backup targets
+	reg("Backups", 4, backups.NewFacadeV4) // adds RestoreModel
 	reg("Block", 2, block.NewAPI)
 	reg("Bundle", 1, bundle.NewFacade)
+	reg("Bundle", 2, bundle.NewFacadeV2) // Adds ExportBundle.
+	reg("Bundle", 3, bundle.NewFacadeV3) // Adds PlanBundle.
 	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
+	reg("CharmUpgrader", 1, charmupgrader.NewAPI)
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
-	reg("Client", 1, client.NewFacade)
+	reg("Client", 1, client.NewFacadeV1)
+	reg("Client", 2, client.NewFacade) // adds FullStatusDelta
 	reg("Cloud", 1, cloud.NewFacade)
 	if featureflag.Enabled(feature.CAAS) {
 		reg("Cloud", 2, cloud.NewFacadeV2)
 	}
 
+	reg("ConstraintCapabilities", 1, constraintcapabilities.NewFacade)
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
+	reg("Controller", 5, controller.NewControllerAPIv5) // adds ValidateMigration
+	reg("Controller", 6, controller.NewControllerAPIv6) // adds config webhooks
+	reg("ControllerHealth", 1, controllerhealth.NewFacade)
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPI)
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
 	reg("ExternalControllerUpdater", 1, externalcontrollerupdater.NewStateAPI)
 
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
+	reg("EntityFinder", 1, entityfinder.NewFacade)
 	reg("FanConfigurer", 1, fanconfigurer.NewFanConfigurerAPI)
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
+	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5) // adds egress rules
 	reg("FirewallRules", 1, firewallrules.NewFacade)
+	reg("FirewallRules", 2, firewallrules.NewFacadeV2) // adds egress rules
+	reg("HealthChecker", 1, healthchecker.NewFacade)
+	reg("HealthChecks", 1, healthchecks.NewFacade)
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
@@ -173,13 +225,16 @@ func AllFacades() *facade.Registry {
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 	reg("LeadershipService", 2, leadership.NewLeadershipServiceFacade)
 	reg("LifeFlag", 1, lifeflag.NewExternalFacade)
-	reg("Logger", 1, loggerapi.NewLoggerAPI)
+	reg("Logger", 1, loggerapi.NewLoggerAPIV1)
+	reg("Logger", 2, loggerapi.NewLoggerAPI) // adds LoggingOutputFormat
 	reg("LogForwarding", 1, logfwd.NewFacade)
 	reg("MachineActions", 1, machineactions.NewExternalFacade)
 
 	reg("MachineManager", 2, machinemanager.NewFacade)
 	reg("MachineManager", 3, machinemanager.NewFacade)   // Version 3 adds DestroyMachine and ForceDestroyMachine.
 	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Version 4 adds DestroyMachineWithParams.
+	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Version 5 adds StartMachines, StopMachines and RebootMachines.
+	reg("MachineManager", 6, machinemanager.NewFacadeV6) // Version 6 adds RebuildMachine.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPI)
@@ -193,13 +248,19 @@ func AllFacades() *facade.Registry {
 	reg("MigrationMaster", 1, migrationmaster.NewFacade)
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
 	reg("MigrationTarget", 1, migrationtarget.NewFacade)
+	reg("MigrationTarget", 2, migrationtarget.NewFacadeV2) // Version 2 adds CheckCompatibility.
 
+	reg("ModelCheckpoint", 1, modelcheckpoint.NewFacade)
 	reg("ModelConfig", 1, modelconfig.NewFacade)
+	reg("ModelConfig", 2, modelconfig.NewFacadeV2) // adds FanNetworking and SetFanNetworking
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
+	reg("ModelManager", 5, modelmanager.NewFacadeV5) // Adds cloud level defaults and EffectiveModelDefaults.
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
+	reg("OfferConnectionPruner", 1, offerconnectionpruner.NewAPI)
+
 	reg("Payloads", 1, payloads.NewFacade)
 	regHookContext(
 		"PayloadsHookContext", 1,
@@ -211,9 +272,13 @@ func AllFacades() *facade.Registry {
 	reg("Provisioner", 3, provisioner.NewProvisionerAPI)
 	reg("Provisioner", 4, provisioner.NewProvisionerAPI)
 	reg("Provisioner", 5, provisioner.NewProvisionerAPIV5) // v5 adds DistributionGroupByMachineId()
+	reg("Provisioner", 6, provisioner.NewProvisionerAPIV6) // v6 adds DrainUnits()
+	reg("PruneStats", 1, prunestats.NewFacade)
 	reg("ProxyUpdater", 1, proxyupdater.NewAPI)
 	reg("Reboot", 2, reboot.NewRebootAPI)
+	reg("RegionLatency", 1, regionlatency.NewFacade)
 	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPI)
+	reg("RemovalPlan", 1, removalplan.NewFacade)
 
 	reg("Resources", 1, resources.NewPublicFacade)
 	regHookContext(
@@ -228,17 +293,26 @@ func AllFacades() *facade.Registry {
 
 	reg("SSHClient", 1, sshclient.NewFacade)
 	reg("SSHClient", 2, sshclient.NewFacade) // v2 adds AllAddresses() method.
+	reg("SSHClient", 3, sshclient.NewFacade) // v3 adds the manual host key methods.
 
 	reg("Spaces", 2, spaces.NewAPIV2)
-	reg("Spaces", 3, spaces.NewAPI)
+	reg("Spaces", 3, spaces.NewAPIV3)
+	reg("Spaces", 4, spaces.NewAPI) // adds RenameSpaces and MoveSubnets
+	reg("SpaceDiscovery", 1, spacediscovery.NewFacade)
 
 	reg("StatusHistory", 2, statushistory.NewAPI)
+	reg("StatusHistoryQuery", 1, statushistoryquery.NewFacade)
 
 	reg("Storage", 3, storage.NewFacadeV3)
 	reg("Storage", 4, storage.NewFacadeV4) // changes Destroy() method signature.
+	reg("Storage", 5, storage.NewFacadeV5) // adds cloud default pool methods.
+	reg("Storage", 6, storage.NewFacadeV6) // adds ListDetachedStorage and ValidateAttach.
+	reg("Storage", 7, storage.NewFacadeV7) // adds ResizeStorage.
+	reg("Storage", 8, storage.NewFacadeV8) // adds UpdatePool and RemovePool.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
+	reg("StorageProvisioner", 5, storageprovisioner.NewFacadeV5)
 	reg("Subnets", 2, subnets.NewAPI)
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
 	reg("UnitAssigner", 1, unitassigner.New)
@@ -246,11 +320,15 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 4, uniter.NewUniterAPIV4)
 	reg("Uniter", 5, uniter.NewUniterAPIV5)
 	reg("Uniter", 6, uniter.NewUniterAPIV6)
-	reg("Uniter", 7, uniter.NewUniterAPI)
+	reg("Uniter", 7, uniter.NewUniterAPIV7)
+	reg("Uniter", 8, uniter.NewUniterAPIV8) // adds LogActionsMessages
+	reg("Uniter", 9, uniter.NewUniterAPI)   // adds charm state snapshots
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
+	reg("Upgrader", 2, upgrader.NewUpgraderFacadeV2) // adds SetToolsIntegrity
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
 	reg("UserManager", 2, usermanager.NewUserManagerAPI) // Adds ResetPassword
+	reg("WaitFor", 1, waitfor.NewFacade)
 
 	regRaw("AllWatcher", 1, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	// Note: AllModelWatcher uses the same infrastructure as AllWatcher
@@ -39,6 +39,7 @@ import (
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/rpc"
@@ -97,6 +98,14 @@ type Server struct {
 	logsinkRateLimitConfig logsink.RateLimitConfig
 	dbloggers              dbloggers
 
+	// callLimiter, if non-nil, limits the rate at which users
+	// may make API calls.
+	callLimiter *callLimiter
+
+	// auditEntrySink, if non-nil, receives audit records for
+	// ssh-proxy sessions.
+	auditEntrySink audit.AuditEntrySinkFn
+
 	// mu guards the fields below it.
 	mu sync.Mutex
 
@@ -118,6 +127,14 @@ type Server struct {
 	// is to support registering the handlers underneath the
 	// "/introspection" prefix.
 	registerIntrospectionHandlers func(func(string, http.Handler))
+
+	// introspection holds the configuration for the dedicated
+	// introspection listener, if any.
+	introspection *IntrospectionConfig
+
+	// introspectionLis is the TLS listener on which the
+	// introspection endpoints are served, if configured.
+	introspectionLis net.Listener
 }
 
 // LoginValidator functions are used to decide whether login requests
@@ -168,6 +185,11 @@ type ServerConfig struct {
 	// aspects of rate limiting connections and logins.
 	RateLimitConfig RateLimitConfig
 
+	// CallRateLimitConfig, if non-nil, holds parameters to control
+	// the rate at which users may make API calls. If this is nil,
+	// user API calls are not rate limited.
+	CallRateLimitConfig *CallRateLimitConfig
+
 	// LogSinkConfig holds parameters to control the API server's
 	// logsink endpoint behaviour. If this is nil, the values from
 	// DefaultLogSinkConfig() will be used.
@@ -175,6 +197,16 @@ type ServerConfig struct {
 
 	// PrometheusRegisterer registers Prometheus collectors.
 	PrometheusRegisterer prometheus.Registerer
+
+	// IntrospectionConfig, if non-nil, holds the configuration for a
+	// dedicated listener on which the introspection and metrics
+	// endpoints are served, authenticated independently of the API.
+	IntrospectionConfig *IntrospectionConfig
+
+	// AuditEntrySink, if non-nil, receives audit records for SSH
+	// sessions tunnelled through the controller by the ssh-proxy
+	// endpoint.
+	AuditEntrySink audit.AuditEntrySinkFn
 }
 
 // Validate validates the API server configuration.
@@ -191,11 +223,24 @@ func (c ServerConfig) Validate() error {
 	if err := c.RateLimitConfig.Validate(); err != nil {
 		return errors.Annotate(err, "validating rate limit configuration")
 	}
+	if c.CallRateLimitConfig != nil {
+		if err := c.CallRateLimitConfig.Validate(); err != nil {
+			return errors.Annotate(err, "validating call rate limit configuration")
+		}
+	}
 	if c.LogSinkConfig != nil {
 		if err := c.LogSinkConfig.Validate(); err != nil {
 			return errors.Annotate(err, "validating logsink configuration")
 		}
 	}
+	if c.IntrospectionConfig != nil {
+		if c.RegisterIntrospectionHandlers == nil {
+			return errors.NotValidf("IntrospectionConfig without RegisterIntrospectionHandlers")
+		}
+		if err := c.IntrospectionConfig.Validate(); err != nil {
+			return errors.Annotate(err, "validating introspection configuration")
+		}
+	}
 	return nil
 }
 
@@ -325,6 +370,7 @@ func NewServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (*Se
 		cfg.LogSinkConfig = &logSinkConfig
 	}
 	if err := cfg.Validate(); err != nil {
+		closeIntrospectionListener(cfg)
 		return nil, errors.Trace(err)
 	}
 
@@ -337,6 +383,7 @@ func NewServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (*Se
 	if err != nil {
 		// There is no running server around to close the listener.
 		lis.Close()
+		closeIntrospectionListener(cfg)
 		return nil, errors.Trace(err)
 	}
 	return srv, nil
@@ -364,6 +411,8 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		allowModelAccess:              cfg.AllowModelAccess,
 		publicDNSName_:                cfg.AutocertDNSName,
 		registerIntrospectionHandlers: cfg.RegisterIntrospectionHandlers,
+		introspection:                 cfg.IntrospectionConfig,
+		auditEntrySink:                cfg.AuditEntrySink,
 		logsinkRateLimitConfig: logsink.RateLimitConfig{
 			Refill: cfg.LogSinkConfig.RateLimitRefill,
 			Burst:  cfg.LogSinkConfig.RateLimitBurst,
@@ -376,6 +425,10 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		},
 	}
 
+	if cfg.CallRateLimitConfig != nil {
+		srv.callLimiter = newCallLimiter(*cfg.CallRateLimitConfig, cfg.Clock)
+	}
+
 	srv.tlsConfig = srv.newTLSConfig(cfg)
 	srv.lis = newThrottlingListener(
 		tls.NewListener(lis, srv.tlsConfig), cfg.RateLimitConfig, clock.WallClock)
@@ -396,6 +449,12 @@ func newServer(stPool *state.StatePool, lis net.Listener, cfg ServerConfig) (_ *
 		return nil, errors.Annotatef(err, "cannot set initial certificate")
 	}
 
+	if srv.introspection != nil {
+		if srv.introspectionLis, err = srv.newIntrospectionListener(); err != nil {
+			return nil, errors.Annotate(err, "cannot create introspection listener")
+		}
+	}
+
 	logSinkWriter, err := logsink.NewFileWriter(filepath.Join(srv.logDir, "logsink.log"))
 	if err != nil {
 		return nil, errors.Annotate(err, "creating logsink writer")
@@ -532,6 +591,11 @@ func (srv *Server) run() {
 		addr := srv.lis.Addr().String() // Addr not valid after close
 		err := srv.lis.Close()
 		logger.Infof("closed listening socket %q with final error: %v", addr, err)
+		if srv.introspectionLis != nil {
+			addr := srv.introspectionLis.Addr().String()
+			err := srv.introspectionLis.Close()
+			logger.Infof("closed introspection listening socket %q with final error: %v", addr, err)
+		}
 
 		// Break deadlocks caused by leadership BlockUntil... calls.
 		srv.statePool.KillWorkers()
@@ -567,6 +631,14 @@ func (srv *Server) run() {
 		srv.tomb.Kill(srv.processModelRemovals())
 	}()
 
+	if machineTag, ok := srv.tag.(names.MachineTag); ok {
+		srv.wg.Add(1)
+		go func() {
+			defer srv.wg.Done()
+			srv.tomb.Kill(srv.recordLoad(machineTag.Id()))
+		}()
+	}
+
 	// for pat based handlers, they are matched in-order of being
 	// registered, first match wins. So more specific ones have to be
 	// registered first.
@@ -592,6 +664,10 @@ func (srv *Server) run() {
 		logger.Debugf("API http server exited, final error was: %v", err)
 	}()
 
+	if srv.introspectionLis != nil {
+		go srv.serveIntrospection()
+	}
+
 	<-srv.tomb.Dying()
 }
 
@@ -624,6 +700,8 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	logStreamHandler := srv.trackRequests(newLogStreamEndpointHandler(httpCtxt))
 	debugLogHandler := srv.trackRequests(newDebugLogDBHandler(httpCtxt))
 	pubsubHandler := srv.trackRequests(newPubSubHandler(httpCtxt, srv.centralHub))
+	deployProgressHandler := srv.trackRequests(newDeployProgressHandler(httpCtxt))
+	sshProxyHandler := srv.trackRequests(newSSHProxyHandler(httpCtxt, srv.clock, srv.auditEntrySink))
 
 	// This handler is model specific even though it only ever makes sense
 	// for a controller because the API caller that is handed to the worker
@@ -632,6 +710,8 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/model/:modeluuid/pubsub", pubsubHandler)
 	add("/model/:modeluuid/logstream", logStreamHandler)
 	add("/model/:modeluuid/log", debugLogHandler)
+	add("/model/:modeluuid/deploy-progress", deployProgressHandler)
+	add("/model/:modeluuid/ssh-proxy", sshProxyHandler)
 
 	logSinkHandler := logsink.NewHTTPHandler(
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
@@ -968,6 +1048,28 @@ func (srv *Server) mongoPinger() error {
 	}
 }
 
+// recordLoad periodically records the load on the API server in state,
+// so that it can be reported alongside that of the other controllers.
+// Failing to record the load does not stop the API server.
+func (srv *Server) recordLoad(machineId string) error {
+	for {
+		load := state.APIServerLoad{
+			Connections:      srv.ConnectionCount(),
+			TotalConnections: srv.TotalConnections(),
+			LoginAttempts:    srv.LoginAttempts(),
+			Updated:          srv.clock.Now(),
+		}
+		if err := srv.statePool.SystemState().SetAPIServerLoad(machineId, load); err != nil {
+			logger.Warningf("cannot record API server load: %v", err)
+		}
+		select {
+		case <-srv.clock.After(apiServerLoadInterval):
+		case <-srv.tomb.Dying():
+			return tomb.ErrDying
+		}
+	}
+}
+
 // publicDNSName returns the current public hostname.
 func (srv *Server) publicDNSName() string {
 	srv.mu.Lock()
@@ -0,0 +1,174 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/ratelimit"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+)
+
+// maxCallLimiterBuckets is the number of token buckets a callLimiter
+// holds before it discards those that are full. It is a variable so
+// it can be patched in tests.
+var maxCallLimiterBuckets = 10000
+
+// callLimiterIdleTTL is how long a callLimiter keeps a token bucket
+// that is not used, so that buckets for users and models that have
+// gone away do not accumulate. It is a variable so it can be patched
+// in tests.
+var callLimiterIdleTTL = 10 * time.Minute
+
+// CallRateLimitConfig holds parameters to control the rate at which
+// users may make API calls. Calls are limited separately for each
+// user, model and facade.
+type CallRateLimitConfig struct {
+	// Burst is the number of calls a user may make to a facade
+	// in a model before being rate limited.
+	Burst int64
+
+	// Refill is the interval at which further calls are allowed
+	// once the burst has been used up.
+	Refill time.Duration
+}
+
+// Validate validates the call rate limit configuration.
+func (c CallRateLimitConfig) Validate() error {
+	if c.Burst <= 0 {
+		return errors.NotValidf("Burst %d <= 0", c.Burst)
+	}
+	if c.Refill <= 0 {
+		return errors.NotValidf("Refill %s <= 0", c.Refill)
+	}
+	return nil
+}
+
+type callLimiterKey struct {
+	user      string
+	modelUUID string
+	facade    string
+}
+
+// callLimiterBucket is a token bucket along with the time it was
+// last used.
+type callLimiterBucket struct {
+	*ratelimit.Bucket
+	lastUsed time.Time
+}
+
+// callLimiter holds a token bucket for each user, model and facade
+// that has been called recently.
+type callLimiter struct {
+	config  CallRateLimitConfig
+	clock   clock.Clock
+	idleTTL time.Duration
+
+	mu        sync.Mutex
+	buckets   map[callLimiterKey]*callLimiterBucket
+	lastSweep time.Time
+}
+
+func newCallLimiter(config CallRateLimitConfig, clock clock.Clock) *callLimiter {
+	// A bucket must not be discarded before it could have refilled,
+	// or discarding it would loosen the limit.
+	idleTTL := callLimiterIdleTTL
+	if refill := time.Duration(config.Burst) * config.Refill; refill > idleTTL {
+		idleTTL = refill
+	}
+	return &callLimiter{
+		config:    config,
+		clock:     clock,
+		idleTTL:   idleTTL,
+		buckets:   make(map[callLimiterKey]*callLimiterBucket),
+		lastSweep: clock.Now(),
+	}
+}
+
+// restrict returns a function, suitable for passing to restrictRoot,
+// that limits the calls the given user makes in the given model.
+func (l *callLimiter) restrict(user names.Tag, modelUUID string) func(string, string) error {
+	return func(facadeName, _ string) error {
+		return l.check(user, modelUUID, facadeName)
+	}
+}
+
+// check returns an error satisfying params.IsCodeRateLimitExceeded if
+// the given user may not call the facade in the given model now.
+func (l *callLimiter) check(user names.Tag, modelUUID, facadeName string) error {
+	// The Pinger keeps the connection alive, and watchers block
+	// until there are changes, so neither can be called too often.
+	if facadeName == "Pinger" || strings.HasSuffix(facadeName, "Watcher") {
+		return nil
+	}
+	key := callLimiterKey{
+		user:      user.String(),
+		modelUUID: modelUUID,
+		facade:    facadeName,
+	}
+	if l.bucket(key).TakeAvailable(1) == 1 {
+		return nil
+	}
+	logger.Debugf("rate limiting %s calls by %q in model %q", facadeName, user.Id(), modelUUID)
+	return errors.Annotatef(common.ErrRateLimitExceeded, "too many %s calls", facadeName)
+}
+
+func (l *callLimiter) bucket(key callLimiterKey) *ratelimit.Bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	if now.Sub(l.lastSweep) >= l.idleTTL {
+		l.discardIdle(now)
+	}
+	if bucket, ok := l.buckets[key]; ok {
+		bucket.lastUsed = now
+		return bucket.Bucket
+	}
+	if len(l.buckets) >= maxCallLimiterBuckets {
+		// A full bucket is no different from a new one, so
+		// it can be discarded without affecting any limits.
+		for k, bucket := range l.buckets {
+			if bucket.Available() >= l.config.Burst {
+				delete(l.buckets, k)
+			}
+		}
+	}
+	bucket := ratelimit.NewBucketWithClock(
+		l.config.Refill,
+		l.config.Burst,
+		ratelimitClock{l.clock},
+	)
+	l.buckets[key] = &callLimiterBucket{
+		Bucket:   bucket,
+		lastUsed: now,
+	}
+	return bucket
+}
+
+// discardIdle discards the buckets that have not been used for the
+// limiter's idle TTL. It must be called with l.mu held.
+func (l *callLimiter) discardIdle(now time.Time) {
+	for k, bucket := range l.buckets {
+		if now.Sub(bucket.lastUsed) >= l.idleTTL {
+			delete(l.buckets, k)
+		}
+	}
+	l.lastSweep = now
+}
+
+// ratelimitClock adapts clock.Clock to ratelimit.Clock.
+type ratelimitClock struct {
+	clock.Clock
+}
+
+// Sleep is defined by the ratelimit.Clock interface.
+func (c ratelimitClock) Sleep(d time.Duration) {
+	<-c.Clock.After(d)
+}
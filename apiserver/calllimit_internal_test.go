@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type callLimiterSuite struct {
+	coretesting.BaseSuite
+	clock   *testing.Clock
+	limiter *callLimiter
+	bob     names.UserTag
+}
+
+var _ = gc.Suite(&callLimiterSuite{})
+
+func (s *callLimiterSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.limiter = newCallLimiter(CallRateLimitConfig{
+		Burst:  2,
+		Refill: time.Second,
+	}, s.clock)
+	s.bob = names.NewUserTag("bob")
+}
+
+func (s *callLimiterSuite) TestValidate(c *gc.C) {
+	err := CallRateLimitConfig{Refill: time.Second}.Validate()
+	c.Check(err, gc.ErrorMatches, "Burst 0 <= 0 not valid")
+	err = CallRateLimitConfig{Burst: 1}.Validate()
+	c.Check(err, gc.ErrorMatches, "Refill 0s <= 0 not valid")
+}
+
+func (s *callLimiterSuite) TestBurstThenLimited(c *gc.C) {
+	check := s.limiter.restrict(s.bob, coretesting.ModelTag.Id())
+	c.Assert(check("Application", "Deploy"), jc.ErrorIsNil)
+	c.Assert(check("Application", "Deploy"), jc.ErrorIsNil)
+
+	err := check("Application", "Deploy")
+	c.Assert(err, gc.ErrorMatches, "too many Application calls: rate limit exceeded")
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrRateLimitExceeded)
+	c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodeRateLimitExceeded)
+
+	s.clock.Advance(time.Second)
+	c.Assert(check("Application", "Deploy"), jc.ErrorIsNil)
+	c.Assert(check("Application", "Deploy"), gc.NotNil)
+}
+
+func (s *callLimiterSuite) TestLimitedSeparately(c *gc.C) {
+	check := s.limiter.restrict(s.bob, coretesting.ModelTag.Id())
+	c.Assert(check("Client", "FullStatus"), jc.ErrorIsNil)
+	c.Assert(check("Client", "FullStatus"), jc.ErrorIsNil)
+	c.Assert(check("Client", "FullStatus"), gc.NotNil)
+
+	// Other facades, users and models have their own limits.
+	c.Assert(check("Application", "Deploy"), jc.ErrorIsNil)
+	otherUser := s.limiter.restrict(names.NewUserTag("mary"), coretesting.ModelTag.Id())
+	c.Assert(otherUser("Client", "FullStatus"), jc.ErrorIsNil)
+	otherModel := s.limiter.restrict(s.bob, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	c.Assert(otherModel("Client", "FullStatus"), jc.ErrorIsNil)
+}
+
+func (s *callLimiterSuite) TestPingerAndWatchersNotLimited(c *gc.C) {
+	check := s.limiter.restrict(s.bob, coretesting.ModelTag.Id())
+	for i := 0; i < 5; i++ {
+		c.Assert(check("Pinger", "Ping"), jc.ErrorIsNil)
+		c.Assert(check("AllWatcher", "Next"), jc.ErrorIsNil)
+	}
+}
+
+func (s *callLimiterSuite) TestFullBucketsDiscarded(c *gc.C) {
+	s.PatchValue(&maxCallLimiterBuckets, 2)
+	c.Assert(s.limiter.check(s.bob, "model-1", "Client"), jc.ErrorIsNil)
+	c.Assert(s.limiter.check(s.bob, "model-2", "Client"), jc.ErrorIsNil)
+	s.clock.Advance(time.Second)
+	c.Assert(s.limiter.check(s.bob, "model-3", "Client"), jc.ErrorIsNil)
+	c.Assert(s.limiter.buckets, gc.HasLen, 1)
+}
+
+func (s *callLimiterSuite) TestIdleBucketsDiscarded(c *gc.C) {
+	s.PatchValue(&callLimiterIdleTTL, time.Minute)
+	limiter := newCallLimiter(CallRateLimitConfig{
+		Burst:  2,
+		Refill: time.Second,
+	}, s.clock)
+	c.Assert(limiter.check(s.bob, "model-1", "Client"), jc.ErrorIsNil)
+	s.clock.Advance(30 * time.Second)
+	c.Assert(limiter.check(s.bob, "model-2", "Client"), jc.ErrorIsNil)
+	c.Assert(limiter.buckets, gc.HasLen, 2)
+
+	s.clock.Advance(30 * time.Second)
+	c.Assert(limiter.check(s.bob, "model-2", "Client"), jc.ErrorIsNil)
+	c.Assert(limiter.buckets, gc.HasLen, 1)
+	_, ok := limiter.buckets[callLimiterKey{
+		user:      s.bob.String(),
+		modelUUID: "model-2",
+		facade:    "Client",
+	}]
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *callLimiterSuite) TestIdleBucketsKeptUntilRefilled(c *gc.C) {
+	s.PatchValue(&callLimiterIdleTTL, time.Second)
+	limiter := newCallLimiter(CallRateLimitConfig{
+		Burst:  2,
+		Refill: time.Minute,
+	}, s.clock)
+	check := limiter.restrict(s.bob, coretesting.ModelTag.Id())
+	c.Assert(check("Client", "FullStatus"), jc.ErrorIsNil)
+	c.Assert(check("Client", "FullStatus"), jc.ErrorIsNil)
+
+	// The bucket is idle for longer than the TTL, but not long
+	// enough to have refilled, so the limit still applies.
+	s.clock.Advance(time.Minute - time.Second)
+	c.Assert(limiter.check(s.bob, "model-2", "Client"), jc.ErrorIsNil)
+	c.Assert(check("Client", "FullStatus"), gc.NotNil)
+}
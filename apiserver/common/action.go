@@ -131,6 +131,28 @@ func FinishActions(args params.ActionExecutionResults, actionFn func(string) (st
 	return results
 }
 
+// LogActionsMessages records the progress messages of running actions.
+// It's a helper function currently used by the uniter.
+// It needs an actionFn that can fetch an action from state using it's id that's usually created by AuthAndActionFromTagFn
+func LogActionsMessages(args params.ActionMessageParams, actionFn func(string) (state.Action, error)) params.ErrorResults {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Messages))}
+
+	for i, arg := range args.Messages {
+		action, err := actionFn(arg.ActionTag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+
+		if err := action.Log(arg.Message); err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+	}
+
+	return results
+}
+
 // Actions returns the Actions by Tags passed in and ensures that the receiver asking for
 // them is the same one that has the action.
 // It's a helper function currently used by the uniter and by machineactions.
@@ -141,6 +141,31 @@ func (s *actionsSuite) TestBeginActions(c *gc.C) {
 	})
 }
 
+func (s *actionsSuite) TestLogActionsMessages(c *gc.C) {
+	args := params.ActionMessageParams{
+		Messages: []params.ActionMessage{
+			{ActionTag: "success", Message: "hello"},
+			{ActionTag: "fail", Message: "hello"},
+			{ActionTag: "invalid", Message: "hello"},
+		},
+	}
+	expectErr := errors.New("explosivo")
+	actionFn := makeGetActionByTagString(map[string]state.Action{
+		"success": fakeAction{},
+		"fail":    fakeAction{logErr: expectErr},
+	})
+
+	results := common.LogActionsMessages(args, actionFn)
+
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		[]params.ErrorResult{
+			{},
+			{common.ServerError(expectErr)},
+			{common.ServerError(actionNotFoundErr)},
+		},
+	})
+}
+
 func (s *actionsSuite) TestGetActions(c *gc.C) {
 	args := entities("success", "fail", "notPending")
 	actionFn := makeGetActionByTagString(map[string]state.Action{
@@ -333,6 +358,7 @@ type fakeAction struct {
 	receiver  string
 	name      string
 	beginErr  error
+	logErr    error
 	finishErr error
 	status    state.ActionStatus
 }
@@ -345,6 +371,10 @@ func (mock fakeAction) Begin() (state.Action, error) {
 	return nil, mock.beginErr
 }
 
+func (mock fakeAction) Log(string) error {
+	return mock.logErr
+}
+
 func (mock fakeAction) Receiver() string {
 	return mock.receiver
 }
@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package configwebhook consults the validation webhooks registered
+// with the controller about proposed config changes, so that policy
+// can be enforced centrally before the changes are committed.
+package configwebhook
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd/webhook"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides the config webhooks registered with the controller,
+// and identifies the model in which changes are made.
+type Backend interface {
+	ConfigWebhooks() ([]state.ConfigWebhook, error)
+	ModelTag() names.ModelTag
+}
+
+// Request describes a proposed config change. It is posted, encoded
+// as JSON, to each webhook registered for its kind of change.
+type Request struct {
+	// Kind is the kind of config being changed.
+	Kind state.ConfigWebhookKind `json:"kind"`
+
+	// ModelUUID identifies the model in which the change is made. It
+	// is filled in by Validate.
+	ModelUUID string `json:"model-uuid"`
+
+	// Application is the name of the application whose config is
+	// being changed, for application config changes.
+	Application string `json:"application,omitempty"`
+
+	// Branch is the name of the branch in which an application
+	// config change is being staged, if any.
+	Branch string `json:"branch,omitempty"`
+
+	// User is the name of the user making the change.
+	User string `json:"user,omitempty"`
+
+	// Set holds the config values being set.
+	Set map[string]interface{} `json:"set,omitempty"`
+
+	// Unset holds the names of the config values being reset to
+	// their defaults.
+	Unset []string `json:"unset,omitempty"`
+}
+
+// Validate posts the request to each of the webhooks registered for
+// its kind of change, in name order. A webhook allows the change by
+// responding with a 2xx status; any other response, or a failure to
+// reach the webhook, rejects it.
+func Validate(backend Backend, req Request) error {
+	if len(req.Set) == 0 && len(req.Unset) == 0 {
+		return nil
+	}
+	webhooks, err := backend.ConfigWebhooks()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, w := range webhooks {
+		if !w.Handles(req.Kind) {
+			continue
+		}
+		if req.ModelUUID == "" {
+			req.ModelUUID = backend.ModelTag().Id()
+		}
+		if err := consult(w, req); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func consult(w state.ConfigWebhook, req Request) error {
+	httpClient, err := webhook.NewHTTPClient(webhook.RawConfig{
+		URL:    w.URL,
+		CACert: w.CACert,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "config webhook %q", w.Name)
+	}
+	err = webhook.PostJSON(httpClient, w.URL, "application/json", req)
+	if respErr, ok := errors.Cause(err).(*webhook.ResponseError); ok {
+		// Report the webhook's explanation of the rejection,
+		// if it gave one.
+		message := respErr.Message
+		if message == "" {
+			message = respErr.Status
+		}
+		return errors.Errorf("config change rejected by webhook %q: %s", w.Name, message)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "consulting config webhook %q", w.Name)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package configwebhook_test
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common/configwebhook"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigWebhookSuite struct {
+	testing.IsolationSuite
+
+	status   int
+	requests []configwebhook.Request
+	server   *httptest.Server
+	backend  *fakeBackend
+}
+
+var _ = gc.Suite(&ConfigWebhookSuite{})
+
+func (s *ConfigWebhookSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.status = http.StatusOK
+	s.requests = nil
+	s.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body configwebhook.Request
+		err := json.NewDecoder(req.Body).Decode(&body)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		s.requests = append(s.requests, body)
+		w.WriteHeader(s.status)
+		if s.status != http.StatusOK {
+			w.Write([]byte("apt-mirror must be internal\n"))
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.backend = &fakeBackend{webhooks: []state.ConfigWebhook{{
+		Name:   "policy",
+		URL:    s.server.URL + "/validate",
+		CACert: serverCACert(s.server),
+		Kinds:  []state.ConfigWebhookKind{state.ModelConfigWebhook},
+	}}}
+}
+
+// serverCACert returns the PEM-encoded certificate of the test server,
+// which is self-signed.
+func serverCACert(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: server.TLS.Certificates[0].Certificate[0],
+	}))
+}
+
+var modelConfigRequest = configwebhook.Request{
+	Kind:  state.ModelConfigWebhook,
+	User:  "bob",
+	Set:   map[string]interface{}{"apt-mirror": "http://mirror.example.com"},
+	Unset: []string{"ftp-proxy"},
+}
+
+func (s *ConfigWebhookSuite) TestValidateAllowed(c *gc.C) {
+	err := configwebhook.Validate(s.backend, modelConfigRequest)
+	c.Assert(err, jc.ErrorIsNil)
+	expected := modelConfigRequest
+	expected.ModelUUID = coretesting.ModelTag.Id()
+	c.Assert(s.requests, jc.DeepEquals, []configwebhook.Request{expected})
+}
+
+func (s *ConfigWebhookSuite) TestValidateRejected(c *gc.C) {
+	s.status = http.StatusForbidden
+	err := configwebhook.Validate(s.backend, modelConfigRequest)
+	c.Assert(err, gc.ErrorMatches, `config change rejected by webhook "policy": apt-mirror must be internal`)
+}
+
+func (s *ConfigWebhookSuite) TestValidateRejectedNoMessage(c *gc.C) {
+	s.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	err := configwebhook.Validate(s.backend, modelConfigRequest)
+	c.Assert(err, gc.ErrorMatches, `config change rejected by webhook "policy": 403 Forbidden`)
+}
+
+func (s *ConfigWebhookSuite) TestValidateUntrustedCertificate(c *gc.C) {
+	s.backend.webhooks[0].CACert = ""
+	err := configwebhook.Validate(s.backend, modelConfigRequest)
+	c.Assert(err, gc.ErrorMatches, `consulting config webhook "policy": .*certificate.*`)
+	c.Assert(s.requests, gc.HasLen, 0)
+}
+
+func (s *ConfigWebhookSuite) TestValidateOtherKind(c *gc.C) {
+	req := modelConfigRequest
+	req.Kind = state.ApplicationConfigWebhook
+	req.Application = "mysql"
+	err := configwebhook.Validate(s.backend, req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 0)
+}
+
+func (s *ConfigWebhookSuite) TestValidateNoChanges(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	err := configwebhook.Validate(s.backend, configwebhook.Request{
+		Kind: state.ModelConfigWebhook,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 0)
+}
+
+func (s *ConfigWebhookSuite) TestValidateBackendError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	err := configwebhook.Validate(s.backend, modelConfigRequest)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeBackend struct {
+	webhooks []state.ConfigWebhook
+	err      error
+}
+
+func (b *fakeBackend) ConfigWebhooks() ([]state.ConfigWebhook, error) {
+	return b.webhooks, b.err
+}
+
+func (b *fakeBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
@@ -6,6 +6,7 @@ package crossmodel
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -202,8 +203,9 @@ func RelationUnitSettings(backend Backend, ru params.RelationUnit) (params.Setti
 	return paramsSettings, nil
 }
 
-// PublishIngressNetworkChange saves the specified ingress networks for a relation.
-func PublishIngressNetworkChange(backend Backend, relationTag names.Tag, change params.IngressNetworksChangeEvent) error {
+// PublishIngressNetworkChange saves the specified ingress networks for a relation
+// to the specified offer.
+func PublishIngressNetworkChange(backend Backend, relationTag names.Tag, offerUUID string, change params.IngressNetworksChangeEvent) error {
 	logger.Debugf("publish into model %v network change for %v: %+v", backend.ModelUUID(), relationTag, change)
 
 	// Ensure the relation exists.
@@ -216,7 +218,7 @@ func PublishIngressNetworkChange(backend Backend, relationTag names.Tag, change
 	}
 
 	logger.Debugf("relation %v requires ingress networks %v", rel, change.Networks)
-	if err := validateIngressNetworks(backend, change.Networks); err != nil {
+	if err := validateIngressNetworks(backend, offerUUID, change.Networks); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -224,33 +226,55 @@ func PublishIngressNetworkChange(backend Backend, relationTag names.Tag, change
 	return err
 }
 
-func validateIngressNetworks(backend Backend, networks []string) error {
+func validateIngressNetworks(backend Backend, offerUUID string, networks []string) error {
 	if len(networks) == 0 {
 		return nil
 	}
+	var requestedCIDRs []*net.IPNet
+	if err := parseCIDRs(&requestedCIDRs, networks); err != nil {
+		return errors.Trace(err)
+	}
 
-	// Check that the required ingress is allowed.
+	// Check that the required ingress is allowed for offers in the model.
 	rule, err := backend.FirewallRule(state.JujuApplicationOfferRule)
 	if err != nil && !errors.IsNotFound(err) {
 		return errors.Trace(err)
 	}
+	if err == nil {
+		if err := checkSubnetsWhitelisted(requestedCIDRs, rule.WhitelistCIDRs, "firewall whitelist"); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	// And by the offer itself.
+	if offerUUID == "" {
+		return nil
+	}
+	offer, err := backend.ApplicationOfferForUUID(offerUUID)
 	if errors.IsNotFound(err) {
 		return nil
 	}
-	var whitelistCIDRs, requestedCIDRs []*net.IPNet
-	if err := parseCIDRs(&whitelistCIDRs, rule.WhitelistCIDRs); err != nil {
+	if err != nil {
 		return errors.Trace(err)
 	}
-	if err := parseCIDRs(&requestedCIDRs, networks); err != nil {
+	return checkSubnetsWhitelisted(requestedCIDRs, offer.IngressCIDRs, "offer ingress networks")
+}
+
+// checkSubnetsWhitelisted returns a forbidden error if any of the requested
+// subnets is not within the whitelist. An empty whitelist allows any subnet.
+func checkSubnetsWhitelisted(requested []*net.IPNet, whitelist []string, what string) error {
+	var whitelistCIDRs []*net.IPNet
+	if err := parseCIDRs(&whitelistCIDRs, whitelist); err != nil {
 		return errors.Trace(err)
 	}
-	if len(whitelistCIDRs) > 0 {
-		for _, n := range requestedCIDRs {
-			if !network.SubnetInAnyRange(whitelistCIDRs, n) {
-				return &params.Error{
-					Code:    params.CodeForbidden,
-					Message: fmt.Sprintf("subnet %v not in firewall whitelist", n),
-				}
+	if len(whitelistCIDRs) == 0 {
+		return nil
+	}
+	for _, n := range requested {
+		if !network.SubnetInAnyRange(whitelistCIDRs, n) {
+			return &params.Error{
+				Code:    params.CodeForbidden,
+				Message: fmt.Sprintf("subnet %v not in %s", n, what),
 			}
 		}
 	}
@@ -314,7 +338,7 @@ func GetOfferStatusChange(st offerGetter, offerUUID string) (*params.OfferStatus
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &params.OfferStatusChange{
+	change := &params.OfferStatusChange{
 		OfferName: offer.OfferName,
 		Status: params.EntityStatus{
 			Status: status.Status,
@@ -322,5 +346,26 @@ func GetOfferStatusChange(st offerGetter, offerUUID string) (*params.OfferStatus
 			Data:   status.Data,
 			Since:  status.Since,
 		},
-	}, nil
+	}
+	if migration := offer.PendingMigration; migration != nil {
+		// Consumers are told of a pending migration through the
+		// offer's status, which is reflected in the status of
+		// their remote application.
+		data := make(map[string]interface{})
+		for k, v := range status.Data {
+			data[k] = v
+		}
+		data["offer-version"] = offer.Version
+		deadline := migration.Deadline.Format(time.RFC3339)
+		data["migration-deadline"] = deadline
+		change.Status.Data = data
+
+		notice := fmt.Sprintf("offer migrating to version %d, cutover by %s", offer.Version+1, deadline)
+		if change.Status.Info == "" {
+			change.Status.Info = notice
+		} else {
+			change.Status.Info += " (" + notice + ")"
+		}
+	}
+	return change, nil
 }
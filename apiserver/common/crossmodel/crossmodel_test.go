@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common/crossmodel"
+	"github.com/juju/juju/apiserver/params"
+	jujucrossmodel "github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&offerStatusSuite{})
+
+type offerStatusSuite struct {
+	coretesting.BaseSuite
+	backend *mockOfferBackend
+	since   time.Time
+}
+
+func (s *offerStatusSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.since = time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.backend = &mockOfferBackend{
+		offer: jujucrossmodel.ApplicationOffer{
+			OfferUUID:       "offer-uuid",
+			OfferName:       "hosted-mysql",
+			ApplicationName: "mysql",
+		},
+		app: &mockApplication{
+			status: status.StatusInfo{
+				Status:  status.Active,
+				Message: "ready",
+				Since:   &s.since,
+			},
+		},
+	}
+}
+
+func (s *offerStatusSuite) TestGetOfferStatusChange(c *gc.C) {
+	change, err := crossmodel.GetOfferStatusChange(s.backend, "offer-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(change, jc.DeepEquals, &params.OfferStatusChange{
+		OfferName: "hosted-mysql",
+		Status: params.EntityStatus{
+			Status: status.Active,
+			Info:   "ready",
+			Since:  &s.since,
+		},
+	})
+}
+
+func (s *offerStatusSuite) TestGetOfferStatusChangePendingMigration(c *gc.C) {
+	s.backend.offer.Version = 2
+	s.backend.offer.PendingMigration = &jujucrossmodel.OfferMigration{
+		ApplicationName: "mysql2",
+		Deadline:        time.Date(2017, 10, 2, 12, 0, 0, 0, time.UTC),
+	}
+	change, err := crossmodel.GetOfferStatusChange(s.backend, "offer-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(change, jc.DeepEquals, &params.OfferStatusChange{
+		OfferName: "hosted-mysql",
+		Status: params.EntityStatus{
+			Status: status.Active,
+			Info:   "ready (offer migrating to version 3, cutover by 2017-10-02T12:00:00Z)",
+			Data: map[string]interface{}{
+				"offer-version":      2,
+				"migration-deadline": "2017-10-02T12:00:00Z",
+			},
+			Since: &s.since,
+		},
+	})
+}
+
+type mockOfferBackend struct {
+	crossmodel.Backend
+	offer jujucrossmodel.ApplicationOffer
+	app   *mockApplication
+}
+
+func (m *mockOfferBackend) ApplicationOfferForUUID(offerUUID string) (*jujucrossmodel.ApplicationOffer, error) {
+	offer := m.offer
+	return &offer, nil
+}
+
+func (m *mockOfferBackend) Application(name string) (crossmodel.Application, error) {
+	return m.app, nil
+}
+
+type mockApplication struct {
+	crossmodel.Application
+	status status.StatusInfo
+}
+
+func (m *mockApplication) Status() (status.StatusInfo, error) {
+	return m.status, nil
+}
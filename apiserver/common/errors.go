@@ -97,6 +97,7 @@ var (
 	ErrStoppedWatcher     = errors.New("watcher has been stopped")
 	ErrBadRequest         = errors.New("invalid request")
 	ErrTryAgain           = errors.New("try again")
+	ErrRateLimitExceeded  = errors.New("rate limit exceeded")
 	ErrActionNotAvailable = errors.New("action no longer available")
 )
 
@@ -130,6 +131,7 @@ var singletonErrorCodes = map[error]string{
 	ErrUnknownWatcher:            params.CodeNotFound,
 	ErrStoppedWatcher:            params.CodeStopped,
 	ErrTryAgain:                  params.CodeTryAgain,
+	ErrRateLimitExceeded:         params.CodeRateLimitExceeded,
 	ErrActionNotAvailable:        params.CodeActionNotAvailable,
 }
 
@@ -184,6 +186,8 @@ func ServerErrorAndStatus(err error) (*params.Error, int) {
 		status = http.StatusUnauthorized
 	case params.CodeRetry:
 		status = http.StatusServiceUnavailable
+	case params.CodeRateLimitExceeded:
+		status = http.StatusTooManyRequests
 	}
 	return err1, status
 }
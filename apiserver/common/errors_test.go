@@ -133,6 +133,11 @@ var errorTransformTests = []struct {
 	code:       params.CodeTryAgain,
 	status:     http.StatusInternalServerError,
 	helperFunc: params.IsCodeTryAgain,
+}, {
+	err:        common.ErrRateLimitExceeded,
+	code:       params.CodeRateLimitExceeded,
+	status:     http.StatusTooManyRequests,
+	helperFunc: params.IsCodeRateLimitExceeded,
 }, {
 	err:        leadership.ErrClaimDenied,
 	code:       params.CodeLeadershipClaimDenied,
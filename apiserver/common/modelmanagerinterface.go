@@ -43,6 +43,7 @@ type ModelManagerBackend interface {
 	ControllerConfig() (controller.Config, error)
 	ModelConfigDefaultValues() (config.ModelDefaultAttributes, error)
 	UpdateModelConfigDefaultValues(update map[string]interface{}, remove []string, regionSpec *environs.RegionSpec) error
+	EffectiveModelConfigDefaults(regionSpec *environs.RegionSpec) (config.ConfigValues, error)
 	Unit(name string) (*state.Unit, error)
 	ModelTag() names.ModelTag
 	ModelConfig() (*config.Config, error)
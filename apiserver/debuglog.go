@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
@@ -60,10 +61,24 @@ func newDebugLogHandler(
 //   excludeEntity -> []string - lists entity tags to exclude from the response
 //      - as with include, it may finish with a '*'
 //   excludeModule -> []string - lists logging modules to exclude from the response
+//   includeMessage -> []string - lists regular expressions, one of which
+//      each message must match to be included in the response
+//   excludeMessage -> []string - lists regular expressions; messages matching
+//      any of them are excluded from the response
+//   includeLabel -> []string - lists label selectors, one of which each
+//      line must match to be included in the response
+//      - a selector is a comma separated list of label=value pairs, all of
+//      - which must match, e.g.: kind=unit,application=mysql
+//      - the labels are kind, application and version
+//   excludeLabel -> []string - lists label selectors; lines matching any of
+//      them are excluded from the response
 //   limit -> uint - show *at most* this many lines
 //   backlog -> uint
 //      - go back this many lines from the end before starting to filter
 //      - has no meaning if 'replay' is true
+//   maxBacklogBytes -> uint
+//      - go back at most this many bytes of log messages from the end,
+//      - even if that is fewer lines than backlog
 //   level -> string one of [TRACE, DEBUG, INFO, WARNING, ERROR]
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
@@ -148,16 +163,21 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	startTime       time.Time
+	maxLines        uint
+	fromTheStart    bool
+	noTail          bool
+	backlog         uint
+	maxBacklogBytes uint
+	filterLevel     loggo.Level
+	includeEntity   []string
+	excludeEntity   []string
+	includeModule   []string
+	excludeModule   []string
+	includeMessage  []string
+	excludeMessage  []string
+	includeLabel    []state.LogLabelSelector
+	excludeLabel    []state.LogLabelSelector
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -195,6 +215,14 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.backlog = uint(num)
 	}
 
+	if value := queryMap.Get("maxBacklogBytes"); value != "" {
+		num, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return params, errors.Errorf("maxBacklogBytes value %q is not a valid unsigned number", value)
+		}
+		params.maxBacklogBytes = uint(num)
+	}
+
 	if value := queryMap.Get("level"); value != "" {
 		var ok bool
 		level, ok := loggo.ParseLevel(value)
@@ -218,5 +246,40 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 	params.includeModule = queryMap["includeModule"]
 	params.excludeModule = queryMap["excludeModule"]
 
+	// The message patterns and label selectors are checked here,
+	// rather than when the log tailer is created, so that any error
+	// can be reported to the client.
+	for _, key := range []string{"includeMessage", "excludeMessage"} {
+		for _, value := range queryMap[key] {
+			if _, err := regexp.Compile(value); err != nil {
+				return params, errors.Errorf("%s value %q is not a valid regular expression", key, value)
+			}
+		}
+	}
+	params.includeMessage = queryMap["includeMessage"]
+	params.excludeMessage = queryMap["excludeMessage"]
+
+	var err error
+	params.includeLabel, err = readLogLabelSelectors(queryMap, "includeLabel")
+	if err != nil {
+		return params, errors.Trace(err)
+	}
+	params.excludeLabel, err = readLogLabelSelectors(queryMap, "excludeLabel")
+	if err != nil {
+		return params, errors.Trace(err)
+	}
+
 	return params, nil
 }
+
+func readLogLabelSelectors(queryMap url.Values, key string) ([]state.LogLabelSelector, error) {
+	var selectors []state.LogLabelSelector
+	for _, value := range queryMap[key] {
+		selector, err := state.ParseLogLabelSelector(value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "%s value %q", key, value)
+		}
+		selectors = append(selectors, selector)
+	}
+	return selectors, nil
+}
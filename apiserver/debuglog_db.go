@@ -56,14 +56,19 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 	params := state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		StartTime:     reqParams.startTime,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		MinLevel:        reqParams.filterLevel,
+		NoTail:          reqParams.noTail,
+		StartTime:       reqParams.startTime,
+		InitialLines:    int(reqParams.backlog),
+		MaxInitialBytes: int(reqParams.maxBacklogBytes),
+		IncludeEntity:   reqParams.includeEntity,
+		ExcludeEntity:   reqParams.excludeEntity,
+		IncludeModule:   reqParams.includeModule,
+		ExcludeModule:   reqParams.excludeModule,
+		IncludeMessage:  reqParams.includeMessage,
+		ExcludeMessage:  reqParams.excludeMessage,
+		IncludeLabel:    reqParams.includeLabel,
+		ExcludeLabel:    reqParams.excludeLabel,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
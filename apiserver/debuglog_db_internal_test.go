@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/juju/loggo"
@@ -68,6 +69,81 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestParamConversionFilters(c *gc.C) {
+	reqParams := debugLogParams{
+		backlog:         100,
+		maxBacklogBytes: 4096,
+		includeMessage:  []string{"^start"},
+		excludeMessage:  []string{"stop$"},
+		includeLabel:    []state.LogLabelSelector{{"kind": "unit"}},
+		excludeLabel:    []state.LogLabelSelector{{"application": "mysql"}},
+	}
+
+	called := false
+	s.PatchValue(&newLogTailer, func(_ state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
+		called = true
+
+		c.Assert(params.InitialLines, gc.Equals, 100)
+		c.Assert(params.MaxInitialBytes, gc.Equals, 4096)
+		c.Assert(params.IncludeMessage, jc.DeepEquals, []string{"^start"})
+		c.Assert(params.ExcludeMessage, jc.DeepEquals, []string{"stop$"})
+		c.Assert(params.IncludeLabel, jc.DeepEquals, []state.LogLabelSelector{{"kind": "unit"}})
+		c.Assert(params.ExcludeLabel, jc.DeepEquals, []state.LogLabelSelector{{"application": "mysql"}})
+
+		return newFakeLogTailer(), nil
+	})
+
+	stop := make(chan struct{})
+	close(stop) // Stop the request immediately.
+	err := handleDebugLogDBRequest(nil, reqParams, s.sock, stop)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsFilters(c *gc.C) {
+	reqParams, err := readDebugLogParams(url.Values{
+		"includeMessage":  {"^start", "error"},
+		"excludeMessage":  {"stop$"},
+		"includeLabel":    {"kind=unit,application=mysql", "version=2.3.0"},
+		"excludeLabel":    {"kind=machine"},
+		"maxBacklogBytes": {"1024"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reqParams.includeMessage, jc.DeepEquals, []string{"^start", "error"})
+	c.Check(reqParams.excludeMessage, jc.DeepEquals, []string{"stop$"})
+	c.Check(reqParams.includeLabel, jc.DeepEquals, []state.LogLabelSelector{
+		{"kind": "unit", "application": "mysql"},
+		{"version": "2.3.0"},
+	})
+	c.Check(reqParams.excludeLabel, jc.DeepEquals, []state.LogLabelSelector{{"kind": "machine"}})
+	c.Check(reqParams.maxBacklogBytes, gc.Equals, uint(1024))
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsBadFilters(c *gc.C) {
+	for _, test := range []struct {
+		values url.Values
+		err    string
+	}{{
+		values: url.Values{"includeMessage": {"("}},
+		err:    `includeMessage value "\(" is not a valid regular expression`,
+	}, {
+		values: url.Values{"excludeMessage": {"[a-"}},
+		err:    `excludeMessage value "\[a-" is not a valid regular expression`,
+	}, {
+		values: url.Values{"includeLabel": {"colour=blue"}},
+		err:    `includeLabel value "colour=blue": log label "colour" not valid`,
+	}, {
+		values: url.Values{"excludeLabel": {"kind"}},
+		err:    `excludeLabel value "kind": label selector "kind" \(expected label=value\) not valid`,
+	}, {
+		values: url.Values{"maxBacklogBytes": {"-1"}},
+		err:    `maxBacklogBytes value "-1" is not a valid unsigned number`,
+	}} {
+		_, err := readDebugLogParams(test.values)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	reqParams := debugLogParams{
 		fromTheStart: true,
@@ -31,6 +31,12 @@ func (s *debugLogDBSuite) TestBadParams(c *gc.C) {
 	websockettest.AssertWebsocketClosed(c, reader)
 }
 
+func (s *debugLogDBSuite) TestBadLabelParams(c *gc.C) {
+	reader := s.openWebsocket(c, url.Values{"includeLabel": {"kind=user"}})
+	websockettest.AssertJSONError(c, reader, `includeLabel value "kind=user": entity kind "user" not valid`)
+	websockettest.AssertWebsocketClosed(c, reader)
+}
+
 func (s *debugLogDBSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL(c, "http", nil).String()
 	s.sendRequest(c, httpRequestParams{
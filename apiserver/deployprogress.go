@@ -0,0 +1,322 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/gorilla/schema"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/featureflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+// deltaWatcher is the part of a state.Multiwatcher used to follow
+// the progress of a deployment.
+type deltaWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+}
+
+// deployProgressHandler takes requests to stream the progress of an
+// application deployment.
+type deployProgressHandler struct {
+	ctxt httpContext
+}
+
+func newDeployProgressHandler(ctxt httpContext) *deployProgressHandler {
+	return &deployProgressHandler{ctxt: ctxt}
+}
+
+// ServeHTTP will serve up connections as a websocket for the
+// deploy-progress API.
+//
+// Args for the HTTP request are as follows:
+//   application -> string - the name of the application being deployed
+//   units -> int - the number of units that must be deployed for the
+//      application to be considered stable
+func (h *deployProgressHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		defer conn.Close()
+
+		st, releaser, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+		if err != nil {
+			h.sendError(conn, req, err)
+			return
+		}
+		defer releaser()
+
+		cfg, err := readDeployProgressConfig(req.URL.Query())
+		if err != nil {
+			h.sendError(conn, req, err)
+			return
+		}
+
+		watcher := st.Watch(state.WatchParams{})
+		defer watcher.Stop()
+
+		// If we get to here, no more errors to report, so we report a nil
+		// error.  This way the first line of the connection is always a json
+		// formatted simple error.
+		h.sendError(conn, req, nil)
+
+		tracker := newDeployProgressTracker(cfg, clock.WallClock)
+		err = streamDeployProgress(watcher, tracker, conn, h.ctxt.stop(), clientClosed(conn))
+		if err != nil {
+			if isBrokenPipe(err) {
+				logger.Tracef("deploy-progress handler stopped (client disconnected)")
+			} else {
+				logger.Errorf("deploy-progress handler error: %v", err)
+			}
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *deployProgressHandler) sendError(ws *websocket.Conn, req *http.Request, err error) {
+	if err != nil && featureflag.Enabled(feature.DeveloperMode) {
+		logger.Errorf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	}
+	if sendErr := ws.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", err)
+		ws.Close()
+	}
+}
+
+func readDeployProgressConfig(query url.Values) (params.DeployProgressConfig, error) {
+	var cfg params.DeployProgressConfig
+	query.Del(":modeluuid")
+	if err := schema.NewDecoder().Decode(&cfg, query); err != nil {
+		return cfg, errors.Annotate(err, "decoding schema")
+	}
+	if !names.IsValidApplication(cfg.Application) {
+		return cfg, errors.NotValidf("application name %q", cfg.Application)
+	}
+	if cfg.NumUnits < 0 {
+		return cfg, errors.NotValidf("negative number of units")
+	}
+	return cfg, nil
+}
+
+// clientClosed returns a channel that is closed when the client end
+// of the websocket goes away. The client never sends anything on the
+// deploy-progress stream, so any read completing marks the end.
+func clientClosed(conn *websocket.Conn) <-chan struct{} {
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+	return closed
+}
+
+// streamDeployProgress sends the events reported by the tracker for
+// the deltas from the watcher until the deployment is stable or has
+// failed, or until either stop or closed is closed.
+func streamDeployProgress(
+	watcher deltaWatcher,
+	tracker *deployProgressTracker,
+	conn messageWriter,
+	stop, closed <-chan struct{},
+) error {
+	done := make(chan struct{})
+	defer close(done)
+
+	deltasCh := make(chan []multiwatcher.Delta)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case deltasCh <- deltas:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-closed:
+			return nil
+		case err := <-errCh:
+			return errors.Annotate(err, "watching model")
+		case deltas := <-deltasCh:
+			events, finished := tracker.update(deltas)
+			for _, event := range events {
+				if err := conn.WriteJSON(event); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			if finished {
+				return nil
+			}
+		}
+	}
+}
+
+// deployProgressTracker turns the changes to a model into events
+// describing the progress of deploying one of its applications.
+type deployProgressTracker struct {
+	config   params.DeployProgressConfig
+	clock    clock.Clock
+	deployed bool
+	units    map[string]*multiwatcher.UnitInfo
+	machines map[string]*multiwatcher.MachineInfo
+
+	// sent records the last status sent for each kind of event and
+	// entity, so that unchanged statuses are not sent again.
+	sent map[string]multiwatcher.StatusInfo
+}
+
+func newDeployProgressTracker(config params.DeployProgressConfig, clock clock.Clock) *deployProgressTracker {
+	return &deployProgressTracker{
+		config:   config,
+		clock:    clock,
+		units:    make(map[string]*multiwatcher.UnitInfo),
+		machines: make(map[string]*multiwatcher.MachineInfo),
+		sent:     make(map[string]multiwatcher.StatusInfo),
+	}
+}
+
+// update applies the deltas, returning the events to send and
+// whether the deployment has finished, either by becoming stable or
+// by failing.
+func (t *deployProgressTracker) update(deltas []multiwatcher.Delta) ([]params.DeployProgressEvent, bool) {
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ApplicationInfo:
+			if info.Name != t.config.Application {
+				continue
+			}
+			if delta.Removed {
+				return []params.DeployProgressEvent{
+					t.failed(names.NewApplicationTag(info.Name), "application removed"),
+				}, true
+			}
+			t.deployed = true
+		case *multiwatcher.UnitInfo:
+			if info.Application != t.config.Application {
+				continue
+			}
+			if delta.Removed {
+				delete(t.units, info.Name)
+			} else {
+				t.units[info.Name] = info
+			}
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(t.machines, info.Id)
+			} else {
+				t.machines[info.Id] = info
+			}
+		}
+	}
+
+	var events []params.DeployProgressEvent
+	addEvent := func(kind string, tag names.Tag, info multiwatcher.StatusInfo) {
+		key := kind + " " + tag.String()
+		if last, ok := t.sent[key]; ok && last.Current == info.Current && last.Message == info.Message {
+			return
+		}
+		t.sent[key] = info
+		events = append(events, params.DeployProgressEvent{
+			Kind:      kind,
+			Entity:    tag.String(),
+			Status:    string(info.Current),
+			Message:   info.Message,
+			Timestamp: t.timestamp(info),
+		})
+	}
+
+	unitNames := make([]string, 0, len(t.units))
+	for name := range t.units {
+		unitNames = append(unitNames, name)
+	}
+	sort.Strings(unitNames)
+
+	stable := t.deployed && len(t.units) >= t.config.NumUnits
+	for _, name := range unitNames {
+		unit := t.units[name]
+		if machine, ok := t.machines[unit.MachineId]; ok {
+			machineTag := names.NewMachineTag(machine.Id)
+			addEvent(params.DeployProgressMachine, machineTag, machine.InstanceStatus)
+			if machine.InstanceStatus.Current == status.ProvisioningError {
+				return append(events, t.failed(machineTag, machine.InstanceStatus.Message)), true
+			}
+		}
+		unitTag := names.NewUnitTag(name)
+		addEvent(params.DeployProgressUnitAgent, unitTag, unit.AgentStatus)
+		addEvent(params.DeployProgressUnitWorkload, unitTag, unit.WorkloadStatus)
+		if unit.AgentStatus.Current == status.Error {
+			return append(events, t.failed(unitTag, unit.AgentStatus.Message)), true
+		}
+		if unit.WorkloadStatus.Current == status.Error {
+			return append(events, t.failed(unitTag, unit.WorkloadStatus.Message)), true
+		}
+		if !unitStable(unit) {
+			stable = false
+		}
+	}
+	if stable {
+		events = append(events, params.DeployProgressEvent{
+			Kind:      params.DeployProgressStable,
+			Entity:    names.NewApplicationTag(t.config.Application).String(),
+			Timestamp: t.clock.Now(),
+		})
+	}
+	return events, stable
+}
+
+func (t *deployProgressTracker) failed(tag names.Tag, message string) params.DeployProgressEvent {
+	return params.DeployProgressEvent{
+		Kind:      params.DeployProgressFailed,
+		Entity:    tag.String(),
+		Status:    string(status.Error),
+		Message:   message,
+		Timestamp: t.clock.Now(),
+	}
+}
+
+func (t *deployProgressTracker) timestamp(info multiwatcher.StatusInfo) time.Time {
+	if info.Since != nil {
+		return *info.Since
+	}
+	return t.clock.Now()
+}
+
+// unitStable reports whether the unit's agent has finished running
+// hooks and its workload is neither still being set up nor waiting
+// on something else.
+func unitStable(unit *multiwatcher.UnitInfo) bool {
+	if unit.AgentStatus.Current != status.Idle {
+		return false
+	}
+	switch unit.WorkloadStatus.Current {
+	case status.Maintenance, status.Waiting:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type deployProgressSuite struct {
+	coretesting.BaseSuite
+	clock   *testing.Clock
+	tracker *deployProgressTracker
+}
+
+var _ = gc.Suite(&deployProgressSuite{})
+
+func (s *deployProgressSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 11, 1, 10, 0, 0, 0, time.UTC))
+	s.tracker = newDeployProgressTracker(params.DeployProgressConfig{
+		Application: "mysql",
+		NumUnits:    1,
+	}, s.clock)
+}
+
+func statusInfo(current status.Status, message string) multiwatcher.StatusInfo {
+	return multiwatcher.StatusInfo{Current: current, Message: message}
+}
+
+func unitDelta(name, machineId string, agent, workload multiwatcher.StatusInfo) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    "mysql",
+		MachineId:      machineId,
+		AgentStatus:    agent,
+		WorkloadStatus: workload,
+	}}
+}
+
+func machineDelta(id string, instance multiwatcher.StatusInfo) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{
+		Id:             id,
+		InstanceStatus: instance,
+	}}
+}
+
+func (s *deployProgressSuite) event(kind, entity string, current status.Status, message string) params.DeployProgressEvent {
+	return params.DeployProgressEvent{
+		Kind:      kind,
+		Entity:    entity,
+		Status:    string(current),
+		Message:   message,
+		Timestamp: s.clock.Now(),
+	}
+}
+
+func (s *deployProgressSuite) TestReadConfig(c *gc.C) {
+	cfg, err := readDeployProgressConfig(url.Values{
+		":modeluuid":  {"uuid"},
+		"application": {"mysql"},
+		"units":       {"3"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, params.DeployProgressConfig{
+		Application: "mysql",
+		NumUnits:    3,
+	})
+}
+
+func (s *deployProgressSuite) TestReadConfigInvalid(c *gc.C) {
+	_, err := readDeployProgressConfig(url.Values{"application": {"MySQL"}})
+	c.Assert(err, gc.ErrorMatches, `application name "MySQL" not valid`)
+	_, err = readDeployProgressConfig(url.Values{"application": {"mysql"}, "units": {"-1"}})
+	c.Assert(err, gc.ErrorMatches, `negative number of units not valid`)
+}
+
+func (s *deployProgressSuite) TestUpdateToStable(c *gc.C) {
+	events, done := s.tracker.update([]multiwatcher.Delta{
+		{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}},
+		{Entity: &multiwatcher.ApplicationInfo{Name: "wordpress"}},
+		unitDelta("mysql/0", "0", statusInfo(status.Allocating, ""), statusInfo(status.Waiting, "waiting for machine")),
+		machineDelta("0", statusInfo(status.Provisioning, "starting")),
+		machineDelta("1", statusInfo(status.Running, "")),
+	})
+	c.Assert(done, jc.IsFalse)
+	c.Assert(events, jc.DeepEquals, []params.DeployProgressEvent{
+		s.event(params.DeployProgressMachine, "machine-0", status.Provisioning, "starting"),
+		s.event(params.DeployProgressUnitAgent, "unit-mysql-0", status.Allocating, ""),
+		s.event(params.DeployProgressUnitWorkload, "unit-mysql-0", status.Waiting, "waiting for machine"),
+	})
+
+	events, done = s.tracker.update([]multiwatcher.Delta{
+		machineDelta("0", statusInfo(status.Running, "")),
+		unitDelta("mysql/0", "0", statusInfo(status.Executing, "running install hook"), statusInfo(status.Waiting, "waiting for machine")),
+	})
+	c.Assert(done, jc.IsFalse)
+	c.Assert(events, jc.DeepEquals, []params.DeployProgressEvent{
+		s.event(params.DeployProgressMachine, "machine-0", status.Running, ""),
+		s.event(params.DeployProgressUnitAgent, "unit-mysql-0", status.Executing, "running install hook"),
+	})
+
+	events, done = s.tracker.update([]multiwatcher.Delta{
+		unitDelta("mysql/0", "0", statusInfo(status.Idle, ""), statusInfo(status.Active, "ready")),
+	})
+	c.Assert(done, jc.IsTrue)
+	c.Assert(events, jc.DeepEquals, []params.DeployProgressEvent{
+		s.event(params.DeployProgressUnitAgent, "unit-mysql-0", status.Idle, ""),
+		s.event(params.DeployProgressUnitWorkload, "unit-mysql-0", status.Active, "ready"),
+		s.event(params.DeployProgressStable, "application-mysql", "", ""),
+	})
+}
+
+func (s *deployProgressSuite) TestUpdateWaitsForAllUnits(c *gc.C) {
+	s.tracker.config.NumUnits = 2
+	_, done := s.tracker.update([]multiwatcher.Delta{
+		{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}},
+		unitDelta("mysql/0", "0", statusInfo(status.Idle, ""), statusInfo(status.Unknown, "")),
+	})
+	c.Assert(done, jc.IsFalse)
+
+	events, done := s.tracker.update([]multiwatcher.Delta{
+		unitDelta("mysql/1", "1", statusInfo(status.Idle, ""), statusInfo(status.Unknown, "")),
+	})
+	c.Assert(done, jc.IsTrue)
+	c.Assert(events, jc.DeepEquals, []params.DeployProgressEvent{
+		s.event(params.DeployProgressUnitAgent, "unit-mysql-1", status.Idle, ""),
+		s.event(params.DeployProgressUnitWorkload, "unit-mysql-1", status.Unknown, ""),
+		s.event(params.DeployProgressStable, "application-mysql", "", ""),
+	})
+}
+
+func (s *deployProgressSuite) TestUpdateUnitError(c *gc.C) {
+	events, done := s.tracker.update([]multiwatcher.Delta{
+		{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}},
+		unitDelta("mysql/0", "", statusInfo(status.Error, `hook failed: "install"`), statusInfo(status.Maintenance, "")),
+	})
+	c.Assert(done, jc.IsTrue)
+	c.Assert(events[len(events)-1], jc.DeepEquals,
+		s.event(params.DeployProgressFailed, "unit-mysql-0", status.Error, `hook failed: "install"`))
+}
+
+func (s *deployProgressSuite) TestUpdateProvisioningError(c *gc.C) {
+	events, done := s.tracker.update([]multiwatcher.Delta{
+		{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}},
+		unitDelta("mysql/0", "0/lxd/0", statusInfo(status.Allocating, ""), statusInfo(status.Waiting, "")),
+		machineDelta("0/lxd/0", statusInfo(status.ProvisioningError, "no image")),
+	})
+	c.Assert(done, jc.IsTrue)
+	c.Assert(events, jc.DeepEquals, []params.DeployProgressEvent{
+		s.event(params.DeployProgressMachine, "machine-0-lxd-0", status.ProvisioningError, "no image"),
+		s.event(params.DeployProgressFailed, "machine-0-lxd-0", status.Error, "no image"),
+	})
+}
+
+func (s *deployProgressSuite) TestUpdateApplicationRemoved(c *gc.C) {
+	events, done := s.tracker.update([]multiwatcher.Delta{
+		{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}, Removed: true},
+	})
+	c.Assert(done, jc.IsTrue)
+	c.Assert(events, jc.DeepEquals, []params.DeployProgressEvent{
+		s.event(params.DeployProgressFailed, "application-mysql", status.Error, "application removed"),
+	})
+}
+
+func (s *deployProgressSuite) TestStreamStopsWhenStable(c *gc.C) {
+	watcher := &fakeDeltaWatcher{deltas: [][]multiwatcher.Delta{
+		{{Entity: &multiwatcher.ApplicationInfo{Name: "mysql"}}},
+		{unitDelta("mysql/0", "", statusInfo(status.Idle, ""), statusInfo(status.Active, ""))},
+	}}
+	writer := &fakeMessageWriter{}
+	err := streamDeployProgress(watcher, s.tracker, writer, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(writer.messages, gc.HasLen, 3)
+	c.Assert(writer.messages[2].(params.DeployProgressEvent).Kind, gc.Equals, params.DeployProgressStable)
+}
+
+func (s *deployProgressSuite) TestStreamWatcherError(c *gc.C) {
+	watcher := &fakeDeltaWatcher{}
+	err := streamDeployProgress(watcher, s.tracker, &fakeMessageWriter{}, nil, nil)
+	c.Assert(err, gc.ErrorMatches, "watching model: watcher was stopped")
+}
+
+func (s *deployProgressSuite) TestStreamStop(c *gc.C) {
+	watcher := &fakeDeltaWatcher{block: make(chan struct{})}
+	defer close(watcher.block)
+	stop := make(chan struct{})
+	close(stop)
+	err := streamDeployProgress(watcher, s.tracker, &fakeMessageWriter{}, stop, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type fakeDeltaWatcher struct {
+	deltas [][]multiwatcher.Delta
+	block  chan struct{}
+}
+
+func (w *fakeDeltaWatcher) Next() ([]multiwatcher.Delta, error) {
+	if w.block != nil {
+		<-w.block
+	}
+	if len(w.deltas) == 0 {
+		return nil, errors.New("watcher was stopped")
+	}
+	next := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return next, nil
+}
+
+type fakeMessageWriter struct {
+	messages []interface{}
+}
+
+func (w *fakeMessageWriter) WriteJSON(v interface{}) error {
+	w.messages = append(w.messages, v)
+	return nil
+}
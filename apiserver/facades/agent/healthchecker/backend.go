@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthchecker
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// healthchecker facade.
+type Backend interface {
+	Unit(name string) (Unit, error)
+}
+
+// Unit defines the unit functionality required by the healthchecker
+// facade. For details on the methods, see the methods on state.Unit
+// with the same names.
+type Unit interface {
+	AssignedMachineId() (string, error)
+	RecordHealthCheckResults([]state.HealthCheckResult) error
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) Unit(name string) (Unit, error) {
+	unit, err := s.State.Unit(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthchecker provides the HealthChecker facade, which machine
+// agents use to record the results of the health checks declared by the
+// charms of the units they host.
+package healthchecker
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// API provides the HealthChecker API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(NewStateBackend(ctx.State()), ctx.Auth())
+}
+
+// NewAPI returns a new HealthChecker API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// SetHealthCheckResults records the health check results of the given
+// units, which must be hosted by the calling machine agent's machine.
+func (api *API) SetHealthCheckResults(args params.SetHealthCheckResults) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Units)),
+	}
+	for i, arg := range args.Units {
+		err := api.setHealthCheckResults(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setHealthCheckResults(arg params.UnitHealthCheckResults) error {
+	unitTag, err := names.ParseUnitTag(arg.Tag)
+	if err != nil {
+		return common.ErrPerm
+	}
+	unit, err := api.backend.Unit(unitTag.Id())
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !api.authorizer.AuthOwner(names.NewMachineTag(machineId)) {
+		return common.ErrPerm
+	}
+	results := make([]state.HealthCheckResult, len(arg.Results))
+	for i, result := range arg.Results {
+		results[i] = state.HealthCheckResult{
+			Check:   result.Check,
+			Healthy: result.Healthy,
+			Message: result.Message,
+			Time:    result.Time,
+		}
+	}
+	return unit.RecordHealthCheckResults(results)
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthchecker_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/healthchecker"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type healthCheckerSuite struct {
+	coretesting.BaseSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&healthCheckerSuite{})
+
+func (s *healthCheckerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		units: map[string]*mockUnit{
+			"mysql/0":     {machineId: "0"},
+			"mysql/1":     {machineId: "1"},
+			"wordpress/0": {err: errors.NewNotAssigned(nil, "not assigned")},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}
+}
+
+func (s *healthCheckerSuite) TestNewAPIRequiresMachineAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewUnitTag("mysql/0")
+	_, err := healthchecker.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *healthCheckerSuite) TestSetHealthCheckResults(c *gc.C) {
+	api, err := healthchecker.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	checks := []params.HealthCheckResult{
+		{Check: "http", Healthy: false, Message: "refused", Time: now},
+	}
+	results, err := api.SetHealthCheckResults(params.SetHealthCheckResults{
+		Units: []params.UnitHealthCheckResults{
+			{Tag: "unit-mysql-0", Results: checks},
+			{Tag: "unit-mysql-1", Results: checks},
+			{Tag: "unit-wordpress-0", Results: checks},
+			{Tag: "unit-missing-0", Results: checks},
+			{Tag: "machine-0", Results: checks},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(s.backend.units["mysql/0"].recorded, jc.DeepEquals, []state.HealthCheckResult{
+		{Check: "http", Healthy: false, Message: "refused", Time: now},
+	})
+	c.Assert(s.backend.units["mysql/1"].recorded, gc.IsNil)
+}
+
+type mockBackend struct {
+	units map[string]*mockUnit
+}
+
+func (b *mockBackend) Unit(name string) (healthchecker.Unit, error) {
+	unit, ok := b.units[name]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q", name)
+	}
+	return unit, nil
+}
+
+type mockUnit struct {
+	machineId string
+	err       error
+	recorded  []state.HealthCheckResult
+}
+
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	return u.machineId, u.err
+}
+
+func (u *mockUnit) RecordHealthCheckResults(results []state.HealthCheckResult) error {
+	u.recorded = results
+	return nil
+}
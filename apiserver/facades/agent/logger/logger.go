@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 )
@@ -20,10 +21,11 @@ import (
 type Logger interface {
 	WatchLoggingConfig(args params.Entities) params.NotifyWatchResults
 	LoggingConfig(args params.Entities) params.StringResults
+	LoggingOutputFormat(args params.Entities) params.StringResults
 }
 
 // LoggerAPI implements the Logger interface and is the concrete
-// implementation of the api end point.
+// implementation of the api end point for version 2.
 type LoggerAPI struct {
 	state      *state.State
 	model      *state.Model
@@ -31,8 +33,28 @@ type LoggerAPI struct {
 	authorizer facade.Authorizer
 }
 
+// LoggerAPIV1 implements version 1 of the Logger API, which lacks
+// LoggingOutputFormat.
+type LoggerAPIV1 struct {
+	*LoggerAPI
+}
+
 var _ Logger = (*LoggerAPI)(nil)
 
+// NewLoggerAPIV1 creates a new server-side logger API end point for
+// version 1.
+func NewLoggerAPIV1(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*LoggerAPIV1, error) {
+	api, err := NewLoggerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &LoggerAPIV1{api}, nil
+}
+
 // NewLoggerAPI creates a new server-side logger API end point.
 func NewLoggerAPI(
 	st *state.State,
@@ -83,6 +105,14 @@ func (api *LoggerAPI) WatchLoggingConfig(arg params.Entities) params.NotifyWatch
 
 // LoggingConfig reports the logging configuration for the agents specified.
 func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
+	return api.modelConfigResults(arg, func(cfg *config.Config) string {
+		return cfg.LoggingConfig()
+	})
+}
+
+// modelConfigResults returns the value read from the model config by
+// get for each of the agents specified.
+func (api *LoggerAPI) modelConfigResults(arg params.Entities, get func(*config.Config) string) params.StringResults {
 	if len(arg.Entities) == 0 {
 		return params.StringResults{}
 	}
@@ -97,7 +127,7 @@ func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 		err = common.ErrPerm
 		if api.authorizer.AuthOwner(tag) {
 			if configErr == nil {
-				results[i].Result = config.LoggingConfig()
+				results[i].Result = get(config)
 				err = nil
 			} else {
 				err = configErr
@@ -107,3 +137,14 @@ func (api *LoggerAPI) LoggingConfig(arg params.Entities) params.StringResults {
 	}
 	return params.StringResults{Results: results}
 }
+
+// LoggingOutputFormat reports the format in which the agents specified
+// should write their logs.
+func (api *LoggerAPI) LoggingOutputFormat(arg params.Entities) params.StringResults {
+	return api.modelConfigResults(arg, func(cfg *config.Config) string {
+		return cfg.LoggingOutputFormat()
+	})
+}
+
+// LoggingOutputFormat is not available in version 1.
+func (*LoggerAPIV1) LoggingOutputFormat(_, _ struct{}) {}
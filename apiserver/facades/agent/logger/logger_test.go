@@ -144,3 +144,22 @@ func (s *loggerSuite) TestLoggingConfigForAgent(c *gc.C) {
 	c.Assert(result.Error, gc.IsNil)
 	c.Assert(result.Result, gc.Equals, newLoggingConfig)
 }
+
+func (s *loggerSuite) TestLoggingOutputFormatForAgent(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.rawMachine.Tag().String()},
+			{Tag: "machine-12354"},
+		},
+	}
+	results := s.logger.LoggingOutputFormat(args)
+	c.Assert(results.Results, jc.DeepEquals, []params.StringResult{
+		{Result: "text"},
+		{Error: apiservertesting.ErrUnauthorized},
+	})
+
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"logging-output-format": "json"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	results = s.logger.LoggingOutputFormat(args)
+	c.Assert(results.Results[0], jc.DeepEquals, params.StringResult{Result: "json"})
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// DrainUnits moves the workloads off each of the given machines, as
+// the cloud is about to reclaim their instances. Each alive principal
+// unit on a machine is replaced by a new unit of the same application,
+// assigned to a new machine, and is then destroyed. Units which are
+// already dying are left alone, so draining a machine more than once
+// does not add further units.
+func (p *ProvisionerAPIV6) DrainUnits(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := p.getAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := p.getMachine(canAccess, tag)
+		if err == nil {
+			err = drainMachine(p.st, machine)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func drainMachine(st *state.State, machine *state.Machine) error {
+	units, err := machine.Units()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, unit := range units {
+		// Subordinates follow their principals, so there is no
+		// need to replace them separately.
+		if !unit.IsPrincipal() || unit.Life() != state.Alive {
+			continue
+		}
+		app, err := unit.Application()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		replacement, err := app.AddUnit(state.AddUnitParams{})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := st.AssignUnit(replacement, state.AssignNew); err != nil {
+			return errors.Annotatef(err, "assigning replacement for unit %q", unit.Name())
+		}
+		logger.Infof("replacing unit %q on machine %q with %q", unit.Name(), machine.Id(), replacement.Name())
+		if err := unit.Destroy(); err != nil {
+			return errors.Annotatef(err, "destroying unit %q", unit.Name())
+		}
+	}
+	return nil
+}
@@ -119,6 +119,42 @@ func (s *ImageMetadataSuite) TestMetadataFromState(c *gc.C) {
 	s.assertImageMetadataResults(c, result, expected...)
 }
 
+func (s *ImageMetadataSuite) TestMetadataFromImageLookup(c *gc.C) {
+	// Metadata in state is ignored in favour of the model's image ids.
+	expected := s.expectedDataSoureImageMetadata()
+	err := s.State.CloudImageMetadataStorage.SaveMetadata(s.convertCloudImageMetadata(expected[0]))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"image-ids": "quantal/amd64=ami-custom trusty/amd64=ami-other",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := provisioner.NewProvisionerAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.ProvisioningInfo(s.getTestMachinesTags(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	custom := make([][]params.CloudImageMetadata, len(s.machines))
+	for i := range s.machines {
+		custom[i] = []params.CloudImageMetadata{{
+			ImageId: "ami-custom",
+			Version: "12.10",
+			Series:  "quantal",
+			Arch:    "amd64",
+			Source:  "custom",
+			Stream:  "daily",
+		}}
+	}
+	s.assertImageMetadataResults(c, result, custom...)
+
+	// The images are not saved in the controller.
+	saved, err := s.State.CloudImageMetadataStorage.FindMetadata(cloudimagemetadata.MetadataFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(saved, gc.HasLen, 1)
+	c.Assert(saved["default cloud images"], gc.HasLen, len(expected[0]))
+}
+
 func (s *ImageMetadataSuite) getTestMachinesTags(c *gc.C) params.Entities {
 
 	testMachines := make([]params.Entity, len(s.machines))
@@ -112,6 +112,10 @@ func NewProvisionerAPI(st *state.State, resources facade.Resources, authorizer f
 	}
 	urlGetter := common.NewToolsURLGetter(model.UUID(), st)
 	storageProviderRegistry := stateenvirons.NewStorageProviderRegistry(env)
+	storagePoolSettings, err := state.NewStoragePoolSettings(st)
+	if err != nil {
+		return nil, err
+	}
 	return &ProvisionerAPI{
 		Remover:                 common.NewRemover(st, false, getAuthFunc),
 		StatusSetter:            common.NewStatusSetter(st, getAuthFunc),
@@ -134,7 +138,7 @@ func NewProvisionerAPI(st *state.State, resources facade.Resources, authorizer f
 		authorizer:              authorizer,
 		configGetter:            configGetter,
 		storageProviderRegistry: storageProviderRegistry,
-		storagePoolManager:      poolmanager.New(state.NewStateSettings(st), storageProviderRegistry),
+		storagePoolManager:      poolmanager.New(storagePoolSettings, storageProviderRegistry),
 		getAuthFunc:             getAuthFunc,
 		getCanModify:            getCanModify,
 	}, nil
@@ -153,6 +157,21 @@ func NewProvisionerAPIV5(st *state.State, resources facade.Resources, authorizer
 	return &ProvisionerAPIV5{provisionerAPI}, nil
 }
 
+// ProvisionerAPIV6 provides v6 of the provisioner facade, which adds
+// DrainUnits.
+type ProvisionerAPIV6 struct {
+	*ProvisionerAPIV5
+}
+
+// NewProvisionerAPIV6 creates a new server-side Provisioner API facade.
+func NewProvisionerAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ProvisionerAPIV6, error) {
+	provisionerAPI, err := NewProvisionerAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ProvisionerAPIV6{provisionerAPI}, nil
+}
+
 func (p *ProvisionerAPI) getMachine(canAccess common.AuthFunc, tag names.MachineTag) (*state.Machine, error) {
 	if !canAccess(tag) {
 		return nil, common.ErrPerm
@@ -1123,6 +1123,60 @@ func (s *withoutControllerSuite) TestDistributionGroupByMachineIdMachineAgentAut
 	})
 }
 
+func (s *withoutControllerSuite) TestDrainUnits(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machines[1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[1].Tag().String()},
+		{Tag: s.machines[2].Tag().String()},
+		{Tag: "machine-42"},
+		{Tag: "unit-foo-0"},
+	}}
+	provisionerV6 := provisioner.ProvisionerAPIV6{&provisioner.ProvisionerAPIV5{s.provisioner}}
+	result, err := provisionerV6.DrainUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: nil},
+			{Error: nil},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// A unit whose agent never started is removed immediately.
+	err = unit.Refresh()
+	if err == nil {
+		c.Assert(unit.Life(), gc.Not(gc.Equals), state.Alive)
+	} else {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	var replacements []*state.Unit
+	for _, u := range units {
+		if u.Name() != unit.Name() {
+			replacements = append(replacements, u)
+		}
+	}
+	c.Assert(replacements, gc.HasLen, 1)
+	machineId, err := replacements[0].AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Not(gc.Equals), s.machines[1].Id())
+
+	// Draining the machine again does not add more units.
+	_, err = provisionerV6.DrainUnits(params.Entities{Entities: args.Entities[:1]})
+	c.Assert(err, jc.ErrorIsNil)
+	drained, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drained, gc.HasLen, len(units))
+}
+
 func (s *provisionerSuite) TestConstraints(c *gc.C) {
 	// Add a machine with some constraints.
 	cons := constraints.MustParse("cores=123", "mem=8G")
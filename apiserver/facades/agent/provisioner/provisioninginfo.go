@@ -17,10 +17,12 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/state/multiwatcher"
@@ -96,6 +98,16 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
 
+	affinityMachineIds, err := p.machineAffinityMachineIds(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot determine machine affinity")
+	}
+
+	charmLXDProfiles, err := p.machineCharmLXDProfiles(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot determine charm LXD profiles")
+	}
+
 	return &params.ProvisioningInfo{
 		Constraints:       cons,
 		Series:            m.Series(),
@@ -108,9 +120,92 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     imageMetadata,
 		ControllerConfig:  controllerCfg,
+
+		AffinityMachineIds: affinityMachineIds,
+		CharmLXDProfiles:   charmLXDProfiles,
 	}, nil
 }
 
+// machineCharmLXDProfiles returns the non-empty LXD profiles declared
+// by the charms of the units assigned to the machine, keyed by the
+// names of the profiles to create for them.
+func (p *ProvisionerAPI) machineCharmLXDProfiles(m *state.Machine) (map[string]params.CharmLXDProfile, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var profiles map[string]params.CharmLXDProfile
+	processedApps := set.NewStrings()
+	for _, unit := range units {
+		appName := unit.ApplicationName()
+		if processedApps.Contains(appName) {
+			continue
+		}
+		processedApps.Add(appName)
+		app, err := unit.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		profile := ch.LXDProfile()
+		if profile.Empty() {
+			continue
+		}
+		if profiles == nil {
+			profiles = make(map[string]params.CharmLXDProfile)
+		}
+		name := lxdprofile.Name(p.m.Name(), appName, ch.Revision())
+		profiles[name] = params.CharmLXDProfile{
+			Description: profile.Description,
+			Config:      profile.Config,
+			Devices:     profile.Devices,
+		}
+	}
+	return profiles, nil
+}
+
+// machineAffinityMachineIds returns the ids of the machines, other than
+// the given machine, that host units of the application named by the
+// machine's affinity placement directive, if it has one.
+func (p *ProvisionerAPI) machineAffinityMachineIds(m *state.Machine) ([]string, error) {
+	affinity, err := instance.ParseAffinity(m.Placement())
+	if err != nil || affinity == nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := p.st.Application(affinity.Application)
+	if errors.IsNotFound(err) {
+		// The application may have been removed since the machine
+		// was added, in which case there is nothing to place the
+		// machine relative to.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineIds := set.NewStrings()
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if machineId != m.Id() {
+			machineIds.Add(machineId)
+		}
+	}
+	if machineIds.IsEmpty() {
+		return nil, nil
+	}
+	return machineIds.SortedValues(), nil
+}
+
 // machineVolumeParams retrieves VolumeParams for the volumes that should be
 // provisioned with, and attached to, the machine. The client should ignore
 // parameters that it does not know how to handle.
@@ -435,6 +530,12 @@ func (p *ProvisionerAPI) constructImageConstraint(m *state.Machine, env environs
 // It looks for image metadata in state.
 // If none are found, we fall back on original image search in simple streams.
 func (p *ProvisionerAPI) findImageMetadata(imageConstraint *imagemetadata.ImageConstraint, env environs.Environ) ([]params.CloudImageMetadata, error) {
+	// A model's own image lookup takes the place of both the
+	// controller's image metadata and simple streams.
+	if lookup, ok := environs.ConfiguredImageLookup(env.Config()); ok {
+		return imageMetadataFromLookup(lookup, imageConstraint)
+	}
+
 	// Look for image metadata in state.
 	stateMetadata, err := p.imageMetadataFromState(imageConstraint)
 	if err != nil && !errors.IsNotFound(err) {
@@ -463,6 +564,41 @@ func (p *ProvisionerAPI) findImageMetadata(imageConstraint *imagemetadata.ImageC
 	return dsMetadata, nil
 }
 
+// imageMetadataFromLookup returns the image metadata found by the
+// model's image lookup. The metadata is specific to the model, so
+// unlike metadata found in data sources, it is not saved in the
+// controller.
+func imageMetadataFromLookup(lookup imagemetadata.ImageLookup, constraint *imagemetadata.ImageConstraint) ([]params.CloudImageMetadata, error) {
+	found, err := lookup.LookupImages(constraint)
+	if err != nil {
+		return nil, errors.Annotatef(err, "looking up images with %s", lookup.Description())
+	}
+	var all []params.CloudImageMetadata
+	for _, m := range found {
+		mSeries, err := series.VersionSeries(m.Version)
+		if err != nil {
+			logger.Warningf("could not determine series for image id %s: %v", m.Id, err)
+			continue
+		}
+		all = append(all, params.CloudImageMetadata{
+			ImageId:         m.Id,
+			Stream:          m.Stream,
+			Region:          m.RegionName,
+			Version:         m.Version,
+			Series:          mSeries,
+			Arch:            m.Arch,
+			VirtType:        m.VirtType,
+			RootStorageType: m.Storage,
+			Source:          "custom",
+		})
+	}
+	if len(all) == 0 {
+		return nil, errors.NotFoundf("image with %s for series %v, arch %v", lookup.Description(), constraint.Series, constraint.Arches)
+	}
+	logger.Debugf("got from %s %d metadata", lookup.Description(), len(all))
+	return all, nil
+}
+
 // imageMetadataFromState returns image metadata stored in state
 // that matches given criteria.
 func (p *ProvisionerAPI) imageMetadataFromState(constraint *imagemetadata.ImageConstraint) ([]params.CloudImageMetadata, error) {
@@ -6,12 +6,14 @@ package provisioner_test
 import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/agent/provisioner"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/provider/dummy"
@@ -20,6 +22,7 @@ import (
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -239,6 +242,91 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithEndpointBindings(c *gc.
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithAffinityPlacement(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	for _, machine := range []*state.Machine{s.machines[2], s.machines[0]} {
+		unit, err := wordpress.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(machine)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	// Unassigned units are ignored.
+	_, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	affinityMachine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:    "quantal",
+		Jobs:      []state.MachineJob{state.JobHostUnits},
+		Placement: "anti-affinity:wordpress",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	missingMachine, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:    "quantal",
+		Jobs:      []state.MachineJob{state.JobHostUnits},
+		Placement: "affinity:mysql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: affinityMachine.Tag().String()},
+		{Tag: missingMachine.Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.Placement, gc.Equals, "anti-affinity:wordpress")
+	c.Assert(result.Results[0].Result.AffinityMachineIds, jc.DeepEquals, []string{
+		s.machines[0].Id(), s.machines[2].Id(),
+	})
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[1].Result.AffinityMachineIds, gc.HasLen, 0)
+}
+
+func (s *withoutControllerSuite) TestProvisioningInfoWithCharmLXDProfiles(c *gc.C) {
+	profile := &lxdprofile.Profile{
+		Config: map[string]string{"security.nesting": "true"},
+		Devices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		},
+	}
+	ch, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       testcharms.Repo.CharmDir("wordpress"),
+		ID:          charm.MustParseURL("local:quantal/wordpress-3"),
+		StoragePath: "dummy-path",
+		SHA256:      "wordpress-3-sha256",
+		LXDProfile:  profile,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := s.AddTestingApplication(c, "wordpress", ch)
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	for _, app := range []*state.Application{wordpress, wordpress, mysql} {
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(s.machines[0])
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Result.CharmLXDProfiles, jc.DeepEquals, map[string]params.CharmLXDProfile{
+		lxdprofile.Name(model.Name(), "wordpress", 3): {
+			Config:  profile.Config,
+			Devices: profile.Devices,
+		},
+	})
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[1].Result.CharmLXDProfiles, gc.HasLen, 0)
+}
+
 func (s *withoutControllerSuite) TestProvisioningInfoWithUnsuitableSpacesConstraints(c *gc.C) {
 	// Add an empty space.
 	_, err := s.State.AddSpace("empty", "", nil, true)
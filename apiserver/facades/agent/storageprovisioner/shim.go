@@ -28,7 +28,11 @@ func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.
 		return nil, errors.Annotate(err, "getting environ")
 	}
 	registry := stateenvirons.NewStorageProviderRegistry(env)
-	pm := poolmanager.New(state.NewStateSettings(st), registry)
+	poolSettings, err := state.NewStoragePoolSettings(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting storage pool settings")
+	}
+	pm := poolmanager.New(poolSettings, registry)
 
 	backend, err := NewStateBackend(st)
 	if err != nil {
@@ -46,6 +50,15 @@ func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.
 	return NewStorageProvisionerAPIv4(v3), nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPIv5, error) {
+	v4, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewStorageProvisionerAPIv5(v4), nil
+}
+
 type Backend interface {
 	state.EntityFinder
 	state.ModelAccessor
@@ -65,6 +78,8 @@ type Backend interface {
 	WatchModelVolumeAttachments() state.StringsWatcher
 	WatchMachineVolumes(names.MachineTag) state.StringsWatcher
 	WatchMachineVolumeAttachments(names.MachineTag) state.StringsWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
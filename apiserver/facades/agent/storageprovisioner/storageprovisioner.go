@@ -21,6 +21,11 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.storageprovisioner")
 
+// StorageProvisionerAPIv5 provides the StorageProvisioner API v5 facade.
+type StorageProvisionerAPIv5 struct {
+	*StorageProvisionerAPIv4
+}
+
 // StorageProvisionerAPIv4 provides the StorageProvisioner API v4 facade.
 type StorageProvisionerAPIv4 struct {
 	*StorageProvisionerAPIv3
@@ -45,6 +50,11 @@ type StorageProvisionerAPIv3 struct {
 	getAttachmentAuthFunc    func() (func(names.MachineTag, names.Tag) bool, error)
 }
 
+// NewStorageProvisionerAPIv5 creates a new server-side StorageProvisioner v5 facade.
+func NewStorageProvisionerAPIv5(v4 *StorageProvisionerAPIv4) *StorageProvisionerAPIv5 {
+	return &StorageProvisionerAPIv5{v4}
+}
+
 // NewStorageProvisionerAPIv4 creates a new server-side StorageProvisioner v4 facade.
 func NewStorageProvisionerAPIv4(v3 *StorageProvisionerAPIv3) *StorageProvisionerAPIv4 {
 	return &StorageProvisionerAPIv4{v3}
@@ -287,6 +297,13 @@ func (s *StorageProvisionerAPIv3) WatchVolumes(args params.Entities) (params.Str
 	return s.watchStorageEntities(args, s.st.WatchModelVolumes, s.st.WatchMachineVolumes)
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, including requests to
+// resize them.
+func (s *StorageProvisionerAPIv5) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
 // WatchFilesystems watches for changes to filesystems scoped
 // to the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPIv3) WatchFilesystems(args params.Entities) (params.StringsWatchResults, error) {
@@ -713,6 +730,61 @@ func (s *StorageProvisionerAPIv4) RemoveVolumeParams(args params.Entities) (para
 	return results, nil
 }
 
+// ResizeVolumeParams returns the parameters for growing the volumes
+// with the specified tags. If a volume has no pending resize, the
+// result's Size will be zero.
+func (s *StorageProvisionerAPIv5) ResizeVolumeParams(args params.Entities) (params.ResizeVolumeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ResizeVolumeParamsResults{}, err
+	}
+	results := params.ResizeVolumeParamsResults{
+		Results: make([]params.ResizeVolumeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.ResizeVolumeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.ResizeVolumeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.ResizeVolumeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		size, ok := volume.PendingSize()
+		if !ok {
+			return params.ResizeVolumeParams{}, nil
+		}
+		volumeInfo, err := volume.Info()
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		provider, _, err := storagecommon.StoragePoolConfig(
+			volumeInfo.Pool, s.poolManager, s.registry,
+		)
+		if err != nil {
+			return params.ResizeVolumeParams{}, err
+		}
+		return params.ResizeVolumeParams{
+			Provider: string(provider),
+			VolumeId: volumeInfo.VolumeId,
+			Size:     size,
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.ResizeVolumeParamsResult
+		volumeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = volumeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (s *StorageProvisionerAPIv3) FilesystemParams(args params.Entities) (params.FilesystemParamsResults, error) {
@@ -1090,6 +1162,14 @@ func (s *StorageProvisionerAPIv3) SetVolumeInfo(args params.Volumes) (params.Err
 		} else if !canAccessVolume(volumeTag) {
 			return common.ErrPerm
 		}
+		if volume, err := s.st.Volume(volumeTag); err == nil {
+			// The provisioner does not know the pool of a volume
+			// that it is updating, e.g. after resizing it, so we
+			// carry over the existing pool.
+			if oldInfo, err := volume.Info(); err == nil && volumeInfo.Pool == "" {
+				volumeInfo.Pool = oldInfo.Pool
+			}
+		}
 		err = s.st.SetVolumeInfo(volumeTag, volumeInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -1119,6 +1199,13 @@ func (s *StorageProvisionerAPIv3) SetFilesystemInfo(args params.Filesystems) (pa
 		} else if !canAccessFilesystem(filesystemTag) {
 			return common.ErrPerm
 		}
+		if filesystem, err := s.st.Filesystem(filesystemTag); err == nil {
+			// As with volumes, carry over the existing pool of
+			// a filesystem that is being updated.
+			if oldInfo, err := filesystem.Info(); err == nil && filesystemInfo.Pool == "" {
+				filesystemInfo.Pool = oldInfo.Pool
+			}
+		}
 		err = s.st.SetFilesystemInfo(filesystemTag, filesystemInfo)
 		if errors.IsNotFound(err) {
 			return common.ErrPerm
@@ -37,7 +37,7 @@ type provisionerSuite struct {
 	factory    *factory.Factory
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
-	api        *storageprovisioner.StorageProvisionerAPIv4
+	api        *storageprovisioner.StorageProvisionerAPIv5
 }
 
 func (s *provisionerSuite) SetUpTest(c *gc.C) {
@@ -63,7 +63,7 @@ func (s *provisionerSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	v3, err := storageprovisioner.NewStorageProvisionerAPIv3(backend, s.resources, s.authorizer, registry, pm)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = storageprovisioner.NewStorageProvisionerAPIv4(v3)
+	s.api = storageprovisioner.NewStorageProvisionerAPIv5(storageprovisioner.NewStorageProvisionerAPIv4(v3))
 }
 
 func (s *provisionerSuite) TestNewStorageProvisionerAPINonMachine(c *gc.C) {
@@ -509,6 +509,81 @@ func (s *provisionerSuite) TestRemoveVolumeParams(c *gc.C) {
 	})
 }
 
+func (s *provisionerSuite) TestResizeVolumeParams(c *gc.C) {
+	s.setupVolumes(c)
+
+	application := s.factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.factory.MakeCharm(c, &factory.CharmParams{
+			Name: "storage-block",
+		}),
+		Storage: map[string]state.StorageConstraints{
+			"data": {
+				Count: 1,
+				Size:  1,
+				Pool:  "modelscoped",
+			},
+		},
+	})
+	s.factory.MakeUnit(c, &factory.UnitParams{
+		Application: application,
+	})
+	storage, err := s.IAASModel.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage, gc.HasLen, 1)
+	storageVolume, err := s.IAASModel.StorageInstanceVolume(storage[0].StorageTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.SetVolumeInfo(storageVolume.VolumeTag(), state.VolumeInfo{
+		VolumeId: "zing",
+		Size:     1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.ResizeStorage(storage[0].StorageTag(), 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.ResizeVolumeParams(params.Entities{
+		Entities: []params.Entity{
+			{storageVolume.Tag().String()},
+			{"volume-0-0"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ResizeVolumeParamsResults{
+		Results: []params.ResizeVolumeParamsResult{{
+			Result: params.ResizeVolumeParams{
+				Provider: "modelscoped",
+				VolumeId: "zing",
+				Size:     2,
+			},
+		}, {
+			// volume 0/0 has no pending resize.
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: "unauthorized access"},
+		}},
+	})
+
+	// Recording the new size, without a pool, completes the resize.
+	errorResults, err := s.api.SetVolumeInfo(params.Volumes{
+		Volumes: []params.Volume{{
+			VolumeTag: storageVolume.Tag().String(),
+			Info: params.VolumeInfo{
+				VolumeId: "zing",
+				Size:     2,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errorResults.OneError(), jc.ErrorIsNil)
+	volume, err := s.IAASModel.Volume(storageVolume.VolumeTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := volume.PendingSize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Pool, gc.Equals, "modelscoped")
+	c.Assert(info.Size, gc.Equals, uint64(2))
+}
+
 func (s *provisionerSuite) TestFilesystemParams(c *gc.C) {
 	s.setupFilesystems(c)
 	results, err := s.api.FilesystemParams(params.Entities{
@@ -903,6 +978,38 @@ func (s *provisionerSuite) TestWatchVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.IAASModel.ModelTag().String()},
+		{"machine-42"}},
+	}
+	result, err := s.api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[1].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1", "2", "3", "4"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v0Watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, v0Watcher)
+	v1Watcher := s.resources.Get("2")
+	defer statetesting.AssertStop(c, v1Watcher)
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, v0Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+	wc = statetesting.NewStringsWatcherC(c, s.State, v1Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+}
+
 func (s *provisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	s.setupVolumes(c)
 	s.factory.MakeMachine(c, nil)
@@ -31,7 +31,7 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v7) of the Uniter API.
+// UniterAPI implements the latest version (v9) of the Uniter API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -53,9 +53,20 @@ type UniterAPI struct {
 	StorageAPI
 }
 
+// UniterAPIV8 doesn't have the SetCharmStateSnapshots or
+// CharmStateSnapshots methods.
+type UniterAPIV8 struct {
+	UniterAPI
+}
+
+// UniterAPIV7 doesn't have the LogActionsMessages method.
+type UniterAPIV7 struct {
+	UniterAPIV8
+}
+
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
 type UniterAPIV6 struct {
-	UniterAPI
+	UniterAPIV7
 }
 
 // UniterAPIV5 returns a RelationResultsV5 instead of RelationResults
@@ -170,14 +181,36 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 	}, nil
 }
 
+// NewUniterAPIV8 creates an instance of the V8 uniter API.
+func NewUniterAPIV8(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV8, error) {
+	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV7 creates an instance of the V7 uniter API.
+func NewUniterAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV7, error) {
+	uniterAPI, err := NewUniterAPIV8(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV7{
+		UniterAPIV8: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV6 creates an instance of the V6 uniter API.
 func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	uniterAPI, err := NewUniterAPIV7(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV6{
-		UniterAPI: *uniterAPI,
+		UniterAPIV7: *uniterAPI,
 	}, nil
 }
 
@@ -625,6 +658,7 @@ func (u *UniterAPI) charmModifiedVersion(tagStr string, canAccess func(names.Tag
 		return -1, err
 	}
 	var application *state.Application
+	unitName := u.authUnitName()
 	switch entity := unitOrApplication.(type) {
 	case *state.Application:
 		application = entity
@@ -633,10 +667,22 @@ func (u *UniterAPI) charmModifiedVersion(tagStr string, canAccess func(names.Tag
 		if err != nil {
 			return -1, err
 		}
+		unitName = entity.Name()
 	default:
 		return -1, errors.BadRequestf("type %T does not have a CharmModifiedVersion", entity)
 	}
-	return application.CharmModifiedVersion(), nil
+	return application.UnitCharmModifiedVersion(unitName), nil
+}
+
+// authUnitName returns the name of the authenticated unit, or the empty
+// string if the caller is not a unit. A unit sees the charm its
+// application had before a rolling charm upgrade until the upgrade
+// reaches it.
+func (u *UniterAPI) authUnitName() string {
+	if tag, ok := u.auth.GetAuthTag().(names.UnitTag); ok {
+		return tag.Id()
+	}
+	return ""
 }
 
 // CharmURL returns the charm URL for all given units or applications.
@@ -660,10 +706,16 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 			var unitOrApplication state.Entity
 			unitOrApplication, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrApplication.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				var curl *charm.URL
+				var ok bool
+				if application, isApplication := unitOrApplication.(*state.Application); isApplication {
+					curl, ok = application.UnitCharmURL(u.authUnitName())
+				} else {
+					charmURLer := unitOrApplication.(interface {
+						CharmURL() (*charm.URL, bool)
+					})
+					curl, ok = charmURLer.CharmURL()
+				}
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -777,6 +829,75 @@ func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (para
 	return result, nil
 }
 
+// SetCharmStateSnapshots stores the charm state snapshots exported by
+// the given units.
+func (u *UniterAPI) SetCharmStateSnapshots(args params.EntityCharmStateSnapshots) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		err = unit.SetCharmStateSnapshot(entity.Data)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// CharmStateSnapshots returns the charm state snapshots last exported
+// by the given units. Only snapshots exported by units of the calling
+// unit's application may be read; the exporting units need no longer
+// exist.
+func (u *UniterAPI) CharmStateSnapshots(args params.Entities) (params.CharmStateSnapshotResults, error) {
+	result := params.CharmStateSnapshotResults{
+		Results: make([]params.CharmStateSnapshotResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		application, err := names.UnitApplication(tag.Id())
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if application != u.unit.ApplicationName() {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		snapshot, err := u.st.CharmStateSnapshot(tag.Id())
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.Data = snapshot.Data()
+		resultItem.Created = snapshot.Created()
+	}
+	return result, nil
+}
+
 // OpenPorts sets the policy of the port range with protocol to be
 // opened, for all given units.
 func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
@@ -988,6 +1109,23 @@ func (u *UniterAPI) BeginActions(args params.Entities) (params.ErrorResults, err
 	return common.BeginActions(args, actionFn), nil
 }
 
+// LogActionsMessages records the progress messages logged by running
+// Actions.
+func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
 // FinishActions saves the result of a completed Action
 func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.ErrorResults, error) {
 	canAccess, err := u.accessUnit()
@@ -1145,6 +1283,9 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 				result.Results[i].Series = unit.Series()
 				result.Results[i].Life = params.Life(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				if deadline, ok := unit.StopDeadline(); ok {
+					result.Results[i].StopDeadline = &deadline
+				}
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -2189,3 +2330,12 @@ func (u *UniterAPIV4) NetworkInfo(_, _ struct{}) {}
 
 // WatchUnitRelations isn't on the V4 API.
 func (u *UniterAPIV4) WatchUnitRelations(_, _ struct{}) {}
+
+// LogActionsMessages isn't on the V7 API.
+func (u *UniterAPIV7) LogActionsMessages(_, _ struct{}) {}
+
+// SetCharmStateSnapshots isn't on the V8 API.
+func (u *UniterAPIV8) SetCharmStateSnapshots(_, _ struct{}) {}
+
+// CharmStateSnapshots isn't on the V8 API.
+func (u *UniterAPIV8) CharmStateSnapshots(_, _ struct{}) {}
@@ -879,6 +879,54 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestSetCharmStateSnapshots(c *gc.C) {
+	args := params.EntityCharmStateSnapshots{Entities: []params.EntityCharmStateSnapshot{
+		{Tag: "unit-mysql-0", Data: []byte("mysql")},
+		{Tag: "unit-wordpress-0", Data: []byte("wordpress")},
+		{Tag: "unit-foo-42", Data: []byte("foo")},
+	}}
+	result, err := s.uniter.SetCharmStateSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	snapshot, err := s.State.CharmStateSnapshot("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(snapshot.Data()), gc.Equals, "wordpress")
+}
+
+func (s *uniterSuite) TestCharmStateSnapshots(c *gc.C) {
+	err := s.wordpressUnit.SetCharmStateSnapshot([]byte("wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysqlUnit.SetCharmStateSnapshot([]byte("mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := s.State.CharmStateSnapshot("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-wordpress-42"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.CharmStateSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1], jc.DeepEquals, params.CharmStateSnapshotResult{
+		Data:    []byte("wordpress"),
+		Created: snapshot.Created(),
+	})
+	c.Assert(result.Results[2].Error, jc.DeepEquals,
+		apiservertesting.NotFoundError(`charm state snapshot for unit "wordpress/42"`))
+	c.Assert(result.Results[3].Error, gc.NotNil)
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -898,6 +946,43 @@ func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestCharmDuringRollingUpgrade(c *gc.C) {
+	pendingUnit := s.Factory.MakeUnit(c, &jujufactory.UnitParams{
+		Application: s.wordpress,
+		Machine:     s.machine0,
+	})
+	newCharm := s.Factory.MakeCharm(c, &jujufactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err := s.wordpress.SetCharm(state.SetCharmConfig{
+		Charm:          newCharm,
+		RollingUpgrade: &state.RollingCharmUpgrade{BatchSize: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The first batch sees the new charm.
+	args := params.Entities{Entities: []params.Entity{{Tag: "application-wordpress"}}}
+	curls, err := s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curls.Results[0].Result, gc.Equals, newCharm.String())
+	versions, err := s.uniter.CharmModifiedVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions.Results[0].Result, gc.Equals, s.wordpress.CharmModifiedVersion())
+
+	// The units still pending see the previous charm.
+	auth := s.authorizer
+	auth.Tag = pendingUnit.Tag()
+	pendingUniter, err := uniter.NewUniterAPI(s.State, s.resources, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	curls, err = pendingUniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curls.Results[0].Result, gc.Equals, s.wpCharm.String())
+	versions, err = pendingUniter.CharmModifiedVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions.Results[0].Result, gc.Equals, s.wordpress.CharmModifiedVersion()-1)
+}
+
 func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	openedPorts, err := s.wordpressUnit.OpenedPorts()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1665,6 +1750,36 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	c.Assert(started.After(enqueued) || started.Equal(enqueued), jc.IsTrue, gc.Commentf("started should be after or equal to enqueued time"))
 }
 
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = good.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	bad, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = bad.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionMessageParams{Messages: []params.ActionMessage{
+		{ActionTag: good.ActionTag().String(), Message: "hello"},
+		{ActionTag: bad.ActionTag().String(), Message: "hello"},
+		{ActionTag: "action-foo", Message: "hello"},
+	}}
+	res, err := s.uniter.LogActionsMessages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(res.Results[2].Error, gc.NotNil)
+
+	action, err := s.IAASModel.Action(good.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "hello")
+}
+
 func (s *uniterSuite) TestRelation(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	wpEp, err := rel.Endpoint("wordpress")
@@ -2979,6 +3094,33 @@ func (s *uniterSuite) TestRefresh(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, expect)
 }
 
+func (s *uniterSuite) TestRefreshStopDeadline(c *gc.C) {
+	now := time.Now()
+	err := s.wordpressUnit.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.SetStopGracePeriod(5 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressUnit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	deadline, ok := s.wordpressUnit.StopDeadline()
+	c.Assert(ok, jc.IsTrue)
+
+	results, err := s.uniter.Refresh(params.Entities{
+		Entities: []params.Entity{{s.wordpressUnit.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Life, gc.Equals, params.Dying)
+	c.Assert(result.StopDeadline, gc.NotNil)
+	c.Assert(result.StopDeadline.Equal(deadline), jc.IsTrue)
+}
+
 func (s *uniterSuite) TestRefreshNoArgs(c *gc.C) {
 	results, err := s.uniter.Refresh(params.Entities{Entities: []params.Entity{}})
 	c.Assert(err, jc.ErrorIsNil)
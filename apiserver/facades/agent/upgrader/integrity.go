@@ -0,0 +1,45 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrader
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// SetToolsIntegrity records the outcome of the given agents checking
+// the integrity of their binaries.
+func (u *UpgraderAPI) SetToolsIntegrity(args params.SetAgentIntegrityArgs) (params.ErrorResults, error) {
+	return setToolsIntegrity(u.st, u.authorizer, args), nil
+}
+
+// SetToolsIntegrity records the outcome of the given agents checking
+// the integrity of their binaries.
+func (u *UnitUpgraderAPI) SetToolsIntegrity(args params.SetAgentIntegrityArgs) (params.ErrorResults, error) {
+	return setToolsIntegrity(u.st, u.authorizer, args), nil
+}
+
+func setToolsIntegrity(st *state.State, authorizer facade.Authorizer, args params.SetAgentIntegrityArgs) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseTag(arg.Tag)
+		if err != nil || !authorizer.AuthOwner(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		status := state.AgentIntegrityStatus(arg.Status)
+		if status == state.AgentIntegrityTampered {
+			logger.Errorf("%s reports tampered tools %s: %s", names.ReadableString(tag), arg.Version, arg.Message)
+		}
+		err = st.SetAgentIntegrity(tag, arg.Version, status, arg.Message)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results
+}
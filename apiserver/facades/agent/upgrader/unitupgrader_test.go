@@ -243,6 +243,31 @@ func (s *unitUpgraderSuite) TestSetTools(c *gc.C) {
 	c.Check(realTools.URL, gc.Equals, "")
 }
 
+func (s *unitUpgraderSuite) TestSetToolsIntegrity(c *gc.C) {
+	results, err := s.upgrader.SetToolsIntegrity(params.SetAgentIntegrityArgs{
+		Args: []params.AgentIntegrity{{
+			Tag:     s.rawUnit.Tag().String(),
+			Version: current,
+			Status:  params.AgentIntegrityUnverified,
+			Message: "no recorded checksums",
+		}, {
+			Tag:     s.rawMachine.Tag().String(),
+			Version: current,
+			Status:  params.AgentIntegrityVerified,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	report, err := s.State.AgentIntegrity(s.rawUnit.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Version, gc.Equals, current)
+	c.Check(report.Status, gc.Equals, state.AgentIntegrityUnverified)
+	c.Check(report.Message, gc.Equals, "no recorded checksums")
+}
+
 func (s *unitUpgraderSuite) TestDesiredVersionNothing(c *gc.C) {
 	// Not an error to watch nothing
 	results, err := s.upgrader.DesiredVersion(params.Entities{})
@@ -27,8 +27,15 @@ var logger = loggo.GetLogger("juju.apiserver.upgrader")
 // to the exact Upgrader API, so the actual calls that are available
 // do not depend on who is currently connected.
 
-// NewUpgraderFacade provides the signature required for facade registration.
+// NewUpgraderFacade provides the signature required for facade
+// registration of version 1 of the Upgrader facade.
 func NewUpgraderFacade(st *state.State, resources facade.Resources, auth facade.Authorizer) (Upgrader, error) {
+	return NewUpgraderFacadeV2(st, resources, auth)
+}
+
+// NewUpgraderFacadeV2 provides the signature required for facade
+// registration of version 2 of the Upgrader facade.
+func NewUpgraderFacadeV2(st *state.State, resources facade.Resources, auth facade.Authorizer) (UpgraderV2, error) {
 	// The type of upgrader we return depends on who is asking.
 	// Machines get an UpgraderAPI, units get a UnitUpgraderAPI.
 	// This is tested in the api/upgrader package since there
@@ -55,6 +62,12 @@ type Upgrader interface {
 	SetTools(args params.EntitiesVersion) (params.ErrorResults, error)
 }
 
+// UpgraderV2 adds SetToolsIntegrity to the Upgrader API.
+type UpgraderV2 interface {
+	Upgrader
+	SetToolsIntegrity(args params.SetAgentIntegrityArgs) (params.ErrorResults, error)
+}
+
 // UpgraderAPI provides access to the Upgrader API facade.
 type UpgraderAPI struct {
 	*common.ToolsGetter
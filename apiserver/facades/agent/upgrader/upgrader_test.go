@@ -237,6 +237,37 @@ func (s *upgraderSuite) TestSetTools(c *gc.C) {
 	c.Check(realTools.URL, gc.Equals, "")
 }
 
+func (s *upgraderSuite) TestSetToolsIntegrity(c *gc.C) {
+	vers := version.MustParseBinary("2.3.0-quantal-amd64")
+	results, err := s.upgrader.SetToolsIntegrity(params.SetAgentIntegrityArgs{
+		Args: []params.AgentIntegrity{{
+			Tag:     s.rawMachine.Tag().String(),
+			Version: vers,
+			Status:  params.AgentIntegrityTampered,
+			Message: "jujud sha256 mismatch",
+		}, {
+			Tag:     s.apiMachine.Tag().String(),
+			Version: vers,
+			Status:  params.AgentIntegrityVerified,
+		}, {
+			Tag:     s.rawMachine.Tag().String(),
+			Version: vers,
+			Status:  "fine",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `agent integrity status "fine" not valid`)
+
+	report, err := s.State.AgentIntegrity(s.rawMachine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(report.Version, gc.Equals, vers)
+	c.Check(report.Status, gc.Equals, state.AgentIntegrityTampered)
+	c.Check(report.Message, gc.Equals, "jujud sha256 mismatch")
+}
+
 func (s *upgraderSuite) TestDesiredVersionNothing(c *gc.C) {
 	// Not an error to watch nothing
 	results, err := s.upgrader.DesiredVersion(params.Entities{})
@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// ActionAPIV4 implements version 4 of the Action API, which adds
+// watching the messages logged by running actions.
+type ActionAPIV4 struct {
+	*ActionAPIV3
+}
+
+// NewActionAPIV4 returns an initialized ActionAPIV4.
+func NewActionAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV4, error) {
+	api, err := NewActionAPIV3(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV4{api}, nil
+}
+
+// WatchActionLogs returns a StringsWatcher for each given action that
+// notifies of the messages logged by the action while it runs. Each
+// message is a JSON encoded params.ActionMessage; the first event
+// contains all the messages logged so far.
+func (a *ActionAPIV4) WatchActionLogs(args params.Entities) (params.StringsWatchResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		result, err := a.watchActionLogs(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (a *ActionAPIV4) watchActionLogs(tag string) (params.StringsWatchResult, error) {
+	nothing := params.StringsWatchResult{}
+	actionTag, err := names.ParseActionTag(tag)
+	if err != nil {
+		return nothing, common.ErrBadId
+	}
+	if _, err := a.model.ActionByTag(actionTag); err != nil {
+		return nothing, errors.Trace(err)
+	}
+	watch := a.model.WatchActionLogs(actionTag.Id())
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: a.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return nothing, watcher.EnsureErr(watch)
+}
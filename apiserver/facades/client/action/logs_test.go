@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+func (s *actionSuite) TestWatchActionLogs(c *gc.C) {
+	resources := common.NewResources()
+	s.AddCleanup(func(*gc.C) { resources.StopAll() })
+	api, err := action.NewActionAPIV4(s.State, resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.WatchActionLogs(params.Entities{Entities: []params.Entity{
+		{Tag: a.Tag().String()},
+		{Tag: "action-01234567-89ab-cdef-0123-456789abcdef"},
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Changes, gc.HasLen, 1)
+	var message params.ActionMessage
+	err = json.Unmarshal([]byte(results.Results[0].Changes[0]), &message)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(message.Message, gc.Equals, "first")
+
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "id not found")
+
+	c.Assert(resources.Count(), gc.Equals, 1)
+	w := resources.Get(results.Results[0].StringsWatcherId).(state.StringsWatcher)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChanges()
+}
+
+func (s *actionSuite) TestWatchActionLogsPermission(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("alpha@bravo")}
+	api, err := action.NewActionAPIV4(s.State, common.NewResources(), auth)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.WatchActionLogs(params.Entities{})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
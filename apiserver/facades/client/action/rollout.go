@@ -0,0 +1,117 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ActionAPIV5 implements version 5 of the Action API, which adds
+// running actions across the units of an application in batches.
+type ActionAPIV5 struct {
+	*ActionAPIV4
+}
+
+// NewActionAPIV5 returns an initialized ActionAPIV5.
+func NewActionAPIV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV5, error) {
+	api, err := NewActionAPIV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV5{api}, nil
+}
+
+// EnqueueActionRollouts adds rollouts that run an action on every unit
+// of an application, no more than MaxParallel units at a time, waiting
+// BatchDelay between batches and optionally stopping once an action
+// fails. The first batch of each rollout is enqueued immediately.
+func (a *ActionAPIV5) EnqueueActionRollouts(args params.ActionRollouts) (params.ActionRolloutResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+
+	results := params.ActionRolloutResults{
+		Results: make([]params.ActionRolloutResult, len(args.Rollouts)),
+	}
+	for i, arg := range args.Rollouts {
+		rollout, err := a.enqueueActionRollout(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Rollout = actionRolloutToParams(rollout)
+	}
+	return results, nil
+}
+
+func (a *ActionAPIV5) enqueueActionRollout(arg params.ActionRollout) (*state.ActionRollout, error) {
+	appTag, err := names.ParseApplicationTag(arg.Application)
+	if err != nil {
+		return nil, common.ErrBadId
+	}
+	rollout, err := a.model.AddActionRollout(state.ActionRolloutArgs{
+		Application:   appTag.Id(),
+		Name:          arg.Name,
+		Parameters:    arg.Parameters,
+		MaxParallel:   arg.MaxParallel,
+		BatchDelay:    arg.BatchDelay,
+		StopOnFailure: arg.StopOnFailure,
+	})
+	return rollout, errors.Trace(err)
+}
+
+// ListActionRollouts returns all the action rollouts in the model, in
+// the order they were added.
+func (a *ActionAPIV5) ListActionRollouts() (params.ActionRolloutResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+	rollouts, err := a.model.AllActionRollouts()
+	if err != nil {
+		return params.ActionRolloutResults{}, errors.Trace(err)
+	}
+	results := params.ActionRolloutResults{
+		Results: make([]params.ActionRolloutResult, len(rollouts)),
+	}
+	for i, rollout := range rollouts {
+		results.Results[i].Rollout = actionRolloutToParams(rollout)
+	}
+	return results, nil
+}
+
+func actionRolloutToParams(rollout *state.ActionRollout) *params.ActionRollout {
+	unitTags := func(unitNames []string) []string {
+		var tags []string
+		for _, name := range unitNames {
+			tags = append(tags, names.NewUnitTag(name).String())
+		}
+		return tags
+	}
+	var actionTags []string
+	for _, id := range rollout.Actions() {
+		actionTags = append(actionTags, names.NewActionTag(id).String())
+	}
+	return &params.ActionRollout{
+		Id:            rollout.Id(),
+		Application:   names.NewApplicationTag(rollout.Application()).String(),
+		Name:          rollout.Name(),
+		Parameters:    rollout.Parameters(),
+		MaxParallel:   rollout.MaxParallel(),
+		BatchDelay:    rollout.BatchDelay(),
+		StopOnFailure: rollout.StopOnFailure(),
+		Status:        string(rollout.Status()),
+		Pending:       unitTags(rollout.Pending()),
+		Actions:       actionTags,
+		Failed:        unitTags(rollout.Failed()),
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+func (s *actionSuite) newActionAPIV5(c *gc.C) *action.ActionAPIV5 {
+	api, err := action.NewActionAPIV5(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *actionSuite) TestEnqueueActionRollouts(c *gc.C) {
+	api := s.newActionAPIV5(c)
+	results, err := api.EnqueueActionRollouts(params.ActionRollouts{
+		Rollouts: []params.ActionRollout{{
+			Application:   s.wordpress.Tag().String(),
+			Name:          "fakeaction",
+			MaxParallel:   1,
+			BatchDelay:    time.Minute,
+			StopOnFailure: true,
+		}, {
+			Application: s.wordpressUnit.Tag().String(),
+			Name:        "fakeaction",
+		}, {
+			Application: s.wordpress.Tag().String(),
+			Name:        "no-such-action",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	rollout := results.Results[0].Rollout
+	c.Check(rollout.Application, gc.Equals, s.wordpress.Tag().String())
+	c.Check(rollout.MaxParallel, gc.Equals, 1)
+	c.Check(rollout.BatchDelay, gc.Equals, time.Minute)
+	c.Check(rollout.StopOnFailure, jc.IsTrue)
+	c.Check(rollout.Status, gc.Equals, "running")
+	c.Check(rollout.Pending, gc.HasLen, 0)
+	c.Check(rollout.Actions, gc.HasLen, 1)
+
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "id not found")
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `action "no-such-action" for application "wordpress" not valid`)
+
+	pending, err := s.wordpressUnit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 1)
+	c.Check(names.NewActionTag(pending[0].Id()).String(), gc.Equals, rollout.Actions[0])
+
+	list, err := api.ListActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Results, gc.HasLen, 1)
+	c.Check(list.Results[0].Rollout.Id, gc.Equals, rollout.Id)
+}
+
+func (s *actionSuite) TestBlockActionRollouts(c *gc.C) {
+	api := s.newActionAPIV5(c)
+	s.BlockAllChanges(c, "EnqueueActionRollouts")
+	_, err := api.EnqueueActionRollouts(params.ActionRollouts{})
+	s.AssertBlocked(c, err, "EnqueueActionRollouts")
+}
+
+func (s *actionSuite) TestActionRolloutsPermissions(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("alpha@bravo")}
+	api, err := action.NewActionAPIV5(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.EnqueueActionRollouts(params.ActionRollouts{})
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+	_, err = api.ListActionRollouts()
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
@@ -4,12 +4,15 @@
 package action
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/state"
@@ -42,7 +45,9 @@ func getAllUnitNames(st *state.State, units, services []string) (result []names.
 }
 
 // Run the commands specified on the machines identified through the
-// list of machines, units and services.
+// list of machines, units and services. Commands run on units may be
+// given a relation context and additional environment variables, and
+// may have their output streamed as action messages.
 func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err error) {
 	if err := a.checkCanAdmin(); err != nil {
 		return results, err
@@ -51,6 +56,14 @@ func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err
 		return results, errors.Trace(err)
 	}
 
+	context, err := runContextParams(run)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if len(context) > 0 && len(run.Machines) > 0 {
+		return results, errors.New("relation, remote unit, environment and stream options are only valid for units")
+	}
+
 	units, err := getAllUnitNames(a.state, run.Units, run.Applications)
 	if err != nil {
 		return results, errors.Trace(err)
@@ -64,11 +77,69 @@ func (a *ActionAPI) Run(run params.RunParams) (results params.ActionResults, err
 		machines[i] = names.NewMachineTag(machineId)
 	}
 
-	actionParams := a.createActionsParams(append(units, machines...), run.Commands, run.Timeout)
+	actionParams := a.createActionsParams(append(units, machines...), run.Commands, run.Timeout, context)
 
 	return queueActions(a, actionParams)
 }
 
+// runContextParams returns the juju-run action parameters that select
+// the hook context and environment requested in run, and whether to
+// stream the output.
+func runContextParams(run params.RunParams) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	if run.Relation != "" {
+		id := run.Relation
+		if i := strings.LastIndex(id, ":"); i != -1 {
+			id = id[i+1:]
+		}
+		relationId, err := strconv.Atoi(id)
+		if err != nil || relationId < 0 {
+			return nil, errors.NotValidf("relation %q", run.Relation)
+		}
+		result["relation-id"] = relationId
+	}
+	if run.RemoteUnit != "" {
+		if run.Relation == "" {
+			return nil, errors.Errorf("remote unit %q provided without a relation", run.RemoteUnit)
+		}
+		if !names.IsValidUnit(run.RemoteUnit) {
+			return nil, errors.NotValidf("remote unit name %q", run.RemoteUnit)
+		}
+		result["remote-unit"] = run.RemoteUnit
+	}
+	if len(run.Environment) > 0 {
+		env := make(map[string]interface{}, len(run.Environment))
+		for k, v := range run.Environment {
+			if k == "" || strings.Contains(k, "=") {
+				return nil, errors.NotValidf("environment variable name %q", k)
+			}
+			env[k] = v
+		}
+		result["env"] = env
+	}
+	if run.Stream {
+		result["stream"] = true
+	}
+	return result, nil
+}
+
+// ActionAPIV6 implements version 6 of the Action API, which adds
+// running commands on units in a relation context, with additional
+// environment variables, and streaming their output as action
+// messages.
+type ActionAPIV6 struct {
+	*ActionAPIV5
+}
+
+// NewActionAPIV6 returns an initialized ActionAPIV6.
+func NewActionAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV6, error) {
+	api, err := NewActionAPIV5(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV6{api}, nil
+}
+
 // RunOnAllMachines attempts to run the specified command on all the machines.
 func (a *ActionAPI) RunOnAllMachines(run params.RunParams) (results params.ActionResults, err error) {
 	if err := a.checkCanAdmin(); err != nil {
@@ -88,16 +159,19 @@ func (a *ActionAPI) RunOnAllMachines(run params.RunParams) (results params.Actio
 		machineTags[i] = machine.Tag()
 	}
 
-	actionParams := a.createActionsParams(machineTags, run.Commands, run.Timeout)
+	actionParams := a.createActionsParams(machineTags, run.Commands, run.Timeout, nil)
 
 	return queueActions(a, actionParams)
 }
 
-func (a *ActionAPI) createActionsParams(actionReceiverTags []names.Tag, quotedCommands string, timeout time.Duration) params.Actions {
+func (a *ActionAPI) createActionsParams(actionReceiverTags []names.Tag, quotedCommands string, timeout time.Duration, context map[string]interface{}) params.Actions {
 
 	apiActionParams := params.Actions{Actions: []params.Action{}}
 
 	actionParams := map[string]interface{}{}
+	for k, v := range context {
+		actionParams[k] = v
+	}
 	actionParams["command"] = quotedCommands
 	actionParams["timeout"] = timeout.Nanoseconds()
 
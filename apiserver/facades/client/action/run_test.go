@@ -210,6 +210,69 @@ func (s *runSuite) TestRunMachineAndService(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *runSuite) TestRunHookContext(c *gc.C) {
+	expectedPayload := map[string]interface{}{
+		"command":     "relation-get",
+		"timeout":     int64(0),
+		"relation-id": 3,
+		"remote-unit": "other/0",
+		"env":         map[string]interface{}{"DEBUG": "1"},
+		"stream":      true,
+	}
+	expectedArgs := params.Actions{
+		Actions: []params.Action{
+			{Receiver: "unit-magic-0", Name: "juju-run", Parameters: expectedPayload},
+		},
+	}
+	called := false
+	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+		called = true
+		c.Assert(args, jc.DeepEquals, expectedArgs)
+		return params.ActionResults{}, nil
+	})
+
+	_, err := s.client.Run(params.RunParams{
+		Commands:    "relation-get",
+		Units:       []string{"magic/0"},
+		Relation:    "db:3",
+		RemoteUnit:  "other/0",
+		Environment: map[string]string{"DEBUG": "1"},
+		Stream:      true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *runSuite) TestRunHookContextErrors(c *gc.C) {
+	s.PatchValue(action.QueueActions, func(client *action.ActionAPI, args params.Actions) (params.ActionResults, error) {
+		c.Fatalf("unexpected call")
+		return params.ActionResults{}, nil
+	})
+	for i, test := range []struct {
+		run params.RunParams
+		err string
+	}{{
+		run: params.RunParams{Machines: []string{"0"}, Stream: true},
+		err: "relation, remote unit, environment and stream options are only valid for units",
+	}, {
+		run: params.RunParams{Units: []string{"magic/0"}, Relation: "db:x"},
+		err: `relation "db:x" not valid`,
+	}, {
+		run: params.RunParams{Units: []string{"magic/0"}, RemoteUnit: "other/0"},
+		err: `remote unit "other/0" provided without a relation`,
+	}, {
+		run: params.RunParams{Units: []string{"magic/0"}, Relation: "1", RemoteUnit: "other"},
+		err: `remote unit name "other" not valid`,
+	}, {
+		run: params.RunParams{Units: []string{"magic/0"}, Environment: map[string]string{"A=B": "c"}},
+		err: `environment variable name "A=B" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.client.Run(test.run)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *runSuite) TestRunOnAllMachines(c *gc.C) {
 	// We only test that we create the actions correctly
 	// There is no need to test anything else at this level.
@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ActionAPIV3 implements version 3 of the Action API, which adds
+// action schedules.
+type ActionAPIV3 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV3 returns an initialized ActionAPIV3.
+func NewActionAPIV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV3, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV3{api}, nil
+}
+
+// ScheduleActions adds schedules for enqueueing actions on units, or on
+// every unit of applications, either once at a given time or repeatedly
+// according to a cron-like schedule.
+func (a *ActionAPIV3) ScheduleActions(args params.ActionSchedules) (params.ActionScheduleResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		schedule, err := a.scheduleAction(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Schedule = actionScheduleToParams(schedule)
+	}
+	return results, nil
+}
+
+func (a *ActionAPIV3) scheduleAction(arg params.ActionSchedule) (*state.ActionSchedule, error) {
+	receiver, err := names.ParseTag(arg.Receiver)
+	if err != nil {
+		return nil, common.ErrBadId
+	}
+	scheduleArgs := state.ActionScheduleArgs{
+		Receiver:   receiver,
+		Name:       arg.Name,
+		Parameters: arg.Parameters,
+		Spec:       arg.Spec,
+	}
+	if arg.At != nil {
+		scheduleArgs.At = *arg.At
+	}
+	schedule, err := a.model.AddActionSchedule(scheduleArgs)
+	return schedule, errors.Trace(err)
+}
+
+// ListActionSchedules returns all the action schedules in the model,
+// ordered by the time they are next due.
+func (a *ActionAPIV3) ListActionSchedules() (params.ActionScheduleResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	schedules, err := a.model.AllActionSchedules()
+	if err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		results.Results[i].Schedule = actionScheduleToParams(schedule)
+	}
+	return results, nil
+}
+
+// CancelActionSchedules removes the specified action schedules. Actions
+// already enqueued by them are not affected.
+func (a *ActionAPIV3) CancelActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		err := a.model.RemoveActionSchedule(id)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func actionScheduleToParams(schedule *state.ActionSchedule) *params.ActionSchedule {
+	nextRun := schedule.NextRun()
+	result := &params.ActionSchedule{
+		Id:         schedule.Id(),
+		Receiver:   schedule.Receiver().String(),
+		Name:       schedule.Name(),
+		Parameters: schedule.Parameters(),
+		Spec:       schedule.Spec(),
+		NextRun:    &nextRun,
+	}
+	if result.Spec == "" {
+		result.At = &nextRun
+	}
+	if lastRun := schedule.LastRun(); !lastRun.IsZero() {
+		result.LastRun = &lastRun
+	}
+	return result
+}
@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+func (s *actionSuite) newActionAPIV3(c *gc.C) *action.ActionAPIV3 {
+	api, err := action.NewActionAPIV3(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *actionSuite) TestScheduleActions(c *gc.C) {
+	api := s.newActionAPIV3(c)
+	at := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	results, err := api.ScheduleActions(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Receiver:   s.wordpressUnit.Tag().String(),
+			Name:       "fakeaction",
+			Parameters: map[string]interface{}{"foo": "bar"},
+			At:         &at,
+		}, {
+			Receiver: s.mysql.Tag().String(),
+			Name:     "fakeaction",
+			Spec:     "30 2 * * *",
+		}, {
+			Receiver: "machine-0",
+			Name:     "fakeaction",
+			Spec:     "@daily",
+		}, {
+			Receiver: "wordpress/0",
+			Name:     "fakeaction",
+			Spec:     "@daily",
+		}, {
+			Receiver: s.mysql.Tag().String(),
+			Name:     "fakeaction",
+			Spec:     "* *",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 5)
+
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	oneOff := results.Results[0].Schedule
+	c.Check(oneOff.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+	c.Check(oneOff.Spec, gc.Equals, "")
+	c.Check(oneOff.At.Equal(at), jc.IsTrue)
+	c.Check(oneOff.NextRun.Equal(at), jc.IsTrue)
+	c.Check(oneOff.LastRun, gc.IsNil)
+
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	recurring := results.Results[1].Schedule
+	c.Check(recurring.Receiver, gc.Equals, s.mysql.Tag().String())
+	c.Check(recurring.Spec, gc.Equals, "30 2 * * *")
+	c.Check(recurring.At, gc.IsNil)
+	c.Check(recurring.NextRun.UTC().Hour(), gc.Equals, 2)
+	c.Check(recurring.NextRun.UTC().Minute(), gc.Equals, 30)
+
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `receiver "machine-0" not valid`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, "id not found")
+	c.Check(results.Results[4].Error, gc.ErrorMatches, `schedule "\* \*": expected 5 fields, got 2 not valid`)
+
+	list, err := api.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Results, gc.HasLen, 2)
+	// Schedules are listed in the order they are next due.
+	c.Check(list.Results[0].Schedule.Id, gc.Equals, recurring.Id)
+	c.Check(list.Results[1].Schedule.Id, gc.Equals, oneOff.Id)
+	c.Check(list.Results[1].Schedule.Parameters, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
+
+func (s *actionSuite) TestCancelActionSchedules(c *gc.C) {
+	api := s.newActionAPIV3(c)
+	results, err := api.ScheduleActions(params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Receiver: s.mysqlUnit.Tag().String(),
+			Name:     "fakeaction",
+			Spec:     "@hourly",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	id := results.Results[0].Schedule.Id
+
+	cancelled, err := api.CancelActionSchedules(params.ActionScheduleIds{Ids: []string{id, "42"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cancelled.Results, gc.HasLen, 2)
+	c.Check(cancelled.Results[0].Error, gc.IsNil)
+	c.Check(cancelled.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	list, err := api.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Results, gc.HasLen, 0)
+}
+
+func (s *actionSuite) TestBlockActionSchedules(c *gc.C) {
+	api := s.newActionAPIV3(c)
+	s.BlockAllChanges(c, "ScheduleActions")
+	_, err := api.ScheduleActions(params.ActionSchedules{})
+	s.AssertBlocked(c, err, "ScheduleActions")
+	_, err = api.CancelActionSchedules(params.ActionScheduleIds{})
+	s.AssertBlocked(c, err, "ScheduleActions")
+}
+
+func (s *actionSuite) TestActionSchedulesPermissions(c *gc.C) {
+	alpha := names.NewUserTag("alpha@bravo")
+	auth := apiservertesting.FakeAuthorizer{Tag: alpha}
+	api, err := action.NewActionAPIV3(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.ScheduleActions(params.ActionSchedules{})
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+	_, err = api.ListActionSchedules()
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+	_, err = api.CancelActionSchedules(params.ActionScheduleIds{})
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+
+	// Write access also allows schedules to be listed.
+	auth.HasWriteTag = alpha
+	api, err = action.NewActionAPIV3(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ListActionSchedules()
+	c.Check(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentintegrity provides the AgentIntegrity facade, which
+// reports the outcome of each of a model's agents checking the
+// integrity of its binaries.
+package agentintegrity
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// agentintegrity facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllAgentIntegrity() ([]state.AgentIntegrity, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+// API provides the AgentIntegrity API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new AgentIntegrity API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkIsAdmin() error {
+	isAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// AgentIntegrity returns the most recent integrity report of each of
+// the model's machine and unit agents, made when the agent started.
+func (api *API) AgentIntegrity() (params.AgentIntegrityResult, error) {
+	if err := api.checkIsAdmin(); err != nil {
+		return params.AgentIntegrityResult{}, errors.Trace(err)
+	}
+	reports, err := api.backend.AllAgentIntegrity()
+	if err != nil {
+		return params.AgentIntegrityResult{}, errors.Trace(err)
+	}
+	result := params.AgentIntegrityResult{
+		Agents: make([]params.AgentIntegrity, len(reports)),
+	}
+	for i, r := range reports {
+		result.Agents[i] = params.AgentIntegrity{
+			Tag:     r.Tag.String(),
+			Version: r.Version,
+			Status:  string(r.Status),
+			Message: r.Message,
+			Updated: r.Updated,
+		}
+	}
+	return result, nil
+}
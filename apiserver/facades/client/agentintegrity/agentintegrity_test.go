@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentintegrity_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/agentintegrity"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AgentIntegritySuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&AgentIntegritySuite{})
+
+func (s *AgentIntegritySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{}
+}
+
+func (s *AgentIntegritySuite) TestNewAPINonClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := agentintegrity.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *AgentIntegritySuite) TestAgentIntegrity(c *gc.C) {
+	updated := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	vers := version.MustParseBinary("2.3.0-xenial-amd64")
+	s.backend.reports = []state.AgentIntegrity{{
+		Tag:     names.NewMachineTag("0"),
+		Version: vers,
+		Status:  state.AgentIntegrityVerified,
+		Updated: updated,
+	}, {
+		Tag:     names.NewUnitTag("mysql/0"),
+		Version: vers,
+		Status:  state.AgentIntegrityTampered,
+		Message: "jujud sha256 mismatch",
+		Updated: updated.Add(time.Minute),
+	}}
+	api, err := agentintegrity.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.AgentIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentIntegrityResult{
+		Agents: []params.AgentIntegrity{{
+			Tag:     "machine-0",
+			Version: vers,
+			Status:  params.AgentIntegrityVerified,
+			Updated: updated,
+		}, {
+			Tag:     "unit-mysql-0",
+			Version: vers,
+			Status:  params.AgentIntegrityTampered,
+			Message: "jujud sha256 mismatch",
+			Updated: updated.Add(time.Minute),
+		}},
+	})
+}
+
+func (s *AgentIntegritySuite) TestAgentIntegrityError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	api, err := agentintegrity.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.AgentIntegrity()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *AgentIntegritySuite) TestAgentIntegrityPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	api, err := agentintegrity.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.AgentIntegrity()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	reports []state.AgentIntegrity
+	err     error
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (m *mockBackend) AllAgentIntegrity() ([]state.AgentIntegrity, error) {
+	return m.reports, m.err
+}
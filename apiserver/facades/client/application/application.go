@@ -9,6 +9,7 @@ package application
 import (
 	"fmt"
 	"net"
+	"sort"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -20,10 +21,12 @@ import (
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/configwebhook"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
@@ -38,18 +41,56 @@ var logger = loggo.GetLogger("juju.apiserver.application")
 
 // APIv4 provides the Application API facade for versions 1-4.
 type APIv4 struct {
+	*APIv5
+}
+
+// APIv5 provides the Application API facade for version 5.
+type APIv5 struct {
+	*APIv6
+}
+
+// APIv6 provides the Application API facade for version 6.
+type APIv6 struct {
+	*APIv7
+}
+
+// APIv7 provides the Application API facade for version 7.
+type APIv7 struct {
+	*APIv8
+}
+
+// APIv8 provides the Application API facade for version 8.
+type APIv8 struct {
+	*APIv9
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
+	*APIv10
+}
+
+// APIv10 provides the Application API facade for version 10.
+type APIv10 struct {
+	*APIv11
+}
+
+// APIv11 provides the Application API facade for version 11.
+type APIv11 struct {
 	*API
 }
 
 // API implements the application interface and is the concrete
 // implementation of the api end point.
 //
-// API provides the Application API facade for version 5.
+// API provides the Application API facade for version 11.
 type API struct {
 	backend    Backend
 	authorizer facade.Authorizer
 	check      BlockChecker
 
+	// resources holds the watchers started by WatchRolloutStatus.
+	resources facade.Resources
+
 	// TODO(axw) stateCharm only exists because I ran out
 	// of time unwinding all of the tendrils of state. We
 	// should pass a charm.Charm and charm.URL back into
@@ -58,18 +99,93 @@ type API struct {
 
 	deployApplicationFunc func(ApplicationDeployer, DeployApplicationParams) (Application, error)
 	getEnviron            stateenvirons.NewEnvironFunc
+
+	// updateCharmProfiles, if non-nil, is called before an
+	// application's charm is changed, to update the LXD profiles
+	// applied to the instances hosting its units.
+	updateCharmProfiles func(appName string, oldCharm, newCharm *state.Charm) error
 }
 
 // NewFacadeV4 provides the signature required for facade registration
 // for versions 1-4.
 func NewFacadeV4(ctx facade.Context) (*APIv4, error) {
-	api, err := NewFacade(ctx)
+	api, err := NewFacadeV5(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv4{api}, nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration
+// for version 5.
+func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
+	api, err := NewFacadeV6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{api}, nil
+}
+
+// NewFacadeV6 provides the signature required for facade registration
+// for version 6.
+func NewFacadeV6(ctx facade.Context) (*APIv6, error) {
+	api, err := NewFacadeV7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv6{api}, nil
+}
+
+// NewFacadeV7 provides the signature required for facade registration
+// for version 7.
+func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
+	api, err := NewFacadeV8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv7{api}, nil
+}
+
+// NewFacadeV8 provides the signature required for facade registration
+// for version 8.
+func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
+	api, err := NewFacadeV9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv8{api}, nil
+}
+
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	api, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{api}, nil
+}
+
+// NewFacadeV10 provides the signature required for facade registration
+// for version 10.
+func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
+	api, err := NewFacadeV11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv10{api}, nil
+}
+
+// NewFacadeV11 provides the signature required for facade registration
+// for version 11.
+func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv11{api}, nil
+}
+
 // NewFacade provides the signature required for facade registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	backend, err := NewStateBackend(ctx.State())
@@ -78,13 +194,23 @@ func NewFacade(ctx facade.Context) (*API, error) {
 	}
 	blockChecker := common.NewBlockChecker(ctx.State())
 	stateCharm := CharmToStateCharm
-	return NewAPI(
+	api, err := NewAPI(
 		backend,
 		ctx.Auth(),
 		blockChecker,
 		stateCharm,
 		DeployApplication,
 	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	st := ctx.State()
+	api.resources = ctx.Resources()
+	api.getEnviron = stateenvirons.GetNewEnvironFunc(environs.New)
+	api.updateCharmProfiles = func(appName string, oldCharm, newCharm *state.Charm) error {
+		return updateCharmProfiles(st, api.getEnviron, appName, oldCharm, newCharm)
+	}
+	return api, nil
 }
 
 // NewAPI returns a new application API facade.
@@ -126,6 +252,15 @@ func (api *API) checkCanWrite() error {
 	return api.checkPermission(api.backend.ModelTag(), permission.WriteAccess)
 }
 
+// userName returns the name of the authenticated user, to be recorded
+// against changes to applications, or "" if the client is not a user.
+func (api *API) userName() string {
+	if tag, ok := api.authorizer.GetAuthTag().(names.UserTag); ok {
+		return tag.Id()
+	}
+	return ""
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -212,18 +347,26 @@ func deployApplication(
 			continue
 		}
 		_, err = backend.Machine(p.Directive)
-		if err != nil {
+		if errors.IsNotFound(err) {
+			return placementError(errors.Annotatef(err, `cannot deploy "%v" to machine %v`, args.ApplicationName, p.Directive))
+		} else if err != nil {
 			return errors.Annotatef(err, `cannot deploy "%v" to machine %v`, args.ApplicationName, p.Directive)
 		}
 	}
 
 	// Try to find the charm URL in state first.
 	ch, err := backend.Charm(curl)
-	if err != nil {
+	if errors.IsNotFound(err) {
+		return charmNotFoundError(err)
+	} else if err != nil {
 		return errors.Trace(err)
 	}
 
-	if err := checkMinVersion(ch); err != nil {
+	agentVersion, err := backend.AgentVersion()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkCharmRequirements(ch, agentVersion, modelProvidesFeature(backend)); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -268,9 +411,9 @@ func deployApplication(
 	return errors.Trace(err)
 }
 
-// ApplicationSetSettingsStrings updates the settings for the given application,
-// taking the configuration from a map of strings.
-func ApplicationSetSettingsStrings(application Application, settings map[string]string) error {
+// applicationSetSettingsStrings updates the settings for the given
+// application, taking the configuration from a map of strings.
+func (api *API) applicationSetSettingsStrings(application Application, settings map[string]string) error {
 	ch, _, err := application.Charm()
 	if err != nil {
 		return errors.Trace(err)
@@ -280,7 +423,7 @@ func ApplicationSetSettingsStrings(application Application, settings map[string]
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return application.UpdateConfigSettings(changes)
+	return api.updateConfigSettings(application, changes)
 }
 
 // parseSettingsCompatible parses setting strings in a way that is
@@ -348,6 +491,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 			args.ForceCharmURL,
 			nil, // resource IDs
 			nil, // storage constraints
+			nil, // rolling upgrade
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -360,11 +504,11 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 	}
 	// Set up application's settings.
 	if args.SettingsYAML != "" {
-		if err = applicationSetSettingsYAML(args.ApplicationName, app, args.SettingsYAML); err != nil {
+		if err = api.applicationSetSettingsYAML(args.ApplicationName, app, args.SettingsYAML); err != nil {
 			return errors.Annotate(err, "setting configuration from YAML")
 		}
 	} else if len(args.SettingsStrings) > 0 {
-		if err = ApplicationSetSettingsStrings(app, args.SettingsStrings); err != nil {
+		if err = api.applicationSetSettingsStrings(app, args.SettingsStrings); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -448,26 +592,331 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 		args.ForceUnits,
 		args.ResourceIDs,
 		args.StorageConstraints,
+		args.RollingUpgrade,
 	)
 }
 
 // GetConfig returns the application config for each of the applications
-// asked for.
-func (api *API) GetConfig(args params.Entities) (params.ApplicationGetConfigResults, error) {
+// asked for, followed by that of each application matching the selector.
+func (api *API) GetConfig(args params.ApplicationGetConfigArgs) (params.ApplicationGetConfigResults, error) {
 	if err := api.checkCanRead(); err != nil {
 		return params.ApplicationGetConfigResults{}, err
 	}
+	selected, err := api.selectApplications(args.Selector)
+	if err != nil {
+		return params.ApplicationGetConfigResults{}, errors.Trace(err)
+	}
 	results := params.ApplicationGetConfigResults{
-		Results: make([]params.ConfigResult, len(args.Entities)),
+		Results: make([]params.ConfigResult, len(args.Entities), len(args.Entities)+len(selected)),
 	}
 	for i, arg := range args.Entities {
 		config, err := api.getConfig(arg.Tag)
 		results.Results[i].Config = config
 		results.Results[i].Error = common.ServerError(err)
 	}
+	for _, app := range selected {
+		tag := names.NewApplicationTag(app.Name()).String()
+		config, err := api.getConfig(tag)
+		results.Results = append(results.Results, params.ConfigResult{
+			Tag:    tag,
+			Config: config,
+			Error:  common.ServerError(err),
+		})
+	}
+	return results, nil
+}
+
+// GetConfigSchema returns the schema of the charm config options of
+// each of the given applications, describing the type, default value
+// and description of each option.
+func (api *API) GetConfigSchema(args params.Entities) (params.ApplicationConfigSchemaResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationConfigSchemaResults{}, errors.Trace(err)
+	}
+	results := params.ApplicationConfigSchemaResults{
+		Results: make([]params.ApplicationConfigSchemaResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		schema, err := api.getConfigSchema(arg.Tag)
+		results.Results[i].Schema = schema
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) getConfigSchema(entity string) (map[string]params.ConfigOptionSchema, error) {
+	tag, err := names.ParseApplicationTag(entity)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, err
+	}
+	return configSchema(ch.Config()), nil
+}
+
+// selectApplications returns the applications whose labels match the
+// given selector, or none if the selector is empty.
+func (api *API) selectApplications(selector string) ([]Application, error) {
+	if selector == "" {
+		return nil, nil
+	}
+	parsed, err := coreapplication.ParseSelector(selector)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.backend.ApplicationsMatching(parsed)
+}
+
+// SetLabels replaces the labels of each of the given applications.
+func (api *API) SetLabels(args params.ApplicationLabelsArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setLabels(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setLabels(arg params.ApplicationLabels) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return err
+	}
+	return app.SetLabels(arg.Labels)
+}
+
+// SetStopGracePeriods sets how long the units of each of the given
+// applications are given to stop when they are destroyed.
+func (api *API) SetStopGracePeriods(args params.ApplicationStopGracePeriods) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setStopGracePeriod(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setStopGracePeriod(arg params.ApplicationStopGracePeriod) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return err
+	}
+	return app.SetStopGracePeriod(arg.StopGracePeriod)
+}
+
+// GetLabels returns the labels of each of the given applications.
+func (api *API) GetLabels(args params.Entities) (params.ApplicationLabelsResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationLabelsResults{}, errors.Trace(err)
+	}
+	results := params.ApplicationLabelsResults{
+		Results: make([]params.ApplicationLabelsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		labels, err := api.getLabels(arg.Tag)
+		results.Results[i].Labels = labels
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) getLabels(entity string) (map[string]string, error) {
+	tag, err := names.ParseApplicationTag(entity)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	return app.Labels(), nil
+}
+
+// CharmUpgradeStatus returns the most recent rolling charm upgrade of
+// each of the given applications.
+func (api *API) CharmUpgradeStatus(args params.Entities) (params.CharmUpgradeStatusResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.CharmUpgradeStatusResults{}, errors.Trace(err)
+	}
+	results := params.CharmUpgradeStatusResults{
+		Results: make([]params.CharmUpgradeStatusResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		result, err := api.charmUpgradeStatus(arg.Tag)
+		results.Results[i].Result = result
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) charmUpgradeStatus(entity string) (*params.CharmUpgradeStatus, error) {
+	tag, err := names.ParseApplicationTag(entity)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	upgrade, ok := app.CharmUpgrade()
+	if !ok {
+		return nil, errors.NotFoundf("charm upgrade of application %q", tag.Id())
+	}
+	return &params.CharmUpgradeStatus{
+		PreviousCharmURL: upgrade.PreviousCharmURL().String(),
+		BatchSize:        upgrade.BatchSize(),
+		Readiness:        upgrade.Readiness(),
+		Status:           string(upgrade.Status()),
+		Message:          upgrade.Message(),
+		Pending:          upgrade.Pending(),
+		Upgrading:        upgrade.Upgrading(),
+		Upgraded:         upgrade.Upgraded(),
+	}, nil
+}
+
+// PauseCharmUpgrade stops the rolling charm upgrades of the given
+// applications from starting another batch of units.
+func (api *API) PauseCharmUpgrade(args params.Entities) (params.ErrorResults, error) {
+	return api.changeCharmUpgrades(args, func(app Application) error {
+		return app.PauseCharmUpgrade()
+	})
+}
+
+// ResumeCharmUpgrade resumes the paused rolling charm upgrades of the
+// given applications.
+func (api *API) ResumeCharmUpgrade(args params.Entities) (params.ErrorResults, error) {
+	return api.changeCharmUpgrades(args, func(app Application) error {
+		return app.ResumeCharmUpgrade()
+	})
+}
+
+// RollbackCharmUpgrade returns the units of the given applications to
+// the charm they ran before their most recent rolling charm upgrade.
+func (api *API) RollbackCharmUpgrade(args params.Entities) (params.ErrorResults, error) {
+	return api.changeCharmUpgrades(args, api.rollbackCharmUpgrade)
+}
+
+func (api *API) rollbackCharmUpgrade(app Application) error {
+	if api.updateCharmProfiles != nil {
+		upgrade, ok := app.CharmUpgrade()
+		if !ok {
+			return errors.NotFoundf("charm upgrade of application %q", app.Name())
+		}
+		currentCharm, _, err := app.Charm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		previousCharm, err := api.backend.Charm(upgrade.PreviousCharmURL())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = api.updateCharmProfiles(app.Name(), api.stateCharm(currentCharm), api.stateCharm(previousCharm))
+		if err != nil {
+			return errors.Annotate(err, "updating charm LXD profiles")
+		}
+	}
+	return app.RollbackCharmUpgrade()
+}
+
+func (api *API) changeCharmUpgrades(args params.Entities, change func(Application) error) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseApplicationTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := api.backend.Application(tag.Id())
+		if err == nil {
+			err = change(app)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// CharmHistory returns the charm URLs and config applied to each of the
+// given applications, newest first, along with who made each change
+// and when.
+func (api *API) CharmHistory(args params.ApplicationCharmHistoryArgs) (params.CharmHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.CharmHistoryResults{}, errors.Trace(err)
+	}
+	results := params.CharmHistoryResults{
+		Results: make([]params.CharmHistoryResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		entries, err := api.charmHistory(arg)
+		results.Results[i].Entries = entries
+		results.Results[i].Error = common.ServerError(err)
+	}
 	return results, nil
 }
 
+func (api *API) charmHistory(arg params.ApplicationCharmHistoryArg) ([]params.CharmHistoryEntry, error) {
+	tag, err := names.ParseApplicationTag(arg.Tag)
+	if err != nil {
+		return nil, err
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	history, err := app.CharmHistory(arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]params.CharmHistoryEntry, len(history))
+	for i, entry := range history {
+		entries[i] = params.CharmHistoryEntry{
+			CharmURL:      entry.CharmURL.String(),
+			ConfigVersion: entry.ConfigVersion,
+			Config:        entry.Config,
+			User:          entry.User,
+			Time:          entry.Time,
+		}
+	}
+	return entries, nil
+}
+
 func (api *API) getConfig(entity string) (map[string]interface{}, error) {
 	tag, err := names.ParseTag(entity)
 	if err != nil {
@@ -505,6 +954,7 @@ func (api *API) applicationSetCharm(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	storageConstraints map[string]params.StorageConstraints,
+	rollingUpgrade *params.RollingCharmUpgrade,
 ) error {
 	curl, err := charm.ParseURL(url)
 	if err != nil {
@@ -514,6 +964,13 @@ func (api *API) applicationSetCharm(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	agentVersion, err := api.backend.AgentVersion()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkCharmRequirements(sch, agentVersion, modelProvidesFeature(api.backend)); err != nil {
+		return errors.Trace(err)
+	}
 	var settings charm.Settings
 	if configSettingsYAML != "" {
 		settings, err = sch.Config().ParseSettingsYAML([]byte(configSettingsYAML), appName)
@@ -523,6 +980,9 @@ func (api *API) applicationSetCharm(
 	if err != nil {
 		return errors.Annotate(err, "parsing config settings")
 	}
+	if err := api.validateConfigChange(appName, "", settings); err != nil {
+		return errors.Trace(err)
+	}
 	var stateStorageConstraints map[string]state.StorageConstraints
 	if len(storageConstraints) > 0 {
 		stateStorageConstraints = make(map[string]state.StorageConstraints)
@@ -537,6 +997,15 @@ func (api *API) applicationSetCharm(
 			stateStorageConstraints[name] = stateCons
 		}
 	}
+	if api.updateCharmProfiles != nil {
+		oldCharm, _, err := application.Charm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := api.updateCharmProfiles(appName, api.stateCharm(oldCharm), api.stateCharm(sch)); err != nil {
+			return errors.Annotate(err, "updating charm LXD profiles")
+		}
+	}
 	cfg := state.SetCharmConfig{
 		Charm:              api.stateCharm(sch),
 		Channel:            channel,
@@ -545,6 +1014,13 @@ func (api *API) applicationSetCharm(
 		ForceUnits:         forceUnits,
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
+		ChangedBy:          api.userName(),
+	}
+	if rollingUpgrade != nil {
+		cfg.RollingUpgrade = &state.RollingCharmUpgrade{
+			BatchSize: rollingUpgrade.BatchSize,
+			Readiness: rollingUpgrade.Readiness,
+		}
 	}
 	return application.SetCharm(cfg)
 }
@@ -579,7 +1055,7 @@ func settingsFromGetYaml(yamlContents map[string]interface{}) (charm.Settings, e
 
 // applicationSetSettingsYAML updates the settings for the given application,
 // taking the configuration from a YAML string.
-func applicationSetSettingsYAML(appName string, application Application, settings string) error {
+func (api *API) applicationSetSettingsYAML(appName string, application Application, settings string) error {
 	b := []byte(settings)
 	var all map[string]interface{}
 	if err := goyaml.Unmarshal(b, &all); err != nil {
@@ -591,7 +1067,7 @@ func applicationSetSettingsYAML(appName string, application Application, setting
 		if err != nil {
 			return errors.Annotate(err, "processing YAML generated by get")
 		}
-		return errors.Annotate(application.UpdateConfigSettings(changes), "updating settings with application YAML")
+		return errors.Annotate(api.updateConfigSettings(application, changes), "updating settings with application YAML")
 	}
 
 	ch, _, err := application.Charm()
@@ -603,7 +1079,7 @@ func applicationSetSettingsYAML(appName string, application Application, setting
 	if err != nil {
 		return errors.Annotate(err, "creating config from YAML")
 	}
-	return errors.Annotate(application.UpdateConfigSettings(changes), "updating settings")
+	return errors.Annotate(api.updateConfigSettings(application, changes), "updating settings")
 }
 
 // GetCharmURL returns the charm URL the given application is
@@ -643,8 +1119,10 @@ func (api *API) Set(p params.ApplicationSet) error {
 	if err != nil {
 		return err
 	}
-
-	return app.UpdateConfigSettings(changes)
+	if p.BranchName != "" {
+		return api.updateBranchConfig(p.BranchName, p.ApplicationName, changes)
+	}
+	return api.updateConfigSettings(app, changes)
 
 }
 
@@ -664,7 +1142,163 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 	for _, option := range p.Options {
 		settings[option] = nil
 	}
-	return app.UpdateConfigSettings(settings)
+	if p.BranchName != "" {
+		return api.updateBranchConfig(p.BranchName, p.ApplicationName, settings)
+	}
+	return api.updateConfigSettings(app, settings)
+}
+
+// updateConfigSettings applies the changes to the application's
+// config, if the controller's config webhooks allow them.
+func (api *API) updateConfigSettings(app Application, changes charm.Settings) error {
+	if err := api.validateConfigChange(app.Name(), "", changes); err != nil {
+		return errors.Trace(err)
+	}
+	return app.UpdateConfigSettingsBy(changes, api.userName())
+}
+
+// validateConfigChange consults the controller's config webhooks about
+// changes to the named application's config, to be staged in the named
+// branch if it is not empty. Settings with nil values are being reset
+// to their defaults.
+func (api *API) validateConfigChange(appName, branchName string, changes charm.Settings) error {
+	req := configwebhook.Request{
+		Kind:        state.ApplicationConfigWebhook,
+		Application: appName,
+		Branch:      branchName,
+		User:        api.userName(),
+	}
+	for name, value := range changes {
+		if value == nil {
+			req.Unset = append(req.Unset, name)
+			continue
+		}
+		if req.Set == nil {
+			req.Set = make(map[string]interface{})
+		}
+		req.Set[name] = value
+	}
+	sort.Strings(req.Unset)
+	return configwebhook.Validate(api.backend, req)
+}
+
+// updateBranchConfig stages changes to the application's config in
+// the named branch.
+func (api *API) updateBranchConfig(branchName, appName string, changes charm.Settings) error {
+	branch, err := api.backend.Branch(branchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.validateConfigChange(appName, branchName, changes); err != nil {
+		return errors.Trace(err)
+	}
+	return branch.UpdateCharmConfig(appName, changes)
+}
+
+// AddBranch adds the given branches, in which application config
+// changes can be staged for a subset of units.
+func (api *API) AddBranch(args params.BranchArgs) (params.ErrorResults, error) {
+	return api.changeBranches(args, func(name string) error {
+		_, err := api.backend.AddBranch(name, api.userName())
+		return err
+	})
+}
+
+// CommitBranch applies the config changes staged in each of the given
+// branches to all units of their applications, and removes the
+// branches.
+func (api *API) CommitBranch(args params.BranchArgs) (params.ErrorResults, error) {
+	return api.changeBranches(args, func(name string) error {
+		branch, err := api.backend.Branch(name)
+		if err != nil {
+			return err
+		}
+		return branch.Commit(api.userName())
+	})
+}
+
+// AbortBranch removes each of the given branches, discarding the
+// config changes staged in them.
+func (api *API) AbortBranch(args params.BranchArgs) (params.ErrorResults, error) {
+	return api.changeBranches(args, func(name string) error {
+		branch, err := api.backend.Branch(name)
+		if err != nil {
+			return err
+		}
+		return branch.Abort()
+	})
+}
+
+func (api *API) changeBranches(args params.BranchArgs, change func(string) error) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Branches)),
+	}
+	for i, arg := range args.Branches {
+		results.Results[i].Error = common.ServerError(change(arg.BranchName))
+	}
+	return results, nil
+}
+
+// TrackBranch assigns the given units to a branch, so that they see
+// the config changes staged in it for their applications.
+func (api *API) TrackBranch(arg params.BranchTrackArg) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	branch, err := api.backend.Branch(arg.BranchName)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(arg.Entities)),
+	}
+	for i, entity := range arg.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err == nil {
+			err = branch.AssignUnit(tag.Id())
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// BranchInfo returns the config changes staged in each of the given
+// branches, and the units assigned to them.
+func (api *API) BranchInfo(args params.BranchArgs) (params.BranchResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.BranchResults{}, errors.Trace(err)
+	}
+	results := params.BranchResults{
+		Results: make([]params.BranchResult, len(args.Branches)),
+	}
+	for i, arg := range args.Branches {
+		branch, err := api.backend.Branch(arg.BranchName)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		info := &params.BranchInfo{
+			Name:          branch.Name(),
+			CreatedBy:     branch.CreatedBy(),
+			Created:       branch.Created(),
+			Config:        make(map[string]map[string]interface{}),
+			AssignedUnits: branch.AssignedUnits(),
+		}
+		for appName, settings := range branch.Config() {
+			info.Config[appName] = settings
+		}
+		results.Results[i].Result = info
+	}
+	return results, nil
 }
 
 // CharmRelations implements the server side of Application.CharmRelations.
@@ -835,7 +1469,7 @@ func (api *API) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyUnitR
 		name := unitTag.Id()
 		unit, err := api.backend.Unit(name)
 		if errors.IsNotFound(err) {
-			return nil, errors.Errorf("unit %q does not exist", name)
+			return nil, errors.NewNotFound(nil, fmt.Sprintf("unit %q does not exist", name))
 		} else if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -862,8 +1496,24 @@ func (api *API) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyUnitR
 				return nil, errors.Trace(err)
 			}
 		}
+		appName, err := names.UnitApplication(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := api.backend.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if period := app.StopGracePeriod(); period > 0 {
+			if arg.Force {
+				info.StopGracePeriodOverridden = true
+			} else {
+				info.StopGracePeriod = period
+			}
+		}
 		op := unit.DestroyOperation()
 		op.DestroyStorage = arg.DestroyStorage
+		op.Force = arg.Force
 		if err := api.backend.ApplyOperation(op); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -1004,7 +1654,11 @@ func (api *API) DestroyApplication(args params.DestroyApplicationsParams) (param
 		}
 		return &info, nil
 	}
-	results := make([]params.DestroyApplicationResult, len(args.Applications))
+	selected, err := api.selectApplications(args.Selector)
+	if err != nil {
+		return params.DestroyApplicationResults{}, errors.Trace(err)
+	}
+	results := make([]params.DestroyApplicationResult, len(args.Applications), len(args.Applications)+len(selected))
 	for i, arg := range args.Applications {
 		info, err := destroyApp(arg)
 		if err != nil {
@@ -1013,6 +1667,20 @@ func (api *API) DestroyApplication(args params.DestroyApplicationsParams) (param
 		}
 		results[i].Info = info
 	}
+	for _, app := range selected {
+		tag := names.NewApplicationTag(app.Name()).String()
+		info, err := destroyApp(params.DestroyApplicationParams{
+			ApplicationTag: tag,
+			DestroyStorage: args.DestroyStorage,
+		})
+		result := params.DestroyApplicationResult{ApplicationTag: tag}
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Info = info
+		}
+		results = append(results, result)
+	}
 	return params.DestroyApplicationResults{results}, nil
 }
 
@@ -1049,7 +1717,8 @@ func (api *API) getConstraints(entity string) (constraints.Value, error) {
 	}
 }
 
-// SetConstraints sets the constraints for a given application.
+// SetConstraints sets the constraints for a given application, or for
+// each application matching a selector.
 func (api *API) SetConstraints(args params.SetConstraints) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -1057,6 +1726,28 @@ func (api *API) SetConstraints(args params.SetConstraints) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if args.Selector != "" {
+		if args.ApplicationName != "" {
+			return errors.NotValidf("both application name and selector")
+		}
+		selected, err := api.selectApplications(args.Selector)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Check every selected application before changing any, so
+		// that an unsuitable match leaves them all unchanged.
+		for _, app := range selected {
+			if !app.IsPrincipal() {
+				return errors.Errorf("setting constraints for application %q: %v", app.Name(), state.ErrSubordinateConstraints)
+			}
+		}
+		for _, app := range selected {
+			if err := app.SetConstraints(args.Constraints); err != nil {
+				return errors.Annotatef(err, "setting constraints for application %q", app.Name())
+			}
+		}
+		return nil
+	}
 	app, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return err
@@ -1387,6 +2078,69 @@ func (u *APIv4) UpdateApplicationSeries(_, _ struct{}) {}
 // GetConfig isn't on the V4 API.
 func (u *APIv4) GetConfig(_, _ struct{}) {}
 
+// SetLabels isn't on the V5 API.
+func (u *APIv5) SetLabels(_, _ struct{}) {}
+
+// GetLabels isn't on the V5 API.
+func (u *APIv5) GetLabels(_, _ struct{}) {}
+
+// GetConfigSchema isn't on the V6 API.
+func (u *APIv6) GetConfigSchema(_, _ struct{}) {}
+
+// CharmUpgradeStatus isn't on the V7 API.
+func (u *APIv7) CharmUpgradeStatus(_, _ struct{}) {}
+
+// PauseCharmUpgrade isn't on the V7 API.
+func (u *APIv7) PauseCharmUpgrade(_, _ struct{}) {}
+
+// ResumeCharmUpgrade isn't on the V7 API.
+func (u *APIv7) ResumeCharmUpgrade(_, _ struct{}) {}
+
+// RollbackCharmUpgrade isn't on the V7 API.
+func (u *APIv7) RollbackCharmUpgrade(_, _ struct{}) {}
+
+// CharmHistory isn't on the V8 API.
+func (u *APIv8) CharmHistory(_, _ struct{}) {}
+
+// AddBranch isn't on the V9 API.
+func (u *APIv9) AddBranch(_, _ struct{}) {}
+
+// CommitBranch isn't on the V9 API.
+func (u *APIv9) CommitBranch(_, _ struct{}) {}
+
+// AbortBranch isn't on the V9 API.
+func (u *APIv9) AbortBranch(_, _ struct{}) {}
+
+// TrackBranch isn't on the V9 API.
+func (u *APIv9) TrackBranch(_, _ struct{}) {}
+
+// BranchInfo isn't on the V9 API.
+func (u *APIv9) BranchInfo(_, _ struct{}) {}
+
+// ScaleApplications isn't on the V10 API.
+func (u *APIv10) ScaleApplications(_, _ struct{}) {}
+
+// SetStopGracePeriods isn't on the V11 API.
+func (u *APIv11) SetStopGracePeriods(_, _ struct{}) {}
+
+// GetPodSpec isn't on the V10 API.
+func (u *APIv10) GetPodSpec(_, _ struct{}) {}
+
+// SetPodSpec isn't on the V10 API.
+func (u *APIv10) SetPodSpec(_, _ struct{}) {}
+
+// PodSpecHistory isn't on the V10 API.
+func (u *APIv10) PodSpecHistory(_, _ struct{}) {}
+
+// RolloutStatus isn't on the V10 API.
+func (u *APIv10) RolloutStatus(_, _ struct{}) {}
+
+// WatchRolloutStatus isn't on the V10 API.
+func (u *APIv10) WatchRolloutStatus(_, _ struct{}) {}
+
+// UnitContainerStatuses isn't on the V10 API.
+func (u *APIv10) UnitContainerStatuses(_, _ struct{}) {}
+
 // GetConstraints returns the v4 implementation of GetConstraints.
 func (api *APIv4) GetConstraints(args params.GetApplicationConstraints) (params.GetConstraintsResults, error) {
 	if err := api.checkCanRead(); err != nil {
@@ -123,7 +123,7 @@ func (s *applicationSuite) TestGetConfig(c *gc.C) {
 		Charm:    dummy,
 		Settings: barConfig,
 	})
-	results, err := s.applicationAPI.GetConfig(params.Entities{
+	results, err := s.applicationAPI.GetConfig(params.ApplicationGetConfigArgs{
 		Entities: []params.Entity{
 			{"wat"}, {"machine-0"}, {"user-foo"},
 			{"application-foo"}, {"application-bar"}, {"application-wat"},
@@ -766,7 +766,7 @@ func (s *applicationSuite) TestAddCharmOverwritesPlaceholders(c *gc.C) {
 
 func (s *applicationSuite) TestApplicationGetCharmURL(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	result, err := s.applicationAPI.GetCharmURL(params.ApplicationGet{"wordpress"})
+	result, err := s.applicationAPI.GetCharmURL(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result.Error, gc.IsNil)
 	c.Assert(result.Result, gc.Equals, "local:quantal/wordpress-3")
@@ -1312,6 +1312,7 @@ func (s *applicationSuite) TestApplicationDeployToMachineNotFound(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, gc.HasLen, 1)
 	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cannot deploy "application-name" to machine 42: machine 42 not found`)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodePlacementNotValid)
 
 	_, err = s.State.Application("application-name")
 	c.Assert(err, gc.ErrorMatches, `application "application-name" not found`)
@@ -1822,6 +1823,7 @@ func (s *applicationSuite) TestClientAddApplicationUnits(c *gc.C) {
 		result, err := s.applicationAPI.AddUnits(args)
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
+			c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodePlacementNotValid)
 			continue
 		}
 		c.Assert(err, jc.ErrorIsNil)
@@ -1898,6 +1900,7 @@ func (s *applicationSuite) TestAddApplicationUnits(c *gc.C) {
 		})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
+			c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodePlacementNotValid)
 			continue
 		}
 		c.Assert(err, jc.ErrorIsNil)
@@ -4,18 +4,28 @@
 package application_test
 
 import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
+	"github.com/juju/juju/apiserver/common/configwebhook"
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/network"
@@ -69,7 +79,8 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 	}
 	s.relation = mockRelation{tag: names.NewRelationTag("wordpress:db mysql:db")}
 	s.backend = mockBackend{
-		controllers: make(map[string]crossmodel.ControllerInfo),
+		agentVersion: version.MustParse("2.3.0"),
+		controllers:  make(map[string]crossmodel.ControllerInfo),
 		applications: map[string]application.Application{
 			"postgresql": &mockApplication{
 				name:        "postgresql",
@@ -183,11 +194,12 @@ func (s *ApplicationSuite) TestSetCharmStorageConstraints(c *gc.C) {
 		},
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm")
+	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm", "AgentVersion")
 	app := s.backend.applications["postgresql"].(*mockApplication)
 	app.CheckCallNames(c, "SetCharm")
 	app.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
-		Charm: &state.Charm{},
+		Charm:     &state.Charm{},
+		ChangedBy: "admin",
 		StorageConstraints: map[string]state.StorageConstraints{
 			"a": {},
 			"b": {Pool: "radiant"},
@@ -197,6 +209,28 @@ func (s *ApplicationSuite) TestSetCharmStorageConstraints(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmRollingUpgrade(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		RollingUpgrade: &params.RollingCharmUpgrade{
+			BatchSize: 2,
+			Readiness: "action:health-check",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCallNames(c, "SetCharm")
+	app.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
+		Charm:     &state.Charm{},
+		ChangedBy: "admin",
+		RollingUpgrade: &state.RollingCharmUpgrade{
+			BatchSize: 2,
+			Readiness: "action:health-check",
+		},
+	})
+}
+
 func (s *ApplicationSuite) TestSetCharmConfigSettings(c *gc.C) {
 	err := s.api.SetCharm(params.ApplicationSetCharm{
 		ApplicationName: "postgresql",
@@ -204,12 +238,13 @@ func (s *ApplicationSuite) TestSetCharmConfigSettings(c *gc.C) {
 		ConfigSettings:  map[string]string{"stringOption": "value"},
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm")
+	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm", "AgentVersion")
 	s.backend.charm.CheckCallNames(c, "Config")
 	app := s.backend.applications["postgresql"].(*mockApplication)
 	app.CheckCallNames(c, "SetCharm")
 	app.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
 		Charm:          &state.Charm{},
+		ChangedBy:      "admin",
 		ConfigSettings: charm.Settings{"stringOption": "value"},
 	})
 }
@@ -224,16 +259,46 @@ postgresql:
 `,
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm")
+	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm", "AgentVersion")
 	s.backend.charm.CheckCallNames(c, "Config")
 	app := s.backend.applications["postgresql"].(*mockApplication)
 	app.CheckCallNames(c, "SetCharm")
 	app.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
 		Charm:          &state.Charm{},
+		ChangedBy:      "admin",
 		ConfigSettings: charm.Settings{"stringOption": "value"},
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmRequirementsNotMet(c *gc.C) {
+	s.backend.charm.meta = &charm.Meta{
+		Name:           "postgresql",
+		MinJujuVersion: version.MustParse("2.4.0"),
+	}
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, gc.ErrorMatches, `charm "postgresql" requirements not met by model running juju 2.3.0: missing juju version 2.4.0 or later`)
+	c.Assert(params.IsCodeCharmRequirementsNotMet(err), jc.IsTrue)
+	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm", "AgentVersion")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetCharmRequiredFeaturesNotMet(c *gc.C) {
+	s.backend.charm.requiredFeatures = []string{"spaces"}
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, gc.ErrorMatches, `charm "postgresql" requirements not met by model running juju 2.3.0: missing features spaces`)
+	c.Assert(params.IsCodeCharmRequirementsNotMet(err), jc.IsTrue)
+	s.backend.CheckCallNames(c, "ModelTag", "Application", "Charm", "AgentVersion", "AllSpaces")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestDestroyRelation(c *gc.C) {
 	err := s.api.DestroyRelation(params.DestroyRelation{Endpoints: []string{"a", "b"}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -371,6 +436,33 @@ func (s *ApplicationSuite) TestDestroyApplicationNotFound(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestDestroyApplicationSelector(c *gc.C) {
+	app := s.backend.applications["postgresql-subordinate"].(*mockApplication)
+	app.labels = map[string]string{"tier": "backend"}
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Selector: "tier=backend",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DestroyApplicationResult{{
+		ApplicationTag: "application-postgresql-subordinate",
+		Info: &params.DestroyApplicationInfo{
+			DestroyedUnits: []params.Entity{
+				{Tag: "unit-postgresql-subordinate-0"},
+				{Tag: "unit-postgresql-subordinate-1"},
+			},
+		},
+	}})
+	s.backend.CheckCall(c, 1, "ApplicationsMatching", coreapplication.Selector{"tier": "backend"})
+}
+
+func (s *ApplicationSuite) TestDestroyApplicationInvalidSelector(c *gc.C) {
+	_, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Selector: "tier",
+	})
+	c.Assert(err, gc.ErrorMatches, `selector term "tier" not valid`)
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
 func (s *ApplicationSuite) TestDestroyUnit(c *gc.C) {
 	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
 		Units: []params.DestroyUnitParams{
@@ -404,18 +496,44 @@ func (s *ApplicationSuite) TestDestroyUnit(c *gc.C) {
 		"StorageInstance",
 		"StorageInstanceFilesystem",
 		"StorageInstanceFilesystem",
+		"Application",
 		"ApplyOperation",
 
 		"Unit",
 		"UnitStorageAttachments",
+		"Application",
 		"ApplyOperation",
 	)
-	s.backend.CheckCall(c, 7, "ApplyOperation", &state.DestroyUnitOperation{})
-	s.backend.CheckCall(c, 10, "ApplyOperation", &state.DestroyUnitOperation{
+	s.backend.CheckCall(c, 8, "ApplyOperation", &state.DestroyUnitOperation{})
+	s.backend.CheckCall(c, 12, "ApplyOperation", &state.DestroyUnitOperation{
 		DestroyStorage: true,
 	})
 }
 
+func (s *ApplicationSuite) TestDestroyUnitStopGracePeriod(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.stopGracePeriod = 5 * time.Minute
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{
+			{UnitTag: "unit-postgresql-1"},
+			{
+				UnitTag: "unit-postgresql-1",
+				Force:   true,
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.DestroyUnitResult{{
+		Info: &params.DestroyUnitInfo{StopGracePeriod: 5 * time.Minute},
+	}, {
+		Info: &params.DestroyUnitInfo{StopGracePeriodOverridden: true},
+	}})
+	s.backend.CheckCall(c, 4, "ApplyOperation", &state.DestroyUnitOperation{})
+	s.backend.CheckCall(c, 8, "ApplyOperation", &state.DestroyUnitOperation{
+		Force: true,
+	})
+}
+
 func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	args := params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
@@ -443,6 +561,23 @@ func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-baz-0" is not a valid volume tag`)
 }
 
+func (s *ApplicationSuite) TestDeployCharmNotFound(c *gc.C) {
+	s.backend.charm = nil
+	results, err := s.api.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.DeepEquals, &params.Error{
+		Code:    params.CodeCharmNotFound,
+		Message: `charm "local:foo-0" not found`,
+	})
+}
+
 func (s *ApplicationSuite) TestAddUnitsAttachStorage(c *gc.C) {
 	results, err := s.api.AddUnits(params.AddApplicationUnits{
 		ApplicationName: "postgresql",
@@ -952,3 +1087,505 @@ func (s *ApplicationSuite) TestRemoteRelationDisAllowedCIDR(c *gc.C) {
 	_, err := s.api.AddRelation(params.AddRelation{Endpoints: endpoints, ViaCIDRs: []string{"0.0.0.0/0"}})
 	c.Assert(err, gc.ErrorMatches, `CIDR "0.0.0.0/0" not allowed`)
 }
+
+func (s *ApplicationSuite) TestSetLabels(c *gc.C) {
+	results, err := s.api.SetLabels(params.ApplicationLabelsArgs{
+		Args: []params.ApplicationLabels{{
+			ApplicationTag: "application-postgresql",
+			Labels:         map[string]string{"tier": "backend"},
+		}, {
+			ApplicationTag: "application-wat",
+			Labels:         map[string]string{"tier": "backend"},
+		}, {
+			ApplicationTag: "unit-postgresql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+		{Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`}},
+	})
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCall(c, 0, "SetLabels", map[string]string{"tier": "backend"})
+}
+
+func (s *ApplicationSuite) TestSetStopGracePeriods(c *gc.C) {
+	results, err := s.api.SetStopGracePeriods(params.ApplicationStopGracePeriods{
+		Args: []params.ApplicationStopGracePeriod{{
+			ApplicationTag:  "application-postgresql",
+			StopGracePeriod: 5 * time.Minute,
+		}, {
+			ApplicationTag:  "application-wat",
+			StopGracePeriod: 5 * time.Minute,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+	})
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCall(c, 0, "SetStopGracePeriod", 5*time.Minute)
+}
+
+func (s *ApplicationSuite) TestBlockChangesSetStopGracePeriods(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetStopGracePeriods(params.ApplicationStopGracePeriods{
+		Args: []params.ApplicationStopGracePeriod{{
+			ApplicationTag:  "application-postgresql",
+			StopGracePeriod: time.Minute,
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ApplicationSuite) TestBlockChangesSetLabels(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetLabels(params.ApplicationLabelsArgs{
+		Args: []params.ApplicationLabels{{
+			ApplicationTag: "application-postgresql",
+			Labels:         map[string]string{"tier": "backend"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ApplicationSuite) TestGetLabels(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.labels = map[string]string{"tier": "backend"}
+	results, err := s.api.GetLabels(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-postgresql-subordinate"},
+			{Tag: "application-wat"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ApplicationLabelsResult{
+		{Labels: map[string]string{"tier": "backend"}},
+		{},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+	})
+}
+
+func (s *ApplicationSuite) TestCharmUpgradeStatusNotFound(c *gc.C) {
+	results, err := s.api.CharmUpgradeStatus(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-wat"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.CharmUpgradeStatusResult{
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `charm upgrade of application "postgresql" not found`}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+	})
+}
+
+func (s *ApplicationSuite) TestPauseResumeRollbackCharmUpgrade(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-wat"},
+			{Tag: "unit-postgresql-0"},
+		},
+	}
+	expected := []params.ErrorResult{
+		{},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+		{Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`}},
+	}
+	results, err := s.api.PauseCharmUpgrade(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, expected)
+	results, err = s.api.ResumeCharmUpgrade(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, expected)
+	results, err = s.api.RollbackCharmUpgrade(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, expected)
+
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCallNames(c, "PauseCharmUpgrade", "ResumeCharmUpgrade", "RollbackCharmUpgrade")
+}
+
+func (s *ApplicationSuite) TestBlockChangesPauseCharmUpgrade(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.PauseCharmUpgrade(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ApplicationSuite) TestCharmHistory(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	t0 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	app.charmHistory = []state.CharmHistoryEntry{{
+		CharmURL:      charm.MustParseURL("cs:postgresql-2"),
+		ConfigVersion: 3,
+		Config:        charm.Settings{"stringOption": "value"},
+		User:          "admin",
+		Time:          t0.Add(time.Minute),
+	}, {
+		CharmURL:      charm.MustParseURL("cs:postgresql-1"),
+		ConfigVersion: 1,
+		Time:          t0,
+	}}
+	results, err := s.api.CharmHistory(params.ApplicationCharmHistoryArgs{
+		Args: []params.ApplicationCharmHistoryArg{
+			{Entity: params.Entity{Tag: "application-postgresql"}, Limit: 5},
+			{Entity: params.Entity{Tag: "unit-postgresql-0"}},
+			{Entity: params.Entity{Tag: "application-wat"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.CharmHistoryResult{
+		{Entries: []params.CharmHistoryEntry{{
+			CharmURL:      "cs:postgresql-2",
+			ConfigVersion: 3,
+			Config:        map[string]interface{}{"stringOption": "value"},
+			User:          "admin",
+			Time:          t0.Add(time.Minute),
+		}, {
+			CharmURL:      "cs:postgresql-1",
+			ConfigVersion: 1,
+			Time:          t0,
+		}}},
+		{Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+	})
+	app.CheckCallNames(c, "CharmHistory")
+	app.CheckCall(c, 0, "CharmHistory", 5)
+}
+
+func (s *ApplicationSuite) TestCharmHistoryPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.CharmHistory(params.ApplicationCharmHistoryArgs{
+		Args: []params.ApplicationCharmHistoryArg{
+			{Entity: params.Entity{Tag: "application-postgresql"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestSetRecordsUser(c *gc.C) {
+	err := s.api.Set(params.ApplicationSet{
+		ApplicationName: "postgresql",
+		Options:         map[string]string{"intOption": "5"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCallNames(c, "UpdateConfigSettingsBy")
+	app.CheckCall(c, 0, "UpdateConfigSettingsBy", charm.Settings{"intOption": int64(5)}, "admin")
+}
+
+func (s *ApplicationSuite) TestAddBranch(c *gc.C) {
+	s.backend.branches = map[string]*mockBranch{"existing": {name: "existing"}}
+	results, err := s.api.AddBranch(params.BranchArgs{
+		Branches: []params.BranchArg{
+			{BranchName: "canary"},
+			{BranchName: "existing"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Code: params.CodeAlreadyExists, Message: `branch "existing" already exists`}},
+	})
+	c.Assert(s.backend.branches["canary"].createdBy, gc.Equals, "admin")
+}
+
+func (s *ApplicationSuite) TestBlockChangesAddBranch(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.AddBranch(params.BranchArgs{
+		Branches: []params.BranchArg{{BranchName: "canary"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ApplicationSuite) TestCommitAbortBranch(c *gc.C) {
+	branch := &mockBranch{name: "canary"}
+	s.backend.branches = map[string]*mockBranch{"canary": branch}
+	args := params.BranchArgs{
+		Branches: []params.BranchArg{
+			{BranchName: "canary"},
+			{BranchName: "wat"},
+		},
+	}
+	expected := []params.ErrorResult{
+		{},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `branch "wat" not found`}},
+	}
+	results, err := s.api.CommitBranch(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, expected)
+	results, err = s.api.AbortBranch(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, expected)
+	branch.CheckCallNames(c, "Commit", "Abort")
+	branch.CheckCall(c, 0, "Commit", "admin")
+}
+
+func (s *ApplicationSuite) TestTrackBranch(c *gc.C) {
+	branch := &mockBranch{name: "canary"}
+	s.backend.branches = map[string]*mockBranch{"canary": branch}
+	branch.SetErrors(nil, errors.NotFoundf(`unit "postgresql/9"`))
+	results, err := s.api.TrackBranch(params.BranchTrackArg{
+		BranchName: "canary",
+		Entities: []params.Entity{
+			{Tag: "unit-postgresql-0"},
+			{Tag: "unit-postgresql-9"},
+			{Tag: "application-postgresql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `unit "postgresql/9" not found`}},
+		{Error: &params.Error{Message: `"application-postgresql" is not a valid unit tag`}},
+	})
+	branch.CheckCallNames(c, "AssignUnit", "AssignUnit")
+	branch.CheckCall(c, 0, "AssignUnit", "postgresql/0")
+}
+
+func (s *ApplicationSuite) TestTrackBranchNotFound(c *gc.C) {
+	_, err := s.api.TrackBranch(params.BranchTrackArg{
+		BranchName: "wat",
+		Entities:   []params.Entity{{Tag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `branch "wat" not found`)
+}
+
+func (s *ApplicationSuite) TestBranchInfo(c *gc.C) {
+	created := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.backend.branches = map[string]*mockBranch{"canary": {
+		name:          "canary",
+		createdBy:     "bob",
+		created:       created,
+		config:        map[string]charm.Settings{"postgresql": {"stringOption": "value"}},
+		assignedUnits: map[string][]string{"postgresql": {"postgresql/0"}},
+	}}
+	results, err := s.api.BranchInfo(params.BranchArgs{
+		Branches: []params.BranchArg{
+			{BranchName: "canary"},
+			{BranchName: "wat"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.BranchResult{
+		{Result: &params.BranchInfo{
+			Name:      "canary",
+			CreatedBy: "bob",
+			Created:   created,
+			Config: map[string]map[string]interface{}{
+				"postgresql": {"stringOption": "value"},
+			},
+			AssignedUnits: map[string][]string{"postgresql": {"postgresql/0"}},
+		}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `branch "wat" not found`}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetUnsetInBranch(c *gc.C) {
+	branch := &mockBranch{name: "canary"}
+	s.backend.branches = map[string]*mockBranch{"canary": branch}
+	err := s.api.Set(params.ApplicationSet{
+		ApplicationName: "postgresql",
+		Options:         map[string]string{"intOption": "5"},
+		BranchName:      "canary",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.api.Unset(params.ApplicationUnset{
+		ApplicationName: "postgresql",
+		Options:         []string{"intOption"},
+		BranchName:      "canary",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	branch.CheckCallNames(c, "UpdateCharmConfig", "UpdateCharmConfig")
+	branch.CheckCall(c, 0, "UpdateCharmConfig", "postgresql", charm.Settings{"intOption": int64(5)})
+	branch.CheckCall(c, 1, "UpdateCharmConfig", "postgresql", charm.Settings{"intOption": nil})
+	// The application's own config is not changed.
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
+// addPolicyWebhook registers an application config webhook that
+// rejects any change to stringOption, and returns the requests posted
+// to it.
+func (s *ApplicationSuite) addPolicyWebhook(c *gc.C) *[]configwebhook.Request {
+	var requests []configwebhook.Request
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body configwebhook.Request
+		err := json.NewDecoder(req.Body).Decode(&body)
+		c.Check(err, jc.ErrorIsNil)
+		requests = append(requests, body)
+		if _, ok := body.Set["stringOption"]; ok {
+			http.Error(w, "stringOption is managed centrally", http.StatusForbidden)
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { server.Close() })
+	s.backend.modelUUID = coretesting.ModelTag.Id()
+	s.backend.webhooks = []state.ConfigWebhook{{
+		Name: "policy",
+		URL:  server.URL,
+		CACert: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: server.TLS.Certificates[0].Certificate[0],
+		})),
+		Kinds: []state.ConfigWebhookKind{state.ApplicationConfigWebhook},
+	}}
+	return &requests
+}
+
+func (s *ApplicationSuite) TestSetConsultsWebhooks(c *gc.C) {
+	requests := s.addPolicyWebhook(c)
+	err := s.api.Set(params.ApplicationSet{
+		ApplicationName: "postgresql",
+		Options:         map[string]string{"intOption": "5"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.api.Unset(params.ApplicationUnset{
+		ApplicationName: "postgresql",
+		Options:         []string{"stringOption"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(*requests, jc.DeepEquals, []configwebhook.Request{{
+		Kind:        state.ApplicationConfigWebhook,
+		ModelUUID:   coretesting.ModelTag.Id(),
+		Application: "postgresql",
+		User:        "admin",
+		Set:         map[string]interface{}{"intOption": float64(5)},
+	}, {
+		Kind:        state.ApplicationConfigWebhook,
+		ModelUUID:   coretesting.ModelTag.Id(),
+		Application: "postgresql",
+		User:        "admin",
+		Unset:       []string{"stringOption"},
+	}})
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCallNames(c, "UpdateConfigSettingsBy", "UpdateConfigSettingsBy")
+}
+
+func (s *ApplicationSuite) TestSetRejectedByWebhook(c *gc.C) {
+	s.addPolicyWebhook(c)
+	err := s.api.Set(params.ApplicationSet{
+		ApplicationName: "postgresql",
+		Options:         map[string]string{"stringOption": "foo"},
+	})
+	c.Assert(err, gc.ErrorMatches, `config change rejected by webhook "policy": stringOption is managed centrally`)
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetInBranchRejectedByWebhook(c *gc.C) {
+	requests := s.addPolicyWebhook(c)
+	branch := &mockBranch{name: "canary"}
+	s.backend.branches = map[string]*mockBranch{"canary": branch}
+	err := s.api.Set(params.ApplicationSet{
+		ApplicationName: "postgresql",
+		Options:         map[string]string{"stringOption": "foo"},
+		BranchName:      "canary",
+	})
+	c.Assert(err, gc.ErrorMatches, `config change rejected by webhook "policy": stringOption is managed centrally`)
+	c.Assert(*requests, gc.HasLen, 1)
+	c.Assert((*requests)[0].Branch, gc.Equals, "canary")
+	branch.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetCharmConfigSettingsRejectedByWebhook(c *gc.C) {
+	s.addPolicyWebhook(c)
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		ConfigSettings:  map[string]string{"stringOption": "value"},
+	})
+	c.Assert(err, gc.ErrorMatches, `config change rejected by webhook "policy": stringOption is managed centrally`)
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestGetConfigSchema(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.charm.config.Options["stringOption"] = charm.Option{
+		Type:        "string",
+		Description: "a string",
+	}
+	results, err := s.api.GetConfigSchema(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "unit-postgresql-0"},
+			{Tag: "application-wat"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ApplicationConfigSchemaResult{
+		{Schema: map[string]params.ConfigOptionSchema{
+			"stringOption": {Type: "string", Description: "a string"},
+			"intOption":    {Type: "int", Default: int(123)},
+		}},
+		{Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+	})
+}
+
+func (s *ApplicationSuite) TestGetConfigSchemaPermission(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.GetConfigSchema(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestSetConstraintsSelector(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.labels = map[string]string{"tier": "backend"}
+	cons := constraints.MustParse("mem=4G")
+	err := s.api.SetConstraints(params.SetConstraints{
+		Selector:    "tier=backend",
+		Constraints: cons,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app.CheckCallNames(c, "IsPrincipal", "SetConstraints")
+	app.CheckCall(c, 1, "SetConstraints", cons)
+}
+
+func (s *ApplicationSuite) TestSetConstraintsSelectorSubordinate(c *gc.C) {
+	for _, name := range []string{"postgresql", "postgresql-subordinate"} {
+		app := s.backend.applications[name].(*mockApplication)
+		app.labels = map[string]string{"tier": "backend"}
+	}
+	err := s.api.SetConstraints(params.SetConstraints{
+		Selector:    "tier=backend",
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	c.Assert(err, gc.ErrorMatches, `setting constraints for application "postgresql-subordinate": constraints do not apply to subordinate applications`)
+	// No application is changed when any of them cannot be.
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCallNames(c, "IsPrincipal")
+}
+
+func (s *ApplicationSuite) TestSetConstraintsSelectorError(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.labels = map[string]string{"tier": "backend"}
+	app.SetErrors(nil, errors.New("boom")) // IsPrincipal, SetConstraints
+	err := s.api.SetConstraints(params.SetConstraints{
+		Selector: "tier=backend",
+	})
+	c.Assert(err, gc.ErrorMatches, `setting constraints for application "postgresql": boom`)
+}
+
+func (s *ApplicationSuite) TestSetConstraintsSelectorAndName(c *gc.C) {
+	err := s.api.SetConstraints(params.SetConstraints{
+		ApplicationName: "postgresql",
+		Selector:        "tier=backend",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "both application name and selector not valid")
+}
@@ -4,12 +4,18 @@
 package application
 
 import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common/configwebhook"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/constraints"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -22,11 +28,17 @@ import (
 // with the same names.
 type Backend interface {
 	storagecommon.StorageInterface
+	configwebhook.Backend
 
+	AgentVersion() (version.Number, error)
 	AllModelUUIDs() ([]string, error)
+	AllSpaces() ([]Space, error)
 	Application(string) (Application, error)
+	ApplicationsMatching(coreapplication.Selector) ([]Application, error)
 	ApplyOperation(state.ModelOperation) error
 	AddApplication(state.AddApplicationArgs) (Application, error)
+	AddBranch(string, string) (Branch, error)
+	Branch(string) (Branch, error)
 	RemoteApplication(string) (RemoteApplication, error)
 	AddRemoteApplication(state.AddRemoteApplicationParams) (RemoteApplication, error)
 	AddRelation(...state.Endpoint) (Relation, error)
@@ -36,6 +48,7 @@ type Backend interface {
 	InferEndpoints(...string) ([]state.Endpoint, error)
 	Machine(string) (Machine, error)
 	ModelTag() names.ModelTag
+	ModelType() state.ModelType
 	Unit(string) (Unit, error)
 	SaveController(info crossmodel.ControllerInfo, modelUUID string) (ExternalController, error)
 	ControllerTag() names.ControllerTag
@@ -59,7 +72,10 @@ type BlockChecker interface {
 type Application interface {
 	AddUnit(state.AddUnitParams) (Unit, error)
 	AllUnits() ([]Unit, error)
+	ChangeScale(int) (int, error)
 	Charm() (Charm, bool, error)
+	CharmHistory(int) ([]state.CharmHistoryEntry, error)
+	CharmUpgrade() (*state.CharmUpgrade, bool)
 	CharmURL() (*charm.URL, bool)
 	Channel() csparams.Channel
 	ClearExposed() error
@@ -69,14 +85,44 @@ type Application interface {
 	DestroyOperation() *state.DestroyApplicationOperation
 	Endpoints() ([]state.Endpoint, error)
 	IsPrincipal() bool
+	Labels() map[string]string
+	Name() string
+	PauseCharmUpgrade() error
+	PodSpec() (state.PodSpec, error)
+	PodSpecHistory(int) ([]state.PodSpec, error)
+	ResumeCharmUpgrade() error
+	RollbackCharmUpgrade() error
+	RolloutStatus() (state.RolloutStatus, error)
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
 	SetExposed() error
+	SetLabels(map[string]string) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	SetPodSpec(string, string) error
+	SetScale(int) error
+	SetStopGracePeriod(time.Duration) error
+	StopGracePeriod() time.Duration
 	UpdateApplicationSeries(string, bool) error
-	UpdateConfigSettings(charm.Settings) error
+	UpdateConfigSettingsBy(charm.Settings, string) error
+	WatchPodSpec() state.NotifyWatcher
+}
+
+// Branch defines a subset of the functionality provided by the
+// state.Branch type, as required by the application facade. For
+// details on the methods, see the methods on state.Branch with
+// the same names.
+type Branch interface {
+	Name() string
+	CreatedBy() string
+	Created() time.Time
+	Config() map[string]charm.Settings
+	AssignedUnits() map[string][]string
+	UpdateCharmConfig(string, charm.Settings) error
+	AssignUnit(string) error
+	Commit(string) error
+	Abort() error
 }
 
 // Charm defines a subset of the functionality provided by the
@@ -85,6 +131,7 @@ type Application interface {
 // the same names.
 type Charm interface {
 	charm.Charm
+	RequiredFeatures() []string
 }
 
 // Machine defines a subset of the functionality provided by the
@@ -114,6 +161,7 @@ type Relation interface {
 // the same names.
 type Unit interface {
 	UnitTag() names.UnitTag
+	ContainerStatus() (status.StatusInfo, error)
 	Destroy() error
 	DestroyOperation() *state.DestroyUnitOperation
 	IsPrincipal() bool
@@ -142,9 +190,13 @@ type Resources interface {
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
 // removed once all relevant methods are moved from state to model.
+//
+// IAASModel is nil in Kubernetes (CAAS) models, which do not support
+// storage yet.
 type stateShim struct {
 	*state.State
 	*state.IAASModel
+	model *state.Model
 }
 
 type ExternalController state.ExternalController
@@ -156,14 +208,20 @@ func (s stateShim) SaveController(controllerInfo crossmodel.ControllerInfo, mode
 
 // NewStateBackend converts a state.State into a Backend.
 func NewStateBackend(st *state.State) (Backend, error) {
-	im, err := st.IAASModel()
+	m, err := st.Model()
 	if err != nil {
 		return nil, err
 	}
-	return &stateShim{
-		State:     st,
-		IAASModel: im,
-	}, nil
+	shim := &stateShim{
+		State: st,
+		model: m,
+	}
+	if m.Type() == state.ModelTypeIAAS {
+		if shim.IAASModel, err = m.IAASModel(); err != nil {
+			return nil, err
+		}
+	}
+	return shim, nil
 }
 
 // NewStateApplication converts a state.Application into an Application.
@@ -179,6 +237,37 @@ func CharmToStateCharm(ch Charm) *state.Charm {
 	return ch.(stateCharmShim).Charm
 }
 
+// AgentVersion returns the agent version of the model.
+func (s stateShim) AgentVersion() (version.Number, error) {
+	cfg, err := s.model.Config()
+	if err != nil {
+		return version.Zero, errors.Trace(err)
+	}
+	ver, ok := cfg.AgentVersion()
+	if !ok {
+		return version.Zero, errors.NotFoundf("model agent version")
+	}
+	return ver, nil
+}
+
+// AllSpaces returns all of the model's spaces.
+func (s stateShim) AllSpaces() ([]Space, error) {
+	spaces, err := s.State.AllSpaces()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Space, len(spaces))
+	for i, space := range spaces {
+		result[i] = spaceShim{space}
+	}
+	return result, nil
+}
+
+// ModelType returns the type of the model.
+func (s stateShim) ModelType() state.ModelType {
+	return s.model.Type()
+}
+
 func (s stateShim) Application(name string) (Application, error) {
 	a, err := s.State.Application(name)
 	if err != nil {
@@ -187,6 +276,18 @@ func (s stateShim) Application(name string) (Application, error) {
 	return stateApplicationShim{a, s.State}, nil
 }
 
+func (s stateShim) ApplicationsMatching(selector coreapplication.Selector) ([]Application, error) {
+	apps, err := s.State.ApplicationsMatching(selector)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Application, len(apps))
+	for i, a := range apps {
+		result[i] = stateApplicationShim{a, s.State}
+	}
+	return result, nil
+}
+
 func (s stateShim) AddApplication(args state.AddApplicationArgs) (Application, error) {
 	a, err := s.State.AddApplication(args)
 	if err != nil {
@@ -195,6 +296,22 @@ func (s stateShim) AddApplication(args state.AddApplicationArgs) (Application, e
 	return stateApplicationShim{a, s.State}, nil
 }
 
+func (s stateShim) AddBranch(name, user string) (Branch, error) {
+	b, err := s.State.AddBranch(name, user)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s stateShim) Branch(name string) (Branch, error) {
+	b, err := s.State.Branch(name)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
 type remoteApplicationShim struct {
 	*state.RemoteApplication
 }
@@ -300,7 +417,7 @@ func (a stateApplicationShim) Charm() (Charm, bool, error) {
 	if err != nil {
 		return nil, false, err
 	}
-	return ch, force, nil
+	return stateCharmShim{ch}, force, nil
 }
 
 func (a stateApplicationShim) AllUnits() ([]Unit, error) {
@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// updateCharmProfiles replaces the LXD profile declared by the old
+// charm of the named application with that declared by the new charm,
+// on each provisioned instance hosting a unit of the application. It
+// does nothing if neither charm declares a profile, or if the model's
+// cloud does not apply charm profiles to its instances.
+//
+// Only the instances of the model's cloud are updated; containers
+// started on machines by the machine agent are not.
+func updateCharmProfiles(
+	st *state.State,
+	getEnviron stateenvirons.NewEnvironFunc,
+	appName string,
+	oldCharm, newCharm *state.Charm,
+) error {
+	oldProfile := oldCharm.LXDProfile()
+	newProfile := newCharm.LXDProfile()
+	if oldProfile.Empty() && newProfile.Empty() {
+		return nil
+	}
+	env, err := getEnviron(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	manager, ok := env.(environs.CharmProfileManager)
+	if !ok {
+		return nil
+	}
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var oldName, newName string
+	if !oldProfile.Empty() {
+		oldName = lxdprofile.Name(model.Name(), appName, oldCharm.Revision())
+	}
+	if !newProfile.Empty() {
+		newName = lxdprofile.Name(model.Name(), appName, newCharm.Revision())
+	}
+	if oldName == newName {
+		return nil
+	}
+
+	app, err := st.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	updated := make(map[instance.Id]bool)
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		machine, err := st.Machine(machineId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		instId, err := machine.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			// The profile will be applied when the
+			// machine is provisioned.
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if updated[instId] {
+			continue
+		}
+		if err := manager.ReplaceCharmProfile(instId, oldName, newName, newProfile); err != nil {
+			return errors.Annotatef(err, "cannot update LXD profile of machine %s", machineId)
+		}
+		updated[instId] = true
+	}
+	return nil
+}
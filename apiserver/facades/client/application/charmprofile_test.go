@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing/factory"
+)
+
+type charmProfileSuite struct {
+	jujutesting.JujuConnSuite
+
+	env *mockCharmProfileEnviron
+}
+
+var _ = gc.Suite(&charmProfileSuite{})
+
+func (s *charmProfileSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.env = &mockCharmProfileEnviron{}
+}
+
+func (s *charmProfileSuite) getEnviron(*state.State) (environs.Environ, error) {
+	return s.env, nil
+}
+
+func (s *charmProfileSuite) addCharm(c *gc.C, revision int, profile *lxdprofile.Profile) *state.Charm {
+	ch, err := s.State.AddCharm(state.CharmInfo{
+		Charm:       testcharms.Repo.CharmDir("wordpress"),
+		ID:          charm.MustParseURL(fmt.Sprintf("local:quantal/wordpress-%d", revision)),
+		StoragePath: fmt.Sprintf("wordpress-%d", revision),
+		SHA256:      fmt.Sprintf("wordpress-%d-sha256", revision),
+		LXDProfile:  profile,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return ch
+}
+
+var kvmProfile = &lxdprofile.Profile{
+	Devices: map[string]map[string]string{
+		"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+	},
+}
+
+func (s *charmProfileSuite) TestUpdateCharmProfiles(c *gc.C) {
+	oldCharm := s.addCharm(c, 3, nil)
+	newCharm := s.addCharm(c, 4, kvmProfile)
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress", Charm: oldCharm})
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{InstanceId: "inst-0"})
+	for i := 0; i < 2; i++ {
+		s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: machine})
+	}
+	// Units of unprovisioned machines are skipped.
+	unprovisioned, _ := s.Factory.MakeUnprovisionedMachineReturningPassword(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: unprovisioned})
+
+	err := application.UpdateCharmProfiles(s.State, s.getEnviron, "wordpress", oldCharm, newCharm)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.env.calls, jc.DeepEquals, []replaceCharmProfileCall{{
+		id:      "inst-0",
+		oldName: "",
+		newName: lxdprofile.Name(model.Name(), "wordpress", 4),
+		profile: kvmProfile,
+	}})
+}
+
+func (s *charmProfileSuite) TestUpdateCharmProfilesNoProfiles(c *gc.C) {
+	oldCharm := s.addCharm(c, 3, nil)
+	newCharm := s.addCharm(c, 4, nil)
+	err := application.UpdateCharmProfiles(s.State, s.getEnviron, "wordpress", oldCharm, newCharm)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.env.calls, gc.HasLen, 0)
+}
+
+func (s *charmProfileSuite) TestUpdateCharmProfilesNotSupported(c *gc.C) {
+	oldCharm := s.addCharm(c, 3, kvmProfile)
+	newCharm := s.addCharm(c, 4, nil)
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress", Charm: oldCharm})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	// The dummy provider does not apply charm profiles.
+	err := application.UpdateCharmProfiles(s.State, func(*state.State) (environs.Environ, error) {
+		return s.Environ, nil
+	}, "wordpress", oldCharm, newCharm)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type replaceCharmProfileCall struct {
+	id      instance.Id
+	oldName string
+	newName string
+	profile *lxdprofile.Profile
+}
+
+type mockCharmProfileEnviron struct {
+	environs.Environ
+	calls []replaceCharmProfileCall
+}
+
+func (e *mockCharmProfileEnviron) ReplaceCharmProfile(id instance.Id, oldName, newName string, profile *lxdprofile.Profile) error {
+	e.calls = append(e.calls, replaceCharmProfileCall{id, oldName, newName, profile})
+	return nil
+}
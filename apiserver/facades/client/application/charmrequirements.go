@@ -0,0 +1,102 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmfeatures"
+)
+
+// modelFeatureCheckers holds, for each feature that a charm may
+// require and that some models can provide, a function reporting
+// whether the model provides it. Features without a checker, such as
+// secrets, are not provided by any model.
+var modelFeatureCheckers = map[string]func(Backend) (bool, error){
+	charmfeatures.Spaces: modelHasSpaces,
+}
+
+// modelHasSpaces reports whether the model has any spaces to which
+// charm endpoints can be bound.
+func modelHasSpaces(backend Backend) (bool, error) {
+	spaces, err := backend.AllSpaces()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return len(spaces) > 0, nil
+}
+
+// modelProvidesFeature returns a function reporting whether the
+// backend's model provides a charm feature.
+func modelProvidesFeature(backend Backend) func(string) (bool, error) {
+	return func(feature string) (bool, error) {
+		check, ok := modelFeatureCheckers[feature]
+		if !ok {
+			return false, nil
+		}
+		return check(backend)
+	}
+}
+
+// checkCharmRequirements returns an error if a model running agents
+// of the given version, and providing the features for which
+// hasFeature returns true, cannot satisfy the minimum Juju version or
+// the features required by the charm.
+func checkCharmRequirements(ch Charm, agentVersion version.Number, hasFeature func(string) (bool, error)) error {
+	meta := ch.Meta()
+	var reqErr charmRequirementsError
+	if minVersion := meta.MinJujuVersion; minVersion != version.Zero && minVersion.Compare(agentVersion) > 0 {
+		reqErr.minVersion = minVersion
+	}
+	for _, feature := range ch.RequiredFeatures() {
+		ok, err := hasFeature(feature)
+		if err != nil {
+			return errors.Annotatef(err, "checking for feature %q", feature)
+		}
+		if !ok {
+			reqErr.missingFeatures = append(reqErr.missingFeatures, feature)
+		}
+	}
+	if reqErr.minVersion == version.Zero && len(reqErr.missingFeatures) == 0 {
+		return nil
+	}
+	reqErr.charmName = meta.Name
+	reqErr.agentVersion = agentVersion
+	return &reqErr
+}
+
+// charmRequirementsError is returned when a charm requires a newer
+// Juju version, or features, not available in the model.
+type charmRequirementsError struct {
+	charmName       string
+	agentVersion    version.Number
+	minVersion      version.Number
+	missingFeatures []string
+}
+
+// Error is part of the error interface.
+func (e *charmRequirementsError) Error() string {
+	var missing []string
+	if e.minVersion != version.Zero {
+		missing = append(missing, fmt.Sprintf("juju version %s or later", e.minVersion))
+	}
+	if len(e.missingFeatures) > 0 {
+		missing = append(missing, fmt.Sprintf("features %s", strings.Join(e.missingFeatures, ", ")))
+	}
+	return fmt.Sprintf(
+		"charm %q requirements not met by model running juju %s: missing %s",
+		e.charmName, e.agentVersion, strings.Join(missing, "; "),
+	)
+}
+
+// ErrorCode returns the error code used when the error is
+// returned over the API.
+func (e *charmRequirementsError) ErrorCode() string {
+	return params.CodeCharmRequirementsNotMet
+}
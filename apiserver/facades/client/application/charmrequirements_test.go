@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type charmRequirementsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&charmRequirementsSuite{})
+
+func noFeatures(string) (bool, error) {
+	return false, nil
+}
+
+func hasFeatures(features ...string) func(string) (bool, error) {
+	return func(feature string) (bool, error) {
+		for _, f := range features {
+			if f == feature {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+func (s *charmRequirementsSuite) TestNoRequirements(c *gc.C) {
+	ch := &mockCharm{meta: &charm.Meta{Name: "dummy"}}
+	err := application.CheckCharmRequirements(ch, version.MustParse("2.3.0"), noFeatures)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmRequirementsSuite) TestMinJujuVersionSatisfied(c *gc.C) {
+	ch := &mockCharm{meta: &charm.Meta{
+		Name:           "dummy",
+		MinJujuVersion: version.MustParse("2.3.0"),
+	}}
+	err := application.CheckCharmRequirements(ch, version.MustParse("2.3.0"), noFeatures)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmRequirementsSuite) TestMinJujuVersionTooHigh(c *gc.C) {
+	ch := &mockCharm{meta: &charm.Meta{
+		Name:           "dummy",
+		MinJujuVersion: version.MustParse("2.4.0"),
+	}}
+	err := application.CheckCharmRequirements(ch, version.MustParse("2.3.0"), noFeatures)
+	c.Assert(err, gc.ErrorMatches,
+		`charm "dummy" requirements not met by model running juju 2.3.0: missing juju version 2.4.0 or later`)
+	c.Assert(params.IsCodeCharmRequirementsNotMet(err), jc.IsTrue)
+}
+
+func (s *charmRequirementsSuite) TestRequiredFeaturesSatisfied(c *gc.C) {
+	ch := &mockCharm{
+		meta:             &charm.Meta{Name: "dummy"},
+		requiredFeatures: []string{"spaces"},
+	}
+	err := application.CheckCharmRequirements(ch, version.MustParse("2.3.0"), hasFeatures("spaces"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmRequirementsSuite) TestRequiredFeaturesMissing(c *gc.C) {
+	ch := &mockCharm{
+		meta:             &charm.Meta{Name: "dummy"},
+		requiredFeatures: []string{"secrets", "spaces", "unknown"},
+	}
+	err := application.CheckCharmRequirements(ch, version.MustParse("2.3.0"), hasFeatures("spaces"))
+	c.Assert(err, gc.ErrorMatches,
+		`charm "dummy" requirements not met by model running juju 2.3.0: missing features secrets, unknown`)
+	c.Assert(params.IsCodeCharmRequirementsNotMet(err), jc.IsTrue)
+}
+
+func (s *charmRequirementsSuite) TestMinJujuVersionAndRequiredFeaturesMissing(c *gc.C) {
+	ch := &mockCharm{
+		meta: &charm.Meta{
+			Name:           "dummy",
+			MinJujuVersion: version.MustParse("2.4.0"),
+		},
+		requiredFeatures: []string{"spaces"},
+	}
+	err := application.CheckCharmRequirements(ch, version.MustParse("2.3.0"), noFeatures)
+	c.Assert(err, gc.ErrorMatches,
+		`charm "dummy" requirements not met by model running juju 2.3.0: missing juju version 2.4.0 or later; features spaces`)
+	c.Assert(params.IsCodeCharmRequirementsNotMet(err), jc.IsTrue)
+}
+
+func (s *charmRequirementsSuite) TestRequiredFeaturesCheckError(c *gc.C) {
+	ch := &mockCharm{
+		meta:             &charm.Meta{Name: "dummy"},
+		requiredFeatures: []string{"spaces"},
+	}
+	err := application.CheckCharmRequirements(ch, version.MustParse("2.3.0"), func(string) (bool, error) {
+		return false, errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, `checking for feature "spaces": boom`)
+	c.Assert(params.IsCodeCharmRequirementsNotMet(err), jc.IsFalse)
+}
@@ -8,9 +8,11 @@ import (
 	"io"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charmrepo.v2-unstable"
@@ -20,6 +22,9 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/core/charmfeatures"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -210,6 +215,14 @@ type CharmArchive struct {
 
 // StoreCharmArchive stores a charm archive in environment storage.
 func StoreCharmArchive(st *state.State, archive CharmArchive) error {
+	profile, err := lxdprofile.ReadCharmProfile(archive.Charm)
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm LXD profile")
+	}
+	features, err := charmfeatures.ReadCharmFeatures(archive.Charm)
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm required features")
+	}
 	storage := newStateStorage(st.ModelUUID(), st.MongoSession())
 	storagePath, err := charmArchiveStoragePath(archive.ID)
 	if err != nil {
@@ -220,11 +233,13 @@ func StoreCharmArchive(st *state.State, archive CharmArchive) error {
 	}
 
 	info := state.CharmInfo{
-		Charm:       archive.Charm,
-		ID:          archive.ID,
-		StoragePath: storagePath,
-		SHA256:      archive.SHA256,
-		Macaroon:    archive.Macaroon,
+		Charm:            archive.Charm,
+		ID:               archive.ID,
+		StoragePath:      storagePath,
+		SHA256:           archive.SHA256,
+		Macaroon:         archive.Macaroon,
+		LXDProfile:       profile,
+		RequiredFeatures: features,
 	}
 
 	// Now update the charm data in state and mark it as no longer pending.
@@ -261,11 +276,25 @@ func charmArchiveStoragePath(curl *charm.URL) (string, error) {
 	return fmt.Sprintf("charms/%s-%s", curl.String(), uuid), nil
 }
 
+// charmResolutionTTL is how long the controller remembers the charm
+// store's resolution of a charm reference.
+const charmResolutionTTL = 5 * time.Minute
+
+// charmResolutionCache is shared by all ResolveCharms calls, so that
+// deploying a bundle with many applications using the same charm
+// doesn't resolve it with the charm store for each of them.
+var charmResolutionCache = charmstore.NewResolutionCache(clock.WallClock, charmResolutionTTL)
+
 // ResolveCharm resolves the best available charm URLs with series, for charm
-// locations without a series specified.
+// locations without a series specified. The charms are resolved in
+// args.Channel, which may name a track as well as a risk.
 func ResolveCharms(st *state.State, args params.ResolveCharms) (params.ResolveCharmResults, error) {
 	var results params.ResolveCharmResults
 
+	channel, err := charmstore.ParseChannel(args.Channel)
+	if err != nil {
+		return params.ResolveCharmResults{}, errors.Trace(err)
+	}
 	model, err := st.Model()
 	if err != nil {
 		return params.ResolveCharmResults{}, errors.Trace(err)
@@ -274,9 +303,17 @@ func ResolveCharms(st *state.State, args params.ResolveCharms) (params.ResolveCh
 	if err != nil {
 		return params.ResolveCharmResults{}, err
 	}
-	repo := config.SpecializeCharmRepo(
-		NewCharmStoreRepo(csclient.New(csclient.Params{})),
-		envConfig)
+	csClient := csclient.New(csclient.Params{})
+	resolve := func(id charmstore.CharmID) (charmstore.ResolvedCharm, error) {
+		repo := config.SpecializeCharmRepo(
+			NewCharmStoreRepo(csClient.WithChannel(id.Channel)),
+			envConfig)
+		curl, err := resolveCharm(id.URL, repo)
+		if err != nil {
+			return charmstore.ResolvedCharm{}, err
+		}
+		return charmstore.ResolvedCharm{URL: curl, Channel: id.Channel}, nil
+	}
 
 	for _, ref := range args.References {
 		result := params.ResolveCharmResult{}
@@ -284,11 +321,15 @@ func ResolveCharms(st *state.State, args params.ResolveCharms) (params.ResolveCh
 		if err != nil {
 			result.Error = err.Error()
 		} else {
-			curl, err := resolveCharm(curl, repo)
+			resolved, err := charmResolutionCache.Resolve(
+				csClient.ServerURL(),
+				charmstore.CharmID{URL: curl, Channel: channel.CSChannel()},
+				resolve,
+			)
 			if err != nil {
 				result.Error = err.Error()
 			} else {
-				result.URL = curl.String()
+				result.URL = resolved.URL.String()
 			}
 		}
 		results.URLs = append(results.URLs, result)
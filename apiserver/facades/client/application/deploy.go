@@ -183,7 +183,7 @@ func addUnits(
 			continue
 		}
 		if err := unit.AssignWithPlacement(placement[i]); err != nil {
-			return nil, errors.Annotatef(err, "adding new machine to host unit %q", unit.UnitTag().Id())
+			return nil, placementError(errors.Annotatef(err, "adding new machine to host unit %q", unit.UnitTag().Id()))
 		}
 		units[i] = unit
 	}
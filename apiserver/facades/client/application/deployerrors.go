@@ -0,0 +1,34 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/juju/apiserver/params"
+)
+
+// codedError associates an error with the code used when it is
+// returned over the API, so that clients can tell well-known
+// deployment failures apart without matching on the message.
+type codedError struct {
+	error
+	code string
+}
+
+// ErrorCode returns the error code used when the error is
+// returned over the API.
+func (e *codedError) ErrorCode() string {
+	return e.code
+}
+
+// charmNotFoundError returns err, which reports that a charm could
+// not be found, with the code CodeCharmNotFound.
+func charmNotFoundError(err error) error {
+	return &codedError{error: err, code: params.CodeCharmNotFound}
+}
+
+// placementError returns err, which reports that a unit could not be
+// placed as directed, with the code CodePlacementNotValid.
+func placementError(err error) error {
+	return &codedError{error: err, code: params.CodePlacementNotValid}
+}
@@ -3,7 +3,16 @@
 
 package application
 
+import "github.com/juju/juju/apiserver/facade"
+
 var (
 	ParseSettingsCompatible = parseSettingsCompatible
 	NewStateStorage         = &newStateStorage
+	CheckCharmRequirements  = checkCharmRequirements
+	UpdateCharmProfiles     = updateCharmProfiles
 )
+
+// SetResources sets the resources in which the API registers watchers.
+func SetResources(api *API, resources facade.Resources) {
+	api.resources = resources
+}
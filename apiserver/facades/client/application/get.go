@@ -38,6 +38,20 @@ func (api *API) getApplicationSettings(
 	if err != nil {
 		return params.ApplicationGetResults{}, err
 	}
+	if args.BranchName != "" {
+		// Show the config as seen by the units assigned to the branch.
+		branch, err := api.backend.Branch(args.BranchName)
+		if err != nil {
+			return params.ApplicationGetResults{}, err
+		}
+		for name, value := range branch.Config()[args.ApplicationName] {
+			if value == nil {
+				delete(settings, name)
+			} else {
+				settings[name] = value
+			}
+		}
+	}
 	charm, _, err := app.Charm()
 	if err != nil {
 		return params.ApplicationGetResults{}, err
@@ -104,3 +118,17 @@ func describeV4(settings charm.Settings, config *charm.Config) map[string]interf
 	}
 	return results
 }
+
+// configSchema returns the schema of each of the options in the
+// given charm config.
+func configSchema(config *charm.Config) map[string]params.ConfigOptionSchema {
+	schema := make(map[string]params.ConfigOptionSchema)
+	for name, option := range config.Options {
+		schema[name] = params.ConfigOptionSchema{
+			Type:        option.Type,
+			Description: option.Description,
+			Default:     option.Default,
+		}
+	}
+	return schema
+}
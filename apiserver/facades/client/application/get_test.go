@@ -49,8 +49,10 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 
 func (s *getSuite) TestClientServiceGetSmoketestV4(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v4 := &application.APIv4{s.serviceAPI}
-	results, err := v4.Get(params.ApplicationGet{"wordpress"})
+	v4 := &application.APIv4{&application.APIv5{&application.APIv6{
+		&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{s.serviceAPI}}}}},
+	}}}
+	results, err := v4.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
 		Application: "wordpress",
@@ -69,7 +71,7 @@ func (s *getSuite) TestClientServiceGetSmoketestV4(c *gc.C) {
 
 func (s *getSuite) TestClientServiceGetSmoketest(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	results, err := s.serviceAPI.Get(params.ApplicationGet{"wordpress"})
+	results, err := s.serviceAPI.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
 		Application: "wordpress",
@@ -88,10 +90,42 @@ func (s *getSuite) TestClientServiceGetSmoketest(c *gc.C) {
 }
 
 func (s *getSuite) TestServiceGetUnknownService(c *gc.C) {
-	_, err := s.serviceAPI.Get(params.ApplicationGet{"unknown"})
+	_, err := s.serviceAPI.Get(params.ApplicationGet{ApplicationName: "unknown"})
 	c.Assert(err, gc.ErrorMatches, `application "unknown" not found`)
 }
 
+func (s *getSuite) TestServiceGetInBranch(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	branch, err := s.State.AddBranch("canary", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig(app.Name(), charm.Settings{"blog-title": "Canary"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.serviceAPI.Get(params.ApplicationGet{
+		ApplicationName: "wordpress",
+		BranchName:      "canary",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Config["blog-title"], jc.DeepEquals, map[string]interface{}{
+		"default":     "My Title",
+		"description": "A descriptive title used for the blog.",
+		"source":      "user",
+		"type":        "string",
+		"value":       "Canary",
+	})
+
+	// The application's own config is unchanged.
+	results, err = s.serviceAPI.Get(params.ApplicationGet{ApplicationName: "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Config["blog-title"], jc.DeepEquals, map[string]interface{}{
+		"default":     "My Title",
+		"description": "A descriptive title used for the blog.",
+		"source":      "default",
+		"type":        "string",
+		"value":       "My Title",
+	})
+}
+
 var getTests = []struct {
 	about       string
 	charm       string
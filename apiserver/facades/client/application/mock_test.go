@@ -5,17 +5,22 @@ package application_test
 
 import (
 	"io"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	jtesting "github.com/juju/testing"
 	"github.com/juju/utils/set"
+	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/constraints"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
@@ -64,14 +69,19 @@ type mockCharm struct {
 	jtesting.Stub
 
 	charm.Charm
-	config *charm.Config
-	meta   *charm.Meta
+	config           *charm.Config
+	meta             *charm.Meta
+	requiredFeatures []string
 }
 
 func (m *mockCharm) Meta() *charm.Meta {
 	return m.meta
 }
 
+func (m *mockCharm) RequiredFeatures() []string {
+	return m.requiredFeatures
+}
+
 func (c *mockCharm) Config() *charm.Config {
 	c.MethodCall(c, "Config")
 	c.PopNoErr()
@@ -82,14 +92,24 @@ type mockApplication struct {
 	jtesting.Stub
 	application.Application
 
-	bindings    map[string]string
-	charm       *mockCharm
-	curl        *charm.URL
-	endpoints   []state.Endpoint
-	name        string
-	subordinate bool
-	series      string
-	units       []mockUnit
+	bindings     map[string]string
+	charm        *mockCharm
+	charmHistory []state.CharmHistoryEntry
+	curl         *charm.URL
+	endpoints    []state.Endpoint
+	labels       map[string]string
+	name         string
+	subordinate  bool
+	series       string
+	units        []mockUnit
+
+	podSpec        state.PodSpec
+	podSpecHistory []state.PodSpec
+	rolloutStatus  state.RolloutStatus
+	scale          int
+	watcher        *mockNotifyWatcher
+
+	stopGracePeriod time.Duration
 }
 
 func (m *mockApplication) Name() string {
@@ -154,6 +174,128 @@ func (a *mockApplication) UpdateApplicationSeries(series string, force bool) err
 	return a.NextErr()
 }
 
+func (a *mockApplication) Labels() map[string]string {
+	a.MethodCall(a, "Labels")
+	a.PopNoErr()
+	return a.labels
+}
+
+func (a *mockApplication) SetLabels(labels map[string]string) error {
+	a.MethodCall(a, "SetLabels", labels)
+	if err := a.NextErr(); err != nil {
+		return err
+	}
+	a.labels = labels
+	return nil
+}
+
+func (a *mockApplication) StopGracePeriod() time.Duration {
+	a.MethodCall(a, "StopGracePeriod")
+	a.PopNoErr()
+	return a.stopGracePeriod
+}
+
+func (a *mockApplication) SetStopGracePeriod(period time.Duration) error {
+	a.MethodCall(a, "SetStopGracePeriod", period)
+	if err := a.NextErr(); err != nil {
+		return err
+	}
+	a.stopGracePeriod = period
+	return nil
+}
+
+func (a *mockApplication) CharmUpgrade() (*state.CharmUpgrade, bool) {
+	a.MethodCall(a, "CharmUpgrade")
+	a.PopNoErr()
+	return nil, false
+}
+
+func (a *mockApplication) CharmHistory(limit int) ([]state.CharmHistoryEntry, error) {
+	a.MethodCall(a, "CharmHistory", limit)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.charmHistory, nil
+}
+
+func (a *mockApplication) PodSpec() (state.PodSpec, error) {
+	a.MethodCall(a, "PodSpec")
+	if err := a.NextErr(); err != nil {
+		return state.PodSpec{}, err
+	}
+	return a.podSpec, nil
+}
+
+func (a *mockApplication) SetPodSpec(spec, user string) error {
+	a.MethodCall(a, "SetPodSpec", spec, user)
+	return a.NextErr()
+}
+
+func (a *mockApplication) PodSpecHistory(limit int) ([]state.PodSpec, error) {
+	a.MethodCall(a, "PodSpecHistory", limit)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.podSpecHistory, nil
+}
+
+func (a *mockApplication) RolloutStatus() (state.RolloutStatus, error) {
+	a.MethodCall(a, "RolloutStatus")
+	if err := a.NextErr(); err != nil {
+		return state.RolloutStatus{}, err
+	}
+	return a.rolloutStatus, nil
+}
+
+func (a *mockApplication) WatchPodSpec() state.NotifyWatcher {
+	a.MethodCall(a, "WatchPodSpec")
+	a.PopNoErr()
+	return a.watcher
+}
+
+func (a *mockApplication) SetScale(scale int) error {
+	a.MethodCall(a, "SetScale", scale)
+	if err := a.NextErr(); err != nil {
+		return err
+	}
+	a.scale = scale
+	return nil
+}
+
+func (a *mockApplication) ChangeScale(scaleChange int) (int, error) {
+	a.MethodCall(a, "ChangeScale", scaleChange)
+	if err := a.NextErr(); err != nil {
+		return 0, err
+	}
+	a.scale += scaleChange
+	return a.scale, nil
+}
+
+func (a *mockApplication) UpdateConfigSettingsBy(changes charm.Settings, user string) error {
+	a.MethodCall(a, "UpdateConfigSettingsBy", changes, user)
+	return a.NextErr()
+}
+
+func (a *mockApplication) PauseCharmUpgrade() error {
+	a.MethodCall(a, "PauseCharmUpgrade")
+	return a.NextErr()
+}
+
+func (a *mockApplication) ResumeCharmUpgrade() error {
+	a.MethodCall(a, "ResumeCharmUpgrade")
+	return a.NextErr()
+}
+
+func (a *mockApplication) RollbackCharmUpgrade() error {
+	a.MethodCall(a, "RollbackCharmUpgrade")
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetConstraints(cons constraints.Value) error {
+	a.MethodCall(a, "SetConstraints", cons)
+	return a.NextErr()
+}
+
 func (a *mockApplication) Series() string {
 	a.MethodCall(a, "Series")
 	a.PopNoErr()
@@ -252,11 +394,13 @@ type mockBackend struct {
 	application.Backend
 
 	modelUUID                  string
+	modelType                  state.ModelType
 	model                      application.Model
 	charm                      *mockCharm
 	allmodels                  []application.Model
 	users                      set.Strings
 	applications               map[string]application.Application
+	branches                   map[string]*mockBranch
 	remoteApplications         map[string]application.RemoteApplication
 	spaces                     map[string]application.Space
 	endpoints                  *[]state.Endpoint
@@ -266,6 +410,8 @@ type mockBackend struct {
 	storageInstances           map[string]*mockStorage
 	storageInstanceFilesystems map[string]*mockFilesystem
 	controllers                map[string]crossmodel.ControllerInfo
+	agentVersion               version.Number
+	webhooks                   []state.ConfigWebhook
 }
 
 func (m *mockBackend) ControllerTag() names.ControllerTag {
@@ -458,6 +604,53 @@ func (m *mockBackend) Application(name string) (application.Application, error)
 	return app, nil
 }
 
+func (m *mockBackend) AddBranch(name, user string) (application.Branch, error) {
+	m.MethodCall(m, "AddBranch", name, user)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	if _, ok := m.branches[name]; ok {
+		return nil, errors.AlreadyExistsf("branch %q", name)
+	}
+	if m.branches == nil {
+		m.branches = make(map[string]*mockBranch)
+	}
+	branch := &mockBranch{name: name, createdBy: user}
+	m.branches[name] = branch
+	return branch, nil
+}
+
+func (m *mockBackend) Branch(name string) (application.Branch, error) {
+	m.MethodCall(m, "Branch", name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	branch, ok := m.branches[name]
+	if !ok {
+		return nil, errors.NotFoundf("branch %q", name)
+	}
+	return branch, nil
+}
+
+func (m *mockBackend) ApplicationsMatching(selector coreapplication.Selector) ([]application.Application, error) {
+	m.MethodCall(m, "ApplicationsMatching", selector)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	var appNames []string
+	for name, app := range m.applications {
+		if selector.Matches(app.(*mockApplication).labels) {
+			appNames = append(appNames, name)
+		}
+	}
+	sort.Strings(appNames)
+	apps := make([]application.Application, len(appNames))
+	for i, name := range appNames {
+		apps[i] = m.applications[name]
+	}
+	return apps, nil
+}
+
 func (m *mockBackend) ApplyOperation(op state.ModelOperation) error {
 	m.MethodCall(m, "ApplyOperation", op)
 	return m.NextErr()
@@ -489,6 +682,15 @@ func (m *mockBackend) Space(name string) (application.Space, error) {
 	return space, nil
 }
 
+func (m *mockBackend) AllSpaces() ([]application.Space, error) {
+	m.MethodCall(m, "AllSpaces")
+	var spaces []application.Space
+	for _, space := range m.spaces {
+		spaces = append(spaces, space)
+	}
+	return spaces, m.NextErr()
+}
+
 func (m *mockBackend) Model() (application.Model, error) {
 	return m.model, nil
 }
@@ -503,6 +705,22 @@ func (m *mockBackend) ModelTag() names.ModelTag {
 	return names.NewModelTag(m.modelUUID)
 }
 
+func (m *mockBackend) ModelType() state.ModelType {
+	if m.modelType == "" {
+		return state.ModelTypeIAAS
+	}
+	return m.modelType
+}
+
+func (m *mockBackend) ConfigWebhooks() ([]state.ConfigWebhook, error) {
+	return m.webhooks, nil
+}
+
+func (m *mockBackend) AgentVersion() (version.Number, error) {
+	m.MethodCall(m, "AgentVersion")
+	return m.agentVersion, m.NextErr()
+}
+
 type mockBlockChecker struct {
 	jtesting.Stub
 }
@@ -564,13 +782,25 @@ func (r *mockRelation) Destroy() error {
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag names.UnitTag
+	tag             names.UnitTag
+	containerStatus *status.StatusInfo
 }
 
 func (u *mockUnit) UnitTag() names.UnitTag {
 	return u.tag
 }
 
+func (u *mockUnit) ContainerStatus() (status.StatusInfo, error) {
+	u.MethodCall(u, "ContainerStatus")
+	if err := u.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	if u.containerStatus == nil {
+		return status.StatusInfo{}, errors.NotFoundf("container status for unit %q", u.tag.Id())
+	}
+	return *u.containerStatus, nil
+}
+
 func (u *mockUnit) IsPrincipal() bool {
 	u.MethodCall(u, "IsPrincipal")
 	u.PopNoErr()
@@ -635,6 +865,19 @@ func (f *mockFilesystem) Detachable() bool {
 	return f.detachable
 }
 
+type mockNotifyWatcher struct {
+	state.NotifyWatcher
+	changes chan struct{}
+}
+
+func (w *mockNotifyWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+func (w *mockNotifyWatcher) Stop() error {
+	return nil
+}
+
 type blobs struct {
 	sync.Mutex
 	m map[string]bool // maps path to added (true), or deleted (false)
@@ -689,3 +932,52 @@ func (s *recordingStorage) Remove(path string) error {
 	s.blobs.Remove(path)
 	return nil
 }
+
+type mockBranch struct {
+	jtesting.Stub
+	name          string
+	createdBy     string
+	created       time.Time
+	config        map[string]charm.Settings
+	assignedUnits map[string][]string
+}
+
+func (b *mockBranch) Name() string {
+	return b.name
+}
+
+func (b *mockBranch) CreatedBy() string {
+	return b.createdBy
+}
+
+func (b *mockBranch) Created() time.Time {
+	return b.created
+}
+
+func (b *mockBranch) Config() map[string]charm.Settings {
+	return b.config
+}
+
+func (b *mockBranch) AssignedUnits() map[string][]string {
+	return b.assignedUnits
+}
+
+func (b *mockBranch) UpdateCharmConfig(appName string, changes charm.Settings) error {
+	b.MethodCall(b, "UpdateCharmConfig", appName, changes)
+	return b.NextErr()
+}
+
+func (b *mockBranch) AssignUnit(unitName string) error {
+	b.MethodCall(b, "AssignUnit", unitName)
+	return b.NextErr()
+}
+
+func (b *mockBranch) Commit(user string) error {
+	b.MethodCall(b, "Commit", user)
+	return b.NextErr()
+}
+
+func (b *mockBranch) Abort() error {
+	b.MethodCall(b, "Abort")
+	return b.NextErr()
+}
@@ -0,0 +1,292 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// checkCAAS returns an error satisfying errors.IsNotSupported unless
+// the model is a Kubernetes (CAAS) model.
+func (api *API) checkCAAS(what string) error {
+	if api.backend.ModelType() != state.ModelTypeCAAS {
+		return errors.NotSupportedf("%s on non-Kubernetes models", what)
+	}
+	return nil
+}
+
+func (api *API) applicationFromTag(entity string) (Application, error) {
+	tag, err := names.ParseApplicationTag(entity)
+	if err != nil {
+		return nil, err
+	}
+	return api.backend.Application(tag.Id())
+}
+
+// ScaleApplications sets or changes the number of pods each of the
+// given applications runs in a Kubernetes model.
+func (api *API) ScaleApplications(args params.ScaleApplicationsParams) (params.ScaleApplicationResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ScaleApplicationResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ScaleApplicationResults{}, errors.Trace(err)
+	}
+	if err := api.checkCAAS("scaling applications"); err != nil {
+		return params.ScaleApplicationResults{}, errors.Trace(err)
+	}
+	results := params.ScaleApplicationResults{
+		Results: make([]params.ScaleApplicationResult, len(args.Applications)),
+	}
+	for i, arg := range args.Applications {
+		scale, err := api.scaleApplication(arg)
+		results.Results[i].Scale = scale
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) scaleApplication(arg params.ScaleApplicationParams) (int, error) {
+	if arg.Scale != 0 && arg.ScaleChange != 0 {
+		return 0, errors.NotValidf("requesting both scale and scale change")
+	}
+	app, err := api.applicationFromTag(arg.ApplicationTag)
+	if err != nil {
+		return 0, err
+	}
+	if arg.ScaleChange != 0 {
+		return app.ChangeScale(arg.ScaleChange)
+	}
+	if err := app.SetScale(arg.Scale); err != nil {
+		return 0, err
+	}
+	return arg.Scale, nil
+}
+
+// GetPodSpec returns the current Kubernetes pod spec of each of the
+// given applications.
+func (api *API) GetPodSpec(args params.Entities) (params.PodSpecResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.PodSpecResults{}, errors.Trace(err)
+	}
+	if err := api.checkCAAS("pod specs"); err != nil {
+		return params.PodSpecResults{}, errors.Trace(err)
+	}
+	results := params.PodSpecResults{
+		Results: make([]params.PodSpecResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		app, err := api.applicationFromTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		spec, err := app.PodSpec()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = podSpecParams(spec)
+	}
+	return results, nil
+}
+
+// SetPodSpec sets the Kubernetes pod spec of each of the given
+// applications, recording the authenticated user as having made the
+// change.
+func (api *API) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.checkCAAS("pod specs"); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Specs)),
+	}
+	for i, arg := range args.Specs {
+		err := api.setPodSpec(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setPodSpec(arg params.EntityPodSpec) error {
+	var spec map[string]interface{}
+	if err := goyaml.Unmarshal([]byte(arg.Spec), &spec); err != nil {
+		return errors.NewNotValid(err, "invalid pod spec")
+	}
+	app, err := api.applicationFromTag(arg.Tag)
+	if err != nil {
+		return err
+	}
+	return app.SetPodSpec(arg.Spec, api.userName())
+}
+
+// PodSpecHistory returns the recent revisions of the Kubernetes pod
+// spec of each of the given applications, newest first.
+func (api *API) PodSpecHistory(args params.PodSpecHistoryArgs) (params.PodSpecHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.PodSpecHistoryResults{}, errors.Trace(err)
+	}
+	if err := api.checkCAAS("pod specs"); err != nil {
+		return params.PodSpecHistoryResults{}, errors.Trace(err)
+	}
+	results := params.PodSpecHistoryResults{
+		Results: make([]params.PodSpecHistoryResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		app, err := api.applicationFromTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		history, err := app.PodSpecHistory(arg.Limit)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		revisions := make([]params.PodSpec, len(history))
+		for j, spec := range history {
+			revisions[j] = *podSpecParams(spec)
+		}
+		results.Results[i].Revisions = revisions
+	}
+	return results, nil
+}
+
+func podSpecParams(spec state.PodSpec) *params.PodSpec {
+	return &params.PodSpec{
+		Spec:     spec.Spec,
+		Revision: spec.Revision,
+		User:     spec.User,
+		Time:     spec.Time,
+	}
+}
+
+// RolloutStatus returns the status of the rollout of the current pod
+// spec revision of each of the given applications.
+func (api *API) RolloutStatus(args params.Entities) (params.RolloutStatusResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RolloutStatusResults{}, errors.Trace(err)
+	}
+	if err := api.checkCAAS("rollout status"); err != nil {
+		return params.RolloutStatusResults{}, errors.Trace(err)
+	}
+	results := params.RolloutStatusResults{
+		Results: make([]params.RolloutStatusResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		app, err := api.applicationFromTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		rollout, err := app.RolloutStatus()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &params.RolloutStatus{
+			Revision: rollout.Revision,
+			State:    string(rollout.State),
+			Message:  rollout.Message,
+			Since:    rollout.Since,
+		}
+	}
+	return results, nil
+}
+
+// WatchRolloutStatus returns a NotifyWatcher for each of the given
+// applications, which notifies of changes to the application's pod
+// spec, scale or rollout status.
+func (api *API) WatchRolloutStatus(args params.Entities) (params.NotifyWatchResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.NotifyWatchResults{}, errors.Trace(err)
+	}
+	if err := api.checkCAAS("rollout status"); err != nil {
+		return params.NotifyWatchResults{}, errors.Trace(err)
+	}
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := api.watchRolloutStatus(arg.Tag)
+		results.Results[i].NotifyWatcherId = id
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) watchRolloutStatus(entity string) (string, error) {
+	app, err := api.applicationFromTag(entity)
+	if err != nil {
+		return "", err
+	}
+	w := app.WatchPodSpec()
+	if _, ok := <-w.Changes(); ok {
+		return api.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// UnitContainerStatuses returns the status of the container running
+// each unit of the given applications, as reported by Kubernetes.
+func (api *API) UnitContainerStatuses(args params.Entities) (params.UnitContainerStatusesResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitContainerStatusesResults{}, errors.Trace(err)
+	}
+	if err := api.checkCAAS("container statuses"); err != nil {
+		return params.UnitContainerStatusesResults{}, errors.Trace(err)
+	}
+	results := params.UnitContainerStatusesResults{
+		Results: make([]params.UnitContainerStatusesResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		units, err := api.unitContainerStatuses(arg.Tag)
+		results.Results[i].Units = units
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) unitContainerStatuses(entity string) ([]params.UnitContainerStatus, error) {
+	app, err := api.applicationFromTag(entity)
+	if err != nil {
+		return nil, err
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, err
+	}
+	var statuses []params.UnitContainerStatus
+	for _, unit := range units {
+		info, err := unit.ContainerStatus()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, params.UnitContainerStatus{
+			UnitTag: unit.UnitTag().String(),
+			Status: params.EntityStatus{
+				Status: info.Status,
+				Info:   info.Message,
+				Data:   info.Data,
+				Since:  info.Since,
+			},
+		})
+	}
+	return statuses, nil
+}
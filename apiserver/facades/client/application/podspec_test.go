@@ -0,0 +1,235 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+func (s *ApplicationSuite) setCAASModel() {
+	s.backend.modelType = state.ModelTypeCAAS
+}
+
+func (s *ApplicationSuite) TestPodSpecCallsNeedCAASModel(c *gc.C) {
+	entities := params.Entities{Entities: []params.Entity{{Tag: "application-postgresql"}}}
+	_, err := s.api.ScaleApplications(params.ScaleApplicationsParams{})
+	c.Assert(err, gc.ErrorMatches, "scaling applications on non-Kubernetes models not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = s.api.GetPodSpec(entities)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = s.api.SetPodSpec(params.SetPodSpecParams{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = s.api.PodSpecHistory(params.PodSpecHistoryArgs{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = s.api.RolloutStatus(entities)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = s.api.WatchRolloutStatus(entities)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = s.api.UnitContainerStatuses(entities)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ApplicationSuite) TestScaleApplications(c *gc.C) {
+	s.setCAASModel()
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.scale = 2
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{
+			{ApplicationTag: "application-postgresql", Scale: 5},
+			{ApplicationTag: "application-postgresql", ScaleChange: -2},
+			{ApplicationTag: "application-postgresql", Scale: 1, ScaleChange: 1},
+			{ApplicationTag: "application-wat", Scale: 1},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ScaleApplicationResult{
+		{Scale: 5},
+		{Scale: 3},
+		{Error: &params.Error{Code: params.CodeNotValid, Message: "requesting both scale and scale change not valid"}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+	})
+	app.CheckCallNames(c, "SetScale", "ChangeScale")
+	app.CheckCall(c, 0, "SetScale", 5)
+	app.CheckCall(c, 1, "ChangeScale", -2)
+}
+
+func (s *ApplicationSuite) TestBlockChangesScaleApplications(c *gc.C) {
+	s.setCAASModel()
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{
+			{ApplicationTag: "application-postgresql", Scale: 5},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+}
+
+func (s *ApplicationSuite) TestGetPodSpec(c *gc.C) {
+	s.setCAASModel()
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	t0 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	app.podSpec = state.PodSpec{Spec: "containers: []", Revision: 2, User: "admin", Time: t0}
+	app.SetErrors(nil, errors.NotFoundf("pod spec for application %q", "postgresql"))
+	results, err := s.api.GetPodSpec(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+		{Tag: "application-postgresql"},
+		{Tag: "unit-postgresql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.PodSpecResult{
+		{Result: &params.PodSpec{Spec: "containers: []", Revision: 2, User: "admin", Time: t0}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `pod spec for application "postgresql" not found`}},
+		{Error: &params.Error{Message: `"unit-postgresql-0" is not a valid application tag`}},
+	})
+}
+
+func (s *ApplicationSuite) TestSetPodSpec(c *gc.C) {
+	s.setCAASModel()
+	results, err := s.api.SetPodSpec(params.SetPodSpecParams{
+		Specs: []params.EntityPodSpec{
+			{Tag: "application-postgresql", Spec: "containers: []"},
+			{Tag: "application-postgresql", Spec: "[unterminated"},
+			{Tag: "application-wat", Spec: "containers: []"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "invalid pod spec: .*")
+	c.Assert(results.Results[1].Error.Code, gc.Equals, params.CodeNotValid)
+	c.Assert(results.Results[2].Error, jc.DeepEquals, &params.Error{
+		Code:    params.CodeNotFound,
+		Message: `application "wat" not found`,
+	})
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCallNames(c, "SetPodSpec")
+	app.CheckCall(c, 0, "SetPodSpec", "containers: []", "admin")
+}
+
+func (s *ApplicationSuite) TestSetPodSpecPermission(c *gc.C) {
+	s.setCAASModel()
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.SetPodSpec(params.SetPodSpecParams{
+		Specs: []params.EntityPodSpec{
+			{Tag: "application-postgresql", Spec: "containers: []"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ApplicationSuite) TestPodSpecHistory(c *gc.C) {
+	s.setCAASModel()
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	t0 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	app.podSpecHistory = []state.PodSpec{
+		{Spec: "containers: [{name: foo}]", Revision: 2, User: "admin", Time: t0.Add(time.Minute)},
+		{Spec: "containers: []", Revision: 1, Time: t0},
+	}
+	results, err := s.api.PodSpecHistory(params.PodSpecHistoryArgs{
+		Args: []params.PodSpecHistoryArg{
+			{Entity: params.Entity{Tag: "application-postgresql"}, Limit: 5},
+			{Entity: params.Entity{Tag: "application-wat"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.PodSpecHistoryResult{
+		{Revisions: []params.PodSpec{
+			{Spec: "containers: [{name: foo}]", Revision: 2, User: "admin", Time: t0.Add(time.Minute)},
+			{Spec: "containers: []", Revision: 1, Time: t0},
+		}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+	})
+	app.CheckCallNames(c, "PodSpecHistory")
+	app.CheckCall(c, 0, "PodSpecHistory", 5)
+}
+
+func (s *ApplicationSuite) TestRolloutStatus(c *gc.C) {
+	s.setCAASModel()
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	t0 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	app.rolloutStatus = state.RolloutStatus{
+		Revision: 3,
+		State:    state.RolloutProgressing,
+		Message:  "1 of 2 pods updated",
+		Since:    t0,
+	}
+	results, err := s.api.RolloutStatus(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+		{Tag: "application-wat"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RolloutStatusResult{
+		{Result: &params.RolloutStatus{
+			Revision: 3,
+			State:    "progressing",
+			Message:  "1 of 2 pods updated",
+			Since:    t0,
+		}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+	})
+}
+
+func (s *ApplicationSuite) TestWatchRolloutStatus(c *gc.C) {
+	s.setCAASModel()
+	resources := common.NewResources()
+	s.AddCleanup(func(*gc.C) { resources.StopAll() })
+	application.SetResources(s.api, resources)
+
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.watcher = &mockNotifyWatcher{changes: make(chan struct{}, 1)}
+	app.watcher.changes <- struct{}{}
+
+	results, err := s.api.WatchRolloutStatus(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+		{Tag: "application-wat"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.NotifyWatchResult{
+		{NotifyWatcherId: "1"},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+	})
+	c.Assert(resources.Get("1"), gc.Equals, app.watcher)
+	app.CheckCallNames(c, "WatchPodSpec")
+}
+
+func (s *ApplicationSuite) TestUnitContainerStatuses(c *gc.C) {
+	s.setCAASModel()
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	t0 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	app.units[1].containerStatus = &status.StatusInfo{
+		Status:  status.Running,
+		Message: "started",
+		Data:    map[string]interface{}{"restarts": 1},
+		Since:   &t0,
+	}
+	results, err := s.api.UnitContainerStatuses(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+		{Tag: "application-wat"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.UnitContainerStatusesResult{
+		{Units: []params.UnitContainerStatus{{
+			UnitTag: "unit-postgresql-1",
+			Status: params.EntityStatus{
+				Status: status.Running,
+				Info:   "started",
+				Data:   map[string]interface{}{"restarts": 1},
+				Since:  &t0,
+			},
+		}}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "wat" not found`}},
+	})
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationleadership
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// applicationleadership facade. For details on the methods, see the
+// methods on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Application(name string) (Application, error)
+	ApplicationLeaders() (map[string]string, error)
+}
+
+// Application defines the application functionality required by the
+// applicationleadership facade.
+type Application interface {
+	WatchLeader() state.NotifyWatcher
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
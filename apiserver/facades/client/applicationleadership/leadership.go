@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package applicationleadership provides the ApplicationLeadership
+// facade, which lets clients with read access to a model find and
+// follow the leaders of its applications.
+package applicationleadership
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state/watcher"
+)
+
+// API provides the ApplicationLeadership API facade for version 1.
+type API struct {
+	backend    Backend
+	resources  facade.Resources
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(NewStateBackend(ctx.State()), ctx.Resources(), ctx.Auth())
+}
+
+// NewAPI returns a new ApplicationLeadership API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		resources:  resources,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Leaders returns the name of the unit leading each of the given
+// applications. A NotFound error is returned for an application
+// without a leader.
+func (api *API) Leaders(args params.Entities) (params.StringResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	results := params.StringResults{
+		Results: make([]params.StringResult, len(args.Entities)),
+	}
+	leaders, err := api.backend.ApplicationLeaders()
+	if err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		leader, err := api.leader(arg.Tag, leaders)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = leader
+	}
+	return results, nil
+}
+
+func (api *API) leader(tag string, leaders map[string]string) (string, error) {
+	appTag, err := names.ParseApplicationTag(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if _, err := api.backend.Application(appTag.Id()); err != nil {
+		return "", errors.Trace(err)
+	}
+	leader, ok := leaders[appTag.Id()]
+	if !ok {
+		return "", errors.NotFoundf("leader of application %q", appTag.Id())
+	}
+	return leader, nil
+}
+
+// WatchLeaders returns a NotifyWatcher for each of the given
+// applications, which notifies when the application's leader changes.
+func (api *API) WatchLeaders(args params.Entities) (params.NotifyWatchResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.NotifyWatchResults{}, errors.Trace(err)
+	}
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := api.watchLeader(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (api *API) watchLeader(tag string) (string, error) {
+	appTag, err := names.ParseApplicationTag(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := api.backend.Application(appTag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchLeader()
+	// Consume the initial event.
+	if _, ok := <-w.Changes(); ok {
+		return api.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
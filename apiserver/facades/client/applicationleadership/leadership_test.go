@@ -0,0 +1,132 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package applicationleadership_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/applicationleadership"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type leadershipSuite struct {
+	coretesting.BaseSuite
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&leadershipSuite{})
+
+func (s *leadershipSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		applications: map[string]*mockApplication{
+			"mysql":     {},
+			"wordpress": {},
+		},
+		leaders: map[string]string{
+			"mysql": "mysql/1",
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+}
+
+func (s *leadershipSuite) newAPI(c *gc.C) *applicationleadership.API {
+	api, err := applicationleadership.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *leadershipSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := applicationleadership.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *leadershipSuite) TestLeaders(c *gc.C) {
+	results, err := s.newAPI(c).Leaders(params.Entities{Entities: []params.Entity{
+		{Tag: "application-mysql"},
+		{Tag: "application-wordpress"},
+		{Tag: "application-missing"},
+		{Tag: "unit-mysql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0], jc.DeepEquals, params.StringResult{Result: "mysql/1"})
+	c.Assert(results.Results[1].Error, jc.DeepEquals, apiservertesting.NotFoundError(`leader of application "wordpress"`))
+	c.Assert(results.Results[2].Error, jc.DeepEquals, apiservertesting.NotFoundError(`application "missing"`))
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid application tag`)
+}
+
+func (s *leadershipSuite) TestLeadersRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).Leaders(params.Entities{Entities: []params.Entity{
+		{Tag: "application-mysql"},
+	}})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *leadershipSuite) TestWatchLeaders(c *gc.C) {
+	results, err := s.newAPI(c).WatchLeaders(params.Entities{Entities: []params.Entity{
+		{Tag: "application-mysql"},
+		{Tag: "application-missing"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Get("1"), gc.Equals, s.backend.applications["mysql"].watcher)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, apiservertesting.NotFoundError(`application "missing"`))
+}
+
+func (s *leadershipSuite) TestWatchLeadersRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).WatchLeaders(params.Entities{Entities: []params.Entity{
+		{Tag: "application-mysql"},
+	}})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+type mockBackend struct {
+	applications map[string]*mockApplication
+	leaders      map[string]string
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (m *mockBackend) Application(name string) (applicationleadership.Application, error) {
+	app, ok := m.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+func (m *mockBackend) ApplicationLeaders() (map[string]string, error) {
+	return m.leaders, nil
+}
+
+type mockApplication struct {
+	watcher *apiservertesting.FakeNotifyWatcher
+}
+
+func (a *mockApplication) WatchLeader() state.NotifyWatcher {
+	a.watcher = apiservertesting.NewFakeNotifyWatcher()
+	return a.watcher
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -34,6 +35,18 @@ type OffersAPI struct {
 	authContext *commoncrossmodel.AuthContext
 }
 
+// OffersAPIV2 implements version 2 of the ApplicationOffers facade,
+// which has no offer migration calls.
+type OffersAPIV2 struct {
+	*OffersAPI
+}
+
+// OffersAPIV1 implements version 1 of the ApplicationOffers facade,
+// which has no offer ingress network or consumer calls.
+type OffersAPIV1 struct {
+	*OffersAPIV2
+}
+
 // createAPI returns a new application offers OffersAPI facade.
 func createOffersAPI(
 	getApplicationOffers func(interface{}) jujucrossmodel.ApplicationOffers,
@@ -95,6 +108,45 @@ func NewOffersAPI(ctx facade.Context) (*OffersAPI, error) {
 	)
 }
 
+// NewOffersAPIV2 returns a new version 2 application offers facade.
+func NewOffersAPIV2(ctx facade.Context) (*OffersAPIV2, error) {
+	api, err := NewOffersAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OffersAPIV2{api}, nil
+}
+
+// NewOffersAPIV1 returns a new version 1 application offers facade.
+func NewOffersAPIV1(ctx facade.Context) (*OffersAPIV1, error) {
+	api, err := NewOffersAPIV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &OffersAPIV1{api}, nil
+}
+
+// StartOfferMigration isn't on the V2 API.
+func (api *OffersAPIV2) StartOfferMigration(_, _ struct{}) {}
+
+// CompleteOfferMigration isn't on the V2 API.
+func (api *OffersAPIV2) CompleteOfferMigration(_, _ struct{}) {}
+
+// AbortOfferMigration isn't on the V2 API.
+func (api *OffersAPIV2) AbortOfferMigration(_, _ struct{}) {}
+
+// SetOfferIngressNetworks isn't on the V1 API.
+func (api *OffersAPIV1) SetOfferIngressNetworks(_, _ struct{}) {}
+
+// OfferIngressNetworks isn't on the V1 API.
+func (api *OffersAPIV1) OfferIngressNetworks(_, _ struct{}) {}
+
+// OfferConsumers isn't on the V1 API.
+func (api *OffersAPIV1) OfferConsumers(_, _ struct{}) {}
+
+// RevokeConsumers isn't on the V1 API.
+func (api *OffersAPIV1) RevokeConsumers(_, _ struct{}) {}
+
 // Offer makes application endpoints available for consumption at a specified URL.
 func (api *OffersAPI) Offer(all params.AddApplicationOffers) (params.ErrorResults, error) {
 	result := make([]params.ErrorResult, len(all.Offers))
@@ -545,3 +597,338 @@ func (api *OffersAPI) DestroyOffers(args params.DestroyApplicationOffers) (param
 	}
 	return params.ErrorResults{Results: result}, nil
 }
+
+// SetOfferIngressNetworks sets the networks from which consumers of the
+// specified offers may connect. An empty list of networks allows any
+// network permitted by the model's firewall rules. Relations that have
+// already been established are not affected.
+func (api *OffersAPI) SetOfferIngressNetworks(args params.SetOfferIngressNetworksArgs) (params.ErrorResults, error) {
+	result := make([]params.ErrorResult, len(args.Args))
+
+	offerURLs := make([]string, len(args.Args))
+	for i, arg := range args.Args {
+		offerURLs[i] = arg.OfferURL
+	}
+	models, err := api.getModelsFromOffers(offerURLs...)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	for i, arg := range args.Args {
+		if models[i].err != nil {
+			result[i].Error = common.ServerError(models[i].err)
+			continue
+		}
+		url, err := jujucrossmodel.ParseOfferURL(arg.OfferURL)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		backend, releaser, err := api.StatePool.Get(models[i].model.UUID())
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		defer releaser()
+
+		if err := api.checkAdmin(backend); err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		err = api.GetApplicationOffers(backend).SetIngressNetworks(url.ApplicationName, arg.CIDRs)
+		result[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+// OfferIngressNetworks returns the networks from which consumers of the
+// specified offers may connect.
+func (api *OffersAPI) OfferIngressNetworks(args params.OfferURLs) (params.OfferIngressNetworksResults, error) {
+	result := make([]params.OfferIngressNetworksResult, len(args.OfferURLs))
+
+	models, err := api.getModelsFromOffers(args.OfferURLs...)
+	if err != nil {
+		return params.OfferIngressNetworksResults{}, errors.Trace(err)
+	}
+
+	for i, one := range args.OfferURLs {
+		if models[i].err != nil {
+			result[i].Error = common.ServerError(models[i].err)
+			continue
+		}
+		url, err := jujucrossmodel.ParseOfferURL(one)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		backend, releaser, err := api.StatePool.Get(models[i].model.UUID())
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		defer releaser()
+
+		if err := api.checkAdmin(backend); err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		offer, err := api.GetApplicationOffers(backend).ApplicationOffer(url.ApplicationName)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		result[i].CIDRs = offer.IngressCIDRs
+	}
+	return params.OfferIngressNetworksResults{Results: result}, nil
+}
+
+// OfferConsumers returns the connections made to the specified offers
+// by their consumers, including the status of each relation and the
+// networks from which the consumer has connected.
+func (api *OffersAPI) OfferConsumers(args params.OfferURLs) (params.OfferConsumersResults, error) {
+	result := make([]params.OfferConsumersResult, len(args.OfferURLs))
+
+	models, err := api.getModelsFromOffers(args.OfferURLs...)
+	if err != nil {
+		return params.OfferConsumersResults{}, errors.Trace(err)
+	}
+
+	for i, one := range args.OfferURLs {
+		if models[i].err != nil {
+			result[i].Error = common.ServerError(models[i].err)
+			continue
+		}
+		consumers, err := api.oneOfferConsumers(models[i].model.UUID(), one)
+		result[i].Consumers = consumers
+		result[i].Error = common.ServerError(err)
+	}
+	return params.OfferConsumersResults{Results: result}, nil
+}
+
+func (api *OffersAPI) oneOfferConsumers(modelUUID, offerURL string) ([]params.OfferConnection, error) {
+	backend, releaser, err := api.StatePool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer releaser()
+
+	offer, conns, err := api.adminOfferConnections(backend, offerURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var consumers []params.OfferConnection
+	for _, oc := range conns {
+		consumer, err := offerConnectionDetails(backend, offer.ApplicationName, oc)
+		if errors.IsNotFound(err) {
+			// The relation has been removed since the
+			// connection was listed.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		consumers = append(consumers, consumer)
+	}
+	return consumers, nil
+}
+
+// RevokeConsumers removes the relations of the selected consumers of
+// the specified offers, returning the ids of the relations removed.
+// Users whose consumers are revoked are left with read access to the
+// offer, unless they administer it. This allows an offer's owner to remove consumers that are no longer
+// wanted, or that have gone away, without identifying each relation.
+func (api *OffersAPI) RevokeConsumers(args params.RevokeOfferConsumersArgs) (params.RevokeOfferConsumersResults, error) {
+	result := make([]params.RevokeOfferConsumersResult, len(args.Args))
+
+	offerURLs := make([]string, len(args.Args))
+	for i, arg := range args.Args {
+		offerURLs[i] = arg.OfferURL
+	}
+	models, err := api.getModelsFromOffers(offerURLs...)
+	if err != nil {
+		return params.RevokeOfferConsumersResults{}, errors.Trace(err)
+	}
+
+	for i, arg := range args.Args {
+		if models[i].err != nil {
+			result[i].Error = common.ServerError(models[i].err)
+			continue
+		}
+		relationIds, err := api.revokeOneOfferConsumers(models[i].model.UUID(), arg)
+		result[i].RelationIds = relationIds
+		result[i].Error = common.ServerError(err)
+	}
+	return params.RevokeOfferConsumersResults{Results: result}, nil
+}
+
+func (api *OffersAPI) revokeOneOfferConsumers(modelUUID string, arg params.RevokeOfferConsumers) ([]int, error) {
+	modelUUIDs := set.NewStrings()
+	for _, tagStr := range arg.SourceModelTags {
+		modelTag, err := names.ParseModelTag(tagStr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		modelUUIDs.Add(modelTag.Id())
+	}
+	usernames := set.NewStrings(arg.Usernames...)
+
+	backend, releaser, err := api.StatePool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer releaser()
+
+	offer, conns, err := api.adminOfferConnections(backend, arg.OfferURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var relationIds []int
+	revokedUsers := set.NewStrings()
+	for _, oc := range conns {
+		if !modelUUIDs.IsEmpty() && !modelUUIDs.Contains(oc.SourceModelUUID()) {
+			continue
+		}
+		if !usernames.IsEmpty() && !usernames.Contains(oc.UserName()) {
+			continue
+		}
+		if arg.StaleOnly && oc.Health() != jujucrossmodel.OfferConnectionStale {
+			continue
+		}
+		revokedUsers.Add(oc.UserName())
+		rel, err := backend.KeyRelation(oc.RelationKey())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return relationIds, errors.Trace(err)
+		}
+		if err := rel.Destroy(); err != nil && !errors.IsNotFound(err) {
+			return relationIds, errors.Annotatef(err, "removing relation %d", oc.RelationId())
+		}
+		relationIds = append(relationIds, oc.RelationId())
+	}
+
+	// Without their consume access, the revoked consumers
+	// cannot simply relate to the offer again.
+	for _, username := range revokedUsers.SortedValues() {
+		if err := api.revokeConsumeAccess(backend, offer, names.NewUserTag(username)); err != nil {
+			return relationIds, errors.Annotatef(err, "revoking consume access for %q", username)
+		}
+	}
+	return relationIds, nil
+}
+
+// revokeConsumeAccess leaves a user who may consume the offer with
+// read-only access to it. Offer admins are left unchanged.
+func (api *OffersAPI) revokeConsumeAccess(backend Backend, offer *jujucrossmodel.ApplicationOffer, user names.UserTag) error {
+	access, err := backend.GetOfferAccess(offer.OfferUUID, user)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if access != permission.ConsumeAccess {
+		return nil
+	}
+	offerTag := names.NewApplicationOfferTag(offer.OfferName)
+	return errors.Trace(api.revokeOfferAccess(backend, offerTag, user, permission.ConsumeAccess))
+}
+
+// adminOfferConnections returns the specified offer and its
+// connections, so long as the authenticated user is an admin of the
+// model hosting the offer.
+func (api *OffersAPI) adminOfferConnections(backend Backend, offerURL string) (*jujucrossmodel.ApplicationOffer, []OfferConnection, error) {
+	url, err := jujucrossmodel.ParseOfferURL(offerURL)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if err := api.checkAdmin(backend); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	offer, err := api.GetApplicationOffers(backend).ApplicationOffer(url.ApplicationName)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	conns, err := backend.OfferConnections(offer.OfferUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return offer, conns, nil
+}
+
+// StartOfferMigration starts the migration of each of the specified
+// offers to a new application. The offers continue to be provided by
+// their current applications until CompleteOfferMigration is called,
+// and in the meantime their consumers are told of the migration and
+// its deadline through the offers' status.
+func (api *OffersAPI) StartOfferMigration(args params.StartOfferMigrationArgs) (params.ErrorResults, error) {
+	offerURLs := make([]string, len(args.Args))
+	for i, arg := range args.Args {
+		offerURLs[i] = arg.OfferURL
+	}
+	return api.migrateOffers(offerURLs, func(offers jujucrossmodel.ApplicationOffers, offerName string, i int) error {
+		arg := args.Args[i]
+		return offers.StartMigration(offerName, jujucrossmodel.OfferMigration{
+			ApplicationName: arg.ApplicationName,
+			Endpoints:       arg.Endpoints,
+			Deadline:        arg.Deadline,
+		})
+	})
+}
+
+// CompleteOfferMigration switches each of the specified offers over to
+// the application of its pending migration. Relations made to an offer
+// before its migration is completed remain with the old application.
+func (api *OffersAPI) CompleteOfferMigration(args params.OfferURLs) (params.ErrorResults, error) {
+	return api.migrateOffers(args.OfferURLs, func(offers jujucrossmodel.ApplicationOffers, offerName string, _ int) error {
+		_, err := offers.CompleteMigration(offerName)
+		return err
+	})
+}
+
+// AbortOfferMigration discards the pending migration of each of the
+// specified offers.
+func (api *OffersAPI) AbortOfferMigration(args params.OfferURLs) (params.ErrorResults, error) {
+	return api.migrateOffers(args.OfferURLs, func(offers jujucrossmodel.ApplicationOffers, offerName string, _ int) error {
+		return offers.AbortMigration(offerName)
+	})
+}
+
+// migrateOffers calls migrate with the name and index of each of the
+// specified offers, so long as the authenticated user is an admin of
+// the model hosting the offer.
+func (api *OffersAPI) migrateOffers(
+	offerURLs []string,
+	migrate func(offers jujucrossmodel.ApplicationOffers, offerName string, i int) error,
+) (params.ErrorResults, error) {
+	result := make([]params.ErrorResult, len(offerURLs))
+
+	models, err := api.getModelsFromOffers(offerURLs...)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	for i, one := range offerURLs {
+		if models[i].err != nil {
+			result[i].Error = common.ServerError(models[i].err)
+			continue
+		}
+		url, err := jujucrossmodel.ParseOfferURL(one)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		backend, releaser, err := api.StatePool.Get(models[i].model.UUID())
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		defer releaser()
+
+		if err := api.checkAdmin(backend); err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		err = migrate(api.GetApplicationOffers(backend), url.ApplicationName, i)
+		result[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: result}, nil
+}
@@ -6,6 +6,7 @@ package applicationoffers_test
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -254,6 +255,8 @@ func (s *applicationOffersSuite) assertList(c *gc.C, expectedErr error) {
 					Username:       "fred",
 					Status:         params.EntityStatus{Status: "joined"},
 					IngressSubnets: []string{"192.168.1.0/32", "10.0.0.0/8"},
+					Health:         "healthy",
+					LastSeen:       &connectionLastSeen,
 				}},
 			},
 		},
@@ -381,6 +384,8 @@ func (s *applicationOffersSuite) TestShow(c *gc.C) {
 				RelationId:     1, Username: "fred", Endpoint: "db",
 				Status:         params.EntityStatus{Status: "joined"},
 				IngressSubnets: []string{"192.168.1.0/32", "10.0.0.0/8"},
+				Health:         "healthy",
+				LastSeen:       &connectionLastSeen,
 			}},
 		},
 	}}
@@ -697,6 +702,8 @@ func (s *applicationOffersSuite) TestFid(c *gc.C) {
 				RelationId:     1, Username: "fred", Endpoint: "db",
 				Status:         params.EntityStatus{Status: "joined"},
 				IngressSubnets: []string{"192.168.1.0/32", "10.0.0.0/8"},
+				Health:         "healthy",
+				LastSeen:       &connectionLastSeen,
 			}},
 		},
 	}
@@ -883,6 +890,8 @@ func (s *applicationOffersSuite) TestFindMulti(c *gc.C) {
 			modelUUID:   testing.ModelTag.Id(),
 			relationKey: "hosted-db2:db wordpress:db",
 			relationId:  1,
+			lastSeen:    connectionLastSeen,
+			health:      jujucrossmodel.OfferConnectionHealthy,
 		},
 	}
 	anotherState.users[user.Name()] = &mockUser{user.Name()}
@@ -1303,3 +1312,282 @@ func (s *consumeSuite) TestDestroyOffersPermission(c *gc.C) {
 	c.Assert(results.Results, gc.HasLen, 1)
 	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
 }
+
+func (s *consumeSuite) TestSetOfferIngressNetworks(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("admin")
+	results, err := s.api.SetOfferIngressNetworks(params.SetOfferIngressNetworksArgs{
+		Args: []params.OfferIngressNetworks{{
+			OfferURL: "fred/prod.hosted-mysql",
+			CIDRs:    []string{"10.0.0.0/8"},
+		}, {
+			OfferURL: "fred/prod.unknown",
+			CIDRs:    []string{"10.0.0.0/8"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{
+			Error: &params.Error{Message: `application offer "unknown" not found`, Code: "not found"},
+		},
+	})
+
+	found, err := s.api.OfferIngressNetworks(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql", "fred/prod.unknown"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, jc.DeepEquals, []params.OfferIngressNetworksResult{
+		{CIDRs: []string{"10.0.0.0/8"}},
+		{
+			Error: &params.Error{Message: `application offer "unknown" not found`, Code: "not found"},
+		},
+	})
+}
+
+func (s *consumeSuite) TestSetOfferIngressNetworksPermission(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("mary")
+	results, err := s.api.SetOfferIngressNetworks(params.SetOfferIngressNetworksArgs{
+		Args: []params.OfferIngressNetworks{{
+			OfferURL: "fred/prod.hosted-mysql",
+			CIDRs:    []string{"10.0.0.0/8"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
+
+	found, err := s.api.OfferIngressNetworks(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
+}
+
+func (s *consumeSuite) setupOfferConsumers() map[string]*mockRelation {
+	s.setupOffer()
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	relations := make(map[string]*mockRelation)
+	for i, consumer := range []struct {
+		key       string
+		modelUUID string
+		username  string
+		health    jujucrossmodel.OfferConnectionHealth
+	}{
+		{"mysql:server wordpress:db", "model-a-uuid", "mary", jujucrossmodel.OfferConnectionHealthy},
+		{"mysql:server mediawiki:db", "model-b-uuid", "bob", jujucrossmodel.OfferConnectionStale},
+		{"mysql:server ghost:db", "model-a-uuid", "bob", jujucrossmodel.OfferConnectionStale},
+	} {
+		rel := &mockRelation{
+			id: i + 1,
+			endpoint: state.Endpoint{
+				ApplicationName: "mysql",
+				Relation:        charm.Relation{Name: "server", Interface: "mysql", Role: "provider"},
+			},
+		}
+		relations[consumer.key] = rel
+		st.relations[consumer.key] = rel
+		user := names.NewUserTag(consumer.username)
+		st.users[user.Name()] = &mockUser{user.Name()}
+		st.accessPerms[offerAccess{user: user, offerUUID: "hosted-mysql-uuid"}] = permission.ConsumeAccess
+		st.connections = append(st.connections, &mockOfferConnection{
+			modelUUID:   consumer.modelUUID,
+			username:    consumer.username,
+			relationKey: consumer.key,
+			relationId:  i + 1,
+			health:      consumer.health,
+		})
+	}
+	return relations
+}
+
+func (s *consumeSuite) TestOfferConsumers(c *gc.C) {
+	s.setupOfferConsumers()
+	s.authorizer.Tag = names.NewUserTag("admin")
+	found, err := s.api.OfferConsumers(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql", "fred/prod.unknown"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 2)
+	c.Assert(found.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `application offer "unknown" not found`, Code: "not found",
+	})
+	consumers := found.Results[0].Consumers
+	c.Assert(found.Results[0].Error, gc.IsNil)
+	c.Assert(consumers, gc.HasLen, 3)
+	c.Assert(consumers[1], jc.DeepEquals, params.OfferConnection{
+		SourceModelTag: "model-model-b-uuid",
+		RelationId:     2,
+		Username:       "bob",
+		Endpoint:       "server",
+		Status:         params.EntityStatus{Status: "joined"},
+		IngressSubnets: []string{"192.168.1.0/32", "10.0.0.0/8"},
+		Health:         "stale",
+	})
+}
+
+func (s *consumeSuite) TestOfferConsumersPermission(c *gc.C) {
+	s.setupOfferConsumers()
+	s.authorizer.Tag = names.NewUserTag("mary")
+	found, err := s.api.OfferConsumers(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.Results, gc.HasLen, 1)
+	c.Assert(found.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
+}
+
+func (s *consumeSuite) TestRevokeConsumers(c *gc.C) {
+	relations := s.setupOfferConsumers()
+	s.authorizer.Tag = names.NewUserTag("admin")
+	results, err := s.api.RevokeConsumers(params.RevokeOfferConsumersArgs{
+		Args: []params.RevokeOfferConsumers{{
+			OfferURL:        "fred/prod.hosted-mysql",
+			SourceModelTags: []string{"model-model-a-uuid"},
+			StaleOnly:       true,
+		}, {
+			OfferURL:  "fred/prod.hosted-mysql",
+			Usernames: []string{"mary"},
+		}, {
+			OfferURL: "fred/prod.unknown",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RevokeOfferConsumersResult{
+		{RelationIds: []int{3}},
+		{RelationIds: []int{1}},
+		{Error: &params.Error{Message: `application offer "unknown" not found`, Code: "not found"}},
+	})
+	c.Assert(relations["mysql:server wordpress:db"].destroyed, jc.IsTrue)
+	c.Assert(relations["mysql:server mediawiki:db"].destroyed, jc.IsFalse)
+	c.Assert(relations["mysql:server ghost:db"].destroyed, jc.IsTrue)
+
+	// The revoked consumers may no longer consume the offer.
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	for _, username := range []string{"mary", "bob"} {
+		access, err := st.GetOfferAccess("hosted-mysql-uuid", names.NewUserTag(username))
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(access, gc.Equals, permission.ReadAccess, gc.Commentf("user %q", username))
+	}
+}
+
+func (s *consumeSuite) TestRevokeConsumersLeavesOfferAdmins(c *gc.C) {
+	s.setupOfferConsumers()
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	mary := names.NewUserTag("mary")
+	st.accessPerms[offerAccess{user: mary, offerUUID: "hosted-mysql-uuid"}] = permission.AdminAccess
+	s.authorizer.Tag = names.NewUserTag("admin")
+	results, err := s.api.RevokeConsumers(params.RevokeOfferConsumersArgs{
+		Args: []params.RevokeOfferConsumers{{OfferURL: "fred/prod.hosted-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	access, err := st.GetOfferAccess("hosted-mysql-uuid", mary)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AdminAccess)
+	access, err = st.GetOfferAccess("hosted-mysql-uuid", names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ReadAccess)
+}
+
+func (s *consumeSuite) TestRevokeConsumersAll(c *gc.C) {
+	relations := s.setupOfferConsumers()
+	s.authorizer.Tag = names.NewUserTag("admin")
+	results, err := s.api.RevokeConsumers(params.RevokeOfferConsumersArgs{
+		Args: []params.RevokeOfferConsumers{{OfferURL: "fred/prod.hosted-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RevokeOfferConsumersResult{
+		{RelationIds: []int{1, 2, 3}},
+	})
+	for key, rel := range relations {
+		c.Check(rel.destroyed, jc.IsTrue, gc.Commentf("relation %q", key))
+	}
+}
+
+func (s *consumeSuite) TestRevokeConsumersPermission(c *gc.C) {
+	relations := s.setupOfferConsumers()
+	s.authorizer.Tag = names.NewUserTag("mary")
+	results, err := s.api.RevokeConsumers(params.RevokeOfferConsumersArgs{
+		Args: []params.RevokeOfferConsumers{{OfferURL: "fred/prod.hosted-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
+	for _, rel := range relations {
+		c.Check(rel.destroyed, jc.IsFalse)
+	}
+}
+
+func (s *consumeSuite) TestOfferMigration(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("admin")
+	deadline := time.Date(2017, 10, 2, 12, 0, 0, 0, time.UTC)
+	results, err := s.api.StartOfferMigration(params.StartOfferMigrationArgs{
+		Args: []params.OfferMigration{{
+			OfferURL:        "fred/prod.hosted-mysql",
+			ApplicationName: "mysql2",
+			Endpoints:       map[string]string{"server": "server"},
+			Deadline:        deadline,
+		}, {
+			OfferURL:        "fred/prod.unknown",
+			ApplicationName: "mysql2",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{
+			Error: &params.Error{Message: `application offer "unknown" not found`, Code: "not found"},
+		},
+	})
+	st := s.mockStatePool.st[testing.ModelTag.Id()].(*mockState)
+	offer := st.applicationOffers["hosted-mysql"]
+	c.Assert(offer.PendingMigration, jc.DeepEquals, &jujucrossmodel.OfferMigration{
+		ApplicationName: "mysql2",
+		Endpoints:       map[string]string{"server": "server"},
+		Deadline:        deadline,
+	})
+
+	results, err = s.api.CompleteOfferMigration(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	offer = st.applicationOffers["hosted-mysql"]
+	c.Assert(offer.ApplicationName, gc.Equals, "mysql2")
+	c.Assert(offer.Version, gc.Equals, 1)
+	c.Assert(offer.PendingMigration, gc.IsNil)
+
+	results, err = s.api.AbortOfferMigration(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{
+		Error: &params.Error{Message: `pending migration not found`, Code: "not found"},
+	}})
+}
+
+func (s *consumeSuite) TestOfferMigrationPermission(c *gc.C) {
+	s.setupOffer()
+	s.authorizer.Tag = names.NewUserTag("mary")
+	results, err := s.api.StartOfferMigration(params.StartOfferMigrationArgs{
+		Args: []params.OfferMigration{{
+			OfferURL:        "fred/prod.hosted-mysql",
+			ApplicationName: "mysql2",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
+
+	results, err = s.api.CompleteOfferMigration(params.OfferURLs{
+		OfferURLs: []string{"fred/prod.hosted-mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, common.ErrPerm.Error())
+}
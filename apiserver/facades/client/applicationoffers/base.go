@@ -180,35 +180,10 @@ func (api *BaseAPI) getOfferAdminDetails(backend Backend, app crossmodel.Applica
 	offer.ApplicationName = app.Name()
 	offer.CharmURL = curl.String()
 	for _, oc := range conns {
-		connDetails := params.OfferConnection{
-			SourceModelTag: names.NewModelTag(oc.SourceModelUUID()).String(),
-			Username:       oc.UserName(),
-			RelationId:     oc.RelationId(),
-		}
-		rel, err := backend.KeyRelation(oc.RelationKey())
-		if err != nil {
-			return errors.Trace(err)
-		}
-		ep, err := rel.Endpoint(app.Name())
-		if err != nil {
-			return errors.Trace(err)
-		}
-		relStatus, err := rel.Status()
-		if err != nil {
-			return errors.Trace(err)
-		}
-		connDetails.Endpoint = ep.Name
-		connDetails.Status = params.EntityStatus{
-			Status: relStatus.Status,
-			Info:   relStatus.Message,
-			Data:   relStatus.Data,
-			Since:  relStatus.Since,
-		}
-		relIngress, err := backend.IngressNetworks(oc.RelationKey())
+		connDetails, err := offerConnectionDetails(backend, app.Name(), oc)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		connDetails.IngressSubnets = relIngress.CIDRS()
 		offer.Connections = append(offer.Connections, connDetails)
 	}
 
@@ -235,6 +210,46 @@ func (api *BaseAPI) getOfferAdminDetails(backend Backend, app crossmodel.Applica
 	return nil
 }
 
+// offerConnectionDetails returns the details of a connection to an
+// offer of the named application, including the status of its
+// relation and the networks from which it has connected.
+func offerConnectionDetails(backend Backend, appName string, oc OfferConnection) (params.OfferConnection, error) {
+	connDetails := params.OfferConnection{
+		SourceModelTag: names.NewModelTag(oc.SourceModelUUID()).String(),
+		Username:       oc.UserName(),
+		RelationId:     oc.RelationId(),
+		Health:         string(oc.Health()),
+	}
+	if lastSeen := oc.LastSeen(); !lastSeen.IsZero() {
+		connDetails.LastSeen = &lastSeen
+	}
+	rel, err := backend.KeyRelation(oc.RelationKey())
+	if err != nil {
+		return connDetails, errors.Trace(err)
+	}
+	ep, err := rel.Endpoint(appName)
+	if err != nil {
+		return connDetails, errors.Trace(err)
+	}
+	relStatus, err := rel.Status()
+	if err != nil {
+		return connDetails, errors.Trace(err)
+	}
+	connDetails.Endpoint = ep.Name
+	connDetails.Status = params.EntityStatus{
+		Status: relStatus.Status,
+		Info:   relStatus.Message,
+		Data:   relStatus.Data,
+		Since:  relStatus.Since,
+	}
+	relIngress, err := backend.IngressNetworks(oc.RelationKey())
+	if err != nil {
+		return connDetails, errors.Trace(err)
+	}
+	connDetails.IngressSubnets = relIngress.CIDRS()
+	return connDetails, nil
+}
+
 // checkOfferAccess returns the level of access the authenticated user has to the offer,
 // so long as it is greater than the requested perm.
 func (api *BaseAPI) checkOfferAccess(backend Backend, offerUUID string, perm permission.Access) (permission.Access, error) {
@@ -4,6 +4,8 @@
 package applicationoffers_test
 
 import (
+	"time"
+
 	jtesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -27,6 +29,8 @@ const (
 	listOffersBackendCall = "listOffersCall"
 )
 
+var connectionLastSeen = time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+
 type baseSuite struct {
 	jtesting.IsolationSuite
 
@@ -123,6 +127,8 @@ func (s *baseSuite) setupOffers(c *gc.C, filterAppName string, filterWithEndpoin
 			modelUUID:   coretesting.ModelTag.Id(),
 			relationKey: "hosted-db2:db wordpress:db",
 			relationId:  1,
+			lastSeen:    connectionLastSeen,
+			health:      jujucrossmodel.OfferConnectionHealthy,
 		},
 	}
 	s.mockState.spaces["myspace"] = &mockSpace{
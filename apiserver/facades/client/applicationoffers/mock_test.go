@@ -252,8 +252,14 @@ func (m *mockSubnet) AvailabilityZones() []string {
 
 type mockRelation struct {
 	crossmodel.Relation
-	id       int
-	endpoint state.Endpoint
+	id        int
+	endpoint  state.Endpoint
+	destroyed bool
+}
+
+func (m *mockRelation) Destroy() error {
+	m.destroyed = true
+	return nil
 }
 
 func (m *mockRelation) Status() (status.StatusInfo, error) {
@@ -272,6 +278,8 @@ type mockOfferConnection struct {
 	username    string
 	relationKey string
 	relationId  int
+	lastSeen    time.Time
+	health      jujucrossmodel.OfferConnectionHealth
 }
 
 func (m *mockOfferConnection) SourceModelUUID() string {
@@ -290,6 +298,14 @@ func (m *mockOfferConnection) RelationId() int {
 	return m.relationId
 }
 
+func (m *mockOfferConnection) LastSeen() time.Time {
+	return m.lastSeen
+}
+
+func (m *mockOfferConnection) Health() jujucrossmodel.OfferConnectionHealth {
+	return m.health
+}
+
 type mockApplicationOffers struct {
 	jujucrossmodel.ApplicationOffers
 	st *mockState
@@ -314,6 +330,62 @@ func (m *mockApplicationOffers) Remove(name string) error {
 	return nil
 }
 
+func (m *mockApplicationOffers) ApplicationOffer(name string) (*jujucrossmodel.ApplicationOffer, error) {
+	offer, ok := m.st.applicationOffers[name]
+	if !ok {
+		return nil, errors.NotFoundf("application offer %q", name)
+	}
+	return &offer, nil
+}
+
+func (m *mockApplicationOffers) SetIngressNetworks(name string, cidrs []string) error {
+	offer, ok := m.st.applicationOffers[name]
+	if !ok {
+		return errors.NotFoundf("application offer %q", name)
+	}
+	offer.IngressCIDRs = cidrs
+	m.st.applicationOffers[name] = offer
+	return nil
+}
+
+func (m *mockApplicationOffers) StartMigration(name string, migration jujucrossmodel.OfferMigration) error {
+	offer, ok := m.st.applicationOffers[name]
+	if !ok {
+		return errors.NotFoundf("application offer %q", name)
+	}
+	offer.PendingMigration = &migration
+	m.st.applicationOffers[name] = offer
+	return nil
+}
+
+func (m *mockApplicationOffers) CompleteMigration(name string) (*jujucrossmodel.ApplicationOffer, error) {
+	offer, ok := m.st.applicationOffers[name]
+	if !ok {
+		return nil, errors.NotFoundf("application offer %q", name)
+	}
+	if offer.PendingMigration == nil {
+		return nil, errors.NotFoundf("pending migration")
+	}
+	offer.ApplicationName = offer.PendingMigration.ApplicationName
+	offer.PendingMigration = nil
+	offer.Version++
+	m.st.applicationOffers[name] = offer
+	return &offer, nil
+}
+
+func (m *mockApplicationOffers) AbortMigration(name string) error {
+	offer, ok := m.st.applicationOffers[name]
+	if !ok {
+		return errors.NotFoundf("application offer %q", name)
+	}
+	if offer.PendingMigration == nil {
+		return errors.NotFoundf("pending migration")
+	}
+	offer.PendingMigration = nil
+	m.st.applicationOffers[name] = offer
+	return nil
+}
+
 type offerAccess struct {
 	user      names.UserTag
 	offerUUID string
@@ -4,6 +4,8 @@
 package applicationoffers
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -213,6 +215,8 @@ type OfferConnection interface {
 	UserName() string
 	RelationKey() string
 	RelationId() int
+	LastSeen() time.Time
+	Health() crossmodel.OfferConnectionHealth
 }
 
 type offerConnectionShim struct {
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides the AuditLog facade, which allows
+// controller administrators to query the audit trail of API
+// requests recorded by the controller.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the auditlog
+// facade. For details on the methods, see the methods on state.State
+// with the same names.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	AuditEntries(state.AuditEntryFilter) ([]audit.AuditEntry, error)
+}
+
+// API provides the AuditLog API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(ctx.State(), ctx.Auth())
+}
+
+// NewAPI returns a new AuditLog API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkIsSuperuser() error {
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Events returns the audit events that match the given arguments,
+// most recent first.
+func (api *API) Events(args params.AuditEventsArgs) (params.AuditEvents, error) {
+	if err := api.checkIsSuperuser(); err != nil {
+		return params.AuditEvents{}, errors.Trace(err)
+	}
+	filter := state.AuditEntryFilter{
+		Limit: args.Limit,
+	}
+	if args.ModelTag != "" {
+		tag, err := names.ParseModelTag(args.ModelTag)
+		if err != nil {
+			return params.AuditEvents{}, errors.Trace(err)
+		}
+		filter.ModelUUID = tag.Id()
+	}
+	if args.UserTag != "" {
+		tag, err := names.ParseUserTag(args.UserTag)
+		if err != nil {
+			return params.AuditEvents{}, errors.Trace(err)
+		}
+		filter.OriginName = tag.String()
+	}
+	if args.After != nil {
+		filter.After = *args.After
+	}
+	if args.Before != nil {
+		filter.Before = *args.Before
+	}
+	entries, err := api.backend.AuditEntries(filter)
+	if err != nil {
+		return params.AuditEvents{}, errors.Trace(err)
+	}
+	events := make([]params.AuditEvent, len(entries))
+	for i, entry := range entries {
+		events[i] = params.AuditEvent{
+			JujuServerVersion: entry.JujuServerVersion.String(),
+			ModelTag:          names.NewModelTag(entry.ModelUUID).String(),
+			Timestamp:         entry.Timestamp,
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Data:              entry.Data,
+		}
+	}
+	return params.AuditEvents{Events: events}, nil
+}
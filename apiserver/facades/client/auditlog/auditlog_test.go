@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	entries []audit.AuditEntry
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) AuditEntries(filter state.AuditEntryFilter) ([]audit.AuditEntry, error) {
+	b.MethodCall(b, "AuditEntries", filter)
+	return b.entries, b.NextErr()
+}
+
+type AuditLogSuite struct {
+	testing.IsolationSuite
+	backend    mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = mockBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *AuditLogSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *AuditLogSuite) TestEvents(c *gc.C) {
+	t0 := time.Date(2017, time.October, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.entries = []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         t0,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "Application:v6 - Deploy",
+		Data:              map[string]interface{}{"method": "Deploy"},
+	}}
+	api, err := auditlog.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	after := t0.Add(-time.Hour)
+	events, err := api.Events(params.AuditEventsArgs{
+		ModelTag: coretesting.ModelTag.String(),
+		UserTag:  "user-bob",
+		After:    &after,
+		Limit:    10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, jc.DeepEquals, params.AuditEvents{
+		Events: []params.AuditEvent{{
+			JujuServerVersion: "2.3.0",
+			ModelTag:          coretesting.ModelTag.String(),
+			Timestamp:         t0,
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        "user-bob",
+			Operation:         "Application:v6 - Deploy",
+			Data:              map[string]interface{}{"method": "Deploy"},
+		}},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{{
+		FuncName: "AuditEntries",
+		Args: []interface{}{state.AuditEntryFilter{
+			ModelUUID:  coretesting.ModelTag.Id(),
+			OriginName: "user-bob",
+			After:      after,
+			Limit:      10,
+		}},
+	}})
+}
+
+func (s *AuditLogSuite) TestEventsInvalidUserTag(c *gc.C) {
+	api, err := auditlog.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Events(params.AuditEventsArgs{UserTag: "machine-0"})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *AuditLogSuite) TestEventsRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	api, err := auditlog.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Events(params.AuditEventsArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
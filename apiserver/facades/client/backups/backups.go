@@ -38,6 +38,7 @@ type Backend interface {
 	ControllerConfig() (controller.Config, error)
 	StateServingInfo() (state.StateServingInfo, error)
 	RestoreInfo() *state.RestoreInfo
+	RestoreModel(args state.RestoreModelArgs) (*state.Model, *state.State, error)
 }
 
 // API serves backup-specific API methods.
@@ -49,6 +50,22 @@ type API struct {
 	machineID string
 }
 
+// APIv3 serves version 3 of the backups API, which lacks RestoreModel.
+type APIv3 struct {
+	*API
+}
+
+// APIv2 serves version 2 of the backups API, which cannot stream
+// backups to a backup target.
+type APIv2 struct {
+	*APIv3
+}
+
+// APIv1 serves version 1 of the backups API, which lacks Prune.
+type APIv1 struct {
+	*APIv2
+}
+
 // NewAPI creates a new instance of the Backups API facade.
 func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	isControllerAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
@@ -135,6 +152,8 @@ func ResultFromMetadata(meta *backups.Metadata) params.BackupsMetadataResult {
 	result.Hostname = meta.Origin.Hostname
 	result.Version = meta.Origin.Version
 	result.Series = meta.Origin.Series
+	result.Parent = meta.Parent
+	result.Target = meta.Target
 
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
@@ -163,6 +182,8 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Version = result.Version
 	meta.Origin.Series = result.Series
 	meta.Notes = result.Notes
+	meta.Parent = result.Parent
+	meta.Target = result.Target
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
@@ -8,14 +8,22 @@ import (
 	"github.com/juju/replicaset"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/backups"
 )
 
-var waitUntilReady = replicaset.WaitUntilReady
+var (
+	waitUntilReady = replicaset.WaitUntilReady
+	getOplogWindow = backups.GetOplogWindow
+	newTarget      = backups.NewTarget
+)
 
 // Create is the API method that requests juju to create a new backup
-// of its state.  It returns the metadata for that backup.
+// of its state.  It returns the metadata for that backup. Incremental
+// backups hold only the changes made since the most recent backup.
+// Backups created with ToTarget set are streamed to the controller's
+// backup target, and only their metadata is stored on the controller.
 func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataResult, err error) {
 	backupsMethods, closer := newBackups(a.backend)
 	defer closer.Close()
@@ -42,6 +50,10 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 	if err != nil {
 		return p, errors.Trace(err)
 	}
+	dbInfo.Oplog, err = getOplogWindow(session)
+	if err != nil {
+		return p, errors.Annotate(err, "reading oplog")
+	}
 	mSeries, err := a.backend.MachineSeries(a.machineID)
 	if err != nil {
 		return p, errors.Trace(err)
@@ -52,6 +64,25 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 		return p, errors.Trace(err)
 	}
 	meta.Notes = args.Notes
+	if args.Incremental {
+		parent, err := latestBackup(backupsMethods, meta.Origin.Model)
+		if err != nil {
+			return p, errors.Trace(err)
+		}
+		meta.Parent = parent.ID()
+	}
+
+	if args.ToTarget {
+		target, err := a.backupTarget()
+		if err != nil {
+			return p, errors.Trace(err)
+		}
+		err = backupsMethods.CreateToTarget(meta, a.paths, dbInfo, target)
+		if err != nil {
+			return p, errors.Trace(err)
+		}
+		return ResultFromMetadata(meta), nil
+	}
 
 	err = backupsMethods.Create(meta, a.paths, dbInfo)
 	if err != nil {
@@ -60,3 +91,50 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 
 	return ResultFromMetadata(meta), nil
 }
+
+// Create is the API method that requests juju to create a new backup
+// of its state. Version 2 of the facade cannot stream backups to a
+// backup target, so ToTarget is ignored.
+func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	args.ToTarget = false
+	return a.API.Create(args)
+}
+
+// backupTarget returns the backup target configured for the controller.
+func (a *API) backupTarget() (backups.Target, error) {
+	controllerConfig, err := a.backend.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	targetConfig, ok := controllerConfig.BackupTarget()
+	if !ok {
+		return nil, errors.NotFoundf("backup target (set %s in controller config)", controller.BackupTargetType)
+	}
+	target, err := newTarget(targetConfig)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening backup target")
+	}
+	return target, nil
+}
+
+// latestBackup returns the most recently started complete backup of
+// the model, on which an incremental backup can build.
+func latestBackup(backupsMethods backups.Backups, modelUUID string) (*backups.Metadata, error) {
+	metaList, err := backupsMethods.List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var latest *backups.Metadata
+	for _, meta := range metaList {
+		if meta.Origin.Model != modelUUID || meta.Finished == nil {
+			continue
+		}
+		if latest == nil || meta.Started.After(latest.Started) {
+			latest = meta
+		}
+	}
+	if latest == nil {
+		return nil, errors.NotFoundf("backup to build incremental backup on")
+	}
+	return latest, nil
+}
@@ -4,12 +4,19 @@
 package backups_test
 
 import (
+	"io"
+	"time"
+
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/backups"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	statebackups "github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -53,3 +60,139 @@ func (s *backupsSuite) TestCreateError(c *gc.C) {
 	c.Logf("%v", err)
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) TestCreateIncremental(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	window := statebackups.OplogWindow{Oldest: 1, Newest: 2}
+	s.PatchValue(backups.GetOplogWindow,
+		func(*mgo.Session) (statebackups.OplogWindow, error) { return window, nil },
+	)
+	fake := s.setBackups(c, nil, "")
+	older := s.storedBackup("older", -2*time.Hour, true)
+	latest := s.storedBackup("latest", -time.Hour, true)
+	unfinished := s.storedBackup("unfinished", 0, false)
+	otherModel := backupstesting.NewMetadata()
+	fake.MetaList = []*statebackups.Metadata{older, latest, unfinished, otherModel}
+
+	result, err := s.api.Create(params.BackupsCreateArgs{Incremental: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Parent, gc.Equals, "latest")
+	c.Check(fake.DBInfoArg.Oplog, gc.Equals, window)
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List", "Create"})
+}
+
+func (s *backupsSuite) TestCreateIncrementalNoBackups(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	s.PatchValue(backups.GetOplogWindow,
+		func(*mgo.Session) (statebackups.OplogWindow, error) { return statebackups.OplogWindow{}, nil },
+	)
+	s.setBackups(c, nil, "")
+
+	_, err := s.api.Create(params.BackupsCreateArgs{Incremental: true})
+	c.Check(err, gc.ErrorMatches, "backup to build incremental backup on not found")
+}
+
+func (s *backupsSuite) storedBackup(id string, age time.Duration, finished bool) *statebackups.Metadata {
+	meta := backupstesting.NewMetadataStarted()
+	meta.SetID(id)
+	meta.Origin.Model = s.State.ModelUUID()
+	meta.Started = s.meta.Started.Add(age)
+	if finished {
+		backupstesting.FinishMetadata(meta)
+	}
+	return meta
+}
+
+func (s *backupsSuite) TestCreateToTargetNotConfigured(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+
+	_, err := s.api.Create(params.BackupsCreateArgs{ToTarget: true})
+	c.Check(err, gc.ErrorMatches, `backup target \(set backup-target-type in controller config\) not found`)
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestCreateToTargetIgnoredByV2(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+
+	api := &backups.APIv2{APIv3: &backups.APIv3{API: s.api}}
+	_, err := api.Create(params.BackupsCreateArgs{ToTarget: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Create"})
+}
+
+type backupTargetSuite struct {
+	backupsSuite
+}
+
+var _ = gc.Suite(&backupTargetSuite{})
+
+func (s *backupTargetSuite) SetUpTest(c *gc.C) {
+	s.ControllerConfigAttrs = map[string]interface{}{
+		controller.BackupTargetType:      controller.BackupTargetS3,
+		controller.BackupTargetContainer: "juju-backups",
+		controller.BackupTargetRegion:    "us-east-1",
+		controller.BackupTargetAccessKey: "access",
+		controller.BackupTargetSecretKey: "secret",
+	}
+	s.backupsSuite.SetUpTest(c)
+}
+
+type fakeTarget struct{}
+
+func (fakeTarget) Put(name string, archive io.Reader, size int64) (string, error) {
+	return "s3://juju-backups/" + name, nil
+}
+
+func (s *backupTargetSuite) TestCreateToTarget(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	var targetConfig controller.BackupTargetConfig
+	s.PatchValue(backups.NewTarget,
+		func(cfg controller.BackupTargetConfig) (statebackups.Target, error) {
+			targetConfig = cfg
+			return fakeTarget{}, nil
+		},
+	)
+	s.meta.Target = "s3://juju-backups/juju-backup.tar.gz"
+	fake := s.setBackups(c, s.meta, "")
+
+	result, err := s.api.Create(params.BackupsCreateArgs{ToTarget: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Target, gc.Equals, "s3://juju-backups/juju-backup.tar.gz")
+	c.Check(fake.Calls, jc.DeepEquals, []string{"CreateToTarget"})
+	c.Check(fake.TargetArg, gc.Equals, fakeTarget{})
+	c.Check(targetConfig, jc.DeepEquals, controller.BackupTargetConfig{
+		Type:      controller.BackupTargetS3,
+		Container: "juju-backups",
+		Region:    "us-east-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+}
+
+func (s *backupTargetSuite) TestCreateToTargetOpenError(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	s.PatchValue(backups.NewTarget,
+		func(controller.BackupTargetConfig) (statebackups.Target, error) {
+			return nil, errors.New("bad credentials")
+		},
+	)
+	fake := s.setBackups(c, s.meta, "")
+
+	_, err := s.api.Create(params.BackupsCreateArgs{ToTarget: true})
+	c.Check(err, gc.ErrorMatches, "opening backup target: bad credentials")
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
@@ -5,5 +5,7 @@ package backups
 
 var (
 	NewBackups     = &newBackups
+	GetOplogWindow = &getOplogWindow
+	NewTarget      = &newTarget
 	WaitUntilReady = &waitUntilReady
 )
@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
+)
+
+// Prune removes the stored backups not retained by the requested
+// retention policy, and returns their metadata. With DryRun set,
+// nothing is removed.
+func (a *API) Prune(args params.BackupsPruneArgs) (params.BackupsPruneResult, error) {
+	var result params.BackupsPruneResult
+
+	backupsMethods, closer := newBackups(a.backend)
+	defer closer.Close()
+
+	policy := backups.RetentionPolicy{
+		KeepLast:   args.KeepLast,
+		KeepDaily:  args.KeepDaily,
+		KeepWeekly: args.KeepWeekly,
+	}
+	var removed []*backups.Metadata
+	if args.DryRun {
+		if err := policy.Validate(); err != nil {
+			return result, errors.Trace(err)
+		}
+		metaList, err := backupsMethods.List()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		removed = policy.Prunable(metaList)
+	} else {
+		var err error
+		removed, err = backupsMethods.Prune(policy)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+
+	result.Removed = make([]params.BackupsMetadataResult, len(removed))
+	for i, meta := range removed {
+		result.Removed[i] = ResultFromMetadata(meta)
+	}
+	return result, nil
+}
+
+// Prune is not available in version 1 of the API.
+func (a *APIv1) Prune(_, _ struct{}) {}
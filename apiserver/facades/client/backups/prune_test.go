@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	backupsAPI "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
+)
+
+func (s *backupsSuite) TestPrune(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	result, err := s.api.Prune(params.BackupsPruneArgs{
+		KeepLast:   1,
+		KeepDaily:  2,
+		KeepWeekly: 3,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"Prune"})
+	c.Check(fake.PolicyArg, jc.DeepEquals, backups.RetentionPolicy{
+		KeepLast:   1,
+		KeepDaily:  2,
+		KeepWeekly: 3,
+	})
+	c.Check(result.Removed, jc.DeepEquals, []params.BackupsMetadataResult{
+		backupsAPI.ResultFromMetadata(s.meta),
+	})
+}
+
+func (s *backupsSuite) TestPruneDryRun(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+	older := s.storedBackup("older", -time.Hour, true)
+	newer := s.storedBackup("newer", 0, true)
+	fake.MetaList = []*backups.Metadata{newer, older}
+
+	result, err := s.api.Prune(params.BackupsPruneArgs{
+		KeepLast: 1,
+		DryRun:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"List"})
+	c.Check(result.Removed, jc.DeepEquals, []params.BackupsMetadataResult{
+		backupsAPI.ResultFromMetadata(older),
+	})
+}
+
+func (s *backupsSuite) TestPruneDryRunInvalidPolicy(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+	_, err := s.api.Prune(params.BackupsPruneArgs{DryRun: true})
+	c.Check(err, gc.ErrorMatches, "retention policy keeping no backups not valid")
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestPruneError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+	_, err := s.api.Prune(params.BackupsPruneArgs{KeepLast: 1})
+	c.Check(err, gc.ErrorMatches, "failed!")
+}
@@ -0,0 +1,40 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
+)
+
+// RestoreModel restores a single model from a controller backup into
+// the running controller, optionally giving it a new UUID and name.
+func (a *API) RestoreModel(args params.BackupsRestoreModelArgs) (params.BackupsRestoreModelResult, error) {
+	var result params.BackupsRestoreModelResult
+
+	modelTag, err := names.ParseModelTag(args.ModelTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	backupsMethods, closer := newBackups(a.backend)
+	defer closer.Close()
+
+	restoredTag, err := backupsMethods.RestoreModel(args.BackupId, a.backend, backups.ModelRestoreArgs{
+		ModelUUID: modelTag.Id(),
+		NewUUID:   args.NewUUID,
+		NewName:   args.NewName,
+	})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.ModelTag = restoredTag.String()
+	return result, nil
+}
+
+// RestoreModel is not available in version 3 of the API.
+func (a *APIv3) RestoreModel(_, _ struct{}) {}
@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+const restoreModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+func (s *backupsSuite) TestRestoreModel(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+
+	result, err := s.api.RestoreModel(params.BackupsRestoreModelArgs{
+		BackupId: "some-id",
+		ModelTag: names.NewModelTag(restoreModelUUID).String(),
+		NewName:  "restored",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ModelTag, gc.Equals, names.NewModelTag(restoreModelUUID).String())
+	c.Check(fake.Calls, jc.DeepEquals, []string{"RestoreModel"})
+	c.Check(fake.IDArg, gc.Equals, "some-id")
+	c.Check(fake.ModelRestoreArg, jc.DeepEquals, statebackups.ModelRestoreArgs{
+		ModelUUID: restoreModelUUID,
+		NewName:   "restored",
+	})
+}
+
+func (s *backupsSuite) TestRestoreModelNewUUID(c *gc.C) {
+	s.setBackups(c, nil, "")
+	newUUID := "c0ffee00-0bad-400d-8000-4b1d0d06f00d"
+
+	result, err := s.api.RestoreModel(params.BackupsRestoreModelArgs{
+		BackupId: "some-id",
+		ModelTag: names.NewModelTag(restoreModelUUID).String(),
+		NewUUID:  newUUID,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ModelTag, gc.Equals, names.NewModelTag(newUUID).String())
+}
+
+func (s *backupsSuite) TestRestoreModelBadTag(c *gc.C) {
+	fake := s.setBackups(c, nil, "")
+
+	_, err := s.api.RestoreModel(params.BackupsRestoreModelArgs{
+		BackupId: "some-id",
+		ModelTag: "machine-0",
+	})
+	c.Check(err, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestRestoreModelError(c *gc.C) {
+	s.setBackups(c, nil, "failed!")
+
+	_, err := s.api.RestoreModel(params.BackupsRestoreModelArgs{
+		BackupId: "some-id",
+		ModelTag: names.NewModelTag(restoreModelUUID).String(),
+	})
+	c.Check(err, gc.ErrorMatches, "failed!")
+}
@@ -32,8 +32,38 @@ func (s *stateShim) MachineSeries(id string) (string, error) {
 	return m.Series(), nil
 }
 
-// NewFacade provides the required signature for facade registration.
-func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+// NewFacade provides the required signature for version 1 facade
+// registration.
+func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv1, error) {
+	api, err := NewFacadeV2(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
+// NewFacadeV2 provides the required signature for version 2 facade
+// registration.
+func NewFacadeV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
+	api, err := NewFacadeV3(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
+// NewFacadeV3 provides the required signature for version 3 facade
+// registration.
+func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	api, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
+// NewFacadeV4 provides the required signature for facade registration.
+func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	model, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -0,0 +1,133 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the bundle
+// facade to export a model as a bundle. For details on the methods,
+// see the methods on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllApplications() ([]Application, error)
+	AllMachines() ([]Machine, error)
+	AllRelations() ([]Relation, error)
+
+	// AllOffers returns all of the application offers in the model.
+	AllOffers() ([]crossmodel.ApplicationOffer, error)
+}
+
+// Application defines the application functionality required by
+// the bundle facade.
+type Application interface {
+	Name() string
+	Series() string
+	CharmURL() (*charm.URL, bool)
+	IsPrincipal() bool
+	IsExposed() bool
+	ConfigSettings() (charm.Settings, error)
+	Constraints() (constraints.Value, error)
+	AllUnits() ([]Unit, error)
+}
+
+// Unit defines the unit functionality required by the bundle facade.
+type Unit interface {
+	Name() string
+	AssignedMachineId() (string, error)
+}
+
+// Machine defines the machine functionality required by the bundle
+// facade.
+type Machine interface {
+	Id() string
+	Series() string
+	Constraints() (constraints.Value, error)
+	ContainerType() instance.ContainerType
+	ParentId() (string, bool)
+}
+
+// Relation defines the relation functionality required by the
+// bundle facade.
+type Relation interface {
+	Endpoints() []state.Endpoint
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+func (s stateShim) AllApplications() ([]Application, error) {
+	apps, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = applicationShim{app}
+	}
+	return result, nil
+}
+
+func (s stateShim) AllMachines() ([]Machine, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+func (s stateShim) AllRelations() ([]Relation, error) {
+	relations, err := s.State.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Relation, len(relations))
+	for i, rel := range relations {
+		result[i] = rel
+	}
+	return result, nil
+}
+
+func (s stateShim) AllOffers() ([]crossmodel.ApplicationOffer, error) {
+	offers, err := state.NewApplicationOffers(s.State).ListOffers()
+	return offers, errors.Trace(err)
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, u := range units {
+		result[i] = u
+	}
+	return result, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
@@ -24,6 +25,18 @@ func NewFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bund
 	return NewBundle(auth)
 }
 
+// NewFacadeV2 provides the required signature for version 2 facade
+// registration.
+func NewFacadeV2(ctx facade.Context) (BundleV2, error) {
+	return NewBundleV2(NewStateBackend(ctx.State()), ctx.Auth())
+}
+
+// NewFacadeV3 provides the required signature for version 3 facade
+// registration.
+func NewFacadeV3(ctx facade.Context) (BundleV3, error) {
+	return NewBundleV3(NewStateBackend(ctx.State()), ctx.Auth())
+}
+
 // NewBundle creates and returns a new Bundle API facade.
 func NewBundle(auth facade.Authorizer) (Bundle, error) {
 	if !auth.AuthClient() {
@@ -39,6 +52,51 @@ type Bundle interface {
 	GetChanges(params.BundleChangesParams) (params.BundleChangesResults, error)
 }
 
+// BundleV2 defines the API endpoint used to retrieve bundle changes
+// and to export a model as a bundle.
+type BundleV2 interface {
+	Bundle
+
+	// ExportBundle returns the YAML for a bundle that would deploy
+	// the applications, relations and machines in the model.
+	ExportBundle() (params.StringResult, error)
+}
+
+// BundleV3 defines the API endpoint used to retrieve bundle changes,
+// to export a model as a bundle, and to preview the changes deploying
+// a bundle would make to the model.
+type BundleV3 interface {
+	BundleV2
+
+	// PlanBundle returns the changes that deploying the given bundle
+	// would make to the model, without making them.
+	PlanBundle(params.BundlePlanParams) (params.BundlePlanResults, error)
+}
+
+// NewBundleV2 creates and returns a new version 2 Bundle API facade.
+func NewBundleV2(backend Backend, auth facade.Authorizer) (BundleV2, error) {
+	if !auth.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &bundleAPIV2{
+		backend:    backend,
+		authorizer: auth,
+	}, nil
+}
+
+// NewBundleV3 creates and returns a new version 3 Bundle API facade.
+func NewBundleV3(backend Backend, auth facade.Authorizer) (BundleV3, error) {
+	if !auth.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &bundleAPIV3{
+		bundleAPIV2{
+			backend:    backend,
+			authorizer: auth,
+		},
+	}, nil
+}
+
 // bundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
 type bundleAPI struct{}
@@ -48,28 +106,13 @@ type bundleAPI struct{}
 // order.
 func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
 	var results params.BundleChangesResults
-	data, err := charm.ReadBundleData(strings.NewReader(args.BundleDataYAML))
+	data, verificationErrors, err := readBundle(args.BundleDataYAML)
 	if err != nil {
-		return results, errors.Annotate(err, "cannot read bundle YAML")
-	}
-	verifyConstraints := func(s string) error {
-		_, err := constraints.Parse(s)
-		return err
+		return results, errors.Trace(err)
 	}
-	verifyStorage := func(s string) error {
-		_, err := storage.ParseConstraints(s)
-		return err
-	}
-	if err := data.Verify(verifyConstraints, verifyStorage); err != nil {
-		if err, ok := err.(*charm.VerificationError); ok {
-			results.Errors = make([]string, len(err.Errors))
-			for i, e := range err.Errors {
-				results.Errors[i] = e.Error()
-			}
-			return results, nil
-		}
-		// This should never happen as Verify only returns verification errors.
-		return results, errors.Annotate(err, "cannot verify bundle")
+	if len(verificationErrors) > 0 {
+		results.Errors = verificationErrors
+		return results, nil
 	}
 	changes, err := bundlechanges.FromData(data, nil)
 	if err != nil {
@@ -86,3 +129,99 @@ func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	}
 	return results, nil
 }
+
+// bundleAPIV2 implements the BundleV2 interface.
+type bundleAPIV2 struct {
+	bundleAPI
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// ExportBundle returns the YAML for a bundle that would deploy the
+// applications, with their options and constraints, relations and
+// machine placements in the model. If the model has application
+// offers, they are described in an overlay following the bundle.
+func (b *bundleAPIV2) ExportBundle() (params.StringResult, error) {
+	if err := b.checkCanRead(); err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	out, err := exportBundle(b.backend)
+	if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	return params.StringResult{Result: out}, nil
+}
+
+func (b *bundleAPIV2) checkCanRead() error {
+	canRead, err := b.authorizer.HasPermission(permission.ReadAccess, b.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// bundleAPIV3 implements the BundleV3 interface.
+type bundleAPIV3 struct {
+	bundleAPIV2
+}
+
+// PlanBundle returns the changes that deploying the given bundle would
+// make to the model: the applications to deploy, the charms and config
+// to change and the units to add to existing applications, and the
+// relations and machines to add. Nothing in the model is changed.
+//
+// The bundle's machines are added unless MapMachines is set, in which
+// case they are matched to the model's machines by id, as they are
+// when deploying with existing machines mapped.
+func (b *bundleAPIV3) PlanBundle(args params.BundlePlanParams) (params.BundlePlanResults, error) {
+	var results params.BundlePlanResults
+	if err := b.checkCanRead(); err != nil {
+		return results, errors.Trace(err)
+	}
+	data, verificationErrors, err := readBundle(args.BundleDataYAML)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if len(verificationErrors) > 0 {
+		results.Errors = verificationErrors
+		return results, nil
+	}
+	plan, err := planBundle(b.backend, data, args.MapMachines)
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Plan = plan
+	return results, nil
+}
+
+// readBundle reads and verifies the given bundle YAML. If the bundle
+// fails verification, the verification errors are returned.
+func readBundle(bundleYAML string) (*charm.BundleData, []string, error) {
+	data, err := charm.ReadBundleData(strings.NewReader(bundleYAML))
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot read bundle YAML")
+	}
+	verifyConstraints := func(s string) error {
+		_, err := constraints.Parse(s)
+		return err
+	}
+	verifyStorage := func(s string) error {
+		_, err := storage.ParseConstraints(s)
+		return err
+	}
+	if err := data.Verify(verifyConstraints, verifyStorage); err != nil {
+		if err, ok := err.(*charm.VerificationError); ok {
+			verificationErrors := make([]string, len(err.Errors))
+			for i, e := range err.Errors {
+				verificationErrors[i] = e.Error()
+			}
+			return nil, verificationErrors, nil
+		}
+		// This should never happen as Verify only returns verification errors.
+		return nil, nil, errors.Annotate(err, "cannot verify bundle")
+	}
+	return data, nil, nil
+}
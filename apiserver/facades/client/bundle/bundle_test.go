@@ -4,13 +4,18 @@
 package bundle_test
 
 import (
+	"strings"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/bundle"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/crossmodel"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -198,3 +203,273 @@ func (s *bundleSuite) TestGetChangesBundleEndpointBindingsSuccess(c *gc.C) {
 		}
 	}
 }
+
+type exportSuite struct {
+	coretesting.BaseSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&exportSuite{})
+
+func (s *exportSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{
+		applications: []bundle.Application{
+			&mockApplication{
+				name:        "mysql",
+				series:      "xenial",
+				charmURL:    "cs:xenial/mysql-42",
+				config:      charm.Settings{"dataset-size": "80%"},
+				constraints: "mem=4G",
+				units: []bundle.Unit{
+					&mockUnit{name: "mysql/1", machineId: "1/lxd/0"},
+					&mockUnit{name: "mysql/0", machineId: "0"},
+				},
+			},
+			&mockApplication{
+				name:     "wordpress",
+				series:   "xenial",
+				charmURL: "cs:xenial/wordpress-5",
+				exposed:  true,
+				units: []bundle.Unit{
+					&mockUnit{name: "wordpress/10", machineId: "2"},
+					&mockUnit{name: "wordpress/2", machineId: "0"},
+				},
+			},
+			&mockApplication{
+				name:        "logging",
+				series:      "xenial",
+				charmURL:    "cs:logging-1",
+				subordinate: true,
+			},
+		},
+		machines: []bundle.Machine{
+			&mockMachine{id: "0", series: "xenial", constraints: "cores=4"},
+			&mockMachine{id: "1", series: "xenial"},
+			&mockMachine{id: "1/lxd/0", series: "xenial"},
+			&mockMachine{id: "2", series: "trusty"},
+			&mockMachine{id: "3", series: "xenial"},
+		},
+		relations: []bundle.Relation{
+			newMockRelation("wordpress:db", "mysql:server"),
+			newMockRelation("logging:info", "wordpress:juju-info"),
+			newMockRelation("mysql:cluster"),
+			newMockRelation("wordpress:cache", "remote-cache:cache"),
+		},
+	}
+}
+
+func (s *exportSuite) exportBundle(c *gc.C) params.StringResult {
+	api, err := bundle.NewBundleV2(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
+func (s *exportSuite) TestExportBundle(c *gc.C) {
+	result := s.exportBundle(c)
+	c.Assert(result.Error, gc.IsNil)
+
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications, jc.DeepEquals, map[string]*charm.ApplicationSpec{
+		"mysql": {
+			Charm:       "cs:xenial/mysql-42",
+			Series:      "xenial",
+			NumUnits:    2,
+			To:          []string{"0", "lxd:1"},
+			Options:     map[string]interface{}{"dataset-size": "80%"},
+			Constraints: constraints.MustParse("mem=4G").String(),
+		},
+		"wordpress": {
+			Charm:    "cs:xenial/wordpress-5",
+			Series:   "xenial",
+			NumUnits: 2,
+			To:       []string{"0", "2"},
+			Expose:   true,
+		},
+		"logging": {
+			Charm:  "cs:logging-1",
+			Series: "xenial",
+		},
+	})
+	c.Assert(data.Machines, jc.DeepEquals, map[string]*charm.MachineSpec{
+		"0": {Series: "xenial", Constraints: "cores=4"},
+		"1": {Series: "xenial"},
+		"2": {Series: "trusty"},
+	})
+	c.Assert(data.Relations, jc.DeepEquals, [][]string{
+		{"logging:info", "wordpress:juju-info"},
+		{"wordpress:db", "mysql:server"},
+	})
+	c.Assert(result.Result, gc.Not(jc.Contains), "overlay.yaml")
+}
+
+func (s *exportSuite) TestExportBundleWithOffers(c *gc.C) {
+	s.backend.offers = []crossmodel.ApplicationOffer{{
+		OfferName:       "hosted-mysql",
+		ApplicationName: "mysql",
+		Endpoints: map[string]charm.Relation{
+			"server": {Name: "server"},
+			"admin":  {Name: "admin"},
+		},
+	}}
+	result := s.exportBundle(c)
+	c.Assert(result.Error, gc.IsNil)
+
+	parts := strings.Split(result.Result, "--- # overlay.yaml\n")
+	c.Assert(parts, gc.HasLen, 2)
+	_, err := charm.ReadBundleData(strings.NewReader(parts[0]))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parts[1], gc.Equals, `
+applications:
+  mysql:
+    offers:
+      hosted-mysql:
+        endpoints:
+        - admin
+        - server
+`[1:])
+}
+
+func (s *exportSuite) TestExportBundleNoApplications(c *gc.C) {
+	s.backend.applications = nil
+	result := s.exportBundle(c)
+	c.Assert(result.Error, gc.ErrorMatches, "nothing to export as there are no applications")
+}
+
+func (s *exportSuite) TestExportBundleNestedContainer(c *gc.C) {
+	s.backend.machines = append(s.backend.machines,
+		&mockMachine{id: "1/lxd/0/lxd/0", series: "xenial"},
+	)
+	s.backend.applications = []bundle.Application{
+		&mockApplication{
+			name:     "mysql",
+			series:   "xenial",
+			charmURL: "cs:xenial/mysql-42",
+			units: []bundle.Unit{
+				&mockUnit{name: "mysql/0", machineId: "1/lxd/0/lxd/0"},
+			},
+		},
+	}
+	result := s.exportBundle(c)
+	c.Assert(result.Error, gc.ErrorMatches,
+		`exporting application "mysql": unit "mysql/0": placement in nested container 1/lxd/0/lxd/0 not supported`)
+}
+
+func (s *exportSuite) TestExportBundlePermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	api, err := bundle.NewBundleV2(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *exportSuite) planBundle(c *gc.C, bundleYAML string, mapMachines bool) params.BundlePlanResults {
+	api, err := bundle.NewBundleV3(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.PlanBundle(params.BundlePlanParams{
+		BundleDataYAML: bundleYAML,
+		MapMachines:    mapMachines,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return result
+}
+
+const planBundleYAML = `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-42
+    num_units: 3
+    to: ["0", "5", "new"]
+    options:
+      dataset-size: 80%
+      max-connections: 500
+  wordpress:
+    charm: cs:wordpress
+    num_units: 1
+  haproxy:
+    charm: cs:xenial/haproxy-3
+    num_units: 2
+  logging:
+    charm: cs:logging-2
+machines:
+  "0": {}
+  "5": {}
+relations:
+- [wordpress:db, mysql:server]
+- [wordpress, logging]
+- [mysql, logging]
+- [haproxy:reverseproxy, wordpress:website]
+`
+
+func (s *exportSuite) TestPlanBundle(c *gc.C) {
+	result := s.planBundle(c, planBundleYAML, false)
+	c.Assert(result.Errors, gc.HasLen, 0)
+	c.Assert(result.Plan, jc.DeepEquals, &params.BundlePlan{
+		AddApplications: []params.BundlePlanApplication{{
+			Name:     "haproxy",
+			Charm:    "cs:xenial/haproxy-3",
+			NumUnits: 2,
+		}},
+		ChangeCharms: []params.BundlePlanCharmChange{{
+			Application:  "logging",
+			CurrentCharm: "cs:logging-1",
+			BundleCharm:  "cs:logging-2",
+		}},
+		ChangeConfig: []params.BundlePlanConfigChange{{
+			Application: "mysql",
+			Key:         "max-connections",
+			Bundle:      500,
+		}},
+		AddUnits: []params.BundlePlanUnits{{
+			Application: "mysql",
+			Count:       1,
+		}},
+		AddRelations: [][]string{
+			{"mysql", "logging"},
+			{"haproxy:reverseproxy", "wordpress:website"},
+		},
+		AddMachines: []string{"0", "5"},
+	})
+}
+
+func (s *exportSuite) TestPlanBundleMapMachines(c *gc.C) {
+	result := s.planBundle(c, planBundleYAML, true)
+	c.Assert(result.Errors, gc.HasLen, 0)
+	// Bundle machine 0 is mapped to the model's machine 0.
+	c.Assert(result.Plan.AddMachines, jc.DeepEquals, []string{"5"})
+}
+
+func (s *exportSuite) TestPlanBundleExported(c *gc.C) {
+	exported := s.exportBundle(c)
+	c.Assert(exported.Error, gc.IsNil)
+	result := s.planBundle(c, exported.Result, true)
+	c.Assert(result.Errors, gc.HasLen, 0)
+	c.Assert(result.Plan, jc.DeepEquals, &params.BundlePlan{})
+}
+
+func (s *exportSuite) TestPlanBundleVerificationErrors(c *gc.C) {
+	result := s.planBundle(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-42
+relations:
+- [mysql:server, wordpress:db]
+`, false)
+	c.Assert(result.Plan, gc.IsNil)
+	c.Assert(result.Errors, gc.Not(gc.HasLen), 0)
+}
+
+func (s *exportSuite) TestPlanBundlePermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	api, err := bundle.NewBundleV3(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.PlanBundle(params.BundlePlanParams{BundleDataYAML: "applications: {}"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,227 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/constraints"
+)
+
+// offersOverlay is the overlay appended to an exported bundle to
+// record the model's application offers, which cannot be expressed
+// in the bundle itself.
+type offersOverlay struct {
+	Applications map[string]*offersOverlayApplication `yaml:"applications"`
+}
+
+type offersOverlayApplication struct {
+	Offers map[string]*offerSpec `yaml:"offers"`
+}
+
+type offerSpec struct {
+	Endpoints []string `yaml:"endpoints"`
+}
+
+// exportBundle returns the YAML for a bundle that would deploy the
+// applications, relations and machines in the model. If the model
+// has any application offers, they are described in an overlay
+// following the bundle in the same YAML stream.
+func exportBundle(backend Backend) (string, error) {
+	apps, err := backend.AllApplications()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(apps) == 0 {
+		return "", errors.New("nothing to export as there are no applications")
+	}
+	allMachines, err := backend.AllMachines()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	machines := make(map[string]Machine)
+	for _, m := range allMachines {
+		machines[m.Id()] = m
+	}
+
+	data := &charm.BundleData{
+		Applications: make(map[string]*charm.ApplicationSpec),
+	}
+	usedMachines := set.NewStrings()
+	for _, app := range apps {
+		spec, hosts, err := applicationSpec(app, machines)
+		if err != nil {
+			return "", errors.Annotatef(err, "exporting application %q", app.Name())
+		}
+		data.Applications[app.Name()] = spec
+		usedMachines = usedMachines.Union(hosts)
+	}
+	for _, id := range usedMachines.Values() {
+		m := machines[id]
+		spec := &charm.MachineSpec{Series: m.Series()}
+		cons, err := m.Constraints()
+		if err != nil && !errors.IsNotFound(err) {
+			return "", errors.Annotatef(err, "exporting machine %s", id)
+		}
+		if !constraints.IsEmpty(&cons) {
+			spec.Constraints = cons.String()
+		}
+		if data.Machines == nil {
+			data.Machines = make(map[string]*charm.MachineSpec)
+		}
+		data.Machines[id] = spec
+	}
+
+	relations, err := backend.AllRelations()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, rel := range relations {
+		eps := rel.Endpoints()
+		if len(eps) != 2 {
+			// Peer relations are established when the
+			// application is deployed.
+			continue
+		}
+		if data.Applications[eps[0].ApplicationName] == nil || data.Applications[eps[1].ApplicationName] == nil {
+			// Relations to remote applications cannot be
+			// expressed in a bundle.
+			continue
+		}
+		data.Relations = append(data.Relations, []string{eps[0].String(), eps[1].String()})
+	}
+	sort.Slice(data.Relations, func(i, j int) bool {
+		return strings.Join(data.Relations[i], " ") < strings.Join(data.Relations[j], " ")
+	})
+
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	overlay, err := exportOffers(backend)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(out) + overlay, nil
+}
+
+// applicationSpec returns the bundle spec for the application, and
+// the ids of the top level machines hosting its units.
+func applicationSpec(app Application, machines map[string]Machine) (*charm.ApplicationSpec, set.Strings, error) {
+	curl, _ := app.CharmURL()
+	spec := &charm.ApplicationSpec{
+		Charm:  curl.String(),
+		Series: app.Series(),
+		Expose: app.IsExposed(),
+	}
+	options, err := app.ConfigSettings()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if len(options) > 0 {
+		spec.Options = options
+	}
+	cons, err := app.Constraints()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, nil, errors.Trace(err)
+	}
+	if !constraints.IsEmpty(&cons) {
+		spec.Constraints = cons.String()
+	}
+
+	hosts := set.NewStrings()
+	if !app.IsPrincipal() {
+		// Subordinate units are placed with their principals.
+		return spec, hosts, nil
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return unitNumber(units[i].Name()) < unitNumber(units[j].Name())
+	})
+	spec.NumUnits = len(units)
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		placement, host, err := unitPlacement(machineId, machines)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "unit %q", unit.Name())
+		}
+		spec.To = append(spec.To, placement)
+		hosts.Add(host)
+	}
+	return spec, hosts, nil
+}
+
+// unitPlacement returns the bundle placement directive for a unit on
+// the machine with the given id, and the id of the top level machine
+// that must be declared in the bundle to host it.
+func unitPlacement(machineId string, machines map[string]Machine) (placement, host string, _ error) {
+	m, ok := machines[machineId]
+	if !ok {
+		return "", "", errors.NotFoundf("machine %s", machineId)
+	}
+	parentId, isContainer := m.ParentId()
+	if !isContainer {
+		return machineId, machineId, nil
+	}
+	if parent, ok := machines[parentId]; !ok {
+		return "", "", errors.NotFoundf("machine %s", parentId)
+	} else if _, nested := parent.ParentId(); nested {
+		return "", "", errors.NotSupportedf("placement in nested container %s", machineId)
+	}
+	return fmt.Sprintf("%s:%s", m.ContainerType(), parentId), parentId, nil
+}
+
+func unitNumber(unitName string) int {
+	n, _ := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	return n
+}
+
+// exportOffers returns the overlay describing the model's application
+// offers, or an empty string if there are none.
+func exportOffers(backend Backend) (string, error) {
+	offers, err := backend.AllOffers()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(offers) == 0 {
+		return "", nil
+	}
+	overlay := offersOverlay{
+		Applications: make(map[string]*offersOverlayApplication),
+	}
+	for _, offer := range offers {
+		app, ok := overlay.Applications[offer.ApplicationName]
+		if !ok {
+			app = &offersOverlayApplication{Offers: make(map[string]*offerSpec)}
+			overlay.Applications[offer.ApplicationName] = app
+		}
+		spec := &offerSpec{}
+		for endpoint := range offer.Endpoints {
+			spec.Endpoints = append(spec.Endpoints, endpoint)
+		}
+		sort.Strings(spec.Endpoints)
+		app.Offers[offer.OfferName] = spec
+	}
+	out, err := yaml.Marshal(overlay)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return "--- # overlay.yaml\n" + string(out), nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/bundle"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	applications []bundle.Application
+	machines     []bundle.Machine
+	relations    []bundle.Relation
+	offers       []crossmodel.ApplicationOffer
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (m *mockBackend) AllApplications() ([]bundle.Application, error) {
+	return m.applications, nil
+}
+
+func (m *mockBackend) AllMachines() ([]bundle.Machine, error) {
+	return m.machines, nil
+}
+
+func (m *mockBackend) AllRelations() ([]bundle.Relation, error) {
+	return m.relations, nil
+}
+
+func (m *mockBackend) AllOffers() ([]crossmodel.ApplicationOffer, error) {
+	return m.offers, nil
+}
+
+type mockApplication struct {
+	name        string
+	series      string
+	charmURL    string
+	subordinate bool
+	exposed     bool
+	config      charm.Settings
+	constraints string
+	units       []bundle.Unit
+}
+
+func (m *mockApplication) Name() string {
+	return m.name
+}
+
+func (m *mockApplication) Series() string {
+	return m.series
+}
+
+func (m *mockApplication) CharmURL() (*charm.URL, bool) {
+	return charm.MustParseURL(m.charmURL), false
+}
+
+func (m *mockApplication) IsPrincipal() bool {
+	return !m.subordinate
+}
+
+func (m *mockApplication) IsExposed() bool {
+	return m.exposed
+}
+
+func (m *mockApplication) ConfigSettings() (charm.Settings, error) {
+	return m.config, nil
+}
+
+func (m *mockApplication) Constraints() (constraints.Value, error) {
+	if m.constraints == "" {
+		return constraints.Value{}, errors.NotFoundf("constraints")
+	}
+	return constraints.MustParse(m.constraints), nil
+}
+
+func (m *mockApplication) AllUnits() ([]bundle.Unit, error) {
+	return m.units, nil
+}
+
+type mockUnit struct {
+	name      string
+	machineId string
+}
+
+func (m *mockUnit) Name() string {
+	return m.name
+}
+
+func (m *mockUnit) AssignedMachineId() (string, error) {
+	if m.machineId == "" {
+		return "", errors.NotAssignedf("unit %q", m.name)
+	}
+	return m.machineId, nil
+}
+
+type mockMachine struct {
+	id          string
+	series      string
+	constraints string
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Series() string {
+	return m.series
+}
+
+func (m *mockMachine) Constraints() (constraints.Value, error) {
+	return constraints.MustParse(m.constraints), nil
+}
+
+func (m *mockMachine) ContainerType() instance.ContainerType {
+	return state.ContainerTypeFromId(m.id)
+}
+
+func (m *mockMachine) ParentId() (string, bool) {
+	parentId := state.ParentId(m.id)
+	return parentId, parentId != ""
+}
+
+type mockRelation struct {
+	endpoints []state.Endpoint
+}
+
+func (m *mockRelation) Endpoints() []state.Endpoint {
+	return m.endpoints
+}
+
+func newMockRelation(keys ...string) *mockRelation {
+	rel := &mockRelation{}
+	for _, key := range keys {
+		parts := strings.SplitN(key, ":", 2)
+		rel.endpoints = append(rel.endpoints, state.Endpoint{
+			ApplicationName: parts[0],
+			Relation:        charm.Relation{Name: parts[1]},
+		})
+	}
+	return rel
+}
@@ -0,0 +1,182 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// planBundle returns the changes that deploying the bundle would make
+// to the model. If mapMachines is true, bundle machines are matched to
+// model machines by id, as they are when deploying with existing
+// machines mapped; otherwise, all bundle machines are new.
+func planBundle(backend Backend, data *charm.BundleData, mapMachines bool) (*params.BundlePlan, error) {
+	apps, err := backend.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	existing := make(map[string]Application)
+	for _, app := range apps {
+		existing[app.Name()] = app
+	}
+
+	plan := &params.BundlePlan{}
+	for _, name := range sortedKeys(data.Applications) {
+		spec := data.Applications[name]
+		app, ok := existing[name]
+		if !ok {
+			plan.AddApplications = append(plan.AddApplications, params.BundlePlanApplication{
+				Name:     name,
+				Charm:    spec.Charm,
+				NumUnits: spec.NumUnits,
+			})
+			continue
+		}
+		if err := planApplication(plan, app, spec); err != nil {
+			return nil, errors.Annotatef(err, "planning application %q", name)
+		}
+	}
+
+	relations, err := backend.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, pair := range data.Relations {
+		if !relationExists(relations, pair) {
+			plan.AddRelations = append(plan.AddRelations, pair)
+		}
+	}
+
+	machineIds := make(map[string]bool)
+	if mapMachines {
+		machines, err := backend.AllMachines()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, m := range machines {
+			machineIds[m.Id()] = true
+		}
+	}
+	for id := range data.Machines {
+		if !machineIds[id] {
+			plan.AddMachines = append(plan.AddMachines, id)
+		}
+	}
+	sort.Strings(plan.AddMachines)
+	return plan, nil
+}
+
+// planApplication adds the changes the bundle spec would make to the
+// existing application to the plan.
+func planApplication(plan *params.BundlePlan, app Application, spec *charm.ApplicationSpec) error {
+	curl, _ := app.CharmURL()
+	if !charmMatches(curl, spec) {
+		plan.ChangeCharms = append(plan.ChangeCharms, params.BundlePlanCharmChange{
+			Application:  app.Name(),
+			CurrentCharm: curl.String(),
+			BundleCharm:  spec.Charm,
+		})
+	}
+
+	settings, err := app.ConfigSettings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	keys := make([]string, 0, len(spec.Options))
+	for key := range spec.Options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		current, proposed := settings[key], spec.Options[key]
+		// The bundle values are decoded from YAML, so they may not
+		// have the same types as the stored values.
+		if current != nil && fmt.Sprint(current) == fmt.Sprint(proposed) {
+			continue
+		}
+		plan.ChangeConfig = append(plan.ChangeConfig, params.BundlePlanConfigChange{
+			Application: app.Name(),
+			Key:         key,
+			Current:     current,
+			Bundle:      proposed,
+		})
+	}
+
+	if !app.IsPrincipal() {
+		return nil
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if count := spec.NumUnits - len(units); count > 0 {
+		plan.AddUnits = append(plan.AddUnits, params.BundlePlanUnits{
+			Application: app.Name(),
+			Count:       count,
+		})
+	}
+	return nil
+}
+
+// charmMatches returns whether the bundle spec's charm is the charm
+// with the given URL. The bundle may omit the series and revision, in
+// which case any series or revision matches. Local charm paths cannot
+// be compared, and always match.
+func charmMatches(curl *charm.URL, spec *charm.ApplicationSpec) bool {
+	if strings.HasPrefix(spec.Charm, ".") || strings.HasPrefix(spec.Charm, "/") {
+		return true
+	}
+	bundleURL, err := charm.ParseURL(spec.Charm)
+	if err != nil {
+		return false
+	}
+	if bundleURL.Series == "" {
+		bundleURL.Series = curl.Series
+	}
+	if bundleURL.Revision < 0 {
+		bundleURL = bundleURL.WithRevision(curl.Revision)
+	}
+	return *bundleURL == *curl
+}
+
+// relationExists returns whether any of the relations joins the
+// endpoints in the pair. The bundle may omit the relation names.
+func relationExists(relations []Relation, pair []string) bool {
+	for _, rel := range relations {
+		eps := rel.Endpoints()
+		if len(eps) != 2 {
+			continue
+		}
+		if endpointMatches(eps[0], pair[0]) && endpointMatches(eps[1], pair[1]) ||
+			endpointMatches(eps[0], pair[1]) && endpointMatches(eps[1], pair[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+func endpointMatches(ep state.Endpoint, bundleEndpoint string) bool {
+	parts := strings.SplitN(bundleEndpoint, ":", 2)
+	if ep.ApplicationName != parts[0] {
+		return false
+	}
+	return len(parts) == 1 || ep.Name == parts[1]
+}
+
+func sortedKeys(apps map[string]*charm.ApplicationSpec) []string {
+	names := make([]string, 0, len(apps))
+	for name := range apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
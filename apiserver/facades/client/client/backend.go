@@ -54,6 +54,7 @@ type Backend interface {
 	ModelConfig() (*config.Config, error)
 	ModelConfigValues() (config.ConfigValues, error)
 	ModelConstraints() (constraints.Value, error)
+	ModelPayloads() (state.ModelPayloads, error)
 	ModelTag() names.ModelTag
 	ModelUUID() string
 	RemoteApplication(string) (*state.RemoteApplication, error)
@@ -87,6 +88,7 @@ type Unit interface {
 	PrivateAddress() (network.Address, error)
 	Resolve(retryHooks bool) error
 	AgentHistory() status.StatusHistoryGetter
+	PayloadHistory() status.StatusHistoryGetter
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
@@ -68,6 +68,11 @@ type Client struct {
 	check      *common.BlockChecker
 }
 
+// ClientV1 serves the v1 Client API, which lacks FullStatusDelta.
+type ClientV1 struct {
+	*Client
+}
+
 func (c *Client) checkCanRead() error {
 	isAdmin, err := c.api.auth.HasPermission(permission.SuperuserAccess, c.api.stateAccessor.ControllerTag())
 	if err != nil {
@@ -116,6 +121,16 @@ func (c *Client) checkIsAdmin() error {
 	return nil
 }
 
+// NewFacadeV1 provides the required signature for registration of
+// version 1 of the facade.
+func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
+	client, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV1{client}, nil
+}
+
 // NewFacade provides the required signature for facade registration.
 func NewFacade(ctx facade.Context) (*Client, error) {
 	st := ctx.State()
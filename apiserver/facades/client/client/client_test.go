@@ -1429,6 +1429,25 @@ func (s *clientRepoSuite) TestResolveCharm(c *gc.C) {
 	}
 }
 
+func (s *clientRepoSuite) TestResolveCharmWithChannel(c *gc.C) {
+	s.UploadCharm(c, "trusty/wordpress-2", "wordpress")
+	client := s.APIState.Client()
+
+	for _, channel := range []string{"stable", "latest/stable", "latest"} {
+		curl, err := client.ResolveCharmWithChannel(charm.MustParseURL("cs:wordpress"), channel)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(curl.String(), gc.Equals, "cs:trusty/wordpress")
+	}
+}
+
+func (s *clientRepoSuite) TestResolveCharmWithBadChannel(c *gc.C) {
+	client := s.APIState.Client()
+
+	curl, err := client.ResolveCharmWithChannel(charm.MustParseURL("cs:wordpress"), "8.0/risky")
+	c.Check(err, gc.ErrorMatches, `risk "risky" in channel "8.0/risky" not valid`)
+	c.Check(curl, gc.IsNil)
+}
+
 func (s *clientSuite) TestRetryProvisioning(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -17,6 +17,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/payload"
+	payloadapi "github.com/juju/juju/payload/api"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/status"
@@ -71,6 +73,13 @@ func (c *Client) unitStatusHistory(unitTag names.UnitTag, filter status.StatusHi
 		}
 		statuses = append(statuses, agentStatusFromStatusInfo(agentStatuses, status.KindUnitAgent)...)
 	}
+	if kind == status.KindPayload {
+		payloadStatuses, err := unit.PayloadHistory().StatusHistory(filter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		statuses = agentStatusFromStatusInfo(payloadStatuses, status.KindPayload)
+	}
 
 	sort.Sort(byTime(statuses))
 	if kind == status.KindUnit && filter.Size > 0 {
@@ -137,7 +146,7 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 		kind := status.HistoryKind(request.Kind)
 		err = errors.NotValidf("%q requires a unit, got %T", kind, request.Tag)
 		switch kind {
-		case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
+		case status.KindUnit, status.KindWorkload, status.KindUnitAgent, status.KindPayload:
 			var u names.UnitTag
 			if u, err = names.ParseUnitTag(request.Tag); err == nil {
 				hist, err = c.unitStatusHistory(u, filter, kind)
@@ -208,6 +217,9 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 			return noStatus, errors.Annotate(err, " could not fetch leaders")
 		}
 	}
+	if context.payloads, err = fetchPayloads(c.api.stateAccessor); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch payloads")
+	}
 
 	logger.Debugf("Applications: %v", context.applications)
 	logger.Debugf("Remote applications: %v", context.consumerRemoteApplications)
@@ -393,6 +405,9 @@ type statusContext struct {
 	units         map[string]map[string]*state.Unit
 	latestCharms  map[charm.URL]*state.Charm
 	leaders       map[string]string
+
+	// payloads: unit name -> payloads registered by the unit
+	payloads map[string][]payload.FullPayloadInfo
 }
 
 // fetchMachines returns a map from top level machine id to machines, where machines[0] is the host
@@ -615,6 +630,27 @@ func fetchOffers(st Backend, applications map[string]*state.Application) (map[st
 	return offersMap, nil
 }
 
+// fetchPayloads returns a map from unit name to the payloads
+// registered by that unit, ordered by payload name and ID.
+func fetchPayloads(st Backend) (map[string][]payload.FullPayloadInfo, error) {
+	payloads, err := st.ModelPayloads()
+	if err != nil {
+		return nil, err
+	}
+	all, err := payloads.ListAll()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].FullID() < all[j].FullID()
+	})
+	out := make(map[string][]payload.FullPayloadInfo)
+	for _, p := range all {
+		out[p.Unit] = append(out[p.Unit], p)
+	}
+	return out, nil
+}
+
 // fetchRelations returns a map of all relations keyed by application name,
 // and another map keyed by id..
 //
@@ -1068,6 +1104,10 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 
 	result.AgentStatus, result.WorkloadStatus = context.processUnitAndAgentStatus(unit)
 
+	for _, p := range context.payloads[unit.Name()] {
+		result.Payloads = append(result.Payloads, payloadapi.Payload2api(p))
+	}
+
 	if subUnits := unit.SubordinateNames(); len(subUnits) > 0 {
 		result.Subordinates = make(map[string]params.UnitStatus)
 		for _, name := range subUnits {
@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing/factory"
 )
@@ -73,6 +75,43 @@ func (s *statusSuite) TestFullStatusUnitLeadership(c *gc.C) {
 	c.Assert(unit.Leader, jc.IsTrue)
 }
 
+func (s *statusSuite) TestFullStatusUnitPayloads(c *gc.C) {
+	u := s.Factory.MakeUnit(c, nil)
+	payloads, err := s.State.UnitPayloads(u)
+	c.Assert(err, jc.ErrorIsNil)
+	err = payloads.Track(payload.Payload{
+		PayloadClass: charm.PayloadClass{
+			Name: "database",
+			Type: "docker",
+		},
+		ID:     "abc123",
+		Status: payload.StateStopped,
+		Labels: []string{"primary"},
+		Unit:   u.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = payloads.SetStatus("database", payload.StateRunning)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, ok := status.Applications[u.ApplicationName()].Units[u.Name()]
+	c.Assert(ok, jc.IsTrue)
+	machineID, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.Payloads, jc.DeepEquals, []params.Payload{{
+		Class:    "database",
+		Type:     "docker",
+		ID:       "abc123",
+		Status:   payload.StateRunning,
+		Labels:   []string{"primary"},
+		Unit:     u.UnitTag().String(),
+		Machine:  names.NewMachineTag(machineID).String(),
+		Restarts: 1,
+	}})
+}
+
 var _ = gc.Suite(&statusUnitTestSuite{})
 
 type statusUnitTestSuite struct {
@@ -0,0 +1,232 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// statusEntity identifies one of the entities in a FullStatus.
+type statusEntity struct {
+	kind string
+	id   string
+}
+
+const (
+	modelStatusEntity             = "model"
+	machineStatusEntity           = "machine"
+	applicationStatusEntity       = "application"
+	remoteApplicationStatusEntity = "remote-application"
+	offerStatusEntity             = "offer"
+	relationStatusEntity          = "relation"
+)
+
+// statusSnapshot records the hash of each entity in the status last
+// returned by FullStatusDelta, so that the following call on the same
+// connection can return only the entities that have changed. It is
+// registered as a connection resource, and goes with the connection.
+type statusSnapshot struct {
+	mu      sync.Mutex
+	version string
+	hashes  map[statusEntity]string
+}
+
+// Stop is part of the facade.Resource interface.
+func (*statusSnapshot) Stop() error {
+	return nil
+}
+
+// FullStatusDelta returns the changes to the model's status since the
+// version previously returned to the caller on this connection. If
+// that version is not known, for example because the caller has
+// reconnected, the full status is returned.
+func (c *Client) FullStatusDelta(args params.StatusDeltaParams) (params.FullStatusDelta, error) {
+	status, err := c.FullStatus(params.StatusParams{Patterns: args.Patterns})
+	if err != nil {
+		return params.FullStatusDelta{}, errors.Trace(err)
+	}
+	hashes, err := statusHashes(status)
+	if err != nil {
+		return params.FullStatusDelta{}, errors.Annotate(err, "cannot hash status")
+	}
+	version := statusVersion(hashes)
+
+	id, snapshot, previous := c.statusSnapshot(args.Since)
+	delta := makeStatusDelta(status, hashes, previous)
+	delta.Version = id + ":" + version
+
+	snapshot.mu.Lock()
+	snapshot.version = version
+	snapshot.hashes = hashes
+	snapshot.mu.Unlock()
+	return delta, nil
+}
+
+// FullStatusDelta is not available in versions of the API before 2.
+func (c *ClientV1) FullStatusDelta(_, _ struct{}) {}
+
+// statusSnapshot returns the snapshot of the status identified by
+// since, along with its resource id and the hashes it held, if they
+// are known. Otherwise, a new snapshot is registered and nil hashes
+// are returned.
+func (c *Client) statusSnapshot(since string) (string, *statusSnapshot, map[statusEntity]string) {
+	if parts := strings.SplitN(since, ":", 2); len(parts) == 2 {
+		if snapshot, ok := c.api.resources.Get(parts[0]).(*statusSnapshot); ok {
+			snapshot.mu.Lock()
+			defer snapshot.mu.Unlock()
+			if snapshot.version == parts[1] {
+				return parts[0], snapshot, snapshot.hashes
+			}
+			return parts[0], snapshot, nil
+		}
+	}
+	snapshot := &statusSnapshot{}
+	return c.api.resources.Register(snapshot), snapshot, nil
+}
+
+// statusHashes returns the hash of each of the entities in the status.
+func statusHashes(status params.FullStatus) (map[statusEntity]string, error) {
+	hashes := make(map[statusEntity]string)
+	add := func(kind, id string, v interface{}) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sum := sha256.Sum256(data)
+		hashes[statusEntity{kind, id}] = hex.EncodeToString(sum[:])
+		return nil
+	}
+	if err := add(modelStatusEntity, "", status.Model); err != nil {
+		return nil, err
+	}
+	for id, m := range status.Machines {
+		if err := add(machineStatusEntity, id, m); err != nil {
+			return nil, err
+		}
+	}
+	for name, app := range status.Applications {
+		if err := add(applicationStatusEntity, name, app); err != nil {
+			return nil, err
+		}
+	}
+	for name, app := range status.RemoteApplications {
+		if err := add(remoteApplicationStatusEntity, name, app); err != nil {
+			return nil, err
+		}
+	}
+	for name, offer := range status.Offers {
+		if err := add(offerStatusEntity, name, offer); err != nil {
+			return nil, err
+		}
+	}
+	for _, rel := range status.Relations {
+		if err := add(relationStatusEntity, strconv.Itoa(rel.Id), rel); err != nil {
+			return nil, err
+		}
+	}
+	return hashes, nil
+}
+
+// statusVersion returns a version identifying the status with the
+// given entity hashes.
+func statusVersion(hashes map[statusEntity]string) string {
+	lines := make([]string, 0, len(hashes))
+	for e, hash := range hashes {
+		lines = append(lines, fmt.Sprintf("%s %s %s\n", e.kind, e.id, hash))
+	}
+	sort.Strings(lines)
+	h := sha256.New()
+	for _, line := range lines {
+		h.Write([]byte(line))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// makeStatusDelta returns the delta between the status whose entities
+// had the previous hashes and the given status. If previous is nil,
+// the delta holds the full status.
+func makeStatusDelta(status params.FullStatus, hashes, previous map[statusEntity]string) params.FullStatusDelta {
+	delta := params.FullStatusDelta{Full: previous == nil}
+	changed := func(kind, id string) bool {
+		e := statusEntity{kind, id}
+		return previous == nil || previous[e] != hashes[e]
+	}
+	if changed(modelStatusEntity, "") {
+		model := status.Model
+		delta.Model = &model
+	}
+	for id, m := range status.Machines {
+		if changed(machineStatusEntity, id) {
+			if delta.Machines == nil {
+				delta.Machines = make(map[string]params.MachineStatus)
+			}
+			delta.Machines[id] = m
+		}
+	}
+	for name, app := range status.Applications {
+		if changed(applicationStatusEntity, name) {
+			if delta.Applications == nil {
+				delta.Applications = make(map[string]params.ApplicationStatus)
+			}
+			delta.Applications[name] = app
+		}
+	}
+	for name, app := range status.RemoteApplications {
+		if changed(remoteApplicationStatusEntity, name) {
+			if delta.RemoteApplications == nil {
+				delta.RemoteApplications = make(map[string]params.RemoteApplicationStatus)
+			}
+			delta.RemoteApplications[name] = app
+		}
+	}
+	for name, offer := range status.Offers {
+		if changed(offerStatusEntity, name) {
+			if delta.Offers == nil {
+				delta.Offers = make(map[string]params.ApplicationOfferStatus)
+			}
+			delta.Offers[name] = offer
+		}
+	}
+	for _, rel := range status.Relations {
+		if changed(relationStatusEntity, strconv.Itoa(rel.Id)) {
+			delta.Relations = append(delta.Relations, rel)
+		}
+	}
+
+	for e := range previous {
+		if _, ok := hashes[e]; ok {
+			continue
+		}
+		switch e.kind {
+		case machineStatusEntity:
+			delta.RemovedMachines = append(delta.RemovedMachines, e.id)
+		case applicationStatusEntity:
+			delta.RemovedApplications = append(delta.RemovedApplications, e.id)
+		case remoteApplicationStatusEntity:
+			delta.RemovedRemoteApplications = append(delta.RemovedRemoteApplications, e.id)
+		case offerStatusEntity:
+			delta.RemovedOffers = append(delta.RemovedOffers, e.id)
+		case relationStatusEntity:
+			id, _ := strconv.Atoi(e.id)
+			delta.RemovedRelations = append(delta.RemovedRelations, id)
+		}
+	}
+	sort.Strings(delta.RemovedMachines)
+	sort.Strings(delta.RemovedApplications)
+	sort.Strings(delta.RemovedRemoteApplications)
+	sort.Strings(delta.RemovedOffers)
+	sort.Ints(delta.RemovedRelations)
+	return delta
+}
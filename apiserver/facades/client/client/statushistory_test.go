@@ -220,10 +220,41 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestStatusHistoryPayloads(c *gc.C) {
+	s.st.agentHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status: status.Idle,
+		},
+	})
+	s.st.payloadHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  "running",
+			Message: "web",
+			Data:    map[string]interface{}{"restarts": 1},
+		},
+		{
+			Status:  "stopped",
+			Message: "web",
+		},
+	})
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "unit-unit-0",
+			Kind:   status.KindPayload.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.IsNil)
+	checkStatusInfo(c, h.Results[0].History.Statuses, reverseStatusInfo(s.st.payloadHistory))
+	c.Assert(h.Results[0].History.Statuses[1].Kind, gc.Equals, status.KindPayload.String())
+	c.Assert(h.Results[0].History.Statuses[1].Data, jc.DeepEquals, map[string]interface{}{"restarts": 1})
+}
+
 type mockState struct {
 	client.Backend
-	unitHistory  []status.StatusInfo
-	agentHistory []status.StatusInfo
+	unitHistory    []status.StatusInfo
+	agentHistory   []status.StatusInfo
+	payloadHistory []status.StatusInfo
 }
 
 func (m *mockState) ModelUUID() string {
@@ -243,14 +274,16 @@ func (m *mockState) Unit(name string) (client.Unit, error) {
 		return nil, errors.NotFoundf("%v", name)
 	}
 	return &mockUnit{
-		status: m.unitHistory,
-		agent:  &mockUnitAgent{m.agentHistory},
+		status:  m.unitHistory,
+		agent:   &mockUnitAgent{m.agentHistory},
+		payload: statuses(m.payloadHistory),
 	}, nil
 }
 
 type mockUnit struct {
-	status statuses
-	agent  *mockUnitAgent
+	status  statuses
+	agent   *mockUnitAgent
+	payload statuses
 	client.Unit
 }
 
@@ -262,6 +295,10 @@ func (m *mockUnit) AgentHistory() status.StatusHistoryGetter {
 	return m.agent
 }
 
+func (m *mockUnit) PayloadHistory() status.StatusHistoryGetter {
+	return m.payload
+}
+
 type mockUnitAgent struct {
 	statuses
 }
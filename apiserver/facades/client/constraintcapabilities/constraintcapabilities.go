@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package constraintcapabilities provides the ConstraintCapabilities
+// facade, which describes the constraints supported by a model's
+// provider so that clients may validate constraints before submitting
+// them.
+package constraintcapabilities
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// constraintcapabilities facade. For details on the methods, see the
+// methods on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ConstraintCapabilities() ([]constraints.AttributeCapability, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+// API provides the ConstraintCapabilities API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new ConstraintCapabilities API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ConstraintCapabilities describes, for each constraint attribute,
+// whether the model's provider supports it, which attributes it
+// conflicts with, and the values allowed for it.
+func (api *API) ConstraintCapabilities() (params.ConstraintCapabilitiesResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ConstraintCapabilitiesResult{}, errors.Trace(err)
+	}
+	capabilities, err := api.backend.ConstraintCapabilities()
+	if err != nil {
+		return params.ConstraintCapabilitiesResult{}, errors.Trace(err)
+	}
+	result := params.ConstraintCapabilitiesResult{
+		Capabilities: make([]params.ConstraintCapability, len(capabilities)),
+	}
+	for i, c := range capabilities {
+		result.Capabilities[i] = params.ConstraintCapability{
+			Name:      c.Name,
+			Supported: c.Supported,
+			Strict:    c.Strict,
+			Conflicts: c.Conflicts,
+			Values:    c.Values,
+		}
+	}
+	return result, nil
+}
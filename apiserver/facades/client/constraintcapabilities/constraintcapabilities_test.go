@@ -0,0 +1,100 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package constraintcapabilities_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/constraintcapabilities"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConstraintCapabilitiesSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&ConstraintCapabilitiesSuite{})
+
+func (s *ConstraintCapabilitiesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+	s.backend = &mockBackend{}
+}
+
+func (s *ConstraintCapabilitiesSuite) TestNewAPINonClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := constraintcapabilities.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ConstraintCapabilitiesSuite) TestConstraintCapabilities(c *gc.C) {
+	s.backend.capabilities = []constraints.AttributeCapability{{
+		Name:      "arch",
+		Supported: true,
+		Conflicts: []string{"instance-type"},
+		Values:    []string{"amd64", "ppc64el"},
+	}, {
+		Name:   "image-id",
+		Strict: true,
+	}}
+	api, err := constraintcapabilities.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.ConstraintCapabilities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ConstraintCapabilitiesResult{
+		Capabilities: []params.ConstraintCapability{{
+			Name:      "arch",
+			Supported: true,
+			Conflicts: []string{"instance-type"},
+			Values:    []string{"amd64", "ppc64el"},
+		}, {
+			Name:   "image-id",
+			Strict: true,
+		}},
+	})
+}
+
+func (s *ConstraintCapabilitiesSuite) TestConstraintCapabilitiesError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	api, err := constraintcapabilities.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.ConstraintCapabilities()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ConstraintCapabilitiesSuite) TestConstraintCapabilitiesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	api, err := constraintcapabilities.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.ConstraintCapabilities()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	capabilities []constraints.AttributeCapability
+	err          error
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (m *mockBackend) ConstraintCapabilities() ([]constraints.AttributeCapability, error) {
+	return m.capabilities, m.err
+}
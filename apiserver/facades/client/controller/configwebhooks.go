@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddConfigWebhooks registers webhooks with the controller, which are
+// consulted before model config and application config changes are
+// committed in any of its models. Only controller admins may register
+// webhooks.
+func (c *ControllerAPI) AddConfigWebhooks(args params.ConfigWebhooks) (params.ErrorResults, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Webhooks)),
+	}
+	for i, arg := range args.Webhooks {
+		kinds := make([]state.ConfigWebhookKind, len(arg.Kinds))
+		for j, kind := range arg.Kinds {
+			kinds[j] = state.ConfigWebhookKind(kind)
+		}
+		err := c.state.AddConfigWebhook(state.ConfigWebhook{
+			Name:   arg.Name,
+			URL:    arg.URL,
+			CACert: arg.CACert,
+			Kinds:  kinds,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveConfigWebhooks removes the named webhooks from the controller.
+func (c *ControllerAPI) RemoveConfigWebhooks(args params.ConfigWebhookNames) (params.ErrorResults, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		err := c.state.RemoveConfigWebhook(name)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ConfigWebhooks returns the webhooks registered with the controller.
+func (c *ControllerAPI) ConfigWebhooks() (params.ConfigWebhooks, error) {
+	result := params.ConfigWebhooks{}
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	webhooks, err := c.state.ConfigWebhooks()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Webhooks = make([]params.ConfigWebhook, len(webhooks))
+	for i, w := range webhooks {
+		kinds := make([]string, len(w.Kinds))
+		for j, kind := range w.Kinds {
+			kinds[j] = string(kind)
+		}
+		result.Webhooks[i] = params.ConfigWebhook{
+			Name:   w.Name,
+			URL:    w.URL,
+			CACert: w.CACert,
+			Kinds:  kinds,
+		}
+	}
+	return result, nil
+}
+
+// AddConfigWebhooks is not available in versions of the API before 6.
+func (c *ControllerAPIv5) AddConfigWebhooks(_, _ struct{}) {}
+
+// RemoveConfigWebhooks is not available in versions of the API before 6.
+func (c *ControllerAPIv5) RemoveConfigWebhooks(_, _ struct{}) {}
+
+// ConfigWebhooks is not available in versions of the API before 6.
+func (c *ControllerAPIv5) ConfigWebhooks(_, _ struct{}) {}
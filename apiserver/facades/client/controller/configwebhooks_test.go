@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+var policyWebhook = params.ConfigWebhook{
+	Name:  "policy",
+	URL:   "https://policy.example.com/validate",
+	Kinds: []string{"model-config", "application-config"},
+}
+
+func (s *controllerSuite) TestAddConfigWebhooks(c *gc.C) {
+	results, err := s.controller.AddConfigWebhooks(params.ConfigWebhooks{
+		Webhooks: []params.ConfigWebhook{policyWebhook, policyWebhook, {
+			Name:  "insecure",
+			URL:   "http://policy.example.com",
+			Kinds: []string{"model-config"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{
+			Code:    params.CodeAlreadyExists,
+			Message: `config webhook "policy" already exists`,
+		}},
+		{Error: &params.Error{
+			Message: `invalid config webhook: URL "http://policy.example.com" (expected https URL) not valid`,
+		}},
+	})
+
+	webhooks, err := s.State.ConfigWebhooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(webhooks, jc.DeepEquals, []state.ConfigWebhook{{
+		Name:  "policy",
+		URL:   "https://policy.example.com/validate",
+		Kinds: []state.ConfigWebhookKind{state.ModelConfigWebhook, state.ApplicationConfigWebhook},
+	}})
+}
+
+func (s *controllerSuite) TestConfigWebhooks(c *gc.C) {
+	_, err := s.controller.AddConfigWebhooks(params.ConfigWebhooks{
+		Webhooks: []params.ConfigWebhook{policyWebhook},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.ConfigWebhooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Webhooks, jc.DeepEquals, []params.ConfigWebhook{policyWebhook})
+}
+
+func (s *controllerSuite) TestRemoveConfigWebhooks(c *gc.C) {
+	_, err := s.controller.AddConfigWebhooks(params.ConfigWebhooks{
+		Webhooks: []params.ConfigWebhook{policyWebhook},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.controller.RemoveConfigWebhooks(params.ConfigWebhookNames{
+		Names: []string{"policy", "policy"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{
+			Code:    params.CodeNotFound,
+			Message: `config webhook "policy" not found`,
+		}},
+	})
+	webhooks, err := s.State.ConfigWebhooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(webhooks, gc.HasLen, 0)
+}
+
+func (s *controllerSuite) TestConfigWebhooksRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPIv6(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.statePool,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.AddConfigWebhooks(params.ConfigWebhooks{
+		Webhooks: []params.ConfigWebhook{policyWebhook},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = endpoint.RemoveConfigWebhooks(params.ConfigWebhookNames{
+		Names: []string{"policy"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = endpoint.ConfigWebhooks()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -7,6 +7,7 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 
 	"github.com/juju/errors"
@@ -42,13 +43,23 @@ type ControllerAPI struct {
 	resources  facade.Resources
 }
 
+// ControllerAPIv5 provides the v5 Controller API.
+type ControllerAPIv5 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv4 provides the v4 Controller API.
+type ControllerAPIv4 struct {
+	*ControllerAPIv5
+}
+
 // ControllerAPIv3 provides the v3 Controller API.
 type ControllerAPIv3 struct {
-	*ControllerAPI
+	*ControllerAPIv4
 }
 
-// NewControllerAPIv4 creates a new ControllerAPIv4.
-func NewControllerAPIv4(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv6 creates a new ControllerAPIv6.
+func NewControllerAPIv6(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -62,6 +73,24 @@ func NewControllerAPIv4(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv5 creates a new ControllerAPIv5.
+func NewControllerAPIv5(ctx facade.Context) (*ControllerAPIv5, error) {
+	v6, err := NewControllerAPIv6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv5{v6}, nil
+}
+
+// NewControllerAPIv4 creates a new ControllerAPIv4.
+func NewControllerAPIv4(ctx facade.Context) (*ControllerAPIv4, error) {
+	v5, err := NewControllerAPIv5(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv4{v5}, nil
+}
+
 // NewControllerAPIv3 creates a new ControllerAPIv3.
 func NewControllerAPIv3(ctx facade.Context) (*ControllerAPIv3, error) {
 	v4, err := NewControllerAPIv4(ctx)
@@ -401,65 +430,133 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, release, err := c.migratingModelState(spec.ModelTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer release()
+
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState, c.statePool.SystemState(), &targetInfo); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return mig.Id(), nil
+}
+
+// ValidateMigration runs the migration prechecks for one or more
+// models against their target controllers without starting the
+// migrations, and reports everything found that would prevent them
+// from succeeding.
+func (c *ControllerAPI) ValidateMigration(args params.InitiateMigrationArgs) (
+	params.MigrationValidationResults, error,
+) {
+	out := params.MigrationValidationResults{
+		Results: make([]params.MigrationValidationResult, len(args.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range args.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		report, err := c.validateOneMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		for _, blocker := range report.Blockers {
+			result.Blockers = append(result.Blockers, params.MigrationValidationBlocker{
+				Kind:    string(blocker.Kind),
+				Message: blocker.Message,
+			})
+		}
+		result.Warnings = report.Warnings
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) validateOneMigration(spec params.MigrationSpec) (coremigration.ValidationReport, error) {
+	hostedState, release, err := c.migratingModelState(spec.ModelTag)
+	if err != nil {
+		return coremigration.ValidationReport{}, errors.Trace(err)
+	}
+	defer release()
+
+	targetInfo, err := makeTargetInfo(spec.TargetInfo)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return coremigration.ValidationReport{}, errors.Trace(err)
+	}
+	return validateMigration(hostedState, c.statePool.SystemState(), &targetInfo)
+}
+
+// migratingModelState returns the state of the model with the given
+// tag, and a function that releases it.
+func (c *ControllerAPI) migratingModelState(tag string) (*state.State, state.StatePoolReleaser, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "model tag")
 	}
 
 	// Ensure the model exists.
 	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
+		return nil, nil, errors.Annotate(err, "reading model")
 	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+		return nil, nil, errors.NotFoundf("model")
 	}
 
 	hostedState, release, err := c.statePool.Get(modelTag.Id())
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
-	defer release()
+	return hostedState, release, nil
+}
 
-	// Construct target info.
-	specTarget := spec.TargetInfo
+// makeTargetInfo converts the target of a migration spec into the
+// information needed to connect to the target controller.
+func makeTargetInfo(specTarget params.MigrationTargetInfo) (coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return empty, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return empty, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return empty, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	return coremigration.TargetInfo{
 		ControllerTag: controllerTag,
 		Addrs:         specTarget.Addrs,
 		CACert:        specTarget.CACert,
 		AuthTag:       authTag,
 		Password:      specTarget.Password,
 		Macaroons:     macs,
-	}
-
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState, c.statePool.SystemState(), &targetInfo); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
-	if err != nil {
-		return "", errors.Trace(err)
-	}
-	return mig.Id(), nil
+	}, nil
 }
 
+// ValidateMigration is not available in versions of the API before 5.
+func (c *ControllerAPIv4) ValidateMigration(_, _ struct{}) {}
+
 // ModifyControllerAccess changes the model access granted to users.
 func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -537,6 +634,63 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// validateMigration checks whether the model could be migrated to the
+// target controller, without starting the migration, and reports every
+// blocker found. Problems connecting to or checking the target
+// controller are reported as blockers too.
+var validateMigration = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo) (coremigration.ValidationReport, error) {
+	// Check model and source controller.
+	backend, err := migration.ValidationShim(st)
+	if err != nil {
+		return coremigration.ValidationReport{}, errors.Annotate(err, "creating backend")
+	}
+	report, err := migration.ValidateSource(backend)
+	if err != nil {
+		return report, errors.Annotate(err, "source validation failed")
+	}
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		report.Block(coremigration.BlockerTarget, "cannot connect to target controller: %v", err)
+		return report, nil
+	}
+	defer conn.Close()
+	if conn.ControllerTag() != targetInfo.ControllerTag {
+		report.Block(coremigration.BlockerTarget, "unexpected target controller UUID (got %s, expected %s)",
+			conn.ControllerTag(), targetInfo.ControllerTag)
+		return report, nil
+	}
+	modelInfo, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	model, err := st.Export()
+	if err != nil {
+		return report, errors.Annotate(err, "exporting model")
+	}
+	reqs := migration.ModelRequirements(model)
+
+	client := migrationtarget.NewClient(conn)
+	compat, err := client.CheckCompatibility(modelInfo, reqs)
+	if errors.IsNotSupported(err) {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("target controller cannot check compatibility: %v", err))
+	} else if err != nil {
+		return report, errors.Annotate(err, "checking target compatibility")
+	}
+	compat.Checks = append(compat.Checks, coremigration.CheckFacades(reqs.Features, conn.AllFacadeVersions()))
+	report.AddCompatibility(compat)
+
+	// The target prechecks stop at a version mismatch, which will
+	// already have been reported.
+	if !report.Blocked(coremigration.BlockerVersionSkew) {
+		if err := client.Prechecks(modelInfo); err != nil {
+			report.Block(coremigration.BlockerTarget, "%v", err)
+		}
+	}
+	return report, nil
+}
+
 func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
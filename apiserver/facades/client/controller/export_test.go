@@ -17,3 +17,9 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetValidationResult(p patcher, report migration.ValidationReport, err error) {
+	p.PatchValue(&validateMigration, func(*state.State, *state.State, *migration.TargetInfo) (migration.ValidationReport, error) {
+		return report, err
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package controllerhealth provides the ControllerHealth facade, which
+// reports on the health of the controller machines: their mongo replica
+// set members, the shared lease store and the load on their API
+// servers.
+package controllerhealth
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// controllerhealth facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ControllerInfo() (*state.ControllerInfo, error)
+	ReplicaSetMembers() ([]state.ReplicaSetMember, error)
+	LeaseStoreHealth() (state.LeaseStoreHealth, error)
+	APIServerLoads() (map[string]state.APIServerLoad, error)
+}
+
+// API provides the ControllerHealth API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(ctx.StatePool().SystemState(), ctx.Auth())
+}
+
+// NewAPI returns a new ControllerHealth API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkIsSuperuser() error {
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Health returns the health of each controller machine's mongo
+// replica set member and API server, and of the lease store shared by
+// the controllers. Failing to read the replica set status or the lease
+// store is reported in the result rather than failing the call, as
+// both are of most interest when the controller is unwell.
+func (api *API) Health() (params.ControllerHealthResult, error) {
+	var result params.ControllerHealthResult
+	if err := api.checkIsSuperuser(); err != nil {
+		return result, errors.Trace(err)
+	}
+	controllerInfo, err := api.backend.ControllerInfo()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	loads, err := api.backend.APIServerLoads()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	members := make(map[string]state.ReplicaSetMember)
+	replicaSetMembers, err := api.backend.ReplicaSetMembers()
+	if err != nil {
+		result.ReplicaSetError = common.ServerError(err)
+	}
+	for _, member := range replicaSetMembers {
+		if member.MachineId != "" {
+			members[member.MachineId] = member
+		}
+	}
+
+	leases, err := api.backend.LeaseStoreHealth()
+	if err != nil {
+		result.LeasesError = common.ServerError(err)
+	} else {
+		result.Leases = &params.LeaseStoreHealth{
+			GlobalTime:    leases.GlobalTime,
+			Leases:        leases.Leases,
+			ExpiredLeases: leases.ExpiredLeases,
+		}
+	}
+
+	// Report the current controller machines, and any machine that
+	// is still a replica set member after being removed as a
+	// controller.
+	machineIds := set.NewStrings(controllerInfo.MachineIds...)
+	for id := range members {
+		machineIds.Add(id)
+	}
+	for _, id := range utils.SortStringsNaturally(machineIds.Values()) {
+		health := params.ControllerMachineHealth{
+			MachineTag: names.NewMachineTag(id).String(),
+		}
+		if member, ok := members[id]; ok {
+			health.ReplicaSet = &params.ReplicaSetMemberHealth{
+				Address:  member.Address,
+				State:    member.State,
+				Healthy:  member.Healthy,
+				OplogLag: member.OplogLag,
+				Message:  member.Message,
+			}
+		}
+		if load, ok := loads[id]; ok {
+			health.APIServer = &params.APIServerLoad{
+				Connections:      load.Connections,
+				TotalConnections: load.TotalConnections,
+				LoginAttempts:    load.LoginAttempts,
+				Updated:          load.Updated,
+			}
+		}
+		result.Machines = append(result.Machines, health)
+	}
+	return result, nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/controllerhealth"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ControllerHealthSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&ControllerHealthSuite{})
+
+var updated = time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *ControllerHealthSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{
+		machineIds: []string{"0", "1", "10"},
+		members: []state.ReplicaSetMember{{
+			Id:        1,
+			MachineId: "0",
+			Address:   "10.0.0.1:37017",
+			State:     "PRIMARY",
+			Healthy:   true,
+		}, {
+			Id:        2,
+			MachineId: "1",
+			Address:   "10.0.0.2:37017",
+			State:     "SECONDARY",
+			Healthy:   true,
+			OplogLag:  2 * time.Second,
+		}, {
+			Id:        3,
+			MachineId: "10",
+			Address:   "10.0.0.3:37017",
+			State:     "(not reachable/healthy)",
+			Message:   "no route to host",
+		}},
+		leases: state.LeaseStoreHealth{
+			GlobalTime:    updated,
+			Leases:        5,
+			ExpiredLeases: 1,
+		},
+		loads: map[string]state.APIServerLoad{
+			"0": {Connections: 10, TotalConnections: 100, Updated: updated},
+			"1": {Connections: 3, TotalConnections: 30, LoginAttempts: 1, Updated: updated},
+		},
+	}
+}
+
+func (s *ControllerHealthSuite) TestNewAPINonClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := controllerhealth.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ControllerHealthSuite) TestHealth(c *gc.C) {
+	api, err := controllerhealth.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.Health()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerHealthResult{
+		Machines: []params.ControllerMachineHealth{{
+			MachineTag: "machine-0",
+			ReplicaSet: &params.ReplicaSetMemberHealth{
+				Address: "10.0.0.1:37017",
+				State:   "PRIMARY",
+				Healthy: true,
+			},
+			APIServer: &params.APIServerLoad{
+				Connections:      10,
+				TotalConnections: 100,
+				Updated:          updated,
+			},
+		}, {
+			MachineTag: "machine-1",
+			ReplicaSet: &params.ReplicaSetMemberHealth{
+				Address:  "10.0.0.2:37017",
+				State:    "SECONDARY",
+				Healthy:  true,
+				OplogLag: 2 * time.Second,
+			},
+			APIServer: &params.APIServerLoad{
+				Connections:      3,
+				TotalConnections: 30,
+				LoginAttempts:    1,
+				Updated:          updated,
+			},
+		}, {
+			MachineTag: "machine-10",
+			ReplicaSet: &params.ReplicaSetMemberHealth{
+				Address: "10.0.0.3:37017",
+				State:   "(not reachable/healthy)",
+				Message: "no route to host",
+			},
+		}},
+		Leases: &params.LeaseStoreHealth{
+			GlobalTime:    updated,
+			Leases:        5,
+			ExpiredLeases: 1,
+		},
+	})
+}
+
+func (s *ControllerHealthSuite) TestHealthRemovedControllerStillMember(c *gc.C) {
+	s.backend.machineIds = []string{"0", "1"}
+	api, err := controllerhealth.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.Health()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Machines, gc.HasLen, 3)
+	c.Check(result.Machines[2].MachineTag, gc.Equals, "machine-10")
+}
+
+func (s *ControllerHealthSuite) TestHealthPartialFailure(c *gc.C) {
+	s.backend.membersErr = errors.New("not running with --replSet")
+	s.backend.leasesErr = errors.New("boom")
+	api, err := controllerhealth.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.Health()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ReplicaSetError, gc.ErrorMatches, "not running with --replSet")
+	c.Check(result.LeasesError, gc.ErrorMatches, "boom")
+	c.Check(result.Leases, gc.IsNil)
+	c.Assert(result.Machines, gc.HasLen, 3)
+	for _, machine := range result.Machines {
+		c.Check(machine.ReplicaSet, gc.IsNil)
+	}
+	c.Check(result.Machines[0].APIServer, gc.NotNil)
+}
+
+func (s *ControllerHealthSuite) TestHealthPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	api, err := controllerhealth.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Health()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	machineIds []string
+	members    []state.ReplicaSetMember
+	membersErr error
+	leases     state.LeaseStoreHealth
+	leasesErr  error
+	loads      map[string]state.APIServerLoad
+}
+
+func (m *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (m *mockBackend) ControllerInfo() (*state.ControllerInfo, error) {
+	return &state.ControllerInfo{MachineIds: m.machineIds}, nil
+}
+
+func (m *mockBackend) ReplicaSetMembers() ([]state.ReplicaSetMember, error) {
+	if m.membersErr != nil {
+		return nil, m.membersErr
+	}
+	return m.members, nil
+}
+
+func (m *mockBackend) LeaseStoreHealth() (state.LeaseStoreHealth, error) {
+	return m.leases, m.leasesErr
+}
+
+func (m *mockBackend) APIServerLoads() (map[string]state.APIServerLoad, error) {
+	return m.loads, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package entityfinder
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// entityfinder facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AllApplications() ([]Application, error)
+	AllMachines() ([]Machine, error)
+}
+
+// Application defines the application functionality required by
+// the entityfinder facade.
+type Application interface {
+	Name() string
+	AllUnits() ([]Unit, error)
+}
+
+// Unit defines the unit functionality required by the entityfinder
+// facade.
+type Unit interface {
+	Name() string
+}
+
+// Machine defines the machine functionality required by the
+// entityfinder facade.
+type Machine interface {
+	Id() string
+	InstanceId() (instance.Id, error)
+	Addresses() []network.Address
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+func (s stateShim) AllApplications() ([]Application, error) {
+	apps, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = applicationShim{app}
+	}
+	return result, nil
+}
+
+func (s stateShim) AllMachines() ([]Machine, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, u := range units {
+		result[i] = u
+	}
+	return result, nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package entityfinder provides the EntityFinder facade, which
+// resolves partial entity names to tags. It is used by the CLI to
+// suggest what the user may have meant when an entity is not found,
+// and by interactive tooling for completion.
+package entityfinder
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/permission"
+)
+
+// defaultLimit is the number of matches returned for a query that
+// does not specify a limit.
+const defaultLimit = 10
+
+// findableKinds holds the tag kinds of the entities that may be found.
+var findableKinds = set.NewStrings(
+	names.ApplicationTagKind,
+	names.MachineTagKind,
+	names.UnitTagKind,
+)
+
+// API provides the EntityFinder API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(NewStateBackend(ctx.State()), ctx.Auth())
+}
+
+// NewAPI returns a new EntityFinder API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// candidate is an entity that may match a query, along with the
+// names by which it may be found.
+type candidate struct {
+	tag   names.Tag
+	names []string
+}
+
+// FindEntities returns the entities in the model that best match
+// each of the specified queries, best match first. Applications are
+// matched by name, machines by id, instance id or display name, and
+// units by name.
+func (api *API) FindEntities(args params.FindEntitiesArgs) (params.FindEntitiesResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.FindEntitiesResults{}, errors.Trace(err)
+	}
+	results := params.FindEntitiesResults{
+		Results: make([]params.FindEntitiesResult, len(args.Queries)),
+	}
+	if len(args.Queries) == 0 {
+		return results, nil
+	}
+	candidates, err := api.candidates()
+	if err != nil {
+		return params.FindEntitiesResults{}, errors.Trace(err)
+	}
+	for i, query := range args.Queries {
+		matches, err := findEntities(query, candidates)
+		results.Results[i].Matches = matches
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// candidates returns all of the entities in the model that may be
+// found.
+func (api *API) candidates() ([]candidate, error) {
+	var candidates []candidate
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, app := range apps {
+		candidates = append(candidates, candidate{
+			tag:   names.NewApplicationTag(app.Name()),
+			names: []string{app.Name()},
+		})
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			candidates = append(candidates, candidate{
+				tag:   names.NewUnitTag(unit.Name()),
+				names: []string{unit.Name()},
+			})
+		}
+	}
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, m := range machines {
+		c := candidate{
+			tag:   names.NewMachineTag(m.Id()),
+			names: []string{m.Id()},
+		}
+		// Machines that have not been provisioned yet can
+		// only be found by id.
+		if instId, err := m.InstanceId(); err == nil && instId != "" {
+			c.names = append(c.names, string(instId))
+		}
+		c.names = append(c.names, displayNames(m)...)
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+// displayNames returns the names by which a machine is displayed: its
+// host names, both fully qualified and without the domain, as
+// reported by the provider (e.g. "node-7.maas" and "node-7").
+func displayNames(m Machine) []string {
+	var result []string
+	for _, addr := range m.Addresses() {
+		if addr.Type != network.HostName {
+			continue
+		}
+		result = append(result, addr.Value)
+		if i := strings.Index(addr.Value, "."); i > 0 {
+			result = append(result, addr.Value[:i])
+		}
+	}
+	return result
+}
+
+func findEntities(query params.FindEntitiesQuery, candidates []candidate) ([]params.EntityMatch, error) {
+	if query.Limit < 0 {
+		return nil, errors.NotValidf("negative limit")
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	kinds := set.NewStrings(query.Kinds...)
+	if unknown := kinds.Difference(findableKinds); !unknown.IsEmpty() {
+		return nil, errors.NotValidf("entity kinds %q", unknown.SortedValues())
+	}
+
+	var matches []params.EntityMatch
+	for _, c := range candidates {
+		if !kinds.IsEmpty() && !kinds.Contains(c.tag.Kind()) {
+			continue
+		}
+		best := 0
+		for _, name := range c.names {
+			if s := score(query.Query, name); s > best {
+				best = s
+			}
+		}
+		if best > 0 {
+			matches = append(matches, params.EntityMatch{
+				Tag:   c.tag.String(),
+				Score: best,
+			})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Tag < matches[j].Tag
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package entityfinder_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/entityfinder"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type EntityFinderSuite struct {
+	testing.IsolationSuite
+	backend    mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *entityfinder.API
+}
+
+var _ = gc.Suite(&EntityFinderSuite{})
+
+func (s *EntityFinderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = mockBackend{
+		modelUUID: coretesting.ModelTag.Id(),
+		applications: []entityfinder.Application{
+			&mockApplication{
+				name: "mysql",
+				units: []entityfinder.Unit{
+					&mockUnit{"mysql/0"},
+					&mockUnit{"mysql/1"},
+				},
+			},
+			&mockApplication{
+				name:  "mysql-router",
+				units: []entityfinder.Unit{&mockUnit{"mysql-router/0"}},
+			},
+			&mockApplication{
+				name:  "wordpress",
+				units: []entityfinder.Unit{&mockUnit{"wordpress/0"}},
+			},
+		},
+		machines: []entityfinder.Machine{
+			&mockMachine{
+				id:         "0",
+				instanceId: "i-abc123",
+				addresses:  network.NewAddresses("10.0.0.1", "node-7.maas"),
+			},
+			&mockMachine{id: "0/lxd/0", instanceId: "juju-0-lxd-0"},
+			&mockMachine{id: "1"},
+		},
+	}
+	api, err := entityfinder.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *EntityFinderSuite) find(c *gc.C, queries ...params.FindEntitiesQuery) []params.FindEntitiesResult {
+	results, err := s.api.FindEntities(params.FindEntitiesArgs{Queries: queries})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, len(queries))
+	return results.Results
+}
+
+func (s *EntityFinderSuite) TestFindApplicationPrefix(c *gc.C) {
+	results := s.find(c, params.FindEntitiesQuery{
+		Query: "mysql",
+		Kinds: []string{"application"},
+	})
+	c.Assert(results[0], jc.DeepEquals, params.FindEntitiesResult{
+		Matches: []params.EntityMatch{
+			{Tag: "application-mysql", Score: 100},
+			{Tag: "application-mysql-router", Score: 80},
+		},
+	})
+}
+
+func (s *EntityFinderSuite) TestFindRanksAcrossKinds(c *gc.C) {
+	results := s.find(c, params.FindEntitiesQuery{Query: "mysql-r"})
+	c.Assert(results[0], jc.DeepEquals, params.FindEntitiesResult{
+		Matches: []params.EntityMatch{
+			{Tag: "application-mysql-router", Score: 80},
+			{Tag: "unit-mysql-router-0", Score: 80},
+		},
+	})
+}
+
+func (s *EntityFinderSuite) TestFindUnitGlob(c *gc.C) {
+	results := s.find(c, params.FindEntitiesQuery{Query: "mysql/*"})
+	c.Assert(results[0], jc.DeepEquals, params.FindEntitiesResult{
+		Matches: []params.EntityMatch{
+			{Tag: "unit-mysql-0", Score: 70},
+			{Tag: "unit-mysql-1", Score: 70},
+		},
+	})
+}
+
+func (s *EntityFinderSuite) TestFindMachineByInstanceId(c *gc.C) {
+	results := s.find(c, params.FindEntitiesQuery{Query: "i-abc"})
+	c.Assert(results[0], jc.DeepEquals, params.FindEntitiesResult{
+		Matches: []params.EntityMatch{
+			{Tag: "machine-0", Score: 80},
+		},
+	})
+}
+
+func (s *EntityFinderSuite) TestFindMachineByDisplayName(c *gc.C) {
+	results := s.find(c,
+		params.FindEntitiesQuery{Query: "node-7"},
+		params.FindEntitiesQuery{Query: "node-7.maas"},
+		params.FindEntitiesQuery{Query: "10.0.0.1"},
+	)
+	c.Assert(results, jc.DeepEquals, []params.FindEntitiesResult{{
+		Matches: []params.EntityMatch{{Tag: "machine-0", Score: 100}},
+	}, {
+		Matches: []params.EntityMatch{{Tag: "machine-0", Score: 100}},
+	}, {
+		// IP addresses are not display names.
+	}})
+}
+
+func (s *EntityFinderSuite) TestFindSimilar(c *gc.C) {
+	results := s.find(c, params.FindEntitiesQuery{Query: "wordpres"})
+	c.Assert(results[0].Matches, gc.HasLen, 2)
+	results = s.find(c, params.FindEntitiesQuery{Query: "wordprss", Kinds: []string{"application"}})
+	c.Assert(results[0], jc.DeepEquals, params.FindEntitiesResult{
+		Matches: []params.EntityMatch{
+			{Tag: "application-wordpress", Score: 29},
+		},
+	})
+}
+
+func (s *EntityFinderSuite) TestFindLimit(c *gc.C) {
+	results := s.find(c, params.FindEntitiesQuery{Query: "mysql", Limit: 1})
+	c.Assert(results[0], jc.DeepEquals, params.FindEntitiesResult{
+		Matches: []params.EntityMatch{
+			{Tag: "application-mysql", Score: 100},
+		},
+	})
+}
+
+func (s *EntityFinderSuite) TestFindInvalidQueries(c *gc.C) {
+	results := s.find(c,
+		params.FindEntitiesQuery{Query: "mysql", Limit: -1},
+		params.FindEntitiesQuery{Query: "mysql", Kinds: []string{"unit", "relation"}},
+		params.FindEntitiesQuery{Query: "nothing-like-it"},
+	)
+	c.Assert(results[0].Error, gc.ErrorMatches, "negative limit not valid")
+	c.Assert(results[1].Error, gc.ErrorMatches, `entity kinds \["relation"\] not valid`)
+	c.Assert(results[2], jc.DeepEquals, params.FindEntitiesResult{})
+}
+
+func (s *EntityFinderSuite) TestFindBackendError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.api.FindEntities(params.FindEntitiesArgs{
+		Queries: []params.FindEntitiesQuery{{Query: "mysql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *EntityFinderSuite) TestFindNoQueries(c *gc.C) {
+	results, err := s.api.FindEntities(params.FindEntitiesArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *EntityFinderSuite) TestFindPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	api, err := entityfinder.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.FindEntities(params.FindEntitiesArgs{
+		Queries: []params.FindEntitiesQuery{{Query: "mysql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, common.ErrPerm.Error())
+}
+
+func (s *EntityFinderSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := entityfinder.NewAPI(&s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+type ScoreSuite struct{}
+
+var _ = gc.Suite(&ScoreSuite{})
+
+func (*ScoreSuite) TestScore(c *gc.C) {
+	for i, test := range []struct {
+		query     string
+		candidate string
+		score     int
+	}{
+		{"mysql", "mysql", 100},
+		{"my", "mysql", 80},
+		{"my*/0", "mysql/0", 70},
+		{"my*/0", "mysql/1", 0},
+		{"sql", "mysql", 50},
+		{"mysqk", "mysql", 29},
+		{"mysqk", "postgresql", 0},
+		{"myqk", "mysql", 0},
+		{"", "mysql", 0},
+	} {
+		c.Logf("test %d: %q ~ %q", i, test.query, test.candidate)
+		c.Check(entityfinder.Score(test.query, test.candidate), gc.Equals, test.score)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package entityfinder_test
+
+import (
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/entityfinder"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+	modelUUID    string
+	applications []entityfinder.Application
+	machines     []entityfinder.Machine
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag(m.modelUUID)
+}
+
+func (m *mockBackend) AllApplications() ([]entityfinder.Application, error) {
+	m.MethodCall(m, "AllApplications")
+	return m.applications, m.NextErr()
+}
+
+func (m *mockBackend) AllMachines() ([]entityfinder.Machine, error) {
+	m.MethodCall(m, "AllMachines")
+	return m.machines, m.NextErr()
+}
+
+type mockApplication struct {
+	name  string
+	units []entityfinder.Unit
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) AllUnits() ([]entityfinder.Unit, error) {
+	return a.units, nil
+}
+
+type mockUnit struct {
+	name string
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+type mockMachine struct {
+	id         string
+	instanceId instance.Id
+	addresses  []network.Address
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %v", m.id)
+	}
+	return m.instanceId, nil
+}
+
+func (m *mockMachine) Addresses() []network.Address {
+	return m.addresses
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package entityfinder
+
+import (
+	"path"
+	"strings"
+)
+
+// Scores given to the different kinds of match, best first. Matches
+// of similar names are scored lower the more edits they need.
+const (
+	scoreExact     = 100
+	scorePrefix    = 80
+	scoreGlob      = 70
+	scoreSubstring = 50
+	scoreSimilar   = 30
+)
+
+// score returns how well the candidate name matches the query, or
+// zero if it does not match at all. A query containing glob
+// wildcards only matches names that the glob matches.
+func score(query, candidate string) int {
+	if query == "" {
+		return 0
+	}
+	if query == candidate {
+		return scoreExact
+	}
+	if strings.ContainsAny(query, "*?[") {
+		if ok, _ := path.Match(query, candidate); ok {
+			return scoreGlob
+		}
+		return 0
+	}
+	if strings.HasPrefix(candidate, query) {
+		return scorePrefix
+	}
+	if strings.Contains(candidate, query) {
+		return scoreSubstring
+	}
+	// Allow one edit per four characters of the query, so short
+	// queries don't match everything.
+	maxEdits := len(query) / 4
+	if maxEdits == 0 {
+		return 0
+	}
+	if edits := editDistance(query, candidate); edits <= maxEdits {
+		return scoreSimilar - edits
+	}
+	return 0
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
@@ -16,6 +16,9 @@ type Backend interface {
 	ModelTag() names.ModelTag
 	SaveFirewallRule(state.FirewallRule) error
 	ListFirewallRules() ([]*state.FirewallRule, error)
+	SaveEgressRule(state.EgressRule) error
+	RemoveEgressRule(application string) error
+	ListEgressRules() ([]*state.EgressRule, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -53,3 +56,18 @@ func (s stateShim) ListFirewallRules() ([]*state.FirewallRule, error) {
 	api := state.NewFirewallRules(s.State)
 	return api.AllRules()
 }
+
+func (s stateShim) SaveEgressRule(rule state.EgressRule) error {
+	api := state.NewFirewallRules(s.State)
+	return api.SaveEgress(rule)
+}
+
+func (s stateShim) RemoveEgressRule(application string) error {
+	api := state.NewFirewallRules(s.State)
+	return api.RemoveEgress(application)
+}
+
+func (s stateShim) ListEgressRules() ([]*state.EgressRule, error) {
+	api := state.NewFirewallRules(s.State)
+	return api.AllEgressRules()
+}
@@ -24,6 +24,21 @@ type API struct {
 	check      BlockChecker
 }
 
+// APIv2 provides the firewallrules facade APIs for v2, which adds
+// application egress rules.
+type APIv2 struct {
+	*API
+}
+
+// NewFacadeV2 provides the signature required for facade registration.
+func NewFacadeV2(ctx facade.Context) (*APIv2, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
 // NewFacade provides the signature required for facade registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	backend, err := NewStateBackend(ctx.State())
@@ -54,6 +69,19 @@ func NewAPI(
 	}, nil
 }
 
+// NewAPIv2 returns a new firewallrules v2 API facade.
+func NewAPIv2(
+	backend Backend,
+	authorizer facade.Authorizer,
+	blockChecker BlockChecker,
+) (*APIv2, error) {
+	api, err := NewAPI(backend, authorizer, blockChecker)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
+}
+
 func (api *API) checkPermission(tag names.Tag, perm permission.Access) error {
 	allowed, err := api.authorizer.HasPermission(perm, tag)
 	if err != nil {
@@ -115,3 +143,77 @@ func (api *API) ListFirewallRules() (params.ListFirewallRulesResults, error) {
 	}
 	return listResults, nil
 }
+
+// SetEgressRules creates or updates the specified egress rules,
+// restricting the outbound traffic of the machines hosting each
+// application's units to the given CIDRs.
+func (api *APIv2) SetEgressRules(args params.EgressRuleArgs) (params.ErrorResults, error) {
+	var errResults params.ErrorResults
+	if err := api.checkAdmin(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		logger.Debugf("saving egress rule %+v", arg)
+		tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = api.backend.SaveEgressRule(state.EgressRule{
+			Application:  tag.Id(),
+			AllowedCIDRs: arg.AllowedCIDRs,
+		})
+		results[i].Error = common.ServerError(err)
+	}
+	errResults.Results = results
+	return errResults, nil
+}
+
+// RemoveEgressRules removes the egress rules of the specified
+// applications, lifting any restriction on their outbound traffic.
+func (api *APIv2) RemoveEgressRules(args params.Entities) (params.ErrorResults, error) {
+	var errResults params.ErrorResults
+	if err := api.checkAdmin(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errResults, errors.Trace(err)
+	}
+
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		tag, err := names.ParseApplicationTag(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Error = common.ServerError(api.backend.RemoveEgressRule(tag.Id()))
+	}
+	errResults.Results = results
+	return errResults, nil
+}
+
+// ListEgressRules returns all the egress rules.
+func (api *APIv2) ListEgressRules() (params.ListEgressRulesResults, error) {
+	var listResults params.ListEgressRulesResults
+	if err := api.checkCanRead(); err != nil {
+		return listResults, errors.Trace(err)
+	}
+	rules, err := api.backend.ListEgressRules()
+	if err != nil {
+		return listResults, errors.Trace(err)
+	}
+	listResults.Rules = make([]params.EgressRule, len(rules))
+	for i, r := range rules {
+		listResults.Rules[i] = params.EgressRule{
+			ApplicationTag: names.NewApplicationTag(r.Application).String(),
+			AllowedCIDRs:   r.AllowedCIDRs,
+		}
+	}
+	return listResults, nil
+}
@@ -47,8 +47,9 @@ func (s *FirewallRulesSuite) SetUpTest(c *gc.C) {
 		Tag: names.NewUserTag("admin"),
 	}
 	s.backend = mockBackend{
-		modelUUID: coretesting.ModelTag.Id(),
-		rules:     make(map[string]state.FirewallRule),
+		modelUUID:   coretesting.ModelTag.Id(),
+		rules:       make(map[string]state.FirewallRule),
+		egressRules: make(map[string]state.EgressRule),
 	}
 	s.blockChecker = mockBlockChecker{}
 	api, err := firewallrules.NewAPI(
@@ -120,3 +121,97 @@ func (s *FirewallRulesSuite) TestListFirewallRulesPermission(c *gc.C) {
 	_, err := s.api.ListFirewallRules()
 	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
 }
+
+func (s *FirewallRulesSuite) newAPIv2(c *gc.C) *firewallrules.APIv2 {
+	api, err := firewallrules.NewAPIv2(
+		&s.backend,
+		s.authorizer,
+		&s.blockChecker,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *FirewallRulesSuite) TestSetEgressRules(c *gc.C) {
+	api := s.newAPIv2(c)
+	result, err := api.SetEgressRules(params.EgressRuleArgs{
+		Args: []params.EgressRule{{
+			ApplicationTag: "application-mysql",
+			AllowedCIDRs:   []string{"10.0.0.0/8"},
+		}, {
+			ApplicationTag: "unit-mysql-0",
+			AllowedCIDRs:   []string{"10.0.0.0/8"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid application tag`)
+	c.Assert(s.backend.egressRules, jc.DeepEquals, map[string]state.EgressRule{
+		"mysql": {
+			Application:  "mysql",
+			AllowedCIDRs: []string{"10.0.0.0/8"},
+		},
+	})
+}
+
+func (s *FirewallRulesSuite) TestSetEgressRulesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary")
+	api := s.newAPIv2(c)
+	_, err := api.SetEgressRules(params.EgressRuleArgs{
+		Args: []params.EgressRule{{
+			ApplicationTag: "application-mysql",
+			AllowedCIDRs:   []string{"10.0.0.0/8"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+	c.Assert(s.backend.egressRules, gc.HasLen, 0)
+}
+
+func (s *FirewallRulesSuite) TestSetEgressRulesBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	api := s.newAPIv2(c)
+	_, err := api.SetEgressRules(params.EgressRuleArgs{
+		Args: []params.EgressRule{{
+			ApplicationTag: "application-mysql",
+			AllowedCIDRs:   []string{"10.0.0.0/8"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	c.Assert(s.backend.egressRules, gc.HasLen, 0)
+}
+
+func (s *FirewallRulesSuite) TestRemoveEgressRules(c *gc.C) {
+	s.backend.egressRules["mysql"] = state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	}
+	api := s.newAPIv2(c)
+	result, err := api.RemoveEgressRules(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{Error: nil}},
+	})
+	c.Assert(s.backend.egressRules, gc.HasLen, 0)
+}
+
+func (s *FirewallRulesSuite) TestListEgressRules(c *gc.C) {
+	api := s.newAPIv2(c)
+	result, err := api.ListEgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListEgressRulesResults{
+		Rules: []params.EgressRule{{
+			ApplicationTag: "application-mysql",
+			AllowedCIDRs:   []string{"10.0.0.0/8"},
+		}},
+	})
+}
+
+func (s *FirewallRulesSuite) TestListEgressRulesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("mary")
+	api := s.newAPIv2(c)
+	_, err := api.ListEgressRules()
+	c.Assert(err, gc.ErrorMatches, ".*permission denied.*")
+}
@@ -15,8 +15,9 @@ type mockBackend struct {
 	jtesting.Stub
 	firewallrules.Backend
 
-	modelUUID string
-	rules     map[string]state.FirewallRule
+	modelUUID   string
+	rules       map[string]state.FirewallRule
+	egressRules map[string]state.EgressRule
 }
 
 func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
@@ -47,6 +48,33 @@ func (m *mockBackend) ListFirewallRules() ([]*state.FirewallRule, error) {
 	}, nil
 }
 
+func (m *mockBackend) SaveEgressRule(rule state.EgressRule) error {
+	m.MethodCall(m, "SaveEgressRule", rule)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.egressRules[rule.Application] = rule
+	return nil
+}
+
+func (m *mockBackend) RemoveEgressRule(application string) error {
+	m.MethodCall(m, "RemoveEgressRule", application)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	delete(m.egressRules, application)
+	return nil
+}
+
+func (m *mockBackend) ListEgressRules() ([]*state.EgressRule, error) {
+	m.MethodCall(m, "ListEgressRules")
+	m.PopNoErr()
+	return []*state.EgressRule{{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	}}, nil
+}
+
 type mockBlockChecker struct {
 	jtesting.Stub
 }
@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthchecks
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the healthchecks
+// facade. For details on the methods, see the methods on state.State
+// with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Unit(name string) (Unit, error)
+}
+
+// Unit defines the unit functionality required by the healthchecks
+// facade.
+type Unit interface {
+	HealthCheckHistory(limit int) ([]state.HealthCheckResult, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+func (s stateShim) Unit(name string) (Unit, error) {
+	unit, err := s.State.Unit(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthchecks provides the HealthChecks facade, which lets
+// clients with read access to a model inspect the recent results of
+// the health checks declared by its units' charms.
+package healthchecks
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// API provides the HealthChecks API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(NewStateBackend(ctx.State()), ctx.Auth())
+}
+
+// NewAPI returns a new HealthChecks API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// HealthCheckHistory returns the most recent health check results of
+// each of the given units, newest first.
+func (api *API) HealthCheckHistory(args params.HealthCheckHistoryArgs) (params.HealthCheckHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.HealthCheckHistoryResults{}, errors.Trace(err)
+	}
+	results := params.HealthCheckHistoryResults{
+		Results: make([]params.HealthCheckHistoryResult, len(args.Units)),
+	}
+	for i, arg := range args.Units {
+		history, err := api.healthCheckHistory(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Results = history
+	}
+	return results, nil
+}
+
+func (api *API) healthCheckHistory(arg params.HealthCheckHistoryArg) ([]params.HealthCheckResult, error) {
+	unitTag, err := names.ParseUnitTag(arg.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(unitTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	history, err := unit.HealthCheckHistory(arg.Limit)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]params.HealthCheckResult, len(history))
+	for i, result := range history {
+		results[i] = params.HealthCheckResult{
+			Check:   result.Check,
+			Healthy: result.Healthy,
+			Message: result.Message,
+			Time:    result.Time,
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthchecks_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/healthchecks"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type healthChecksSuite struct {
+	coretesting.BaseSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	now        time.Time
+}
+
+var _ = gc.Suite(&healthChecksSuite{})
+
+func (s *healthChecksSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.now = time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.backend = &mockBackend{
+		units: map[string]*mockUnit{
+			"mysql/0": {history: []state.HealthCheckResult{
+				{Check: "tcp", Healthy: false, Message: "refused", Time: s.now.Add(time.Minute)},
+				{Check: "tcp", Healthy: true, Time: s.now},
+			}},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+}
+
+func (s *healthChecksSuite) newAPI(c *gc.C) *healthchecks.API {
+	api, err := healthchecks.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *healthChecksSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := healthchecks.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *healthChecksSuite) TestHealthCheckHistory(c *gc.C) {
+	results, err := s.newAPI(c).HealthCheckHistory(params.HealthCheckHistoryArgs{
+		Units: []params.HealthCheckHistoryArg{
+			{Tag: "unit-mysql-0", Limit: 1},
+			{Tag: "unit-missing-0"},
+			{Tag: "application-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.HealthCheckHistoryResult{
+		Results: []params.HealthCheckResult{
+			{Check: "tcp", Healthy: false, Message: "refused", Time: s.now.Add(time.Minute)},
+		},
+	})
+	c.Assert(results.Results[1].Error, jc.DeepEquals, apiservertesting.NotFoundError(`unit "missing/0"`))
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"application-mysql" is not a valid unit tag`)
+}
+
+func (s *healthChecksSuite) TestHealthCheckHistoryRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).HealthCheckHistory(params.HealthCheckHistoryArgs{
+		Units: []params.HealthCheckHistoryArg{{Tag: "unit-mysql-0"}},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+type mockBackend struct {
+	units map[string]*mockUnit
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Unit(name string) (healthchecks.Unit, error) {
+	unit, ok := b.units[name]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q", name)
+	}
+	return unit, nil
+}
+
+type mockUnit struct {
+	history []state.HealthCheckResult
+}
+
+func (u *mockUnit) HealthCheckHistory(limit int) ([]state.HealthCheckResult, error) {
+	if limit > 0 && limit < len(u.history) {
+		return u.history[:limit], nil
+	}
+	return u.history, nil
+}
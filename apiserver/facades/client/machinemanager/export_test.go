@@ -3,4 +3,18 @@
 
 package machinemanager
 
+import "github.com/juju/juju/cloudconfig/instancecfg"
+
 var InstanceTypes = instanceTypes
+
+func NewMachineManagerAPIV5(api *MachineManagerAPI, getEnviron environGetFunc) *MachineManagerAPIV5 {
+	return &MachineManagerAPIV5{&MachineManagerAPIV4{api}, getEnviron}
+}
+
+func NewMachineManagerAPIV6(
+	api *MachineManagerAPI,
+	getEnviron environGetFunc,
+	instanceConfig func(machineId, nonce string) (*instancecfg.InstanceConfig, error),
+) *MachineManagerAPIV6 {
+	return &MachineManagerAPIV6{NewMachineManagerAPIV5(api, getEnviron), instanceConfig}
+}
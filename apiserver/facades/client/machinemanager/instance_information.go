@@ -21,24 +21,32 @@ func (mm *MachineManagerAPI) InstanceTypes(cons params.ModelInstanceTypesConstra
 
 type environGetFunc func(st environs.EnvironConfigGetter, newEnviron environs.NewEnvironFunc) (environs.Environ, error)
 
-func instanceTypes(mm *MachineManagerAPI,
-	getEnviron environGetFunc,
-	cons params.ModelInstanceTypesConstraints,
-) (params.InstanceTypesResults, error) {
+// environConfigGetter returns an EnvironConfigGetter for the model
+// the API is serving.
+func (mm *MachineManagerAPI) environConfigGetter() (environs.EnvironConfigGetter, error) {
 	model, err := mm.st.Model()
 	if err != nil {
-		return params.InstanceTypesResults{}, errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
-
 	cloudSpec := func() (environs.CloudSpec, error) {
 		cloudName := model.Cloud()
 		regionName := model.CloudRegion()
 		credentialTag, _ := model.CloudCredential()
 		return stateenvirons.CloudSpec(mm.st, cloudName, regionName, credentialTag)
 	}
-	backend := common.EnvironConfigGetterFuncs{
+	return common.EnvironConfigGetterFuncs{
 		CloudSpecFunc:   cloudSpec,
 		ModelConfigFunc: model.Config,
+	}, nil
+}
+
+func instanceTypes(mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	cons params.ModelInstanceTypesConstraints,
+) (params.InstanceTypesResults, error) {
+	backend, err := mm.environConfigGetter()
+	if err != nil {
+		return params.InstanceTypesResults{}, errors.Trace(err)
 	}
 
 	env, err := getEnviron(backend, environs.New)
@@ -137,6 +137,14 @@ func (mockModel) CloudCredential() (names.CloudCredentialTag, bool) {
 	return names.NewCloudCredentialTag("foo/bob/bar"), true
 }
 
+func (mockModel) Name() string {
+	return "controller"
+}
+
+func (mockModel) UUID() string {
+	return "beef1beef1-0000-0000-000011112222"
+}
+
 func (mockModel) ModelTag() names.ModelTag {
 	return names.NewModelTag("beef1beef1-0000-0000-000011112222")
 }
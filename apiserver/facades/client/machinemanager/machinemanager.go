@@ -14,7 +14,10 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/client"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/permission"
@@ -57,6 +60,38 @@ func NewFacadeV4(ctx facade.Context) (*MachineManagerAPIV4, error) {
 	return &MachineManagerAPIV4{machineManagerAPI}, nil
 }
 
+type MachineManagerAPIV5 struct {
+	*MachineManagerAPIV4
+	getEnviron environGetFunc
+}
+
+// NewFacadeV5 creates a new server-side MachineManager API facade.
+func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
+	machineManagerAPIV4, err := NewFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV5{machineManagerAPIV4, environs.GetEnviron}, nil
+}
+
+type MachineManagerAPIV6 struct {
+	*MachineManagerAPIV5
+	instanceConfig instanceConfigFunc
+}
+
+// NewFacadeV6 creates a new server-side MachineManager API facade.
+func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
+	machineManagerAPIV5, err := NewFacadeV5(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	st := ctx.State()
+	instanceConfig := func(machineId, nonce string) (*instancecfg.InstanceConfig, error) {
+		return client.InstanceConfig(st, machineId, nonce, "")
+	}
+	return &MachineManagerAPIV6{machineManagerAPIV5, instanceConfig}, nil
+}
+
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
 func NewMachineManagerAPI(backend Backend, pool Pool, auth facade.Authorizer) (*MachineManagerAPI, error) {
 	if !auth.AuthClient() {
@@ -143,12 +178,18 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		// For 1.21 we should support both UUID and name, and with 1.22
-		// just support UUID
-		if p.Placement.Scope != model.Name() && p.Placement.Scope != model.UUID() {
+		switch p.Placement.Scope {
+		case model.Name(), model.UUID():
+			// For 1.21 we should support both UUID and name, and with 1.22
+			// just support UUID
+			placementDirective = p.Placement.Directive
+		case instance.AffinityScope, instance.AntiAffinityScope:
+			// Affinity directives are recorded in full, for the
+			// provisioner to interpret.
+			placementDirective = p.Placement.String()
+		default:
 			return nil, fmt.Errorf("invalid model name %q", p.Placement.Scope)
 		}
-		placementDirective = p.Placement.Directive
 	}
 
 	volumes := make([]state.MachineVolumeParams, 0, len(p.Disks))
@@ -4,6 +4,8 @@
 package machinemanager_test
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	jtesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
@@ -104,6 +107,31 @@ func (s *MachineManagerSuite) TestNewMachineManagerAPINonClient(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *MachineManagerSuite) TestAddMachinesWithPlacement(c *gc.C) {
+	apiParams := []params.AddMachineParams{{
+		Series:    "trusty",
+		Jobs:      []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		Placement: &instance.Placement{Scope: "beef1beef1-0000-0000-000011112222", Directive: "zone=a"},
+	}, {
+		Series:    "trusty",
+		Jobs:      []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		Placement: &instance.Placement{Scope: instance.AntiAffinityScope, Directive: "mysql"},
+	}, {
+		Series:    "trusty",
+		Jobs:      []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		Placement: &instance.Placement{Scope: "elsewhere", Directive: "zone=a"},
+	}}
+	results, err := s.api.AddMachines(params.AddMachines{MachineParams: apiParams})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines, gc.HasLen, 3)
+	c.Assert(results.Machines[0].Error, gc.IsNil)
+	c.Assert(results.Machines[1].Error, gc.IsNil)
+	c.Assert(results.Machines[2].Error, gc.ErrorMatches, `invalid model name "elsewhere"`)
+	c.Assert(s.st.machineTemplates, gc.HasLen, 2)
+	c.Assert(s.st.machineTemplates[0].Placement, gc.Equals, "zone=a")
+	c.Assert(s.st.machineTemplates[1].Placement, gc.Equals, "anti-affinity:mysql")
+}
+
 func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	s.st.err = errors.New("boom")
 	results, err := s.api.AddMachines(params.AddMachines{
@@ -318,6 +346,7 @@ type mockState struct {
 	calls            int
 	machineTemplates []state.MachineTemplate
 	machines         map[string]*mockMachine
+	volumes          map[string]*mockVolume
 	err              error
 	blockMsg         string
 	block            state.BlockType
@@ -385,6 +414,32 @@ func (st *mockState) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageA
 	return nil, nil
 }
 
+func (st *mockState) MachineVolumeAttachments(tag names.MachineTag) ([]state.VolumeAttachment, error) {
+	var ids []string
+	for id := range st.volumes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var attachments []state.VolumeAttachment
+	for _, id := range ids {
+		if v := st.volumes[id]; v.machine == tag.Id() {
+			attachments = append(attachments, &mockVolumeAttachment{
+				volume:      names.NewVolumeTag(id),
+				provisioned: v.info != nil,
+				readOnly:    v.readOnly,
+			})
+		}
+	}
+	return attachments, nil
+}
+
+func (st *mockState) Volume(tag names.VolumeTag) (state.Volume, error) {
+	if v, ok := st.volumes[tag.Id()]; ok {
+		return v, nil
+	}
+	return nil, errors.NotFoundf("volume %v", tag.Id())
+}
+
 type mockBlock struct {
 	state.Block
 	t state.BlockType
@@ -417,6 +472,15 @@ type mockMachine struct {
 
 	keep   bool
 	series string
+	instId instance.Id
+	manual bool
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instId == "" {
+		return "", errors.NotProvisionedf("machine")
+	}
+	return m.instId, nil
 }
 
 func (m *mockMachine) Destroy() error {
@@ -437,6 +501,17 @@ func (m *mockMachine) Series() string {
 	return m.series
 }
 
+func (m *mockMachine) IsManual() (bool, error) {
+	return m.manual, nil
+}
+
+func (m *mockMachine) ProvisionedNonce() string {
+	if m.instId == "" {
+		return ""
+	}
+	return "nonce-" + string(m.instId)
+}
+
 func (m *mockMachine) Units() ([]machinemanager.Unit, error) {
 	return []machinemanager.Unit{
 		&mockUnit{names.NewUnitTag("foo/0")},
@@ -489,6 +564,16 @@ func (a *mockStorageAttachment) StorageInstance() names.StorageTag {
 type mockVolume struct {
 	state.Volume
 	detachable bool
+	machine    string
+	readOnly   bool
+	info       *state.VolumeInfo
+}
+
+func (v *mockVolume) Info() (state.VolumeInfo, error) {
+	if v.info == nil {
+		return state.VolumeInfo{}, errors.NotProvisionedf("volume")
+	}
+	return *v.info, nil
 }
 
 func (v *mockVolume) Detachable() bool {
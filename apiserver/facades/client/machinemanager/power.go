@@ -0,0 +1,93 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+// StartMachines starts the previously stopped instances of the
+// given machines.
+func (mm *MachineManagerAPIV5) StartMachines(args params.Entities) (params.ErrorResults, error) {
+	return mm.powerMachines(args, environs.InstancePowerManager.PowerOnInstances)
+}
+
+// StopMachines stops the instances of the given machines, without
+// removing the machines from the model, so that they may be started
+// again later with StartMachines.
+func (mm *MachineManagerAPIV5) StopMachines(args params.Entities) (params.ErrorResults, error) {
+	return mm.powerMachines(args, environs.InstancePowerManager.PowerOffInstances)
+}
+
+// RebootMachines reboots the instances of the given machines.
+func (mm *MachineManagerAPIV5) RebootMachines(args params.Entities) (params.ErrorResults, error) {
+	return mm.powerMachines(args, environs.InstancePowerManager.RebootInstances)
+}
+
+func (mm *MachineManagerAPIV5) powerMachines(
+	args params.Entities,
+	power func(environs.InstancePowerManager, ...instance.Id) error,
+) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if err := mm.checkCanWrite(); err != nil {
+		return results, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	powerManager, err := mm.powerManager()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		instId, err := mm.machineInstanceId(entity.Tag)
+		if err == nil {
+			err = power(powerManager, instId)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// powerManager returns the model's environ as an InstancePowerManager,
+// or an error satisfying errors.IsNotSupported if the provider cannot
+// stop and start instances without destroying them.
+func (mm *MachineManagerAPIV5) powerManager() (environs.InstancePowerManager, error) {
+	backend, err := mm.environConfigGetter()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env, err := mm.getEnviron(backend, environs.New)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	powerManager, ok := env.(environs.InstancePowerManager)
+	if !ok {
+		return nil, errors.NotSupportedf("stopping and starting machines on this cloud")
+	}
+	return powerManager, nil
+}
+
+func (mm *MachineManagerAPIV5) machineInstanceId(tag string) (instance.Id, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if names.IsContainerMachine(machineTag.Id()) {
+		return "", errors.NotSupportedf("stopping and starting containers")
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return machine.InstanceId()
+}
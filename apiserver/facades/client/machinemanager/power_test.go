@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type PowerSuite struct {
+	coretesting.BaseSuite
+	authorizer *apiservertesting.FakeAuthorizer
+	st         *mockState
+	env        *mockPowerEnviron
+}
+
+var _ = gc.Suite(&PowerSuite{})
+
+func (s *PowerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.st = &mockState{machines: map[string]*mockMachine{
+		"0":       {instId: "inst-0"},
+		"1":       {instId: "inst-1"},
+		"2":       {},
+		"0/lxd/0": {instId: "juju-0-lxd-0"},
+	}}
+	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	s.env = &mockPowerEnviron{}
+}
+
+func (s *PowerSuite) api(c *gc.C, env environs.Environ) *machinemanager.MachineManagerAPIV5 {
+	api, err := machinemanager.NewMachineManagerAPI(s.st, &mockPool{}, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	return machinemanager.NewMachineManagerAPIV5(api, getEnviron)
+}
+
+func (s *PowerSuite) entities() params.Entities {
+	return params.Entities{Entities: []params.Entity{
+		{Tag: "machine-0"},
+		{Tag: "machine-1"},
+		{Tag: "machine-2"},
+		{Tag: "machine-3"},
+		{Tag: "machine-0-lxd-0"},
+		{Tag: "application-mysql"},
+	}}
+}
+
+func (s *PowerSuite) assertPowerResults(c *gc.C, results params.ErrorResults) {
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "instance-fail failed"}},
+		{Error: &params.Error{Message: "machine not provisioned", Code: params.CodeNotProvisioned}},
+		{Error: &params.Error{Message: "machine 3 not found", Code: params.CodeNotFound}},
+		{Error: &params.Error{Message: "stopping and starting containers not supported", Code: params.CodeNotSupported}},
+		{Error: &params.Error{Message: `"application-mysql" is not a valid machine tag`}},
+	}})
+}
+
+func (s *PowerSuite) TestStartMachines(c *gc.C) {
+	s.env.SetErrors(nil, errors.New("instance-fail failed"))
+	results, err := s.api(c, s.env).StartMachines(s.entities())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPowerResults(c, results)
+	s.env.CheckCalls(c, []jujutesting.StubCall{
+		{"PowerOnInstances", []interface{}{[]instance.Id{"inst-0"}}},
+		{"PowerOnInstances", []interface{}{[]instance.Id{"inst-1"}}},
+	})
+}
+
+func (s *PowerSuite) TestStopMachines(c *gc.C) {
+	s.env.SetErrors(nil, errors.New("instance-fail failed"))
+	results, err := s.api(c, s.env).StopMachines(s.entities())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPowerResults(c, results)
+	s.env.CheckCalls(c, []jujutesting.StubCall{
+		{"PowerOffInstances", []interface{}{[]instance.Id{"inst-0"}}},
+		{"PowerOffInstances", []interface{}{[]instance.Id{"inst-1"}}},
+	})
+}
+
+func (s *PowerSuite) TestRebootMachines(c *gc.C) {
+	s.env.SetErrors(nil, errors.New("instance-fail failed"))
+	results, err := s.api(c, s.env).RebootMachines(s.entities())
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPowerResults(c, results)
+	s.env.CheckCalls(c, []jujutesting.StubCall{
+		{"RebootInstances", []interface{}{[]instance.Id{"inst-0"}}},
+		{"RebootInstances", []interface{}{[]instance.Id{"inst-1"}}},
+	})
+}
+
+func (s *PowerSuite) TestStopMachinesNotSupported(c *gc.C) {
+	_, err := s.api(c, &mockEnviron{}).StopMachines(s.entities())
+	c.Assert(err, gc.ErrorMatches, "stopping and starting machines on this cloud not supported")
+}
+
+func (s *PowerSuite) TestStopMachinesPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.api(c, s.env).StopMachines(s.entities())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.env.CheckNoCalls(c)
+}
+
+func (s *PowerSuite) TestStopMachinesBlocked(c *gc.C) {
+	s.st.block = state.ChangeBlock
+	s.st.blockMsg = "TestStopMachinesBlocked"
+	_, err := s.api(c, s.env).StopMachines(s.entities())
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "TestStopMachinesBlocked")
+	s.env.CheckNoCalls(c)
+}
+
+type mockPowerEnviron struct {
+	environs.Environ
+	jujutesting.Stub
+}
+
+func (e *mockPowerEnviron) PowerOffInstances(ids ...instance.Id) error {
+	e.MethodCall(e, "PowerOffInstances", ids)
+	return e.NextErr()
+}
+
+func (e *mockPowerEnviron) PowerOnInstances(ids ...instance.Id) error {
+	e.MethodCall(e, "PowerOnInstances", ids)
+	return e.NextErr()
+}
+
+func (e *mockPowerEnviron) RebootInstances(ids ...instance.Id) error {
+	e.MethodCall(e, "RebootInstances", ids)
+	return e.NextErr()
+}
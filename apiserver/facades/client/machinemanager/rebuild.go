@@ -0,0 +1,167 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/storage"
+)
+
+type instanceConfigFunc func(machineId, nonce string) (*instancecfg.InstanceConfig, error)
+
+// RebuildMachine reprovisions the instances of the given machines
+// with a fresh image of the requested series, or of their current
+// series if none is given. The Juju-managed volumes attached to each
+// machine are detached before the instance is rebuilt and reattached
+// afterwards, so that the machine's units keep their storage.
+func (mm *MachineManagerAPIV6) RebuildMachine(args params.UpdateSeriesArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if err := mm.checkCanWrite(); err != nil {
+		return results, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	rebuilder, err := mm.rebuilder()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		err := mm.rebuildOneMachine(rebuilder, arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// rebuilder returns the model's environ as an InstanceRebuilder, or
+// an error satisfying errors.IsNotSupported if the provider cannot
+// rebuild instances in place.
+func (mm *MachineManagerAPIV6) rebuilder() (environs.InstanceRebuilder, error) {
+	backend, err := mm.environConfigGetter()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env, err := mm.getEnviron(backend, environs.New)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rebuilder, ok := env.(environs.InstanceRebuilder)
+	if !ok {
+		return nil, errors.NotSupportedf("rebuilding machines on this cloud")
+	}
+	return rebuilder, nil
+}
+
+func (mm *MachineManagerAPIV6) rebuildOneMachine(rebuilder environs.InstanceRebuilder, arg params.UpdateSeriesArg) error {
+	machineTag, err := names.ParseMachineTag(arg.Entity.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if names.IsContainerMachine(machineTag.Id()) {
+		return errors.NotSupportedf("rebuilding containers")
+	}
+	machine, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	manual, err := machine.IsManual()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if manual {
+		return errors.NotSupportedf("rebuilding manually provisioned machines")
+	}
+	instId, err := machine.InstanceId()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumes, err := mm.machineVolumes(machineTag, instId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	oldSeries := machine.Series()
+	series := arg.Series
+	if series == "" {
+		series = oldSeries
+	}
+	if series != oldSeries {
+		if err := machine.UpdateMachineSeries(series, arg.Force); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	err = mm.rebuildInstance(rebuilder, machineTag, instId, machine.ProvisionedNonce(), volumes)
+	if err != nil && series != oldSeries {
+		// The instance still runs the old series, so restore it.
+		if err := machine.UpdateMachineSeries(oldSeries, true); err != nil {
+			logger.Errorf("cannot restore series of machine %s: %v", machineTag.Id(), err)
+		}
+	}
+	return errors.Annotatef(err, "cannot rebuild machine %s", machineTag.Id())
+}
+
+func (mm *MachineManagerAPIV6) rebuildInstance(
+	rebuilder environs.InstanceRebuilder,
+	machineTag names.MachineTag,
+	instId instance.Id,
+	nonce string,
+	volumes []storage.VolumeAttachmentParams,
+) error {
+	instanceConfig, err := mm.instanceConfig(machineTag.Id(), nonce)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return rebuilder.RebuildInstance(environs.RebuildInstanceParams{
+		InstanceId:     instId,
+		InstanceConfig: instanceConfig,
+		Volumes:        volumes,
+	})
+}
+
+// machineVolumes returns the parameters of the provisioned volume
+// attachments of the machine with the given tag.
+func (mm *MachineManagerAPIV6) machineVolumes(machineTag names.MachineTag, instId instance.Id) ([]storage.VolumeAttachmentParams, error) {
+	attachments, err := mm.st.MachineVolumeAttachments(machineTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var volumes []storage.VolumeAttachmentParams
+	for _, attachment := range attachments {
+		attachmentInfo, err := attachment.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volume, err := mm.st.Volume(attachment.Volume())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumeInfo, err := volume.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumes = append(volumes, storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Machine:    machineTag,
+				InstanceId: instId,
+				ReadOnly:   attachmentInfo.ReadOnly,
+			},
+			Volume:   attachment.Volume(),
+			VolumeId: volumeInfo.VolumeId,
+		})
+	}
+	return volumes, nil
+}
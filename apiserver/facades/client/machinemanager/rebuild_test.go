@@ -0,0 +1,240 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RebuildSuite struct {
+	coretesting.BaseSuite
+	authorizer *apiservertesting.FakeAuthorizer
+	st         *mockState
+	env        *mockRebuildEnviron
+}
+
+var _ = gc.Suite(&RebuildSuite{})
+
+func (s *RebuildSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.st = &mockState{
+		machines: map[string]*mockMachine{
+			"0":       {instId: "inst-0", series: "trusty"},
+			"1":       {instId: "inst-1", series: "trusty"},
+			"2":       {series: "trusty"},
+			"3":       {instId: "manual:10.0.0.1", series: "trusty", manual: true},
+			"0/lxd/0": {instId: "juju-0-lxd-0", series: "trusty"},
+		},
+		volumes: map[string]*mockVolume{
+			"0": {machine: "0", info: &state.VolumeInfo{VolumeId: "vol-0"}},
+			"1": {machine: "0", readOnly: true, info: &state.VolumeInfo{VolumeId: "vol-1"}},
+			"2": {machine: "0"},
+			"3": {machine: "1", info: &state.VolumeInfo{VolumeId: "vol-3"}},
+		},
+	}
+	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	s.env = &mockRebuildEnviron{}
+}
+
+func (s *RebuildSuite) api(c *gc.C, env environs.Environ) *machinemanager.MachineManagerAPIV6 {
+	api, err := machinemanager.NewMachineManagerAPI(s.st, &mockPool{}, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	instanceConfig := func(machineId, nonce string) (*instancecfg.InstanceConfig, error) {
+		return &instancecfg.InstanceConfig{
+			MachineId:    machineId,
+			MachineNonce: nonce,
+			Series:       s.st.machines[machineId].series,
+		}, nil
+	}
+	return machinemanager.NewMachineManagerAPIV6(api, getEnviron, instanceConfig)
+}
+
+func rebuildArg(tag, series string) params.UpdateSeriesArg {
+	return params.UpdateSeriesArg{
+		Entity: params.Entity{Tag: tag},
+		Series: series,
+	}
+}
+
+func (s *RebuildSuite) TestRebuildMachine(c *gc.C) {
+	s.env.SetErrors(nil, errors.New("boom"))
+	results, err := s.api(c, s.env).RebuildMachine(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{
+			rebuildArg("machine-0", ""),
+			rebuildArg("machine-1", ""),
+			rebuildArg("machine-2", ""),
+			rebuildArg("machine-3", ""),
+			rebuildArg("machine-4", ""),
+			rebuildArg("machine-0-lxd-0", ""),
+			rebuildArg("application-mysql", ""),
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "cannot rebuild machine 1: boom"}},
+		{Error: &params.Error{Message: "machine not provisioned", Code: params.CodeNotProvisioned}},
+		{Error: &params.Error{Message: "rebuilding manually provisioned machines not supported", Code: params.CodeNotSupported}},
+		{Error: &params.Error{Message: "machine 4 not found", Code: params.CodeNotFound}},
+		{Error: &params.Error{Message: "rebuilding containers not supported", Code: params.CodeNotSupported}},
+		{Error: &params.Error{Message: `"application-mysql" is not a valid machine tag`}},
+	}})
+
+	machine0 := names.NewMachineTag("0")
+	s.env.CheckCalls(c, []jujutesting.StubCall{
+		{"RebuildInstance", []interface{}{environs.RebuildInstanceParams{
+			InstanceId: "inst-0",
+			InstanceConfig: &instancecfg.InstanceConfig{
+				MachineId:    "0",
+				MachineNonce: "nonce-inst-0",
+				Series:       "trusty",
+			},
+			Volumes: []storage.VolumeAttachmentParams{{
+				AttachmentParams: storage.AttachmentParams{
+					Machine:    machine0,
+					InstanceId: "inst-0",
+				},
+				Volume:   names.NewVolumeTag("0"),
+				VolumeId: "vol-0",
+			}, {
+				AttachmentParams: storage.AttachmentParams{
+					Machine:    machine0,
+					InstanceId: "inst-0",
+					ReadOnly:   true,
+				},
+				Volume:   names.NewVolumeTag("1"),
+				VolumeId: "vol-1",
+			}},
+		}}},
+		{"RebuildInstance", []interface{}{environs.RebuildInstanceParams{
+			InstanceId: "inst-1",
+			InstanceConfig: &instancecfg.InstanceConfig{
+				MachineId:    "1",
+				MachineNonce: "nonce-inst-1",
+				Series:       "trusty",
+			},
+			Volumes: []storage.VolumeAttachmentParams{{
+				AttachmentParams: storage.AttachmentParams{
+					Machine:    names.NewMachineTag("1"),
+					InstanceId: "inst-1",
+				},
+				Volume:   names.NewVolumeTag("3"),
+				VolumeId: "vol-3",
+			}},
+		}}},
+	})
+	s.st.machines["0"].CheckCallNames(c, "Series")
+}
+
+func (s *RebuildSuite) TestRebuildMachineNewSeries(c *gc.C) {
+	results, err := s.api(c, s.env).RebuildMachine(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: "machine-1"},
+			Series: "xenial",
+			Force:  true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.st.machines["1"].CheckCalls(c, []jujutesting.StubCall{
+		{"Series", nil},
+		{"UpdateMachineSeries", []interface{}{"xenial", true}},
+	})
+	s.env.CheckCallNames(c, "RebuildInstance")
+}
+
+func (s *RebuildSuite) TestRebuildMachineNewSeriesRestoredOnFailure(c *gc.C) {
+	s.env.SetErrors(errors.New("boom"))
+	results, err := s.api(c, s.env).RebuildMachine(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{rebuildArg("machine-1", "xenial")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "cannot rebuild machine 1: boom")
+	s.st.machines["1"].CheckCalls(c, []jujutesting.StubCall{
+		{"Series", nil},
+		{"UpdateMachineSeries", []interface{}{"xenial", false}},
+		{"UpdateMachineSeries", []interface{}{"trusty", true}},
+	})
+}
+
+func (s *RebuildSuite) TestRebuildMachineUpdateSeriesFails(c *gc.C) {
+	s.st.machines["1"].SetErrors(errors.New("series not supported by charm"))
+	results, err := s.api(c, s.env).RebuildMachine(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{rebuildArg("machine-1", "xenial")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "series not supported by charm")
+	s.env.CheckNoCalls(c)
+}
+
+func (s *RebuildSuite) TestRebuildMachineNotSupported(c *gc.C) {
+	_, err := s.api(c, &mockEnviron{}).RebuildMachine(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{rebuildArg("machine-0", "")},
+	})
+	c.Assert(err, gc.ErrorMatches, "rebuilding machines on this cloud not supported")
+}
+
+func (s *RebuildSuite) TestRebuildMachinePermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.api(c, s.env).RebuildMachine(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{rebuildArg("machine-0", "")},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.env.CheckNoCalls(c)
+}
+
+func (s *RebuildSuite) TestRebuildMachineBlocked(c *gc.C) {
+	s.st.block = state.ChangeBlock
+	s.st.blockMsg = "TestRebuildMachineBlocked"
+	_, err := s.api(c, s.env).RebuildMachine(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{rebuildArg("machine-0", "")},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, "TestRebuildMachineBlocked")
+	s.env.CheckNoCalls(c)
+}
+
+type mockRebuildEnviron struct {
+	environs.Environ
+	jujutesting.Stub
+}
+
+func (e *mockRebuildEnviron) RebuildInstance(args environs.RebuildInstanceParams) error {
+	e.MethodCall(e, "RebuildInstance", args)
+	return e.NextErr()
+}
+
+type mockVolumeAttachment struct {
+	state.VolumeAttachment
+	volume      names.VolumeTag
+	provisioned bool
+	readOnly    bool
+}
+
+func (a *mockVolumeAttachment) Volume() names.VolumeTag {
+	return a.volume
+}
+
+func (a *mockVolumeAttachment) Info() (state.VolumeAttachmentInfo, error) {
+	if !a.provisioned {
+		return state.VolumeAttachmentInfo{}, errors.NotProvisionedf("volume attachment")
+	}
+	return state.VolumeAttachmentInfo{ReadOnly: a.readOnly}, nil
+}
@@ -25,6 +25,8 @@ type Backend interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	MachineVolumeAttachments(names.MachineTag) ([]state.VolumeAttachment, error)
+	Volume(names.VolumeTag) (state.Volume, error)
 }
 
 type Pool interface {
@@ -44,9 +46,12 @@ type Machine interface {
 	Destroy() error
 	ForceDestroy() error
 	Series() string
+	InstanceId() (instance.Id, error)
 	Units() ([]Unit, error)
 	SetKeepInstance(keepInstance bool) error
 	UpdateMachineSeries(string, bool) error
+	IsManual() (bool, error)
+	ProvisionedNonce() string
 }
 
 type stateShim struct {
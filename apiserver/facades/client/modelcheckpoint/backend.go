@@ -0,0 +1,42 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcheckpoint
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// modelcheckpoint facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	CreateModelCheckpoint(name string) (*state.ModelCheckpoint, error)
+	AllModelCheckpoints() ([]*state.ModelCheckpoint, error)
+	DiffModelCheckpoint(name string) (*state.ModelCheckpointDiff, error)
+	RollbackModelCheckpointConfig(name string) error
+	RemoveModelCheckpoint(name string) error
+}
+
+// BlockChecker defines the block-checking functionality required by
+// the modelcheckpoint facade. This is implemented by
+// apiserver/common.BlockChecker.
+type BlockChecker interface {
+	ChangeAllowed() error
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
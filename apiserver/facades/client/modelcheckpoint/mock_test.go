@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcheckpoint_test
+
+import (
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+	modelUUID   string
+	checkpoints []*state.ModelCheckpoint
+	diff        *state.ModelCheckpointDiff
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag(m.modelUUID)
+}
+
+func (m *mockBackend) CreateModelCheckpoint(name string) (*state.ModelCheckpoint, error) {
+	m.MethodCall(m, "CreateModelCheckpoint", name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return &state.ModelCheckpoint{Name: name}, nil
+}
+
+func (m *mockBackend) AllModelCheckpoints() ([]*state.ModelCheckpoint, error) {
+	m.MethodCall(m, "AllModelCheckpoints")
+	return m.checkpoints, m.NextErr()
+}
+
+func (m *mockBackend) DiffModelCheckpoint(name string) (*state.ModelCheckpointDiff, error) {
+	m.MethodCall(m, "DiffModelCheckpoint", name)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.diff, nil
+}
+
+func (m *mockBackend) RollbackModelCheckpointConfig(name string) error {
+	m.MethodCall(m, "RollbackModelCheckpointConfig", name)
+	return m.NextErr()
+}
+
+func (m *mockBackend) RemoveModelCheckpoint(name string) error {
+	m.MethodCall(m, "RemoveModelCheckpoint", name)
+	return m.NextErr()
+}
+
+type mockBlockChecker struct {
+	jtesting.Stub
+}
+
+func (c *mockBlockChecker) ChangeAllowed() error {
+	c.MethodCall(c, "ChangeAllowed")
+	return c.NextErr()
+}
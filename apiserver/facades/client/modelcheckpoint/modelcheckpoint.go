@@ -0,0 +1,203 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelcheckpoint provides the ModelCheckpoint facade, which
+// records named checkpoints of a model's applications, charms, config
+// and relations, compares the model against them, and rolls
+// application config back to them.
+package modelcheckpoint
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// API provides the ModelCheckpoint API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      BlockChecker
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(NewStateBackend(ctx.State()), ctx.Auth(), common.NewBlockChecker(ctx.State()))
+}
+
+// NewAPI returns a new ModelCheckpoint API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, blockChecker BlockChecker) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      blockChecker,
+	}, nil
+}
+
+func (api *API) checkPermission(tag names.Tag, perm permission.Access) error {
+	allowed, err := api.authorizer.HasPermission(perm, tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (api *API) checkCanRead() error {
+	return api.checkPermission(api.backend.ModelTag(), permission.ReadAccess)
+}
+
+func (api *API) checkCanWrite() error {
+	return api.checkPermission(api.backend.ModelTag(), permission.WriteAccess)
+}
+
+// CreateCheckpoints records a checkpoint of the model under each of
+// the specified names.
+func (api *API) CreateCheckpoints(args params.ModelCheckpointNames) (params.ModelCheckpointResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ModelCheckpointResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ModelCheckpointResults{}, errors.Trace(err)
+	}
+	results := params.ModelCheckpointResults{
+		Results: make([]params.ModelCheckpointResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		checkpoint, err := api.backend.CreateModelCheckpoint(name)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = checkpointToParams(checkpoint)
+	}
+	return results, nil
+}
+
+// ListCheckpoints returns all of the checkpoints in the model, oldest
+// first.
+func (api *API) ListCheckpoints() (params.ModelCheckpointResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ModelCheckpointResults{}, errors.Trace(err)
+	}
+	checkpoints, err := api.backend.AllModelCheckpoints()
+	if err != nil {
+		return params.ModelCheckpointResults{}, errors.Trace(err)
+	}
+	results := params.ModelCheckpointResults{
+		Results: make([]params.ModelCheckpointResult, len(checkpoints)),
+	}
+	for i, checkpoint := range checkpoints {
+		results.Results[i].Result = checkpointToParams(checkpoint)
+	}
+	return results, nil
+}
+
+// DiffCheckpoints returns how the model has changed since each of the
+// specified checkpoints was created.
+func (api *API) DiffCheckpoints(args params.ModelCheckpointNames) (params.ModelCheckpointDiffResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ModelCheckpointDiffResults{}, errors.Trace(err)
+	}
+	results := params.ModelCheckpointDiffResults{
+		Results: make([]params.ModelCheckpointDiffResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		diff, err := api.backend.DiffModelCheckpoint(name)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = diffToParams(diff)
+	}
+	return results, nil
+}
+
+// RollbackConfig restores the charm config of the model's
+// applications to that recorded in each of the specified checkpoints.
+func (api *API) RollbackConfig(args params.ModelCheckpointNames) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		err := api.backend.RollbackModelCheckpointConfig(name)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveCheckpoints removes each of the specified checkpoints.
+func (api *API) RemoveCheckpoints(args params.ModelCheckpointNames) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		err := api.backend.RemoveModelCheckpoint(name)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func checkpointToParams(checkpoint *state.ModelCheckpoint) *params.ModelCheckpoint {
+	result := &params.ModelCheckpoint{
+		Name:         checkpoint.Name,
+		Created:      checkpoint.Created,
+		Applications: make([]params.ModelCheckpointApplication, len(checkpoint.Applications)),
+		Relations:    checkpoint.Relations,
+	}
+	for i, app := range checkpoint.Applications {
+		result.Applications[i] = params.ModelCheckpointApplication{
+			Name:     app.Name,
+			CharmURL: app.CharmURL,
+			Config:   app.Config,
+		}
+	}
+	return result
+}
+
+func diffToParams(diff *state.ModelCheckpointDiff) *params.ModelCheckpointDiff {
+	result := &params.ModelCheckpointDiff{
+		AddedApplications:   diff.AddedApplications,
+		RemovedApplications: diff.RemovedApplications,
+		AddedRelations:      diff.AddedRelations,
+		RemovedRelations:    diff.RemovedRelations,
+	}
+	for _, change := range diff.ChangedCharms {
+		result.ChangedCharms = append(result.ChangedCharms, params.CheckpointCharmChange{
+			Application:        change.Application,
+			CheckpointCharmURL: change.CheckpointCharmURL,
+			CurrentCharmURL:    change.CurrentCharmURL,
+		})
+	}
+	for _, change := range diff.ChangedConfig {
+		result.ChangedConfig = append(result.ChangedConfig, params.CheckpointConfigChange{
+			Application:     change.Application,
+			Key:             change.Key,
+			CheckpointValue: change.CheckpointValue,
+			CurrentValue:    change.CurrentValue,
+		})
+	}
+	return result
+}
@@ -0,0 +1,222 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcheckpoint_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelcheckpoint"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ModelCheckpointSuite struct {
+	testing.IsolationSuite
+	backend    mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	blocks     mockBlockChecker
+	api        *modelcheckpoint.API
+}
+
+var _ = gc.Suite(&ModelCheckpointSuite{})
+
+func (s *ModelCheckpointSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = mockBackend{
+		modelUUID: coretesting.ModelTag.Id(),
+	}
+	s.blocks = mockBlockChecker{}
+	api, err := modelcheckpoint.NewAPI(&s.backend, s.authorizer, &s.blocks)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *ModelCheckpointSuite) setUser(c *gc.C, user string) {
+	s.authorizer.Tag = names.NewUserTag(user)
+	api, err := modelcheckpoint.NewAPI(&s.backend, s.authorizer, &s.blocks)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *ModelCheckpointSuite) TestNewAPINonClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelcheckpoint.NewAPI(&s.backend, s.authorizer, &s.blocks)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ModelCheckpointSuite) TestCreateCheckpoints(c *gc.C) {
+	s.backend.SetErrors(nil, errors.AlreadyExistsf(`checkpoint "two"`))
+	results, err := s.api.CreateCheckpoints(params.ModelCheckpointNames{
+		Names: []string{"one", "two"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.Name, gc.Equals, "one")
+	c.Assert(results.Results[1].Result, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeAlreadyExists)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"CreateModelCheckpoint", []interface{}{"one"}},
+		{"CreateModelCheckpoint", []interface{}{"two"}},
+	})
+}
+
+func (s *ModelCheckpointSuite) TestCreateCheckpointsPermission(c *gc.C) {
+	s.setUser(c, "someone")
+	_, err := s.api.CreateCheckpoints(params.ModelCheckpointNames{
+		Names: []string{"one"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ModelCheckpointSuite) TestListCheckpoints(c *gc.C) {
+	created := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.checkpoints = []*state.ModelCheckpoint{{
+		Name:    "one",
+		Created: created,
+		Applications: []state.CheckpointApplication{{
+			Name:     "wordpress",
+			CharmURL: "cs:wordpress-3",
+			Config:   charm.Settings{"blog-title": "mine"},
+		}},
+		Relations: []string{"wordpress:db mysql:server"},
+	}}
+	results, err := s.api.ListCheckpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ModelCheckpointResults{
+		Results: []params.ModelCheckpointResult{{
+			Result: &params.ModelCheckpoint{
+				Name:    "one",
+				Created: created,
+				Applications: []params.ModelCheckpointApplication{{
+					Name:     "wordpress",
+					CharmURL: "cs:wordpress-3",
+					Config:   map[string]interface{}{"blog-title": "mine"},
+				}},
+				Relations: []string{"wordpress:db mysql:server"},
+			},
+		}},
+	})
+}
+
+func (s *ModelCheckpointSuite) TestListCheckpointsPermission(c *gc.C) {
+	s.setUser(c, "someone")
+	_, err := s.api.ListCheckpoints()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ModelCheckpointSuite) TestDiffCheckpoints(c *gc.C) {
+	s.backend.diff = &state.ModelCheckpointDiff{
+		AddedApplications: []string{"dummy"},
+		ChangedCharms: []state.CheckpointCharmChange{{
+			Application:        "mysql",
+			CheckpointCharmURL: "cs:mysql-1",
+			CurrentCharmURL:    "cs:mysql-2",
+		}},
+		ChangedConfig: []state.CheckpointConfigChange{{
+			Application:     "wordpress",
+			Key:             "blog-title",
+			CheckpointValue: "mine",
+		}},
+		RemovedRelations: []string{"wordpress:db mysql:server"},
+	}
+	s.backend.SetErrors(nil, errors.NotFoundf(`checkpoint "missing"`))
+	results, err := s.api.DiffCheckpoints(params.ModelCheckpointNames{
+		Names: []string{"one", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.ModelCheckpointDiffResult{
+		Result: &params.ModelCheckpointDiff{
+			AddedApplications: []string{"dummy"},
+			ChangedCharms: []params.CheckpointCharmChange{{
+				Application:        "mysql",
+				CheckpointCharmURL: "cs:mysql-1",
+				CurrentCharmURL:    "cs:mysql-2",
+			}},
+			ChangedConfig: []params.CheckpointConfigChange{{
+				Application:     "wordpress",
+				Key:             "blog-title",
+				CheckpointValue: "mine",
+			}},
+			RemovedRelations: []string{"wordpress:db mysql:server"},
+		},
+	})
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *ModelCheckpointSuite) TestRollbackConfig(c *gc.C) {
+	s.backend.SetErrors(errors.New("charms changed"))
+	results, err := s.api.RollbackConfig(params.ModelCheckpointNames{
+		Names: []string{"one", "two"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "charms changed")
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"RollbackModelCheckpointConfig", []interface{}{"one"}},
+		{"RollbackModelCheckpointConfig", []interface{}{"two"}},
+	})
+}
+
+func (s *ModelCheckpointSuite) TestRollbackConfigPermission(c *gc.C) {
+	s.setUser(c, "someone")
+	_, err := s.api.RollbackConfig(params.ModelCheckpointNames{
+		Names: []string{"one"},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ModelCheckpointSuite) TestRemoveCheckpoints(c *gc.C) {
+	results, err := s.api.RemoveCheckpoints(params.ModelCheckpointNames{
+		Names: []string{"one"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"RemoveModelCheckpoint", []interface{}{"one"}},
+	})
+}
+
+func (s *ModelCheckpointSuite) TestChangesBlocked(c *gc.C) {
+	args := params.ModelCheckpointNames{Names: []string{"one"}}
+	for _, call := range []func() error{
+		func() error {
+			_, err := s.api.CreateCheckpoints(args)
+			return err
+		},
+		func() error {
+			_, err := s.api.RollbackConfig(args)
+			return err
+		},
+		func() error {
+			_, err := s.api.RemoveCheckpoints(args)
+			return err
+		},
+	} {
+		s.blocks.SetErrors(common.OperationBlockedError("TestChangesBlocked"))
+		err := call()
+		c.Assert(err, gc.ErrorMatches, "TestChangesBlocked")
+		c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+	}
+	s.blocks.CheckCallNames(c, "ChangeAllowed", "ChangeAllowed", "ChangeAllowed")
+	s.backend.CheckNoCalls(c)
+}
@@ -4,9 +4,12 @@
 package modelconfig
 
 import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	names "gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/configwebhook"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
@@ -15,12 +18,14 @@ import (
 // allowing stubs to be created for testing.
 type Backend interface {
 	common.BlockGetter
+	configwebhook.Backend
 	ControllerTag() names.ControllerTag
 	ModelTag() names.ModelTag
 	ModelConfigValues() (config.ConfigValues, error)
 	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
 	SetSLA(level, owner string, credentials []byte) error
 	SLALevel() (string, error)
+	MachineSubnetCIDRs() (map[string][]string, error)
 }
 
 type stateShim struct {
@@ -45,6 +50,30 @@ func (st stateShim) ModelTag() names.ModelTag {
 	return m.ModelTag()
 }
 
+// MachineSubnetCIDRs returns the CIDRs of the subnets each machine in
+// the model has addresses in, keyed by machine id.
+func (st stateShim) MachineSubnetCIDRs() (map[string][]string, error) {
+	machines, err := st.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string][]string, len(machines))
+	for _, m := range machines {
+		addresses, err := m.AllAddresses()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs := set.NewStrings()
+		for _, address := range addresses {
+			if cidr := address.SubnetCIDR(); cidr != "" {
+				cidrs.Add(cidr)
+			}
+		}
+		result[m.Id()] = cidrs.SortedValues()
+	}
+	return result, nil
+}
+
 // NewStateBackend creates a backend for the facade to use.
 func NewStateBackend(m *state.Model) Backend {
 	return stateShim{m.State(), m}
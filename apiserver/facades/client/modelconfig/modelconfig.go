@@ -4,13 +4,19 @@
 package modelconfig
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/configwebhook"
+	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
@@ -24,6 +30,15 @@ func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*Mo
 	return NewModelConfigAPI(NewStateBackend(model), auth)
 }
 
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(st *state.State, resources facade.Resources, auth facade.Authorizer) (*ModelConfigAPIV2, error) {
+	api, err := NewFacade(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV2{api}, nil
+}
+
 // ModelConfigAPI is the endpoint which implements the model config facade.
 type ModelConfigAPI struct {
 	backend Backend
@@ -44,6 +59,22 @@ func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelCon
 	return client, nil
 }
 
+// ModelConfigAPIV2 is the endpoint which implements version 2 of the
+// model config facade, adding typed FAN networking configuration.
+type ModelConfigAPIV2 struct {
+	*ModelConfigAPI
+}
+
+// NewModelConfigAPIV2 creates a new instance of version 2 of the
+// ModelConfig Facade.
+func NewModelConfigAPIV2(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV2, error) {
+	api, err := NewModelConfigAPI(backend, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV2{api}, nil
+}
+
 func (c *ModelConfigAPI) checkCanWrite() error {
 	canWrite, err := c.auth.HasPermission(permission.WriteAccess, c.backend.ModelTag())
 	if err != nil {
@@ -81,6 +112,22 @@ func (c *ModelConfigAPI) canReadModel() error {
 	return nil
 }
 
+// checkWebhooks is a state.ValidateConfigFunc that consults the
+// controller's config webhooks about a model config change before it
+// is committed.
+func (c *ModelConfigAPI) checkWebhooks(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
+	var user string
+	if tag, ok := c.auth.GetAuthTag().(names.UserTag); ok {
+		user = tag.Id()
+	}
+	return configwebhook.Validate(c.backend, configwebhook.Request{
+		Kind:  state.ModelConfigWebhook,
+		User:  user,
+		Set:   updateAttrs,
+		Unset: removeAttrs,
+	})
+}
+
 // ModelGet implements the server-side part of the
 // model-config CLI command.
 func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
@@ -163,7 +210,7 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 
 	// Replace any deprecated attributes with their new values.
 	attrs := config.ProcessDeprecatedAttributes(args.Config)
-	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace)
+	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace, c.checkWebhooks)
 }
 
 // ModelUnset implements the server-side part of the
@@ -175,7 +222,7 @@ func (c *ModelConfigAPI) ModelUnset(args params.ModelUnset) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	return c.backend.UpdateModelConfig(nil, args.Keys)
+	return c.backend.UpdateModelConfig(nil, args.Keys, c.checkWebhooks)
 }
 
 // SetSLALevel sets the sla level on the model.
@@ -197,3 +244,119 @@ func (c *ModelConfigAPI) SLALevel() (params.StringResult, error) {
 	result.Result = level
 	return result, nil
 }
+
+// FanNetworking returns the FAN configuration of the model and the
+// method used to set up container networking.
+func (c *ModelConfigAPIV2) FanNetworking() (params.FanNetworkingConfig, error) {
+	result := params.FanNetworkingConfig{}
+	if err := c.canReadModel(); err != nil {
+		return result, errors.Trace(err)
+	}
+	values, err := c.backend.ModelConfigValues()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	fanLine, _ := values[config.FanConfig].Value.(string)
+	fans, err := network.ParseFanConfig(fanLine)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Fans = networkingcommon.FanConfigToFanConfigResult(fans).Fans
+	result.ContainerNetworkingMethod, _ = values[config.ContainerNetworkingMethod].Value.(string)
+	return result, nil
+}
+
+// SetFanNetworking validates the given FAN configuration and container
+// networking method, computes the FAN overlay segments every machine in
+// the model would get, and, unless it is a dry run, updates the model
+// config with them.
+func (c *ModelConfigAPIV2) SetFanNetworking(args params.SetFanNetworkingArgs) (params.SetFanNetworkingResult, error) {
+	result := params.SetFanNetworkingResult{}
+	if err := c.checkCanWrite(); err != nil {
+		return result, err
+	}
+	if !args.DryRun {
+		if err := c.check.ChangeAllowed(); err != nil {
+			return result, errors.Trace(err)
+		}
+	}
+	fans, err := validateFanNetworking(args.Config)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	machineSubnets, err := c.backend.MachineSubnetCIDRs()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Machines, err = machineFanOverlays(fans, machineSubnets)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if args.DryRun {
+		return result, nil
+	}
+	attrs := map[string]interface{}{
+		config.FanConfig:                 fans.String(),
+		config.ContainerNetworkingMethod: args.Config.ContainerNetworkingMethod,
+	}
+	if err := c.backend.UpdateModelConfig(attrs, nil, c.checkWebhooks); err != nil {
+		return params.SetFanNetworkingResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// validateFanNetworking checks the FAN configuration and container
+// networking method the same way model config validation does, and
+// returns the parsed FAN configuration.
+func validateFanNetworking(cfg params.FanNetworkingConfig) (network.FanConfig, error) {
+	fans, err := networkingcommon.FanConfigResultToFanConfig(params.FanConfigResult{Fans: cfg.Fans})
+	if err != nil {
+		return nil, errors.NewNotValid(err, "invalid FAN config")
+	}
+	// Round trip through the model config format so that the underlay
+	// and overlay sizes are checked as well.
+	if fans, err = network.ParseFanConfig(fans.String()); err != nil {
+		return nil, errors.NewNotValid(err, "")
+	}
+	switch cfg.ContainerNetworkingMethod {
+	case "fan":
+		if len(fans) == 0 {
+			return nil, errors.NotValidf("container networking method %q without FAN config", "fan")
+		}
+	case "provider", "local", "":
+	default:
+		return nil, errors.NotValidf("container networking method %q", cfg.ContainerNetworkingMethod)
+	}
+	return fans, nil
+}
+
+// machineFanOverlays returns, for each machine, the segments of the FAN
+// overlays corresponding to the subnets the machine is connected to.
+func machineFanOverlays(fans network.FanConfig, machineSubnets map[string][]string) ([]params.MachineFanOverlays, error) {
+	machineIds := make([]string, 0, len(machineSubnets))
+	for id := range machineSubnets {
+		machineIds = append(machineIds, id)
+	}
+	sort.Strings(machineIds)
+
+	result := make([]params.MachineFanOverlays, len(machineIds))
+	for i, id := range machineIds {
+		result[i].MachineTag = names.NewMachineTag(id).String()
+		for _, cidr := range machineSubnets[id] {
+			for _, fan := range fans {
+				overlay, err := network.CalculateOverlaySegment(cidr, fan)
+				if err != nil {
+					return nil, errors.Annotatef(err, "machine %s", id)
+				}
+				if overlay == nil {
+					continue
+				}
+				result[i].Segments = append(result[i].Segments, params.FanOverlaySegment{
+					Subnet:  cidr,
+					Overlay: overlay.String(),
+				})
+			}
+		}
+	}
+	return result, nil
+}
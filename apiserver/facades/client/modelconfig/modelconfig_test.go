@@ -4,12 +4,18 @@
 package modelconfig_test
 
 import (
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common/configwebhook"
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
@@ -202,16 +208,210 @@ func (s *modelconfigSuite) TestModelUnsetMissing(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+// addPolicyWebhook registers a model config webhook that rejects any
+// change to apt-mirror, and returns the requests posted to it.
+func (s *modelconfigSuite) addPolicyWebhook(c *gc.C) *[]configwebhook.Request {
+	var requests []configwebhook.Request
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body configwebhook.Request
+		err := json.NewDecoder(req.Body).Decode(&body)
+		c.Check(err, jc.ErrorIsNil)
+		requests = append(requests, body)
+		if _, ok := body.Set["apt-mirror"]; ok {
+			http.Error(w, "apt-mirror cannot be changed", http.StatusForbidden)
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { server.Close() })
+	s.backend.webhooks = []state.ConfigWebhook{{
+		Name: "policy",
+		URL:  server.URL,
+		CACert: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: server.TLS.Certificates[0].Certificate[0],
+		})),
+		Kinds: []state.ConfigWebhookKind{state.ModelConfigWebhook},
+	}}
+	return &requests
+}
+
+func (s *modelconfigSuite) TestModelSetConsultsWebhooks(c *gc.C) {
+	requests := s.addPolicyWebhook(c)
+	err := s.api.ModelSet(params.ModelSet{
+		Config: map[string]interface{}{"some-key": "value"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigValue(c, "some-key", "value")
+	c.Assert(*requests, jc.DeepEquals, []configwebhook.Request{{
+		Kind:      state.ModelConfigWebhook,
+		ModelUUID: "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		User:      "bruce",
+		Set:       map[string]interface{}{"some-key": "value"},
+	}})
+}
+
+func (s *modelconfigSuite) TestModelSetRejectedByWebhook(c *gc.C) {
+	s.addPolicyWebhook(c)
+	err := s.api.ModelSet(params.ModelSet{
+		Config: map[string]interface{}{"apt-mirror": "http://mirror.example.com"},
+	})
+	c.Assert(err, gc.ErrorMatches, `config change rejected by webhook "policy": apt-mirror cannot be changed`)
+	s.assertConfigValueMissing(c, "apt-mirror")
+}
+
+func (s *modelconfigSuite) TestModelUnsetConsultsWebhooks(c *gc.C) {
+	requests := s.addPolicyWebhook(c)
+	err := s.api.ModelUnset(params.ModelUnset{[]string{"ftp-proxy"}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertConfigValueMissing(c, "ftp-proxy")
+	c.Assert(*requests, gc.HasLen, 1)
+	c.Assert((*requests)[0].Unset, jc.DeepEquals, []string{"ftp-proxy"})
+}
+
 func (s *modelconfigSuite) TestSetSupportCredentals(c *gc.C) {
 	err := s.api.SetSLALevel(params.ModelSLA{params.ModelSLAInfo{"level", "bob"}, []byte("foobar")})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) newAPIV2(c *gc.C) *modelconfig.ModelConfigAPIV2 {
+	api, err := modelconfig.NewModelConfigAPIV2(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *modelconfigSuite) TestFanNetworking(c *gc.C) {
+	s.backend.cfg["fan-config"] = config.ConfigValue{"10.0.0.0/16=252.0.0.0/8", "model"}
+	s.backend.cfg["container-networking-method"] = config.ConfigValue{"fan", "model"}
+
+	result, err := s.newAPIV2(c).FanNetworking()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FanNetworkingConfig{
+		Fans: []params.FanConfigEntry{{
+			Underlay: "10.0.0.0/16",
+			Overlay:  "252.0.0.0/8",
+		}},
+		ContainerNetworkingMethod: "fan",
+	})
+}
+
+func (s *modelconfigSuite) TestFanNetworkingUnset(c *gc.C) {
+	result, err := s.newAPIV2(c).FanNetworking()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.FanNetworkingConfig{
+		Fans: []params.FanConfigEntry{},
+	})
+}
+
+func (s *modelconfigSuite) fanNetworkingArgs(dryRun bool) params.SetFanNetworkingArgs {
+	return params.SetFanNetworkingArgs{
+		Config: params.FanNetworkingConfig{
+			Fans: []params.FanConfigEntry{{
+				Underlay: "10.0.0.0/16",
+				Overlay:  "252.0.0.0/8",
+			}},
+			ContainerNetworkingMethod: "fan",
+		},
+		DryRun: dryRun,
+	}
+}
+
+func (s *modelconfigSuite) TestSetFanNetworking(c *gc.C) {
+	s.backend.machineSubnets = map[string][]string{
+		"0": {"10.0.5.0/24", "192.168.1.0/24"},
+		"1": {"192.168.1.0/24"},
+	}
+	result, err := s.newAPIV2(c).SetFanNetworking(s.fanNetworkingArgs(false))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SetFanNetworkingResult{
+		Machines: []params.MachineFanOverlays{{
+			MachineTag: "machine-0",
+			Segments: []params.FanOverlaySegment{{
+				Subnet:  "10.0.5.0/24",
+				Overlay: "252.5.0.0/16",
+			}},
+		}, {
+			MachineTag: "machine-1",
+		}},
+	})
+	s.assertConfigValue(c, "fan-config", "10.0.0.0/16=252.0.0.0/8")
+	s.assertConfigValue(c, "container-networking-method", "fan")
+}
+
+func (s *modelconfigSuite) TestSetFanNetworkingDryRun(c *gc.C) {
+	s.backend.machineSubnets = map[string][]string{
+		"0": {"10.0.5.0/24"},
+	}
+	result, err := s.newAPIV2(c).SetFanNetworking(s.fanNetworkingArgs(true))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Machines, gc.HasLen, 1)
+	c.Assert(result.Machines[0].Segments, jc.DeepEquals, []params.FanOverlaySegment{{
+		Subnet:  "10.0.5.0/24",
+		Overlay: "252.5.0.0/16",
+	}})
+	s.assertConfigValueMissing(c, "fan-config")
+	s.assertConfigValueMissing(c, "container-networking-method")
+}
+
+func (s *modelconfigSuite) TestSetFanNetworkingInvalid(c *gc.C) {
+	api := s.newAPIV2(c)
+	for i, test := range []struct {
+		config params.FanNetworkingConfig
+		err    string
+	}{{
+		config: params.FanNetworkingConfig{
+			Fans: []params.FanConfigEntry{{Underlay: "10.0.0.0/16", Overlay: "nope"}},
+		},
+		err: `invalid FAN config: invalid CIDR address: nope`,
+	}, {
+		config: params.FanNetworkingConfig{
+			Fans: []params.FanConfigEntry{{Underlay: "10.0.0.0/8", Overlay: "252.0.0.0/16"}},
+		},
+		err: `invalid FAN config, underlay mask must be larger than overlay: .*`,
+	}, {
+		config: params.FanNetworkingConfig{ContainerNetworkingMethod: "fan"},
+		err:    `container networking method "fan" without FAN config not valid`,
+	}, {
+		config: params.FanNetworkingConfig{ContainerNetworkingMethod: "magic"},
+		err:    `container networking method "magic" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := api.SetFanNetworking(params.SetFanNetworkingArgs{Config: test.config})
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+	s.assertConfigValueMissing(c, "fan-config")
+}
+
+func (s *modelconfigSuite) TestSetFanNetworkingBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestSetFanNetworkingBlocked")
+	api := s.newAPIV2(c)
+	_, err := api.SetFanNetworking(s.fanNetworkingArgs(false))
+	s.assertBlocked(c, err, "TestSetFanNetworkingBlocked")
+
+	// A dry run changes nothing, so it is allowed.
+	_, err = api.SetFanNetworking(s.fanNetworkingArgs(true))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelconfigSuite) TestSetFanNetworkingPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	api := s.newAPIV2(c)
+	_, err := api.FanNetworking()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.SetFanNetworking(s.fanNetworkingArgs(true))
+	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
+}
+
 type mockBackend struct {
-	cfg config.ConfigValues
-	old *config.Config
-	b   state.BlockType
-	msg string
+	cfg            config.ConfigValues
+	old            *config.Config
+	b              state.BlockType
+	msg            string
+	machineSubnets map[string][]string
+	webhooks       []state.ConfigWebhook
+}
+
+func (m *mockBackend) ConfigWebhooks() ([]state.ConfigWebhook, error) {
+	return m.webhooks, nil
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
@@ -257,6 +457,10 @@ func (m *mockBackend) SLALevel() (string, error) {
 	return "mock-level", nil
 }
 
+func (m *mockBackend) MachineSubnetCIDRs() (map[string][]string, error) {
+	return m.machineSubnets, nil
+}
+
 type mockBlock struct {
 	state.Block
 	t state.BlockType
@@ -756,7 +756,11 @@ func (st *mockState) ModelConfigDefaultValues() (config.ModelDefaultAttributes,
 func (st *mockState) UpdateModelConfigDefaultValues(update map[string]interface{}, remove []string, rspec *environs.RegionSpec) error {
 	st.MethodCall(st, "UpdateModelConfigDefaultValues", update, remove, rspec)
 	for k, v := range update {
-		if rspec != nil {
+		if rspec != nil && rspec.Region == "" {
+			adv := st.cfgDefaults[k]
+			adv.Cloud = v
+			st.cfgDefaults[k] = adv
+		} else if rspec != nil {
 			adv := st.cfgDefaults[k]
 			adv.Regions = append(adv.Regions, config.RegionDefaultValue{
 				Name:  rspec.Region,
@@ -788,6 +792,13 @@ func (st *mockState) UpdateModelConfigDefaultValues(update map[string]interface{
 	return nil
 }
 
+func (st *mockState) EffectiveModelConfigDefaults(rspec *environs.RegionSpec) (config.ConfigValues, error) {
+	st.MethodCall(st, "EffectiveModelConfigDefaults", rspec)
+	return config.ConfigValues{
+		"attr": {Value: "val++", Source: "region"},
+	}, st.NextErr()
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	st.MethodCall(st, "GetBlockForType", t)
 	if st.block == t {
@@ -37,7 +37,18 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
-// ModelManagerV4 defines the methods on the version 2 facade for the
+// ModelManagerV5 defines the methods on the version 5 facade for the
+// modelmanager API endpoint.
+type ModelManagerV5 interface {
+	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
+	DumpModels(args params.DumpModelRequest) params.StringResults
+	DumpModelsDB(args params.Entities) params.MapResults
+	ListModels(user params.Entity) (params.UserModelList, error)
+	DestroyModels(args params.DestroyModelsParams) (params.ErrorResults, error)
+	EffectiveModelDefaults(args params.CloudRegionSpecs) (params.EffectiveModelDefaultsResults, error)
+}
+
+// ModelManagerV4 defines the methods on the version 4 facade for the
 // modelmanager API endpoint.
 type ModelManagerV4 interface {
 	CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error)
@@ -81,10 +92,16 @@ type ModelManagerAPI struct {
 	model       common.Model
 }
 
-// ModelManagerAPIV2 provides a way to wrap the different calls between
+// ModelManagerAPIV4 provides a way to wrap the different calls between
+// version 4 and version 5 of the model manager API
+type ModelManagerAPIV4 struct {
+	*ModelManagerAPI
+}
+
+// ModelManagerAPIV3 provides a way to wrap the different calls between
 // version 3 and version 4 of the model manager API
 type ModelManagerAPIV3 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV4
 }
 
 // ModelManagerAPIV2 provides a way to wrap the different calls between
@@ -94,13 +111,14 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV4 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV5 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
 	_ ModelManagerV3 = (*ModelManagerAPIV3)(nil)
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV4 is used for API registration.
-func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV5 is used for API registration.
+func NewFacadeV5(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -128,6 +146,15 @@ func NewFacadeV4(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV4 is used for API registration.
+func NewFacadeV4(ctx facade.Context) (*ModelManagerAPIV4, error) {
+	v5, err := NewFacadeV5(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV4{v5}, nil
+}
+
 // NewFacadeV3 is used for API registration.
 func NewFacadeV3(ctx facade.Context) (*ModelManagerAPIV3, error) {
 	v4, err := NewFacadeV4(ctx)
@@ -1120,6 +1147,7 @@ func (m *ModelManagerAPI) ModelDefaults() (params.ModelDefaultsResult, error) {
 		settings := params.ModelDefaults{
 			Controller: val.Controller,
 			Default:    val.Default,
+			Cloud:      val.Cloud,
 		}
 		for _, v := range val.Regions {
 			settings.Regions = append(
@@ -1159,13 +1187,9 @@ func (m *ModelManagerAPI) setModelDefaults(args params.ModelDefaultValues) error
 		return errors.New("agent-version cannot have a default value")
 	}
 
-	var rspec *environs.RegionSpec
-	if args.CloudRegion != "" {
-		spec, err := m.makeRegionSpec(args.CloudTag, args.CloudRegion)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		rspec = spec
+	rspec, err := m.makeRegionSpec(args.CloudTag, args.CloudRegion)
+	if err != nil {
+		return errors.Trace(err)
 	}
 	return m.state.UpdateModelConfigDefaultValues(args.Config, nil, rspec)
 }
@@ -1182,15 +1206,11 @@ func (m *ModelManagerAPI) UnsetModelDefaults(args params.UnsetModelDefaults) (pa
 	}
 
 	for i, arg := range args.Keys {
-		var rspec *environs.RegionSpec
-		if arg.CloudRegion != "" {
-			spec, err := m.makeRegionSpec(arg.CloudTag, arg.CloudRegion)
-			if err != nil {
-				results.Results[i].Error = common.ServerError(
-					errors.Trace(err))
-				continue
-			}
-			rspec = spec
+		rspec, err := m.makeRegionSpec(arg.CloudTag, arg.CloudRegion)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(
+				errors.Trace(err))
+			continue
 		}
 		results.Results[i].Error = common.ServerError(
 			m.state.UpdateModelConfigDefaultValues(nil, arg.Keys, rspec),
@@ -1200,12 +1220,23 @@ func (m *ModelManagerAPI) UnsetModelDefaults(args params.UnsetModelDefaults) (pa
 }
 
 // makeRegionSpec is a helper method for methods that call
-// state.UpdateModelConfigDefaultValues.
+// state.UpdateModelConfigDefaultValues. It returns nil, meaning the
+// controller level, if no cloud is specified, and a spec for the whole
+// cloud if no region is.
 func (m *ModelManagerAPI) makeRegionSpec(cloudTag, r string) (*environs.RegionSpec, error) {
+	if cloudTag == "" {
+		if r != "" {
+			return nil, errors.NotValidf("region %q without cloud", r)
+		}
+		return nil, nil
+	}
 	cTag, err := names.ParseCloudTag(cloudTag)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if r == "" {
+		return &environs.RegionSpec{Cloud: cTag.Id()}, nil
+	}
 	rspec, err := environs.NewRegionSpec(cTag.Id(), r)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1213,6 +1244,78 @@ func (m *ModelManagerAPI) makeRegionSpec(cloudTag, r string) (*environs.RegionSp
 	return rspec, nil
 }
 
+// EffectiveModelDefaults returns, for each of the given cloud regions,
+// the default config values that a new model there would be created
+// with, along with the level each value comes from. A region value
+// takes precedence over a cloud value, which takes precedence over a
+// controller value.
+func (m *ModelManagerAPI) EffectiveModelDefaults(args params.CloudRegionSpecs) (params.EffectiveModelDefaultsResults, error) {
+	results := params.EffectiveModelDefaultsResults{
+		Results: make([]params.EffectiveModelDefaultsResult, len(args.Specs)),
+	}
+	if !m.isAdmin {
+		return results, common.ErrPerm
+	}
+	for i, spec := range args.Specs {
+		values, err := m.effectiveModelDefaults(spec)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Config = values
+	}
+	return results, nil
+}
+
+func (m *ModelManagerAPI) effectiveModelDefaults(spec params.CloudRegionSpec) (map[string]params.ConfigValue, error) {
+	rspec, err := m.makeRegionSpec(spec.CloudTag, spec.CloudRegion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if rspec == nil {
+		return nil, errors.NotValidf("empty cloud")
+	}
+	values, err := m.state.EffectiveModelConfigDefaults(rspec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]params.ConfigValue)
+	for attr, val := range values {
+		result[attr] = params.ConfigValue{
+			Value:  val.Value,
+			Source: val.Source,
+		}
+	}
+	return result, nil
+}
+
+// EffectiveModelDefaults isn't on the V4 API.
+func (m *ModelManagerAPIV4) EffectiveModelDefaults(_, _ struct{}) {}
+
+// SetModelDefaults writes new values for the specified default model
+// settings. Version 4 of the API has no cloud level defaults, so a
+// cloud without a region refers to the controller level, as before.
+func (m *ModelManagerAPIV4) SetModelDefaults(args params.SetModelDefaults) (params.ErrorResults, error) {
+	for i, arg := range args.Config {
+		if arg.CloudRegion == "" {
+			args.Config[i].CloudTag = ""
+		}
+	}
+	return m.ModelManagerAPI.SetModelDefaults(args)
+}
+
+// UnsetModelDefaults removes the specified default model settings.
+// Version 4 of the API has no cloud level defaults, so a cloud without
+// a region refers to the controller level, as before.
+func (m *ModelManagerAPIV4) UnsetModelDefaults(args params.UnsetModelDefaults) (params.ErrorResults, error) {
+	for i, arg := range args.Keys {
+		if arg.CloudRegion == "" {
+			args.Keys[i].CloudTag = ""
+		}
+	}
+	return m.ModelManagerAPI.UnsetModelDefaults(args)
+}
+
 // ModelStatus is a legacy method call to ensure that we preserve
 // backward compatibility.
 // TODO (anastasiamac 2017-10-26) This should be made obsolete/removed.
@@ -630,9 +630,103 @@ func (s *modelManagerSuite) TestUnsetModelDefaultsAsNormalUser(c *gc.C) {
 	c.Assert(cfg.Config["attr2"].Controller.(string), gc.Equals, "val3")
 }
 
+func (s *modelManagerSuite) TestSetModelDefaultsCloud(c *gc.C) {
+	result, err := s.api.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			CloudTag: "cloud-dummy",
+			Config:   map[string]interface{}{"attr": "cloudval"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.st.CheckCall(c, len(s.st.Calls())-1, "UpdateModelConfigDefaultValues",
+		map[string]interface{}{"attr": "cloudval"}, []string(nil),
+		&environs.RegionSpec{Cloud: "dummy"},
+	)
+	c.Assert(s.st.cfgDefaults["attr"].Cloud, gc.Equals, "cloudval")
+}
+
+func (s *modelManagerSuite) TestSetModelDefaultsRegionWithoutCloud(c *gc.C) {
+	result, err := s.api.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			CloudRegion: "dummy",
+			Config:      map[string]interface{}{"attr": "val"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `region "dummy" without cloud not valid`)
+}
+
+func (s *modelManagerSuite) TestUnsetModelDefaultsCloud(c *gc.C) {
+	result, err := s.api.UnsetModelDefaults(params.UnsetModelDefaults{
+		Keys: []params.ModelUnsetKeys{{
+			CloudTag: "cloud-dummy",
+			Keys:     []string{"attr"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.st.CheckCall(c, len(s.st.Calls())-1, "UpdateModelConfigDefaultValues",
+		map[string]interface{}(nil), []string{"attr"},
+		&environs.RegionSpec{Cloud: "dummy"},
+	)
+}
+
+func (s *modelManagerSuite) TestSetModelDefaultsCloudV4(c *gc.C) {
+	api := &modelmanager.ModelManagerAPIV4{s.api}
+	result, err := api.SetModelDefaults(params.SetModelDefaults{
+		Config: []params.ModelDefaultValues{{
+			CloudTag: "cloud-dummy",
+			Config:   map[string]interface{}{"attr": "ctrlval"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.st.CheckCall(c, len(s.st.Calls())-1, "UpdateModelConfigDefaultValues",
+		map[string]interface{}{"attr": "ctrlval"}, []string(nil),
+		(*environs.RegionSpec)(nil),
+	)
+	c.Assert(s.st.cfgDefaults["attr"].Controller, gc.Equals, "ctrlval")
+}
+
+func (s *modelManagerSuite) TestEffectiveModelDefaults(c *gc.C) {
+	result, err := s.api.EffectiveModelDefaults(params.CloudRegionSpecs{
+		Specs: []params.CloudRegionSpec{{
+			CloudTag:    "cloud-dummy",
+			CloudRegion: "dummy",
+		}, {
+			CloudTag: "cloud-dummy",
+		}, {
+			CloudRegion: "dummy",
+		}, {
+			CloudTag: "bad-tag",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0], jc.DeepEquals, params.EffectiveModelDefaultsResult{
+		Config: map[string]params.ConfigValue{
+			"attr": {Value: "val++", Source: "region"},
+		},
+	})
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `region "dummy" without cloud not valid`)
+	c.Assert(result.Results[3].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+	s.st.CheckCallNames(c, "ControllerTag", "ModelUUID",
+		"EffectiveModelConfigDefaults", "EffectiveModelConfigDefaults")
+	s.st.CheckCall(c, 2, "EffectiveModelConfigDefaults",
+		&environs.RegionSpec{Cloud: "dummy", Region: "dummy"})
+	s.st.CheckCall(c, 3, "EffectiveModelConfigDefaults",
+		&environs.RegionSpec{Cloud: "dummy"})
+}
+
+func (s *modelManagerSuite) TestEffectiveModelDefaultsAsNormalUser(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("charlie"))
+	_, err := s.api.EffectiveModelDefaults(params.CloudRegionSpecs{
+		Specs: []params.CloudRegionSpec{{CloudTag: "cloud-dummy"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV2{
-		&modelmanager.ModelManagerAPIV3{s.api},
+		&modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}},
 	}
 
 	results := api.DumpModels(params.Entities{[]params.Entity{{
@@ -812,7 +906,7 @@ func (s *modelManagerSuite) TestAddModelCantCreateModelForSomeoneElse(c *gc.C) {
 }
 
 func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV3{s.api}
+	api := &modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}}
 	results, err := api.DestroyModels(params.Entities{
 		Entities: []params.Entity{{coretesting.ModelTag.String()}},
 	})
@@ -1584,7 +1678,7 @@ func (s *modelManagerStateSuite) TestModifyModelAccessInvalidAction(c *gc.C) {
 
 func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV2{
-		&modelmanager.ModelManagerAPIV3{s.api},
+		&modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}},
 	}
 	// Check that we err out immediately if a model errs.
 	results, err := api.ModelStatus(params.Entities{[]params.Entity{{
@@ -1613,7 +1707,7 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 }
 
 func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV3{s.api}
+	api := &modelmanager.ModelManagerAPIV3{&modelmanager.ModelManagerAPIV4{s.api}}
 
 	// Check that we err out immediately if a model errs.
 	results, err := api.ModelStatus(params.Entities{[]params.Entity{{
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package prunestats provides the PruneStats facade, which reports on
+// the most recent pruning of a model's collections.
+package prunestats
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the prunestats
+// facade. For details on the methods, see the methods on state.State
+// with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	PruneStats() ([]state.PruneStats, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+// API provides the PruneStats API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new PruneStats API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkIsAdmin() error {
+	isAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// PruneStats returns the outcome of the most recent pruning of each
+// of the model's collections: when it was pruned, how many entries
+// were removed, and how much space was reclaimed.
+func (api *API) PruneStats() (params.PruneStatsResult, error) {
+	if err := api.checkIsAdmin(); err != nil {
+		return params.PruneStatsResult{}, errors.Trace(err)
+	}
+	stats, err := api.backend.PruneStats()
+	if err != nil {
+		return params.PruneStatsResult{}, errors.Trace(err)
+	}
+	result := params.PruneStatsResult{
+		Stats: make([]params.PruneStats, len(stats)),
+	}
+	for i, s := range stats {
+		result.Stats[i] = params.PruneStats{
+			Collection:  s.Collection,
+			Time:        s.Time,
+			Removed:     s.Removed,
+			ReclaimedMB: s.ReclaimedMB,
+		}
+	}
+	return result, nil
+}
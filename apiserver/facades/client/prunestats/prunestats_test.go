@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package prunestats_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/prunestats"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type PruneStatsSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&PruneStatsSuite{})
+
+func (s *PruneStatsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{}
+}
+
+func (s *PruneStatsSuite) TestNewAPINonClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := prunestats.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *PruneStatsSuite) TestPruneStats(c *gc.C) {
+	pruned := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.stats = []state.PruneStats{{
+		Collection:  "actions",
+		Time:        pruned,
+		Removed:     12,
+		ReclaimedMB: 0,
+	}, {
+		Collection:  "statuseshistory",
+		Time:        pruned.Add(time.Minute),
+		Removed:     3000,
+		ReclaimedMB: 2,
+	}}
+	api, err := prunestats.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.PruneStats()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.PruneStatsResult{
+		Stats: []params.PruneStats{{
+			Collection:  "actions",
+			Time:        pruned,
+			Removed:     12,
+			ReclaimedMB: 0,
+		}, {
+			Collection:  "statuseshistory",
+			Time:        pruned.Add(time.Minute),
+			Removed:     3000,
+			ReclaimedMB: 2,
+		}},
+	})
+}
+
+func (s *PruneStatsSuite) TestPruneStatsError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	api, err := prunestats.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.PruneStats()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *PruneStatsSuite) TestPruneStatsPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	api, err := prunestats.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.PruneStats()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	stats []state.PruneStats
+	err   error
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (m *mockBackend) PruneStats() ([]state.PruneStats, error) {
+	return m.stats, m.err
+}
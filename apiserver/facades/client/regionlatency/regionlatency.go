@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package regionlatency provides the RegionLatency facade, which
+// reports the latency of cloud regions' API endpoints as seen from
+// the controller, so that clients can choose the nearest region for
+// new models.
+package regionlatency
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+// cacheTTL is how long the results of probing a cloud's regions are
+// reused before the regions are probed again.
+const cacheTTL = 10 * time.Minute
+
+// latencyCache is shared by all instances of the facade, so that the
+// probe results are cached for the lifetime of the controller agent.
+var latencyCache = environs.NewRegionLatencyCache(clock.WallClock, cacheTTL, environs.DialLatency)
+
+// Backend defines the state functionality required by the
+// regionlatency facade.
+type Backend interface {
+	Cloud(name string) (cloud.Cloud, error)
+}
+
+// LatencyCache provides the, possibly cached, latencies of the
+// regions of a cloud.
+type LatencyCache interface {
+	RegionLatencies(cloud.Cloud) ([]environs.RegionLatency, time.Time)
+}
+
+// API provides the RegionLatency API facade for version 1.
+type API struct {
+	backend Backend
+	cache   LatencyCache
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(ctx.State(), latencyCache, ctx.Auth())
+}
+
+// NewAPI returns a new RegionLatency API facade.
+func NewAPI(backend Backend, cache LatencyCache, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		cache:   cache,
+	}, nil
+}
+
+// RegionLatencies returns the latency of each of the regions of the
+// given clouds, along with the nearest region of each cloud.
+func (api *API) RegionLatencies(args params.Entities) (params.RegionLatencyResults, error) {
+	results := params.RegionLatencyResults{
+		Results: make([]params.RegionLatencyResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		result, err := api.regionLatencies(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *API) regionLatencies(tag string) (params.RegionLatencyResult, error) {
+	cloudTag, err := names.ParseCloudTag(tag)
+	if err != nil {
+		return params.RegionLatencyResult{}, errors.Trace(err)
+	}
+	cloud, err := api.backend.Cloud(cloudTag.Id())
+	if err != nil {
+		return params.RegionLatencyResult{}, errors.Trace(err)
+	}
+	latencies, probed := api.cache.RegionLatencies(cloud)
+	result := params.RegionLatencyResult{
+		Regions:  make([]params.RegionLatency, len(latencies)),
+		ProbedAt: probed,
+	}
+	for i, latency := range latencies {
+		result.Regions[i] = params.RegionLatency{
+			Region:  latency.Region,
+			Latency: latency.Latency,
+			Error:   common.ServerError(latency.Error),
+		}
+	}
+	if nearest, err := environs.NearestRegion(latencies); err == nil {
+		result.Nearest = nearest
+	}
+	return result, nil
+}
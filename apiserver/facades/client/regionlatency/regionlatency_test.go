@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package regionlatency_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/regionlatency"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+type RegionLatencySuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	cache      *mockCache
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&RegionLatencySuite{})
+
+var probed = time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func (s *RegionLatencySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	}
+	s.backend = &mockBackend{
+		clouds: map[string]cloud.Cloud{
+			"dummy": {Name: "dummy"},
+		},
+	}
+	s.cache = &mockCache{
+		latencies: []environs.RegionLatency{
+			{Region: "far", Latency: 200 * time.Millisecond},
+			{Region: "near", Latency: 20 * time.Millisecond},
+			{Region: "down", Error: errors.New("connection refused")},
+		},
+	}
+}
+
+func (s *RegionLatencySuite) TestNewAPINonClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := regionlatency.NewAPI(s.backend, s.cache, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *RegionLatencySuite) TestRegionLatencies(c *gc.C) {
+	api, err := regionlatency.NewAPI(s.backend, s.cache, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.RegionLatencies(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "cloud-dummy"},
+			{Tag: "cloud-missing"},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.RegionLatencyResult{{
+		Regions: []params.RegionLatency{
+			{Region: "far", Latency: 200 * time.Millisecond},
+			{Region: "near", Latency: 20 * time.Millisecond},
+			{Region: "down", Error: &params.Error{Message: "connection refused"}},
+		},
+		Nearest:  "near",
+		ProbedAt: probed,
+	}, {
+		Error: &params.Error{Code: params.CodeNotFound, Message: `cloud "missing" not found`},
+	}, {
+		Error: &params.Error{Message: `"machine-0" is not a valid cloud tag`},
+	}})
+	c.Assert(s.cache.clouds, jc.DeepEquals, []string{"dummy"})
+}
+
+func (s *RegionLatencySuite) TestRegionLatenciesNoneReachable(c *gc.C) {
+	s.cache.latencies = s.cache.latencies[2:]
+	api, err := regionlatency.NewAPI(s.backend, s.cache, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := api.RegionLatencies(params.Entities{
+		Entities: []params.Entity{{Tag: "cloud-dummy"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Nearest, gc.Equals, "")
+}
+
+type mockBackend struct {
+	clouds map[string]cloud.Cloud
+}
+
+func (b *mockBackend) Cloud(name string) (cloud.Cloud, error) {
+	cloud, ok := b.clouds[name]
+	if !ok {
+		return cloud, errors.NotFoundf("cloud %q", name)
+	}
+	return cloud, nil
+}
+
+type mockCache struct {
+	clouds    []string
+	latencies []environs.RegionLatency
+}
+
+func (c *mockCache) RegionLatencies(cloud cloud.Cloud) ([]environs.RegionLatency, time.Time) {
+	c.clouds = append(c.clouds, cloud.Name)
+	return c.latencies, probed
+}
@@ -0,0 +1,195 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package removalplan
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// removalplan facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Application(name string) (Application, error)
+	Unit(name string) (Unit, error)
+	Machine(id string) (Machine, error)
+
+	// ApplicationOffers returns the names of the offers of the
+	// specified application.
+	ApplicationOffers(appName string) ([]string, error)
+
+	// UnitStorage returns the storage instances attached to the
+	// specified unit, split into those that would be destroyed and
+	// those that would be detached and left in the model if the
+	// unit were removed.
+	UnitStorage(unit names.UnitTag) (destroyed, detached []names.StorageTag, _ error)
+}
+
+// BlockChecker defines the block-checking functionality required by
+// the removalplan facade. This is implemented by
+// apiserver/common.BlockChecker.
+type BlockChecker interface {
+	RemoveAllowed() error
+}
+
+// Application defines the application functionality required by
+// the removalplan facade.
+type Application interface {
+	Name() string
+	AllUnits() ([]Unit, error)
+	Relations() ([]Relation, error)
+
+	// Destroy destroys the application, along with its units
+	// and relations and any offers of it.
+	Destroy() error
+}
+
+// Relation defines the relation functionality required by the
+// removalplan facade.
+type Relation interface {
+	Tag() names.Tag
+}
+
+// Unit defines the unit functionality required by the removalplan
+// facade.
+type Unit interface {
+	Name() string
+	ApplicationName() string
+	IsPrincipal() bool
+	SubordinateNames() []string
+	AssignedMachineId() (string, error)
+	Destroy() error
+}
+
+// Machine defines the machine functionality required by the
+// removalplan facade.
+type Machine interface {
+	Id() string
+	IsManager() bool
+	Principals() []string
+	Containers() ([]string, error)
+	Destroy() error
+}
+
+type stateShim struct {
+	*state.State
+	*state.IAASModel
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) (Backend, error) {
+	im, err := st.IAASModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return stateShim{
+		State:     st,
+		IAASModel: im,
+	}, nil
+}
+
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationShim{app, s.State}, nil
+}
+
+func (s stateShim) Unit(name string) (Unit, error) {
+	unit, err := s.State.Unit(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit, nil
+}
+
+func (s stateShim) Machine(id string) (Machine, error) {
+	m, err := s.State.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
+
+func (s stateShim) ApplicationOffers(appName string) ([]string, error) {
+	offers, err := state.NewApplicationOffers(s.State).ListOffers(crossmodel.ApplicationOfferFilter{
+		ApplicationName: appName,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]string, len(offers))
+	for i, offer := range offers {
+		result[i] = offer.OfferName
+	}
+	return result, nil
+}
+
+func (s stateShim) UnitStorage(unit names.UnitTag) (destroyed, detached []names.StorageTag, _ error) {
+	storage, err := storagecommon.UnitStorage(s, unit)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	destroyedEntities, detachedEntities, err := storagecommon.ClassifyDetachedStorage(s, storage)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for _, entity := range destroyedEntities {
+		tag, err := names.ParseStorageTag(entity.Tag)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		destroyed = append(destroyed, tag)
+	}
+	for _, entity := range detachedEntities {
+		tag, err := names.ParseStorageTag(entity.Tag)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		detached = append(detached, tag)
+	}
+	return destroyed, detached, nil
+}
+
+type applicationShim struct {
+	*state.Application
+	st *state.State
+}
+
+func (a applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, u := range units {
+		result[i] = u
+	}
+	return result, nil
+}
+
+func (a applicationShim) Relations() ([]Relation, error) {
+	relations, err := a.Application.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Relation, len(relations))
+	for i, rel := range relations {
+		result[i] = rel
+	}
+	return result, nil
+}
+
+func (a applicationShim) Destroy() error {
+	op := a.Application.DestroyOperation()
+	op.RemoveOffers = true
+	return a.st.ApplyOperation(op)
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package removalplan_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/removalplan"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+// executeSuite tests that removal plans can be executed against
+// real state.
+type executeSuite struct {
+	jujutesting.JujuConnSuite
+	api *removalplan.API
+}
+
+var _ = gc.Suite(&executeSuite{})
+
+func (s *executeSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	backend, err := removalplan.NewStateBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	authorizer := apiservertesting.FakeAuthorizer{Tag: s.AdminUserTag(c)}
+	s.api, err = removalplan.NewAPI(backend, authorizer, common.NewBlockChecker(s.State))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *executeSuite) planAndExecute(c *gc.C, tags ...names.Tag) {
+	planned, err := s.api.PlanRemoval(params.Entities{Entities: entities(tags...)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(planned.Error, gc.IsNil)
+	result, err := s.api.ExecuteRemovalPlan(*planned.Result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+}
+
+func (s *executeSuite) removeUnit(c *gc.C, unit *state.Unit) {
+	c.Assert(unit.Refresh(), jc.ErrorIsNil)
+	c.Assert(unit.Life(), gc.Equals, state.Dying)
+	c.Assert(unit.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(unit.Remove(), jc.ErrorIsNil)
+}
+
+func (s *executeSuite) assertMachineLife(c *gc.C, machine *state.Machine, life state.Life) {
+	c.Assert(machine.Refresh(), jc.ErrorIsNil)
+	c.Assert(machine.Life(), gc.Equals, life)
+}
+
+func (s *executeSuite) TestExecuteMachineWithUnits(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	app := s.Factory.MakeApplication(c, nil)
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: machine})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: machine})
+
+	s.planAndExecute(c, machine.Tag())
+
+	// The machine is destroyed when its last unit is removed.
+	s.assertMachineLife(c, machine, state.Alive)
+	s.removeUnit(c, unit0)
+	s.assertMachineLife(c, machine, state.Alive)
+	s.removeUnit(c, unit1)
+	s.assertMachineLife(c, machine, state.Dying)
+}
+
+func (s *executeSuite) TestExecuteApplication(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	app := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: machine})
+
+	s.planAndExecute(c, app.Tag())
+
+	c.Assert(app.Refresh(), jc.ErrorIsNil)
+	c.Assert(app.Life(), gc.Equals, state.Dying)
+	c.Assert(s.State.Cleanup(), jc.ErrorIsNil)
+	s.removeUnit(c, unit)
+	s.assertMachineLife(c, machine, state.Dying)
+}
+
+func (s *executeSuite) TestExecuteEmptyMachine(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+
+	s.planAndExecute(c, machine.Tag())
+
+	s.assertMachineLife(c, machine, state.Dying)
+}
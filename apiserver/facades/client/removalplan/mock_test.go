@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package removalplan_test
+
+import (
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/removalplan"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+	modelUUID    string
+	applications map[string]*mockApplication
+	units        map[string]*mockUnit
+	machines     map[string]*mockMachine
+	offers       map[string][]string
+	storage      map[string][2][]names.StorageTag
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return names.NewModelTag(m.modelUUID)
+}
+
+func (m *mockBackend) Application(name string) (removalplan.Application, error) {
+	app, ok := m.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+func (m *mockBackend) Unit(name string) (removalplan.Unit, error) {
+	unit, ok := m.units[name]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q", name)
+	}
+	return unit, nil
+}
+
+func (m *mockBackend) Machine(id string) (removalplan.Machine, error) {
+	machine, ok := m.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	return machine, nil
+}
+
+func (m *mockBackend) ApplicationOffers(appName string) ([]string, error) {
+	return m.offers[appName], nil
+}
+
+func (m *mockBackend) UnitStorage(unit names.UnitTag) (destroyed, detached []names.StorageTag, _ error) {
+	storage := m.storage[unit.Id()]
+	return storage[0], storage[1], nil
+}
+
+type mockApplication struct {
+	stub      *jtesting.Stub
+	name      string
+	units     []removalplan.Unit
+	relations []removalplan.Relation
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) AllUnits() ([]removalplan.Unit, error) {
+	return a.units, nil
+}
+
+func (a *mockApplication) Relations() ([]removalplan.Relation, error) {
+	return a.relations, nil
+}
+
+func (a *mockApplication) Destroy() error {
+	a.stub.MethodCall(a, "Destroy", a.name)
+	return a.stub.NextErr()
+}
+
+type mockRelation struct {
+	key string
+}
+
+func (r *mockRelation) Tag() names.Tag {
+	return names.NewRelationTag(r.key)
+}
+
+type mockUnit struct {
+	stub         *jtesting.Stub
+	name         string
+	subordinates []string
+	machineId    string
+	principal    bool
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+func (u *mockUnit) ApplicationName() string {
+	appName, _ := names.UnitApplication(u.name)
+	return appName
+}
+
+func (u *mockUnit) IsPrincipal() bool {
+	return u.principal
+}
+
+func (u *mockUnit) SubordinateNames() []string {
+	return u.subordinates
+}
+
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	if u.machineId == "" {
+		return "", errors.NotAssignedf("unit %q", u.name)
+	}
+	return u.machineId, nil
+}
+
+func (u *mockUnit) Destroy() error {
+	u.stub.MethodCall(u, "Destroy", u.name)
+	return u.stub.NextErr()
+}
+
+type mockMachine struct {
+	stub       *jtesting.Stub
+	id         string
+	manager    bool
+	principals []string
+	containers []string
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) IsManager() bool {
+	return m.manager
+}
+
+func (m *mockMachine) Principals() []string {
+	return m.principals
+}
+
+func (m *mockMachine) Containers() ([]string, error) {
+	return m.containers, nil
+}
+
+func (m *mockMachine) Destroy() error {
+	m.stub.MethodCall(m, "Destroy", m.id)
+	return m.stub.NextErr()
+}
+
+type mockBlockChecker struct {
+	jtesting.Stub
+}
+
+func (c *mockBlockChecker) RemoveAllowed() error {
+	c.MethodCall(c, "RemoveAllowed")
+	return c.NextErr()
+}
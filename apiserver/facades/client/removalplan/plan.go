@@ -0,0 +1,306 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package removalplan
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// planner computes the cascade of removals that follows from removing
+// a set of entities.
+type planner struct {
+	backend Backend
+
+	applications     set.Strings
+	units            set.Strings
+	machines         set.Strings
+	destroyedStorage set.Strings
+	detachedStorage  set.Strings
+	relations        set.Strings
+	offers           set.Strings
+
+	// principals holds the names of the principal units that will
+	// be removed; subordinate units are removed along with their
+	// principals.
+	principals set.Strings
+
+	// hosts holds the ids of the machines hosting principal units
+	// that will be removed.
+	hosts set.Strings
+}
+
+// planRemoval computes the cascade of removals that follows from
+// removing the given entities.
+func planRemoval(backend Backend, entities []params.Entity) (*planner, error) {
+	if len(entities) == 0 {
+		return nil, errors.NotValidf("empty removal")
+	}
+	p := &planner{
+		backend:          backend,
+		applications:     set.NewStrings(),
+		units:            set.NewStrings(),
+		machines:         set.NewStrings(),
+		destroyedStorage: set.NewStrings(),
+		detachedStorage:  set.NewStrings(),
+		relations:        set.NewStrings(),
+		offers:           set.NewStrings(),
+		principals:       set.NewStrings(),
+		hosts:            set.NewStrings(),
+	}
+	for _, entity := range entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch tag := tag.(type) {
+		case names.ApplicationTag:
+			err = p.addApplication(tag.Id())
+		case names.UnitTag:
+			err = p.addUnit(tag.Id())
+		case names.MachineTag:
+			err = p.addMachine(tag.Id())
+		default:
+			err = errors.NotValidf("removal of %s", names.ReadableString(tag))
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := p.addEmptiedMachines(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return p, nil
+}
+
+func (p *planner) addApplication(name string) error {
+	if p.applications.Contains(name) {
+		return nil
+	}
+	app, err := p.backend.Application(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p.applications.Add(name)
+	units, err := app.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, unit := range units {
+		if err := p.addUnitCascade(unit); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	relations, err := app.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rel := range relations {
+		p.relations.Add(rel.Tag().String())
+	}
+	offers, err := p.backend.ApplicationOffers(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, offer := range offers {
+		p.offers.Add(offer)
+	}
+	return nil
+}
+
+func (p *planner) addUnit(name string) error {
+	unit, err := p.backend.Unit(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !unit.IsPrincipal() {
+		return errors.Errorf("unit %q is a subordinate", name)
+	}
+	return errors.Trace(p.addUnitCascade(unit))
+}
+
+// addUnitCascade adds the unit to the plan, along with its
+// subordinates and storage.
+func (p *planner) addUnitCascade(unit Unit) error {
+	if p.units.Contains(unit.Name()) {
+		return nil
+	}
+	p.units.Add(unit.Name())
+	destroyed, detached, err := p.backend.UnitStorage(names.NewUnitTag(unit.Name()))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, tag := range destroyed {
+		p.destroyedStorage.Add(tag.String())
+	}
+	for _, tag := range detached {
+		p.detachedStorage.Add(tag.String())
+	}
+	if !unit.IsPrincipal() {
+		return nil
+	}
+	p.principals.Add(unit.Name())
+	for _, subName := range unit.SubordinateNames() {
+		sub, err := p.backend.Unit(subName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if err := p.addUnitCascade(sub); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	p.hosts.Add(machineId)
+	return nil
+}
+
+func (p *planner) addMachine(id string) error {
+	if p.machines.Contains(id) {
+		return nil
+	}
+	m, err := p.backend.Machine(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if m.IsManager() {
+		return errors.Errorf("machine %s is required by the model", id)
+	}
+	containers, err := m.Containers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(containers) > 0 {
+		return errors.Errorf("machine %s is hosting containers %q", id, strings.Join(containers, ","))
+	}
+	p.machines.Add(id)
+	for _, unitName := range m.Principals() {
+		unit, err := p.backend.Unit(unitName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if err := p.addUnitCascade(unit); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// addEmptiedMachines adds to the plan the machines that would be
+// left without any units.
+func (p *planner) addEmptiedMachines() error {
+	for _, id := range p.hosts.Difference(p.machines).SortedValues() {
+		m, err := p.backend.Machine(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if m.IsManager() {
+			continue
+		}
+		containers, err := m.Containers()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(containers) > 0 {
+			continue
+		}
+		if set.NewStrings(m.Principals()...).Difference(p.principals).IsEmpty() {
+			p.machines.Add(id)
+		}
+	}
+	return nil
+}
+
+// plan returns the removal plan for the requested entities.
+func (p *planner) plan(entities []params.Entity) *params.RemovalPlan {
+	plan := &params.RemovalPlan{
+		Entities: entities,
+		Applications: idEntities(p.applications, func(id string) names.Tag {
+			return names.NewApplicationTag(id)
+		}),
+		Units: idEntities(p.units, func(id string) names.Tag {
+			return names.NewUnitTag(id)
+		}),
+		Machines: idEntities(p.machines, func(id string) names.Tag {
+			return names.NewMachineTag(id)
+		}),
+		DestroyedStorage: tagEntities(p.destroyedStorage.SortedValues()),
+		DetachedStorage:  tagEntities(p.detachedStorage.SortedValues()),
+		Relations:        tagEntities(p.relations.SortedValues()),
+	}
+	if !p.offers.IsEmpty() {
+		plan.Offers = p.offers.SortedValues()
+	}
+	return plan
+}
+
+// idEntities returns entities for the given ids, sorted by tag.
+func idEntities(ids set.Strings, newTag func(string) names.Tag) []params.Entity {
+	tags := make([]string, 0, len(ids))
+	for _, id := range ids.Values() {
+		tags = append(tags, newTag(id).String())
+	}
+	sort.Strings(tags)
+	return tagEntities(tags)
+}
+
+func tagEntities(tags []string) []params.Entity {
+	if len(tags) == 0 {
+		return nil
+	}
+	result := make([]params.Entity, len(tags))
+	for i, tag := range tags {
+		result[i] = params.Entity{Tag: tag}
+	}
+	return result
+}
+
+// samePlan reports whether the two plans describe the same removals,
+// regardless of the order in which they are listed.
+func samePlan(a, b params.RemovalPlan) bool {
+	return reflect.DeepEqual(normalisePlan(a), normalisePlan(b))
+}
+
+func normalisePlan(plan params.RemovalPlan) params.RemovalPlan {
+	sorted := func(entities []params.Entity) []params.Entity {
+		tags := make([]string, len(entities))
+		for i, entity := range entities {
+			tags[i] = entity.Tag
+		}
+		sort.Strings(tags)
+		return tagEntities(tags)
+	}
+	var offers []string
+	if len(plan.Offers) > 0 {
+		offers = append(offers, plan.Offers...)
+		sort.Strings(offers)
+	}
+	return params.RemovalPlan{
+		Entities:         sorted(plan.Entities),
+		Applications:     sorted(plan.Applications),
+		Units:            sorted(plan.Units),
+		Machines:         sorted(plan.Machines),
+		DestroyedStorage: sorted(plan.DestroyedStorage),
+		DetachedStorage:  sorted(plan.DetachedStorage),
+		Relations:        sorted(plan.Relations),
+		Offers:           offers,
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package removalplan provides the RemovalPlan facade, which computes
+// everything that would be removed along with a set of applications,
+// units and machines, so that it can be confirmed before anything is
+// removed.
+package removalplan
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// ErrPlanOutOfDate is returned by ExecuteRemovalPlan when the model
+// has changed since the plan was made.
+var ErrPlanOutOfDate = errors.New("removal plan is out of date; the model has changed since it was made")
+
+// API provides the RemovalPlan API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      BlockChecker
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	backend, err := NewStateBackend(ctx.State())
+	if err != nil {
+		return nil, errors.Annotate(err, "getting state")
+	}
+	return NewAPI(backend, ctx.Auth(), common.NewBlockChecker(ctx.State()))
+}
+
+// NewAPI returns a new RemovalPlan API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, blockChecker BlockChecker) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      blockChecker,
+	}, nil
+}
+
+func (api *API) checkPermission(tag names.Tag, perm permission.Access) error {
+	allowed, err := api.authorizer.HasPermission(perm, tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (api *API) checkCanRead() error {
+	return api.checkPermission(api.backend.ModelTag(), permission.ReadAccess)
+}
+
+func (api *API) checkCanWrite() error {
+	return api.checkPermission(api.backend.ModelTag(), permission.WriteAccess)
+}
+
+// PlanRemoval returns everything that would be removed, or affected,
+// by removing the specified applications, units and machines:
+// their units and subordinate units, the machines that would be left
+// without units, their storage, relations and application offers.
+// Nothing is removed.
+func (api *API) PlanRemoval(args params.Entities) (params.RemovalPlanResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RemovalPlanResult{}, errors.Trace(err)
+	}
+	p, err := planRemoval(api.backend, args.Entities)
+	if err != nil {
+		return params.RemovalPlanResult{Error: common.ServerError(err)}, nil
+	}
+	return params.RemovalPlanResult{Result: p.plan(args.Entities)}, nil
+}
+
+// ExecuteRemovalPlan removes the entities in a plan previously
+// returned by PlanRemoval. The plan is computed again immediately
+// before anything is removed; if it differs from the approved plan,
+// ErrPlanOutOfDate is returned and nothing is removed.
+//
+// Applications are removed first, then the remaining units, and
+// finally the machines without units. A machine that still hosts
+// units is not destroyed directly, which would fail while its units
+// are dying; state destroys it when its last unit is removed. The
+// plan only includes machines whose units are all being removed, and
+// which are not hosting containers or required by the model, so each
+// of them will be destroyed.
+func (api *API) ExecuteRemovalPlan(args params.RemovalPlan) (params.ErrorResult, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	if err := api.check.RemoveAllowed(); err != nil {
+		return params.ErrorResult{}, errors.Trace(err)
+	}
+	return params.ErrorResult{Error: common.ServerError(api.executeRemovalPlan(args))}, nil
+}
+
+func (api *API) executeRemovalPlan(approved params.RemovalPlan) error {
+	p, err := planRemoval(api.backend, approved.Entities)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !samePlan(approved, *p.plan(approved.Entities)) {
+		return ErrPlanOutOfDate
+	}
+	for _, name := range p.applications.SortedValues() {
+		app, err := api.backend.Application(name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := app.Destroy(); err != nil {
+			return errors.Annotatef(err, "removing application %q", name)
+		}
+	}
+	for _, name := range p.principals.SortedValues() {
+		unit, err := api.backend.Unit(name)
+		if errors.IsNotFound(err) {
+			// Removed along with its application.
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if p.applications.Contains(unit.ApplicationName()) {
+			continue
+		}
+		if err := unit.Destroy(); err != nil {
+			return errors.Annotatef(err, "removing unit %q", name)
+		}
+	}
+	for _, id := range p.machines.SortedValues() {
+		m, err := api.backend.Machine(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if len(m.Principals()) > 0 {
+			// Destroyed along with its last unit.
+			continue
+		}
+		if err := m.Destroy(); err != nil {
+			return errors.Annotatef(err, "removing machine %s", id)
+		}
+	}
+	return nil
+}
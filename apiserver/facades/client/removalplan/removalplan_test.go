@@ -0,0 +1,278 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package removalplan_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/removalplan"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RemovalPlanSuite struct {
+	testing.IsolationSuite
+	backend    mockBackend
+	blocks     mockBlockChecker
+	authorizer apiservertesting.FakeAuthorizer
+	api        *removalplan.API
+}
+
+var _ = gc.Suite(&RemovalPlanSuite{})
+
+func (s *RemovalPlanSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.blocks = mockBlockChecker{}
+	s.backend = mockBackend{
+		modelUUID: coretesting.ModelTag.Id(),
+		offers: map[string][]string{
+			"wordpress": {"wp"},
+		},
+		storage: map[string][2][]names.StorageTag{
+			"wordpress/0": {
+				{names.NewStorageTag("data/0")},
+				{names.NewStorageTag("logs/1")},
+			},
+		},
+	}
+	stub := &s.backend.Stub
+	s.backend.units = map[string]*mockUnit{
+		"wordpress/0": {stub: stub, name: "wordpress/0", principal: true, machineId: "0", subordinates: []string{"logging/0"}},
+		"wordpress/1": {stub: stub, name: "wordpress/1", principal: true, machineId: "1"},
+		"logging/0":   {stub: stub, name: "logging/0", machineId: "0"},
+		"mysql/0":     {stub: stub, name: "mysql/0", principal: true, machineId: "1"},
+		"mysql/1":     {stub: stub, name: "mysql/1", principal: true},
+	}
+	units := s.backend.units
+	s.backend.applications = map[string]*mockApplication{
+		"wordpress": {
+			stub:  stub,
+			name:  "wordpress",
+			units: []removalplan.Unit{units["wordpress/0"], units["wordpress/1"]},
+			relations: []removalplan.Relation{
+				&mockRelation{"wordpress:db mysql:server"},
+				&mockRelation{"logging:info wordpress:juju-info"},
+			},
+		},
+		"mysql": {
+			stub:      stub,
+			name:      "mysql",
+			units:     []removalplan.Unit{units["mysql/0"], units["mysql/1"]},
+			relations: []removalplan.Relation{&mockRelation{"wordpress:db mysql:server"}},
+		},
+	}
+	s.backend.machines = map[string]*mockMachine{
+		"0": {stub: stub, id: "0", principals: []string{"wordpress/0"}},
+		"1": {stub: stub, id: "1", principals: []string{"wordpress/1", "mysql/0"}},
+		"2": {stub: stub, id: "2", manager: true},
+		"3": {stub: stub, id: "3", containers: []string{"3/lxd/0"}},
+	}
+	s.setUser(c, "admin")
+}
+
+func (s *RemovalPlanSuite) setUser(c *gc.C, user string) {
+	s.authorizer.Tag = names.NewUserTag(user)
+	api, err := removalplan.NewAPI(&s.backend, s.authorizer, &s.blocks)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func entities(tags ...names.Tag) []params.Entity {
+	result := make([]params.Entity, len(tags))
+	for i, tag := range tags {
+		result[i] = params.Entity{Tag: tag.String()}
+	}
+	return result
+}
+
+func (s *RemovalPlanSuite) wordpressPlan() *params.RemovalPlan {
+	return &params.RemovalPlan{
+		Entities:     entities(names.NewApplicationTag("wordpress")),
+		Applications: entities(names.NewApplicationTag("wordpress")),
+		Units: entities(
+			names.NewUnitTag("logging/0"),
+			names.NewUnitTag("wordpress/0"),
+			names.NewUnitTag("wordpress/1"),
+		),
+		Machines:         entities(names.NewMachineTag("0")),
+		DestroyedStorage: entities(names.NewStorageTag("data/0")),
+		DetachedStorage:  entities(names.NewStorageTag("logs/1")),
+		Relations: entities(
+			names.NewRelationTag("logging:info wordpress:juju-info"),
+			names.NewRelationTag("wordpress:db mysql:server"),
+		),
+		Offers: []string{"wp"},
+	}
+}
+
+func (s *RemovalPlanSuite) TestNewAPINonClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := removalplan.NewAPI(&s.backend, s.authorizer, &s.blocks)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *RemovalPlanSuite) TestPlanApplication(c *gc.C) {
+	result, err := s.api.PlanRemoval(params.Entities{
+		Entities: entities(names.NewApplicationTag("wordpress")),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	// Machine 1 is still hosting mysql/0, so is not removed.
+	c.Assert(result.Result, jc.DeepEquals, s.wordpressPlan())
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *RemovalPlanSuite) TestPlanUnits(c *gc.C) {
+	result, err := s.api.PlanRemoval(params.Entities{
+		Entities: entities(names.NewUnitTag("wordpress/1"), names.NewUnitTag("mysql/0")),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, jc.DeepEquals, &params.RemovalPlan{
+		Entities: entities(names.NewUnitTag("wordpress/1"), names.NewUnitTag("mysql/0")),
+		Units:    entities(names.NewUnitTag("mysql/0"), names.NewUnitTag("wordpress/1")),
+		Machines: entities(names.NewMachineTag("1")),
+	})
+}
+
+func (s *RemovalPlanSuite) TestPlanMachine(c *gc.C) {
+	result, err := s.api.PlanRemoval(params.Entities{
+		Entities: entities(names.NewMachineTag("0")),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, jc.DeepEquals, &params.RemovalPlan{
+		Entities:         entities(names.NewMachineTag("0")),
+		Units:            entities(names.NewUnitTag("logging/0"), names.NewUnitTag("wordpress/0")),
+		Machines:         entities(names.NewMachineTag("0")),
+		DestroyedStorage: entities(names.NewStorageTag("data/0")),
+		DetachedStorage:  entities(names.NewStorageTag("logs/1")),
+	})
+}
+
+func (s *RemovalPlanSuite) TestPlanErrors(c *gc.C) {
+	for i, test := range []struct {
+		tag names.Tag
+		err string
+	}{{
+		tag: names.NewUnitTag("logging/0"),
+		err: `unit "logging/0" is a subordinate`,
+	}, {
+		tag: names.NewMachineTag("2"),
+		err: `machine 2 is required by the model`,
+	}, {
+		tag: names.NewMachineTag("3"),
+		err: `machine 3 is hosting containers "3/lxd/0"`,
+	}, {
+		tag: names.NewApplicationTag("foo"),
+		err: `application "foo" not found`,
+	}, {
+		tag: names.NewUserTag("bob"),
+		err: `removal of user bob not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.tag)
+		result, err := s.api.PlanRemoval(params.Entities{
+			Entities: entities(test.tag),
+		})
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(result.Result, gc.IsNil)
+		c.Check(result.Error, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RemovalPlanSuite) TestPlanEmpty(c *gc.C) {
+	result, err := s.api.PlanRemoval(params.Entities{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "empty removal not valid")
+}
+
+func (s *RemovalPlanSuite) TestPlanPermission(c *gc.C) {
+	s.setUser(c, "someone")
+	_, err := s.api.PlanRemoval(params.Entities{
+		Entities: entities(names.NewApplicationTag("wordpress")),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *RemovalPlanSuite) TestExecute(c *gc.C) {
+	result, err := s.api.ExecuteRemovalPlan(*s.wordpressPlan())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	// The application's units are removed along with it, and the
+	// machine left without units is removed with its last unit.
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"Destroy", []interface{}{"wordpress"}},
+	})
+}
+
+func (s *RemovalPlanSuite) TestExecuteUnits(c *gc.C) {
+	plan := params.RemovalPlan{
+		// The order of the approved plan does not matter.
+		Entities: entities(names.NewUnitTag("wordpress/1"), names.NewUnitTag("mysql/0")),
+		Units:    entities(names.NewUnitTag("wordpress/1"), names.NewUnitTag("mysql/0")),
+		Machines: entities(names.NewMachineTag("1")),
+	}
+	result, err := s.api.ExecuteRemovalPlan(plan)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"Destroy", []interface{}{"mysql/0"}},
+		{"Destroy", []interface{}{"wordpress/1"}},
+	})
+}
+
+func (s *RemovalPlanSuite) TestExecuteEmptyMachine(c *gc.C) {
+	s.backend.machines["4"] = &mockMachine{stub: &s.backend.Stub, id: "4"}
+	plan := params.RemovalPlan{
+		Entities: entities(names.NewMachineTag("4")),
+		Machines: entities(names.NewMachineTag("4")),
+	}
+	result, err := s.api.ExecuteRemovalPlan(plan)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"Destroy", []interface{}{"4"}},
+	})
+}
+
+func (s *RemovalPlanSuite) TestExecuteOutOfDate(c *gc.C) {
+	plan := s.wordpressPlan()
+	// A unit has been added to machine 0 since the plan was made.
+	s.backend.machines["0"].principals = append(s.backend.machines["0"].principals, "mysql/1")
+	result, err := s.api.ExecuteRemovalPlan(*plan)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "removal plan is out of date; the model has changed since it was made")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *RemovalPlanSuite) TestExecuteError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	result, err := s.api.ExecuteRemovalPlan(*s.wordpressPlan())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `removing application "wordpress": boom`)
+	s.backend.CheckCallNames(c, "Destroy")
+}
+
+func (s *RemovalPlanSuite) TestExecuteBlocked(c *gc.C) {
+	s.blocks.SetErrors(common.OperationBlockedError("TestExecuteBlocked"))
+	_, err := s.api.ExecuteRemovalPlan(*s.wordpressPlan())
+	c.Assert(err, gc.ErrorMatches, "TestExecuteBlocked")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *RemovalPlanSuite) TestExecutePermission(c *gc.C) {
+	s.setUser(c, "someone")
+	_, err := s.api.ExecuteRemovalPlan(*s.wordpressPlan())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
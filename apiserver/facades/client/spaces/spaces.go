@@ -4,7 +4,10 @@
 package spaces
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/networkingcommon"
@@ -20,6 +23,15 @@ type API interface {
 	CreateSpaces(params.CreateSpacesParams) (params.ErrorResults, error)
 	ListSpaces() (params.ListSpacesResults, error)
 	ReloadSpaces() error
+	RenameSpaces(params.RenameSpacesParams) (params.SpaceChangeResults, error)
+	MoveSubnets(params.MoveSubnetsParams) (params.SpaceChangeResults, error)
+}
+
+// APIV3 is missing RenameSpaces and MoveSubnets methods
+type APIV3 interface {
+	CreateSpaces(params.CreateSpacesParams) (params.ErrorResults, error)
+	ListSpaces() (params.ListSpacesResults, error)
+	ReloadSpaces() error
 }
 
 // APIV2 is missing ReloadSpaces method
@@ -28,9 +40,34 @@ type APIV2 interface {
 	ListSpaces() (params.ListSpacesResults, error)
 }
 
+// Backing defines the state methods the Spaces API facade needs.
+type Backing interface {
+	networkingcommon.NetworkBacking
+
+	// RenameSpace renames the space called from to to.
+	RenameSpace(from, to string) error
+
+	// MoveSubnet moves the subnet with the given CIDR into the space
+	// with the given name.
+	MoveSubnet(cidr, spaceName string) error
+
+	// SubnetSpaceName returns the name of the space the subnet with
+	// the given CIDR is in.
+	SubnetSpaceName(cidr string) (string, error)
+
+	// SpaceReferences returns the applications whose endpoint
+	// bindings or constraints refer to the named space.
+	SpaceReferences(spaceName string) (state.SpaceReferences, error)
+
+	// MachinesStrandedBySubnetMove returns the IDs of the machines
+	// that would have no address in a space they need if the subnet
+	// with the given CIDR were moved out of its space.
+	MachinesStrandedBySubnetMove(cidr string) ([]string, error)
+}
+
 // spacesAPI implements the API interface.
 type spacesAPI struct {
-	backing    networkingcommon.NetworkBacking
+	backing    Backing
 	resources  facade.Resources
 	authorizer facade.Authorizer
 }
@@ -38,16 +75,16 @@ type spacesAPI struct {
 // NewAPI creates a new Space API server-side facade with a
 // state.State backing.
 func NewAPI(st *state.State, res facade.Resources, auth facade.Authorizer) (API, error) {
-	stateShim, err := networkingcommon.NewStateShim(st)
+	networkShim, err := networkingcommon.NewStateShim(st)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return newAPIWithBacking(stateShim, res, auth)
+	return newAPIWithBacking(&stateShim{networkShim, st}, res, auth)
 }
 
 // newAPIWithBacking creates a new server-side Spaces API facade with
 // the given Backing.
-func newAPIWithBacking(backing networkingcommon.NetworkBacking, resources facade.Resources, authorizer facade.Authorizer) (API, error) {
+func newAPIWithBacking(backing Backing, resources facade.Resources, authorizer facade.Authorizer) (API, error) {
 	// Only clients can access the Spaces facade.
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -64,6 +101,11 @@ func NewAPIV2(st *state.State, res facade.Resources, auth facade.Authorizer) (AP
 	return NewAPI(st, res, auth)
 }
 
+// NewAPIV3 is a wrapper that creates a V3 spaces API.
+func NewAPIV3(st *state.State, res facade.Resources, auth facade.Authorizer) (APIV3, error) {
+	return NewAPI(st, res, auth)
+}
+
 // CreateSpaces creates a new Juju network space, associating the
 // specified subnets with it (optional; can be empty).
 func (api *spacesAPI) CreateSpaces(args params.CreateSpacesParams) (results params.ErrorResults, err error) {
@@ -135,3 +177,159 @@ func (api *spacesAPI) ReloadSpaces() error {
 	}
 	return errors.Trace(api.backing.ReloadSpaces(env))
 }
+
+// RenameSpaces renames spaces, updating the subnets, endpoint bindings
+// and constraints that refer to them. Each result reports the
+// applications whose bindings and constraints refer to the renamed
+// space. Changes with DryRun set only report that impact.
+func (api *spacesAPI) RenameSpaces(args params.RenameSpacesParams) (results params.SpaceChangeResults, err error) {
+	if err := api.checkCanChangeSpaces(); err != nil {
+		return results, err
+	}
+	results.Results = make([]params.SpaceChangeResult, len(args.Changes))
+	for i, change := range args.Changes {
+		result, err := api.renameSpace(change)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *spacesAPI) renameSpace(arg params.RenameSpaceParams) (params.SpaceChangeResult, error) {
+	var result params.SpaceChangeResult
+	fromTag, err := names.ParseSpaceTag(arg.FromSpaceTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	toTag, err := names.ParseSpaceTag(arg.ToSpaceTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	refs, err := api.backing.SpaceReferences(fromTag.Id())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.ApplicationBindings = refs.Bindings
+	result.ApplicationConstraints = refs.Constraints
+	if arg.DryRun {
+		return result, nil
+	}
+	return result, errors.Trace(api.backing.RenameSpace(fromTag.Id(), toTag.Id()))
+}
+
+// MoveSubnets moves subnets between spaces. Each result reports the
+// applications whose bindings and constraints refer to the space the
+// subnet is moved out of, and the machines that would be left without
+// an address in that space. A move that leaves any such machines is
+// refused unless forced. Moves with DryRun set only report the impact.
+func (api *spacesAPI) MoveSubnets(args params.MoveSubnetsParams) (results params.SpaceChangeResults, err error) {
+	if err := api.checkCanChangeSpaces(); err != nil {
+		return results, err
+	}
+	results.Results = make([]params.SpaceChangeResult, len(args.Args))
+	for i, arg := range args.Args {
+		result, err := api.moveSubnet(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *spacesAPI) moveSubnet(arg params.MoveSubnetParams) (params.SpaceChangeResult, error) {
+	var result params.SpaceChangeResult
+	subnetTag, err := names.ParseSubnetTag(arg.SubnetTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	spaceTag, err := names.ParseSpaceTag(arg.SpaceTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	cidr := subnetTag.Id()
+	fromSpace, err := api.backing.SubnetSpaceName(cidr)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if fromSpace != "" {
+		refs, err := api.backing.SpaceReferences(fromSpace)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		result.ApplicationBindings = refs.Bindings
+		result.ApplicationConstraints = refs.Constraints
+	}
+	machines, err := api.backing.MachinesStrandedBySubnetMove(cidr)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Machines = machines
+	if arg.DryRun {
+		return result, nil
+	}
+	if len(machines) > 0 && !arg.Force {
+		return result, errors.Errorf(
+			"moving subnet %q would leave machines %s without an address in space %q (use force to override)",
+			cidr, strings.Join(machines, ", "), fromSpace,
+		)
+	}
+	return result, errors.Trace(api.backing.MoveSubnet(cidr, spaceTag.Id()))
+}
+
+// checkCanChangeSpaces returns an error if the authenticated user
+// cannot change the model's spaces, or the model does not support them.
+func (api *spacesAPI) checkCanChangeSpaces() error {
+	isAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.backing.ModelTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ServerError(common.ErrPerm)
+	}
+	if err := networkingcommon.SupportsSpaces(api.backing); err != nil {
+		return common.ServerError(errors.Trace(err))
+	}
+	return nil
+}
+
+// stateShim extends the common networking state shim with the
+// methods needed to rename spaces and move subnets between them.
+type stateShim struct {
+	networkingcommon.NetworkBacking
+	st *state.State
+}
+
+func (s *stateShim) RenameSpace(from, to string) error {
+	space, err := s.st.Space(from)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return space.Rename(to)
+}
+
+func (s *stateShim) MoveSubnet(cidr, spaceName string) error {
+	subnet, err := s.st.Subnet(cidr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return subnet.MoveToSpace(spaceName)
+}
+
+func (s *stateShim) SubnetSpaceName(cidr string) (string, error) {
+	subnet, err := s.st.Subnet(cidr)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return subnet.SpaceName(), nil
+}
+
+func (s *stateShim) SpaceReferences(spaceName string) (state.SpaceReferences, error) {
+	return s.st.SpaceReferences(spaceName)
+}
+
+func (s *stateShim) MachinesStrandedBySubnetMove(cidr string) ([]string, error) {
+	return s.st.MachinesStrandedBySubnetMove(cidr)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -25,6 +27,7 @@ type SpacesSuite struct {
 
 	resources  *common.Resources
 	authorizer apiservertesting.FakeAuthorizer
+	backing    *stubBacking
 	facade     spaces.API
 }
 
@@ -43,6 +46,10 @@ func (s *SpacesSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	apiservertesting.BackingInstance.SetUp(c, apiservertesting.StubZonedNetworkingEnvironName, apiservertesting.WithZones, apiservertesting.WithSpaces, apiservertesting.WithSubnets)
 
+	s.backing = &stubBacking{
+		StubBacking: apiservertesting.BackingInstance,
+		stub:        &testing.Stub{},
+	}
 	s.resources = common.NewResources()
 	s.authorizer = apiservertesting.FakeAuthorizer{
 		Tag:        names.NewUserTag("admin"),
@@ -51,7 +58,7 @@ func (s *SpacesSuite) SetUpTest(c *gc.C) {
 
 	var err error
 	s.facade, err = spaces.NewAPIWithBacking(
-		apiservertesting.BackingInstance, s.resources, s.authorizer,
+		s.backing, s.resources, s.authorizer,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s.facade, gc.NotNil)
@@ -67,7 +74,7 @@ func (s *SpacesSuite) TearDownTest(c *gc.C) {
 func (s *SpacesSuite) TestNewAPIWithBacking(c *gc.C) {
 	// Clients are allowed.
 	facade, err := spaces.NewAPIWithBacking(
-		apiservertesting.BackingInstance, s.resources, s.authorizer,
+		s.backing, s.resources, s.authorizer,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(facade, gc.NotNil)
@@ -78,7 +85,7 @@ func (s *SpacesSuite) TestNewAPIWithBacking(c *gc.C) {
 	agentAuthorizer := s.authorizer
 	agentAuthorizer.Tag = names.NewMachineTag("42")
 	facade, err = spaces.NewAPIWithBacking(
-		apiservertesting.BackingInstance, s.resources, agentAuthorizer,
+		s.backing, s.resources, agentAuthorizer,
 	)
 	c.Assert(err, jc.DeepEquals, common.ErrPerm)
 	c.Assert(facade, gc.IsNil)
@@ -373,10 +380,202 @@ func (s *SpacesSuite) TestReloadSpacesUserDenied(c *gc.C) {
 	agentAuthorizer := s.authorizer
 	agentAuthorizer.Tag = names.NewUserTag("regular")
 	facade, err := spaces.NewAPIWithBacking(
-		apiservertesting.BackingInstance, s.resources, agentAuthorizer,
+		s.backing, s.resources, agentAuthorizer,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	err = facade.ReloadSpaces()
 	c.Check(err, gc.ErrorMatches, "permission denied")
 	apiservertesting.CheckMethodCalls(c, apiservertesting.SharedStub)
 }
+
+func (s *SpacesSuite) TestRenameSpaces(c *gc.C) {
+	s.backing.refs = state.SpaceReferences{
+		Bindings:    map[string][]string{"mysql": {"server"}},
+		Constraints: []string{"wordpress"},
+	}
+	results, err := s.facade.RenameSpaces(params.RenameSpacesParams{
+		Changes: []params.RenameSpaceParams{{
+			FromSpaceTag: "space-db",
+			ToSpaceTag:   "space-database",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.SpaceChangeResults{
+		Results: []params.SpaceChangeResult{{
+			ApplicationBindings:    map[string][]string{"mysql": {"server"}},
+			ApplicationConstraints: []string{"wordpress"},
+		}},
+	})
+	s.backing.stub.CheckCalls(c, []testing.StubCall{
+		{FuncName: "SpaceReferences", Args: []interface{}{"db"}},
+		{FuncName: "RenameSpace", Args: []interface{}{"db", "database"}},
+	})
+}
+
+func (s *SpacesSuite) TestRenameSpacesDryRun(c *gc.C) {
+	s.backing.refs = state.SpaceReferences{
+		Constraints: []string{"wordpress"},
+	}
+	results, err := s.facade.RenameSpaces(params.RenameSpacesParams{
+		Changes: []params.RenameSpaceParams{{
+			FromSpaceTag: "space-db",
+			ToSpaceTag:   "space-database",
+			DryRun:       true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].ApplicationConstraints, jc.DeepEquals, []string{"wordpress"})
+	s.backing.stub.CheckCallNames(c, "SpaceReferences")
+}
+
+func (s *SpacesSuite) TestRenameSpacesErrors(c *gc.C) {
+	s.backing.stub.SetErrors(nil, errors.AlreadyExistsf("space %q", "database"))
+	results, err := s.facade.RenameSpaces(params.RenameSpacesParams{
+		Changes: []params.RenameSpaceParams{{
+			FromSpaceTag: "space-db",
+			ToSpaceTag:   "space-database",
+		}, {
+			FromSpaceTag: "bad-tag",
+			ToSpaceTag:   "space-database",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `space "database" already exists`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+}
+
+func (s *SpacesSuite) TestRenameSpacesUserDenied(c *gc.C) {
+	authorizer := s.authorizer
+	authorizer.Tag = names.NewUserTag("regular")
+	facade, err := spaces.NewAPIWithBacking(s.backing, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.RenameSpaces(params.RenameSpacesParams{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backing.stub.CheckNoCalls(c)
+}
+
+func (s *SpacesSuite) TestMoveSubnets(c *gc.C) {
+	s.backing.subnetSpace = "db"
+	s.backing.refs = state.SpaceReferences{
+		Bindings: map[string][]string{"mysql": {"server"}},
+	}
+	results, err := s.facade.MoveSubnets(params.MoveSubnetsParams{
+		Args: []params.MoveSubnetParams{{
+			SubnetTag: "subnet-10.0.0.0/24",
+			SpaceTag:  "space-client",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.SpaceChangeResults{
+		Results: []params.SpaceChangeResult{{
+			ApplicationBindings: map[string][]string{"mysql": {"server"}},
+		}},
+	})
+	s.backing.stub.CheckCalls(c, []testing.StubCall{
+		{FuncName: "SubnetSpaceName", Args: []interface{}{"10.0.0.0/24"}},
+		{FuncName: "SpaceReferences", Args: []interface{}{"db"}},
+		{FuncName: "MachinesStrandedBySubnetMove", Args: []interface{}{"10.0.0.0/24"}},
+		{FuncName: "MoveSubnet", Args: []interface{}{"10.0.0.0/24", "client"}},
+	})
+}
+
+func (s *SpacesSuite) TestMoveSubnetsRefusesStrandingMachines(c *gc.C) {
+	s.backing.subnetSpace = "db"
+	s.backing.stranded = []string{"0", "3"}
+	results, err := s.facade.MoveSubnets(params.MoveSubnetsParams{
+		Args: []params.MoveSubnetParams{{
+			SubnetTag: "subnet-10.0.0.0/24",
+			SpaceTag:  "space-client",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Machines, jc.DeepEquals, []string{"0", "3"})
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`moving subnet "10.0.0.0/24" would leave machines 0, 3 without an address in space "db" \(use force to override\)`,
+	)
+	s.backing.stub.CheckCallNames(c, "SubnetSpaceName", "SpaceReferences", "MachinesStrandedBySubnetMove")
+}
+
+func (s *SpacesSuite) TestMoveSubnetsForce(c *gc.C) {
+	s.backing.subnetSpace = "db"
+	s.backing.stranded = []string{"0"}
+	results, err := s.facade.MoveSubnets(params.MoveSubnetsParams{
+		Args: []params.MoveSubnetParams{{
+			SubnetTag: "subnet-10.0.0.0/24",
+			SpaceTag:  "space-client",
+			Force:     true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Machines, jc.DeepEquals, []string{"0"})
+	s.backing.stub.CheckCallNames(c, "SubnetSpaceName", "SpaceReferences", "MachinesStrandedBySubnetMove", "MoveSubnet")
+}
+
+func (s *SpacesSuite) TestMoveSubnetsDryRun(c *gc.C) {
+	s.backing.subnetSpace = "db"
+	s.backing.stranded = []string{"0"}
+	results, err := s.facade.MoveSubnets(params.MoveSubnetsParams{
+		Args: []params.MoveSubnetParams{{
+			SubnetTag: "subnet-10.0.0.0/24",
+			SpaceTag:  "space-client",
+			DryRun:    true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Machines, jc.DeepEquals, []string{"0"})
+	s.backing.stub.CheckCallNames(c, "SubnetSpaceName", "SpaceReferences", "MachinesStrandedBySubnetMove")
+}
+
+func (s *SpacesSuite) TestMoveSubnetsUserDenied(c *gc.C) {
+	authorizer := s.authorizer
+	authorizer.Tag = names.NewUserTag("regular")
+	facade, err := spaces.NewAPIWithBacking(s.backing, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = facade.MoveSubnets(params.MoveSubnetsParams{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backing.stub.CheckNoCalls(c)
+}
+
+// stubBacking extends the shared networking stub backing with the
+// methods needed to rename spaces and move subnets.
+type stubBacking struct {
+	*apiservertesting.StubBacking
+	stub *testing.Stub
+
+	refs        state.SpaceReferences
+	subnetSpace string
+	stranded    []string
+}
+
+func (sb *stubBacking) RenameSpace(from, to string) error {
+	sb.stub.AddCall("RenameSpace", from, to)
+	return sb.stub.NextErr()
+}
+
+func (sb *stubBacking) MoveSubnet(cidr, spaceName string) error {
+	sb.stub.AddCall("MoveSubnet", cidr, spaceName)
+	return sb.stub.NextErr()
+}
+
+func (sb *stubBacking) SubnetSpaceName(cidr string) (string, error) {
+	sb.stub.AddCall("SubnetSpaceName", cidr)
+	return sb.subnetSpace, sb.stub.NextErr()
+}
+
+func (sb *stubBacking) SpaceReferences(spaceName string) (state.SpaceReferences, error) {
+	sb.stub.AddCall("SpaceReferences", spaceName)
+	return sb.refs, sb.stub.NextErr()
+}
+
+func (sb *stubBacking) MachinesStrandedBySubnetMove(cidr string) ([]string, error) {
+	sb.stub.AddCall("MachinesStrandedBySubnetMove", cidr)
+	return sb.stranded, sb.stub.NextErr()
+}
@@ -6,8 +6,12 @@
 package sshclient
 
 import (
+	"fmt"
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/ssh"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -15,6 +19,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.sshclient")
@@ -190,6 +195,103 @@ func (facade *Facade) PublicKeys(args params.Entities) (params.SSHPublicKeysResu
 	return out, nil
 }
 
+// ListManualHostKeys returns the SSH host keys stored for all manually
+// provisioned hosts in the model, ordered by host.
+func (facade *Facade) ListManualHostKeys() (params.SSHManualHostKeysResults, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.SSHManualHostKeysResults{}, errors.Trace(err)
+	}
+	all, err := facade.backend.AllManualHostKeys()
+	if err != nil {
+		return params.SSHManualHostKeysResults{}, errors.Trace(err)
+	}
+	hosts := make([]string, 0, len(all))
+	for host := range all {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	out := params.SSHManualHostKeysResults{
+		Results: make([]params.SSHManualHostKeysResult, len(hosts)),
+	}
+	for i, host := range hosts {
+		out.Results[i].Host = host
+		out.Results[i].PublicKeys = []string(all[host])
+	}
+	return out, nil
+}
+
+// ManualHostKeys returns the SSH host keys stored for one or more
+// manually provisioned hosts. A NotFound error is returned for hosts
+// that have no stored keys.
+func (facade *Facade) ManualHostKeys(args params.SSHManualHosts) (params.SSHManualHostKeysResults, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.SSHManualHostKeysResults{}, errors.Trace(err)
+	}
+	out := params.SSHManualHostKeysResults{
+		Results: make([]params.SSHManualHostKeysResult, len(args.Hosts)),
+	}
+	for i, host := range args.Hosts {
+		out.Results[i].Host = host
+		keys, err := facade.backend.ManualHostKeys(host)
+		if err != nil {
+			out.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		out.Results[i].PublicKeys = []string(keys)
+	}
+	return out, nil
+}
+
+// SetManualHostKeys stores the SSH host keys for one or more manually
+// provisioned hosts, replacing any keys previously stored for them.
+// Manual provisioning verifies the identity of a host against its
+// stored keys.
+func (facade *Facade) SetManualHostKeys(args params.SSHManualHostKeySet) (params.ErrorResults, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	out := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.HostKeys)),
+	}
+	for i, arg := range args.HostKeys {
+		err := facade.setManualHostKeys(arg.Host, arg.PublicKeys)
+		out.Results[i].Error = common.ServerError(err)
+	}
+	return out, nil
+}
+
+func (facade *Facade) setManualHostKeys(host string, keys []string) error {
+	if host == "" {
+		return errors.NotValidf("empty host")
+	}
+	if len(keys) == 0 {
+		return errors.NotValidf("empty keys for host %q", host)
+	}
+	for _, key := range keys {
+		if _, err := ssh.ParseAuthorisedKey(key); err != nil {
+			return errors.NewNotValid(err, fmt.Sprintf("key %q for host %q", key, host))
+		}
+	}
+	return facade.backend.SetManualHostKeys(host, state.SSHHostKeys(keys))
+}
+
+// RemoveManualHostKeys removes the SSH host keys stored for one or more
+// manually provisioned hosts. It is not an error to remove the keys of
+// a host that has none stored.
+func (facade *Facade) RemoveManualHostKeys(args params.SSHManualHosts) (params.ErrorResults, error) {
+	if err := facade.checkIsModelAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	out := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Hosts)),
+	}
+	for i, host := range args.Hosts {
+		err := facade.backend.RemoveManualHostKeys(host)
+		out.Results[i].Error = common.ServerError(err)
+	}
+	return out, nil
+}
+
 // Proxy returns whether SSH connections should be proxied through the
 // controller hosts for the model associated with the API connection.
 func (facade *Facade) Proxy() (params.SSHProxyResult, error) {
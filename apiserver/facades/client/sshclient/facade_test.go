@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	sshtesting "github.com/juju/utils/ssh/testing"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -177,6 +178,90 @@ func (s *facadeSuite) TestProxyFalse(c *gc.C) {
 	})
 }
 
+func (s *facadeSuite) TestListManualHostKeys(c *gc.C) {
+	results, err := s.facade.ListManualHostKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHManualHostKeysResults{
+		Results: []params.SSHManualHostKeysResult{
+			{Host: "10.0.0.1", PublicKeys: []string{"rsa-a"}},
+			{Host: "10.0.0.2", PublicKeys: []string{"rsa-b", "dsa-b"}},
+		},
+	})
+	s.backend.stub.CheckCallNames(c, "AllManualHostKeys")
+}
+
+func (s *facadeSuite) TestManualHostKeys(c *gc.C) {
+	args := params.SSHManualHosts{
+		Hosts: []string{"10.0.0.1", "10.0.0.9"},
+	}
+	results, err := s.facade.ManualHostKeys(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.SSHManualHostKeysResults{
+		Results: []params.SSHManualHostKeysResult{
+			{Host: "10.0.0.1", PublicKeys: []string{"rsa-a"}},
+			{Host: "10.0.0.9", Error: apiservertesting.NotFoundError(`keys for host "10.0.0.9"`)},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ManualHostKeys", []interface{}{"10.0.0.1"}},
+		{"ManualHostKeys", []interface{}{"10.0.0.9"}},
+	})
+}
+
+func (s *facadeSuite) TestSetManualHostKeys(c *gc.C) {
+	args := params.SSHManualHostKeySet{
+		HostKeys: []params.SSHManualHostKeys{
+			{Host: "10.0.0.1", PublicKeys: []string{sshtesting.ValidKeyOne.Key}},
+			{Host: "10.0.0.2", PublicKeys: []string{"not-a-key"}},
+			{Host: "10.0.0.3"},
+			{PublicKeys: []string{sshtesting.ValidKeyTwo.Key}},
+		},
+	}
+	results, err := s.facade.SetManualHostKeys(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `key "not-a-key" for host "10.0.0.2": .*`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `empty keys for host "10.0.0.3" not valid`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `empty host not valid`)
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"SetManualHostKeys", []interface{}{"10.0.0.1", state.SSHHostKeys{sshtesting.ValidKeyOne.Key}}},
+	})
+}
+
+func (s *facadeSuite) TestRemoveManualHostKeys(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.New("boom"))
+	args := params.SSHManualHosts{
+		Hosts: []string{"10.0.0.1", "10.0.0.2"},
+	}
+	results, err := s.facade.RemoveManualHostKeys(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "boom"}},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"RemoveManualHostKeys", []interface{}{"10.0.0.1"}},
+		{"RemoveManualHostKeys", []interface{}{"10.0.0.2"}},
+	})
+}
+
+func (s *facadeSuite) TestManualHostKeysNotModelAdmin(c *gc.C) {
+	s.authorizer.AdminTag = names.NewUserTag("someone-else")
+
+	_, err := s.facade.ListManualHostKeys()
+	c.Check(err, gc.Equals, common.ErrPerm)
+	_, err = s.facade.ManualHostKeys(params.SSHManualHosts{Hosts: []string{"10.0.0.1"}})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	_, err = s.facade.SetManualHostKeys(params.SSHManualHostKeySet{})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	_, err = s.facade.RemoveManualHostKeys(params.SSHManualHosts{})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	s.backend.stub.CheckNoCalls(c)
+}
+
 type mockBackend struct {
 	stub     jujutesting.Stub
 	proxySSH bool
@@ -235,6 +320,32 @@ func (backend *mockBackend) GetSSHHostKeys(tag names.MachineTag) (state.SSHHostK
 	return nil, errors.New("machine not found")
 }
 
+func (backend *mockBackend) ManualHostKeys(host string) (state.SSHHostKeys, error) {
+	backend.stub.AddCall("ManualHostKeys", host)
+	if host == "10.0.0.1" {
+		return state.SSHHostKeys{"rsa-a"}, nil
+	}
+	return nil, errors.NotFoundf("keys for host %q", host)
+}
+
+func (backend *mockBackend) AllManualHostKeys() (map[string]state.SSHHostKeys, error) {
+	backend.stub.AddCall("AllManualHostKeys")
+	return map[string]state.SSHHostKeys{
+		"10.0.0.2": {"rsa-b", "dsa-b"},
+		"10.0.0.1": {"rsa-a"},
+	}, nil
+}
+
+func (backend *mockBackend) SetManualHostKeys(host string, keys state.SSHHostKeys) error {
+	backend.stub.AddCall("SetManualHostKeys", host, keys)
+	return backend.stub.NextErr()
+}
+
+func (backend *mockBackend) RemoveManualHostKeys(host string) error {
+	backend.stub.AddCall("RemoveManualHostKeys", host)
+	return backend.stub.NextErr()
+}
+
 func (backend *mockBackend) CloudSpec() (environs.CloudSpec, error) {
 	backend.stub.AddCall("CloudSpec")
 	return dummy.SampleCloudSpec(), nil
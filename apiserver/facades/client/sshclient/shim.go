@@ -21,6 +21,10 @@ type Backend interface {
 	CloudSpec() (environs.CloudSpec, error)
 	GetMachineForEntity(tag string) (SSHMachine, error)
 	GetSSHHostKeys(names.MachineTag) (state.SSHHostKeys, error)
+	ManualHostKeys(host string) (state.SSHHostKeys, error)
+	AllManualHostKeys() (map[string]state.SSHHostKeys, error)
+	SetManualHostKeys(host string, keys state.SSHHostKeys) error
+	RemoveManualHostKeys(host string) error
 	ModelTag() names.ModelTag
 }
 
@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package statushistoryquery provides the StatusHistoryQuery facade,
+// which lets clients such as dashboards page through the status
+// history of a model's units and machines over a time range.
+package statushistoryquery
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+const (
+	// DefaultLimit is the number of entries in a page when a query
+	// does not specify a limit.
+	DefaultLimit = 100
+
+	// MaxLimit is the greatest number of entries returned in a page.
+	MaxLimit = 1000
+)
+
+// Backend defines the state functionality required by the
+// statushistoryquery facade. For details on the methods, see the
+// methods on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	StatusHistoryPage(names.Tag, status.HistoryKind, status.StatusHistoryRange) (status.StatusHistoryPage, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+// API provides the StatusHistoryQuery API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new StatusHistoryQuery API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Query returns a page of status history for each of the queries.
+// Entries are returned oldest first; a page's Next cursor, passed as
+// the After of a subsequent query over the same range, selects the
+// following page.
+func (api *API) Query(args params.StatusHistoryQueries) (params.StatusHistoryPageResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.StatusHistoryPageResults{}, errors.Trace(err)
+	}
+	results := params.StatusHistoryPageResults{
+		Results: make([]params.StatusHistoryPageResult, len(args.Queries)),
+	}
+	for i, q := range args.Queries {
+		page, err := api.query(q)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Page = page
+	}
+	return results, nil
+}
+
+func (api *API) query(q params.StatusHistoryQuery) (params.StatusHistoryPage, error) {
+	tag, err := names.ParseTag(q.Tag)
+	if err != nil {
+		return params.StatusHistoryPage{}, errors.Trace(err)
+	}
+	kind := status.HistoryKind(q.Kind)
+	if !kind.Valid() {
+		return params.StatusHistoryPage{}, errors.NotValidf("status history kind %q", q.Kind)
+	}
+	r := status.StatusHistoryRange{
+		From:  q.From,
+		After: q.After,
+		Limit: q.Limit,
+	}
+	if q.To != nil {
+		r.To = *q.To
+	}
+	if r.Limit == 0 {
+		r.Limit = DefaultLimit
+	} else if r.Limit > MaxLimit {
+		r.Limit = MaxLimit
+	}
+	page, err := api.backend.StatusHistoryPage(tag, kind, r)
+	if err != nil {
+		return params.StatusHistoryPage{}, errors.Trace(err)
+	}
+	result := params.StatusHistoryPage{
+		Statuses: make([]params.DetailedStatus, len(page.Statuses)),
+		Next:     page.Next,
+	}
+	for i, s := range page.Statuses {
+		result.Statuses[i] = params.DetailedStatus{
+			Status: s.Status.String(),
+			Info:   s.Info,
+			Data:   s.Data,
+			Since:  s.Since,
+			Kind:   s.Kind.String(),
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statushistoryquery_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/statushistoryquery"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type StatusHistoryQuerySuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&StatusHistoryQuerySuite{})
+
+func (s *StatusHistoryQuerySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+	s.backend = &mockBackend{}
+}
+
+func (s *StatusHistoryQuerySuite) newAPI(c *gc.C) *statushistoryquery.API {
+	api, err := statushistoryquery.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *StatusHistoryQuerySuite) TestNewAPINonClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := statushistoryquery.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *StatusHistoryQuerySuite) TestQuery(c *gc.C) {
+	from := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	since := from.Add(time.Minute)
+	s.backend.page = status.StatusHistoryPage{
+		Statuses: []status.DetailedStatus{{
+			Status: status.Active,
+			Info:   "ready",
+			Data:   map[string]interface{}{"foo": "bar"},
+			Since:  &since,
+			Kind:   status.KindWorkload,
+		}},
+		Next: "cursor",
+	}
+	results, err := s.newAPI(c).Query(params.StatusHistoryQueries{
+		Queries: []params.StatusHistoryQuery{{
+			Tag:   "unit-mysql-0",
+			Kind:  "unit",
+			From:  from,
+			To:    &to,
+			After: "previous",
+			Limit: 10,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StatusHistoryPageResults{
+		Results: []params.StatusHistoryPageResult{{
+			Page: params.StatusHistoryPage{
+				Statuses: []params.DetailedStatus{{
+					Status: "active",
+					Info:   "ready",
+					Data:   map[string]interface{}{"foo": "bar"},
+					Since:  &since,
+					Kind:   "workload",
+				}},
+				Next: "cursor",
+			},
+		}},
+	})
+	s.backend.CheckCall(c, 1, "StatusHistoryPage", names.NewUnitTag("mysql/0"), status.KindUnit, status.StatusHistoryRange{
+		From:  from,
+		To:    to,
+		After: "previous",
+		Limit: 10,
+	})
+}
+
+func (s *StatusHistoryQuerySuite) TestQueryLimits(c *gc.C) {
+	results, err := s.newAPI(c).Query(params.StatusHistoryQueries{
+		Queries: []params.StatusHistoryQuery{{
+			Tag:  "machine-0",
+			Kind: "machine",
+		}, {
+			Tag:   "machine-0",
+			Kind:  "machine",
+			Limit: 5000,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	s.backend.CheckCallNames(c, "ModelTag", "StatusHistoryPage", "StatusHistoryPage")
+	c.Assert(s.backend.Calls()[1].Args[2], jc.DeepEquals, status.StatusHistoryRange{
+		Limit: statushistoryquery.DefaultLimit,
+	})
+	c.Assert(s.backend.Calls()[2].Args[2], jc.DeepEquals, status.StatusHistoryRange{
+		Limit: statushistoryquery.MaxLimit,
+	})
+}
+
+func (s *StatusHistoryQuerySuite) TestQueryErrors(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf("unit %q", "mysql/0"))
+	results, err := s.newAPI(c).Query(params.StatusHistoryQueries{
+		Queries: []params.StatusHistoryQuery{{
+			Tag:  "unit-mysql-0",
+			Kind: "unit",
+		}, {
+			Tag:  "mysql",
+			Kind: "unit",
+		}, {
+			Tag:  "unit-mysql-0",
+			Kind: "nope",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, jc.DeepEquals, apiservertesting.NotFoundError(`unit "mysql/0"`))
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"mysql" is not a valid tag`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `status history kind "nope" not valid`)
+}
+
+func (s *StatusHistoryQuerySuite) TestQueryPermission(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	_, err := s.newAPI(c).Query(params.StatusHistoryQueries{
+		Queries: []params.StatusHistoryQuery{{
+			Tag:  "unit-mysql-0",
+			Kind: "unit",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	testing.Stub
+	page status.StatusHistoryPage
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	m.MethodCall(m, "ModelTag")
+	return coretesting.ModelTag
+}
+
+func (m *mockBackend) StatusHistoryPage(tag names.Tag, kind status.HistoryKind, r status.StatusHistoryRange) (status.StatusHistoryPage, error) {
+	m.MethodCall(m, "StatusHistoryPage", tag, kind, r)
+	if err := m.NextErr(); err != nil {
+		return status.StatusHistoryPage{}, err
+	}
+	return m.page, nil
+}
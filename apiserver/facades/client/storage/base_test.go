@@ -92,10 +92,14 @@ const (
 	getBlockForTypeCall                     = "getBlockForType"
 	volumeAttachmentCall                    = "volumeAttachment"
 	attachStorageCall                       = "attachStorage"
+	validateAttachStorageCall               = "validateAttachStorage"
 	detachStorageCall                       = "detachStorage"
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
 	addExistingFilesystemCall               = "addExistingFilesystem"
+	addExistingVolumeCall                   = "addExistingVolume"
+	resizeStorageCall                       = "resizeStorage"
+	storagePoolInUseCall                    = "storagePoolInUse"
 )
 
 func (s *baseStorageSuite) constructState() *mockState {
@@ -257,6 +261,18 @@ func (s *baseStorageSuite) constructState() *mockState {
 				names.ReadableString(unit),
 			)
 		},
+		resizeStorage: func(storage names.StorageTag, size uint64) error {
+			s.stub.AddCall(resizeStorageCall, storage, size)
+			return s.stub.NextErr()
+		},
+		storagePoolInUse: func(name string) (bool, error) {
+			s.stub.AddCall(storagePoolInUseCall, name)
+			return false, s.stub.NextErr()
+		},
+		validateAttachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
+			s.stub.AddCall(validateAttachStorageCall, storage, unit)
+			return s.stub.NextErr()
+		},
 		attachStorage: func(storage names.StorageTag, unit names.UnitTag) error {
 			s.stub.AddCall(attachStorageCall, storage, unit)
 			if storage == s.storageTag && unit == s.unitTag {
@@ -280,6 +296,10 @@ func (s *baseStorageSuite) constructState() *mockState {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
 		},
+		addExistingVolume: func(v state.VolumeInfo, storageName string) (names.StorageTag, error) {
+			s.stub.AddCall(addExistingVolumeCall, v, storageName)
+			return s.storageTag, s.stub.NextErr()
+		},
 	}
 }
 
@@ -324,6 +344,18 @@ func (s *baseStorageSuite) constructPoolManager() *mockPoolManager {
 			delete(s.pools, name)
 			return nil
 		},
+		replacePool: func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) error {
+			existing, ok := s.pools[name]
+			if !ok {
+				return errors.NotFoundf("mock pool manager: replace pool %v", name)
+			}
+			if providerType == "" {
+				providerType = existing.Provider()
+			}
+			pool, err := jujustorage.NewConfig(name, providerType, attrs)
+			s.pools[name] = pool
+			return err
+		},
 		listPools: func() ([]*jujustorage.Config, error) {
 			result := make([]*jujustorage.Config, len(s.pools))
 			i := 0
@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/storage"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+)
+
+type cloudPoolSuite struct {
+	baseStorageSuite
+
+	apiv5            *storage.APIv5
+	cloudPools       map[string]*jujustorage.Config
+	cloudPoolManager *mockPoolManager
+}
+
+var _ = gc.Suite(&cloudPoolSuite{})
+
+func (s *cloudPoolSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.cloudPools = make(map[string]*jujustorage.Config)
+	s.cloudPoolManager = &mockPoolManager{
+		createPool: func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error) {
+			pool, err := jujustorage.NewConfig(name, providerType, attrs)
+			s.cloudPools[name] = pool
+			return pool, err
+		},
+		deletePool: func(name string) error {
+			if _, ok := s.cloudPools[name]; !ok {
+				return errors.NotFoundf("pool %q", name)
+			}
+			delete(s.cloudPools, name)
+			return nil
+		},
+		listPools: func() ([]*jujustorage.Config, error) {
+			result := make([]*jujustorage.Config, 0, len(s.cloudPools))
+			for _, v := range s.cloudPools {
+				result = append(result, v)
+			}
+			return result, nil
+		},
+	}
+	s.apiv5 = s.newAPI(c)
+}
+
+func (s *cloudPoolSuite) newAPI(c *gc.C) *storage.APIv5 {
+	api, err := storage.NewAPIv5(
+		s.state, s.registry, s.poolManager, "dummy", s.cloudPoolManager,
+		s.resources, s.authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *cloudPoolSuite) TestCreateCloudPools(c *gc.C) {
+	results, err := s.apiv5.CreateCloudPools(params.CloudStoragePools{
+		Pools: []params.CloudStoragePool{{
+			CloudTag: "cloud-dummy",
+			Pool: params.StoragePool{
+				Name:     "fast",
+				Provider: string(provider.LoopProviderType),
+				Attrs:    map[string]interface{}{"foo": "bar"},
+			},
+		}, {
+			CloudTag: "cloud-other",
+			Pool:     params.StoragePool{Name: "slow", Provider: string(provider.LoopProviderType)},
+		}, {
+			CloudTag: "machine-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `managing storage pools of cloud "other" from a model on cloud "dummy" not supported`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid cloud tag`)
+
+	expected, err := jujustorage.NewConfig("fast", provider.LoopProviderType, map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.cloudPools, jc.DeepEquals, map[string]*jujustorage.Config{"fast": expected})
+	c.Assert(s.pools, gc.HasLen, 0)
+}
+
+func (s *cloudPoolSuite) TestCreateCloudPoolsRequiresSuperuser(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("writer")}
+	_, err := s.newAPI(c).CreateCloudPools(params.CloudStoragePools{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *cloudPoolSuite) TestListCloudPools(c *gc.C) {
+	pool, err := jujustorage.NewConfig("fast", provider.LoopProviderType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.cloudPools["fast"] = pool
+
+	results, err := s.apiv5.ListCloudPools(params.Entities{
+		Entities: []params.Entity{{Tag: "cloud-dummy"}, {Tag: "cloud-other"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, jc.DeepEquals, []params.StoragePool{{
+		Name:     "fast",
+		Provider: string(provider.LoopProviderType),
+	}})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `managing storage pools of cloud "other" .* not supported`)
+}
+
+func (s *cloudPoolSuite) TestRemoveCloudPools(c *gc.C) {
+	pool, err := jujustorage.NewConfig("fast", provider.LoopProviderType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.cloudPools["fast"] = pool
+
+	results, err := s.apiv5.RemoveCloudPools(params.CloudStoragePoolNames{
+		Pools: []params.CloudStoragePoolName{
+			{CloudTag: "cloud-dummy", Name: "fast"},
+			{CloudTag: "cloud-dummy", Name: "missing"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(s.cloudPools, gc.HasLen, 0)
+}
+
+func (s *cloudPoolSuite) TestRemoveCloudPoolsRequiresSuperuser(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("writer")}
+	_, err := s.newAPI(c).RemoveCloudPools(params.CloudStoragePoolNames{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
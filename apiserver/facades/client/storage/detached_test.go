@@ -0,0 +1,204 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/storage"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type detachedStorageSuite struct {
+	baseStorageSuite
+	apiv6 *storage.APIv6
+}
+
+var _ = gc.Suite(&detachedStorageSuite{})
+
+func (s *detachedStorageSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.apiv6 = s.newAPI(c)
+}
+
+func (s *detachedStorageSuite) newAPI(c *gc.C) *storage.APIv6 {
+	api, err := storage.NewAPIv6(
+		s.state, s.registry, s.poolManager, "dummy", &mockPoolManager{},
+		s.resources, s.authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *detachedStorageSuite) TestListDetachedStorage(c *gc.C) {
+	blockTag := names.NewStorageTag("pgdata/1")
+	filesystemTag := names.NewStorageTag("logs/2")
+	dyingTag := names.NewStorageTag("logs/3")
+	s.state.allStorageInstances = func() ([]state.StorageInstance, error) {
+		return []state.StorageInstance{
+			// Owned storage is not detached.
+			s.storageInstance,
+			&mockStorageInstance{
+				kind:            state.StorageKindBlock,
+				lastOwner:       names.NewUnitTag("postgresql/0"),
+				storageTag:      blockTag,
+				storageName:     "pgdata",
+				pool:            "ebs",
+				filesystemLabel: "pgdata",
+				life:            state.Alive,
+			},
+			&mockStorageInstance{
+				kind:        state.StorageKindFilesystem,
+				storageTag:  filesystemTag,
+				storageName: "logs",
+				pool:        "rootfs",
+				life:        state.Alive,
+			},
+			// Dying storage cannot be reused.
+			&mockStorageInstance{
+				kind:        state.StorageKindFilesystem,
+				storageTag:  dyingTag,
+				storageName: "logs",
+				life:        state.Dying,
+			},
+		}, nil
+	}
+	s.state.storageInstanceVolume = func(tag names.StorageTag) (state.Volume, error) {
+		c.Assert(tag, gc.Equals, blockTag)
+		return &mockVolume{info: &state.VolumeInfo{Size: 1024}}, nil
+	}
+	s.state.storageInstanceFilesystem = func(tag names.StorageTag) (state.Filesystem, error) {
+		c.Assert(tag, gc.Equals, filesystemTag)
+		return &mockFilesystem{}, nil
+	}
+
+	results, err := s.apiv6.ListDetachedStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.DetachedStorageResults{
+		Results: []params.DetachedStorageDetails{{
+			StorageTag:      "storage-pgdata-1",
+			StorageName:     "pgdata",
+			Kind:            params.StorageKindBlock,
+			Pool:            "ebs",
+			Size:            1024,
+			LastApplication: "postgresql",
+			LastUnitTag:     "unit-postgresql-0",
+			FilesystemLabel: "pgdata",
+		}, {
+			StorageTag:  "storage-logs-2",
+			StorageName: "logs",
+			Kind:        params.StorageKindFilesystem,
+			Pool:        "rootfs",
+		}},
+	})
+}
+
+func (s *detachedStorageSuite) TestListDetachedStorageNotFound(c *gc.C) {
+	s.state.allStorageInstances = func() ([]state.StorageInstance, error) {
+		return []state.StorageInstance{
+			&mockStorageInstance{
+				kind:        state.StorageKindBlock,
+				storageTag:  names.NewStorageTag("pgdata/1"),
+				storageName: "pgdata",
+				life:        state.Alive,
+			},
+			&mockStorageInstance{
+				kind:        state.StorageKindFilesystem,
+				storageTag:  names.NewStorageTag("logs/2"),
+				storageName: "logs",
+				life:        state.Alive,
+			},
+		}, nil
+	}
+	s.state.storageInstanceVolume = func(tag names.StorageTag) (state.Volume, error) {
+		return nil, errors.NotFoundf("volume for %s", names.ReadableString(tag))
+	}
+	s.state.storageInstanceFilesystem = func(tag names.StorageTag) (state.Filesystem, error) {
+		return nil, errors.NotFoundf("filesystem for %s", names.ReadableString(tag))
+	}
+
+	results, err := s.apiv6.ListDetachedStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.DetachedStorageResults{
+		Results: []params.DetachedStorageDetails{{
+			StorageTag:  "storage-pgdata-1",
+			StorageName: "pgdata",
+			Kind:        params.StorageKindBlock,
+		}, {
+			StorageTag:  "storage-logs-2",
+			StorageName: "logs",
+			Kind:        params.StorageKindFilesystem,
+		}},
+	})
+}
+
+func (s *detachedStorageSuite) TestListDetachedStorageError(c *gc.C) {
+	detachedTag := names.NewStorageTag("pgdata/1")
+	s.state.allStorageInstances = func() ([]state.StorageInstance, error) {
+		return []state.StorageInstance{&mockStorageInstance{
+			kind:       state.StorageKindBlock,
+			storageTag: detachedTag,
+			life:       state.Alive,
+		}}, nil
+	}
+	s.state.storageInstanceVolume = func(tag names.StorageTag) (state.Volume, error) {
+		return nil, errors.New("boom")
+	}
+	_, err := s.apiv6.ListDetachedStorage()
+	c.Assert(err, gc.ErrorMatches, "getting details for storage pgdata/1: boom")
+}
+
+func (s *detachedStorageSuite) TestListDetachedStoragePermission(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("someone")}
+	api := s.newAPI(c)
+	_, err := api.ListDetachedStorage()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *detachedStorageSuite) TestValidateAttach(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("charm has no storage called pgdata"))
+	results, err := s.apiv6.ValidateAttach(params.StorageAttachmentIds{
+		Ids: []params.StorageAttachmentId{{
+			StorageTag: "storage-data-0",
+			UnitTag:    "unit-mysql-0",
+		}, {
+			StorageTag: "storage-pgdata-1",
+			UnitTag:    "unit-mysql-0",
+		}, {
+			StorageTag: "volume-0",
+			UnitTag:    "unit-mysql-0",
+		}, {
+			StorageTag: "storage-data-0",
+			UnitTag:    "machine-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "charm has no storage called pgdata"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		{Error: &params.Error{Message: `"machine-0" is not a valid unit tag`}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{validateAttachStorageCall, []interface{}{
+			names.NewStorageTag("data/0"), names.NewUnitTag("mysql/0"),
+		}},
+		{validateAttachStorageCall, []interface{}{
+			names.NewStorageTag("pgdata/1"), names.NewUnitTag("mysql/0"),
+		}},
+	})
+}
+
+func (s *detachedStorageSuite) TestValidateAttachPermission(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("someone")}
+	api := s.newAPI(c)
+	_, err := api.ValidateAttach(params.StorageAttachmentIds{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -15,10 +15,11 @@ import (
 )
 
 type mockPoolManager struct {
-	getPool    func(name string) (*jujustorage.Config, error)
-	createPool func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error)
-	deletePool func(name string) error
-	listPools  func() ([]*jujustorage.Config, error)
+	getPool     func(name string) (*jujustorage.Config, error)
+	createPool  func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) (*jujustorage.Config, error)
+	deletePool  func(name string) error
+	replacePool func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) error
+	listPools   func() ([]*jujustorage.Config, error)
 }
 
 func (m *mockPoolManager) Get(name string) (*jujustorage.Config, error) {
@@ -33,6 +34,10 @@ func (m *mockPoolManager) Delete(name string) error {
 	return m.deletePool(name)
 }
 
+func (m *mockPoolManager) Replace(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) error {
+	return m.replacePool(name, providerType, attrs)
+}
+
 func (m *mockPoolManager) List() ([]*jujustorage.Config, error) {
 	return m.listPools()
 }
@@ -66,8 +71,12 @@ type mockState struct {
 	destroyStorageInstance              func(names.StorageTag, bool) error
 	releaseStorageInstance              func(names.StorageTag, bool) error
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
+	validateAttachStorage               func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
+	addExistingVolume                   func(state.VolumeInfo, string) (names.StorageTag, error)
+	resizeStorage                       func(names.StorageTag, uint64) error
+	storagePoolInUse                    func(string) (bool, error)
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -181,10 +190,22 @@ func (st *mockState) AttachStorage(storage names.StorageTag, unit names.UnitTag)
 	return st.attachStorage(storage, unit)
 }
 
+func (st *mockState) ValidateAttachStorage(storage names.StorageTag, unit names.UnitTag) error {
+	return st.validateAttachStorage(storage, unit)
+}
+
 func (st *mockState) DetachStorage(storage names.StorageTag, unit names.UnitTag) error {
 	return st.detachStorage(storage, unit)
 }
 
+func (st *mockState) ResizeStorage(tag names.StorageTag, size uint64) error {
+	return st.resizeStorage(tag, size)
+}
+
+func (st *mockState) StoragePoolInUse(name string) (bool, error) {
+	return st.storagePoolInUse(name)
+}
+
 func (st *mockState) DestroyStorageInstance(tag names.StorageTag, destroyAttached bool) error {
 	return st.destroyStorageInstance(tag, destroyAttached)
 }
@@ -201,6 +222,10 @@ func (st *mockState) AddExistingFilesystem(f state.FilesystemInfo, v *state.Volu
 	return st.addExistingFilesystem(f, v, s)
 }
 
+func (st *mockState) AddExistingVolume(v state.VolumeInfo, s string) (names.StorageTag, error) {
+	return st.addExistingVolume(v, s)
+}
+
 type mockVolume struct {
 	state.Volume
 	tag     names.VolumeTag
@@ -313,10 +338,14 @@ func (m *mockFilesystemAttachment) Life() state.Life {
 
 type mockStorageInstance struct {
 	state.StorageInstance
-	kind       state.StorageKind
-	owner      names.Tag
-	storageTag names.Tag
-	life       state.Life
+	kind            state.StorageKind
+	owner           names.Tag
+	lastOwner       names.Tag
+	storageTag      names.Tag
+	storageName     string
+	pool            string
+	filesystemLabel string
+	life            state.Life
 }
 
 func (m *mockStorageInstance) Kind() state.StorageKind {
@@ -327,6 +356,22 @@ func (m *mockStorageInstance) Owner() (names.Tag, bool) {
 	return m.owner, m.owner != nil
 }
 
+func (m *mockStorageInstance) LastOwner() (names.Tag, bool) {
+	return m.lastOwner, m.lastOwner != nil
+}
+
+func (m *mockStorageInstance) FilesystemLabel() string {
+	return m.filesystemLabel
+}
+
+func (m *mockStorageInstance) StorageName() string {
+	return m.storageName
+}
+
+func (m *mockStorageInstance) Pool() string {
+	return m.pool
+}
+
 func (m *mockStorageInstance) Tag() names.Tag {
 	return m.storageTag
 }
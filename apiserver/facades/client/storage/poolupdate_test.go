@@ -0,0 +1,135 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/storage"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+)
+
+type poolUpdateSuite struct {
+	baseStorageSuite
+	apiv8 *storage.APIv8
+}
+
+var _ = gc.Suite(&poolUpdateSuite{})
+
+func (s *poolUpdateSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.apiv8 = s.newAPI(c)
+	pool, err := jujustorage.NewConfig("fast", provider.LoopProviderType, map[string]interface{}{"iops": 100})
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["fast"] = pool
+}
+
+func (s *poolUpdateSuite) newAPI(c *gc.C) *storage.APIv8 {
+	api, err := storage.NewAPIv8(
+		s.state, s.registry, s.poolManager, "dummy", &mockPoolManager{},
+		s.resources, s.authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *poolUpdateSuite) TestUpdatePool(c *gc.C) {
+	results, err := s.apiv8.UpdatePool(params.StoragePoolArgs{
+		Pools: []params.StoragePool{{
+			Name:  "fast",
+			Attrs: map[string]interface{}{"iops": 200},
+		}, {
+			Name:  "missing",
+			Attrs: map[string]interface{}{"iops": 200},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	pool := s.pools["fast"]
+	c.Assert(pool.Provider(), gc.Equals, provider.LoopProviderType)
+	c.Assert(pool.Attrs(), jc.DeepEquals, map[string]interface{}{"iops": 200})
+	// The provider is unchanged, so there's no need to check
+	// whether the pool is in use.
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolProvider(c *gc.C) {
+	results, err := s.apiv8.UpdatePool(params.StoragePoolArgs{
+		Pools: []params.StoragePool{{
+			Name:     "fast",
+			Provider: "tmpfs",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Assert(s.pools["fast"].Provider(), gc.Equals, jujustorage.ProviderType("tmpfs"))
+	s.stub.CheckCallNames(c, storagePoolInUseCall)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolProviderInUse(c *gc.C) {
+	s.state.storagePoolInUse = func(name string) (bool, error) {
+		return true, nil
+	}
+	results, err := s.apiv8.UpdatePool(params.StoragePoolArgs{
+		Pools: []params.StoragePool{{
+			Name:     "fast",
+			Provider: "tmpfs",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, `cannot change provider of storage pool "fast": pool is in use`)
+	c.Assert(s.pools["fast"].Provider(), gc.Equals, provider.LoopProviderType)
+}
+
+func (s *poolUpdateSuite) TestRemovePool(c *gc.C) {
+	results, err := s.apiv8.RemovePool(params.StoragePoolDeleteArgs{
+		Pools: []params.StoragePoolDeleteArg{{Name: "fast"}, {Name: "missing"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(s.pools, gc.HasLen, 0)
+	s.stub.CheckCall(c, 0, storagePoolInUseCall, "fast")
+}
+
+func (s *poolUpdateSuite) TestRemovePoolInUse(c *gc.C) {
+	s.state.storagePoolInUse = func(name string) (bool, error) {
+		return true, nil
+	}
+	results, err := s.apiv8.RemovePool(params.StoragePoolDeleteArgs{
+		Pools: []params.StoragePoolDeleteArg{{Name: "fast"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, `cannot remove storage pool "fast": pool is in use`)
+	c.Assert(s.pools, gc.HasLen, 1)
+}
+
+func (s *poolUpdateSuite) TestRemovePoolInUseError(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	results, err := s.apiv8.RemovePool(params.StoragePoolDeleteArgs{
+		Pools: []params.StoragePoolDeleteArg{{Name: "fast"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "boom")
+	c.Assert(s.pools, gc.HasLen, 1)
+}
+
+func (s *poolUpdateSuite) TestPoolPermission(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("someone")}
+	api := s.newAPI(c)
+	_, err := api.UpdatePool(params.StoragePoolArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = api.RemovePool(params.StoragePoolDeleteArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/storage"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type resizeStorageSuite struct {
+	baseStorageSuite
+	apiv7 *storage.APIv7
+}
+
+var _ = gc.Suite(&resizeStorageSuite{})
+
+func (s *resizeStorageSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.apiv7 = s.newAPI(c)
+}
+
+func (s *resizeStorageSuite) newAPI(c *gc.C) *storage.APIv7 {
+	api, err := storage.NewAPIv7(
+		s.state, s.registry, s.poolManager, "dummy", &mockPoolManager{},
+		s.resources, s.authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *resizeStorageSuite) TestResizeStorage(c *gc.C) {
+	s.stub.SetErrors(nil, nil, errors.NotValidf("new size"))
+	results, err := s.apiv7.ResizeStorage(params.ResizeStorage{
+		Storage: []params.ResizeStorageInstance{{
+			Tag:  "storage-data-0",
+			Size: 2048,
+		}, {
+			Tag:  "storage-data-1",
+			Size: 512,
+		}, {
+			Tag:  "volume-0",
+			Size: 2048,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "new size not valid"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{resizeStorageCall, []interface{}{names.NewStorageTag("data/0"), uint64(2048)}},
+		{resizeStorageCall, []interface{}{names.NewStorageTag("data/1"), uint64(512)}},
+	})
+}
+
+func (s *resizeStorageSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "resize blocked")
+	_, err := s.apiv7.ResizeStorage(params.ResizeStorage{
+		Storage: []params.ResizeStorageInstance{{
+			Tag:  "storage-data-0",
+			Size: 2048,
+		}},
+	})
+	s.assertBlocked(c, err, "resize blocked")
+}
+
+func (s *resizeStorageSuite) TestResizeStoragePermission(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("someone")}
+	api := s.newAPI(c)
+	_, err := api.ResizeStorage(params.ResizeStorage{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -30,7 +30,11 @@ func NewFacadeV4(
 		return nil, errors.Annotate(err, "getting environ")
 	}
 	registry := stateenvirons.NewStorageProviderRegistry(env)
-	pm := poolmanager.New(state.NewStateSettings(st), registry)
+	poolSettings, err := state.NewStoragePoolSettings(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting storage pool settings")
+	}
+	pm := poolmanager.New(poolSettings, registry)
 
 	backend, err := getState(st)
 	if err != nil {
@@ -39,6 +43,75 @@ func NewFacadeV4(
 	return NewAPIv4(backend, registry, pm, resources, authorizer)
 }
 
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	poolSettings, err := state.NewStoragePoolSettings(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting storage pool settings")
+	}
+	pm := poolmanager.New(poolSettings, registry)
+
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting model")
+	}
+	cloudPM := poolmanager.New(state.NewCloudStoragePoolSettings(st, model.Cloud()), registry)
+
+	backend, err := getState(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting backend")
+	}
+	return NewAPIv5(backend, registry, pm, model.Cloud(), cloudPM, resources, authorizer)
+}
+
+// NewFacadeV6 provides the signature required for facade registration.
+func NewFacadeV6(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv6, error) {
+	apiv5, err := NewFacadeV5(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv6{apiv5}, nil
+}
+
+// NewFacadeV8 provides the signature required for facade registration.
+func NewFacadeV8(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv8, error) {
+	apiv7, err := NewFacadeV7(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv8{apiv7}, nil
+}
+
+// NewFacadeV7 provides the signature required for facade registration.
+func NewFacadeV7(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv7, error) {
+	apiv6, err := NewFacadeV6(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv7{apiv6}, nil
+}
+
 // NewFacadeV3 provides the signature required for facade registration.
 func NewFacadeV3(
 	st *state.State,
@@ -50,7 +123,11 @@ func NewFacadeV3(
 		return nil, errors.Annotate(err, "getting environ")
 	}
 	registry := stateenvirons.NewStorageProviderRegistry(env)
-	pm := poolmanager.New(state.NewStateSettings(st), registry)
+	poolSettings, err := state.NewStoragePoolSettings(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting storage pool settings")
+	}
+	pm := poolmanager.New(poolSettings, registry)
 
 	backend, err := getState(st)
 	if err != nil {
@@ -142,6 +219,11 @@ type storageAccess interface {
 	// specified tag to the unit with the specified tag.
 	AttachStorage(names.StorageTag, names.UnitTag) error
 
+	// ValidateAttachStorage checks that the storage instance with the
+	// specified tag could be attached to the unit with the specified
+	// tag, without attaching it.
+	ValidateAttachStorage(names.StorageTag, names.UnitTag) error
+
 	// DetachStorage detaches the storage instance with the
 	// specified tag from the unit with the specified tag.
 	DetachStorage(names.StorageTag, names.UnitTag) error
@@ -158,6 +240,17 @@ type storageAccess interface {
 
 	// AddExistingFilesystem imports an existing filesystem into the model.
 	AddExistingFilesystem(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error)
+
+	// AddExistingVolume imports an existing volume into the model.
+	AddExistingVolume(v state.VolumeInfo, storageName string) (names.StorageTag, error)
+
+	// ResizeStorage grows the storage instance with the specified
+	// tag to the specified size, in MiB.
+	ResizeStorage(names.StorageTag, uint64) error
+
+	// StoragePoolInUse reports whether any of the model's storage
+	// refers to the named storage pool.
+	StoragePoolInUse(name string) (bool, error)
 }
 
 var getState = func(st *state.State) (storageAccess, error) {
@@ -35,6 +35,103 @@ type APIv4 struct {
 	*APIv3
 }
 
+// APIv5 implements the storage v5 API.
+type APIv5 struct {
+	*APIv4
+
+	// cloud is the name of the cloud hosting the model, and
+	// cloudPoolManager manages the cloud's default storage pools.
+	cloud            string
+	cloudPoolManager poolmanager.PoolManager
+}
+
+// APIv6 implements the storage v6 API.
+type APIv6 struct {
+	*APIv5
+}
+
+// APIv7 implements the storage v7 API.
+type APIv7 struct {
+	*APIv6
+}
+
+// APIv8 implements the storage v8 API.
+type APIv8 struct {
+	*APIv7
+}
+
+// NewAPIv8 returns a new storage v8 API facade.
+func NewAPIv8(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	cloud string,
+	cloudPM poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv8, error) {
+	apiv7, err := NewAPIv7(st, registry, pm, cloud, cloudPM, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv8{apiv7}, nil
+}
+
+// NewAPIv7 returns a new storage v7 API facade.
+func NewAPIv7(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	cloud string,
+	cloudPM poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv7, error) {
+	apiv6, err := NewAPIv6(st, registry, pm, cloud, cloudPM, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv7{apiv6}, nil
+}
+
+// NewAPIv6 returns a new storage v6 API facade.
+func NewAPIv6(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	cloud string,
+	cloudPM poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv6, error) {
+	apiv5, err := NewAPIv5(st, registry, pm, cloud, cloudPM, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv6{apiv5}, nil
+}
+
+// NewAPIv5 returns a new storage v5 API facade.
+func NewAPIv5(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	cloud string,
+	cloudPM poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	apiv4, err := NewAPIv4(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv5{
+		APIv4:            apiv4,
+		cloud:            cloud,
+		cloudPoolManager: cloudPM,
+	}, nil
+}
+
 // NewAPIv4 returns a new storage v4 API facade.
 func NewAPIv4(
 	st storageAccess,
@@ -946,8 +1043,7 @@ func (a *APIv4) Import(args params.BulkImportStorageParams) (params.ImportStorag
 }
 
 func (a *APIv4) importStorage(arg params.ImportStorageParams) (*params.ImportStorageDetails, error) {
-	if arg.Kind != params.StorageKindFilesystem {
-		// TODO(axw) implement support for volumes.
+	if arg.Kind != params.StorageKindFilesystem && arg.Kind != params.StorageKindBlock {
 		return nil, errors.NotSupportedf("storage kind %q", arg.Kind.String())
 	}
 	if !storage.IsValidPoolName(arg.Pool) {
@@ -971,18 +1067,82 @@ func (a *APIv4) importStorage(arg params.ImportStorageParams) (*params.ImportSto
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if arg.Kind == params.StorageKindBlock {
+		return a.importVolume(arg, provider, cfg)
+	}
 	return a.importFilesystem(arg, provider, cfg)
 }
 
-func (a *APIv4) importFilesystem(
+func (a *APIv4) importVolume(
 	arg params.ImportStorageParams,
 	provider storage.Provider,
 	cfg *storage.Config,
 ) (*params.ImportStorageDetails, error) {
-	resourceTags := map[string]string{
+	if !provider.Supports(storage.StorageKindBlock) {
+		return nil, errors.NotSupportedf(
+			"importing volume with storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	volumeInfo, err := a.importProviderVolume(arg, provider, cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageTag, err := a.storage.AddExistingVolume(*volumeInfo, arg.StorageName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ImportStorageDetails{
+		StorageTag: storageTag.String(),
+	}, nil
+}
+
+// importProviderVolume imports the volume with the given provider ID
+// using the storage provider's volume source, returning the volume
+// info to record in state.
+func (a *APIv4) importProviderVolume(
+	arg params.ImportStorageParams,
+	provider storage.Provider,
+	cfg *storage.Config,
+) (*state.VolumeInfo, error) {
+	volumeSource, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeImporter, ok := volumeSource.(storage.VolumeImporter)
+	if !ok {
+		return nil, errors.NotSupportedf(
+			"importing volume with storage provider %q",
+			cfg.Provider(),
+		)
+	}
+	info, err := volumeImporter.ImportVolume(arg.ProviderId, a.importResourceTags())
+	if err != nil {
+		return nil, errors.Annotate(err, "importing volume")
+	}
+	return &state.VolumeInfo{
+		HardwareId: info.HardwareId,
+		WWN:        info.WWN,
+		Size:       info.Size,
+		Pool:       arg.Pool,
+		VolumeId:   info.VolumeId,
+		Persistent: info.Persistent,
+	}, nil
+}
+
+// importResourceTags returns the tags to apply to imported storage.
+func (a *APIv4) importResourceTags() map[string]string {
+	return map[string]string{
 		tags.JujuModel:      a.storage.ModelTag().Id(),
 		tags.JujuController: a.storage.ControllerTag().Id(),
 	}
+}
+
+func (a *APIv4) importFilesystem(
+	arg params.ImportStorageParams,
+	provider storage.Provider,
+	cfg *storage.Config,
+) (*params.ImportStorageDetails, error) {
 	var volumeInfo *state.VolumeInfo
 	filesystemInfo := state.FilesystemInfo{Pool: arg.Pool}
 
@@ -1000,36 +1160,18 @@ func (a *APIv4) importFilesystem(
 				cfg.Provider(),
 			)
 		}
-		info, err := filesystemImporter.ImportFilesystem(arg.ProviderId, resourceTags)
+		info, err := filesystemImporter.ImportFilesystem(arg.ProviderId, a.importResourceTags())
 		if err != nil {
 			return nil, errors.Annotate(err, "importing filesystem")
 		}
 		filesystemInfo.FilesystemId = arg.ProviderId
 		filesystemInfo.Size = info.Size
 	} else {
-		volumeSource, err := provider.VolumeSource(cfg)
+		info, err := a.importProviderVolume(arg, provider, cfg)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		volumeImporter, ok := volumeSource.(storage.VolumeImporter)
-		if !ok {
-			return nil, errors.NotSupportedf(
-				"importing volume with storage provider %q",
-				cfg.Provider(),
-			)
-		}
-		info, err := volumeImporter.ImportVolume(arg.ProviderId, resourceTags)
-		if err != nil {
-			return nil, errors.Annotate(err, "importing volume")
-		}
-		volumeInfo = &state.VolumeInfo{
-			HardwareId: info.HardwareId,
-			WWN:        info.WWN,
-			Size:       info.Size,
-			Pool:       arg.Pool,
-			VolumeId:   info.VolumeId,
-			Persistent: info.Persistent,
-		}
+		volumeInfo = info
 		filesystemInfo.Size = info.Size
 	}
 
@@ -1048,3 +1190,306 @@ func (a *APIv4) importFilesystem(
 
 // Destroy was dropped in V4, replaced with Remove.
 func (*APIv4) Destroy(_, _ struct{}) {}
+
+func (a *APIv5) checkIsControllerAdmin() error {
+	isAdmin, err := a.authorizer.HasPermission(permission.SuperuserAccess, a.storage.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// checkCloud returns an error if the cloud tag does not refer to the
+// cloud hosting the model. Default pools can only be managed for the
+// model's cloud, as they are validated using the model's storage
+// providers.
+func (a *APIv5) checkCloud(cloudTag string) error {
+	tag, err := names.ParseCloudTag(cloudTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if tag.Id() != a.cloud {
+		return errors.NotSupportedf("managing storage pools of cloud %q from a model on cloud %q", tag.Id(), a.cloud)
+	}
+	return nil
+}
+
+// ListCloudPools returns the default storage pools of the specified
+// clouds, which are inherited by all models on those clouds.
+func (a *APIv5) ListCloudPools(args params.Entities) (params.StoragePoolsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StoragePoolsResults{}, errors.Trace(err)
+	}
+	results := params.StoragePoolsResults{
+		Results: make([]params.StoragePoolsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		if err := a.checkCloud(arg.Tag); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		pools, err := a.cloudPoolManager.List()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = filterPools(pools, buildFilter(params.StoragePoolFilter{}))
+	}
+	return results, nil
+}
+
+// CreateCloudPools creates default storage pools for the specified
+// clouds. Models on the clouds inherit the pools, and may override
+// them by creating pools with the same names.
+func (a *APIv5) CreateCloudPools(args params.CloudStoragePools) (params.ErrorResults, error) {
+	if err := a.checkIsControllerAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Pools)),
+	}
+	for i, arg := range args.Pools {
+		if err := a.checkCloud(arg.CloudTag); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		_, err := a.cloudPoolManager.Create(
+			arg.Pool.Name,
+			storage.ProviderType(arg.Pool.Provider),
+			arg.Pool.Attrs,
+		)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveCloudPools removes default storage pools from the specified
+// clouds. Pools that models have created with the same names are not
+// affected.
+func (a *APIv5) RemoveCloudPools(args params.CloudStoragePoolNames) (params.ErrorResults, error) {
+	if err := a.checkIsControllerAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Pools)),
+	}
+	for i, arg := range args.Pools {
+		if err := a.checkCloud(arg.CloudTag); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Error = common.ServerError(a.cloudPoolManager.Delete(arg.Name))
+	}
+	return results, nil
+}
+
+// ListDetachedStorage returns the storage instances in the model that
+// are not owned by any unit or application, along with the unit that
+// last owned them. Detached storage may be attached to new units with
+// Application.Deploy, or to existing units with Attach.
+func (a *APIv6) ListDetachedStorage() (params.DetachedStorageResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.DetachedStorageResults{}, errors.Trace(err)
+	}
+	storageInstances, err := a.storage.AllStorageInstances()
+	if err != nil {
+		return params.DetachedStorageResults{}, errors.Trace(err)
+	}
+	results := []params.DetachedStorageDetails{}
+	for _, si := range storageInstances {
+		if _, ok := si.Owner(); ok || si.Life() != state.Alive {
+			continue
+		}
+		details, err := a.detachedStorageDetails(si)
+		if err != nil {
+			return params.DetachedStorageResults{}, errors.Annotatef(
+				err, "getting details for %s",
+				names.ReadableString(si.Tag()),
+			)
+		}
+		results = append(results, details)
+	}
+	return params.DetachedStorageResults{Results: results}, nil
+}
+
+func (a *APIv6) detachedStorageDetails(si state.StorageInstance) (params.DetachedStorageDetails, error) {
+	details := params.DetachedStorageDetails{
+		StorageTag:      si.StorageTag().String(),
+		StorageName:     si.StorageName(),
+		Kind:            params.StorageKind(si.Kind()),
+		Pool:            si.Pool(),
+		FilesystemLabel: si.FilesystemLabel(),
+	}
+	if lastOwner, ok := si.LastOwner(); ok {
+		details.LastUnitTag = lastOwner.String()
+		if lastOwner.Kind() == names.UnitTagKind {
+			appName, err := names.UnitApplication(lastOwner.Id())
+			if err != nil {
+				return params.DetachedStorageDetails{}, errors.Trace(err)
+			}
+			details.LastApplication = appName
+		}
+	}
+	size, err := a.storageSize(si)
+	if err != nil {
+		return params.DetachedStorageDetails{}, errors.Trace(err)
+	}
+	details.Size = size
+	return details, nil
+}
+
+// storageSize returns the provisioned size of the volume or filesystem
+// for the storage instance, or zero if it does not exist or has not
+// been provisioned.
+func (a *APIv6) storageSize(si state.StorageInstance) (uint64, error) {
+	if si.Kind() == state.StorageKindBlock {
+		volume, err := a.storage.StorageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return 0, nil
+		} else if err != nil {
+			return 0, errors.Trace(err)
+		}
+		info, err := volume.Info()
+		if errors.IsNotProvisioned(err) {
+			return 0, nil
+		} else if err != nil {
+			return 0, errors.Trace(err)
+		}
+		return info.Size, nil
+	}
+	filesystem, err := a.storage.StorageInstanceFilesystem(si.StorageTag())
+	if errors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	info, err := filesystem.Info()
+	if errors.IsNotProvisioned(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return info.Size, nil
+}
+
+// ValidateAttach checks that existing storage instances could be
+// attached to the specified units, without attaching them. The
+// storage must not be owned by another unit, and must be compatible
+// with the storage declared by the unit's charm.
+func (a *APIv6) ValidateAttach(args params.StorageAttachmentIds) (params.ErrorResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	validateOne := func(arg params.StorageAttachmentId) error {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			return err
+		}
+		unitTag, err := names.ParseUnitTag(arg.UnitTag)
+		if err != nil {
+			return err
+		}
+		return a.storage.ValidateAttachStorage(storageTag, unitTag)
+	}
+	results := make([]params.ErrorResult, len(args.Ids))
+	for i, arg := range args.Ids {
+		results[i].Error = common.ServerError(validateOne(arg))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// ResizeStorage grows the specified storage instances. The cloud
+// storage backing each storage instance is grown by the storage
+// provisioner, and filesystems are grown online where supported.
+func (a *APIv7) ResizeStorage(args params.ResizeStorage) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	resizeOne := func(arg params.ResizeStorageInstance) error {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			return err
+		}
+		return a.storage.ResizeStorage(tag, arg.Size)
+	}
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		result[i].Error = common.ServerError(resizeOne(arg))
+	}
+	return params.ErrorResults{result}, nil
+}
+
+// UpdatePool replaces the attributes of each of the specified pools.
+// If a provider is specified it replaces the pool's provider, unless
+// the pool is in use by the model's storage.
+func (a *APIv8) UpdatePool(args params.StoragePoolArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Pools)),
+	}
+	for i, pool := range args.Pools {
+		results.Results[i].Error = common.ServerError(a.updatePool(pool))
+	}
+	return results, nil
+}
+
+func (a *APIv8) updatePool(pool params.StoragePool) error {
+	existing, err := a.poolManager.Get(pool.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	providerType := storage.ProviderType(pool.Provider)
+	if providerType != "" && providerType != existing.Provider() {
+		inUse, err := a.storage.StoragePoolInUse(pool.Name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if inUse {
+			return errors.Errorf(
+				"cannot change provider of storage pool %q: pool is in use",
+				pool.Name,
+			)
+		}
+	}
+	return a.poolManager.Replace(pool.Name, providerType, pool.Attrs)
+}
+
+// RemovePool removes each of the specified pools from the model. A
+// pool may not be removed while it is in use by the model's storage.
+func (a *APIv8) RemovePool(args params.StoragePoolDeleteArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Pools)),
+	}
+	for i, pool := range args.Pools {
+		results.Results[i].Error = common.ServerError(a.removePool(pool.Name))
+	}
+	return results, nil
+}
+
+func (a *APIv8) removePool(name string) error {
+	if _, err := a.poolManager.Get(name); err != nil {
+		return errors.Trace(err)
+	}
+	inUse, err := a.storage.StoragePoolInUse(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if inUse {
+		return errors.Errorf("cannot remove storage pool %q: pool is in use", name)
+	}
+	return a.poolManager.Delete(name)
+}
@@ -690,12 +690,113 @@ func (s *storageSuite) TestImportFilesystemVolumeBackedNotSupported(c *gc.C) {
 	s.stub.CheckCallNames(c, getBlockForTypeCall)
 }
 
-func (s *storageSuite) TestImportValidationErrors(c *gc.C) {
+func (s *storageSuite) TestImportVolume(c *gc.C) {
+	s.state.modelTag = coretesting.ModelTag
+	volumeSource := volumeImporter{&dummy.VolumeSource{}}
+	dummyStorageProvider := &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		SupportsFunc: func(kind storage.StorageKind) bool {
+			return kind == storage.StorageKindBlock
+		},
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	s.registry.Providers["radiance"] = dummyStorageProvider
+
 	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
 		Kind:        params.StorageKindBlock,
 		Pool:        "radiance",
 		ProviderId:  "foo",
 		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{{
+		Result: &params.ImportStorageDetails{
+			StorageTag: "storage-data-0",
+		},
+	}})
+	volumeSource.CheckCalls(c, []testing.StubCall{
+		{"ImportVolume", []interface{}{
+			"foo", map[string]string{
+				"juju-model-uuid":      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				"juju-controller-uuid": "deadbeef-1bad-500d-9000-4b1d0d06f00d",
+			},
+		}},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{addExistingVolumeCall, []interface{}{
+			state.VolumeInfo{
+				VolumeId:   "foo",
+				Pool:       "radiance",
+				Size:       123,
+				HardwareId: "hw",
+			},
+			"pgdata",
+		}},
+	})
+}
+
+func (s *storageSuite) TestImportVolumeError(c *gc.C) {
+	volumeSource := volumeImporter{&dummy.VolumeSource{}}
+	dummyStorageProvider := &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*storage.Config) (storage.VolumeSource, error) {
+			return volumeSource, nil
+		},
+	}
+	s.registry.Providers["radiance"] = dummyStorageProvider
+
+	volumeSource.SetErrors(errors.New("nope"))
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{
+		{Error: &params.Error{Message: `importing volume: nope`}},
+	})
+	volumeSource.CheckCallNames(c, "ImportVolume")
+	s.stub.CheckCallNames(c, getBlockForTypeCall)
+}
+
+func (s *storageSuite) TestImportVolumeProviderFilesystemOnly(c *gc.C) {
+	dummyStorageProvider := &dummy.StorageProvider{
+		StorageScope: storage.ScopeEnviron,
+		IsDynamic:    true,
+		SupportsFunc: func(kind storage.StorageKind) bool {
+			return kind == storage.StorageKindFilesystem
+		},
+	}
+	s.registry.Providers["radiance"] = dummyStorageProvider
+
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindBlock,
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{
+		{Error: &params.Error{
+			Message: `importing volume with storage provider "radiance" not supported`,
+			Code:    "not supported",
+		}},
+	})
+	s.stub.CheckCallNames(c, getBlockForTypeCall)
+}
+
+func (s *storageSuite) TestImportValidationErrors(c *gc.C) {
+	results, err := s.api.Import(params.BulkImportStorageParams{[]params.ImportStorageParams{{
+		Kind:        params.StorageKindUnknown,
+		Pool:        "radiance",
+		ProviderId:  "foo",
+		StorageName: "pgdata",
 	}, {
 		Kind:        params.StorageKindFilesystem,
 		Pool:        "123",
@@ -704,7 +805,7 @@ func (s *storageSuite) TestImportValidationErrors(c *gc.C) {
 	}}})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results, jc.DeepEquals, []params.ImportStorageResult{
-		{Error: &params.Error{Message: `storage kind "block" not supported`, Code: "not supported"}},
+		{Error: &params.Error{Message: `storage kind "unknown" not supported`, Code: "not supported"}},
 		{Error: &params.Error{Message: `pool name "123" not valid`}},
 	})
 }
@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+// Backend defines the state functionality required by the waitfor
+// facade. For details on the methods, see the methods on state.State
+// with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Application(name string) (Application, error)
+	Unit(name string) (Unit, error)
+	Machine(id string) (Machine, error)
+	WatchApplications() state.StringsWatcher
+	WatchModelMachines() state.StringsWatcher
+}
+
+// Application defines the application functionality required by the
+// waitfor facade.
+type Application interface {
+	Status() (status.StatusInfo, error)
+	ConfigSettings() (charm.Settings, error)
+	Watch() state.NotifyWatcher
+	WatchStatus() state.NotifyWatcher
+	WatchConfigSettings() state.NotifyWatcher
+
+	// UnitCount returns the number of units of the application.
+	UnitCount() (int, error)
+}
+
+// Unit defines the unit functionality required by the waitfor facade.
+type Unit interface {
+	Status() (status.StatusInfo, error)
+	AgentStatus() (status.StatusInfo, error)
+	Watch() state.NotifyWatcher
+	WatchStatus() state.NotifyWatcher
+}
+
+// Machine defines the machine functionality required by the waitfor
+// facade.
+type Machine interface {
+	Status() (status.StatusInfo, error)
+	InstanceStatus() (status.StatusInfo, error)
+	Watch() state.NotifyWatcher
+	WatchStatus() state.NotifyWatcher
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.ModelUUID())
+}
+
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationShim{app}, nil
+}
+
+func (s stateShim) Unit(name string) (Unit, error) {
+	return s.State.Unit(name)
+}
+
+func (s stateShim) Machine(id string) (Machine, error) {
+	return s.State.Machine(id)
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) UnitCount() (int, error) {
+	units, err := a.AllUnits()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return len(units), nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	worker "gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/facades/client/waitfor"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type mockBackend struct {
+	mu           sync.Mutex
+	applications map[string]*mockApplication
+	units        map[string]*mockUnit
+	machines     map[string]*mockMachine
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (m *mockBackend) Application(name string) (waitfor.Application, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	app, ok := m.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+func (m *mockBackend) Unit(name string) (waitfor.Unit, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	unit, ok := m.units[name]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q", name)
+	}
+	return unit, nil
+}
+
+func (m *mockBackend) Machine(id string) (waitfor.Machine, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	machine, ok := m.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %s", id)
+	}
+	return machine, nil
+}
+
+func (m *mockBackend) WatchApplications() state.StringsWatcher {
+	return newMockStringsWatcher()
+}
+
+func (m *mockBackend) WatchModelMachines() state.StringsWatcher {
+	return newMockStringsWatcher()
+}
+
+func (m *mockBackend) setUnitStatus(name string, value status.Status) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.units[name].status = value
+}
+
+type mockApplication struct {
+	status    status.Status
+	config    charm.Settings
+	unitCount int
+}
+
+func (m *mockApplication) Status() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: m.status}, nil
+}
+
+func (m *mockApplication) ConfigSettings() (charm.Settings, error) {
+	return m.config, nil
+}
+
+func (m *mockApplication) UnitCount() (int, error) {
+	return m.unitCount, nil
+}
+
+func (m *mockApplication) Watch() state.NotifyWatcher {
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (m *mockApplication) WatchStatus() state.NotifyWatcher {
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (m *mockApplication) WatchConfigSettings() state.NotifyWatcher {
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+type mockUnit struct {
+	status      status.Status
+	agentStatus status.Status
+
+	// statusWatchers, if set, receives the status watchers
+	// started for the unit.
+	statusWatchers chan *apiservertesting.FakeNotifyWatcher
+}
+
+func (m *mockUnit) Status() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: m.status}, nil
+}
+
+func (m *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: m.agentStatus}, nil
+}
+
+func (m *mockUnit) Watch() state.NotifyWatcher {
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (m *mockUnit) WatchStatus() state.NotifyWatcher {
+	w := apiservertesting.NewFakeNotifyWatcher()
+	if m.statusWatchers != nil {
+		m.statusWatchers <- w
+	}
+	return w
+}
+
+type mockMachine struct {
+	status         status.Status
+	instanceStatus status.Status
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: m.status}, nil
+}
+
+func (m *mockMachine) InstanceStatus() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: m.instanceStatus}, nil
+}
+
+func (m *mockMachine) Watch() state.NotifyWatcher {
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (m *mockMachine) WatchStatus() state.NotifyWatcher {
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+type mockStringsWatcher struct {
+	worker.Worker
+	changes chan []string
+}
+
+func newMockStringsWatcher() *mockStringsWatcher {
+	changes := make(chan []string, 1)
+	changes <- nil
+	return &mockStringsWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: changes,
+	}
+}
+
+func (w *mockStringsWatcher) Stop() error {
+	return worker.Stop(w)
+}
+
+func (w *mockStringsWatcher) Err() error {
+	return nil
+}
+
+func (w *mockStringsWatcher) Changes() <-chan []string {
+	return w.changes
+}
@@ -0,0 +1,284 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package waitfor provides the WaitFor facade, which blocks until a
+// condition on the entities in a model is satisfied.
+package waitfor
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/condition"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/status"
+)
+
+// MaxTimeout is the longest a single WaitFor call will wait for its
+// condition to be satisfied.
+const MaxTimeout = 5 * time.Minute
+
+// API provides the WaitFor API facade for version 1.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	clock      clock.Clock
+	abort      <-chan struct{}
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(NewStateBackend(ctx.State()), ctx.Auth(), clock.WallClock, ctx.Abort())
+}
+
+// NewAPI returns a new WaitFor API facade. Calls waiting for a
+// condition return early when the abort channel is closed.
+func NewAPI(backend Backend, authorizer facade.Authorizer, clock clock.Clock, abort <-chan struct{}) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		clock:      clock,
+		abort:      abort,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// WaitFor waits until the condition is satisfied, or until the
+// timeout expires. The timeout is limited to MaxTimeout; clients
+// wanting to wait for longer should call WaitFor again. A zero
+// timeout evaluates the condition once without waiting.
+func (api *API) WaitFor(args params.WaitForParams) (params.WaitForResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.WaitForResult{}, errors.Trace(err)
+	}
+	cond, err := condition.Parse(args.Condition)
+	if err != nil {
+		return params.WaitForResult{Error: common.ServerError(err)}, nil
+	}
+	if args.Timeout < 0 {
+		return params.WaitForResult{Error: common.ServerError(errors.NotValidf("negative timeout"))}, nil
+	}
+	timeout := args.Timeout
+	if timeout > MaxTimeout {
+		timeout = MaxTimeout
+	}
+
+	deadline := api.clock.After(timeout)
+	for {
+		result, done, err := api.waitForChange(cond, deadline)
+		if done || err != nil {
+			return result, err
+		}
+	}
+}
+
+// waitForChange evaluates the condition and, if it is not satisfied,
+// waits for one of the entities it refers to to change. It reports
+// done when the condition is satisfied, the deadline passes or the
+// condition cannot be evaluated.
+func (api *API) waitForChange(cond condition.Condition, deadline <-chan time.Time) (
+	result params.WaitForResult, done bool, err error,
+) {
+	// Start watching before evaluating the condition, so that no
+	// change made after the evaluation is missed.
+	w, err := api.watchCondition(cond)
+	if err != nil {
+		return params.WaitForResult{}, true, errors.Trace(err)
+	}
+	defer func() {
+		if stopErr := w.Stop(); stopErr != nil && err == nil {
+			result, done, err = params.WaitForResult{}, true, errors.Trace(stopErr)
+		}
+	}()
+
+	unsatisfied, err := api.evaluate(cond)
+	if err != nil {
+		return params.WaitForResult{Error: common.ServerError(err)}, true, nil
+	}
+	if len(unsatisfied) == 0 {
+		return params.WaitForResult{Satisfied: true}, true, nil
+	}
+	select {
+	case <-api.abort:
+		return params.WaitForResult{}, true, errors.New("wait aborted")
+	case <-deadline:
+		return params.WaitForResult{Unsatisfied: unsatisfied}, true, nil
+	case <-w.Changes():
+		return params.WaitForResult{}, false, nil
+	}
+}
+
+// watchCondition returns a watcher that notifies when any of the
+// entities referred to by the condition change. Entities that do not
+// exist yet are watched for through the collection that will hold
+// them once they are created.
+func (api *API) watchCondition(cond condition.Condition) (_ *conditionWatcher, err error) {
+	w := newConditionWatcher()
+	defer func() {
+		if err != nil {
+			w.Stop()
+		}
+	}()
+	for _, term := range cond {
+		if err := api.watchTerm(w, term); err != nil {
+			return nil, errors.Annotatef(err, "watching %q", term)
+		}
+	}
+	return w, nil
+}
+
+func (api *API) watchTerm(w *conditionWatcher, term condition.Term) error {
+	switch tag := term.Entity.(type) {
+	case names.ApplicationTag:
+		app, err := api.backend.Application(tag.Id())
+		if errors.IsNotFound(err) {
+			return w.addStringsWatcher(api.backend.WatchApplications())
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		// The application document records the unit count and
+		// the charm URL, which determines the config settings.
+		if err := w.addNotifyWatcher(app.Watch()); err != nil {
+			return errors.Trace(err)
+		}
+		switch term.Attribute {
+		case condition.Status:
+			return w.addNotifyWatcher(app.WatchStatus())
+		case condition.Config:
+			return w.addNotifyWatcher(app.WatchConfigSettings())
+		}
+	case names.UnitTag:
+		unit, err := api.backend.Unit(tag.Id())
+		if errors.IsNotFound(err) {
+			// Adding a unit updates the application's unit count.
+			appName, err := names.UnitApplication(tag.Id())
+			if err != nil {
+				return errors.Trace(err)
+			}
+			return api.watchTerm(w, condition.Term{
+				Entity:    names.NewApplicationTag(appName),
+				Attribute: condition.Units,
+			})
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if err := w.addNotifyWatcher(unit.Watch()); err != nil {
+			return errors.Trace(err)
+		}
+		return w.addNotifyWatcher(unit.WatchStatus())
+	case names.MachineTag:
+		machine, err := api.backend.Machine(tag.Id())
+		if errors.IsNotFound(err) {
+			return w.addStringsWatcher(api.backend.WatchModelMachines())
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if err := w.addNotifyWatcher(machine.Watch()); err != nil {
+			return errors.Trace(err)
+		}
+		return w.addNotifyWatcher(machine.WatchStatus())
+	}
+	return nil
+}
+
+// evaluate returns the terms of the condition that are not satisfied.
+func (api *API) evaluate(cond condition.Condition) ([]string, error) {
+	var unsatisfied []string
+	for _, term := range cond {
+		actual, err := api.attributeValue(term)
+		if errors.IsNotFound(err) {
+			// The entity may yet be created.
+			unsatisfied = append(unsatisfied, term.String())
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "evaluating %q", term)
+		}
+		ok, err := term.Compare(actual)
+		if err != nil {
+			return nil, errors.Annotatef(err, "evaluating %q", term)
+		}
+		if !ok {
+			unsatisfied = append(unsatisfied, term.String())
+		}
+	}
+	return unsatisfied, nil
+}
+
+// attributeValue returns the current value of the attribute compared
+// by the term.
+func (api *API) attributeValue(term condition.Term) (string, error) {
+	switch tag := term.Entity.(type) {
+	case names.ApplicationTag:
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		switch term.Attribute {
+		case condition.Status:
+			return statusValue(app.Status())
+		case condition.Units:
+			count, err := app.UnitCount()
+			return strconv.Itoa(count), errors.Trace(err)
+		case condition.Config:
+			settings, err := app.ConfigSettings()
+			if err != nil {
+				return "", errors.Trace(err)
+			}
+			if value, ok := settings[term.Key]; ok && value != nil {
+				return fmt.Sprint(value), nil
+			}
+			return "", nil
+		}
+	case names.UnitTag:
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		switch term.Attribute {
+		case condition.Status:
+			return statusValue(unit.Status())
+		case condition.AgentStatus:
+			return statusValue(unit.AgentStatus())
+		}
+	case names.MachineTag:
+		machine, err := api.backend.Machine(tag.Id())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		switch term.Attribute {
+		case condition.Status:
+			return statusValue(machine.Status())
+		case condition.InstanceStatus:
+			return statusValue(machine.InstanceStatus())
+		}
+	}
+	return "", errors.NotValidf("term %q", term)
+}
+
+func statusValue(info status.StatusInfo, err error) (string, error) {
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(info.Status), nil
+}
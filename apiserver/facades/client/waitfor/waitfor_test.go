@@ -0,0 +1,186 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/waitfor"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WaitForSuite struct {
+	testing.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	clock      *testing.Clock
+	abort      chan struct{}
+	api        *waitfor.API
+}
+
+var _ = gc.Suite(&WaitForSuite{})
+
+func (s *WaitForSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.backend = &mockBackend{
+		applications: map[string]*mockApplication{
+			"mysql": {
+				status:    status.Active,
+				config:    charm.Settings{"dataset-size": "80%", "max-connections": int64(500)},
+				unitCount: 2,
+			},
+		},
+		units: map[string]*mockUnit{
+			"mysql/0": {status: status.Active, agentStatus: status.Idle},
+			"mysql/1": {status: status.Maintenance, agentStatus: status.Executing},
+		},
+		machines: map[string]*mockMachine{
+			"0": {status: status.Started, instanceStatus: status.Running},
+		},
+	}
+	s.clock = testing.NewClock(time.Time{})
+	s.abort = make(chan struct{})
+	s.api = s.newAPI(c)
+}
+
+func (s *WaitForSuite) newAPI(c *gc.C) *waitfor.API {
+	api, err := waitfor.NewAPI(s.backend, s.authorizer, s.clock, s.abort)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *WaitForSuite) TestNewAPINonClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := waitfor.NewAPI(s.backend, s.authorizer, s.clock, s.abort)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *WaitForSuite) TestWaitForPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	_, err := s.newAPI(c).WaitFor(params.WaitForParams{Condition: "machine:0.status == started"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *WaitForSuite) TestWaitForSatisfied(c *gc.C) {
+	result, err := s.api.WaitFor(params.WaitForParams{
+		Condition: "application:mysql.status == active && application:mysql.units >= 2 && " +
+			"application:mysql.config.dataset-size == 80% && application:mysql.config.max-connections > 100 && " +
+			"unit:mysql/0.agent-status == idle && machine:0.instance-status == running",
+		Timeout: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.WaitForResult{Satisfied: true})
+}
+
+func (s *WaitForSuite) TestWaitForTimeout(c *gc.C) {
+	result, err := s.api.WaitFor(params.WaitForParams{
+		Condition: "application:mysql.units >= 3 && unit:mysql/1.status == active && application:wordpress.status == active",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.WaitForResult{
+		Unsatisfied: []string{
+			"application:mysql.units >= 3",
+			"unit:mysql/1.status == active",
+			"application:wordpress.status == active",
+		},
+	})
+}
+
+func (s *WaitForSuite) TestWaitForBecomesSatisfied(c *gc.C) {
+	watchers := make(chan *apiservertesting.FakeNotifyWatcher, 1)
+	s.backend.units["mysql/1"].statusWatchers = watchers
+	results := make(chan params.WaitForResult, 1)
+	go func() {
+		result, err := s.api.WaitFor(params.WaitForParams{
+			Condition: "unit:mysql/1.status == active",
+			Timeout:   time.Minute,
+		})
+		c.Check(err, jc.ErrorIsNil)
+		results <- result
+	}()
+
+	var w *apiservertesting.FakeNotifyWatcher
+	select {
+	case w = <-watchers:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for unit status watcher")
+	}
+	s.backend.setUnitStatus("mysql/1", status.Active)
+	w.C <- struct{}{}
+
+	select {
+	case result := <-results:
+		c.Assert(result, jc.DeepEquals, params.WaitForResult{Satisfied: true})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for result")
+	}
+}
+
+func (s *WaitForSuite) TestWaitForTimesOut(c *gc.C) {
+	results := make(chan params.WaitForResult, 1)
+	go func() {
+		result, err := s.api.WaitFor(params.WaitForParams{
+			Condition: "machine:1.status == started",
+			Timeout:   time.Minute,
+		})
+		c.Check(err, jc.ErrorIsNil)
+		results <- result
+	}()
+
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case result := <-results:
+		c.Assert(result, jc.DeepEquals, params.WaitForResult{
+			Unsatisfied: []string{"machine:1.status == started"},
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for result")
+	}
+}
+
+func (s *WaitForSuite) TestWaitForAborted(c *gc.C) {
+	close(s.abort)
+	_, err := s.api.WaitFor(params.WaitForParams{
+		Condition: "unit:mysql/1.status == active",
+		Timeout:   time.Minute,
+	})
+	c.Assert(err, gc.ErrorMatches, "wait aborted")
+}
+
+func (s *WaitForSuite) TestWaitForInvalidCondition(c *gc.C) {
+	result, err := s.api.WaitFor(params.WaitForParams{Condition: "model:foo.status == active"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `entity kind "model" not valid`)
+}
+
+func (s *WaitForSuite) TestWaitForNegativeTimeout(c *gc.C) {
+	result, err := s.api.WaitFor(params.WaitForParams{
+		Condition: "machine:0.status == started",
+		Timeout:   -time.Second,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "negative timeout not valid")
+}
+
+func (s *WaitForSuite) TestWaitForNonNumericComparison(c *gc.C) {
+	result, err := s.api.WaitFor(params.WaitForParams{Condition: "application:mysql.status > 1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches,
+		`evaluating "application:mysql.status > 1": comparing non-numeric value "active" with > not valid`)
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// conditionWatcher combines the watchers for the entities referred to
+// by a condition, notifying when any of them change. The initial event
+// of each watcher is consumed as it is added.
+type conditionWatcher struct {
+	watchers []state.Watcher
+	changes  chan struct{}
+	done     chan struct{}
+}
+
+func newConditionWatcher() *conditionWatcher {
+	return &conditionWatcher{
+		changes: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// Changes returns a channel that receives a value when any of the
+// watched entities change, or when one of the watchers stops.
+func (w *conditionWatcher) Changes() <-chan struct{} {
+	return w.changes
+}
+
+// Stop stops all of the watchers, returning the first error any of
+// them encountered.
+func (w *conditionWatcher) Stop() error {
+	close(w.done)
+	var result error
+	for _, sw := range w.watchers {
+		if err := sw.Stop(); err != nil && result == nil {
+			result = err
+		}
+	}
+	return result
+}
+
+func (w *conditionWatcher) addNotifyWatcher(nw state.NotifyWatcher) error {
+	w.watchers = append(w.watchers, nw)
+	if _, ok := <-nw.Changes(); !ok {
+		return errors.Trace(watcher.EnsureErr(nw))
+	}
+	go func() {
+		for {
+			select {
+			case <-w.done:
+				return
+			case _, ok := <-nw.Changes():
+				w.notify()
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (w *conditionWatcher) addStringsWatcher(sw state.StringsWatcher) error {
+	w.watchers = append(w.watchers, sw)
+	if _, ok := <-sw.Changes(); !ok {
+		return errors.Trace(watcher.EnsureErr(sw))
+	}
+	go func() {
+		for {
+			select {
+			case <-w.done:
+				return
+			case _, ok := <-sw.Changes():
+				w.notify()
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// notify records a change without blocking; pending changes are
+// coalesced into a single event.
+func (w *conditionWatcher) notify() {
+	select {
+	case w.changes <- struct{}{}:
+	default:
+	}
+}
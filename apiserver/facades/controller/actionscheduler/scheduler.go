@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// API provides access to version 2 of the ActionScheduler API facade.
+type API struct {
+	model *state.Model
+}
+
+// APIV1 provides access to version 1 of the ActionScheduler API
+// facade, which lacks AdvanceRollouts.
+type APIV1 struct {
+	*API
+}
+
+// NewAPIV1 returns a new version 1 ActionScheduler API facade.
+func NewAPIV1(st *state.State, resources facade.Resources, auth facade.Authorizer) (*APIV1, error) {
+	api, err := NewAPI(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
+// NewAPI returns a new ActionScheduler API facade.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{model: model}, nil
+}
+
+// RunDue enqueues the actions of all action schedules that are due.
+func (api *API) RunDue() error {
+	return api.model.RunDueActionSchedules()
+}
+
+// AdvanceRollouts records the outcome of the finished actions of all
+// running action rollouts, and enqueues the next batch of those that
+// are ready for it.
+func (api *API) AdvanceRollouts() error {
+	return api.model.AdvanceActionRollouts()
+}
+
+// AdvanceRollouts isn't on the v1 API.
+func (api *APIV1) AdvanceRollouts(_, _ struct{}) {}
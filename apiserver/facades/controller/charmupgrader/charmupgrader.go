@@ -0,0 +1,36 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// API provides access to the CharmUpgrader API facade.
+type API struct {
+	model *state.Model
+}
+
+// NewAPI returns a new CharmUpgrader API facade.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{model: model}, nil
+}
+
+// AdvanceCharmUpgrades checks the readiness of the units being
+// upgraded by the model's rolling charm upgrades, and starts the next
+// batch of units of those that are ready for it.
+func (api *API) AdvanceCharmUpgrades() error {
+	return api.model.AdvanceCharmUpgrades()
+}
@@ -91,7 +91,9 @@ func NewCrossModelRelationsAPI(
 	}, nil
 }
 
-func (api *CrossModelRelationsAPI) checkMacaroonsForRelation(relationTag names.Tag, mac macaroon.Slice) error {
+// offerUUIDForRelation returns the UUID of the offer the specified
+// relation was made to.
+func (api *CrossModelRelationsAPI) offerUUIDForRelation(relationTag names.Tag) (string, error) {
 	api.mu.Lock()
 	defer api.mu.Unlock()
 
@@ -99,12 +101,37 @@ func (api *CrossModelRelationsAPI) checkMacaroonsForRelation(relationTag names.T
 	if !ok {
 		oc, err := api.st.OfferConnectionForRelation(relationTag.Id())
 		if err != nil {
-			return errors.Trace(err)
+			return "", errors.Trace(err)
 		}
 		offerUUID = oc.OfferUUID()
 	}
+	return offerUUID, nil
+}
+
+func (api *CrossModelRelationsAPI) checkMacaroonsForRelation(relationTag names.Tag, mac macaroon.Slice) error {
+	offerUUID, err := api.offerUUIDForRelation(relationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	auth := api.authCtxt.Authenticator(api.st.ModelUUID(), offerUUID)
-	return auth.CheckRelationMacaroons(relationTag, mac)
+	if err := auth.CheckRelationMacaroons(relationTag, mac); err != nil {
+		return err
+	}
+	api.recordActivity(relationTag, OfferConnection.RecordIngress)
+	return nil
+}
+
+// recordActivity records activity by the consuming model on the offer
+// connection for the relation, so that stale connections can be
+// detected. Failure to do so is logged rather than failing the call.
+func (api *CrossModelRelationsAPI) recordActivity(relationTag names.Tag, record func(OfferConnection) error) {
+	oc, err := api.st.OfferConnectionForRelation(relationTag.Id())
+	if err == nil {
+		err = record(oc)
+	}
+	if err != nil {
+		logger.Warningf("cannot record activity for relation %v: %v", relationTag.Id(), err)
+	}
 }
 
 // PublishRelationChanges publishes relation changes to the
@@ -286,6 +313,7 @@ func (api *CrossModelRelationsAPI) registerRemoteRelation(relation params.Regist
 	if err != nil {
 		return nil, errors.Annotate(err, "creating relation macaroon")
 	}
+	api.recordActivity(localRel.Tag(), OfferConnection.RecordMacaroonRefresh)
 	return &params.RemoteRelationDetails{
 		Token:    token,
 		Macaroon: relationMacaroon,
@@ -492,7 +520,12 @@ func (api *CrossModelRelationsAPI) PublishIngressNetworkChanges(
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		if err := commoncrossmodel.PublishIngressNetworkChange(api.st, relationTag, change); err != nil {
+		offerUUID, err := api.offerUUIDForRelation(relationTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := commoncrossmodel.PublishIngressNetworkChange(api.st, relationTag, offerUUID, change); err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
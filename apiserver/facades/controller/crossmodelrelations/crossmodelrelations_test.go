@@ -173,7 +173,7 @@ func (s *crossmodelRelationsSuite) TestPublishRelationsChangesDyingWhileSuspende
 	s.assertPublishRelationsChanges(c, params.Dying, "")
 }
 
-func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
+func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C, macaroonRefreshes int) {
 	app := &mockApplication{}
 	app.eps = []state.Endpoint{{
 		ApplicationName: "offeredapp",
@@ -247,16 +247,18 @@ func (s *crossmodelRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
 		relationKey:     "offeredapp:local remote-apptoken:remote",
 		username:        "mary",
 		offerUUID:       "offer-uuid",
+
+		macaroonRefreshCount: macaroonRefreshes,
 	})
 }
 
 func (s *crossmodelRelationsSuite) TestRegisterRemoteRelations(c *gc.C) {
-	s.assertRegisterRemoteRelations(c)
+	s.assertRegisterRemoteRelations(c, 1)
 }
 
 func (s *crossmodelRelationsSuite) TestRegisterRemoteRelationsIdempotent(c *gc.C) {
-	s.assertRegisterRemoteRelations(c)
-	s.assertRegisterRemoteRelations(c)
+	s.assertRegisterRemoteRelations(c, 1)
+	s.assertRegisterRemoteRelations(c, 2)
 }
 
 func (s *crossmodelRelationsSuite) TestRelationUnitSettings(c *gc.C) {
@@ -328,6 +330,7 @@ func (s *crossmodelRelationsSuite) TestPublishIngressNetworkChanges(c *gc.C) {
 	err = results.Combine()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s.st.ingressNetworks[rel.key], jc.DeepEquals, []string{"1.2.3.4/32"})
+	c.Assert(s.st.offerConnectionsByKey[rel.key].ingressCount, gc.Equals, 1)
 	s.st.CheckCalls(c, []testing.StubCall{
 		{"GetRemoteEntity", []interface{}{"token-db2:db django:db"}},
 		{"KeyRelation", []interface{}{"db2:db django:db"}},
@@ -372,6 +375,45 @@ func (s *crossmodelRelationsSuite) TestPublishIngressNetworkChangesRejected(c *g
 	})
 }
 
+func (s *crossmodelRelationsSuite) TestPublishIngressNetworkChangesRejectedByOffer(c *gc.C) {
+	s.st.remoteApplications["db2"] = &mockRemoteApplication{}
+	s.st.relations["db2:db django:db"] = newMockRelation(1)
+	s.st.remoteEntities[names.NewApplicationTag("db2")] = "token-db2"
+	s.st.remoteEntities[names.NewRelationTag("db2:db django:db")] = "token-db2:db django:db"
+	s.st.offerConnectionsByKey["db2:db django:db"] = &mockOfferConnection{
+		offerUUID:       "hosted-db2-uuid",
+		sourcemodelUUID: "source-model-uuid",
+		relationKey:     "db2:db django:db",
+		relationId:      1,
+	}
+	s.st.offers["hosted-db2-uuid"] = &crossmodel.ApplicationOffer{
+		OfferUUID:    "hosted-db2-uuid",
+		OfferName:    "hosted-db2",
+		IngressCIDRs: []string{"10.0.0.0/8"},
+	}
+	mac, err := s.bakery.NewMacaroon("", nil,
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+			checkers.DeclaredCaveat("relation-key", "db2:db django:db"),
+			checkers.DeclaredCaveat("username", "mary"),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.api.PublishIngressNetworkChanges(params.IngressNetworksChanges{
+		Changes: []params.IngressNetworksChangeEvent{
+			{
+				ApplicationToken: "token-db2",
+				RelationToken:    "token-db2:db django:db",
+				Networks:         []string{"10.1.2.0/24", "1.2.3.4/32"},
+				Macaroons:        macaroon.Slice{mac},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = results.Combine()
+	c.Assert(err, gc.ErrorMatches, regexp.QuoteMeta("subnet 1.2.3.4/32 not in offer ingress networks"))
+	c.Assert(s.st.ingressNetworks, gc.HasLen, 0)
+}
+
 func (s *crossmodelRelationsSuite) TestWatchEgressAddressesForRelations(c *gc.C) {
 	s.st.remoteEntities[names.NewRelationTag("db2:db django:db")] = "token-db2:db django:db"
 	s.st.offerConnectionsByKey["db2:db django:db"] = &mockOfferConnection{
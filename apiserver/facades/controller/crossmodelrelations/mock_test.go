@@ -438,12 +438,25 @@ type mockOfferConnection struct {
 	relationKey     string
 	username        string
 	offerUUID       string
+
+	ingressCount         int
+	macaroonRefreshCount int
 }
 
 func (m *mockOfferConnection) OfferUUID() string {
 	return m.offerUUID
 }
 
+func (m *mockOfferConnection) RecordIngress() error {
+	m.ingressCount++
+	return nil
+}
+
+func (m *mockOfferConnection) RecordMacaroonRefresh() error {
+	m.macaroonRefreshCount++
+	return nil
+}
+
 type mockRelationUnit struct {
 	commoncrossmodel.RelationUnit
 	testing.Stub
@@ -58,4 +58,6 @@ func (st stateShim) Model() (Model, error) {
 
 type OfferConnection interface {
 	OfferUUID() string
+	RecordIngress() error
+	RecordMacaroonRefresh() error
 }
@@ -46,6 +46,11 @@ type FirewallerAPIV4 struct {
 	*common.ControllerConfigAPI
 }
 
+// FirewallerAPIV5 provides access to the Firewaller v5 API facade.
+type FirewallerAPIV5 struct {
+	*FirewallerAPIV4
+}
+
 // NewStateFirewallerAPIv3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -74,6 +79,17 @@ func NewStateFirewallerAPIV4(context facade.Context) (*FirewallerAPIV4, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIv5 creates a new server-side FirewallerAPIV5 facade.
+func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
+	facadev4, err := NewStateFirewallerAPIV4(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV5{
+		FirewallerAPIV4: facadev4,
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -491,3 +507,45 @@ func (f *FirewallerAPIV4) FirewallRules(args params.KnownServiceArgs) (params.Li
 	}
 	return result, nil
 }
+
+// WatchEgressRules returns a StringsWatcher that notifies of changes
+// to the egress rules of the model's applications. The changes are
+// the names of the applications whose rules have changed.
+func (f *FirewallerAPIV5) WatchEgressRules() (params.StringsWatchResult, error) {
+	watch := f.st.WatchEgressRules()
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: f.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// EgressRules returns the CIDRs the outbound traffic of each given
+// application is restricted to. Applications without an egress rule
+// are unrestricted, and have a not found error in their result.
+func (f *FirewallerAPIV5) EgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	result := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.EgressRulesResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		rule, err := f.st.EgressRule(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].AllowedCIDRs = rule.AllowedCIDRs
+	}
+	return result, nil
+}
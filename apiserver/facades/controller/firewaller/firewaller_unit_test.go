@@ -134,3 +134,37 @@ func (s *RemoteFirewallerSuite) TestFirewallRules(c *gc.C) {
 	c.Assert(result.Rules[0].KnownService, gc.Equals, params.KnownServiceValue("juju-application-offer"))
 	c.Assert(result.Rules[0].WhitelistCIDRS, jc.SameContents, []string{"192.168.0.0/16"})
 }
+
+func (s *RemoteFirewallerSuite) TestWatchEgressRules(c *gc.C) {
+	s.st.egressWatcher.changes <- []string{"mysql"}
+	api := &firewaller.FirewallerAPIV5{FirewallerAPIV4: s.api}
+	result, err := api.WatchEgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Changes, jc.DeepEquals, []string{"mysql"})
+	c.Assert(result.StringsWatcherId, gc.Equals, "1")
+
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.egressWatcher)
+	s.st.CheckCallNames(c, "WatchEgressRules")
+}
+
+func (s *RemoteFirewallerSuite) TestEgressRules(c *gc.C) {
+	s.st.egressRules["mysql"] = &state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	}
+	api := &firewaller.FirewallerAPIV5{FirewallerAPIV4: s.api}
+	result, err := api.EgressRules(params.Entities{Entities: []params.Entity{
+		{Tag: "application-mysql"},
+		{Tag: "application-wordpress"},
+		{Tag: "unit-mysql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0], jc.DeepEquals, params.EgressRulesResult{
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	})
+	c.Assert(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(result.Results[2].Error, jc.Satisfies, params.IsCodeUnauthorized)
+}
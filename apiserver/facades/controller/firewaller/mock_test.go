@@ -40,7 +40,9 @@ type mockState struct {
 	relations      map[string]*mockRelation
 	controllerInfo map[string]*mockControllerInfo
 	firewallRules  map[state.WellKnownServiceType]*state.FirewallRule
+	egressRules    map[string]*state.EgressRule
 	subnetsWatcher *mockStringsWatcher
+	egressWatcher  *mockStringsWatcher
 	modelWatcher   *mockNotifyWatcher
 	configAttrs    map[string]interface{}
 }
@@ -53,7 +55,9 @@ func newMockState(modelUUID string) *mockState {
 		macaroons:      make(map[names.Tag]*macaroon.Macaroon),
 		controllerInfo: make(map[string]*mockControllerInfo),
 		firewallRules:  make(map[state.WellKnownServiceType]*state.FirewallRule),
+		egressRules:    make(map[string]*state.EgressRule),
 		subnetsWatcher: newMockStringsWatcher(),
+		egressWatcher:  newMockStringsWatcher(),
 		modelWatcher:   newMockNotifyWatcher(),
 		configAttrs:    coretesting.FakeConfig(),
 	}
@@ -120,6 +124,20 @@ func (st *mockState) FirewallRule(service state.WellKnownServiceType) (*state.Fi
 	return r, nil
 }
 
+func (st *mockState) WatchEgressRules() state.StringsWatcher {
+	st.MethodCall(st, "WatchEgressRules")
+	return st.egressWatcher
+}
+
+func (st *mockState) EgressRule(application string) (*state.EgressRule, error) {
+	st.MethodCall(st, "EgressRule", application)
+	r, ok := st.egressRules[application]
+	if !ok {
+		return nil, errors.NotFoundf("egress rule for application %v", application)
+	}
+	return r, nil
+}
+
 type mockWatcher struct {
 	testing.Stub
 	tomb.Tomb
@@ -25,6 +25,10 @@ type State interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 
 	FirewallRule(service state.WellKnownServiceType) (*state.FirewallRule, error)
+
+	WatchEgressRules() state.StringsWatcher
+
+	EgressRule(application string) (*state.EgressRule, error)
 }
 
 // TODO(wallyworld) - for tests, remove when remaining firewaller tests become unit tests.
@@ -58,3 +62,12 @@ func (s stateShim) FirewallRule(service state.WellKnownServiceType) (*state.Fire
 	api := state.NewFirewallRules(s.st)
 	return api.Rule(service)
 }
+
+func (st stateShim) WatchEgressRules() state.StringsWatcher {
+	return st.st.WatchEgressRules()
+}
+
+func (s stateShim) EgressRule(application string) (*state.EgressRule, error) {
+	api := state.NewFirewallRules(s.st)
+	return api.EgressRule(application)
+}
@@ -134,6 +134,23 @@ func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
 	}, nil
 }
 
+// ModelRequirements returns what the model to be migrated needs of
+// the target controller, so that the target's compatibility can be
+// checked before the migration proceeds.
+func (api *API) ModelRequirements() (params.MigrationModelRequirements, error) {
+	model, err := api.backend.Export()
+	if err != nil {
+		return params.MigrationModelRequirements{}, errors.Annotate(err, "exporting model")
+	}
+	reqs := migration.ModelRequirements(model)
+	return params.MigrationModelRequirements{
+		Cloud:            reqs.Cloud,
+		Features:         reqs.Features,
+		StorageProviders: reqs.StorageProviders,
+		BinariesSize:     reqs.BinariesSize,
+	}, nil
+}
+
 // SetPhase sets the phase of the active model migration. The provided
 // phase must be a valid phase value, for example QUIESCE" or
 // "ABORT". See the core/migration package for the complete list.
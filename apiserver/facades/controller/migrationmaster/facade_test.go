@@ -186,6 +186,56 @@ func (s *Suite) TestPrechecks(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "retrieving model: boom")
 }
 
+func (s *Suite) TestModelRequirements(c *gc.C) {
+	app := s.model.AddApplication(description.ApplicationArgs{
+		Tag:                names.NewApplicationTag("foo"),
+		CharmURL:           "cs:foo-0",
+		MetricsCredentials: []byte("creds"),
+	})
+	m := s.model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("9")})
+	m.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.1-xenial-amd64"),
+		Size:    1000,
+	})
+	unit := app.AddUnit(description.UnitArgs{
+		Tag: names.NewUnitTag("foo/0"),
+	})
+	unit.SetTools(description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.0.1-xenial-amd64"),
+		Size:    1000,
+	})
+	res := app.AddResource(description.ResourceArgs{"bin"})
+	res.SetApplicationRevision(description.ResourceRevisionArgs{
+		Revision: 2,
+		Type:     "file",
+		Path:     "bin.tar.gz",
+		Origin:   "upload",
+		Size:     123,
+	})
+	s.model.AddStoragePool(description.StoragePoolArgs{
+		Name:     "fast",
+		Provider: "ebs",
+	})
+	s.model.AddVolume(description.VolumeArgs{
+		Tag:  names.NewVolumeTag("0"),
+		Pool: "fast",
+	})
+	s.model.AddFilesystem(description.FilesystemArgs{
+		Tag:  names.NewFilesystemTag("0"),
+		Pool: "rootfs",
+	})
+
+	api := s.mustMakeAPI(c)
+	reqs, err := api.ModelRequirements()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reqs, jc.DeepEquals, params.MigrationModelRequirements{
+		Cloud:            s.model.Cloud(),
+		Features:         []string{"metrics", "resources", "storage"},
+		StorageProviders: []string{"ebs", "rootfs"},
+		BinariesSize:     1123,
+	})
+}
+
 func (s *Suite) TestExport(c *gc.C) {
 	app := s.model.AddApplication(description.ApplicationArgs{
 		Tag:      names.NewApplicationTag("foo"),
@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migrationtarget
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/du"
+	"github.com/juju/utils/series"
+
+	"github.com/juju/juju/juju/paths"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/storage/provider"
+)
+
+// freeSpace returns the free disk space in the controller's data
+// directory, where the binaries of a migrated model are stored.
+var freeSpace = func() (uint64, error) {
+	dataDir, err := paths.DataDir(series.MustHostSeries())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return du.NewDiskUsage(dataDir).Free(), nil
+}
+
+// compatibilityBackend implements migration.CompatibilityBackend
+// for the target controller.
+type compatibilityBackend struct {
+	migration.PrecheckBackend
+	st         *state.State
+	getEnviron stateenvirons.NewEnvironFunc
+}
+
+func newCompatibilityBackend(st *state.State, getEnviron stateenvirons.NewEnvironFunc) (*compatibilityBackend, error) {
+	backend, err := migration.PrecheckShim(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &compatibilityBackend{
+		PrecheckBackend: backend,
+		st:              st,
+		getEnviron:      getEnviron,
+	}, nil
+}
+
+// CloudType is part of migration.CompatibilityBackend.
+func (b *compatibilityBackend) CloudType(name string) (string, error) {
+	cloud, err := b.st.Cloud(name)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cloud.Type, nil
+}
+
+// StorageProviderTypes is part of migration.CompatibilityBackend.
+// Only the controller model's environ can be consulted, so if the
+// model is on a cloud of a different type, only the common storage
+// provider types are known to be supported.
+func (b *compatibilityBackend) StorageProviderTypes(cloudType string) ([]string, bool, error) {
+	types, err := provider.CommonStorageProviders().StorageProviderTypes()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	controllerModel, err := b.st.Model()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	controllerCloudType, err := b.CloudType(controllerModel.Cloud())
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	complete := controllerCloudType == cloudType
+	if complete {
+		env, err := b.getEnviron(b.st)
+		if err != nil {
+			return nil, false, errors.Trace(err)
+		}
+		envTypes, err := env.StorageProviderTypes()
+		if err != nil {
+			return nil, false, errors.Trace(err)
+		}
+		types = append(types, envTypes...)
+	}
+	result := make([]string, len(types))
+	for i, t := range types {
+		result[i] = string(t)
+	}
+	return result, complete, nil
+}
+
+// FreeSpace is part of migration.CompatibilityBackend.
+func (b *compatibilityBackend) FreeSpace() (uint64, error) {
+	return freeSpace()
+}
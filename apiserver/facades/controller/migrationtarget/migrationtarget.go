@@ -101,6 +101,66 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	)
 }
 
+// APIV2 implements version 2 of the MigrationTarget facade, which
+// adds CheckCompatibility.
+type APIV2 struct {
+	*API
+}
+
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*APIV2, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{api}, nil
+}
+
+// CheckCompatibility checks whether the target controller can host
+// the described model, returning a go/no-go report of the outcome of
+// each check.
+func (api *APIV2) CheckCompatibility(args params.MigrationCompatibilityArgs) (params.MigrationCompatibilityReport, error) {
+	var empty params.MigrationCompatibilityReport
+	ownerTag, err := names.ParseUserTag(args.Model.OwnerTag)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	backend, err := newCompatibilityBackend(api.state, api.getEnviron)
+	if err != nil {
+		return empty, errors.Annotate(err, "creating backend")
+	}
+	report, err := migration.TargetCompatibility(
+		backend,
+		coremigration.ModelInfo{
+			UUID:                   args.Model.UUID,
+			Name:                   args.Model.Name,
+			Owner:                  ownerTag,
+			AgentVersion:           args.Model.AgentVersion,
+			ControllerAgentVersion: args.Model.ControllerAgentVersion,
+		},
+		coremigration.ModelRequirements{
+			Cloud:            args.Requirements.Cloud,
+			Features:         args.Requirements.Features,
+			StorageProviders: args.Requirements.StorageProviders,
+			BinariesSize:     args.Requirements.BinariesSize,
+		},
+	)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	result := params.MigrationCompatibilityReport{
+		Checks: make([]params.MigrationCompatibilityCheck, len(report.Checks)),
+	}
+	for i, check := range report.Checks {
+		result.Checks[i] = params.MigrationCompatibilityCheck{
+			Name:    check.Name,
+			Status:  string(check.Status),
+			Message: check.Message,
+		}
+	}
+	return result, nil
+}
+
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
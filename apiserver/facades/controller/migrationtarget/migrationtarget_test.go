@@ -23,10 +23,12 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/migration"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/storage"
 	jujutesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
@@ -70,6 +72,19 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
+func (s *Suite) TestFacadeRegisteredV2(c *gc.C) {
+	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV2))
+}
+
 func (s *Suite) TestNotUser(c *gc.C) {
 	s.authorizer.Tag = names.NewMachineTag("0")
 	_, err := s.newAPI(nil)
@@ -102,6 +117,71 @@ func (s *Suite) TestPrechecks(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *Suite) compatibilityArgs(c *gc.C) params.MigrationCompatibilityArgs {
+	return params.MigrationCompatibilityArgs{
+		Model: params.MigrationModelInfo{
+			UUID:                   "uuid",
+			Name:                   "some-model",
+			OwnerTag:               names.NewUserTag("someone").String(),
+			AgentVersion:           s.controllerVersion(c),
+			ControllerAgentVersion: s.controllerVersion(c),
+		},
+		Requirements: params.MigrationModelRequirements{
+			Cloud:            "dummy",
+			StorageProviders: []string{"loop", "dummy"},
+			BinariesSize:     1000,
+		},
+	}
+}
+
+func (s *Suite) TestCheckCompatibility(c *gc.C) {
+	s.PatchValue(migrationtarget.FreeSpace, func() (uint64, error) {
+		return migration.MinFreeSpace + 1000, nil
+	})
+	env := &mockEnviron{Stub: &testing.Stub{}, storageProviders: []storage.ProviderType{"dummy"}}
+	api := &migrationtarget.APIV2{s.mustNewAPIWithEnviron(c, env)}
+	report, err := api.CheckCompatibility(s.compatibilityArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report, jc.DeepEquals, params.MigrationCompatibilityReport{
+		Checks: []params.MigrationCompatibilityCheck{
+			{Name: "version", Status: "ok"},
+			{Name: "cloud", Status: "ok"},
+			{Name: "storage", Status: "ok"},
+			{Name: "capacity", Status: "ok"},
+		},
+	})
+	env.CheckCallNames(c, "StorageProviderTypes")
+}
+
+func (s *Suite) TestCheckCompatibilityFails(c *gc.C) {
+	s.PatchValue(migrationtarget.FreeSpace, func() (uint64, error) {
+		return 1000, nil
+	})
+	env := &mockEnviron{Stub: &testing.Stub{}}
+	api := &migrationtarget.APIV2{s.mustNewAPIWithEnviron(c, env)}
+	args := s.compatibilityArgs(c)
+	args.Requirements.Cloud = "elsewhere"
+	report, err := api.CheckCompatibility(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Checks, gc.HasLen, 3)
+	c.Assert(report.Checks[1], jc.DeepEquals, params.MigrationCompatibilityCheck{
+		Name:    "cloud",
+		Status:  "failed",
+		Message: `cloud "elsewhere" not known to target controller`,
+	})
+	c.Assert(report.Checks[2].Name, gc.Equals, "capacity")
+	c.Assert(report.Checks[2].Status, gc.Equals, "failed")
+	env.CheckNoCalls(c)
+}
+
+func (s *Suite) TestCheckCompatibilityBadOwner(c *gc.C) {
+	api := &migrationtarget.APIV2{s.mustNewAPI(c)}
+	args := s.compatibilityArgs(c)
+	args.Model.OwnerTag = "machine-0"
+	_, err := api.CheckCompatibility(args)
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+}
+
 func (s *Suite) TestCACert(c *gc.C) {
 	api := s.mustNewAPI(c)
 	r := api.CACert()
@@ -464,7 +544,13 @@ type mockEnviron struct {
 	environs.Environ
 	*testing.Stub
 
-	instances []*mockInstance
+	instances        []*mockInstance
+	storageProviders []storage.ProviderType
+}
+
+func (e *mockEnviron) StorageProviderTypes() ([]storage.ProviderType, error) {
+	e.MethodCall(e, "StorageProviderTypes")
+	return e.storageProviders, e.NextErr()
 }
 
 func (e *mockEnviron) AdoptResources(controllerUUID string, sourceVersion version.Number) error {
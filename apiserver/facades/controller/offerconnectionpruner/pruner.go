@@ -0,0 +1,31 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offerconnectionpruner
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// API provides access to the OfferConnectionPruner API facade.
+type API struct {
+	st *state.State
+}
+
+// NewAPI returns a new OfferConnectionPruner API facade.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{st: st}, nil
+}
+
+// Prune removes the relations of offer connections whose consuming
+// model has not been heard from for longer than the specified period,
+// and which is not hosted by this controller.
+func (api *API) Prune(p params.OfferConnectionPruneArgs) error {
+	return state.PruneOfferConnections(api.st, p.MaxStaleness)
+}
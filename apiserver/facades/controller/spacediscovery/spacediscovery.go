@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spacediscovery
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// Backend defines the methods the space discovery facade needs
+// from state.State.
+type Backend interface {
+	// ReloadSpacesWithDrift loads spaces and subnets from the given
+	// environ into state, and reports how they had drifted.
+	ReloadSpacesWithDrift(environs.Environ) (state.SpacesDrift, error)
+}
+
+// API implements the API facade used by the space discovery worker.
+type API struct {
+	backend    Backend
+	getEnviron func() (environs.Environ, error)
+}
+
+// NewAPI returns a new space discovery API facade, which reloads
+// spaces into the given backend from the environ returned by
+// getEnviron.
+func NewAPI(
+	backend Backend,
+	getEnviron func() (environs.Environ, error),
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		getEnviron: getEnviron,
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	configGetter := stateenvirons.EnvironConfigGetter{State: st, Model: m}
+	getEnviron := func() (environs.Environ, error) {
+		return environs.GetEnviron(configGetter, environs.New)
+	}
+	return NewAPI(st, getEnviron, authorizer)
+}
+
+// ReloadSpaces reloads the model's spaces and subnets from its
+// provider, and reports those that were added to the model and
+// those the provider no longer reports. A NotSupported error is
+// returned if the provider does not support networking.
+func (api *API) ReloadSpaces() (params.ReloadSpacesResult, error) {
+	drift, err := api.reloadSpaces()
+	if err != nil {
+		return params.ReloadSpacesResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ReloadSpacesResult{
+		AddedSpaces:    drift.AddedSpaces,
+		AddedSubnets:   drift.AddedSubnets,
+		MissingSpaces:  drift.MissingSpaces,
+		MissingSubnets: drift.MissingSubnets,
+	}, nil
+}
+
+func (api *API) reloadSpaces() (state.SpacesDrift, error) {
+	env, err := api.getEnviron()
+	if err != nil {
+		return state.SpacesDrift{}, errors.Trace(err)
+	}
+	drift, err := api.backend.ReloadSpacesWithDrift(env)
+	return drift, errors.Trace(err)
+}
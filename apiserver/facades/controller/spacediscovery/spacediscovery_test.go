@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spacediscovery_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/controller/spacediscovery"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+)
+
+type spaceDiscoverySuite struct {
+	testing.IsolationSuite
+
+	backend *mockBackend
+	environ environs.Environ
+	api     *spacediscovery.API
+}
+
+var _ = gc.Suite(&spaceDiscoverySuite{})
+
+func (s *spaceDiscoverySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{Stub: &testing.Stub{}}
+	s.environ = &mockEnviron{}
+	api, err := spacediscovery.NewAPI(
+		s.backend,
+		func() (environs.Environ, error) { return s.environ, nil },
+		apiservertesting.FakeAuthorizer{Controller: true},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *spaceDiscoverySuite) TestRequiresController(c *gc.C) {
+	_, err := spacediscovery.NewAPI(
+		s.backend,
+		func() (environs.Environ, error) { return s.environ, nil },
+		apiservertesting.FakeAuthorizer{Controller: false},
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *spaceDiscoverySuite) TestReloadSpaces(c *gc.C) {
+	s.backend.drift = state.SpacesDrift{
+		AddedSpaces:    []string{"space2"},
+		AddedSubnets:   []string{"10.1.0.0/24"},
+		MissingSpaces:  []string{"space1"},
+		MissingSubnets: []string{"10.0.0.0/24"},
+	}
+	result, err := s.api.ReloadSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ReloadSpacesResult{
+		AddedSpaces:    []string{"space2"},
+		AddedSubnets:   []string{"10.1.0.0/24"},
+		MissingSpaces:  []string{"space1"},
+		MissingSubnets: []string{"10.0.0.0/24"},
+	})
+	s.backend.CheckCall(c, 0, "ReloadSpacesWithDrift", s.environ)
+}
+
+func (s *spaceDiscoverySuite) TestReloadSpacesNotSupported(c *gc.C) {
+	s.backend.SetErrors(errors.NotSupportedf("spaces discovery in a non-networking environ"))
+	result, err := s.api.ReloadSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *spaceDiscoverySuite) TestReloadSpacesEnvironError(c *gc.C) {
+	api, err := spacediscovery.NewAPI(
+		s.backend,
+		func() (environs.Environ, error) { return nil, errors.New("boom") },
+		apiservertesting.FakeAuthorizer{Controller: true},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ReloadSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+	s.backend.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	*testing.Stub
+	drift state.SpacesDrift
+}
+
+func (b *mockBackend) ReloadSpacesWithDrift(env environs.Environ) (state.SpacesDrift, error) {
+	b.AddCall("ReloadSpacesWithDrift", env)
+	return b.drift, b.NextErr()
+}
+
+type mockEnviron struct {
+	environs.Environ
+}
@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state"
+)
+
+// idempotentCalls records the outcomes of API calls made with an
+// idempotency key. It is implemented by *state.State.
+type idempotentCalls interface {
+	ClaimIdempotentCall(key string) (bool, state.IdempotentCallOutcome, error)
+	CompleteIdempotentCall(key string, outcome state.IdempotentCallOutcome) error
+}
+
+// deduplicateRoot wraps the provided root so that calls made by the
+// given entity with an idempotency key are run at most once; the
+// outcome of the first call is returned for any repeats.
+func deduplicateRoot(root rpc.Root, calls idempotentCalls, entity names.Tag) *idempotentRoot {
+	return &idempotentRoot{
+		Root:   root,
+		calls:  calls,
+		entity: entity,
+	}
+}
+
+type idempotentRoot struct {
+	rpc.Root
+	calls  idempotentCalls
+	entity names.Tag
+}
+
+// Deduplicate implements rpc.Deduplicator.
+func (r *idempotentRoot) Deduplicate(key string, run func() (interface{}, error)) (interface{}, error) {
+	// Keys are chosen by clients, so they're only unique
+	// to the entity that sent them.
+	key = r.entity.String() + ":" + key
+	claimed, outcome, err := r.calls.ClaimIdempotentCall(key)
+	if err == state.ErrIdempotentCallPending {
+		return nil, &params.Error{
+			Message: "call is still in progress, or its outcome is unknown",
+			Code:    params.CodeRetry,
+		}
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !claimed {
+		logger.Debugf("returning recorded outcome of call %q", key)
+		if outcome.Error != "" {
+			return nil, &params.Error{
+				Message: outcome.Error,
+				Code:    outcome.ErrorCode,
+			}
+		}
+		return json.RawMessage(outcome.Response), nil
+	}
+
+	result, callErr := run()
+	if callErr != nil {
+		serverErr := common.ServerError(callErr)
+		outcome.Error = serverErr.Message
+		outcome.ErrorCode = serverErr.Code
+	} else if outcome.Response, err = json.Marshal(result); err != nil {
+		// Leave the call pending; a repeat will be
+		// told to retry until the claim expires.
+		logger.Errorf("cannot record outcome of call %q: %v", key, err)
+		return result, nil
+	}
+	if err := r.calls.CompleteIdempotentCall(key, outcome); err != nil {
+		logger.Errorf("cannot record outcome of call %q: %v", key, err)
+	}
+	return result, callErr
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type idempotentRootSuite struct {
+	coretesting.BaseSuite
+	calls *fakeIdempotentCalls
+	root  *idempotentRoot
+	runs  int
+}
+
+var _ = gc.Suite(&idempotentRootSuite{})
+
+func (s *idempotentRootSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.calls = &fakeIdempotentCalls{
+		claimed:  make(map[string]bool),
+		outcomes: make(map[string]state.IdempotentCallOutcome),
+	}
+	s.root = deduplicateRoot(nil, s.calls, names.NewUserTag("bob"))
+	s.runs = 0
+}
+
+func (s *idempotentRootSuite) run(result interface{}, err error) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		s.runs++
+		return result, err
+	}
+}
+
+func (s *idempotentRootSuite) TestRepeatReturnsRecordedResult(c *gc.C) {
+	expect := params.StringResult{Result: "done"}
+	result, err := s.root.Deduplicate("key", s.run(expect, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, expect)
+
+	result, err = s.root.Deduplicate("key", s.run(nil, errors.New("should not run")))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runs, gc.Equals, 1)
+	var replayed params.StringResult
+	err = json.Unmarshal(result.(json.RawMessage), &replayed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replayed, jc.DeepEquals, expect)
+}
+
+func (s *idempotentRootSuite) TestRepeatReturnsRecordedError(c *gc.C) {
+	_, err := s.root.Deduplicate("key", s.run(nil, errors.NotFoundf("thing")))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.root.Deduplicate("key", s.run(nil, nil))
+	c.Assert(s.runs, gc.Equals, 1)
+	c.Assert(err, jc.DeepEquals, &params.Error{
+		Message: "thing not found",
+		Code:    params.CodeNotFound,
+	})
+}
+
+func (s *idempotentRootSuite) TestRepeatWhilePending(c *gc.C) {
+	s.calls.claimed["user-bob:key"] = true
+	_, err := s.root.Deduplicate("key", s.run(nil, nil))
+	c.Assert(s.runs, gc.Equals, 0)
+	c.Assert(err, jc.DeepEquals, &params.Error{
+		Message: "call is still in progress, or its outcome is unknown",
+		Code:    params.CodeRetry,
+	})
+}
+
+func (s *idempotentRootSuite) TestKeysQualifiedByEntity(c *gc.C) {
+	_, err := s.root.Deduplicate("key", s.run(params.StringResult{}, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	mary := deduplicateRoot(nil, s.calls, names.NewUserTag("mary"))
+	_, err = mary.Deduplicate("key", s.run(params.StringResult{}, nil))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runs, gc.Equals, 2)
+}
+
+type fakeIdempotentCalls struct {
+	claimed  map[string]bool
+	outcomes map[string]state.IdempotentCallOutcome
+}
+
+func (f *fakeIdempotentCalls) ClaimIdempotentCall(key string) (bool, state.IdempotentCallOutcome, error) {
+	if !f.claimed[key] {
+		f.claimed[key] = true
+		return true, state.IdempotentCallOutcome{}, nil
+	}
+	outcome, ok := f.outcomes[key]
+	if !ok {
+		return false, state.IdempotentCallOutcome{}, state.ErrIdempotentCallPending
+	}
+	return false, outcome, nil
+}
+
+func (f *fakeIdempotentCalls) CompleteIdempotentCall(key string, outcome state.IdempotentCallOutcome) error {
+	f.outcomes[key] = outcome
+	return nil
+}
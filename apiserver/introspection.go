@@ -4,8 +4,15 @@
 package apiserver
 
 import (
+	"crypto/subtle"
+	"crypto/tls"
+	"log"
+	"net"
 	"net/http"
 
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
 	names "gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -73,3 +80,123 @@ func (h introspectionHandler) checkAuth(r *http.Request) error {
 		Message: "access denied",
 	}
 }
+
+// IntrospectionConfig holds the configuration for the dedicated
+// listener on which the introspection and metrics endpoints are
+// served. Requests to the listener are authenticated with HTTP basic
+// authentication against the configured credentials, rather than
+// with controller user credentials, so that monitoring tools can
+// scrape metrics without being given access to the API.
+type IntrospectionConfig struct {
+	// Listener is the listener on which the endpoints are served.
+	// The API server will wrap it with TLS, and close it when the
+	// server exits.
+	Listener net.Listener
+
+	// Cert and Key hold the certificate and private key (in PEM
+	// format) used to serve TLS on Listener. If these are empty,
+	// the API server's certificate is used.
+	Cert string
+	Key  string
+
+	// Username and Password hold the credentials that clients
+	// must present to access the endpoints.
+	Username string
+	Password string
+}
+
+// Validate validates the introspection configuration.
+func (c IntrospectionConfig) Validate() error {
+	if c.Listener == nil {
+		return errors.NotValidf("missing Listener")
+	}
+	if c.Cert != "" && c.Key == "" {
+		return errors.NotValidf("missing Key")
+	}
+	if c.Key != "" && c.Cert == "" {
+		return errors.NotValidf("missing Cert")
+	}
+	if c.Username == "" {
+		return errors.NotValidf("missing Username")
+	}
+	if c.Password == "" {
+		return errors.NotValidf("missing Password")
+	}
+	return nil
+}
+
+// closeIntrospectionListener closes the introspection listener in
+// cfg, if any. It is used when the server fails to start, as there
+// is no running server to close it.
+func closeIntrospectionListener(cfg ServerConfig) {
+	if cfg.IntrospectionConfig != nil && cfg.IntrospectionConfig.Listener != nil {
+		cfg.IntrospectionConfig.Listener.Close()
+	}
+}
+
+// newIntrospectionListener returns a TLS listener wrapping the
+// configured introspection listener.
+func (srv *Server) newIntrospectionListener() (net.Listener, error) {
+	cfg := srv.introspection
+	if cfg.Cert == "" {
+		// Share the API server's certificate, including any
+		// subsequent updates to it.
+		return tls.NewListener(cfg.Listener, srv.tlsConfig), nil
+	}
+	cert, err := tls.X509KeyPair([]byte(cfg.Cert), []byte(cfg.Key))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsConfig := utils.SecureTLSConfig()
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	return tls.NewListener(cfg.Listener, tlsConfig), nil
+}
+
+// serveIntrospection serves the introspection endpoints on the
+// introspection listener until it is closed.
+func (srv *Server) serveIntrospection() {
+	mux := http.NewServeMux()
+	srv.registerIntrospectionHandlers(func(path string, handler http.Handler) {
+		mux.Handle(path, basicAuthHandler{
+			username: srv.introspection.Username,
+			password: srv.introspection.Password,
+			handler:  handler,
+		})
+	})
+	logger.Debugf("starting introspection http server on address %q", srv.introspectionLis.Addr())
+	httpSrv := &http.Server{
+		Handler: mux,
+		ErrorLog: log.New(&loggoWrapper{
+			level:  loggo.WARNING,
+			logger: logger,
+		}, "", 0),
+	}
+	err := httpSrv.Serve(srv.introspectionLis)
+	// As with the API http server, an error is expected here
+	// when the listener is closed.
+	logger.Debugf("introspection http server exited, final error was: %v", err)
+}
+
+// basicAuthHandler is an http.Handler that requires requests to be
+// authenticated with the given HTTP basic authentication credentials
+// before passing them on to the wrapped handler.
+type basicAuthHandler struct {
+	username string
+	password string
+	handler  http.Handler
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h basicAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	username, password, ok := r.BasicAuth()
+	if !ok || !secureCompare(username, h.username) || !secureCompare(password, h.password) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="juju introspection"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+func secureCompare(given, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(expected)) == 1
+}
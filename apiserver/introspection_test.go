@@ -5,12 +5,15 @@ package apiserver_test
 
 import (
 	"io/ioutil"
+	"net"
 	"net/http"
 
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
@@ -75,3 +78,52 @@ func (s *introspectionSuite) TestAccessDenied(c *gc.C) {
 	defer resp.Body.Close()
 	c.Assert(resp.StatusCode, gc.Equals, http.StatusForbidden)
 }
+
+type introspectionConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&introspectionConfigSuite{})
+
+func (s *introspectionConfigSuite) validConfig(c *gc.C) apiserver.IntrospectionConfig {
+	listener, err := net.Listen("tcp", "localhost:0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { listener.Close() })
+	return apiserver.IntrospectionConfig{
+		Listener: listener,
+		Username: "prometheus",
+		Password: "sekrit",
+	}
+}
+
+func (s *introspectionConfigSuite) TestValidate(c *gc.C) {
+	cfg := s.validConfig(c)
+	c.Assert(cfg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *introspectionConfigSuite) TestValidateErrors(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*apiserver.IntrospectionConfig)
+		err    string
+	}{{
+		mutate: func(cfg *apiserver.IntrospectionConfig) { cfg.Listener = nil },
+		err:    "missing Listener not valid",
+	}, {
+		mutate: func(cfg *apiserver.IntrospectionConfig) { cfg.Cert = "cert" },
+		err:    "missing Key not valid",
+	}, {
+		mutate: func(cfg *apiserver.IntrospectionConfig) { cfg.Key = "key" },
+		err:    "missing Cert not valid",
+	}, {
+		mutate: func(cfg *apiserver.IntrospectionConfig) { cfg.Username = "" },
+		err:    "missing Username not valid",
+	}, {
+		mutate: func(cfg *apiserver.IntrospectionConfig) { cfg.Password = "" },
+		err:    "missing Password not valid",
+	}} {
+		c.Logf("test %d", i)
+		cfg := s.validConfig(c)
+		test.mutate(&cfg)
+		c.Check(cfg.Validate(), gc.ErrorMatches, test.err)
+	}
+}
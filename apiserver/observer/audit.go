@@ -97,14 +97,26 @@ type AuditRPCObserver struct {
 	remoteAddress     string
 }
 
-// ServerRequest implements Observer.
+// ServerRequest implements Observer. Only requests that may change
+// something are audited, and secrets are redacted from their bodies.
 func (a *AuditRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
+	if !audit.IsMutating(hdr.Request.Type, hdr.Request.Action) {
+		return
+	}
 	auditEntry := a.boilerplateAuditEntry()
 	auditEntry.OriginName = a.authenticatedTag
 
 	auditEntry.OriginType = "API request"
 	auditEntry.Operation = rpcRequestToOperation(hdr.Request)
-	auditEntry.Data = map[string]interface{}{"request-body": body}
+	auditEntry.Data = map[string]interface{}{
+		"facade":       hdr.Request.Type,
+		"version":      hdr.Request.Version,
+		"method":       hdr.Request.Action,
+		"request-body": audit.Redact(body),
+	}
+	if hdr.Request.CorrelationId != "" {
+		auditEntry.Data["correlation-id"] = hdr.Request.CorrelationId
+	}
 	err := a.handleAuditEntry(auditEntry)
 	if err != nil {
 		a.errorHandler(errors.Trace(err))
@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer_test
+
+import (
+	"net/http"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditSuite struct {
+	testing.IsolationSuite
+	entries []audit.AuditEntry
+	rpc     rpc.Observer
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.entries = nil
+	o := observer.NewAudit(&observer.AuditContext{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+	}, func(entry audit.AuditEntry) error {
+		s.entries = append(s.entries, entry)
+		return nil
+	}, func(err error) {
+		c.Errorf("unexpected error: %v", err)
+	})
+	o.Join(&http.Request{RemoteAddr: "10.0.0.1"}, 1)
+	o.Login(names.NewUserTag("bob"), coretesting.ModelTag, false, "")
+	s.rpc = o.RPCObserver()
+}
+
+func (s *auditSuite) TestMutatingRequestAudited(c *gc.C) {
+	s.rpc.ServerRequest(&rpc.Header{
+		Request: rpc.Request{
+			Type:          "UserManager",
+			Version:       1,
+			Action:        "SetPassword",
+			CorrelationId: "abc",
+		},
+	}, struct {
+		Tag      string `json:"tag"`
+		Password string `json:"password"`
+	}{"user-mary", "hunter2"})
+
+	c.Assert(s.entries, gc.HasLen, 1)
+	entry := s.entries[0]
+	c.Check(entry.OriginName, gc.Equals, "user-bob")
+	c.Check(entry.RemoteAddress, gc.Equals, "10.0.0.1")
+	c.Check(entry.Operation, gc.Equals, "UserManager:v1 - SetPassword")
+	c.Check(entry.Data, jc.DeepEquals, map[string]interface{}{
+		"facade":  "UserManager",
+		"version": 1,
+		"method":  "SetPassword",
+		"request-body": map[string]interface{}{
+			"tag":      "user-mary",
+			"password": audit.Redacted,
+		},
+		"correlation-id": "abc",
+	})
+}
+
+func (s *auditSuite) TestReadOnlyRequestNotAudited(c *gc.C) {
+	s.rpc.ServerRequest(&rpc.Header{
+		Request: rpc.Request{
+			Type:    "Client",
+			Version: 1,
+			Action:  "FullStatus",
+		},
+	}, nil)
+	c.Assert(s.entries, gc.HasLen, 0)
+}
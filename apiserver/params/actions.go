@@ -143,3 +143,138 @@ type ActionPruneArgs struct {
 	MaxHistoryTime time.Duration `json:"max-history-time"`
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
+
+// ActionSchedules holds the action schedules to add.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionSchedule describes an action to be enqueued on a unit, or on
+// every unit of an application, either once at a given time or
+// repeatedly according to a cron-like schedule. Exactly one of Spec
+// and At is set.
+type ActionSchedule struct {
+	// Id identifies the schedule. It is ignored when adding a schedule.
+	Id string `json:"id,omitempty"`
+
+	// Receiver is the tag of the unit or application on which the
+	// action is enqueued.
+	Receiver string `json:"receiver"`
+
+	// Name is the name of the action.
+	Name string `json:"name"`
+
+	// Parameters holds the action's parameters, if any.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// Spec is the cron-like schedule of a recurring action, in the
+	// five field cron format, evaluated in UTC.
+	Spec string `json:"spec,omitempty"`
+
+	// At is the time at which an action that is run once is enqueued.
+	At *time.Time `json:"at,omitempty"`
+
+	// NextRun is the time the action is next due to be enqueued. It
+	// is ignored when adding a schedule.
+	NextRun *time.Time `json:"next-run,omitempty"`
+
+	// LastRun is the time a recurring action was last enqueued, if it
+	// has been. It is ignored when adding a schedule.
+	LastRun *time.Time `json:"last-run,omitempty"`
+}
+
+// ActionScheduleResult holds an action schedule or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionScheduleResults holds the results of bulk action schedule
+// calls.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results"`
+}
+
+// ActionScheduleIds identifies action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ActionMessageParams holds the messages logged by running actions.
+type ActionMessageParams struct {
+	Messages []ActionMessage `json:"messages"`
+}
+
+// ActionMessage is a message logged by a running action.
+type ActionMessage struct {
+	// ActionTag is the tag of the action. It is only used when logging
+	// a message.
+	ActionTag string `json:"action-tag,omitempty"`
+
+	// Timestamp is the time the message was logged. It is ignored
+	// when logging a message.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Message is the logged message.
+	Message string `json:"message"`
+}
+
+// ActionRollouts holds the action rollouts to add.
+type ActionRollouts struct {
+	Rollouts []ActionRollout `json:"rollouts"`
+}
+
+// ActionRollout describes an action run across the units of an
+// application in batches.
+type ActionRollout struct {
+	// Id identifies the rollout. It is ignored when adding a rollout.
+	Id string `json:"id,omitempty"`
+
+	// Application is the tag of the application whose units run the
+	// action.
+	Application string `json:"application"`
+
+	// Name is the name of the action.
+	Name string `json:"name"`
+
+	// Parameters holds the action's parameters, if any.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+
+	// MaxParallel is the maximum number of units that run the action
+	// at the same time. Zero means no limit.
+	MaxParallel int `json:"max-parallel,omitempty"`
+
+	// BatchDelay is the time to wait after a batch has finished
+	// before starting the next one.
+	BatchDelay time.Duration `json:"batch-delay,omitempty"`
+
+	// StopOnFailure, if true, stops the rollout once an action fails.
+	StopOnFailure bool `json:"stop-on-failure,omitempty"`
+
+	// Status is the state of the rollout. It is ignored when adding a
+	// rollout.
+	Status string `json:"status,omitempty"`
+
+	// Pending holds the tags of the units on which the action has not
+	// yet been enqueued. It is ignored when adding a rollout.
+	Pending []string `json:"pending,omitempty"`
+
+	// Actions holds the tags of the actions enqueued so far. It is
+	// ignored when adding a rollout.
+	Actions []string `json:"actions,omitempty"`
+
+	// Failed holds the tags of the units whose action failed or was
+	// cancelled. It is ignored when adding a rollout.
+	Failed []string `json:"failed,omitempty"`
+}
+
+// ActionRolloutResult holds an action rollout or an error.
+type ActionRolloutResult struct {
+	Rollout *ActionRollout `json:"rollout,omitempty"`
+	Error   *Error         `json:"error,omitempty"`
+}
+
+// ActionRolloutResults holds the results of bulk action rollout calls.
+type ActionRolloutResults struct {
+	Results []ActionRolloutResult `json:"results"`
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+
+	"github.com/juju/version"
+)
+
+// The values of AgentIntegrity.Status.
+const (
+	// AgentIntegrityVerified indicates that the agent's binaries
+	// matched the tools metadata recorded by the controller.
+	AgentIntegrityVerified = "verified"
+
+	// AgentIntegrityUnverified indicates that the agent's binaries
+	// could not be checked.
+	AgentIntegrityUnverified = "unverified"
+
+	// AgentIntegrityTampered indicates that the agent's binaries did
+	// not match the tools metadata recorded by the controller, and
+	// that the agent has refused to run.
+	AgentIntegrityTampered = "tampered"
+)
+
+// AgentIntegrity describes the outcome of an agent checking the
+// integrity of its own binaries.
+type AgentIntegrity struct {
+	// Tag is the tag of the agent.
+	Tag string `json:"tag"`
+
+	// Version is the version of the checked binaries.
+	Version version.Binary `json:"version"`
+
+	// Status is one of the AgentIntegrity* values.
+	Status string `json:"status"`
+
+	// Message holds further details of the outcome, if any.
+	Message string `json:"message,omitempty"`
+
+	// Updated is when the outcome was recorded by the controller.
+	// It is ignored when setting agent integrity.
+	Updated time.Time `json:"updated,omitempty"`
+}
+
+// SetAgentIntegrityArgs holds the arguments to the Upgrader facade's
+// SetToolsIntegrity call.
+type SetAgentIntegrityArgs struct {
+	Args []AgentIntegrity `json:"args"`
+}
+
+// AgentIntegrityResult holds the result of the AgentIntegrity
+// facade's AgentIntegrity call.
+type AgentIntegrityResult struct {
+	Agents []AgentIntegrity `json:"agents"`
+}
@@ -82,6 +82,7 @@ const (
 	CodeNotProvisioned            = "not provisioned"
 	CodeNoAddressSet              = "no address set"
 	CodeTryAgain                  = "try again"
+	CodeRateLimitExceeded         = "rate limit exceeded"
 	CodeNotImplemented            = "not implemented" // asserted to match rpc.codeNotImplemented in rpc/rpc_test.go
 	CodeAlreadyExists             = "already exists"
 	CodeUpgradeInProgress         = "upgrade in progress"
@@ -98,6 +99,10 @@ const (
 	CodeRedirect                  = "redirection required"
 	CodeRetry                     = "retry"
 	CodeIncompatibleSeries        = "incompatible series"
+	CodeCharmRequirementsNotMet   = "charm requirements not met"
+	CodeCharmNotFound             = "charm not found"
+	CodePlacementNotValid         = "placement not valid"
+	CodeQuotaLimitExceeded        = "quota limit exceeded"
 )
 
 // ErrCode returns the error code associated with
@@ -216,6 +221,13 @@ func IsCodeTryAgain(err error) bool {
 	return ErrCode(err) == CodeTryAgain
 }
 
+// IsCodeRateLimitExceeded returns true if the error was returned
+// because the caller has made too many calls; it should back off
+// before trying again.
+func IsCodeRateLimitExceeded(err error) bool {
+	return ErrCode(err) == CodeRateLimitExceeded
+}
+
 func IsCodeNotImplemented(err error) bool {
 	return ErrCode(err) == CodeNotImplemented
 }
@@ -260,6 +272,22 @@ func IsCodeIncompatibleSeries(err error) bool {
 	return ErrCode(err) == CodeIncompatibleSeries
 }
 
+func IsCodeCharmRequirementsNotMet(err error) bool {
+	return ErrCode(err) == CodeCharmRequirementsNotMet
+}
+
+func IsCodeCharmNotFound(err error) bool {
+	return ErrCode(err) == CodeCharmNotFound
+}
+
+func IsCodePlacementNotValid(err error) bool {
+	return ErrCode(err) == CodePlacementNotValid
+}
+
+func IsCodeQuotaLimitExceeded(err error) bool {
+	return ErrCode(err) == CodeQuotaLimitExceeded
+}
+
 func IsCodeForbidden(err error) bool {
 	return ErrCode(err) == CodeForbidden
 }
@@ -0,0 +1,44 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// AuditEventsArgs holds the arguments for the AuditLog.Events call.
+// Zero-valued fields are ignored.
+type AuditEventsArgs struct {
+	// ModelTag selects events recorded for the given model.
+	ModelTag string `json:"model-tag,omitempty"`
+
+	// UserTag selects events for requests made by the given user.
+	UserTag string `json:"user-tag,omitempty"`
+
+	// After and Before select events recorded at or after, and
+	// before, the given times.
+	After  *time.Time `json:"after,omitempty"`
+	Before *time.Time `json:"before,omitempty"`
+
+	// Limit is the maximum number of events to return.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditEvent describes a single API request recorded in the audit log.
+type AuditEvent struct {
+	JujuServerVersion string                 `json:"juju-server-version"`
+	ModelTag          string                 `json:"model-tag"`
+	Timestamp         time.Time              `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+// AuditEvents holds the results of the AuditLog.Events call, most
+// recent first.
+type AuditEvents struct {
+	Events []AuditEvent `json:"events"`
+}
@@ -12,6 +12,14 @@ import (
 // BackupsCreateArgs holds the args for the API Create method.
 type BackupsCreateArgs struct {
 	Notes string `json:"notes"`
+
+	// Incremental requests a backup holding only the changes made
+	// since the most recent backup, rather than a full backup.
+	Incremental bool `json:"incremental,omitempty"`
+
+	// ToTarget requests that the backup archive be streamed to the
+	// controller's backup target rather than stored on the controller.
+	ToTarget bool `json:"to-target,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -39,6 +47,30 @@ type BackupsRemoveArgs struct {
 	ID string `json:"id"`
 }
 
+// BackupsPruneArgs holds the args for the API Prune method. Stored
+// backups not retained by any of the Keep rules are removed.
+type BackupsPruneArgs struct {
+	// KeepLast is the number of most recent backups to keep.
+	KeepLast int `json:"keep-last,omitempty"`
+
+	// KeepDaily is the number of days for which the most recent
+	// backup of each day is kept.
+	KeepDaily int `json:"keep-daily,omitempty"`
+
+	// KeepWeekly is the number of weeks for which the most recent
+	// backup of each week is kept.
+	KeepWeekly int `json:"keep-weekly,omitempty"`
+
+	// DryRun reports the backups that would be removed without
+	// removing them.
+	DryRun bool `json:"dry-run,omitempty"`
+}
+
+// BackupsPruneResult holds the backups removed by the API Prune method.
+type BackupsPruneResult struct {
+	Removed []BackupsMetadataResult `json:"removed"`
+}
+
 // BackupsListResult holds the list of all stored backups.
 type BackupsListResult struct {
 	List []BackupsMetadataResult `json:"list"`
@@ -68,6 +100,14 @@ type BackupsMetadataResult struct {
 	Version  version.Number `json:"version"`
 	Series   string         `json:"series"`
 
+	// Parent is the ID of the backup on which an incremental backup
+	// builds. It is empty for full backups.
+	Parent string `json:"parent,omitempty"`
+
+	// Target refers to the object holding the archive in the
+	// controller's backup target, if it was streamed there.
+	Target string `json:"target,omitempty"`
+
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
 }
@@ -77,3 +117,25 @@ type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
 }
+
+// BackupsRestoreModelArgs holds the args for the API RestoreModel method.
+type BackupsRestoreModelArgs struct {
+	// BackupId is the ID of the controller backup holding the model.
+	BackupId string `json:"backup-id"`
+
+	// ModelTag is the tag of the model in the backup.
+	ModelTag string `json:"model-tag"`
+
+	// NewUUID, if set, is the UUID given to the restored model.
+	NewUUID string `json:"new-uuid,omitempty"`
+
+	// NewName, if set, is the name given to the restored model.
+	NewName string `json:"new-name,omitempty"`
+}
+
+// BackupsRestoreModelResult holds the result of the API RestoreModel
+// method.
+type BackupsRestoreModelResult struct {
+	// ModelTag is the tag of the restored model.
+	ModelTag string `json:"model-tag"`
+}
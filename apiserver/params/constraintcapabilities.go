@@ -0,0 +1,32 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// ConstraintCapability describes the support of a model's provider
+// for a constraint attribute.
+type ConstraintCapability struct {
+	// Name is the name of the constraint attribute.
+	Name string `json:"name"`
+
+	// Supported reports whether the provider supports the attribute.
+	Supported bool `json:"supported"`
+
+	// Strict reports whether specifying the attribute when it is not
+	// supported is an error, rather than being ignored with a warning.
+	Strict bool `json:"strict,omitempty"`
+
+	// Conflicts holds the names of the attributes which may not be
+	// specified together with this one.
+	Conflicts []string `json:"conflicts,omitempty"`
+
+	// Values holds the values allowed for the attribute. If empty,
+	// any value is allowed.
+	Values []string `json:"values,omitempty"`
+}
+
+// ConstraintCapabilitiesResult holds the result of the
+// ConstraintCapabilities facade's ConstraintCapabilities call.
+type ConstraintCapabilitiesResult struct {
+	Capabilities []ConstraintCapability `json:"capabilities"`
+}
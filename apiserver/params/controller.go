@@ -98,3 +98,22 @@ const (
 	GrantControllerAccess  ControllerAction = "grant"
 	RevokeControllerAccess ControllerAction = "revoke"
 )
+
+// ConfigWebhook describes a webhook, registered with the controller,
+// that validates config changes before they are committed.
+type ConfigWebhook struct {
+	Name   string   `json:"name"`
+	URL    string   `json:"url"`
+	CACert string   `json:"ca-cert,omitempty"`
+	Kinds  []string `json:"kinds"`
+}
+
+// ConfigWebhooks holds a list of config webhooks.
+type ConfigWebhooks struct {
+	Webhooks []ConfigWebhook `json:"webhooks"`
+}
+
+// ConfigWebhookNames holds the names of config webhooks.
+type ConfigWebhookNames struct {
+	Names []string `json:"names"`
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// ControllerHealthResult holds the result of the ControllerHealth
+// Health call.
+type ControllerHealthResult struct {
+	// Machines holds the health of each controller machine.
+	Machines []ControllerMachineHealth `json:"machines"`
+
+	// Leases holds the health of the lease store shared by the
+	// controllers.
+	Leases *LeaseStoreHealth `json:"leases,omitempty"`
+
+	// ReplicaSetError is set if the replica set status could not be
+	// read.
+	ReplicaSetError *Error `json:"replica-set-error,omitempty"`
+
+	// LeasesError is set if the lease store health could not be
+	// read.
+	LeasesError *Error `json:"leases-error,omitempty"`
+}
+
+// ControllerMachineHealth describes the health of a single controller
+// machine.
+type ControllerMachineHealth struct {
+	// MachineTag is the tag of the controller machine.
+	MachineTag string `json:"machine-tag"`
+
+	// ReplicaSet holds the state of the machine's mongo replica set
+	// member, if it has one.
+	ReplicaSet *ReplicaSetMemberHealth `json:"replica-set,omitempty"`
+
+	// APIServer holds the most recently recorded load on the
+	// machine's API server, if it has been recorded.
+	APIServer *APIServerLoad `json:"api-server,omitempty"`
+}
+
+// ReplicaSetMemberHealth describes the state of a mongo replica set
+// member.
+type ReplicaSetMemberHealth struct {
+	// Address is the member's host:port address.
+	Address string `json:"address"`
+
+	// State is the member's replica set state, such as PRIMARY or
+	// SECONDARY.
+	State string `json:"state"`
+
+	// Healthy is true if the member is up.
+	Healthy bool `json:"healthy"`
+
+	// OplogLag is how far the member's oplog is behind the primary's.
+	OplogLag time.Duration `json:"oplog-lag"`
+
+	// Message describes any problem with the member.
+	Message string `json:"message,omitempty"`
+}
+
+// LeaseStoreHealth describes the state of the lease store.
+type LeaseStoreHealth struct {
+	// GlobalTime is the current time of the global clock by which
+	// leases expire.
+	GlobalTime time.Time `json:"global-time"`
+
+	// Leases is the number of leases held in all models.
+	Leases int `json:"leases"`
+
+	// ExpiredLeases is the number of expired leases not yet removed
+	// by the lease managers.
+	ExpiredLeases int `json:"expired-leases"`
+}
+
+// APIServerLoad describes the load on an API server.
+type APIServerLoad struct {
+	// Connections is the number of current API connections.
+	Connections int64 `json:"connections"`
+
+	// TotalConnections is the number of API connections made since
+	// the API server started.
+	TotalConnections int64 `json:"total-connections"`
+
+	// LoginAttempts is the number of logins in progress.
+	LoginAttempts int64 `json:"login-attempts"`
+
+	// Updated is when the load was recorded.
+	Updated time.Time `json:"updated"`
+}
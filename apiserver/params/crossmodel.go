@@ -4,6 +4,8 @@
 package params
 
 import (
+	"time"
+
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/macaroon.v1"
 )
@@ -101,6 +103,16 @@ type OfferConnection struct {
 	Endpoint       string       `json:"endpoint"`
 	Status         EntityStatus `json:"status"`
 	IngressSubnets []string     `json:"ingress-subnets"`
+	Health         string       `json:"health,omitempty"`
+	LastSeen       *time.Time   `json:"last-seen,omitempty"`
+}
+
+// OfferConnectionPruneArgs holds the parameters for pruning stale
+// offer connections.
+type OfferConnectionPruneArgs struct {
+	// MaxStaleness is how long a consuming model may go without
+	// being heard from before its connection is pruned.
+	MaxStaleness time.Duration `json:"max-staleness"`
 }
 
 // QueryApplicationOffersResults is a result of searching application offers.
@@ -129,6 +141,97 @@ type DestroyApplicationOffers struct {
 	Force     bool     `json:"force,omitempty"`
 }
 
+// OfferIngressNetworks holds the networks from which consumers of an
+// offer may connect.
+type OfferIngressNetworks struct {
+	OfferURL string   `json:"offer-url"`
+	CIDRs    []string `json:"cidrs"`
+}
+
+// SetOfferIngressNetworksArgs holds the parameters for the
+// SetOfferIngressNetworks call.
+type SetOfferIngressNetworksArgs struct {
+	Args []OfferIngressNetworks `json:"args"`
+}
+
+// OfferIngressNetworksResult holds the networks from which consumers
+// of an offer may connect, or an error.
+type OfferIngressNetworksResult struct {
+	CIDRs []string `json:"cidrs,omitempty"`
+	Error *Error   `json:"error,omitempty"`
+}
+
+// OfferIngressNetworksResults holds the results of the
+// OfferIngressNetworks call.
+type OfferIngressNetworksResults struct {
+	Results []OfferIngressNetworksResult `json:"results"`
+}
+
+// OfferMigration holds the details of the migration of an offer to a
+// new application.
+type OfferMigration struct {
+	OfferURL        string            `json:"offer-url"`
+	ApplicationName string            `json:"application-name"`
+	Endpoints       map[string]string `json:"endpoints"`
+	Deadline        time.Time         `json:"deadline"`
+}
+
+// StartOfferMigrationArgs holds the parameters for the
+// StartOfferMigration call.
+type StartOfferMigrationArgs struct {
+	Args []OfferMigration `json:"args"`
+}
+
+// OfferConsumersResult holds the connections made to an offer by
+// its consumers, or an error.
+type OfferConsumersResult struct {
+	Consumers []OfferConnection `json:"consumers,omitempty"`
+	Error     *Error            `json:"error,omitempty"`
+}
+
+// OfferConsumersResults holds the results of the OfferConsumers call.
+type OfferConsumersResults struct {
+	Results []OfferConsumersResult `json:"results"`
+}
+
+// RevokeOfferConsumers identifies the consumers of an offer whose
+// relations are to be removed. If no model tags or usernames are
+// given, all consumers are selected.
+type RevokeOfferConsumers struct {
+	OfferURL string `json:"offer-url"`
+
+	// SourceModelTags, if set, restricts the consumers to those
+	// connecting from the specified models.
+	SourceModelTags []string `json:"source-model-tags,omitempty"`
+
+	// Usernames, if set, restricts the consumers to those
+	// connecting as the specified users.
+	Usernames []string `json:"usernames,omitempty"`
+
+	// StaleOnly, if true, restricts the consumers to those which
+	// have not been heard from recently.
+	StaleOnly bool `json:"stale-only,omitempty"`
+}
+
+// RevokeOfferConsumersArgs holds the parameters for the
+// RevokeConsumers call.
+type RevokeOfferConsumersArgs struct {
+	Args []RevokeOfferConsumers `json:"args"`
+}
+
+// RevokeOfferConsumersResult holds the ids of the relations removed
+// by a RevokeConsumers call, or an error.
+type RevokeOfferConsumersResult struct {
+	RelationIds []int  `json:"relation-ids,omitempty"`
+	Error       *Error `json:"error,omitempty"`
+}
+
+// RevokeOfferConsumersResults holds the results of the
+// RevokeConsumers call.
+type RevokeOfferConsumersResults struct {
+	Results []RevokeOfferConsumersResult `json:"results"`
+}
+
 // RemoteEndpoint represents a remote application endpoint.
 type RemoteEndpoint struct {
 	Name      string             `json:"name"`
@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// DeployProgressConfig holds the information necessary to open a
+// streaming connection to the API endpoint for following the
+// progress of an application deployment.
+//
+// The field tags relate to the following 2 libraries:
+//   github.com/google/go-querystring/query (encoding)
+//   github.com/gorilla/schema (decoding)
+type DeployProgressConfig struct {
+	// Application is the name of the application being deployed.
+	Application string `schema:"application" url:"application"`
+
+	// NumUnits is the number of units that must be deployed for the
+	// application to be considered stable.
+	NumUnits int `schema:"units" url:"units,omitempty"`
+}
+
+// The kinds of DeployProgressEvent.
+const (
+	// DeployProgressMachine reports a change in the instance status
+	// of a machine hosting a unit of the application.
+	DeployProgressMachine = "machine"
+
+	// DeployProgressUnitAgent reports a change in the agent status
+	// of a unit of the application.
+	DeployProgressUnitAgent = "unit-agent"
+
+	// DeployProgressUnitWorkload reports a change in the workload
+	// status of a unit of the application.
+	DeployProgressUnitWorkload = "unit-workload"
+
+	// DeployProgressStable is the final event of a successful
+	// deployment.
+	DeployProgressStable = "stable"
+
+	// DeployProgressFailed is the final event of a failed deployment.
+	DeployProgressFailed = "failed"
+)
+
+// DeployProgressEvent describes a single step in the progress of an
+// application deployment being streamed from the server.
+type DeployProgressEvent struct {
+	Kind      string    `json:"kind"`
+	Entity    string    `json:"entity,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
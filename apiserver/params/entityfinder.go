@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// FindEntitiesQuery holds a partial entity name to resolve.
+type FindEntitiesQuery struct {
+	// Query is an application name prefix, a machine id or
+	// instance id, or a unit name, which may contain glob
+	// wildcards.
+	Query string `json:"query"`
+
+	// Kinds, if set, restricts the matches to entities with
+	// the specified tag kinds: "application", "machine" or
+	// "unit".
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Limit is the maximum number of matches to return. If it is
+	// zero, a default limit is used.
+	Limit int `json:"limit,omitempty"`
+}
+
+// FindEntitiesArgs holds the parameters for the FindEntities call.
+type FindEntitiesArgs struct {
+	Queries []FindEntitiesQuery `json:"queries"`
+}
+
+// EntityMatch holds an entity matched by a FindEntities query.
+type EntityMatch struct {
+	Tag string `json:"tag"`
+
+	// Score ranks the match; higher scores are better matches.
+	Score int `json:"score"`
+}
+
+// FindEntitiesResult holds the entities matched by a FindEntities
+// query, best match first, or an error.
+type FindEntitiesResult struct {
+	Matches []EntityMatch `json:"matches,omitempty"`
+	Error   *Error        `json:"error,omitempty"`
+}
+
+// FindEntitiesResults holds the results of the FindEntities call.
+type FindEntitiesResults struct {
+	Results []FindEntitiesResult `json:"results"`
+}
@@ -27,6 +27,41 @@ type FirewallRule struct {
 	WhitelistCIDRS []string `json:"whitelist-cidrs,omitempty"`
 }
 
+// EgressRuleArgs holds the parameters for updating
+// one or more egress rules.
+type EgressRuleArgs struct {
+	// Args holds the parameters for updating an egress rule.
+	Args []EgressRule `json:"args"`
+}
+
+// ListEgressRulesResults holds the results of listing egress rules.
+type ListEgressRulesResults struct {
+	// Rules is a list of egress rules.
+	Rules []EgressRule `json:"rules"`
+}
+
+// EgressRule restricts the outbound traffic of the machines
+// hosting an application's units.
+type EgressRule struct {
+	// ApplicationTag is the tag of the application the rule applies to.
+	ApplicationTag string `json:"application-tag"`
+
+	// AllowedCIDRs is the list of subnets outbound traffic may reach.
+	AllowedCIDRs []string `json:"allowed-cidrs"`
+}
+
+// EgressRulesResult holds the subnets an application's outbound
+// traffic is restricted to, or an error.
+type EgressRulesResult struct {
+	AllowedCIDRs []string `json:"allowed-cidrs,omitempty"`
+	Error        *Error   `json:"error,omitempty"`
+}
+
+// EgressRulesResults holds the results of the EgressRules API call.
+type EgressRulesResults struct {
+	Results []EgressRulesResult `json:"results"`
+}
+
 // KnownServiceArgs holds the parameters for retrieving firewall rules.
 type KnownServiceArgs struct {
 	// KnownServices are the well known services for a firewall rule.
@@ -364,6 +364,80 @@ type EntityWorkloadVersions struct {
 	Entities []EntityWorkloadVersion `json:"entities"`
 }
 
+// EntityCharmStateSnapshot holds the charm state snapshot exported by
+// a unit.
+type EntityCharmStateSnapshot struct {
+	Tag  string `json:"tag"`
+	Data []byte `json:"data"`
+}
+
+// EntityCharmStateSnapshots holds the parameters for storing the charm
+// state snapshots of a set of units.
+type EntityCharmStateSnapshots struct {
+	Entities []EntityCharmStateSnapshot `json:"entities"`
+}
+
+// CharmStateSnapshotResult holds a unit's charm state snapshot, or an
+// error indicating why it is not available.
+type CharmStateSnapshotResult struct {
+	Data    []byte    `json:"data,omitempty"`
+	Created time.Time `json:"created"`
+	Error   *Error    `json:"error,omitempty"`
+}
+
+// CharmStateSnapshotResults holds the results of a bulk call to
+// retrieve charm state snapshots.
+type CharmStateSnapshotResults struct {
+	Results []CharmStateSnapshotResult `json:"results"`
+}
+
+// HealthCheckResult holds the outcome of running one of the health
+// checks declared by a unit's charm.
+type HealthCheckResult struct {
+	Check   string    `json:"check"`
+	Healthy bool      `json:"healthy"`
+	Message string    `json:"message,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// UnitHealthCheckResults holds the health check results for a unit.
+type UnitHealthCheckResults struct {
+	Tag     string              `json:"tag"`
+	Results []HealthCheckResult `json:"results"`
+}
+
+// SetHealthCheckResults holds the parameters for recording the health
+// check results of a set of units.
+type SetHealthCheckResults struct {
+	Units []UnitHealthCheckResults `json:"units"`
+}
+
+// HealthCheckHistoryArg identifies a unit whose health check history
+// is requested, and how many of the most recent results to return.
+type HealthCheckHistoryArg struct {
+	Tag   string `json:"tag"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// HealthCheckHistoryArgs holds the parameters for retrieving the health
+// check history of a set of units.
+type HealthCheckHistoryArgs struct {
+	Units []HealthCheckHistoryArg `json:"units"`
+}
+
+// HealthCheckHistoryResult holds a unit's health check history, newest
+// first, or an error indicating why it is not available.
+type HealthCheckHistoryResult struct {
+	Results []HealthCheckResult `json:"results,omitempty"`
+	Error   *Error              `json:"error,omitempty"`
+}
+
+// HealthCheckHistoryResults holds the results of a bulk call to
+// retrieve health check histories.
+type HealthCheckHistoryResults struct {
+	Results []HealthCheckHistoryResult `json:"results"`
+}
+
 // BytesResult holds the result of an API call that returns a slice
 // of bytes.
 type BytesResult struct {
@@ -646,6 +720,18 @@ type RunParams struct {
 	Machines     []string      `json:"machines,omitempty"`
 	Applications []string      `json:"applications,omitempty"`
 	Units        []string      `json:"units,omitempty"`
+
+	// The remaining fields apply only when running commands on units.
+	// Relation identifies the relation, either by id or as
+	// "<endpoint>:<id>", whose hook context the commands are run in,
+	// and RemoteUnit the remote unit of that context. Environment
+	// holds additional environment variables for the commands, and
+	// Stream asks for each line of their output to be logged as an
+	// action message while they run.
+	Relation    string            `json:"relation,omitempty"`
+	RemoteUnit  string            `json:"remote-unit,omitempty"`
+	Environment map[string]string `json:"environment,omitempty"`
+	Stream      bool              `json:"stream,omitempty"`
 }
 
 // RunResult contains the result from an individual run call on a machine.
@@ -685,6 +771,21 @@ type ProvisioningInfo struct {
 	ImageMetadata     []CloudImageMetadata      `json:"image-metadata,omitempty"`
 	EndpointBindings  map[string]string         `json:"endpoint-bindings,omitempty"`
 	ControllerConfig  map[string]interface{}    `json:"controller-config,omitempty"`
+
+	// AffinityMachineIds holds the ids of the machines hosting units
+	// of the application named by an affinity placement directive.
+	AffinityMachineIds []string `json:"affinity-machine-ids,omitempty"`
+
+	// CharmLXDProfiles holds the LXD profiles declared by the charms
+	// of the units assigned to the machine, keyed by profile name.
+	CharmLXDProfiles map[string]CharmLXDProfile `json:"charm-lxd-profiles,omitempty"`
+}
+
+// CharmLXDProfile holds the LXD profile declared by a charm.
+type CharmLXDProfile struct {
+	Description string                       `json:"description,omitempty"`
+	Config      map[string]string            `json:"config,omitempty"`
+	Devices     map[string]map[string]string `json:"devices,omitempty"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -812,6 +913,10 @@ type UnitRefreshResult struct {
 	Resolved ResolvedMode
 	Series   string
 	Error    *Error
+
+	// StopDeadline, if set, is the time by which the dying unit
+	// must have stopped.
+	StopDeadline *time.Time `json:",omitempty"`
 }
 
 // UnitRefreshResults holds the results for any API call which ends
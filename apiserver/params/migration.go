@@ -52,6 +52,28 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationValidationBlocker describes something that would prevent
+// a model migration from succeeding.
+type MigrationValidationBlocker struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+// MigrationValidationResults is used to return the result of
+// validating one or more model migrations.
+type MigrationValidationResults struct {
+	Results []MigrationValidationResult `json:"results"`
+}
+
+// MigrationValidationResult is used to return the result of
+// validating one model migration without starting it.
+type MigrationValidationResult struct {
+	ModelTag string                       `json:"model-tag"`
+	Blockers []MigrationValidationBlocker `json:"blockers,omitempty"`
+	Warnings []string                     `json:"warnings,omitempty"`
+	Error    *Error                       `json:"error,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -217,3 +239,33 @@ type AdoptResourcesArgs struct {
 	// that version.
 	SourceControllerVersion version.Number `json:"source-controller-version"`
 }
+
+// MigrationModelRequirements describes what a model being migrated
+// needs of the target controller.
+type MigrationModelRequirements struct {
+	Cloud            string   `json:"cloud"`
+	Features         []string `json:"features,omitempty"`
+	StorageProviders []string `json:"storage-providers,omitempty"`
+	BinariesSize     uint64   `json:"binaries-size"`
+}
+
+// MigrationCompatibilityArgs holds the arguments to the
+// MigrationTarget facade's CheckCompatibility call.
+type MigrationCompatibilityArgs struct {
+	Model        MigrationModelInfo         `json:"model"`
+	Requirements MigrationModelRequirements `json:"requirements"`
+}
+
+// MigrationCompatibilityCheck holds the outcome of checking one
+// aspect of whether a target controller can host a migrated model.
+type MigrationCompatibilityCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// MigrationCompatibilityReport holds the outcomes of the checks made
+// by a target controller before a model is migrated to it.
+type MigrationCompatibilityReport struct {
+	Checks []MigrationCompatibilityCheck `json:"checks"`
+}
@@ -51,6 +51,7 @@ type ModelDefaultsResult struct {
 type ModelDefaults struct {
 	Default    interface{}      `json:"default,omitempty"`
 	Controller interface{}      `json:"controller,omitempty"`
+	Cloud      interface{}      `json:"cloud,omitempty"`
 	Regions    []RegionDefaults `json:"regions,omitempty"`
 }
 
@@ -107,6 +108,33 @@ type UnsetModelDefaults struct {
 	Keys []ModelUnsetKeys `json:"keys"`
 }
 
+// CloudRegionSpec identifies a cloud and, optionally, a region
+// within it.
+type CloudRegionSpec struct {
+	CloudTag    string `json:"cloud-tag"`
+	CloudRegion string `json:"cloud-region,omitempty"`
+}
+
+// CloudRegionSpecs contains the arguments for the
+// EffectiveModelDefaults client API call.
+type CloudRegionSpecs struct {
+	Specs []CloudRegionSpec `json:"specs"`
+}
+
+// EffectiveModelDefaultsResult holds the default config values that
+// would be used for a new model in a cloud region, and their sources,
+// or an error.
+type EffectiveModelDefaultsResult struct {
+	Config map[string]ConfigValue `json:"config,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// EffectiveModelDefaultsResults contains the results of the
+// EffectiveModelDefaults client API call.
+type EffectiveModelDefaultsResults struct {
+	Results []EffectiveModelDefaultsResult `json:"results"`
+}
+
 // SetModelAgentVersion contains the arguments for
 // SetModelAgentVersion client API call.
 type SetModelAgentVersion struct {
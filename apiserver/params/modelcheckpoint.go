@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// ModelCheckpointNames holds the names of model checkpoints to
+// operate on.
+type ModelCheckpointNames struct {
+	Names []string `json:"names"`
+}
+
+// ModelCheckpointApplication records an application in a model
+// checkpoint.
+type ModelCheckpointApplication struct {
+	Name     string `json:"name"`
+	CharmURL string `json:"charm-url"`
+
+	// Config holds the charm config values set by the user.
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// ModelCheckpoint is a named record of the applications, charms,
+// config and relations in a model at a point in time.
+type ModelCheckpoint struct {
+	Name         string                       `json:"name"`
+	Created      time.Time                    `json:"created"`
+	Applications []ModelCheckpointApplication `json:"applications"`
+
+	// Relations holds the keys of the relations in the model.
+	Relations []string `json:"relations"`
+}
+
+// ModelCheckpointResult holds a model checkpoint or an error.
+type ModelCheckpointResult struct {
+	Result *ModelCheckpoint `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// ModelCheckpointResults holds the results of an API call that
+// returns model checkpoints.
+type ModelCheckpointResults struct {
+	Results []ModelCheckpointResult `json:"results"`
+}
+
+// CheckpointCharmChange records an application whose charm has
+// changed since a checkpoint was created.
+type CheckpointCharmChange struct {
+	Application        string `json:"application"`
+	CheckpointCharmURL string `json:"checkpoint-charm-url"`
+	CurrentCharmURL    string `json:"current-charm-url"`
+}
+
+// CheckpointConfigChange records an application config value that
+// has changed since a checkpoint was created. A nil value means that
+// the key was not set by the user.
+type CheckpointConfigChange struct {
+	Application     string      `json:"application"`
+	Key             string      `json:"key"`
+	CheckpointValue interface{} `json:"checkpoint-value,omitempty"`
+	CurrentValue    interface{} `json:"current-value,omitempty"`
+}
+
+// ModelCheckpointDiff describes how a model has changed since a
+// checkpoint was created.
+type ModelCheckpointDiff struct {
+	AddedApplications   []string                 `json:"added-applications,omitempty"`
+	RemovedApplications []string                 `json:"removed-applications,omitempty"`
+	ChangedCharms       []CheckpointCharmChange  `json:"changed-charms,omitempty"`
+	ChangedConfig       []CheckpointConfigChange `json:"changed-config,omitempty"`
+	AddedRelations      []string                 `json:"added-relations,omitempty"`
+	RemovedRelations    []string                 `json:"removed-relations,omitempty"`
+}
+
+// ModelCheckpointDiffResult holds the difference between a model and
+// one of its checkpoints, or an error.
+type ModelCheckpointDiffResult struct {
+	Result *ModelCheckpointDiff `json:"result,omitempty"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// ModelCheckpointDiffResults holds the results of the
+// DiffCheckpoints call.
+type ModelCheckpointDiffResults struct {
+	Results []ModelCheckpointDiffResult `json:"results"`
+}
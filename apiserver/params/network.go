@@ -632,6 +632,70 @@ type ListSpacesResults struct {
 	Results []Space `json:"results"`
 }
 
+// ReloadSpacesResult holds the result of reloading a model's spaces
+// and subnets from its provider, and how they had drifted.
+type ReloadSpacesResult struct {
+	AddedSpaces    []string `json:"added-spaces,omitempty"`
+	AddedSubnets   []string `json:"added-subnets,omitempty"`
+	MissingSpaces  []string `json:"missing-spaces,omitempty"`
+	MissingSubnets []string `json:"missing-subnets,omitempty"`
+	Error          *Error   `json:"error,omitempty"`
+}
+
+// RenameSpacesParams holds the arguments of the RenameSpaces API call.
+type RenameSpacesParams struct {
+	Changes []RenameSpaceParams `json:"changes"`
+}
+
+// RenameSpaceParams holds the tags of a space and its new name. When
+// DryRun is set, the impact of the rename is reported without making
+// any changes.
+type RenameSpaceParams struct {
+	FromSpaceTag string `json:"from-space-tag"`
+	ToSpaceTag   string `json:"to-space-tag"`
+	DryRun       bool   `json:"dry-run,omitempty"`
+}
+
+// MoveSubnetsParams holds the arguments of the MoveSubnets API call.
+type MoveSubnetsParams struct {
+	Args []MoveSubnetParams `json:"args"`
+}
+
+// MoveSubnetParams holds the tags of a subnet and the space it should
+// be moved to. The move is refused if it would leave machines without
+// an address in a space they need, unless Force is set. When DryRun is
+// set, the impact of the move is reported without making any changes.
+type MoveSubnetParams struct {
+	SubnetTag string `json:"subnet-tag"`
+	SpaceTag  string `json:"space-tag"`
+	Force     bool   `json:"force,omitempty"`
+	DryRun    bool   `json:"dry-run,omitempty"`
+}
+
+// SpaceChangeResult holds the impact of renaming a space or moving a
+// subnet between spaces.
+type SpaceChangeResult struct {
+	// ApplicationBindings maps the applications with endpoints bound
+	// to the affected space to the names of those endpoints.
+	ApplicationBindings map[string][]string `json:"application-bindings,omitempty"`
+
+	// ApplicationConstraints holds the names of the applications
+	// whose constraints refer to the affected space.
+	ApplicationConstraints []string `json:"application-constraints,omitempty"`
+
+	// Machines holds the IDs of the machines that would be left
+	// without an address in a space they need.
+	Machines []string `json:"machines,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// SpaceChangeResults holds the results of the RenameSpaces and
+// MoveSubnets API calls.
+type SpaceChangeResults struct {
+	Results []SpaceChangeResult `json:"results"`
+}
+
 // Space holds the information about a single space and its associated subnets.
 type Space struct {
 	Name    string   `json:"name"`
@@ -716,3 +780,37 @@ type FanConfigEntry struct {
 type FanConfigResult struct {
 	Fans []FanConfigEntry `json:"fans"`
 }
+
+// FanNetworkingConfig holds the FAN configuration of a model along with
+// the method used to set up container networking.
+type FanNetworkingConfig struct {
+	Fans                      []FanConfigEntry `json:"fans"`
+	ContainerNetworkingMethod string           `json:"container-networking-method"`
+}
+
+// SetFanNetworkingArgs holds the FAN networking configuration to set on
+// a model. If DryRun is true the configuration is only validated and
+// the resulting overlay segments are reported.
+type SetFanNetworkingArgs struct {
+	Config FanNetworkingConfig `json:"config"`
+	DryRun bool                `json:"dry-run"`
+}
+
+// FanOverlaySegment maps a subnet a machine is connected to onto the
+// segment of a FAN overlay its containers are addressed from.
+type FanOverlaySegment struct {
+	Subnet  string `json:"subnet"`
+	Overlay string `json:"overlay"`
+}
+
+// MachineFanOverlays holds the FAN overlay segments of a machine.
+type MachineFanOverlays struct {
+	MachineTag string              `json:"machine-tag"`
+	Segments   []FanOverlaySegment `json:"segments"`
+}
+
+// SetFanNetworkingResult holds the FAN overlay segments computed for
+// each machine in the model.
+type SetFanNetworkingResult struct {
+	Machines []MachineFanOverlays `json:"machines"`
+}
@@ -333,6 +333,24 @@ type ApplicationSetCharm struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]StorageConstraints `json:"storage-constraints,omitempty"`
+
+	// RollingUpgrade, if set, upgrades the application's units in
+	// batches rather than all at once. This field is only understood
+	// by Application facade version 8 and greater.
+	RollingUpgrade *RollingCharmUpgrade `json:"rolling-upgrade,omitempty"`
+}
+
+// RollingCharmUpgrade holds the parameters for upgrading an
+// application's units to a new charm in batches.
+type RollingCharmUpgrade struct {
+	// BatchSize is the number of units upgraded at the same time.
+	BatchSize int `json:"batch-size"`
+
+	// Readiness is "workload-active", the default, to wait for the
+	// units of a batch to report an active workload status before
+	// starting the next; or "action:<name>" to wait for the named
+	// action to complete on each of them.
+	Readiness string `json:"readiness,omitempty"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
@@ -345,6 +363,10 @@ type ApplicationExpose struct {
 type ApplicationSet struct {
 	ApplicationName string            `json:"application"`
 	Options         map[string]string `json:"options"`
+
+	// BranchName, if set, stages the changes in the named branch
+	// rather than applying them to all of the application's units.
+	BranchName string `json:"branch,omitempty"`
 }
 
 // ApplicationUnset holds the parameters for an application Unset
@@ -353,12 +375,20 @@ type ApplicationSet struct {
 type ApplicationUnset struct {
 	ApplicationName string   `json:"application"`
 	Options         []string `json:"options"`
+
+	// BranchName, if set, stages the changes in the named branch
+	// rather than applying them to all of the application's units.
+	BranchName string `json:"branch,omitempty"`
 }
 
 // ApplicationGet holds parameters for making the Get or
 // GetCharmURL calls.
 type ApplicationGet struct {
 	ApplicationName string `json:"application"`
+
+	// BranchName, if set, makes Get return the config seen by the
+	// units assigned to the named branch.
+	BranchName string `json:"branch,omitempty"`
 }
 
 // ApplicationGetResults holds results of the application Get call.
@@ -396,13 +426,321 @@ type ApplicationMetricCredentials struct {
 	Creds []ApplicationMetricCredential `json:"creds"`
 }
 
+// ApplicationStopGracePeriod holds the stop grace period of an
+// application.
+type ApplicationStopGracePeriod struct {
+	ApplicationTag  string        `json:"application-tag"`
+	StopGracePeriod time.Duration `json:"stop-grace-period"`
+}
+
+// ApplicationStopGracePeriods holds the arguments for the
+// Application.SetStopGracePeriods call.
+type ApplicationStopGracePeriods struct {
+	Args []ApplicationStopGracePeriod `json:"args"`
+}
+
+// ApplicationLabels holds the labels of an application.
+type ApplicationLabels struct {
+	ApplicationTag string            `json:"application-tag"`
+	Labels         map[string]string `json:"labels"`
+}
+
+// ApplicationLabelsArgs holds the arguments for the
+// Application.SetLabels call.
+type ApplicationLabelsArgs struct {
+	Args []ApplicationLabels `json:"args"`
+}
+
+// ApplicationLabelsResult holds the labels of an application, or an
+// error.
+type ApplicationLabelsResult struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// ApplicationLabelsResults holds the results of the
+// Application.GetLabels call.
+type ApplicationLabelsResults struct {
+	Results []ApplicationLabelsResult `json:"results"`
+}
+
+// CharmUpgradeStatus describes an application's rolling charm
+// upgrade.
+type CharmUpgradeStatus struct {
+	PreviousCharmURL string   `json:"previous-charm-url"`
+	BatchSize        int      `json:"batch-size"`
+	Readiness        string   `json:"readiness"`
+	Status           string   `json:"status"`
+	Message          string   `json:"message,omitempty"`
+	Pending          []string `json:"pending"`
+	Upgrading        []string `json:"upgrading"`
+	Upgraded         []string `json:"upgraded"`
+}
+
+// CharmUpgradeStatusResult holds an application's rolling charm
+// upgrade, or an error.
+type CharmUpgradeStatusResult struct {
+	Result *CharmUpgradeStatus `json:"result,omitempty"`
+	Error  *Error              `json:"error,omitempty"`
+}
+
+// CharmUpgradeStatusResults holds the results of the
+// Application.CharmUpgradeStatus call.
+type CharmUpgradeStatusResults struct {
+	Results []CharmUpgradeStatusResult `json:"results"`
+}
+
+// ApplicationCharmHistoryArgs holds the arguments for the
+// Application.CharmHistory call.
+type ApplicationCharmHistoryArgs struct {
+	Args []ApplicationCharmHistoryArg `json:"args"`
+}
+
+// ApplicationCharmHistoryArg identifies an application whose charm
+// history is wanted. Limit, if positive, restricts the result to that
+// many of the most recent entries.
+type ApplicationCharmHistoryArg struct {
+	Entity
+	Limit int `json:"limit,omitempty"`
+}
+
+// CharmHistoryEntry records the charm and config of an application
+// after a change by a user.
+type CharmHistoryEntry struct {
+	CharmURL      string                 `json:"charm-url"`
+	ConfigVersion int64                  `json:"config-version"`
+	Config        map[string]interface{} `json:"config,omitempty"`
+	User          string                 `json:"user,omitempty"`
+	Time          time.Time              `json:"time"`
+}
+
+// CharmHistoryResult holds the charm history of an application,
+// newest first, or an error.
+type CharmHistoryResult struct {
+	Entries []CharmHistoryEntry `json:"entries,omitempty"`
+	Error   *Error              `json:"error,omitempty"`
+}
+
+// CharmHistoryResults holds the results of the
+// Application.CharmHistory call.
+type CharmHistoryResults struct {
+	Results []CharmHistoryResult `json:"results"`
+}
+
+// BranchArgs holds the arguments for the Application calls that act
+// on whole branches.
+type BranchArgs struct {
+	Branches []BranchArg `json:"branches"`
+}
+
+// BranchArg identifies a branch of application config changes.
+type BranchArg struct {
+	BranchName string `json:"branch"`
+}
+
+// BranchTrackArg holds the arguments for the Application.TrackBranch
+// call, which assigns the given units to a branch.
+type BranchTrackArg struct {
+	BranchName string   `json:"branch"`
+	Entities   []Entity `json:"entities"`
+}
+
+// BranchInfo describes a branch of application config changes. Config
+// and AssignedUnits are keyed by application name.
+type BranchInfo struct {
+	Name          string                            `json:"name"`
+	CreatedBy     string                            `json:"created-by,omitempty"`
+	Created       time.Time                         `json:"created"`
+	Config        map[string]map[string]interface{} `json:"config,omitempty"`
+	AssignedUnits map[string][]string               `json:"assigned-units,omitempty"`
+}
+
+// BranchResult holds a branch or an error.
+type BranchResult struct {
+	Result *BranchInfo `json:"result,omitempty"`
+	Error  *Error      `json:"error,omitempty"`
+}
+
+// BranchResults holds the results of the Application.BranchInfo call.
+type BranchResults struct {
+	Results []BranchResult `json:"results"`
+}
+
+// ScaleApplicationsParams holds the arguments for the
+// Application.ScaleApplications call.
+type ScaleApplicationsParams struct {
+	Applications []ScaleApplicationParams `json:"applications"`
+}
+
+// ScaleApplicationParams sets the number of pods an application runs
+// in a Kubernetes model. If ScaleChange is non-zero, the scale is
+// changed by that amount; otherwise it is set to Scale.
+type ScaleApplicationParams struct {
+	ApplicationTag string `json:"application-tag"`
+	Scale          int    `json:"scale"`
+	ScaleChange    int    `json:"scale-change,omitempty"`
+}
+
+// ScaleApplicationResult holds the new scale of an application, or an
+// error.
+type ScaleApplicationResult struct {
+	Scale int    `json:"scale"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// ScaleApplicationResults holds the results of the
+// Application.ScaleApplications call.
+type ScaleApplicationResults struct {
+	Results []ScaleApplicationResult `json:"results"`
+}
+
+// SetPodSpecParams holds the arguments for the Application.SetPodSpec
+// call.
+type SetPodSpecParams struct {
+	Specs []EntityPodSpec `json:"specs"`
+}
+
+// EntityPodSpec holds the Kubernetes pod spec, as YAML, to set for
+// the application with the given tag.
+type EntityPodSpec struct {
+	Tag  string `json:"tag"`
+	Spec string `json:"spec"`
+}
+
+// PodSpec records a revision of the Kubernetes pod spec of an
+// application.
+type PodSpec struct {
+	Spec     string    `json:"spec"`
+	Revision int       `json:"revision"`
+	User     string    `json:"user,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// PodSpecResult holds the current pod spec of an application, or an
+// error.
+type PodSpecResult struct {
+	Result *PodSpec `json:"result,omitempty"`
+	Error  *Error   `json:"error,omitempty"`
+}
+
+// PodSpecResults holds the results of the Application.GetPodSpec call.
+type PodSpecResults struct {
+	Results []PodSpecResult `json:"results"`
+}
+
+// PodSpecHistoryArgs holds the arguments for the
+// Application.PodSpecHistory call.
+type PodSpecHistoryArgs struct {
+	Args []PodSpecHistoryArg `json:"args"`
+}
+
+// PodSpecHistoryArg identifies an application whose pod spec history
+// is wanted. Limit, if positive, restricts the result to that many of
+// the most recent revisions.
+type PodSpecHistoryArg struct {
+	Entity
+	Limit int `json:"limit,omitempty"`
+}
+
+// PodSpecHistoryResult holds the pod spec revisions of an
+// application, newest first, or an error.
+type PodSpecHistoryResult struct {
+	Revisions []PodSpec `json:"revisions,omitempty"`
+	Error     *Error    `json:"error,omitempty"`
+}
+
+// PodSpecHistoryResults holds the results of the
+// Application.PodSpecHistory call.
+type PodSpecHistoryResults struct {
+	Results []PodSpecHistoryResult `json:"results"`
+}
+
+// RolloutStatus describes the rollout of the current revision of an
+// application's pod spec. State is one of "pending", "progressing",
+// "complete" or "failed".
+type RolloutStatus struct {
+	Revision int       `json:"revision"`
+	State    string    `json:"state"`
+	Message  string    `json:"message,omitempty"`
+	Since    time.Time `json:"since"`
+}
+
+// RolloutStatusResult holds the rollout status of an application, or
+// an error.
+type RolloutStatusResult struct {
+	Result *RolloutStatus `json:"result,omitempty"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// RolloutStatusResults holds the results of the
+// Application.RolloutStatus call.
+type RolloutStatusResults struct {
+	Results []RolloutStatusResult `json:"results"`
+}
+
+// UnitContainerStatus holds the status of the container running a
+// unit's workload in a Kubernetes model.
+type UnitContainerStatus struct {
+	UnitTag string       `json:"unit-tag"`
+	Status  EntityStatus `json:"status"`
+}
+
+// UnitContainerStatusesResult holds the container statuses of an
+// application's units, or an error. Units whose container status has
+// not been reported are omitted.
+type UnitContainerStatusesResult struct {
+	Units []UnitContainerStatus `json:"units,omitempty"`
+	Error *Error                `json:"error,omitempty"`
+}
+
+// UnitContainerStatusesResults holds the results of the
+// Application.UnitContainerStatuses call.
+type UnitContainerStatusesResults struct {
+	Results []UnitContainerStatusesResult `json:"results"`
+}
+
+// ConfigOptionSchema describes a single charm config option. Type is
+// one of "string", "int", "float" or "boolean", and values set for the
+// option must be of that type.
+type ConfigOptionSchema struct {
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+}
+
+// ApplicationConfigSchemaResult holds the config schema of an
+// application's charm, keyed by option name, or an error.
+type ApplicationConfigSchemaResult struct {
+	Schema map[string]ConfigOptionSchema `json:"schema,omitempty"`
+	Error  *Error                        `json:"error,omitempty"`
+}
+
+// ApplicationConfigSchemaResults holds the results of the
+// Application.GetConfigSchema call.
+type ApplicationConfigSchemaResults struct {
+	Results []ApplicationConfigSchemaResult `json:"results"`
+}
+
 // ApplicationGetConfigResults holds the return values for application GetConfig.
 type ApplicationGetConfigResults struct {
 	Results []ConfigResult
 }
 
+// ApplicationGetConfigArgs holds the arguments for the
+// Application.GetConfig call.
+type ApplicationGetConfigArgs struct {
+	Entities []Entity `json:"entities"`
+
+	// Selector, if set, selects further applications by label.
+	// Their results follow those of Entities.
+	Selector string `json:"selector,omitempty"`
+}
+
 // ConfigResults holds configuration values for an entity.
 type ConfigResult struct {
+	// Tag identifies the entity for results of entities that were
+	// selected by label.
+	Tag    string                 `json:"tag,omitempty"`
 	Config map[string]interface{} `json:"config"`
 	Error  *Error                 `json:"error,omitempty"`
 }
@@ -473,6 +811,12 @@ type DestroyUnitParams struct {
 	// DestroyStorage controls whether or not storage
 	// attached to the unit should be destroyed.
 	DestroyStorage bool `json:"destroy-storage,omitempty"`
+
+	// Force controls whether or not the application's stop grace
+	// period is overridden, so that the unit's machine is torn down
+	// without waiting for its stop hook. Only known by facade
+	// version 12 and greater.
+	Force bool `json:"force,omitempty"`
 }
 
 // ApplicationDestroy holds the parameters for making the deprecated
@@ -485,6 +829,15 @@ type ApplicationDestroy struct {
 // Application.DestroyApplication call.
 type DestroyApplicationsParams struct {
 	Applications []DestroyApplicationParams `json:"applications"`
+
+	// Selector, if set, selects further applications to destroy by
+	// label. Their results follow those of Applications.
+	Selector string `json:"selector,omitempty"`
+
+	// DestroyStorage controls whether or not storage attached to
+	// units of the applications selected by label should be
+	// destroyed.
+	DestroyStorage bool `json:"destroy-storage,omitempty"`
 }
 
 // DestroyApplicationParams holds parameters for the
@@ -569,11 +922,20 @@ type ApplicationConstraint struct {
 type SetConstraints struct {
 	ApplicationName string            `json:"application"` //optional, if empty, model constraints are set.
 	Constraints     constraints.Value `json:"constraints"`
+
+	// Selector, if set instead of ApplicationName, sets the
+	// constraints of all applications selected by label.
+	Selector string `json:"selector,omitempty"`
 }
 
 // ResolveCharms stores charm references for a ResolveCharms call.
 type ResolveCharms struct {
 	References []string `json:"references"`
+
+	// Channel is the charm store channel in which to resolve the
+	// references, e.g. "8.0/stable". If it is empty, the charm store's
+	// default channel is used.
+	Channel string `json:"channel,omitempty"`
 }
 
 // ResolveCharmResult holds the result of resolving a charm reference to a URL, or any error that occurred.
@@ -803,6 +1165,11 @@ type LoginResult struct {
 	// ServerVersion is the string representation of the server version
 	// if the server supports it.
 	ServerVersion string `json:"server-version,omitempty"`
+
+	// SupportsIdempotencyKeys reports whether the server runs requests
+	// carrying an idempotency key at most once, so that clients may
+	// safely resend them after reconnecting.
+	SupportsIdempotencyKeys bool `json:"supports-idempotency-keys,omitempty"`
 }
 
 // ControllersServersSpec contains arguments for
@@ -961,6 +1328,83 @@ type BundleChange struct {
 	Requires []string `json:"requires"`
 }
 
+// BundlePlanParams holds parameters for making the Bundle.PlanBundle
+// call.
+type BundlePlanParams struct {
+	// BundleDataYAML is the YAML-encoded charm bundle data
+	// (see "github.com/juju/charm.BundleData").
+	BundleDataYAML string `json:"yaml"`
+	// MapMachines is true if the bundle's machines would be mapped
+	// to the model's machines with the same ids, rather than added.
+	MapMachines bool `json:"map-machines,omitempty"`
+}
+
+// BundlePlanResults holds results of the Bundle.PlanBundle call.
+type BundlePlanResults struct {
+	// Plan holds the changes that deploying the bundle would make to
+	// the model. It is omitted if the provided bundle YAML has
+	// verification errors.
+	Plan *BundlePlan `json:"plan,omitempty"`
+	// Errors holds possible bundle verification errors.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// BundlePlan describes the changes that deploying a bundle would make
+// to a model.
+type BundlePlan struct {
+	// AddApplications holds the applications that would be deployed.
+	AddApplications []BundlePlanApplication `json:"add-applications,omitempty"`
+	// ChangeCharms holds the existing applications whose charm differs
+	// from the one in the bundle.
+	ChangeCharms []BundlePlanCharmChange `json:"change-charms,omitempty"`
+	// ChangeConfig holds the config values that would be changed on
+	// existing applications.
+	ChangeConfig []BundlePlanConfigChange `json:"change-config,omitempty"`
+	// AddUnits holds the units that would be added to existing
+	// applications.
+	AddUnits []BundlePlanUnits `json:"add-units,omitempty"`
+	// AddRelations holds the relations that would be created, as
+	// pairs of endpoints.
+	AddRelations [][]string `json:"add-relations,omitempty"`
+	// AddMachines holds the ids of the bundle machines that would be
+	// added. Unless the machines are mapped, every bundle machine is
+	// added.
+	AddMachines []string `json:"add-machines,omitempty"`
+}
+
+// BundlePlanApplication holds an application that would be deployed
+// from a bundle.
+type BundlePlanApplication struct {
+	Name     string `json:"name"`
+	Charm    string `json:"charm"`
+	NumUnits int    `json:"num-units"`
+}
+
+// BundlePlanCharmChange holds an existing application whose charm
+// differs from the one in a bundle.
+type BundlePlanCharmChange struct {
+	Application  string `json:"application"`
+	CurrentCharm string `json:"current-charm"`
+	BundleCharm  string `json:"bundle-charm"`
+}
+
+// BundlePlanConfigChange holds an existing application's config value
+// that would be changed by a bundle. A nil current value means that
+// the key is not set by the user.
+type BundlePlanConfigChange struct {
+	Application string      `json:"application"`
+	Key         string      `json:"key"`
+	Current     interface{} `json:"current,omitempty"`
+	Bundle      interface{} `json:"bundle"`
+}
+
+// BundlePlanUnits holds the number of units that would be added to an
+// existing application by a bundle.
+type BundlePlanUnits struct {
+	Application string `json:"application"`
+	Count       int    `json:"count"`
+}
+
 type MongoVersion struct {
 	Major         int    `json:"major"`
 	Minor         int    `json:"minor"`
@@ -1059,8 +1503,11 @@ type DestroyApplicationResults struct {
 // DestroyApplicationResult contains one of the results of a
 // DestroyApplication API request.
 type DestroyApplicationResult struct {
-	Error *Error                  `json:"error,omitempty"`
-	Info  *DestroyApplicationInfo `json:"info,omitempty"`
+	// ApplicationTag identifies the application for results of
+	// applications that were selected by label.
+	ApplicationTag string                  `json:"application-tag,omitempty"`
+	Error          *Error                  `json:"error,omitempty"`
+	Info           *DestroyApplicationInfo `json:"info,omitempty"`
 }
 
 // DestroyApplicationInfo contains information related to the removal of
@@ -1103,6 +1550,14 @@ type DestroyUnitInfo struct {
 	// DestroyedStorage is the tags of storage instances that will be
 	// destroyed as a result of destroying the unit.
 	DestroyedStorage []Entity `json:"destroyed-storage,omitempty"`
+
+	// StopGracePeriod is how long the unit will be given to run its
+	// stop hook before its machine is torn down regardless.
+	StopGracePeriod time.Duration `json:"stop-grace-period,omitempty"`
+
+	// StopGracePeriodOverridden reports whether the application's
+	// stop grace period was overridden by forcing the removal.
+	StopGracePeriodOverridden bool `json:"stop-grace-period-overridden,omitempty"`
 }
 
 // DumpModelRequest wraps the request for a dump-model call.
@@ -38,4 +38,8 @@ type Payload struct {
 
 	// Machine identifies the machine tag associated with the payload.
 	Machine string `json:"machine"`
+
+	// Restarts is the number of times the payload has been started
+	// again after stopping.
+	Restarts int `json:"restarts,omitempty"`
 }
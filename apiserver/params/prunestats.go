@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// PruneStats describes the most recent pruning of one of a model's
+// collections.
+type PruneStats struct {
+	// Collection is the name of the pruned collection.
+	Collection string `json:"collection"`
+
+	// Time is when the collection was last pruned.
+	Time time.Time `json:"time"`
+
+	// Removed is the number of entries removed.
+	Removed int `json:"removed"`
+
+	// ReclaimedMB is the amount of space, in MiB, freed by
+	// removing the entries.
+	ReclaimedMB int `json:"reclaimed-mb"`
+}
+
+// PruneStatsResult holds the result of the PruneStats call.
+type PruneStatsResult struct {
+	Stats []PruneStats `json:"stats"`
+}
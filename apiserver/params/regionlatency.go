@@ -0,0 +1,45 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// RegionLatency holds the measured latency of a cloud region's API
+// endpoint, as seen from the controller.
+type RegionLatency struct {
+	// Region is the name of the cloud region.
+	Region string `json:"region"`
+
+	// Latency is the time taken to reach the region's API endpoint.
+	// It is only valid if Error is nil.
+	Latency time.Duration `json:"latency"`
+
+	// Error holds the reason the region could not be probed, if any.
+	Error *Error `json:"error,omitempty"`
+}
+
+// RegionLatencyResult holds the region latencies of a cloud, or an
+// error.
+type RegionLatencyResult struct {
+	// Regions holds the latency of each of the cloud's regions.
+	Regions []RegionLatency `json:"regions,omitempty"`
+
+	// Nearest is the name of the reachable region with the lowest
+	// latency, if any region could be reached.
+	Nearest string `json:"nearest,omitempty"`
+
+	// ProbedAt is when the regions were probed. Results are cached
+	// by the controller, so this may be some time in the past.
+	ProbedAt time.Time `json:"probed-at"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// RegionLatencyResults holds the results of the
+// RegionLatency.RegionLatencies call.
+type RegionLatencyResults struct {
+	Results []RegionLatencyResult `json:"results"`
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// RemovalPlan describes everything that is removed, or affected, when
+// a set of entities is removed from a model. All lists are sorted.
+type RemovalPlan struct {
+	// Entities holds the tags of the applications, units and
+	// machines whose removal was requested.
+	Entities []Entity `json:"entities"`
+
+	// Applications holds the tags of the applications that
+	// will be removed.
+	Applications []Entity `json:"applications,omitempty"`
+
+	// Units holds the tags of the units, including subordinate
+	// units, that will be removed.
+	Units []Entity `json:"units,omitempty"`
+
+	// Machines holds the tags of the machines that will be
+	// removed, including those that would be left without
+	// any units.
+	Machines []Entity `json:"machines,omitempty"`
+
+	// DestroyedStorage holds the tags of the storage instances
+	// that will be destroyed.
+	DestroyedStorage []Entity `json:"destroyed-storage,omitempty"`
+
+	// DetachedStorage holds the tags of the storage instances
+	// that will be detached, and remain in the model.
+	DetachedStorage []Entity `json:"detached-storage,omitempty"`
+
+	// Relations holds the tags of the relations that will
+	// be removed.
+	Relations []Entity `json:"relations,omitempty"`
+
+	// Offers holds the names of the application offers that
+	// will be removed.
+	Offers []string `json:"offers,omitempty"`
+}
+
+// RemovalPlanResult holds a removal plan or an error.
+type RemovalPlanResult struct {
+	Result *RemovalPlan `json:"result,omitempty"`
+	Error  *Error       `json:"error,omitempty"`
+}
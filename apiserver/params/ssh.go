@@ -58,3 +58,37 @@ type SSHPublicKeysResult struct {
 	Error      *Error   `json:"error,omitempty"`
 	PublicKeys []string `json:"public-keys,omitempty"`
 }
+
+// SSHManualHosts identifies one or more manually provisioned hosts,
+// by the hostname or address used to provision them.
+type SSHManualHosts struct {
+	Hosts []string `json:"hosts"`
+}
+
+// SSHManualHostKeySet defines SSH host keys for one or more manually
+// provisioned hosts.
+type SSHManualHostKeySet struct {
+	HostKeys []SSHManualHostKeys `json:"host-keys"`
+}
+
+// SSHManualHostKeys defines the SSH host keys for one manually
+// provisioned host.
+type SSHManualHostKeys struct {
+	Host       string   `json:"host"`
+	PublicKeys []string `json:"public-keys"`
+}
+
+// SSHManualHostKeysResults is used to return the SSH host keys stored
+// for manually provisioned hosts by the SSHClient.ManualHostKeys and
+// SSHClient.ListManualHostKeys APIs.
+type SSHManualHostKeysResults struct {
+	Results []SSHManualHostKeysResult `json:"results"`
+}
+
+// SSHManualHostKeysResult is used to return the SSH host keys stored
+// for one manually provisioned host (see SSHManualHostKeysResults).
+type SSHManualHostKeysResult struct {
+	Error      *Error   `json:"error,omitempty"`
+	Host       string   `json:"host"`
+	PublicKeys []string `json:"public-keys,omitempty"`
+}
@@ -0,0 +1,17 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// SSHProxyConfig holds the information necessary to open a connection
+// to the SSH server of a machine, tunnelled through the controller
+// over a websocket.
+//
+// The field tags relate to the following 2 libraries:
+//   github.com/google/go-querystring/query (encoding)
+//   github.com/gorilla/schema (decoding)
+type SSHProxyConfig struct {
+	// Target is the tag of the machine, or of a unit on the machine,
+	// to connect to.
+	Target string `schema:"target" url:"target"`
+}
@@ -30,6 +30,45 @@ type FullStatus struct {
 	Relations          []RelationStatus                   `json:"relations"`
 }
 
+// StatusDeltaParams holds parameters for the FullStatusDelta call.
+type StatusDeltaParams struct {
+	Patterns []string `json:"patterns"`
+
+	// Since holds the Version of the status delta previously returned
+	// to the caller, if any.
+	Since string `json:"since,omitempty"`
+}
+
+// FullStatusDelta holds the changes to a model's status since a
+// previously returned version. Entities that have not changed are
+// omitted; entities that have changed or been added are included in
+// full, and entities that have gone are listed by name (or id, for
+// relations).
+type FullStatusDelta struct {
+	// Version identifies the status described once the delta has
+	// been applied. It is passed as Since to the next call.
+	Version string `json:"version"`
+
+	// Full reports whether the delta holds the complete status,
+	// because the Since version was not known to the server.
+	Full bool `json:"full,omitempty"`
+
+	// Model is set only when the model's status has changed.
+	Model *ModelStatusInfo `json:"model,omitempty"`
+
+	Machines           map[string]MachineStatus           `json:"machines,omitempty"`
+	Applications       map[string]ApplicationStatus       `json:"applications,omitempty"`
+	RemoteApplications map[string]RemoteApplicationStatus `json:"remote-applications,omitempty"`
+	Offers             map[string]ApplicationOfferStatus  `json:"offers,omitempty"`
+	Relations          []RelationStatus                   `json:"relations,omitempty"`
+
+	RemovedMachines           []string `json:"removed-machines,omitempty"`
+	RemovedApplications       []string `json:"removed-applications,omitempty"`
+	RemovedRemoteApplications []string `json:"removed-remote-applications,omitempty"`
+	RemovedOffers             []string `json:"removed-offers,omitempty"`
+	RemovedRelations          []int    `json:"removed-relations,omitempty"`
+}
+
 // ModelStatusInfo holds status information about the model itself.
 type ModelStatusInfo struct {
 	Name             string         `json:"name"`
@@ -162,6 +201,10 @@ type UnitStatus struct {
 	Charm         string                `json:"charm"`
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
 	Leader        bool                  `json:"leader,omitempty"`
+
+	// Payloads holds the payloads registered by the unit, including
+	// their reported status and restart counts.
+	Payloads []Payload `json:"payloads,omitempty"`
 }
 
 // RelationStatus holds status info about a relation.
@@ -238,6 +281,48 @@ type StatusHistoryResults struct {
 	Results []StatusHistoryResult `json:"results"`
 }
 
+// StatusHistoryQuery selects a page of an entity's status history
+// recorded within a time range.
+type StatusHistoryQuery struct {
+	Tag  string    `json:"tag"`
+	Kind string    `json:"kind"`
+	From time.Time `json:"from"`
+
+	// To, if set, is the time before which all entries are expected.
+	To *time.Time `json:"to,omitempty"`
+
+	// After holds the cursor returned with the previous page, if any.
+	After string `json:"after,omitempty"`
+
+	// Limit is the maximum number of entries expected. If it is zero,
+	// a default limit applies.
+	Limit int `json:"limit,omitempty"`
+}
+
+// StatusHistoryQueries holds a slice of StatusHistoryQuery.
+type StatusHistoryQueries struct {
+	Queries []StatusHistoryQuery `json:"queries"`
+}
+
+// StatusHistoryPage holds a page of status history entries, oldest
+// first, and the cursor from which the following page can be
+// selected. Next is empty if there are no more entries in the range.
+type StatusHistoryPage struct {
+	Statuses []DetailedStatus `json:"statuses"`
+	Next     string           `json:"next,omitempty"`
+}
+
+// StatusHistoryPageResult holds a page of status history or an error.
+type StatusHistoryPageResult struct {
+	Page  StatusHistoryPage `json:"page"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// StatusHistoryPageResults holds a slice of StatusHistoryPageResult.
+type StatusHistoryPageResults struct {
+	Results []StatusHistoryPageResult `json:"results"`
+}
+
 // StatusHistoryPruneArgs holds arguments for status history
 // prunning process.
 type StatusHistoryPruneArgs struct {
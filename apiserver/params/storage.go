@@ -232,6 +232,18 @@ type RemoveVolumeParams struct {
 	Destroy bool `json:"destroy,omitempty"`
 }
 
+// ResizeVolumeParams holds the parameters for growing a storage volume.
+type ResizeVolumeParams struct {
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Size is the size, in MiB, that the volume should be grown to.
+	Size uint64 `json:"size"`
+}
+
 // VolumeAttachmentParams holds the parameters for creating a volume
 // attachment.
 type VolumeAttachmentParams struct {
@@ -301,6 +313,17 @@ type RemoveVolumeParamsResults struct {
 	Results []RemoveVolumeParamsResult `json:"results,omitempty"`
 }
 
+// ResizeVolumeParamsResult holds parameters for growing a volume.
+type ResizeVolumeParamsResult struct {
+	Result ResizeVolumeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// ResizeVolumeParamsResults holds parameters for growing multiple volumes.
+type ResizeVolumeParamsResults struct {
+	Results []ResizeVolumeParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -482,6 +505,46 @@ type StorageDetails struct {
 	Attachments map[string]StorageAttachmentDetails `json:"attachments,omitempty"`
 }
 
+// DetachedStorageDetails holds information about a storage instance
+// that is not attached to any unit, and so may be attached to a new
+// or existing unit for reuse.
+type DetachedStorageDetails struct {
+	// StorageTag holds the tag of the storage instance.
+	StorageTag string `json:"storage-tag"`
+
+	// StorageName holds the name of the storage, as declared
+	// by the charm that the storage was created for.
+	StorageName string `json:"storage-name"`
+
+	// Kind holds what kind of storage this instance is.
+	Kind StorageKind `json:"kind"`
+
+	// Pool holds the name of the storage pool that the
+	// storage instance was provisioned from.
+	Pool string `json:"pool"`
+
+	// Size holds the size of the storage in MiB, if known.
+	Size uint64 `json:"size,omitempty"`
+
+	// LastApplication holds the name of the application whose
+	// unit last owned the storage, if any.
+	LastApplication string `json:"last-application,omitempty"`
+
+	// LastUnitTag holds the tag of the unit that last owned
+	// the storage, if any.
+	LastUnitTag string `json:"last-unit-tag,omitempty"`
+
+	// FilesystemLabel holds the label of the filesystem on the
+	// storage, as last observed while it was attached, if any.
+	FilesystemLabel string `json:"filesystem-label,omitempty"`
+}
+
+// DetachedStorageResults holds the result of the ListDetachedStorage
+// API call.
+type DetachedStorageResults struct {
+	Results []DetachedStorageDetails `json:"results"`
+}
+
 // StorageFilter holds filter terms for listing storage details.
 type StorageFilter struct {
 	// We don't currently implement any filters. This exists to get the
@@ -549,6 +612,21 @@ type StoragePool struct {
 	Attrs map[string]interface{} `json:"attrs"`
 }
 
+// StoragePoolArgs holds a collection of storage pools.
+type StoragePoolArgs struct {
+	Pools []StoragePool `json:"pools"`
+}
+
+// StoragePoolDeleteArg holds the name of a storage pool to delete.
+type StoragePoolDeleteArg struct {
+	Name string `json:"name"`
+}
+
+// StoragePoolDeleteArgs holds a collection of storage pools to delete.
+type StoragePoolDeleteArgs struct {
+	Pools []StoragePoolDeleteArg `json:"pools"`
+}
+
 // StoragePoolFilter holds a filter for matching storage pools.
 type StoragePoolFilter struct {
 	// Names are pool's names to filter on.
@@ -574,6 +652,35 @@ type StoragePoolsResults struct {
 	Results []StoragePoolsResult `json:"results,omitempty"`
 }
 
+// CloudStoragePool holds a default storage pool for a cloud, which
+// is inherited by all models on the cloud.
+type CloudStoragePool struct {
+	// CloudTag is the tag of the cloud.
+	CloudTag string `json:"cloud-tag"`
+
+	// Pool holds the pool's name, provider and attributes.
+	Pool StoragePool `json:"pool"`
+}
+
+// CloudStoragePools holds a collection of cloud storage pools.
+type CloudStoragePools struct {
+	Pools []CloudStoragePool `json:"pools"`
+}
+
+// CloudStoragePoolName identifies a default storage pool of a cloud.
+type CloudStoragePoolName struct {
+	// CloudTag is the tag of the cloud.
+	CloudTag string `json:"cloud-tag"`
+
+	// Name is the pool's name.
+	Name string `json:"name"`
+}
+
+// CloudStoragePoolNames holds a collection of cloud storage pool names.
+type CloudStoragePoolNames struct {
+	Pools []CloudStoragePoolName `json:"pools"`
+}
+
 // VolumeFilter holds a filter for volume list API call.
 type VolumeFilter struct {
 	// Machines are machine tags to filter on.
@@ -805,6 +912,23 @@ type RemoveStorageInstance struct {
 	DestroyStorage bool `json:"destroy-storage,omitempty"`
 }
 
+// ResizeStorage holds the parameters for resizing storage in the model.
+type ResizeStorage struct {
+	Storage []ResizeStorageInstance `json:"storage"`
+}
+
+// ResizeStorageInstance holds the parameters for resizing a storage
+// instance.
+type ResizeStorageInstance struct {
+	// Tag is the tag of the storage instance to be resized.
+	Tag string `json:"tag"`
+
+	// Size is the new size of the storage instance, in MiB. Storage
+	// may only be grown, so the size must be larger than the current
+	// size.
+	Size uint64 `json:"size"`
+}
+
 // BulkImportStorageParams contains the parameters for importing a collection
 // of storage entities.
 type BulkImportStorageParams struct {
@@ -0,0 +1,31 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// WaitForParams holds the arguments for the WaitFor call.
+type WaitForParams struct {
+	// Condition is the condition to wait for, in the form accepted by
+	// core/condition.Parse.
+	Condition string `json:"condition"`
+
+	// Timeout is the longest the call should wait for the condition
+	// to be satisfied. The controller may wait for less time.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// WaitForResult holds the result of the WaitFor call.
+type WaitForResult struct {
+	// Satisfied is true if the condition was satisfied.
+	Satisfied bool `json:"satisfied"`
+
+	// Unsatisfied holds the terms of the condition that were not
+	// satisfied when the call returned.
+	Unsatisfied []string `json:"unsatisfied,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
@@ -16,11 +16,14 @@ import (
 var controllerFacadeNames = set.NewStrings(
 	"AllModelWatcher",
 	"ApplicationOffers",
+	"AuditLog",
 	"Cloud",
 	"Controller",
+	"ControllerHealth",
 	"CrossController",
 	"MigrationTarget",
 	"ModelManager",
+	"RegionLatency",
 	"UserManager",
 )
 
@@ -34,6 +34,9 @@ func (s *restrictControllerSuite) TestAllowed(c *gc.C) {
 	s.assertMethod(c, "Bundle", 1, "GetChanges")
 	s.assertMethod(c, "HighAvailability", 2, "EnableHA")
 	s.assertMethod(c, "ApplicationOffers", 1, "ApplicationOffers")
+	s.assertMethod(c, "AuditLog", 1, "Events")
+	s.assertMethod(c, "RegionLatency", 1, "RegionLatencies")
+	s.assertMethod(c, "ControllerHealth", 1, "Health")
 }
 
 func (s *restrictControllerSuite) TestNotAllowed(c *gc.C) {
@@ -28,7 +28,8 @@ func IsMethodAllowedDuringMigration(facadeName, methodName string) bool {
 // logins during the migration of the model from one controller to another.
 var allowedMethodsDuringMigration = map[string]set.Strings{
 	"Client": set.NewStrings(
-		"FullStatus", // for "juju status"
+		"FullStatus",      // for "juju status"
+		"FullStatusDelta", // for status polling
 	),
 	"SSHClient": set.NewStrings( // allow all SSH client related calls
 		"PublicAddress",
@@ -33,6 +33,7 @@ func IsMethodAllowedDuringUpgrade(facadeName, methodName string) bool {
 var allowedMethodsDuringUpgrades = map[string]set.Strings{
 	"Client": set.NewStrings(
 		"FullStatus",          // for "juju status"
+		"FullStatusDelta",     // for status polling
 		"FindTools",           // for "juju upgrade-juju", before we can reset upgrade to re-run
 		"AbortCurrentUpgrade", // for "juju upgrade-juju", so that we can reset upgrade to re-run
 
@@ -33,6 +33,11 @@ var (
 	// alive. When the ping returns an error, the server will be
 	// terminated.
 	mongoPingInterval = 10 * time.Second
+
+	// apiServerLoadInterval defines the interval at which an API
+	// server running on a controller machine records its load in
+	// state, for the ControllerHealth facade to report.
+	apiServerLoadInterval = time.Minute
 )
 
 type objectKey struct {
@@ -7,6 +7,8 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"time"
@@ -349,6 +351,64 @@ func (s *serverSuite) TestNonCompatiblePathsAre404(c *gc.C) {
 	c.Assert(conn, gc.IsNil)
 }
 
+func (s *serverSuite) TestIntrospectionListener(c *gc.C) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg := defaultServerConfig(c)
+	cfg.RegisterIntrospectionHandlers = func(f func(string, http.Handler)) {
+		f("/navel", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "gazing")
+		}))
+	}
+	cfg.IntrospectionConfig = &apiserver.IntrospectionConfig{
+		Listener: listener,
+		Username: "prometheus",
+		Password: "sekrit",
+	}
+	_, srv := newServerWithConfig(c, s.pool, cfg)
+	defer assertStop(c, srv)
+
+	pool := x509.NewCertPool()
+	xcert, err := cert.ParseCert(coretesting.CACert)
+	c.Assert(err, jc.ErrorIsNil)
+	pool.AddCert(xcert)
+	tlsConfig := utils.SecureTLSConfig()
+	tlsConfig.RootCAs = pool
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+
+	url := fmt.Sprintf("https://localhost:%d/navel", listener.Addr().(*net.TCPAddr).Port)
+	get := func(username, password string) *http.Response {
+		req, err := http.NewRequest("GET", url, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		if username != "" {
+			req.SetBasicAuth(username, password)
+		}
+		resp, err := client.Do(req)
+		c.Assert(err, jc.ErrorIsNil)
+		return resp
+	}
+
+	resp := get("", "")
+	resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusUnauthorized)
+
+	resp = get("prometheus", "wrong")
+	resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusUnauthorized)
+
+	// Controller user credentials are not accepted.
+	resp = get("user-admin", "dummy-secret")
+	resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusUnauthorized)
+
+	resp = get("prometheus", "sekrit")
+	defer resp.Body.Close()
+	c.Assert(resp.StatusCode, gc.Equals, http.StatusOK)
+	content, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "gazing")
+}
+
 func (s *serverSuite) TestNoBakeryWhenNoIdentityURL(c *gc.C) {
 	_, srv := newServer(c, s.pool)
 	defer assertStop(c, srv)
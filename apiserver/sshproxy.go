@@ -0,0 +1,335 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/schema"
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/featureflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	jujuversion "github.com/juju/juju/version"
+)
+
+const (
+	// sshProxyPort is the port the ssh-proxy endpoint connects to on
+	// the target machine. Only SSH is proxied; the endpoint is not a
+	// general purpose TCP relay.
+	sshProxyPort = 22
+
+	// sshProxyDialTimeout is how long the controller waits for the
+	// target machine to accept the connection.
+	sshProxyDialTimeout = 30 * time.Second
+
+	// sshProxyBufferSize is the maximum amount of data read from the
+	// target before it is sent on to the client.
+	sshProxyBufferSize = 32 * 1024
+)
+
+// sshProxyHandler relays connections to the SSH server of a model's
+// machines over a websocket, so that clients can reach machines that
+// have no address they can route to directly.
+type sshProxyHandler struct {
+	ctxt      httpContext
+	clock     clock.Clock
+	auditSink audit.AuditEntrySinkFn
+	dial      func(address string) (net.Conn, error)
+}
+
+func newSSHProxyHandler(ctxt httpContext, clock clock.Clock, auditSink audit.AuditEntrySinkFn) *sshProxyHandler {
+	return &sshProxyHandler{
+		ctxt:      ctxt,
+		clock:     clock,
+		auditSink: auditSink,
+		dial: func(address string) (net.Conn, error) {
+			return net.DialTimeout("tcp", address, sshProxyDialTimeout)
+		},
+	}
+}
+
+// ServeHTTP will serve up connections as a websocket for the
+// ssh-proxy API. Once the initial error line has been sent, binary
+// messages carry the raw bytes of the SSH session in each direction.
+//
+// Args for the HTTP request are as follows:
+//   target -> string - the tag of the machine, or of a unit on the
+//      machine, to connect to
+func (h *sshProxyHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		defer conn.Close()
+
+		st, releaser, entity, err := h.ctxt.stateAndEntityForRequestAuthenticatedUser(req)
+		if err != nil {
+			h.sendError(conn, req, err)
+			return
+		}
+		defer releaser()
+		userTag := entity.Tag()
+
+		cfg, err := readSSHProxyConfig(req.URL.Query())
+		if err != nil {
+			h.sendError(conn, req, err)
+			return
+		}
+		if err := checkSSHProxyAccess(st, userTag); err != nil {
+			h.sendError(conn, req, err)
+			return
+		}
+		targetTag, _ := names.ParseTag(cfg.Target)
+		address, err := sshProxyAddress(st, targetTag)
+		if err != nil {
+			h.sendError(conn, req, err)
+			return
+		}
+		target, err := h.dial(address)
+		if err != nil {
+			h.sendError(conn, req, errors.Annotatef(err, "connecting to %s", names.ReadableString(targetTag)))
+			return
+		}
+		defer target.Close()
+
+		// If we get to here, no more errors to report, so we report a nil
+		// error.  This way the first line of the connection is always a json
+		// formatted simple error.
+		h.sendError(conn, req, nil)
+
+		session := sshProxySession{
+			modelUUID:     st.ModelUUID(),
+			remoteAddress: req.RemoteAddr,
+			user:          userTag,
+			target:        targetTag,
+			address:       address,
+			started:       h.clock.Now(),
+		}
+		logger.Infof("%s connected to %s (%s) through the ssh proxy", userTag.Id(), names.ReadableString(targetTag), address)
+		h.audit(session, "connect", nil)
+
+		sent, received, err := relaySSH(conn, target, h.ctxt.stop())
+		if err != nil {
+			logger.Debugf("ssh proxy session for %s ended: %v", names.ReadableString(targetTag), err)
+		}
+		duration := h.clock.Now().Sub(session.started)
+		logger.Infof("%s disconnected from %s (%s) after %v: %d bytes sent, %d bytes received",
+			userTag.Id(), names.ReadableString(targetTag), address, duration, sent, received)
+		h.audit(session, "disconnect", map[string]interface{}{
+			"duration":       duration.String(),
+			"bytes-sent":     sent,
+			"bytes-received": received,
+		})
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *sshProxyHandler) sendError(ws *websocket.Conn, req *http.Request, err error) {
+	if err != nil && featureflag.Enabled(feature.DeveloperMode) {
+		logger.Errorf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	}
+	if sendErr := ws.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", err)
+		ws.Close()
+	}
+}
+
+// sshProxySession describes a connection made through the ssh proxy.
+type sshProxySession struct {
+	modelUUID     string
+	remoteAddress string
+	user          names.Tag
+	target        names.Tag
+	address       string
+	started       time.Time
+}
+
+// audit records an audit entry for the session, if auditing is
+// enabled.
+func (h *sshProxyHandler) audit(session sshProxySession, operation string, extra map[string]interface{}) {
+	if h.auditSink == nil {
+		return
+	}
+	data := map[string]interface{}{
+		"target":  session.target.String(),
+		"address": session.address,
+	}
+	for key, value := range extra {
+		data[key] = value
+	}
+	err := h.auditSink(audit.AuditEntry{
+		JujuServerVersion: jujuversion.Current,
+		ModelUUID:         session.modelUUID,
+		Timestamp:         h.clock.Now().UTC(),
+		RemoteAddress:     session.remoteAddress,
+		OriginType:        "SSH proxy",
+		OriginName:        session.user.String(),
+		Operation:         "ssh-proxy " + operation,
+		Data:              data,
+	})
+	if err != nil {
+		logger.Errorf("cannot record audit entry for ssh proxy session: %v", err)
+	}
+}
+
+func readSSHProxyConfig(query url.Values) (params.SSHProxyConfig, error) {
+	var cfg params.SSHProxyConfig
+	query.Del(":modeluuid")
+	if err := schema.NewDecoder().Decode(&cfg, query); err != nil {
+		return cfg, errors.Annotate(err, "decoding schema")
+	}
+	tag, err := names.ParseTag(cfg.Target)
+	if err != nil {
+		return cfg, errors.NotValidf("target %q", cfg.Target)
+	}
+	switch tag.Kind() {
+	case names.MachineTagKind, names.UnitTagKind:
+	default:
+		return cfg, errors.NotValidf("target %q", cfg.Target)
+	}
+	return cfg, nil
+}
+
+// checkSSHProxyAccess returns an error unless the user is allowed to
+// make SSH connections to the model's machines. As with the SSHClient
+// facade, that requires admin access to the model.
+func checkSSHProxyAccess(st *state.State, user names.Tag) error {
+	ok, err := common.HasPermission(
+		st.UserPermission,
+		user,
+		permission.SuperuserAccess,
+		st.ControllerTag(),
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ok {
+		return nil
+	}
+	ok, err = common.HasPermission(
+		st.UserPermission,
+		user,
+		permission.AdminAccess,
+		names.NewModelTag(st.ModelUUID()),
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// sshProxyAddress returns the address of the SSH server on the machine
+// identified by the tag, which may also be that of a unit on the
+// machine. The machine's private address is used, as that is the one
+// most likely to be reachable from the controller.
+func sshProxyAddress(st *state.State, tag names.Tag) (string, error) {
+	machineId := tag.Id()
+	if tag.Kind() == names.UnitTagKind {
+		unit, err := st.Unit(tag.Id())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		machineId, err = unit.AssignedMachineId()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	machine, err := st.Machine(machineId)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	address, err := machine.PrivateAddress()
+	if err != nil {
+		return "", errors.Annotatef(err, "getting address of machine %s", machineId)
+	}
+	return net.JoinHostPort(address.Value, strconv.Itoa(sshProxyPort)), nil
+}
+
+// sshProxyStream is the part of a websocket connection used to relay
+// an SSH session.
+type sshProxyStream interface {
+	io.Closer
+	NextReader() (int, io.Reader, error)
+	WriteMessage(messageType int, data []byte) error
+}
+
+// relaySSH copies data between the client's stream and the connection
+// to the target until either of them is closed, or until stop is
+// closed. Binary messages from the client are written to the target,
+// and data read from the target is sent to the client as binary
+// messages. It returns the number of bytes sent to and received from
+// the client, and the error that ended the session, if it did not end
+// normally.
+func relaySSH(stream sshProxyStream, target io.ReadWriteCloser, stop <-chan struct{}) (sent, received int64, err error) {
+	errs := make(chan error, 2)
+	go func() {
+		for {
+			messageType, r, err := stream.NextReader()
+			if err != nil {
+				errs <- err
+				return
+			}
+			if messageType != gorillaws.BinaryMessage {
+				continue
+			}
+			n, err := io.Copy(target, r)
+			received += n
+			if err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+	go func() {
+		buf := make([]byte, sshProxyBufferSize)
+		for {
+			n, err := target.Read(buf)
+			if n > 0 {
+				if err := stream.WriteMessage(gorillaws.BinaryMessage, buf[:n]); err != nil {
+					errs <- err
+					return
+				}
+				sent += int64(n)
+			}
+			if err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+
+	pending := 2
+	select {
+	case <-stop:
+	case err = <-errs:
+		pending--
+	}
+	// Closing both ends stops any goroutine that is still running;
+	// waiting for them means the byte counts are no longer changing.
+	target.Close()
+	stream.Close()
+	for ; pending > 0; pending-- {
+		<-errs
+	}
+	if err == io.EOF || gorillaws.IsCloseError(err, gorillaws.CloseNormalClosure, gorillaws.CloseGoingAway) {
+		err = nil
+	}
+	return sent, received, err
+}
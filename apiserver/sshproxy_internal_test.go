@@ -0,0 +1,194 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type sshProxySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&sshProxySuite{})
+
+func (s *sshProxySuite) TestReadSSHProxyConfig(c *gc.C) {
+	cfg, err := readSSHProxyConfig(url.Values{"target": {"machine-0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Target, gc.Equals, "machine-0")
+
+	cfg, err = readSSHProxyConfig(url.Values{"target": {"unit-mysql-0"}, ":modeluuid": {"foo"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Target, gc.Equals, "unit-mysql-0")
+}
+
+func (s *sshProxySuite) TestReadSSHProxyConfigInvalidTarget(c *gc.C) {
+	for _, target := range []string{"", "0", "application-mysql"} {
+		_, err := readSSHProxyConfig(url.Values{"target": {target}})
+		c.Check(err, gc.ErrorMatches, `target ".*" not valid`)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *sshProxySuite) TestRelaySSH(c *gc.C) {
+	stream := newFakeSSHProxyStream()
+	local, remote := net.Pipe()
+
+	type result struct {
+		sent, received int64
+		err            error
+	}
+	done := make(chan result, 1)
+	go func() {
+		sent, received, err := relaySSH(stream, local, nil)
+		done <- result{sent, received, err}
+	}()
+
+	stream.incoming <- []byte("hello")
+	buf := make([]byte, 5)
+	_, err := io.ReadFull(remote, buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(buf), gc.Equals, "hello")
+
+	_, err = remote.Write([]byte("world!"))
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case data := <-stream.written:
+		c.Assert(string(data), gc.Equals, "world!")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for data from target")
+	}
+
+	// The session ends when the target closes the connection.
+	remote.Close()
+	select {
+	case r := <-done:
+		c.Assert(r.err, jc.ErrorIsNil)
+		c.Assert(r.sent, gc.Equals, int64(6))
+		c.Assert(r.received, gc.Equals, int64(5))
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for relay to finish")
+	}
+	c.Assert(stream.isClosed(), jc.IsTrue)
+}
+
+func (s *sshProxySuite) TestRelaySSHStop(c *gc.C) {
+	stream := newFakeSSHProxyStream()
+	local, remote := net.Pipe()
+	defer remote.Close()
+	stop := make(chan struct{})
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := relaySSH(stream, local, stop)
+		done <- err
+	}()
+	close(stop)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for relay to finish")
+	}
+	c.Assert(stream.isClosed(), jc.IsTrue)
+}
+
+func (s *sshProxySuite) TestAudit(c *gc.C) {
+	var entries []audit.AuditEntry
+	clock := testing.NewClock(time.Date(2017, 11, 1, 10, 0, 0, 0, time.UTC))
+	h := newSSHProxyHandler(httpContext{}, clock, func(entry audit.AuditEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	session := sshProxySession{
+		modelUUID:     coretesting.ModelTag.Id(),
+		remoteAddress: "10.0.0.1:1234",
+		user:          names.NewUserTag("bob"),
+		target:        names.NewMachineTag("3"),
+		address:       "192.168.0.3:22",
+		started:       clock.Now(),
+	}
+	h.audit(session, "disconnect", map[string]interface{}{"bytes-sent": int64(42)})
+
+	c.Assert(entries, gc.HasLen, 1)
+	entry := entries[0]
+	c.Assert(entry.Validate(), jc.ErrorIsNil)
+	c.Assert(entry.OriginName, gc.Equals, "user-bob")
+	c.Assert(entry.Operation, gc.Equals, "ssh-proxy disconnect")
+	c.Assert(entry.Data, jc.DeepEquals, map[string]interface{}{
+		"target":     "machine-3",
+		"address":    "192.168.0.3:22",
+		"bytes-sent": int64(42),
+	})
+}
+
+func (s *sshProxySuite) TestAuditDisabled(c *gc.C) {
+	h := newSSHProxyHandler(httpContext{}, testing.NewClock(time.Now()), nil)
+	// Does not panic without a sink.
+	h.audit(sshProxySession{user: names.NewUserTag("bob"), target: names.NewMachineTag("0")}, "connect", nil)
+}
+
+// fakeSSHProxyStream is an sshProxyStream that receives the messages
+// sent on incoming, and records the messages written to it.
+type fakeSSHProxyStream struct {
+	incoming chan []byte
+	written  chan []byte
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func newFakeSSHProxyStream() *fakeSSHProxyStream {
+	return &fakeSSHProxyStream{
+		incoming: make(chan []byte),
+		written:  make(chan []byte, 10),
+		closed:   make(chan struct{}),
+	}
+}
+
+func (s *fakeSSHProxyStream) NextReader() (int, io.Reader, error) {
+	select {
+	case data := <-s.incoming:
+		return gorillaws.BinaryMessage, bytes.NewReader(data), nil
+	case <-s.closed:
+		return 0, nil, &gorillaws.CloseError{Code: gorillaws.CloseNormalClosure}
+	}
+}
+
+func (s *fakeSSHProxyStream) WriteMessage(messageType int, data []byte) error {
+	if messageType != gorillaws.BinaryMessage {
+		return errors.Errorf("unexpected message type %d", messageType)
+	}
+	s.written <- append([]byte(nil), data...)
+	return nil
+}
+
+func (s *fakeSSHProxyStream) Close() error {
+	s.closeOnce.Do(func() { close(s.closed) })
+	return nil
+}
+
+func (s *fakeSSHProxyStream) isClosed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
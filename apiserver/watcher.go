@@ -96,12 +96,20 @@ func isAgent(auth facade.Authorizer) bool {
 	return auth.AuthMachineAgent() || auth.AuthUnitAgent()
 }
 
+// isAgentOrClient reports whether the authenticated entity is an agent
+// or a client. As with the AllWatcher, clients can only reach watchers
+// they created themselves, so the facade that created the watcher is
+// responsible for checking their permissions.
+func isAgentOrClient(auth facade.Authorizer) bool {
+	return isAgent(auth) || auth.AuthClient()
+}
+
 func newNotifyWatcher(context facade.Context) (facade.Facade, error) {
 	id := context.ID()
 	auth := context.Auth()
 	resources := context.Resources()
 
-	if auth.GetAuthTag() != nil && !isAgent(auth) {
+	if auth.GetAuthTag() != nil && !isAgentOrClient(auth) {
 		return nil, common.ErrPerm
 	}
 	watcher, ok := resources.Get(id).(state.NotifyWatcher)
@@ -144,7 +152,7 @@ func newStringsWatcher(context facade.Context) (facade.Facade, error) {
 
 	// TODO(wallyworld) - enhance this watcher to support
 	// anonymous api calls with macaroons.
-	if auth.GetAuthTag() != nil && !isAgent(auth) {
+	if auth.GetAuthTag() != nil && !isAgentOrClient(auth) {
 		return nil, common.ErrPerm
 	}
 	watcher, ok := resources.Get(id).(state.StringsWatcher)
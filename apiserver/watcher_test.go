@@ -103,6 +103,27 @@ func (s *watcherSuite) TestFilesystemAttachmentsWatcher(c *gc.C) {
 	})
 }
 
+func (s *watcherSuite) TestStringsWatcherClient(c *gc.C) {
+	ch := make(chan []string, 1)
+	id := s.resources.Register(&fakeStringsWatcher{ch: ch})
+	s.authorizer.Tag = names.NewUserTag("bob")
+
+	ch <- []string{"a", "b"}
+	facade := s.getFacade(c, "StringsWatcher", 1, id, nopDispose).(stringsWatcher)
+	result, err := facade.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Changes, jc.DeepEquals, []string{"a", "b"})
+}
+
+func (s *watcherSuite) TestNotifyWatcherClient(c *gc.C) {
+	id := s.resources.Register(apiservertesting.NewFakeNotifyWatcher())
+	s.authorizer.Tag = names.NewUserTag("bob")
+
+	facade := s.getFacade(c, "NotifyWatcher", 1, id, nopDispose).(notifyWatcher)
+	err := facade.Next()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *watcherSuite) TestMigrationStatusWatcher(c *gc.C) {
 	w := apiservertesting.NewFakeNotifyWatcher()
 	id := s.resources.Register(w)
@@ -154,6 +175,14 @@ func (s *watcherSuite) TestMigrationStatusWatcherNotAgent(c *gc.C) {
 	c.Assert(err, gc.Equals, common.ErrPerm)
 }
 
+type notifyWatcher interface {
+	Next() error
+}
+
+type stringsWatcher interface {
+	Next() (params.StringsWatchResult, error)
+}
+
 type machineStorageIdsWatcher interface {
 	Next() (params.MachineStorageIdsWatchResult, error)
 }
@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"gopkg.in/tomb.v1"
+)
+
+// AsyncSink sends audit entries to another sink from a separate
+// goroutine, so that a slow or unavailable sink does not delay the
+// API requests being audited. It is a worker, and stops sending
+// entries once killed.
+type AsyncSink struct {
+	tomb    tomb.Tomb
+	sink    AuditEntrySinkFn
+	entries chan AuditEntry
+}
+
+// NewAsyncSink returns an AsyncSink which sends entries to the given
+// sink, holding at most size entries that are waiting to be sent.
+func NewAsyncSink(sink AuditEntrySinkFn, size int) *AsyncSink {
+	s := &AsyncSink{
+		sink:    sink,
+		entries: make(chan AuditEntry, size),
+	}
+	go func() {
+		defer s.tomb.Done()
+		s.tomb.Kill(s.loop())
+	}()
+	return s
+}
+
+// Send queues the entry to be sent, and never blocks; if the queue is
+// full, the entry is dropped and the loss is logged. Send may be used
+// as an AuditEntrySinkFn.
+func (s *AsyncSink) Send(entry AuditEntry) error {
+	select {
+	case s.entries <- entry:
+	default:
+		logger.Errorf("audit queue full, dropping record of %q by %q", entry.Operation, entry.OriginName)
+	}
+	return nil
+}
+
+func (s *AsyncSink) loop() error {
+	for {
+		select {
+		case <-s.tomb.Dying():
+			return tomb.ErrDying
+		case entry := <-s.entries:
+			if err := s.sink(entry); err != nil {
+				logger.Errorf("cannot send audit record: %v", err)
+			}
+		}
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (s *AsyncSink) Kill() {
+	s.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (s *AsyncSink) Wait() error {
+	return s.tomb.Wait()
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type asyncSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&asyncSuite{})
+
+func (s *asyncSuite) TestSendsEntries(c *gc.C) {
+	received := make(chan audit.AuditEntry)
+	sink := audit.NewAsyncSink(func(entry audit.AuditEntry) error {
+		received <- entry
+		return nil
+	}, 1)
+	defer func() {
+		sink.Kill()
+		c.Assert(sink.Wait(), jc.ErrorIsNil)
+	}()
+
+	err := sink.Send(audit.AuditEntry{Operation: "Deploy"})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case entry := <-received:
+		c.Assert(entry.Operation, gc.Equals, "Deploy")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for entry")
+	}
+}
+
+func (s *asyncSuite) TestDropsEntriesWhenFull(c *gc.C) {
+	started := make(chan struct{}, 3)
+	release := make(chan struct{})
+	received := make(chan audit.AuditEntry, 3)
+	sink := audit.NewAsyncSink(func(entry audit.AuditEntry) error {
+		started <- struct{}{}
+		<-release
+		received <- entry
+		return nil
+	}, 1)
+	defer func() {
+		sink.Kill()
+		c.Assert(sink.Wait(), jc.ErrorIsNil)
+	}()
+
+	// Wait for the first entry to be taken from the queue; its
+	// sending blocks until released. The second fills the queue,
+	// and the third is dropped rather than blocking the caller.
+	err := sink.Send(audit.AuditEntry{Operation: "first"})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for send to start")
+	}
+	for _, operation := range []string{"second", "third"} {
+		err := sink.Send(audit.AuditEntry{Operation: operation})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	close(release)
+
+	var operations []string
+	for i := 0; i < 2; i++ {
+		select {
+		case entry := <-received:
+			operations = append(operations, entry.Operation)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for entry")
+		}
+	}
+	c.Assert(operations, jc.DeepEquals, []string{"first", "second"})
+	select {
+	case entry := <-received:
+		c.Fatalf("unexpected entry %q", entry.Operation)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"strings"
+	"unicode"
+)
+
+// readOnlyFacades holds the facades whose methods never change
+// anything, other than watchers, which are recognised by name.
+var readOnlyFacades = map[string]bool{
+	"AuditLog": true,
+	"Pinger":   true,
+}
+
+// readOnlyPrefixes holds the words that begin the names of facade
+// methods that only read.
+var readOnlyPrefixes = []string{
+	"Describe",
+	"Find",
+	"FullStatus",
+	"Get",
+	"Info",
+	"List",
+	"Read",
+	"Show",
+	"Status",
+	"Watch",
+}
+
+// IsMutating reports whether a call to the given facade method may
+// change the state of the controller or its models, judged by the
+// names of the facade and method. Calls that are not known to be
+// read-only are taken to be mutating.
+func IsMutating(facade, method string) bool {
+	if readOnlyFacades[facade] || strings.HasSuffix(facade, "Watcher") {
+		return false
+	}
+	for _, prefix := range readOnlyPrefixes {
+		if !strings.HasPrefix(method, prefix) {
+			continue
+		}
+		// Only match whole words.
+		rest := method[len(prefix):]
+		if rest == "" || unicode.IsUpper(rune(rest[0])) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type mutatingSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&mutatingSuite{})
+
+func (s *mutatingSuite) TestIsMutating(c *gc.C) {
+	for i, test := range []struct {
+		facade   string
+		method   string
+		mutating bool
+	}{
+		{"Application", "Deploy", true},
+		{"Application", "SetConstraints", true},
+		{"Application", "DestroyApplication", true},
+		{"Application", "GetConfig", false},
+		{"Application", "Get", false},
+		{"Client", "FullStatus", false},
+		{"Client", "StatusHistory", false},
+		{"ModelManager", "ListModels", false},
+		{"Client", "WatchAll", false},
+		{"AllWatcher", "Stop", false},
+		{"NotifyWatcher", "Next", false},
+		{"Pinger", "Ping", false},
+		{"AuditLog", "Events", false},
+		{"Storage", "Getaway", true},
+		{"Action", "Enqueue", true},
+	} {
+		c.Logf("test %d: %s.%s", i, test.facade, test.method)
+		c.Check(audit.IsMutating(test.facade, test.method), gc.Equals, test.mutating)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Redacted replaces the values of secret fields in redacted data.
+const Redacted = "<redacted>"
+
+// secretFieldMarkers holds the substrings that mark a field name as
+// holding a secret.
+var secretFieldMarkers = []string{
+	"password",
+	"secret",
+	"credential",
+	"macaroon",
+	"token",
+	"private-key",
+	"nonce",
+}
+
+// isSecretField reports whether the named field holds a secret.
+func isSecretField(name string) bool {
+	name = strings.ToLower(name)
+	for _, marker := range secretFieldMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a copy of v, as decoded from its JSON encoding, with
+// the values of any fields whose names suggest that they hold secrets
+// replaced by Redacted. If v cannot be encoded, Redacted is returned
+// in its place, so that nothing is recorded that might not have been
+// redacted.
+func Redact(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		logger.Warningf("cannot encode %T for redaction: %v", v, err)
+		return Redacted
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		logger.Warningf("cannot decode %T for redaction: %v", v, err)
+		return Redacted
+	}
+	return redact(decoded)
+}
+
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSecretField(key) {
+				v[key] = Redacted
+				continue
+			}
+			v[key] = redact(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	}
+	return v
+}
@@ -0,0 +1,57 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+)
+
+type redactSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&redactSuite{})
+
+func (s *redactSuite) TestRedact(c *gc.C) {
+	type user struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}
+	type args struct {
+		Users       []user            `json:"users"`
+		Credentials map[string]string `json:"credentials"`
+		AuthToken   string            `json:"auth-token"`
+		Count       int               `json:"count"`
+	}
+	redacted := audit.Redact(args{
+		Users:       []user{{"bob", "hunter2"}},
+		Credentials: map[string]string{"key": "value"},
+		AuthToken:   "t0k3n",
+		Count:       2,
+	})
+	c.Assert(redacted, jc.DeepEquals, map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{
+				"name":     "bob",
+				"password": audit.Redacted,
+			},
+		},
+		"credentials": audit.Redacted,
+		"auth-token":  audit.Redacted,
+		"count":       float64(2),
+	})
+}
+
+func (s *redactSuite) TestRedactScalar(c *gc.C) {
+	c.Assert(audit.Redact("hello"), gc.Equals, "hello")
+	c.Assert(audit.Redact(nil), gc.IsNil)
+}
+
+func (s *redactSuite) TestRedactUnencodable(c *gc.C) {
+	c.Assert(audit.Redact(make(chan int)), gc.Equals, audit.Redacted)
+}
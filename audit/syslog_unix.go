@@ -0,0 +1,33 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package audit
+
+import (
+	"fmt"
+	"log/syslog"
+
+	"github.com/juju/errors"
+)
+
+// NewSyslogSink returns an audit entry sink which writes each entry
+// to the local syslog daemon, tagged with the given tag.
+func NewSyslogSink(tag string) (AuditEntrySinkFn, error) {
+	writer, err := syslog.New(syslog.LOG_INFO|syslog.LOG_AUTH, tag)
+	if err != nil {
+		return nil, errors.Annotate(err, "connecting to syslog")
+	}
+	return func(entry AuditEntry) error {
+		return errors.Trace(writer.Info(fmt.Sprintf(
+			"model=%s remote-address=%s origin=%s origin-type=%q operation=%q data=%v",
+			entry.ModelUUID,
+			entry.RemoteAddress,
+			entry.OriginName,
+			entry.OriginType,
+			entry.Operation,
+			entry.Data,
+		)))
+	}, nil
+}
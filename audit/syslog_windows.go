@@ -0,0 +1,13 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"github.com/juju/errors"
+)
+
+// NewSyslogSink is not supported on Windows.
+func NewSyslogSink(tag string) (AuditEntrySinkFn, error) {
+	return nil, errors.NotSupportedf("syslog on windows")
+}
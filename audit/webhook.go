@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// webhookEntry is the JSON form of an AuditEntry sent to a webhook.
+type webhookEntry struct {
+	JujuServerVersion string                 `json:"juju-server-version"`
+	ModelUUID         string                 `json:"model-uuid"`
+	Timestamp         string                 `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+// NewWebhookSink returns an audit entry sink which POSTs each entry,
+// encoded as JSON, to the given URL using the given client. The
+// client should have a timeout, since entries are recorded as API
+// requests are made.
+func NewWebhookSink(url string, client *http.Client) AuditEntrySinkFn {
+	return func(entry AuditEntry) error {
+		body, err := json.Marshal(webhookEntry{
+			JujuServerVersion: entry.JujuServerVersion.String(),
+			ModelUUID:         entry.ModelUUID,
+			Timestamp:         entry.Timestamp.UTC().Format(time.RFC3339Nano),
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Data:              entry.Data,
+		})
+		if err != nil {
+			return errors.Annotate(err, "encoding audit entry")
+		}
+		resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+		if err != nil {
+			return errors.Annotate(err, "sending audit entry")
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			return errors.Errorf("sending audit entry: %s responded with %q", url, resp.Status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type webhookSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&webhookSuite{})
+
+func (s *webhookSuite) TestWebhook(c *gc.C) {
+	received := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		var body map[string]interface{}
+		err := json.NewDecoder(req.Body).Decode(&body)
+		c.Check(err, jc.ErrorIsNil)
+		received <- body
+	}))
+	defer server.Close()
+
+	sink := audit.NewWebhookSink(server.URL, http.DefaultClient)
+	err := sink(audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         time.Date(2017, time.October, 1, 12, 0, 0, 0, time.UTC),
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-admin",
+		Operation:         "Application:v6 - Deploy",
+		Data:              map[string]interface{}{"correlation-id": "abc"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(<-received, jc.DeepEquals, map[string]interface{}{
+		"juju-server-version": "2.3.0",
+		"model-uuid":          coretesting.ModelTag.Id(),
+		"timestamp":           "2017-10-01T12:00:00Z",
+		"remote-address":      "10.0.0.1",
+		"origin-type":         "API request",
+		"origin-name":         "user-admin",
+		"operation":           "Application:v6 - Deploy",
+		"data":                map[string]interface{}{"correlation-id": "abc"},
+	})
+}
+
+func (s *webhookSuite) TestWebhookErrorStatus(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	sink := audit.NewWebhookSink(server.URL, http.DefaultClient)
+	err := sink(audit.AuditEntry{})
+	c.Assert(err, gc.ErrorMatches, `sending audit entry: .* responded with "403 Forbidden"`)
+}
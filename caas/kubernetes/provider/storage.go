@@ -0,0 +1,165 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package provider contains the parts of the Kubernetes provider that
+// are needed outside the cluster, such as its storage provider.
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// K8sProviderType is the storage provider type for storage
+	// provisioned by a Kubernetes cluster, through persistent volume
+	// claims against a StorageClass.
+	K8sProviderType = storage.ProviderType("kubernetes")
+
+	// StorageClass is the name of the StorageClass that persistent
+	// volume claims for a pool are made against. If it is not set,
+	// the cluster's default StorageClass is used.
+	StorageClass = "storage-class"
+
+	// StorageProvisioner is the provisioner of the pool's
+	// StorageClass, such as "kubernetes.io/aws-ebs". If it is set,
+	// the StorageClass is created with the pool's parameters when it
+	// does not already exist in the cluster.
+	StorageProvisioner = "storage-provisioner"
+
+	// StorageParametersPrefix prefixes the pool attributes that are
+	// passed to the provisioner as StorageClass parameters, with the
+	// prefix removed.
+	StorageParametersPrefix = "parameters."
+)
+
+var storageConfigFields = schema.Fields{
+	StorageClass:       schema.String(),
+	StorageProvisioner: schema.String(),
+}
+
+var storageConfigChecker = schema.FieldMap(
+	storageConfigFields,
+	schema.Defaults{
+		StorageClass:       "",
+		StorageProvisioner: "",
+	},
+)
+
+// StorageConfig describes the StorageClass that a Juju storage pool
+// maps to.
+type StorageConfig struct {
+	// StorageClass is the name of the StorageClass, or empty for the
+	// cluster's default StorageClass.
+	StorageClass string
+
+	// StorageProvisioner is the provisioner used to create the
+	// StorageClass, or empty if the StorageClass must already exist.
+	StorageProvisioner string
+
+	// Parameters holds the provisioner parameters of the
+	// StorageClass.
+	Parameters map[string]string
+}
+
+// NewStorageConfig validates the attributes of a storage pool of the
+// Kubernetes provider type, and returns the StorageClass they describe.
+func NewStorageConfig(attrs map[string]interface{}) (*StorageConfig, error) {
+	known := make(map[string]interface{})
+	parameters := make(map[string]string)
+	for k, v := range attrs {
+		if strings.HasPrefix(k, StorageParametersPrefix) {
+			name := strings.TrimPrefix(k, StorageParametersPrefix)
+			if name == "" {
+				return nil, errors.NotValidf("empty storage class parameter name")
+			}
+			parameters[name] = fmt.Sprint(v)
+			continue
+		}
+		if _, ok := storageConfigFields[k]; ok {
+			known[k] = v
+		}
+	}
+	out, err := storageConfigChecker.Coerce(known, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating Kubernetes storage config")
+	}
+	coerced := out.(map[string]interface{})
+	cfg := &StorageConfig{
+		StorageClass:       coerced[StorageClass].(string),
+		StorageProvisioner: coerced[StorageProvisioner].(string),
+	}
+	if len(parameters) > 0 {
+		cfg.Parameters = parameters
+	}
+	if cfg.StorageProvisioner != "" && cfg.StorageClass == "" {
+		return nil, errors.New("storage-class must be specified with storage-provisioner")
+	}
+	if cfg.Parameters != nil && cfg.StorageProvisioner == "" {
+		return nil, errors.New("storage-provisioner must be specified with storage class parameters")
+	}
+	return cfg, nil
+}
+
+// StorageProviders returns a registry holding the storage providers
+// available in Kubernetes models.
+func StorageProviders() storage.ProviderRegistry {
+	return storage.StaticProviderRegistry{
+		Providers: map[storage.ProviderType]storage.Provider{
+			K8sProviderType: storageProvider{},
+		},
+	}
+}
+
+// storageProvider is a storage.Provider whose storage is provisioned
+// by the Kubernetes cluster when a unit's pod claims it, rather than by
+// the storage provisioner worker.
+type storageProvider struct{}
+
+var _ storage.Provider = storageProvider{}
+
+// ValidateConfig is defined on the Provider interface.
+func (storageProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := NewStorageConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (storageProvider) VolumeSource(*storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (storageProvider) FilesystemSource(*storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems provisioned outside Kubernetes")
+}
+
+// Supports is defined on the Provider interface.
+func (storageProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the Provider interface.
+func (storageProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (storageProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type storageSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) storageProvider(c *gc.C) storage.Provider {
+	p, err := provider.StorageProviders().StorageProvider(provider.K8sProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+	return p
+}
+
+func (s *storageSuite) TestStorageProviderTypes(c *gc.C) {
+	types, err := provider.StorageProviders().StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, []storage.ProviderType{"kubernetes"})
+}
+
+func (s *storageSuite) TestSupports(c *gc.C) {
+	p := s.storageProvider(c)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *storageSuite) TestNewStorageConfigDefaults(c *gc.C) {
+	cfg, err := provider.NewStorageConfig(map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, &provider.StorageConfig{})
+}
+
+func (s *storageSuite) TestNewStorageConfig(c *gc.C) {
+	cfg, err := provider.NewStorageConfig(map[string]interface{}{
+		"storage-class":        "juju-ebs",
+		"storage-provisioner":  "kubernetes.io/aws-ebs",
+		"parameters.type":      "gp2",
+		"parameters.iopsPerGB": 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, &provider.StorageConfig{
+		StorageClass:       "juju-ebs",
+		StorageProvisioner: "kubernetes.io/aws-ebs",
+		Parameters: map[string]string{
+			"type":      "gp2",
+			"iopsPerGB": "10",
+		},
+	})
+}
+
+func (s *storageSuite) TestNewStorageConfigExistingClass(c *gc.C) {
+	cfg, err := provider.NewStorageConfig(map[string]interface{}{
+		"storage-class": "fast",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, &provider.StorageConfig{StorageClass: "fast"})
+}
+
+func (s *storageSuite) TestNewStorageConfigInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"storage-class": 42},
+		err:   `validating Kubernetes storage config: storage-class: expected string, got int\(42\)`,
+	}, {
+		attrs: map[string]interface{}{"storage-provisioner": "kubernetes.io/aws-ebs"},
+		err:   "storage-class must be specified with storage-provisioner",
+	}, {
+		attrs: map[string]interface{}{"storage-class": "fast", "parameters.type": "gp2"},
+		err:   "storage-provisioner must be specified with storage class parameters",
+	}, {
+		attrs: map[string]interface{}{"parameters.": "gp2"},
+		err:   "empty storage class parameter name not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := provider.NewStorageConfig(test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *storageSuite) TestValidateConfig(c *gc.C) {
+	p := s.storageProvider(c)
+	cfg, err := storage.NewConfig("fast", provider.K8sProviderType, map[string]interface{}{
+		"storage-provisioner": "kubernetes.io/aws-ebs",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, "storage-class must be specified with storage-provisioner")
+}
+
+func (s *storageSuite) TestSourcesNotSupported(c *gc.C) {
+	p := s.storageProvider(c)
+	cfg, err := storage.NewConfig("fast", provider.K8sProviderType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "volumes not supported")
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "filesystems provisioned outside Kubernetes not supported")
+}
@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+)
+
+// DefaultTrack is the track used for a channel that names only a risk.
+const DefaultTrack = "latest"
+
+// Risks holds the risk levels of a channel, from the most to the least
+// stable.
+var Risks = []csparams.Channel{
+	csparams.StableChannel,
+	csparams.CandidateChannel,
+	csparams.BetaChannel,
+	csparams.EdgeChannel,
+}
+
+// Channel identifies a charm store channel by its track and risk, as
+// in "8.0/stable". Charms published in a track are only resolved when
+// that track is asked for.
+type Channel struct {
+	// Track is the track of the channel, e.g. "8.0". It is empty for
+	// the default track.
+	Track string
+
+	// Risk is the risk level of the channel, e.g. "stable". It is
+	// empty only for the zero Channel.
+	Risk csparams.Channel
+}
+
+// ParseChannel parses a channel of the form "[track/]risk" or "track".
+// A channel naming only a track selects the stable risk of that track,
+// and the "latest" track is the default track. The empty string parses
+// to the zero Channel.
+func ParseChannel(value string) (Channel, error) {
+	if value == "" {
+		return Channel{}, nil
+	}
+	var track, risk string
+	switch parts := strings.Split(value, "/"); len(parts) {
+	case 1:
+		if isRisk(parts[0]) || parts[0] == string(csparams.UnpublishedChannel) {
+			risk = parts[0]
+		} else {
+			track = parts[0]
+		}
+	case 2:
+		track, risk = parts[0], parts[1]
+		if !isRisk(risk) {
+			return Channel{}, errors.NotValidf("risk %q in channel %q", risk, value)
+		}
+	default:
+		return Channel{}, errors.NotValidf("channel %q (expected [track/]risk)", value)
+	}
+	if track == DefaultTrack {
+		track = ""
+	} else if track != "" && isRisk(track) {
+		return Channel{}, errors.NotValidf("track %q in channel %q", track, value)
+	}
+	if risk == "" {
+		risk = string(csparams.StableChannel)
+	}
+	return Channel{Track: track, Risk: csparams.Channel(risk)}, nil
+}
+
+func isRisk(value string) bool {
+	for _, risk := range Risks {
+		if value == string(risk) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the channel in the form "[track/]risk", omitting the
+// default track.
+func (c Channel) String() string {
+	if c.Track == "" {
+		return string(c.Risk)
+	}
+	return c.Track + "/" + string(c.Risk)
+}
+
+// CSChannel returns the channel as passed to the charm store.
+func (c Channel) CSChannel() csparams.Channel {
+	return csparams.Channel(c.String())
+}
+
+// normalizeChannel returns the channel as passed to the charm store,
+// so that equivalent channels such as "latest/stable" and "stable" are
+// requested in the same way. Channels that cannot be parsed are passed
+// on unchanged, for the charm store to reject.
+func normalizeChannel(channel csparams.Channel) csparams.Channel {
+	parsed, err := ParseChannel(string(channel))
+	if err != nil {
+		return channel
+	}
+	return parsed.CSChannel()
+}
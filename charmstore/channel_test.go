@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/charmstore"
+)
+
+type ChannelSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ChannelSuite{})
+
+func (ChannelSuite) TestParseChannel(c *gc.C) {
+	for _, test := range []struct {
+		value    string
+		expected charmstore.Channel
+		str      string
+	}{{
+		value: "",
+	}, {
+		value:    "stable",
+		expected: charmstore.Channel{Risk: csparams.StableChannel},
+		str:      "stable",
+	}, {
+		value:    "edge",
+		expected: charmstore.Channel{Risk: csparams.EdgeChannel},
+		str:      "edge",
+	}, {
+		value:    "unpublished",
+		expected: charmstore.Channel{Risk: csparams.UnpublishedChannel},
+		str:      "unpublished",
+	}, {
+		value:    "8.0/candidate",
+		expected: charmstore.Channel{Track: "8.0", Risk: csparams.CandidateChannel},
+		str:      "8.0/candidate",
+	}, {
+		value:    "8.0",
+		expected: charmstore.Channel{Track: "8.0", Risk: csparams.StableChannel},
+		str:      "8.0/stable",
+	}, {
+		value:    "latest/beta",
+		expected: charmstore.Channel{Risk: csparams.BetaChannel},
+		str:      "beta",
+	}, {
+		value:    "latest",
+		expected: charmstore.Channel{Risk: csparams.StableChannel},
+		str:      "stable",
+	}} {
+		c.Logf("parsing %q", test.value)
+		channel, err := charmstore.ParseChannel(test.value)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(channel, gc.Equals, test.expected)
+		c.Check(channel.String(), gc.Equals, test.str)
+		c.Check(channel.CSChannel(), gc.Equals, csparams.Channel(test.str))
+	}
+}
+
+func (ChannelSuite) TestParseChannelInvalid(c *gc.C) {
+	for _, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "8.0/risky",
+		err:   `risk "risky" in channel "8.0/risky" not valid`,
+	}, {
+		value: "8.0/unpublished",
+		err:   `risk "unpublished" in channel "8.0/unpublished" not valid`,
+	}, {
+		value: "stable/edge",
+		err:   `track "stable" in channel "stable/edge" not valid`,
+	}, {
+		value: "8.0/stable/x",
+		err:   `channel "8.0/stable/x" \(expected \[track/\]risk\) not valid`,
+	}} {
+		c.Logf("parsing %q", test.value)
+		_, err := charmstore.ParseChannel(test.value)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (ChannelSuite) TestCharmIDTrackRisk(c *gc.C) {
+	id := charmstore.CharmID{
+		URL:     charm.MustParseURL("cs:mysql"),
+		Channel: "5.7/edge",
+	}
+
+	channel, err := id.TrackRisk()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(channel, gc.Equals, charmstore.Channel{Track: "5.7", Risk: csparams.EdgeChannel})
+}
@@ -14,6 +14,12 @@ type CharmID struct {
 	// URL is the url of the charm.
 	URL *charm.URL
 
-	// Channel is the channel in which the charm was published.
+	// Channel is the channel in which the charm was published. It may
+	// name a track as well as a risk, e.g. "8.0/stable".
 	Channel csparams.Channel
 }
+
+// TrackRisk returns the track and risk of the charm's channel.
+func (id CharmID) TrackRisk() (Channel, error) {
+	return ParseChannel(string(id.Channel))
+}
@@ -251,26 +251,26 @@ type csclientImpl struct {
 
 // Latest gets the latest CharmRevisions for the charm URLs on the channel.
 func (c csclientImpl) Latest(channel csparams.Channel, ids []*charm.URL, metadata map[string][]string) ([]csparams.CharmRevision, error) {
-	client := c.WithChannel(channel)
+	client := c.WithChannel(normalizeChannel(channel))
 	client.SetHTTPHeader(http.Header(metadata))
 	return client.Latest(ids)
 }
 
 // ListResources gets the latest resources for the charm URL on the channel.
 func (c csclientImpl) ListResources(channel csparams.Channel, id *charm.URL) ([]csparams.Resource, error) {
-	client := c.WithChannel(channel)
+	client := c.WithChannel(normalizeChannel(channel))
 	return client.ListResources(id)
 }
 
 // Getresource downloads the bytes and some metadata about the bytes for the revisioned resource.
 func (c csclientImpl) GetResource(channel csparams.Channel, id *charm.URL, name string, revision int) (csclient.ResourceData, error) {
-	client := c.WithChannel(channel)
+	client := c.WithChannel(normalizeChannel(channel))
 	return client.GetResource(id, name, revision)
 }
 
 // ResourceInfo gets the full metadata for the revisioned resource.
 func (c csclientImpl) ResourceMeta(channel csparams.Channel, id *charm.URL, name string, revision int) (csparams.Resource, error) {
-	client := c.WithChannel(channel)
+	client := c.WithChannel(normalizeChannel(channel))
 	return client.ResourceMeta(id, name, revision)
 }
 
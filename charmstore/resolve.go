@@ -0,0 +1,133 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+)
+
+// ResolvedCharm holds the result of resolving a charm reference with
+// the charm store.
+type ResolvedCharm struct {
+	// URL is the fully resolved charm URL.
+	URL *charm.URL
+
+	// Channel is the channel from which the charm was resolved.
+	Channel csparams.Channel
+
+	// SupportedSeries holds the series supported by the charm.
+	SupportedSeries []string
+}
+
+// ResolveFunc resolves a charm reference in the given channel.
+type ResolveFunc func(CharmID) (ResolvedCharm, error)
+
+// ResolutionCache remembers the charm store's resolution of charm
+// references for a limited time, so that the same reference, such as
+// one used by many applications in a bundle, is only resolved once.
+// Failed resolutions are not cached.
+type ResolutionCache struct {
+	clock clock.Clock
+	ttl   time.Duration
+
+	mu      sync.Mutex
+	entries map[resolutionKey]resolutionEntry
+}
+
+type resolutionKey struct {
+	server  string
+	channel csparams.Channel
+	url     string
+}
+
+type resolutionEntry struct {
+	resolved ResolvedCharm
+	expires  time.Time
+}
+
+// NewResolutionCache returns a ResolutionCache whose entries expire
+// after the given time.
+func NewResolutionCache(clock clock.Clock, ttl time.Duration) *ResolutionCache {
+	return &ResolutionCache{
+		clock:   clock,
+		ttl:     ttl,
+		entries: make(map[resolutionKey]resolutionEntry),
+	}
+}
+
+// Resolve returns the resolution of the charm reference by the charm
+// store at the given server URL, calling resolve if it is not cached.
+// Channels are compared by their track and risk, so "latest/stable"
+// and "stable" share cache entries.
+func (c *ResolutionCache) Resolve(server string, id CharmID, resolve ResolveFunc) (ResolvedCharm, error) {
+	channel, err := id.TrackRisk()
+	if err != nil {
+		return ResolvedCharm{}, errors.Trace(err)
+	}
+	id.Channel = channel.CSChannel()
+	key := resolutionKey{
+		server:  server,
+		channel: id.Channel,
+		url:     id.URL.String(),
+	}
+	if resolved, ok := c.get(key); ok {
+		return resolved, nil
+	}
+	resolved, err := resolve(id)
+	if err != nil {
+		return ResolvedCharm{}, errors.Trace(err)
+	}
+	c.set(key, resolved)
+	return copyResolved(resolved), nil
+}
+
+func (c *ResolutionCache) get(key resolutionKey) (ResolvedCharm, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return ResolvedCharm{}, false
+	}
+	if !c.clock.Now().Before(entry.expires) {
+		delete(c.entries, key)
+		return ResolvedCharm{}, false
+	}
+	return copyResolved(entry.resolved), true
+}
+
+func (c *ResolutionCache) set(key resolutionKey, resolved ResolvedCharm) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	// Drop expired entries so that references that are never asked
+	// for again don't accumulate.
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = resolutionEntry{
+		resolved: copyResolved(resolved),
+		expires:  now.Add(c.ttl),
+	}
+}
+
+// copyResolved returns a copy of the resolved charm, so that callers
+// cannot change cached values.
+func copyResolved(resolved ResolvedCharm) ResolvedCharm {
+	if resolved.URL != nil {
+		url := *resolved.URL
+		resolved.URL = &url
+	}
+	if resolved.SupportedSeries != nil {
+		resolved.SupportedSeries = append([]string(nil), resolved.SupportedSeries...)
+	}
+	return resolved
+}
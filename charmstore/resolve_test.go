@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/charmstore"
+)
+
+type ResolutionCacheSuite struct {
+	testing.IsolationSuite
+
+	clock *testing.Clock
+	cache *charmstore.ResolutionCache
+	calls []charmstore.CharmID
+}
+
+var _ = gc.Suite(&ResolutionCacheSuite{})
+
+func (s *ResolutionCacheSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC))
+	s.cache = charmstore.NewResolutionCache(s.clock, time.Minute)
+	s.calls = nil
+}
+
+func (s *ResolutionCacheSuite) resolve(id charmstore.CharmID) (charmstore.ResolvedCharm, error) {
+	s.calls = append(s.calls, id)
+	if id.URL.Name == "missing" {
+		return charmstore.ResolvedCharm{}, errors.NotFoundf("charm %q", id.URL)
+	}
+	return charmstore.ResolvedCharm{
+		URL:             id.URL.WithRevision(len(s.calls)),
+		Channel:         id.Channel,
+		SupportedSeries: []string{"xenial"},
+	}, nil
+}
+
+func (s *ResolutionCacheSuite) TestResolveCached(c *gc.C) {
+	id := charmstore.CharmID{
+		URL:     charm.MustParseURL("cs:xenial/mysql"),
+		Channel: "5.7/stable",
+	}
+	expected := charmstore.ResolvedCharm{
+		URL:             charm.MustParseURL("cs:xenial/mysql-1"),
+		Channel:         "5.7/stable",
+		SupportedSeries: []string{"xenial"},
+	}
+
+	resolved, err := s.cache.Resolve("https://api.example.com", id, s.resolve)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resolved, jc.DeepEquals, expected)
+
+	// Changing the result doesn't change the cached value.
+	resolved.SupportedSeries[0] = "trusty"
+
+	s.clock.Advance(59 * time.Second)
+	resolved, err = s.cache.Resolve("https://api.example.com", id, s.resolve)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resolved, jc.DeepEquals, expected)
+	c.Check(s.calls, gc.HasLen, 1)
+}
+
+func (s *ResolutionCacheSuite) TestResolveExpired(c *gc.C) {
+	id := charmstore.CharmID{URL: charm.MustParseURL("cs:mysql")}
+
+	_, err := s.cache.Resolve("https://api.example.com", id, s.resolve)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock.Advance(time.Minute)
+	resolved, err := s.cache.Resolve("https://api.example.com", id, s.resolve)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resolved.URL, jc.DeepEquals, charm.MustParseURL("cs:mysql-2"))
+	c.Check(s.calls, gc.HasLen, 2)
+}
+
+func (s *ResolutionCacheSuite) TestResolveKeys(c *gc.C) {
+	for _, test := range []struct {
+		server  string
+		url     string
+		channel csparams.Channel
+	}{
+		{"https://api.example.com", "cs:mysql", "stable"},
+		{"https://api.example.com", "cs:mysql", "latest/stable"},
+		{"https://api.example.com", "cs:mysql", "5.7/stable"},
+		{"https://api.example.com", "cs:mysql", "edge"},
+		{"https://api.example.com", "cs:xenial/mysql", "stable"},
+		{"https://other.example.com", "cs:mysql", "stable"},
+	} {
+		_, err := s.cache.Resolve(test.server, charmstore.CharmID{
+			URL:     charm.MustParseURL(test.url),
+			Channel: test.channel,
+		}, s.resolve)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// The "latest" track is the same as the default track, and the
+	// resolution is passed the normalised channel.
+	c.Assert(s.calls, gc.HasLen, 5)
+	c.Check(s.calls[1].Channel, gc.Equals, csparams.Channel("5.7/stable"))
+}
+
+func (s *ResolutionCacheSuite) TestResolveErrorNotCached(c *gc.C) {
+	id := charmstore.CharmID{URL: charm.MustParseURL("cs:missing")}
+
+	for i := 0; i < 2; i++ {
+		_, err := s.cache.Resolve("https://api.example.com", id, s.resolve)
+		c.Check(err, jc.Satisfies, errors.IsNotFound)
+	}
+	c.Check(s.calls, gc.HasLen, 2)
+}
+
+func (s *ResolutionCacheSuite) TestResolveBadChannel(c *gc.C) {
+	id := charmstore.CharmID{
+		URL:     charm.MustParseURL("cs:mysql"),
+		Channel: "5.7/risky",
+	}
+
+	_, err := s.cache.Resolve("https://api.example.com", id, s.resolve)
+	c.Check(err, gc.ErrorMatches, `risk "risky" in channel "5.7/risky" not valid`)
+	c.Check(s.calls, gc.HasLen, 0)
+}
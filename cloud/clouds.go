@@ -58,6 +58,27 @@ const (
 	// CertificateAuthType is an authentication type using certificates.
 	CertificateAuthType AuthType = "certificate"
 
+	// SSHKeyAuthType is an authentication type using a username
+	// and an SSH private key.
+	SSHKeyAuthType AuthType = "ssh-key"
+
+	// HTTPSigAuthType is an authentication type using HTTP request
+	// signatures, made with an API signing key.
+	HTTPSigAuthType AuthType = "httpsig"
+
+	// InstancePrincipalAuthType is an authentication type using the
+	// identity of the cloud instance that the client is running on.
+	InstancePrincipalAuthType AuthType = "instance-principal"
+
+	// InstanceRoleAuthType is an authentication type using the
+	// temporary credentials of the IAM role associated with the
+	// instance that the client is running on.
+	InstanceRoleAuthType AuthType = "instance-role"
+
+	// ApplicationCredentialAuthType is an authentication type using
+	// an OpenStack Keystone application credential's id and secret.
+	ApplicationCredentialAuthType AuthType = "application-credential"
+
 	// EmptyAuthType is the authentication type used for providers
 	// that require no credentials, e.g. "lxd", and "manual".
 	EmptyAuthType AuthType = "empty"
@@ -10,7 +10,7 @@ clouds:
   aws:
     type: ec2
     description: Amazon Web Services
-    auth-types: [ access-key ]
+    auth-types: [ access-key, instance-role ]
     regions:
       us-east-1:
         endpoint: https://ec2.us-east-1.amazonaws.com
@@ -43,14 +43,14 @@ clouds:
   aws-china:
     type: ec2
     description: Amazon China
-    auth-types: [ access-key ]
+    auth-types: [ access-key, instance-role ]
     regions:
       cn-north-1:
         endpoint: https://ec2.cn-north-1.amazonaws.com.cn
   aws-gov:
     type: ec2
     description: Amazon (USA Government)
-    auth-types: [ access-key ]
+    auth-types: [ access-key, instance-role ]
     regions:
       us-gov-west-1:
         endpoint: https://ec2.us-gov-west-1.amazonaws.com
@@ -762,10 +762,11 @@ mongoversion: "0.0"
 "@
 cmd.exe /C mklink /D C:\Juju\lib\juju\tools\machine-10 1.2.3-win8-amd64
 if ($jujuCreds) {
-  New-Service -Credential $jujuCreds -Name 'jujud-machine-10' -DependsOn Winmgmt -DisplayName 'juju agent for machine-10' '"C:\Juju\lib\juju\tools\machine-10\jujud.exe" machine --data-dir "C:\Juju\lib\juju" --machine-id 10 --debug'
+  New-Service -Credential $jujuCreds -Name 'jujud-machine-10' -DependsOn 'Winmgmt','Tcpip' -DisplayName 'juju agent for machine-10' '"C:\Juju\lib\juju\tools\machine-10\jujud.exe" machine --data-dir "C:\Juju\lib\juju" --machine-id 10 --debug'
 } else {
-  New-Service -Name 'jujud-machine-10' -DependsOn Winmgmt -DisplayName 'juju agent for machine-10' '"C:\Juju\lib\juju\tools\machine-10\jujud.exe" machine --data-dir "C:\Juju\lib\juju" --machine-id 10 --debug'
+  New-Service -Name 'jujud-machine-10' -DependsOn 'Winmgmt','Tcpip' -DisplayName 'juju agent for machine-10' '"C:\Juju\lib\juju\tools\machine-10\jujud.exe" machine --data-dir "C:\Juju\lib\juju" --machine-id 10 --debug'
 }
-sc.exe failure 'jujud-machine-10' reset=5 actions=restart/1000
+sc.exe failure 'jujud-machine-10' reset= 5 actions= restart/5000
 sc.exe failureflag 'jujud-machine-10' 1
+sc.exe config 'jujud-machine-10' start= delayed-auto
 Start-Service 'jujud-machine-10'`
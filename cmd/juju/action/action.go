@@ -7,10 +7,12 @@ import (
 	"io"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/watcher"
 )
 
 // type APIClient represents the action API functionality.
@@ -56,6 +58,10 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// WatchActionLogs returns a watcher that reports the messages
+	// logged by the action, each as a JSON encoded ActionMessage.
+	WatchActionLogs(names.ActionTag) (watcher.StringsWatcher, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
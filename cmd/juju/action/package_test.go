@@ -14,11 +14,13 @@ import (
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
 )
 
 const (
@@ -128,6 +130,8 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	actionMessages     []string
+	watchedActionTag   names.ActionTag
 	apiErr             error
 }
 
@@ -210,3 +214,24 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) WatchActionLogs(tag names.ActionTag) (watcher.StringsWatcher, error) {
+	c.watchedActionTag = tag
+	changes := make(chan []string, 1)
+	changes <- c.actionMessages
+	return &fakeStringsWatcher{changes: changes}, c.apiErr
+}
+
+type fakeStringsWatcher struct {
+	changes chan []string
+}
+
+func (w *fakeStringsWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
+
+func (*fakeStringsWatcher) Kill() {}
+
+func (*fakeStringsWatcher) Wait() error {
+	return nil
+}
@@ -4,6 +4,8 @@
 package action
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
 	"time"
 
@@ -27,6 +29,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	stream      bool
 }
 
 const showOutputDoc = `
@@ -38,6 +41,11 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+With --stream, the messages logged by the action are printed to stderr
+as they arrive while waiting for the result, which includes the output
+of commands run in the background with "juju run --background --stream".
+Unless --wait is given, --stream waits indefinitely.
 `
 
 // Set up the output.
@@ -45,6 +53,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.stream, "stream", false, "Print messages logged by the action while waiting for results")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -83,6 +92,10 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.stream && waitDur < 0 {
+		// Streaming messages only makes sense while waiting.
+		waitDur = 0
+	}
 
 	api, err := c.NewActionAPIClient()
 	if err != nil {
@@ -104,7 +117,12 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
-	result, err := GetActionResult(api, c.requestedId, wait)
+	var result params.ActionResult
+	if c.stream {
+		result, err = streamActionResult(ctx, api, c.requestedId, wait)
+	} else {
+		result, err = GetActionResult(api, c.requestedId, wait)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -112,6 +130,77 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, FormatActionResult(result))
 }
 
+// streamActionResult behaves like GetActionResult, but also writes the
+// messages logged by the action to stderr as they are received.
+func streamActionResult(ctx *cmd.Context, api APIClient, requestedId string, wait *time.Timer) (params.ActionResult, error) {
+	actionTag, err := getActionTagByPrefix(api, requestedId)
+	if err != nil {
+		return params.ActionResult{}, err
+	}
+	w, err := api.WatchActionLogs(actionTag)
+	if err != nil {
+		return params.ActionResult{}, errors.Trace(err)
+	}
+	defer w.Kill()
+
+	changes := w.Changes()
+	writeMessages := func(messages []string) error {
+		for _, data := range messages {
+			var message params.ActionMessage
+			if err := json.Unmarshal([]byte(data), &message); err != nil {
+				return errors.Annotate(err, "cannot decode action message")
+			}
+			fmt.Fprintf(ctx.Stderr, "%s %s\n", message.Timestamp.Format(time.RFC3339), message.Message)
+		}
+		return nil
+	}
+
+	var result params.ActionResult
+	tick := time.NewTimer(0)
+	for {
+		select {
+		case messages, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			if err := writeMessages(messages); err != nil {
+				return result, err
+			}
+			continue
+		case <-wait.C:
+			return result, nil
+		case <-tick.C:
+		}
+
+		result, err = fetchResult(api, requestedId)
+		if err != nil {
+			return result, err
+		}
+		switch result.Status {
+		case params.ActionRunning, params.ActionPending:
+			tick.Reset(2 * time.Second)
+			continue
+		}
+
+		// Write any messages that have already been sent
+		// before reporting the result.
+		for {
+			select {
+			case messages, ok := <-changes:
+				if ok {
+					if err := writeMessages(messages); err != nil {
+						return result, err
+					}
+					continue
+				}
+			default:
+			}
+			return result, nil
+		}
+	}
+}
+
 // GetActionResult tries to repeatedly fetch an action until it is
 // in a completed state and then it returns it.
 // It waits for a maximum of "wait" before returning with the latest action status.
@@ -290,6 +290,37 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestRunStream(c *gc.C) {
+	client := makeFakeClient(
+		0*time.Second,
+		10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Action: &params.Action{
+				Tag: validActionTagString,
+			},
+			Status: params.ActionCompleted,
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	client.actionMessages = []string{
+		`{"timestamp":"2015-02-14T08:14:00Z","message":"stdout: one"}`,
+		`{"timestamp":"2015-02-14T08:15:00Z","message":"stderr: two"}`,
+	}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--stream")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "status: completed\n")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"2015-02-14T08:14:00Z stdout: one\n"+
+		"2015-02-14T08:15:00Z stderr: two\n")
+	c.Check(client.watchedActionTag.Id(), gc.Equals, validActionId)
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
@@ -419,7 +419,10 @@ Where 'bar' and 'baz' are resources named in the metadata for the 'foo' charm.
 
 When using a placement directive to deploy to an existing machine or container
 ('--to' option), the ` + "`juju status`" + ` command should be used for guidance. A few
-placement directives are provider-dependent (e.g.: 'zone').
+placement directives are provider-dependent (e.g.: 'zone'). The
+'affinity:<application>' and 'anti-affinity:<application>' directives start
+new machines close to, or away from, the machines of the named application;
+where the provider has no native support for this, availability zones are used.
 
 In more complex scenarios, Juju's network spaces are used to partition the
 cloud networking layer into sets of subnets. Instances hosting units inside the
@@ -11,8 +11,10 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/entityfinder"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -26,6 +28,10 @@ type removeApplicationCommand struct {
 	modelcmd.ModelCommandBase
 	DestroyStorage   bool
 	ApplicationNames []string
+
+	// entityFinder is used to suggest applications in
+	// place of those that are not found.
+	entityFinder common.EntityFinder
 }
 
 var helpSummaryRmApp = `
@@ -87,6 +93,7 @@ func (c *removeApplicationCommand) getAPI() (removeApplicationAPI, int, error) {
 		return nil, -1, errors.Trace(err)
 	}
 	version := root.BestFacadeVersion("Application")
+	c.entityFinder = entityfinder.NewClient(root)
 	return application.NewClient(root), version, nil
 }
 
@@ -136,7 +143,9 @@ func (c *removeApplicationCommand) removeApplications(
 	for i, name := range c.ApplicationNames {
 		result := results[i]
 		if result.Error != nil {
-			ctx.Infof("removing application %s failed: %s", name, result.Error)
+			ctx.Infof("removing application %s failed: %s", name,
+				common.ErrorWithSuggestion(c.entityFinder, result.Error, name, names.ApplicationTagKind),
+			)
 			anyFailed = true
 			continue
 		}
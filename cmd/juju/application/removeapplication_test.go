@@ -155,6 +155,17 @@ removing application gargleblaster failed: application "gargleblaster" not found
 `[1:])
 }
 
+func (s *RemoveApplicationSuite) TestFailureSuggestsApplications(c *gc.C) {
+	s.setupTestApplication(c)
+	ctx, err := runRemoveApplication(c, "multi-serie")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	stderr := cmdtesting.Stderr(ctx)
+	c.Assert(stderr, gc.Equals, `
+removing application multi-serie failed: application "multi-serie" not found; did you mean "multi-series"?
+`[1:])
+}
+
 func (s *RemoveApplicationSuite) TestInvalidArgs(c *gc.C) {
 	_, err := runRemoveApplication(c)
 	c.Assert(err, gc.ErrorMatches, `no application specified`)
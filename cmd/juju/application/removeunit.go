@@ -10,7 +10,9 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/entityfinder"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -23,7 +25,12 @@ func NewRemoveUnitCommand() modelcmd.ModelCommand {
 type removeUnitCommand struct {
 	modelcmd.ModelCommandBase
 	DestroyStorage bool
+	Force          bool
 	UnitNames      []string
+
+	// entityFinder is used to suggest units in place
+	// of those that are not found.
+	entityFinder common.EntityFinder
 }
 
 const removeUnitDoc = `
@@ -37,6 +44,11 @@ model.
 Juju will also remove the machine if the removed unit was the only unit left
 on that machine (including units in containers).
 
+If the unit's application has a stop grace period, the unit is given that
+long to run its stop hook before its machine is removed regardless. The
+--force option overrides the grace period, so that the machine is removed
+without waiting for the stop hook.
+
 Removing all units of a application is not equivalent to removing the
 application itself; for that, the ` + "`juju remove-application`" + ` command
 is used.
@@ -44,6 +56,7 @@ is used.
 Examples:
 
     juju remove-unit wordpress/2 wordpress/3 wordpress/4
+    juju remove-unit --force wordpress/5
 
 See also:
     remove-application
@@ -61,6 +74,7 @@ func (c *removeUnitCommand) Info() *cmd.Info {
 func (c *removeUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.DestroyStorage, "destroy-storage", false, "Destroy storage attached to the unit")
+	f.BoolVar(&c.Force, "force", false, "Remove the unit's machine without waiting for its stop grace period")
 }
 
 func (c *removeUnitCommand) Init(args []string) error {
@@ -82,6 +96,7 @@ func (c *removeUnitCommand) getAPI() (removeApplicationAPI, int, error) {
 		return nil, -1, errors.Trace(err)
 	}
 	version := root.BestFacadeVersion("Application")
+	c.entityFinder = entityfinder.NewClient(root)
 	return application.NewClient(root), version, nil
 }
 
@@ -100,6 +115,9 @@ func (c *removeUnitCommand) Run(ctx *cmd.Context) error {
 	if c.DestroyStorage && apiVersion < 5 {
 		return errors.New("--destroy-storage is not supported by this controller")
 	}
+	if c.Force && apiVersion < 12 {
+		return errors.New("--force is not supported by this controller")
+	}
 	return c.removeUnits(ctx, client)
 }
 
@@ -114,6 +132,7 @@ func (c *removeUnitCommand) removeUnits(ctx *cmd.Context, client removeApplicati
 	results, err := client.DestroyUnits(application.DestroyUnitsParams{
 		Units:          c.UnitNames,
 		DestroyStorage: c.DestroyStorage,
+		Force:          c.Force,
 	})
 	if err != nil {
 		return block.ProcessBlockedError(err, block.BlockRemove)
@@ -123,7 +142,9 @@ func (c *removeUnitCommand) removeUnits(ctx *cmd.Context, client removeApplicati
 		result := results[i]
 		if result.Error != nil {
 			anyFailed = true
-			ctx.Infof("removing unit %s failed: %s", name, result.Error)
+			ctx.Infof("removing unit %s failed: %s", name,
+				common.ErrorWithSuggestion(c.entityFinder, result.Error, name, names.UnitTagKind),
+			)
 			continue
 		}
 		ctx.Infof("removing unit %s", name)
@@ -143,6 +164,11 @@ func (c *removeUnitCommand) removeUnits(ctx *cmd.Context, client removeApplicati
 			}
 			ctx.Infof("- will detach %s", names.ReadableString(storageTag))
 		}
+		if result.Info.StopGracePeriodOverridden {
+			ctx.Infof("- stop grace period overridden by --force")
+		} else if period := result.Info.StopGracePeriod; period > 0 {
+			ctx.Infof("- will wait up to %v for the unit to stop", period)
+		}
 	}
 	if anyFailed {
 		return cmd.ErrSilent
@@ -5,6 +5,7 @@ package application
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -54,7 +55,7 @@ func (s *RemoveUnitSuite) TestRemoveUnit(c *gc.C) {
 	c.Assert(stderr, gc.Equals, `
 removing unit multi-series/0
 removing unit multi-series/1
-removing unit multi-series/2 failed: unit "multi-series/2" does not exist
+removing unit multi-series/2 failed: unit "multi-series/2" does not exist; did you mean "multi-series/0" or "multi-series/1"?
 removing unit sillybilly/17 failed: unit "sillybilly/17" does not exist
 `[1:])
 
@@ -100,6 +101,26 @@ removing unit storage-filesystem/0
 `[1:], action))
 }
 
+func (s *RemoveUnitSuite) TestRemoveUnitStopGracePeriod(c *gc.C) {
+	app := s.setupUnitForRemove(c)
+	err := app.SetStopGracePeriod(5 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := runRemoveUnit(c, "multi-series/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing unit multi-series/0
+- will wait up to 5m0s for the unit to stop
+`[1:])
+
+	ctx, err = runRemoveUnit(c, "--force", "multi-series/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing unit multi-series/1
+- stop grace period overridden by --force
+`[1:])
+}
+
 func (s *RemoveUnitSuite) TestBlockRemoveUnit(c *gc.C) {
 	app := s.setupUnitForRemove(c)
 
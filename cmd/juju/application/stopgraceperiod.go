@@ -0,0 +1,100 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSetStopGracePeriodSummary = `
+Sets how long an application's units are given to stop when removed.`[1:]
+
+var usageSetStopGracePeriodDetails = `
+When a unit of the application is removed, its stop hook is given up to
+the grace period to drain the workload before the unit's machine is torn
+down. A stop hook still running at the end of the grace period is killed.
+A grace period of 0 removes the limit, so that machines are torn down as
+soon as their units have stopped, however long that takes.
+
+The grace period can be overridden with "juju remove-unit --force".
+
+Examples:
+    juju set-stop-grace-period postgresql 10m
+    juju set-stop-grace-period postgresql 0
+
+See also:
+    remove-unit`[1:]
+
+// NewSetStopGracePeriodCommand returns a command to set the stop grace
+// period of an application.
+func NewSetStopGracePeriodCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&setStopGracePeriodCommand{})
+}
+
+// setStopGracePeriodCommand is responsible for setting the stop grace
+// period of an application.
+type setStopGracePeriodCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	StopGracePeriod time.Duration
+}
+
+func (c *setStopGracePeriodCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-stop-grace-period",
+		Args:    "<application name> <duration>",
+		Purpose: usageSetStopGracePeriodSummary,
+		Doc:     usageSetStopGracePeriodDetails,
+	}
+}
+
+func (c *setStopGracePeriodCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application name specified")
+	case 1:
+		return errors.New("no stop grace period specified")
+	}
+	c.ApplicationName = args[0]
+	period, err := time.ParseDuration(args[1])
+	if err != nil {
+		return errors.Annotate(err, "invalid stop grace period")
+	}
+	if period < 0 {
+		return errors.Errorf("invalid stop grace period: negative duration %v", period)
+	}
+	c.StopGracePeriod = period
+	return cmd.CheckEmpty(args[2:])
+}
+
+type stopGracePeriodAPI interface {
+	Close() error
+	SetStopGracePeriod(application string, period time.Duration) error
+}
+
+func (c *setStopGracePeriodCommand) getAPI() (stopGracePeriodAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run sets the application's stop grace period.
+func (c *setStopGracePeriodCommand) Run(_ *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.SetStopGracePeriod(c.ApplicationName, c.StopGracePeriod)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
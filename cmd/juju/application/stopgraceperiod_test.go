@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type SetStopGracePeriodSuite struct {
+	jujutesting.RepoSuite
+	testing.CmdBlockHelper
+}
+
+func (s *SetStopGracePeriodSuite) SetUpTest(c *gc.C) {
+	s.RepoSuite.SetUpTest(c)
+	s.CmdBlockHelper = testing.NewCmdBlockHelper(s.APIState)
+	c.Assert(s.CmdBlockHelper, gc.NotNil)
+	s.AddCleanup(func(*gc.C) { s.CmdBlockHelper.Close() })
+}
+
+var _ = gc.Suite(&SetStopGracePeriodSuite{})
+
+func runSetStopGracePeriod(c *gc.C, args ...string) error {
+	_, err := cmdtesting.RunCommand(c, NewSetStopGracePeriodCommand(), args...)
+	return err
+}
+
+func (s *SetStopGracePeriodSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"wordpress"},
+		err:  "no stop grace period specified",
+	}, {
+		args: []string{"wordpress", "soon"},
+		err:  `invalid stop grace period: time: invalid duration "?soon"?`,
+	}, {
+		args: []string{"wordpress", "-1m"},
+		err:  "invalid stop grace period: negative duration -1m0s",
+	}, {
+		args: []string{"wordpress", "1m", "2m"},
+		err:  `unrecognized args: \["2m"\]`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		err := runSetStopGracePeriod(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SetStopGracePeriodSuite) TestSetStopGracePeriod(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "some-application-name"})
+
+	err := runSetStopGracePeriod(c, "some-application-name", "10m")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.StopGracePeriod(), gc.Equals, 10*time.Minute)
+
+	err = runSetStopGracePeriod(c, "some-application-name", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.StopGracePeriod(), gc.Equals, time.Duration(0))
+}
+
+func (s *SetStopGracePeriodSuite) TestBlockSetStopGracePeriod(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "some-application-name"})
+
+	// Block operation
+	s.BlockAllChanges(c, "TestBlockSetStopGracePeriod")
+
+	err := runSetStopGracePeriod(c, "some-application-name", "10m")
+	s.AssertBlocked(c, err, ".*TestBlockSetStopGracePeriod.*")
+}
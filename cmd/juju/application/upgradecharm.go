@@ -121,6 +121,14 @@ type upgradeCharmCommand struct {
 	// Storage is a map of storage constraints, keyed on the storage name
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
+
+	// BatchSize, if positive, upgrades the application's units in
+	// batches of this size rather than all at once.
+	BatchSize int
+
+	// Readiness determines when a batch of units is ready and the
+	// next may be upgraded.
+	Readiness string
 }
 
 const upgradeCharmDoc = `
@@ -191,6 +199,14 @@ is determined by the contents of the charm at the specified path.
 number with --switch, give it in the charm URL, for instance "cs:wordpress-5"
 would specify revision number 5 of the wordpress charm.
 
+The units of an application may be upgraded in batches, rather than all at
+once, by specifying the --batch-size flag. The next batch is upgraded once
+every unit of the current batch has reported an active workload status or,
+if --readiness is "action:<name>", once the named action has completed on
+each of them. A failed readiness action pauses the upgrade.
+
+  juju upgrade-charm foo --batch-size 2 --readiness action:health-check
+
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
@@ -216,6 +232,8 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "Upgrade units in batches of this size")
+	f.StringVar(&c.Readiness, "readiness", "", `When a batch of units is ready: "workload-active" or "action:<name>"`)
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -239,6 +257,12 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.BatchSize < 0 {
+		return errors.Errorf("--batch-size must be positive")
+	}
+	if c.Readiness != "" && c.BatchSize == 0 {
+		return errors.Errorf("--readiness requires --batch-size")
+	}
 	return nil
 }
 
@@ -343,6 +367,12 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
 	}
+	if c.BatchSize > 0 {
+		cfg.RollingUpgrade = &params.RollingCharmUpgrade{
+			BatchSize: c.BatchSize,
+			Readiness: c.Readiness,
+		}
+	}
 	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
 }
 
@@ -230,6 +230,30 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) TestRollingUpgrade(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2", "--readiness", "action:health-check")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharm")
+	s.charmUpgradeClient.CheckCall(c, 2, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		RollingUpgrade: &params.RollingCharmUpgrade{
+			BatchSize: 2,
+			Readiness: "action:health-check",
+		},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeInvalid(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "-1")
+	c.Assert(err, gc.ErrorMatches, "--batch-size must be positive")
+	_, err = s.runUpgradeCharm(c, "foo", "--readiness", "workload-active")
+	c.Assert(err, gc.ErrorMatches, "--readiness requires --batch-size")
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
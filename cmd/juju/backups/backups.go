@@ -25,6 +25,9 @@ type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
 	Create(notes string) (*params.BackupsMetadataResult, error)
+	// CreateToTarget sends an RPC request to create a new backup,
+	// streamed to the controller's backup target.
+	CreateToTarget(notes string) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -85,6 +88,9 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
 	fmt.Fprintf(ctx.Stdout, "created on host: %q\n", result.Hostname)
 	fmt.Fprintf(ctx.Stdout, "juju version:    %v\n", result.Version)
+	if result.Target != "" {
+		fmt.Fprintf(ctx.Stdout, "target:          %q\n", result.Target)
+	}
 }
 
 // ArchiveReader can read a backup archive.
@@ -44,6 +44,12 @@ Therefore, you should use the --download or --filename options, or use:
 to get a local copy of the backup archive.
 This local copy can then be used to restore an model even if that
 model was already destroyed or is otherwise unavailable.
+
+If the controller has a backup target configured (see the
+backup-target-* controller config settings), the --to-target option
+streams the backup archive from the controller directly to that object
+storage, rather than storing it on the controller and downloading it.
+The reference to the stored archive is printed after the backup's ID.
 `
 
 // NewCreateCommand returns a command used to create backups.
@@ -60,6 +66,9 @@ type createCommand struct {
 	Filename string
 	// Notes is the custom message to associated with the new backup.
 	Notes string
+	// ToTarget means the backup archive should be streamed to the
+	// controller's backup target.
+	ToTarget bool
 }
 
 // Info implements Command.Info.
@@ -77,6 +86,7 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.BoolVar(&c.ToTarget, "to-target", false, "Stream the archive to the controller's backup target")
 }
 
 // Init implements Command.Init.
@@ -93,6 +103,9 @@ func (c *createCommand) Init(args []string) error {
 	if c.Filename == "" {
 		return errors.Errorf("missing filename")
 	}
+	if c.ToTarget && c.Filename != notset {
+		return errors.Errorf("cannot mix --to-target and --filename")
+	}
 
 	return nil
 }
@@ -110,6 +123,10 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.ToTarget {
+		return c.createToTarget(ctx, client)
+	}
+
 	result, err := client.Create(c.Notes)
 	if err != nil {
 		return errors.Trace(err)
@@ -135,6 +152,21 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// createToTarget creates a backup which the controller streams to its
+// backup target, so there is nothing to download.
+func (c *createCommand) createToTarget(ctx *cmd.Context, client APIClient) error {
+	result, err := client.CreateToTarget(c.Notes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.Log != nil && !c.Log.Quiet {
+		c.dumpMetadata(ctx, result)
+	}
+	fmt.Fprintln(ctx.Stdout, result.ID)
+	fmt.Fprintln(ctx.Stdout, "streamed to "+result.Target)
+	return nil
+}
+
 func (c *createCommand) decideFilename(ctx *cmd.Context, filename string, timestamp time.Time) string {
 	if filename != notset {
 		return filename
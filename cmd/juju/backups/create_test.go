@@ -133,6 +133,24 @@ func (s *createSuite) TestFilenameAndNoDownload(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "cannot mix --no-download and --filename")
 }
 
+func (s *createSuite) TestToTarget(c *gc.C) {
+	client := s.setSuccess()
+	s.metaresult.Target = "s3://juju-backups/juju-backup.tar.gz"
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--to-target", "--quiet")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "CreateToTarget")
+	out := s.metaresult.ID + "\nstreamed to s3://juju-backups/juju-backup.tar.gz\n"
+	s.checkStd(c, ctx, out, "")
+}
+
+func (s *createSuite) TestFilenameAndToTarget(c *gc.C) {
+	s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--to-target", "--filename", "backup.tgz")
+
+	c.Check(err, gc.ErrorMatches, "cannot mix --to-target and --filename")
+}
+
 func (s *createSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand)
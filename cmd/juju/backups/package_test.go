@@ -141,6 +141,16 @@ func (c *fakeAPIClient) Create(notes string) (*params.BackupsMetadataResult, err
 	return c.metaresult, nil
 }
 
+func (c *fakeAPIClient) CreateToTarget(notes string) (*params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "CreateToTarget")
+	c.args = append(c.args, "notes")
+	c.notes = notes
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.metaresult, nil
+}
+
 func (c *fakeAPIClient) Info(id string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Info")
 	c.args = append(c.args, "id")
@@ -36,7 +36,7 @@ func (s *showSuite) TestShow(c *gc.C) {
 defined: public
 type: ec2
 description: Amazon China
-auth-types: [access-key]
+auth-types: [access-key, instance-role]
 regions:
   cn-north-1:
     endpoint: https://ec2.cn-north-1.amazonaws.com.cn
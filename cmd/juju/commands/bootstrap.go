@@ -15,6 +15,7 @@ import (
 	"github.com/juju/gnuflag"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/featureflag"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -23,6 +24,7 @@ import (
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
@@ -104,6 +106,12 @@ In other words, a 2.2.1 client can bootstrap any 2.2.x agents but cannot
 bootstrap any 2.0.x or 2.1.x agents.
 The agent version can be specified a simple numeric version, e.g. 2.2.4.
 
+If '--preflight' is used, bootstrap checks that the cloud accepts the
+credential, that the agent and image metadata endpoints can be reached,
+that the local clock is accurate, and that suitable images and agent
+binaries exist, then reports the outcome of each check. No resources
+are created and no controller is recorded.
+
 Examples:
     juju bootstrap
     juju bootstrap --clouds
@@ -114,6 +122,7 @@ Examples:
     juju bootstrap --config=~/config-rs.yaml rackspace joe-syd
     juju bootstrap --agent-version=2.2.4 aws joe-us-east-1
     juju bootstrap --config bootstrap-timeout=1200 azure joe-eastus
+    juju bootstrap --preflight aws/us-east-1
 
 See also:
     add-credentials
@@ -165,6 +174,7 @@ type bootstrapCommand struct {
 	noGUI               bool
 	noSwitch            bool
 	interactive         bool
+	preflight           bool
 }
 
 func (c *bootstrapCommand) Info() *cmd.Info {
@@ -199,6 +209,7 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.showRegionsForCloud, "regions", "", "Print the available regions for the specified cloud")
 	f.BoolVar(&c.noGUI, "no-gui", false, "Do not install the Juju GUI in the controller when bootstrapping")
 	f.BoolVar(&c.noSwitch, "no-switch", false, "Do not switch to the newly created controller")
+	f.BoolVar(&c.preflight, "preflight", false, "Check that bootstrap would succeed, without creating a controller")
 }
 
 func (c *bootstrapCommand) Init(args []string) (err error) {
@@ -310,6 +321,7 @@ var getBootstrapFuncs = func() BootstrapInterface {
 
 var (
 	bootstrapPrepare           = bootstrap.Prepare
+	bootstrapPreflight         = bootstrap.Preflight
 	environsDestroy            = environs.Destroy
 	waitForAgentInitialisation = common.WaitForAgentInitialisation
 )
@@ -414,6 +426,19 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 		return errors.Trace(err)
 	}
 
+	cloudSpec := environs.CloudSpec{
+		Type:             cloud.Type,
+		Name:             cloud.Name,
+		Region:           region.Name,
+		Endpoint:         region.Endpoint,
+		IdentityEndpoint: region.IdentityEndpoint,
+		StorageEndpoint:  region.StorageEndpoint,
+		Credential:       credentials.credential,
+	}
+	if c.preflight {
+		return c.runPreflight(ctx, config.bootstrapModel, cloudSpec)
+	}
+
 	// Read existing current controller so we can clean up on error.
 	var oldCurrentController string
 	store := c.ClientStore()
@@ -450,17 +475,9 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 			ModelConfig:      config.bootstrapModel,
 			ControllerConfig: config.controller,
 			ControllerName:   c.controllerName,
-			Cloud: environs.CloudSpec{
-				Type:             cloud.Type,
-				Name:             cloud.Name,
-				Region:           region.Name,
-				Endpoint:         region.Endpoint,
-				IdentityEndpoint: region.IdentityEndpoint,
-				StorageEndpoint:  region.StorageEndpoint,
-				Credential:       credentials.credential,
-			},
-			CredentialName: credentials.name,
-			AdminSecret:    config.bootstrap.AdminSecret,
+			Cloud:            cloudSpec,
+			CredentialName:   credentials.name,
+			AdminSecret:      config.bootstrap.AdminSecret,
 		},
 	)
 	if err != nil {
@@ -632,6 +649,35 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	return waitForAgentInitialisation(ctx, &c.ModelCommandBase, c.controllerName, c.hostedModelName)
 }
 
+// runPreflight checks whether bootstrap would succeed, reporting the
+// outcome of each check.
+func (c *bootstrapCommand) runPreflight(ctx *cmd.Context, modelConfig map[string]interface{}, cloudSpec environs.CloudSpec) error {
+	report, err := bootstrapPreflight(modelcmd.BootstrapContext(ctx), bootstrap.PreflightParams{
+		ModelConfig:          modelConfig,
+		Cloud:                cloudSpec,
+		BootstrapSeries:      c.BootstrapSeries,
+		BootstrapImage:       c.BootstrapImage,
+		BootstrapConstraints: c.BootstrapConstraints,
+		AgentVersion:         c.AgentVersion,
+		Clock:                clock.WallClock,
+	})
+	if err != nil {
+		return errors.Annotate(err, "running preflight checks")
+	}
+	tw := output.TabWriter(ctx.Stdout)
+	fmt.Fprintln(tw, "Check\tResult\tDetails")
+	for _, result := range report {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Check, result.Status, result.Message)
+	}
+	tw.Flush()
+	if !report.Passed() {
+		ctx.Infof("Preflight checks failed; bootstrap is likely to fail.")
+		return cmd.ErrSilent
+	}
+	ctx.Infof("Preflight checks passed.")
+	return nil
+}
+
 func (c *bootstrapCommand) handleCommandLineErrorsAndInfoRequests(ctx *cmd.Context) (bool, error) {
 	if c.BootstrapImage != "" {
 		if c.BootstrapSeries == "" {
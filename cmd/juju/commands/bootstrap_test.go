@@ -504,6 +504,21 @@ func (s *BootstrapSuite) TestBootstrapDefaultControllerNameNoRegions(c *gc.C) {
 	c.Assert(currentController, gc.Equals, "no-cloud-regions")
 }
 
+func (s *BootstrapSuite) TestBootstrapLocalDev(c *gc.C) {
+	s.setupAutoUploadTest(c, "1.8.3", "raring")
+
+	_, err := cmdtesting.RunCommand(c, s.newBootstrapCommand(), "localdev", "--auto-upgrade")
+	c.Assert(err, jc.ErrorIsNil)
+	currentController := s.store.CurrentControllerName
+	c.Assert(currentController, gc.Equals, "localdev-dummy")
+	details, err := s.store.ControllerByName(currentController)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details.Cloud, gc.Equals, "localdev")
+	bootstrapConfig, err := s.store.BootstrapConfigForController(currentController)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bootstrapConfig.CloudType, gc.Equals, "dummy")
+}
+
 func (s *BootstrapSuite) TestBootstrapSetsCurrentModel(c *gc.C) {
 	s.setupAutoUploadTest(c, "1.8.3", "raring")
 
@@ -525,6 +540,51 @@ func (s *BootstrapSuite) TestNoSwitch(c *gc.C) {
 	c.Assert(s.store.CurrentControllerName, gc.Equals, "")
 }
 
+func (s *BootstrapSuite) TestPreflight(c *gc.C) {
+	var params bootstrap.PreflightParams
+	s.PatchValue(&bootstrapPreflight, func(_ environs.BootstrapContext, args bootstrap.PreflightParams) (bootstrap.PreflightReport, error) {
+		params = args
+		return bootstrap.PreflightReport{
+			{Check: "credentials", Status: bootstrap.PreflightPassed, Message: "credential accepted"},
+			{Check: "quota", Status: bootstrap.PreflightSkipped, Message: "not reported"},
+		}, nil
+	})
+	s.PatchValue(&bootstrapPrepare, func(environs.BootstrapContext, jujuclient.ClientStore, bootstrap.PrepareParams) (environs.Environ, error) {
+		c.Fatalf("unexpected prepare")
+		return nil, nil
+	})
+
+	ctx, err := cmdtesting.RunCommand(c, s.newBootstrapCommand(), "dummy", "devcontroller", "--preflight", "--bootstrap-series", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Check        Result   Details
+credentials  passed   credential accepted
+quota        skipped  not reported
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, "(?s).*Preflight checks passed.\n")
+	c.Assert(params.Cloud.Name, gc.Equals, "dummy")
+	c.Assert(params.BootstrapSeries, gc.Equals, "xenial")
+	c.Assert(params.ModelConfig["type"], gc.Equals, "dummy")
+	c.Assert(s.store.Controllers, gc.HasLen, 0)
+}
+
+func (s *BootstrapSuite) TestPreflightFailure(c *gc.C) {
+	s.PatchValue(&bootstrapPreflight, func(environs.BootstrapContext, bootstrap.PreflightParams) (bootstrap.PreflightReport, error) {
+		return bootstrap.PreflightReport{
+			{Check: "clock", Status: bootstrap.PreflightFailed, Message: "too slow"},
+		}, nil
+	})
+
+	ctx, err := cmdtesting.RunCommand(c, s.newBootstrapCommand(), "dummy", "devcontroller", "--preflight")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Check  Result  Details
+clock  failed  too slow
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, "(?s).*Preflight checks failed; bootstrap is likely to fail.\n")
+	c.Assert(s.store.Controllers, gc.HasLen, 0)
+}
+
 func (s *BootstrapSuite) TestBootstrapSetsControllerDetails(c *gc.C) {
 	s.setupAutoUploadTest(c, "1.8.3", "raring")
 
@@ -1704,6 +1764,7 @@ google
 joyent                                       
 oracle                                       
 rackspace                                    
+localdev                                     
 localhost                                    
 dummy-cloud                     joe          home
 dummy-cloud-with-config                      
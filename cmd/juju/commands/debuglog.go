@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -49,14 +50,31 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-message' and '--exclude-message' options filter by regular
+expressions matched against the log message.
+
+The '--include-label' and '--exclude-label' options filter by label
+selectors. A selector is a comma separated list of label=value pairs, all of
+which must match. The supported labels are "kind" (machine, unit or
+application), "application" and "version" (the Juju version of the agent).
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
+* All --include-message options are logically ORed together.
+* All --exclude-message options are logically ORed together.
+* All --include-label options are logically ORed together.
+* All --exclude-label options are logically ORed together.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --include-message, --exclude-message, --include-label and --exclude-label
   selections are logically ANDed to form the complete filter.
 
+The filtering is done by the controller, so only the selected messages are
+sent. The '--max-backlog-bytes' option additionally limits the initial lines
+to the most recent of them totalling at most that many bytes.
+
 Examples:
 
 Exclude all machine 0 messages; show a maximum of 100 lines; and continue to
@@ -89,6 +107,18 @@ machine-3 or machine-4, and then stop:
         --exclude machine-3 \
         --exclude machine-4 
 
+Show the messages from all mysql units that mention a hook failing, except
+those from units still running Juju 2.2.6:
+
+    juju debug-log --include-label kind=unit,application=mysql \
+        --include-message 'hook .* failed' \
+        --exclude-label version=2.2.6
+
+Show at most 500 of the most recent lines, but no more than 64KB of them,
+and continue to append filtered messages:
+
+    juju debug-log --lines 500 --max-backlog-bytes 65536
+
 To see all WARNING and ERROR messages and then continue showing any
 new WARNING and ERROR messages as they are logged:
 
@@ -141,12 +171,17 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeMessage), "include-message", "Only show log messages matching these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeMessage), "exclude-message", "Do not show log messages matching these regular expressions")
+	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeLabel), "include-label", "Only show log messages matching these label selectors")
+	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeLabel), "exclude-label", "Do not show log messages matching these label selectors")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
 
 	f.UintVar(&c.params.Backlog, "n", defaultLineCount, "Show this many of the most recent (possibly filtered) lines, and continue to append")
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
+	f.UintVar(&c.params.MaxBacklogBytes, "max-backlog-bytes", 0, "Limit the most recent lines shown to this many bytes of log messages")
 	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many of the most recent (possibly filtered) lines are shown")
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
 
@@ -169,6 +204,11 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	for _, pattern := range append(c.params.IncludeMessage, c.params.ExcludeMessage...) {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return errors.Errorf("message filter %q is not a valid regular expression", pattern)
+		}
+	}
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
@@ -88,6 +88,32 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				ExcludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
 			},
+		}, {
+			args: []string{"--include-message", "^hook", "--exclude-message", "failed$"},
+			expected: common.DebugLogParams{
+				IncludeMessage: []string{"^hook"},
+				ExcludeMessage: []string{"failed$"},
+				Backlog:        10,
+			},
+		}, {
+			args:     []string{"--include-message", "(hook"},
+			errMatch: `message filter "\(hook" is not a valid regular expression`,
+		}, {
+			args: []string{
+				"--include-label", "kind=unit,application=mysql",
+				"--include-label", "kind=machine",
+				"--exclude-label", "version=2.2.6"},
+			expected: common.DebugLogParams{
+				IncludeLabel: []string{"kind=unit,application=mysql", "kind=machine"},
+				ExcludeLabel: []string{"version=2.2.6"},
+				Backlog:      10,
+			},
+		}, {
+			args: []string{"--max-backlog-bytes", "4096"},
+			expected: common.DebugLogParams{
+				Backlog:         10,
+				MaxBacklogBytes: 4096,
+			},
 		}, {
 			args: []string{"--replay"},
 			expected: common.DebugLogParams{
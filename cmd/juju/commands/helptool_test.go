@@ -31,9 +31,12 @@ Show help on a Juju charm tool.
 var expectedCommands = []string{
 	"action-fail",
 	"action-get",
+	"action-log",
 	"action-set",
 	"add-metric",
 	"application-version-set",
+	"charm-state-export",
+	"charm-state-import",
 	"close-port",
 	"config-get",
 	"is-leader",
@@ -44,7 +47,9 @@ var expectedCommands = []string{
 	"network-get",
 	"open-port",
 	"opened-ports",
+	"payload-list",
 	"payload-register",
+	"payload-status",
 	"payload-status-set",
 	"payload-unregister",
 	"relation-get",
@@ -53,6 +58,7 @@ var expectedCommands = []string{
 	"relation-set",
 	"resource-get",
 	"status-get",
+	"status-progress",
 	"status-set",
 	"storage-add",
 	"storage-get",
@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/manual"
+)
+
+var usageListManualHostKeysSummary = `
+Lists the SSH host keys stored for manually provisioned hosts.`[1:]
+
+var usageListManualHostKeysDetails = `
+Juju verifies the identity of a host being manually provisioned with
+"juju add-machine ssh:[user@]host" against the SSH host keys stored for
+it in the controller. This command displays the keys stored for all hosts
+in the current (or specified) model, or for the host given. The keys are
+displayed in SSH known_hosts format.
+
+Examples:
+    juju manual-host-keys
+    juju manual-host-keys 10.10.0.3
+
+See also:
+    set-manual-host-keys
+    remove-manual-host-keys
+    add-machine`[1:]
+
+// NewListManualHostKeysCommand returns a command used to list the SSH
+// host keys stored for manually provisioned hosts.
+func NewListManualHostKeysCommand() cmd.Command {
+	return modelcmd.Wrap(&listManualHostKeysCommand{})
+}
+
+// listManualHostKeysCommand is used to list the SSH host keys stored
+// for manually provisioned hosts.
+type listManualHostKeysCommand struct {
+	ManualHostKeysBase
+	host string
+}
+
+// Info implements Command.Info.
+func (c *listManualHostKeysCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "manual-host-keys",
+		Args:    "[<host>]",
+		Purpose: usageListManualHostKeysSummary,
+		Doc:     usageListManualHostKeysDetails,
+		Aliases: []string{"list-manual-host-keys"},
+	}
+}
+
+// Init implements Command.Init.
+func (c *listManualHostKeysCommand) Init(args []string) (err error) {
+	c.host, err = cmd.ZeroOrOneArgs(args)
+	return err
+}
+
+// Run implements Command.Run.
+func (c *listManualHostKeysCommand) Run(context *cmd.Context) error {
+	client, err := c.NewSSHClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var keys map[string][]string
+	if c.host != "" {
+		hostKeys, err := client.ManualHostKeys(c.host)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		keys = map[string][]string{c.host: hostKeys}
+	} else {
+		keys, err = client.ListManualHostKeys()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(keys) == 0 {
+		context.Infof("No host keys to display.")
+		return nil
+	}
+	hosts := make([]string, 0, len(keys))
+	for host := range keys {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		if err := manual.WriteKnownHosts(context.Stdout, host, keys[host]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
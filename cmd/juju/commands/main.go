@@ -304,6 +304,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newSSHCommand(nil))
 	r.Register(newResolvedCommand())
 	r.Register(newDebugLogCommand())
+	r.Register(newOnChangeCommand())
 	r.Register(newDebugHooksCommand(nil))
 
 	// Configuration commands.
@@ -335,6 +336,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(NewImportKeysCommand())
 	r.Register(NewListKeysCommand())
 
+	// Manage the SSH host keys of manually provisioned hosts.
+	r.Register(NewListManualHostKeysCommand())
+	r.Register(NewSetManualHostKeysCommand())
+	r.Register(NewRemoveManualHostKeysCommand())
+
 	// Manage users and access
 	r.Register(user.NewAddCommand())
 	r.Register(user.NewChangePasswordCommand())
@@ -365,6 +371,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewWaitForCommand())
 
 	r.Register(newMigrateCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -388,6 +395,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewDeployCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
+	r.Register(application.NewSetStopGracePeriodCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
 
@@ -399,6 +407,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage storage
 	r.Register(storage.NewAddCommand())
 	r.Register(storage.NewListCommand())
+	r.Register(storage.NewDetachedCommand())
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
 	r.Register(storage.NewShowCommand())
@@ -406,6 +415,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
+	r.Register(storage.NewImportVolumeCommand(storage.NewStorageImporter, nil))
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
@@ -428,6 +428,7 @@ var commandNames = []string{
 	"destroy-controller",
 	"destroy-model",
 	"detach-storage",
+	"detached-storage",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -447,6 +448,7 @@ var commandNames = []string{
 	"help-tool",
 	"import-filesystem",
 	"import-ssh-key",
+	"import-volume",
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
@@ -456,9 +458,11 @@ var commandNames = []string{
 	"list-clouds",
 	"list-controllers",
 	"list-credentials",
+	"list-detached-storage",
 	"list-disabled-commands",
 	"list-firewall-rules",
 	"list-machines",
+	"list-manual-host-keys",
 	"list-models",
 	"list-offers",
 	"list-payloads",
@@ -475,6 +479,7 @@ var commandNames = []string{
 	"login",
 	"logout",
 	"machines",
+	"manual-host-keys",
 	"metrics",
 	"migrate",
 	"model-config",
@@ -483,6 +488,7 @@ var commandNames = []string{
 	"models",
 	"offer",
 	"offers",
+	"on-change",
 	"payloads",
 	"plans",
 	"regions",
@@ -495,6 +501,7 @@ var commandNames = []string{
 	"remove-cloud",
 	"remove-credential",
 	"remove-machine",
+	"remove-manual-host-keys",
 	"remove-offer",
 	"remove-relation",
 	"remove-ssh-key",
@@ -515,9 +522,11 @@ var commandNames = []string{
 	"set-default-credential",
 	"set-default-region",
 	"set-firewall-rule",
+	"set-manual-host-keys",
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
+	"set-stop-grace-period",
 	"set-wallet",
 	"show-action-output",
 	"show-action-status",
@@ -554,6 +563,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait-for",
 	"wallets",
 	"whoami",
 }
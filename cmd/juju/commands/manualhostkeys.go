@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/juju/api/sshclient"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// ManualHostKeysBase is embedded by the commands that manage the SSH
+// host keys stored for manually provisioned hosts.
+type ManualHostKeysBase struct {
+	modelcmd.ModelCommandBase
+}
+
+// NewSSHClient returns an SSHClient facade client for the root api
+// endpoint that the model command returns.
+func (c *ManualHostKeysBase) NewSSHClient() (*sshclient.Facade, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return sshclient.NewFacade(root), nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	sshtesting "github.com/juju/utils/ssh/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/osenv"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+func (s *SSHKeysSuite) TestHelpListManualHostKeys(c *gc.C) {
+	s.assertHelpOutput(c, "manual-host-keys", "[<host>]")
+}
+
+func (s *SSHKeysSuite) TestHelpSetManualHostKeys(c *gc.C) {
+	s.assertHelpOutput(c, "set-manual-host-keys", "<host> <ssh host key> ...")
+}
+
+func (s *SSHKeysSuite) TestHelpRemoveManualHostKeys(c *gc.C) {
+	s.assertHelpOutput(c, "remove-manual-host-keys", "<host> ...")
+}
+
+type ManualHostKeysSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&ManualHostKeysSuite{})
+
+func (s *ManualHostKeysSuite) SetUpSuite(c *gc.C) {
+	s.JujuConnSuite.SetUpSuite(c)
+	s.PatchEnvironment(osenv.JujuModelEnvKey, "controller")
+}
+
+func (s *ManualHostKeysSuite) TestListManualHostKeys(c *gc.C) {
+	err := s.State.SetManualHostKeys("10.0.0.2", state.SSHHostKeys{sshtesting.ValidKeyTwo.Key})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetManualHostKeys("10.0.0.1", state.SSHHostKeys{sshtesting.ValidKeyOne.Key})
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := cmdtesting.RunCommand(c, NewListManualHostKeysCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"10.0.0.1 "+sshtesting.ValidKeyOne.Key+"\n"+
+		"10.0.0.2 "+sshtesting.ValidKeyTwo.Key+"\n",
+	)
+
+	context, err = cmdtesting.RunCommand(c, NewListManualHostKeysCommand(), "10.0.0.2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "10.0.0.2 "+sshtesting.ValidKeyTwo.Key+"\n")
+}
+
+func (s *ManualHostKeysSuite) TestListManualHostKeysNone(c *gc.C) {
+	context, err := cmdtesting.RunCommand(c, NewListManualHostKeysCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "No host keys to display.\n")
+}
+
+func (s *ManualHostKeysSuite) TestListManualHostKeysNotFound(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewListManualHostKeysCommand(), "10.0.0.1")
+	c.Assert(err, gc.ErrorMatches, `keys for host "10.0.0.1" not found`)
+}
+
+func (s *ManualHostKeysSuite) TestSetManualHostKeys(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewSetManualHostKeysCommand(),
+		"10.0.0.1", sshtesting.ValidKeyOne.Key, sshtesting.ValidKeyTwo.Key,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err := s.State.ManualHostKeys("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, state.SSHHostKeys{
+		sshtesting.ValidKeyOne.Key,
+		sshtesting.ValidKeyTwo.Key,
+	})
+}
+
+func (s *ManualHostKeysSuite) TestSetManualHostKeysInvalid(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewSetManualHostKeysCommand(), "10.0.0.1", "not-a-key")
+	c.Assert(err, gc.ErrorMatches, `key "not-a-key" for host "10.0.0.1": .*`)
+}
+
+func (s *ManualHostKeysSuite) TestSetManualHostKeysInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewSetManualHostKeysCommand())
+	c.Assert(err, gc.ErrorMatches, "no host specified")
+	_, err = cmdtesting.RunCommand(c, NewSetManualHostKeysCommand(), "10.0.0.1")
+	c.Assert(err, gc.ErrorMatches, "no ssh host key specified")
+}
+
+func (s *ManualHostKeysSuite) TestRemoveManualHostKeys(c *gc.C) {
+	err := s.State.SetManualHostKeys("10.0.0.1", state.SSHHostKeys{sshtesting.ValidKeyOne.Key})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetManualHostKeys("10.0.0.2", state.SSHHostKeys{sshtesting.ValidKeyTwo.Key})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, NewRemoveManualHostKeysCommand(), "10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	all, err := s.State.AllManualHostKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, map[string]state.SSHHostKeys{
+		"10.0.0.2": {sshtesting.ValidKeyTwo.Key},
+	})
+}
+
+func (s *ManualHostKeysSuite) TestRemoveManualHostKeysInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, NewRemoveManualHostKeysCommand())
+	c.Assert(err, gc.ErrorMatches, "no host specified")
+}
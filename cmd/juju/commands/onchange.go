@@ -0,0 +1,425 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// defaultOnChangeDebounce is the default period of quiet that must
+// pass after a matching change before the command is run.
+const defaultOnChangeDebounce = time.Second
+
+var usageOnChangeSummary = `
+Runs a command whenever the model changes.`[1:]
+
+var usageOnChangeDetails = `
+Watches the model and runs the given command each time a change is seen
+that matches the supplied filters. The command is run locally, with its
+standard output and error connected to those of juju.
+
+The '--kind' option restricts the changes to entities of the given kind,
+one of: application, unit, machine, relation, action, annotation, block,
+model, remoteApplication or applicationOffer.
+
+The '--entity' option restricts the changes to entities whose ID matches
+the given shell pattern, such as "mysql/*" or "0".
+
+The '--status' option restricts the changes to entities entering the given
+status. For units, both the workload and agent status are considered; for
+machines, both the agent and instance status are considered. The command
+is only run when an entity enters the status, not while it remains in it.
+
+Each of the options may be specified more than once; changes matching any
+of the values of an option are selected, and the options are combined so
+that a change must satisfy all of them.
+
+Changes to the same entity that occur within the '--debounce' period are
+combined, so that the command is run once for the latest change.
+
+The following environment variables describe the change to the command:
+
+    JUJU_MODEL            the name of the model being watched
+    JUJU_EVENT_KIND       the kind of the changed entity
+    JUJU_EVENT_ID         the ID of the changed entity
+    JUJU_EVENT_TYPE       "change" or "remove"
+    JUJU_EVENT_STATUS     the status that matched, if any
+    JUJU_EVENT_MESSAGE    the message of the status that matched, if any
+    JUJU_EVENT_ENTITY     the JSON representation of the changed entity
+
+Examples:
+
+    juju on-change --kind unit --status error -- notify-send 'unit failed'
+
+    juju on-change --entity 'mysql/*' --debounce 10s -- ./mysql-changed.sh
+
+See also:
+    status
+    debug-log`
+
+// onChangeKinds holds the entity kinds that may be used to filter
+// changes.
+var onChangeKinds = set.NewStrings(
+	"action",
+	"annotation",
+	"application",
+	"applicationOffer",
+	"block",
+	"machine",
+	"model",
+	"relation",
+	"remoteApplication",
+	"unit",
+)
+
+func newOnChangeCommand() cmd.Command {
+	return modelcmd.Wrap(&onChangeCommand{
+		clock:      clock.WallClock,
+		runCommand: runOnChangeCommand,
+	})
+}
+
+// onChangeCommand runs a command for each matching change in a model.
+type onChangeCommand struct {
+	modelcmd.ModelCommandBase
+
+	filter   onChangeFilter
+	debounce time.Duration
+	command  []string
+
+	clock      clock.Clock
+	runCommand func(ctx *cmd.Context, command []string, env []string) error
+}
+
+func (c *onChangeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "on-change",
+		Args:    "<command> [<args>...]",
+		Purpose: usageOnChangeSummary,
+		Doc:     usageOnChangeDetails,
+	}
+}
+
+func (c *onChangeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.filter.kinds), "kind", "Only consider changes to entities of this kind")
+	f.Var(cmd.NewAppendStringsValue(&c.filter.entities), "entity", "Only consider changes to entities with IDs matching this pattern")
+	f.Var(cmd.NewAppendStringsValue(&c.filter.statuses), "status", "Only consider entities entering this status")
+	f.DurationVar(&c.debounce, "debounce", defaultOnChangeDebounce, "Combine changes to an entity that occur within this period")
+}
+
+func (c *onChangeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no command specified")
+	}
+	c.command = args
+	for _, kind := range c.filter.kinds {
+		if !onChangeKinds.Contains(kind) {
+			return errors.NotValidf("kind %q", kind)
+		}
+	}
+	for _, pattern := range c.filter.entities {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.NotValidf("entity pattern %q", pattern)
+		}
+	}
+	if c.debounce < 0 {
+		return errors.NotValidf("negative debounce period")
+	}
+	c.filter.lastStatus = make(map[multiwatcher.EntityId][]string)
+	return nil
+}
+
+// AllWatcher represents the watcher methods used by the on-change
+// command. It is implemented by *api.AllWatcher.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// OnChangeAPI represents the API methods used by the on-change command.
+type OnChangeAPI interface {
+	WatchAll() (AllWatcher, error)
+	Close() error
+}
+
+type onChangeClient struct {
+	*api.Client
+}
+
+func (c onChangeClient) WatchAll() (AllWatcher, error) {
+	return c.Client.WatchAll()
+}
+
+var getOnChangeAPI = func(c *onChangeCommand) (OnChangeAPI, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return onChangeClient{client}, nil
+}
+
+// Run watches the model, running the command for matching changes.
+func (c *onChangeCommand) Run(ctx *cmd.Context) error {
+	client, err := getOnChangeAPI(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer watcher.Stop()
+
+	modelName, err := c.ModelName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	deltas := make(chan []multiwatcher.Delta)
+	watchErr := make(chan error, 1)
+	go func() {
+		for {
+			d, err := watcher.Next()
+			if err != nil {
+				watchErr <- err
+				return
+			}
+			select {
+			case deltas <- d:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	var pending onChangeEvents
+	var timeout <-chan time.Time
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case err := <-watchErr:
+			return errors.Annotate(err, "watching model")
+		case d := <-deltas:
+			due := c.clock.Now().Add(c.debounce)
+			for _, delta := range d {
+				if event, ok := c.filter.match(delta); ok {
+					event.due = due
+					pending.add(event)
+				}
+			}
+		case <-timeout:
+		}
+		now := c.clock.Now()
+		for _, event := range pending.popDue(now) {
+			if err := c.runCommand(ctx, c.command, event.environ(modelName)); err != nil {
+				ctx.Infof("running command for %s %q: %v", event.kind, event.id, err)
+			}
+		}
+		timeout = nil
+		if next, ok := pending.nextDue(); ok {
+			timeout = c.clock.After(next.Sub(now))
+		}
+	}
+}
+
+func runOnChangeCommand(ctx *cmd.Context, command []string, env []string) error {
+	runCmd := exec.Command(command[0], command[1:]...)
+	runCmd.Dir = ctx.Dir
+	runCmd.Stdin = nil
+	runCmd.Stdout = ctx.Stdout
+	runCmd.Stderr = ctx.Stderr
+	runCmd.Env = append(os.Environ(), env...)
+	return runCmd.Run()
+}
+
+// onChangeFilter decides which model changes are of interest.
+type onChangeFilter struct {
+	kinds    []string
+	entities []string
+	statuses []string
+
+	// lastStatus records the statuses most recently seen for each
+	// entity, so that only entry into a status is reported.
+	lastStatus map[multiwatcher.EntityId][]string
+}
+
+// match returns the event describing the delta, and whether the delta
+// satisfies the filter.
+func (f *onChangeFilter) match(delta multiwatcher.Delta) (onChangeEvent, bool) {
+	id := delta.Entity.EntityId()
+	if len(f.kinds) > 0 && !set.NewStrings(f.kinds...).Contains(id.Kind) {
+		return onChangeEvent{}, false
+	}
+	if len(f.entities) > 0 && !matchesAnyPattern(f.entities, id.Id) {
+		return onChangeEvent{}, false
+	}
+	event := onChangeEvent{
+		key:     id,
+		kind:    id.Kind,
+		id:      id.Id,
+		removed: delta.Removed,
+		entity:  delta.Entity,
+	}
+	if len(f.statuses) == 0 {
+		return event, true
+	}
+
+	current := entityStatuses(delta.Entity)
+	previous := f.lastStatus[id]
+	if delta.Removed {
+		delete(f.lastStatus, id)
+		return onChangeEvent{}, false
+	}
+	f.lastStatus[id] = statusValues(current)
+	wanted := set.NewStrings(f.statuses...)
+	for _, info := range current {
+		value := string(info.Current)
+		if !wanted.Contains(value) || set.NewStrings(previous...).Contains(value) {
+			continue
+		}
+		event.status = value
+		event.message = info.Message
+		return event, true
+	}
+	return onChangeEvent{}, false
+}
+
+func matchesAnyPattern(patterns []string, id string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, id); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// entityStatuses returns the statuses held by the entity, if any.
+func entityStatuses(entity multiwatcher.EntityInfo) []multiwatcher.StatusInfo {
+	switch info := entity.(type) {
+	case *multiwatcher.UnitInfo:
+		return []multiwatcher.StatusInfo{info.WorkloadStatus, info.AgentStatus}
+	case *multiwatcher.MachineInfo:
+		return []multiwatcher.StatusInfo{info.AgentStatus, info.InstanceStatus}
+	case *multiwatcher.ApplicationInfo:
+		return []multiwatcher.StatusInfo{info.Status}
+	case *multiwatcher.RemoteApplicationInfo:
+		return []multiwatcher.StatusInfo{info.Status}
+	case *multiwatcher.ModelInfo:
+		return []multiwatcher.StatusInfo{info.Status}
+	}
+	return nil
+}
+
+func statusValues(statuses []multiwatcher.StatusInfo) []string {
+	values := make([]string, len(statuses))
+	for i, info := range statuses {
+		values[i] = string(info.Current)
+	}
+	return values
+}
+
+// onChangeEvent describes a change to a single entity.
+type onChangeEvent struct {
+	key     multiwatcher.EntityId
+	kind    string
+	id      string
+	removed bool
+	status  string
+	message string
+	entity  multiwatcher.EntityInfo
+
+	// due is the time after which the command should be run for
+	// the event, if no further change to the entity is seen.
+	due time.Time
+}
+
+// environ returns the environment variables that describe
+// the event to the command.
+func (e onChangeEvent) environ(modelName string) []string {
+	eventType := "change"
+	if e.removed {
+		eventType = "remove"
+	}
+	entityJSON, err := json.Marshal(e.entity)
+	if err != nil {
+		entityJSON = []byte("{}")
+	}
+	return []string{
+		"JUJU_MODEL=" + modelName,
+		"JUJU_EVENT_KIND=" + e.kind,
+		"JUJU_EVENT_ID=" + e.id,
+		"JUJU_EVENT_TYPE=" + eventType,
+		"JUJU_EVENT_STATUS=" + e.status,
+		"JUJU_EVENT_MESSAGE=" + e.message,
+		fmt.Sprintf("JUJU_EVENT_ENTITY=%s", entityJSON),
+	}
+}
+
+// onChangeEvents holds pending events in the order they were
+// first seen, holding only the latest event for each entity.
+type onChangeEvents []onChangeEvent
+
+func (events *onChangeEvents) add(event onChangeEvent) {
+	for i, existing := range *events {
+		if existing.key == event.key {
+			(*events)[i] = event
+			return
+		}
+	}
+	*events = append(*events, event)
+}
+
+// popDue removes and returns the events that are due at the given
+// time. Each entity's events are debounced independently, so changes
+// to one entity never delay the events for another.
+func (events *onChangeEvents) popDue(now time.Time) []onChangeEvent {
+	var due []onChangeEvent
+	remaining := (*events)[:0]
+	for _, event := range *events {
+		if event.due.After(now) {
+			remaining = append(remaining, event)
+		} else {
+			due = append(due, event)
+		}
+	}
+	*events = remaining
+	return due
+}
+
+// nextDue returns the earliest time at which an event is due, and
+// whether there are any events.
+func (events onChangeEvents) nextDue() (time.Time, bool) {
+	var next time.Time
+	for i, event := range events {
+		if i == 0 || event.due.Before(next) {
+			next = event.due
+		}
+	}
+	return next, len(events) > 0
+}
@@ -0,0 +1,242 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+)
+
+type OnChangeSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&OnChangeSuite{})
+
+func (s *OnChangeSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Accounts["ctrl"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+}
+
+func (s *OnChangeSuite) newCommand(runs *[][]string) *onChangeCommand {
+	command := &onChangeCommand{
+		clock: clock.WallClock,
+		runCommand: func(_ *cmd.Context, _ []string, env []string) error {
+			*runs = append(*runs, env)
+			return nil
+		},
+	}
+	command.SetClientStore(s.store)
+	return command
+}
+
+func (s *OnChangeSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		errMatch: "no command specified",
+	}, {
+		args:     []string{"--kind", "foo", "true"},
+		errMatch: `kind "foo" not valid`,
+	}, {
+		args:     []string{"--entity", "[", "true"},
+		errMatch: `entity pattern "\[" not valid`,
+	}, {
+		args:     []string{"--debounce", "-1s", "true"},
+		errMatch: "negative debounce period not valid",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		var runs [][]string
+		err := cmdtesting.InitCommand(modelcmd.Wrap(s.newCommand(&runs)), test.args)
+		c.Check(err, gc.ErrorMatches, test.errMatch)
+	}
+}
+
+func (s *OnChangeSuite) TestInit(c *gc.C) {
+	var runs [][]string
+	command := s.newCommand(&runs)
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{
+		"--kind", "unit", "--entity", "mysql/*", "--status", "error",
+		"--debounce", "5s", "notify", "--urgent",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(command.filter.kinds, jc.DeepEquals, []string{"unit"})
+	c.Check(command.filter.entities, jc.DeepEquals, []string{"mysql/*"})
+	c.Check(command.filter.statuses, jc.DeepEquals, []string{"error"})
+	c.Check(command.debounce, gc.Equals, 5*time.Second)
+	c.Check(command.command, jc.DeepEquals, []string{"notify", "--urgent"})
+}
+
+func (s *OnChangeSuite) TestFilterKindAndEntity(c *gc.C) {
+	filter := onChangeFilter{
+		kinds:      []string{"unit"},
+		entities:   []string{"mysql/*"},
+		lastStatus: make(map[multiwatcher.EntityId][]string),
+	}
+	_, ok := filter.match(multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{Id: "0"}})
+	c.Check(ok, jc.IsFalse)
+	_, ok = filter.match(multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{Name: "wordpress/0"}})
+	c.Check(ok, jc.IsFalse)
+	event, ok := filter.match(multiwatcher.Delta{
+		Removed: true,
+		Entity:  &multiwatcher.UnitInfo{Name: "mysql/0"},
+	})
+	c.Check(ok, jc.IsTrue)
+	c.Check(event.kind, gc.Equals, "unit")
+	c.Check(event.id, gc.Equals, "mysql/0")
+	c.Check(event.removed, jc.IsTrue)
+}
+
+func (s *OnChangeSuite) TestFilterStatusTransitions(c *gc.C) {
+	filter := onChangeFilter{
+		statuses:   []string{"error"},
+		lastStatus: make(map[multiwatcher.EntityId][]string),
+	}
+	unit := func(workload status.Status, message string) multiwatcher.Delta {
+		return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+			Name:           "mysql/0",
+			WorkloadStatus: multiwatcher.StatusInfo{Current: workload, Message: message},
+			AgentStatus:    multiwatcher.StatusInfo{Current: status.Idle},
+		}}
+	}
+
+	_, ok := filter.match(unit(status.Active, ""))
+	c.Check(ok, jc.IsFalse)
+	event, ok := filter.match(unit(status.Error, "hook failed"))
+	c.Check(ok, jc.IsTrue)
+	c.Check(event.status, gc.Equals, "error")
+	c.Check(event.message, gc.Equals, "hook failed")
+	// Remaining in the status does not trigger again.
+	_, ok = filter.match(unit(status.Error, "hook failed"))
+	c.Check(ok, jc.IsFalse)
+	_, ok = filter.match(unit(status.Active, ""))
+	c.Check(ok, jc.IsFalse)
+	_, ok = filter.match(unit(status.Error, "hook failed again"))
+	c.Check(ok, jc.IsTrue)
+}
+
+func (s *OnChangeSuite) TestEventsCoalesced(c *gc.C) {
+	var events onChangeEvents
+	first := onChangeEvent{key: multiwatcher.EntityId{Kind: "unit", Id: "mysql/0"}, status: "active"}
+	second := onChangeEvent{key: multiwatcher.EntityId{Kind: "unit", Id: "mysql/1"}}
+	latest := onChangeEvent{key: multiwatcher.EntityId{Kind: "unit", Id: "mysql/0"}, status: "error"}
+	events.add(first)
+	events.add(second)
+	events.add(latest)
+	c.Assert(events, jc.DeepEquals, onChangeEvents{latest, second})
+}
+
+func (s *OnChangeSuite) TestEventsDebouncedPerEntity(c *gc.C) {
+	start := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	mysql0 := multiwatcher.EntityId{Kind: "unit", Id: "mysql/0"}
+	mysql1 := multiwatcher.EntityId{Kind: "unit", Id: "mysql/1"}
+
+	var events onChangeEvents
+	_, ok := events.nextDue()
+	c.Assert(ok, jc.IsFalse)
+
+	events.add(onChangeEvent{key: mysql0, due: start.Add(time.Second)})
+	events.add(onChangeEvent{key: mysql1, due: start.Add(2 * time.Second)})
+	// A later change to mysql/1 must not delay mysql/0.
+	events.add(onChangeEvent{key: mysql1, due: start.Add(3 * time.Second)})
+
+	next, ok := events.nextDue()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(next, gc.Equals, start.Add(time.Second))
+	c.Assert(events.popDue(start), gc.HasLen, 0)
+
+	due := events.popDue(start.Add(time.Second))
+	c.Assert(due, jc.DeepEquals, []onChangeEvent{{key: mysql0, due: start.Add(time.Second)}})
+	next, ok = events.nextDue()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(next, gc.Equals, start.Add(3*time.Second))
+
+	due = events.popDue(start.Add(3 * time.Second))
+	c.Assert(due, jc.DeepEquals, []onChangeEvent{{key: mysql1, due: start.Add(3 * time.Second)}})
+	c.Assert(events, gc.HasLen, 0)
+}
+
+func (s *OnChangeSuite) TestRun(c *gc.C) {
+	fake := &fakeOnChangeAPI{watcher: &fakeAllWatcher{
+		deltas: [][]multiwatcher.Delta{{{
+			Entity: &multiwatcher.UnitInfo{
+				Name:           "mysql/0",
+				WorkloadStatus: multiwatcher.StatusInfo{Current: status.Error, Message: "boom"},
+			},
+		}, {
+			Entity: &multiwatcher.MachineInfo{Id: "0"},
+		}}},
+	}}
+	s.PatchValue(&getOnChangeAPI, func(_ *onChangeCommand) (OnChangeAPI, error) {
+		return fake, nil
+	})
+	var runs [][]string
+	_, err := cmdtesting.RunCommand(c, modelcmd.Wrap(s.newCommand(&runs)),
+		"-m", "test-target", "--kind", "unit", "--status", "error", "--debounce", "0", "handle-error",
+	)
+	c.Assert(err, gc.ErrorMatches, "watching model: watcher was stopped")
+	c.Assert(runs, gc.HasLen, 1)
+	c.Assert(runs[0][0], gc.Matches, "JUJU_MODEL=.*test-target")
+	c.Assert(runs[0][1:6], jc.DeepEquals, []string{
+		"JUJU_EVENT_KIND=unit",
+		"JUJU_EVENT_ID=mysql/0",
+		"JUJU_EVENT_TYPE=change",
+		"JUJU_EVENT_STATUS=error",
+		"JUJU_EVENT_MESSAGE=boom",
+	})
+	c.Assert(runs[0][6], jc.HasPrefix, `JUJU_EVENT_ENTITY={"model-uuid":"","name":"mysql/0"`)
+	c.Assert(fake.watcher.stopped, jc.IsTrue)
+	c.Assert(fake.closed, jc.IsTrue)
+}
+
+type fakeOnChangeAPI struct {
+	watcher *fakeAllWatcher
+	closed  bool
+}
+
+func (f *fakeOnChangeAPI) WatchAll() (AllWatcher, error) {
+	return f.watcher, nil
+}
+
+func (f *fakeOnChangeAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+type fakeAllWatcher struct {
+	deltas  [][]multiwatcher.Delta
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.deltas) == 0 {
+		return nil, errors.New("watcher was stopped")
+	}
+	next := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return next, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageRemoveManualHostKeysSummary = `
+Removes the SSH host keys stored for manually provisioned hosts.`[1:]
+
+var usageRemoveManualHostKeysDetails = `
+Removes the SSH host keys stored for each host given. A host with no
+stored keys cannot be manually provisioned until its keys are stored
+again, either with set-manual-host-keys, or by provisioning it with
+"juju add-machine --accept-new-host-keys". Remove a host's keys when
+the host has been reinstalled, and its keys have changed.
+
+Examples:
+    juju remove-manual-host-keys 10.10.0.3 host.example.com
+
+See also:
+    manual-host-keys
+    set-manual-host-keys`[1:]
+
+// NewRemoveManualHostKeysCommand returns a command used to remove the
+// SSH host keys stored for manually provisioned hosts.
+func NewRemoveManualHostKeysCommand() cmd.Command {
+	return modelcmd.Wrap(&removeManualHostKeysCommand{})
+}
+
+// removeManualHostKeysCommand is used to remove the SSH host keys
+// stored for manually provisioned hosts.
+type removeManualHostKeysCommand struct {
+	ManualHostKeysBase
+	hosts []string
+}
+
+// Info implements Command.Info.
+func (c *removeManualHostKeysCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-manual-host-keys",
+		Args:    "<host> ...",
+		Purpose: usageRemoveManualHostKeysSummary,
+		Doc:     usageRemoveManualHostKeysDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeManualHostKeysCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no host specified")
+	}
+	c.hosts = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeManualHostKeysCommand) Run(context *cmd.Context) error {
+	client, err := c.NewSSHClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return errors.Trace(client.RemoveManualHostKeys(c.hosts...))
+}
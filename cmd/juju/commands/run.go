@@ -4,7 +4,9 @@
 package commands
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -21,6 +23,7 @@ import (
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
 )
 
 func newDefaultRunCommand() cmd.Command {
@@ -44,6 +47,12 @@ type runCommand struct {
 	units     []string
 	commands  string
 	timeAfter func(time.Duration) <-chan time.Time
+
+	relation   string
+	remoteUnit string
+	env        map[string]string
+	stream     bool
+	background bool
 }
 
 const runDoc = `
@@ -73,9 +82,35 @@ the unit.
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+Commands run on units can be given the context of one of the unit's
+relations, as they would have in a relation hook, with --relation and
+--remote-unit; the relation is given by id or as <endpoint>:<id>. If
+--remote-unit is not specified, it is inferred where the relation has
+only one remote unit. Additional environment variables can be set
+with --env, which may be repeated. For example:
+
+    juju run --unit mysql/0 --relation db:2 -- relation-get -
+    juju run --unit mysql/0 --env DEBUG=1 -- hooks/config-changed
+
+When running on a single unit, --stream prints the output of the
+commands as they produce it, rather than once they have finished.
+
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 
+With --background, juju run queues the commands and prints the id of the
+action created for each target, without waiting for them to complete.
+This avoids holding a connection open while commands run on many
+machines. The results can then be fetched with "juju show-action-output",
+and commands that have not yet run can be cancelled with "juju
+cancel-action". Combined with --stream, the output of commands run on
+units is recorded as they run, and can be followed with "juju
+show-action-output --stream". For example:
+
+    juju run --background --application mysql -- hooks/update-status
+    juju run --background --stream --unit mysql/0 -- hooks/install
+    juju show-action-output --stream <action id>
+
 If you need to pass flags to the command being run, you must precede the
 command and its arguments with "--", to tell "juju run" to stop processing
 those arguments. For example:
@@ -105,6 +140,11 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
+	f.StringVar(&c.relation, "relation", "", "Run the commands in the context of a relation of the units")
+	f.StringVar(&c.remoteUnit, "remote-unit", "", "The remote unit of the relation context")
+	f.Var(cmd.StringMap{&c.env}, "env", "Set an environment variable for the commands, as KEY=VALUE")
+	f.BoolVar(&c.stream, "stream", false, "Print the output of the commands on a single unit as they produce it")
+	f.BoolVar(&c.background, "background", false, "Print the ids of the queued actions without waiting for results")
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -159,6 +199,16 @@ func (c *runCommand) Init(args []string) error {
 			strings.Join(nameErrors, "\n"))
 	}
 
+	hasContext := c.relation != "" || c.remoteUnit != "" || len(c.env) > 0 || c.stream
+	if hasContext && (c.all || len(c.machines) != 0) {
+		return errors.Errorf("You cannot specify --relation, --remote-unit, --env or --stream when running on machines")
+	}
+	if c.remoteUnit != "" && c.relation == "" {
+		return errors.Errorf("You cannot specify --remote-unit without --relation")
+	}
+	if c.stream && !c.background && (len(c.units) != 1 || len(c.services) != 0) {
+		return errors.Errorf("You can only specify --stream when running on a single unit, unless running in the background")
+	}
 	return nil
 }
 
@@ -227,6 +277,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			Machines:     c.machines,
 			Applications: c.services,
 			Units:        c.units,
+			Relation:     c.relation,
+			RemoteUnit:   c.remoteUnit,
+			Environment:  c.env,
+			Stream:       c.stream,
 		}
 		runResults, err = client.Run(params)
 	}
@@ -272,6 +326,19 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	if len(actionsToQuery) == 0 {
 		return errors.New("no actions were successfully enqueued, aborting")
 	}
+	if c.background {
+		values := make([]interface{}, len(actionsToQuery))
+		for i, query := range actionsToQuery {
+			values[i] = map[string]interface{}{
+				"Action":                    query.actionTag.Id(),
+				query.receiver.receiverType: query.receiver.tag.Id(),
+			}
+		}
+		return c.out.Write(ctx, values)
+	}
+	if c.stream {
+		return c.streamOutput(ctx, client, actionsToQuery[0])
+	}
 
 	timeout := c.timeAfter(c.timeout)
 	values := []interface{}{}
@@ -359,6 +426,88 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// streamOutput writes the output of the single streamed action to
+// stdout and stderr as the commands produce it, and then reports the
+// action's result as it would be reported when not streaming.
+func (c *runCommand) streamOutput(ctx *cmd.Context, client RunClient, query actionQuery) error {
+	w, err := client.WatchActionLogs(query.actionTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.Kill()
+
+	// The output written so far, which is a prefix of the output in
+	// the action's result unless lines were lost.
+	var stdout, stderr bytes.Buffer
+	changes := w.Changes()
+	timeout := c.timeAfter(c.timeout)
+	poll := c.timeAfter(1 * time.Second)
+	for {
+		select {
+		case messages, ok := <-changes:
+			if !ok {
+				// Carry on polling for the result, which holds
+				// the full output.
+				changes = nil
+				continue
+			}
+			for _, data := range messages {
+				var message params.ActionMessage
+				if err := json.Unmarshal([]byte(data), &message); err != nil {
+					return errors.Annotate(err, "cannot decode action message")
+				}
+				switch {
+				case strings.HasPrefix(message.Message, actions.JujuRunStdoutPrefix):
+					line := strings.TrimPrefix(message.Message, actions.JujuRunStdoutPrefix) + "\n"
+					stdout.WriteString(line)
+					ctx.Stdout.Write([]byte(line))
+				case strings.HasPrefix(message.Message, actions.JujuRunStderrPrefix):
+					line := strings.TrimPrefix(message.Message, actions.JujuRunStderrPrefix) + "\n"
+					stderr.WriteString(line)
+					ctx.Stderr.Write([]byte(line))
+				}
+			}
+		case <-poll:
+			actionResults, err := client.Actions(entities([]actionQuery{query}))
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if len(actionResults.Results) != 1 {
+				return errors.Errorf("expected 1 result, got %d", len(actionResults.Results))
+			}
+			result := actionResults.Results[0]
+			if result.Error == nil {
+				switch result.Status {
+				case params.ActionRunning, params.ActionPending:
+					poll = c.timeAfter(1 * time.Second)
+					continue
+				}
+			}
+			values := ConvertActionResults(result, query)
+			if res, ok := values["Error"].(string); ok {
+				return errors.New(res)
+			}
+			// Write any output that was produced after the last
+			// message received.
+			if out := formatOutput(values, "Stdout"); bytes.HasPrefix(out, stdout.Bytes()) {
+				ctx.Stdout.Write(out[stdout.Len():])
+			}
+			if out := formatOutput(values, "Stderr"); bytes.HasPrefix(out, stderr.Bytes()) {
+				ctx.Stderr.Write(out[stderr.Len():])
+			}
+			if code, ok := values["ReturnCode"].(int); ok && code != 0 {
+				return cmd.NewRcPassthroughError(code)
+			}
+			if res, ok := values["Message"].(string); ok && res != "" {
+				ctx.Stderr.Write([]byte(res))
+			}
+			return nil
+		case <-timeout:
+			return errors.Errorf("timed out waiting for result from: %s", names.ReadableString(query.receiver.tag))
+		}
+	}
+}
+
 type actionReceiver struct {
 	receiverType string
 	tag          names.Tag
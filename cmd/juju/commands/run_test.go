@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
 )
 
 type RunSuite struct {
@@ -133,6 +134,63 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 	}
 }
 
+func (*RunSuite) TestHookContextArgParsing(c *gc.C) {
+	for i, test := range []struct {
+		message    string
+		args       []string
+		relation   string
+		remoteUnit string
+		env        map[string]string
+		stream     bool
+		errMatch   string
+	}{{
+		message:    "relation context",
+		args:       []string{"--unit=mysql/0", "--relation=db:2", "--remote-unit=wordpress/0", "relation-get"},
+		relation:   "db:2",
+		remoteUnit: "wordpress/0",
+	}, {
+		message: "environment and streaming",
+		args:    []string{"--unit=mysql/0", "--env", "A=1", "--env", "B=2", "--stream", "hooks/install"},
+		env:     map[string]string{"A": "1", "B": "2"},
+		stream:  true,
+	}, {
+		message:  "relation on machines",
+		args:     []string{"--machine=0", "--relation=2", "hostname"},
+		errMatch: "You cannot specify --relation, --remote-unit, --env or --stream when running on machines",
+	}, {
+		message:  "environment on all machines",
+		args:     []string{"--all", "--env", "A=1", "hostname"},
+		errMatch: "You cannot specify --relation, --remote-unit, --env or --stream when running on machines",
+	}, {
+		message:  "remote unit without relation",
+		args:     []string{"--unit=mysql/0", "--remote-unit=wordpress/0", "relation-get"},
+		errMatch: "You cannot specify --remote-unit without --relation",
+	}, {
+		message:  "stream to several units",
+		args:     []string{"--unit=mysql/0,mysql/1", "--stream", "hostname"},
+		errMatch: "You can only specify --stream when running on a single unit, unless running in the background",
+	}, {
+		message: "stream in the background to an application",
+		args:    []string{"--application=mysql", "--stream", "--background", "hostname"},
+		stream:  true,
+	}, {
+		message:  "stream to an application",
+		args:     []string{"--application=mysql", "--stream", "hostname"},
+		errMatch: "You can only specify --stream when running on a single unit, unless running in the background",
+	}} {
+		c.Log(fmt.Sprintf("%v: %s", i, test.message))
+		cmd := &runCommand{}
+		runCmd := modelcmd.Wrap(cmd)
+		cmdtesting.TestInit(c, runCmd, test.args, test.errMatch)
+		if test.errMatch == "" {
+			c.Check(cmd.relation, gc.Equals, test.relation)
+			c.Check(cmd.remoteUnit, gc.Equals, test.remoteUnit)
+			c.Check(cmd.env, gc.DeepEquals, test.env)
+			c.Check(cmd.stream, gc.Equals, test.stream)
+		}
+	}
+}
+
 func (*RunSuite) TestTimeoutArgParsing(c *gc.C) {
 	for i, test := range []struct {
 		message  string
@@ -491,6 +549,52 @@ func (s *RunSuite) TestSingleResponse(c *gc.C) {
 	}
 }
 
+func (s *RunSuite) TestStream(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setResponse("mysql/0", mockResponse{
+		stdout:  "one\nthree\nfour\n",
+		stderr:  "two\n",
+		code:    "3",
+		unitTag: "unit-mysql-0",
+	})
+	actionId := mock.receiverIdMap["mysql/0"]
+	mock.actionResponses = map[string]params.ActionResult{
+		actionId: mock.runResponses["mysql/0"],
+	}
+	// The last line of output is not received as a message, and is
+	// taken from the result.
+	mock.actionMessages = []string{
+		`{"timestamp":"2017-06-01T00:00:00Z","message":"stdout: one"}`,
+		`{"timestamp":"2017-06-01T00:00:00Z","message":"stderr: two"}`,
+		`{"timestamp":"2017-06-01T00:00:00Z","message":"stdout: three"}`,
+	}
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}), "--unit=mysql/0", "--stream", "hooks/install")
+	c.Check(err, gc.ErrorMatches, "subprocess encountered error code 3")
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "one\nthree\nfour\n")
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "two\n")
+	c.Check(mock.runParams.Stream, jc.IsTrue)
+	c.Check(mock.watched, gc.Equals, names.NewActionTag(actionId))
+}
+
+func (s *RunSuite) TestBackground(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setResponse("0", mockResponse{machineTag: "machine-0"})
+	mock.setResponse("mysql/0", mockResponse{unitTag: "unit-mysql-0"})
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}), "--background", "--machine=0", "--unit=mysql/0", "hostname")
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := &bytes.Buffer{}
+	err = cmd.FormatYaml(expected, []interface{}{
+		map[string]interface{}{"Action": mock.receiverIdMap["0"], "MachineId": "0"},
+		map[string]interface{}{"Action": mock.receiverIdMap["mysql/0"], "UnitId": "mysql/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, expected.String())
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "")
+}
+
 func (s *RunSuite) setupMockAPI() *mockRunAPI {
 	mock := &mockRunAPI{}
 	s.PatchValue(&getRunAPIClient, func(_ *runCommand) (RunClient, error) {
@@ -510,6 +614,9 @@ type mockRunAPI struct {
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
 	block           bool
+	runParams       params.RunParams
+	actionMessages  []string
+	watched         names.ActionTag
 }
 
 type mockResponse struct {
@@ -621,6 +728,7 @@ func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, err
 	if m.block {
 		return result, common.OperationBlockedError("the operation has been blocked")
 	}
+	m.runParams = runParams
 	// Just add in ids that match in order.
 	for _, id := range runParams.Machines {
 		response, found := m.runResponses[id]
@@ -658,5 +766,26 @@ func (m *mockRunAPI) Actions(actionTags params.Entities) (params.ActionResults,
 	return results, nil
 }
 
+func (m *mockRunAPI) WatchActionLogs(tag names.ActionTag) (watcher.StringsWatcher, error) {
+	m.watched = tag
+	changes := make(chan []string, 1)
+	changes <- m.actionMessages
+	return &mockStringsWatcher{changes: changes}, nil
+}
+
+type mockStringsWatcher struct {
+	changes chan []string
+}
+
+func (w *mockStringsWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
+
+func (*mockStringsWatcher) Kill() {}
+
+func (*mockStringsWatcher) Wait() error {
+	return nil
+}
+
 // validUUID is a UUID used in tests
 var validUUID = "01234567-89ab-cdef-0123-456789abcdef"
@@ -0,0 +1,80 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageSetManualHostKeysSummary = `
+Stores the SSH host keys of a host to be manually provisioned.`[1:]
+
+var usageSetManualHostKeysDetails = `
+Juju verifies the identity of a host being manually provisioned with
+"juju add-machine ssh:[user@]host" against the SSH host keys stored for
+it in the controller, and refuses to provision a host that presents any
+other key. This command stores the public host keys of the host given,
+replacing any keys already stored for it. The host must be specified as
+it will be in the add-machine placement, and each key must be quoted in
+full, as it appears in the host's /etc/ssh/ssh_host_*_key.pub files.
+
+Examples:
+    juju set-manual-host-keys 10.10.0.3 "$(ssh-keyscan -t ed25519 10.10.0.3 | cut -d' ' -f2-)"
+
+    juju set-manual-host-keys host.example.com \
+        "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKHu7ECLFOhV8LlVmMD5rLV2wBsvw2C2vwyv4HlAS4pR"
+
+See also:
+    manual-host-keys
+    remove-manual-host-keys
+    add-machine`[1:]
+
+// NewSetManualHostKeysCommand returns a command used to store the SSH
+// host keys of a manually provisioned host.
+func NewSetManualHostKeysCommand() cmd.Command {
+	return modelcmd.Wrap(&setManualHostKeysCommand{})
+}
+
+// setManualHostKeysCommand is used to store the SSH host keys of a
+// manually provisioned host.
+type setManualHostKeysCommand struct {
+	ManualHostKeysBase
+	host string
+	keys []string
+}
+
+// Info implements Command.Info.
+func (c *setManualHostKeysCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-manual-host-keys",
+		Args:    "<host> <ssh host key> ...",
+		Purpose: usageSetManualHostKeysSummary,
+		Doc:     usageSetManualHostKeysDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *setManualHostKeysCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no host specified")
+	case 1:
+		return errors.New("no ssh host key specified")
+	}
+	c.host, c.keys = args[0], args[1:]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setManualHostKeysCommand) Run(context *cmd.Context) error {
+	client, err := c.NewSSHClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return errors.Trace(client.SetManualHostKeys(c.host, c.keys))
+}
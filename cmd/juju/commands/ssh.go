@@ -4,10 +4,14 @@
 package commands
 
 import (
+	"io"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/utils/ssh"
 
+	"github.com/juju/juju/api/sshclient"
 	"github.com/juju/juju/cmd/modelcmd"
 	jujussh "github.com/juju/juju/network/ssh"
 )
@@ -28,6 +32,12 @@ The SSH host keys of the target are verified. The --no-host-key-checks option
 can be used to disable these checks. Use of this option is not recommended as
 it opens up the possibility of a man-in-the-middle attack.
 
+Machines that cannot be reached directly, such as those without a public
+address, can be reached with the --tunnel option. The SSH connection is then
+tunnelled through the controller over the Juju API, which requires admin
+access to the model. Unlike --proxy, no SSH access to the controller machine
+itself is needed.
+
 The default identity known to Juju and used by this command is ~/.ssh/id_rsa
 
 Options can be passed to the local OpenSSH client (ssh) on platforms 
@@ -52,6 +62,10 @@ Connect to a jenkins unit as user jenkins:
 
     juju ssh jenkins@jenkins/0
 
+Connect to a mysql unit through the controller:
+
+    juju ssh --tunnel mysql/0
+
 Connect to a mysql unit with an identity not known to juju (ssh option -i):
 
     juju ssh mysql/0 -i ~/.ssh/my_private_key echo hello
@@ -68,6 +82,16 @@ func newSSHCommand(hostChecker jujussh.ReachableChecker) cmd.Command {
 // sshCommand is responsible for launching a ssh shell on a given unit or machine.
 type sshCommand struct {
 	SSHCommon
+
+	// tunnelStdio is set when the command is run as the ProxyCommand
+	// of a tunnelled connection.
+	tunnelStdio bool
+}
+
+func (c *sshCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SSHCommon.SetFlags(f)
+	f.BoolVar(&c.tunnel, "tunnel", false, "Tunnel the connection through the controller over the API")
+	f.BoolVar(&c.tunnelStdio, "tunnel-stdio", false, "Relay stdin and stdout to the target's SSH server over the API (used by --tunnel)")
 }
 
 func (c *sshCommand) Info() *cmd.Info {
@@ -84,12 +108,23 @@ func (c *sshCommand) Init(args []string) error {
 		return errors.Errorf("no target name specified")
 	}
 	c.Target, c.Args = args[0], args[1:]
+	if c.tunnelStdio {
+		if c.tunnel {
+			return errors.New("cannot specify both --tunnel and --tunnel-stdio")
+		}
+		if len(c.Args) > 0 {
+			return errors.New("--tunnel-stdio does not accept a command")
+		}
+	}
 	return nil
 }
 
 // Run resolves c.Target to a machine, to the address of a i
 // machine or unit forks ssh passing any arguments provided.
 func (c *sshCommand) Run(ctx *cmd.Context) error {
+	if c.tunnelStdio {
+		return c.runTunnelStdio(ctx)
+	}
 	err := c.initRun()
 	if err != nil {
 		return errors.Trace(err)
@@ -112,3 +147,29 @@ func (c *sshCommand) Run(ctx *cmd.Context) error {
 	cmd.Stderr = ctx.Stderr
 	return cmd.Run()
 }
+
+// runTunnelStdio connects to the SSH server of c.Target through the
+// controller, and relays stdin and stdout over the connection until
+// either side closes it.
+func (c *sshCommand) runTunnelStdio(ctx *cmd.Context) error {
+	conn, err := c.NewAPIRoot()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer conn.Close()
+
+	_, entity := splitUserTarget(c.Target)
+	target, err := sshclient.NewFacade(conn).Dial(entity)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer target.Close()
+
+	// The session ends when the SSH server closes the connection,
+	// which it does once the client has finished with it.
+	go io.Copy(target, ctx.Stdin)
+	if _, err := io.Copy(ctx.Stdout, target); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ import (
 type SSHCommon struct {
 	modelcmd.ModelCommandBase
 	proxy           bool
+	tunnel          bool
 	pty             bool
 	noHostKeyChecks bool
 	Target          string
@@ -196,7 +197,11 @@ func (c *SSHCommon) getSSHOptions(enablePty bool, targets ...*resolvedTarget) (*
 		options.EnablePTY()
 	}
 
-	if c.proxy {
+	if c.tunnel {
+		if err := c.setTunnelCommand(&options, targets); err != nil {
+			return nil, err
+		}
+	} else if c.proxy {
 		if err := c.setProxyCommand(&options); err != nil {
 			return nil, err
 		}
@@ -296,6 +301,31 @@ func (c *SSHCommon) setProxyCommand(options *ssh.Options) error {
 	return nil
 }
 
+// setTunnelCommand sets the proxy command option so that the SSH
+// connection to the target is tunnelled through the controller over
+// the API, rather than through an SSH session to the controller
+// machine.
+func (c *SSHCommon) setTunnelCommand(options *ssh.Options, targets []*resolvedTarget) error {
+	if len(targets) != 1 || !targets[0].isAgent() {
+		return errors.New("--tunnel requires a single machine or unit target")
+	}
+	juju, err := getJujuExecutable()
+	if err != nil {
+		return errors.Errorf("failed to get juju executable path: %v", err)
+	}
+	modelName, err := c.ModelName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	options.SetProxyCommand(
+		juju, "ssh",
+		"--model="+modelName,
+		"--tunnel-stdio",
+		targets[0].entity,
+	)
+	return nil
+}
+
 func (c *SSHCommon) ensureAPIClient() error {
 	if c.apiClient != nil {
 		return nil
@@ -325,7 +355,7 @@ func (c *SSHCommon) resolveTarget(target string) (*resolvedTarget, error) {
 	if c.apiClient.BestAPIVersion() < 2 || c.forceAPIv1 {
 		logger.Debugf("using legacy SSHClient API v1: no support for AllAddresses()")
 		getAddress = c.legacyAddressGetter
-	} else if c.proxy {
+	} else if c.proxy || c.tunnel {
 		// Ideally a reachability scan would be done from the
 		// controller's perspective but that isn't possible yet, so
 		// fall back to the legacy mode (i.e. use the instance's
@@ -337,7 +367,7 @@ func (c *SSHCommon) resolveTarget(target string) (*resolvedTarget, error) {
 		// reachability scan juju ssh could inadvertently end up using
 		// the public address when it really should be using the
 		// internal/private address.
-		logger.Debugf("proxy-ssh enabled so not doing reachability scan")
+		logger.Debugf("proxy-ssh or tunnel enabled so not doing reachability scan")
 		getAddress = c.legacyAddressGetter
 	}
 
@@ -387,10 +417,11 @@ func (c *SSHCommon) resolveWithRetry(target resolvedTarget, getAddress addressGe
 }
 
 // legacyAddressGetter returns the preferred public or private address of the
-// given entity (private when c.proxy is true), using the apiClient. Only used
-// when the SSHClient API facade v2 is not available or when proxy-ssh is set.
+// given entity (private when c.proxy or c.tunnel is true), using the
+// apiClient. Only used when the SSHClient API facade v2 is not available or
+// when proxy-ssh or tunnelling is set.
 func (c *SSHCommon) legacyAddressGetter(entity string) (string, error) {
-	if c.proxy {
+	if c.proxy || c.tunnel {
 		return c.apiClient.PrivateAddress(entity)
 	}
 
@@ -33,6 +33,11 @@ type argsSpec struct {
 	// expected.
 	withProxy bool
 
+	// withTunnel specifies the target expected in the juju
+	// ProxyCommand option used to tunnel through the controller, if
+	// any.
+	withTunnel string
+
 	// enablePty specifies if the forced PTY allocation switches are
 	// expected.
 	enablePty bool
@@ -79,6 +84,11 @@ func (s *argsSpec) check(c *gc.C, output string) {
 			"--no-host-key-checks " +
 			"--pty=false ubuntu@localhost -q \"nc %h %p\"")
 	}
+	if s.withTunnel != "" {
+		expect("-o ProxyCommand juju ssh " +
+			"--model=controller " +
+			"--tunnel-stdio " + s.withTunnel)
+	}
 	expect("-o PasswordAuthentication no -o ServerAliveInterval 30")
 	if s.enablePty {
 		expect("-t -t")
@@ -168,6 +168,28 @@ var sshTests = []struct {
 			argsMatch:       `ubuntu@0.private`,
 		},
 	},
+	{
+		about:       "connect to unit mysql/0 with tunnel",
+		args:        []string{"--tunnel", "mysql/0"},
+		hostChecker: nil, // Host checker shouldn't get used with --tunnel
+		expected: argsSpec{
+			hostKeyChecking: "yes",
+			knownHosts:      "0",
+			enablePty:       true,
+			withTunnel:      "mysql/0",
+			args:            "ubuntu@0.private",
+		},
+	},
+	{
+		about:       "connect to arbitrary hostname with tunnel",
+		args:        []string{"--tunnel", "some.host"},
+		expectedErr: "--tunnel requires a single machine or unit target",
+	},
+	{
+		about:       "tunnel stdio with a command",
+		args:        []string{"--tunnel-stdio", "0", "uname"},
+		expectedErr: "--tunnel-stdio does not accept a command",
+	},
 }
 
 func (s *SSHSuite) TestSSHCommand(c *gc.C) {
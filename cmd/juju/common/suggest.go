@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/juju/api/entityfinder"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// maxSuggestions is the number of entities suggested in place of one
+// that was not found.
+const maxSuggestions = 3
+
+// EntityFinder finds the entities whose names best match a partial
+// name. It is implemented by *entityfinder.Client.
+type EntityFinder interface {
+	BestAPIVersion() int
+	FindEntities(query string, limit int, kinds ...string) ([]entityfinder.Match, error)
+}
+
+// SuggestEntities returns a hint naming the entities of the given tag
+// kinds whose names are closest to the given name, for example
+// `did you mean "mysql" or "mysql-2"?`, for use when no entity has
+// that name. If there are no such entities, or the controller cannot
+// find them, it returns the empty string.
+func SuggestEntities(finder EntityFinder, name string, kinds ...string) string {
+	if finder.BestAPIVersion() < 1 {
+		return ""
+	}
+	matches, err := finder.FindEntities(name, maxSuggestions, kinds...)
+	if err != nil {
+		logger.Debugf("cannot find entities similar to %q: %v", name, err)
+		return ""
+	}
+	var quoted []string
+	for _, match := range matches {
+		if id := match.Tag.Id(); id != name {
+			quoted = append(quoted, fmt.Sprintf("%q", id))
+		}
+	}
+	switch n := len(quoted); n {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("did you mean %s?", quoted[0])
+	default:
+		return fmt.Sprintf("did you mean %s or %s?", strings.Join(quoted[:n-1], ", "), quoted[n-1])
+	}
+}
+
+// ErrorWithSuggestion returns the message of err, followed by a hint
+// naming the entities of the given kinds that may have been meant if
+// err reports that the named entity was not found.
+func ErrorWithSuggestion(finder EntityFinder, err error, name string, kinds ...string) string {
+	if !params.IsCodeNotFound(err) {
+		return err.Error()
+	}
+	if hint := SuggestEntities(finder, name, kinds...); hint != "" {
+		return err.Error() + "; " + hint
+	}
+	return err.Error()
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/entityfinder"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+)
+
+type suggestSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&suggestSuite{})
+
+func (s *suggestSuite) TestSuggestEntities(c *gc.C) {
+	for i, test := range []struct {
+		matches []string
+		expect  string
+	}{{
+		expect: "",
+	}, {
+		matches: []string{"application-mysql"},
+		expect:  `did you mean "mysql"?`,
+	}, {
+		matches: []string{"application-mysql", "application-mysql-2"},
+		expect:  `did you mean "mysql" or "mysql-2"?`,
+	}, {
+		matches: []string{"application-mysql", "application-mysql-2", "application-mysqldb"},
+		expect:  `did you mean "mysql", "mysql-2" or "mysqldb"?`,
+	}, {
+		// The name itself is not suggested.
+		matches: []string{"application-mysq", "application-mysql"},
+		expect:  `did you mean "mysql"?`,
+	}} {
+		c.Logf("test %d", i)
+		finder := &fakeEntityFinder{version: 1}
+		for _, tag := range test.matches {
+			finder.matches = append(finder.matches, entityfinder.Match{Tag: names.NewApplicationTag(tag[len("application-"):])})
+		}
+		hint := common.SuggestEntities(finder, "mysq", names.ApplicationTagKind)
+		c.Check(hint, gc.Equals, test.expect)
+		c.Check(finder.args, gc.DeepEquals, []interface{}{"mysq", 3, []string{names.ApplicationTagKind}})
+	}
+}
+
+func (s *suggestSuite) TestSuggestEntitiesNotSupported(c *gc.C) {
+	finder := &fakeEntityFinder{matches: []entityfinder.Match{{Tag: names.NewApplicationTag("mysql")}}}
+	c.Assert(common.SuggestEntities(finder, "mysq"), gc.Equals, "")
+	c.Assert(finder.args, gc.IsNil)
+}
+
+func (s *suggestSuite) TestSuggestEntitiesError(c *gc.C) {
+	finder := &fakeEntityFinder{version: 1, err: errors.New("boom")}
+	c.Assert(common.SuggestEntities(finder, "mysq"), gc.Equals, "")
+}
+
+func (s *suggestSuite) TestErrorWithSuggestion(c *gc.C) {
+	finder := &fakeEntityFinder{version: 1, matches: []entityfinder.Match{{Tag: names.NewApplicationTag("mysql")}}}
+	err := &params.Error{Code: params.CodeNotFound, Message: `application "mysq" not found`}
+	msg := common.ErrorWithSuggestion(finder, err, "mysq", names.ApplicationTagKind)
+	c.Assert(msg, gc.Equals, `application "mysq" not found; did you mean "mysql"?`)
+
+	// Only entities that were not found have suggestions.
+	finder.args = nil
+	msg = common.ErrorWithSuggestion(finder, errors.New("boom"), "mysq", names.ApplicationTagKind)
+	c.Assert(msg, gc.Equals, "boom")
+	c.Assert(finder.args, gc.IsNil)
+}
+
+type fakeEntityFinder struct {
+	version int
+	matches []entityfinder.Match
+	err     error
+	args    []interface{}
+}
+
+func (f *fakeEntityFinder) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeEntityFinder) FindEntities(query string, limit int, kinds ...string) ([]entityfinder.Match, error) {
+	f.args = []interface{}{query, limit, kinds}
+	return f.matches, f.err
+}
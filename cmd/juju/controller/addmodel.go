@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/api/base"
 	cloudapi "github.com/juju/juju/api/cloud"
 	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/api/regionlatency"
 	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/common"
@@ -37,6 +38,9 @@ func NewAddModelCommand() cmd.Command {
 		newCloudAPI: func(caller base.APICallCloser) CloudAPI {
 			return cloudapi.NewClient(caller)
 		},
+		newRegionLatencyAPI: func(caller base.APICallCloser) RegionLatencyAPI {
+			return regionlatency.NewClient(caller)
+		},
 		providerRegistry: environs.GlobalProviderRegistry(),
 	})
 }
@@ -44,10 +48,11 @@ func NewAddModelCommand() cmd.Command {
 // addModelCommand calls the API to add a new model.
 type addModelCommand struct {
 	modelcmd.ControllerCommandBase
-	apiRoot          api.Connection
-	newAddModelAPI   func(base.APICallCloser) AddModelAPI
-	newCloudAPI      func(base.APICallCloser) CloudAPI
-	providerRegistry environs.ProviderRegistry
+	apiRoot             api.Connection
+	newAddModelAPI      func(base.APICallCloser) AddModelAPI
+	newCloudAPI         func(base.APICallCloser) CloudAPI
+	newRegionLatencyAPI func(base.APICallCloser) RegionLatencyAPI
+	providerRegistry    environs.ProviderRegistry
 
 	Name           string
 	Owner          string
@@ -55,6 +60,7 @@ type addModelCommand struct {
 	CloudRegion    string
 	Config         common.ConfigFlag
 	noSwitch       bool
+	nearestRegion  bool
 }
 
 const addModelHelpDoc = `
@@ -93,11 +99,18 @@ to manage multiple clouds, so the only valid cloud is the same cloud
 as the controller model is deployed to. This may change in a future
 release.
 
+If --nearest-region is specified and no region is given, the controller
+measures the latency to each region of the cloud and the model is
+deployed to the region with the lowest latency. Latencies are measured
+from the controller, not from the client, and are cached by the
+controller for a short period.
+
 Examples:
 
     juju add-model mymodel
     juju add-model mymodel us-east-1
     juju add-model mymodel aws/us-east-1
+    juju add-model mymodel aws --nearest-region
     juju add-model mymodel --config my-config.yaml --config image-stream=daily
     juju add-model mymodel --credential credential_name --config authorized-keys="ssh-rsa ..."
 `
@@ -117,6 +130,7 @@ func (c *addModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.CredentialName, "credential", "", "Credential used to add the model")
 	f.Var(&c.Config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...])")
 	f.BoolVar(&c.noSwitch, "no-switch", false, "Do not switch to the newly created model")
+	f.BoolVar(&c.nearestRegion, "nearest-region", false, "Deploy the model to the cloud region nearest the controller")
 }
 
 func (c *addModelCommand) Init(args []string) error {
@@ -156,6 +170,10 @@ type CloudAPI interface {
 	UpdateCredential(names.CloudCredentialTag, jujucloud.Credential) error
 }
 
+type RegionLatencyAPI interface {
+	RegionLatencies(names.CloudTag) (params.RegionLatencyResult, error)
+}
+
 func (c *addModelCommand) newAPIRoot() (api.Connection, error) {
 	if c.apiRoot != nil {
 		return c.apiRoot, nil
@@ -208,6 +226,14 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 			return errors.Trace(err)
 		}
 	}
+	if c.nearestRegion {
+		if cloudRegion != "" {
+			return errors.Errorf("cannot specify a region with --nearest-region")
+		}
+		if cloudRegion, err = c.findNearestRegion(ctx, api, cloudTag, cloud); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	// Find a credential to use with the new model.
 	credential, credentialTag, cloudRegion, err := c.findCredential(ctx, cloudClient, &findCredentialParams{
@@ -391,6 +417,29 @@ by this controller are:`[1:], c.CloudRegion)
 	return errors.Errorf("%s\n\n%s", prefix, buf.String())
 }
 
+// findNearestRegion asks the controller for the latency of each region
+// of the given cloud, and returns the name of the region with the lowest
+// latency. If the cloud has no regions, the empty string is returned.
+func (c *addModelCommand) findNearestRegion(ctx *cmd.Context, api base.APICallCloser, cloudTag names.CloudTag, cloud jujucloud.Cloud) (string, error) {
+	if len(cloud.Regions) == 0 {
+		return "", nil
+	}
+	result, err := c.newRegionLatencyAPI(api).RegionLatencies(cloudTag)
+	if err != nil {
+		return "", errors.Annotate(err, "querying region latencies")
+	}
+	if result.Nearest == "" {
+		return "", errors.Errorf("none of the regions of cloud %q are reachable from the controller", cloudTag.Id())
+	}
+	for _, region := range result.Regions {
+		if region.Region == result.Nearest {
+			ctx.Infof("Selected nearest region %q (%v)", region.Region, region.Latency)
+			break
+		}
+	}
+	return result.Nearest, nil
+}
+
 func defaultCloud(cloudClient CloudAPI) (names.CloudTag, jujucloud.Cloud, error) {
 	cloudTag, err := cloudClient.DefaultCloud()
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -34,6 +35,7 @@ type AddModelSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	fakeAddModelAPI      *fakeAddClient
 	fakeCloudAPI         *fakeCloudAPI
+	fakeRegionLatencyAPI *fakeRegionLatencyAPI
 	fakeProvider         *fakeProvider
 	fakeProviderRegistry *fakeProviderRegistry
 	store                *jujuclient.MemStore
@@ -64,6 +66,15 @@ func (s *AddModelSuite) SetUpTest(c *gc.C) {
 			names.NewCloudCredentialTag("aws/other/secrets"),
 		},
 	}
+	s.fakeRegionLatencyAPI = &fakeRegionLatencyAPI{
+		result: params.RegionLatencyResult{
+			Regions: []params.RegionLatency{
+				{Region: "us-east-1", Latency: 80 * time.Millisecond},
+				{Region: "us-west-1", Latency: 20 * time.Millisecond},
+			},
+			Nearest: "us-west-1",
+		},
+	}
 	s.fakeProvider = &fakeProvider{
 		detected: cloud.NewEmptyCloudCredential(),
 	}
@@ -103,6 +114,7 @@ func (s *AddModelSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
 		&fakeAPIConnection{},
 		s.fakeAddModelAPI,
 		s.fakeCloudAPI,
+		s.fakeRegionLatencyAPI,
 		s.store,
 		s.fakeProviderRegistry,
 	)
@@ -160,7 +172,7 @@ func (s *AddModelSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, command := controller.NewAddModelCommandForTest(nil, nil, nil, s.store, nil)
+		wrappedCommand, command := controller.NewAddModelCommandForTest(nil, nil, nil, nil, s.store, nil)
 		err := cmdtesting.InitCommand(wrappedCommand, test.args)
 		if test.err != "" {
 			c.Assert(err, gc.ErrorMatches, test.err)
@@ -416,6 +428,49 @@ func (s *AddModelSuite) TestCloudDefaultRegionUsedIfSet(c *gc.C) {
 	c.Assert(s.fakeAddModelAPI.cloudRegion, gc.Equals, "us-west-1")
 }
 
+func (s *AddModelSuite) TestNearestRegion(c *gc.C) {
+	ctx, err := s.run(c, "test", "aws", "--nearest-region")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.fakeRegionLatencyAPI.CheckCalls(c, []gitjujutesting.StubCall{
+		{"RegionLatencies", []interface{}{names.NewCloudTag("aws")}},
+	})
+	c.Assert(s.fakeAddModelAPI.cloudName, gc.Equals, "aws")
+	c.Assert(s.fakeAddModelAPI.cloudRegion, gc.Equals, "us-west-1")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Selected nearest region "us-west-1" (20ms)`)
+}
+
+func (s *AddModelSuite) TestNearestRegionDefaultCloud(c *gc.C) {
+	_, err := s.run(c, "test", "--nearest-region")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.fakeRegionLatencyAPI.CheckCallNames(c, "RegionLatencies")
+	c.Assert(s.fakeAddModelAPI.cloudName, gc.Equals, "aws")
+	c.Assert(s.fakeAddModelAPI.cloudRegion, gc.Equals, "us-west-1")
+}
+
+func (s *AddModelSuite) TestNearestRegionWithRegion(c *gc.C) {
+	_, err := s.run(c, "test", "aws/us-east-1", "--nearest-region")
+	c.Assert(err, gc.ErrorMatches, "cannot specify a region with --nearest-region")
+	s.fakeRegionLatencyAPI.CheckNoCalls(c)
+}
+
+func (s *AddModelSuite) TestNearestRegionNoneReachable(c *gc.C) {
+	s.fakeRegionLatencyAPI.result = params.RegionLatencyResult{
+		Regions: []params.RegionLatency{
+			{Region: "us-east-1", Error: &params.Error{Message: "connection refused"}},
+		},
+	}
+	_, err := s.run(c, "test", "aws", "--nearest-region")
+	c.Assert(err, gc.ErrorMatches, `none of the regions of cloud "aws" are reachable from the controller`)
+}
+
+func (s *AddModelSuite) TestNearestRegionError(c *gc.C) {
+	s.fakeRegionLatencyAPI.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "test", "aws", "--nearest-region")
+	c.Assert(err, gc.ErrorMatches, "querying region latencies: boom")
+}
+
 func (s *AddModelSuite) TestInvalidCloudOrRegionName(c *gc.C) {
 	_, err := s.run(c, "test", "oro")
 	c.Assert(err, gc.ErrorMatches, `
@@ -669,6 +724,16 @@ func (c *fakeCloudAPI) UpdateCredential(credentialTag names.CloudCredentialTag,
 	return c.NextErr()
 }
 
+type fakeRegionLatencyAPI struct {
+	gitjujutesting.Stub
+	result params.RegionLatencyResult
+}
+
+func (r *fakeRegionLatencyAPI) RegionLatencies(tag names.CloudTag) (params.RegionLatencyResult, error) {
+	r.MethodCall(r, "RegionLatencies", tag)
+	return r.result, r.NextErr()
+}
+
 type fakeProviderRegistry struct {
 	gitjujutesting.Stub
 	environs.ProviderRegistry
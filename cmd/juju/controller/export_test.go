@@ -44,6 +44,7 @@ func NewAddModelCommandForTest(
 	apiRoot api.Connection,
 	api AddModelAPI,
 	cloudAPI CloudAPI,
+	regionLatencyAPI RegionLatencyAPI,
 	store jujuclient.ClientStore,
 	providerRegistry environs.ProviderRegistry,
 ) (cmd.Command, *AddModelCommand) {
@@ -55,6 +56,9 @@ func NewAddModelCommandForTest(
 		newCloudAPI: func(base.APICallCloser) CloudAPI {
 			return cloudAPI
 		},
+		newRegionLatencyAPI: func(base.APICallCloser) RegionLatencyAPI {
+			return regionLatencyAPI
+		},
 		providerRegistry: providerRegistry,
 	}
 	c.SetClientStore(store)
@@ -240,6 +240,8 @@ type offerConnectionDetails struct {
 	Endpoint        string                `json:"endpoint" yaml:"endpoint"`
 	Status          offerConnectionStatus `json:"status" yaml:"status"`
 	IngressSubnets  []string              `json:"ingress-subnets,omitempty" yaml:"ingress-subnets,omitempty"`
+	Health          string                `json:"health,omitempty" yaml:"health,omitempty"`
+	LastSeen        string                `json:"last-seen,omitempty" yaml:"last-seen,omitempty"`
 }
 
 func formatApplicationOfferDetails(store string, all []*crossmodel.ApplicationOfferDetails, activeOnly bool) (offeredApplications, error) {
@@ -284,6 +286,8 @@ func convertOfferToListItem(url *crossmodel.OfferURL, offer *crossmodel.Applicat
 				Since:   friendlyDuration(conn.Since),
 			},
 			IngressSubnets: conn.IngressSubnets,
+			Health:         string(conn.Health),
+			LastSeen:       friendlyDuration(conn.LastSeen),
 		})
 	}
 	return item
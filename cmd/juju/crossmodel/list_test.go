@@ -239,6 +239,7 @@ func (s *ListSuite) TestListYAML(c *gc.C) {
 			RelationId:      2,
 			Endpoint:        "http",
 			IngressSubnets:  []string{"192.168.0.1/32", "10.0.0.0/8"},
+			Health:          model.OfferConnectionStale,
 		},
 	}
 	s.applications[0].Users = []model.OfferUserDetails{{
@@ -275,6 +276,7 @@ hosted-db2:
     ingress-subnets:
     - 192.168.0.1/32
     - 10.0.0.0/8
+    health: stale
   users:
     fred:
       display-name: Fred
@@ -4,9 +4,14 @@
 package machine
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/cmd"
@@ -17,6 +22,7 @@ import (
 
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/api/sshclient"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
@@ -55,7 +61,15 @@ container types are: $CONTAINER_TYPES$.
 Manual provisioning is the process of installing Juju on an existing machine
 and bringing it under Juju's management; currently this requires that the
 machine be running Ubuntu, that it be accessible via SSH, and be running on
-the same network as the API server.
+the same network as the API server. Several machines may be manually
+provisioned at once, by specifying an ssh: or winrm: placement for each; the
+machines are provisioned concurrently.
+
+The identity of a machine provisioned with SSH is verified against the SSH
+host keys stored for it in the controller (see set-manual-host-keys). If no
+keys are stored for the host, add-machine fails, unless "--accept-new-host-keys"
+is specified; the host key presented on first connection is then trusted, and
+stored for later connections.
 
 It is possible to override or augment constraints by passing provider-specific
 "placement directives" as an argument; these give the provider additional
@@ -71,11 +85,17 @@ Examples:
    juju add-machine --constraints mem=8G (starts a machine with at least 8GB RAM)
    juju add-machine ssh:user@10.10.0.3   (manually provisions machine with ssh)
    juju add-machine winrm:user@10.10.0.3 (manually provisions machine with winrm)
+   juju add-machine ssh:10.10.0.3 ssh:10.10.0.4
+                                         (manually provisions 2 machines with ssh)
+   juju add-machine ssh:10.10.0.3 --accept-new-host-keys
+                                         (trusts and stores the host's SSH key)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
 
 See also:
     remove-machine
+    manual-host-keys
+    set-manual-host-keys
 `
 
 func init() {
@@ -102,6 +122,7 @@ type addCommand struct {
 	api               AddMachineAPI
 	modelConfigAPI    ModelConfigAPI
 	machineManagerAPI MachineManagerAPI
+	hostKeysAPI       ManualHostKeysAPI
 	// If specified, use this series, else use the model default-series
 	Series string
 	// If specified, these constraints are merged with those already in the model.
@@ -110,6 +131,12 @@ type addCommand struct {
 	ConstraintsStr string
 	// Placement is passed verbatim to the API, to be parsed and evaluated server-side.
 	Placement *instance.Placement
+	// Placements holds the placements of the machines to manually
+	// provision, when more than one is specified.
+	Placements []*instance.Placement
+	// AcceptNewHostKeys records whether the SSH host keys presented by
+	// manually provisioned hosts with no stored keys are trusted.
+	AcceptNewHostKeys bool
 	// NumMachines is the number of machines to add.
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
@@ -119,7 +146,7 @@ type addCommand struct {
 func (c *addCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "add-machine",
-		Args:    "[<container>:machine | <container> | ssh:[user@]host | winrm:[user@]host | placement]",
+		Args:    "[<container>:machine | <container> | ssh:[user@]host ... | winrm:[user@]host ... | placement]",
 		Purpose: "Start a new, empty machine and optionally a container, or add a container to a machine.",
 		Doc:     addMachineDoc,
 	}
@@ -131,12 +158,16 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.BoolVar(&c.AcceptNewHostKeys, "accept-new-host-keys", false, "Trust and store the SSH host keys of manually provisioned hosts with no stored keys")
 }
 
 func (c *addCommand) Init(args []string) error {
 	if c.Constraints.Container != nil {
 		return errors.Errorf("container constraint %q not allowed when adding a machine", *c.Constraints.Container)
 	}
+	if len(args) > 1 {
+		return c.initManualPlacements(args)
+	}
 	placement, err := cmd.ZeroOrOneArgs(args)
 	if err != nil {
 		return err
@@ -155,6 +186,32 @@ func (c *addCommand) Init(args []string) error {
 	return nil
 }
 
+// initManualPlacements parses the placements of several machines to be
+// manually provisioned at once. Only ssh: and winrm: placements may be
+// specified more than once.
+func (c *addCommand) initManualPlacements(args []string) error {
+	hosts := make(map[string]bool)
+	for i, arg := range args {
+		placement, err := instance.ParsePlacement(arg)
+		if err != nil || !isManualScope(placement.Scope) {
+			if i == 0 {
+				return cmd.CheckEmpty(args[1:])
+			}
+			return errors.Errorf("cannot combine placement %q with manual placements", arg)
+		}
+		_, host := splitUserHost(placement.Directive)
+		if hosts[host] {
+			return errors.Errorf("host %q specified more than once", host)
+		}
+		hosts[host] = true
+		c.Placements = append(c.Placements, placement)
+	}
+	if c.NumMachines > 1 {
+		return errors.New("cannot use -n when specifying a placement directive")
+	}
+	return nil
+}
+
 type AddMachineAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
 	Close() error
@@ -169,6 +226,14 @@ type ModelConfigAPI interface {
 	Close() error
 }
 
+// ManualHostKeysAPI defines the methods of the SSHClient facade used to
+// verify the SSH host keys of manually provisioned machines.
+type ManualHostKeysAPI interface {
+	ManualHostKeys(host string) ([]string, error)
+	SetManualHostKeys(host string, keys []string) error
+	Close() error
+}
+
 type MachineManagerAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
 	BestAPIVersion() int
@@ -218,6 +283,17 @@ func (c *addCommand) getMachineManagerAPI() (MachineManagerAPI, error) {
 	return c.NewMachineManagerClient()
 }
 
+func (c *addCommand) getManualHostKeysAPI() (ManualHostKeysAPI, error) {
+	if c.hostKeysAPI != nil {
+		return c.hostKeysAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return sshclient.NewFacade(root), nil
+}
+
 func (c *addCommand) Run(ctx *cmd.Context) error {
 	var err error
 	c.Constraints, err = common.ParseConstraints(ctx, c.ConstraintsStr)
@@ -260,7 +336,7 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	if c.Placement != nil {
+	if c.Placement != nil || len(c.Placements) > 0 {
 		err := c.tryManualProvision(client, config, ctx)
 		if err != errNonManualScope {
 			return err
@@ -346,46 +422,217 @@ var (
 	winrmScope        = "winrm"
 )
 
+func isManualScope(scope string) bool {
+	return scope == sshScope || scope == winrmScope
+}
+
 func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Config, ctx *cmd.Context) error {
+	placements := c.Placements
+	if len(placements) == 0 {
+		if !isManualScope(c.Placement.Scope) {
+			return errNonManualScope
+		}
+		placements = []*instance.Placement{c.Placement}
+	}
+
+	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
+	if err != nil {
+		return errors.Annotatef(err, "cannot reading authorized-keys")
+	}
 
-	var provisionMachine manual.ProvisionMachineFunc
-	switch c.Placement.Scope {
+	var hostKeys ManualHostKeysAPI
+	for _, placement := range placements {
+		if placement.Scope != sshScope {
+			continue
+		}
+		hostKeys, err = c.getManualHostKeysAPI()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer hostKeys.Close()
+		break
+	}
+
+	p := manualProvisioner{
+		client:   client,
+		hostKeys: hostKeys,
+		ctx:      ctx,
+		baseArgs: manual.ProvisionMachineArgs{
+			Client:         client,
+			Stdin:          ctx.Stdin,
+			Stdout:         ctx.Stdout,
+			Stderr:         ctx.Stderr,
+			AuthorizedKeys: authKeys,
+			UpdateBehavior: &params.UpdateBehavior{
+				EnableOSRefreshUpdate: config.EnableOSRefreshUpdate(),
+				EnableOSUpgrade:       config.EnableOSUpgrade(),
+			},
+		},
+		acceptNewHostKeys: c.AcceptNewHostKeys,
+	}
+	if len(placements) == 1 {
+		machineId, err := p.provision(placements[0])
+		if err == nil {
+			ctx.Infof("created machine %v", machineId)
+		}
+		return err
+	}
+
+	// Provision the machines concurrently. The user may be prompted
+	// for each machine's sudo password, so prompts are serialised with
+	// a shared lock, and each machine's progress is reported on its
+	// own lines, prefixed with its host.
+	var promptLock, outputLock sync.Mutex
+	p.baseArgs.PromptLock = &promptLock
+	machineIds := make([]string, len(placements))
+	errs := make([]error, len(placements))
+	var wg sync.WaitGroup
+	for i, placement := range placements {
+		wg.Add(1)
+		go func(i int, placement *instance.Placement) {
+			defer wg.Done()
+			_, host := splitUserHost(placement.Directive)
+			stderr := &prefixWriter{
+				mu:     &outputLock,
+				w:      ctx.Stderr,
+				prefix: host + ": ",
+			}
+			defer stderr.Flush()
+			p := p
+			p.baseArgs.Stderr = stderr
+			machineIds[i], errs[i] = p.provision(placement)
+		}(i, placement)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, placement := range placements {
+		if errs[i] != nil {
+			_, host := splitUserHost(placement.Directive)
+			failed = append(failed, fmt.Sprintf("%s: %v", host, errs[i]))
+			continue
+		}
+		ctx.Infof("created machine %v", machineIds[i])
+	}
+	switch len(failed) {
+	case 0:
+		return nil
+	case 1:
+		fmt.Fprint(ctx.Stderr, "failed to create 1 machine\n")
+	default:
+		fmt.Fprintf(ctx.Stderr, "failed to create %d machines\n", len(failed))
+	}
+	return errors.New(strings.Join(failed, ", "))
+}
+
+// manualProvisioner provisions machines on existing hosts, given
+// their ssh: or winrm: placements.
+type manualProvisioner struct {
+	client   AddMachineAPI
+	hostKeys ManualHostKeysAPI
+	ctx      *cmd.Context
+
+	// baseArgs holds the arguments common to all of the machines
+	// being provisioned.
+	baseArgs manual.ProvisionMachineArgs
+
+	// acceptNewHostKeys records whether the host key presented by an
+	// ssh: host with no stored keys may be accepted and stored.
+	acceptNewHostKeys bool
+}
+
+// provision provisions the machine with the given placement, returning
+// the new machine's id.
+func (p manualProvisioner) provision(placement *instance.Placement) (string, error) {
+	args := p.baseArgs
+	args.User, args.Host = splitUserHost(placement.Directive)
+	switch placement.Scope {
 	case sshScope:
-		provisionMachine = sshProvisioner
+		return p.provisionSSH(args)
 	case winrmScope:
-		provisionMachine = winrmProvisioner
+		return p.provisionWinRM(args)
+	}
+	return "", errNonManualScope
+}
+
+// provisionSSH provisions a machine over SSH, verifying its host key
+// against the keys stored for it in the controller. If no keys are
+// stored, and new host keys may be accepted, the key the host presents
+// on first connection is stored once the machine is provisioned.
+func (p manualProvisioner) provisionSSH(args manual.ProvisionMachineArgs) (string, error) {
+	keys, err := p.hostKeys.ManualHostKeys(args.Host)
+	switch {
+	case err == nil:
+	case errors.IsNotSupported(err):
+		logger.Warningf(
+			"the controller cannot store SSH host keys; the host key of %q "+
+				"is checked according to your SSH configuration", args.Host,
+		)
+		return sshProvisioner(args)
+	case params.IsCodeNotFound(err):
+		if !p.acceptNewHostKeys {
+			return "", errors.Errorf(`no SSH host keys are stored for %q
+
+Verify the host's keys, and store them with:
+    juju set-manual-host-keys %s <key> ...
+or run add-machine with --accept-new-host-keys to trust the host key
+presented on first connection.`, args.Host, args.Host)
+		}
+		args.AcceptNewHostKey = true
 	default:
-		return errNonManualScope
+		return "", errors.Annotatef(err, "getting SSH host keys for %q", args.Host)
 	}
 
-	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
+	f, err := ioutil.TempFile("", "juju-known-hosts")
 	if err != nil {
-		return errors.Annotatef(err, "cannot reading authorized-keys")
+		return "", errors.Trace(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := manual.WriteKnownHosts(f, args.Host, keys); err != nil {
+		return "", errors.Trace(err)
 	}
+	if err := f.Sync(); err != nil {
+		return "", errors.Annotate(err, "writing known_hosts")
+	}
+	args.KnownHostsFile = f.Name()
 
-	user, host := splitUserHost(c.Placement.Directive)
-	args := manual.ProvisionMachineArgs{
-		Host:           host,
-		User:           user,
-		Client:         client,
-		Stdin:          ctx.Stdin,
-		Stdout:         ctx.Stdout,
-		Stderr:         ctx.Stderr,
-		AuthorizedKeys: authKeys,
-		UpdateBehavior: &params.UpdateBehavior{
-			EnableOSRefreshUpdate: config.EnableOSRefreshUpdate(),
-			EnableOSUpgrade:       config.EnableOSUpgrade(),
-		},
+	machineId, err := sshProvisioner(args)
+	if err != nil || !args.AcceptNewHostKey {
+		return machineId, err
+	}
+
+	// The host key accepted on first connection has been added to the
+	// known_hosts file by ssh; store it so that later connections to
+	// the host are verified.
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return machineId, errors.Trace(err)
+	}
+	keys, err = manual.ReadKnownHosts(f)
+	if err != nil {
+		return machineId, errors.Trace(err)
+	}
+	if len(keys) == 0 {
+		logger.Warningf("no SSH host keys were recorded for %q", args.Host)
+		return machineId, nil
+	}
+	if err := p.hostKeys.SetManualHostKeys(args.Host, keys); err != nil {
+		return machineId, errors.Annotatef(err, "storing SSH host keys for %q", args.Host)
 	}
+	p.ctx.Verbosef("stored SSH host keys for %s", args.Host)
+	return machineId, nil
+}
 
+// provisionWinRM provisions a Windows machine over WinRM.
+func (p manualProvisioner) provisionWinRM(args manual.ProvisionMachineArgs) (string, error) {
 	base := osenv.JujuXDGDataHomePath("x509")
 	keyPath := filepath.Join(base, "winrmkey.pem")
 	certPath := filepath.Join(base, "winrmcert.crt")
 	cert := winrm.NewX509()
 	if err := cert.LoadClientCert(keyPath, certPath); err != nil {
-		return errors.Annotatef(err, "connot load/create x509 client certs for winrm connection")
+		return "", errors.Annotatef(err, "connot load/create x509 client certs for winrm connection")
 	}
-	if err = cert.LoadCACert(filepath.Join(base, "winrmcacert.crt")); err != nil {
+	if err := cert.LoadCACert(filepath.Join(base, "winrmcacert.crt")); err != nil {
 		logger.Infof("cannot not find any CA cert to load")
 	}
 
@@ -407,17 +654,58 @@ func (c *addCommand) tryManualProvision(client AddMachineAPI, config *config.Con
 		cfg.CACert = caCert
 	}
 
+	var err error
 	args.WinRM = manual.WinRMArgs{}
 	args.WinRM.Keys = cert
 	args.WinRM.Client, err = winrm.NewClient(cfg)
 	if err != nil {
-		return errors.Annotatef(err, "cannot create secure winrm client conn")
+		return "", errors.Annotatef(err, "cannot create secure winrm client conn")
 	}
+	return winrmProvisioner(args)
+}
+
+// prefixWriter writes each line written to it to w, prefixed with
+// prefix. Lines are written while holding mu, which is shared by the
+// prefixWriters of all machines being provisioned concurrently, so
+// that their output is not interleaved within lines.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix string
+
+	// buf holds the incomplete last line written.
+	buf []byte
+}
 
-	machineId, err := provisionMachine(args)
-	if err == nil {
-		ctx.Infof("created machine %v", machineId)
+// Write is part of the io.Writer interface.
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i == -1 {
+			break
+		}
+		if err := p.writeLine(p.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		p.buf = p.buf[i+1:]
 	}
+	return len(b), nil
+}
+
+// Flush writes any incomplete last line.
+func (p *prefixWriter) Flush() error {
+	if len(p.buf) == 0 {
+		return nil
+	}
+	line := append(p.buf, '\n')
+	p.buf = nil
+	return p.writeLine(line)
+}
 
+func (p *prefixWriter) writeLine(line []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err := fmt.Fprintf(p.w, "%s%s", p.prefix, line)
 	return err
 }
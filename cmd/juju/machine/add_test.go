@@ -4,12 +4,17 @@
 package machine_test
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -27,6 +32,7 @@ type AddMachineSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	fakeAddMachine     *fakeAddMachineAPI
 	fakeMachineManager *fakeMachineManagerAPI
+	fakeHostKeys       *fakeHostKeysAPI
 }
 
 var _ = gc.Suite(&AddMachineSuite{})
@@ -35,6 +41,12 @@ func (s *AddMachineSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 	s.fakeAddMachine = &fakeAddMachineAPI{}
 	s.fakeMachineManager = &fakeMachineManagerAPI{}
+	s.fakeHostKeys = &fakeHostKeysAPI{
+		keys: map[string][]string{
+			"10.1.2.3": {"ssh-rsa AAAA1"},
+			"10.1.2.4": {"ssh-rsa AAAA2"},
+		},
+	}
 }
 
 func (s *AddMachineSuite) TestInit(c *gc.C) {
@@ -93,7 +105,7 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, addCmd := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager)
+		wrappedCommand, addCmd := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, s.fakeHostKeys)
 		err := cmdtesting.InitCommand(wrappedCommand, test.args)
 		if test.errorString == "" {
 			c.Check(err, jc.ErrorIsNil)
@@ -111,8 +123,44 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 	}
 }
 
+func (s *AddMachineSuite) TestInitManualPlacements(c *gc.C) {
+	wrappedCommand, addCmd := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, s.fakeHostKeys)
+	err := cmdtesting.InitCommand(wrappedCommand, []string{"ssh:user@10.10.0.3", "ssh:10.10.0.4", "winrm:10.10.0.5"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addCmd.Placement, gc.IsNil)
+	var placements []string
+	for _, placement := range addCmd.Placements {
+		placements = append(placements, placement.String())
+	}
+	c.Check(placements, jc.DeepEquals, []string{"ssh:user@10.10.0.3", "ssh:10.10.0.4", "winrm:10.10.0.5"})
+}
+
+func (s *AddMachineSuite) TestInitManualPlacementsErrors(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		errorString string
+	}{{
+		args:        []string{"ssh:10.10.0.3", "zone=us-east-1a"},
+		errorString: `cannot combine placement "zone=us-east-1a" with manual placements`,
+	}, {
+		args:        []string{"ssh:10.10.0.3", "lxd:4"},
+		errorString: `cannot combine placement "lxd:4" with manual placements`,
+	}, {
+		args:        []string{"ssh:10.10.0.3", "ssh:user@10.10.0.3"},
+		errorString: `host "10.10.0.3" specified more than once`,
+	}, {
+		args:        []string{"ssh:10.10.0.3", "ssh:10.10.0.4", "-n", "2"},
+		errorString: "cannot use -n when specifying a placement directive",
+	}} {
+		c.Logf("test %d", i)
+		wrappedCommand, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, s.fakeHostKeys)
+		err := cmdtesting.InitCommand(wrappedCommand, test.args)
+		c.Check(err, gc.ErrorMatches, test.errorString)
+	}
+}
+
 func (s *AddMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
-	add, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager)
+	add, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, s.fakeHostKeys)
 	return cmdtesting.RunCommand(c, add, args...)
 }
 
@@ -156,6 +204,106 @@ func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
 }
 
+func (s *AddMachineSuite) TestSSHPlacementKnownHosts(c *gc.C) {
+	var knownHosts string
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Check(args.Host, gc.Equals, "10.1.2.3")
+		c.Check(args.User, gc.Equals, "ubuntu")
+		c.Check(args.AcceptNewHostKey, jc.IsFalse)
+		data, err := ioutil.ReadFile(args.KnownHostsFile)
+		c.Check(err, jc.ErrorIsNil)
+		knownHosts = string(data)
+		return "42", nil
+	})
+	_, err := s.run(c, "ssh:ubuntu@10.1.2.3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(knownHosts, gc.Equals, "10.1.2.3 ssh-rsa AAAA1\n")
+	s.fakeHostKeys.CheckCallNames(c, "ManualHostKeys", "Close")
+}
+
+func (s *AddMachineSuite) TestSSHPlacementNoHostKeys(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Error("unexpected provisioning")
+		return "", nil
+	})
+	_, err := s.run(c, "ssh:10.9.9.9")
+	c.Assert(err, gc.ErrorMatches, `(?s)no SSH host keys are stored for "10.9.9.9".*--accept-new-host-keys.*`)
+}
+
+func (s *AddMachineSuite) TestSSHPlacementAcceptNewHostKeys(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Check(args.AcceptNewHostKey, jc.IsTrue)
+		// Simulate ssh recording the key presented by the host.
+		f, err := os.OpenFile(args.KnownHostsFile, os.O_APPEND|os.O_WRONLY, 0600)
+		c.Assert(err, jc.ErrorIsNil)
+		defer f.Close()
+		_, err = fmt.Fprintln(f, "10.9.9.9 ssh-ed25519 AAAA9")
+		c.Assert(err, jc.ErrorIsNil)
+		return "42", nil
+	})
+	context, err := s.run(c, "ssh:10.9.9.9", "--accept-new-host-keys")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "created machine 42\n")
+	s.fakeHostKeys.CheckCallNames(c, "ManualHostKeys", "SetManualHostKeys", "Close")
+	s.fakeHostKeys.CheckCall(c, 1, "SetManualHostKeys", "10.9.9.9", []string{"ssh-ed25519 AAAA9"})
+}
+
+func (s *AddMachineSuite) TestSSHPlacementAcceptNewHostKeysProvisionError(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		return "", errors.New("failed to initialize warp core")
+	})
+	_, err := s.run(c, "ssh:10.9.9.9", "--accept-new-host-keys")
+	c.Assert(err, gc.ErrorMatches, "failed to initialize warp core")
+	s.fakeHostKeys.CheckCallNames(c, "ManualHostKeys", "Close")
+}
+
+func (s *AddMachineSuite) TestSSHPlacementHostKeysNotSupported(c *gc.C) {
+	s.fakeHostKeys.SetErrors(errors.NotSupportedf("manual host keys on this controller"))
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Check(args.KnownHostsFile, gc.Equals, "")
+		c.Check(args.AcceptNewHostKey, jc.IsFalse)
+		return "42", nil
+	})
+	_, err := s.run(c, "ssh:10.1.2.3")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AddMachineSuite) TestMultipleSSHPlacements(c *gc.C) {
+	var mu sync.Mutex
+	hosts := make(map[string]bool)
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Check(args.PromptLock, gc.NotNil)
+		fmt.Fprintln(args.Stderr, "provisioning")
+		mu.Lock()
+		hosts[args.Host] = true
+		mu.Unlock()
+		switch args.Host {
+		case "10.1.2.3":
+			return "42", nil
+		case "10.1.2.4":
+			return "43", nil
+		}
+		return "", errors.New("failed to initialize warp core")
+	})
+	s.fakeHostKeys.keys["10.1.2.5"] = []string{"ssh-rsa AAAA3"}
+	context, err := s.run(c, "ssh:10.1.2.3", "ssh:10.1.2.4", "ssh:10.1.2.5")
+	c.Assert(err, gc.ErrorMatches, "10.1.2.5: failed to initialize warp core")
+	c.Check(hosts, jc.DeepEquals, map[string]bool{
+		"10.1.2.3": true,
+		"10.1.2.4": true,
+		"10.1.2.5": true,
+	})
+	stderr := cmdtesting.Stderr(context)
+	c.Check(stderr, jc.Contains, "10.1.2.3: provisioning\n")
+	c.Check(stderr, jc.Contains, "10.1.2.4: provisioning\n")
+	c.Check(stderr, jc.Contains, "10.1.2.5: provisioning\n")
+	c.Check(stderr, jc.HasSuffix, `
+created machine 42
+created machine 43
+failed to create 1 machine
+`)
+}
+
 func (s *AddMachineSuite) TestParamsPassedOn(c *gc.C) {
 	_, err := s.run(c, "--constraints", "mem=8G", "--series=special", "zone=nz")
 	c.Assert(err, jc.ErrorIsNil)
@@ -276,6 +424,40 @@ func (f *fakeAddMachineAPI) ModelGet() (map[string]interface{}, error) {
 	}), nil
 }
 
+type fakeHostKeysAPI struct {
+	jujutesting.Stub
+	mu   sync.Mutex
+	keys map[string][]string
+}
+
+func (f *fakeHostKeysAPI) ManualHostKeys(host string) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.MethodCall(f, "ManualHostKeys", host)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	keys, ok := f.keys[host]
+	if !ok {
+		return nil, &params.Error{Code: params.CodeNotFound, Message: "keys not found"}
+	}
+	return keys, nil
+}
+
+func (f *fakeHostKeysAPI) SetManualHostKeys(host string, keys []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.MethodCall(f, "SetManualHostKeys", host, keys)
+	return f.NextErr()
+}
+
+func (f *fakeHostKeysAPI) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
 type fakeMachineManagerAPI struct {
 	apiVersion int
 	fakeAddMachineAPI
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/cmd"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/storage"
 )
@@ -20,11 +21,14 @@ type AddCommand struct {
 }
 
 // NewAddCommand returns an AddCommand with the api provided as specified.
-func NewAddCommandForTest(api AddMachineAPI, mcAPI ModelConfigAPI, mmAPI MachineManagerAPI) (cmd.Command, *AddCommand) {
+func NewAddCommandForTest(
+	api AddMachineAPI, mcAPI ModelConfigAPI, mmAPI MachineManagerAPI, hkAPI ManualHostKeysAPI,
+) (cmd.Command, *AddCommand) {
 	cmd := &addCommand{
 		api:               api,
 		machineManagerAPI: mmAPI,
 		modelConfigAPI:    mcAPI,
+		hostKeysAPI:       hkAPI,
 	}
 	return modelcmd.Wrap(cmd), &AddCommand{cmd}
 }
@@ -46,10 +50,11 @@ type RemoveCommand struct {
 }
 
 // NewRemoveCommand returns an RemoveCommand with the api provided as specified.
-func NewRemoveCommandForTest(apiRoot api.Connection, machineAPI RemoveMachineAPI) (cmd.Command, *RemoveCommand) {
+func NewRemoveCommandForTest(apiRoot api.Connection, machineAPI RemoveMachineAPI, entityFinder common.EntityFinder) (cmd.Command, *RemoveCommand) {
 	cmd := &removeCommand{
-		apiRoot:    apiRoot,
-		machineAPI: machineAPI,
+		apiRoot:      apiRoot,
+		machineAPI:   machineAPI,
+		entityFinder: entityFinder,
 	}
 	return modelcmd.Wrap(cmd), &RemoveCommand{cmd}
 }
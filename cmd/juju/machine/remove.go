@@ -10,9 +10,11 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/entityfinder"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -26,6 +28,7 @@ type removeCommand struct {
 	modelcmd.ModelCommandBase
 	apiRoot      api.Connection
 	machineAPI   RemoveMachineAPI
+	entityFinder common.EntityFinder
 	MachineIds   []string
 	Force        bool
 	KeepInstance bool
@@ -136,6 +139,9 @@ func (c *removeCommand) getRemoveMachineAPI() (RemoveMachineAPI, error) {
 	if err != nil {
 		return nil, err
 	}
+	if c.entityFinder == nil {
+		c.entityFinder = entityfinder.NewClient(root)
+	}
 	if root.BestFacadeVersion("MachineManager") < 4 && c.KeepInstance {
 		return nil, errors.New("this version of Juju doesn't support --keep-instance")
 	}
@@ -175,7 +181,9 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 		result := results[i]
 		if result.Error != nil {
 			anyFailed = true
-			ctx.Infof("removing machine %s failed: %s", id, result.Error)
+			ctx.Infof("removing machine %s failed: %s", id,
+				common.ErrorWithSuggestion(c.entityFinder, result.Error, id, names.MachineTagKind),
+			)
 			continue
 		}
 		if c.KeepInstance {
@@ -8,8 +8,10 @@ import (
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/entityfinder"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
@@ -20,6 +22,7 @@ type RemoveMachineSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	fake          *fakeRemoveMachineAPI
 	apiConnection *mockAPIConnection
+	finder        *fakeEntityFinder
 }
 
 var _ = gc.Suite(&RemoveMachineSuite{})
@@ -30,10 +33,11 @@ func (s *RemoveMachineSuite) SetUpTest(c *gc.C) {
 	s.apiConnection = &mockAPIConnection{
 		bestFacadeVersion: 4,
 	}
+	s.finder = &fakeEntityFinder{}
 }
 
 func (s *RemoveMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
-	remove, _ := machine.NewRemoveCommandForTest(s.apiConnection, s.fake)
+	remove, _ := machine.NewRemoveCommandForTest(s.apiConnection, s.fake, s.finder)
 	return cmdtesting.RunCommand(c, remove, args...)
 }
 
@@ -74,7 +78,7 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.apiConnection, s.fake)
+		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.apiConnection, s.fake, s.finder)
 		err := cmdtesting.InitCommand(wrappedCommand, test.args)
 		if test.errorString == "" {
 			c.Check(err, jc.ErrorIsNil)
@@ -118,6 +122,26 @@ removing machine 2/lxd/1
 `[1:])
 }
 
+func (s *RemoveMachineSuite) TestRemoveNotFoundSuggestsMachines(c *gc.C) {
+	s.finder.matches = []entityfinder.Match{
+		{Tag: names.NewMachineTag("1")},
+		{Tag: names.NewMachineTag("12")},
+	}
+	s.fake.results = []params.DestroyMachineResult{{
+		Error: &params.Error{
+			Message: "machine 2 not found",
+			Code:    params.CodeNotFound,
+		},
+	}}
+	ctx, err := s.run(c, "2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 2 failed: machine 2 not found; did you mean "1" or "12"?
+`[1:])
+	c.Assert(s.finder.query, gc.Equals, "2")
+	c.Assert(s.finder.kinds, jc.DeepEquals, []string{names.MachineTagKind})
+}
+
 func (s *RemoveMachineSuite) TestRemoveOutputKeep(c *gc.C) {
 	ctx, err := s.run(c, "--keep-instance", "1", "2")
 	c.Assert(err, jc.ErrorIsNil)
@@ -212,3 +236,19 @@ type mockAPIConnection struct {
 func (m *mockAPIConnection) BestFacadeVersion(name string) int {
 	return m.bestFacadeVersion
 }
+
+type fakeEntityFinder struct {
+	matches []entityfinder.Match
+	query   string
+	kinds   []string
+}
+
+func (f *fakeEntityFinder) BestAPIVersion() int {
+	return 1
+}
+
+func (f *fakeEntityFinder) FindEntities(query string, limit int, kinds ...string) ([]entityfinder.Match, error) {
+	f.query = query
+	f.kinds = kinds
+	return f.matches, nil
+}
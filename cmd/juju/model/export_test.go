@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -42,6 +43,14 @@ func NewRetryProvisioningCommandForTest(api RetryProvisioningAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewWaitForCommandForTest returns a WaitForCommand with the api and
+// clock provided as specified.
+func NewWaitForCommandForTest(api WaitForAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	cmd := &waitForCommand{api: api, clock: clock}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewShowCommandForTest returns a ShowCommand with the api provided as specified.
 func NewShowCommandForTest(api ShowModelAPI, refreshFunc func(jujuclient.ClientStore, string) error, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showModelCommand{api: api}
@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/api/waitfor"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/condition"
+)
+
+// NewWaitForCommand returns a command that waits for a condition on
+// the model's applications, units and machines to be satisfied.
+func NewWaitForCommand() cmd.Command {
+	return modelcmd.Wrap(&waitForCommand{clock: clock.WallClock})
+}
+
+// waitForCommand blocks until a condition evaluated by the controller
+// is satisfied, or until it times out.
+type waitForCommand struct {
+	modelcmd.ModelCommandBase
+	api   WaitForAPI
+	clock clock.Clock
+
+	condition string
+	timeout   time.Duration
+}
+
+// WaitForAPI defines the methods on the waitfor API that the
+// wait-for command calls.
+type WaitForAPI interface {
+	Close() error
+	WaitFor(condition string, timeout time.Duration) (bool, []string, error)
+}
+
+const waitForHelpDoc = `
+Waits until a condition on the model's applications, units or machines
+is satisfied. The condition is evaluated by the controller, which
+notifies the client as soon as it is satisfied. If the condition is not
+satisfied before the timeout expires, the command fails and reports the
+terms of the condition that were not satisfied.
+
+A condition is one or more terms joined by "&&". Each term compares an
+attribute of an entity with a value, using one of ==, !=, <, <=, > or
+>=. The supported attributes are:
+
+    application:<name>.status
+    application:<name>.units
+    application:<name>.config.<key>
+    unit:<name>.status
+    unit:<name>.agent-status
+    machine:<id>.status
+    machine:<id>.instance-status
+
+Examples:
+
+    juju wait-for "application:mysql.status == active"
+    juju wait-for "application:mysql.units >= 3 && unit:mysql/0.agent-status == idle"
+    juju wait-for --timeout 30m "machine:0.instance-status == running"
+
+See also:
+    status
+`
+
+// Info implements Command.
+func (c *waitForCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait-for",
+		Args:    "<condition>",
+		Purpose: "Waits for a condition on the model's entities to be satisfied.",
+		Doc:     waitForHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *waitForCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.timeout, "timeout", 10*time.Minute, "How long to wait for the condition")
+}
+
+// Init implements Command.
+func (c *waitForCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no condition specified")
+	}
+	c.condition = strings.Join(args, " ")
+	if _, err := condition.Parse(c.condition); err != nil {
+		return errors.Annotate(err, "invalid condition")
+	}
+	if c.timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+	return nil
+}
+
+func (c *waitForCommand) getAPI() (WaitForAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return waitfor.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *waitForCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// The controller limits how long each call waits, so keep
+	// calling until the condition is satisfied or the timeout
+	// expires.
+	deadline := c.clock.Now().Add(c.timeout)
+	for {
+		remaining := deadline.Sub(c.clock.Now())
+		if remaining < 0 {
+			remaining = 0
+		}
+		satisfied, unsatisfied, err := client.WaitFor(c.condition, remaining)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if satisfied {
+			ctx.Infof("condition satisfied")
+			return nil
+		}
+		if !c.clock.Now().Before(deadline) {
+			return errors.Errorf("timed out after %v waiting for: %s", c.timeout, strings.Join(unsatisfied, " && "))
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type WaitForCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeWaitForClient
+	clock *gitjujutesting.Clock
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&WaitForCommandSuite{})
+
+type waitForResult struct {
+	satisfied   bool
+	unsatisfied []string
+}
+
+// fakeWaitForClient returns the queued results in turn, advancing
+// the clock by the time each call would have waited.
+type fakeWaitForClient struct {
+	gitjujutesting.Stub
+	clock   *gitjujutesting.Clock
+	results []waitForResult
+}
+
+func (f *fakeWaitForClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeWaitForClient) WaitFor(condition string, timeout time.Duration) (bool, []string, error) {
+	f.MethodCall(f, "WaitFor", condition, timeout)
+	if err := f.NextErr(); err != nil {
+		return false, nil, err
+	}
+	result := f.results[0]
+	f.results = f.results[1:]
+	if !result.satisfied {
+		if timeout > 5*time.Minute {
+			timeout = 5 * time.Minute
+		}
+		f.clock.Advance(timeout)
+	}
+	return result.satisfied, result.unsatisfied, nil
+}
+
+func (s *WaitForCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.clock = gitjujutesting.NewClock(time.Time{})
+	s.fake = &fakeWaitForClient{clock: s.clock}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *WaitForCommandSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewWaitForCommandForTest(s.fake, s.clock, s.store), args...)
+}
+
+func (s *WaitForCommandSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no condition specified",
+	}, {
+		args: []string{"application:mysql.status"},
+		err:  `invalid condition: term "application:mysql.status" not valid`,
+	}, {
+		args: []string{"--timeout", "-1s", "application:mysql.status == active"},
+		err:  "timeout must not be negative",
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WaitForCommandSuite) TestSatisfied(c *gc.C) {
+	s.fake.results = []waitForResult{{satisfied: true}}
+	ctx, err := s.run(c, "application:mysql.units", ">=", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "condition satisfied\n")
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"WaitFor", []interface{}{"application:mysql.units >= 3", 10 * time.Minute}},
+		{"Close", nil},
+	})
+}
+
+func (s *WaitForCommandSuite) TestSatisfiedAfterSeveralCalls(c *gc.C) {
+	s.fake.results = []waitForResult{
+		{unsatisfied: []string{"application:mysql.units >= 3"}},
+		{satisfied: true},
+	}
+	_, err := s.run(c, "application:mysql.units >= 3")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"WaitFor", []interface{}{"application:mysql.units >= 3", 10 * time.Minute}},
+		{"WaitFor", []interface{}{"application:mysql.units >= 3", 5 * time.Minute}},
+		{"Close", nil},
+	})
+}
+
+func (s *WaitForCommandSuite) TestTimeout(c *gc.C) {
+	s.fake.results = []waitForResult{
+		{unsatisfied: []string{"application:mysql.units >= 3", "machine:0.status == started"}},
+	}
+	_, err := s.run(c, "--timeout", "2m", "application:mysql.units >= 3 && machine:0.status == started")
+	c.Assert(err, gc.ErrorMatches,
+		`timed out after 2m0s waiting for: application:mysql.units >= 3 && machine:0.status == started`)
+	s.fake.CheckCallNames(c, "WaitFor", "Close")
+}
+
+func (s *WaitForCommandSuite) TestError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "application:mysql.status == active")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
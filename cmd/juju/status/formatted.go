@@ -194,6 +194,16 @@ type unitStatus struct {
 	OpenedPorts   []string              `json:"open-ports,omitempty" yaml:"open-ports,omitempty"`
 	PublicAddress string                `json:"public-address,omitempty" yaml:"public-address,omitempty"`
 	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
+
+	// Payloads is keyed by the payload's full ID (class/id).
+	Payloads map[string]payloadStatus `json:"payloads,omitempty" yaml:"payloads,omitempty"`
+}
+
+type payloadStatus struct {
+	Type     string   `json:"type" yaml:"type"`
+	Status   string   `json:"status" yaml:"status"`
+	Labels   []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Restarts int      `json:"restarts,omitempty" yaml:"restarts,omitempty"`
 }
 
 func (s *formattedStatus) applicationScale(name string) (string, bool) {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/status"
 )
@@ -336,6 +337,18 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		}
 	}
 
+	if len(info.unit.Payloads) > 0 {
+		out.Payloads = make(map[string]payloadStatus)
+		for _, p := range info.unit.Payloads {
+			out.Payloads[payload.BuildID(p.Class, p.ID)] = payloadStatus{
+				Type:     p.Type,
+				Status:   p.Status,
+				Labels:   p.Labels,
+				Restarts: p.Restarts,
+			}
+		}
+	}
+
 	for k, m := range info.unit.Subordinates {
 		out.Subordinates[k] = sf.formatUnit(unitFormatInfo{
 			unit:            m,
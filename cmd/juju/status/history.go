@@ -50,6 +50,7 @@ The statuses are available for the following types.
     juju-unit: will show statuses for the unit's juju agent.
     workload: will show statuses for the unit's workload.
     unit: will show workload and juju agent combined for the specified unit.
+    payload: will show statuses for the unit's payloads.
     juju-machine: will show statuses for machine's juju agent.
     machine: will show statuses for machines.
     juju-container: will show statuses for the container's juju agent.
@@ -69,7 +70,7 @@ func (c *statusHistoryCommand) Info() *cmd.Info {
 
 func (c *statusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
-	f.StringVar(&c.outputContent, "type", "unit", "Type of statuses to be displayed [agent|workload|combined|machine|machineInstance|container|containerinstance]")
+	f.StringVar(&c.outputContent, "type", "unit", "Type of statuses to be displayed [agent|workload|combined|payload|machine|machineInstance|container|containerinstance]")
 	f.IntVar(&c.backlogSize, "n", 0, "Returns the last N logs (cannot be combined with --days or --date)")
 	f.IntVar(&c.backlogSizeDays, "days", 0, "Returns the logs for the past <days> days (cannot be combined with -n or --date)")
 	f.StringVar(&c.backlogDate, "from-date", "", "Returns logs for any date after the passed one, the expected date format is YYYY-MM-DD (cannot be combined with -n or --days)")
@@ -149,7 +150,7 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 	}
 	var tag names.Tag
 	switch kind {
-	case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
+	case status.KindUnit, status.KindWorkload, status.KindUnitAgent, status.KindPayload:
 		if !names.IsValidUnit(c.entityName) {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
 		}
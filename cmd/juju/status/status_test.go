@@ -4782,3 +4782,29 @@ func (s *StatusSuite) TestFormatProvisioningError(c *gc.C) {
 		Offers:             map[string]offerStatus{},
 	})
 }
+
+func (s *StatusSuite) TestFormatUnitPayloads(c *gc.C) {
+	formatter := NewStatusFormatter(&params.FullStatus{}, true)
+	out := formatter.formatUnit(unitFormatInfo{
+		unitName:        "mysql/0",
+		applicationName: "mysql",
+		unit: params.UnitStatus{
+			Payloads: []params.Payload{{
+				Class:    "db",
+				Type:     "docker",
+				ID:       "abc123",
+				Status:   "running",
+				Labels:   []string{"primary"},
+				Restarts: 2,
+			}},
+		},
+	})
+	c.Check(out.Payloads, jc.DeepEquals, map[string]payloadStatus{
+		"db/abc123": {
+			Type:     "docker",
+			Status:   "running",
+			Labels:   []string{"primary"},
+			Restarts: 2,
+		},
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewDetachedCommand returns a command for listing detached storage.
+func NewDetachedCommand() cmd.Command {
+	cmd := &detachedCommand{}
+	cmd.newAPIFunc = func() (StorageDetachedAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+const detachedCommandDoc = `
+List storage in the model that is not attached to any unit.
+
+Detached storage keeps its data, and may be reused by attaching it
+to an existing unit with "juju attach-storage", or to a new unit with
+"juju deploy --attach-storage" or "juju add-unit --attach-storage".
+The unit that last owned the storage, and the label of the filesystem
+on it (if known), are shown to help identify the storage to reuse.
+
+Examples:
+    juju detached-storage
+    juju attach-storage postgresql/1 pgdata/0
+
+See also:
+    attach-storage
+    storage
+`
+
+// detachedCommand lists detached storage instances.
+type detachedCommand struct {
+	StorageCommandBase
+	out        cmd.Output
+	newAPIFunc func() (StorageDetachedAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *detachedCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "detached-storage",
+		Purpose: "Lists storage that is not attached to any unit.",
+		Doc:     detachedCommandDoc,
+		Aliases: []string{"list-detached-storage"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *detachedCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatDetachedStorageTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *detachedCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *detachedCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.ListDetachedStorage()
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		ctx.Infof("No detached storage to display.")
+		return nil
+	}
+	detached, err := formatDetachedStorage(results)
+	if err != nil {
+		return err
+	}
+	return c.out.Write(ctx, detached)
+}
+
+// StorageDetachedAPI defines the API methods that the detached-storage
+// command uses.
+type StorageDetachedAPI interface {
+	Close() error
+	ListDetachedStorage() ([]params.DetachedStorageDetails, error)
+}
+
+// DetachedStorageInfo defines the serialization behaviour of
+// detached storage information.
+type DetachedStorageInfo struct {
+	Kind            string `yaml:"kind" json:"kind"`
+	Pool            string `yaml:"pool,omitempty" json:"pool,omitempty"`
+	Size            uint64 `yaml:"size,omitempty" json:"size,omitempty"`
+	LastApplication string `yaml:"last-application,omitempty" json:"last-application,omitempty"`
+	LastUnit        string `yaml:"last-unit,omitempty" json:"last-unit,omitempty"`
+	FilesystemLabel string `yaml:"filesystem-label,omitempty" json:"filesystem-label,omitempty"`
+}
+
+func formatDetachedStorage(all []params.DetachedStorageDetails) (map[string]DetachedStorageInfo, error) {
+	output := make(map[string]DetachedStorageInfo)
+	for _, details := range all {
+		storageTag, err := names.ParseStorageTag(details.StorageTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := DetachedStorageInfo{
+			Kind:            details.Kind.String(),
+			Pool:            details.Pool,
+			Size:            details.Size,
+			LastApplication: details.LastApplication,
+			FilesystemLabel: details.FilesystemLabel,
+		}
+		if details.LastUnitTag != "" {
+			unitTag, err := names.ParseUnitTag(details.LastUnitTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info.LastUnit = unitTag.Id()
+		}
+		output[storageTag.Id()] = info
+	}
+	return output, nil
+}
+
+// formatDetachedStorageTabular writes a tabular summary of detached
+// storage instances.
+func formatDetachedStorageTabular(writer io.Writer, value interface{}) error {
+	detached, ok := value.(map[string]DetachedStorageInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", detached, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Storage", "Kind", "Pool", "Size", "Last unit", "Label")
+
+	ids := make([]string, 0, len(detached))
+	for id := range detached {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		info := detached[id]
+		var size string
+		if info.Size > 0 {
+			size = humanize.IBytes(info.Size * humanize.MiByte)
+		}
+		print(id, info.Kind, info.Pool, size, info.LastUnit, info.FilesystemLabel)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	_ "github.com/juju/juju/provider/dummy"
+)
+
+type DetachedStorageSuite struct {
+	SubStorageSuite
+	api *mockDetachedAPI
+}
+
+var _ = gc.Suite(&DetachedStorageSuite{})
+
+func (s *DetachedStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.api = &mockDetachedAPI{
+		detached: []params.DetachedStorageDetails{{
+			StorageTag:      "storage-pgdata-0",
+			StorageName:     "pgdata",
+			Kind:            params.StorageKindBlock,
+			Pool:            "ebs",
+			Size:            10240,
+			LastApplication: "postgresql",
+			LastUnitTag:     "unit-postgresql-0",
+			FilesystemLabel: "pgdata",
+		}, {
+			StorageTag:      "storage-logs-1",
+			StorageName:     "logs",
+			Kind:            params.StorageKindFilesystem,
+			Pool:            "rootfs",
+			Size:            512,
+			LastApplication: "wordpress",
+			LastUnitTag:     "unit-wordpress-1",
+			FilesystemLabel: "logs",
+		}},
+	}
+}
+
+func (s *DetachedStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewDetachedCommandForTest(s.api, s.store), args...)
+}
+
+func (s *DetachedStorageSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Storage   Kind        Pool    Size     Last unit     Label
+logs/1    filesystem  rootfs  512 MiB  wordpress/1   logs
+pgdata/0  block       ebs     10 GiB   postgresql/0  pgdata
+`[1:])
+	s.api.CheckCallNames(c, "ListDetachedStorage", "Close")
+}
+
+func (s *DetachedStorageSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+logs/1:
+  kind: filesystem
+  pool: rootfs
+  size: 512
+  last-application: wordpress
+  last-unit: wordpress/1
+  filesystem-label: logs
+pgdata/0:
+  kind: block
+  pool: ebs
+  size: 10240
+  last-application: postgresql
+  last-unit: postgresql/0
+  filesystem-label: pgdata
+`[1:])
+}
+
+func (s *DetachedStorageSuite) TestNone(c *gc.C) {
+	s.api.detached = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No detached storage to display.\n")
+}
+
+func (s *DetachedStorageSuite) TestError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *DetachedStorageSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "pgdata/0")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["pgdata/0"\]`)
+}
+
+type mockDetachedAPI struct {
+	testing.Stub
+	detached []params.DetachedStorageDetails
+}
+
+func (m *mockDetachedAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockDetachedAPI) ListDetachedStorage() ([]params.DetachedStorageDetails, error) {
+	m.MethodCall(m, "ListDetachedStorage")
+	return m.detached, m.NextErr()
+}
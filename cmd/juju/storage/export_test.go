@@ -47,6 +47,14 @@ func NewListCommandForTest(api StorageListAPI, store jujuclient.ClientStore) cmd
 	return modelcmd.Wrap(cmd)
 }
 
+func NewDetachedCommandForTest(api StorageDetachedAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &detachedCommand{newAPIFunc: func() (StorageDetachedAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewAddCommandForTest(api StorageAddAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &addCommand{newAPIFunc: func() (StorageAddAPI, error) {
 		return api, nil
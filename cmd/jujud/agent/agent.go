@@ -17,6 +17,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/logformat"
 	"github.com/juju/juju/cmd/jujud/util"
 )
 
@@ -136,6 +137,15 @@ func setupAgentLogging(config agent.Config) {
 		}
 	}
 
+	format := config.Value(agent.LoggingFormatOverride)
+	if format == "" {
+		format = config.Value(agent.LoggingFormat)
+	}
+	logformat.Default().SetContext(config.Model().Id(), config.Tag())
+	if err := logformat.Default().SetFormat(format); err != nil {
+		logger.Errorf("problem setting logging format %v", err)
+	}
+
 	if flags := featureflag.String(); flags != "" {
 		logger.Warningf("developer feature flags enabled: %s", flags)
 	}
@@ -4,6 +4,8 @@
 package agent
 
 import (
+	"encoding/json"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/loggo"
@@ -11,15 +13,18 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/series"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/logformat"
 	"github.com/juju/juju/cmd/jujud/agent/agenttest"
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	imagetesting "github.com/juju/juju/environs/imagemetadata/testing"
 	"github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/proxyupdater"
 )
 
@@ -119,11 +124,50 @@ func (*agentLoggingSuite) TestLoggingConfig(c *gc.C) {
 	c.Assert(loggo.LoggerInfo(), gc.Equals, "<root>=WARNING;test=INFO")
 }
 
+func (s *agentLoggingSuite) TestLoggingFormat(c *gc.C) {
+	s.AddCleanup(func(*gc.C) { logformat.Default().SetFormat("") })
+	f := &fakeLoggingConfig{
+		loggingFormat: "json",
+	}
+
+	setupAgentLogging(f)
+
+	formatted := logformat.Default().Format(loggo.Entry{Message: "hello"}, loggo.DefaultFormatter)
+	var record logformat.Record
+	c.Assert(json.Unmarshal([]byte(formatted), &record), jc.ErrorIsNil)
+	c.Check(record.Message, gc.Equals, "hello")
+	c.Check(record.ModelUUID, gc.Equals, coretesting.ModelTag.Id())
+	c.Check(record.Entity, gc.Equals, "machine-0")
+}
+
+func (s *agentLoggingSuite) TestLoggingFormatOverride(c *gc.C) {
+	s.AddCleanup(func(*gc.C) { logformat.Default().SetFormat("") })
+	f := &fakeLoggingConfig{
+		loggingFormat:         "json",
+		loggingFormatOverride: "text",
+	}
+
+	setupAgentLogging(f)
+
+	formatted := logformat.Default().Format(loggo.Entry{Message: "hello"}, loggo.DefaultFormatter)
+	c.Check(formatted, gc.Equals, loggo.DefaultFormatter(loggo.Entry{Message: "hello"}))
+}
+
 type fakeLoggingConfig struct {
 	agent.Config
 
-	loggingConfig   string
-	loggingOverride string
+	loggingConfig         string
+	loggingOverride       string
+	loggingFormat         string
+	loggingFormatOverride string
+}
+
+func (f *fakeLoggingConfig) Tag() names.Tag {
+	return names.NewMachineTag("0")
+}
+
+func (f *fakeLoggingConfig) Model() names.ModelTag {
+	return coretesting.ModelTag
 }
 
 func (f *fakeLoggingConfig) LoggingConfig() string {
@@ -131,8 +175,13 @@ func (f *fakeLoggingConfig) LoggingConfig() string {
 }
 
 func (f *fakeLoggingConfig) Value(key string) string {
-	if key == agent.LoggingOverride {
+	switch key {
+	case agent.LoggingOverride:
 		return f.loggingOverride
+	case agent.LoggingFormat:
+		return f.loggingFormat
+	case agent.LoggingFormatOverride:
+		return f.loggingFormatOverride
 	}
 	return ""
 }
@@ -39,7 +39,9 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"action-scheduler",
 		"charm-revision-updater",
+		"charm-upgrader",
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
@@ -50,12 +52,15 @@ var (
 		"migration-inactive-flag",
 		"migration-master",
 		"application-scaler",
+		"space-discovery",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
 		"unit-assigner",
 		"remote-relations",
+		"offer-connection-pruner",
 		"log-forwarder",
+		"retry-budget",
 	}
 	migratingModelWorkers = []string{
 		"environ-tracker",
@@ -65,6 +70,7 @@ var (
 		"model-upgrade-gate",
 		"model-upgraded-flag",
 		"log-forwarder",
+		"retry-budget",
 	}
 	// ReallyLongTimeout should be long enough for the model-tracker
 	// tests that depend on a hosted model; its backing state is not
@@ -121,6 +127,7 @@ var (
 		"api-address-updater",
 		"disk-manager",
 		"fan-configurer",
+		"health-checker",
 		// "host-key-reporter", not stable, exits when done
 		"log-sender",
 		"logging-config-updater",
@@ -5,6 +5,7 @@ package agent
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
@@ -40,6 +41,7 @@ import (
 	"github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/api"
 	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/apimetrics"
 	"github.com/juju/juju/api/base"
 	apideployer "github.com/juju/juju/api/deployer"
 	apimachiner "github.com/juju/juju/api/machiner"
@@ -81,6 +83,7 @@ import (
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/dependency/dependencymetrics"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/imagemetadataworker"
@@ -309,6 +312,8 @@ func NewMachineAgent(
 		prometheusRegistry:          prometheusRegistry,
 		mongoTxnCollector:           mongometrics.NewTxnCollector(),
 		mongoDialCollector:          mongometrics.NewDialCollector(),
+		engineMetrics:               &dependencymetrics.EngineMetrics{},
+		apiCallMetrics:              apimetrics.NewCallMetrics(),
 		preUpgradeSteps:             preUpgradeSteps,
 		statePool:                   &statePoolHolder{},
 	}
@@ -340,6 +345,12 @@ func (a *MachineAgent) registerPrometheusCollectors() error {
 	if err := a.prometheusRegistry.Register(a.mongoDialCollector); err != nil {
 		return errors.Annotate(err, "registering mongo dial collector")
 	}
+	if err := a.prometheusRegistry.Register(a.engineMetrics); err != nil {
+		return errors.Annotate(err, "registering dependency engine collector")
+	}
+	if err := a.prometheusRegistry.Register(a.apiCallMetrics); err != nil {
+		return errors.Annotate(err, "registering API call collector")
+	}
 	return nil
 }
 
@@ -374,6 +385,8 @@ type MachineAgent struct {
 	prometheusRegistry         *prometheus.Registry
 	mongoTxnCollector          *mongometrics.TxnCollector
 	mongoDialCollector         *mongometrics.DialCollector
+	engineMetrics              *dependencymetrics.EngineMetrics
+	apiCallMetrics             *apimetrics.CallMetrics
 	preUpgradeSteps            upgrades.PreUpgradeStepsFunc
 
 	// Only API servers have hubs. This is temporary until the apiserver and
@@ -557,6 +570,12 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 				return nil
 			})
 		}
+		updateAgentConfLoggingFormat := func(format string) error {
+			return a.AgentConfigWriter.ChangeConfig(func(setter agent.ConfigSetter) error {
+				setter.SetValue(agent.LoggingFormat, format)
+				return nil
+			})
+		}
 		manifolds := machineManifolds(machine.ManifoldsConfig{
 			PreviousAgentVersion: previousAgentVersion,
 			Agent:                agent.APIHostPortsSetter{Agent: a},
@@ -575,15 +594,18 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			Clock:                clock.WallClock,
 			ValidateMigration:    a.validateMigration,
 			PrometheusRegisterer: a.prometheusRegistry,
+			APICallInterceptors:  []base.APICallInterceptor{a.apiCallMetrics},
 			CentralHub:           a.centralHub,
 			PubSubReporter:       pubsubReporter,
 			UpdateLoggerConfig:   updateAgentConfLogging,
+			UpdateLoggerFormat:   updateAgentConfLoggingFormat,
 			NewAgentStatusSetter: func(apiConn api.Connection) (upgradesteps.StatusSetter, error) {
 				return a.machine(apiConn)
 			},
 			ControllerLeaseDuration:  time.Minute,
 			LogPruneInterval:         5 * time.Minute,
 			TransactionPruneInterval: time.Hour,
+			HealthCheckInterval:      time.Minute,
 		})
 		if err := dependency.Install(engine, manifolds); err != nil {
 			if err := worker.Stop(engine); err != nil {
@@ -606,6 +628,17 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			// and the agent is controlled by by the OS to only have one.
 			logger.Errorf("failed to start introspection worker: %v", err)
 		}
+		if err := startMetricsEndpoint(metricsEndpointConfig{
+			AgentConfig:   a.CurrentConfig(),
+			Engine:        engine,
+			Gatherer:      a.prometheusRegistry,
+			NewSocketName: a.newIntrospectionSocketName,
+		}); err != nil {
+			// As with the introspection worker, the agent is
+			// still useful without its metrics endpoint.
+			logger.Errorf("failed to start metrics endpoint: %v", err)
+		}
+		a.engineMetrics.SetEngine(engine)
 		return engine, nil
 	}
 }
@@ -1102,7 +1135,7 @@ func (a *MachineAgent) initState(agentConfig agent.Config) (*state.State, error)
 func (a *MachineAgent) startStateWorkers(
 	st *state.State,
 	dependencyReporter dependency.Reporter,
-) (worker.Worker, error) {
+) (_ worker.Worker, err error) {
 	agentConfig := a.CurrentConfig()
 
 	m, err := getMachine(st, agentConfig.Tag())
@@ -1238,16 +1271,21 @@ func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (work
 	}
 
 	manifolds := modelManifolds(model.ManifoldsConfig{
-		Agent:                       modelAgent,
-		AgentConfigChanged:          a.configChangedVal,
-		Clock:                       clock.WallClock,
-		RunFlagDuration:             time.Minute,
-		CharmRevisionUpdateInterval: 24 * time.Hour,
-		InstPollerAggregationDelay:  3 * time.Second,
-		StatusHistoryPrunerInterval: 5 * time.Minute,
-		ActionPrunerInterval:        24 * time.Hour,
-		NewEnvironFunc:              newEnvirons,
-		NewMigrationMaster:          migrationmaster.NewWorker,
+		Agent:                         modelAgent,
+		AgentConfigChanged:            a.configChangedVal,
+		Clock:                         clock.WallClock,
+		RunFlagDuration:               time.Minute,
+		CharmRevisionUpdateInterval:   24 * time.Hour,
+		SpaceDiscoveryInterval:        time.Hour,
+		InstPollerAggregationDelay:    3 * time.Second,
+		StatusHistoryPrunerInterval:   5 * time.Minute,
+		ActionPrunerInterval:          24 * time.Hour,
+		ActionSchedulerInterval:       time.Minute,
+		ActionRolloutInterval:         5 * time.Second,
+		CharmUpgraderInterval:         10 * time.Second,
+		OfferConnectionPrunerInterval: time.Hour,
+		NewEnvironFunc:                newEnvirons,
+		NewMigrationMaster:            migrationmaster.NewWorker,
 	})
 	if err := dependency.Install(engine, manifolds); err != nil {
 		if err := worker.Stop(engine); err != nil {
@@ -1331,12 +1369,20 @@ func (a *MachineAgent) newAPIserverWorker(
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	auditEntrySink, auditWorkers := newAuditEntrySink(st, logDir, controllerConfig)
+	defer func() {
+		if err != nil {
+			for _, w := range auditWorkers {
+				worker.Stop(w)
+			}
+		}
+	}()
 	newObserver, err := newObserverFn(
 		controllerConfig,
 		clock.WallClock,
 		jujuversion.Current,
 		agentConfig.Model().Id(),
-		newAuditEntrySink(st, logDir),
+		auditEntrySink,
 		auditErrorHandler,
 		a.prometheusRegistry,
 	)
@@ -1360,6 +1406,18 @@ func (a *MachineAgent) newAPIserverWorker(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting log sink config")
 	}
+	introspectionConfig, err := getIntrospectionConfig(agentConfig)
+	if err != nil {
+		listener.Close()
+		return nil, errors.Annotate(err, "getting introspection config")
+	}
+
+	// SSH sessions tunnelled through the controller are audited
+	// along with the API requests, when auditing is enabled.
+	var sshProxyAuditSink audit.AuditEntrySinkFn
+	if controllerConfig.AuditingEnabled() {
+		sshProxyAuditSink = auditEntrySink
+	}
 
 	server, err := apiserver.NewServer(statePool, listener, apiserver.ServerConfig{
 		Clock:                         clock.WallClock,
@@ -1379,6 +1437,9 @@ func (a *MachineAgent) newAPIserverWorker(
 		RateLimitConfig:               rateLimitConfig,
 		LogSinkConfig:                 &logSinkConfig,
 		PrometheusRegisterer:          a.prometheusRegistry,
+		IntrospectionConfig:           introspectionConfig,
+		CallRateLimitConfig:           getCallRateLimitConfig(controllerConfig),
+		AuditEntrySink:                sshProxyAuditSink,
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start api server worker")
@@ -1409,7 +1470,7 @@ func (a *MachineAgent) newAPIserverWorker(
 			stateMetricsRunner.Wait()
 			return apiserverWorker.Catacomb.ErrDying()
 		},
-		Init: []worker.Worker{server, stateMetricsRunner},
+		Init: append([]worker.Worker{server, stateMetricsRunner}, auditWorkers...),
 	}); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1425,6 +1486,19 @@ func (w *catacombWorker) Kill() {
 	w.Catacomb.Kill(nil)
 }
 
+// getCallRateLimitConfig returns the configuration for limiting user
+// API calls, or nil if they should not be limited.
+func getCallRateLimitConfig(cfg controller.Config) *apiserver.CallRateLimitConfig {
+	burst := cfg.APICallRateLimitBurst()
+	if burst <= 0 {
+		return nil
+	}
+	return &apiserver.CallRateLimitConfig{
+		Burst:  int64(burst),
+		Refill: cfg.APICallRateLimitRefill(),
+	}
+}
+
 func getRateLimitConfig(cfg agent.Config) (apiserver.RateLimitConfig, error) {
 	result := apiserver.DefaultRateLimitConfig()
 	if v := cfg.Value(agent.AgentLoginRateLimit); v != "" {
@@ -1511,9 +1585,39 @@ func getRateLimitConfig(cfg agent.Config) (apiserver.RateLimitConfig, error) {
 	return result, nil
 }
 
-func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
+// auditWebhookQueueSize is the number of audit records that may be
+// waiting to be sent to the webhook before further records are dropped.
+const auditWebhookQueueSize = 1000
+
+// newAuditEntrySink returns the sink for audit records, along with any
+// workers started to send them, which must be stopped when the sink
+// is no longer used.
+func newAuditEntrySink(st *state.State, logDir string, controllerConfig controller.Config) (audit.AuditEntrySinkFn, []worker.Worker) {
 	persistFn := st.PutAuditEntryFn()
 	fileSinkFn := audit.NewLogFileSink(logDir)
+
+	// Records are also sent to any optional sinks that have been
+	// configured. Failures to do so are logged, but do not
+	// prevent the record being saved.
+	var extraSinks []audit.AuditEntrySinkFn
+	var workers []worker.Worker
+	if controllerConfig.AuditingEnabled() && controllerConfig.AuditLogSyslog() {
+		syslogSinkFn, err := audit.NewSyslogSink("juju-audit")
+		if err != nil {
+			logger.Errorf("cannot send audit records to syslog: %v", err)
+		} else {
+			extraSinks = append(extraSinks, syslogSinkFn)
+		}
+	}
+	if url := controllerConfig.AuditLogWebhookURL(); controllerConfig.AuditingEnabled() && url != "" {
+		// The webhook is called in the background, so that a slow
+		// or unavailable endpoint does not hold up API requests.
+		client := &http.Client{Timeout: 10 * time.Second}
+		webhookSink := audit.NewAsyncSink(audit.NewWebhookSink(url, client), auditWebhookQueueSize)
+		extraSinks = append(extraSinks, webhookSink.Send)
+		workers = append(workers, webhookSink)
+	}
+
 	return func(entry audit.AuditEntry) error {
 		// We don't care about auditing anything but user actions.
 		if _, err := names.ParseUserTag(entry.OriginName); err != nil {
@@ -1523,6 +1627,11 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 		if strings.HasPrefix(entry.Operation, "Pinger:") {
 			return nil
 		}
+		for _, sink := range extraSinks {
+			if err := sink(entry); err != nil {
+				logger.Errorf("cannot send audit record: %v", err)
+			}
+		}
 		persistErr := persistFn(entry)
 		sinkErr := fileSinkFn(entry)
 		if persistErr == nil {
@@ -1532,7 +1641,7 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 			return errors.Annotate(persistErr, "cannot save audit record to database")
 		}
 		return errors.Annotate(persistErr, "cannot save audit record to file or database")
-	}
+	}, workers
 }
 
 func newObserverFn(
@@ -1929,6 +2038,44 @@ func newStateMetricsWorker(statePool *state.StatePool, registry *prometheus.Regi
 	})
 }
 
+// getIntrospectionConfig returns the configuration for the dedicated
+// introspection listener, or nil if no introspection port is set. The
+// returned config holds a newly opened listener.
+func getIntrospectionConfig(cfg agent.Config) (*apiserver.IntrospectionConfig, error) {
+	v := cfg.Value(agent.IntrospectionPort)
+	if v == "" {
+		return nil, nil
+	}
+	port, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing %s", agent.IntrospectionPort)
+	}
+	result := &apiserver.IntrospectionConfig{
+		Username: cfg.Value(agent.IntrospectionUsername),
+		Password: cfg.Value(agent.IntrospectionPassword),
+	}
+	if path := cfg.Value(agent.IntrospectionCertFile); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading %s", agent.IntrospectionCertFile)
+		}
+		result.Cert = string(data)
+	}
+	if path := cfg.Value(agent.IntrospectionKeyFile); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading %s", agent.IntrospectionKeyFile)
+		}
+		result.Key = string(data)
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(port)))
+	if err != nil {
+		return nil, errors.Annotate(err, "listening on introspection port")
+	}
+	result.Listener = listener
+	return result, nil
+}
+
 func getLogSinkConfig(cfg agent.Config) (apiserver.LogSinkConfig, error) {
 	result := apiserver.DefaultLogSinkConfig()
 	var err error
@@ -43,6 +43,7 @@ import (
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/globalclockupdater"
+	"github.com/juju/juju/worker/healthchecker"
 	"github.com/juju/juju/worker/hostkeyreporter"
 	"github.com/juju/juju/worker/identityfilewriter"
 	"github.com/juju/juju/worker/logger"
@@ -158,6 +159,10 @@ type ManifoldsConfig struct {
 	// by workers to register Prometheus metric collectors.
 	PrometheusRegisterer prometheus.Registerer
 
+	// APICallInterceptors are added to the agent's API connections,
+	// and are called for every API call made over them.
+	APICallInterceptors []base.APICallInterceptor
+
 	// CentralHub is the primary hub that exists in the apiserver.
 	CentralHub *pubsub.StructuredHub
 
@@ -169,6 +174,10 @@ type ManifoldsConfig struct {
 	// config value as the logging config in the agent.conf file.
 	UpdateLoggerConfig func(string) error
 
+	// UpdateLoggerFormat is a function that will save the specified
+	// log output format in the agent.conf file.
+	UpdateLoggerFormat func(string) error
+
 	// NewAgentStatusSetter provides upgradesteps.StatusSetter.
 	NewAgentStatusSetter func(apiConn api.Connection) (upgradesteps.StatusSetter, error)
 
@@ -183,6 +192,10 @@ type ManifoldsConfig struct {
 	// TransactionPruneInterval defines how frequently mgo/txn transactions
 	// are pruned from the database.
 	TransactionPruneInterval time.Duration
+
+	// HealthCheckInterval defines how frequently the health checks
+	// declared by the charms of the machine's units are run.
+	HealthCheckInterval time.Duration
 }
 
 // Manifolds returns a set of co-configured manifolds covering the
@@ -339,8 +352,9 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:            agentName,
 			APIConfigWatcherName: apiConfigWatcherName,
 			APIOpen:              api.Open,
-			NewConnection:        apicaller.ScaryConnect,
+			NewConnection:        apicaller.RetryingConnect(apicaller.ScaryConnect),
 			Filter:               connectFilter,
+			APICallInterceptors:  config.APICallInterceptors,
 		}),
 
 		// The upgrade steps gate is used to coordinate workers which
@@ -485,6 +499,8 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:       agentName,
 			APICallerName:   apiCallerName,
 			UpdateAgentFunc: config.UpdateLoggerConfig,
+
+			UpdateAgentFormatFunc: config.UpdateLoggerFormat,
 		})),
 
 		// The diskmanager worker periodically lists block devices on the
@@ -599,6 +615,15 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
 
+		healthCheckerName: ifNotMigrating(healthchecker.Manifold(healthchecker.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Interval:      config.HealthCheckInterval,
+			NewFacade:     healthchecker.NewFacade,
+			NewWorker:     healthchecker.NewWorker,
+		})),
+
 		externalControllerUpdaterName: ifNotMigrating(ifPrimaryController(externalcontrollerupdater.Manifold(
 			externalcontrollerupdater.ManifoldConfig{
 				APICallerName:                      apiCallerName,
@@ -700,6 +725,7 @@ const (
 	toolsVersionCheckerName       = "tools-version-checker"
 	machineActionName             = "machine-action-runner"
 	hostKeyReporterName           = "host-key-reporter"
+	healthCheckerName             = "health-checker"
 	fanConfigurerName             = "fan-configurer"
 	externalControllerUpdaterName = "external-controller-updater"
 	globalClockUpdaterName        = "global-clock-updater"
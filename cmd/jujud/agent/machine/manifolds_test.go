@@ -53,6 +53,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"external-controller-updater",
 		"fan-configurer",
 		"global-clock-updater",
+		"health-checker",
 		"host-key-reporter",
 		"is-controller-flag",
 		"is-primary-controller-flag",
@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"crypto/tls"
+	"net"
+	"strconv"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	names "gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/metricsendpoint"
+)
+
+// metricsEndpointConfig defines what the metrics endpoint worker
+// serves, and where the unit agents on the machine are found.
+type metricsEndpointConfig struct {
+	AgentConfig   agent.Config
+	Engine        *dependency.Engine
+	Gatherer      prometheus.Gatherer
+	NewSocketName func(names.Tag) string
+}
+
+// startMetricsEndpoint starts the metrics endpoint worker, if a
+// metrics port is configured. Like the introspection worker, it is
+// not run in the engine, so that metrics remain available while the
+// engine's workers are failing; its life is tied to that of the
+// engine.
+func startMetricsEndpoint(cfg metricsEndpointConfig) error {
+	config, err := getMetricsEndpointConfig(cfg.AgentConfig)
+	if err != nil || config == nil {
+		return errors.Trace(err)
+	}
+	config.Gatherer = prometheus.Gatherers{
+		cfg.Gatherer,
+		metricsendpoint.UnitAgentsGatherer{
+			AgentsDir:  agent.BaseDir(cfg.AgentConfig.DataDir()),
+			SocketName: cfg.NewSocketName,
+		},
+	}
+	w, err := metricsendpoint.NewWorker(*config)
+	if err != nil {
+		config.Listener.Close()
+		return errors.Trace(err)
+	}
+	go func() {
+		cfg.Engine.Wait()
+		logger.Debugf("engine stopped, stopping metrics endpoint")
+		w.Kill()
+		w.Wait()
+		logger.Debugf("metrics endpoint stopped")
+	}()
+	return nil
+}
+
+// getMetricsEndpointConfig returns the configuration for the metrics
+// endpoint worker, without a Gatherer, or nil if no metrics port is
+// set. The returned config holds a newly opened listener.
+func getMetricsEndpointConfig(cfg agent.Config) (*metricsendpoint.Config, error) {
+	v := cfg.Value(agent.MetricsPort)
+	if v == "" {
+		return nil, nil
+	}
+	port, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing %s", agent.MetricsPort)
+	}
+	result := &metricsendpoint.Config{
+		Username: cfg.Value(agent.MetricsUsername),
+		Password: cfg.Value(agent.MetricsPassword),
+	}
+	if result.Username == "" || result.Password == "" {
+		return nil, errors.Errorf(
+			"%s and %s must be set to serve metrics",
+			agent.MetricsUsername, agent.MetricsPassword,
+		)
+	}
+	certFile := cfg.Value(agent.MetricsCertFile)
+	keyFile := cfg.Value(agent.MetricsKeyFile)
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.Errorf(
+			"%s and %s must be set together",
+			agent.MetricsCertFile, agent.MetricsKeyFile,
+		)
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Annotate(err, "loading metrics certificate")
+		}
+		result.TLSConfig = utils.SecureTLSConfig()
+		result.TLSConfig.Certificates = []tls.Certificate{cert}
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(port)))
+	if err != nil {
+		return nil, errors.Annotate(err, "listening on metrics port")
+	}
+	result.Listener = listener
+	return result, nil
+}
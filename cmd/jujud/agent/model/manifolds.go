@@ -15,15 +15,18 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/applicationscaler"
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
+	"github.com/juju/juju/worker/charmupgrader"
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/environ"
@@ -39,10 +42,13 @@ import (
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/modelupgrader"
+	"github.com/juju/juju/worker/offerconnectionpruner"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/retrystrategy"
 	"github.com/juju/juju/worker/singular"
+	"github.com/juju/juju/worker/spacediscovery"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
 	"github.com/juju/juju/worker/undertaker"
@@ -84,6 +90,10 @@ type ManifoldsConfig struct {
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
 
+	// SpaceDiscoveryInterval determines how often the space discovery
+	// worker will reload spaces and subnets from the provider.
+	SpaceDiscoveryInterval time.Duration
+
 	// StatusHistoryPruner* values control status-history pruning
 	// behaviour.
 	StatusHistoryPrunerInterval time.Duration
@@ -92,6 +102,24 @@ type ManifoldsConfig struct {
 	// worker is run.
 	ActionPrunerInterval time.Duration
 
+	// ActionSchedulerInterval controls how often the action scheduler
+	// worker enqueues the actions of due action schedules.
+	ActionSchedulerInterval time.Duration
+
+	// ActionRolloutInterval controls how often the action scheduler
+	// worker checks whether action rollouts can start their next
+	// batch.
+	ActionRolloutInterval time.Duration
+
+	// CharmUpgraderInterval controls how often the charm upgrader
+	// worker checks whether rolling charm upgrades can start their
+	// next batch.
+	CharmUpgraderInterval time.Duration
+
+	// OfferConnectionPrunerInterval controls the rate at which the
+	// offer connection pruner worker is run.
+	OfferConnectionPrunerInterval time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -123,7 +151,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		apiCallerName: apicaller.Manifold(apicaller.ManifoldConfig{
 			AgentName:     agentName,
 			APIOpen:       api.Open,
-			NewConnection: apicaller.OnlyConnect,
+			NewConnection: apicaller.RetryingConnect(apicaller.OnlyConnect),
 			Filter:        apiConnectFilter,
 		}),
 
@@ -166,6 +194,14 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewEnvironFunc: config.NewEnvironFunc,
 		})),
 
+		// The retry budget is shared by the workers that make
+		// calls to the cloud, so that they back off together
+		// when it is failing.
+		retryBudgetName: ifResponsible(retrystrategy.BudgetManifold(retrystrategy.BudgetManifoldConfig{
+			ClockName:  clockName,
+			NewBudgets: retrystrategy.NewDefaultBudgets,
+		})),
+
 		// The model upgrader runs on all controller agents, and
 		// unlocks the gate when the model is up-to-date. The
 		// environ tracker will be supplied only to the leader,
@@ -253,18 +289,21 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:          agentName,
 			APICallerName:      apiCallerName,
 			EnvironName:        environTrackerName,
+			RetryBudgetName:    retryBudgetName,
 			NewProvisionerFunc: provisioner.NewEnvironProvisioner,
 		})),
 		storageProvisionerName: ifNotMigrating(storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
-			APICallerName: apiCallerName,
-			ClockName:     clockName,
-			EnvironName:   environTrackerName,
-			Scope:         modelTag,
+			APICallerName:   apiCallerName,
+			ClockName:       clockName,
+			EnvironName:     environTrackerName,
+			RetryBudgetName: retryBudgetName,
+			Scope:           modelTag,
 		})),
 		firewallerName: ifNotMigrating(firewaller.Manifold(firewaller.ManifoldConfig{
 			AgentName:               agentName,
 			APICallerName:           apiCallerName,
 			EnvironName:             environTrackerName,
+			RetryBudgetName:         retryBudgetName,
 			NewControllerConnection: apicaller.NewExternalControllerConnection,
 
 			NewFirewallerWorker:      firewaller.NewWorker,
@@ -293,6 +332,13 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade: charmrevisionmanifold.NewAPIFacade,
 			NewWorker: charmrevision.NewWorker,
 		})),
+		spaceDiscoveryName: ifNotMigrating(spacediscovery.Manifold(spacediscovery.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Period:        config.SpaceDiscoveryInterval,
+			NewFacade:     spacediscovery.NewFacade,
+			NewWorker:     spacediscovery.NewWorker,
+		})),
 		metricWorkerName: ifNotMigrating(metricworker.Manifold(metricworker.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
@@ -316,6 +362,21 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     actionpruner.NewFacade,
 			PruneInterval: config.ActionPrunerInterval,
 		})),
+		actionSchedulerName: ifNotMigrating(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Period:        config.ActionSchedulerInterval,
+			RolloutPeriod: config.ActionRolloutInterval,
+			NewFacade:     actionscheduler.NewFacade,
+			NewWorker:     actionscheduler.NewWorker,
+		})),
+		charmUpgraderName: ifNotMigrating(charmupgrader.Manifold(charmupgrader.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Period:        config.CharmUpgraderInterval,
+			NewFacade:     charmupgrader.NewFacade,
+			NewWorker:     charmupgrader.NewWorker,
+		})),
 		machineUndertakerName: ifNotMigrating(machineundertaker.Manifold(machineundertaker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -323,10 +384,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
-			Sinks: []logforwarder.LogSinkSpec{{
-				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
-			}},
+			OpenSink:      sinks.Open,
 		})),
 	}
 	result[remoteRelationsName] = ifNotMigrating(remoterelations.Manifold(remoterelations.ManifoldConfig{
@@ -336,6 +394,14 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		NewRemoteRelationsFacade: remoterelations.NewRemoteRelationsFacade,
 		NewWorker:                remoterelations.NewWorker,
 	}))
+	result[offerConnectionPrunerName] = ifNotMigrating(offerconnectionpruner.Manifold(offerconnectionpruner.ManifoldConfig{
+		APICallerName: apiCallerName,
+		ClockName:     clockName,
+		Period:        config.OfferConnectionPrunerInterval,
+		MaxStaleness:  crossmodel.OfferConnectionPruneAfter,
+		NewFacade:     offerconnectionpruner.NewFacade,
+		NewWorker:     offerconnectionpruner.NewWorker,
+	}))
 	return result
 }
 
@@ -426,20 +492,25 @@ const (
 	modelUpgradedFlagName = "model-upgraded-flag"
 	modelUpgraderName     = "model-upgrader"
 
-	environTrackerName       = "environ-tracker"
-	undertakerName           = "undertaker"
-	computeProvisionerName   = "compute-provisioner"
-	storageProvisionerName   = "storage-provisioner"
-	firewallerName           = "firewaller"
-	unitAssignerName         = "unit-assigner"
-	applicationScalerName    = "application-scaler"
-	instancePollerName       = "instance-poller"
-	charmRevisionUpdaterName = "charm-revision-updater"
-	metricWorkerName         = "metric-worker"
-	stateCleanerName         = "state-cleaner"
-	statusHistoryPrunerName  = "status-history-pruner"
-	actionPrunerName         = "action-pruner"
-	machineUndertakerName    = "machine-undertaker"
-	remoteRelationsName      = "remote-relations"
-	logForwarderName         = "log-forwarder"
+	environTrackerName        = "environ-tracker"
+	retryBudgetName           = "retry-budget"
+	undertakerName            = "undertaker"
+	computeProvisionerName    = "compute-provisioner"
+	storageProvisionerName    = "storage-provisioner"
+	firewallerName            = "firewaller"
+	unitAssignerName          = "unit-assigner"
+	applicationScalerName     = "application-scaler"
+	instancePollerName        = "instance-poller"
+	charmRevisionUpdaterName  = "charm-revision-updater"
+	spaceDiscoveryName        = "space-discovery"
+	metricWorkerName          = "metric-worker"
+	stateCleanerName          = "state-cleaner"
+	statusHistoryPrunerName   = "status-history-pruner"
+	actionPrunerName          = "action-pruner"
+	actionSchedulerName       = "action-scheduler"
+	charmUpgraderName         = "charm-upgrader"
+	machineUndertakerName     = "machine-undertaker"
+	remoteRelationsName       = "remote-relations"
+	offerConnectionPrunerName = "offer-connection-pruner"
+	logForwarderName          = "log-forwarder"
 )
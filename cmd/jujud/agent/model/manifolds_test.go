@@ -32,11 +32,13 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
 		"action-pruner",
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
 		"application-scaler",
 		"charm-revision-updater",
+		"charm-upgrader",
 		"clock",
 		"compute-provisioner",
 		"environ-tracker",
@@ -54,7 +56,10 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"model-upgrader",
 		"not-alive-flag",
 		"not-dead-flag",
+		"offer-connection-pruner",
 		"remote-relations",
+		"retry-budget",
+		"space-discovery",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
@@ -148,11 +153,13 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
 		"action-pruner",
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
 		"application-scaler",
 		"charm-revision-updater",
+		"charm-upgrader",
 		"clock",
 		"compute-provisioner",
 		"environ-tracker",
@@ -170,7 +177,9 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 		"model-upgrader",
 		"not-alive-flag",
 		"not-dead-flag",
+		"offer-connection-pruner",
 		"remote-relations",
+		"space-discovery",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/tomb.v1"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/apimetrics"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/cmd/jujud/agent/unit"
@@ -24,9 +25,11 @@ import (
 	"github.com/juju/juju/upgrades"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/dependency/dependencymetrics"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/introspection"
 	"github.com/juju/juju/worker/logsender"
+	uniterrunner "github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/upgradesteps"
 )
 
@@ -56,6 +59,9 @@ type UnitAgent struct {
 	upgradeComplete             gate.Lock
 
 	prometheusRegistry *prometheus.Registry
+	engineMetrics      *dependencymetrics.EngineMetrics
+	apiCallMetrics     *apimetrics.CallMetrics
+	hookMetrics        *uniterrunner.HookMetrics
 }
 
 // NewUnitAgent creates a new UnitAgent value properly initialized.
@@ -64,15 +70,35 @@ func NewUnitAgent(ctx *cmd.Context, bufferedLogger *logsender.BufferedLogWriter)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &UnitAgent{
+	a := &UnitAgent{
 		AgentConf:        NewAgentConf(""),
 		configChangedVal: voyeur.NewValue(true),
 		ctx:              ctx,
 		initialUpgradeCheckComplete: gate.NewLock(),
 		bufferedLogger:              bufferedLogger,
 		prometheusRegistry:          prometheusRegistry,
+		engineMetrics:               &dependencymetrics.EngineMetrics{},
+		apiCallMetrics:              apimetrics.NewCallMetrics(),
+		hookMetrics:                 uniterrunner.NewHookMetrics(),
 		preUpgradeSteps:             upgrades.PreUpgradeSteps,
-	}, nil
+	}
+	if err := a.registerPrometheusCollectors(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a, nil
+}
+
+func (a *UnitAgent) registerPrometheusCollectors() error {
+	if err := a.prometheusRegistry.Register(a.engineMetrics); err != nil {
+		return errors.Annotate(err, "registering dependency engine collector")
+	}
+	if err := a.prometheusRegistry.Register(a.apiCallMetrics); err != nil {
+		return errors.Annotate(err, "registering API call collector")
+	}
+	if err := a.prometheusRegistry.Register(a.hookMetrics); err != nil {
+		return errors.Annotate(err, "registering hook collector")
+	}
+	return nil
 }
 
 // Info returns usage information for the command.
@@ -162,6 +188,12 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 			return nil
 		})
 	}
+	updateAgentConfLoggingFormat := func(format string) error {
+		return a.AgentConf.ChangeConfig(func(setter agent.ConfigSetter) error {
+			setter.SetValue(agent.LoggingFormat, format)
+			return nil
+		})
+	}
 
 	agentConfig := a.AgentConf.CurrentConfig()
 	a.upgradeComplete = upgradesteps.NewLock(agentConfig)
@@ -173,7 +205,10 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		AgentConfigChanged:   a.configChangedVal,
 		ValidateMigration:    a.validateMigration,
 		PrometheusRegisterer: a.prometheusRegistry,
+		APICallInterceptors:  []base.APICallInterceptor{a.apiCallMetrics},
+		HookMetrics:          a.hookMetrics,
 		UpdateLoggerConfig:   updateAgentConfLogging,
+		UpdateLoggerFormat:   updateAgentConfLoggingFormat,
 		PreviousAgentVersion: agentConfig.UpgradedToVersion(),
 		PreUpgradeSteps:      a.preUpgradeSteps,
 		UpgradeStepsLock:     a.upgradeComplete,
@@ -209,6 +244,7 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		// and the agent is controlled by by the OS to only have one.
 		logger.Errorf("failed to start introspection worker: %v", err)
 	}
+	a.engineMetrics.SetEngine(engine)
 	return engine, nil
 }
 
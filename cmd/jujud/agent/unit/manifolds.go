@@ -40,6 +40,7 @@ import (
 	"github.com/juju/juju/worker/proxyupdater"
 	"github.com/juju/juju/worker/retrystrategy"
 	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/upgrader"
 	"github.com/juju/juju/worker/upgradesteps"
 )
@@ -70,10 +71,22 @@ type ManifoldsConfig struct {
 	// by workers to register Prometheus metric collectors.
 	PrometheusRegisterer prometheus.Registerer
 
+	// APICallInterceptors are added to the agent's API connections,
+	// and are called for every API call made over them.
+	APICallInterceptors []base.APICallInterceptor
+
+	// HookMetrics, if not nil, records the durations of the hooks
+	// run by the uniter.
+	HookMetrics *runner.HookMetrics
+
 	// UpdateLoggerConfig is a function that will save the specified
 	// config value as the logging config in the agent.conf file.
 	UpdateLoggerConfig func(string) error
 
+	// UpdateLoggerFormat is a function that will save the specified
+	// log output format in the agent.conf file.
+	UpdateLoggerFormat func(string) error
+
 	// PreviousAgentVersion passes through the version the unit
 	// agent was running before the current restart.
 	PreviousAgentVersion version.Number
@@ -139,8 +152,9 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:            agentName,
 			APIConfigWatcherName: apiConfigWatcherName,
 			APIOpen:              api.Open,
-			NewConnection:        apicaller.ScaryConnect,
+			NewConnection:        apicaller.RetryingConnect(apicaller.ScaryConnect),
 			Filter:               connectFilter,
+			APICallInterceptors:  config.APICallInterceptors,
 		}),
 
 		// The log sender is a leaf worker that sends log messages to some
@@ -240,6 +254,8 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:       agentName,
 			APICallerName:   apiCallerName,
 			UpdateAgentFunc: config.UpdateLoggerConfig,
+
+			UpdateAgentFormatFunc: config.UpdateLoggerFormat,
 		})),
 
 		// The api address updater is a leaf worker that rewrites agent config
@@ -302,6 +318,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			CharmDirName:          charmDirName,
 			HookRetryStrategyName: hookRetryStrategyName,
 			TranslateResolverErr:  uniter.TranslateFortressErrors,
+			HookMetrics:           config.HookMetrics,
 		})),
 
 		// TODO (mattyw) should be added to machine agent.
@@ -20,6 +20,7 @@ import (
 	proxyutils "github.com/juju/utils/proxy"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/logformat"
 	jujucmd "github.com/juju/juju/cmd"
 	agentcmd "github.com/juju/juju/cmd/jujud/agent"
 	"github.com/juju/juju/cmd/jujud/dumplogs"
@@ -244,11 +245,14 @@ type jujudWriter struct {
 }
 
 func (w *jujudWriter) Write(entry loggo.Entry) {
+	fmt.Fprintln(w.target, logformat.Default().Format(entry, w.textFormat))
+}
+
+func (w *jujudWriter) textFormat(entry loggo.Entry) string {
 	if strings.HasPrefix(entry.Module, "unit.") {
-		fmt.Fprintln(w.target, w.unitFormat(entry))
-	} else {
-		fmt.Fprintln(w.target, loggo.DefaultFormatter(entry))
+		return w.unitFormat(entry)
 	}
+	return loggo.DefaultFormatter(entry)
 }
 
 func (w *jujudWriter) unitFormat(entry loggo.Entry) string {
@@ -359,6 +359,11 @@ func (f *fakeService) Installed() (bool, error) {
 	return true, nil
 }
 
+func (f *fakeService) Logs(n int) ([]string, error) {
+	f.ranCommands = append(f.ranCommands, "Logs")
+	return nil, nil
+}
+
 func (f *fakeService) InstallCommands() ([]string, error) {
 	f.ranCommands = append(f.ranCommands, "InstalledCommands")
 	return []string{"echo", "install"}, nil
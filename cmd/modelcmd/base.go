@@ -9,10 +9,13 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"golang.org/x/crypto/ssh/terminal"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
@@ -65,6 +68,7 @@ type CommandBase struct {
 	authOpts      AuthOpts
 	runStarted    bool
 	refreshModels func(jujuclient.ClientStore, string) error
+	correlationId string
 }
 
 func (c *CommandBase) assertRunStarted() {
@@ -149,13 +153,26 @@ func (c *CommandBase) NewAPIRoot(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	conn, err := juju.NewAPIConnection(param)
+	// Commands may take a while to run, and so are likely to see a
+	// controller restart; redial, and resend interrupted calls where
+	// that's safe, rather than fail the command.
+	conn, err := api.NewRetryingConnection(func() (api.Connection, error) {
+		return juju.NewAPIConnection(param)
+	}, apiRetryPolicy)
 	if modelName != "" && params.ErrCode(err) == params.CodeModelNotFound {
 		return nil, c.missingModelError(store, controllerName, modelName)
 	}
 	return conn, err
 }
 
+// apiRetryPolicy controls how API calls made by commands are retried
+// when the connection to the controller is lost.
+var apiRetryPolicy = base.RetryPolicy{
+	Attempts: 3,
+	Delay:    time.Second,
+	Clock:    clock.WallClock,
+}
+
 func (c *CommandBase) missingModelError(store jujuclient.ClientStore, controllerName, modelName string) error {
 	// First, we'll try and clean up the missing model from the local cache.
 	err := store.RemoveModel(controllerName, modelName)
@@ -200,13 +217,29 @@ func (c *CommandBase) NewAPIConnectionParams(
 		}
 	}
 
-	return newAPIConnectionParams(
+	connParams, err := newAPIConnectionParams(
 		store, controllerName, modelName,
 		accountDetails,
 		bakeryClient,
 		c.apiOpen,
 		getPassword,
 	)
+	if err != nil {
+		return juju.NewAPIConnectionParams{}, errors.Trace(err)
+	}
+	connParams.DialOpts.CorrelationId = c.CorrelationId()
+	return connParams, nil
+}
+
+// CorrelationId returns the ID sent with every API call made by the
+// command. The controller records it in its audit log, so that all
+// the calls made by a single command can be found.
+func (c *CommandBase) CorrelationId() string {
+	if c.correlationId == "" {
+		c.correlationId = utils.MustNewUUID().String()
+		logger.Debugf("API correlation ID %s", c.correlationId)
+	}
+	return c.correlationId
 }
 
 // HTTPClient returns an http.Client that contains the loaded
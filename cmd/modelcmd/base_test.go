@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api"
@@ -78,6 +79,29 @@ func (s *BaseCommandSuite) TestUnknownModelNotCurrent(c *gc.C) {
 	s.assertUnknownModel(c, "admin/goodmodel", "admin/goodmodel")
 }
 
+func (s *BaseCommandSuite) TestCorrelationId(c *gc.C) {
+	var correlationIds []string
+	apiOpen := func(_ *api.Info, opts api.DialOpts) (api.Connection, error) {
+		correlationIds = append(correlationIds, opts.CorrelationId)
+		return nil, errors.New("no API")
+	}
+	baseCmd := new(modelcmd.ModelCommandBase)
+	baseCmd.SetClientStore(s.store)
+	baseCmd.SetAPIOpen(apiOpen)
+	modelcmd.InitContexts(&cmd.Context{Stderr: ioutil.Discard}, baseCmd)
+	modelcmd.SetRunStarted(baseCmd)
+	baseCmd.SetModelName("foo:admin/goodmodel", false)
+	for i := 0; i < 2; i++ {
+		_, err := baseCmd.NewAPIRoot()
+		c.Assert(err, gc.ErrorMatches, ".*no API")
+	}
+
+	c.Assert(correlationIds, gc.HasLen, 2)
+	c.Check(correlationIds[0], gc.Equals, baseCmd.CorrelationId())
+	c.Check(correlationIds[1], gc.Equals, baseCmd.CorrelationId())
+	c.Check(utils.IsValidUUIDString(correlationIds[0]), jc.IsTrue)
+}
+
 type NewGetBootstrapConfigParamsFuncSuite struct {
 	testing.IsolationSuite
 }
@@ -131,6 +131,24 @@ func (payloads) registerHookContextCommands() {
 		return cmd, nil
 	})
 
+	jujuc.RegisterCommand(context.ListCmdName, func(ctx jujuc.Context) (cmd.Command, error) {
+		compCtx := payloadsHookContext{ctx}
+		cmd, err := context.NewListCmd(compCtx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return cmd, nil
+	})
+
+	jujuc.RegisterCommand(context.StatusCmdName, func(ctx jujuc.Context) (cmd.Command, error) {
+		compCtx := payloadsHookContext{ctx}
+		cmd, err := context.NewStatusCmd(compCtx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return cmd, nil
+	})
+
 	jujuc.RegisterCommand(context.UnregisterCmdName, func(ctx jujuc.Context) (cmd.Command, error) {
 		compCtx := payloadsHookContext{ctx}
 		cmd, err := context.NewUnregisterCmd(compCtx)
@@ -32,6 +32,11 @@ const (
 	InstanceType = "instance-type"
 	Spaces       = "spaces"
 	VirtType     = "virt-type"
+
+	AllocatePublicIP  = "allocate-public-ip"
+	ImageID           = "image-id"
+	InstanceLifecycle = "instance-lifecycle"
+	MaxPrice          = "max-price"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -85,6 +90,25 @@ type Value struct {
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+
+	// AllocatePublicIP, if not nil, indicates whether a machine must
+	// (true) or must not (false) be allocated a public IP address.
+	AllocatePublicIP *bool `json:"allocate-public-ip,omitempty" yaml:"allocate-public-ip,omitempty"`
+
+	// ImageID, if not nil or empty, indicates that a machine must be
+	// started from the specified provider image, instead of one
+	// chosen from the image metadata.
+	ImageID *string `json:"image-id,omitempty" yaml:"image-id,omitempty"`
+
+	// InstanceLifecycle, if not nil or empty, indicates that a machine
+	// must be started with the specified lifecycle: "on-demand",
+	// "spot" or "preemptible".
+	InstanceLifecycle *string `json:"instance-lifecycle,omitempty" yaml:"instance-lifecycle,omitempty"`
+
+	// MaxPrice, if not nil or empty, indicates the highest hourly
+	// price, in the cloud's currency, that may be paid for a spot
+	// instance.
+	MaxPrice *string `json:"max-price,omitempty" yaml:"max-price,omitempty"`
 }
 
 var rawAliases = map[string]string{
@@ -180,9 +204,35 @@ func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
 }
 
+// HasAllocatePublicIP returns true if the constraints.Value specifies
+// whether or not a public IP address is to be allocated.
+func (v *Value) HasAllocatePublicIP() bool {
+	return v.AllocatePublicIP != nil
+}
+
+// HasImageID returns true if the constraints.Value specifies an image.
+func (v *Value) HasImageID() bool {
+	return v.ImageID != nil && *v.ImageID != ""
+}
+
+// HasInstanceLifecycle returns true if the constraints.Value specifies
+// an instance lifecycle.
+func (v *Value) HasInstanceLifecycle() bool {
+	return v.InstanceLifecycle != nil && *v.InstanceLifecycle != ""
+}
+
+// HasMaxPrice returns true if the constraints.Value specifies a
+// maximum price.
+func (v *Value) HasMaxPrice() bool {
+	return v.MaxPrice != nil && *v.MaxPrice != ""
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
+	if v.AllocatePublicIP != nil {
+		strs = append(strs, "allocate-public-ip="+strconv.FormatBool(*v.AllocatePublicIP))
+	}
 	if v.Arch != nil {
 		strs = append(strs, "arch="+*v.Arch)
 	}
@@ -195,9 +245,18 @@ func (v Value) String() string {
 	if v.CpuPower != nil {
 		strs = append(strs, "cpu-power="+uintStr(*v.CpuPower))
 	}
+	if v.ImageID != nil {
+		strs = append(strs, "image-id="+*v.ImageID)
+	}
+	if v.InstanceLifecycle != nil {
+		strs = append(strs, "instance-lifecycle="+*v.InstanceLifecycle)
+	}
 	if v.InstanceType != nil {
 		strs = append(strs, "instance-type="+string(*v.InstanceType))
 	}
+	if v.MaxPrice != nil {
+		strs = append(strs, "max-price="+*v.MaxPrice)
+	}
 	if v.Mem != nil {
 		s := uintStr(*v.Mem)
 		if s != "" {
@@ -264,6 +323,18 @@ func (v Value) GoString() string {
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
+	if v.AllocatePublicIP != nil {
+		values = append(values, fmt.Sprintf("AllocatePublicIP: %v", *v.AllocatePublicIP))
+	}
+	if v.ImageID != nil {
+		values = append(values, fmt.Sprintf("ImageID: %q", *v.ImageID))
+	}
+	if v.InstanceLifecycle != nil {
+		values = append(values, fmt.Sprintf("InstanceLifecycle: %q", *v.InstanceLifecycle))
+	}
+	if v.MaxPrice != nil {
+		values = append(values, fmt.Sprintf("MaxPrice: %q", *v.MaxPrice))
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -420,6 +491,14 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setSpaces(str)
 	case VirtType:
 		err = v.setVirtType(str)
+	case AllocatePublicIP:
+		err = v.setAllocatePublicIP(str)
+	case ImageID:
+		err = v.setImageID(str)
+	case InstanceLifecycle:
+		err = v.setInstanceLifecycle(str)
+	case MaxPrice:
+		err = v.setMaxPrice(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -483,6 +562,14 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		case VirtType:
 			v.VirtType = &vstr
+		case AllocatePublicIP:
+			v.AllocatePublicIP, err = parseBool(vstr)
+		case ImageID:
+			v.ImageID = &vstr
+		case InstanceLifecycle:
+			v.InstanceLifecycle, err = parseLifecycle(vstr)
+		case MaxPrice:
+			v.MaxPrice, err = parsePrice(vstr)
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -607,6 +694,64 @@ func (v *Value) setVirtType(str string) error {
 	return nil
 }
 
+func (v *Value) setAllocatePublicIP(str string) (err error) {
+	if v.AllocatePublicIP != nil {
+		return errors.Errorf("already set")
+	}
+	v.AllocatePublicIP, err = parseBool(str)
+	return
+}
+
+func (v *Value) setImageID(str string) error {
+	if v.ImageID != nil {
+		return errors.Errorf("already set")
+	}
+	v.ImageID = &str
+	return nil
+}
+
+func (v *Value) setInstanceLifecycle(str string) (err error) {
+	if v.InstanceLifecycle != nil {
+		return errors.Errorf("already set")
+	}
+	v.InstanceLifecycle, err = parseLifecycle(str)
+	return
+}
+
+func (v *Value) setMaxPrice(str string) (err error) {
+	if v.MaxPrice != nil {
+		return errors.Errorf("already set")
+	}
+	v.MaxPrice, err = parsePrice(str)
+	return
+}
+
+func parseLifecycle(str string) (*string, error) {
+	if str != "" {
+		if _, err := instance.ParseLifecycle(str); err != nil {
+			return nil, errors.Errorf("must be one of %q", instance.Lifecycles)
+		}
+	}
+	return &str, nil
+}
+
+func parsePrice(str string) (*string, error) {
+	if str != "" {
+		if val, err := strconv.ParseFloat(str, 64); err != nil || val < 0 {
+			return nil, errors.Errorf("must be a non-negative float")
+		}
+	}
+	return &str, nil
+}
+
+func parseBool(str string) (*bool, error) {
+	value, err := strconv.ParseBool(str)
+	if err != nil {
+		return nil, errors.Errorf("must be true or false")
+	}
+	return &value, nil
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
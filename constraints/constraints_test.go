@@ -313,6 +313,78 @@ var parseConstraintsTests = []struct {
 		err:     `bad "virt-type" constraint: already set`,
 	},
 
+	// "allocate-public-ip" in detail.
+	{
+		summary: "set allocate-public-ip true",
+		args:    []string{"allocate-public-ip=true"},
+	}, {
+		summary: "set allocate-public-ip false",
+		args:    []string{"allocate-public-ip=false"},
+	}, {
+		summary: "set allocate-public-ip empty",
+		args:    []string{"allocate-public-ip="},
+		err:     `bad "allocate-public-ip" constraint: must be true or false`,
+	}, {
+		summary: "set allocate-public-ip invalid",
+		args:    []string{"allocate-public-ip=maybe"},
+		err:     `bad "allocate-public-ip" constraint: must be true or false`,
+	}, {
+		summary: "double set allocate-public-ip",
+		args:    []string{"allocate-public-ip=true", "allocate-public-ip=false"},
+		err:     `bad "allocate-public-ip" constraint: already set`,
+	},
+
+	// "image-id" in detail.
+	{
+		summary: "set image-id",
+		args:    []string{"image-id=ami-12345678"},
+	}, {
+		summary: "set image-id empty",
+		args:    []string{"image-id="},
+	}, {
+		summary: "double set image-id",
+		args:    []string{"image-id=ami-12345678 image-id=ami-87654321"},
+		err:     `bad "image-id" constraint: already set`,
+	},
+
+	// "instance-lifecycle" in detail.
+	{
+		summary: "set instance-lifecycle spot",
+		args:    []string{"instance-lifecycle=spot"},
+	}, {
+		summary: "set instance-lifecycle empty",
+		args:    []string{"instance-lifecycle="},
+	}, {
+		summary: "set instance-lifecycle invalid",
+		args:    []string{"instance-lifecycle=reserved"},
+		err:     `bad "instance-lifecycle" constraint: must be one of \["on-demand" "spot" "preemptible"\]`,
+	}, {
+		summary: "double set instance-lifecycle",
+		args:    []string{"instance-lifecycle=spot instance-lifecycle=preemptible"},
+		err:     `bad "instance-lifecycle" constraint: already set`,
+	},
+
+	// "max-price" in detail.
+	{
+		summary: "set max-price",
+		args:    []string{"max-price=0.05"},
+	}, {
+		summary: "set max-price empty",
+		args:    []string{"max-price="},
+	}, {
+		summary: "set max-price negative",
+		args:    []string{"max-price=-1"},
+		err:     `bad "max-price" constraint: must be a non-negative float`,
+	}, {
+		summary: "set max-price invalid",
+		args:    []string{"max-price=cheap"},
+		err:     `bad "max-price" constraint: must be a non-negative float`,
+	}, {
+		summary: "double set max-price",
+		args:    []string{"max-price=0.05", "max-price=0.1"},
+		err:     `bad "max-price" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
@@ -469,6 +541,10 @@ func uint64p(i uint64) *uint64 {
 	return &i
 }
 
+func boolp(b bool) *bool {
+	return &b
+}
+
 func strp(s string) *string {
 	return &s
 }
@@ -510,16 +586,29 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"AllocatePublicIP1", constraints.Value{AllocatePublicIP: nil}},
+	{"AllocatePublicIP2", constraints.Value{AllocatePublicIP: boolp(false)}},
+	{"AllocatePublicIP3", constraints.Value{AllocatePublicIP: boolp(true)}},
+	{"ImageID1", constraints.Value{ImageID: strp("")}},
+	{"ImageID2", constraints.Value{ImageID: strp("ami-12345678")}},
+	{"InstanceLifecycle1", constraints.Value{InstanceLifecycle: strp("")}},
+	{"InstanceLifecycle2", constraints.Value{InstanceLifecycle: strp("spot")}},
+	{"MaxPrice1", constraints.Value{MaxPrice: strp("")}},
+	{"MaxPrice2", constraints.Value{MaxPrice: strp("0.05")}},
 	{"All", constraints.Value{
-		Arch:         strp("i386"),
-		Container:    ctypep("lxd"),
-		CpuCores:     uint64p(4096),
-		CpuPower:     uint64p(9001),
-		Mem:          uint64p(18000000000),
-		RootDisk:     uint64p(24000000000),
-		Tags:         &[]string{"foo", "bar"},
-		Spaces:       &[]string{"space1", "^space2"},
-		InstanceType: strp("foo"),
+		Arch:              strp("i386"),
+		Container:         ctypep("lxd"),
+		CpuCores:          uint64p(4096),
+		CpuPower:          uint64p(9001),
+		Mem:               uint64p(18000000000),
+		RootDisk:          uint64p(24000000000),
+		Tags:              &[]string{"foo", "bar"},
+		Spaces:            &[]string{"space1", "^space2"},
+		InstanceType:      strp("foo"),
+		AllocatePublicIP:  boolp(true),
+		ImageID:           strp("ami-12345678"),
+		InstanceLifecycle: strp("spot"),
+		MaxPrice:          strp("0.05"),
 	}},
 }
 
@@ -597,6 +686,35 @@ func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	c.Check(cons.HasInstanceType(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasAllocatePublicIP(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64")
+	c.Check(cons.HasAllocatePublicIP(), jc.IsFalse)
+	cons = constraints.MustParse("allocate-public-ip=false")
+	c.Check(cons.HasAllocatePublicIP(), jc.IsTrue)
+	c.Check(*cons.AllocatePublicIP, jc.IsFalse)
+}
+
+func (s *ConstraintsSuite) TestHasImageID(c *gc.C) {
+	cons := constraints.MustParse("image-id=")
+	c.Check(cons.HasImageID(), jc.IsFalse)
+	cons = constraints.MustParse("image-id=ami-12345678")
+	c.Check(cons.HasImageID(), jc.IsTrue)
+}
+
+func (s *ConstraintsSuite) TestHasInstanceLifecycle(c *gc.C) {
+	cons := constraints.MustParse("instance-lifecycle=")
+	c.Check(cons.HasInstanceLifecycle(), jc.IsFalse)
+	cons = constraints.MustParse("instance-lifecycle=preemptible")
+	c.Check(cons.HasInstanceLifecycle(), jc.IsTrue)
+}
+
+func (s *ConstraintsSuite) TestHasMaxPrice(c *gc.C) {
+	cons := constraints.MustParse("max-price=")
+	c.Check(cons.HasMaxPrice(), jc.IsFalse)
+	cons = constraints.MustParse("max-price=0.05")
+	c.Check(cons.HasMaxPrice(), jc.IsTrue)
+}
+
 const initialWithoutCons = "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cores=4 spaces=space1,^space2 tags=foo container=lxd instance-type=bar"
 
 var withoutTests = []struct {
@@ -6,6 +6,7 @@ package constraints
 import (
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/juju/utils/set"
 )
@@ -25,6 +26,9 @@ type Validator interface {
 	RegisterConflicts(reds, blues []string)
 
 	// RegisterUnsupported records attributes which are not supported by a constraints Value.
+	// Unsupported attributes are normally ignored, but the allocate-public-ip,
+	// image-id, instance-lifecycle and max-price attributes cannot be, so
+	// validating a Value which specifies any of them when unsupported is an error.
 	RegisterUnsupported(unsupported []string)
 
 	// RegisterVocabulary records allowed values for the specified constraint attribute.
@@ -46,6 +50,53 @@ type Validator interface {
 	//     and new values are {c, d},
 	//     then the merge result would be {a, b, c, d}.
 	UpdateVocabulary(attributeName string, newValues interface{})
+
+	// Capabilities describes, for each constraint attribute, whether
+	// it is supported, which attributes it conflicts with, and the
+	// values allowed for it, so that constraints can be validated
+	// without access to the validator itself.
+	Capabilities() []AttributeCapability
+}
+
+// AttributeCapability describes the support of a validator for a
+// constraint attribute.
+type AttributeCapability struct {
+	// Name is the name of the constraint attribute.
+	Name string
+
+	// Supported reports whether the attribute is supported.
+	Supported bool
+
+	// Strict reports whether specifying the attribute when it is
+	// unsupported is an error, rather than being ignored.
+	Strict bool
+
+	// Conflicts holds the names of the attributes which may not be
+	// specified together with this one.
+	Conflicts []string
+
+	// Values holds the values allowed for the attribute. If empty,
+	// any value is allowed.
+	Values []string
+}
+
+// attributeNames holds the names of all constraint attributes, in the
+// order they are described by Validator.Capabilities.
+var attributeNames = []string{
+	Arch,
+	Container,
+	Cores,
+	CpuPower,
+	Mem,
+	RootDisk,
+	Tags,
+	InstanceType,
+	Spaces,
+	VirtType,
+	AllocatePublicIP,
+	ImageID,
+	InstanceLifecycle,
+	MaxPrice,
 }
 
 // NewValidator returns a new constraints Validator instance.
@@ -135,6 +186,53 @@ func (v *validator) updateVocabularyFromMap(attributeName string, valuesMap map[
 	v.RegisterVocabulary(attributeName, merged)
 }
 
+// Capabilities is defined on Validator.
+func (v *validator) Capabilities() []AttributeCapability {
+	result := make([]AttributeCapability, len(attributeNames))
+	for i, name := range attributeNames {
+		result[i] = AttributeCapability{
+			Name:      name,
+			Supported: !v.unsupported.Contains(name),
+			Strict:    strictConstraints.Contains(name),
+			Conflicts: v.conflicts[name].SortedValues(),
+		}
+		if vocab, ok := v.vocab[name]; ok {
+			values := make([]string, len(vocab))
+			for j, value := range vocab {
+				values[j] = fmt.Sprint(value)
+			}
+			sort.Strings(values)
+			result[i].Values = values
+		}
+	}
+	return result
+}
+
+// NewValidatorFromCapabilities returns a Validator which validates
+// constraints according to the given capabilities, as returned by
+// the Capabilities method of another Validator.
+func NewValidatorFromCapabilities(capabilities []AttributeCapability) Validator {
+	v := &validator{
+		unsupported: set.NewStrings(),
+		conflicts:   make(map[string]set.Strings),
+		vocab:       make(map[string][]interface{}),
+	}
+	for _, capability := range capabilities {
+		if !capability.Supported {
+			v.unsupported.Add(capability.Name)
+		}
+		// Conflicts are recorded per attribute rather than through
+		// RegisterConflicts, which replaces the conflicts of both sides.
+		if len(capability.Conflicts) > 0 {
+			v.conflicts[capability.Name] = set.NewStrings(capability.Conflicts...)
+		}
+		if len(capability.Values) > 0 {
+			v.RegisterVocabulary(capability.Name, capability.Values)
+		}
+	}
+	return v
+}
+
 // checkConflicts returns an error if the constraints Value contains conflicting attributes.
 func (v *validator) checkConflicts(cons Value) error {
 	attrValues := cons.attributesWithValues()
@@ -161,6 +259,23 @@ func (v *validator) checkUnsupported(cons Value) []string {
 	return cons.hasAny(v.unsupported.Values()...)
 }
 
+// strictConstraints holds the attributes which must be honoured if
+// they are specified. Unlike other unsupported attributes, which are
+// reported and then ignored, it is an error to specify one of these
+// when it is unsupported.
+var strictConstraints = set.NewStrings(AllocatePublicIP, ImageID, InstanceLifecycle, MaxPrice)
+
+// checkStrict returns an error if any of the unsupported attributes
+// must be honoured.
+func checkStrict(unsupported []string) error {
+	for _, attrTag := range set.NewStrings(unsupported...).SortedValues() {
+		if strictConstraints.Contains(attrTag) {
+			return fmt.Errorf("conflicting constraints: %q is not supported by this cloud", attrTag)
+		}
+	}
+	return nil
+}
+
 // checkValidValues returns an error if the constraints value contains an
 // attribute value which is not allowed by the vocab which may have been
 // registered for it.
@@ -253,6 +368,9 @@ func withFallbacks(v Value, vFallback Value) Value {
 // Validate is defined on Validator.
 func (v *validator) Validate(cons Value) ([]string, error) {
 	unsupported := v.checkUnsupported(cons)
+	if err := checkStrict(unsupported); err != nil {
+		return unsupported, err
+	}
 	if err := v.checkConflicts(cons); err != nil {
 		return unsupported, err
 	}
@@ -39,6 +39,24 @@ var validationTests = []struct {
 		cons:        "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cores=4 instance-type=foo",
 		unsupported: []string{"cpu-power", "instance-type"},
 	},
+	{
+		desc:        "unsupported allocate-public-ip",
+		cons:        "mem=4G allocate-public-ip=false",
+		unsupported: []string{"allocate-public-ip"},
+		err:         `conflicting constraints: "allocate-public-ip" is not supported by this cloud`,
+	},
+	{
+		desc:        "unsupported image-id",
+		cons:        "mem=4G image-id=ami-12345678 tags=foo",
+		unsupported: []string{"image-id", "tags"},
+		err:         `conflicting constraints: "image-id" is not supported by this cloud`,
+	},
+	{
+		desc:        "unsupported instance-lifecycle",
+		cons:        "mem=4G instance-lifecycle=spot max-price=0.05",
+		unsupported: []string{"instance-lifecycle", "max-price"},
+		err:         `conflicting constraints: "instance-lifecycle" is not supported by this cloud`,
+	},
 	{
 		desc:        "Ambiguous constraint errors take precedence over unsupported errors.",
 		cons:        "root-disk=8G mem=4G cores=4 instance-type=foo",
@@ -116,6 +134,12 @@ var validationTests = []struct {
 		vocab: map[string][]interface{}{"instance-type": {"bar"}},
 		err:   "invalid constraint value: instance-type=foo\nvalid values are:.*",
 	},
+	{
+		desc:  "invalid instance-lifecycle vocab",
+		cons:  "mem=4G instance-lifecycle=spot",
+		vocab: map[string][]interface{}{"instance-lifecycle": {"on-demand", "preemptible"}},
+		err:   "invalid constraint value: instance-lifecycle=spot\nvalid values are:.*",
+	},
 	{
 		desc:  "invalid tags vocab",
 		cons:  "mem=4G tags=foo,other",
@@ -387,3 +411,60 @@ valid values are: [amd64]`))
 	_, err = validator.Validate(cons2)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *validationSuite) TestCapabilities(c *gc.C) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported([]string{"tags", "image-id"})
+	validator.RegisterConflicts([]string{"instance-type"}, []string{"mem", "arch"})
+	validator.RegisterVocabulary("arch", []string{"ppc64el", "amd64"})
+
+	capabilities := make(map[string]constraints.AttributeCapability)
+	for _, capability := range validator.Capabilities() {
+		capabilities[capability.Name] = capability
+	}
+	c.Assert(capabilities, gc.HasLen, 12)
+	c.Check(capabilities["tags"], jc.DeepEquals, constraints.AttributeCapability{
+		Name:      "tags",
+		Conflicts: []string{},
+	})
+	c.Check(capabilities["image-id"], jc.DeepEquals, constraints.AttributeCapability{
+		Name:      "image-id",
+		Strict:    true,
+		Conflicts: []string{},
+	})
+	c.Check(capabilities["arch"], jc.DeepEquals, constraints.AttributeCapability{
+		Name:      "arch",
+		Supported: true,
+		Conflicts: []string{"instance-type"},
+		Values:    []string{"amd64", "ppc64el"},
+	})
+	c.Check(capabilities["instance-type"], jc.DeepEquals, constraints.AttributeCapability{
+		Name:      "instance-type",
+		Supported: true,
+		Conflicts: []string{"arch", "mem"},
+	})
+}
+
+func (s *validationSuite) TestNewValidatorFromCapabilities(c *gc.C) {
+	original := constraints.NewValidator()
+	original.RegisterUnsupported([]string{"tags", "image-id"})
+	original.RegisterConflicts([]string{"instance-type"}, []string{"mem"})
+	original.RegisterVocabulary("arch", []string{"amd64"})
+
+	validator := constraints.NewValidatorFromCapabilities(original.Capabilities())
+	c.Assert(validator.Capabilities(), jc.DeepEquals, original.Capabilities())
+
+	unsupported, err := validator.Validate(constraints.MustParse("mem=4G tags=foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.DeepEquals, []string{"tags"})
+
+	_, err = validator.Validate(constraints.MustParse("image-id=ami-12345678"))
+	c.Assert(err, gc.ErrorMatches, `conflicting constraints: "image-id" is not supported by this cloud`)
+
+	_, err = validator.Validate(constraints.MustParse("instance-type=foo mem=4G"))
+	c.Assert(err, gc.ErrorMatches, `ambiguous constraints: "instance-type" overlaps with "mem"`)
+
+	_, err = validator.Validate(constraints.MustParse("arch=ppc64el"))
+	c.Assert(err, gc.ErrorMatches, regexp.QuoteMeta(`invalid constraint value: arch=ppc64el
+valid values are: [amd64]`))
+}
@@ -28,10 +28,27 @@ const (
 	// APIPort is the port used for api connections.
 	APIPort = "api-port"
 
+	// APICallRateLimitBurst is the number of calls a user may make
+	// to a single facade in a model before their calls are rate
+	// limited. If it is not set, user calls are not rate limited.
+	APICallRateLimitBurst = "api-call-rate-limit-burst"
+
+	// APICallRateLimitRefill is the interval at which a rate limited
+	// user is allowed further calls, eg "100ms".
+	APICallRateLimitRefill = "api-call-rate-limit-refill"
+
 	// AuditingEnabled determines whether the controller will record
 	// auditing information.
 	AuditingEnabled = "auditing-enabled"
 
+	// AuditLogSyslog determines whether audit records are also
+	// written to the local syslog daemon when auditing is enabled.
+	AuditLogSyslog = "audit-log-syslog"
+
+	// AuditLogWebhookURL, if set, is an http or https URL to which
+	// audit records are also posted when auditing is enabled.
+	AuditLogWebhookURL = "audit-log-webhook-url"
+
 	// StatePort is the port used for mongo connections.
 	StatePort = "state-port"
 
@@ -82,8 +99,54 @@ const (
 	// MaxTxnLogSize is the maximum size the of capped txn log collection, eg "10M"
 	MaxTxnLogSize = "max-txn-log-size"
 
+	// MaxStatusHistoryCollectionSize is the maximum size the status
+	// history collection, shared by all models, can grow to before it
+	// is pruned, eg "5G". When set, it takes precedence over the
+	// max-status-history-size of the controller model.
+	MaxStatusHistoryCollectionSize = "max-status-history-collection-size"
+
+	// MaxActionResultsCollectionSize is the maximum size the actions
+	// collection, shared by all models, can grow to before it is
+	// pruned, eg "5G". When set, it takes precedence over the
+	// max-action-results-size of the controller model.
+	MaxActionResultsCollectionSize = "max-action-results-collection-size"
+
+	// BackupTargetType is the kind of object storage to which backup
+	// archives may be streamed instead of being stored on the
+	// controller: one of "s3", "swift" or "azure". If it is not set,
+	// backups are only stored on the controller.
+	BackupTargetType = "backup-target-type"
+
+	// BackupTargetContainer is the S3 bucket, Swift container or Azure
+	// blob container holding streamed backup archives.
+	BackupTargetContainer = "backup-target-container"
+
+	// BackupTargetRegion is the region of the backup target. It is
+	// required for S3, and optional for Swift.
+	BackupTargetRegion = "backup-target-region"
+
+	// BackupTargetEndpoint is the identity URL of a Swift backup
+	// target, or a custom endpoint URL for an S3 backup target.
+	BackupTargetEndpoint = "backup-target-endpoint"
+
+	// BackupTargetTenant is the tenant name used to authenticate with
+	// a Swift backup target.
+	BackupTargetTenant = "backup-target-tenant"
+
+	// BackupTargetAccessKey is the S3 access key, Swift user name or
+	// Azure storage account name used to access the backup target.
+	BackupTargetAccessKey = "backup-target-access-key"
+
+	// BackupTargetSecretKey is the S3 secret key, Swift password or
+	// Azure storage account key used to access the backup target.
+	BackupTargetSecretKey = "backup-target-secret-key"
+
 	// Attribute Defaults
 
+	// DefaultAPICallRateLimitRefill is the default interval at which
+	// a rate limited user is allowed further calls.
+	DefaultAPICallRateLimitRefill = 100 * time.Millisecond
+
 	// DefaultAuditingEnabled contains the default value for the
 	// AuditingEnabled config value.
 	DefaultAuditingEnabled = false
@@ -112,11 +175,24 @@ const (
 	DefaultMaxTxnLogCollectionMB = 10 // 10 MB
 )
 
+const (
+	// BackupTargetS3 is the backup target type for Amazon S3.
+	BackupTargetS3 = "s3"
+	// BackupTargetSwift is the backup target type for OpenStack Swift.
+	BackupTargetSwift = "swift"
+	// BackupTargetAzure is the backup target type for Azure blob storage.
+	BackupTargetAzure = "azure"
+)
+
 // ControllerOnlyConfigAttributes are attributes which are only relevant
 // for a controller, never a model.
 var ControllerOnlyConfigAttributes = []string{
 	AllowModelAccessKey,
 	APIPort,
+	APICallRateLimitBurst,
+	APICallRateLimitRefill,
+	AuditLogSyslog,
+	AuditLogWebhookURL,
 	AutocertDNSNameKey,
 	AutocertURLKey,
 	CACertKey,
@@ -129,6 +205,15 @@ var ControllerOnlyConfigAttributes = []string{
 	MaxLogsSize,
 	MaxLogsAge,
 	MaxTxnLogSize,
+	MaxStatusHistoryCollectionSize,
+	MaxActionResultsCollectionSize,
+	BackupTargetType,
+	BackupTargetContainer,
+	BackupTargetRegion,
+	BackupTargetEndpoint,
+	BackupTargetTenant,
+	BackupTargetAccessKey,
+	BackupTargetSecretKey,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -210,6 +295,28 @@ func (c Config) APIPort() int {
 	return c.mustInt(APIPort)
 }
 
+// APICallRateLimitBurst returns the number of calls a user may make
+// to a single facade in a model before being rate limited, or 0 if
+// user calls are not rate limited.
+func (c Config) APICallRateLimitBurst() int {
+	if v, ok := c[APICallRateLimitBurst]; ok {
+		return v.(int)
+	}
+	return 0
+}
+
+// APICallRateLimitRefill returns the interval at which a rate limited
+// user is allowed further calls.
+func (c Config) APICallRateLimitRefill() time.Duration {
+	v := c.asString(APICallRateLimitRefill)
+	if v == "" {
+		return DefaultAPICallRateLimitRefill
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(v)
+	return val
+}
+
 // AuditingEnabled returns whether or not auditing has been enabled
 // for the environment. The default is false.
 func (c Config) AuditingEnabled() bool {
@@ -219,6 +326,21 @@ func (c Config) AuditingEnabled() bool {
 	return false
 }
 
+// AuditLogSyslog returns whether audit records should also be written
+// to syslog. The default is false.
+func (c Config) AuditLogSyslog() bool {
+	if v, ok := c[AuditLogSyslog]; ok {
+		return v.(bool)
+	}
+	return false
+}
+
+// AuditLogWebhookURL returns the URL to which audit records should
+// also be posted, or "" if there is none.
+func (c Config) AuditLogWebhookURL() string {
+	return c.asString(AuditLogWebhookURL)
+}
+
 // ControllerUUID returns the uuid for the model's controller.
 func (c Config) ControllerUUID() string {
 	return c.mustString(ControllerUUIDKey)
@@ -313,6 +435,51 @@ func (c Config) MaxTxnLogSizeMB() int {
 	return int(val)
 }
 
+// MaxStatusHistoryCollectionSizeMB is the maximum size in MiB which
+// the status history collection can grow to before being pruned, or
+// zero if the size is governed by the controller model's config.
+func (c Config) MaxStatusHistoryCollectionSizeMB() int {
+	// Value has already been validated.
+	val, _ := utils.ParseSize(c.asString(MaxStatusHistoryCollectionSize))
+	return int(val)
+}
+
+// MaxActionResultsCollectionSizeMB is the maximum size in MiB which
+// the actions collection can grow to before being pruned, or zero if
+// the size is governed by the controller model's config.
+func (c Config) MaxActionResultsCollectionSizeMB() int {
+	// Value has already been validated.
+	val, _ := utils.ParseSize(c.asString(MaxActionResultsCollectionSize))
+	return int(val)
+}
+
+// BackupTargetConfig holds the configuration of the object storage to
+// which backup archives may be streamed.
+type BackupTargetConfig struct {
+	Type      string
+	Container string
+	Region    string
+	Endpoint  string
+	Tenant    string
+	AccessKey string
+	SecretKey string
+}
+
+// BackupTarget returns the configuration of the controller's backup
+// target, and whether one is configured.
+func (c Config) BackupTarget() (BackupTargetConfig, bool) {
+	target := BackupTargetConfig{
+		Type:      c.asString(BackupTargetType),
+		Container: c.asString(BackupTargetContainer),
+		Region:    c.asString(BackupTargetRegion),
+		Endpoint:  c.asString(BackupTargetEndpoint),
+		Tenant:    c.asString(BackupTargetTenant),
+		AccessKey: c.asString(BackupTargetAccessKey),
+		SecretKey: c.asString(BackupTargetSecretKey),
+	}
+	return target, target.Type != ""
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -336,6 +503,30 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[APICallRateLimitBurst].(int); ok && v < 0 {
+		return errors.Errorf("%s: expected non-negative number, got %d", APICallRateLimitBurst, v)
+	}
+
+	if v, ok := c[APICallRateLimitRefill].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid API call rate limit refill in configuration")
+		}
+		if d <= 0 {
+			return errors.Errorf("%s: expected positive duration, got %q", APICallRateLimitRefill, v)
+		}
+	}
+
+	if v, ok := c[AuditLogWebhookURL].(string); ok {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid audit log webhook URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("%s: expected http or https URL, got %q", AuditLogWebhookURL, v)
+		}
+	}
+
 	caCert, caCertOK := c.CACert()
 	if !caCertOK {
 		return errors.Errorf("missing CA certificate")
@@ -372,6 +563,71 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[MaxStatusHistoryCollectionSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid max status history collection size in configuration")
+		}
+	}
+
+	if v, ok := c[MaxActionResultsCollectionSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid max action results collection size in configuration")
+		}
+	}
+
+	if err := validateBackupTarget(c); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+// validateBackupTarget checks that the backup target attributes, if
+// any, describe a usable backup target.
+func validateBackupTarget(c Config) error {
+	target, ok := c.BackupTarget()
+	if !ok {
+		for _, key := range []string{
+			BackupTargetContainer,
+			BackupTargetRegion,
+			BackupTargetEndpoint,
+			BackupTargetTenant,
+			BackupTargetAccessKey,
+			BackupTargetSecretKey,
+		} {
+			if _, ok := c[key]; ok {
+				return errors.Errorf("%s: not valid without %s", key, BackupTargetType)
+			}
+		}
+		return nil
+	}
+	switch target.Type {
+	case BackupTargetS3, BackupTargetSwift, BackupTargetAzure:
+	default:
+		return errors.Errorf("%s: expected one of %s, %s or %s, got %q",
+			BackupTargetType, BackupTargetS3, BackupTargetSwift, BackupTargetAzure, target.Type)
+	}
+	required := []string{BackupTargetContainer, BackupTargetAccessKey, BackupTargetSecretKey}
+	switch target.Type {
+	case BackupTargetS3:
+		required = append(required, BackupTargetRegion)
+	case BackupTargetSwift:
+		required = append(required, BackupTargetEndpoint)
+	}
+	for _, key := range required {
+		if c.asString(key) == "" {
+			return errors.Errorf("%s: required for %s backup target", key, target.Type)
+		}
+	}
+	if target.Endpoint != "" {
+		u, err := url.Parse(target.Endpoint)
+		if err != nil {
+			return errors.Annotate(err, "invalid backup target endpoint")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("%s: expected http or https URL, got %q", BackupTargetEndpoint, target.Endpoint)
+		}
+	}
 	return nil
 }
 
@@ -383,7 +639,11 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 
 var configChecker = schema.FieldMap(schema.Fields{
 	AuditingEnabled:         schema.Bool(),
+	AuditLogSyslog:          schema.Bool(),
+	AuditLogWebhookURL:      schema.String(),
 	APIPort:                 schema.ForceInt(),
+	APICallRateLimitBurst:   schema.ForceInt(),
+	APICallRateLimitRefill:  schema.String(),
 	StatePort:               schema.ForceInt(),
 	IdentityURL:             schema.String(),
 	IdentityPublicKey:       schema.String(),
@@ -395,9 +655,24 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxLogsAge:              schema.String(),
 	MaxLogsSize:             schema.String(),
 	MaxTxnLogSize:           schema.String(),
+
+	MaxStatusHistoryCollectionSize: schema.String(),
+	MaxActionResultsCollectionSize: schema.String(),
+
+	BackupTargetType:      schema.String(),
+	BackupTargetContainer: schema.String(),
+	BackupTargetRegion:    schema.String(),
+	BackupTargetEndpoint:  schema.String(),
+	BackupTargetTenant:    schema.String(),
+	BackupTargetAccessKey: schema.String(),
+	BackupTargetSecretKey: schema.String(),
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
+	APICallRateLimitBurst:   schema.Omit,
+	APICallRateLimitRefill:  schema.Omit,
 	AuditingEnabled:         DefaultAuditingEnabled,
+	AuditLogSyslog:          schema.Omit,
+	AuditLogWebhookURL:      schema.Omit,
 	StatePort:               DefaultStatePort,
 	IdentityURL:             schema.Omit,
 	IdentityPublicKey:       schema.Omit,
@@ -409,4 +684,15 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
 	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
 	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+
+	MaxStatusHistoryCollectionSize: schema.Omit,
+	MaxActionResultsCollectionSize: schema.Omit,
+
+	BackupTargetType:      schema.Omit,
+	BackupTargetContainer: schema.Omit,
+	BackupTargetRegion:    schema.Omit,
+	BackupTargetEndpoint:  schema.Omit,
+	BackupTargetTenant:    schema.Omit,
+	BackupTargetAccessKey: schema.Omit,
+	BackupTargetSecretKey: schema.Omit,
 })
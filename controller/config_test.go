@@ -121,6 +121,104 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "HTTPS audit log webhook URL OK",
+	config: controller.Config{
+		controller.AuditLogWebhookURL: "https://audit.example.com/juju",
+		controller.CACertKey:          testing.CACert,
+	},
+}, {
+	about: "audit log webhook URL must be http or https",
+	config: controller.Config{
+		controller.AuditLogWebhookURL: "ftp://audit.example.com/juju",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `audit-log-webhook-url: expected http or https URL, got "ftp://audit.example.com/juju"`,
+}, {
+	about: "negative API call rate limit burst",
+	config: controller.Config{
+		controller.APICallRateLimitBurst: -1,
+		controller.CACertKey:             testing.CACert,
+	},
+	expectError: `api-call-rate-limit-burst: expected non-negative number, got -1`,
+}, {
+	about: "invalid API call rate limit refill",
+	config: controller.Config{
+		controller.APICallRateLimitRefill: "soon",
+		controller.CACertKey:              testing.CACert,
+	},
+	expectError: `invalid API call rate limit refill in configuration: time: invalid duration "?soon"?`,
+}, {
+	about: "zero API call rate limit refill",
+	config: controller.Config{
+		controller.APICallRateLimitRefill: "0s",
+		controller.CACertKey:              testing.CACert,
+	},
+	expectError: `api-call-rate-limit-refill: expected positive duration, got "0s"`,
+}, {
+	about: "S3 backup target OK",
+	config: controller.Config{
+		controller.BackupTargetType:      "s3",
+		controller.BackupTargetContainer: "juju-backups",
+		controller.BackupTargetRegion:    "us-east-1",
+		controller.BackupTargetAccessKey: "access",
+		controller.BackupTargetSecretKey: "secret",
+		controller.CACertKey:             testing.CACert,
+	},
+}, {
+	about: "unknown backup target type",
+	config: controller.Config{
+		controller.BackupTargetType: "ftp",
+		controller.CACertKey:        testing.CACert,
+	},
+	expectError: `backup-target-type: expected one of s3, swift or azure, got "ftp"`,
+}, {
+	about: "backup target attribute without type",
+	config: controller.Config{
+		controller.BackupTargetContainer: "juju-backups",
+		controller.CACertKey:             testing.CACert,
+	},
+	expectError: `backup-target-container: not valid without backup-target-type`,
+}, {
+	about: "S3 backup target requires region",
+	config: controller.Config{
+		controller.BackupTargetType:      "s3",
+		controller.BackupTargetContainer: "juju-backups",
+		controller.BackupTargetAccessKey: "access",
+		controller.BackupTargetSecretKey: "secret",
+		controller.CACertKey:             testing.CACert,
+	},
+	expectError: `backup-target-region: required for s3 backup target`,
+}, {
+	about: "Swift backup target requires endpoint",
+	config: controller.Config{
+		controller.BackupTargetType:      "swift",
+		controller.BackupTargetContainer: "juju-backups",
+		controller.BackupTargetAccessKey: "user",
+		controller.BackupTargetSecretKey: "password",
+		controller.CACertKey:             testing.CACert,
+	},
+	expectError: `backup-target-endpoint: required for swift backup target`,
+}, {
+	about: "backup target endpoint must be http or https",
+	config: controller.Config{
+		controller.BackupTargetType:      "swift",
+		controller.BackupTargetContainer: "juju-backups",
+		controller.BackupTargetEndpoint:  "ftp://keystone.example.com/v2.0",
+		controller.BackupTargetAccessKey: "user",
+		controller.BackupTargetSecretKey: "password",
+		controller.CACertKey:             testing.CACert,
+	},
+	expectError: `backup-target-endpoint: expected http or https URL, got "ftp://keystone.example.com/v2.0"`,
+}, {
+	about: "Azure backup target requires secret key",
+	config: controller.Config{
+		controller.BackupTargetType:      "azure",
+		controller.BackupTargetContainer: "juju-backups",
+		controller.BackupTargetAccessKey: "account",
+		controller.CACertKey:             testing.CACert,
+	},
+	expectError: `backup-target-secret-key: required for azure backup target`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -135,6 +233,83 @@ func (s *ConfigSuite) TestValidate(c *gc.C) {
 	}
 }
 
+func (s *ConfigSuite) TestAuditLogSinksDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSyslog(), jc.IsFalse)
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestAuditLogSinks(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"audit-log-syslog":      true,
+			"audit-log-webhook-url": "https://audit.example.com/juju",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogSyslog(), jc.IsTrue)
+	c.Assert(cfg.AuditLogWebhookURL(), gc.Equals, "https://audit.example.com/juju")
+}
+
+func (s *ConfigSuite) TestBackupTargetDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := cfg.BackupTarget()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestBackupTarget(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-target-type":       "swift",
+			"backup-target-container":  "juju-backups",
+			"backup-target-region":     "region-1",
+			"backup-target-endpoint":   "https://keystone.example.com/v2.0",
+			"backup-target-tenant":     "tenant",
+			"backup-target-access-key": "user",
+			"backup-target-secret-key": "password",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	target, ok := cfg.BackupTarget()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(target, jc.DeepEquals, controller.BackupTargetConfig{
+		Type:      controller.BackupTargetSwift,
+		Container: "juju-backups",
+		Region:    "region-1",
+		Endpoint:  "https://keystone.example.com/v2.0",
+		Tenant:    "tenant",
+		AccessKey: "user",
+		SecretKey: "password",
+	})
+}
+
+func (s *ConfigSuite) TestAPICallRateLimitDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APICallRateLimitBurst(), gc.Equals, 0)
+	c.Assert(cfg.APICallRateLimitRefill(), gc.Equals, controller.DefaultAPICallRateLimitRefill)
+}
+
+func (s *ConfigSuite) TestAPICallRateLimit(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"api-call-rate-limit-burst":  20,
+			"api-call-rate-limit-refill": "250ms",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APICallRateLimitBurst(), gc.Equals, 20)
+	c.Assert(cfg.APICallRateLimitRefill(), gc.Equals, 250*time.Millisecond)
+}
+
 func (s *ConfigSuite) TestLogConfigDefaults(c *gc.C) {
 	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -173,3 +348,35 @@ func (s *ConfigSuite) TestTxnLogConfigValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MaxTxnLogSizeMB(), gc.Equals, 8192)
 }
+
+func (s *ConfigSuite) TestPruneCollectionSizeDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxStatusHistoryCollectionSizeMB(), gc.Equals, 0)
+	c.Assert(cfg.MaxActionResultsCollectionSizeMB(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestPruneCollectionSizeValue(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"max-status-history-collection-size": "5G",
+			"max-action-results-collection-size": "512M",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxStatusHistoryCollectionSizeMB(), gc.Equals, 5120)
+	c.Assert(cfg.MaxActionResultsCollectionSizeMB(), gc.Equals, 512)
+}
+
+func (s *ConfigSuite) TestPruneCollectionSizeInvalid(c *gc.C) {
+	_, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"max-status-history-collection-size": "lots",
+		},
+	)
+	c.Assert(err, gc.ErrorMatches, "invalid max status history collection size in configuration: .*")
+}
@@ -11,6 +11,14 @@ import (
 // JujuRunActionName defines the action name used by juju-run.
 const JujuRunActionName = "juju-run"
 
+// When a juju-run action is asked to stream its output, each line the
+// commands write is logged as an action message, with one of these
+// prefixes to tell standard output and standard error apart.
+const (
+	JujuRunStdoutPrefix = "stdout: "
+	JujuRunStderrPrefix = "stderr: "
+)
+
 // PredefinedActionsSpec defines a spec for each predefined action.
 var PredefinedActionsSpec = map[string]charm.ActionSpec{
 	JujuRunActionName: charm.ActionSpec{
@@ -29,6 +37,25 @@ var PredefinedActionsSpec = map[string]charm.ActionSpec{
 					"type":        "number",
 					"description": "timeout for command execution",
 				},
+				"relation-id": map[string]interface{}{
+					"type":        "number",
+					"description": "relation to run the command in the context of",
+				},
+				"remote-unit": map[string]interface{}{
+					"type":        "string",
+					"description": "remote unit of the relation context",
+				},
+				"env": map[string]interface{}{
+					"type":        "object",
+					"description": "additional environment variables for the command",
+					"additionalProperties": map[string]interface{}{
+						"type": "string",
+					},
+				},
+				"stream": map[string]interface{}{
+					"type":        "boolean",
+					"description": "log each line of output as an action message",
+				},
 			},
 		},
 	},
@@ -0,0 +1,190 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule is a parsed cron-like schedule, used to run actions
+// periodically. Schedules are evaluated in UTC, to the minute.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny record whether the day of month and day of
+	// week fields were "*". As with cron, if both are restricted a
+	// day matches if either of them does.
+	domAny, dowAny bool
+}
+
+// scheduleMacros holds the shorthand schedules understood by
+// ParseSchedule.
+var scheduleMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// scheduleField describes the range of values allowed in a field of
+// a schedule.
+type scheduleField struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteField = scheduleField{"minute", 0, 59}
+	hourField   = scheduleField{"hour", 0, 23}
+	domField    = scheduleField{"day of month", 1, 31}
+	monthField  = scheduleField{"month", 1, 12}
+	// Both 0 and 7 are Sunday.
+	dowField = scheduleField{"day of week", 0, 7}
+)
+
+// ParseSchedule parses a schedule in the five field format used by
+// cron: minute, hour, day of month, month and day of week. Each field
+// may be "*", a value, a range such as "1-5", or a comma separated
+// list of these, and any of them may be followed by a step such as
+// "/15". The macros @yearly, @annually, @monthly, @weekly, @daily and
+// @hourly are also accepted.
+func ParseSchedule(spec string) (*Schedule, error) {
+	expanded := strings.TrimSpace(spec)
+	if macro, ok := scheduleMacros[expanded]; ok {
+		expanded = macro
+	}
+	fields := strings.Fields(expanded)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+	var s Schedule
+	var err error
+	parse := func(value string, field scheduleField) uint64 {
+		if err != nil {
+			return 0
+		}
+		var bits uint64
+		bits, err = parseScheduleField(value, field)
+		if err != nil {
+			err = errors.NotValidf("schedule %q: %v", spec, err)
+		}
+		return bits
+	}
+	s.minute = parse(fields[0], minuteField)
+	s.hour = parse(fields[1], hourField)
+	s.dom = parse(fields[2], domField)
+	s.month = parse(fields[3], monthField)
+	s.dow = parse(fields[4], dowField)
+	if err != nil {
+		return nil, err
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domAny = fields[2] == "*"
+	s.dowAny = fields[4] == "*"
+	return &s, nil
+}
+
+func parseScheduleField(value string, field scheduleField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.Errorf("invalid step %q in %s", part[i+1:], field.name)
+			}
+		}
+		var first, last int
+		switch {
+		case rangePart == "*":
+			first, last = field.min, field.max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if first, err = parseScheduleValue(bounds[0], field); err != nil {
+				return 0, err
+			}
+			if last, err = parseScheduleValue(bounds[1], field); err != nil {
+				return 0, err
+			}
+			if last < first {
+				return 0, errors.Errorf("invalid range %q in %s", rangePart, field.name)
+			}
+		default:
+			var err error
+			if first, err = parseScheduleValue(rangePart, field); err != nil {
+				return 0, err
+			}
+			last = first
+			if step > 1 {
+				// As with cron, "a/n" means every n from a.
+				last = field.max
+			}
+		}
+		for v := first; v <= last; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseScheduleValue(value string, field scheduleField) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < field.min || v > field.max {
+		return 0, errors.Errorf("%s %q out of range %d-%d", field.name, value, field.min, field.max)
+	}
+	return v, nil
+}
+
+// maxScheduleSearch bounds the search for the next time a schedule
+// matches, so that schedules that can never match (such as the 30th
+// of February) do not search forever.
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first time after the given time at which the
+// schedule matches. If the schedule never matches, the zero time is
+// returned.
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type ScheduleSuite struct{}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+// Wednesday.
+var scheduleBase = time.Date(2017, 11, 1, 10, 17, 30, 0, time.UTC)
+
+func (*ScheduleSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec   string
+		expect time.Time
+	}{{
+		spec:   "* * * * *",
+		expect: time.Date(2017, 11, 1, 10, 18, 0, 0, time.UTC),
+	}, {
+		spec:   "*/15 * * * *",
+		expect: time.Date(2017, 11, 1, 10, 30, 0, 0, time.UTC),
+	}, {
+		spec:   "5 * * * *",
+		expect: time.Date(2017, 11, 1, 11, 5, 0, 0, time.UTC),
+	}, {
+		spec:   "30 2 * * *",
+		expect: time.Date(2017, 11, 2, 2, 30, 0, 0, time.UTC),
+	}, {
+		spec:   "0 9-17/4 * * *",
+		expect: time.Date(2017, 11, 1, 13, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 * * 0",
+		expect: time.Date(2017, 11, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 * * 7",
+		expect: time.Date(2017, 11, 5, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 15 * 1",
+		expect: time.Date(2017, 11, 6, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 29 2 *",
+		expect: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0,30 4 1,15 1,7 *",
+		expect: time.Date(2018, 1, 1, 4, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@hourly",
+		expect: time.Date(2017, 11, 1, 11, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@monthly",
+		expect: time.Date(2017, 12, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 30 2 *",
+		expect: time.Time{},
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		s, err := actions.ParseSchedule(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(s.Next(scheduleBase), gc.DeepEquals, test.expect)
+	}
+}
+
+func (*ScheduleSuite) TestNextIsAfter(c *gc.C) {
+	s, err := actions.ParseSchedule("0 * * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	on := time.Date(2017, 11, 1, 10, 0, 0, 0, time.UTC)
+	c.Check(s.Next(on), gc.DeepEquals, on.Add(time.Hour))
+}
+
+func (*ScheduleSuite) TestParseScheduleErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `schedule "": expected 5 fields, got 0 not valid`,
+	}, {
+		spec: "* * * *",
+		err:  `schedule "\* \* \* \*": expected 5 fields, got 4 not valid`,
+	}, {
+		spec: "60 * * * *",
+		err:  `schedule "60 \* \* \* \*": minute "60" out of range 0-59 not valid`,
+	}, {
+		spec: "* * 0 * *",
+		err:  `schedule .*: day of month "0" out of range 1-31 not valid`,
+	}, {
+		spec: "* 5-2 * * *",
+		err:  `schedule .*: invalid range "5-2" in hour not valid`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `schedule .*: invalid step "0" in minute not valid`,
+	}, {
+		spec: "* * * jan *",
+		err:  `schedule .*: month "jan" out of range 1-12 not valid`,
+	}, {
+		spec: "@sometimes",
+		err:  `schedule "@sometimes": expected 5 fields, got 1 not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := actions.ParseSchedule(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
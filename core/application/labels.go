@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package application holds logic pertaining to applications that is
+// shared by the client and the controller.
+package application
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+var (
+	// validLabelKey matches a valid label key. Keys are restricted
+	// so that they can be used as document field names.
+	validLabelKey = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+	// validLabelValue matches a valid label value.
+	validLabelValue = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]{0,61}[a-zA-Z0-9])?$`)
+)
+
+// ValidateLabels returns an error if any of the given application
+// labels has an invalid key or value.
+func ValidateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if !validLabelKey.MatchString(key) {
+			return errors.NotValidf("label key %q", key)
+		}
+		if !validLabelValue.MatchString(value) {
+			return errors.NotValidf("label %q value %q", key, value)
+		}
+	}
+	return nil
+}
+
+// Selector selects applications by their labels. An application is
+// selected if it has every one of the selector's labels.
+type Selector map[string]string
+
+// ParseSelector parses a selector of the form "key=value[,key=value...]".
+func ParseSelector(s string) (Selector, error) {
+	selector := make(Selector)
+	for _, term := range strings.Split(s, ",") {
+		term = strings.TrimSpace(term)
+		parts := strings.SplitN(term, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.NotValidf("selector term %q", term)
+		}
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		if existing, ok := selector[key]; ok && existing != value {
+			return nil, errors.NotValidf("selector with conflicting values for label %q", key)
+		}
+		selector[key] = value
+	}
+	if err := ValidateLabels(selector); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return selector, nil
+}
+
+// Matches reports whether an application with the given labels is
+// selected.
+func (s Selector) Matches(labels map[string]string) bool {
+	for key, value := range s {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in the form accepted by ParseSelector,
+// with its terms sorted by key.
+func (s Selector) String() string {
+	terms := make([]string, 0, len(s))
+	for key, value := range s {
+		terms = append(terms, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(terms)
+	return strings.Join(terms, ",")
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+type LabelsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&LabelsSuite{})
+
+func (*LabelsSuite) TestValidateLabelsValid(c *gc.C) {
+	for i, test := range []map[string]string{
+		nil,
+		{"tier": "frontend"},
+		{"tier": "frontend", "team-2": "Web_1.0"},
+		{"a": "b"},
+		{strings.Repeat("k", 63): strings.Repeat("v", 63)},
+	} {
+		c.Logf("test %d: %v", i, test)
+		err := application.ValidateLabels(test)
+		c.Check(err, jc.ErrorIsNil)
+	}
+}
+
+func (*LabelsSuite) TestValidateLabelsInvalid(c *gc.C) {
+	for i, test := range []struct {
+		labels map[string]string
+		err    string
+	}{{
+		labels: map[string]string{"": "frontend"},
+		err:    `label key "" not valid`,
+	}, {
+		labels: map[string]string{"Tier": "frontend"},
+		err:    `label key "Tier" not valid`,
+	}, {
+		labels: map[string]string{"tier.name": "frontend"},
+		err:    `label key "tier.name" not valid`,
+	}, {
+		labels: map[string]string{"-tier": "frontend"},
+		err:    `label key "-tier" not valid`,
+	}, {
+		labels: map[string]string{strings.Repeat("k", 64): "frontend"},
+		err:    `label key "k+" not valid`,
+	}, {
+		labels: map[string]string{"tier": ""},
+		err:    `label "tier" value "" not valid`,
+	}, {
+		labels: map[string]string{"tier": "front end"},
+		err:    `label "tier" value "front end" not valid`,
+	}, {
+		labels: map[string]string{"tier": "frontend-"},
+		err:    `label "tier" value "frontend-" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.labels)
+		err := application.ValidateLabels(test.labels)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*LabelsSuite) TestParseSelector(c *gc.C) {
+	for i, test := range []struct {
+		selector string
+		expect   application.Selector
+	}{{
+		selector: "tier=frontend",
+		expect:   application.Selector{"tier": "frontend"},
+	}, {
+		selector: "tier=frontend,team=web",
+		expect:   application.Selector{"tier": "frontend", "team": "web"},
+	}, {
+		selector: " tier = frontend , team=web ",
+		expect:   application.Selector{"tier": "frontend", "team": "web"},
+	}, {
+		selector: "tier=frontend,tier=frontend",
+		expect:   application.Selector{"tier": "frontend"},
+	}} {
+		c.Logf("test %d: %q", i, test.selector)
+		selector, err := application.ParseSelector(test.selector)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(selector, jc.DeepEquals, test.expect)
+	}
+}
+
+func (*LabelsSuite) TestParseSelectorInvalid(c *gc.C) {
+	for i, test := range []struct {
+		selector string
+		err      string
+	}{{
+		selector: "",
+		err:      `selector term "" not valid`,
+	}, {
+		selector: "tier",
+		err:      `selector term "tier" not valid`,
+	}, {
+		selector: "tier=frontend,",
+		err:      `selector term "" not valid`,
+	}, {
+		selector: "tier=frontend,tier=backend",
+		err:      `selector with conflicting values for label "tier" not valid`,
+	}, {
+		selector: "Tier=frontend",
+		err:      `label key "Tier" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.selector)
+		_, err := application.ParseSelector(test.selector)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*LabelsSuite) TestMatches(c *gc.C) {
+	selector := application.Selector{"tier": "frontend", "team": "web"}
+	c.Check(selector.Matches(map[string]string{
+		"tier": "frontend", "team": "web", "zone": "a",
+	}), jc.IsTrue)
+	c.Check(selector.Matches(map[string]string{"tier": "frontend"}), jc.IsFalse)
+	c.Check(selector.Matches(map[string]string{
+		"tier": "frontend", "team": "db",
+	}), jc.IsFalse)
+	c.Check(selector.Matches(nil), jc.IsFalse)
+}
+
+func (*LabelsSuite) TestString(c *gc.C) {
+	selector := application.Selector{"tier": "frontend", "team": "web"}
+	c.Check(selector.String(), gc.Equals, "team=web,tier=frontend")
+	parsed, err := application.ParseSelector(selector.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(parsed, jc.DeepEquals, selector)
+}
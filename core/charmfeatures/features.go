@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmfeatures holds logic pertaining to the Juju features
+// that charms may declare they require, in the "required-features"
+// field of their metadata.
+package charmfeatures
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// Secrets is the feature required by charms that store and
+	// share secrets through Juju.
+	Secrets = "secrets"
+
+	// Spaces is the feature required by charms whose endpoints must
+	// be bound to network spaces.
+	Spaces = "spaces"
+)
+
+// metadataFile is the name of the file, at the root of a charm, that
+// holds the charm's metadata.
+const metadataFile = "metadata.yaml"
+
+// featuresMeta holds the charm metadata field that declares the
+// features a charm requires. It is not part of charm.Meta, so it is
+// read separately.
+type featuresMeta struct {
+	RequiredFeatures []string `yaml:"required-features"`
+}
+
+// Parse returns the sorted features required by the charm metadata
+// held in data.
+func Parse(data []byte) ([]string, error) {
+	var meta featuresMeta
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, errors.Annotate(err, "cannot parse charm metadata")
+	}
+	for _, feature := range meta.RequiredFeatures {
+		if feature == "" {
+			return nil, errors.NotValidf("empty required feature")
+		}
+	}
+	sort.Strings(meta.RequiredFeatures)
+	return meta.RequiredFeatures, nil
+}
+
+// ReadCharmFeatures returns the features required by the given charm.
+// Only charm directories and archives can declare required features.
+func ReadCharmFeatures(ch charm.Charm) ([]string, error) {
+	var data []byte
+	var err error
+	switch ch := ch.(type) {
+	case *charm.CharmDir:
+		data, err = ioutil.ReadFile(filepath.Join(ch.Path, metadataFile))
+	case *charm.CharmArchive:
+		data, err = readArchiveFile(ch.Path, metadataFile)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm metadata")
+	}
+	return Parse(data)
+}
+
+func readArchiveFile(path, name string) ([]byte, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer rc.Close()
+		return ioutil.ReadAll(rc)
+	}
+	return nil, os.ErrNotExist
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmfeatures_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/core/charmfeatures"
+	"github.com/juju/juju/testcharms"
+)
+
+type FeaturesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FeaturesSuite{})
+
+func (*FeaturesSuite) TestParse(c *gc.C) {
+	features, err := charmfeatures.Parse([]byte("name: foo\nrequired-features: [spaces, secrets]\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"secrets", "spaces"})
+
+	features, err = charmfeatures.Parse([]byte("name: foo\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, gc.HasLen, 0)
+}
+
+func (*FeaturesSuite) TestParseInvalid(c *gc.C) {
+	_, err := charmfeatures.Parse([]byte("required-features: spaces\n"))
+	c.Assert(err, gc.ErrorMatches, "cannot parse charm metadata: .*")
+
+	_, err = charmfeatures.Parse([]byte("required-features: [\"\"]\n"))
+	c.Assert(err, gc.ErrorMatches, "empty required feature not valid")
+}
+
+func (*FeaturesSuite) TestReadCharmFeatures(c *gc.C) {
+	dir := testcharms.Repo.ClonedDirPath(c.MkDir(), "dummy")
+	ch, err := charm.ReadCharmDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	features, err := charmfeatures.ReadCharmFeatures(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, gc.HasLen, 0)
+
+	metadataPath := filepath.Join(dir, "metadata.yaml")
+	metadata, err := ioutil.ReadFile(metadataPath)
+	c.Assert(err, jc.ErrorIsNil)
+	metadata = append(metadata, "\nrequired-features: [spaces]\n"...)
+	err = ioutil.WriteFile(metadataPath, metadata, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err = charm.ReadCharmDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	features, err = charmfeatures.ReadCharmFeatures(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(features, jc.DeepEquals, []string{"spaces"})
+
+	archivePath := filepath.Join(c.MkDir(), "dummy.charm")
+	f, err := os.Create(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ch.ArchiveTo(f)
+	f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	archive, err := charm.ReadCharmArchive(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	archiveFeatures, err := charmfeatures.ReadCharmFeatures(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archiveFeatures, jc.DeepEquals, features)
+}
@@ -0,0 +1,211 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package condition holds the parsing and comparison of conditions on
+// the entities in a model, which are shared by the client and the
+// controller.
+//
+// A condition is one or more terms joined by "&&". Each term compares
+// an attribute of an application, unit or machine with a value:
+//
+//	application:mysql.status == active
+//	application:mysql.units >= 3
+//	application:mysql.config.dataset-size == 80%
+//	unit:mysql/0.agent-status == idle
+//	machine:0.instance-status != pending
+//
+// The supported operators are ==, !=, <, <=, > and >=. The ordering
+// operators require numeric values.
+package condition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// Attributes that may be compared in a term.
+const (
+	Status         = "status"
+	AgentStatus    = "agent-status"
+	InstanceStatus = "instance-status"
+	Units          = "units"
+	Config         = "config"
+)
+
+// attributes holds the attributes that may be compared for each kind
+// of entity.
+var attributes = map[string][]string{
+	names.ApplicationTagKind: {Status, Units, Config},
+	names.UnitTagKind:        {Status, AgentStatus},
+	names.MachineTagKind:     {Status, InstanceStatus},
+}
+
+var operators = []string{"==", "!=", "<", "<=", ">", ">="}
+
+// Term is a single comparison in a condition.
+type Term struct {
+	// Entity is the entity whose attribute is compared.
+	Entity names.Tag
+
+	// Attribute is the name of the compared attribute.
+	Attribute string
+
+	// Key is the config key compared, if Attribute is Config.
+	Key string
+
+	// Operator is the comparison operator.
+	Operator string
+
+	// Value is the value the attribute is compared with.
+	Value string
+}
+
+// String returns the term in the form accepted by Parse.
+func (t Term) String() string {
+	attribute := t.Attribute
+	if t.Attribute == Config {
+		attribute += "." + t.Key
+	}
+	return fmt.Sprintf("%s:%s.%s %s %s", t.Entity.Kind(), t.Entity.Id(), attribute, t.Operator, t.Value)
+}
+
+// Compare returns whether the actual value of the term's attribute
+// satisfies the term. If both values are numbers they are compared
+// numerically; otherwise only equality may be compared.
+func (t Term) Compare(actual string) (bool, error) {
+	want, err1 := strconv.ParseFloat(t.Value, 64)
+	got, err2 := strconv.ParseFloat(actual, 64)
+	if err1 != nil || err2 != nil {
+		switch t.Operator {
+		case "==":
+			return actual == t.Value, nil
+		case "!=":
+			return actual != t.Value, nil
+		}
+		return false, errors.NotValidf("comparing non-numeric value %q with %s", actual, t.Operator)
+	}
+	switch t.Operator {
+	case "==":
+		return got == want, nil
+	case "!=":
+		return got != want, nil
+	case "<":
+		return got < want, nil
+	case "<=":
+		return got <= want, nil
+	case ">":
+		return got > want, nil
+	case ">=":
+		return got >= want, nil
+	}
+	return false, errors.NotValidf("operator %q", t.Operator)
+}
+
+// Condition is a set of terms that must all be satisfied.
+type Condition []Term
+
+// String returns the condition in the form accepted by Parse.
+func (c Condition) String() string {
+	terms := make([]string, len(c))
+	for i, term := range c {
+		terms[i] = term.String()
+	}
+	return strings.Join(terms, " && ")
+}
+
+// Parse parses a condition of the form "term [&& term...]".
+func Parse(s string) (Condition, error) {
+	var condition Condition
+	for _, termString := range strings.Split(s, "&&") {
+		term, err := parseTerm(strings.TrimSpace(termString))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		condition = append(condition, term)
+	}
+	return condition, nil
+}
+
+// parseTerm parses a term of the form "kind:id.attribute op value".
+func parseTerm(s string) (Term, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 3 {
+		return Term{}, errors.NotValidf("term %q", s)
+	}
+	subject, operator, value := fields[0], fields[1], strings.Trim(fields[2], `"'`)
+	if !isOperator(operator) {
+		return Term{}, errors.NotValidf("operator %q in term %q", operator, s)
+	}
+
+	parts := strings.SplitN(subject, ":", 2)
+	if len(parts) != 2 {
+		return Term{}, errors.NotValidf("subject %q", subject)
+	}
+	kind := parts[0]
+	parts = strings.SplitN(parts[1], ".", 2)
+	if len(parts) != 2 {
+		return Term{}, errors.NotValidf("subject %q", subject)
+	}
+	id, attribute := parts[0], parts[1]
+	tag, err := newTag(kind, id)
+	if err != nil {
+		return Term{}, errors.Trace(err)
+	}
+
+	term := Term{
+		Entity:    tag,
+		Attribute: attribute,
+		Operator:  operator,
+		Value:     value,
+	}
+	if kind == names.ApplicationTagKind && strings.HasPrefix(attribute, Config+".") {
+		term.Attribute = Config
+		term.Key = strings.TrimPrefix(attribute, Config+".")
+	}
+	if !isAttribute(kind, term.Attribute) || term.Attribute == Config && term.Key == "" {
+		return Term{}, errors.NotValidf("%s attribute %q", kind, attribute)
+	}
+	return term, nil
+}
+
+func newTag(kind, id string) (names.Tag, error) {
+	switch kind {
+	case names.ApplicationTagKind:
+		if names.IsValidApplication(id) {
+			return names.NewApplicationTag(id), nil
+		}
+	case names.UnitTagKind:
+		if names.IsValidUnit(id) {
+			return names.NewUnitTag(id), nil
+		}
+	case names.MachineTagKind:
+		if names.IsValidMachine(id) {
+			return names.NewMachineTag(id), nil
+		}
+	default:
+		return nil, errors.NotValidf("entity kind %q", kind)
+	}
+	return nil, errors.NotValidf("%s %q", kind, id)
+}
+
+func isOperator(s string) bool {
+	for _, op := range operators {
+		if s == op {
+			return true
+		}
+	}
+	return false
+}
+
+func isAttribute(kind, attribute string) bool {
+	for _, a := range attributes[kind] {
+		if a == attribute {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package condition_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/condition"
+)
+
+type ConditionSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConditionSuite{})
+
+func (*ConditionSuite) TestParse(c *gc.C) {
+	cond, err := condition.Parse(
+		"application:mysql.status == active && unit:mysql/0.agent-status==idle && " +
+			"application:mysql.config.dataset-size != '80%' && machine:0/lxd/1.instance-status == running",
+	)
+	c.Assert(err, gc.ErrorMatches, `term "unit:mysql/0.agent-status==idle" not valid`)
+
+	cond, err = condition.Parse(
+		"application:mysql.status == active && unit:mysql/0.agent-status == idle && " +
+			"application:mysql.config.dataset-size != '80%' && machine:0/lxd/1.instance-status == running",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cond, jc.DeepEquals, condition.Condition{{
+		Entity:    names.NewApplicationTag("mysql"),
+		Attribute: condition.Status,
+		Operator:  "==",
+		Value:     "active",
+	}, {
+		Entity:    names.NewUnitTag("mysql/0"),
+		Attribute: condition.AgentStatus,
+		Operator:  "==",
+		Value:     "idle",
+	}, {
+		Entity:    names.NewApplicationTag("mysql"),
+		Attribute: condition.Config,
+		Key:       "dataset-size",
+		Operator:  "!=",
+		Value:     "80%",
+	}, {
+		Entity:    names.NewMachineTag("0/lxd/1"),
+		Attribute: condition.InstanceStatus,
+		Operator:  "==",
+		Value:     "running",
+	}})
+	c.Assert(cond.String(), gc.Equals,
+		"application:mysql.status == active && unit:mysql/0.agent-status == idle && "+
+			"application:mysql.config.dataset-size != 80% && machine:0/lxd/1.instance-status == running")
+}
+
+func (*ConditionSuite) TestParseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		condition string
+		err       string
+	}{{
+		condition: "",
+		err:       `term "" not valid`,
+	}, {
+		condition: "application:mysql.status ~= active",
+		err:       `operator "~=" in term "application:mysql.status ~= active" not valid`,
+	}, {
+		condition: "mysql.status == active",
+		err:       `subject "mysql.status" not valid`,
+	}, {
+		condition: "application:mysql == active",
+		err:       `subject "application:mysql" not valid`,
+	}, {
+		condition: "model:foo.status == active",
+		err:       `entity kind "model" not valid`,
+	}, {
+		condition: "unit:mysql.status == active",
+		err:       `unit "mysql" not valid`,
+	}, {
+		condition: "unit:mysql/0.units == 1",
+		err:       `unit attribute "units" not valid`,
+	}, {
+		condition: "application:mysql.config. == 1",
+		err:       `application attribute "config." not valid`,
+	}, {
+		condition: "application:mysql.units >= 1 &&",
+		err:       `term "" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.condition)
+		_, err := condition.Parse(test.condition)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*ConditionSuite) TestCompare(c *gc.C) {
+	for i, test := range []struct {
+		operator string
+		value    string
+		actual   string
+		expect   bool
+	}{
+		{"==", "active", "active", true},
+		{"==", "active", "blocked", false},
+		{"!=", "active", "blocked", true},
+		{"==", "3", "3.0", true},
+		{">=", "3", "3", true},
+		{">=", "3", "2", false},
+		{">", "3", "4", true},
+		{"<", "3", "4", false},
+		{"<=", "3", "3", true},
+		{"!=", "3", "3", false},
+	} {
+		c.Logf("test %d: %s %s %s", i, test.actual, test.operator, test.value)
+		term := condition.Term{Operator: test.operator, Value: test.value}
+		ok, err := term.Compare(test.actual)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(ok, gc.Equals, test.expect)
+	}
+}
+
+func (*ConditionSuite) TestCompareNonNumeric(c *gc.C) {
+	term := condition.Term{Operator: ">", Value: "3"}
+	_, err := term.Compare("many")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `comparing non-numeric value "many" with > not valid`)
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel
+
+import (
+	"time"
+)
+
+// OfferConnectionHealth describes whether the consuming side of an
+// offer connection has been heard from recently.
+type OfferConnectionHealth string
+
+const (
+	// OfferConnectionHealthy indicates that the consumer has been
+	// heard from within OfferConnectionStaleAfter.
+	OfferConnectionHealthy OfferConnectionHealth = "healthy"
+
+	// OfferConnectionStale indicates that the consumer has not been
+	// heard from within OfferConnectionStaleAfter.
+	OfferConnectionStale OfferConnectionHealth = "stale"
+
+	// OfferConnectionHealthUnknown indicates that there is no record
+	// of when the consumer was last heard from.
+	OfferConnectionHealthUnknown OfferConnectionHealth = "unknown"
+)
+
+const (
+	// OfferConnectionStaleAfter is how long the consumer of an offer
+	// may go without contacting the offering model before the
+	// connection is reported as stale.
+	OfferConnectionStaleAfter = 24 * time.Hour
+
+	// OfferConnectionPruneAfter is how long the consumer of an offer
+	// may go without contacting the offering model before the
+	// connection's relation is removed, if the consuming model is
+	// not hosted by the offering model's controller.
+	OfferConnectionPruneAfter = 7 * 24 * time.Hour
+)
+
+// ConnectionHealth returns the health of an offer connection whose
+// consumer was last heard from at lastSeen.
+func ConnectionHealth(lastSeen, now time.Time) OfferConnectionHealth {
+	if lastSeen.IsZero() {
+		return OfferConnectionHealthUnknown
+	}
+	if now.Sub(lastSeen) > OfferConnectionStaleAfter {
+		return OfferConnectionStale
+	}
+	return OfferConnectionHealthy
+}
@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodel_test
+
+import (
+	"time"
+
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+)
+
+type HealthSuite struct{}
+
+var _ = gc.Suite(&HealthSuite{})
+
+func (*HealthSuite) TestConnectionHealth(c *gc.C) {
+	now := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	c.Assert(crossmodel.ConnectionHealth(now, now), gc.Equals, crossmodel.OfferConnectionHealthy)
+	c.Assert(
+		crossmodel.ConnectionHealth(now.Add(-crossmodel.OfferConnectionStaleAfter), now),
+		gc.Equals, crossmodel.OfferConnectionHealthy,
+	)
+	c.Assert(
+		crossmodel.ConnectionHealth(now.Add(-crossmodel.OfferConnectionStaleAfter-time.Second), now),
+		gc.Equals, crossmodel.OfferConnectionStale,
+	)
+	c.Assert(crossmodel.ConnectionHealth(time.Time{}, now), gc.Equals, crossmodel.OfferConnectionHealthUnknown)
+}
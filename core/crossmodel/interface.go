@@ -4,6 +4,8 @@
 package crossmodel
 
 import (
+	"time"
+
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/macaroon.v1"
 
@@ -29,6 +31,37 @@ type ApplicationOffer struct {
 	// Endpoints is the collection of endpoint names offered (internal->published).
 	// The map allows for advertised endpoint names to be aliased.
 	Endpoints map[string]charm.Relation
+
+	// IngressCIDRs are the networks from which consumers of the offer
+	// may connect. If empty, consumers may connect from any network
+	// allowed by the model's firewall rules.
+	IngressCIDRs []string
+
+	// Version is the number of times the offer has been migrated to a
+	// new application.
+	Version int
+
+	// PendingMigration holds the details of a migration of the offer
+	// to a new application which has been started but not completed.
+	PendingMigration *OfferMigration
+}
+
+// OfferMigration describes the migration of an offer to a new
+// application, so that the application providing the offer can be
+// replaced while it is being consumed.
+type OfferMigration struct {
+	// ApplicationName is the name of the application which will
+	// provide the offer once the migration is completed.
+	ApplicationName string
+
+	// Endpoints is the collection of endpoint names offered by the new
+	// application (published->internal). Every endpoint published by
+	// the offer must be included, with the same interface and role.
+	Endpoints map[string]string
+
+	// Deadline is the time by which the offer's consumers are told
+	// the migration will be completed.
+	Deadline time.Time
 }
 
 // AddApplicationOfferArgs contains parameters used to create an application offer.
@@ -143,6 +176,21 @@ type ApplicationOffers interface {
 
 	// AllApplicationOffers returns all application offers in the model.
 	AllApplicationOffers() (offers []*ApplicationOffer, _ error)
+
+	// SetIngressNetworks sets the networks from which consumers of the
+	// named offer may connect. An empty list allows any network.
+	SetIngressNetworks(offerName string, cidrs []string) error
+
+	// StartMigration records the pending migration of the named offer
+	// to a new application.
+	StartMigration(offerName string, migration OfferMigration) error
+
+	// CompleteMigration switches the named offer over to the
+	// application of its pending migration.
+	CompleteMigration(offerName string) (*ApplicationOffer, error)
+
+	// AbortMigration discards the pending migration of the named offer.
+	AbortMigration(offerName string) error
 }
 
 // RemoteApplication represents a remote application.
@@ -80,4 +80,12 @@ type OfferConnection struct {
 
 	// IngressSubnets is the list of subnets from which traffic will originate.
 	IngressSubnets []string
+
+	// Health describes whether the consuming model has been
+	// heard from recently.
+	Health OfferConnectionHealth
+
+	// LastSeen is when the consuming model was last heard from,
+	// if known.
+	LastSeen *time.Time
 }
@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lxdprofile holds logic pertaining to the LXD profiles that
+// charms may declare, describing the devices and configuration that
+// their units require of an LXD container.
+package lxdprofile
+
+import (
+	"archive/zip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+)
+
+// Filename is the name of the file, at the root of a charm, in which
+// the charm declares its LXD profile.
+const Filename = "lxd-profile.yaml"
+
+// reservedConfigPrefixes holds the prefixes of container config keys
+// that are managed by Juju or LXD, and so may not be set by charms.
+var reservedConfigPrefixes = []string{"boot.", "image.", "user.", "volatile."}
+
+// Profile holds the devices and configuration that a charm requires
+// of the LXD containers hosting its units.
+type Profile struct {
+	Description string                       `yaml:"description,omitempty"`
+	Config      map[string]string            `yaml:"config,omitempty"`
+	Devices     map[string]map[string]string `yaml:"devices,omitempty"`
+}
+
+// Empty reports whether the profile requires nothing of a container.
+// A nil profile is empty.
+func (p *Profile) Empty() bool {
+	return p == nil || (len(p.Config) == 0 && len(p.Devices) == 0)
+}
+
+// Validate returns an error if the profile sets reserved config, or
+// declares a device without a type.
+func (p *Profile) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for key := range p.Config {
+		for _, prefix := range reservedConfigPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				return errors.NotValidf("LXD profile config key %q", key)
+			}
+		}
+	}
+	for name, device := range p.Devices {
+		if device["type"] == "" {
+			return errors.NotValidf("LXD profile device %q without type", name)
+		}
+	}
+	return nil
+}
+
+// Parse parses and validates the LXD profile held in data.
+func Parse(data []byte) (*Profile, error) {
+	var profile Profile
+	if err := yaml.Unmarshal(data, &profile); err != nil {
+		return nil, errors.Annotate(err, "cannot parse LXD profile")
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &profile, nil
+}
+
+// ReadCharmProfile returns the LXD profile declared by the given charm,
+// or nil if it declares none. Only charm directories and archives can
+// declare profiles.
+func ReadCharmProfile(ch charm.Charm) (*Profile, error) {
+	var data []byte
+	var err error
+	switch ch := ch.(type) {
+	case *charm.CharmDir:
+		data, err = ioutil.ReadFile(filepath.Join(ch.Path, Filename))
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+	case *charm.CharmArchive:
+		data, err = readArchiveFile(ch.Path, Filename)
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read LXD profile")
+	}
+	return Parse(data)
+}
+
+func readArchiveFile(path, name string) ([]byte, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+	for _, f := range r.File {
+		if f.Name != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer rc.Close()
+		return ioutil.ReadAll(rc)
+	}
+	return nil, errors.NotFoundf("%s", name)
+}
+
+// Name returns the name of the LXD profile holding the profile of
+// the given revision of an application's charm in the named model.
+// Each revision has its own profile, so that containers can be moved
+// from one to the other when the application's charm is upgraded.
+func Name(modelName, appName string, revision int) string {
+	return fmt.Sprintf("juju-%s-%s-%d", modelName, appName, revision)
+}
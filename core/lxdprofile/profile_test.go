@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxdprofile_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/testcharms"
+)
+
+type ProfileSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ProfileSuite{})
+
+const kvmProfile = `
+description: KVM acceleration
+config:
+  security.nesting: "true"
+devices:
+  kvm:
+    type: unix-char
+    path: /dev/kvm
+`
+
+func (*ProfileSuite) TestParse(c *gc.C) {
+	profile, err := lxdprofile.Parse([]byte(kvmProfile))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, &lxdprofile.Profile{
+		Description: "KVM acceleration",
+		Config:      map[string]string{"security.nesting": "true"},
+		Devices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		},
+	})
+	c.Assert(profile.Empty(), jc.IsFalse)
+}
+
+func (*ProfileSuite) TestParseInvalid(c *gc.C) {
+	for i, test := range []struct {
+		data string
+		err  string
+	}{{
+		data: "config: [",
+		err:  "cannot parse LXD profile: .*",
+	}, {
+		data: "config:\n  boot.autostart: \"true\"\n",
+		err:  `LXD profile config key "boot.autostart" not valid`,
+	}, {
+		data: "config:\n  user.juju-model: foo\n",
+		err:  `LXD profile config key "user.juju-model" not valid`,
+	}, {
+		data: "devices:\n  kvm:\n    path: /dev/kvm\n",
+		err:  `LXD profile device "kvm" without type not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.data)
+		_, err := lxdprofile.Parse([]byte(test.data))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (*ProfileSuite) TestEmpty(c *gc.C) {
+	var profile *lxdprofile.Profile
+	c.Assert(profile.Empty(), jc.IsTrue)
+	c.Assert((&lxdprofile.Profile{Description: "nothing"}).Empty(), jc.IsTrue)
+}
+
+func (*ProfileSuite) TestReadCharmProfile(c *gc.C) {
+	dir := testcharms.Repo.ClonedDirPath(c.MkDir(), "dummy")
+	ch, err := charm.ReadCharmDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	profile, err := lxdprofile.ReadCharmProfile(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+
+	err = ioutil.WriteFile(filepath.Join(dir, lxdprofile.Filename), []byte(kvmProfile), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err = charm.ReadCharmDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	profile, err = lxdprofile.ReadCharmProfile(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile.Devices["kvm"]["path"], gc.Equals, "/dev/kvm")
+
+	archivePath := filepath.Join(c.MkDir(), "dummy.charm")
+	f, err := os.Create(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ch.ArchiveTo(f)
+	f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	archive, err := charm.ReadCharmArchive(archivePath)
+	c.Assert(err, jc.ErrorIsNil)
+	archiveProfile, err := lxdprofile.ReadCharmProfile(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archiveProfile, jc.DeepEquals, profile)
+}
+
+func (*ProfileSuite) TestName(c *gc.C) {
+	c.Assert(lxdprofile.Name("default", "mysql", 3), gc.Equals, "juju-default-mysql-3")
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// The model features whose use is reported in ModelRequirements.
+const (
+	FeatureStorage            = "storage"
+	FeatureResources          = "resources"
+	FeaturePayloads           = "payloads"
+	FeatureMetrics            = "metrics"
+	FeatureActions            = "actions"
+	FeatureCrossModelRelation = "cross-model-relations"
+)
+
+// FeatureFacades maps each model feature to the facades that the
+// target controller must provide for the migrated model's agents and
+// workers to keep using the feature.
+var FeatureFacades = map[string][]string{
+	FeatureStorage:            {"Storage", "StorageProvisioner"},
+	FeatureResources:          {"Resources"},
+	FeaturePayloads:           {"Payloads"},
+	FeatureMetrics:            {"MetricsAdder", "MetricsManager"},
+	FeatureActions:            {"Action"},
+	FeatureCrossModelRelation: {"CrossModelRelations", "RemoteRelations"},
+}
+
+// ModelRequirements describes what a model being migrated needs of
+// the target controller.
+type ModelRequirements struct {
+	// Cloud is the name of the cloud hosting the model.
+	Cloud string
+
+	// Features holds the model features in use; see FeatureFacades.
+	Features []string
+
+	// StorageProviders holds the storage provider types used by the
+	// model's volumes and filesystems.
+	StorageProviders []string
+
+	// BinariesSize is the total size in bytes of the agent binaries
+	// and resources that will be transferred to the target controller.
+	BinariesSize uint64
+}
+
+// CompatibilityStatus is the outcome of a CompatibilityCheck.
+type CompatibilityStatus string
+
+const (
+	// CompatibilityOK indicates that the check passed.
+	CompatibilityOK CompatibilityStatus = "ok"
+
+	// CompatibilityWarning indicates that the check could not be
+	// completed, but does not prevent the migration.
+	CompatibilityWarning CompatibilityStatus = "warning"
+
+	// CompatibilityFailed indicates that the check failed, and that
+	// the migration must not proceed.
+	CompatibilityFailed CompatibilityStatus = "failed"
+)
+
+// CompatibilityCheck holds the outcome of checking one aspect of
+// whether a target controller can host a migrated model.
+type CompatibilityCheck struct {
+	// Name identifies the check, e.g. "version".
+	Name string
+
+	// Status is the outcome of the check.
+	Status CompatibilityStatus
+
+	// Message describes the outcome, if it was not CompatibilityOK.
+	Message string
+}
+
+// String returns a description of the check's outcome.
+func (c CompatibilityCheck) String() string {
+	if c.Message == "" {
+		return fmt.Sprintf("%s: %s", c.Name, c.Status)
+	}
+	return fmt.Sprintf("%s: %s (%s)", c.Name, c.Status, c.Message)
+}
+
+// CompatibilityReport holds the outcomes of the checks made before a
+// migration to decide whether the target controller can host the
+// model.
+type CompatibilityReport struct {
+	Checks []CompatibilityCheck
+}
+
+// Go reports whether the migration may proceed; that is, whether
+// none of the checks failed.
+func (r CompatibilityReport) Go() bool {
+	for _, check := range r.Checks {
+		if check.Status == CompatibilityFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns an error describing the failed checks, or nil if the
+// migration may proceed.
+func (r CompatibilityReport) Err() error {
+	var failed []string
+	for _, check := range r.Checks {
+		if check.Status == CompatibilityFailed {
+			failed = append(failed, fmt.Sprintf("%s: %s", check.Name, check.Message))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return errors.Errorf("target controller is not compatible: %s", strings.Join(failed, "; "))
+}
+
+// CheckFacades checks that the facades needed by the given model
+// features are among those available on the target controller.
+func CheckFacades(features []string, available map[string][]int) CompatibilityCheck {
+	var missing []string
+	for _, feature := range features {
+		for _, name := range FeatureFacades[feature] {
+			if len(available[name]) == 0 {
+				missing = append(missing, fmt.Sprintf("%s (for %s)", name, feature))
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return CompatibilityCheck{Name: "facades", Status: CompatibilityOK}
+	}
+	sort.Strings(missing)
+	return CompatibilityCheck{
+		Name:    "facades",
+		Status:  CompatibilityFailed,
+		Message: "missing " + strings.Join(missing, ", "),
+	}
+}
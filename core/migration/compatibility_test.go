@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/migration"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CompatibilitySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(new(CompatibilitySuite))
+
+func (s *CompatibilitySuite) TestReportGo(c *gc.C) {
+	report := migration.CompatibilityReport{Checks: []migration.CompatibilityCheck{
+		{Name: "version", Status: migration.CompatibilityOK},
+		{Name: "storage", Status: migration.CompatibilityWarning, Message: "cannot verify ebs"},
+	}}
+	c.Assert(report.Go(), jc.IsTrue)
+	c.Assert(report.Err(), jc.ErrorIsNil)
+}
+
+func (s *CompatibilitySuite) TestReportNoGo(c *gc.C) {
+	report := migration.CompatibilityReport{Checks: []migration.CompatibilityCheck{
+		{Name: "version", Status: migration.CompatibilityFailed, Message: "too old"},
+		{Name: "storage", Status: migration.CompatibilityOK},
+		{Name: "capacity", Status: migration.CompatibilityFailed, Message: "not enough disk"},
+	}}
+	c.Assert(report.Go(), jc.IsFalse)
+	c.Assert(report.Err(), gc.ErrorMatches, "target controller is not compatible: version: too old; capacity: not enough disk")
+}
+
+func (s *CompatibilitySuite) TestCheckString(c *gc.C) {
+	check := migration.CompatibilityCheck{Name: "version", Status: migration.CompatibilityOK}
+	c.Assert(check.String(), gc.Equals, "version: ok")
+	check = migration.CompatibilityCheck{Name: "capacity", Status: migration.CompatibilityFailed, Message: "full"}
+	c.Assert(check.String(), gc.Equals, "capacity: failed (full)")
+}
+
+func (s *CompatibilitySuite) TestCheckFacades(c *gc.C) {
+	available := map[string][]int{
+		"Resources":          {1},
+		"Storage":            {3},
+		"StorageProvisioner": {3},
+	}
+	check := migration.CheckFacades([]string{migration.FeatureStorage, migration.FeatureResources}, available)
+	c.Assert(check, jc.DeepEquals, migration.CompatibilityCheck{
+		Name:   "facades",
+		Status: migration.CompatibilityOK,
+	})
+
+	check = migration.CheckFacades([]string{migration.FeatureMetrics, migration.FeatureResources}, available)
+	c.Assert(check, jc.DeepEquals, migration.CompatibilityCheck{
+		Name:    "facades",
+		Status:  migration.CompatibilityFailed,
+		Message: "missing MetricsAdder (for metrics), MetricsManager (for metrics)",
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+)
+
+// BlockerKind classifies a ValidationBlocker.
+type BlockerKind string
+
+const (
+	// BlockerModel indicates that the state of the model itself
+	// prevents it from being migrated.
+	BlockerModel BlockerKind = "model"
+
+	// BlockerController indicates that the state of the source
+	// controller prevents the model from being migrated.
+	BlockerController BlockerKind = "controller"
+
+	// BlockerMachine indicates that one of the model's machines is
+	// not in a state that can be migrated.
+	BlockerMachine BlockerKind = "machine"
+
+	// BlockerApplication indicates that one of the model's
+	// applications or units is not in a state that can be migrated.
+	BlockerApplication BlockerKind = "application"
+
+	// BlockerAgentLag indicates that an agent is not running the
+	// model's agent version.
+	BlockerAgentLag BlockerKind = "agent-lag"
+
+	// BlockerVersionSkew indicates that the target controller runs
+	// an older version than the model or the source controller.
+	BlockerVersionSkew BlockerKind = "version-skew"
+
+	// BlockerUnsupportedFeature indicates that the model uses
+	// something that cannot be migrated, or that the target
+	// controller does not support.
+	BlockerUnsupportedFeature BlockerKind = "unsupported-feature"
+
+	// BlockerSpaceMismatch indicates that the model refers to
+	// network spaces that it does not define.
+	BlockerSpaceMismatch BlockerKind = "space-mismatch"
+
+	// BlockerTarget indicates that the target controller cannot
+	// accept the model for another reason.
+	BlockerTarget BlockerKind = "target"
+)
+
+// compatibilityBlockerKinds maps the names of the target controller's
+// compatibility checks to the kind of blocker reported when they fail.
+var compatibilityBlockerKinds = map[string]BlockerKind{
+	"version":  BlockerVersionSkew,
+	"cloud":    BlockerUnsupportedFeature,
+	"storage":  BlockerUnsupportedFeature,
+	"facades":  BlockerUnsupportedFeature,
+	"capacity": BlockerTarget,
+}
+
+// ValidationBlocker describes something that would prevent a model
+// migration from succeeding.
+type ValidationBlocker struct {
+	// Kind classifies the blocker.
+	Kind BlockerKind
+
+	// Message describes the blocker.
+	Message string
+}
+
+// String returns a description of the blocker.
+func (b ValidationBlocker) String() string {
+	return fmt.Sprintf("%s: %s", b.Kind, b.Message)
+}
+
+// ValidationReport holds the outcome of validating a model migration
+// without starting it.
+type ValidationReport struct {
+	// Blockers holds everything found that would prevent the
+	// migration from succeeding.
+	Blockers []ValidationBlocker
+
+	// Warnings holds things that could not be verified, but would
+	// not prevent the migration.
+	Warnings []string
+}
+
+// Go reports whether the migration is expected to succeed; that is,
+// whether no blockers were found.
+func (r ValidationReport) Go() bool {
+	return len(r.Blockers) == 0
+}
+
+// Block adds a blocker of the given kind to the report.
+func (r *ValidationReport) Block(kind BlockerKind, format string, args ...interface{}) {
+	r.Blockers = append(r.Blockers, ValidationBlocker{
+		Kind:    kind,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Blocked reports whether the report holds a blocker of the given kind.
+func (r ValidationReport) Blocked(kind BlockerKind) bool {
+	for _, blocker := range r.Blockers {
+		if blocker.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// AddCompatibility adds the failed checks in the target controller's
+// compatibility report to the report as blockers, and the checks that
+// could not be completed as warnings.
+func (r *ValidationReport) AddCompatibility(compat CompatibilityReport) {
+	for _, check := range compat.Checks {
+		switch check.Status {
+		case CompatibilityFailed:
+			kind, ok := compatibilityBlockerKinds[check.Name]
+			if !ok {
+				kind = BlockerTarget
+			}
+			r.Block(kind, "%s", check.Message)
+		case CompatibilityWarning:
+			r.Warnings = append(r.Warnings, check.String())
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/migration"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ValidationSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(new(ValidationSuite))
+
+func (s *ValidationSuite) TestBlock(c *gc.C) {
+	var report migration.ValidationReport
+	c.Assert(report.Go(), jc.IsTrue)
+
+	report.Block(migration.BlockerAgentLag, "machine %s tools don't match model", "0")
+	c.Assert(report.Go(), jc.IsFalse)
+	c.Assert(report.Blocked(migration.BlockerAgentLag), jc.IsTrue)
+	c.Assert(report.Blocked(migration.BlockerVersionSkew), jc.IsFalse)
+	c.Assert(report.Blockers, jc.DeepEquals, []migration.ValidationBlocker{{
+		Kind:    migration.BlockerAgentLag,
+		Message: "machine 0 tools don't match model",
+	}})
+	c.Assert(report.Blockers[0].String(), gc.Equals, "agent-lag: machine 0 tools don't match model")
+}
+
+func (s *ValidationSuite) TestAddCompatibility(c *gc.C) {
+	var report migration.ValidationReport
+	report.AddCompatibility(migration.CompatibilityReport{Checks: []migration.CompatibilityCheck{
+		{Name: "version", Status: migration.CompatibilityFailed, Message: "too old"},
+		{Name: "cloud", Status: migration.CompatibilityOK},
+		{Name: "storage", Status: migration.CompatibilityWarning, Message: "cannot verify ebs"},
+		{Name: "facades", Status: migration.CompatibilityFailed, Message: "missing Payloads (for payloads)"},
+		{Name: "capacity", Status: migration.CompatibilityFailed, Message: "not enough disk"},
+		{Name: "other", Status: migration.CompatibilityFailed, Message: "nope"},
+	}})
+	c.Assert(report.Blockers, jc.DeepEquals, []migration.ValidationBlocker{
+		{Kind: migration.BlockerVersionSkew, Message: "too old"},
+		{Kind: migration.BlockerUnsupportedFeature, Message: "missing Payloads (for payloads)"},
+		{Kind: migration.BlockerTarget, Message: "not enough disk"},
+		{Kind: migration.BlockerTarget, Message: "nope"},
+	})
+	c.Assert(report.Warnings, jc.DeepEquals, []string{"storage: warning (cannot verify ebs)"})
+}
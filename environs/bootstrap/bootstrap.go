@@ -575,6 +575,23 @@ func bootstrapImageMetadata(
 		return []*imagemetadata.ImageMetadata{meta}, nil
 	}
 
+	// A model's own image lookup takes the place of simplestreams.
+	if lookup, ok := environs.ConfiguredImageLookup(environ.Config()); ok {
+		imageConstraint := imagemetadata.NewImageConstraint(simplestreams.LookupParams{
+			CloudSpec: region,
+			Stream:    environ.Config().ImageStream(),
+		})
+		lookupMetadata, err := lookup.LookupImages(imageConstraint)
+		if err != nil {
+			return nil, errors.Annotatef(err, "looking up images with %s", lookup.Description())
+		}
+		logger.Debugf("found %d image metadata with %s", len(lookupMetadata), lookup.Description())
+		if len(lookupMetadata) == 0 {
+			return nil, errors.Errorf("no images found with %s", lookup.Description())
+		}
+		return lookupMetadata, nil
+	}
+
 	// For providers that support making use of simplestreams
 	// image metadata, search public image metadata. We need
 	// to pass this onto Bootstrap for selecting images.
@@ -242,6 +242,46 @@ func (s *bootstrapSuite) TestBootstrapImage(c *gc.C) {
 	c.Assert(env.instanceConfig.Bootstrap.ControllerModelEnvironVersion, gc.Equals, 123)
 }
 
+func (s *bootstrapSuite) TestBootstrapImageLookup(c *gc.C) {
+	s.PatchValue(&series.MustHostSeries, func() string { return "precise" })
+	s.PatchValue(&arch.HostArch, func() string { return arch.AMD64 })
+
+	metadataDir, _ := createImageMetadata(c)
+	stor, err := filestorage.NewFileStorageWriter(metadataDir)
+	c.Assert(err, jc.ErrorIsNil)
+	envtesting.UploadFakeTools(c, stor, "released", "released")
+
+	env := bootstrapEnvironWithRegion{
+		newEnviron("foo", useDefaultKeys, map[string]interface{}{
+			"image-ids": "precise/amd64=img-custom",
+		}),
+		simplestreams.CloudSpec{
+			Region:   "nether",
+			Endpoint: "hearnoretheir",
+		},
+	}
+	s.setDummyStorage(c, env.bootstrapEnviron)
+
+	err = bootstrap.Bootstrap(envtesting.BootstrapContext(c), env, bootstrap.BootstrapParams{
+		ControllerConfig:     coretesting.FakeControllerConfig(),
+		AdminSecret:          "admin-secret",
+		CAPrivateKey:         coretesting.CAKey,
+		BootstrapSeries:      "precise",
+		BootstrapConstraints: constraints.MustParse("arch=amd64"),
+		MetadataDir:          metadataDir,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.bootstrapCount, gc.Equals, 1)
+	c.Assert(env.args.ImageMetadata, jc.DeepEquals, []*imagemetadata.ImageMetadata{{
+		Id:         "img-custom",
+		Arch:       "amd64",
+		Version:    "12.04",
+		RegionName: "nether",
+		Endpoint:   "hearnoretheir",
+		Stream:     "released",
+	}})
+}
+
 func (s *bootstrapSuite) TestBootstrapAddsArchFromImageToExistingProviderSupportedArches(c *gc.C) {
 	data := s.setupImageMetadata(c)
 	env := s.setupProviderWithSomeSupportedArches(c)
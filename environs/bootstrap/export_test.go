@@ -10,4 +10,7 @@ var (
 	FindBootstrapTools       = findBootstrapTools
 	FindPackagedTools        = findPackagedTools
 	GUIFetchMetadata         = &guiFetchMetadata
+	PreflightHTTPGet         = &preflightHTTPGet
+	CheckEgress              = checkEgress
+	CheckClock               = checkClock
 )
@@ -0,0 +1,277 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/clock"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/simplestreams"
+	envtools "github.com/juju/juju/environs/tools"
+)
+
+// PreflightStatus is the outcome of a single preflight check.
+type PreflightStatus string
+
+const (
+	// PreflightPassed indicates that the check found no problem.
+	PreflightPassed PreflightStatus = "passed"
+
+	// PreflightFailed indicates that bootstrap is expected to fail
+	// for the reason given in the check's message.
+	PreflightFailed PreflightStatus = "failed"
+
+	// PreflightSkipped indicates that the check could not be made.
+	PreflightSkipped PreflightStatus = "skipped"
+)
+
+// PreflightResult holds the outcome of a single preflight check.
+type PreflightResult struct {
+	// Check is the name of the check.
+	Check string
+
+	// Status is the outcome of the check.
+	Status PreflightStatus
+
+	// Message describes the outcome, and for a failure,
+	// what might be done about it.
+	Message string
+}
+
+// PreflightReport holds the outcomes of all preflight checks, in the
+// order in which they were made.
+type PreflightReport []PreflightResult
+
+// Passed reports whether none of the checks failed.
+func (r PreflightReport) Passed() bool {
+	for _, result := range r {
+		if result.Status == PreflightFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// MaxClockSkew is the greatest difference between the local clock and
+// that of the remote endpoints that the preflight checks allow.
+// Larger differences cause certificate and macaroon validation to
+// fail during bootstrap.
+const MaxClockSkew = 5 * time.Minute
+
+// PreflightParams holds the parameters for Preflight.
+type PreflightParams struct {
+	// ModelConfig and Cloud are as for PrepareParams.
+	ModelConfig map[string]interface{}
+	Cloud       environs.CloudSpec
+
+	// BootstrapSeries, BootstrapImage, BootstrapConstraints and
+	// AgentVersion are as for BootstrapParams.
+	BootstrapSeries      string
+	BootstrapImage       string
+	BootstrapConstraints constraints.Value
+	AgentVersion         *version.Number
+
+	// Clock is used to check the local time against that
+	// of the remote endpoints.
+	Clock clock.Clock
+}
+
+// preflightHTTPGet is used to check that the endpoints needed for
+// bootstrap can be reached. It is a variable so it can be patched
+// in tests.
+var preflightHTTPGet = (&http.Client{Timeout: 30 * time.Second}).Get
+
+// Preflight checks whether a controller could be bootstrapped with the
+// given parameters, without creating any resources in the cloud or
+// recording anything in the client store. An error is returned only if
+// the checks could not be run at all; problems found by the checks
+// are recorded in the returned report.
+func Preflight(ctx environs.BootstrapContext, args PreflightParams) (PreflightReport, error) {
+	if args.Clock == nil {
+		return nil, errors.NotValidf("nil Clock")
+	}
+	cloudType, ok := args.ModelConfig["type"].(string)
+	if !ok {
+		return nil, errors.NotFoundf("cloud type in base configuration")
+	}
+	p, err := environs.Provider(cloudType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := config.New(config.NoDefaults, args.ModelConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var report PreflightReport
+	env, err := openPreflightEnviron(p, args.Cloud, cfg)
+	if err != nil {
+		report = append(report, PreflightResult{
+			Check:   "credentials",
+			Status:  PreflightFailed,
+			Message: err.Error(),
+		})
+		return report, nil
+	}
+	ctx.Verbosef("Checking credentials")
+	report = append(report, checkCredentials(env, args.Cloud))
+	report = append(report, PreflightResult{
+		Check:   "quota",
+		Status:  PreflightSkipped,
+		Message: fmt.Sprintf("%q provider does not report quota", cloudType),
+	})
+	ctx.Verbosef("Checking network access")
+	egress, serverTime := checkEgress(env.Config())
+	report = append(report, egress)
+	report = append(report, checkClock(args.Clock.Now(), serverTime))
+
+	var seriesPtr, archPtr *string
+	if args.BootstrapSeries != "" {
+		seriesPtr = &args.BootstrapSeries
+	}
+	bootstrapArch := arch.HostArch()
+	if args.BootstrapConstraints.Arch != nil {
+		bootstrapArch = *args.BootstrapConstraints.Arch
+		archPtr = &bootstrapArch
+	}
+	ctx.Verbosef("Checking image metadata")
+	report = append(report, checkImages(env, seriesPtr, bootstrapArch, args.BootstrapImage))
+	ctx.Verbosef("Checking agent binaries")
+	report = append(report, checkAgentBinaries(env, args.AgentVersion, archPtr, seriesPtr))
+	return report, nil
+}
+
+func openPreflightEnviron(p environs.EnvironProvider, cloud environs.CloudSpec, cfg *config.Config) (environs.Environ, error) {
+	cfg, err := p.PrepareConfig(environs.PrepareConfigParams{cloud, cfg})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env, err := p.Open(environs.OpenParams{
+		Cloud:  cloud,
+		Config: cfg,
+	})
+	return env, errors.Trace(err)
+}
+
+// checkCredentials makes a read-only request of the cloud, to check
+// that it accepts the credential.
+func checkCredentials(env environs.Environ, cloud environs.CloudSpec) PreflightResult {
+	result := PreflightResult{Check: "credentials"}
+	if _, err := env.AllInstances(); err != nil {
+		result.Status = PreflightFailed
+		result.Message = fmt.Sprintf("listing instances on cloud %q: %v", cloud.Name, err)
+		return result
+	}
+	result.Status = PreflightPassed
+	result.Message = fmt.Sprintf("credential accepted by cloud %q", cloud.Name)
+	return result
+}
+
+// checkEgress checks that the agent and image metadata endpoints can
+// be reached, returning the time reported by the first that responded.
+func checkEgress(cfg *config.Config) (PreflightResult, time.Time) {
+	agentURL := envtools.DefaultBaseURL
+	if url, ok := cfg.AgentMetadataURL(); ok {
+		agentURL = url
+	}
+	imageURL := imagemetadata.DefaultUbuntuBaseURL
+	if url, ok := cfg.ImageMetadataURL(); ok {
+		imageURL = url
+	}
+
+	result := PreflightResult{Check: "network", Status: PreflightPassed}
+	var serverTime time.Time
+	for _, url := range []string{agentURL, imageURL} {
+		resp, err := preflightHTTPGet(url)
+		if err != nil {
+			result.Status = PreflightFailed
+			result.Message = fmt.Sprintf("cannot reach %s: %v", url, err)
+			return result, serverTime
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			result.Status = PreflightFailed
+			result.Message = fmt.Sprintf("%s responded with %q", url, resp.Status)
+			return result, serverTime
+		}
+		if serverTime.IsZero() {
+			serverTime, _ = http.ParseTime(resp.Header.Get("Date"))
+		}
+	}
+	result.Message = fmt.Sprintf("reached %s and %s", agentURL, imageURL)
+	return result, serverTime
+}
+
+// checkClock checks that the local time is close to that reported by
+// a remote server.
+func checkClock(now, serverTime time.Time) PreflightResult {
+	result := PreflightResult{Check: "clock"}
+	if serverTime.IsZero() {
+		result.Status = PreflightSkipped
+		result.Message = "no remote time available to compare against"
+		return result
+	}
+	skew := now.Sub(serverTime)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew > MaxClockSkew {
+		result.Status = PreflightFailed
+		result.Message = fmt.Sprintf("local clock differs from remote servers by %v; synchronise it before bootstrapping", skew-skew%time.Second)
+		return result
+	}
+	result.Status = PreflightPassed
+	result.Message = fmt.Sprintf("local clock is within %v of remote servers", MaxClockSkew)
+	return result
+}
+
+func checkImages(env environs.Environ, series *string, bootstrapArch, image string) PreflightResult {
+	result := PreflightResult{Check: "images"}
+	if _, ok := env.(simplestreams.HasRegion); !ok {
+		result.Status = PreflightSkipped
+		result.Message = fmt.Sprintf("%q provider does not use image metadata", env.Config().Type())
+		return result
+	}
+	var custom []*imagemetadata.ImageMetadata
+	metadata, err := bootstrapImageMetadata(env, series, bootstrapArch, image, &custom)
+	if err != nil {
+		result.Status = PreflightFailed
+		result.Message = err.Error()
+		return result
+	}
+	if len(metadata) == 0 {
+		result.Status = PreflightFailed
+		result.Message = fmt.Sprintf("no %s images found; use --metadata-source to supply image metadata", bootstrapArch)
+		return result
+	}
+	result.Status = PreflightPassed
+	result.Message = fmt.Sprintf("found %d images", len(metadata))
+	return result
+}
+
+func checkAgentBinaries(env environs.Environ, vers *version.Number, arch, series *string) PreflightResult {
+	result := PreflightResult{Check: "agent binaries"}
+	tools, err := findBootstrapTools(env, vers, arch, series)
+	if err != nil {
+		result.Status = PreflightFailed
+		result.Message = err.Error()
+		if errors.IsNotFound(err) {
+			result.Message += "; use --build-agent or --metadata-source to supply agent binaries"
+		}
+		return result
+	}
+	result.Status = PreflightPassed
+	result.Message = fmt.Sprintf("found %d agent binaries", len(tools))
+	return result
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type preflightSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&preflightSuite{})
+
+func (s *preflightSuite) patchHTTPGet(c *gc.C, date time.Time, errs map[string]error) *[]string {
+	var urls []string
+	s.PatchValue(bootstrap.PreflightHTTPGet, func(url string) (*http.Response, error) {
+		urls = append(urls, url)
+		if err := errs[url]; err != nil {
+			return nil, err
+		}
+		header := make(http.Header)
+		header.Set("Date", date.UTC().Format(http.TimeFormat))
+		return &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	})
+	return &urls
+}
+
+func (s *preflightSuite) TestCheckEgress(c *gc.C) {
+	date := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	urls := s.patchHTTPGet(c, date, nil)
+	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"agent-metadata-url": "https://agents.example.com",
+		"image-metadata-url": "https://images.example.com",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, serverTime := bootstrap.CheckEgress(cfg)
+	c.Assert(result, jc.DeepEquals, bootstrap.PreflightResult{
+		Check:   "network",
+		Status:  bootstrap.PreflightPassed,
+		Message: "reached https://agents.example.com and https://images.example.com",
+	})
+	c.Assert(serverTime.Equal(date), jc.IsTrue)
+	c.Assert(*urls, jc.DeepEquals, []string{"https://agents.example.com", "https://images.example.com"})
+}
+
+func (s *preflightSuite) TestCheckEgressFailure(c *gc.C) {
+	s.patchHTTPGet(c, time.Now(), map[string]error{
+		"https://images.example.com": errors.New("no route to host"),
+	})
+	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"image-metadata-url": "https://images.example.com",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, _ := bootstrap.CheckEgress(cfg)
+	c.Assert(result.Status, gc.Equals, bootstrap.PreflightFailed)
+	c.Assert(result.Message, gc.Equals, "cannot reach https://images.example.com: no route to host")
+}
+
+func (s *preflightSuite) TestCheckClock(c *gc.C) {
+	now := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	result := bootstrap.CheckClock(now, now.Add(bootstrap.MaxClockSkew))
+	c.Assert(result.Status, gc.Equals, bootstrap.PreflightPassed)
+
+	result = bootstrap.CheckClock(now, now.Add(-time.Hour))
+	c.Assert(result.Status, gc.Equals, bootstrap.PreflightFailed)
+	c.Assert(result.Message, gc.Equals, "local clock differs from remote servers by 1h0m0s; synchronise it before bootstrapping")
+
+	result = bootstrap.CheckClock(now, time.Time{})
+	c.Assert(result.Status, gc.Equals, bootstrap.PreflightSkipped)
+}
+
+func (s *preflightSuite) TestReportPassed(c *gc.C) {
+	report := bootstrap.PreflightReport{
+		{Check: "a", Status: bootstrap.PreflightPassed},
+		{Check: "b", Status: bootstrap.PreflightSkipped},
+	}
+	c.Assert(report.Passed(), jc.IsTrue)
+	report = append(report, bootstrap.PreflightResult{Check: "c", Status: bootstrap.PreflightFailed})
+	c.Assert(report.Passed(), jc.IsFalse)
+}
@@ -4,8 +4,11 @@
 package environs
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/instance"
@@ -40,10 +43,23 @@ type StartInstanceParams struct {
 	// instance should be started.
 	Placement string
 
+	// Affinity, if non-nil, describes where the instance should be
+	// started relative to the instances of another application.
+	// Providers with a native mechanism for this, such as placement
+	// groups or anti-affinity rules, may use it; otherwise the
+	// provisioner approximates it by choosing AvailabilityZone.
+	Affinity *instance.Affinity
+
 	// AvailabilityZone, provides the name of the availability
 	// zone required to start the instance.
 	AvailabilityZone string
 
+	// InstanceLifecycle describes whether the instance should be
+	// started on-demand, or as a cheaper instance that the cloud may
+	// reclaim. It is derived from the instance-lifecycle and max-price
+	// constraints; the zero value requests an on-demand instance.
+	InstanceLifecycle InstanceLifecycle
+
 	// Volumes is a set of parameters for volumes that should be created.
 	//
 	// StartInstance need not check the value of the Attachment field,
@@ -79,6 +95,11 @@ type StartInstanceParams struct {
 	// that may be used to start this instance.
 	ImageMetadata []*imagemetadata.ImageMetadata
 
+	// CharmLXDProfiles holds the LXD profiles declared by the charms of
+	// the units to be deployed to the instance, keyed by profile name.
+	// It is used only by providers that start LXD containers.
+	CharmLXDProfiles map[string]*lxdprofile.Profile
+
 	// CleanupCallback is a callback to be used to clean up any residual
 	// status-reporting output from StatusCallback.
 	CleanupCallback func(info string) error
@@ -142,3 +163,126 @@ type InstanceBroker interface {
 	// correct network configuration.
 	MaintainInstance(args StartInstanceParams) error
 }
+
+// InstancePowerManager is an interface that may be implemented by an
+// InstanceBroker that can stop, start and reboot instances without
+// destroying them.
+type InstancePowerManager interface {
+	// PowerOffInstances stops the instances with the specified IDs,
+	// retaining them and their disks so that they may be started
+	// again later.
+	PowerOffInstances(...instance.Id) error
+
+	// PowerOnInstances starts the previously stopped instances with
+	// the specified IDs.
+	PowerOnInstances(...instance.Id) error
+
+	// RebootInstances reboots the instances with the specified IDs.
+	RebootInstances(...instance.Id) error
+}
+
+// CharmProfileManager is an interface that may be implemented by an
+// InstanceBroker whose instances are LXD containers, to which the LXD
+// profiles declared by charms are applied.
+type CharmProfileManager interface {
+	// ReplaceCharmProfile creates the named charm profile if it does
+	// not already exist, and applies it to the instance with the given
+	// ID in place of the profile named oldName. Either name may be
+	// empty, to only apply or only remove a profile.
+	ReplaceCharmProfile(id instance.Id, oldName, newName string, newProfile *lxdprofile.Profile) error
+}
+
+// InstanceLifecycle describes the lifecycle with which an instance
+// is to be started.
+type InstanceLifecycle struct {
+	// Type is the lifecycle of the instance. If empty, the instance
+	// is started on-demand.
+	Type instance.Lifecycle
+
+	// MaxPrice, if non-empty, is the highest hourly price that may
+	// be paid for a spot instance. If empty, the cloud's on-demand
+	// price is the limit.
+	MaxPrice string
+}
+
+// Reclaimable reports whether the instance may be reclaimed by the
+// cloud before it is stopped.
+func (l InstanceLifecycle) Reclaimable() bool {
+	return l.Type.Reclaimable()
+}
+
+// NewInstanceLifecycle returns the InstanceLifecycle described by
+// the given constraints.
+func NewInstanceLifecycle(cons constraints.Value) (InstanceLifecycle, error) {
+	var lifecycle InstanceLifecycle
+	if cons.HasInstanceLifecycle() {
+		lifecycleType, err := instance.ParseLifecycle(*cons.InstanceLifecycle)
+		if err != nil {
+			return InstanceLifecycle{}, errors.Trace(err)
+		}
+		lifecycle.Type = lifecycleType
+	}
+	if cons.HasMaxPrice() {
+		if lifecycle.Type != instance.Spot {
+			return InstanceLifecycle{}, errors.NotValidf("max-price without instance-lifecycle=spot")
+		}
+		lifecycle.MaxPrice = *cons.MaxPrice
+	}
+	return lifecycle, nil
+}
+
+// InstanceReclamationNotifier is an interface that may be implemented
+// by an InstanceBroker that can start instances which the cloud may
+// reclaim, such as spot or preemptible instances.
+type InstanceReclamationNotifier interface {
+	// ReclaimingInstances returns the IDs of the instances started
+	// by the broker that the cloud has given notice it is reclaiming,
+	// or has already reclaimed.
+	ReclaimingInstances() ([]instance.Id, error)
+}
+
+// RebuildInstanceParams holds parameters for the
+// InstanceRebuilder.RebuildInstance method.
+type RebuildInstanceParams struct {
+	// InstanceId is the ID of the instance to rebuild.
+	InstanceId instance.Id
+
+	// InstanceConfig describes the machine's configuration after the
+	// rebuild, including the series whose image is to be used.
+	InstanceConfig *instancecfg.InstanceConfig
+
+	// Volumes holds the parameters of the Juju-managed volumes
+	// attached to the instance. They are detached before the instance
+	// is rebuilt, and reattached once it is running again.
+	Volumes []storage.VolumeAttachmentParams
+}
+
+// InstanceRebuilder is an interface that may be implemented by an
+// InstanceBroker that can reprovision an instance with a new image
+// in place, retaining its identity and its attached volumes.
+type InstanceRebuilder interface {
+	// RebuildInstance reprovisions the instance with the specified
+	// parameters.
+	RebuildInstance(args RebuildInstanceParams) error
+}
+
+// BatchStartInstanceResult holds the outcome of starting one of the
+// instances requested in a call to InstanceBatchStarter.BatchStartInstance.
+type BatchStartInstanceResult struct {
+	// Result holds the result of starting the instance, if it was
+	// started successfully.
+	Result *StartInstanceResult
+
+	// Error holds the reason the instance could not be started, if any.
+	Error error
+}
+
+// InstanceBatchStarter is an interface that may be implemented by an
+// InstanceBroker that can start several instances with a single
+// request to the cloud.
+type InstanceBatchStarter interface {
+	// BatchStartInstance starts the instances described by the given
+	// parameters, returning a result for each in the same order. An
+	// error is returned only if the request as a whole failed.
+	BatchStartInstance(args []StartInstanceParams) ([]BatchStartInstanceResult, error)
+}
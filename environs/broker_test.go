@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/testing"
+)
+
+type BrokerSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&BrokerSuite{})
+
+func (s *BrokerSuite) TestNewInstanceLifecycle(c *gc.C) {
+	for i, test := range []struct {
+		cons     string
+		expected environs.InstanceLifecycle
+	}{{
+		cons:     "mem=4G",
+		expected: environs.InstanceLifecycle{},
+	}, {
+		cons:     "instance-lifecycle=on-demand",
+		expected: environs.InstanceLifecycle{Type: instance.OnDemand},
+	}, {
+		cons:     "instance-lifecycle=preemptible",
+		expected: environs.InstanceLifecycle{Type: instance.Preemptible},
+	}, {
+		cons:     "instance-lifecycle=spot max-price=0.05",
+		expected: environs.InstanceLifecycle{Type: instance.Spot, MaxPrice: "0.05"},
+	}} {
+		c.Logf("test %d: %s", i, test.cons)
+		lifecycle, err := environs.NewInstanceLifecycle(constraints.MustParse(test.cons))
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(lifecycle, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *BrokerSuite) TestNewInstanceLifecycleMaxPriceWithoutSpot(c *gc.C) {
+	_, err := environs.NewInstanceLifecycle(constraints.MustParse("instance-lifecycle=preemptible max-price=0.05"))
+	c.Assert(err, gc.ErrorMatches, "max-price without instance-lifecycle=spot not valid")
+	_, err = environs.NewInstanceLifecycle(constraints.MustParse("max-price=0.05"))
+	c.Assert(err, gc.ErrorMatches, "max-price without instance-lifecycle=spot not valid")
+}
+
+func (s *BrokerSuite) TestInstanceLifecycleReclaimable(c *gc.C) {
+	c.Assert(environs.InstanceLifecycle{}.Reclaimable(), jc.IsFalse)
+	c.Assert(environs.InstanceLifecycle{Type: instance.Spot}.Reclaimable(), jc.IsTrue)
+}
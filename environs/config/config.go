@@ -6,6 +6,7 @@ package config
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
+	"github.com/juju/utils/arch"
 	"github.com/juju/utils/proxy"
 	"github.com/juju/utils/series"
 	"github.com/juju/version"
@@ -24,6 +26,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/sinkconfig"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
 )
@@ -78,6 +81,15 @@ const (
 	// AgentMetadataURLKey stores the key for this setting.
 	AgentMetadataURLKey = "agent-metadata-url"
 
+	// ImageLookupURLKey is the key for the URL of an HTTPS service
+	// used to look up image ids in place of simplestreams.
+	ImageLookupURLKey = "image-lookup-url"
+
+	// ImageIdsKey is an optional list or space-separated string of
+	// <series>/<arch>=<image id> pairs, used to look up image ids in
+	// place of simplestreams.
+	ImageIdsKey = "image-ids"
+
 	// HTTPProxyKey stores the key for this setting.
 	HTTPProxyKey = "http-proxy"
 
@@ -138,6 +150,10 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdSinks holds the YAML configuration of the log sinks,
+	// other than the syslog server, to which logs are forwarded.
+	LogFwdSinks = "logforward-sinks"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -176,6 +192,24 @@ const (
 	// FanConfig defines the configuration for FAN network running in the model.
 	FanConfig = "fan-config"
 
+	// InstancePollInterval is how often the instance poller checks the
+	// addresses and status of an instance once it is running.
+	InstancePollInterval = "instance-poll-interval"
+
+	// InstancePollShortInterval is how often the instance poller first
+	// checks the addresses and status of a new instance. The interval
+	// is doubled after each check, up to InstancePollInterval.
+	InstancePollShortInterval = "instance-poll-short-interval"
+
+	// HookTimeout is the maximum time a charm hook may run before the
+	// uniter kills it. Charms may override it by declaring hook-timeout
+	// in their metadata. Zero means hooks are never killed.
+	HookTimeout = "hook-timeout"
+
+	// LoggingOutputFormat is the format in which Juju agents write
+	// their logs: "text" (the default) or "json".
+	LoggingOutputFormat = "logging-output-format"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -338,6 +372,17 @@ const (
 
 	DefaultActionResultsAge = "336h" // 2 weeks
 
+	// DefaultInstancePollInterval is the default value for InstancePollInterval.
+	DefaultInstancePollInterval = "15m"
+
+	// DefaultInstancePollShortInterval is the default value for
+	// InstancePollShortInterval.
+	DefaultInstancePollShortInterval = "1s"
+
+	// DefaultHookTimeout is the default value for HookTimeout, which
+	// lets hooks run for as long as they need.
+	DefaultHookTimeout = "0s"
+
 	DefaultActionResultsSize = "5G"
 )
 
@@ -386,6 +431,8 @@ var defaultConfigValues = map[string]interface{}{
 	// Image and agent streams and URLs.
 	"image-stream":       "released",
 	"image-metadata-url": "",
+	ImageLookupURLKey:    "",
+	ImageIdsKey:          "",
 	AgentStreamKey:       "released",
 	AgentMetadataURLKey:  "",
 
@@ -457,9 +504,10 @@ func ProcessDeprecatedAttributes(attrs map[string]interface{}) map[string]interf
 func CoerceForStorage(attrs map[string]interface{}) map[string]interface{} {
 	coercedAttrs := make(map[string]interface{}, len(attrs))
 	for attrName, attrValue := range attrs {
-		if attrName == ResourceTagsKey {
-			// Resource Tags are specified by the user as a string but transformed
-			// to a map when config is parsed. We want to store as a string.
+		if attrName == ResourceTagsKey || attrName == ImageIdsKey {
+			// Resource Tags and image ids are specified by the user as a string
+			// but transformed to a map when config is parsed. We want to store
+			// as a string.
 			var tagsSlice []string
 			if tags, ok := attrValue.(map[string]string); ok {
 				for resKey, resValue := range tags {
@@ -517,6 +565,16 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[LogFwdSinks].(string); ok && v != "" {
+		lfCfg, _, err := cfg.LogForwardConfig()
+		if err != nil {
+			return errors.Annotate(err, "invalid log forwarding sinks")
+		}
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid log forwarding sinks")
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -526,6 +584,10 @@ func Validate(cfg, old *Config) error {
 		return errors.Annotate(err, "validating resource tags")
 	}
 
+	if err := validateImageLookup(cfg); err != nil {
+		return errors.Trace(err)
+	}
+
 	if v, ok := cfg.defined[MaxStatusHistoryAge].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid max status history age in model configuration")
@@ -563,6 +625,23 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	pollInterval, err := parsePollInterval(cfg.defined, InstancePollInterval, DefaultInstancePollInterval)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	shortPollInterval, err := parsePollInterval(cfg.defined, InstancePollShortInterval, DefaultInstancePollShortInterval)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if shortPollInterval > pollInterval {
+		return errors.Errorf("%s %v cannot be greater than %s %v",
+			InstancePollShortInterval, shortPollInterval, InstancePollInterval, pollInterval)
+	}
+
+	if _, err := parseHookTimeout(cfg.defined); err != nil {
+		return errors.Trace(err)
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -834,6 +913,37 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogForwardConfig returns the log forwarding config, holding the
+// syslog server configured by the syslog-* settings, if any, and the
+// sinks configured by logforward-sinks. It returns false if no log
+// forwarding is configured.
+func (c *Config) LogForwardConfig() (*sinkconfig.Config, bool, error) {
+	var lfCfg sinkconfig.Config
+	syslogCfg, partial := c.LogFwdSyslog()
+	if partial {
+		lfCfg.Enabled = syslogCfg.Enabled
+		if syslogCfg.Host != "" {
+			lfCfg.Sinks = append(lfCfg.Sinks, sinkconfig.Sink{
+				Name:   sinkconfig.DefaultSyslogName,
+				Type:   sinkconfig.TypeSyslog,
+				Syslog: syslogCfg,
+			})
+		}
+	}
+	if v := c.asString(LogFwdSinks); v != "" {
+		partial = true
+		sinks, err := sinkconfig.Parse(v)
+		if err != nil {
+			return nil, false, errors.Trace(err)
+		}
+		lfCfg.Sinks = append(lfCfg.Sinks, sinks...)
+	}
+	if !partial {
+		return nil, false, nil
+	}
+	return &lfCfg, true, nil
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -873,6 +983,51 @@ func (c *Config) ImageMetadataURL() (string, bool) {
 	return "", false
 }
 
+// ImageLookupURL returns the URL of the service used to look up image
+// ids in place of simplestreams, and whether it has been set.
+func (c *Config) ImageLookupURL() (string, bool) {
+	if url, ok := c.defined[ImageLookupURLKey].(string); ok && url != "" {
+		return url, true
+	}
+	return "", false
+}
+
+// ImageIds returns the image ids used in place of simplestreams,
+// keyed by "<series>/<arch>", and whether any have been set.
+func (c *Config) ImageIds() (map[string]string, bool) {
+	ids, ok := c.defined[ImageIdsKey].(map[string]string)
+	return ids, ok && len(ids) > 0
+}
+
+// validateImageLookup checks the settings used to look up image ids
+// in place of simplestreams.
+func validateImageLookup(cfg *Config) error {
+	lookupURL, hasURL := cfg.ImageLookupURL()
+	ids, hasIds := cfg.ImageIds()
+	if hasURL && hasIds {
+		return errors.Errorf("cannot specify both %s and %s", ImageLookupURLKey, ImageIdsKey)
+	}
+	if hasURL {
+		u, err := url.Parse(lookupURL)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", ImageLookupURLKey)
+		}
+		if u.Scheme != "https" || u.Host == "" {
+			return errors.Errorf("invalid %s %q: expected an https URL", ImageLookupURLKey, lookupURL)
+		}
+	}
+	for key := range ids {
+		parts := strings.Split(key, "/")
+		if len(parts) != 2 || !arch.IsSupportedArch(parts[1]) {
+			return errors.Errorf("invalid %s key %q: expected <series>/<arch>", ImageIdsKey, key)
+		}
+		if _, err := series.SeriesVersion(parts[0]); err != nil {
+			return errors.Annotatef(err, "invalid %s key %q", ImageIdsKey, key)
+		}
+	}
+	return nil
+}
+
 // Development returns whether the environment is in development mode.
 func (c *Config) Development() bool {
 	value, _ := c.defined["development"].(bool)
@@ -910,6 +1065,15 @@ func (c *Config) LoggingConfig() string {
 	return c.asString("logging-config")
 }
 
+// LoggingOutputFormat returns the format in which agents write their
+// logs, "text" or "json".
+func (c *Config) LoggingOutputFormat() string {
+	if v := c.asString(LoggingOutputFormat); v != "" {
+		return v
+	}
+	return "text"
+}
+
 // AutomaticallyRetryHooks returns whether we should automatically retry hooks.
 // By default this should be true.
 func (c *Config) AutomaticallyRetryHooks() bool {
@@ -1073,6 +1237,64 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// InstancePollInterval is how often the instance poller checks the
+// addresses and status of a running instance.
+func (c *Config) InstancePollInterval() time.Duration {
+	// Value has already been validated.
+	val, _ := parsePollInterval(c.defined, InstancePollInterval, DefaultInstancePollInterval)
+	return val
+}
+
+// InstancePollShortInterval is how often the instance poller first
+// checks the addresses and status of a new instance.
+func (c *Config) InstancePollShortInterval() time.Duration {
+	// Value has already been validated.
+	val, _ := parsePollInterval(c.defined, InstancePollShortInterval, DefaultInstancePollShortInterval)
+	return val
+}
+
+// parsePollInterval returns the instance poll interval held in attrs
+// under the given key, or the default if it is not set.
+func parsePollInterval(attrs map[string]interface{}, key, defaultValue string) (time.Duration, error) {
+	raw, _ := attrs[key].(string)
+	if raw == "" {
+		raw = defaultValue
+	}
+	val, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, errors.Annotatef(err, "invalid %s in model configuration", key)
+	}
+	if val <= 0 {
+		return 0, errors.Errorf("%s %v must be positive", key, val)
+	}
+	return val, nil
+}
+
+// HookTimeout is the maximum time a charm hook may run before it is
+// killed. Zero means hooks are never killed.
+func (c *Config) HookTimeout() time.Duration {
+	// Value has already been validated.
+	val, _ := parseHookTimeout(c.defined)
+	return val
+}
+
+// parseHookTimeout returns the hook timeout held in attrs, or the
+// default if it is not set.
+func parseHookTimeout(attrs map[string]interface{}) (time.Duration, error) {
+	raw, _ := attrs[HookTimeout].(string)
+	if raw == "" {
+		raw = DefaultHookTimeout
+	}
+	val, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, errors.Annotatef(err, "invalid %s in model configuration", HookTimeout)
+	}
+	if val < 0 {
+		return 0, errors.Errorf("%s %v cannot be negative", HookTimeout, val)
+	}
+	return val, nil
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1165,6 +1387,7 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdSinks:            schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1190,6 +1413,8 @@ var alwaysOptional = schema.Defaults{
 	"enable-os-upgrade":          schema.Omit,
 	"image-stream":               schema.Omit,
 	"image-metadata-url":         schema.Omit,
+	ImageLookupURLKey:            schema.Omit,
+	ImageIdsKey:                  schema.Omit,
 	AgentMetadataURLKey:          schema.Omit,
 	"default-series":             schema.Omit,
 	"development":                schema.Omit,
@@ -1209,6 +1434,10 @@ var alwaysOptional = schema.Defaults{
 	UpdateStatusHookInterval:     schema.Omit,
 	EgressSubnets:                schema.Omit,
 	FanConfig:                    schema.Omit,
+	InstancePollInterval:         schema.Omit,
+	InstancePollShortInterval:    schema.Omit,
+	HookTimeout:                  schema.Omit,
+	LoggingOutputFormat:          schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1479,6 +1708,16 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	ImageIdsKey: {
+		Description: "Image ids to use in place of simplestreams, as space-separated <series>/<arch>=<image id> pairs",
+		Type:        environschema.Tattrs,
+		Group:       environschema.EnvironGroup,
+	},
+	ImageLookupURLKey: {
+		Description: "The HTTPS URL of a service used to look up image ids in place of simplestreams",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"image-stream": {
 		Description: `The simplestreams stream used to identify which image ids to search when starting an instance.`,
 		Type:        environschema.Tstring,
@@ -1544,6 +1783,11 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdSinks: {
+		Description: `Additional log forwarding targets in YAML format, as a map of sink name to its type (syslog, webhook or kafka), connection settings and optional level, modules and entities filters.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1634,4 +1878,25 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	InstancePollInterval: {
+		Description: "How often to check the addresses and status of running instances, in human-readable time format (default 15m)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	InstancePollShortInterval: {
+		Description: "How often to first check the addresses and status of new instances, in human-readable time format (default 1s)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	HookTimeout: {
+		Description: "The maximum time a charm hook may run before it is killed, in human-readable time format (default 0s, meaning no limit)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LoggingOutputFormat: {
+		Description: `The format in which Juju agents write their logs: "text", or "json" for one structured JSON record per line (default text)`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"text", "json"},
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -23,6 +23,10 @@ import (
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/webhook"
 	"github.com/juju/juju/testing"
 )
 
@@ -461,6 +465,40 @@ var configTests = []configTest{
 			"resource-tags": []string{"a"},
 		}),
 		err: `resource-tags: expected "key=value", got "a"`,
+	}, {
+		about:       "Image lookup URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"image-lookup-url": "https://images.example.com/lookup",
+		}),
+	}, {
+		about:       "Image lookup URL not https",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"image-lookup-url": "http://images.example.com/lookup",
+		}),
+		err: `invalid image-lookup-url "http://images.example.com/lookup": expected an https URL`,
+	}, {
+		about:       "Image ids",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"image-ids": "xenial/amd64=ami-1 trusty/arm64=ami-2",
+		}),
+	}, {
+		about:       "Image ids with invalid key",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"image-ids": "xenial=ami-1",
+		}),
+		err: `invalid image-ids key "xenial": expected <series>/<arch>`,
+	}, {
+		about:       "Image ids and image lookup URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"image-ids":        "xenial/amd64=ami-1",
+			"image-lookup-url": "https://images.example.com/lookup",
+		}),
+		err: `cannot specify both image-lookup-url and image-ids`,
 	}, {
 		about:       "Invalid syslog ca cert format",
 		useDefaults: config.UseDefaults,
@@ -520,6 +558,33 @@ var configTests = []configTest{
 			"syslog-client-key":  serverKey2,
 		}),
 		err: `invalid syslog forwarding config: validating TLS config: parsing client key pair: (crypto/)?tls: private key does not match public key`,
+	}, {
+		about:       "Log forwarding sinks",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-sinks":   "audit:\n  type: webhook\n  url: https://logs.example.com/juju\n  level: WARNING\n",
+		}),
+	}, {
+		about:       "Invalid log forwarding sink",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-sinks":   "audit:\n  type: kafka\n  url: https://kafka-rest.example.com\n",
+		}),
+		err: `invalid log forwarding sinks: sink "audit": invalid kafka config: Topic "" not valid`,
+	}, {
+		about:       "Log forwarding sink clashes with syslog",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"syslog-host":        "10.0.0.1:12345",
+			"syslog-ca-cert":     testing.CACert,
+			"syslog-client-cert": testing.ServerCert,
+			"syslog-client-key":  testing.ServerKey,
+			"logforward-sinks":   "juju-log-forward:\n  type: webhook\n  url: https://logs.example.com/juju\n",
+		}),
+		err: `invalid log forwarding sinks: duplicate sink "juju-log-forward" not valid`,
 	}, {
 		about:       "net-bond-reconfigure-delay value",
 		useDefaults: config.UseDefaults,
@@ -550,6 +615,49 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Invalid instance-poll-interval",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"instance-poll-interval": "soon",
+		}),
+		err: `invalid instance-poll-interval in model configuration: time: invalid duration "?soon"?`,
+	}, {
+		about:       "Negative instance-poll-short-interval",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"instance-poll-short-interval": "-1s",
+		}),
+		err: `instance-poll-short-interval -1s must be positive`,
+	}, {
+		about:       "instance-poll-short-interval greater than instance-poll-interval",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"instance-poll-interval":       "1m",
+			"instance-poll-short-interval": "2m",
+		}),
+		err: `instance-poll-short-interval 2m0s cannot be greater than instance-poll-interval 1m0s`,
+	}, {
+		about:       "Invalid hook-timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "forever",
+		}),
+		err: `invalid hook-timeout in model configuration: time: invalid duration "?forever"?`,
+	}, {
+		about:       "Negative hook-timeout",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"hook-timeout": "-1m",
+		}),
+		err: `hook-timeout -1m0s cannot be negative`,
+	}, {
+		about:       "Invalid logging-output-format",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logging-output-format": "xml",
+		}),
+		err: `logging-output-format: expected one of \[text json\], got "xml"`,
 	},
 }
 
@@ -702,6 +810,25 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	}
 	c.Assert(agentStreamValue, gc.Equals, expectedAgentStreamAttr)
 
+	lookupURL, urlPresent := cfg.ImageLookupURL()
+	if v, _ := test.attrs["image-lookup-url"].(string); v != "" {
+		c.Assert(lookupURL, gc.Equals, v)
+		c.Assert(urlPresent, jc.IsTrue)
+	} else {
+		c.Assert(urlPresent, jc.IsFalse)
+	}
+
+	imageIds, idsPresent := cfg.ImageIds()
+	if v, _ := test.attrs["image-ids"].(string); v != "" {
+		c.Assert(imageIds, jc.DeepEquals, map[string]string{
+			"xenial/amd64": "ami-1",
+			"trusty/arm64": "ami-2",
+		})
+		c.Assert(idsPresent, jc.IsTrue)
+	} else {
+		c.Assert(idsPresent, jc.IsFalse)
+	}
+
 	resourceTags, cfgHasResourceTags := cfg.ResourceTags()
 	c.Assert(cfgHasResourceTags, jc.IsTrue)
 	if tags, ok := test.attrs["resource-tags"]; ok {
@@ -1124,6 +1251,93 @@ func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigValue(c *gc.C) {
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 30*time.Minute)
 }
 
+func (s *ConfigSuite) TestInstancePollIntervalConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.InstancePollInterval(), gc.Equals, 15*time.Minute)
+	c.Assert(cfg.InstancePollShortInterval(), gc.Equals, time.Second)
+}
+
+func (s *ConfigSuite) TestInstancePollIntervalConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"instance-poll-interval":       "1h",
+		"instance-poll-short-interval": "5s",
+	})
+	c.Assert(cfg.InstancePollInterval(), gc.Equals, time.Hour)
+	c.Assert(cfg.InstancePollShortInterval(), gc.Equals, 5*time.Second)
+}
+
+func (s *ConfigSuite) TestHookTimeoutConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookTimeout(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestHookTimeoutConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"hook-timeout": "30m",
+	})
+	c.Assert(cfg.HookTimeout(), gc.Equals, 30*time.Minute)
+}
+
+func (s *ConfigSuite) TestLoggingOutputFormatDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.LoggingOutputFormat(), gc.Equals, "text")
+}
+
+func (s *ConfigSuite) TestLoggingOutputFormatValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logging-output-format": "json",
+	})
+	c.Assert(cfg.LoggingOutputFormat(), gc.Equals, "json")
+}
+
+func (s *ConfigSuite) TestLogForwardConfigNotSet(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	lfCfg, ok, err := cfg.LogForwardConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ok, jc.IsFalse)
+	c.Check(lfCfg, gc.IsNil)
+}
+
+func (s *ConfigSuite) TestLogForwardConfig(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled": true,
+		"syslog-host":        "10.0.0.1:12345",
+		"syslog-ca-cert":     testing.CACert,
+		"syslog-client-cert": testing.ServerCert,
+		"syslog-client-key":  testing.ServerKey,
+		"logforward-sinks": `
+audit:
+  type: webhook
+  url: https://logs.example.com/juju
+  entities: [machine-0]
+`,
+	})
+	lfCfg, ok, err := cfg.LogForwardConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(lfCfg, jc.DeepEquals, &sinkconfig.Config{
+		Enabled: true,
+		Sinks: []sinkconfig.Sink{{
+			Name: sinkconfig.DefaultSyslogName,
+			Type: sinkconfig.TypeSyslog,
+			Syslog: &syslog.RawConfig{
+				Enabled:    true,
+				Host:       "10.0.0.1:12345",
+				CACert:     testing.CACert,
+				ClientCert: testing.ServerCert,
+				ClientKey:  testing.ServerKey,
+			},
+		}, {
+			Name:   "audit",
+			Type:   sinkconfig.TypeWebhook,
+			Filter: logfwd.Filter{Entities: []string{"machine-0"}},
+			Webhook: &webhook.RawConfig{
+				URL: "https://logs.example.com/juju",
+			},
+		}},
+	})
+}
+
 func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"egress-subnets": "10.0.0.1/32, 192.168.1.1/16",
@@ -19,6 +19,11 @@ const (
 	// come from those associated with the controller.
 	JujuControllerSource = "controller"
 
+	// JujuCloudSource is used to label model config attributes that
+	// come from those associated with the cloud where the model is
+	// running.
+	JujuCloudSource = "cloud"
+
 	// JujuRegionSource is used to label model config attributes that come from
 	// those associated with the region where the model is
 	// running.
@@ -71,9 +76,11 @@ type ModelDefaultAttributes map[string]AttributeDefaultValues
 // AttributeDefaultValues represents all the default values at each level for a given
 // setting.
 type AttributeDefaultValues struct {
-	// Default and Controller represent the values as set at those levels.
+	// Default, Controller and Cloud represent the values as set at
+	// those levels.
 	Default    interface{} `json:"default,omitempty" yaml:"default,omitempty"`
 	Controller interface{} `json:"controller,omitempty" yaml:"controller,omitempty"`
+	Cloud      interface{} `json:"cloud,omitempty" yaml:"cloud,omitempty"`
 	// Regions is a slice of Region representing the values as set in each
 	// region.
 	Regions []RegionDefaultValue `json:"regions,omitempty" yaml:"regions,omitempty"`
@@ -13,3 +13,28 @@ var (
 	ErrPartialInstances       = errors.New("only some instances were found")
 	ErrAvailabilityZoneFailed = errors.New("failed to start instance in provided availability zone")
 )
+
+// retryableError marks an error returned by a provider as transient.
+type retryableError struct {
+	error
+}
+
+// NewRetryableError marks err as having been caused by a transient
+// failure of the cloud, such as the cloud API being unavailable or
+// rate limiting requests, so that repeating the operation later may
+// succeed. Workers count only such errors against their shared retry
+// budgets; other errors, such as invalid requests, say nothing about
+// the health of the cloud.
+func NewRetryableError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err}
+}
+
+// IsRetryableError reports whether err was marked with
+// NewRetryableError.
+func IsRetryableError(err error) bool {
+	_, ok := errors.Cause(err).(*retryableError)
+	return ok
+}
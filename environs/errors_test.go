@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type errorsSuite struct{}
+
+var _ = gc.Suite(&errorsSuite{})
+
+func (s *errorsSuite) TestRetryableError(c *gc.C) {
+	err := environs.NewRetryableError(errors.New("rate limited"))
+	c.Assert(err, gc.ErrorMatches, "rate limited")
+	c.Assert(err, jc.Satisfies, environs.IsRetryableError)
+	c.Assert(errors.Annotate(err, "starting instance"), jc.Satisfies, environs.IsRetryableError)
+	c.Assert(errors.New("rate limited"), gc.Not(jc.Satisfies), environs.IsRetryableError)
+	c.Assert(environs.NewRetryableError(nil), gc.IsNil)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
 )
@@ -114,6 +115,24 @@ func ImageMetadataSources(env Environ) ([]simplestreams.DataSource, error) {
 	return sources, nil
 }
 
+// ConfiguredImageLookup returns the ImageLookup configured for the
+// model with the image-lookup-url or image-ids settings, and whether
+// there is one. A configured lookup is used in place of the image
+// metadata sources.
+func ConfiguredImageLookup(cfg *config.Config) (imagemetadata.ImageLookup, bool) {
+	if lookupURL, ok := cfg.ImageLookupURL(); ok {
+		verify := utils.VerifySSLHostnames
+		if !cfg.SSLHostnameVerification() {
+			verify = utils.NoVerifySSLHostnames
+		}
+		return imagemetadata.NewHTTPImageLookup(lookupURL, verify), true
+	}
+	if ids, ok := cfg.ImageIds(); ok {
+		return imagemetadata.NewStaticImageLookup(ids), true
+	}
+	return nil, false
+}
+
 // environmentDataSources returns simplestreams datasources for the environment
 // by calling the functions registered in RegisterImageDataSourceFunc.
 // The datasources returned will be in the same order the functions were registered.
@@ -0,0 +1,184 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package imagemetadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/series"
+)
+
+// ImageLookup resolves image ids for series and architectures from a
+// source other than simplestreams, such as an organisation's own image
+// pipeline. When a model has an ImageLookup configured, it is used in
+// place of the simplestreams image metadata.
+type ImageLookup interface {
+	// Description describes the lookup, for logging.
+	Description() string
+
+	// LookupImages returns metadata for the images that match the
+	// constraint.
+	LookupImages(*ImageConstraint) ([]*ImageMetadata, error)
+}
+
+// NewStaticImageLookup returns an ImageLookup that resolves images
+// from a fixed mapping of "<series>/<arch>" to image id.
+func NewStaticImageLookup(ids map[string]string) ImageLookup {
+	return staticImageLookup(ids)
+}
+
+type staticImageLookup map[string]string
+
+// Description is part of the ImageLookup interface.
+func (staticImageLookup) Description() string {
+	return "image-ids"
+}
+
+// LookupImages is part of the ImageLookup interface.
+func (l staticImageLookup) LookupImages(cons *ImageConstraint) ([]*ImageMetadata, error) {
+	var result []*ImageMetadata
+	for _, s := range cons.Series {
+		for _, a := range cons.Arches {
+			id, ok := l[s+"/"+a]
+			if !ok {
+				continue
+			}
+			meta, err := lookupImageMetadata(cons, id, s, a)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			result = append(result, meta)
+		}
+	}
+	return result, nil
+}
+
+// NewHTTPImageLookup returns an ImageLookup that resolves images by
+// querying the service at the given URL.
+//
+// The service is sent a GET request with the query parameters
+// "series" and "arch", each of which may be repeated, and "region",
+// "endpoint" and "stream", and must respond with a JSON document of
+// the form:
+//
+//	{"images": [{"id": "ami-123", "series": "xenial", "arch": "amd64"}]}
+//
+// Each image may also specify "region", "virt-type" and "root-store".
+func NewHTTPImageLookup(lookupURL string, hostnameVerification utils.SSLHostnameVerification) ImageLookup {
+	return &httpImageLookup{
+		url:                  lookupURL,
+		hostnameVerification: hostnameVerification,
+	}
+}
+
+type httpImageLookup struct {
+	url                  string
+	hostnameVerification utils.SSLHostnameVerification
+}
+
+// lookupImagesResponse is the document returned by an image lookup
+// service.
+type lookupImagesResponse struct {
+	Images []lookupImage `json:"images"`
+}
+
+type lookupImage struct {
+	Id        string `json:"id"`
+	Series    string `json:"series"`
+	Arch      string `json:"arch"`
+	Region    string `json:"region,omitempty"`
+	VirtType  string `json:"virt-type,omitempty"`
+	RootStore string `json:"root-store,omitempty"`
+}
+
+// Description is part of the ImageLookup interface.
+func (l *httpImageLookup) Description() string {
+	return fmt.Sprintf("image-lookup-url %q", l.url)
+}
+
+// LookupImages is part of the ImageLookup interface.
+func (l *httpImageLookup) LookupImages(cons *ImageConstraint) ([]*ImageMetadata, error) {
+	lookupURL, err := url.Parse(l.url)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	query := lookupURL.Query()
+	query["series"] = cons.Series
+	query["arch"] = cons.Arches
+	for k, v := range map[string]string{
+		"region":   cons.Region,
+		"endpoint": cons.Endpoint,
+		"stream":   cons.Stream,
+	} {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+	lookupURL.RawQuery = query.Encode()
+
+	client := utils.GetHTTPClient(l.hostnameVerification)
+	resp, err := client.Get(lookupURL.String())
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot look up images")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("cannot look up images: %s", resp.Status)
+	}
+	var response lookupImagesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, errors.Annotate(err, "cannot decode image lookup response")
+	}
+
+	// The service should only return the images asked for, but
+	// make sure of it.
+	wanted := make(map[string]bool)
+	for _, s := range cons.Series {
+		for _, a := range cons.Arches {
+			wanted[s+"/"+a] = true
+		}
+	}
+	var result []*ImageMetadata
+	for _, image := range response.Images {
+		if image.Id == "" {
+			return nil, errors.New("image lookup response has an image with no id")
+		}
+		if !wanted[image.Series+"/"+image.Arch] {
+			continue
+		}
+		if image.Region != "" && cons.Region != "" && image.Region != cons.Region {
+			continue
+		}
+		meta, err := lookupImageMetadata(cons, image.Id, image.Series, image.Arch)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		meta.VirtType = image.VirtType
+		meta.Storage = image.RootStore
+		result = append(result, meta)
+	}
+	return result, nil
+}
+
+// lookupImageMetadata returns the metadata for an image found by an
+// ImageLookup. The image is taken to be in the region being searched.
+func lookupImageMetadata(cons *ImageConstraint, id, imageSeries, arch string) (*ImageMetadata, error) {
+	version, err := series.SeriesVersion(imageSeries)
+	if err != nil {
+		return nil, errors.Annotatef(err, "image %q", id)
+	}
+	return &ImageMetadata{
+		Id:         id,
+		Arch:       arch,
+		Version:    version,
+		RegionName: cons.Region,
+		Endpoint:   cons.Endpoint,
+		Stream:     cons.Stream,
+	}, nil
+}
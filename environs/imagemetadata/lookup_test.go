@@ -0,0 +1,115 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package imagemetadata_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/testing"
+)
+
+type lookupSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&lookupSuite{})
+
+var lookupConstraint = imagemetadata.NewImageConstraint(simplestreams.LookupParams{
+	CloudSpec: simplestreams.CloudSpec{Region: "region", Endpoint: "endpoint"},
+	Series:    []string{"trusty", "xenial"},
+	Arches:    []string{"amd64"},
+	Stream:    "released",
+})
+
+func (s *lookupSuite) TestStaticImageLookup(c *gc.C) {
+	lookup := imagemetadata.NewStaticImageLookup(map[string]string{
+		"xenial/amd64": "ami-xenial",
+		"trusty/amd64": "ami-trusty",
+		"xenial/arm64": "ami-arm64",
+	})
+	c.Assert(lookup.Description(), gc.Equals, "image-ids")
+	metadata, err := lookup.LookupImages(lookupConstraint)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metadata, jc.DeepEquals, []*imagemetadata.ImageMetadata{{
+		Id:         "ami-trusty",
+		Arch:       "amd64",
+		Version:    "14.04",
+		RegionName: "region",
+		Endpoint:   "endpoint",
+		Stream:     "released",
+	}, {
+		Id:         "ami-xenial",
+		Arch:       "amd64",
+		Version:    "16.04",
+		RegionName: "region",
+		Endpoint:   "endpoint",
+		Stream:     "released",
+	}})
+}
+
+func (s *lookupSuite) TestHTTPImageLookup(c *gc.C) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"images": [
+			{"id": "ami-xenial", "series": "xenial", "arch": "amd64", "virt-type": "hvm", "root-store": "ebs"},
+			{"id": "ami-elsewhere", "series": "xenial", "arch": "amd64", "region": "elsewhere"},
+			{"id": "ami-arm64", "series": "xenial", "arch": "arm64"}
+		]}`)
+	}))
+	defer server.Close()
+
+	lookup := imagemetadata.NewHTTPImageLookup(server.URL+"/images?token=abc", utils.VerifySSLHostnames)
+	metadata, err := lookup.LookupImages(lookupConstraint)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(query, jc.DeepEquals, url.Values{
+		"token":    {"abc"},
+		"series":   {"trusty", "xenial"},
+		"arch":     {"amd64"},
+		"region":   {"region"},
+		"endpoint": {"endpoint"},
+		"stream":   {"released"},
+	})
+	c.Assert(metadata, jc.DeepEquals, []*imagemetadata.ImageMetadata{{
+		Id:         "ami-xenial",
+		Arch:       "amd64",
+		Version:    "16.04",
+		RegionName: "region",
+		Endpoint:   "endpoint",
+		Stream:     "released",
+		VirtType:   "hvm",
+		Storage:    "ebs",
+	}})
+}
+
+func (s *lookupSuite) TestHTTPImageLookupError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	lookup := imagemetadata.NewHTTPImageLookup(server.URL, utils.VerifySSLHostnames)
+	_, err := lookup.LookupImages(lookupConstraint)
+	c.Assert(err, gc.ErrorMatches, "cannot look up images: 403 Forbidden")
+}
+
+func (s *lookupSuite) TestHTTPImageLookupNoId(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"images": [{"series": "xenial", "arch": "amd64"}]}`)
+	}))
+	defer server.Close()
+
+	lookup := imagemetadata.NewHTTPImageLookup(server.URL, utils.VerifySSLHostnames)
+	_, err := lookup.LookupImages(lookupConstraint)
+	c.Assert(err, gc.ErrorMatches, "image lookup response has an image with no id")
+}
@@ -131,3 +131,22 @@ func (s *ImageMetadataSuite) TestImageMetadataURLsNonReleaseStream(c *gc.C) {
 		{"http://cloud-images.ubuntu.com/daily/", imagemetadata.SimplestreamsImagesPublicKey},
 	})
 }
+
+func (s *ImageMetadataSuite) TestConfiguredImageLookup(c *gc.C) {
+	_, ok := environs.ConfiguredImageLookup(testing.ModelConfig(c))
+	c.Assert(ok, jc.IsFalse)
+
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"image-lookup-url": "https://images.example.com/lookup",
+	})
+	lookup, ok := environs.ConfiguredImageLookup(cfg)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(lookup.Description(), gc.Equals, `image-lookup-url "https://images.example.com/lookup"`)
+
+	cfg = testing.CustomModelConfig(c, testing.Attrs{
+		"image-ids": "xenial/amd64=ami-1",
+	})
+	lookup, ok = environs.ConfiguredImageLookup(cfg)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(lookup.Description(), gc.Equals, "image-ids")
+}
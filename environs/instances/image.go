@@ -62,6 +62,13 @@ type InstanceSpec struct {
 // compatible with the matching instance types is returned.
 func FindInstanceSpec(possibleImages []Image, ic *InstanceConstraint, allInstanceTypes []InstanceType) (*InstanceSpec, error) {
 	logger.Debugf("instance constraints %+v", ic)
+	if ic.Constraints.HasImageID() {
+		image, err := imageWithID(possibleImages, ic)
+		if err != nil {
+			return nil, err
+		}
+		possibleImages = []Image{image}
+	}
 	if len(possibleImages) == 0 {
 		return nil, fmt.Errorf("no %q images in %s with arches %s",
 			ic.Series, ic.Region, ic.Arches)
@@ -114,6 +121,31 @@ func FindInstanceSpec(possibleImages []Image, ic *InstanceConstraint, allInstanc
 	return nil, fmt.Errorf("no %q images in %s matching instance types %v", ic.Series, ic.Region, names)
 }
 
+// imageWithID returns the image specified by the image-id constraint.
+// If the image is not described by the image metadata, its architecture
+// must be implied by the constraints.
+func imageWithID(possibleImages []Image, ic *InstanceConstraint) (Image, error) {
+	id := *ic.Constraints.ImageID
+	for _, image := range possibleImages {
+		if image.Id == id {
+			return image, nil
+		}
+	}
+	image := Image{Id: id}
+	switch {
+	case ic.Constraints.HasArch():
+		image.Arch = *ic.Constraints.Arch
+	case len(ic.Arches) == 1:
+		image.Arch = ic.Arches[0]
+	default:
+		return Image{}, fmt.Errorf(
+			"image %q not found in image metadata, and arch not constrained to one of %s",
+			id, ic.Arches,
+		)
+	}
+	return image, nil
+}
+
 // byArch sorts InstanceSpecs first by descending word-size, then
 // alphabetically by name, and choose the first spec in the sequence.
 type byArch []*InstanceSpec
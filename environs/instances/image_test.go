@@ -326,6 +326,30 @@ var findInstanceSpecTests = []instanceSpecTestParams{
 		},
 		err: `no instance types in test matching constraints "instance-type=it-10"`,
 	},
+	{
+		desc:        "image-id constraint in metadata",
+		region:      "test",
+		constraints: "image-id=ami-00000034",
+		imageId:     "ami-00000034",
+		instanceTypes: []InstanceType{
+			{Id: "1", Name: "it-1", Arches: []string{"amd64", "armhf"}, VirtType: &pv, Mem: 512},
+		},
+	},
+	{
+		desc:        "image-id constraint not in metadata",
+		region:      "test",
+		constraints: "image-id=ami-custom arch=amd64",
+		imageId:     "ami-custom",
+		instanceTypes: []InstanceType{
+			{Id: "1", Name: "it-1", Arches: []string{"amd64"}, Mem: 512},
+		},
+	},
+	{
+		desc:        "image-id constraint not in metadata, ambiguous arch",
+		region:      "test",
+		constraints: "image-id=ami-custom",
+		err:         `image "ami-custom" not found in image metadata, and arch not constrained to one of \[amd64 armhf\]`,
+	},
 	{
 		desc:   "no image exists in metadata",
 		region: "invalid-region",
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/watcher"
 )
 
 // A EnvironProvider represents a computing and storage provider.
@@ -423,6 +424,16 @@ type InstanceTypesFetcher interface {
 	InstanceTypes(constraints.Value) (instances.InstanceTypesWithCostMetadata, error)
 }
 
+// InstanceChangeWatcher is an interface that may be implemented by an
+// Environ whose cloud can push notifications of changes to instances,
+// such as a change of address or status, so that they need not be
+// polled for as often.
+type InstanceChangeWatcher interface {
+	// WatchInstanceChanges returns a watcher that notifies of the IDs
+	// of instances whose addresses or status may have changed.
+	WatchInstanceChanges() (watcher.StringsWatcher, error)
+}
+
 // Upgrader is an interface that can be used for upgrading Environs. If an
 // Environ implements this interface, its UpgradeOperations method will be
 // invoked to identify operations that should be run on upgrade.
@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// WriteKnownHosts writes an SSH known_hosts file to w, recording each
+// of the public keys, in authorized_keys format, as a key of host.
+func WriteKnownHosts(w io.Writer, host string, keys []string) error {
+	for _, key := range keys {
+		if _, err := fmt.Fprintln(w, host, strings.TrimSpace(key)); err != nil {
+			return errors.Annotate(err, "writing known_hosts")
+		}
+	}
+	return nil
+}
+
+// ReadKnownHosts reads the public keys recorded in an SSH known_hosts
+// file. The keys are returned in authorized_keys format, without the
+// host patterns or comments; comment lines and lines with markers,
+// such as @revoked, are skipped, as are duplicate keys.
+func ReadKnownHosts(r io.Reader) ([]string, error) {
+	var keys []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "@") {
+			continue
+		}
+		// Each line is "hosts keytype base64-key [comment]".
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, errors.Errorf("malformed known_hosts line %q", line)
+		}
+		key := fields[1] + " " + fields[2]
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Annotate(err, "reading known_hosts")
+	}
+	return keys, nil
+}
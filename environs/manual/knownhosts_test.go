@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package manual_test
+
+import (
+	"bytes"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/testing"
+)
+
+type knownHostsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&knownHostsSuite{})
+
+func (s *knownHostsSuite) TestWriteKnownHosts(c *gc.C) {
+	var buf bytes.Buffer
+	err := manual.WriteKnownHosts(&buf, "10.0.0.1", []string{
+		"ssh-rsa AAAA1 comment",
+		"ssh-ed25519 AAAA2\n",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, `
+10.0.0.1 ssh-rsa AAAA1 comment
+10.0.0.1 ssh-ed25519 AAAA2
+`[1:])
+}
+
+func (s *knownHostsSuite) TestReadKnownHosts(c *gc.C) {
+	keys, err := manual.ReadKnownHosts(strings.NewReader(`
+# A comment.
+10.0.0.1 ssh-rsa AAAA1 comment
+|1|c2FsdA==|aGFzaA== ssh-ed25519 AAAA2
+
+@revoked * ssh-rsa AAAA3
+10.0.0.1,host ssh-rsa AAAA1
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, []string{
+		"ssh-rsa AAAA1",
+		"ssh-ed25519 AAAA2",
+	})
+}
+
+func (s *knownHostsSuite) TestReadKnownHostsMalformed(c *gc.C) {
+	_, err := manual.ReadKnownHosts(strings.NewReader("10.0.0.1 ssh-rsa\n"))
+	c.Assert(err, gc.ErrorMatches, `malformed known_hosts line "10.0.0.1 ssh-rsa"`)
+}
+
+func (s *knownHostsSuite) TestRoundTrip(c *gc.C) {
+	var buf bytes.Buffer
+	keys := []string{"ssh-rsa AAAA1", "ecdsa-sha2-nistp256 AAAA2"}
+	c.Assert(manual.WriteKnownHosts(&buf, "host.example.com", keys), jc.ErrorIsNil)
+	read, err := manual.ReadKnownHosts(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, keys)
+}
@@ -7,6 +7,7 @@ package manual
 import (
 	"errors"
 	"io"
+	"sync"
 
 	"github.com/juju/loggo"
 	"github.com/juju/utils/winrm"
@@ -51,6 +52,23 @@ type ProvisionMachineArgs struct {
 	// ubuntu user's ~/.ssh/authorized_keys.
 	AuthorizedKeys string
 
+	// KnownHostsFile, if non-empty, is the path of an SSH known_hosts
+	// file holding the host keys of Host. Connections to Host are made
+	// with strict host key checking against this file. If empty, host
+	// keys are checked according to the user's SSH configuration.
+	KnownHostsFile string
+
+	// AcceptNewHostKey, if true, allows the first SSH connection to
+	// Host to accept the host key presented, which is then added to
+	// KnownHostsFile and verified by all later connections. It should
+	// only be set when KnownHostsFile holds no keys for Host.
+	AcceptNewHostKey bool
+
+	// PromptLock, if non-nil, is held while the user may be prompted
+	// for input, such as a sudo password, so that the prompts for
+	// machines being provisioned concurrently are not interleaved.
+	PromptLock sync.Locker
+
 	// WinRM contains keys and client interface api with the remote windows machine
 	WinRM WinRMArgs
 
@@ -4,6 +4,22 @@
 
 package sshprovisioner
 
+import "io"
+
 const (
 	DetectionScript = detectionScript
 )
+
+// InitUbuntuUserKnownHosts calls initUbuntuUser, verifying the host's
+// key against the given known_hosts file.
+func InitUbuntuUserKnownHosts(
+	host, login, authorizedKeys string,
+	read io.Reader, write io.Writer,
+	knownHostsFile string, acceptNew bool,
+) error {
+	checking := &hostKeyChecking{
+		knownHostsFile: knownHostsFile,
+		acceptNew:      acceptNew,
+	}
+	return initUbuntuUser(host, login, authorizedKeys, read, write, checking)
+}
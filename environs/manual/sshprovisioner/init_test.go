@@ -5,8 +5,11 @@
 package sshprovisioner_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -169,3 +172,55 @@ func (s *initialisationSuite) TestInitUbuntuUserError(c *gc.C) {
 	err := sshprovisioner.InitUbuntuUser("testhost", "testuser", "", nil, nil)
 	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 123 \\(failed to create ubuntu user\\)")
 }
+
+// recordingSSHScript is a fake "ssh" command that records its
+// arguments, one invocation per line, and fails only the first
+// invocation, simulating a failed ubuntu@ login.
+const recordingSSHScript = `#!/bin/bash --norc
+echo "$*" >> "$0.log"
+[ $(wc -l < "$0.log") -gt 1 ]
+`
+
+func installRecordingFakeSSH(c *gc.C) (logfile string, restore jujutesting.Restorer) {
+	fakebin := c.MkDir()
+	ssh := filepath.Join(fakebin, "ssh")
+	err := ioutil.WriteFile(ssh, []byte(recordingSSHScript), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	return ssh + ".log", jujutesting.PatchEnvPathPrepend(fakebin)
+}
+
+func (s *initialisationSuite) TestInitUbuntuUserKnownHosts(c *gc.C) {
+	logfile, restore := installRecordingFakeSSH(c)
+	defer restore()
+	knownHosts := filepath.Join(c.MkDir(), "known_hosts")
+	err := sshprovisioner.InitUbuntuUserKnownHosts("testhost", "testuser", "", nil, nil, knownHosts, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(logfile)
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+	for _, line := range lines {
+		c.Check(line, jc.Contains, "StrictHostKeyChecking yes")
+		c.Check(line, jc.Contains, "UserKnownHostsFile "+knownHosts)
+	}
+}
+
+func (s *initialisationSuite) TestInitUbuntuUserAcceptNewHostKey(c *gc.C) {
+	logfile, restore := installRecordingFakeSSH(c)
+	defer restore()
+	knownHosts := filepath.Join(c.MkDir(), "known_hosts")
+	err := sshprovisioner.InitUbuntuUserKnownHosts("testhost", "testuser", "", nil, nil, knownHosts, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(logfile)
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	c.Assert(lines, gc.HasLen, 2)
+	// Only the first connection may accept a new host key.
+	c.Check(lines[0], jc.Contains, "StrictHostKeyChecking no")
+	c.Check(lines[1], jc.Contains, "StrictHostKeyChecking yes")
+	for _, line := range lines {
+		c.Check(line, jc.Contains, "UserKnownHostsFile "+knownHosts)
+	}
+}
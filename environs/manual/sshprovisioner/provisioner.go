@@ -28,16 +28,31 @@ func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err e
 		}
 	}()
 
+	checking := &hostKeyChecking{
+		knownHostsFile: args.KnownHostsFile,
+		acceptNew:      args.AcceptNewHostKey,
+	}
+
 	// Create the "ubuntu" user and initialise passwordless sudo. We populate
 	// the ubuntu user's authorized_keys file with the public keys in the current
 	// user's ~/.ssh directory. The authenticationworker will later update the
 	// ubuntu user's authorized_keys.
-	if err = InitUbuntuUser(args.Host, args.User,
-		args.AuthorizedKeys, args.Stdin, args.Stdout); err != nil {
+	//
+	// The user may be prompted for a sudo password, so hold the prompt
+	// lock while other machines are being provisioned concurrently.
+	if args.PromptLock != nil {
+		args.PromptLock.Lock()
+	}
+	err = initUbuntuUser(args.Host, args.User,
+		args.AuthorizedKeys, args.Stdin, args.Stdout, checking)
+	if args.PromptLock != nil {
+		args.PromptLock.Unlock()
+	}
+	if err != nil {
 		return "", err
 	}
 
-	machineParams, err := gatherMachineParams(args.Host)
+	machineParams, err := gatherMachineParams(args.Host, checking)
 	if err != nil {
 		return "", err
 	}
@@ -60,7 +75,7 @@ func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err e
 	}
 
 	// Finally, provision the machine agent.
-	err = runProvisionScript(provisioningScript, args.Host, args.Stderr)
+	err = runProvisionScript(provisioningScript, args.Host, args.Stderr, checking)
 	if err != nil {
 		return machineId, err
 	}
@@ -40,6 +40,14 @@ import (
 // authorizedKeys may be empty, in which case the file
 // will be created and left empty.
 func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io.Writer) error {
+	return initUbuntuUser(host, login, authorizedKeys, read, write, nil)
+}
+
+func initUbuntuUser(
+	host, login, authorizedKeys string,
+	read io.Reader, write io.Writer,
+	checking *hostKeyChecking,
+) error {
 	logger.Infof("initialising %q, user %q", host, login)
 
 	// To avoid unnecessary prompting for the specified login,
@@ -49,7 +57,7 @@ func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io
 	//
 	// Note that we explicitly do not allocate a PTY, so we
 	// get a failure if sudo prompts.
-	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"sudo", "-n", "true"}, checking.options())
 	if cmd.Run() == nil {
 		logger.Infof("ubuntu user is already initialised")
 		return nil
@@ -61,10 +69,10 @@ func InitUbuntuUser(host, login, authorizedKeys string, read io.Reader, write io
 		host = login + "@" + host
 	}
 	script := fmt.Sprintf(initUbuntuScript, utils.ShQuote(authorizedKeys))
-	var options ssh.Options
+	options := checking.options()
 	options.AllowPasswordAuthentication()
 	options.EnablePTY()
-	cmd = ssh.Command(host, []string{"sudo", "/bin/bash -c " + utils.ShQuote(script)}, &options)
+	cmd = ssh.Command(host, []string{"sudo", "/bin/bash -c " + utils.ShQuote(script)}, options)
 	var stderr bytes.Buffer
 	cmd.Stdin = read
 	cmd.Stdout = write
@@ -96,11 +104,16 @@ fi`
 // DetectSeriesAndHardwareCharacteristics detects the OS
 // series and hardware characteristics of the remote machine
 // by connecting to the machine and executing a bash script.
-var DetectSeriesAndHardwareCharacteristics = detectSeriesAndHardwareCharacteristics
+var DetectSeriesAndHardwareCharacteristics = func(host string) (instance.HardwareCharacteristics, string, error) {
+	return detectSeriesAndHardwareCharacteristics(host, nil)
+}
 
-func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCharacteristics, series string, err error) {
+func detectSeriesAndHardwareCharacteristics(
+	host string,
+	checking *hostKeyChecking,
+) (hc instance.HardwareCharacteristics, series string, err error) {
 	logger.Infof("Detecting series and characteristics on %s", host)
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, checking.options())
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -161,14 +174,16 @@ func detectSeriesAndHardwareCharacteristics(host string) (hc instance.HardwareCh
 
 // CheckProvisioned checks if any juju init service already
 // exist on the host machine.
-var CheckProvisioned = checkProvisioned
+var CheckProvisioned = func(host string) (bool, error) {
+	return checkProvisioned(host, nil)
+}
 
-func checkProvisioned(host string) (bool, error) {
+func checkProvisioned(host string, checking *hostKeyChecking) (bool, error) {
 	logger.Infof("Checking if %s is already provisioned", host)
 
 	script := service.ListServicesScript()
 
-	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, checking.options())
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -210,7 +225,7 @@ cat /proc/cpuinfo`
 // The hostname supplied should not include a username.
 // If we can, we will reverse lookup the hostname by its IP address, and use
 // the DNS resolved name, rather than the name that was supplied
-func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
+func gatherMachineParams(hostname string, checking *hostKeyChecking) (*params.AddMachineParams, error) {
 
 	// Generate a unique nonce for the machine.
 	uuid, err := utils.NewUUID()
@@ -223,7 +238,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, errors.Annotatef(err, "failed to compute public address for %q", hostname)
 	}
 
-	provisioned, err := checkProvisioned(hostname)
+	provisioned, err := checkProvisioned(hostname, checking)
 	if err != nil {
 		return nil, errors.Annotatef(err, "error checking if provisioned")
 	}
@@ -231,7 +246,7 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 		return nil, manual.ErrProvisioned
 	}
 
-	hc, series, err := DetectSeriesAndHardwareCharacteristics(hostname)
+	hc, series, err := detectSeriesAndHardwareCharacteristics(hostname, checking)
 	if err != nil {
 		return nil, errors.Annotatef(err, "error detecting linux hardware characteristics")
 	}
@@ -254,14 +269,48 @@ func gatherMachineParams(hostname string) (*params.AddMachineParams, error) {
 	return machineParams, nil
 }
 
-func runProvisionScript(script, host string, progressWriter io.Writer) error {
+func runProvisionScript(script, host string, progressWriter io.Writer, checking *hostKeyChecking) error {
 	params := sshinit.ConfigureParams{
 		Host:           "ubuntu@" + host,
+		SSHOptions:     checking.options(),
 		ProgressWriter: progressWriter,
 	}
 	return sshinit.RunConfigureScript(script, params)
 }
 
+// hostKeyChecking determines how the SSH host key of a machine is
+// verified by the connections made to provision it.
+type hostKeyChecking struct {
+	// knownHostsFile is the known_hosts file holding the host's keys.
+	// If it is empty, the user's SSH configuration applies.
+	knownHostsFile string
+
+	// acceptNew records whether the next connection may accept a
+	// host key that is not in knownHostsFile.
+	acceptNew bool
+}
+
+// options returns the SSH options for the next connection to the host.
+// A nil hostKeyChecking leaves host key checking to the user's SSH
+// configuration.
+func (c *hostKeyChecking) options() *ssh.Options {
+	var options ssh.Options
+	if c == nil || c.knownHostsFile == "" {
+		return &options
+	}
+	options.SetKnownHostsFile(c.knownHostsFile)
+	if c.acceptNew {
+		// Only the first connection may accept a new host key.
+		// ssh adds the key to the known_hosts file, and all later
+		// connections are verified against it.
+		c.acceptNew = false
+		options.SetStrictHostKeyChecking(ssh.StrictHostChecksNo)
+	} else {
+		options.SetStrictHostKeyChecking(ssh.StrictHostChecksYes)
+	}
+	return &options
+}
+
 // ProvisioningScript generates a bash script that can be
 // executed on a remote host to carry out the cloud-init
 // configuration.
@@ -0,0 +1,166 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	jujucloud "github.com/juju/juju/cloud"
+)
+
+// regionProbeTimeout is the maximum amount of time to wait when
+// probing the API endpoint of a single cloud region.
+const regionProbeTimeout = 5 * time.Second
+
+// RegionLatency holds the result of probing the API endpoint of a
+// cloud region.
+type RegionLatency struct {
+	// Region is the name of the cloud region.
+	Region string
+
+	// Latency is the time taken to reach the region's API endpoint.
+	// It is only valid if Error is nil.
+	Latency time.Duration
+
+	// Error holds the reason the region could not be probed, if any.
+	Error error
+}
+
+// LatencyProber measures the latency of the given cloud API endpoint.
+type LatencyProber func(endpoint string) (time.Duration, error)
+
+// DialLatency is a LatencyProber that measures the time taken to open
+// a TCP connection to the host of the given endpoint URL.
+func DialLatency(endpoint string) (time.Duration, error) {
+	address, err := endpointAddress(endpoint)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", address, regionProbeTimeout)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	latency := time.Since(start)
+	conn.Close()
+	return latency, nil
+}
+
+// endpointAddress returns the host:port address of the given endpoint
+// URL, using the default port of the URL's scheme if none is given.
+func endpointAddress(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errors.Annotatef(err, "parsing endpoint %q", endpoint)
+	}
+	if u.Host == "" {
+		return "", errors.NotValidf("endpoint %q", endpoint)
+	}
+	if u.Port() != "" {
+		return u.Host, nil
+	}
+	port := "443"
+	if u.Scheme == "http" {
+		port = "80"
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
+
+// ProbeRegionLatencies probes the API endpoint of each of the given
+// regions concurrently, returning the results in the same order as
+// the regions.
+func ProbeRegionLatencies(regions []jujucloud.Region, probe LatencyProber) []RegionLatency {
+	results := make([]RegionLatency, len(regions))
+	var wg sync.WaitGroup
+	for i, region := range regions {
+		results[i].Region = region.Name
+		if region.Endpoint == "" {
+			results[i].Error = errors.NotFoundf("endpoint for region %q", region.Name)
+			continue
+		}
+		wg.Add(1)
+		go func(result *RegionLatency, endpoint string) {
+			defer wg.Done()
+			result.Latency, result.Error = probe(endpoint)
+		}(&results[i], region.Endpoint)
+	}
+	wg.Wait()
+	return results
+}
+
+// NearestRegion returns the name of the successfully probed region
+// with the lowest latency. If no region could be probed, an error
+// satisfying errors.IsNotFound is returned.
+func NearestRegion(latencies []RegionLatency) (string, error) {
+	var nearest *RegionLatency
+	for i, latency := range latencies {
+		if latency.Error != nil {
+			continue
+		}
+		if nearest == nil || latency.Latency < nearest.Latency {
+			nearest = &latencies[i]
+		}
+	}
+	if nearest == nil {
+		return "", errors.NotFoundf("reachable region")
+	}
+	return nearest.Region, nil
+}
+
+// RegionLatencyCache probes the regions of clouds, caching the results
+// for each cloud for a fixed period so that repeated requests do not
+// repeatedly probe the cloud.
+type RegionLatencyCache struct {
+	clock clock.Clock
+	ttl   time.Duration
+	probe LatencyProber
+
+	mu      sync.Mutex
+	entries map[string]regionLatencyEntry
+}
+
+type regionLatencyEntry struct {
+	probed    time.Time
+	latencies []RegionLatency
+}
+
+// NewRegionLatencyCache returns a new RegionLatencyCache that probes
+// regions with the given prober, and caches the results for ttl.
+func NewRegionLatencyCache(clock clock.Clock, ttl time.Duration, probe LatencyProber) *RegionLatencyCache {
+	return &RegionLatencyCache{
+		clock:   clock,
+		ttl:     ttl,
+		probe:   probe,
+		entries: make(map[string]regionLatencyEntry),
+	}
+}
+
+// RegionLatencies returns the latencies of the regions of the given
+// cloud, and the time at which they were probed. The regions are
+// probed if there are no cached results for the cloud, or the cached
+// results have expired.
+func (c *RegionLatencyCache) RegionLatencies(cloud jujucloud.Cloud) ([]RegionLatency, time.Time) {
+	c.mu.Lock()
+	entry, ok := c.entries[cloud.Name]
+	c.mu.Unlock()
+	now := c.clock.Now()
+	if ok && now.Before(entry.probed.Add(c.ttl)) {
+		return entry.latencies, entry.probed
+	}
+
+	entry = regionLatencyEntry{
+		probed:    now,
+		latencies: ProbeRegionLatencies(cloud.Regions, c.probe),
+	}
+	c.mu.Lock()
+	c.entries[cloud.Name] = entry
+	c.mu.Unlock()
+	return entry.latencies, entry.probed
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+type regionLatencySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&regionLatencySuite{})
+
+var latencyTestCloud = cloud.Cloud{
+	Name: "foo",
+	Regions: []cloud.Region{
+		{Name: "far", Endpoint: "https://far.example.com"},
+		{Name: "near", Endpoint: "https://near.example.com"},
+		{Name: "down", Endpoint: "https://down.example.com"},
+		{Name: "nowhere"},
+	},
+}
+
+// fakeProbe is a LatencyProber for the regions of latencyTestCloud.
+func fakeProbe(endpoint string) (time.Duration, error) {
+	switch endpoint {
+	case "https://far.example.com":
+		return 200 * time.Millisecond, nil
+	case "https://near.example.com":
+		return 20 * time.Millisecond, nil
+	}
+	return 0, errors.New("connection refused")
+}
+
+func (s *regionLatencySuite) TestProbeRegionLatencies(c *gc.C) {
+	results := environs.ProbeRegionLatencies(latencyTestCloud.Regions, fakeProbe)
+	c.Assert(results, gc.HasLen, 4)
+	c.Check(results[0], jc.DeepEquals, environs.RegionLatency{Region: "far", Latency: 200 * time.Millisecond})
+	c.Check(results[1], jc.DeepEquals, environs.RegionLatency{Region: "near", Latency: 20 * time.Millisecond})
+	c.Check(results[2].Region, gc.Equals, "down")
+	c.Check(results[2].Error, gc.ErrorMatches, "connection refused")
+	c.Check(results[3].Region, gc.Equals, "nowhere")
+	c.Check(results[3].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *regionLatencySuite) TestNearestRegion(c *gc.C) {
+	nearest, err := environs.NearestRegion([]environs.RegionLatency{
+		{Region: "far", Latency: 200 * time.Millisecond},
+		{Region: "down", Error: errors.New("connection refused")},
+		{Region: "near", Latency: 20 * time.Millisecond},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(nearest, gc.Equals, "near")
+}
+
+func (s *regionLatencySuite) TestNearestRegionNoneReachable(c *gc.C) {
+	_, err := environs.NearestRegion([]environs.RegionLatency{
+		{Region: "down", Error: errors.New("connection refused")},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "reachable region not found")
+}
+
+func (s *regionLatencySuite) TestRegionLatencyCache(c *gc.C) {
+	testCloud := cloud.Cloud{
+		Name:    "foo",
+		Regions: latencyTestCloud.Regions[1:2],
+	}
+	var probed []string
+	clock := testing.NewClock(time.Time{})
+	cache := environs.NewRegionLatencyCache(clock, time.Hour, func(endpoint string) (time.Duration, error) {
+		probed = append(probed, endpoint)
+		return fakeProbe(endpoint)
+	})
+
+	latencies, when := cache.RegionLatencies(testCloud)
+	c.Assert(latencies, jc.DeepEquals, []environs.RegionLatency{
+		{Region: "near", Latency: 20 * time.Millisecond},
+	})
+	c.Assert(when, gc.Equals, clock.Now())
+	c.Assert(probed, gc.HasLen, 1)
+
+	// The cached results are used until they expire.
+	clock.Advance(59 * time.Minute)
+	_, when2 := cache.RegionLatencies(testCloud)
+	c.Assert(when2, gc.Equals, when)
+	c.Assert(probed, gc.HasLen, 1)
+
+	clock.Advance(time.Minute)
+	_, when3 := cache.RegionLatencies(testCloud)
+	c.Assert(when3, gc.Equals, clock.Now())
+	c.Assert(probed, gc.HasLen, 2)
+}
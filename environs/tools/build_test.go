@@ -279,9 +279,10 @@ func (b *buildSuite) TestBundleToolsIncludesVersionFile(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	unpackDir := filepath.Join(dir, "tools", "1.2.3-quantal-arm64")
-	// downloaded-tools.txt is added by UnpackTools.
+	// downloaded-tools.txt and downloaded-tools-sha256.txt are
+	// added by UnpackTools.
 	c.Assert(listDir(c, unpackDir), gc.DeepEquals, []string{
-		"downloaded-tools.txt", "jujud", "jujud-versions.yaml"})
+		"downloaded-tools-sha256.txt", "downloaded-tools.txt", "jujud", "jujud-versions.yaml"})
 }
 
 func listDir(c *gc.C, dir string) []string {
@@ -400,9 +401,10 @@ func (b *buildSuite) TestBundleToolsFindsVersionFileInFallbackLocation(c *gc.C)
 	c.Assert(err, jc.ErrorIsNil)
 
 	unpackDir := filepath.Join(dir, "tools", "1.2.3-quantal-arm64")
-	// downloaded-tools.txt is added by UnpackTools.
+	// downloaded-tools.txt and downloaded-tools-sha256.txt are
+	// added by UnpackTools.
 	c.Assert(listDir(c, unpackDir), gc.DeepEquals, []string{
-		"downloaded-tools.txt", "jujud", "jujud-versions.yaml"})
+		"downloaded-tools-sha256.txt", "downloaded-tools.txt", "jujud", "jujud-versions.yaml"})
 }
 
 func (b *buildSuite) TestBundleToolsUsesAdjacentVersionFirst(c *gc.C) {
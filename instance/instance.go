@@ -60,6 +60,24 @@ type InstanceFirewaller interface {
 	IngressRules(machineId string) ([]network.IngressRule, error)
 }
 
+// InstanceEgressFirewaller is implemented by instances whose outbound
+// traffic can be restricted by the provider.
+type InstanceEgressFirewaller interface {
+	// RestrictEgress restricts the outbound traffic of the instance,
+	// which should have been started with the given machine id, to
+	// the given CIDRs.
+	RestrictEgress(machineId string, cidrs []string) error
+
+	// UnrestrictEgress removes any restriction on the outbound traffic
+	// of the instance, which should have been started with the given
+	// machine id.
+	UnrestrictEgress(machineId string) error
+
+	// EgressRestriction returns the CIDRs the outbound traffic of the
+	// instance is restricted to, and whether it is restricted at all.
+	EgressRestriction(machineId string) ([]string, bool, error)
+}
+
 // HardwareCharacteristics represents the characteristics of the instance (if known).
 // Attributes that are nil are unknown or not supported.
 type HardwareCharacteristics struct {
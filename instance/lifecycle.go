@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instance
+
+import (
+	"github.com/juju/errors"
+)
+
+// Lifecycle describes how long a cloud instance may be expected to
+// live, and so whether the cloud may reclaim it before it is stopped.
+type Lifecycle string
+
+// Known instance lifecycles.
+const (
+	// OnDemand instances run until they are stopped.
+	OnDemand Lifecycle = "on-demand"
+
+	// Spot instances are bid for, and may be reclaimed by the cloud
+	// when their price exceeds the bid.
+	Spot Lifecycle = "spot"
+
+	// Preemptible instances are offered at a fixed, lower, price
+	// and may be reclaimed by the cloud at any time.
+	Preemptible Lifecycle = "preemptible"
+)
+
+// Lifecycles holds all the known instance lifecycles.
+var Lifecycles = []Lifecycle{
+	OnDemand,
+	Spot,
+	Preemptible,
+}
+
+// ParseLifecycle converts the specified string into a known
+// Lifecycle, or returns an error if the lifecycle is not valid.
+func ParseLifecycle(lifecycle string) (Lifecycle, error) {
+	for _, known := range Lifecycles {
+		if Lifecycle(lifecycle) == known {
+			return known, nil
+		}
+	}
+	return "", errors.NotValidf("instance lifecycle %q", lifecycle)
+}
+
+// Reclaimable reports whether instances with the lifecycle may be
+// reclaimed by the cloud before they are stopped.
+func (l Lifecycle) Reclaimable() bool {
+	return l == Spot || l == Preemptible
+}
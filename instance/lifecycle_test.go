@@ -0,0 +1,31 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instance_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+)
+
+type LifecycleSuite struct{}
+
+var _ = gc.Suite(&LifecycleSuite{})
+
+func (s *LifecycleSuite) TestParseLifecycle(c *gc.C) {
+	for _, lifecycle := range instance.Lifecycles {
+		parsed, err := instance.ParseLifecycle(string(lifecycle))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(parsed, gc.Equals, lifecycle)
+	}
+	_, err := instance.ParseLifecycle("reserved")
+	c.Assert(err, gc.ErrorMatches, `instance lifecycle "reserved" not valid`)
+}
+
+func (s *LifecycleSuite) TestReclaimable(c *gc.C) {
+	c.Assert(instance.OnDemand.Reclaimable(), jc.IsFalse)
+	c.Assert(instance.Spot.Reclaimable(), jc.IsTrue)
+	c.Assert(instance.Preemptible.Reclaimable(), jc.IsTrue)
+}
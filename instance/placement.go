@@ -14,6 +14,16 @@ const (
 	// MachineScope is a special scope name that is used
 	// for machine placement directives (e.g. --to 0).
 	MachineScope = "#"
+
+	// AffinityScope is the scope name used for placement directives
+	// that ask for a machine to be started close to the machines of
+	// an existing application (e.g. --to affinity:mysql).
+	AffinityScope = "affinity"
+
+	// AntiAffinityScope is the scope name used for placement directives
+	// that ask for a machine to be started away from the machines of
+	// an existing application (e.g. --to anti-affinity:mysql).
+	AntiAffinityScope = "anti-affinity"
 )
 
 var ErrPlacementScopeMissing = fmt.Errorf("placement scope missing")
@@ -31,7 +41,8 @@ type Placement struct {
 	// Directive is a scope-specific placement directive.
 	//
 	// For MachineScope or a container scope, this may be empty or
-	// the ID of an existing machine.
+	// the ID of an existing machine. For AffinityScope and
+	// AntiAffinityScope, this is the name of an application.
 	Directive string `json:"directive"`
 }
 
@@ -63,6 +74,10 @@ func ParsePlacement(directive string) (*Placement, error) {
 		if (scope == MachineScope || isContainerType(scope)) && !names.IsValidMachine(directive) {
 			return nil, fmt.Errorf("invalid value %q for %q scope: expected machine-id", directive, scope)
 		}
+		// Affinity scopes require an application name as the value.
+		if isAffinityScope(scope) && !names.IsValidApplication(directive) {
+			return nil, fmt.Errorf("invalid value %q for %q scope: expected application name", directive, scope)
+		}
 		return &Placement{Scope: scope, Directive: directive}, nil
 	}
 	if names.IsValidMachine(directive) {
@@ -83,3 +98,55 @@ func MustParsePlacement(directive string) *Placement {
 	}
 	return placement
 }
+
+func isAffinityScope(s string) bool {
+	return s == AffinityScope || s == AntiAffinityScope
+}
+
+// Affinity describes where a machine should be started relative to
+// the machines hosting the units of another application.
+type Affinity struct {
+	// Application is the name of the application whose machines
+	// the new machine should be placed relative to.
+	Application string
+
+	// Anti is true if the new machine should be kept away from the
+	// application's machines, rather than placed alongside them.
+	Anti bool
+}
+
+// String returns the placement directive corresponding to the affinity.
+func (a *Affinity) String() string {
+	scope := AffinityScope
+	if a.Anti {
+		scope = AntiAffinityScope
+	}
+	return fmt.Sprintf("%s:%s", scope, a.Application)
+}
+
+// Affinity returns the affinity described by the placement, or nil if
+// the placement does not have one of the affinity scopes.
+func (p *Placement) Affinity() *Affinity {
+	if p == nil || !isAffinityScope(p.Scope) {
+		return nil
+	}
+	return &Affinity{
+		Application: p.Directive,
+		Anti:        p.Scope == AntiAffinityScope,
+	}
+}
+
+// ParseAffinity returns the affinity described by the specified
+// placement directive, as recorded against a machine. If the directive
+// is not an affinity directive, ParseAffinity returns nil, nil.
+func ParseAffinity(directive string) (*Affinity, error) {
+	colon := strings.IndexRune(directive, ':')
+	if colon == -1 || !isAffinityScope(directive[:colon]) {
+		return nil, nil
+	}
+	placement, err := ParsePlacement(directive)
+	if err != nil {
+		return nil, err
+	}
+	return placement.Affinity(), nil
+}
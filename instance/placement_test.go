@@ -55,6 +55,20 @@ func (s *PlacementSuite) TestParsePlacement(c *gc.C) {
 		arg:             "non:standard",
 		expectScope:     "non",
 		expectDirective: "standard",
+	}, {
+		arg:             "affinity:mysql",
+		expectScope:     instance.AffinityScope,
+		expectDirective: "mysql",
+	}, {
+		arg:             "anti-affinity:mysql",
+		expectScope:     instance.AntiAffinityScope,
+		expectDirective: "mysql",
+	}, {
+		arg: "affinity:0",
+		err: `invalid value "0" for "affinity" scope: expected application name`,
+	}, {
+		arg: "anti-affinity:",
+		err: `invalid value "" for "anti-affinity" scope: expected application name`,
 	}}
 
 	for i, t := range parsePlacementTests {
@@ -75,3 +89,24 @@ func (s *PlacementSuite) TestParsePlacement(c *gc.C) {
 		}
 	}
 }
+
+func (s *PlacementSuite) TestParseAffinity(c *gc.C) {
+	affinity, err := instance.ParseAffinity("affinity:mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(affinity, jc.DeepEquals, &instance.Affinity{Application: "mysql"})
+	c.Assert(affinity.String(), gc.Equals, "affinity:mysql")
+
+	affinity, err = instance.ParseAffinity("anti-affinity:mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(affinity, jc.DeepEquals, &instance.Affinity{Application: "mysql", Anti: true})
+	c.Assert(affinity.String(), gc.Equals, "anti-affinity:mysql")
+
+	for _, directive := range []string{"", "zone=us-east-1a", "lxd:0"} {
+		affinity, err = instance.ParseAffinity(directive)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(affinity, gc.IsNil)
+	}
+
+	_, err = instance.ParseAffinity("affinity:0")
+	c.Assert(err, gc.ErrorMatches, `invalid value "0" for "affinity" scope: expected application name`)
+}
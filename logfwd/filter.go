@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+)
+
+// Filter selects the log records that are forwarded to a log sink.
+// The zero Filter selects all records.
+type Filter struct {
+	// Level is the minimum level of the selected records. If it is
+	// not set then records of all levels are selected.
+	Level loggo.Level
+
+	// Modules, if not empty, restricts the selected records to those
+	// originating in the given modules or their sub-modules.
+	Modules []string
+
+	// Entities, if not empty, restricts the selected records to those
+	// originating from the entities with the given tags.
+	Entities []string
+}
+
+// Validate ensures that the filter is correct.
+func (f Filter) Validate() error {
+	for _, module := range f.Modules {
+		if module == "" {
+			return errors.NewNotValid(nil, "empty module")
+		}
+	}
+	for _, entity := range f.Entities {
+		if _, err := names.ParseTag(entity); err != nil {
+			return errors.NewNotValid(err, "invalid entity")
+		}
+	}
+	return nil
+}
+
+// Match returns whether the record is selected by the filter.
+func (f Filter) Match(rec Record) bool {
+	if f.Level != loggo.UNSPECIFIED && rec.Level < f.Level {
+		return false
+	}
+	if len(f.Modules) > 0 && !f.matchModule(rec.Location.Module) {
+		return false
+	}
+	if len(f.Entities) > 0 && !f.matchEntity(rec.Origin) {
+		return false
+	}
+	return true
+}
+
+func (f Filter) matchModule(module string) bool {
+	for _, m := range f.Modules {
+		if module == m || strings.HasPrefix(module, m+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Filter) matchEntity(origin Origin) bool {
+	tag := origin.Tag()
+	if tag == nil {
+		return false
+	}
+	for _, entity := range f.Entities {
+		if entity == tag.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// Tag returns the tag of the entity that generated the record, or nil
+// if the entity is not known.
+func (o Origin) Tag() names.Tag {
+	switch o.Type {
+	case OriginTypeUser:
+		if names.IsValidUser(o.Name) {
+			return names.NewUserTag(o.Name)
+		}
+	case OriginTypeMachine:
+		if names.IsValidMachine(o.Name) {
+			return names.NewMachineTag(o.Name)
+		}
+	case OriginTypeUnit:
+		if names.IsValidUnit(o.Name) {
+			return names.NewUnitTag(o.Name)
+		}
+	}
+	return nil
+}
+
+// FilterRecords returns the records selected by the filter.
+func FilterRecords(f Filter, records []Record) []Record {
+	var selected []Record
+	for _, rec := range records {
+		if f.Match(rec) {
+			selected = append(selected, rec)
+		}
+	}
+	return selected
+}
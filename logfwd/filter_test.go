@@ -0,0 +1,150 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfwd_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+)
+
+type FilterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FilterSuite{})
+
+func (s *FilterSuite) TestValidateZero(c *gc.C) {
+	var f logfwd.Filter
+
+	err := f.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *FilterSuite) TestValidateEmptyModule(c *gc.C) {
+	f := logfwd.Filter{Modules: []string{"juju.worker", ""}}
+
+	err := f.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty module`)
+}
+
+func (s *FilterSuite) TestValidateBadEntity(c *gc.C) {
+	f := logfwd.Filter{Entities: []string{"machine-0", "mysql/0"}}
+
+	err := f.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `invalid entity: "mysql/0" is not a valid tag`)
+}
+
+func (s *FilterSuite) TestMatchZero(c *gc.C) {
+	var f logfwd.Filter
+
+	c.Check(f.Match(validRecord), jc.IsTrue)
+}
+
+func (s *FilterSuite) TestMatchLevel(c *gc.C) {
+	f := logfwd.Filter{Level: loggo.WARNING}
+	rec := validRecord
+
+	for level, expected := range map[loggo.Level]bool{
+		loggo.TRACE:    false,
+		loggo.DEBUG:    false,
+		loggo.INFO:     false,
+		loggo.WARNING:  true,
+		loggo.ERROR:    true,
+		loggo.CRITICAL: true,
+	} {
+		rec.Level = level
+		c.Check(f.Match(rec), gc.Equals, expected, gc.Commentf("level %v", level))
+	}
+}
+
+func (s *FilterSuite) TestMatchModules(c *gc.C) {
+	f := logfwd.Filter{Modules: []string{"juju.worker", "unit.mysql/0"}}
+	rec := validRecord
+
+	for module, expected := range map[string]bool{
+		"juju.worker":            true,
+		"juju.worker.uniter":     true,
+		"juju.workers":           false,
+		"juju":                   false,
+		"unit.mysql/0.juju-log":  true,
+		"unit.mysql/1.juju-log":  false,
+		"juju.apiserver.logsink": false,
+		"":                       false,
+	} {
+		rec.Location.Module = module
+		c.Check(f.Match(rec), gc.Equals, expected, gc.Commentf("module %q", module))
+	}
+}
+
+func (s *FilterSuite) TestMatchEntities(c *gc.C) {
+	f := logfwd.Filter{Entities: []string{"machine-0", "unit-mysql-0"}}
+	rec := validRecord
+
+	rec.Origin.Type = logfwd.OriginTypeMachine
+	rec.Origin.Name = "0"
+	c.Check(f.Match(rec), jc.IsTrue)
+
+	rec.Origin.Type = logfwd.OriginTypeUnit
+	rec.Origin.Name = "mysql/0"
+	c.Check(f.Match(rec), jc.IsTrue)
+
+	rec.Origin.Name = "mysql/1"
+	c.Check(f.Match(rec), jc.IsFalse)
+
+	rec.Origin.Type = logfwd.OriginTypeUnknown
+	rec.Origin.Name = ""
+	c.Check(f.Match(rec), jc.IsFalse)
+}
+
+func (s *FilterSuite) TestMatchAll(c *gc.C) {
+	f := logfwd.Filter{
+		Level:    loggo.ERROR,
+		Modules:  []string{"spam"},
+		Entities: []string{"user-a-user"},
+	}
+	rec := validRecord
+	c.Check(f.Match(rec), jc.IsTrue)
+
+	rec.Level = loggo.INFO
+	c.Check(f.Match(rec), jc.IsFalse)
+}
+
+func (s *FilterSuite) TestFilterRecords(c *gc.C) {
+	f := logfwd.Filter{Level: loggo.WARNING}
+	rec0 := validRecord
+	rec0.ID = 10
+	rec1 := validRecord
+	rec1.ID = 11
+	rec1.Level = loggo.DEBUG
+	rec2 := validRecord
+	rec2.ID = 12
+
+	selected := logfwd.FilterRecords(f, []logfwd.Record{rec0, rec1, rec2})
+
+	c.Check(selected, jc.DeepEquals, []logfwd.Record{rec0, rec2})
+}
+
+func (s *FilterSuite) TestOriginTag(c *gc.C) {
+	origin := validOrigin
+	c.Check(origin.Tag(), gc.Equals, names.NewUserTag("a-user"))
+
+	origin.Type = logfwd.OriginTypeUnit
+	origin.Name = "mysql/0"
+	c.Check(origin.Tag(), gc.Equals, names.NewUnitTag("mysql/0"))
+
+	origin.Type = logfwd.OriginTypeUnknown
+	origin.Name = ""
+	c.Check(origin.Tag(), gc.IsNil)
+}
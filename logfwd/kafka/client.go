@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kafka
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/webhook"
+)
+
+// contentType is the content type of requests to produce messages
+// in the REST proxy's JSON embedded format.
+const contentType = "application/vnd.kafka.json.v2+json"
+
+// Client produces log records to a Kafka topic through a REST proxy.
+type Client struct {
+	// URL is the REST proxy URL to which records are posted.
+	URL string
+
+	// HTTPClient is the HTTP client used to post records.
+	HTTPClient *http.Client
+}
+
+// Open returns a new client for the Kafka topic.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	httpClient, err := webhook.NewHTTPClient(cfg.proxyConfig())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		URL:        TopicURL(cfg.URL, cfg.Topic),
+		HTTPClient: httpClient,
+	}, nil
+}
+
+// TopicURL returns the URL of the topic on the REST proxy with the
+// given URL. Valid topic names need no escaping.
+func TopicURL(proxyURL, topic string) string {
+	return strings.TrimSuffix(proxyURL, "/") + "/topics/" + topic
+}
+
+type produceRequest struct {
+	Records []produceRecord `json:"records"`
+}
+
+type produceRecord struct {
+	Key   string         `json:"key,omitempty"`
+	Value webhook.Record `json:"value"`
+}
+
+// Send produces a message for each record. The messages are keyed by
+// model UUID, so that each model's records stay in order.
+func (client Client) Send(records []logfwd.Record) error {
+	req := produceRequest{
+		Records: make([]produceRecord, len(records)),
+	}
+	for i, rec := range records {
+		req.Records[i] = produceRecord{
+			Key:   rec.Origin.ModelUUID,
+			Value: webhook.NewRecord(rec),
+		}
+	}
+	err := webhook.PostJSON(client.HTTPClient, client.URL, contentType, req)
+	return errors.Annotate(err, "posting log records")
+}
+
+// Close implements io.Closer. There is no connection to close.
+func (client Client) Close() error {
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kafka_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/kafka"
+	"github.com/juju/juju/logfwd/webhook"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestOpen(c *gc.C) {
+	client, err := kafka.Open(kafka.RawConfig{
+		URL:   "https://kafka-rest.example.com:8082/",
+		Topic: "juju-logs",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(client.URL, gc.Equals, "https://kafka-rest.example.com:8082/topics/juju-logs")
+	c.Check(client.HTTPClient, gc.NotNil)
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	type produceRecord struct {
+		Key   string         `json:"key"`
+		Value webhook.Record `json:"value"`
+	}
+	var (
+		req  *http.Request
+		body struct {
+			Records []produceRecord `json:"records"`
+		}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		err := json.NewDecoder(r.Body).Decode(&body)
+		c.Check(err, jc.ErrorIsNil)
+	}))
+	defer server.Close()
+
+	rec := logfwd.Record{
+		ID: 10,
+		Origin: logfwd.OriginForMachineAgent(
+			names.NewMachineTag("0"),
+			"9f484882-2f18-4fd2-967d-db9663db7bea",
+			"deadbeef-2f18-4fd2-967d-db9663db7bea",
+			version.MustParse("2.3.0"),
+		),
+		Timestamp: time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC),
+		Level:     loggo.WARNING,
+		Message:   "uh-oh",
+	}
+	client := kafka.Client{
+		URL:        kafka.TopicURL(server.URL, "juju-logs"),
+		HTTPClient: http.DefaultClient,
+	}
+	err := client.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(req, gc.NotNil)
+	c.Check(req.URL.Path, gc.Equals, "/topics/juju-logs")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/vnd.kafka.json.v2+json")
+	c.Check(body.Records, jc.DeepEquals, []produceRecord{{
+		Key:   "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Value: webhook.NewRecord(rec),
+	}})
+}
+
+func (s *ClientSuite) TestSendFailure(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error_code":40401,"message":"Topic not found."}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := kafka.Client{
+		URL:        kafka.TopicURL(server.URL, "juju-logs"),
+		HTTPClient: http.DefaultClient,
+	}
+	err := client.Send([]logfwd.Record{{ID: 10, Message: "uh-oh"}})
+	c.Check(err, gc.ErrorMatches, `posting log records: 404 Not Found: {"error_code":40401,"message":"Topic not found."}`)
+}
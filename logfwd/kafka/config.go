@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kafka
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/webhook"
+)
+
+// validTopic matches the topic names accepted by Kafka.
+var validTopic = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+// RawConfig holds the raw configuration data for a connection to a
+// Kafka forwarding target.
+type RawConfig struct {
+	// URL is the https URL of the Kafka REST proxy.
+	URL string
+
+	// Topic is the Kafka topic to which log records are produced.
+	Topic string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the REST proxy's certificate when connecting.
+	// If it is not set then the system's root CAs are used.
+	CACert string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.proxyConfig().Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if !validTopic.MatchString(cfg.Topic) {
+		return errors.NotValidf("Topic %q", cfg.Topic)
+	}
+	return nil
+}
+
+func (cfg RawConfig) proxyConfig() webhook.RawConfig {
+	return webhook.RawConfig{
+		URL:    cfg.URL,
+		CACert: cfg.CACert,
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kafka_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/kafka"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := kafka.RawConfig{
+		URL:    "https://kafka-rest.example.com:8082",
+		Topic:  "juju-logs",
+		CACert: coretesting.CACert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg kafka.RawConfig
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty URL`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadURL(c *gc.C) {
+	cfg := kafka.RawConfig{
+		URL:   "kafka.example.com:9092",
+		Topic: "juju-logs",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ConfigSuite) TestRawValidateBadTopic(c *gc.C) {
+	for _, topic := range []string{"", "juju logs", "juju/logs"} {
+		cfg := kafka.RawConfig{
+			URL:   "https://kafka-rest.example.com:8082",
+			Topic: topic,
+		}
+
+		err := cfg.Validate()
+
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, `Topic ".*" not valid`)
+	}
+}
@@ -0,0 +1,8 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The kafka package holds the tools needed to perform log forwarding
+// from Juju to a Kafka topic. Records are produced through a Kafka
+// REST proxy (such as the Confluent REST proxy), using its JSON
+// embedded format.
+package kafka
@@ -0,0 +1,202 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The sinkconfig package holds the configuration of the log sinks to
+// which a model's log records are forwarded.
+package sinkconfig
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/kafka"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/webhook"
+)
+
+// These are the supported log sink types.
+const (
+	TypeSyslog  = "syslog"
+	TypeWebhook = "webhook"
+	TypeKafka   = "kafka"
+)
+
+// DefaultSyslogName is the name of the syslog sink configured by the
+// syslog-* model config settings. The position of the last record
+// forwarded to it is recorded under this name.
+const DefaultSyslogName = "juju-log-forward"
+
+// Sink holds the configuration of a single log sink.
+type Sink struct {
+	// Name identifies the sink within the model.
+	Name string
+
+	// Type is the type of the sink: one of TypeSyslog, TypeWebhook
+	// or TypeKafka.
+	Type string
+
+	// Filter selects the records forwarded to the sink.
+	Filter logfwd.Filter
+
+	// Syslog holds the configuration of a syslog sink.
+	Syslog *syslog.RawConfig
+
+	// Webhook holds the configuration of a webhook sink.
+	Webhook *webhook.RawConfig
+
+	// Kafka holds the configuration of a Kafka sink.
+	Kafka *kafka.RawConfig
+}
+
+// Validate ensures that the sink config is correct.
+func (s Sink) Validate() error {
+	if s.Name == "" {
+		return errors.NewNotValid(nil, "empty Name")
+	}
+	var err error
+	switch s.Type {
+	case TypeSyslog:
+		if s.Syslog == nil {
+			return errors.NewNotValid(nil, "missing syslog config")
+		}
+		err = s.Syslog.Validate()
+	case TypeWebhook:
+		if s.Webhook == nil {
+			return errors.NewNotValid(nil, "missing webhook config")
+		}
+		err = s.Webhook.Validate()
+	case TypeKafka:
+		if s.Kafka == nil {
+			return errors.NewNotValid(nil, "missing kafka config")
+		}
+		err = s.Kafka.Validate()
+	default:
+		return errors.NotValidf("sink type %q", s.Type)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s config", s.Type)
+	}
+	if err := s.Filter.Validate(); err != nil {
+		return errors.Annotate(err, "invalid filter")
+	}
+	return nil
+}
+
+// Config holds a model's log forwarding configuration.
+type Config struct {
+	// Enabled is true if log forwarding is enabled.
+	Enabled bool
+
+	// Sinks holds the log sinks to which records are forwarded.
+	Sinks []Sink
+}
+
+// Validate ensures that the config is correct.
+func (cfg Config) Validate() error {
+	seen := make(map[string]bool)
+	for _, sink := range cfg.Sinks {
+		if seen[sink.Name] {
+			return errors.NotValidf("duplicate sink %q", sink.Name)
+		}
+		seen[sink.Name] = true
+		if err := sink.Validate(); err != nil {
+			return errors.Annotatef(err, "sink %q", sink.Name)
+		}
+	}
+	return nil
+}
+
+// Sink returns the config of the named sink, and whether it exists.
+func (cfg Config) Sink(name string) (Sink, bool) {
+	for _, sink := range cfg.Sinks {
+		if sink.Name == name {
+			return sink, true
+		}
+	}
+	return Sink{}, false
+}
+
+// sinkAttrs holds the YAML attributes of a sink.
+type sinkAttrs struct {
+	Type       string   `yaml:"type"`
+	Host       string   `yaml:"host"`
+	URL        string   `yaml:"url"`
+	Topic      string   `yaml:"topic"`
+	CACert     string   `yaml:"ca-cert"`
+	ClientCert string   `yaml:"client-cert"`
+	ClientKey  string   `yaml:"client-key"`
+	Level      string   `yaml:"level"`
+	Modules    []string `yaml:"modules"`
+	Entities   []string `yaml:"entities"`
+}
+
+// Parse parses the log sinks described in YAML, as a map of sink name
+// to sink attributes, for example:
+//
+//	audit:
+//	  type: webhook
+//	  url: https://logs.example.com/juju
+//	  level: WARNING
+//	  modules: [juju.apiserver]
+//	  entities: [machine-0]
+//
+// The sinks are returned sorted by name. Parse does not validate the
+// sinks' configuration.
+func Parse(value string) ([]Sink, error) {
+	var attrs map[string]sinkAttrs
+	if err := yaml.Unmarshal([]byte(value), &attrs); err != nil {
+		return nil, errors.Annotate(err, "parsing log sinks")
+	}
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sinks := make([]Sink, 0, len(attrs))
+	for _, name := range names {
+		a := attrs[name]
+		sink := Sink{
+			Name: name,
+			Type: a.Type,
+			Filter: logfwd.Filter{
+				Modules:  a.Modules,
+				Entities: a.Entities,
+			},
+		}
+		if a.Level != "" {
+			level, ok := loggo.ParseLevel(a.Level)
+			if !ok {
+				return nil, errors.NotValidf("sink %q level %q", name, a.Level)
+			}
+			sink.Filter.Level = level
+		}
+		switch a.Type {
+		case TypeSyslog:
+			sink.Syslog = &syslog.RawConfig{
+				Enabled:    true,
+				Host:       a.Host,
+				CACert:     a.CACert,
+				ClientCert: a.ClientCert,
+				ClientKey:  a.ClientKey,
+			}
+		case TypeWebhook:
+			sink.Webhook = &webhook.RawConfig{
+				URL:    a.URL,
+				CACert: a.CACert,
+			}
+		case TypeKafka:
+			sink.Kafka = &kafka.RawConfig{
+				URL:    a.URL,
+				Topic:  a.Topic,
+				CACert: a.CACert,
+			}
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
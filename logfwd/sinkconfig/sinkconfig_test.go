@@ -0,0 +1,203 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinkconfig_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/kafka"
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/webhook"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SinkConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinkConfigSuite{})
+
+func (s *SinkConfigSuite) TestParse(c *gc.C) {
+	sinks, err := sinkconfig.Parse(`
+webhook:
+  type: webhook
+  url: https://logs.example.com/juju
+  level: warning
+  modules: [juju.apiserver]
+  entities: [machine-0, unit-mysql-0]
+kafka:
+  type: kafka
+  url: https://kafka-rest.example.com:8082
+  topic: juju-logs
+  ca-cert: <ca-cert>
+syslog:
+  type: syslog
+  host: syslog.example.com:6514
+  ca-cert: <ca-cert>
+  client-cert: <client-cert>
+  client-key: <client-key>
+`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(sinks, jc.DeepEquals, []sinkconfig.Sink{{
+		Name: "kafka",
+		Type: sinkconfig.TypeKafka,
+		Kafka: &kafka.RawConfig{
+			URL:    "https://kafka-rest.example.com:8082",
+			Topic:  "juju-logs",
+			CACert: "<ca-cert>",
+		},
+	}, {
+		Name: "syslog",
+		Type: sinkconfig.TypeSyslog,
+		Syslog: &syslog.RawConfig{
+			Enabled:    true,
+			Host:       "syslog.example.com:6514",
+			CACert:     "<ca-cert>",
+			ClientCert: "<client-cert>",
+			ClientKey:  "<client-key>",
+		},
+	}, {
+		Name: "webhook",
+		Type: sinkconfig.TypeWebhook,
+		Filter: logfwd.Filter{
+			Level:    loggo.WARNING,
+			Modules:  []string{"juju.apiserver"},
+			Entities: []string{"machine-0", "unit-mysql-0"},
+		},
+		Webhook: &webhook.RawConfig{
+			URL: "https://logs.example.com/juju",
+		},
+	}})
+}
+
+func (s *SinkConfigSuite) TestParseEmpty(c *gc.C) {
+	sinks, err := sinkconfig.Parse("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(sinks, gc.HasLen, 0)
+}
+
+func (s *SinkConfigSuite) TestParseBadYAML(c *gc.C) {
+	_, err := sinkconfig.Parse("- webhook")
+	c.Check(err, gc.ErrorMatches, `parsing log sinks: yaml: .*`)
+}
+
+func (s *SinkConfigSuite) TestParseBadLevel(c *gc.C) {
+	_, err := sinkconfig.Parse(`
+webhook:
+  type: webhook
+  url: https://logs.example.com/juju
+  level: loud
+`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `sink "webhook" level "loud" not valid`)
+}
+
+func (s *SinkConfigSuite) TestValidate(c *gc.C) {
+	cfg := sinkconfig.Config{
+		Enabled: true,
+		Sinks: []sinkconfig.Sink{{
+			Name: "syslog",
+			Type: sinkconfig.TypeSyslog,
+			Syslog: &syslog.RawConfig{
+				Enabled:    true,
+				Host:       "syslog.example.com",
+				CACert:     coretesting.CACert,
+				ClientCert: coretesting.ServerCert,
+				ClientKey:  coretesting.ServerKey,
+			},
+		}, {
+			Name: "webhook",
+			Type: sinkconfig.TypeWebhook,
+			Webhook: &webhook.RawConfig{
+				URL: "https://logs.example.com/juju",
+			},
+			Filter: logfwd.Filter{
+				Entities: []string{"machine-0"},
+			},
+		}},
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *SinkConfigSuite) TestValidateDuplicate(c *gc.C) {
+	sink := sinkconfig.Sink{
+		Name: "webhook",
+		Type: sinkconfig.TypeWebhook,
+		Webhook: &webhook.RawConfig{
+			URL: "https://logs.example.com/juju",
+		},
+	}
+	cfg := sinkconfig.Config{Sinks: []sinkconfig.Sink{sink, sink}}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `duplicate sink "webhook" not valid`)
+}
+
+func (s *SinkConfigSuite) TestValidateSink(c *gc.C) {
+	for i, test := range []struct {
+		sink sinkconfig.Sink
+		err  string
+	}{{
+		sink: sinkconfig.Sink{Type: sinkconfig.TypeWebhook},
+		err:  `empty Name`,
+	}, {
+		sink: sinkconfig.Sink{Name: "x", Type: "carrier-pigeon"},
+		err:  `sink type "carrier-pigeon" not valid`,
+	}, {
+		sink: sinkconfig.Sink{Name: "x", Type: sinkconfig.TypeKafka},
+		err:  `missing kafka config`,
+	}, {
+		sink: sinkconfig.Sink{
+			Name:    "x",
+			Type:    sinkconfig.TypeWebhook,
+			Webhook: &webhook.RawConfig{URL: "http://logs.example.com"},
+		},
+		err: `invalid webhook config: URL "http://logs.example.com" \(expected https URL\) not valid`,
+	}, {
+		sink: sinkconfig.Sink{
+			Name:   "x",
+			Type:   sinkconfig.TypeSyslog,
+			Syslog: &syslog.RawConfig{Enabled: true},
+		},
+		err: `invalid syslog config: Host "" not valid`,
+	}, {
+		sink: sinkconfig.Sink{
+			Name:    "x",
+			Type:    sinkconfig.TypeWebhook,
+			Webhook: &webhook.RawConfig{URL: "https://logs.example.com"},
+			Filter:  logfwd.Filter{Entities: []string{"bob"}},
+		},
+		err: `invalid filter: invalid entity: "bob" is not a valid tag`,
+	}} {
+		c.Logf("test %d", i)
+		err := test.sink.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *SinkConfigSuite) TestSink(c *gc.C) {
+	cfg := sinkconfig.Config{
+		Sinks: []sinkconfig.Sink{{Name: "a"}, {Name: "b"}},
+	}
+
+	sink, ok := cfg.Sink("b")
+	c.Check(ok, jc.IsTrue)
+	c.Check(sink.Name, gc.Equals, "b")
+
+	_, ok = cfg.Sink("c")
+	c.Check(ok, jc.IsFalse)
+}
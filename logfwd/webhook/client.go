@@ -0,0 +1,157 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// sendTimeout is the time allowed for each batch of records to be
+// posted.
+const sendTimeout = 30 * time.Second
+
+// Record is the JSON representation of a log record, as posted to a
+// webhook.
+type Record struct {
+	ID             int64     `json:"id"`
+	Timestamp      time.Time `json:"timestamp"`
+	Level          string    `json:"level"`
+	Module         string    `json:"module"`
+	Location       string    `json:"location,omitempty"`
+	ControllerUUID string    `json:"controller-uuid"`
+	ModelUUID      string    `json:"model-uuid"`
+	Entity         string    `json:"entity,omitempty"`
+	Hostname       string    `json:"hostname,omitempty"`
+	Message        string    `json:"message"`
+}
+
+// NewRecord returns the JSON representation of the log record.
+func NewRecord(rec logfwd.Record) Record {
+	r := Record{
+		ID:             rec.ID,
+		Timestamp:      rec.Timestamp.UTC(),
+		Level:          rec.Level.String(),
+		Module:         rec.Location.Module,
+		Location:       rec.Location.String(),
+		ControllerUUID: rec.Origin.ControllerUUID,
+		ModelUUID:      rec.Origin.ModelUUID,
+		Hostname:       rec.Origin.Hostname,
+		Message:        rec.Message,
+	}
+	if tag := rec.Origin.Tag(); tag != nil {
+		r.Entity = tag.String()
+	}
+	return r
+}
+
+// Client posts log records to a webhook.
+type Client struct {
+	// URL is the URL to which records are posted.
+	URL string
+
+	// HTTPClient is the HTTP client used to post records.
+	HTTPClient *http.Client
+}
+
+// Open returns a new client for the webhook.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	httpClient, err := NewHTTPClient(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		URL:        cfg.URL,
+		HTTPClient: httpClient,
+	}, nil
+}
+
+// NewHTTPClient returns an HTTP client that connects using the
+// TLS configuration in the config.
+func NewHTTPClient(cfg RawConfig) (*http.Client, error) {
+	tlsConfig, err := cfg.TLSConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+		Timeout: sendTimeout,
+	}, nil
+}
+
+// Send posts the records to the webhook as a JSON array.
+func (client Client) Send(records []logfwd.Record) error {
+	body := make([]Record, len(records))
+	for i, rec := range records {
+		body[i] = NewRecord(rec)
+	}
+	err := PostJSON(client.HTTPClient, client.URL, "application/json", body)
+	return errors.Annotate(err, "posting log records")
+}
+
+// Close implements io.Closer. There is no connection to close.
+func (client Client) Close() error {
+	return nil
+}
+
+// PostJSON posts the value, encoded as JSON with the given content
+// type, to the URL. If the response is not 2xx, the error returned is
+// a *ResponseError.
+func PostJSON(httpClient *http.Client, url, contentType string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := httpClient.Post(url, contentType, bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxMessageSize))
+		return &ResponseError{
+			Status:  resp.Status,
+			Message: string(bytes.TrimSpace(message)),
+		}
+	}
+	return nil
+}
+
+// maxMessageSize is the maximum number of bytes of an unsuccessful
+// response's body that are recorded in a ResponseError.
+const maxMessageSize = 1024
+
+// ResponseError is the error returned by PostJSON when the server
+// responds with a status other than 2xx.
+type ResponseError struct {
+	// Status is the response's status line, e.g. "404 Not Found".
+	Status string
+
+	// Message holds the start of the response's body, with
+	// surrounding white space removed.
+	Message string
+}
+
+// Error implements error.
+func (e *ResponseError) Error() string {
+	if e.Message == "" {
+		return e.Status
+	}
+	return fmt.Sprintf("%s: %s", e.Status, e.Message)
+}
@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhook_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/webhook"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	status   int
+	requests []*http.Request
+	bodies   [][]webhook.Record
+	server   *httptest.Server
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.status = http.StatusOK
+	s.requests = nil
+	s.bodies = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body []webhook.Record
+		err := json.NewDecoder(req.Body).Decode(&body)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests = append(s.requests, req)
+		s.bodies = append(s.bodies, body)
+		w.WriteHeader(s.status)
+		if s.status != http.StatusOK {
+			w.Write([]byte("no thanks\n"))
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *ClientSuite) client() webhook.Client {
+	return webhook.Client{
+		URL:        s.server.URL + "/juju",
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+func (s *ClientSuite) TestOpen(c *gc.C) {
+	client, err := webhook.Open(webhook.RawConfig{
+		URL: "https://logs.example.com/juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(client.URL, gc.Equals, "https://logs.example.com/juju")
+	c.Check(client.HTTPClient, gc.NotNil)
+}
+
+func (s *ClientSuite) TestOpenInvalid(c *gc.C) {
+	_, err := webhook.Open(webhook.RawConfig{
+		URL: "ftp://logs.example.com/juju",
+	})
+	c.Check(err, gc.ErrorMatches, `URL "ftp://logs.example.com/juju" \(expected https URL\) not valid`)
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	rec0 := testRecord
+	rec1 := testRecord
+	rec1.ID = 11
+	rec1.Message = "it's all good"
+
+	err := s.client().Send([]logfwd.Record{rec0, rec1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].Method, gc.Equals, "POST")
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/juju")
+	c.Check(s.requests[0].Header.Get("Content-Type"), gc.Equals, "application/json")
+	c.Check(s.bodies[0], jc.DeepEquals, []webhook.Record{
+		webhook.NewRecord(rec0),
+		webhook.NewRecord(rec1),
+	})
+}
+
+func (s *ClientSuite) TestSendFailure(c *gc.C) {
+	s.status = http.StatusServiceUnavailable
+
+	err := s.client().Send([]logfwd.Record{testRecord})
+	c.Check(err, gc.ErrorMatches, `posting log records: 503 Service Unavailable: no thanks`)
+}
+
+func (s *ClientSuite) TestNewRecord(c *gc.C) {
+	c.Check(webhook.NewRecord(testRecord), jc.DeepEquals, webhook.Record{
+		ID:             10,
+		Timestamp:      time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC),
+		Level:          "ERROR",
+		Module:         "juju.worker.uniter",
+		Location:       "uniter.go:42",
+		ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Entity:         "unit-mysql-0",
+		Hostname:       "unit-mysql-0.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Message:        "uh-oh",
+	})
+}
+
+var testRecord = logfwd.Record{
+	ID: 10,
+	Origin: logfwd.OriginForUnitAgent(
+		names.NewUnitTag("mysql/0"),
+		"9f484882-2f18-4fd2-967d-db9663db7bea",
+		"deadbeef-2f18-4fd2-967d-db9663db7bea",
+		version.MustParse("2.3.0"),
+	),
+	Timestamp: time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC),
+	Level:     loggo.ERROR,
+	Location: logfwd.SourceLocation{
+		Module:   "juju.worker.uniter",
+		Filename: "uniter.go",
+		Line:     42,
+	},
+	Message: "uh-oh",
+}
@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhook
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+// RawConfig holds the raw configuration data for a connection to a
+// webhook forwarding target.
+type RawConfig struct {
+	// URL is the https URL to which log records are posted.
+	URL string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If it
+	// is not set then the system's root CAs are used.
+	CACert string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.URL == "" {
+		return errors.NewNotValid(nil, "empty URL")
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NewNotValid(err, "invalid URL")
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.NotValidf("URL %q (expected https URL)", cfg.URL)
+	}
+	if _, err := cfg.TLSConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	return nil
+}
+
+// TLSConfig returns the TLS configuration to use when connecting to
+// the webhook.
+func (cfg RawConfig) TLSConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return &tls.Config{}, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+	return &tls.Config{
+		RootCAs: rootCAs,
+	}, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package webhook_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/webhook"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := webhook.RawConfig{
+		URL:    "https://logs.example.com/juju",
+		CACert: coretesting.CACert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateWithoutCACert(c *gc.C) {
+	cfg := webhook.RawConfig{
+		URL: "https://logs.example.com:8443/juju",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg webhook.RawConfig
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `empty URL`)
+}
+
+func (s *ConfigSuite) TestRawValidateNotHTTPS(c *gc.C) {
+	cfg := webhook.RawConfig{
+		URL: "http://logs.example.com/juju",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `URL "http://logs.example.com/juju" \(expected https URL\) not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := webhook.RawConfig{
+		URL:    "https://logs.example.com/juju",
+		CACert: "abc",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: no certificates found`)
+}
+
+func (s *ConfigSuite) TestTLSConfig(c *gc.C) {
+	cfg := webhook.RawConfig{
+		URL:    "https://logs.example.com/juju",
+		CACert: coretesting.CACert,
+	}
+
+	tlsConfig, err := cfg.TLSConfig()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(tlsConfig.RootCAs.Subjects(), gc.HasLen, 1)
+}
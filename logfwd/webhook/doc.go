@@ -0,0 +1,7 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The webhook package holds the tools needed to perform log forwarding
+// from Juju to an HTTPS endpoint, which receives batches of log records
+// as JSON.
+package webhook
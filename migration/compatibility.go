@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+
+	coremigration "github.com/juju/juju/core/migration"
+)
+
+// MinFreeSpace is the disk space, in bytes, that must remain free on
+// the target controller once a model's binaries have been transferred
+// to it.
+var MinFreeSpace = uint64(250 * humanize.MiByte)
+
+// CompatibilityBackend defines what the compatibility checks need to
+// know about the target controller.
+type CompatibilityBackend interface {
+	// AgentVersion returns the target controller's agent version.
+	AgentVersion() (version.Number, error)
+
+	// CloudType returns the type of the named cloud, or an error
+	// satisfying errors.IsNotFound if the cloud is not known to the
+	// target controller.
+	CloudType(cloud string) (string, error)
+
+	// StorageProviderTypes returns the storage provider types that
+	// the target controller supports for clouds of the given type.
+	// If the supported types cannot all be determined, the types
+	// known to be supported are returned, along with false.
+	StorageProviderTypes(cloudType string) ([]string, bool, error)
+
+	// FreeSpace returns the disk space, in bytes, available to the
+	// target controller for storing the model's binaries.
+	FreeSpace() (uint64, error)
+}
+
+// TargetCompatibility checks whether the target controller can host
+// the described model, returning a report of the outcome of each
+// check. The backend provided must be for the target controller.
+func TargetCompatibility(
+	backend CompatibilityBackend,
+	modelInfo coremigration.ModelInfo,
+	reqs coremigration.ModelRequirements,
+) (coremigration.CompatibilityReport, error) {
+	var report coremigration.CompatibilityReport
+
+	check, err := checkVersionCompatibility(backend, modelInfo)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	report.Checks = append(report.Checks, check)
+
+	cloudType, err := backend.CloudType(reqs.Cloud)
+	if errors.IsNotFound(err) {
+		report.Checks = append(report.Checks, failedCheck("cloud", "cloud %q not known to target controller", reqs.Cloud))
+	} else if err != nil {
+		return report, errors.Annotate(err, "retrieving cloud")
+	} else {
+		report.Checks = append(report.Checks, okCheck("cloud"))
+		check, err := checkStorageCompatibility(backend, cloudType, reqs.StorageProviders)
+		if err != nil {
+			return report, errors.Trace(err)
+		}
+		report.Checks = append(report.Checks, check)
+	}
+
+	check, err = checkCapacity(backend, reqs.BinariesSize)
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	report.Checks = append(report.Checks, check)
+	return report, nil
+}
+
+func checkVersionCompatibility(backend CompatibilityBackend, modelInfo coremigration.ModelInfo) (coremigration.CompatibilityCheck, error) {
+	controllerVersion, err := backend.AgentVersion()
+	if err != nil {
+		return coremigration.CompatibilityCheck{}, errors.Annotate(err, "retrieving controller version")
+	}
+	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
+		return failedCheck("version", "model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion), nil
+	}
+	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
+		return failedCheck("version", "source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion), nil
+	}
+	return okCheck("version"), nil
+}
+
+func checkStorageCompatibility(backend CompatibilityBackend, cloudType string, required []string) (coremigration.CompatibilityCheck, error) {
+	if len(required) == 0 {
+		return okCheck("storage"), nil
+	}
+	types, complete, err := backend.StorageProviderTypes(cloudType)
+	if err != nil {
+		return coremigration.CompatibilityCheck{}, errors.Annotate(err, "retrieving storage provider types")
+	}
+	missing := set.NewStrings(required...).Difference(set.NewStrings(types...))
+	switch {
+	case missing.IsEmpty():
+		return okCheck("storage"), nil
+	case complete:
+		return failedCheck("storage", "storage providers not supported by target controller: %s",
+			strings.Join(missing.SortedValues(), ", ")), nil
+	}
+	return coremigration.CompatibilityCheck{
+		Name:   "storage",
+		Status: coremigration.CompatibilityWarning,
+		Message: fmt.Sprintf("cannot verify support for storage providers: %s",
+			strings.Join(missing.SortedValues(), ", ")),
+	}, nil
+}
+
+func checkCapacity(backend CompatibilityBackend, binariesSize uint64) (coremigration.CompatibilityCheck, error) {
+	free, err := backend.FreeSpace()
+	if err != nil {
+		return coremigration.CompatibilityCheck{}, errors.Annotate(err, "retrieving free disk space")
+	}
+	if needed := binariesSize + MinFreeSpace; free < needed {
+		return failedCheck("capacity", "not enough free disk space on target controller: %s available, require %s",
+			humanize.IBytes(free), humanize.IBytes(needed)), nil
+	}
+	return okCheck("capacity"), nil
+}
+
+func okCheck(name string) coremigration.CompatibilityCheck {
+	return coremigration.CompatibilityCheck{Name: name, Status: coremigration.CompatibilityOK}
+}
+
+func failedCheck(name, format string, args ...interface{}) coremigration.CompatibilityCheck {
+	return coremigration.CompatibilityCheck{
+		Name:    name,
+		Status:  coremigration.CompatibilityFailed,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
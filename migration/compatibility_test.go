@@ -0,0 +1,161 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/testing"
+)
+
+type CompatibilitySuite struct {
+	testing.BaseSuite
+	backend *fakeCompatibilityBackend
+	info    coremigration.ModelInfo
+	reqs    coremigration.ModelRequirements
+}
+
+var _ = gc.Suite(&CompatibilitySuite{})
+
+func (s *CompatibilitySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &fakeCompatibilityBackend{
+		agentVersion:     backendVersion,
+		clouds:           map[string]string{"aws": "ec2"},
+		storageProviders: []string{"ebs", "loop", "rootfs"},
+		complete:         true,
+		freeSpace:        migration.MinFreeSpace + 1000,
+	}
+	s.info = coremigration.ModelInfo{
+		UUID:                   modelUUID,
+		Owner:                  modelOwner,
+		Name:                   modelName,
+		AgentVersion:           backendVersion,
+		ControllerAgentVersion: backendVersion,
+	}
+	s.reqs = coremigration.ModelRequirements{
+		Cloud:            "aws",
+		StorageProviders: []string{"ebs", "loop"},
+		BinariesSize:     1000,
+	}
+}
+
+func (s *CompatibilitySuite) check(c *gc.C) coremigration.CompatibilityReport {
+	report, err := migration.TargetCompatibility(s.backend, s.info, s.reqs)
+	c.Assert(err, jc.ErrorIsNil)
+	return report
+}
+
+func (s *CompatibilitySuite) TestCompatible(c *gc.C) {
+	report := s.check(c)
+	c.Assert(report, jc.DeepEquals, coremigration.CompatibilityReport{
+		Checks: []coremigration.CompatibilityCheck{
+			{Name: "version", Status: coremigration.CompatibilityOK},
+			{Name: "cloud", Status: coremigration.CompatibilityOK},
+			{Name: "storage", Status: coremigration.CompatibilityOK},
+			{Name: "capacity", Status: coremigration.CompatibilityOK},
+		},
+	})
+	c.Assert(report.Go(), jc.IsTrue)
+}
+
+func (s *CompatibilitySuite) TestModelVersionTooHigh(c *gc.C) {
+	s.info.AgentVersion = version.MustParse("1.2.4")
+	report := s.check(c)
+	c.Assert(report.Checks[0], jc.DeepEquals, coremigration.CompatibilityCheck{
+		Name:    "version",
+		Status:  coremigration.CompatibilityFailed,
+		Message: "model has higher version than target controller (1.2.4 > 1.2.3)",
+	})
+	c.Assert(report.Go(), jc.IsFalse)
+}
+
+func (s *CompatibilitySuite) TestSourceControllerVersionTooHigh(c *gc.C) {
+	s.info.ControllerAgentVersion = version.MustParse("1.3.0")
+	report := s.check(c)
+	c.Assert(report.Checks[0], jc.DeepEquals, coremigration.CompatibilityCheck{
+		Name:    "version",
+		Status:  coremigration.CompatibilityFailed,
+		Message: "source controller has higher version than target controller (1.3.0 > 1.2.3)",
+	})
+}
+
+func (s *CompatibilitySuite) TestUnknownCloud(c *gc.C) {
+	s.reqs.Cloud = "gce"
+	report := s.check(c)
+	c.Assert(report.Checks, jc.DeepEquals, []coremigration.CompatibilityCheck{
+		{Name: "version", Status: coremigration.CompatibilityOK},
+		{Name: "cloud", Status: coremigration.CompatibilityFailed, Message: `cloud "gce" not known to target controller`},
+		{Name: "capacity", Status: coremigration.CompatibilityOK},
+	})
+}
+
+func (s *CompatibilitySuite) TestMissingStorageProvider(c *gc.C) {
+	s.reqs.StorageProviders = []string{"ebs", "tmpfs", "cinder"}
+	report := s.check(c)
+	c.Assert(report.Checks[2], jc.DeepEquals, coremigration.CompatibilityCheck{
+		Name:    "storage",
+		Status:  coremigration.CompatibilityFailed,
+		Message: "storage providers not supported by target controller: cinder, tmpfs",
+	})
+}
+
+func (s *CompatibilitySuite) TestUnverifiedStorageProvider(c *gc.C) {
+	s.backend.complete = false
+	s.backend.storageProviders = []string{"loop"}
+	report := s.check(c)
+	c.Assert(report.Checks[2], jc.DeepEquals, coremigration.CompatibilityCheck{
+		Name:    "storage",
+		Status:  coremigration.CompatibilityWarning,
+		Message: "cannot verify support for storage providers: ebs",
+	})
+	c.Assert(report.Go(), jc.IsTrue)
+}
+
+func (s *CompatibilitySuite) TestNotEnoughSpace(c *gc.C) {
+	s.backend.freeSpace = 1000
+	report := s.check(c)
+	c.Assert(report.Checks[3].Status, gc.Equals, coremigration.CompatibilityFailed)
+	c.Assert(report.Checks[3].Message, gc.Matches, "not enough free disk space on target controller: 1000 B available, require .*")
+}
+
+func (s *CompatibilitySuite) TestBackendError(c *gc.C) {
+	s.backend.freeSpaceErr = errors.New("boom")
+	_, err := migration.TargetCompatibility(s.backend, s.info, s.reqs)
+	c.Assert(err, gc.ErrorMatches, "retrieving free disk space: boom")
+}
+
+type fakeCompatibilityBackend struct {
+	agentVersion     version.Number
+	clouds           map[string]string
+	storageProviders []string
+	complete         bool
+	freeSpace        uint64
+	freeSpaceErr     error
+}
+
+func (b *fakeCompatibilityBackend) AgentVersion() (version.Number, error) {
+	return b.agentVersion, nil
+}
+
+func (b *fakeCompatibilityBackend) CloudType(cloud string) (string, error) {
+	cloudType, ok := b.clouds[cloud]
+	if !ok {
+		return "", errors.NotFoundf("cloud %q", cloud)
+	}
+	return cloudType, nil
+}
+
+func (b *fakeCompatibilityBackend) StorageProviderTypes(string) ([]string, bool, error) {
+	return b.storageProviders, b.complete, nil
+}
+
+func (b *fakeCompatibilityBackend) FreeSpace() (uint64, error) {
+	return b.freeSpace, b.freeSpaceErr
+}
@@ -80,6 +80,7 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	Labels() map[string]string
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -264,32 +265,38 @@ func checkMachines(backend PrecheckBackend) error {
 		return errors.Annotate(err, "retrieving machines")
 	}
 	for _, machine := range machines {
-		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+		if err := checkMachine(machine); err != nil {
+			return errors.Trace(err)
 		}
-
-		if statusInfo, err := machine.InstanceStatus(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
-		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
+			return errors.Trace(err)
 		}
+	}
+	return nil
+}
 
-		if statusInfo, err := common.MachineStatus(machine); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
-		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
-		}
+func checkMachine(machine PrecheckMachine) error {
+	if machine.Life() != state.Alive {
+		return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+	}
 
-		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
-		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
-		}
+	if statusInfo, err := machine.InstanceStatus(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
+	} else if statusInfo.Status != status.Running {
+		return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+	}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
-			return errors.Trace(err)
-		}
+	if statusInfo, err := common.MachineStatus(machine); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
+	} else if statusInfo.Status != status.Started {
+		return newStatusError("machine %s agent not functioning at this time",
+			machine.Id(), statusInfo.Status)
+	}
+
+	if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
+	} else if rebootAction != state.ShouldDoNothing {
+		return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
 	}
 	return nil
 }
@@ -307,6 +314,9 @@ func checkApplications(backend PrecheckBackend) error {
 		if app.Life() != state.Alive {
 			return errors.Errorf("application %s is %s", app.Name(), app.Life())
 		}
+		if len(app.Labels()) > 0 {
+			return errors.Errorf("application %s has labels, which cannot be migrated", app.Name())
+		}
 		err := checkUnits(app, modelVersion)
 		if err != nil {
 			return errors.Trace(err)
@@ -13,6 +13,15 @@ import (
 
 // PrecheckShim wraps a *state.State to implement PrecheckBackend.
 func PrecheckShim(st *state.State) (PrecheckBackend, error) {
+	return newPrecheckShim(st)
+}
+
+// ValidationShim wraps a *state.State to implement ValidationBackend.
+func ValidationShim(st *state.State) (ValidationBackend, error) {
+	return newPrecheckShim(st)
+}
+
+func newPrecheckShim(st *state.State) (*precheckShim, error) {
 	rSt, err := st.Resources()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -114,6 +123,36 @@ func (s *precheckShim) ControllerBackend() (PrecheckBackendCloser, error) {
 	}, nil
 }
 
+// AllSpaceNames implements ValidationBackend.
+func (s *precheckShim) AllSpaceNames() ([]string, error) {
+	spaces, err := s.State.AllSpaces()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(spaces))
+	for i, space := range spaces {
+		names[i] = space.Name()
+	}
+	return names, nil
+}
+
+// AllEndpointBindings implements ValidationBackend.
+func (s *precheckShim) AllEndpointBindings() (map[string]map[string]string, error) {
+	apps, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make(map[string]map[string]string, len(apps))
+	for _, app := range apps {
+		bindings, err := app.EndpointBindings()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		out[app.Name()] = bindings
+	}
+	return out, nil
+}
+
 // PoolShim wraps a state.StatePool to produce a Pool.
 func PoolShim(pool *state.StatePool) Pool {
 	return &poolShim{pool}
@@ -168,6 +168,19 @@ func (s *SourcePrecheckSuite) TestDyingApplication(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "application foo is dying")
 }
 
+func (s *SourcePrecheckSuite) TestApplicationWithLabels(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:   "foo",
+				labels: map[string]string{"tier": "frontend"},
+			},
+		},
+	}
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has labels, which cannot be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestWithPendingMinUnits(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -844,6 +857,7 @@ type fakeApp struct {
 	charmURL string
 	units    []migration.PrecheckUnit
 	minunits int
+	labels   map[string]string
 }
 
 func (a *fakeApp) Name() string {
@@ -870,6 +884,10 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) Labels() map[string]string {
+	return a.labels
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
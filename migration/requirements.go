@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/description"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+
+	coremigration "github.com/juju/juju/core/migration"
+)
+
+// ModelRequirements returns what the exported model needs of the
+// controller it is migrated to.
+func ModelRequirements(model description.Model) coremigration.ModelRequirements {
+	return coremigration.ModelRequirements{
+		Cloud:            model.Cloud(),
+		Features:         getUsedFeatures(model),
+		StorageProviders: getUsedStorageProviders(model),
+		BinariesSize:     getBinariesSize(model),
+	}
+}
+
+func getUsedFeatures(model description.Model) []string {
+	result := set.NewStrings()
+	if len(model.Storages()) > 0 || len(model.Volumes()) > 0 || len(model.Filesystems()) > 0 {
+		result.Add(coremigration.FeatureStorage)
+	}
+	if len(model.Actions()) > 0 {
+		result.Add(coremigration.FeatureActions)
+	}
+	if len(model.RemoteApplications()) > 0 {
+		result.Add(coremigration.FeatureCrossModelRelation)
+	}
+	for _, application := range model.Applications() {
+		if len(application.Resources()) > 0 {
+			result.Add(coremigration.FeatureResources)
+		}
+		if len(application.MetricsCredentials()) > 0 {
+			result.Add(coremigration.FeatureMetrics)
+		}
+		for _, unit := range application.Units() {
+			if len(unit.Payloads()) > 0 {
+				result.Add(coremigration.FeaturePayloads)
+			}
+		}
+	}
+	return result.SortedValues()
+}
+
+func getUsedStorageProviders(model description.Model) []string {
+	// A volume or filesystem names either a storage pool, or a
+	// storage provider type directly.
+	poolProviders := make(map[string]string)
+	for _, pool := range model.StoragePools() {
+		poolProviders[pool.Name()] = pool.Provider()
+	}
+	result := set.NewStrings()
+	addPool := func(pool string) {
+		if pool == "" {
+			return
+		}
+		if provider, ok := poolProviders[pool]; ok {
+			result.Add(provider)
+		} else {
+			result.Add(pool)
+		}
+	}
+	for _, volume := range model.Volumes() {
+		addPool(volume.Pool())
+	}
+	for _, filesystem := range model.Filesystems() {
+		addPool(filesystem.Pool())
+	}
+	return result.SortedValues()
+}
+
+func getBinariesSize(model description.Model) uint64 {
+	toolsSizes := make(map[version.Binary]int64)
+	for _, machine := range model.Machines() {
+		addToolsSizeForMachine(machine, toolsSizes)
+	}
+	var size int64
+	for _, application := range model.Applications() {
+		for _, unit := range application.Units() {
+			tools := unit.Tools()
+			toolsSizes[tools.Version()] = tools.Size()
+		}
+		for _, resource := range application.Resources() {
+			if rev := resource.ApplicationRevision(); rev != nil {
+				size += rev.Size()
+			}
+		}
+	}
+	for _, toolsSize := range toolsSizes {
+		size += toolsSize
+	}
+	return uint64(size)
+}
+
+func addToolsSizeForMachine(machine description.Machine, toolsSizes map[version.Binary]int64) {
+	tools := machine.Tools()
+	toolsSizes[tools.Version()] = tools.Size()
+	for _, container := range machine.Containers() {
+		addToolsSizeForMachine(container, toolsSizes)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+)
+
+// ValidationBackend defines the interface to query Juju's state for
+// validating a model migration without starting it.
+type ValidationBackend interface {
+	PrecheckBackend
+
+	// AllSpaceNames returns the names of the model's spaces.
+	AllSpaceNames() ([]string, error)
+
+	// AllEndpointBindings returns the endpoint bindings of each of
+	// the model's applications, keyed by application name.
+	AllEndpointBindings() (map[string]map[string]string, error)
+}
+
+// ValidateSource checks the model and source controller like
+// SourcePrecheck does, but rather than stopping at the first problem
+// found, it reports every blocker that it finds. An error is returned
+// only if the checks could not be made.
+func ValidateSource(backend ValidationBackend) (coremigration.ValidationReport, error) {
+	var report coremigration.ValidationReport
+
+	if err := checkModel(backend); err != nil {
+		report.Block(coremigration.BlockerModel, "%v", err)
+	}
+	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
+		return report, errors.Annotate(err, "checking cleanups")
+	} else if cleanupNeeded {
+		report.Block(coremigration.BlockerModel, "cleanup needed")
+	}
+
+	modelVersion, err := backend.AgentVersion()
+	if err != nil {
+		return report, errors.Annotate(err, "retrieving model version")
+	}
+	if err := validateMachines(backend, modelVersion, &report); err != nil {
+		return report, errors.Trace(err)
+	}
+	if err := validateApplications(backend, modelVersion, &report); err != nil {
+		return report, errors.Trace(err)
+	}
+	if err := validateSpaces(backend, &report); err != nil {
+		return report, errors.Trace(err)
+	}
+
+	controllerBackend, err := backend.ControllerBackend()
+	if err != nil {
+		return report, errors.Trace(err)
+	}
+	defer controllerBackend.Close()
+	if err := checkController(controllerBackend); err != nil {
+		report.Block(coremigration.BlockerController, "%v", err)
+	}
+	return report, nil
+}
+
+func validateMachines(backend PrecheckBackend, modelVersion version.Number, report *coremigration.ValidationReport) error {
+	machines, err := backend.AllMachines()
+	if err != nil {
+		return errors.Annotate(err, "retrieving machines")
+	}
+	for _, machine := range machines {
+		if err := checkMachine(machine); err != nil {
+			report.Block(coremigration.BlockerMachine, "%v", err)
+		}
+		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
+			report.Block(coremigration.BlockerAgentLag, "%v", err)
+		}
+	}
+	return nil
+}
+
+func validateApplications(backend PrecheckBackend, modelVersion version.Number, report *coremigration.ValidationReport) error {
+	apps, err := backend.AllApplications()
+	if err != nil {
+		return errors.Annotate(err, "retrieving applications")
+	}
+	for _, app := range apps {
+		if app.Life() != state.Alive {
+			report.Block(coremigration.BlockerApplication, "application %s is %s", app.Name(), app.Life())
+		}
+		if len(app.Labels()) > 0 {
+			report.Block(coremigration.BlockerUnsupportedFeature, "application %s has labels, which cannot be migrated", app.Name())
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return errors.Annotatef(err, "retrieving units for %s", app.Name())
+		}
+		if len(units) < app.MinUnits() {
+			report.Block(coremigration.BlockerApplication, "application %s is below its minimum units threshold", app.Name())
+		}
+		appCharmURL, _ := app.CharmURL()
+		for _, unit := range units {
+			if unit.Life() != state.Alive {
+				report.Block(coremigration.BlockerApplication, "unit %s is %s", unit.Name(), unit.Life())
+			}
+			if err := checkUnitAgentStatus(unit); err != nil {
+				report.Block(coremigration.BlockerApplication, "%v", err)
+			}
+			if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+				report.Block(coremigration.BlockerAgentLag, "%v", err)
+			}
+			unitCharmURL, _ := unit.CharmURL()
+			if appCharmURL.String() != unitCharmURL.String() {
+				report.Block(coremigration.BlockerApplication, "unit %s is upgrading", unit.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// validateSpaces reports the application endpoints bound to spaces
+// that the model does not define, which cannot be recreated when the
+// model is imported into the target controller.
+func validateSpaces(backend ValidationBackend, report *coremigration.ValidationReport) error {
+	spaceNames, err := backend.AllSpaceNames()
+	if err != nil {
+		return errors.Annotate(err, "retrieving spaces")
+	}
+	spaces := set.NewStrings(spaceNames...)
+	bindings, err := backend.AllEndpointBindings()
+	if err != nil {
+		return errors.Annotate(err, "retrieving endpoint bindings")
+	}
+	appNames := make([]string, 0, len(bindings))
+	for appName := range bindings {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+	for _, appName := range appNames {
+		endpoints := make([]string, 0, len(bindings[appName]))
+		for endpoint := range bindings[appName] {
+			endpoints = append(endpoints, endpoint)
+		}
+		sort.Strings(endpoints)
+		for _, endpoint := range endpoints {
+			space := bindings[appName][endpoint]
+			if space == "" || spaces.Contains(space) {
+				continue
+			}
+			report.Block(coremigration.BlockerSpaceMismatch,
+				"application %s endpoint %q is bound to unknown space %q", appName, endpoint, space)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type ValidateSourceSuite struct {
+	precheckBaseSuite
+}
+
+var _ = gc.Suite(&ValidateSourceSuite{})
+
+func (*ValidateSourceSuite) TestSuccess(c *gc.C) {
+	backend := newFakeValidationBackend(newHappyBackend())
+	backend.controllerBackend = newHappyBackend()
+	report, err := migration.ValidateSource(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Go(), jc.IsTrue)
+}
+
+func (*ValidateSourceSuite) TestReportsAllBlockers(c *gc.C) {
+	fake := newFakeBackend()
+	fake.model.life = state.Dying
+	fake.cleanupNeeded = true
+	fake.machines = []migration.PrecheckMachine{
+		&fakeMachine{id: "0", status: status.Down},
+		&fakeMachine{id: "1", version: version.MustParseBinary("1.2.2-xenial-amd64")},
+	}
+	fake.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name:   "foo",
+			labels: map[string]string{"team": "db"},
+			units: []migration.PrecheckUnit{
+				&fakeUnit{name: "foo/0", charmURL: "cs:foo-0"},
+				&fakeUnit{name: "foo/1", version: version.MustParseBinary("1.2.2-xenial-amd64")},
+			},
+		},
+	}
+	fake.controllerBackend.isUpgrading = true
+	backend := newFakeValidationBackend(fake)
+	backend.spaces = []string{"db"}
+	backend.bindings = map[string]map[string]string{
+		"foo": {"": "", "server": "db", "admin": "mgmt"},
+	}
+
+	report, err := migration.ValidateSource(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Blockers, jc.DeepEquals, []coremigration.ValidationBlocker{
+		{Kind: coremigration.BlockerModel, Message: "model is dying"},
+		{Kind: coremigration.BlockerModel, Message: "cleanup needed"},
+		{Kind: coremigration.BlockerMachine, Message: "machine 0 agent not functioning at this time (down)"},
+		{Kind: coremigration.BlockerAgentLag, Message: "machine 1 tools don't match model (1.2.2 != 1.2.3)"},
+		{Kind: coremigration.BlockerUnsupportedFeature, Message: "application foo has labels, which cannot be migrated"},
+		{Kind: coremigration.BlockerApplication, Message: "unit foo/0 is upgrading"},
+		{Kind: coremigration.BlockerAgentLag, Message: "unit foo/1 tools don't match model (1.2.2 != 1.2.3)"},
+		{Kind: coremigration.BlockerSpaceMismatch, Message: `application foo endpoint "admin" is bound to unknown space "mgmt"`},
+		{Kind: coremigration.BlockerController, Message: "upgrade in progress"},
+	})
+}
+
+func (*ValidateSourceSuite) TestCleanupsError(c *gc.C) {
+	fake := newFakeBackend()
+	fake.cleanupErr = errors.New("boom")
+	_, err := migration.ValidateSource(newFakeValidationBackend(fake))
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
+func (*ValidateSourceSuite) TestSpacesError(c *gc.C) {
+	backend := newFakeValidationBackend(newFakeBackend())
+	backend.spacesErr = errors.New("boom")
+	_, err := migration.ValidateSource(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving spaces: boom")
+}
+
+func newFakeValidationBackend(backend *fakeBackend) *fakeValidationBackend {
+	return &fakeValidationBackend{fakeBackend: backend}
+}
+
+type fakeValidationBackend struct {
+	*fakeBackend
+
+	spaces    []string
+	spacesErr error
+	bindings  map[string]map[string]string
+}
+
+func (b *fakeValidationBackend) AllSpaceNames() ([]string, error) {
+	return b.spaces, b.spacesErr
+}
+
+func (b *fakeValidationBackend) AllEndpointBindings() (map[string]map[string]string, error) {
+	return b.bindings, nil
+}
@@ -41,13 +41,14 @@ func Payload2api(p payload.FullPayloadInfo) params.Payload {
 	}
 
 	return params.Payload{
-		Class:   p.Name,
-		Type:    p.Type,
-		ID:      p.ID,
-		Status:  p.Status,
-		Labels:  labels,
-		Unit:    unitTag,
-		Machine: machineTag,
+		Class:    p.Name,
+		Type:     p.Type,
+		ID:       p.ID,
+		Status:   p.Status,
+		Labels:   labels,
+		Unit:     unitTag,
+		Machine:  machineTag,
+		Restarts: p.Restarts,
 	}
 }
 
@@ -85,6 +86,7 @@ func API2Payload(apiInfo params.Payload) (payload.FullPayloadInfo, error) {
 			Labels: labels,
 			Unit:   unit,
 		},
-		Machine: machine,
+		Machine:  machine,
+		Restarts: apiInfo.Restarts,
 	}, nil
 }
@@ -32,29 +32,32 @@ func (helpersSuite) TestPayload2api(c *gc.C) {
 			Labels: []string{"a-tag"},
 			Unit:   "a-application/0",
 		},
-		Machine: "1",
+		Machine:  "1",
+		Restarts: 2,
 	})
 
 	c.Check(apiPayload, jc.DeepEquals, params.Payload{
-		Class:   "spam",
-		Type:    "docker",
-		ID:      "idspam",
-		Status:  payload.StateRunning,
-		Labels:  []string{"a-tag"},
-		Unit:    names.NewUnitTag("a-application/0").String(),
-		Machine: names.NewMachineTag("1").String(),
+		Class:    "spam",
+		Type:     "docker",
+		ID:       "idspam",
+		Status:   payload.StateRunning,
+		Labels:   []string{"a-tag"},
+		Unit:     names.NewUnitTag("a-application/0").String(),
+		Machine:  names.NewMachineTag("1").String(),
+		Restarts: 2,
 	})
 }
 
 func (helpersSuite) TestAPI2Payload(c *gc.C) {
 	pl, err := API2Payload(params.Payload{
-		Class:   "spam",
-		Type:    "docker",
-		ID:      "idspam",
-		Status:  payload.StateRunning,
-		Labels:  []string{"a-tag"},
-		Unit:    names.NewUnitTag("a-application/0").String(),
-		Machine: names.NewMachineTag("1").String(),
+		Class:    "spam",
+		Type:     "docker",
+		ID:       "idspam",
+		Status:   payload.StateRunning,
+		Labels:   []string{"a-tag"},
+		Unit:     names.NewUnitTag("a-application/0").String(),
+		Machine:  names.NewMachineTag("1").String(),
+		Restarts: 2,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -69,6 +72,7 @@ func (helpersSuite) TestAPI2Payload(c *gc.C) {
 			Labels: []string{"a-tag"},
 			Unit:   "a-application/0",
 		},
-		Machine: "1",
+		Machine:  "1",
+		Restarts: 2,
 	})
 }
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// ListCmdName is the name of the payload list command.
+const ListCmdName = "payload-list"
+
+// NewListCmd returns a new ListCmd that wraps the given context.
+func NewListCmd(ctx HookContext) (*ListCmd, error) {
+	return &ListCmd{hookContextFunc: componentHookContext(ctx)}, nil
+}
+
+// ListCmd is a command that lists the payloads registered for the unit.
+type ListCmd struct {
+	cmd.CommandBase
+
+	hookContextFunc func() (Component, error)
+	out             cmd.Output
+	details         bool
+}
+
+// Info implements cmd.Command.
+func (c ListCmd) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    ListCmdName,
+		Purpose: "list the payloads registered for the unit",
+		Doc: `
+"payload-list" is used while a hook is running to list the payloads that
+have been registered with juju using payload-register. By default only the
+"<class>/<id>" of each payload is shown; use --details to include the type,
+status and tags of each payload.
+`,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *ListCmd) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.BoolVar(&c.details, "details", false, "show the full details of each payload")
+}
+
+// Init implements cmd.Command.
+func (c *ListCmd) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *ListCmd) Run(ctx *cmd.Context) error {
+	hctx, err := c.hookContextFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	ids, err := hctx.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ids == nil {
+		ids = []string{}
+	}
+	if !c.details {
+		return c.out.Write(ctx, ids)
+	}
+
+	formatted := make([]FormattedPayload, 0, len(ids))
+	for _, fullID := range ids {
+		pl, err := getPayload(hctx, fullID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		formatted = append(formatted, formatPayload(*pl))
+	}
+	return c.out.Write(ctx, formatted)
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context_test
+
+import (
+	"bytes"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/payload/context"
+)
+
+type listSuite struct {
+	baseSuite
+
+	stub    *testing.Stub
+	compCtx *stubContextComponent
+	ctx     *cmd.Context
+}
+
+var _ = gc.Suite(&listSuite{})
+
+func (s *listSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.compCtx = newStubContextComponent(s.stub)
+	s.ctx = cmdtesting.Context(c)
+}
+
+func (s *listSuite) Component(name string) (context.Component, error) {
+	s.stub.AddCall("Component", name)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.compCtx, nil
+}
+
+func (s *listSuite) addPayload(name, id, status string) {
+	pl := s.newPayload(name, "docker", id, status)
+	s.compCtx.payloads[pl.FullID()] = pl
+}
+
+func (s *listSuite) TestHelp(c *gc.C) {
+	list, err := context.NewListCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	code := cmd.Main(list, s.ctx, []string{"--help"})
+	c.Assert(code, gc.Equals, 0)
+
+	c.Check(s.ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+Usage: payload-list [options]
+
+Summary:
+list the payloads registered for the unit
+
+Options:
+--details  (= false)
+    show the full details of each payload
+--format  (= smart)
+    Specify output format (json|smart|yaml)
+-o, --output (= "")
+    Specify an output file
+
+Details:
+"payload-list" is used while a hook is running to list the payloads that
+have been registered with juju using payload-register. By default only the
+"<class>/<id>" of each payload is shown; use --details to include the type,
+status and tags of each payload.
+`[1:])
+}
+
+func (s *listSuite) TestInitTooManyArgs(c *gc.C) {
+	list, err := context.NewListCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	err = list.Init([]string{"spam"})
+
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["spam"\]`)
+}
+
+func (s *listSuite) TestRunEmpty(c *gc.C) {
+	list, err := context.NewListCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	code := cmd.Main(list, s.ctx, []string{"--format", "json"})
+	c.Assert(code, gc.Equals, 0)
+
+	c.Check(s.ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, "[]\n")
+	s.stub.CheckCallNames(c, "Component", "List")
+}
+
+func (s *listSuite) TestRunIDs(c *gc.C) {
+	s.addPayload("spam", "abc123", payload.StateRunning)
+	list, err := context.NewListCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	code := cmd.Main(list, s.ctx, []string{"--format", "yaml"})
+	c.Assert(code, gc.Equals, 0)
+
+	c.Check(s.ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, "- spam/abc123\n")
+	s.stub.CheckCallNames(c, "Component", "List")
+}
+
+func (s *listSuite) TestRunDetails(c *gc.C) {
+	s.addPayload("spam", "abc123", payload.StateRunning)
+	list, err := context.NewListCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	code := cmd.Main(list, s.ctx, []string{"--details", "--format", "json"})
+	c.Assert(code, gc.Equals, 0)
+
+	c.Check(s.ctx.Stdout.(*bytes.Buffer).String(), gc.Equals,
+		`[{"class":"spam","type":"docker","id":"abc123","status":"running"}]`+"\n")
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "Component",
+		Args:     []interface{}{payload.ComponentName},
+	}, {
+		FuncName: "List",
+	}, {
+		FuncName: "Get",
+		Args:     []interface{}{"spam", "abc123"},
+	}})
+}
+
+func (s *listSuite) TestRunListError(c *gc.C) {
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(nil, failure)
+	list, err := context.NewListCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	err = list.Init(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = list.Run(s.ctx)
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+}
@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/payload"
+)
+
+// StatusCmdName is the name of the payload status command.
+const StatusCmdName = "payload-status"
+
+// NewStatusCmd returns a new StatusCmd that wraps the given context.
+func NewStatusCmd(ctx HookContext) (*StatusCmd, error) {
+	return &StatusCmd{hookContextFunc: componentHookContext(ctx)}, nil
+}
+
+// StatusCmd is a command that reports the details of a registered payload.
+type StatusCmd struct {
+	cmd.CommandBase
+
+	hookContextFunc func() (Component, error)
+	out             cmd.Output
+	class           string
+	id              string
+}
+
+// Info implements cmd.Command.
+func (c StatusCmd) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    StatusCmdName,
+		Args:    "<class> <id>",
+		Purpose: "show the status of a payload",
+		Doc: `
+"payload-status" is used while a hook is running to show the current
+details of a registered payload. The <class> and <id> provided must match
+a payload that has been previously registered with juju using
+payload-register.
+`,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *StatusCmd) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *StatusCmd) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.Errorf("missing required arguments")
+	}
+	c.class = args[0]
+	c.id = args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements cmd.Command.
+func (c *StatusCmd) Run(ctx *cmd.Context) error {
+	hctx, err := c.hookContextFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	pl, err := hctx.Get(c.class, c.id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatPayload(*pl))
+}
+
+// FormattedPayload holds the representation of a payload written
+// out by the payload hook tools.
+type FormattedPayload struct {
+	Class  string   `json:"class" yaml:"class"`
+	Type   string   `json:"type" yaml:"type"`
+	ID     string   `json:"id" yaml:"id"`
+	Status string   `json:"status" yaml:"status"`
+	Labels []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+func formatPayload(pl payload.Payload) FormattedPayload {
+	var labels []string
+	if len(pl.Labels) > 0 {
+		labels = make([]string, len(pl.Labels))
+		copy(labels, pl.Labels)
+	}
+	return FormattedPayload{
+		Class:  pl.Name,
+		Type:   pl.Type,
+		ID:     pl.ID,
+		Status: pl.Status,
+		Labels: labels,
+	}
+}
+
+func getPayload(hctx Component, fullID string) (*payload.Payload, error) {
+	class, id := payload.ParseID(fullID)
+	pl, err := hctx.Get(class, id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return pl, nil
+}
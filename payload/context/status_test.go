@@ -0,0 +1,135 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context_test
+
+import (
+	"bytes"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/payload"
+	"github.com/juju/juju/payload/context"
+)
+
+type statusSuite struct {
+	baseSuite
+
+	stub    *testing.Stub
+	compCtx *stubContextComponent
+	ctx     *cmd.Context
+}
+
+var _ = gc.Suite(&statusSuite{})
+
+func (s *statusSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.compCtx = newStubContextComponent(s.stub)
+	s.ctx = cmdtesting.Context(c)
+
+	pl := s.newPayload("spam", "docker", "abc123", payload.StateRunning)
+	pl.Labels = []string{"tagA"}
+	s.compCtx.payloads[pl.FullID()] = pl
+}
+
+func (s *statusSuite) Component(name string) (context.Component, error) {
+	s.stub.AddCall("Component", name)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.compCtx, nil
+}
+
+func (s *statusSuite) TestHelp(c *gc.C) {
+	status, err := context.NewStatusCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	code := cmd.Main(status, s.ctx, []string{"--help"})
+	c.Assert(code, gc.Equals, 0)
+
+	c.Check(s.ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+Usage: payload-status [options] <class> <id>
+
+Summary:
+show the status of a payload
+
+Options:
+--format  (= yaml)
+    Specify output format (json|yaml)
+-o, --output (= "")
+    Specify an output file
+
+Details:
+"payload-status" is used while a hook is running to show the current
+details of a registered payload. The <class> and <id> provided must match
+a payload that has been previously registered with juju using
+payload-register.
+`[1:])
+}
+
+func (s *statusSuite) TestInitTooFewArgs(c *gc.C) {
+	status, err := context.NewStatusCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	err = status.Init([]string{"spam"})
+
+	c.Check(err, gc.ErrorMatches, `missing .*`)
+}
+
+func (s *statusSuite) TestInitTooManyArgs(c *gc.C) {
+	status, err := context.NewStatusCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	err = status.Init([]string{"spam", "abc123", "eggs"})
+
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["eggs"\]`)
+}
+
+func (s *statusSuite) TestRunYAML(c *gc.C) {
+	status, err := context.NewStatusCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	code := cmd.Main(status, s.ctx, []string{"spam", "abc123"})
+	c.Assert(code, gc.Equals, 0)
+
+	c.Check(s.ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+class: spam
+type: docker
+id: abc123
+status: running
+tags:
+- tagA
+`[1:])
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "Component",
+		Args:     []interface{}{payload.ComponentName},
+	}, {
+		FuncName: "Get",
+		Args:     []interface{}{"spam", "abc123"},
+	}})
+}
+
+func (s *statusSuite) TestRunJSON(c *gc.C) {
+	status, err := context.NewStatusCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	code := cmd.Main(status, s.ctx, []string{"--format", "json", "spam", "abc123"})
+	c.Assert(code, gc.Equals, 0)
+
+	c.Check(s.ctx.Stdout.(*bytes.Buffer).String(), gc.Equals,
+		`{"class":"spam","type":"docker","id":"abc123","status":"running","tags":["tagA"]}`+"\n")
+}
+
+func (s *statusSuite) TestRunNotFound(c *gc.C) {
+	status, err := context.NewStatusCmd(s)
+	c.Assert(err, jc.ErrorIsNil)
+	err = status.Init([]string{"spam", "xyz"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = status.Run(s.ctx)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
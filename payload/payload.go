@@ -62,6 +62,10 @@ type FullPayloadInfo struct {
 
 	// Machine identifies the Juju machine associated with the payload.
 	Machine string
+
+	// Restarts is the number of times the payload has been started
+	// again after stopping.
+	Restarts int
 }
 
 // Result is a struct that ties an error to a payload ID.
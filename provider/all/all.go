@@ -10,11 +10,14 @@ import (
 	_ "github.com/juju/juju/provider/ec2"
 	_ "github.com/juju/juju/provider/gce"
 	_ "github.com/juju/juju/provider/joyent"
+	_ "github.com/juju/juju/provider/libvirt"
 	_ "github.com/juju/juju/provider/lxd"
 	_ "github.com/juju/juju/provider/maas"
 	_ "github.com/juju/juju/provider/manual"
+	_ "github.com/juju/juju/provider/oci"
 	_ "github.com/juju/juju/provider/openstack"
 	_ "github.com/juju/juju/provider/oracle"
+	_ "github.com/juju/juju/provider/ovirt"
 	_ "github.com/juju/juju/provider/rackspace"
 	_ "github.com/juju/juju/provider/vsphere"
 )
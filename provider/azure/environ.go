@@ -372,6 +372,10 @@ func (env *azureEnviron) ConstraintsValidator() (constraints.Validator, error) {
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		constraints.AllocatePublicIP,
+		constraints.ImageID,
+		constraints.InstanceLifecycle,
+		constraints.MaxPrice,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/tools"
+)
+
+// FinishInstanceConfig selects, from the agent binaries offered in
+// args, those for the given architecture, and then completes
+// args.InstanceConfig in place using the model config. It is intended
+// to be called by StartInstance once the provider has chosen the
+// image, and hence the architecture, of the instance to start.
+func FinishInstanceConfig(args environs.StartInstanceParams, arch string, cfg *config.Config) error {
+	agentTools, err := args.Tools.Match(tools.Filter{Arch: arch})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := args.InstanceConfig.SetTools(agentTools); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(instancecfg.FinishInstanceConfig(args.InstanceConfig, cfg))
+}
+
+// StopInstances calls stop concurrently for each of the given instance
+// ids, and waits for all of the calls to complete. The returned error
+// identifies every instance that could not be stopped.
+//
+// Providers whose APIs remove one instance per request can use
+// StopInstances to implement environs.InstanceBroker.StopInstances;
+// stop must then be safe to call concurrently.
+func StopInstances(ids []instance.Id, stop func(instance.Id) error) error {
+	results := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id instance.Id) {
+			defer wg.Done()
+			results[i] = stop(id)
+		}(i, id)
+	}
+	wg.Wait()
+
+	var errIds []instance.Id
+	var errs []error
+	for i, err := range results {
+		if err != nil {
+			errIds = append(errIds, ids[i])
+			errs = append(errs, err)
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.Annotatef(errs[0], "failed to stop instance %s", errIds[0])
+	default:
+		return errors.Errorf(
+			"failed to stop instances %s: %s",
+			errIds, errs,
+		)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type FinishInstanceConfigSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&FinishInstanceConfigSuite{})
+
+func (s *FinishInstanceConfigSuite) startInstanceParams(c *gc.C) environs.StartInstanceParams {
+	var cons constraints.Value
+	instanceConfig, err := instancecfg.NewBootstrapInstanceConfig(
+		testing.FakeControllerConfig(), cons, cons, "trusty", "",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return environs.StartInstanceParams{
+		InstanceConfig: instanceConfig,
+		Tools: coretools.List{{
+			Version: version.MustParseBinary("1.2.3-trusty-amd64"),
+			URL:     "https://example.org/amd64",
+		}, {
+			Version: version.MustParseBinary("1.2.3-trusty-arm64"),
+			URL:     "https://example.org/arm64",
+		}},
+	}
+}
+
+func (s *FinishInstanceConfigSuite) TestFinishInstanceConfig(c *gc.C) {
+	args := s.startInstanceParams(c)
+	cfg := testing.CustomModelConfig(c, nil)
+	err := common.FinishInstanceConfig(args, arch.ARM64, cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(args.InstanceConfig.ToolsList(), gc.HasLen, 1)
+	c.Assert(args.InstanceConfig.ToolsList()[0].URL, gc.Equals, "https://example.org/arm64")
+	c.Assert(args.InstanceConfig.AuthorizedKeys, gc.Equals, cfg.AuthorizedKeys())
+}
+
+func (s *FinishInstanceConfigSuite) TestFinishInstanceConfigNoMatchingTools(c *gc.C) {
+	args := s.startInstanceParams(c)
+	err := common.FinishInstanceConfig(args, arch.PPC64EL, testing.CustomModelConfig(c, nil))
+	c.Assert(errors.Cause(err), gc.Equals, coretools.ErrNoMatches)
+}
+
+type StopInstancesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&StopInstancesSuite{})
+
+func (s *StopInstancesSuite) TestStopInstances(c *gc.C) {
+	var mu sync.Mutex
+	var stopped []string
+	err := common.StopInstances([]instance.Id{"i0", "i1", "i2"}, func(id instance.Id) error {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = append(stopped, string(id))
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stopped, jc.SameContents, []string{"i0", "i1", "i2"})
+}
+
+func (s *StopInstancesSuite) TestStopInstancesError(c *gc.C) {
+	err := common.StopInstances([]instance.Id{"i0", "i1"}, func(id instance.Id) error {
+		if id == "i1" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	c.Assert(err, gc.ErrorMatches, "failed to stop instance i1: boom")
+}
+
+func (s *StopInstancesSuite) TestStopInstancesErrors(c *gc.C) {
+	err := common.StopInstances([]instance.Id{"i0", "i1", "i2"}, func(id instance.Id) error {
+		if id == "i1" {
+			return nil
+		}
+		return errors.Errorf("%s: boom", id)
+	})
+	c.Assert(err, gc.ErrorMatches, `failed to stop instances \[i0 i2\]: \[i0: boom i2: boom\]`)
+}
@@ -0,0 +1,34 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// ValidateConfig validates cfg, coercing its provider-specific
+// attributes according to fields and filling in defaults for any
+// that are unset, and returns the resulting config. If old is
+// non-nil, cfg is validated as a change to old.
+//
+// Providers whose config has no attributes beyond those described
+// by fields may use ValidateConfig to implement both Validate and
+// SetConfig.
+func ValidateConfig(cfg, old *config.Config, fields schema.Fields, defaults schema.Defaults) (*config.Config, error) {
+	if err := config.Validate(cfg, old); err != nil {
+		return nil, errors.Trace(err)
+	}
+	validated, err := cfg.ValidateUnknownAttrs(fields, defaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	valid, err := cfg.Apply(validated)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return valid, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/schema"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/testing"
+)
+
+type ValidateConfigSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ValidateConfigSuite{})
+
+var (
+	testConfigFields = schema.Fields{
+		"network": schema.String(),
+		"pool":    schema.String(),
+	}
+	testConfigDefaults = schema.Defaults{
+		"network": "default",
+		"pool":    schema.Omit,
+	}
+)
+
+func (s *ValidateConfigSuite) TestValidateConfigDefaults(c *gc.C) {
+	cfg := testing.CustomModelConfig(c, testing.Attrs{"pool": "images"})
+	valid, err := common.ValidateConfig(cfg, nil, testConfigFields, testConfigDefaults)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(valid.UnknownAttrs(), jc.DeepEquals, map[string]interface{}{
+		"network": "default",
+		"pool":    "images",
+	})
+}
+
+func (s *ValidateConfigSuite) TestValidateConfigInvalidAttribute(c *gc.C) {
+	cfg := testing.CustomModelConfig(c, testing.Attrs{"network": 123})
+	_, err := common.ValidateConfig(cfg, nil, testConfigFields, testConfigDefaults)
+	c.Assert(err, gc.ErrorMatches, `network: expected string, got int\(123\)`)
+}
+
+func (s *ValidateConfigSuite) TestValidateConfigChange(c *gc.C) {
+	old := testing.CustomModelConfig(c, testing.Attrs{"network": "default"})
+	cfg := testing.CustomModelConfig(c, testing.Attrs{"network": "br0"})
+	valid, err := common.ValidateConfig(cfg, old, testConfigFields, testConfigDefaults)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(valid.UnknownAttrs()["network"], gc.Equals, "br0")
+}
+
+func (s *ValidateConfigSuite) TestValidateConfigInvalidChange(c *gc.C) {
+	old := testing.CustomModelConfig(c, nil)
+	cfg := testing.CustomModelConfig(c, testing.Attrs{"name": "renamed"})
+	_, err := common.ValidateConfig(cfg, old, testConfigFields, testConfigDefaults)
+	c.Assert(err, gc.ErrorMatches, `cannot change name from "testenv" to "renamed"`)
+}
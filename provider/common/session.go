@@ -0,0 +1,27 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+)
+
+// WithSession calls f while holding open a connection to a cloud's
+// API, for providers that connect afresh for each Environ method
+// call. The connection is opened by calling open, which returns a
+// function that closes it again once f has returned. A failure to
+// close the connection is logged rather than returned, as the
+// outcome of f has been decided by then.
+func WithSession(open func() (close func() error, err error), f func() error) error {
+	closeSession, err := open()
+	if err != nil {
+		return errors.Annotate(err, "dialing client")
+	}
+	defer func() {
+		if err := closeSession(); err != nil {
+			logger.Warningf("closing session: %v", err)
+		}
+	}()
+	return f()
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/testing"
+)
+
+type WithSessionSuite struct {
+	testing.BaseSuite
+	stub gitjujutesting.Stub
+}
+
+var _ = gc.Suite(&WithSessionSuite{})
+
+func (s *WithSessionSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.stub.ResetCalls()
+}
+
+func (s *WithSessionSuite) open() (func() error, error) {
+	s.stub.AddCall("Open")
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return func() error {
+		s.stub.AddCall("Close")
+		return s.stub.NextErr()
+	}, nil
+}
+
+func (s *WithSessionSuite) call() error {
+	s.stub.AddCall("Call")
+	return s.stub.NextErr()
+}
+
+func (s *WithSessionSuite) TestWithSession(c *gc.C) {
+	err := common.WithSession(s.open, s.call)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "Open", "Call", "Close")
+}
+
+func (s *WithSessionSuite) TestWithSessionOpenError(c *gc.C) {
+	s.stub.SetErrors(errors.New("connection refused"))
+	err := common.WithSession(s.open, s.call)
+	c.Assert(err, gc.ErrorMatches, "dialing client: connection refused")
+	s.stub.CheckCallNames(c, "Open")
+}
+
+func (s *WithSessionSuite) TestWithSessionCallError(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("boom"))
+	err := common.WithSession(s.open, s.call)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.stub.CheckCallNames(c, "Open", "Call", "Close")
+}
+
+func (s *WithSessionSuite) TestWithSessionCloseErrorIgnored(c *gc.C) {
+	s.stub.SetErrors(nil, nil, errors.New("already closed"))
+	err := common.WithSession(s.open, s.call)
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "Open", "Call", "Close")
+}
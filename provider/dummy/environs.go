@@ -196,6 +196,28 @@ type OpStopInstances struct {
 	Ids []instance.Id
 }
 
+type OpPowerOffInstances struct {
+	Env string
+	Ids []instance.Id
+}
+
+type OpPowerOnInstances struct {
+	Env string
+	Ids []instance.Id
+}
+
+type OpRebootInstances struct {
+	Env string
+	Ids []instance.Id
+}
+
+type OpRebuildInstance struct {
+	Env        string
+	InstanceId instance.Id
+	Series     string
+	Volumes    []storage.VolumeAttachmentParams
+}
+
 type OpOpenPorts struct {
 	Env        string
 	MachineId  string
@@ -267,6 +289,8 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.Networking = (*environ)(nil)
+var _ environs.InstancePowerManager = (*environ)(nil)
+var _ environs.InstanceRebuilder = (*environ)(nil)
 
 // discardOperations discards all Operations written to it.
 var discardOperations = make(chan Operation)
@@ -1146,6 +1170,71 @@ func (e *environ) StopInstances(ids ...instance.Id) error {
 	return nil
 }
 
+// PowerOffInstances is specified in the environs.InstancePowerManager interface.
+func (e *environ) PowerOffInstances(ids ...instance.Id) error {
+	return e.powerInstances("PowerOffInstances", "stopped", ids, OpPowerOffInstances{Env: e.name, Ids: ids})
+}
+
+// PowerOnInstances is specified in the environs.InstancePowerManager interface.
+func (e *environ) PowerOnInstances(ids ...instance.Id) error {
+	return e.powerInstances("PowerOnInstances", string(status.Running), ids, OpPowerOnInstances{Env: e.name, Ids: ids})
+}
+
+// RebootInstances is specified in the environs.InstancePowerManager interface.
+func (e *environ) RebootInstances(ids ...instance.Id) error {
+	return e.powerInstances("RebootInstances", string(status.Running), ids, OpRebootInstances{Env: e.name, Ids: ids})
+}
+
+func (e *environ) powerInstances(method, instStatus string, ids []instance.Id, op Operation) error {
+	defer delay()
+	if err := e.checkBroken(method); err != nil {
+		return err
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, id := range ids {
+		if estate.insts[id] == nil {
+			return errors.NotFoundf("instance %q", id)
+		}
+	}
+	for _, id := range ids {
+		SetInstanceStatus(estate.insts[id], instStatus)
+	}
+	estate.ops <- op
+	return nil
+}
+
+// RebuildInstance is specified in the environs.InstanceRebuilder interface.
+func (e *environ) RebuildInstance(args environs.RebuildInstanceParams) error {
+	defer delay()
+	if err := e.checkBroken("RebuildInstance"); err != nil {
+		return err
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	inst := estate.insts[args.InstanceId]
+	if inst == nil {
+		return errors.NotFoundf("instance %q", args.InstanceId)
+	}
+	series := args.InstanceConfig.Series
+	inst.series = series
+	estate.ops <- OpRebuildInstance{
+		Env:        e.name,
+		InstanceId: args.InstanceId,
+		Series:     series,
+		Volumes:    args.Volumes,
+	}
+	return nil
+}
+
 func (e *environ) Instances(ids []instance.Id) (insts []instance.Instance, err error) {
 	defer delay()
 	if err := e.checkBroken("Instances"); err != nil {
@@ -1528,6 +1617,8 @@ func (*environ) Provider() environs.EnvironProvider {
 type dummyInstance struct {
 	state        *environState
 	rules        network.IngressRuleSlice
+	egress       []string
+	restricted   bool
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1705,6 +1796,52 @@ func (inst *dummyInstance) IngressRules(machineId string) (rules []network.Ingre
 	return
 }
 
+// RestrictEgress is specified in the instance.InstanceEgressFirewaller interface.
+func (inst *dummyInstance) RestrictEgress(machineId string, cidrs []string) error {
+	defer delay()
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("RestrictEgress with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("RestrictEgress"); err != nil {
+		return err
+	}
+	inst.restricted = true
+	inst.egress = append([]string{}, cidrs...)
+	return nil
+}
+
+// UnrestrictEgress is specified in the instance.InstanceEgressFirewaller interface.
+func (inst *dummyInstance) UnrestrictEgress(machineId string) error {
+	defer delay()
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("UnrestrictEgress with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("UnrestrictEgress"); err != nil {
+		return err
+	}
+	inst.restricted = false
+	inst.egress = nil
+	return nil
+}
+
+// EgressRestriction is specified in the instance.InstanceEgressFirewaller interface.
+func (inst *dummyInstance) EgressRestriction(machineId string) ([]string, bool, error) {
+	defer delay()
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("EgressRestriction with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("EgressRestriction"); err != nil {
+		return nil, false, err
+	}
+	return append([]string{}, inst.egress...), inst.restricted, nil
+}
+
 // providerDelay controls the delay before dummy responds.
 // non empty values in JUJU_DUMMY_DELAY will be parsed as
 // time.Durations into this value.
@@ -0,0 +1,39 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dummy
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+// LocalDevCloudName is the name of the built-in cloud backed by the
+// dummy provider, for bootstrapping a local development controller
+// whose machines are started in-process and are never real VMs.
+const LocalDevCloudName = "localdev"
+
+var localDevCloud = cloud.Cloud{
+	Name:        LocalDevCloudName,
+	Type:        "dummy",
+	Description: "Local development controller (in-memory)",
+	AuthTypes:   []cloud.AuthType{cloud.EmptyAuthType},
+	Regions:     []cloud.Region{{Name: "dummy"}},
+}
+
+var _ environs.CloudDetector = (*environProvider)(nil)
+
+// DetectClouds implements environs.CloudDetector.
+func (*environProvider) DetectClouds() ([]cloud.Cloud, error) {
+	return []cloud.Cloud{localDevCloud}, nil
+}
+
+// DetectCloud implements environs.CloudDetector.
+func (*environProvider) DetectCloud(name string) (cloud.Cloud, error) {
+	if name == LocalDevCloudName {
+		return localDevCloud, nil
+	}
+	return cloud.Cloud{}, errors.NotFoundf("cloud %s", name)
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dummy_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/testing"
+)
+
+type localDevSuite struct {
+	testing.BaseSuite
+	detector environs.CloudDetector
+}
+
+var _ = gc.Suite(&localDevSuite{})
+
+func (s *localDevSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	provider, err := environs.Provider("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	s.detector = provider.(environs.CloudDetector)
+}
+
+func (s *localDevSuite) TestDetectClouds(c *gc.C) {
+	clouds, err := s.detector.DetectClouds()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clouds, gc.HasLen, 1)
+	c.Assert(clouds[0].Name, gc.Equals, dummy.LocalDevCloudName)
+	c.Assert(clouds[0].Type, gc.Equals, "dummy")
+	c.Assert(clouds[0].AuthTypes, jc.DeepEquals, []cloud.AuthType{cloud.EmptyAuthType})
+	c.Assert(clouds[0].Regions, jc.DeepEquals, []cloud.Region{{Name: "dummy"}})
+}
+
+func (s *localDevSuite) TestDetectCloud(c *gc.C) {
+	localDev, err := s.detector.DetectCloud("localdev")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(localDev.Name, gc.Equals, "localdev")
+
+	_, err = s.detector.DetectCloud("dummy")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
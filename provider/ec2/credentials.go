@@ -37,6 +37,10 @@ func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.C
 				},
 			},
 		},
+		// Instance role credentials have no attributes: they are
+		// obtained from the instance metadata service of the host
+		// on which the client or controller is running.
+		cloud.InstanceRoleAuthType: {},
 	}
 }
 
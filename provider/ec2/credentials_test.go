@@ -36,7 +36,7 @@ func (s *credentialsSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *credentialsSuite) TestCredentialSchemas(c *gc.C) {
-	envtesting.AssertProviderAuthTypes(c, s.provider, "access-key")
+	envtesting.AssertProviderAuthTypes(c, s.provider, "access-key", "instance-role")
 }
 
 func (s *credentialsSuite) TestAccessKeyCredentialsValid(c *gc.C) {
@@ -50,6 +50,10 @@ func (s *credentialsSuite) TestAccessKeyHiddenAttributes(c *gc.C) {
 	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "access-key", "secret-key")
 }
 
+func (s *credentialsSuite) TestInstanceRoleCredentialsValid(c *gc.C) {
+	envtesting.AssertProviderCredentialsValid(c, s.provider, "instance-role", map[string]string{})
+}
+
 func (s *credentialsSuite) TestDetectCredentialsNotFound(c *gc.C) {
 	// No environment variables set, so no credentials should be found.
 	_, err := s.provider.DetectCredentials()
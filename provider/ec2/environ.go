@@ -64,6 +64,11 @@ type environ struct {
 	cloud environs.CloudSpec
 	ec2   *ec2.EC2
 
+	// instanceProfile holds the credentials with which the EC2
+	// client signs requests, if the cloud credential is an
+	// instance role credential, and is otherwise nil.
+	instanceProfile *instanceProfileCredentials
+
 	// ecfgMutex protects the *Unlocked fields below.
 	ecfgMutex    sync.Mutex
 	ecfgUnlocked *environConfig
@@ -161,6 +166,8 @@ var unsupportedConstraints = []string{
 	// TODO(anastasiamac 2016-03-16) LP#1557874
 	// use virt-type in StartInstances
 	constraints.VirtType,
+	constraints.AllocatePublicIP,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -179,6 +186,9 @@ func (e *environ) ConstraintsValidator() (constraints.Validator, error) {
 		instTypeNames[i] = itype.Name
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
+	// The EC2 client does not yet support the spot instance API, so
+	// only on-demand instances can be started.
+	validator.RegisterVocabulary(constraints.InstanceLifecycle, []string{string(instance.OnDemand)})
 	return validator, nil
 }
 
@@ -519,6 +529,16 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		BlockDeviceMappings: blockDeviceMappings,
 		ImageId:             spec.Image.Id,
 	}
+	if e.instanceProfile != nil && args.InstanceConfig.Controller != nil {
+		// Controllers must be able to obtain the same instance
+		// role credentials that we are using, so launch them
+		// with our instance profile.
+		profileName, err := e.instanceProfile.instanceProfileName()
+		if err != nil {
+			return nil, errors.Annotate(err, "getting instance profile")
+		}
+		commonRunArgs.IAMInstanceProfile = profileName
+	}
 
 	haveVPCID := isVPCIDSet(e.ecfg().vpcID())
 
@@ -575,6 +595,9 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	if isZoneOrSubnetConstrainedError(err) {
 		return nil, errors.Wrap(err, environs.ErrAvailabilityZoneFailed)
 	}
+	if isTransientError(err) {
+		return nil, environs.NewRetryableError(errors.Annotate(err, "cannot run instances"))
+	}
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot run instances")
 	}
@@ -1935,6 +1958,22 @@ func isSubnetConstrainedError(err error) bool {
 	return false
 }
 
+// isTransientError reports whether or not the error indicates that
+// EC2 could not handle the request at the time it was made, because
+// of throttling or an internal failure, so that it may succeed if
+// made again later.
+func isTransientError(err error) bool {
+	switch err := err.(type) {
+	case *ec2.Error:
+		switch err.Code {
+		case "RequestLimitExceeded", "InternalError", "Unavailable", "ServiceUnavailable":
+			return true
+		}
+		return err.StatusCode >= 500
+	}
+	return false
+}
+
 // If the err is of type *ec2.Error, ec2ErrCode returns
 // its code, otherwise it returns the empty string.
 func ec2ErrCode(err error) string {
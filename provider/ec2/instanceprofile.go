@@ -0,0 +1,250 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/amz.v3/aws"
+)
+
+// instanceMetadataURL is the base URL of the EC2 instance metadata
+// service.
+var instanceMetadataURL = "http://169.254.169.254/latest"
+
+const (
+	// metadataTokenTTL is the lifetime requested for IMDSv2
+	// session tokens.
+	metadataTokenTTL = 6 * time.Hour
+
+	// metadataTimeout is the time allowed for a request to the
+	// instance metadata service. The service is link-local, so
+	// anything slower means we are not running on EC2.
+	metadataTimeout = 5 * time.Second
+
+	// credentialsRefreshWindow is how long before the role
+	// credentials expire that they are refreshed. EC2 makes
+	// rotated credentials available at least five minutes
+	// before the old ones expire.
+	credentialsRefreshWindow = 5 * time.Minute
+)
+
+// instanceProfileCredentials provides the temporary credentials of
+// the IAM role associated with the instance profile of the host on
+// which it is running. The credentials are obtained from the instance
+// metadata service using IMDSv2 session tokens, and are cached until
+// they are due to be rotated, or are invalidated.
+type instanceProfileCredentials struct {
+	metadataURL string
+	client      *http.Client
+	clock       clock.Clock
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+	role        *roleCredentials
+}
+
+// roleCredentials holds the temporary credentials of an IAM role, as
+// returned by the instance metadata service.
+type roleCredentials struct {
+	Code            string
+	AccessKeyId     string
+	SecretAccessKey string
+	Token           string
+	Expiration      time.Time
+}
+
+// instanceProfileInfo holds the details of the host's instance profile,
+// as returned by the instance metadata service.
+type instanceProfileInfo struct {
+	Code               string
+	InstanceProfileArn string
+}
+
+func newInstanceProfileCredentials(metadataURL string, clock clock.Clock) *instanceProfileCredentials {
+	return &instanceProfileCredentials{
+		metadataURL: metadataURL,
+		client:      &http.Client{Timeout: metadataTimeout},
+		clock:       clock,
+	}
+}
+
+// signer returns an aws.Signer that signs requests with the instance
+// profile's current credentials, using the given signer. The aws.Auth
+// passed to the returned signer is ignored.
+func (p *instanceProfileCredentials) signer(sign aws.Signer) aws.Signer {
+	return func(req *http.Request, _ aws.Auth) error {
+		auth, token, err := p.credentials()
+		if err != nil {
+			return errors.Annotate(err, "getting instance profile credentials")
+		}
+		req.Header.Set("X-Amz-Security-Token", token)
+		return sign(req, auth)
+	}
+}
+
+// credentials returns the instance profile's current credentials and
+// session token, fetching new ones if there are none cached or the
+// cached ones are due to be rotated.
+func (p *instanceProfileCredentials) credentials() (aws.Auth, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.role == nil || !p.clock.Now().Add(credentialsRefreshWindow).Before(p.role.Expiration) {
+		role, err := p.fetchRoleCredentials()
+		if err != nil {
+			return aws.Auth{}, "", errors.Trace(err)
+		}
+		logger.Debugf("obtained instance profile credentials expiring at %s", role.Expiration)
+		p.role = role
+	}
+	auth := aws.Auth{
+		AccessKey: p.role.AccessKeyId,
+		SecretKey: p.role.SecretAccessKey,
+	}
+	return auth, p.role.Token, nil
+}
+
+// invalidate discards the cached credentials, so that new ones are
+// fetched for the next request. It should be called when the cached
+// credentials have been rejected, for example because they were
+// rotated early.
+func (p *instanceProfileCredentials) invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.role = nil
+}
+
+// instanceProfileName returns the name of the host's instance profile.
+func (p *instanceProfileCredentials) instanceProfileName() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	data, err := p.getMetadata("iam/info")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var info instanceProfileInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return "", errors.Annotate(err, "decoding instance profile info")
+	}
+	if info.Code != "Success" {
+		return "", errors.Errorf("getting instance profile info: %s", info.Code)
+	}
+	// The ARN has the form
+	// arn:aws:iam::<account>:instance-profile[/<path>]/<name>.
+	i := strings.LastIndex(info.InstanceProfileArn, "/")
+	if i < 0 {
+		return "", errors.NotValidf("instance profile ARN %q", info.InstanceProfileArn)
+	}
+	return info.InstanceProfileArn[i+1:], nil
+}
+
+// fetchRoleCredentials fetches the credentials of the instance
+// profile's role from the metadata service. It must be called with
+// p.mu held.
+func (p *instanceProfileCredentials) fetchRoleCredentials() (*roleCredentials, error) {
+	data, err := p.getMetadata("iam/security-credentials/")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The role names are listed one per line, though an
+	// instance profile can only contain a single role.
+	roleName := strings.TrimSpace(strings.SplitN(string(data), "\n", 2)[0])
+	if roleName == "" {
+		return nil, errors.NotFoundf("instance profile role")
+	}
+
+	data, err = p.getMetadata("iam/security-credentials/" + roleName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var role roleCredentials
+	if err := json.Unmarshal(data, &role); err != nil {
+		return nil, errors.Annotatef(err, "decoding credentials of role %q", roleName)
+	}
+	if role.Code != "Success" {
+		return nil, errors.Errorf("getting credentials of role %q: %s", roleName, role.Code)
+	}
+	return &role, nil
+}
+
+// getMetadata returns the metadata item at the given path, relative to
+// the meta-data root. If the session token has expired, a new one is
+// requested and the request is retried once. It must be called with
+// p.mu held.
+func (p *instanceProfileCredentials) getMetadata(path string) ([]byte, error) {
+	for retried := false; ; retried = true {
+		token, err := p.sessionToken()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		req, err := http.NewRequest("GET", p.metadataURL+"/meta-data/"+path, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		req.Header.Set("X-aws-ec2-metadata-token", token)
+		resp, err := p.client.Do(req)
+		if err != nil {
+			return nil, errors.Annotate(err, "querying instance metadata service")
+		}
+		data, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading instance metadata %q", path)
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			return data, nil
+		case http.StatusUnauthorized:
+			// The session token has expired or is otherwise
+			// invalid; request a new one.
+			p.token = ""
+			if !retried {
+				continue
+			}
+		case http.StatusNotFound:
+			return nil, errors.NotFoundf("instance metadata %q", path)
+		}
+		return nil, errors.Errorf("getting instance metadata %q: %s", path, resp.Status)
+	}
+}
+
+// sessionToken returns an IMDSv2 session token, requesting a new one
+// if there is none or the current one is about to expire. It must be
+// called with p.mu held.
+func (p *instanceProfileCredentials) sessionToken() (string, error) {
+	now := p.clock.Now()
+	if p.token != "" && now.Add(time.Minute).Before(p.tokenExpiry) {
+		return p.token, nil
+	}
+	req, err := http.NewRequest("PUT", p.metadataURL+"/api/token", nil)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	ttl := int(metadataTokenTTL / time.Second)
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", strconv.Itoa(ttl))
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", errors.Annotate(err, "requesting instance metadata session token")
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Annotate(err, "reading instance metadata session token")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("requesting instance metadata session token: %s", resp.Status)
+	}
+	p.token = strings.TrimSpace(string(data))
+	p.tokenExpiry = now.Add(metadataTokenTTL)
+	return p.token, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/aws"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type instanceProfileSuite struct {
+	coretesting.BaseSuite
+
+	clock    *testing.Clock
+	metadata *fakeMetadataServer
+	server   *httptest.Server
+	creds    *instanceProfileCredentials
+}
+
+var _ = gc.Suite(&instanceProfileSuite{})
+
+func (s *instanceProfileSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.metadata = &fakeMetadataServer{
+		role:       "juju-controller",
+		profileArn: "arn:aws:iam::123456789012:instance-profile/juju/juju-controller-profile",
+		expiration: s.clock.Now().Add(time.Hour),
+		generation: 1,
+		tokens:     make(map[string]bool),
+	}
+	s.server = httptest.NewServer(s.metadata)
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	s.creds = newInstanceProfileCredentials(s.server.URL+"/latest", s.clock)
+}
+
+func (s *instanceProfileSuite) TestCredentials(c *gc.C) {
+	auth, token, err := s.creds.credentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(auth, jc.DeepEquals, aws.Auth{
+		AccessKey: "access-key-1",
+		SecretKey: "secret-key-1",
+	})
+	c.Assert(token, gc.Equals, "session-token-1")
+	c.Assert(s.metadata.requests(), jc.DeepEquals, []string{
+		"PUT /latest/api/token",
+		"GET /latest/meta-data/iam/security-credentials/",
+		"GET /latest/meta-data/iam/security-credentials/juju-controller",
+	})
+}
+
+func (s *instanceProfileSuite) TestCredentialsCached(c *gc.C) {
+	_, _, err := s.creds.credentials()
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock.Advance(50 * time.Minute)
+	auth, _, err := s.creds.credentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(auth.AccessKey, gc.Equals, "access-key-1")
+	c.Assert(s.metadata.requests(), gc.HasLen, 3)
+}
+
+func (s *instanceProfileSuite) TestCredentialsRotated(c *gc.C) {
+	_, _, err := s.creds.credentials()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Within the refresh window, the rotated credentials are
+	// fetched, reusing the metadata session token.
+	s.metadata.rotate(s.clock.Now().Add(2 * time.Hour))
+	s.clock.Advance(56 * time.Minute)
+	auth, token, err := s.creds.credentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(auth.AccessKey, gc.Equals, "access-key-2")
+	c.Assert(token, gc.Equals, "session-token-2")
+	c.Assert(s.metadata.requests()[3:], jc.DeepEquals, []string{
+		"GET /latest/meta-data/iam/security-credentials/",
+		"GET /latest/meta-data/iam/security-credentials/juju-controller",
+	})
+}
+
+func (s *instanceProfileSuite) TestInvalidate(c *gc.C) {
+	_, _, err := s.creds.credentials()
+	c.Assert(err, jc.ErrorIsNil)
+	s.metadata.rotate(s.clock.Now().Add(2 * time.Hour))
+	s.creds.invalidate()
+	auth, _, err := s.creds.credentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(auth.AccessKey, gc.Equals, "access-key-2")
+}
+
+func (s *instanceProfileSuite) TestSessionTokenExpired(c *gc.C) {
+	_, _, err := s.creds.credentials()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The metadata service rejects the session token, so
+	// a new one is requested and the request retried.
+	s.metadata.revokeTokens()
+	s.creds.invalidate()
+	_, _, err = s.creds.credentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.metadata.requests()[3:], jc.DeepEquals, []string{
+		"GET /latest/meta-data/iam/security-credentials/",
+		"PUT /latest/api/token",
+		"GET /latest/meta-data/iam/security-credentials/",
+		"GET /latest/meta-data/iam/security-credentials/juju-controller",
+	})
+}
+
+func (s *instanceProfileSuite) TestNoInstanceProfile(c *gc.C) {
+	s.metadata.role = ""
+	_, _, err := s.creds.credentials()
+	c.Assert(err, gc.ErrorMatches, `instance metadata "iam/security-credentials/" not found`)
+}
+
+func (s *instanceProfileSuite) TestInstanceProfileName(c *gc.C) {
+	name, err := s.creds.instanceProfileName()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "juju-controller-profile")
+}
+
+func (s *instanceProfileSuite) TestSigner(c *gc.C) {
+	var signedAuth aws.Auth
+	signer := s.creds.signer(func(req *http.Request, auth aws.Auth) error {
+		signedAuth = auth
+		return nil
+	})
+	req, err := http.NewRequest("GET", "https://ec2.us-east-1.amazonaws.com", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = signer(req, aws.Auth{AccessKey: "ignored"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(signedAuth.AccessKey, gc.Equals, "access-key-1")
+	c.Assert(req.Header.Get("X-Amz-Security-Token"), gc.Equals, "session-token-1")
+}
+
+// fakeMetadataServer is an http.Handler that implements the parts of
+// the EC2 instance metadata service used to obtain instance profile
+// credentials, requiring IMDSv2 session tokens.
+type fakeMetadataServer struct {
+	mu         sync.Mutex
+	role       string
+	profileArn string
+	expiration time.Time
+	generation int
+	issued     int
+	tokens     map[string]bool
+	log        []string
+}
+
+func (s *fakeMetadataServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.log = append(s.log, req.Method+" "+req.URL.Path)
+
+	if req.URL.Path == "/latest/api/token" {
+		if req.Method != "PUT" || req.Header.Get("X-aws-ec2-metadata-token-ttl-seconds") == "" {
+			http.Error(w, "bad token request", http.StatusBadRequest)
+			return
+		}
+		s.issued++
+		token := fmt.Sprintf("imds-token-%d", s.issued)
+		s.tokens[token] = true
+		fmt.Fprint(w, token)
+		return
+	}
+	if !s.tokens[req.Header.Get("X-aws-ec2-metadata-token")] {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch req.URL.Path {
+	case "/latest/meta-data/iam/info":
+		json.NewEncoder(w).Encode(instanceProfileInfo{
+			Code:               "Success",
+			InstanceProfileArn: s.profileArn,
+		})
+	case "/latest/meta-data/iam/security-credentials/":
+		if s.role == "" {
+			http.NotFound(w, req)
+			return
+		}
+		fmt.Fprintln(w, s.role)
+	case "/latest/meta-data/iam/security-credentials/" + s.role:
+		json.NewEncoder(w).Encode(roleCredentials{
+			Code:            "Success",
+			AccessKeyId:     fmt.Sprintf("access-key-%d", s.generation),
+			SecretAccessKey: fmt.Sprintf("secret-key-%d", s.generation),
+			Token:           fmt.Sprintf("session-token-%d", s.generation),
+			Expiration:      s.expiration,
+		})
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+// rotate replaces the role's credentials with new ones, expiring at
+// the given time.
+func (s *fakeMetadataServer) rotate(expiration time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.generation++
+	s.expiration = expiration
+}
+
+// revokeTokens invalidates all session tokens issued so far.
+func (s *fakeMetadataServer) revokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = make(map[string]bool)
+}
+
+func (s *fakeMetadataServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.log...)
+}
@@ -1357,6 +1357,16 @@ func (t *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "invalid constraint value: instance-type=foo\nvalid values are:.*")
 }
 
+func (t *localServerSuite) TestConstraintsValidatorVocabInstanceLifecycle(c *gc.C) {
+	env := t.Prepare(c)
+	validator, err := env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("instance-lifecycle=on-demand"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("instance-lifecycle=spot"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: instance-lifecycle=spot\nvalid values are:.*")
+}
+
 func (t *localServerSuite) TestConstraintsValidatorVocabNoDefaultOrSpecifiedVPC(c *gc.C) {
 	t.srv.defaultVPC.IsDefault = false
 	err := t.srv.ec2srv.UpdateVPC(*t.srv.defaultVPC)
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/jsonschema"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/amz.v3/aws"
 	"gopkg.in/amz.v3/ec2"
 
@@ -50,7 +51,7 @@ func (p environProvider) Open(args environs.OpenParams) (environs.Environ, error
 	}
 
 	var err error
-	e.ec2, err = awsClient(e.cloud)
+	e.ec2, e.instanceProfile, err = awsClient(e.cloud)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -82,25 +83,34 @@ func isBrokenCloud(cloud environs.CloudSpec) bool {
 	return false
 }
 
-func awsClient(cloud environs.CloudSpec) (*ec2.EC2, error) {
-	if err := validateCloudSpec(cloud); err != nil {
-		return nil, errors.Annotate(err, "validating cloud spec")
+// awsClient returns an EC2 client for the given cloud spec. If the
+// spec's credential is an instance role credential, the client signs
+// requests with the credentials of the host's instance profile, which
+// are also returned so that they may be invalidated.
+func awsClient(spec environs.CloudSpec) (*ec2.EC2, *instanceProfileCredentials, error) {
+	if err := validateCloudSpec(spec); err != nil {
+		return nil, nil, errors.Annotate(err, "validating cloud spec")
+	}
+
+	region := aws.Region{
+		Name:        spec.Region,
+		EC2Endpoint: spec.Endpoint,
 	}
+	signer := aws.SignV4Factory(spec.Region, "ec2")
 
-	credentialAttrs := cloud.Credential.Attributes()
+	if spec.Credential.AuthType() == cloud.InstanceRoleAuthType {
+		creds := newInstanceProfileCredentials(instanceMetadataURL, clock.WallClock)
+		return ec2.New(aws.Auth{}, region, creds.signer(signer)), creds, nil
+	}
+
+	credentialAttrs := spec.Credential.Attributes()
 	accessKey := credentialAttrs["access-key"]
 	secretKey := credentialAttrs["secret-key"]
 	auth := aws.Auth{
 		AccessKey: accessKey,
 		SecretKey: secretKey,
 	}
-
-	region := aws.Region{
-		Name:        cloud.Region,
-		EC2Endpoint: cloud.Endpoint,
-	}
-	signer := aws.SignV4Factory(cloud.Region, "ec2")
-	return ec2.New(auth, region, signer), nil
+	return ec2.New(auth, region, signer), nil, nil
 }
 
 // CloudSchema returns the schema used to validate input for add-cloud.  Since
@@ -137,7 +147,9 @@ func validateCloudSpec(c environs.CloudSpec) error {
 	if c.Credential == nil {
 		return errors.NotValidf("missing credential")
 	}
-	if authType := c.Credential.AuthType(); authType != cloud.AccessKeyAuthType {
+	switch authType := c.Credential.AuthType(); authType {
+	case cloud.AccessKeyAuthType, cloud.InstanceRoleAuthType:
+	default:
 		return errors.NotSupportedf("%q auth-type", authType)
 	}
 	return nil
@@ -178,19 +190,33 @@ Please ensure the Secret Access Key you have specified is correct.
 You can obtain the Secret Access Key via the "Security Credentials"
 page in the AWS console.`
 
+const badInstanceProfile = `
+Please ensure the host is associated with an instance profile, and
+that the profile's IAM role grants access to EC2.`
+
 // verifyCredentials issues a cheap, non-modifying/idempotent request to EC2 to
 // verify the configured credentials. If verification fails, a user-friendly
 // error will be returned, and the original error will be logged at debug
 // level.
 var verifyCredentials = func(e *environ) error {
 	_, err := e.ec2.AccountAttributes()
+	if err != nil && e.instanceProfile != nil && isAuthError(err) {
+		// The cached instance profile credentials may have been
+		// rotated or revoked since they were obtained; discard
+		// them and try again with fresh ones.
+		logger.Debugf("ec2 request failed with instance profile credentials: %v", err)
+		e.instanceProfile.invalidate()
+		_, err = e.ec2.AccountAttributes()
+	}
 	if err != nil {
 		logger.Debugf("ec2 request failed: %v", err)
 		if err, ok := err.(*ec2.Error); ok {
-			switch err.Code {
-			case "AuthFailure":
+			switch {
+			case e.instanceProfile != nil && isAuthError(err):
+				return errors.New("authentication failed.\n" + badInstanceProfile)
+			case err.Code == "AuthFailure":
 				return errors.New("authentication failed.\n" + badAccessKey)
-			case "SignatureDoesNotMatch":
+			case err.Code == "SignatureDoesNotMatch":
 				return errors.New("authentication failed.\n" + badSecretKey)
 			default:
 				return err
@@ -200,3 +226,15 @@ var verifyCredentials = func(e *environ) error {
 	}
 	return nil
 }
+
+// isAuthError reports whether the error is an EC2 error indicating
+// that the request's credentials were rejected.
+func isAuthError(err error) bool {
+	if err, ok := err.(*ec2.Error); ok {
+		switch err.Code {
+		case "AuthFailure", "ExpiredToken", "InvalidToken", "SignatureDoesNotMatch":
+			return true
+		}
+	}
+	return false
+}
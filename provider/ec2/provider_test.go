@@ -106,6 +106,19 @@ func (s *ProviderSuite) TestOpenKnownRegionValidEndpoint(c *gc.C) {
 	c.Assert(ec2Client.Region.EC2Endpoint, gc.Equals, "https://ec2.us-east-1.amazonaws.com")
 }
 
+func (s *ProviderSuite) TestOpenInstanceRoleCredential(c *gc.C) {
+	// Opening the environ does not contact the instance
+	// metadata service; credentials are obtained on demand.
+	credential := cloud.NewCredential(cloud.InstanceRoleAuthType, nil)
+	s.spec.Credential = &credential
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  s.spec,
+		Config: coretesting.ModelConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env, gc.NotNil)
+}
+
 func (s *ProviderSuite) TestOpenMissingCredential(c *gc.C) {
 	s.spec.Credential = nil
 	s.testOpenError(c, s.spec, `validating cloud spec: missing credential not valid`)
@@ -85,6 +85,7 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.NetworkingEnviron = (*environ)(nil)
+var _ environs.InstanceReclamationNotifier = (*environ)(nil)
 
 // Function entry points defined as variables so they can be overridden
 // for testing purposes.
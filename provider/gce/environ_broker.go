@@ -151,6 +151,13 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 		return nil, errors.Trace(err)
 	}
 
+	// An unnamed interface has no external access config, and so no
+	// public IP address.
+	networkInterface := "ExternalNAT"
+	if cons := args.Constraints; cons.HasAllocatePublicIP() && !*cons.AllocatePublicIP {
+		networkInterface = ""
+	}
+
 	// TODO(ericsnow) Use the env ID for the network name (instead of default)?
 	// TODO(ericsnow) Make the network name configurable?
 	// TODO(ericsnow) Support multiple networks?
@@ -159,9 +166,10 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 		ID:                hostname,
 		Type:              spec.InstanceType.Name,
 		Disks:             disks,
-		NetworkInterfaces: []string{"ExternalNAT"},
+		NetworkInterfaces: []string{networkInterface},
 		Metadata:          metadata,
 		Tags:              tags,
+		Preemptible:       args.InstanceLifecycle.Type == instance.Preemptible,
 		// Network is omitted (left empty).
 	}
 
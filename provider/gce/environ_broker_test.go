@@ -13,6 +13,8 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/environs/simplestreams"
@@ -149,6 +151,48 @@ func (s *environBrokerSuite) TestNewRawInstance(c *gc.C) {
 	c.Check(inst, jc.DeepEquals, s.BaseInstance)
 }
 
+func (s *environBrokerSuite) TestNewRawInstanceNoPublicIP(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{{
+		ZoneName:  "home-zone",
+		Instances: []instance.Id{s.Instance.Id()},
+	}}
+	s.StartInstArgs.Constraints = constraints.MustParse("allocate-public-ip=false")
+
+	_, err := gce.NewRawInstance(s.Env, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var found bool
+	for _, call := range s.FakeConn.Calls {
+		if call.FuncName == "AddInstance" {
+			found = true
+			c.Check(call.InstanceSpec.NetworkInterfaces, jc.DeepEquals, []string{""})
+		}
+	}
+	c.Check(found, jc.IsTrue)
+}
+
+func (s *environBrokerSuite) TestNewRawInstancePreemptible(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{{
+		ZoneName:  "home-zone",
+		Instances: []instance.Id{s.Instance.Id()},
+	}}
+	s.StartInstArgs.InstanceLifecycle = environs.InstanceLifecycle{Type: instance.Preemptible}
+
+	_, err := gce.NewRawInstance(s.Env, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var found bool
+	for _, call := range s.FakeConn.Calls {
+		if call.FuncName == "AddInstance" {
+			found = true
+			c.Check(call.InstanceSpec.Preemptible, jc.IsTrue)
+		}
+	}
+	c.Check(found, jc.IsTrue)
+}
+
 func (s *environBrokerSuite) TestGetMetadataUbuntu(c *gc.C) {
 	metadata, err := gce.GetMetadata(s.StartInstArgs, jujuos.Ubuntu)
 
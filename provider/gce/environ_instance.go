@@ -98,6 +98,32 @@ func (env *environ) instances() ([]instance.Instance, error) {
 	return results, err
 }
 
+// preemptedStatuses holds the statuses of instances which GCE may
+// have preempted.
+var preemptedStatuses = []string{
+	google.StatusStopping,
+	google.StatusStopped,
+	google.StatusTerminated,
+}
+
+// ReclaimingInstances is part of the environs.InstanceReclamationNotifier
+// interface. It returns the IDs of the preemptible instances in the
+// environment that GCE is stopping, or has stopped.
+func (env *environ) ReclaimingInstances() ([]instance.Id, error) {
+	prefix := env.namespace.Prefix()
+	instances, err := env.gce.Instances(prefix, preemptedStatuses...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []instance.Id
+	for _, inst := range instances {
+		if inst.Preempted() {
+			results = append(results, instance.Id(inst.ID))
+		}
+	}
+	return results, nil
+}
+
 // ControllerInstances returns the IDs of the instances corresponding
 // to juju controllers.
 func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, error) {
@@ -122,6 +122,26 @@ func (s *environInstSuite) TestControllerInstancesAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].Statuses, jc.DeepEquals, []string{google.StatusPending, google.StatusStaging, google.StatusRunning})
 }
 
+func (s *environInstSuite) TestReclaimingInstances(c *gc.C) {
+	preempted := *s.BaseInstance
+	preempted.InstanceSummary.ID = "preempted"
+	preempted.InstanceSummary.Preemptible = true
+	preempted.InstanceSummary.Status = google.StatusTerminated
+	stopped := *s.BaseInstance
+	stopped.InstanceSummary.ID = "stopped"
+	stopped.InstanceSummary.Status = google.StatusTerminated
+	s.FakeConn.Insts = []google.Instance{preempted, stopped}
+
+	ids, err := s.Env.ReclaimingInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, jc.DeepEquals, []instance.Id{"preempted"})
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Instances")
+	c.Check(s.FakeConn.Calls[0].Prefix, gc.Equals, s.Prefix())
+	c.Check(s.FakeConn.Calls[0].Statuses, jc.DeepEquals, []string{google.StatusStopping, google.StatusStopped, google.StatusTerminated})
+}
+
 func (s *environInstSuite) TestControllerInstancesNotBootstrapped(c *gc.C) {
 	_, err := s.Env.ControllerInstances(s.ControllerUUID)
 
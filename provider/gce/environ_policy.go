@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
 )
 
 // PrecheckInstance verifies that the provided series and constraints
@@ -33,6 +34,8 @@ func (env *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.ImageID,
+	constraints.MaxPrice,
 }
 
 // instanceTypeConstraints defines the fields defined on each of the
@@ -72,6 +75,12 @@ func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
 
 	validator.RegisterVocabulary(constraints.Container, []string{vtype})
 
+	// GCE offers preemptible, but not spot, instances.
+	validator.RegisterVocabulary(constraints.InstanceLifecycle, []string{
+		string(instance.OnDemand),
+		string(instance.Preemptible),
+	})
+
 	return validator, nil
 }
 
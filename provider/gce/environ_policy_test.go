@@ -196,6 +196,17 @@ func (s *environPolSuite) TestConstraintsValidatorVocabContainer(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "invalid constraint value: container=lxd\nvalid values are:.*")
 }
 
+func (s *environPolSuite) TestConstraintsValidatorVocabInstanceLifecycle(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = validator.Validate(constraints.MustParse("instance-lifecycle=preemptible"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = validator.Validate(constraints.MustParse("instance-lifecycle=spot"))
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: instance-lifecycle=spot\nvalid values are:.*")
+}
+
 func (s *environPolSuite) TestConstraintsValidatorConflicts(c *gc.C) {
 	validator, err := s.Env.ConstraintsValidator()
 	c.Assert(err, jc.ErrorIsNil)
@@ -43,10 +43,13 @@ type InstanceSpec struct {
 	// useful when making bulk calls or in relation to some API methods
 	// (e.g. related to firewalls access rules).
 	Tags []string
+	// Preemptible indicates that the instance may be stopped by GCE
+	// at any time, in exchange for a lower price.
+	Preemptible bool
 }
 
 func (is InstanceSpec) raw() *compute.Instance {
-	return &compute.Instance{
+	raw := &compute.Instance{
 		Name:              is.ID,
 		Disks:             is.disks(),
 		NetworkInterfaces: is.networkInterfaces(),
@@ -54,6 +57,17 @@ func (is InstanceSpec) raw() *compute.Instance {
 		Tags:              &compute.Tags{Items: is.Tags},
 		// MachineType is set in the addInstance call.
 	}
+	if is.Preemptible {
+		// GCE requires that preemptible instances are neither
+		// restarted automatically nor migrated for maintenance.
+		automaticRestart := false
+		raw.Scheduling = &compute.Scheduling{
+			Preemptible:       true,
+			AutomaticRestart:  &automaticRestart,
+			OnHostMaintenance: "TERMINATE",
+		}
+	}
+	return raw
 }
 
 // Summary builds an InstanceSummary based on the spec and returns it.
@@ -102,6 +116,9 @@ type InstanceSummary struct {
 	// NetworkInterfaces are the network connections associated with
 	// the instance.
 	NetworkInterfaces []*compute.NetworkInterface
+	// Preemptible indicates whether the instance may be stopped by
+	// GCE at any time.
+	Preemptible bool
 }
 
 func newInstanceSummary(raw *compute.Instance) InstanceSummary {
@@ -112,6 +129,7 @@ func newInstanceSummary(raw *compute.Instance) InstanceSummary {
 		Metadata:          unpackMetadata(raw.Metadata),
 		Addresses:         extractAddresses(raw.NetworkInterfaces...),
 		NetworkInterfaces: raw.NetworkInterfaces,
+		Preemptible:       raw.Scheduling != nil && raw.Scheduling.Preemptible,
 	}
 }
 
@@ -166,6 +184,19 @@ func (gi Instance) Status() string {
 	return gi.InstanceSummary.Status
 }
 
+// Preempted reports whether the instance is preemptible and has been,
+// or is being, stopped by GCE.
+func (gi Instance) Preempted() bool {
+	if !gi.InstanceSummary.Preemptible {
+		return false
+	}
+	switch gi.InstanceSummary.Status {
+	case StatusStopping, StatusStopped, StatusTerminated:
+		return true
+	}
+	return false
+}
+
 // Addresses identifies information about the network addresses
 // associated with the instance and returns it.
 func (gi Instance) Addresses() []network.Address {
@@ -62,6 +62,27 @@ func (s *instanceSuite) TestInstanceStatusDown(c *gc.C) {
 	c.Check(status, gc.Equals, google.StatusDown)
 }
 
+func (s *instanceSuite) TestNewInstancePreemptible(c *gc.C) {
+	s.InstanceSpec.Preemptible = true
+	summary := s.InstanceSpec.Summary()
+	c.Check(summary.Preemptible, jc.IsTrue)
+
+	inst := google.NewInstance(summary, &s.InstanceSpec)
+	c.Check(inst.Preemptible, jc.IsTrue)
+}
+
+func (s *instanceSuite) TestInstancePreempted(c *gc.C) {
+	c.Check(s.Instance.Preempted(), jc.IsFalse)
+
+	s.Instance.InstanceSummary.Preemptible = true
+	c.Check(s.Instance.Preempted(), jc.IsFalse)
+
+	for _, status := range []string{google.StatusStopping, google.StatusStopped, google.StatusTerminated} {
+		s.Instance.InstanceSummary.Status = status
+		c.Check(s.Instance.Preempted(), jc.IsTrue)
+	}
+}
+
 func (s *instanceSuite) TestInstanceAddresses(c *gc.C) {
 	addresses := s.Instance.Addresses()
 
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+// defaultSSHPort is the port to connect to on the
+// hypervisor host if the endpoint does not specify one.
+const defaultSSHPort = "22"
+
+// DialFunc is a function type for dialing libvirt client connections.
+type DialFunc func(address string, config libvirtclient.SSHConfig) (Client, error)
+
+// Client is an interface for managing the libvirt daemon
+// of a hypervisor host.
+type Client interface {
+	Close() error
+	AttachVolume(domain, pool, name, serial string) (string, error)
+	CreateDomain(libvirtclient.CreateDomainParams) (*libvirtclient.Domain, error)
+	CreateVolume(pool, name string, sizeMiB uint64) (*libvirtclient.Volume, error)
+	DeleteVolume(pool, name string) error
+	DestroyDomain(name string) error
+	DetachVolume(domain, serial string) error
+	DomainAddresses(name string) ([]libvirtclient.InterfaceAddress, error)
+	Domains(prefix string) ([]*libvirtclient.Domain, error)
+	EnsureImage(pool string, source libvirtclient.ImageSource) (string, error)
+	Networks() ([]*libvirtclient.Network, error)
+	SetDomainTags(name string, tags map[string]string) error
+	Volumes(pool, prefix string) ([]*libvirtclient.Volume, error)
+}
+
+func dialClient(cloudSpec environs.CloudSpec, dial DialFunc) (Client, error) {
+	address, err := sshAddress(cloudSpec.Endpoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	credAttrs := cloudSpec.Credential.Attributes()
+	return dial(address, libvirtclient.SSHConfig{
+		User:       credAttrs[credAttrUsername],
+		PrivateKey: []byte(credAttrs[credAttrPrivateKey]),
+		HostKeys:   credAttrs[credAttrHostKeys],
+	})
+}
+
+// sshAddress returns the "host:port" address of the hypervisor host
+// for the given endpoint, which may be either "host[:port]", or an
+// ssh:// or qemu+ssh:// URL.
+func sshAddress(endpoint string) (string, error) {
+	address := endpoint
+	if strings.Contains(endpoint, "://") {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return "", errors.Annotate(err, "parsing endpoint")
+		}
+		switch u.Scheme {
+		case "ssh", "qemu+ssh":
+		default:
+			return "", errors.Errorf("invalid endpoint %q: expected an ssh or qemu+ssh URL", endpoint)
+		}
+		if u.User != nil {
+			return "", errors.Errorf("invalid endpoint %q: the user must be specified by the credential", endpoint)
+		}
+		address = u.Host
+	}
+	if address == "" {
+		return "", errors.Errorf("invalid endpoint %q: missing host", endpoint)
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		// There is no port, so use the default.
+		address = net.JoinHostPort(strings.Trim(address, "[]"), defaultSSHPort)
+	}
+	return address, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/common"
+)
+
+// The libvirt-specific config keys.
+const (
+	// cfgNetwork is the name of the libvirt network to which all
+	// guests are connected.
+	cfgNetwork = "network"
+
+	// cfgStoragePool is the name of the libvirt storage pool in
+	// which images and root disks are stored, and in which volumes
+	// are created unless the Juju storage pool specifies one.
+	cfgStoragePool = "storage-pool"
+)
+
+// configFields is the spec for each libvirt config value's type.
+var (
+	configFields = schema.Fields{
+		cfgNetwork:     schema.String(),
+		cfgStoragePool: schema.String(),
+	}
+
+	configDefaults = schema.Defaults{
+		cfgNetwork:     "default",
+		cfgStoragePool: "default",
+	}
+)
+
+type environConfig struct {
+	*config.Config
+	attrs map[string]interface{}
+}
+
+// newValidConfig validates cfg, as a change to old if old is
+// non-nil, and returns it as an environConfig. The network and
+// storage pool are looked up on the host only when they are used,
+// as the host may be unreachable while the config is validated.
+func newValidConfig(cfg, old *config.Config) (*environConfig, error) {
+	valid, err := common.ValidateConfig(cfg, old, configFields, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &environConfig{
+		Config: valid,
+		attrs:  valid.UnknownAttrs(),
+	}, nil
+}
+
+func (c *environConfig) network() string {
+	network, _ := c.attrs[cfgNetwork].(string)
+	return network
+}
+
+func (c *environConfig) storagePool() string {
+	pool, _ := c.attrs[cfgStoragePool].(string)
+	return pool
+}
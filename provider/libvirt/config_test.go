@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing"
+)
+
+func fakeConfig(c *gc.C, attrs ...testing.Attrs) *config.Config {
+	cfg, err := testing.ModelConfig(c).Apply(fakeConfigAttrs(attrs...))
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func fakeConfigAttrs(attrs ...testing.Attrs) testing.Attrs {
+	merged := testing.FakeConfig().Merge(testing.Attrs{
+		"type": "libvirt",
+		"uuid": fakeModelUUID,
+	})
+	for _, attrs := range attrs {
+		merged = merged.Merge(attrs)
+	}
+	return merged
+}
+
+func fakeCloudSpec() environs.CloudSpec {
+	cred := fakeCredential()
+	return environs.CloudSpec{
+		Type:       "libvirt",
+		Name:       "libvirt",
+		Region:     "host1",
+		Endpoint:   "10.0.0.1",
+		Credential: &cred,
+	}
+}
+
+func fakeCredential() cloud.Credential {
+	return cloud.NewCredential(cloud.SSHKeyAuthType, map[string]string{
+		"username":    "ubuntu",
+		"private-key": "private-key-data",
+	})
+}
+
+type ConfigSuite struct {
+	testing.BaseSuite
+	provider environs.EnvironProvider
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	var err error
+	s.provider, err = environs.Provider("libvirt")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestValidateNewConfig(c *gc.C) {
+	cfg := fakeConfig(c, testing.Attrs{
+		"network":      "br0",
+		"storage-pool": "ssd",
+	})
+	validCfg, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validCfg.UnknownAttrs(), jc.DeepEquals, map[string]interface{}{
+		"network":      "br0",
+		"storage-pool": "ssd",
+	})
+}
+
+func (s *ConfigSuite) TestValidateNewConfigDefaults(c *gc.C) {
+	validCfg, err := s.provider.Validate(fakeConfig(c), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validCfg.UnknownAttrs(), jc.DeepEquals, map[string]interface{}{
+		"network":      "default",
+		"storage-pool": "default",
+	})
+}
+
+func (s *ConfigSuite) TestValidateNewConfigInvalid(c *gc.C) {
+	cfg := fakeConfig(c, testing.Attrs{"network": 123})
+	_, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid config: .*expected string, got int\(123\)`)
+}
+
+func (s *ConfigSuite) TestValidateChange(c *gc.C) {
+	oldCfg := fakeConfig(c)
+	newCfg := fakeConfig(c, testing.Attrs{"storage-pool": "ssd"})
+	validCfg, err := s.provider.Validate(newCfg, oldCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validCfg.UnknownAttrs()["storage-pool"], gc.Equals, "ssd")
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+const (
+	credAttrUsername       = "username"
+	credAttrPrivateKey     = "private-key"
+	credAttrPrivateKeyPath = "private-key-path"
+	credAttrHostKeys       = "host-keys"
+)
+
+type environProviderCredentials struct{}
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.SSHKeyAuthType: {
+			{
+				credAttrUsername, cloud.CredentialAttr{
+					Description: "The user to log in to the hypervisor hosts as, which must be permitted to manage libvirt.",
+				},
+			}, {
+				credAttrPrivateKey, cloud.CredentialAttr{
+					Description: "The SSH private key to authenticate with.",
+					Hidden:      true,
+					FileAttr:    credAttrPrivateKeyPath,
+				},
+			}, {
+				credAttrHostKeys, cloud.CredentialAttr{
+					Description: "The public keys of the hypervisor hosts, in authorized_keys format. If unspecified, host keys are not verified.",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	return nil, errors.NotFoundf("credentials")
+}
+
+// FinalizeCredential is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
+	return &args.Credential, nil
+}
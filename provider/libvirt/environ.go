@@ -0,0 +1,226 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+type environ struct {
+	name     string
+	cloud    environs.CloudSpec
+	provider *environProvider
+
+	// namespace is used to create the domain and volume names.
+	namespace instance.Namespace
+
+	lock sync.Mutex // lock protects access the following fields.
+	ecfg *environConfig
+}
+
+func newEnviron(
+	provider *environProvider,
+	cloud environs.CloudSpec,
+	cfg *config.Config,
+) (*environ, error) {
+	ecfg, err := newValidConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+
+	namespace, err := instance.NewNamespace(cfg.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	env := &environ{
+		name:      ecfg.Name(),
+		cloud:     cloud,
+		provider:  provider,
+		ecfg:      ecfg,
+		namespace: namespace,
+	}
+	return env, nil
+}
+
+// Name is part of the environs.Environ interface.
+func (env *environ) Name() string {
+	return env.name
+}
+
+// Provider is part of the environs.Environ interface.
+func (env *environ) Provider() environs.EnvironProvider {
+	return env.provider
+}
+
+// SetConfig is part of the environs.Environ interface.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	if env.ecfg == nil {
+		return errors.New("cannot set config on uninitialized env")
+	}
+
+	ecfg, err := newValidConfig(cfg, env.ecfg.Config)
+	if err != nil {
+		return errors.Annotate(err, "invalid config change")
+	}
+	env.ecfg = ecfg
+	return nil
+}
+
+// Config is part of the environs.Environ interface.
+func (env *environ) Config() *config.Config {
+	env.lock.Lock()
+	cfg := env.ecfg.Config
+	env.lock.Unlock()
+	return cfg
+}
+
+func (env *environ) environConfig() *environConfig {
+	env.lock.Lock()
+	ecfg := env.ecfg
+	env.lock.Unlock()
+	return ecfg
+}
+
+// PrepareForBootstrap implements environs.Environ.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
+	return nil
+}
+
+// Create implements environs.Environ.
+func (env *environ) Create(args environs.CreateParams) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.Create(args)
+	})
+}
+
+// Create implements environs.Environ.
+func (env *sessionEnviron) Create(args environs.CreateParams) error {
+	// There is nothing to create up front, but make sure that
+	// the host is accessible, and has the configured network.
+	_, err := env.findNetwork(env.environConfig().network())
+	return errors.Trace(err)
+}
+
+// Bootstrap is part of the environs.Environ interface.
+func (env *environ) Bootstrap(
+	ctx environs.BootstrapContext,
+	args environs.BootstrapParams,
+) (result *environs.BootstrapResult, err error) {
+	// The SSH connection of a sessionEnviron is closed when the
+	// session ends, whereas common.Bootstrap holds on to the Environ
+	// to finalize the controller after returning.
+	return common.Bootstrap(ctx, env, args)
+}
+
+// destroyEnv is patched in tests, so that DestroyController can be
+// tested without listing and stopping the controller model's domains.
+var destroyEnv = common.Destroy
+
+// AdoptResources is part of the Environ interface.
+func (env *environ) AdoptResources(controllerUUID string, fromVersion version.Number) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.AdoptResources(controllerUUID, fromVersion)
+	})
+}
+
+// AdoptResources is part of the Environ interface.
+func (env *sessionEnviron) AdoptResources(controllerUUID string, fromVersion version.Number) error {
+	// Volumes have no metadata, and are identified by the model's
+	// namespace alone, so only the domains need to be updated.
+	domains, err := env.modelDomains()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, domain := range domains {
+		if domain.Tags[tags.JujuController] == controllerUUID {
+			continue
+		}
+		domainTags := make(map[string]string)
+		for k, v := range domain.Tags {
+			domainTags[k] = v
+		}
+		domainTags[tags.JujuController] = controllerUUID
+		if err := env.client.SetDomainTags(domain.Name, domainTags); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Destroy is part of the environs.Environ interface.
+func (env *environ) Destroy() error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.Destroy()
+	})
+}
+
+// Destroy is part of the environs.Environ interface.
+func (env *sessionEnviron) Destroy() error {
+	return errors.Trace(destroyEnv(env))
+}
+
+// DestroyController implements the Environ interface.
+func (env *environ) DestroyController(controllerUUID string) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.DestroyController(controllerUUID)
+	})
+}
+
+// DestroyController implements the Environ interface.
+func (env *sessionEnviron) DestroyController(controllerUUID string) error {
+	if err := env.Destroy(); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Remove the domains of any models that remain in the
+	// controller, along with their volumes in the configured
+	// storage pool.
+	domains, err := env.client.Domains(tags.JujuTagPrefix)
+	if err != nil {
+		return errors.Annotate(err, "listing domains")
+	}
+	modelUUIDs := set.NewStrings()
+	for _, domain := range domains {
+		if domain.Tags[tags.JujuController] != controllerUUID {
+			continue
+		}
+		if err := env.client.DestroyDomain(domain.Name); err != nil {
+			return errors.Annotate(err, "removing domains")
+		}
+		if modelUUID := domain.Tags[tags.JujuModel]; modelUUID != "" {
+			modelUUIDs.Add(modelUUID)
+		}
+	}
+	pool := env.environConfig().storagePool()
+	for _, modelUUID := range modelUUIDs.SortedValues() {
+		namespace, err := instance.NewNamespace(modelUUID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumes, err := env.client.Volumes(pool, volumeNamePrefix(namespace))
+		if err != nil {
+			return errors.Annotate(err, "listing volumes")
+		}
+		for _, volume := range volumes {
+			if err := env.client.DeleteVolume(pool, volume.Name); err != nil {
+				return errors.Annotate(err, "removing volumes")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+	"github.com/juju/juju/status"
+)
+
+// The sizes of guests started without constraints.
+const (
+	defaultMemMiB      = 2048
+	defaultCores       = 1
+	defaultRootDiskMiB = 8 * 1024
+)
+
+// modelDomains returns the domains of the model's instances. The
+// domain's names are prefixed with the model's namespace, and the
+// model UUID is recorded in their metadata.
+func (env *sessionEnviron) modelDomains() ([]*libvirtclient.Domain, error) {
+	domains, err := env.client.Domains(env.namespace.Prefix())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelUUID := env.Config().UUID()
+	var results []*libvirtclient.Domain
+	for _, domain := range domains {
+		if domain.Tags[tags.JujuModel] == modelUUID {
+			results = append(results, domain)
+		}
+	}
+	return results, nil
+}
+
+// MaintainInstance is specified in the InstanceBroker interface.
+func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *environ) StartInstance(args environs.StartInstanceParams) (result *environs.StartInstanceResult, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		result, err = env.StartInstance(args)
+		return err
+	})
+	return result, err
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *sessionEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	// Only amd64 hosts are supported, and guests are not emulated.
+	if err := common.FinishInstanceConfig(args, arch.AMD64, env.Config()); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	domain, hw, err := env.newRawInstance(args)
+	if err != nil {
+		args.StatusCallback(status.ProvisioningError, fmt.Sprint(err), nil)
+		return nil, errors.Trace(err)
+	}
+
+	logger.Infof("started instance %q", domain.Name)
+	inst := newInstance(domain, env.environ)
+	result := environs.StartInstanceResult{
+		Instance: inst,
+		Hardware: hw,
+	}
+	return &result, nil
+}
+
+// newRawInstance creates and starts a domain whose root disk is a
+// copy-on-write overlay of the cached cloud image for the series,
+// and returns it along with its hardware. The user data is passed to
+// cloud-init on a NoCloud seed image attached to the domain.
+func (env *sessionEnviron) newRawInstance(
+	args environs.StartInstanceParams,
+) (*libvirtclient.Domain, *instance.HardwareCharacteristics, error) {
+
+	domainName, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	series := args.InstanceConfig.Series
+	cloudcfg, err := cloudinit.New(series)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	// Not every libvirt network registers its guests with DNS,
+	// so make the domain's hostname resolvable locally.
+	cloudcfg.ManageEtcHosts(true)
+
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudcfg, LibvirtRenderer{})
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot make user data")
+	}
+	logger.Debugf("libvirt user data; %d bytes", len(userData))
+
+	pool := env.environConfig().storagePool()
+	if args.StatusCallback != nil {
+		args.StatusCallback(status.Provisioning, "preparing image", nil)
+	}
+	image, err := env.ensureImage(pool, series)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	networks, err := env.startInstanceNetworks(args)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	cons := args.Constraints
+	createDomainArgs := libvirtclient.CreateDomainParams{
+		Name:        domainName,
+		MemoryMiB:   defaultMemMiB,
+		VCPUs:       defaultCores,
+		Pool:        pool,
+		Image:       image,
+		RootDiskMiB: defaultRootDiskMiB,
+		UserData:    userData,
+		Networks:    networks,
+		Tags:        args.InstanceConfig.Tags,
+	}
+	if cons.Mem != nil {
+		createDomainArgs.MemoryMiB = *cons.Mem
+	}
+	if cons.CpuCores != nil {
+		createDomainArgs.VCPUs = *cons.CpuCores
+	}
+	if cons.RootDisk != nil {
+		createDomainArgs.RootDiskMiB = *cons.RootDisk
+	}
+
+	domain, err := env.client.CreateDomain(createDomainArgs)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	instArch := arch.AMD64
+	hw := &instance.HardwareCharacteristics{
+		Arch:     &instArch,
+		Mem:      &domain.MemoryMiB,
+		CpuCores: &domain.VCPUs,
+		RootDisk: &createDomainArgs.RootDiskMiB,
+	}
+	return domain, hw, nil
+}
+
+// startInstanceNetworks returns the names of the networks to which an
+// instance must be connected: the configured network, and each network
+// with subnets that the instance must be connected to.
+func (env *sessionEnviron) startInstanceNetworks(args environs.StartInstanceParams) ([]string, error) {
+	networks := []string{env.environConfig().network()}
+	if len(args.SubnetsToZones) == 0 {
+		return networks, nil
+	}
+	subnets, err := env.Subnets(instance.UnknownId, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seen := set.NewStrings(networks...)
+	for _, subnet := range subnets {
+		if _, ok := args.SubnetsToZones[subnet.ProviderId]; !ok {
+			continue
+		}
+		name := string(subnet.ProviderNetworkId)
+		if !seen.Contains(name) {
+			seen.Add(name)
+			networks = append(networks, name)
+		}
+	}
+	return networks, nil
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *environ) AllInstances() (instances []instance.Instance, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		instances, err = env.AllInstances()
+		return err
+	})
+	return instances, err
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *sessionEnviron) AllInstances() ([]instance.Instance, error) {
+	domains, err := env.modelDomains()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]instance.Instance, len(domains))
+	for i, domain := range domains {
+		results[i] = newInstance(domain, env.environ)
+	}
+	return results, nil
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *environ) StopInstances(ids ...instance.Id) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.StopInstances(ids...)
+	})
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *sessionEnviron) StopInstances(ids ...instance.Id) error {
+	// Each domain is destroyed by a separate virsh command, run in
+	// its own session on the shared SSH connection.
+	return common.StopInstances(ids, func(id instance.Id) error {
+		return env.client.DestroyDomain(string(id))
+	})
+}
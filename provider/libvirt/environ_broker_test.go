@@ -0,0 +1,241 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/imagedownloads"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/libvirt"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type environBrokerSuite struct {
+	EnvironFixture
+	statusCallbackStub testing.Stub
+	imageMetadataStub  testing.Stub
+}
+
+var _ = gc.Suite(&environBrokerSuite{})
+
+func (s *environBrokerSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	s.statusCallbackStub.ResetCalls()
+	s.imageMetadataStub.ResetCalls()
+
+	s.PatchValue(libvirt.FetchImageMetadata, func(
+		arch, series, ftype string, src func() simplestreams.DataSource,
+	) (*imagedownloads.Metadata, error) {
+		s.imageMetadataStub.AddCall("One", arch, series, ftype)
+		return &imagedownloads.Metadata{
+			Arch:    arch,
+			Release: series,
+			SHA256:  "0123456789abcdef",
+			Path:    "server/releases/trusty/release/ubuntu-14.04-server-cloudimg-amd64-disk1.img",
+			BaseURL: "https://cloud-images.ubuntu.com/releases",
+		}, s.imageMetadataStub.NextErr()
+	})
+
+	s.client.image = "juju-trusty-amd64-0123456789ab.img"
+	s.client.createdDomain = &libvirtclient.Domain{
+		Name:      "juju-f75cba-0",
+		State:     libvirtclient.DomainStateRunning,
+		MemoryMiB: 2048,
+		VCPUs:     1,
+	}
+}
+
+func (s *environBrokerSuite) createStartInstanceArgs(c *gc.C) environs.StartInstanceParams {
+	var cons constraints.Value
+	instanceConfig, err := instancecfg.NewBootstrapInstanceConfig(
+		coretesting.FakeControllerConfig(), cons, cons, "trusty", "",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	instanceConfig.AuthorizedKeys = fakeConfig(c).AuthorizedKeys()
+
+	tools := coretools.List{{
+		Version: version.Binary{
+			Number: version.MustParse("1.2.3"),
+			Arch:   arch.AMD64,
+			Series: "trusty",
+		},
+		URL: "https://example.org",
+	}}
+	err = instanceConfig.SetTools(tools[:1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	return environs.StartInstanceParams{
+		ControllerUUID: instanceConfig.Controller.Config.ControllerUUID(),
+		InstanceConfig: instanceConfig,
+		Tools:          tools,
+		Constraints:    cons,
+		StatusCallback: func(status status.Status, info string, data map[string]interface{}) error {
+			s.statusCallbackStub.AddCall("StatusCallback", status, info, data)
+			return s.statusCallbackStub.NextErr()
+		},
+	}
+}
+
+func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
+	args := s.createStartInstanceArgs(c)
+	args.InstanceConfig.Tags = map[string]string{
+		tags.JujuModel:        fakeModelUUID,
+		tags.JujuIsController: "true",
+	}
+	result, err := s.env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.NotNil)
+	c.Assert(result.Instance, gc.NotNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("juju-f75cba-0"))
+
+	s.imageMetadataStub.CheckCalls(c, []testing.StubCall{{
+		"One", []interface{}{"amd64", "trusty", "disk1.img"},
+	}})
+	s.client.CheckCallNames(c, "EnsureImage", "CreateDomain", "Close")
+	s.client.CheckCall(c, 0, "EnsureImage", "default", libvirtclient.ImageSource{
+		URL:     "https://cloud-images.ubuntu.com/releases/server/releases/trusty/release/ubuntu-14.04-server-cloudimg-amd64-disk1.img",
+		SHA256:  "0123456789abcdef",
+		Release: "trusty",
+		Arch:    "amd64",
+	})
+
+	call := s.client.Calls()[1]
+	c.Assert(call.Args, gc.HasLen, 1)
+	c.Assert(call.Args[0], gc.FitsTypeOf, libvirtclient.CreateDomainParams{})
+	createDomainArgs := call.Args[0].(libvirtclient.CreateDomainParams)
+	c.Assert(createDomainArgs.UserData, gc.Not(gc.HasLen), 0)
+	createDomainArgs.UserData = nil
+	c.Assert(createDomainArgs, jc.DeepEquals, libvirtclient.CreateDomainParams{
+		Name:        "juju-f75cba-0",
+		MemoryMiB:   2048,
+		VCPUs:       1,
+		Pool:        "default",
+		Image:       "juju-trusty-amd64-0123456789ab.img",
+		RootDiskMiB: 8192,
+		Networks:    []string{"default"},
+		Tags: map[string]string{
+			tags.JujuModel:        fakeModelUUID,
+			tags.JujuIsController: "true",
+		},
+	})
+
+	c.Assert(*result.Hardware.Arch, gc.Equals, "amd64")
+	c.Assert(*result.Hardware.Mem, gc.Equals, uint64(2048))
+	c.Assert(*result.Hardware.CpuCores, gc.Equals, uint64(1))
+	c.Assert(*result.Hardware.RootDisk, gc.Equals, uint64(8192))
+}
+
+func (s *environBrokerSuite) TestStartInstanceConstraints(c *gc.C) {
+	args := s.createStartInstanceArgs(c)
+	args.Constraints = constraints.MustParse("mem=4G cores=4 root-disk=20G")
+	_, err := s.env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	call := s.client.Calls()[1]
+	createDomainArgs := call.Args[0].(libvirtclient.CreateDomainParams)
+	c.Assert(createDomainArgs.MemoryMiB, gc.Equals, uint64(4096))
+	c.Assert(createDomainArgs.VCPUs, gc.Equals, uint64(4))
+	c.Assert(createDomainArgs.RootDiskMiB, gc.Equals, uint64(20480))
+}
+
+func (s *environBrokerSuite) TestStartInstanceNetworks(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: fakeConfig(c, coretesting.Attrs{"network": "br0"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.networks = []*libvirtclient.Network{
+		{Name: "br0", Forward: "bridge", Bridge: "br0", CIDRs: []string{"192.168.1.0/24"}},
+		{Name: "default", CIDRs: []string{"192.168.122.0/24"}},
+		{Name: "isolated", CIDRs: []string{"10.0.0.0/24"}},
+	}
+
+	args := s.createStartInstanceArgs(c)
+	args.SubnetsToZones = map[network.Id][]string{
+		"br0:192.168.1.0/24":   nil,
+		"isolated:10.0.0.0/24": nil,
+	}
+	_, err = env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c, "EnsureImage", "Networks", "CreateDomain", "Close")
+	createDomainArgs := s.client.Calls()[2].Args[0].(libvirtclient.CreateDomainParams)
+	c.Assert(createDomainArgs.Networks, jc.DeepEquals, []string{"br0", "isolated"})
+}
+
+func (s *environBrokerSuite) TestStartInstanceImageError(c *gc.C) {
+	s.imageMetadataStub.SetErrors(errors.New("no images"))
+	args := s.createStartInstanceArgs(c)
+	_, err := s.env.StartInstance(args)
+	c.Assert(err, gc.ErrorMatches, `finding image for series "trusty": no images`)
+	s.client.CheckCallNames(c, "Close")
+	s.statusCallbackStub.CheckCallNames(c, "StatusCallback", "StatusCallback")
+	s.statusCallbackStub.CheckCall(c, 1, "StatusCallback",
+		status.ProvisioningError, `finding image for series "trusty": no images`,
+		map[string]interface{}(nil),
+	)
+}
+
+func (s *environBrokerSuite) TestAllInstances(c *gc.C) {
+	other := newDomain("juju-f75cba-2", libvirtclient.DomainStateRunning)
+	other.Tags["juju-model-uuid"] = "another-model"
+	s.client.domains = []*libvirtclient.Domain{
+		newDomain("juju-f75cba-0", libvirtclient.DomainStateRunning),
+		newDomain("juju-f75cba-1", libvirtclient.DomainStateShutOff),
+		other,
+	}
+	instances, err := s.env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 2)
+	c.Assert(instances[0].Id(), gc.Equals, instance.Id("juju-f75cba-0"))
+	c.Assert(instances[0].Status().Status, gc.Equals, status.Running)
+	c.Assert(instances[1].Id(), gc.Equals, instance.Id("juju-f75cba-1"))
+	c.Assert(instances[1].Status(), jc.DeepEquals, instance.InstanceStatus{
+		Status:  status.Empty,
+		Message: "shut off",
+	})
+
+	s.client.CheckCallNames(c, "Domains", "Close")
+	s.client.CheckCall(c, 0, "Domains", "juju-f75cba-")
+}
+
+func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
+	err := s.env.StopInstances("juju-f75cba-0", "juju-f75cba-1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var names []string
+	s.client.CheckCallNames(c, "DestroyDomain", "DestroyDomain", "Close")
+	for i := 0; i < 2; i++ {
+		args := s.client.Calls()[i].Args
+		names = append(names, args[0].(string))
+	}
+	// The instances are removed concurrently,
+	// so the order of the calls is not deterministic.
+	c.Assert(names, jc.SameContents, []string{"juju-f75cba-0", "juju-f75cba-1"})
+}
+
+func (s *environBrokerSuite) TestStopInstancesOneFailure(c *gc.C) {
+	s.client.SetErrors(errors.New("bah"))
+	err := s.env.StopInstances("juju-f75cba-0", "juju-f75cba-1")
+
+	s.client.CheckCallNames(c, "DestroyDomain", "DestroyDomain", "Close")
+	name := s.client.Calls()[0].Args[0].(string)
+	c.Assert(err, gc.ErrorMatches, "failed to stop instance "+name+": bah")
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+type environInstanceSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environInstanceSuite{})
+
+func (s *environInstanceSuite) TestInstances(c *gc.C) {
+	s.client.domains = []*libvirtclient.Domain{
+		newDomain("juju-f75cba-0", libvirtclient.DomainStateRunning),
+		newDomain("juju-f75cba-1", libvirtclient.DomainStateRunning),
+	}
+	instances, err := s.env.Instances([]instance.Id{"juju-f75cba-1", "juju-f75cba-2"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(instances, gc.HasLen, 2)
+	c.Assert(instances[0].Id(), gc.Equals, instance.Id("juju-f75cba-1"))
+	c.Assert(instances[1], gc.IsNil)
+}
+
+func (s *environInstanceSuite) TestInstancesNoInstances(c *gc.C) {
+	_, err := s.env.Instances([]instance.Id{"juju-f75cba-0"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environInstanceSuite) TestInstanceAddresses(c *gc.C) {
+	s.client.domains = []*libvirtclient.Domain{
+		newDomain("juju-f75cba-0", libvirtclient.DomainStateRunning),
+	}
+	s.client.domainAddresses = []libvirtclient.InterfaceAddress{
+		{MAC: "52:54:00:00:00:01", Address: "192.168.122.10/24"},
+		{MAC: "52:54:00:00:00:01", Address: "fe80::5054:ff:fe00:1/64"},
+	}
+	instances, err := s.env.Instances([]instance.Id{"juju-f75cba-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	addrs, err := instances[0].Addresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []network.Address{
+		network.NewAddress("192.168.122.10"),
+		network.NewAddress("fe80::5054:ff:fe00:1"),
+	})
+	s.client.CheckCallNames(c, "Domains", "Close", "DomainAddresses", "Close")
+	s.client.CheckCall(c, 2, "DomainAddresses", "juju-f75cba-0")
+}
+
+func (s *environInstanceSuite) TestControllerInstances(c *gc.C) {
+	controller := newDomain("juju-f75cba-0", libvirtclient.DomainStateRunning)
+	controller.Tags[tags.JujuController] = "foo"
+	controller.Tags[tags.JujuIsController] = "true"
+	otherController := newDomain("juju-f75cba-1", libvirtclient.DomainStateRunning)
+	otherController.Tags[tags.JujuController] = "bar"
+	otherController.Tags[tags.JujuIsController] = "true"
+	s.client.domains = []*libvirtclient.Domain{
+		controller,
+		otherController,
+		newDomain("juju-f75cba-2", libvirtclient.DomainStateRunning),
+	}
+	ids, err := s.env.ControllerInstances("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []instance.Id{"juju-f75cba-0"})
+}
+
+func (s *environInstanceSuite) TestControllerInstancesNotBootstrapped(c *gc.C) {
+	_, err := s.env.ControllerInstances("foo")
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
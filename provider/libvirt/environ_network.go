@@ -0,0 +1,290 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+var _ environs.NetworkingEnviron = (*environ)(nil)
+
+// OpenPorts is part of the environs.Firewaller interface.
+func (*environ) OpenPorts(rules []network.IngressRule) error {
+	return errors.Trace(errors.NotSupportedf("OpenPorts"))
+}
+
+// ClosePorts is part of the environs.Firewaller interface.
+func (*environ) ClosePorts(rules []network.IngressRule) error {
+	return errors.Trace(errors.NotSupportedf("ClosePorts"))
+}
+
+// IngressRules is part of the environs.Firewaller interface.
+func (*environ) IngressRules() ([]network.IngressRule, error) {
+	return nil, errors.Trace(errors.NotSupportedf("IngressRules"))
+}
+
+// libvirt networks are mapped to Juju spaces: each network on the host
+// is a space, whose provider id is the network's name. The subnets of
+// a network are those defined by its IP elements or, for networks that
+// forward to an existing host bridge, the subnets of the addresses on
+// the bridge.
+
+// subnetProviderId returns the provider id of the subnet with the
+// given CIDR on the named network.
+func subnetProviderId(networkName, cidr string) network.Id {
+	return network.Id(networkName + ":" + cidr)
+}
+
+// findNetwork returns the named network on the host.
+func (env *sessionEnviron) findNetwork(name string) (*libvirtclient.Network, error) {
+	networks, err := env.client.Networks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, n := range networks {
+		if n.Name == name {
+			return n, nil
+		}
+	}
+	return nil, errors.NotFoundf("network %q", name)
+}
+
+// Subnets is part of the environs.Networking interface.
+func (env *environ) Subnets(instId instance.Id, subnetIds []network.Id) (subnets []network.SubnetInfo, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		subnets, err = env.Subnets(instId, subnetIds)
+		return err
+	})
+	return subnets, err
+}
+
+// Subnets is part of the environs.Networking interface.
+func (env *sessionEnviron) Subnets(instId instance.Id, subnetIds []network.Id) ([]network.SubnetInfo, error) {
+	networks, err := env.client.Networks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if instId != instance.UnknownId {
+		// Only consider the networks the instance is connected to.
+		domain, err := env.domain(instId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		instNetworks := set.NewStrings()
+		for _, iface := range domain.Interfaces {
+			instNetworks.Add(iface.Network)
+		}
+		var filtered []*libvirtclient.Network
+		for _, n := range networks {
+			if instNetworks.Contains(n.Name) {
+				filtered = append(filtered, n)
+			}
+		}
+		networks = filtered
+	}
+	subnets := makeSubnets(networks)
+	if len(subnetIds) == 0 {
+		return subnets, nil
+	}
+
+	var results []network.SubnetInfo
+	missing := set.NewStrings()
+	for _, id := range subnetIds {
+		missing.Add(string(id))
+	}
+	for _, subnet := range subnets {
+		if missing.Contains(string(subnet.ProviderId)) {
+			results = append(results, subnet)
+			missing.Remove(string(subnet.ProviderId))
+		}
+	}
+	if !missing.IsEmpty() {
+		return nil, errors.NotFoundf("subnets %v", missing.SortedValues())
+	}
+	return results, nil
+}
+
+// makeSubnets returns the subnets of the given networks.
+func makeSubnets(networks []*libvirtclient.Network) []network.SubnetInfo {
+	var results []network.SubnetInfo
+	for _, n := range networks {
+		for _, cidr := range n.CIDRs {
+			results = append(results, network.SubnetInfo{
+				CIDR:              cidr,
+				ProviderId:        subnetProviderId(n.Name, cidr),
+				ProviderNetworkId: network.Id(n.Name),
+				SpaceProviderId:   network.Id(n.Name),
+			})
+		}
+	}
+	return results
+}
+
+// domain returns the model's domain with the given instance id.
+func (env *sessionEnviron) domain(instId instance.Id) (*libvirtclient.Domain, error) {
+	instances, err := env.Instances([]instance.Id{instId})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return instances[0].(*environInstance).base, nil
+}
+
+// SuperSubnets is part of the environs.Networking interface.
+func (*environ) SuperSubnets() ([]string, error) {
+	return nil, errors.NotSupportedf("super subnets")
+}
+
+// NetworkInterfaces is part of the environs.Networking interface.
+func (env *environ) NetworkInterfaces(instId instance.Id) (interfaces []network.InterfaceInfo, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		interfaces, err = env.NetworkInterfaces(instId)
+		return err
+	})
+	return interfaces, err
+}
+
+// NetworkInterfaces is part of the environs.Networking interface.
+func (env *sessionEnviron) NetworkInterfaces(instId instance.Id) ([]network.InterfaceInfo, error) {
+	domain, err := env.domain(instId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	networks, err := env.client.Networks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	networksByName := make(map[string]*libvirtclient.Network)
+	for _, n := range networks {
+		networksByName[n.Name] = n
+	}
+
+	// Addresses are reported by MAC address, which we
+	// match up with the domain's interfaces.
+	ifaceAddrs, err := env.client.DomainAddresses(domain.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addresses := make(map[string]net.IP)
+	for _, addr := range ifaceAddrs {
+		if _, ok := addresses[addr.MAC]; ok {
+			continue
+		}
+		if ip := addressIP(addr.Address); ip != nil {
+			addresses[addr.MAC] = ip
+		}
+	}
+
+	results := make([]network.InterfaceInfo, len(domain.Interfaces))
+	for i, iface := range domain.Interfaces {
+		info := network.InterfaceInfo{
+			DeviceIndex:       i,
+			MACAddress:        iface.MAC,
+			ProviderNetworkId: network.Id(iface.Network),
+			ProviderSpaceId:   network.Id(iface.Network),
+			InterfaceType:     network.EthernetInterface,
+			ConfigType:        network.ConfigDHCP,
+		}
+		if ip := addresses[iface.MAC]; ip != nil {
+			info.Address = network.NewAddress(ip.String())
+			if n := networksByName[iface.Network]; n != nil {
+				setInterfaceSubnet(&info, n, ip)
+			}
+		}
+		results[i] = info
+	}
+	return results, nil
+}
+
+// setInterfaceSubnet sets the interface's CIDR and subnet id to those
+// of the network's subnet containing the given address, if any.
+func setInterfaceSubnet(info *network.InterfaceInfo, n *libvirtclient.Network, ip net.IP) {
+	for _, cidr := range n.CIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil || !ipNet.Contains(ip) {
+			continue
+		}
+		info.CIDR = cidr
+		info.ProviderSubnetId = subnetProviderId(n.Name, cidr)
+		return
+	}
+}
+
+// SupportsSpaces is part of the environs.Networking interface.
+func (*environ) SupportsSpaces() (bool, error) {
+	return true, nil
+}
+
+// SupportsSpaceDiscovery is part of the environs.Networking interface.
+func (*environ) SupportsSpaceDiscovery() (bool, error) {
+	return true, nil
+}
+
+// Spaces is part of the environs.Networking interface.
+func (env *environ) Spaces() (spaces []network.SpaceInfo, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		spaces, err = env.Spaces()
+		return err
+	})
+	return spaces, err
+}
+
+// Spaces is part of the environs.Networking interface.
+func (env *sessionEnviron) Spaces() ([]network.SpaceInfo, error) {
+	networks, err := env.client.Networks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]network.SpaceInfo, len(networks))
+	for i, n := range networks {
+		results[i] = network.SpaceInfo{
+			Name:       n.Name,
+			ProviderId: network.Id(n.Name),
+			Subnets:    makeSubnets([]*libvirtclient.Network{n}),
+		}
+	}
+	return results, nil
+}
+
+// ProviderSpaceInfo is part of the environs.Networking interface.
+func (*environ) ProviderSpaceInfo(space *network.SpaceInfo) (*environs.ProviderSpaceInfo, error) {
+	return nil, errors.NotSupportedf("provider space info")
+}
+
+// AreSpacesRoutable is part of the environs.Networking interface.
+func (*environ) AreSpacesRoutable(space1, space2 *environs.ProviderSpaceInfo) (bool, error) {
+	return false, nil
+}
+
+// SupportsContainerAddresses is part of the environs.Networking interface.
+func (*environ) SupportsContainerAddresses() (bool, error) {
+	return false, errors.NotSupportedf("container address allocation")
+}
+
+// AllocateContainerAddresses is part of the environs.Networking interface.
+func (*environ) AllocateContainerAddresses(
+	hostInstanceID instance.Id,
+	containerTag names.MachineTag,
+	preparedInfo []network.InterfaceInfo,
+) ([]network.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("container address allocation")
+}
+
+// ReleaseContainerAddresses is part of the environs.Networking interface.
+func (*environ) ReleaseContainerAddresses(interfaces []network.ProviderInterfaceInfo) error {
+	return errors.NotSupportedf("container address allocation")
+}
+
+// SSHAddresses is part of the environs.Networking interface.
+func (*environ) SSHAddresses(addresses []network.Address) ([]network.Address, error) {
+	return addresses, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+type environNetworkSuite struct {
+	EnvironFixture
+	netEnv environs.NetworkingEnviron
+}
+
+var _ = gc.Suite(&environNetworkSuite{})
+
+func (s *environNetworkSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	c.Assert(s.env, gc.Implements, new(environs.NetworkingEnviron))
+	s.netEnv = s.env.(environs.NetworkingEnviron)
+
+	s.client.networks = []*libvirtclient.Network{
+		{Name: "default", Active: true, CIDRs: []string{"192.168.122.0/24"}},
+		{Name: "isolated", Active: true},
+		{
+			Name:    "br0",
+			Active:  true,
+			Forward: libvirtclient.NetworkForwardBridge,
+			Bridge:  "br0",
+			CIDRs:   []string{"10.0.0.0/24", "10.0.1.0/24"},
+		},
+	}
+}
+
+func (s *environNetworkSuite) TestSubnets(c *gc.C) {
+	subnets, err := s.netEnv.Subnets(instance.UnknownId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		CIDR:              "192.168.122.0/24",
+		ProviderId:        "default:192.168.122.0/24",
+		ProviderNetworkId: "default",
+		SpaceProviderId:   "default",
+	}, {
+		CIDR:              "10.0.0.0/24",
+		ProviderId:        "br0:10.0.0.0/24",
+		ProviderNetworkId: "br0",
+		SpaceProviderId:   "br0",
+	}, {
+		CIDR:              "10.0.1.0/24",
+		ProviderId:        "br0:10.0.1.0/24",
+		ProviderNetworkId: "br0",
+		SpaceProviderId:   "br0",
+	}})
+}
+
+func (s *environNetworkSuite) TestSubnetsInstance(c *gc.C) {
+	domain := newDomain("juju-f75cba-0", libvirtclient.DomainStateRunning)
+	domain.Interfaces = []libvirtclient.DomainInterface{
+		{MAC: "52:54:00:00:00:01", Network: "default"},
+	}
+	s.client.domains = []*libvirtclient.Domain{domain}
+
+	subnets, err := s.netEnv.Subnets("juju-f75cba-0", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Assert(subnets[0].ProviderId, gc.Equals, network.Id("default:192.168.122.0/24"))
+}
+
+func (s *environNetworkSuite) TestSubnetsNotFound(c *gc.C) {
+	_, err := s.netEnv.Subnets(instance.UnknownId, []network.Id{
+		"br0:10.0.0.0/24", "br0:10.0.2.0/24",
+	})
+	c.Assert(err, gc.ErrorMatches, `subnets \[br0:10.0.2.0/24\] not found`)
+}
+
+func (s *environNetworkSuite) TestSpaces(c *gc.C) {
+	spaces, err := s.netEnv.Spaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spaces, gc.HasLen, 3)
+	c.Assert(spaces[0].Name, gc.Equals, "default")
+	c.Assert(spaces[0].ProviderId, gc.Equals, network.Id("default"))
+	c.Assert(spaces[0].Subnets, gc.HasLen, 1)
+	c.Assert(spaces[1].Name, gc.Equals, "isolated")
+	c.Assert(spaces[1].ProviderId, gc.Equals, network.Id("isolated"))
+	c.Assert(spaces[1].Subnets, gc.HasLen, 0)
+	c.Assert(spaces[2].Name, gc.Equals, "br0")
+	c.Assert(spaces[2].ProviderId, gc.Equals, network.Id("br0"))
+	c.Assert(spaces[2].Subnets, gc.HasLen, 2)
+}
+
+func (s *environNetworkSuite) TestNetworkInterfaces(c *gc.C) {
+	domain := newDomain("juju-f75cba-0", libvirtclient.DomainStateRunning)
+	domain.Interfaces = []libvirtclient.DomainInterface{
+		{MAC: "52:54:00:00:00:01", Network: "default"},
+		{MAC: "52:54:00:00:00:02", Network: "br0"},
+	}
+	s.client.domains = []*libvirtclient.Domain{domain}
+	s.client.domainAddresses = []libvirtclient.InterfaceAddress{
+		{MAC: "52:54:00:00:00:02", Address: "10.0.1.5/24"},
+	}
+
+	interfaces, err := s.netEnv.NetworkInterfaces("juju-f75cba-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interfaces, jc.DeepEquals, []network.InterfaceInfo{{
+		DeviceIndex:       0,
+		MACAddress:        "52:54:00:00:00:01",
+		InterfaceType:     network.EthernetInterface,
+		ConfigType:        network.ConfigDHCP,
+		ProviderNetworkId: "default",
+		ProviderSpaceId:   "default",
+	}, {
+		DeviceIndex:       1,
+		MACAddress:        "52:54:00:00:00:02",
+		InterfaceType:     network.EthernetInterface,
+		ConfigType:        network.ConfigDHCP,
+		ProviderNetworkId: "br0",
+		ProviderSpaceId:   "br0",
+		ProviderSubnetId:  "br0:10.0.1.0/24",
+		CIDR:              "10.0.1.0/24",
+		Address:           network.NewAddress("10.0.1.5"),
+	}})
+	s.client.CheckCallNames(c, "Domains", "Networks", "DomainAddresses", "Close")
+}
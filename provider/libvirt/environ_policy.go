@@ -0,0 +1,39 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+)
+
+// PrecheckInstance is part of the environs.Environ interface.
+func (env *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error {
+	return parsePlacement(args.Placement)
+}
+
+// unsupportedConstraints lists the constraints that the provider does
+// not support. Guests are sized by mem, cores and root-disk alone, and
+// are always created from Ubuntu cloud images.
+var unsupportedConstraints = []string{
+	constraints.Tags,
+	constraints.VirtType,
+	constraints.AllocatePublicIP,
+	constraints.ImageID,
+	constraints.InstanceLifecycle,
+	constraints.MaxPrice,
+	constraints.InstanceType,
+	constraints.CpuPower,
+}
+
+// ConstraintsValidator returns a Validator value which is used to
+// validate and merge constraints.
+func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterVocabulary(constraints.Arch, []string{arch.AMD64})
+	return validator, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/libvirt"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+type environSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environSuite{})
+
+func (s *environSuite) TestCreate(c *gc.C) {
+	err := s.env.Create(environs.CreateParams{ControllerUUID: "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "Networks", "Close")
+}
+
+func (s *environSuite) TestCreateMissingNetwork(c *gc.C) {
+	// The configured network must already be defined on the host.
+	s.client.networks = nil
+	err := s.env.Create(environs.CreateParams{ControllerUUID: "foo"})
+	c.Assert(err, gc.ErrorMatches, `network "default" not found`)
+	s.client.CheckCallNames(c, "Networks", "Close")
+}
+
+func (s *environSuite) TestCreateDialError(c *gc.C) {
+	s.dialStub.SetErrors(libvirtclient.NewUnauthorizedError(errors.New("ssh: unable to authenticate")))
+	err := s.env.Create(environs.CreateParams{ControllerUUID: "foo"})
+	c.Assert(err, gc.ErrorMatches, "dialing client: ssh: unable to authenticate")
+	s.client.CheckNoCalls(c)
+}
+
+func (s *environSuite) TestAdoptResources(c *gc.C) {
+	adopted := newDomain("juju-f75cba-0", libvirtclient.DomainStateRunning)
+	adopted.Tags[tags.JujuController] = "old"
+	current := newDomain("juju-f75cba-1", libvirtclient.DomainStateRunning)
+	current.Tags[tags.JujuController] = "new"
+	s.client.domains = []*libvirtclient.Domain{adopted, current}
+
+	err := s.env.AdoptResources("new", version.MustParse("2.2.0"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c, "Domains", "SetDomainTags", "Close")
+	s.client.CheckCall(c, 1, "SetDomainTags", "juju-f75cba-0", map[string]string{
+		tags.JujuModel:      fakeModelUUID,
+		tags.JujuController: "new",
+	})
+	// The domain's existing tags must not be modified in place.
+	c.Assert(adopted.Tags[tags.JujuController], gc.Equals, "old")
+}
+
+func (s *environSuite) TestDestroyController(c *gc.C) {
+	var destroyed []environs.Environ
+	s.PatchValue(libvirt.DestroyEnv, func(env environs.Environ) error {
+		destroyed = append(destroyed, env)
+		return nil
+	})
+
+	hosted := newDomain("juju-abcdef-0", libvirtclient.DomainStateRunning)
+	hosted.Tags[tags.JujuModel] = "deadbeef-0bad-400d-8000-4b1d0dabcdef"
+	hosted.Tags[tags.JujuController] = "foo"
+	other := newDomain("juju-123456-0", libvirtclient.DomainStateRunning)
+	other.Tags[tags.JujuModel] = "deadbeef-0bad-400d-8000-4b1d0d123456"
+	other.Tags[tags.JujuController] = "bar"
+	s.client.domains = []*libvirtclient.Domain{hosted, other}
+	s.client.volumes = []*libvirtclient.Volume{{Name: "juju-abcdef-volume-0"}}
+
+	err := s.env.DestroyController("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(destroyed, gc.HasLen, 1)
+
+	s.client.CheckCallNames(c, "Domains", "DestroyDomain", "Volumes", "DeleteVolume", "Close")
+	s.client.CheckCall(c, 0, "Domains", "juju-")
+	s.client.CheckCall(c, 1, "DestroyDomain", "juju-abcdef-0")
+	s.client.CheckCall(c, 2, "Volumes", "default", "juju-abcdef-volume-")
+	s.client.CheckCall(c, 3, "DeleteVolume", "default", "juju-abcdef-volume-0")
+}
@@ -0,0 +1,9 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+var (
+	DestroyEnv         = &destroyEnv
+	FetchImageMetadata = &fetchImageMetadata
+)
@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/libvirt"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+// ProviderFixture provides a libvirt provider whose SSH connections to
+// hypervisor hosts are made to a mock client, recording the address
+// and SSH config with which each is dialed.
+type ProviderFixture struct {
+	testing.IsolationSuite
+	dialStub testing.Stub
+	client   *mockClient
+	provider environs.EnvironProvider
+}
+
+func (s *ProviderFixture) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dialStub.ResetCalls()
+	s.client = &mockClient{}
+	s.provider = libvirt.NewEnvironProvider(libvirt.EnvironProviderConfig{
+		Dial: newMockDialFunc(&s.dialStub, s.client),
+	})
+}
+
+// EnvironFixture provides an environ for the host "host1", which has
+// only the NAT network that libvirt defines when it is installed.
+type EnvironFixture struct {
+	ProviderFixture
+	env environs.Environ
+}
+
+func (s *EnvironFixture) SetUpTest(c *gc.C) {
+	s.ProviderFixture.SetUpTest(c)
+	s.client.networks = []*libvirtclient.Network{
+		{Name: "default", Active: true, CIDRs: []string{"192.168.122.0/24"}},
+	}
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.env = env
+}
@@ -0,0 +1,52 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/environs/imagedownloads"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+// diskImageFType is the simplestreams file type of the
+// cloud images from which root disks are created.
+const diskImageFType = "disk1.img"
+
+// fetchImageMetadata is the function used to look up cloud images.
+// It is a variable so that it can be replaced in tests.
+var fetchImageMetadata = imagedownloads.One
+
+// ensureImage ensures that the cloud image for the given series is in
+// the named storage pool, downloading it to the host if necessary, and
+// returns the name of the image volume. Images are found with the same
+// simplestreams data as is used for KVM containers.
+func (env *sessionEnviron) ensureImage(pool, series string) (string, error) {
+	source := func() simplestreams.DataSource {
+		return imagedownloads.NewDataSource(
+			imagemetadata.UbuntuCloudImagesURL + "/" + env.Config().ImageStream(),
+		)
+	}
+	md, err := fetchImageMetadata(arch.AMD64, series, diskImageFType, source)
+	if err != nil {
+		return "", errors.Annotatef(err, "finding image for series %q", series)
+	}
+	u, err := md.DownloadURL()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	name, err := env.client.EnsureImage(pool, libvirtclient.ImageSource{
+		URL:     u.String(),
+		SHA256:  md.SHA256,
+		Release: md.Release,
+		Arch:    md.Arch,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return name, nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+const (
+	providerType = "libvirt"
+)
+
+func init() {
+	dial := func(address string, config libvirtclient.SSHConfig) (Client, error) {
+		return libvirtclient.Dial(address, config, logger)
+	}
+	environs.RegisterProvider(providerType, NewEnvironProvider(EnvironProviderConfig{
+		Dial: dial,
+	}))
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+	"github.com/juju/juju/status"
+)
+
+type environInstance struct {
+	base *libvirtclient.Domain
+	env  *environ
+}
+
+var _ instance.Instance = (*environInstance)(nil)
+
+func newInstance(base *libvirtclient.Domain, env *environ) *environInstance {
+	return &environInstance{
+		base: base,
+		env:  env,
+	}
+}
+
+// Id implements instance.Instance.
+func (inst *environInstance) Id() instance.Id {
+	return instance.Id(inst.base.Name)
+}
+
+// Status implements instance.Instance.
+func (inst *environInstance) Status() instance.InstanceStatus {
+	instanceStatus := instance.InstanceStatus{
+		Status:  status.Empty,
+		Message: inst.base.State,
+	}
+	if inst.base.State == libvirtclient.DomainStateRunning {
+		instanceStatus.Status = status.Running
+	}
+	return instanceStatus
+}
+
+// Addresses implements instance.Instance.
+//
+// The addresses are those leased to the domain by libvirt's DHCP
+// server or, for bridged networks, found in the host's ARP table,
+// so there may be none until the guest has brought up its network.
+func (inst *environInstance) Addresses() (addresses []network.Address, err error) {
+	err = inst.env.withSession(func(env *sessionEnviron) error {
+		ifaceAddrs, err := env.client.DomainAddresses(inst.base.Name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, addr := range ifaceAddrs {
+			if ip := addressIP(addr.Address); ip != nil {
+				addresses = append(addresses, network.NewAddress(ip.String()))
+			}
+		}
+		return nil
+	})
+	return addresses, err
+}
+
+// addressIP returns the IP of an address given in CIDR notation, or
+// as a plain IP address. If the address cannot be parsed, nil is
+// returned.
+func addressIP(address string) net.IP {
+	if ip, _, err := net.ParseCIDR(address); err == nil {
+		return ip
+	}
+	return net.ParseIP(address)
+}
@@ -0,0 +1,256 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirtclient
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// connectURI is the libvirt URI that virsh connects to on the
+	// hypervisor host. Juju manages system-wide guests only.
+	connectURI = "qemu:///system"
+
+	// dialTimeout is the maximum amount of time to wait for the
+	// SSH connection to the host to be established.
+	dialTimeout = 30 * time.Second
+)
+
+// Runner runs commands on a hypervisor host.
+type Runner interface {
+	// Run runs the given shell command on the host, passing it
+	// stdin, and returns the command's standard output.
+	Run(command string, stdin []byte) (string, error)
+
+	// Close releases the resources held by the runner.
+	Close() error
+}
+
+// SSHConfig holds the parameters for connecting to a hypervisor
+// host with SSH.
+type SSHConfig struct {
+	// User is the name of the user to log in as.
+	User string
+
+	// PrivateKey is the PEM-encoded private key to
+	// authenticate with.
+	PrivateKey []byte
+
+	// HostKeys holds the public keys of the host, in
+	// authorized_keys format. If HostKeys is empty, the
+	// host's key is not verified.
+	HostKeys string
+}
+
+// Client encapsulates the libvirt daemon of a hypervisor host, which
+// is managed by running virsh and related commands on the host over
+// SSH. Client exposes the subset of functionality that we require in
+// the Juju provider.
+type Client struct {
+	runner Runner
+	logger loggo.Logger
+}
+
+// Dial returns a new libvirt client for the hypervisor host at the
+// given address ("host:port"), connecting with SSH. The resulting
+// Client's Close method must be called in order to release resources
+// allocated by Dial.
+func Dial(address string, config SSHConfig, logger loggo.Logger) (*Client, error) {
+	clientConfig, err := sshClientConfig(config, logger)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sshClient, err := ssh.Dial("tcp", address, clientConfig)
+	if err != nil {
+		if strings.Contains(err.Error(), "unable to authenticate") {
+			return nil, NewUnauthorizedError(err)
+		}
+		return nil, errors.Annotatef(err, "connecting to %s", address)
+	}
+	return newClient(&sshRunner{sshClient}, logger), nil
+}
+
+func newClient(runner Runner, logger loggo.Logger) *Client {
+	return &Client{runner: runner, logger: logger}
+}
+
+func sshClientConfig(config SSHConfig, logger loggo.Logger) (*ssh.ClientConfig, error) {
+	clientConfig := &ssh.ClientConfig{
+		User:    config.User,
+		Timeout: dialTimeout,
+	}
+	if len(config.PrivateKey) > 0 {
+		signer, err := ssh.ParsePrivateKey(config.PrivateKey)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing private key")
+		}
+		clientConfig.Auth = []ssh.AuthMethod{ssh.PublicKeys(signer)}
+	}
+	if config.HostKeys == "" {
+		clientConfig.HostKeyCallback = func(hostname string, _ net.Addr, _ ssh.PublicKey) error {
+			logger.Warningf("not verifying the host key of %s, as no host keys are configured", hostname)
+			return nil
+		}
+		return clientConfig, nil
+	}
+	var hostKeys [][]byte
+	rest := []byte(config.HostKeys)
+	for len(bytes.TrimSpace(rest)) > 0 {
+		key, _, _, remaining, err := ssh.ParseAuthorizedKey(rest)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing host keys")
+		}
+		hostKeys = append(hostKeys, key.Marshal())
+		rest = remaining
+	}
+	clientConfig.HostKeyCallback = func(hostname string, _ net.Addr, key ssh.PublicKey) error {
+		marshalled := key.Marshal()
+		for _, hostKey := range hostKeys {
+			if bytes.Equal(hostKey, marshalled) {
+				return nil
+			}
+		}
+		return errors.Errorf("host key of %s does not match any of the configured host keys", hostname)
+	}
+	return clientConfig, nil
+}
+
+// Close releases the resources held by the client.
+func (c *Client) Close() error {
+	return c.runner.Close()
+}
+
+// unauthorizedError is returned by Dial when the host
+// rejects the client's credentials.
+type unauthorizedError struct {
+	error
+}
+
+// NewUnauthorizedError returns an error that satisfies
+// IsUnauthorized, wrapping the given error.
+func NewUnauthorizedError(err error) error {
+	return &unauthorizedError{err}
+}
+
+// IsUnauthorized reports whether the error was caused by the host
+// rejecting the client's credentials.
+func IsUnauthorized(err error) bool {
+	_, ok := errors.Cause(err).(*unauthorizedError)
+	return ok
+}
+
+// CommandError is returned when a command run on the
+// hypervisor host exits with a non-zero status.
+type CommandError struct {
+	Command    string
+	ExitStatus int
+	Stderr     string
+}
+
+// Error is part of the error interface.
+func (e *CommandError) Error() string {
+	msg := fmt.Sprintf("%s: exit status %d", e.Command, e.ExitStatus)
+	if e.Stderr != "" {
+		msg += ": " + e.Stderr
+	}
+	return msg
+}
+
+// isNotFound reports whether the error was caused by virsh being
+// unable to find the domain, network or volume specified.
+func isNotFound(err error) bool {
+	e, ok := errors.Cause(err).(*CommandError)
+	if !ok {
+		return false
+	}
+	for _, s := range []string{
+		"failed to get domain",
+		"failed to get network",
+		"failed to get vol",
+		"Domain not found",
+		"Storage volume not found",
+	} {
+		if strings.Contains(e.Stderr, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// virsh runs virsh on the host with the given arguments,
+// connected to the system libvirt daemon.
+func (c *Client) virsh(args ...string) (string, error) {
+	return c.run(nil, append([]string{"virsh", "--connect", connectURI}, args...)...)
+}
+
+// run runs the command with the given arguments on the host,
+// passing it stdin, and returns its standard output.
+func (c *Client) run(stdin []byte, args ...string) (string, error) {
+	command := shellCommand(args...)
+	c.logger.Tracef("running %q", command)
+	return c.runner.Run(command, stdin)
+}
+
+// shellCommand returns a shell command line that
+// runs the given command with arguments.
+func shellCommand(args ...string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote quotes s so that it is interpreted by
+// the shell as a single literal word.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=,@+") == "" {
+		return s
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// sshRunner is a Runner that runs commands in sessions
+// of an SSH connection.
+type sshRunner struct {
+	client *ssh.Client
+}
+
+// Run is part of the Runner interface.
+func (r *sshRunner) Run(command string, stdin []byte) (string, error) {
+	session, err := r.client.NewSession()
+	if err != nil {
+		return "", errors.Annotate(err, "creating SSH session")
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdin = bytes.NewReader(stdin)
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	if err := session.Run(command); err != nil {
+		if exitErr, ok := err.(*ssh.ExitError); ok {
+			return "", &CommandError{
+				Command:    command,
+				ExitStatus: exitErr.ExitStatus(),
+				Stderr:     strings.TrimSpace(stderr.String()),
+			}
+		}
+		return "", errors.Annotatef(err, "running %q", command)
+	}
+	return stdout.String(), nil
+}
+
+// Close is part of the Runner interface.
+func (r *sshRunner) Close() error {
+	return r.client.Close()
+}
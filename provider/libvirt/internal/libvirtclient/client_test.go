@@ -0,0 +1,406 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirtclient
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+const virsh = "virsh --connect qemu:///system "
+
+const domainDefinition = `
+<domain type='kvm' id='1'>
+  <name>juju-abcdef-0</name>
+  <uuid>d1a3e6c2-5d4b-4b4e-9b8e-1a2b3c4d5e6f</uuid>
+  <memory unit='KiB'>2097152</memory>
+  <vcpu placement='static'>2</vcpu>
+  <metadata>
+    <juju:instance xmlns:juju="http://jujucharms.com/libvirt/1.0">
+      <juju:tag key="juju-model-uuid" value="model-uuid"/>
+      <juju:tag key="juju-controller-uuid" value="controller-uuid"/>
+    </juju:instance>
+  </metadata>
+  <devices>
+    <disk type='volume' device='disk'>
+      <source pool='default' volume='juju-abcdef-0-root'/>
+      <target dev='vda' bus='virtio'/>
+    </disk>
+    <disk type='file' device='disk'>
+      <source file='/var/lib/libvirt/images/juju-abcdef-volume-0'/>
+      <target dev='vdb' bus='virtio'/>
+      <serial>0123456789abcdef0123</serial>
+    </disk>
+    <interface type='network'>
+      <mac address='52:54:00:9d:2e:51'/>
+      <source network='default'/>
+      <model type='virtio'/>
+    </interface>
+  </devices>
+</domain>`
+
+type clientSuite struct {
+	testing.IsolationSuite
+	runner *fakeRunner
+	client *Client
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.runner = &fakeRunner{
+		outputs: make(map[string]string),
+		errors:  make(map[string]error),
+	}
+	s.client = newClient(s.runner, loggo.GetLogger("libvirtclient_test"))
+}
+
+func (s *clientSuite) TestDomains(c *gc.C) {
+	s.runner.outputs[virsh+"list --all --name"] = "juju-abcdef-0\nother\n\n"
+	s.runner.outputs[virsh+"domstate juju-abcdef-0"] = "running\n\n"
+	s.runner.outputs[virsh+"dumpxml juju-abcdef-0"] = domainDefinition
+
+	domains, err := s.client.Domains("juju-abcdef-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(domains, jc.DeepEquals, []*Domain{{
+		Name:      "juju-abcdef-0",
+		UUID:      "d1a3e6c2-5d4b-4b4e-9b8e-1a2b3c4d5e6f",
+		State:     DomainStateRunning,
+		MemoryMiB: 2048,
+		VCPUs:     2,
+		Tags: map[string]string{
+			"juju-model-uuid":      "model-uuid",
+			"juju-controller-uuid": "controller-uuid",
+		},
+		Interfaces: []DomainInterface{{
+			MAC:     "52:54:00:9d:2e:51",
+			Network: "default",
+		}},
+		Disks: []DomainDisk{{
+			Target: "vda",
+			Pool:   "default",
+			Volume: "juju-abcdef-0-root",
+		}, {
+			Target: "vdb",
+			Serial: "0123456789abcdef0123",
+		}},
+	}})
+	s.runner.checkCommands(c,
+		virsh+"list --all --name",
+		virsh+"domstate juju-abcdef-0",
+		virsh+"dumpxml juju-abcdef-0",
+	)
+}
+
+func (s *clientSuite) TestDomainNotFound(c *gc.C) {
+	s.runner.errors[virsh+"domstate juju-abcdef-0"] = &CommandError{
+		ExitStatus: 1,
+		Stderr:     "error: failed to get domain 'juju-abcdef-0'",
+	}
+	_, err := s.client.Domain("juju-abcdef-0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *clientSuite) TestCreateDomain(c *gc.C) {
+	s.runner.outputs[virsh+"domstate juju-abcdef-0"] = "running"
+	s.runner.outputs[virsh+"dumpxml juju-abcdef-0"] = domainDefinition
+
+	domain, err := s.client.CreateDomain(CreateDomainParams{
+		Name:        "juju-abcdef-0",
+		MemoryMiB:   2048,
+		VCPUs:       2,
+		Pool:        "default",
+		Image:       "juju-xenial-amd64-0123456789ab.img",
+		RootDiskMiB: 8192,
+		UserData:    []byte("#cloud-config\n"),
+		Networks:    []string{"default"},
+		Tags:        map[string]string{"juju-model-uuid": "model-uuid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(domain.Name, gc.Equals, "juju-abcdef-0")
+
+	commands := s.runner.commands
+	c.Assert(commands, gc.HasLen, 7)
+	c.Assert(commands[0], gc.Equals, virsh+"vol-create-as default juju-abcdef-0-root 8192M "+
+		"--format qcow2 --backing-vol juju-xenial-amd64-0123456789ab.img --backing-vol-format qcow2")
+
+	// The seed image is built on the host, from the user-data
+	// passed on stdin.
+	c.Assert(commands[1], jc.HasPrefix, "sh -c ")
+	c.Assert(commands[1], jc.Contains, "genisoimage")
+	c.Assert(commands[1], jc.Contains, "vol-upload --pool default juju-abcdef-0-seed")
+	c.Assert(s.runner.stdin[1], gc.Equals, "#cloud-config\n")
+
+	c.Assert(commands[2], gc.Equals, virsh+"define /dev/stdin")
+	def := s.runner.stdin[2]
+	c.Assert(def, jc.Contains, `<name>juju-abcdef-0</name>`)
+	c.Assert(def, jc.Contains, `<memory unit="MiB">2048</memory>`)
+	c.Assert(def, jc.Contains, `<vcpu>2</vcpu>`)
+	c.Assert(def, jc.Contains, `<instance xmlns="http://jujucharms.com/libvirt/1.0">`)
+	c.Assert(def, jc.Contains, `<tag key="juju-model-uuid" value="model-uuid"></tag>`)
+	c.Assert(def, jc.Contains, `<source pool="default" volume="juju-abcdef-0-root"></source>`)
+	c.Assert(def, jc.Contains, `<source pool="default" volume="juju-abcdef-0-seed"></source>`)
+	c.Assert(def, jc.Contains, `<source network="default"></source>`)
+
+	c.Assert(commands[3:], jc.DeepEquals, []string{
+		virsh + "start juju-abcdef-0",
+		virsh + "autostart juju-abcdef-0",
+		virsh + "domstate juju-abcdef-0",
+		virsh + "dumpxml juju-abcdef-0",
+	})
+}
+
+func (s *clientSuite) TestCreateDomainCleansUp(c *gc.C) {
+	s.runner.errors[virsh+"start juju-abcdef-0"] = &CommandError{ExitStatus: 1, Stderr: "error: no space left"}
+
+	_, err := s.client.CreateDomain(CreateDomainParams{
+		Name:        "juju-abcdef-0",
+		Pool:        "default",
+		Image:       "image",
+		RootDiskMiB: 8192,
+	})
+	c.Assert(err, gc.ErrorMatches, `starting domain "juju-abcdef-0": .*: error: no space left`)
+
+	commands := s.runner.commands
+	c.Assert(commands[len(commands)-3:], jc.DeepEquals, []string{
+		virsh + "undefine juju-abcdef-0",
+		virsh + "vol-delete --pool default juju-abcdef-0-seed",
+		virsh + "vol-delete --pool default juju-abcdef-0-root",
+	})
+}
+
+func (s *clientSuite) TestDestroyDomain(c *gc.C) {
+	s.runner.outputs[virsh+"domstate juju-abcdef-0"] = "running"
+	s.runner.outputs[virsh+"dumpxml juju-abcdef-0"] = domainDefinition
+
+	err := s.client.DestroyDomain("juju-abcdef-0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.runner.checkCommands(c,
+		virsh+"domstate juju-abcdef-0",
+		virsh+"dumpxml juju-abcdef-0",
+		virsh+"destroy juju-abcdef-0",
+		virsh+"undefine juju-abcdef-0 --storage vda,hdc",
+	)
+}
+
+func (s *clientSuite) TestDestroyDomainNotFound(c *gc.C) {
+	s.runner.errors[virsh+"domstate juju-abcdef-0"] = &CommandError{
+		ExitStatus: 1,
+		Stderr:     "error: failed to get domain 'juju-abcdef-0'",
+	}
+	err := s.client.DestroyDomain("juju-abcdef-0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestDomainAddresses(c *gc.C) {
+	s.runner.outputs[virsh+"domifaddr juju-abcdef-0 --source lease"] = `
+ Name       MAC address          Protocol     Address
+-------------------------------------------------------------------------------
+
+`
+	s.runner.outputs[virsh+"domifaddr juju-abcdef-0 --source arp"] = `
+ Name       MAC address          Protocol     Address
+-------------------------------------------------------------------------------
+ vnet0      52:54:00:9d:2e:51    ipv4         10.0.0.5/0
+ -          -                    ipv4         10.0.0.6/0
+`
+	addresses, err := s.client.DomainAddresses("juju-abcdef-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addresses, jc.DeepEquals, []InterfaceAddress{
+		{MAC: "52:54:00:9d:2e:51", Address: "10.0.0.5/0"},
+		{MAC: "52:54:00:9d:2e:51", Address: "10.0.0.6/0"},
+	})
+}
+
+func (s *clientSuite) TestSetDomainTags(c *gc.C) {
+	s.runner.outputs[virsh+"domstate juju-abcdef-0"] = "running"
+	s.runner.outputs[virsh+"dumpxml juju-abcdef-0"] = domainDefinition
+
+	err := s.client.SetDomainTags("juju-abcdef-0", map[string]string{"b": "2", "a": "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runner.commands[2], gc.Equals, virsh+
+		"metadata juju-abcdef-0 --uri http://jujucharms.com/libvirt/1.0 --key juju "+
+		`--set '<instance><tag key="a" value="1"></tag><tag key="b" value="2"></tag></instance>' `+
+		"--config --live",
+	)
+}
+
+func (s *clientSuite) TestNetworks(c *gc.C) {
+	s.runner.outputs[virsh+"net-list --all --name"] = "default\nbr0\n"
+	s.runner.outputs[virsh+"net-list --name"] = "default\n"
+	s.runner.outputs[virsh+"net-dumpxml default"] = `
+<network>
+  <name>default</name>
+  <forward mode='nat'/>
+  <bridge name='virbr0' stp='on' delay='0'/>
+  <ip address='192.168.122.1' netmask='255.255.255.0'/>
+  <ip family='ipv6' address='fd00::1' prefix='64'/>
+</network>`
+	s.runner.outputs[virsh+"net-dumpxml br0"] = `
+<network>
+  <name>br0</name>
+  <forward mode='bridge'/>
+  <bridge name='br0'/>
+</network>`
+	s.runner.outputs["ip -o addr show dev br0"] = strings.Join([]string{
+		`4: br0    inet 10.0.0.5/24 brd 10.0.0.255 scope global br0\       valid_lft forever preferred_lft forever`,
+		`4: br0    inet6 fe80::1/64 scope link \       valid_lft forever preferred_lft forever`,
+	}, "\n")
+
+	networks, err := s.client.Networks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(networks, jc.DeepEquals, []*Network{{
+		Name:    "default",
+		Active:  true,
+		Forward: "nat",
+		Bridge:  "virbr0",
+		CIDRs:   []string{"192.168.122.0/24", "fd00::/64"},
+	}, {
+		Name:    "br0",
+		Forward: NetworkForwardBridge,
+		Bridge:  "br0",
+		CIDRs:   []string{"10.0.0.0/24"},
+	}})
+}
+
+func (s *clientSuite) TestVolumes(c *gc.C) {
+	s.runner.outputs[virsh+"vol-list --pool default"] = `
+ Name                   Path
+------------------------------------------------------------------------------
+ juju-abcdef-volume-0   /var/lib/libvirt/images/juju-abcdef-volume-0
+ other                  /var/lib/libvirt/images/other
+`
+	s.runner.outputs[virsh+"vol-dumpxml --pool default juju-abcdef-volume-0"] = `
+<volume type='file'>
+  <name>juju-abcdef-volume-0</name>
+  <key>/var/lib/libvirt/images/juju-abcdef-volume-0</key>
+  <capacity unit='bytes'>1073741824</capacity>
+  <target>
+    <path>/var/lib/libvirt/images/juju-abcdef-volume-0</path>
+  </target>
+</volume>`
+
+	volumes, err := s.client.Volumes("default", "juju-abcdef-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes, jc.DeepEquals, []*Volume{{
+		Name:        "juju-abcdef-volume-0",
+		Pool:        "default",
+		Path:        "/var/lib/libvirt/images/juju-abcdef-volume-0",
+		CapacityMiB: 1024,
+	}})
+}
+
+func (s *clientSuite) TestAttachVolume(c *gc.C) {
+	s.runner.outputs[virsh+"domstate juju-abcdef-0"] = "running"
+	s.runner.outputs[virsh+"dumpxml juju-abcdef-0"] = domainDefinition
+	s.runner.outputs[virsh+"vol-dumpxml --pool default juju-abcdef-volume-1"] = `
+<volume>
+  <name>juju-abcdef-volume-1</name>
+  <target><path>/var/lib/libvirt/images/juju-abcdef-volume-1</path></target>
+</volume>`
+
+	target, err := s.client.AttachVolume("juju-abcdef-0", "default", "juju-abcdef-volume-1", "fedcba9876543210fedc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target, gc.Equals, "vdc")
+	c.Assert(s.runner.commands[3], gc.Equals, virsh+
+		"attach-disk juju-abcdef-0 /var/lib/libvirt/images/juju-abcdef-volume-1 vdc "+
+		"--targetbus virtio --serial fedcba9876543210fedc --persistent",
+	)
+}
+
+func (s *clientSuite) TestAttachVolumeAlreadyAttached(c *gc.C) {
+	s.runner.outputs[virsh+"domstate juju-abcdef-0"] = "running"
+	s.runner.outputs[virsh+"dumpxml juju-abcdef-0"] = domainDefinition
+
+	target, err := s.client.AttachVolume("juju-abcdef-0", "default", "juju-abcdef-volume-0", "0123456789abcdef0123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target, gc.Equals, "vdb")
+	c.Assert(s.runner.commands, gc.HasLen, 2)
+}
+
+func (s *clientSuite) TestDetachVolume(c *gc.C) {
+	s.runner.outputs[virsh+"domstate juju-abcdef-0"] = "running"
+	s.runner.outputs[virsh+"dumpxml juju-abcdef-0"] = domainDefinition
+
+	err := s.client.DetachVolume("juju-abcdef-0", "0123456789abcdef0123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runner.commands[2], gc.Equals, virsh+"detach-disk juju-abcdef-0 vdb --persistent")
+}
+
+func (s *clientSuite) TestEnsureImageExists(c *gc.C) {
+	s.runner.outputs[virsh+"vol-dumpxml --pool default juju-xenial-amd64-0123456789ab.img"] = `<volume/>`
+
+	name, err := s.client.EnsureImage("default", ImageSource{
+		URL:     "https://cloud-images.ubuntu.com/xenial.img",
+		SHA256:  "0123456789abcdef",
+		Release: "xenial",
+		Arch:    "amd64",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "juju-xenial-amd64-0123456789ab.img")
+	c.Assert(s.runner.commands, gc.HasLen, 1)
+}
+
+func (s *clientSuite) TestEnsureImageDownloads(c *gc.C) {
+	s.runner.errors[virsh+"vol-dumpxml --pool default juju-xenial-amd64-0123456789ab.img"] = &CommandError{
+		ExitStatus: 1,
+		Stderr:     "error: failed to get vol 'juju-xenial-amd64-0123456789ab.img'",
+	}
+
+	_, err := s.client.EnsureImage("default", ImageSource{
+		URL:     "https://cloud-images.ubuntu.com/xenial.img",
+		SHA256:  "0123456789abcdef",
+		Release: "xenial",
+		Arch:    "amd64",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.runner.commands, gc.HasLen, 2)
+	c.Assert(s.runner.commands[1], jc.Contains, "curl -sSfL")
+	c.Assert(s.runner.commands[1], jc.Contains, "sha256sum -c")
+	c.Assert(s.runner.commands[1], jc.Contains, "vol-upload --pool default juju-xenial-amd64-0123456789ab.img")
+}
+
+func (s *clientSuite) TestShellQuote(c *gc.C) {
+	c.Assert(shellQuote("juju-abcdef-0"), gc.Equals, "juju-abcdef-0")
+	c.Assert(shellQuote(""), gc.Equals, "''")
+	c.Assert(shellQuote("a b"), gc.Equals, "'a b'")
+	c.Assert(shellQuote("it's"), gc.Equals, `'it'\''s'`)
+}
+
+func (s *clientSuite) TestSSHClientConfigHostKeys(c *gc.C) {
+	_, err := sshClientConfig(SSHConfig{HostKeys: "not a key"}, loggo.GetLogger("libvirtclient_test"))
+	c.Assert(err, gc.ErrorMatches, "parsing host keys: .*")
+}
+
+type fakeRunner struct {
+	outputs  map[string]string
+	errors   map[string]error
+	commands []string
+	stdin    []string
+	closed   bool
+}
+
+func (r *fakeRunner) Run(command string, stdin []byte) (string, error) {
+	r.commands = append(r.commands, command)
+	r.stdin = append(r.stdin, string(stdin))
+	if err := r.errors[command]; err != nil {
+		return "", err
+	}
+	return r.outputs[command], nil
+}
+
+func (r *fakeRunner) Close() error {
+	r.closed = true
+	return nil
+}
+
+func (r *fakeRunner) checkCommands(c *gc.C, expect ...string) {
+	c.Assert(r.commands, jc.DeepEquals, expect)
+}
@@ -0,0 +1,473 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirtclient
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// DomainStateRunning is the state of a running domain.
+	DomainStateRunning = "running"
+
+	// DomainStateShutOff is the state of a domain that is
+	// defined, but not running.
+	DomainStateShutOff = "shut off"
+
+	// metadataNamespace is the XML namespace of the metadata that
+	// Juju records in the definition of each domain it creates.
+	metadataNamespace = "http://jujucharms.com/libvirt/1.0"
+
+	// metadataKey is the prefix used for the metadata namespace.
+	metadataKey = "juju"
+
+	// rootDiskTarget and seedDiskTarget are the target devices
+	// of the root disk, and of the cloud-init NoCloud seed image,
+	// of each domain created by Juju.
+	rootDiskTarget = "vda"
+	seedDiskTarget = "hdc"
+)
+
+// Domain describes a libvirt domain, i.e. a guest.
+type Domain struct {
+	Name      string
+	UUID      string
+	State     string
+	MemoryMiB uint64
+	VCPUs     uint64
+
+	// Tags holds the metadata recorded by Juju when the
+	// domain was created, or by SetDomainTags.
+	Tags map[string]string
+
+	Interfaces []DomainInterface
+	Disks      []DomainDisk
+}
+
+// DomainInterface describes a network interface of a domain.
+type DomainInterface struct {
+	MAC     string
+	Network string
+}
+
+// DomainDisk describes a disk attached to a domain.
+type DomainDisk struct {
+	Target string
+	Serial string
+	Pool   string
+	Volume string
+}
+
+// InterfaceAddress is an IP address assigned to one of a
+// domain's network interfaces.
+type InterfaceAddress struct {
+	MAC string
+
+	// Address is the address, in CIDR notation.
+	Address string
+}
+
+// CreateDomainParams contains the parameters for CreateDomain.
+type CreateDomainParams struct {
+	Name      string
+	MemoryMiB uint64
+	VCPUs     uint64
+
+	// Pool is the name of the storage pool in which to create
+	// the domain's root disk and cloud-init seed image.
+	Pool string
+
+	// Image is the name of the volume in Pool to use as the
+	// backing image of the root disk.
+	Image string
+
+	// RootDiskMiB is the size of the root disk.
+	RootDiskMiB uint64
+
+	// UserData is the cloud-init user-data for the domain.
+	UserData []byte
+
+	// Networks holds the names of the libvirt networks to
+	// connect the domain to, one interface per network.
+	Networks []string
+
+	// Tags holds metadata to record in the domain's definition.
+	Tags map[string]string
+}
+
+// Domains returns the domains whose names start with the given prefix.
+func (c *Client) Domains(prefix string) ([]*Domain, error) {
+	out, err := c.virsh("list", "--all", "--name")
+	if err != nil {
+		return nil, errors.Annotate(err, "listing domains")
+	}
+	var domains []*Domain
+	for _, name := range strings.Fields(out) {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		domain, err := c.Domain(name)
+		if errors.IsNotFound(err) {
+			// The domain was removed after it was listed.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// Domain returns the domain with the given name.
+func (c *Client) Domain(name string) (*Domain, error) {
+	state, err := c.virsh("domstate", name)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errors.NotFoundf("domain %q", name)
+		}
+		return nil, errors.Annotatef(err, "getting state of domain %q", name)
+	}
+	def, err := c.domainXML(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	domain := &Domain{
+		Name:      def.Name,
+		UUID:      def.UUID,
+		State:     strings.TrimSpace(state),
+		MemoryMiB: def.Memory.mebibytes(),
+		VCPUs:     def.VCPU,
+	}
+	if def.Metadata != nil && def.Metadata.Instance != nil {
+		domain.Tags = make(map[string]string)
+		for _, tag := range def.Metadata.Instance.Tags {
+			domain.Tags[tag.Key] = tag.Value
+		}
+	}
+	for _, iface := range def.Devices.Interfaces {
+		var info DomainInterface
+		if iface.MAC != nil {
+			info.MAC = iface.MAC.Address
+		}
+		info.Network = iface.Source.Network
+		domain.Interfaces = append(domain.Interfaces, info)
+	}
+	for _, disk := range def.Devices.Disks {
+		domain.Disks = append(domain.Disks, DomainDisk{
+			Target: disk.Target.Dev,
+			Serial: disk.Serial,
+			Pool:   disk.Source.Pool,
+			Volume: disk.Source.Volume,
+		})
+	}
+	return domain, nil
+}
+
+func (c *Client) domainXML(name string) (*domainXML, error) {
+	out, err := c.virsh("dumpxml", name)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errors.NotFoundf("domain %q", name)
+		}
+		return nil, errors.Annotatef(err, "getting definition of domain %q", name)
+	}
+	var def domainXML
+	if err := xml.Unmarshal([]byte(out), &def); err != nil {
+		return nil, errors.Annotatef(err, "decoding definition of domain %q", name)
+	}
+	return &def, nil
+}
+
+// CreateDomain creates and starts a domain, booting from a copy-on-write
+// root disk backed by the specified image. The domain is configured by
+// cloud-init, using a NoCloud seed image holding the given user-data.
+func (c *Client) CreateDomain(params CreateDomainParams) (_ *Domain, err error) {
+	rootVolume := params.Name + "-root"
+	seedVolume := params.Name + "-seed"
+
+	var cleanups []func()
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}()
+	deleteVolume := func(name string) func() {
+		return func() {
+			if err := c.DeleteVolume(params.Pool, name); err != nil {
+				c.logger.Warningf("failed to delete volume %q: %v", name, err)
+			}
+		}
+	}
+
+	if _, err := c.virsh(
+		"vol-create-as", params.Pool, rootVolume, fmt.Sprintf("%dM", params.RootDiskMiB),
+		"--format", "qcow2",
+		"--backing-vol", params.Image,
+		"--backing-vol-format", "qcow2",
+	); err != nil {
+		return nil, errors.Annotate(err, "creating root disk")
+	}
+	cleanups = append(cleanups, deleteVolume(rootVolume))
+
+	if err := c.createSeedVolume(params.Pool, seedVolume, params.Name, params.UserData); err != nil {
+		return nil, errors.Annotate(err, "creating cloud-init seed image")
+	}
+	cleanups = append(cleanups, deleteVolume(seedVolume))
+
+	def, err := newDomainXML(params, rootVolume, seedVolume)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := c.run(def, "virsh", "--connect", connectURI, "define", "/dev/stdin"); err != nil {
+		return nil, errors.Annotatef(err, "defining domain %q", params.Name)
+	}
+	cleanups = append(cleanups, func() {
+		if _, err := c.virsh("undefine", params.Name); err != nil {
+			c.logger.Warningf("failed to undefine domain %q: %v", params.Name, err)
+		}
+	})
+
+	if _, err := c.virsh("start", params.Name); err != nil {
+		return nil, errors.Annotatef(err, "starting domain %q", params.Name)
+	}
+	cleanups = append(cleanups, func() {
+		if _, err := c.virsh("destroy", params.Name); err != nil {
+			c.logger.Warningf("failed to destroy domain %q: %v", params.Name, err)
+		}
+	})
+
+	if _, err := c.virsh("autostart", params.Name); err != nil {
+		return nil, errors.Annotatef(err, "enabling autostart of domain %q", params.Name)
+	}
+	domain, err := c.Domain(params.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return domain, nil
+}
+
+// createSeedVolume creates a volume holding a cloud-init NoCloud seed
+// image, with the given user-data. The image is built on the host, so
+// genisoimage must be installed there.
+func (c *Client) createSeedVolume(pool, volume, hostname string, userData []byte) error {
+	virsh := shellCommand("virsh", "--connect", connectURI)
+	script := strings.Join([]string{
+		"set -e",
+		`dir=$(mktemp -d)`,
+		`trap 'rm -rf "$dir"' EXIT`,
+		`cat > "$dir/user-data"`,
+		fmt.Sprintf(
+			`printf 'instance-id: %%s\nlocal-hostname: %%s\n' %s %s > "$dir/meta-data"`,
+			shellQuote(hostname), shellQuote(hostname),
+		),
+		`genisoimage -quiet -output "$dir/seed.iso" -volid cidata -joliet -rock "$dir/user-data" "$dir/meta-data"`,
+		fmt.Sprintf(
+			`%s vol-create-as %s %s "$(stat -c %%s "$dir/seed.iso")" --format raw`,
+			virsh, shellQuote(pool), shellQuote(volume),
+		),
+		fmt.Sprintf(
+			`%s vol-upload --pool %s %s "$dir/seed.iso"`,
+			virsh, shellQuote(pool), shellQuote(volume),
+		),
+	}, "\n")
+	_, err := c.run(userData, "sh", "-c", script)
+	return errors.Trace(err)
+}
+
+// DestroyDomain stops and removes the domain with the given name,
+// along with its root disk and cloud-init seed image. Any other
+// volumes attached to the domain are left intact. It is not an
+// error for the domain not to exist.
+func (c *Client) DestroyDomain(name string) error {
+	domain, err := c.Domain(name)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if domain.State != DomainStateShutOff {
+		if _, err := c.virsh("destroy", name); err != nil && !isNotFound(err) {
+			return errors.Annotatef(err, "stopping domain %q", name)
+		}
+	}
+	if _, err := c.virsh(
+		"undefine", name,
+		"--storage", rootDiskTarget+","+seedDiskTarget,
+	); err != nil && !isNotFound(err) {
+		return errors.Annotatef(err, "removing domain %q", name)
+	}
+	return nil
+}
+
+// DomainAddresses returns the IP addresses assigned to the network
+// interfaces of the domain with the given name. Addresses are taken
+// from the DHCP leases of libvirt-managed networks if there are any,
+// and otherwise from the host's ARP table.
+func (c *Client) DomainAddresses(name string) ([]InterfaceAddress, error) {
+	var addresses []InterfaceAddress
+	for _, source := range []string{"lease", "arp"} {
+		out, err := c.virsh("domifaddr", name, "--source", source)
+		if err != nil {
+			if isNotFound(err) {
+				return nil, errors.NotFoundf("domain %q", name)
+			}
+			return nil, errors.Annotatef(err, "getting addresses of domain %q", name)
+		}
+		addresses = parseDomainAddresses(out)
+		if len(addresses) > 0 {
+			break
+		}
+	}
+	return addresses, nil
+}
+
+// parseDomainAddresses parses the output of "virsh domifaddr", e.g.
+//
+//	 Name       MAC address          Protocol     Address
+//	-------------------------------------------------------------------------------
+//	 vnet0      52:54:00:9d:2e:51    ipv4         192.168.122.10/24
+//	 -          -                    ipv6         fe80::5054:ff:fe9d:2e51/64
+func parseDomainAddresses(out string) []InterfaceAddress {
+	var results []InterfaceAddress
+	var mac string
+	for _, fields := range tableRows(out) {
+		if len(fields) != 4 {
+			continue
+		}
+		if fields[1] != "-" {
+			mac = fields[1]
+		}
+		results = append(results, InterfaceAddress{
+			MAC:     mac,
+			Address: fields[3],
+		})
+	}
+	return results
+}
+
+// tableRows returns the fields of each row of a table output by
+// virsh, in which the rows follow a header and a line of dashes.
+func tableRows(out string) [][]string {
+	var rows [][]string
+	var inBody bool
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if !inBody {
+			inBody = strings.HasPrefix(line, "---")
+			continue
+		}
+		if line != "" {
+			rows = append(rows, strings.Fields(line))
+		}
+	}
+	return rows
+}
+
+// SetDomainTags replaces the metadata recorded in the definition of the
+// domain with the given name.
+func (c *Client) SetDomainTags(name string, tags map[string]string) error {
+	domain, err := c.Domain(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The namespace of the metadata is given by --uri,
+	// so the element is encoded without one.
+	var metadata bytes.Buffer
+	if err := xml.NewEncoder(&metadata).EncodeElement(
+		&instanceMetadataXML{Tags: tagsXML(tags)},
+		xml.StartElement{Name: xml.Name{Local: "instance"}},
+	); err != nil {
+		return errors.Trace(err)
+	}
+	args := []string{
+		"metadata", name,
+		"--uri", metadataNamespace,
+		"--key", metadataKey,
+		"--set", metadata.String(),
+		"--config",
+	}
+	if domain.State == DomainStateRunning {
+		args = append(args, "--live")
+	}
+	if _, err := c.virsh(args...); err != nil {
+		return errors.Annotatef(err, "setting metadata of domain %q", name)
+	}
+	return nil
+}
+
+func newDomainXML(params CreateDomainParams, rootVolume, seedVolume string) ([]byte, error) {
+	def := domainXML{
+		Type:   "kvm",
+		Name:   params.Name,
+		Memory: memoryXML{Unit: "MiB", Value: params.MemoryMiB},
+		VCPU:   params.VCPUs,
+		Metadata: &metadataXML{
+			Instance: &instanceMetadataXML{Tags: tagsXML(params.Tags)},
+		},
+		OS: osXML{
+			Type: osTypeXML{Arch: "x86_64", Machine: "pc", Value: "hvm"},
+			Boot: []bootXML{{Dev: "hd"}},
+		},
+		Features: featuresXML{ACPI: &struct{}{}, APIC: &struct{}{}},
+		Devices: devicesXML{
+			Disks: []diskXML{{
+				Type:   "volume",
+				Device: "disk",
+				Driver: &diskDriverXML{Name: "qemu", Type: "qcow2"},
+				Source: diskSourceXML{Pool: params.Pool, Volume: rootVolume},
+				Target: diskTargetXML{Dev: rootDiskTarget, Bus: "virtio"},
+			}, {
+				Type:     "volume",
+				Device:   "cdrom",
+				Driver:   &diskDriverXML{Name: "qemu", Type: "raw"},
+				Source:   diskSourceXML{Pool: params.Pool, Volume: seedVolume},
+				Target:   diskTargetXML{Dev: seedDiskTarget, Bus: "ide"},
+				ReadOnly: &struct{}{},
+			}},
+			Serials: []serialXML{{
+				Type:   "pty",
+				Target: serialTargetXML{Port: "0"},
+			}},
+			Consoles: []serialXML{{
+				Type:   "pty",
+				Target: serialTargetXML{Type: "serial", Port: "0"},
+			}},
+		},
+	}
+	for _, network := range params.Networks {
+		def.Devices.Interfaces = append(def.Devices.Interfaces, interfaceXML{
+			Type:   "network",
+			Source: interfaceSourceXML{Network: network},
+			Model:  &interfaceModelXML{Type: "virtio"},
+		})
+	}
+	out, err := xml.MarshalIndent(&def, "", "  ")
+	if err != nil {
+		return nil, errors.Annotate(err, "encoding domain definition")
+	}
+	return out, nil
+}
+
+// tagsXML returns the tags in XML form, ordered by key.
+func tagsXML(tags map[string]string) []tagXML {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]tagXML, len(keys))
+	for i, key := range keys {
+		result[i] = tagXML{Key: key, Value: tags[key]}
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirtclient
+
+import (
+	"encoding/xml"
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// NetworkForwardBridge is the forward mode of a network that
+// connects guests directly to a bridge on the host.
+const NetworkForwardBridge = "bridge"
+
+// Network describes a libvirt virtual network.
+type Network struct {
+	Name   string
+	Active bool
+
+	// Forward is the forward mode of the network, e.g. "nat"
+	// or "bridge". Forward is empty for isolated networks.
+	Forward string
+
+	// Bridge is the name of the host bridge device used by
+	// the network.
+	Bridge string
+
+	// CIDRs holds the CIDRs of the subnets on the network.
+	CIDRs []string
+}
+
+// Networks returns the virtual networks defined on the host.
+func (c *Client) Networks() ([]*Network, error) {
+	out, err := c.virsh("net-list", "--all", "--name")
+	if err != nil {
+		return nil, errors.Annotate(err, "listing networks")
+	}
+	activeOut, err := c.virsh("net-list", "--name")
+	if err != nil {
+		return nil, errors.Annotate(err, "listing active networks")
+	}
+	active := set.NewStrings(strings.Fields(activeOut)...)
+
+	var networks []*Network
+	for _, name := range strings.Fields(out) {
+		network, err := c.network(name)
+		if errors.IsNotFound(err) {
+			// The network was removed after it was listed.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		network.Active = active.Contains(name)
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+func (c *Client) network(name string) (*Network, error) {
+	out, err := c.virsh("net-dumpxml", name)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errors.NotFoundf("network %q", name)
+		}
+		return nil, errors.Annotatef(err, "getting definition of network %q", name)
+	}
+	var def networkXML
+	if err := xml.Unmarshal([]byte(out), &def); err != nil {
+		return nil, errors.Annotatef(err, "decoding definition of network %q", name)
+	}
+	network := &Network{Name: def.Name}
+	if def.Forward != nil {
+		network.Forward = def.Forward.Mode
+	}
+	if def.Bridge != nil {
+		network.Bridge = def.Bridge.Name
+	}
+	for _, ip := range def.IPs {
+		if cidr := networkCIDR(ip); cidr != "" {
+			network.CIDRs = append(network.CIDRs, cidr)
+		}
+	}
+	if network.Forward == NetworkForwardBridge && network.Bridge != "" {
+		// libvirt does not manage the addressing of bridged
+		// networks, so take the subnets from the addresses
+		// of the host's bridge device.
+		cidrs, err := c.deviceCIDRs(network.Bridge)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting subnets of network %q", name)
+		}
+		network.CIDRs = append(network.CIDRs, cidrs...)
+	}
+	return network, nil
+}
+
+// networkCIDR returns the CIDR of the subnet
+// described by the network's ip element.
+func networkCIDR(ip networkIPXML) string {
+	addr := net.ParseIP(ip.Address)
+	if addr == nil {
+		return ""
+	}
+	var mask net.IPMask
+	switch {
+	case ip.Netmask != "":
+		netmask := net.ParseIP(ip.Netmask)
+		if netmask == nil || netmask.To4() == nil {
+			return ""
+		}
+		mask = net.IPMask(netmask.To4())
+	case ip.Prefix != "":
+		_, ipNet, err := net.ParseCIDR(ip.Address + "/" + ip.Prefix)
+		if err != nil {
+			return ""
+		}
+		return ipNet.String()
+	default:
+		return ""
+	}
+	if addr.To4() != nil {
+		addr = addr.To4()
+	}
+	return (&net.IPNet{IP: addr.Mask(mask), Mask: mask}).String()
+}
+
+// deviceCIDRs returns the CIDRs of the subnets of the global
+// addresses assigned to the named network device on the host.
+func (c *Client) deviceCIDRs(device string) ([]string, error) {
+	out, err := c.run(nil, "ip", "-o", "addr", "show", "dev", device)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Each line is of the form:
+	//  4: br0    inet 10.0.0.5/24 brd 10.0.0.255 scope global br0\       valid_lft forever ...
+	var cidrs []string
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		for i := 0; i+1 < len(fields); i++ {
+			if fields[i] != "inet" && fields[i] != "inet6" {
+				continue
+			}
+			if !strings.Contains(line, "scope global") {
+				break
+			}
+			if _, ipNet, err := net.ParseCIDR(fields[i+1]); err == nil {
+				cidrs = append(cidrs, ipNet.String())
+			}
+			break
+		}
+	}
+	return cidrs, nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirtclient
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// Volume describes a volume in a libvirt storage pool.
+type Volume struct {
+	Name string
+	Pool string
+
+	// Path is the path of the volume on the host.
+	Path string
+
+	// CapacityMiB is the size of the volume.
+	CapacityMiB uint64
+}
+
+// ImageSource describes a cloud image to download.
+type ImageSource struct {
+	URL     string
+	SHA256  string
+	Release string
+	Arch    string
+}
+
+// Volumes returns the volumes in the named storage pool whose
+// names start with the given prefix.
+func (c *Client) Volumes(pool, prefix string) ([]*Volume, error) {
+	out, err := c.virsh("vol-list", "--pool", pool)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing volumes in pool %q", pool)
+	}
+	var volumes []*Volume
+	for _, fields := range tableRows(out) {
+		if !strings.HasPrefix(fields[0], prefix) {
+			continue
+		}
+		volume, err := c.Volume(pool, fields[0])
+		if errors.IsNotFound(err) {
+			// The volume was removed after it was listed.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumes = append(volumes, volume)
+	}
+	return volumes, nil
+}
+
+// Volume returns the named volume in the named storage pool.
+func (c *Client) Volume(pool, name string) (*Volume, error) {
+	out, err := c.virsh("vol-dumpxml", "--pool", pool, name)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errors.NotFoundf("volume %q in pool %q", name, pool)
+		}
+		return nil, errors.Annotatef(err, "getting definition of volume %q", name)
+	}
+	var def volumeXML
+	if err := xml.Unmarshal([]byte(out), &def); err != nil {
+		return nil, errors.Annotatef(err, "decoding definition of volume %q", name)
+	}
+	return &Volume{
+		Name:        def.Name,
+		Pool:        pool,
+		Path:        def.Target.Path,
+		CapacityMiB: def.Capacity.Value / 1024 / 1024,
+	}, nil
+}
+
+// CreateVolume creates a raw volume of the given size in the named
+// storage pool.
+func (c *Client) CreateVolume(pool, name string, sizeMiB uint64) (*Volume, error) {
+	if _, err := c.virsh("vol-create-as", pool, name, fmt.Sprintf("%dM", sizeMiB)); err != nil {
+		return nil, errors.Annotatef(err, "creating volume %q", name)
+	}
+	volume, err := c.Volume(pool, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return volume, nil
+}
+
+// DeleteVolume deletes the named volume from the named storage pool.
+// It is not an error for the volume not to exist.
+func (c *Client) DeleteVolume(pool, name string) error {
+	if _, err := c.virsh("vol-delete", "--pool", pool, name); err != nil && !isNotFound(err) {
+		return errors.Annotatef(err, "deleting volume %q", name)
+	}
+	return nil
+}
+
+// AttachVolume attaches the named volume to the named domain as a
+// virtio disk with the given serial number, and returns the target
+// device of the disk. If the volume is already attached, the existing
+// target device is returned.
+func (c *Client) AttachVolume(domainName, pool, name, serial string) (string, error) {
+	domain, err := c.Domain(domainName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	targets := set.NewStrings()
+	for _, disk := range domain.Disks {
+		if disk.Serial == serial {
+			return disk.Target, nil
+		}
+		targets.Add(disk.Target)
+	}
+	target, err := nextDiskTarget(targets)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	volume, err := c.Volume(pool, name)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if _, err := c.virsh(
+		"attach-disk", domainName, volume.Path, target,
+		"--targetbus", "virtio",
+		"--serial", serial,
+		"--persistent",
+	); err != nil {
+		return "", errors.Annotatef(err, "attaching volume %q to domain %q", name, domainName)
+	}
+	return target, nil
+}
+
+// nextDiskTarget returns the first virtio disk target device
+// that is not already in use.
+func nextDiskTarget(inUse set.Strings) (string, error) {
+	for c := 'b'; c <= 'z'; c++ {
+		target := "vd" + string(c)
+		if !inUse.Contains(target) {
+			return target, nil
+		}
+	}
+	return "", errors.New("no free disk targets")
+}
+
+// DetachVolume detaches the disk with the given serial number from
+// the named domain. It is not an error for the disk not to be
+// attached.
+func (c *Client) DetachVolume(domainName, serial string) error {
+	domain, err := c.Domain(domainName)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, disk := range domain.Disks {
+		if disk.Serial != serial {
+			continue
+		}
+		if _, err := c.virsh("detach-disk", domainName, disk.Target, "--persistent"); err != nil {
+			return errors.Annotatef(err, "detaching disk %q from domain %q", disk.Target, domainName)
+		}
+		return nil
+	}
+	return nil
+}
+
+// EnsureImage ensures that the cloud image described by source exists
+// as a volume in the named storage pool, downloading the image to the
+// host if necessary, and returns the name of the volume. The image is
+// downloaded and verified on the host, so curl and sha256sum must be
+// installed there.
+func (c *Client) EnsureImage(pool string, source ImageSource) (string, error) {
+	if len(source.SHA256) < 12 {
+		return "", errors.NotValidf("image SHA-256 %q", source.SHA256)
+	}
+	name := fmt.Sprintf("juju-%s-%s-%s.img", source.Release, source.Arch, source.SHA256[:12])
+	if _, err := c.Volume(pool, name); err == nil {
+		return name, nil
+	} else if !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+
+	c.logger.Infof("downloading image %s to pool %q", source.URL, pool)
+	virsh := shellCommand("virsh", "--connect", connectURI)
+	script := strings.Join([]string{
+		"set -e",
+		`file=$(mktemp)`,
+		`trap 'rm -f "$file"' EXIT`,
+		fmt.Sprintf(`curl -sSfL -o "$file" %s`, shellQuote(source.URL)),
+		fmt.Sprintf(`printf '%%s  %%s\n' %s "$file" | sha256sum -c --quiet -`, shellQuote(source.SHA256)),
+		fmt.Sprintf(
+			`%s vol-create-as %s %s "$(stat -c %%s "$file")" --format qcow2`,
+			virsh, shellQuote(pool), shellQuote(name),
+		),
+		// Remove the volume if the upload fails, so that
+		// the incomplete image is not used later.
+		fmt.Sprintf(
+			`%[1]s vol-upload --pool %[2]s %[3]s "$file" || { %[1]s vol-delete --pool %[2]s %[3]s; exit 1; }`,
+			virsh, shellQuote(pool), shellQuote(name),
+		),
+	}, "\n")
+	if _, err := c.run(nil, "sh", "-c", script); err != nil {
+		return "", errors.Annotatef(err, "downloading image %s", source.URL)
+	}
+	return name, nil
+}
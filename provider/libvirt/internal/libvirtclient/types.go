@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirtclient
+
+import "encoding/xml"
+
+// The types below describe the subset of libvirt's domain, network and
+// storage volume XML formats that we use. See https://libvirt.org/format.html.
+
+type domainXML struct {
+	XMLName  xml.Name     `xml:"domain"`
+	Type     string       `xml:"type,attr"`
+	Name     string       `xml:"name"`
+	UUID     string       `xml:"uuid,omitempty"`
+	Memory   memoryXML    `xml:"memory"`
+	VCPU     uint64       `xml:"vcpu"`
+	Metadata *metadataXML `xml:"metadata"`
+	OS       osXML        `xml:"os"`
+	Features featuresXML  `xml:"features"`
+	Devices  devicesXML   `xml:"devices"`
+}
+
+type memoryXML struct {
+	Unit  string `xml:"unit,attr,omitempty"`
+	Value uint64 `xml:",chardata"`
+}
+
+// mebibytes returns the amount of memory in MiB.
+func (m memoryXML) mebibytes() uint64 {
+	switch m.Unit {
+	case "b", "bytes":
+		return m.Value / 1024 / 1024
+	case "MiB", "M":
+		return m.Value
+	case "GiB", "G":
+		return m.Value * 1024
+	default:
+		// libvirt reports memory in KiB, which is
+		// also the default unit.
+		return m.Value / 1024
+	}
+}
+
+type metadataXML struct {
+	Instance *instanceMetadataXML `xml:"http://jujucharms.com/libvirt/1.0 instance"`
+}
+
+type instanceMetadataXML struct {
+	Tags []tagXML `xml:"tag"`
+}
+
+type tagXML struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type osXML struct {
+	Type osTypeXML `xml:"type"`
+	Boot []bootXML `xml:"boot"`
+}
+
+type osTypeXML struct {
+	Arch    string `xml:"arch,attr,omitempty"`
+	Machine string `xml:"machine,attr,omitempty"`
+	Value   string `xml:",chardata"`
+}
+
+type bootXML struct {
+	Dev string `xml:"dev,attr"`
+}
+
+type featuresXML struct {
+	ACPI *struct{} `xml:"acpi"`
+	APIC *struct{} `xml:"apic"`
+}
+
+type devicesXML struct {
+	Disks      []diskXML      `xml:"disk"`
+	Interfaces []interfaceXML `xml:"interface"`
+	Serials    []serialXML    `xml:"serial"`
+	Consoles   []serialXML    `xml:"console"`
+}
+
+type diskXML struct {
+	Type     string         `xml:"type,attr"`
+	Device   string         `xml:"device,attr"`
+	Driver   *diskDriverXML `xml:"driver"`
+	Source   diskSourceXML  `xml:"source"`
+	Target   diskTargetXML  `xml:"target"`
+	Serial   string         `xml:"serial,omitempty"`
+	ReadOnly *struct{}      `xml:"readonly"`
+}
+
+type diskDriverXML struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type diskSourceXML struct {
+	Pool   string `xml:"pool,attr,omitempty"`
+	Volume string `xml:"volume,attr,omitempty"`
+	File   string `xml:"file,attr,omitempty"`
+}
+
+type diskTargetXML struct {
+	Dev string `xml:"dev,attr"`
+	Bus string `xml:"bus,attr,omitempty"`
+}
+
+type interfaceXML struct {
+	Type   string             `xml:"type,attr"`
+	MAC    *macXML            `xml:"mac"`
+	Source interfaceSourceXML `xml:"source"`
+	Model  *interfaceModelXML `xml:"model"`
+}
+
+type macXML struct {
+	Address string `xml:"address,attr"`
+}
+
+type interfaceSourceXML struct {
+	Network string `xml:"network,attr,omitempty"`
+	Bridge  string `xml:"bridge,attr,omitempty"`
+}
+
+type interfaceModelXML struct {
+	Type string `xml:"type,attr"`
+}
+
+type serialXML struct {
+	Type   string          `xml:"type,attr"`
+	Target serialTargetXML `xml:"target"`
+}
+
+type serialTargetXML struct {
+	Type string `xml:"type,attr,omitempty"`
+	Port string `xml:"port,attr"`
+}
+
+type networkXML struct {
+	XMLName xml.Name           `xml:"network"`
+	Name    string             `xml:"name"`
+	Forward *networkForwardXML `xml:"forward"`
+	Bridge  *networkBridgeXML  `xml:"bridge"`
+	IPs     []networkIPXML     `xml:"ip"`
+}
+
+type networkForwardXML struct {
+	Mode string `xml:"mode,attr"`
+}
+
+type networkBridgeXML struct {
+	Name string `xml:"name,attr"`
+}
+
+type networkIPXML struct {
+	Family  string `xml:"family,attr"`
+	Address string `xml:"address,attr"`
+	Netmask string `xml:"netmask,attr"`
+	Prefix  string `xml:"prefix,attr"`
+}
+
+type volumeXML struct {
+	XMLName  xml.Name          `xml:"volume"`
+	Name     string            `xml:"name"`
+	Key      string            `xml:"key"`
+	Capacity volumeCapacityXML `xml:"capacity"`
+	Target   volumeTargetXML   `xml:"target"`
+}
+
+// volumeCapacityXML is the capacity of a volume,
+// which libvirt reports in bytes.
+type volumeCapacityXML struct {
+	Unit  string `xml:"unit,attr"`
+	Value uint64 `xml:",chardata"`
+}
+
+type volumeTargetXML struct {
+	Path string `xml:"path"`
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"sync"
+
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/provider/libvirt"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+func newMockDialFunc(dialStub *testing.Stub, client libvirt.Client) libvirt.DialFunc {
+	return func(address string, config libvirtclient.SSHConfig) (libvirt.Client, error) {
+		dialStub.AddCall("Dial", address, config)
+		if err := dialStub.NextErr(); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+}
+
+// mockClient is a libvirt.Client that records the virsh operations
+// requested by the provider, without a hypervisor host.
+type mockClient struct {
+	mu sync.Mutex
+	testing.Stub
+
+	createdDomain     *libvirtclient.Domain
+	domains           []*libvirtclient.Domain
+	domainAddresses   []libvirtclient.InterfaceAddress
+	networks          []*libvirtclient.Network
+	createdVolume     *libvirtclient.Volume
+	volumes           []*libvirtclient.Volume
+	attachedVolumeDev string
+	image             string
+}
+
+// call records a call to the named method and returns the next
+// configured error. StopInstances destroys domains concurrently, so
+// calls are serialised to keep each recorded call paired with its
+// error.
+func (c *mockClient) call(name string, args ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, name, args...)
+	return c.NextErr()
+}
+
+func (c *mockClient) Close() error {
+	return c.call("Close")
+}
+
+func (c *mockClient) AttachVolume(domain, pool, name, serial string) (string, error) {
+	return c.attachedVolumeDev, c.call("AttachVolume", domain, pool, name, serial)
+}
+
+func (c *mockClient) CreateDomain(args libvirtclient.CreateDomainParams) (*libvirtclient.Domain, error) {
+	return c.createdDomain, c.call("CreateDomain", args)
+}
+
+func (c *mockClient) CreateVolume(pool, name string, sizeMiB uint64) (*libvirtclient.Volume, error) {
+	return c.createdVolume, c.call("CreateVolume", pool, name, sizeMiB)
+}
+
+func (c *mockClient) DeleteVolume(pool, name string) error {
+	return c.call("DeleteVolume", pool, name)
+}
+
+func (c *mockClient) DestroyDomain(name string) error {
+	return c.call("DestroyDomain", name)
+}
+
+func (c *mockClient) DetachVolume(domain, serial string) error {
+	return c.call("DetachVolume", domain, serial)
+}
+
+func (c *mockClient) DomainAddresses(name string) ([]libvirtclient.InterfaceAddress, error) {
+	return c.domainAddresses, c.call("DomainAddresses", name)
+}
+
+func (c *mockClient) Domains(prefix string) ([]*libvirtclient.Domain, error) {
+	return c.domains, c.call("Domains", prefix)
+}
+
+func (c *mockClient) EnsureImage(pool string, source libvirtclient.ImageSource) (string, error) {
+	return c.image, c.call("EnsureImage", pool, source)
+}
+
+func (c *mockClient) Networks() ([]*libvirtclient.Network, error) {
+	return c.networks, c.call("Networks")
+}
+
+func (c *mockClient) SetDomainTags(name string, tags map[string]string) error {
+	return c.call("SetDomainTags", name, tags)
+}
+
+func (c *mockClient) Volumes(pool, prefix string) ([]*libvirtclient.Volume, error) {
+	return c.volumes, c.call("Volumes", pool, prefix)
+}
+
+const fakeModelUUID = "2d02eeac-9dbb-11e4-89d3-123b93f75cba"
+
+func newDomain(name, state string) *libvirtclient.Domain {
+	return &libvirtclient.Domain{
+		Name:  name,
+		State: state,
+		Tags: map[string]string{
+			"juju-model-uuid": fakeModelUUID,
+		},
+	}
+}
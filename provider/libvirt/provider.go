@@ -0,0 +1,152 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+var logger = loggo.GetLogger("juju.provider.libvirt")
+
+const (
+	providerVersion1 = 1
+
+	currentProviderVersion = providerVersion1
+)
+
+type environProvider struct {
+	environProviderCredentials
+	dial DialFunc
+}
+
+// EnvironProviderConfig holds the dependencies of the libvirt
+// EnvironProvider, which tests replace to avoid connecting to a host.
+type EnvironProviderConfig struct {
+	// Dial is a function used for dialing connections to the
+	// hypervisor hosts.
+	Dial DialFunc
+}
+
+// NewEnvironProvider returns a new environs.EnvironProvider that will
+// dial libvirt connections with the given dial function.
+func NewEnvironProvider(config EnvironProviderConfig) environs.EnvironProvider {
+	return &environProvider{
+		dial: config.Dial,
+	}
+}
+
+// Version implements environs.EnvironProvider.
+func (p *environProvider) Version() int {
+	return currentProviderVersion
+}
+
+// Open implements environs.EnvironProvider.
+func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	env, err := newEnviron(p, args.Cloud, args.Config)
+	return env, errors.Trace(err)
+}
+
+// Each region of a libvirt cloud is a hypervisor host, whose
+// endpoint is the host's SSH address.
+var cloudSchema = &jsonschema.Schema{
+	Type:     []jsonschema.Type{jsonschema.ObjectType},
+	Required: []string{cloud.AuthTypesKey, cloud.RegionsKey},
+	Order:    []string{cloud.AuthTypesKey, cloud.RegionsKey},
+	Properties: map[string]*jsonschema.Schema{
+		cloud.AuthTypesKey: &jsonschema.Schema{
+			// don't need a prompt, since there's only one choice.
+			Type: []jsonschema.Type{jsonschema.ArrayType},
+			Enum: []interface{}{[]string{string(cloud.SSHKeyAuthType)}},
+		},
+		cloud.RegionsKey: {
+			Type:     []jsonschema.Type{jsonschema.ObjectType},
+			Singular: "host",
+			Plural:   "hosts",
+			AdditionalProperties: &jsonschema.Schema{
+				Type:          []jsonschema.Type{jsonschema.ObjectType},
+				Required:      []string{cloud.EndpointKey},
+				MaxProperties: jsonschema.Int(1),
+				Properties: map[string]*jsonschema.Schema{
+					cloud.EndpointKey: &jsonschema.Schema{
+						Singular: "the SSH address of the host (host[:port])",
+						Type:     []jsonschema.Type{jsonschema.StringType},
+					},
+				},
+			},
+		},
+	},
+}
+
+// CloudSchema returns the schema for adding new clouds of this type.
+func (p *environProvider) CloudSchema() *jsonschema.Schema {
+	return cloudSchema
+}
+
+// Ping tests the connection to the cloud, to verify the endpoint is valid.
+func (p *environProvider) Ping(endpoint string) error {
+	address, err := sshAddress(endpoint)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Dial without a private key. The SSH server rejects the login,
+	// but only after the handshake, which shows that there is an
+	// SSH server at the address.
+	client, err := p.dial(address, libvirtclient.SSHConfig{User: "juju"})
+	if err != nil {
+		if libvirtclient.IsUnauthorized(err) {
+			return nil
+		}
+		logger.Errorf("Unexpected error dialing SSH connection: %v", err)
+		return errors.Errorf("No SSH server available at %s", endpoint)
+	}
+	defer client.Close()
+	return nil
+}
+
+// PrepareConfig implements environs.EnvironProvider.
+func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return args.Config, nil
+}
+
+// Validate implements environs.EnvironProvider.
+func (*environProvider) Validate(cfg, old *config.Config) (valid *config.Config, err error) {
+	ecfg, err := newValidConfig(cfg, old)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	return ecfg.Config, nil
+}
+
+func validateCloudSpec(spec environs.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Region == "" {
+		return errors.NotValidf("missing region (host)")
+	}
+	if _, err := sshAddress(spec.Endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Credential == nil {
+		return errors.NotValidf("missing credential")
+	}
+	if authType := spec.Credential.AuthType(); authType != cloud.SSHKeyAuthType {
+		return errors.NotSupportedf("%q auth-type", authType)
+	}
+	return nil
+}
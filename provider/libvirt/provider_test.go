@@ -0,0 +1,176 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+)
+
+type providerSuite struct {
+	ProviderFixture
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) TestRegistered(c *gc.C) {
+	provider, err := environs.Provider("libvirt")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider, gc.NotNil)
+}
+
+func (s *providerSuite) TestOpen(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: fakeConfig(c),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(env.Config().Name(), gc.Equals, "testenv")
+
+	// Opening the environ does not connect to the host.
+	s.dialStub.CheckNoCalls(c)
+}
+
+func (s *providerSuite) TestOpenInvalidCloudSpec(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Name = ""
+	s.testOpenError(c, spec, `validating cloud spec: cloud name "" not valid`)
+}
+
+func (s *providerSuite) TestOpenMissingRegion(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Region = ""
+	s.testOpenError(c, spec, `validating cloud spec: missing region \(host\) not valid`)
+}
+
+func (s *providerSuite) TestOpenInvalidEndpoint(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Endpoint = "qemu+tls://10.0.0.1/system"
+	s.testOpenError(c, spec, `validating cloud spec: invalid endpoint "qemu\+tls://10.0.0.1/system": expected an ssh or qemu\+ssh URL`)
+}
+
+func (s *providerSuite) TestOpenEndpointWithUser(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Endpoint = "ssh://root@10.0.0.1"
+	s.testOpenError(c, spec, `validating cloud spec: invalid endpoint "ssh://root@10.0.0.1": the user must be specified by the credential`)
+}
+
+func (s *providerSuite) TestOpenMissingCredential(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Credential = nil
+	s.testOpenError(c, spec, `validating cloud spec: missing credential not valid`)
+}
+
+func (s *providerSuite) TestOpenUnsupportedCredential(c *gc.C) {
+	credential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{})
+	spec := fakeCloudSpec()
+	spec.Credential = &credential
+	s.testOpenError(c, spec, `validating cloud spec: "userpass" auth-type not supported`)
+}
+
+func (s *providerSuite) testOpenError(c *gc.C, spec environs.CloudSpec, expect string) {
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud:  spec,
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+func (s *providerSuite) TestPrepareConfig(c *gc.C) {
+	cfg, err := s.provider.PrepareConfig(environs.PrepareConfigParams{
+		Config: fakeConfig(c),
+		Cloud:  fakeCloudSpec(),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cfg, gc.NotNil)
+}
+
+func (s *providerSuite) TestValidate(c *gc.C) {
+	config := fakeConfig(c)
+	validCfg, err := s.provider.Validate(config, nil)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(config.AllAttrs(), gc.DeepEquals, validCfg.AllAttrs())
+}
+
+func (s *providerSuite) TestSchema(c *gc.C) {
+	y := []byte(`
+auth-types: [ssh-key]
+regions:
+  host1:
+    endpoint: 10.0.0.1
+  host2:
+    endpoint: qemu+ssh://10.0.0.2:2222/system
+`[1:])
+	var v interface{}
+	err := yaml.Unmarshal(y, &v)
+	c.Assert(err, jc.ErrorIsNil)
+	v, err = utils.ConformYAML(v)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.provider.CloudSchema().Validate(v)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestDial(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Endpoint = "qemu+ssh://10.0.0.1:2222/system"
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  spec,
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.dialStub.CheckCalls(c, []testing.StubCall{{
+		"Dial", []interface{}{"10.0.0.1:2222", libvirtclient.SSHConfig{
+			User:       "ubuntu",
+			PrivateKey: []byte("private-key-data"),
+		}},
+	}})
+}
+
+type pingSuite struct {
+	ProviderFixture
+}
+
+var _ = gc.Suite(&pingSuite{})
+
+func (s *pingSuite) TestPingUnauthorized(c *gc.C) {
+	s.dialStub.SetErrors(libvirtclient.NewUnauthorizedError(errors.New("unable to authenticate")))
+	err := s.provider.Ping("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.dialStub.CheckCalls(c, []testing.StubCall{{
+		"Dial", []interface{}{"10.0.0.1:22", libvirtclient.SSHConfig{User: "juju"}},
+	}})
+}
+
+func (s *pingSuite) TestPingNoHost(c *gc.C) {
+	s.dialStub.SetErrors(errors.New("connection refused"))
+	err := s.provider.Ping("ssh://10.0.0.1:2222")
+	c.Assert(err, gc.ErrorMatches, "No SSH server available at ssh://10.0.0.1:2222")
+}
+
+func (s *pingSuite) TestPingInvalidScheme(c *gc.C) {
+	err := s.provider.Ping("https://10.0.0.1")
+	c.Assert(err, gc.ErrorMatches, `invalid endpoint "https://10.0.0.1": expected an ssh or qemu\+ssh URL`)
+	s.dialStub.CheckNoCalls(c)
+}
+
+func (s *pingSuite) TestPingLoginSucceeded(c *gc.C) {
+	// An SSH server that permits logins without authentication
+	// is still an SSH server, so Ping succeeds.
+	err := s.provider.Ping("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "Close")
+}
@@ -0,0 +1,33 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/juju/provider/common"
+)
+
+// sessionEnviron holds an SSH connection to the hypervisor host for
+// the duration of a single exported environ method call. Each client
+// method runs virsh on the host in a new SSH session, so a method
+// call pays for the SSH handshake and authentication only once,
+// however many commands it runs.
+type sessionEnviron struct {
+	*environ
+
+	client Client
+}
+
+func (env *environ) withSession(f func(*sessionEnviron) error) error {
+	session := &sessionEnviron{environ: env}
+	return common.WithSession(func() (func() error, error) {
+		client, err := dialClient(env.cloud, env.provider.dial)
+		if err != nil {
+			return nil, err
+		}
+		session.client = client
+		return client.Close, nil
+	}, func() error {
+		return f(session)
+	})
+}
@@ -0,0 +1,275 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	libvirtStorageProviderType = storage.ProviderType("libvirt")
+
+	// storagePoolAttr is the storage pool attribute naming the
+	// libvirt storage pool in which to create volumes. If
+	// unspecified, the model's "storage-pool" config is used.
+	storagePoolAttr = "pool"
+
+	// serialLength is the length of the disk serial numbers
+	// seen by guests for virtio disks.
+	serialLength = 20
+)
+
+// volumeNamePrefix returns the prefix of the names of volumes created
+// for the model with the given namespace. The names of the root disks
+// and seed images of the model's domains do not have this prefix.
+func volumeNamePrefix(namespace instance.Namespace) string {
+	return namespace.Value("volume-")
+}
+
+// volumeSerial returns the serial number to give the disk of the named
+// volume when attaching it to a domain. The serial number identifies
+// the disk within the guest, and so must be stable across attachments.
+func volumeSerial(volumeName string) string {
+	sum := sha1.Sum([]byte(volumeName))
+	return hex.EncodeToString(sum[:])[:serialLength]
+}
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (*environ) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return []storage.ProviderType{libvirtStorageProviderType}, nil
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == libvirtStorageProviderType {
+		return &storageProvider{env}, nil
+	}
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+// storageProvider is a storage.Provider that creates volumes in
+// libvirt storage pools on the host.
+type storageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+// VolumeSource is part of the storage.Provider interface.
+func (p *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	pool, _ := cfg.ValueString(storagePoolAttr)
+	if pool == "" {
+		pool = p.env.environConfig().storagePool()
+	}
+	return &volumeSource{
+		env:  p.env,
+		pool: pool,
+	}, nil
+}
+
+// FilesystemSource is part of the storage.Provider interface.
+func (p *storageProvider) FilesystemSource(cfg *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is part of the storage.Provider interface.
+func (p *storageProvider) Supports(kind storage.StorageKind) bool {
+	return kind == storage.StorageKindBlock
+}
+
+// Scope is part of the storage.Provider interface.
+func (p *storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is part of the storage.Provider interface.
+func (p *storageProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is part of the storage.Provider interface.
+//
+// Volumes are associated with a model by their names alone,
+// so they cannot be released from one model for another.
+func (p *storageProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is part of the storage.Provider interface.
+func (p *storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// ValidateConfig is part of the storage.Provider interface.
+func (p *storageProvider) ValidateConfig(cfg *storage.Config) error {
+	if v, ok := cfg.Attrs()[storagePoolAttr]; ok {
+		if _, ok := v.(string); !ok {
+			return errors.Errorf("%s: expected string, got %T", storagePoolAttr, v)
+		}
+	}
+	return nil
+}
+
+// volumeSource is a storage.VolumeSource that creates volumes in a
+// libvirt storage pool, and attaches them to domains as virtio disks.
+// The volume ids are the names of the volumes in the pool.
+type volumeSource struct {
+	env  *environ
+	pool string
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// CreateVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) CreateVolumes(params []storage.VolumeParams) (results []storage.CreateVolumesResult, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		results = make([]storage.CreateVolumesResult, len(params))
+		for i, p := range params {
+			results[i].Volume, results[i].Error = v.createVolume(env, p)
+		}
+		return nil
+	})
+	return results, err
+}
+
+func (v *volumeSource) createVolume(env *sessionEnviron, p storage.VolumeParams) (*storage.Volume, error) {
+	volume, err := env.client.CreateVolume(v.pool, env.namespace.Value(p.Tag.String()), p.Size)
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating volume %s", p.Tag.Id())
+	}
+	return &storage.Volume{
+		Tag:        p.Tag,
+		VolumeInfo: makeVolumeInfo(volume),
+	}, nil
+}
+
+func makeVolumeInfo(volume *libvirtclient.Volume) storage.VolumeInfo {
+	return storage.VolumeInfo{
+		VolumeId:   volume.Name,
+		Size:       volume.CapacityMiB,
+		Persistent: true,
+	}
+}
+
+// modelVolumes returns the model's volumes in the source's pool.
+func (v *volumeSource) modelVolumes(env *sessionEnviron) ([]*libvirtclient.Volume, error) {
+	volumes, err := env.client.Volumes(v.pool, volumeNamePrefix(env.namespace))
+	return volumes, errors.Trace(err)
+}
+
+// ListVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ListVolumes() (volumeIds []string, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		volumes, err := v.modelVolumes(env)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeIds = make([]string, len(volumes))
+		for i, volume := range volumes {
+			volumeIds[i] = volume.Name
+		}
+		return nil
+	})
+	return volumeIds, err
+}
+
+// DescribeVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DescribeVolumes(volumeIds []string) (results []storage.DescribeVolumesResult, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		volumes, err := v.modelVolumes(env)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		byName := make(map[string]*libvirtclient.Volume)
+		for _, volume := range volumes {
+			byName[volume.Name] = volume
+		}
+		results = make([]storage.DescribeVolumesResult, len(volumeIds))
+		for i, id := range volumeIds {
+			volume, ok := byName[id]
+			if !ok {
+				results[i].Error = errors.NotFoundf("volume %q", id)
+				continue
+			}
+			info := makeVolumeInfo(volume)
+			results[i].VolumeInfo = &info
+		}
+		return nil
+	})
+	return results, err
+}
+
+// DestroyVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DestroyVolumes(volumeIds []string) (results []error, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		results = foreachVolume(volumeIds, func(id string) error {
+			return env.client.DeleteVolume(v.pool, id)
+		})
+		return nil
+	})
+	return results, err
+}
+
+// ReleaseVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ReleaseVolumes(volumeIds []string) ([]error, error) {
+	return nil, errors.NotSupportedf("releasing volumes")
+}
+
+func foreachVolume(volumeIds []string, f func(string) error) []error {
+	results := make([]error, len(volumeIds))
+	for i, id := range volumeIds {
+		results[i] = f(id)
+	}
+	return results
+}
+
+// ValidateVolumeParams is part of the storage.VolumeSource interface.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	return nil
+}
+
+// AttachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) (results []storage.AttachVolumesResult, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		results = make([]storage.AttachVolumesResult, len(params))
+		for i, p := range params {
+			results[i].VolumeAttachment, results[i].Error = v.attachVolume(env, p)
+		}
+		return nil
+	})
+	return results, err
+}
+
+func (v *volumeSource) attachVolume(env *sessionEnviron, p storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	serial := volumeSerial(p.VolumeId)
+	if _, err := env.client.AttachVolume(string(p.InstanceId), v.pool, p.VolumeId, serial); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeAttachment{
+		Volume:  p.Volume,
+		Machine: p.Machine,
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: "/dev/disk/by-id/virtio-" + serial,
+		},
+	}, nil
+}
+
+// DetachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) (results []error, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		results = make([]error, len(params))
+		for i, p := range params {
+			results[i] = env.client.DetachVolume(string(p.InstanceId), volumeSerial(p.VolumeId))
+		}
+		return nil
+	})
+	return results, err
+}
@@ -0,0 +1,193 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/provider/libvirt/internal/libvirtclient"
+	"github.com/juju/juju/storage"
+)
+
+type storageSuite struct {
+	EnvironFixture
+	provider storage.Provider
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	registry, ok := s.env.(storage.ProviderRegistry)
+	c.Assert(ok, jc.IsTrue)
+	types, err := registry.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, []storage.ProviderType{"libvirt"})
+	s.provider, err = registry.StorageProvider("libvirt")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) volumeSource(c *gc.C, attrs map[string]interface{}) storage.VolumeSource {
+	cfg, err := storage.NewConfig("libvirt", "libvirt", attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := s.provider.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *storageSuite) TestProvider(c *gc.C) {
+	c.Assert(s.provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(s.provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+	c.Assert(s.provider.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(s.provider.Dynamic(), jc.IsTrue)
+	c.Assert(s.provider.Releasable(), jc.IsFalse)
+}
+
+func (s *storageSuite) TestValidateConfig(c *gc.C) {
+	cfg, err := storage.NewConfig("libvirt", "libvirt", map[string]interface{}{
+		"pool": 123,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `pool: expected string, got int`)
+}
+
+func (s *storageSuite) TestCreateVolumes(c *gc.C) {
+	s.client.createdVolume = &libvirtclient.Volume{
+		Name:        "juju-f75cba-volume-0",
+		Pool:        "default",
+		CapacityMiB: 1024,
+	}
+
+	source := s.volumeSource(c, nil)
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		Tag: names.NewVolumeTag("0"),
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId:   "juju-f75cba-volume-0",
+			Size:       1024,
+			Persistent: true,
+		},
+	})
+
+	s.client.CheckCallNames(c, "CreateVolume", "Close")
+	s.client.CheckCall(c, 0, "CreateVolume", "default", "juju-f75cba-volume-0", uint64(1024))
+}
+
+func (s *storageSuite) TestCreateVolumesPoolConfig(c *gc.C) {
+	s.client.createdVolume = &libvirtclient.Volume{Name: "juju-f75cba-volume-0"}
+	source := s.volumeSource(c, map[string]interface{}{
+		"pool": "ssd",
+	})
+	_, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "CreateVolume", "Close")
+	s.client.CheckCall(c, 0, "CreateVolume", "ssd", "juju-f75cba-volume-0", uint64(1024))
+}
+
+func (s *storageSuite) TestListVolumes(c *gc.C) {
+	s.client.volumes = []*libvirtclient.Volume{
+		{Name: "juju-f75cba-volume-0"},
+		{Name: "juju-f75cba-volume-1"},
+	}
+	source := s.volumeSource(c, nil)
+	volumeIds, err := source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.DeepEquals, []string{"juju-f75cba-volume-0", "juju-f75cba-volume-1"})
+
+	s.client.CheckCallNames(c, "Volumes", "Close")
+	s.client.CheckCall(c, 0, "Volumes", "default", "juju-f75cba-volume-")
+}
+
+func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
+	s.client.volumes = []*libvirtclient.Volume{
+		{Name: "juju-f75cba-volume-0", CapacityMiB: 2048},
+	}
+	source := s.volumeSource(c, nil)
+	results, err := source.DescribeVolumes([]string{"juju-f75cba-volume-0", "juju-f75cba-volume-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId:   "juju-f75cba-volume-0",
+		Size:       2048,
+		Persistent: true,
+	})
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
+	s.client.SetErrors(nil, errors.New("nope"))
+	source := s.volumeSource(c, nil)
+	results, err := source.DestroyVolumes([]string{"juju-f75cba-volume-0", "juju-f75cba-volume-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, "nope")
+
+	s.client.CheckCallNames(c, "DeleteVolume", "DeleteVolume", "Close")
+	s.client.CheckCall(c, 0, "DeleteVolume", "default", "juju-f75cba-volume-0")
+}
+
+func (s *storageSuite) TestReleaseVolumes(c *gc.C) {
+	source := s.volumeSource(c, nil)
+	_, err := source.ReleaseVolumes([]string{"juju-f75cba-volume-0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.client.CheckNoCalls(c)
+}
+
+func (s *storageSuite) TestAttachVolumes(c *gc.C) {
+	s.client.attachedVolumeDev = "vdb"
+	source := s.volumeSource(c, nil)
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "juju-f75cba-0",
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "juju-f75cba-volume-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment, jc.DeepEquals, &storage.VolumeAttachment{
+		Volume:  names.NewVolumeTag("0"),
+		Machine: names.NewMachineTag("0"),
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: "/dev/disk/by-id/virtio-95c85e69966bd091f05d",
+		},
+	})
+	s.client.CheckCallNames(c, "AttachVolume", "Close")
+	s.client.CheckCall(c, 0, "AttachVolume",
+		"juju-f75cba-0", "default", "juju-f75cba-volume-0", "95c85e69966bd091f05d",
+	)
+}
+
+func (s *storageSuite) TestDetachVolumes(c *gc.C) {
+	source := s.volumeSource(c, nil)
+	results, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "juju-f75cba-0",
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "juju-f75cba-volume-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+	s.client.CheckCallNames(c, "DetachVolume", "Close")
+	s.client.CheckCall(c, 0, "DetachVolume", "juju-f75cba-0", "95c85e69966bd091f05d")
+}
@@ -0,0 +1,26 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package libvirt
+
+import (
+	"github.com/juju/errors"
+	jujuos "github.com/juju/utils/os"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/providerinit/renderers"
+)
+
+// LibvirtRenderer renders cloud-init user-data for libvirt guests. The
+// user-data is written to a NoCloud seed image, from which cloud-init
+// reads it unencoded.
+type LibvirtRenderer struct{}
+
+func (LibvirtRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
+	switch os {
+	case jujuos.Ubuntu, jujuos.CentOS:
+		return renderers.RenderYAML(cfg)
+	default:
+		return nil, errors.Errorf("Cannot encode userdata for OS: %s", os.String())
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+// lxdAvailabilityZone is an availability zone, corresponding
+// to a member of an LXD cluster.
+type lxdAvailabilityZone struct {
+	member lxdclient.ClusterMember
+}
+
+// Name implements common.AvailabilityZone.
+func (z *lxdAvailabilityZone) Name() string {
+	return z.member.Name
+}
+
+// Available implements common.AvailabilityZone.
+func (z *lxdAvailabilityZone) Available() bool {
+	return z.member.Status == lxdclient.ClusterMemberOnline
+}
+
+// AvailabilityZones is part of the common.ZonedEnviron interface.
+// Each member of an LXD cluster is an availability zone; a standalone
+// LXD server has no availability zones.
+func (env *environ) AvailabilityZones() ([]common.AvailabilityZone, error) {
+	if !env.raw.ClusterSupported() {
+		return nil, nil
+	}
+	members, err := env.raw.ClusterMembers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	zones := make([]common.AvailabilityZone, len(members))
+	for i, member := range members {
+		zones[i] = &lxdAvailabilityZone{member}
+	}
+	return zones, nil
+}
+
+// InstanceAvailabilityZoneNames is part of the common.ZonedEnviron interface.
+func (env *environ) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	instances, err := env.Instances(ids)
+	switch err {
+	case nil, environs.ErrPartialInstances:
+		break
+	case environs.ErrNoInstances:
+		return nil, err
+	default:
+		return nil, errors.Trace(err)
+	}
+
+	results := make([]string, len(ids))
+	if !env.raw.ClusterSupported() {
+		return results, err
+	}
+	locations, lerr := env.raw.InstanceLocations(env.namespace.Prefix())
+	if lerr != nil {
+		return nil, errors.Trace(lerr)
+	}
+	for i, inst := range instances {
+		if inst == nil {
+			continue
+		}
+		results[i] = locations[string(inst.Id())]
+	}
+	return results, err
+}
+
+// DeriveAvailabilityZone is part of the common.ZonedEnviron interface.
+func (env *environ) DeriveAvailabilityZone(args environs.StartInstanceParams) (string, error) {
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if placement.zone != nil {
+		return placement.zone.Name(), nil
+	}
+	return "", nil
+}
+
+// availZone returns the available availability zone with
+// the given name.
+func (env *environ) availZone(name string) (*lxdAvailabilityZone, error) {
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, z := range zones {
+		if z.Name() != name {
+			continue
+		}
+		if !z.Available() {
+			return nil, errors.Errorf("availability zone %q is unavailable", name)
+		}
+		return z.(*lxdAvailabilityZone), nil
+	}
+	return nil, errors.NotValidf("availability zone %q", name)
+}
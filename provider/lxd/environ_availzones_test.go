@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type environAvailzonesSuite struct {
+	lxd.BaseSuite
+}
+
+var _ = gc.Suite(&environAvailzonesSuite{})
+
+func (s *environAvailzonesSuite) TestAvailabilityZonesStandalone(c *gc.C) {
+	zones, err := s.Env.AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 0)
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *environAvailzonesSuite) TestAvailabilityZonesCluster(c *gc.C) {
+	s.SetUpCluster("node1", "node2")
+	s.Client.Members[1].Status = "Offline"
+
+	zones, err := s.Env.AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 2)
+	c.Assert(zones[0].Name(), gc.Equals, "node1")
+	c.Assert(zones[0].Available(), jc.IsTrue)
+	c.Assert(zones[1].Name(), gc.Equals, "node2")
+	c.Assert(zones[1].Available(), jc.IsFalse)
+	s.Stub.CheckCallNames(c, "ClusterMembers")
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNamesStandalone(c *gc.C) {
+	s.Client.Insts = []lxdclient.Instance{*s.NewRawInstance(c, "spam")}
+
+	zones, err := s.Env.InstanceAvailabilityZoneNames([]instance.Id{"spam"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, jc.DeepEquals, []string{""})
+	s.Stub.CheckCallNames(c, "Instances")
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNamesCluster(c *gc.C) {
+	s.SetUpCluster("node1", "node2")
+	s.Client.Insts = []lxdclient.Instance{
+		*s.NewRawInstance(c, "spam"),
+		*s.NewRawInstance(c, "eggs"),
+	}
+	s.Client.Locations = map[string]string{
+		"spam": "node2",
+		"eggs": "node1",
+	}
+
+	zones, err := s.Env.InstanceAvailabilityZoneNames([]instance.Id{"eggs", "ham", "spam"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(zones, jc.DeepEquals, []string{"node1", "", "node2"})
+	s.Stub.CheckCallNames(c, "Instances", "InstanceLocations")
+	s.Stub.CheckCall(c, 1, "InstanceLocations", s.Prefix())
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNamesNoInstances(c *gc.C) {
+	s.SetUpCluster("node1")
+
+	_, err := s.Env.InstanceAvailabilityZoneNames([]instance.Id{"spam"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZone(c *gc.C) {
+	s.SetUpCluster("node1", "node2")
+
+	zone, err := s.Env.DeriveAvailabilityZone(environs.StartInstanceParams{
+		Placement: "zone=node2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zone, gc.Equals, "node2")
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZoneNoPlacement(c *gc.C) {
+	s.SetUpCluster("node1", "node2")
+
+	zone, err := s.Env.DeriveAvailabilityZone(environs.StartInstanceParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zone, gc.Equals, "")
+	s.Stub.CheckNoCalls(c)
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZoneUnknown(c *gc.C) {
+	s.SetUpCluster("node1", "node2")
+
+	_, err := s.Env.DeriveAvailabilityZone(environs.StartInstanceParams{
+		Placement: "zone=node3",
+	})
+	c.Assert(err, gc.ErrorMatches, `availability zone "node3" not valid`)
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/juju/juju/tools/lxdclient"
 )
 
+const megabyte = 1024 * 1024
+
 // MaintainInstance is specified in the InstanceBroker interface.
 func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
 	return nil
@@ -41,7 +43,15 @@ func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.
 
 	// TODO(ericsnow) Handle constraints?
 
-	raw, err := env.newRawInstance(args, arch)
+	target, err := env.startInstanceTarget(args)
+	if err != nil {
+		if args.StatusCallback != nil {
+			args.StatusCallback(status.ProvisioningError, err.Error(), nil)
+		}
+		return nil, errors.Trace(err)
+	}
+
+	raw, err := env.newRawInstance(args, arch, target)
 	if err != nil {
 		if args.StatusCallback != nil {
 			args.StatusCallback(status.ProvisioningError, err.Error(), nil)
@@ -117,12 +127,91 @@ func (env *environ) getImageSources() ([]lxdclient.Remote, error) {
 	return remotes, nil
 }
 
+// startInstanceTarget returns the name of the cluster member on which
+// to start an instance, or "" if the LXD server is not clustered. If a
+// zone was specified then its member is used, so long as it has enough
+// free memory for the instance; otherwise the available member with the
+// most free memory is chosen.
+func (env *environ) startInstanceTarget(args environs.StartInstanceParams) (string, error) {
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	zone := placement.zone
+	if zone == nil && args.AvailabilityZone != "" {
+		if zone, err = env.availZone(args.AvailabilityZone); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	if !env.raw.ClusterSupported() {
+		return "", nil
+	}
+
+	var memRequired uint64
+	if args.Constraints.Mem != nil {
+		memRequired = *args.Constraints.Mem * megabyte
+	}
+	if zone != nil {
+		memFree, err := env.memberMemoryFree(zone.Name())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if memFree < memRequired {
+			logger.Warningf(
+				"cluster member %q has %dMiB of memory free, %dMiB required",
+				zone.Name(), memFree/megabyte, memRequired/megabyte,
+			)
+			return "", errors.Wrap(
+				errors.Errorf("insufficient memory on cluster member %q", zone.Name()),
+				environs.ErrAvailabilityZoneFailed,
+			)
+		}
+		return zone.Name(), nil
+	}
+
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var target string
+	var targetMemFree uint64
+	for _, z := range zones {
+		if !z.Available() {
+			continue
+		}
+		memFree, err := env.memberMemoryFree(z.Name())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if memFree < memRequired || (target != "" && memFree <= targetMemFree) {
+			continue
+		}
+		target, targetMemFree = z.Name(), memFree
+	}
+	if target == "" {
+		return "", errors.Errorf("no cluster member has %dMiB of memory free", memRequired/megabyte)
+	}
+	return target, nil
+}
+
+// memberMemoryFree returns the amount of unused memory on the
+// named cluster member, in bytes.
+func (env *environ) memberMemoryFree(name string) (uint64, error) {
+	resources, err := env.raw.ClusterMemberResources(name)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return resources.MemoryFree(), nil
+}
+
 // newRawInstance is where the new physical instance is actually
 // provisioned, relative to the provided args and spec. Info for that
-// low-level instance is returned.
+// low-level instance is returned. If target is non-empty, the instance
+// is created on the cluster member with that name.
 func (env *environ) newRawInstance(
 	args environs.StartInstanceParams,
 	arch string,
+	target string,
 ) (*lxdclient.Instance, error) {
 	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
 	if err != nil {
@@ -181,6 +270,11 @@ func (env *environ) newRawInstance(
 		return nil, errors.Trace(err)
 	}
 
+	charmProfiles, err := env.ensureCharmProfiles(args.CharmLXDProfiles)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create charm LXD profiles")
+	}
+
 	// TODO(ericsnow) Use the env ID for the network name (instead of default)?
 	// TODO(ericsnow) Make the network name configurable?
 	// TODO(ericsnow) Support multiple networks?
@@ -192,18 +286,23 @@ func (env *environ) newRawInstance(
 		//Disks:             getDisks(spec, args.Constraints),
 		//NetworkInterfaces: []string{"ExternalNAT"},
 		Metadata: metadata,
-		Profiles: []string{
+		Profiles: append([]string{
 			//TODO(wwitzel3) allow the user to specify lxc profiles to apply. This allows the
 			// user to setup any custom devices order config settings for their environment.
 			// Also we must ensure that a device with the parent: lxcbr0 exists in at least
 			// one of the profiles.
 			"default",
 			env.profileName(),
-		},
+		}, charmProfiles...),
 		// Network is omitted (left empty).
+		Target: target,
 	}
 
-	logger.Infof("starting instance %q (image %q)...", instSpec.Name, instSpec.Image)
+	if target != "" {
+		logger.Infof("starting instance %q (image %q) on cluster member %q...", instSpec.Name, instSpec.Image, target)
+	} else {
+		logger.Infof("starting instance %q (image %q)...", instSpec.Name, instSpec.Image)
+	}
 
 	statusCallback(status.Allocating, "preparing image")
 	inst, err := env.raw.AddInstance(instSpec)
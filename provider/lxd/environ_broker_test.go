@@ -6,12 +6,17 @@
 package lxd_test
 
 import (
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/provider/lxd"
+	"github.com/juju/juju/tools/lxdclient"
 )
 
 type environBrokerSuite struct {
@@ -40,6 +45,29 @@ func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
 	s.Stub.CheckCall(c, 0, "EnsureImageExists", "trusty", "arm64")
 }
 
+func (s *environBrokerSuite) TestStartInstanceWithCharmProfiles(c *gc.C) {
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	s.StartInstArgs.CharmLXDProfiles = map[string]*lxdprofile.Profile{
+		"juju-foo-mysql-2": {
+			Config: map[string]string{"security.nesting": "true"},
+			Devices: map[string]map[string]string{
+				"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+			},
+		},
+	}
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "EnsureImageExists", "HasProfile", "CreateProfile", "ProfileDeviceAdd", "AddInstance")
+	s.Stub.CheckCall(c, 2, "CreateProfile", "juju-foo-mysql-2", map[string]string{"security.nesting": "true"})
+	s.Stub.CheckCall(c, 3, "ProfileDeviceAdd", "juju-foo-mysql-2", "kvm", "unix-char", []string{"path=/dev/kvm"})
+	spec := s.Stub.Calls()[4].Args[0].(lxdclient.InstanceSpec)
+	c.Assert(spec.Profiles, gc.HasLen, 3)
+	c.Assert(spec.Profiles[2], gc.Equals, "juju-foo-mysql-2")
+}
+
 func (s *environBrokerSuite) TestStartInstanceNoTools(c *gc.C) {
 	s.Client.Inst = s.RawInstance
 
@@ -50,6 +78,74 @@ func (s *environBrokerSuite) TestStartInstanceNoTools(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "no matching agent binaries available")
 }
 
+func (s *environBrokerSuite) TestStartInstanceClusterZone(c *gc.C) {
+	s.SetUpCluster("node1", "node2")
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	s.StartInstArgs.Placement = "zone=node2"
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "ClusterMembers", "ClusterMemberResources", "EnsureImageExists", "AddInstance")
+	s.Stub.CheckCall(c, 1, "ClusterMemberResources", "node2")
+	spec := s.Stub.Calls()[3].Args[0].(lxdclient.InstanceSpec)
+	c.Assert(spec.Target, gc.Equals, "node2")
+}
+
+func (s *environBrokerSuite) TestStartInstanceClusterZoneInsufficientMemory(c *gc.C) {
+	s.SetUpCluster("node1", "node2")
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	s.StartInstArgs.Placement = "zone=node2"
+	s.StartInstArgs.Constraints = constraints.MustParse("mem=8G")
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(errors.Cause(err), gc.Equals, environs.ErrAvailabilityZoneFailed)
+
+	s.Stub.CheckCallNames(c, "ClusterMembers", "ClusterMemberResources")
+}
+
+func (s *environBrokerSuite) TestStartInstanceClusterNoZone(c *gc.C) {
+	s.SetUpCluster("node1", "node2", "node3")
+	s.Client.MemberResources["node2"].MemoryUsed = 0
+	s.Client.Members[2].Status = "Offline"
+	s.Client.MemberResources["node3"].MemoryUsed = 0
+	s.Client.Inst = s.RawInstance
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// node2 is the available member with the most free memory.
+	s.Stub.CheckCallNames(c,
+		"ClusterMembers",
+		"ClusterMemberResources",
+		"ClusterMemberResources",
+		"EnsureImageExists",
+		"AddInstance",
+	)
+	spec := s.Stub.Calls()[4].Args[0].(lxdclient.InstanceSpec)
+	c.Assert(spec.Target, gc.Equals, "node2")
+}
+
+func (s *environBrokerSuite) TestStartInstanceClusterNoZoneInsufficientMemory(c *gc.C) {
+	s.SetUpCluster("node1", "node2")
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	s.StartInstArgs.Constraints = constraints.MustParse("mem=8G")
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, gc.ErrorMatches, "no cluster member has 8192MiB of memory free")
+}
+
+func (s *environBrokerSuite) TestStartInstanceUnknownZone(c *gc.C) {
+	s.PatchValue(&arch.HostArch, func() string { return arch.ARM64 })
+
+	s.StartInstArgs.Placement = "zone=node2"
+	_, err := s.Env.StartInstance(s.StartInstArgs)
+	c.Assert(err, gc.ErrorMatches, `availability zone "node2" not valid`)
+	s.Stub.CheckNoCalls(c)
+}
+
 func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
 	err := s.Env.StopInstances(s.Instance.Id())
 	c.Assert(err, jc.ErrorIsNil)
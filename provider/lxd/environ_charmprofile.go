@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+)
+
+var _ environs.CharmProfileManager = (*environ)(nil)
+
+// ReplaceCharmProfile is part of the environs.CharmProfileManager
+// interface.
+func (env *environ) ReplaceCharmProfile(id instance.Id, oldName, newName string, newProfile *lxdprofile.Profile) error {
+	if newName != "" {
+		if err := env.ensureCharmProfile(newName, newProfile); err != nil {
+			return errors.Annotatef(err, "cannot create LXD profile %q", newName)
+		}
+	}
+	err := env.raw.ReplaceProfile(string(id), oldName, newName)
+	return errors.Annotatef(err, "cannot apply LXD profile to %q", id)
+}
+
+// ensureCharmProfiles creates any of the given charm profiles that do
+// not already exist, and returns their names in sorted order.
+func (env *environ) ensureCharmProfiles(profiles map[string]*lxdprofile.Profile) ([]string, error) {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := env.ensureCharmProfile(name, profiles[name]); err != nil {
+			return nil, errors.Annotatef(err, "cannot create LXD profile %q", name)
+		}
+	}
+	return names, nil
+}
+
+// ensureCharmProfile creates the named profile with the config and
+// devices of the given charm profile, unless it already exists. Charm
+// profiles are named by charm revision, so an existing profile need
+// not be updated.
+func (env *environ) ensureCharmProfile(name string, profile *lxdprofile.Profile) error {
+	hasProfile, err := env.raw.HasProfile(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if hasProfile {
+		return nil
+	}
+	if profile == nil {
+		profile = &lxdprofile.Profile{}
+	}
+	if err := profile.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := env.raw.CreateProfile(name, profile.Config); err != nil {
+		return errors.Trace(err)
+	}
+	deviceNames := make([]string, 0, len(profile.Devices))
+	for deviceName := range profile.Devices {
+		deviceNames = append(deviceNames, deviceName)
+	}
+	sort.Strings(deviceNames)
+	for _, deviceName := range deviceNames {
+		device := profile.Devices[deviceName]
+		var props []string
+		for key, value := range device {
+			if key != "type" {
+				props = append(props, key+"="+value)
+			}
+		}
+		sort.Strings(props)
+		if _, err := env.raw.ProfileDeviceAdd(name, deviceName, device["type"], props); err != nil {
+			return errors.Annotatef(err, "cannot add device %q", deviceName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/lxd"
+)
+
+type charmProfileSuite struct {
+	lxd.BaseSuite
+}
+
+var _ = gc.Suite(&charmProfileSuite{})
+
+func (s *charmProfileSuite) TestReplaceCharmProfile(c *gc.C) {
+	profile := &lxdprofile.Profile{
+		Devices: map[string]map[string]string{
+			"hugepages": {"type": "disk", "source": "/dev/hugepages", "path": "/dev/hugepages"},
+		},
+	}
+	var manager environs.CharmProfileManager = s.Env
+	err := manager.ReplaceCharmProfile("spam", "juju-foo-mysql-1", "juju-foo-mysql-2", profile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "HasProfile", "CreateProfile", "ProfileDeviceAdd", "ReplaceProfile")
+	s.Stub.CheckCall(c, 2, "ProfileDeviceAdd", "juju-foo-mysql-2", "hugepages", "disk",
+		[]string{"path=/dev/hugepages", "source=/dev/hugepages"})
+	s.Stub.CheckCall(c, 3, "ReplaceProfile", "spam", "juju-foo-mysql-1", "juju-foo-mysql-2")
+}
+
+func (s *charmProfileSuite) TestReplaceCharmProfileRemoveOnly(c *gc.C) {
+	var manager environs.CharmProfileManager = s.Env
+	err := manager.ReplaceCharmProfile("spam", "juju-foo-mysql-1", "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCallNames(c, "ReplaceProfile")
+	s.Stub.CheckCall(c, 0, "ReplaceProfile", "spam", "juju-foo-mysql-1", "")
+}
+
+func (s *charmProfileSuite) TestReplaceCharmProfileError(c *gc.C) {
+	s.Stub.SetErrors(nil, errors.New("boom"))
+	var manager environs.CharmProfileManager = s.Env
+	err := manager.ReplaceCharmProfile("spam", "", "juju-foo-mysql-2", &lxdprofile.Profile{})
+	c.Assert(err, gc.ErrorMatches, `cannot create LXD profile "juju-foo-mysql-2": boom`)
+}
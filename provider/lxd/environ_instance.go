@@ -6,6 +6,8 @@
 package lxd
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
 
@@ -115,13 +117,30 @@ func (env *environ) ControllerInstances(controllerUUID string) ([]instance.Id, e
 	return results, nil
 }
 
-type instPlacement struct{}
+type instPlacement struct {
+	// zone is the availability zone, i.e. the cluster member,
+	// named by a "zone" placement directive, if any.
+	zone *lxdAvailabilityZone
+}
 
 func (env *environ) parsePlacement(placement string) (*instPlacement, error) {
 	if placement == "" {
 		return &instPlacement{}, nil
 	}
 
+	pos := strings.IndexRune(placement, '=')
+	if pos == -1 {
+		return nil, errors.Errorf("unknown placement directive: %v", placement)
+	}
+
+	switch key, value := placement[:pos], placement[pos+1:]; key {
+	case "zone":
+		zone, err := env.availZone(value)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &instPlacement{zone: zone}, nil
+	}
 	return nil, errors.Errorf("unknown placement directive: %v", placement)
 }
 
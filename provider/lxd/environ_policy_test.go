@@ -58,7 +58,31 @@ func (s *environPolSuite) TestPrecheckInstanceAvailZone(c *gc.C) {
 	placement := "zone=a-zone"
 	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Placement: placement})
 
-	c.Check(err, gc.ErrorMatches, `unknown placement directive: .*`)
+	c.Check(err, gc.ErrorMatches, `availability zone "a-zone" not valid`)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceAvailZoneCluster(c *gc.C) {
+	s.SetUpCluster("node1", "node2")
+	placement := "zone=node2"
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Placement: placement})
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceAvailZoneUnavailable(c *gc.C) {
+	s.SetUpCluster("node1", "node2")
+	s.Client.Members[1].Status = "Offline"
+	placement := "zone=node2"
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Placement: placement})
+
+	c.Check(err, gc.ErrorMatches, `availability zone "node2" is unavailable`)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceUnknownPlacement(c *gc.C) {
+	placement := "node2"
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{Series: series.LatestLts(), Placement: placement})
+
+	c.Check(err, gc.ErrorMatches, `unknown placement directive: node2`)
 }
 
 func (s *environPolSuite) TestConstraintsValidatorOkay(c *gc.C) {
@@ -21,6 +21,7 @@ type rawProvider struct {
 	lxdProfiles
 	lxdImages
 	lxdStorage
+	lxdCluster
 
 	remote lxdclient.Remote
 }
@@ -45,12 +46,14 @@ type lxdInstances interface {
 	Addresses(string) ([]network.Address, error)
 	AttachDisk(string, string, lxdclient.DiskDevice) error
 	RemoveDevice(string, string) error
+	ReplaceProfile(string, string, string) error
 }
 
 type lxdProfiles interface {
 	DefaultProfileBridgeName() string
 	CreateProfile(string, map[string]string) error
 	HasProfile(string) (bool, error)
+	ProfileDeviceAdd(string, string, string, []string) (*lxdapi.Response, error)
 }
 
 type lxdImages interface {
@@ -71,6 +74,13 @@ type lxdStorage interface {
 	VolumeList(pool string) ([]lxdapi.StorageVolume, error)
 }
 
+type lxdCluster interface {
+	ClusterSupported() bool
+	ClusterMembers() ([]lxdclient.ClusterMember, error)
+	ClusterMemberResources(name string) (*lxdclient.ClusterMemberResources, error)
+	InstanceLocations(prefix string) (map[string]string, error)
+}
+
 func newRawProvider(spec environs.CloudSpec, local bool) (*rawProvider, error) {
 	if local {
 		return newLocalRawProvider()
@@ -103,6 +113,7 @@ func newRawProviderFromConfig(config lxdclient.Config) (*rawProvider, error) {
 		lxdProfiles:  client,
 		lxdImages:    client,
 		lxdStorage:   client,
+		lxdCluster:   client,
 		remote:       config.Remote,
 	}, nil
 }
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/testing"
 	coretools "github.com/juju/juju/tools"
 	"github.com/juju/juju/tools/lxdclient"
@@ -85,8 +86,9 @@ var (
 
 // We test these here since they are not exported.
 var (
-	_ environs.Environ  = (*environ)(nil)
-	_ instance.Instance = (*environInstance)(nil)
+	_ environs.Environ    = (*environ)(nil)
+	_ common.ZonedEnviron = (*environ)(nil)
+	_ instance.Instance   = (*environInstance)(nil)
 )
 
 type BaseSuiteUnpatched struct {
@@ -348,6 +350,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 		lxdProfiles:  s.Client,
 		lxdImages:    s.Client,
 		lxdStorage:   s.Client,
+		lxdCluster:   s.Client,
 		remote: lxdclient.Remote{
 			Cert: &lxdclient.Cert{
 				Name:    "juju",
@@ -473,6 +476,10 @@ type StubClient struct {
 	Server             *api.Server
 	StorageIsSupported bool
 	Volumes            map[string][]api.StorageVolume
+	ClusterIsSupported bool
+	Members            []lxdclient.ClusterMember
+	MemberResources    map[string]*lxdclient.ClusterMemberResources
+	Locations          map[string]string
 }
 
 func (conn *StubClient) Instances(prefix string, statuses ...string) ([]lxdclient.Instance, error) {
@@ -584,6 +591,11 @@ func (conn *StubClient) HasProfile(name string) (bool, error) {
 	return false, conn.NextErr()
 }
 
+func (conn *StubClient) ProfileDeviceAdd(profile, devname, devtype string, props []string) (*api.Response, error) {
+	conn.AddCall("ProfileDeviceAdd", profile, devname, devtype, props)
+	return &api.Response{}, conn.NextErr()
+}
+
 func (conn *StubClient) AttachDisk(container, device string, disk lxdclient.DiskDevice) error {
 	conn.AddCall("AttachDisk", container, device, disk)
 	return conn.NextErr()
@@ -594,6 +606,11 @@ func (conn *StubClient) RemoveDevice(container, device string) error {
 	return conn.NextErr()
 }
 
+func (conn *StubClient) ReplaceProfile(container, oldProfile, newProfile string) error {
+	conn.AddCall("ReplaceProfile", container, oldProfile, newProfile)
+	return conn.NextErr()
+}
+
 func (conn *StubClient) StorageSupported() bool {
 	conn.AddCall("StorageSupported")
 	return conn.StorageIsSupported
@@ -658,3 +675,56 @@ func (conn *StubClient) VolumeUpdate(pool, volume string, update api.StorageVolu
 	conn.AddCall("VolumeUpdate", pool, volume, update)
 	return conn.NextErr()
 }
+
+func (conn *StubClient) ClusterSupported() bool {
+	// This is not recorded as a call, as it does not
+	// make a request to the LXD server.
+	return conn.ClusterIsSupported
+}
+
+func (conn *StubClient) ClusterMembers() ([]lxdclient.ClusterMember, error) {
+	conn.AddCall("ClusterMembers")
+	if err := conn.NextErr(); err != nil {
+		return nil, err
+	}
+	return conn.Members, nil
+}
+
+func (conn *StubClient) ClusterMemberResources(name string) (*lxdclient.ClusterMemberResources, error) {
+	conn.AddCall("ClusterMemberResources", name)
+	if err := conn.NextErr(); err != nil {
+		return nil, err
+	}
+	resources, ok := conn.MemberResources[name]
+	if !ok {
+		return nil, errors.NotFoundf("cluster member %q", name)
+	}
+	return resources, nil
+}
+
+func (conn *StubClient) InstanceLocations(prefix string) (map[string]string, error) {
+	conn.AddCall("InstanceLocations", prefix)
+	if err := conn.NextErr(); err != nil {
+		return nil, err
+	}
+	return conn.Locations, nil
+}
+
+// SetUpCluster makes the stub client report a cluster with the
+// given members, all online and each with 4GiB of memory free.
+func (s *BaseSuite) SetUpCluster(members ...string) {
+	s.Client.ClusterIsSupported = true
+	s.Client.Members = nil
+	s.Client.MemberResources = make(map[string]*lxdclient.ClusterMemberResources)
+	for _, name := range members {
+		s.Client.Members = append(s.Client.Members, lxdclient.ClusterMember{
+			Name:   name,
+			Status: lxdclient.ClusterMemberOnline,
+		})
+		s.Client.MemberResources[name] = &lxdclient.ClusterMemberResources{
+			CPUThreads:  4,
+			MemoryTotal: 8 * 1024 * 1024 * 1024,
+			MemoryUsed:  4 * 1024 * 1024 * 1024,
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+)
+
+// DialFunc is a function type for dialing OCI client connections.
+type DialFunc func(context.Context, ociclient.Config) (Client, error)
+
+// Client is an interface for interacting with the OCI APIs.
+type Client interface {
+	Tenancy() string
+	AttachVolume(_ context.Context, instanceId, volumeId, device string) (*ociclient.VolumeAttachment, error)
+	AvailabilityDomains(_ context.Context, compartmentId string) ([]*ociclient.AvailabilityDomain, error)
+	CreateVolume(context.Context, ociclient.CreateVolumeParams) (*ociclient.Volume, error)
+	DeleteVolume(_ context.Context, id string) error
+	DetachVolume(_ context.Context, attachmentId string) error
+	Images(_ context.Context, compartmentId, operatingSystem, version string) ([]*ociclient.Image, error)
+	InstanceDevices(_ context.Context, instanceId string) ([]*ociclient.Device, error)
+	Instances(_ context.Context, compartmentId string) ([]*ociclient.Instance, error)
+	LaunchInstance(context.Context, ociclient.LaunchInstanceParams) (*ociclient.Instance, error)
+	Shapes(_ context.Context, compartmentId string) ([]*ociclient.Shape, error)
+	Subnet(_ context.Context, id string) (*ociclient.Subnet, error)
+	Subnets(_ context.Context, compartmentId, vcnId string) ([]*ociclient.Subnet, error)
+	TerminateInstance(_ context.Context, id string) error
+	UpdateInstanceTags(_ context.Context, id string, tags map[string]string) error
+	UpdateVolumeTags(_ context.Context, id string, tags map[string]string) error
+	Vcns(_ context.Context, compartmentId string) ([]*ociclient.Vcn, error)
+	Vnic(_ context.Context, id string) (*ociclient.Vnic, error)
+	VnicAttachments(_ context.Context, compartmentId, instanceId string) ([]*ociclient.VnicAttachment, error)
+	VolumeAttachments(_ context.Context, compartmentId, instanceId string) ([]*ociclient.VolumeAttachment, error)
+	Volumes(_ context.Context, compartmentId string) ([]*ociclient.Volume, error)
+}
+
+func dialClient(
+	ctx context.Context,
+	cloudSpec environs.CloudSpec,
+	dial DialFunc,
+) (Client, error) {
+	endpoint, err := apiEndpoint(cloudSpec.Endpoint, cloudSpec.Region)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	config := ociclient.Config{Endpoint: endpoint}
+	switch cloudSpec.Credential.AuthType() {
+	case cloud.InstancePrincipalAuthType:
+		config.InstancePrincipal = true
+	default:
+		credAttrs := cloudSpec.Credential.Attributes()
+		config.Tenancy = credAttrs[credAttrTenancy]
+		config.User = credAttrs[credAttrUser]
+		config.Fingerprint = credAttrs[credAttrFingerprint]
+		config.PrivateKey = credAttrs[credAttrKey]
+		config.PassPhrase = credAttrs[credAttrPassPhrase]
+	}
+	return dial(ctx, config)
+}
+
+// apiEndpoint returns the URL of the core services API for the given
+// endpoint, which may be a host name or URL. If the endpoint is empty,
+// the well-known endpoint of the named region is used.
+func apiEndpoint(endpoint, region string) (string, error) {
+	if endpoint == "" {
+		if region == "" {
+			return "", errors.New("missing endpoint and region")
+		}
+		return "https://iaas." + region + ".oraclecloud.com", nil
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", errors.Annotate(err, "parsing endpoint")
+	}
+	if u.Scheme != "https" {
+		return "", errors.Errorf("invalid endpoint %q: expected an https URL", endpoint)
+	}
+	if u.Host == "" {
+		return "", errors.Errorf("invalid endpoint %q: missing host", endpoint)
+	}
+	return u.String(), nil
+}
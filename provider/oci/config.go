@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/common"
+)
+
+// The OCI-specific config keys.
+const (
+	// cfgCompartmentId is the OCID of the compartment in which
+	// instances, volumes and networks are created and found. If
+	// unspecified, the tenancy's root compartment is used.
+	cfgCompartmentId = "compartment-id"
+
+	// cfgSubnetId is the OCID of the subnet to which instances
+	// are connected, when there are no space constraints. If
+	// unspecified, a subnet in the instance's availability domain
+	// is chosen.
+	cfgSubnetId = "subnet-id"
+)
+
+// configFields is the spec for each OCI config value's type.
+var (
+	configFields = schema.Fields{
+		cfgCompartmentId: schema.String(),
+		cfgSubnetId:      schema.String(),
+	}
+
+	configDefaults = schema.Defaults{
+		cfgCompartmentId: schema.Omit,
+		cfgSubnetId:      schema.Omit,
+	}
+
+	configImmutableFields = []string{
+		cfgCompartmentId,
+	}
+)
+
+type environConfig struct {
+	*config.Config
+	attrs map[string]interface{}
+}
+
+// newValidConfig validates cfg, as a change to old if old is
+// non-nil, and returns it as an environConfig. The compartment may
+// not be changed once the model exists, as the model's instances,
+// volumes and subnets are only looked for in that compartment.
+func newValidConfig(cfg, old *config.Config) (*environConfig, error) {
+	valid, err := common.ValidateConfig(cfg, old, configFields, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ecfg := &environConfig{
+		Config: valid,
+		attrs:  valid.UnknownAttrs(),
+	}
+	if old != nil {
+		oldAttrs := old.UnknownAttrs()
+		for _, field := range configImmutableFields {
+			if ecfg.attrs[field] != oldAttrs[field] {
+				return nil, errors.Errorf(
+					"%s: cannot change from %v to %v",
+					field, oldAttrs[field], ecfg.attrs[field],
+				)
+			}
+		}
+	}
+	return ecfg, nil
+}
+
+func (c *environConfig) compartmentId() string {
+	id, _ := c.attrs[cfgCompartmentId].(string)
+	return id
+}
+
+func (c *environConfig) subnetId() string {
+	id, _ := c.attrs[cfgSubnetId].(string)
+	return id
+}
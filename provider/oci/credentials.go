@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/ini.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+const (
+	credAttrUser        = "user"
+	credAttrTenancy     = "tenancy"
+	credAttrFingerprint = "fingerprint"
+	credAttrKey         = "key"
+	credAttrKeyFile     = "key-file"
+	credAttrPassPhrase  = "pass-phrase"
+)
+
+type environProviderCredentials struct{}
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.HTTPSigAuthType: {
+			{
+				credAttrUser, cloud.CredentialAttr{
+					Description: "The OCID of the user to authenticate as.",
+				},
+			}, {
+				credAttrTenancy, cloud.CredentialAttr{
+					Description: "The OCID of the tenancy the user belongs to.",
+				},
+			}, {
+				credAttrFingerprint, cloud.CredentialAttr{
+					Description: "The fingerprint of the user's API signing key.",
+				},
+			}, {
+				credAttrKey, cloud.CredentialAttr{
+					Description: "The PEM-encoded private part of the user's API signing key.",
+					Hidden:      true,
+					FileAttr:    credAttrKeyFile,
+				},
+			}, {
+				credAttrPassPhrase, cloud.CredentialAttr{
+					Description: "The pass phrase with which the API signing key is encrypted, if any.",
+					Hidden:      true,
+					Optional:    true,
+				},
+			},
+		},
+		// Instance principals authenticate as the instance that
+		// Juju is running on, so there are no attributes.
+		cloud.InstancePrincipalAuthType: {},
+	}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+// Credentials are read from the profiles in the OCI CLI's configuration
+// file, ~/.oci/config.
+func (e environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	configFile := filepath.Join(configDir(), "config")
+	configInfo, err := ini.LooseLoad(configFile)
+	if err != nil {
+		return nil, errors.Annotate(err, "loading OCI config file")
+	}
+
+	// Profiles inherit the values in the DEFAULT section.
+	defaults := configInfo.Section(ini.DEFAULT_SECTION)
+	value := func(section *ini.Section, key string) string {
+		if section.HasKey(key) {
+			return section.Key(key).String()
+		}
+		return defaults.Key(key).String()
+	}
+
+	result := cloud.CloudCredential{
+		AuthCredentials: make(map[string]cloud.Credential),
+	}
+	for _, section := range configInfo.Sections() {
+		profile := section.Name()
+		user := value(section, "user")
+		tenancy := value(section, "tenancy")
+		fingerprint := value(section, "fingerprint")
+		keyFile := value(section, "key_file")
+		if user == "" || tenancy == "" || fingerprint == "" || keyFile == "" {
+			if profile != ini.DEFAULT_SECTION {
+				logger.Errorf("missing OCI credential attributes in config file profile %q", profile)
+			}
+			continue
+		}
+		keyFile, err := utils.NormalizePath(keyFile)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		key, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			logger.Errorf("reading API signing key of config file profile %q: %v", profile, err)
+			continue
+		}
+		attrs := map[string]string{
+			credAttrUser:        user,
+			credAttrTenancy:     tenancy,
+			credAttrFingerprint: fingerprint,
+			credAttrKey:         string(key),
+		}
+		if passPhrase := value(section, "pass_phrase"); passPhrase != "" {
+			attrs[credAttrPassPhrase] = passPhrase
+		}
+		credential := cloud.NewCredential(cloud.HTTPSigAuthType, attrs)
+		credential.Label = fmt.Sprintf("oci credential %q", profile)
+		result.AuthCredentials[profile] = credential
+		if result.DefaultRegion == "" || profile == ini.DEFAULT_SECTION {
+			result.DefaultRegion = value(section, "region")
+		}
+	}
+	if len(result.AuthCredentials) == 0 {
+		return nil, errors.NotFoundf("credentials")
+	}
+	return &result, nil
+}
+
+func configDir() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("USERPROFILE"), ".oci")
+	}
+	return filepath.Join(utils.Home(), ".oci")
+}
+
+// FinalizeCredential is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
+	return &args.Credential, nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"context"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+type environ struct {
+	name     string
+	cloud    environs.CloudSpec
+	provider *environProvider
+
+	// namespace is used to create the machine and volume names.
+	namespace instance.Namespace
+
+	lock sync.Mutex // lock protects access the following fields.
+	ecfg *environConfig
+
+	// clientMu protects client.
+	clientMu sync.Mutex
+	// client is the client used to make OCI API requests. It is
+	// dialed on first use, as dialing authenticates the credential
+	// and, for instance principals, obtains a session token; the
+	// client is then reused for the life of the environ.
+	client Client
+}
+
+func newEnviron(
+	provider *environProvider,
+	cloud environs.CloudSpec,
+	cfg *config.Config,
+) (*environ, error) {
+	ecfg, err := newValidConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+
+	namespace, err := instance.NewNamespace(cfg.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	env := &environ{
+		name:      ecfg.Name(),
+		cloud:     cloud,
+		provider:  provider,
+		ecfg:      ecfg,
+		namespace: namespace,
+	}
+	return env, nil
+}
+
+func (env *environ) getClient(ctx context.Context) (Client, error) {
+	env.clientMu.Lock()
+	defer env.clientMu.Unlock()
+	if env.client == nil {
+		client, err := dialClient(ctx, env.cloud, env.provider.dial)
+		if err != nil {
+			return nil, errors.Annotate(err, "dialing client")
+		}
+		env.client = client
+	}
+	return env.client, nil
+}
+
+// Name is part of the environs.Environ interface.
+func (env *environ) Name() string {
+	return env.name
+}
+
+// Provider is part of the environs.Environ interface.
+func (env *environ) Provider() environs.EnvironProvider {
+	return env.provider
+}
+
+// SetConfig is part of the environs.Environ interface.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	if env.ecfg == nil {
+		return errors.New("cannot set config on uninitialized env")
+	}
+
+	ecfg, err := newValidConfig(cfg, env.ecfg.Config)
+	if err != nil {
+		return errors.Annotate(err, "invalid config change")
+	}
+	env.ecfg = ecfg
+	return nil
+}
+
+// Config is part of the environs.Environ interface.
+func (env *environ) Config() *config.Config {
+	env.lock.Lock()
+	cfg := env.ecfg.Config
+	env.lock.Unlock()
+	return cfg
+}
+
+func (env *environ) environConfig() *environConfig {
+	env.lock.Lock()
+	ecfg := env.ecfg
+	env.lock.Unlock()
+	return ecfg
+}
+
+// PrepareForBootstrap implements environs.Environ.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
+	return nil
+}
+
+// Create implements environs.Environ.
+func (env *environ) Create(args environs.CreateParams) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.Create(args)
+	})
+}
+
+// Create implements environs.Environ.
+func (env *sessionEnviron) Create(args environs.CreateParams) error {
+	// There is nothing to create up front, but make sure
+	// that the compartment exists and is accessible.
+	_, err := env.AvailabilityZones()
+	return errors.Trace(err)
+}
+
+// Bootstrap is part of the environs.Environ interface.
+func (env *environ) Bootstrap(
+	ctx environs.BootstrapContext,
+	args environs.BootstrapParams,
+) (result *environs.BootstrapResult, err error) {
+	// common.Bootstrap calls back into the Environ to finish the
+	// controller after this method returns, so give it the environ:
+	// each of those calls then resolves the compartment from the
+	// config in force at the time.
+	return common.Bootstrap(ctx, env, args)
+}
+
+// AdoptResources is part of the Environ interface.
+func (env *environ) AdoptResources(controllerUUID string, fromVersion version.Number) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.AdoptResources(controllerUUID, fromVersion)
+	})
+}
+
+// AdoptResources is part of the Environ interface.
+func (env *sessionEnviron) AdoptResources(controllerUUID string, fromVersion version.Number) error {
+	instances, err := env.modelInstances()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, inst := range instances {
+		if inst.FreeformTags[tags.JujuController] == controllerUUID {
+			continue
+		}
+		newTags := withControllerTag(inst.FreeformTags, controllerUUID)
+		if err := env.client.UpdateInstanceTags(env.ctx, inst.Id, newTags); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	volumes, err := env.modelVolumes()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, volume := range volumes {
+		if volume.FreeformTags[tags.JujuController] == controllerUUID {
+			continue
+		}
+		newTags := withControllerTag(volume.FreeformTags, controllerUUID)
+		if err := env.client.UpdateVolumeTags(env.ctx, volume.Id, newTags); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// withControllerTag returns a copy of the given resource tags, with
+// the controller tag set to the given controller UUID.
+func withControllerTag(resourceTags map[string]string, controllerUUID string) map[string]string {
+	result := make(map[string]string)
+	for k, v := range resourceTags {
+		result[k] = v
+	}
+	result[tags.JujuController] = controllerUUID
+	return result
+}
+
+// Destroy is part of the environs.Environ interface.
+func (env *environ) Destroy() error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.Destroy()
+	})
+}
+
+// Destroy is part of the environs.Environ interface.
+func (env *sessionEnviron) Destroy() error {
+	return errors.Trace(common.Destroy(env))
+}
+
+// DestroyController implements the Environ interface.
+func (env *environ) DestroyController(controllerUUID string) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.DestroyController(controllerUUID)
+	})
+}
+
+// DestroyController implements the Environ interface.
+func (env *sessionEnviron) DestroyController(controllerUUID string) error {
+	if err := env.Destroy(); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Remove the instances and volumes of any models
+	// that remain in the controller.
+	instances, err := env.client.Instances(env.ctx, env.compartmentId)
+	if err != nil {
+		return errors.Annotate(err, "listing instances")
+	}
+	for _, inst := range instances {
+		if inst.FreeformTags[tags.JujuController] != controllerUUID {
+			continue
+		}
+		if err := env.client.TerminateInstance(env.ctx, inst.Id); err != nil {
+			return errors.Annotate(err, "terminating instances")
+		}
+	}
+	volumes, err := env.client.Volumes(env.ctx, env.compartmentId)
+	if err != nil {
+		return errors.Annotate(err, "listing volumes")
+	}
+	for _, volume := range volumes {
+		if volume.FreeformTags[tags.JujuController] != controllerUUID {
+			continue
+		}
+		if err := env.client.DeleteVolume(env.ctx, volume.Id); err != nil {
+			return errors.Annotate(err, "deleting volumes")
+		}
+	}
+	return nil
+}
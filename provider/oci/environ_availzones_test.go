@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+)
+
+type environAvailzonesSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environAvailzonesSuite{})
+
+func (s *environAvailzonesSuite) TestAvailabilityZones(c *gc.C) {
+	c.Assert(s.env, gc.Implements, new(common.ZonedEnviron))
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zones, err := zonedEnviron.AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(zones), gc.Equals, 2)
+	c.Assert(zones[0].Name(), gc.Equals, "AD-1")
+	c.Assert(zones[1].Name(), gc.Equals, "AD-2")
+
+	s.client.CheckCallNames(c, "AvailabilityDomains")
+	c.Assert(s.client.Calls()[0].Args[1], gc.Equals, fakeCompartmentId)
+}
+
+func (s *environAvailzonesSuite) TestAvailabilityZonesTenancy(c *gc.C) {
+	// If no compartment is configured, the
+	// tenancy's root compartment is used.
+	cfg, err := fakeConfig(c).Remove([]string{"compartment-id"})
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.tenancy = fakeTenancy
+
+	_, err = env.(common.ZonedEnviron).AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "AvailabilityDomains")
+	c.Assert(s.client.Calls()[0].Args[1], gc.Equals, fakeTenancy)
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNames(c *gc.C) {
+	inst0 := newInstance("inst-0", ociclient.InstanceStateRunning)
+	inst0.AvailabilityDomain = "AD-2"
+	s.client.instances = []*ociclient.Instance{
+		inst0,
+		newInstance("inst-1", ociclient.InstanceStateRunning),
+	}
+	ids := []instance.Id{"inst-0", "inst-1", "inst-2"}
+
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zones, err := zonedEnviron.InstanceAvailabilityZoneNames(ids)
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(zones, jc.DeepEquals, []string{"AD-2", "AD-1", ""})
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNamesNoInstances(c *gc.C) {
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	_, err := zonedEnviron.InstanceAvailabilityZoneNames([]instance.Id{"inst-0"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZone(c *gc.C) {
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zone, err := zonedEnviron.DeriveAvailabilityZone(
+		environs.StartInstanceParams{Placement: "zone=AD-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zone, gc.Equals, "AD-2")
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZoneUnknown(c *gc.C) {
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zone, err := zonedEnviron.DeriveAvailabilityZone(
+		environs.StartInstanceParams{Placement: "zone=AD-3"})
+	c.Assert(err, gc.ErrorMatches, `availability zone "AD-3" not found`)
+	c.Assert(zone, gc.Equals, "")
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZoneInvalidPlacement(c *gc.C) {
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zone, err := zonedEnviron.DeriveAvailabilityZone(
+		environs.StartInstanceParams{Placement: "invalid-placement"})
+	c.Assert(err, gc.ErrorMatches, `unknown placement directive: invalid-placement`)
+	c.Assert(zone, gc.Equals, "")
+}
@@ -0,0 +1,321 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+	"github.com/juju/juju/status"
+)
+
+const (
+	// minBootVolumeSizeGB is the minimum size of an instance's
+	// boot volume, and the size used if no root-disk constraint
+	// is specified.
+	minBootVolumeSizeGB = 50
+)
+
+// MaintainInstance is specified in the InstanceBroker interface.
+func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *environ) StartInstance(args environs.StartInstanceParams) (result *environs.StartInstanceResult, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		result, err = env.StartInstance(args)
+		return err
+	})
+	return result, err
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *sessionEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	spec, err := env.findInstanceSpec(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := common.FinishInstanceConfig(args, spec.Image.Arch, env.Config()); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	inst, hw, err := env.newRawInstance(args, spec)
+	if err != nil {
+		args.StatusCallback(status.ProvisioningError, fmt.Sprint(err), nil)
+		return nil, errors.Trace(err)
+	}
+
+	logger.Infof("started instance %q", inst.DisplayName)
+	result := environs.StartInstanceResult{
+		Instance: newInstance(inst, env.environ),
+		Hardware: hw,
+	}
+	return &result, nil
+}
+
+// findInstanceSpec returns the shape and image with which to start
+// an instance, chosen from the compartment's shapes and the platform
+// images for the instance's series.
+func (env *sessionEnviron) findInstanceSpec(args environs.StartInstanceParams) (*instances.InstanceSpec, error) {
+	instanceTypes, err := env.instanceTypes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	images, err := env.seriesImages(args.InstanceConfig.Series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(images) > 1 {
+		// Images are listed newest first; use the latest.
+		images = images[:1]
+	}
+	spec, err := instances.FindInstanceSpec(images, &instances.InstanceConstraint{
+		Region:      env.cloud.Region,
+		Series:      args.InstanceConfig.Series,
+		Arches:      args.Tools.Arches(),
+		Constraints: args.Constraints,
+	}, instanceTypes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return spec, nil
+}
+
+// newRawInstance launches an instance of the spec's shape and image in
+// an availability domain chosen by the placement directive, attached
+// to a subnet in that domain. Cloud-init user data is passed in the
+// instance metadata. The instance is returned while it is still being
+// provisioned.
+func (env *sessionEnviron) newRawInstance(
+	args environs.StartInstanceParams,
+	spec *instances.InstanceSpec,
+) (*ociclient.Instance, *instance.HardwareCharacteristics, error) {
+
+	hostname, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, nil, OCIRenderer{})
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot make user data")
+	}
+	logger.Debugf("OCI user data; %d bytes", len(userData))
+
+	// A placement directive may name the availability domain;
+	// otherwise the region's first domain is used.
+	zoneName, err := env.parseAvailabilityZone(args)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	zone, err := env.startInstanceZone(zoneName)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	zoneName = zone.Name()
+
+	subnet, err := env.startInstanceSubnet(args, zoneName)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	cons := args.Constraints
+	bootVolumeSizeGB := uint64(minBootVolumeSizeGB)
+	if cons.RootDisk != nil {
+		if sizeGB := mibToGB(*cons.RootDisk); sizeGB > bootVolumeSizeGB {
+			bootVolumeSizeGB = sizeGB
+		}
+	}
+	assignPublicIp := !subnet.ProhibitPublicIpOnVnic
+	if cons.AllocatePublicIP != nil && !*cons.AllocatePublicIP {
+		assignPublicIp = false
+	}
+
+	launchArgs := ociclient.LaunchInstanceParams{
+		CompartmentId:       env.compartmentId,
+		AvailabilityDomain:  zoneName,
+		DisplayName:         hostname,
+		Shape:               spec.InstanceType.Name,
+		ImageId:             spec.Image.Id,
+		BootVolumeSizeInGBs: bootVolumeSizeGB,
+		SubnetId:            subnet.Id,
+		AssignPublicIp:      assignPublicIp,
+		SSHAuthorizedKeys:   args.InstanceConfig.AuthorizedKeys,
+		UserData:            string(userData),
+		FreeformTags:        args.InstanceConfig.Tags,
+	}
+
+	logger.Debugf("attempting to launch instance in availability domain %s", zoneName)
+	inst, err := env.client.LaunchInstance(env.ctx, launchArgs)
+	if err != nil {
+		logger.Warningf("failed to launch instance in availability domain %s: %s", zoneName, err)
+
+		return nil, nil, errors.Wrap(err, environs.ErrAvailabilityZoneFailed)
+	}
+
+	rootDisk := bootVolumeSizeGB * 1024
+	hw := &instance.HardwareCharacteristics{
+		Arch:             &spec.Image.Arch,
+		Mem:              &spec.InstanceType.Mem,
+		CpuCores:         &spec.InstanceType.CpuCores,
+		RootDisk:         &rootDisk,
+		AvailabilityZone: &zoneName,
+	}
+	return inst, hw, nil
+}
+
+// mibToGB converts a size in MiB to a size in GB, rounding up.
+func mibToGB(mib uint64) uint64 {
+	return (mib + 1023) / 1024
+}
+
+// startInstanceZone returns the zone in which to start an instance.
+// If no zone is specified, the first zone is used.
+func (env *sessionEnviron) startInstanceZone(name string) (*ociAvailZone, error) {
+	if name != "" {
+		zone, err := env.availZone(name)
+		if err != nil {
+			logger.Warningf("failed to get availability zone %s: %s", name, err)
+			return nil, errors.Wrap(err, environs.ErrAvailabilityZoneFailed)
+		}
+		return zone.(*ociAvailZone), nil
+	}
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(zones) == 0 {
+		return nil, errors.NotFoundf("availability domains in region %q", env.cloud.Region)
+	}
+	return zones[0].(*ociAvailZone), nil
+}
+
+// startInstanceSubnet returns the subnet to which to connect an
+// instance started in the named zone. A subnet required by the
+// instance's space constraints is preferred, followed by the
+// configured subnet, followed by the first subnet in the compartment
+// that is available in the zone.
+func (env *sessionEnviron) startInstanceSubnet(args environs.StartInstanceParams, zoneName string) (*ociclient.Subnet, error) {
+	if len(args.SubnetsToZones) > 0 {
+		var subnetIds []string
+		for id, zones := range args.SubnetsToZones {
+			for _, zone := range zones {
+				if zone == zoneName {
+					subnetIds = append(subnetIds, string(id))
+					break
+				}
+			}
+		}
+		if len(subnetIds) == 0 {
+			return nil, errors.Wrap(
+				errors.NotFoundf("subnets in availability domain %s matching constraints", zoneName),
+				environs.ErrAvailabilityZoneFailed,
+			)
+		}
+		sort.Strings(subnetIds)
+		return env.client.Subnet(env.ctx, subnetIds[0])
+	}
+
+	if subnetId := env.environConfig().subnetId(); subnetId != "" {
+		subnet, err := env.client.Subnet(env.ctx, subnetId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !subnetInZone(subnet, zoneName) {
+			return nil, errors.Wrap(
+				errors.Errorf("subnet %s is not in availability domain %s", subnetId, zoneName),
+				environs.ErrAvailabilityZoneFailed,
+			)
+		}
+		return subnet, nil
+	}
+
+	subnets, err := env.allSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, subnet := range subnets {
+		if subnetInZone(subnet, zoneName) {
+			return subnet, nil
+		}
+	}
+	return nil, errors.Wrap(
+		errors.NotFoundf("subnets in availability domain %s", zoneName),
+		environs.ErrAvailabilityZoneFailed,
+	)
+}
+
+// subnetInZone reports whether instances in the named zone may be
+// connected to the subnet. Regional subnets span all zones.
+func subnetInZone(subnet *ociclient.Subnet, zoneName string) bool {
+	return subnet.AvailabilityDomain == "" || subnet.AvailabilityDomain == zoneName
+}
+
+// modelInstances returns the instances in the model.
+func (env *sessionEnviron) modelInstances() ([]*ociclient.Instance, error) {
+	all, err := env.client.Instances(env.ctx, env.compartmentId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelUUID := env.Config().UUID()
+	var results []*ociclient.Instance
+	for _, inst := range all {
+		if inst.FreeformTags[tags.JujuModel] == modelUUID {
+			results = append(results, inst)
+		}
+	}
+	return results, nil
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *environ) AllInstances() (instances []instance.Instance, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		instances, err = env.AllInstances()
+		return err
+	})
+	return instances, err
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *sessionEnviron) AllInstances() ([]instance.Instance, error) {
+	insts, err := env.modelInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]instance.Instance, len(insts))
+	for i, inst := range insts {
+		results[i] = newInstance(inst, env.environ)
+	}
+	return results, nil
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *environ) StopInstances(ids ...instance.Id) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.StopInstances(ids...)
+	})
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *sessionEnviron) StopInstances(ids ...instance.Id) error {
+	return common.StopInstances(ids, func(id instance.Id) error {
+		// An instance that is already gone has been stopped.
+		err := env.client.TerminateInstance(env.ctx, string(id))
+		if ociclient.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+}
@@ -0,0 +1,297 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type environBrokerSuite struct {
+	EnvironFixture
+	statusCallbackStub testing.Stub
+}
+
+var _ = gc.Suite(&environBrokerSuite{})
+
+func (s *environBrokerSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	s.statusCallbackStub.ResetCalls()
+
+	s.client.shapes = []*ociclient.Shape{
+		{Shape: "VM.Standard2.2", OCPUs: 2, MemoryInGBs: 30},
+		{Shape: "VM.Standard2.1", OCPUs: 1, MemoryInGBs: 15},
+		{Shape: "VM.Standard.E3.Flex", IsFlexible: true},
+	}
+	s.client.images = []*ociclient.Image{
+		{Id: "image-gpu", DisplayName: "Canonical-Ubuntu-16.04-Gen2-GPU-2019.10.01-0"},
+		{Id: "image-new", DisplayName: "Canonical-Ubuntu-16.04-2019.10.01-0"},
+		{Id: "image-old", DisplayName: "Canonical-Ubuntu-16.04-2019.09.01-0"},
+	}
+	s.client.vcns = []*ociclient.Vcn{{Id: "vcn-0", DisplayName: "vcn"}}
+	s.client.subnets = map[string][]*ociclient.Subnet{
+		"vcn-0": {
+			{Id: "subnet-0", VcnId: "vcn-0", AvailabilityDomain: "AD-2"},
+			{Id: "subnet-1", VcnId: "vcn-0", AvailabilityDomain: "AD-1"},
+			{Id: "subnet-2", VcnId: "vcn-0"},
+		},
+	}
+	s.client.launchedInstance = newInstance("new-inst", ociclient.InstanceStateProvisioning)
+}
+
+func (s *environBrokerSuite) createStartInstanceArgs(c *gc.C) environs.StartInstanceParams {
+	var cons constraints.Value
+	instanceConfig, err := instancecfg.NewBootstrapInstanceConfig(
+		coretesting.FakeControllerConfig(), cons, cons, "xenial", "",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	instanceConfig.AuthorizedKeys = fakeConfig(c).AuthorizedKeys()
+	instanceConfig.Tags = map[string]string{
+		tags.JujuModel:      fakeModelUUID,
+		tags.JujuController: coretesting.ControllerTag.Id(),
+	}
+
+	tools := coretools.List{{
+		Version: version.Binary{
+			Number: version.MustParse("1.2.3"),
+			Arch:   arch.AMD64,
+			Series: "xenial",
+		},
+		URL: "https://example.org",
+	}}
+	err = instanceConfig.SetTools(tools[:1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	return environs.StartInstanceParams{
+		ControllerUUID: instanceConfig.Controller.Config.ControllerUUID(),
+		InstanceConfig: instanceConfig,
+		Tools:          tools,
+		Constraints:    cons,
+		Placement:      "zone=AD-1",
+		StatusCallback: func(status status.Status, info string, data map[string]interface{}) error {
+			s.statusCallbackStub.AddCall("StatusCallback", status, info, data)
+			return s.statusCallbackStub.NextErr()
+		},
+	}
+}
+
+func (s *environBrokerSuite) launchInstanceArgs(c *gc.C) ociclient.LaunchInstanceParams {
+	for _, call := range s.client.Calls() {
+		if call.FuncName == "LaunchInstance" {
+			return call.Args[1].(ociclient.LaunchInstanceParams)
+		}
+	}
+	c.Fatalf("LaunchInstance not called")
+	panic("unreachable")
+}
+
+func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
+	result, err := s.env.StartInstance(s.createStartInstanceArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.NotNil)
+	c.Assert(result.Instance, gc.NotNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("new-inst"))
+
+	s.client.CheckCallNames(c,
+		"Shapes", "Images", "AvailabilityDomains",
+		"Vcns", "Subnets", "LaunchInstance",
+	)
+	s.client.CheckCall(c, 1, "Images",
+		s.client.Calls()[1].Args[0],
+		fakeCompartmentId, "Canonical Ubuntu", "16.04",
+	)
+	call := s.client.Calls()[5]
+	c.Assert(call.Args, gc.HasLen, 2)
+	c.Assert(call.Args[0], gc.Implements, new(context.Context))
+	c.Assert(call.Args[1], gc.FitsTypeOf, ociclient.LaunchInstanceParams{})
+
+	launchArgs := call.Args[1].(ociclient.LaunchInstanceParams)
+	c.Assert(launchArgs.UserData, gc.Not(gc.Equals), "")
+	launchArgs.UserData = ""
+	c.Assert(launchArgs, jc.DeepEquals, ociclient.LaunchInstanceParams{
+		CompartmentId:       fakeCompartmentId,
+		AvailabilityDomain:  "AD-1",
+		DisplayName:         "juju-f75cba-0",
+		Shape:               "VM.Standard2.1",
+		ImageId:             "image-new",
+		BootVolumeSizeInGBs: 50,
+		SubnetId:            "subnet-1",
+		AssignPublicIp:      true,
+		SSHAuthorizedKeys:   fakeConfig(c).AuthorizedKeys(),
+		FreeformTags: map[string]string{
+			tags.JujuModel:      fakeModelUUID,
+			tags.JujuController: coretesting.ControllerTag.Id(),
+		},
+	})
+
+	c.Assert(*result.Hardware.Arch, gc.Equals, "amd64")
+	c.Assert(*result.Hardware.AvailabilityZone, gc.Equals, "AD-1")
+	c.Assert(*result.Hardware.Mem, gc.Equals, uint64(15*1024))
+	c.Assert(*result.Hardware.CpuCores, gc.Equals, uint64(1))
+	c.Assert(*result.Hardware.RootDisk, gc.Equals, uint64(50*1024))
+}
+
+func (s *environBrokerSuite) TestStartInstanceConstraints(c *gc.C) {
+	args := s.createStartInstanceArgs(c)
+	args.Constraints = constraints.MustParse("cores=2 root-disk=100G allocate-public-ip=false")
+	result, err := s.env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	launchArgs := s.launchInstanceArgs(c)
+	c.Assert(launchArgs.Shape, gc.Equals, "VM.Standard2.2")
+	c.Assert(launchArgs.BootVolumeSizeInGBs, gc.Equals, uint64(100))
+	c.Assert(launchArgs.AssignPublicIp, jc.IsFalse)
+	c.Assert(*result.Hardware.CpuCores, gc.Equals, uint64(2))
+	c.Assert(*result.Hardware.RootDisk, gc.Equals, uint64(100*1024))
+}
+
+func (s *environBrokerSuite) TestStartInstanceRegionalSubnet(c *gc.C) {
+	s.client.subnets["vcn-0"] = s.client.subnets["vcn-0"][:1]
+	s.client.subnets["vcn-0"] = append(s.client.subnets["vcn-0"], &ociclient.Subnet{
+		Id:                     "subnet-2",
+		VcnId:                  "vcn-0",
+		ProhibitPublicIpOnVnic: true,
+	})
+	_, err := s.env.StartInstance(s.createStartInstanceArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	launchArgs := s.launchInstanceArgs(c)
+	c.Assert(launchArgs.SubnetId, gc.Equals, "subnet-2")
+	c.Assert(launchArgs.AssignPublicIp, jc.IsFalse)
+}
+
+func (s *environBrokerSuite) TestStartInstanceConfiguredSubnet(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: fakeConfig(c, coretesting.Attrs{"subnet-id": "subnet-2"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.subnetsById = map[string]*ociclient.Subnet{
+		"subnet-2": {Id: "subnet-2", VcnId: "vcn-0"},
+	}
+
+	_, err = env.StartInstance(s.createStartInstanceArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c,
+		"Shapes", "Images", "AvailabilityDomains",
+		"Subnet", "LaunchInstance",
+	)
+	c.Assert(s.launchInstanceArgs(c).SubnetId, gc.Equals, "subnet-2")
+}
+
+func (s *environBrokerSuite) TestStartInstanceSpaces(c *gc.C) {
+	s.client.subnetsById = map[string]*ociclient.Subnet{
+		"subnet-3": {Id: "subnet-3", VcnId: "vcn-1", AvailabilityDomain: "AD-1"},
+	}
+	args := s.createStartInstanceArgs(c)
+	args.SubnetsToZones = map[network.Id][]string{
+		"subnet-3": {"AD-1"},
+		"subnet-4": {"AD-2"},
+	}
+	_, err := s.env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c,
+		"Shapes", "Images", "AvailabilityDomains",
+		"Subnet", "LaunchInstance",
+	)
+	c.Assert(s.client.Calls()[3].Args[1], gc.Equals, "subnet-3")
+	c.Assert(s.launchInstanceArgs(c).SubnetId, gc.Equals, "subnet-3")
+}
+
+func (s *environBrokerSuite) TestStartInstanceSpacesWrongZone(c *gc.C) {
+	args := s.createStartInstanceArgs(c)
+	args.SubnetsToZones = map[network.Id][]string{"subnet-4": {"AD-2"}}
+	_, err := s.env.StartInstance(args)
+	c.Assert(errors.Cause(err), gc.Equals, environs.ErrAvailabilityZoneFailed)
+	s.statusCallbackStub.CheckCallNames(c, "StatusCallback")
+}
+
+func (s *environBrokerSuite) TestStartInstanceNoImages(c *gc.C) {
+	s.client.images = s.client.images[:1]
+	_, err := s.env.StartInstance(s.createStartInstanceArgs(c))
+	c.Assert(err, gc.ErrorMatches, `no "xenial" images in us-phoenix-1 with arches \[amd64\]`)
+	s.client.CheckCallNames(c, "Shapes", "Images")
+}
+
+func (s *environBrokerSuite) TestStartInstanceFailure(c *gc.C) {
+	s.client.SetErrors(nil, nil, nil, nil, nil, errors.New("nope"))
+	_, err := s.env.StartInstance(s.createStartInstanceArgs(c))
+	c.Assert(errors.Cause(err), gc.Equals, environs.ErrAvailabilityZoneFailed)
+
+	s.client.CheckCallNames(c,
+		"Shapes", "Images", "AvailabilityDomains",
+		"Vcns", "Subnets", "LaunchInstance",
+	)
+	s.statusCallbackStub.CheckCallNames(c, "StatusCallback")
+	c.Assert(s.statusCallbackStub.Calls()[0].Args[0], gc.Equals, status.ProvisioningError)
+}
+
+func (s *environBrokerSuite) TestAllInstances(c *gc.C) {
+	otherModel := newInstance("inst-2", ociclient.InstanceStateRunning)
+	otherModel.FreeformTags[tags.JujuModel] = "other"
+	s.client.instances = []*ociclient.Instance{
+		newInstance("inst-0", ociclient.InstanceStateRunning),
+		newInstance("inst-1", ociclient.InstanceStateProvisioning),
+		otherModel,
+	}
+	instances, err := s.env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 2)
+	c.Assert(instances[0].Id(), gc.Equals, instance.Id("inst-0"))
+	c.Assert(instances[0].Status().Status, gc.Equals, status.Running)
+	c.Assert(instances[1].Id(), gc.Equals, instance.Id("inst-1"))
+	c.Assert(instances[1].Status().Status, gc.Equals, status.Provisioning)
+
+	s.client.CheckCallNames(c, "Instances")
+	s.client.CheckCall(c, 0, "Instances", s.client.Calls()[0].Args[0], fakeCompartmentId)
+}
+
+func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
+	err := s.env.StopInstances("inst-0", "inst-1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var ids []string
+	s.client.CheckCallNames(c, "TerminateInstance", "TerminateInstance")
+	for i := 0; i < 2; i++ {
+		args := s.client.Calls()[i].Args
+		ids = append(ids, args[1].(string))
+	}
+	// The instances are terminated concurrently,
+	// so the order of the calls is not deterministic.
+	c.Assert(ids, jc.SameContents, []string{"inst-0", "inst-1"})
+}
+
+func (s *environBrokerSuite) TestStopInstancesNotFound(c *gc.C) {
+	s.client.SetErrors(&ociclient.Error{StatusCode: 404})
+	err := s.env.StopInstances("inst-0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *environBrokerSuite) TestStopInstancesOneFailure(c *gc.C) {
+	s.client.SetErrors(errors.New("bah"))
+	err := s.env.StopInstances("inst-0", "inst-1")
+
+	s.client.CheckCallNames(c, "TerminateInstance", "TerminateInstance")
+	id := s.client.Calls()[0].Args[1].(string)
+	c.Assert(err, gc.ErrorMatches, "failed to stop instance "+id+": bah")
+}
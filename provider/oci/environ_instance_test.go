@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type environInstanceSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environInstanceSuite{})
+
+func (s *environInstanceSuite) TestInstances(c *gc.C) {
+	s.client.instances = []*ociclient.Instance{
+		newInstance("inst-0", ociclient.InstanceStateRunning),
+		newInstance("inst-1", ociclient.InstanceStateRunning),
+	}
+	instances, err := s.env.Instances([]instance.Id{"inst-1", "inst-2"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(instances, gc.HasLen, 2)
+	c.Assert(instances[0].Id(), gc.Equals, instance.Id("inst-1"))
+	c.Assert(instances[1], gc.IsNil)
+}
+
+func (s *environInstanceSuite) TestInstancesNoInstances(c *gc.C) {
+	_, err := s.env.Instances([]instance.Id{"inst-0"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environInstanceSuite) TestInstanceAddresses(c *gc.C) {
+	s.client.instances = []*ociclient.Instance{
+		newInstance("inst-0", ociclient.InstanceStateRunning),
+	}
+	s.client.vnicAttachments = []*ociclient.VnicAttachment{
+		{Id: "att-1", VnicId: "vnic-1", NicIndex: 1, LifecycleState: "ATTACHED"},
+		{Id: "att-0", VnicId: "vnic-0", NicIndex: 0, LifecycleState: "ATTACHED"},
+		{Id: "att-2", NicIndex: 2, LifecycleState: "ATTACHING"},
+	}
+	s.client.vnics = map[string]*ociclient.Vnic{
+		"vnic-0": {Id: "vnic-0", PrivateIp: "10.0.0.2", PublicIp: "203.0.113.1"},
+		"vnic-1": {Id: "vnic-1", PrivateIp: "10.0.1.2"},
+	}
+	instances, err := s.env.Instances([]instance.Id{"inst-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	addrs, err := instances[0].Addresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("10.0.0.2", network.ScopeCloudLocal),
+		network.NewScopedAddress("203.0.113.1", network.ScopePublic),
+		network.NewScopedAddress("10.0.1.2", network.ScopeCloudLocal),
+	})
+	s.client.CheckCallNames(c, "Instances", "VnicAttachments", "Vnic", "Vnic")
+	s.client.CheckCall(c, 1, "VnicAttachments",
+		s.client.Calls()[1].Args[0], fakeCompartmentId, "inst-0",
+	)
+}
+
+func (s *environInstanceSuite) TestControllerInstances(c *gc.C) {
+	controller := newInstance("inst-0", ociclient.InstanceStateRunning)
+	controller.FreeformTags[tags.JujuIsController] = "true"
+	otherController := newInstance("inst-1", ociclient.InstanceStateRunning)
+	otherController.FreeformTags[tags.JujuController] = "other"
+	otherController.FreeformTags[tags.JujuIsController] = "true"
+	s.client.instances = []*ociclient.Instance{
+		controller,
+		otherController,
+		newInstance("inst-2", ociclient.InstanceStateRunning),
+	}
+	ids, err := s.env.ControllerInstances(coretesting.ControllerTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []instance.Id{"inst-0"})
+}
+
+func (s *environInstanceSuite) TestControllerInstancesNotBootstrapped(c *gc.C) {
+	_, err := s.env.ControllerInstances("foo")
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
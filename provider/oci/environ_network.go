@@ -0,0 +1,314 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+)
+
+var _ environs.NetworkingEnviron = (*environ)(nil)
+
+// OpenPorts is part of the environs.Firewaller interface.
+func (*environ) OpenPorts(rules []network.IngressRule) error {
+	return errors.Trace(errors.NotSupportedf("OpenPorts"))
+}
+
+// ClosePorts is part of the environs.Firewaller interface.
+func (*environ) ClosePorts(rules []network.IngressRule) error {
+	return errors.Trace(errors.NotSupportedf("ClosePorts"))
+}
+
+// IngressRules is part of the environs.Firewaller interface.
+func (*environ) IngressRules() ([]network.IngressRule, error) {
+	return nil, errors.Trace(errors.NotSupportedf("IngressRules"))
+}
+
+// OCI virtual cloud networks are mapped to Juju spaces: each VCN in
+// the model's compartment is a space, whose provider id is the VCN's
+// OCID, and whose subnets are the VCN's subnets. Subnets are either
+// specific to an availability domain, or regional and so available
+// in every domain.
+
+// Subnets is part of the environs.Networking interface.
+func (env *environ) Subnets(instId instance.Id, subnetIds []network.Id) (subnets []network.SubnetInfo, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		subnets, err = env.Subnets(instId, subnetIds)
+		return err
+	})
+	return subnets, err
+}
+
+// Subnets is part of the environs.Networking interface.
+func (env *sessionEnviron) Subnets(instId instance.Id, subnetIds []network.Id) ([]network.SubnetInfo, error) {
+	all, err := env.allSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if instId != instance.UnknownId {
+		// Only consider the subnets the instance is connected to.
+		interfaces, err := env.NetworkInterfaces(instId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		instSubnets := set.NewStrings()
+		for _, iface := range interfaces {
+			instSubnets.Add(string(iface.ProviderSubnetId))
+		}
+		var filtered []*ociclient.Subnet
+		for _, subnet := range all {
+			if instSubnets.Contains(subnet.Id) {
+				filtered = append(filtered, subnet)
+			}
+		}
+		all = filtered
+	}
+	subnets, err := env.subnetInfos(all)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(subnetIds) == 0 {
+		return subnets, nil
+	}
+
+	var results []network.SubnetInfo
+	missing := set.NewStrings()
+	for _, id := range subnetIds {
+		missing.Add(string(id))
+	}
+	for _, subnet := range subnets {
+		if missing.Contains(string(subnet.ProviderId)) {
+			results = append(results, subnet)
+			missing.Remove(string(subnet.ProviderId))
+		}
+	}
+	if !missing.IsEmpty() {
+		return nil, errors.NotFoundf("subnets %v", missing.SortedValues())
+	}
+	return results, nil
+}
+
+// allSubnets returns the subnets of all VCNs in the model's
+// compartment.
+func (env *sessionEnviron) allSubnets() ([]*ociclient.Subnet, error) {
+	vcns, err := env.client.Vcns(env.ctx, env.compartmentId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []*ociclient.Subnet
+	for _, vcn := range vcns {
+		subnets, err := env.client.Subnets(env.ctx, env.compartmentId, vcn.Id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results = append(results, subnets...)
+	}
+	return results, nil
+}
+
+// subnetInfos returns the network.SubnetInfo for each of the given
+// subnets.
+func (env *sessionEnviron) subnetInfos(subnets []*ociclient.Subnet) ([]network.SubnetInfo, error) {
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	zoneNames := make([]string, len(zones))
+	for i, zone := range zones {
+		zoneNames[i] = zone.Name()
+	}
+
+	results := make([]network.SubnetInfo, len(subnets))
+	for i, subnet := range subnets {
+		subnetZones := zoneNames
+		if subnet.AvailabilityDomain != "" {
+			subnetZones = []string{subnet.AvailabilityDomain}
+		}
+		results[i] = network.SubnetInfo{
+			CIDR:              subnet.CidrBlock,
+			ProviderId:        network.Id(subnet.Id),
+			ProviderNetworkId: network.Id(subnet.VcnId),
+			SpaceProviderId:   network.Id(subnet.VcnId),
+			AvailabilityZones: subnetZones,
+		}
+	}
+	return results, nil
+}
+
+// SuperSubnets is part of the environs.Networking interface.
+func (*environ) SuperSubnets() ([]string, error) {
+	return nil, errors.NotSupportedf("super subnets")
+}
+
+// NetworkInterfaces is part of the environs.Networking interface.
+func (env *environ) NetworkInterfaces(instId instance.Id) (interfaces []network.InterfaceInfo, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		interfaces, err = env.NetworkInterfaces(instId)
+		return err
+	})
+	return interfaces, err
+}
+
+// NetworkInterfaces is part of the environs.Networking interface.
+func (env *sessionEnviron) NetworkInterfaces(instId instance.Id) ([]network.InterfaceInfo, error) {
+	instances, err := env.Instances([]instance.Id{instId})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	inst := instances[0].(*environInstance).base
+	attachments, err := env.vnicAttachments(inst)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	results := make([]network.InterfaceInfo, len(attachments))
+	for i, attachment := range attachments {
+		vnic, err := env.client.Vnic(env.ctx, attachment.VnicId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		subnet, err := env.client.Subnet(env.ctx, vnic.SubnetId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results[i] = network.InterfaceInfo{
+			DeviceIndex:       attachment.NicIndex,
+			MACAddress:        vnic.MacAddress,
+			CIDR:              subnet.CidrBlock,
+			ProviderId:        network.Id(vnic.Id),
+			ProviderSubnetId:  network.Id(subnet.Id),
+			ProviderNetworkId: network.Id(subnet.VcnId),
+			ProviderSpaceId:   network.Id(subnet.VcnId),
+			VLANTag:           attachment.VlanTag,
+			InterfaceType:     network.EthernetInterface,
+			ConfigType:        network.ConfigDHCP,
+			Address:           network.NewScopedAddress(vnic.PrivateIp, network.ScopeCloudLocal),
+			GatewayAddress:    network.NewScopedAddress(subnet.VirtualRouterIp, network.ScopeCloudLocal),
+		}
+	}
+	return results, nil
+}
+
+// vnicAttachments returns the attachments of VNICs to the instance,
+// ordered by NIC index. VNICs that are still being attached, or that
+// are being detached, are excluded.
+func (env *sessionEnviron) vnicAttachments(inst *ociclient.Instance) ([]*ociclient.VnicAttachment, error) {
+	all, err := env.client.VnicAttachments(env.ctx, inst.CompartmentId, inst.Id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []*ociclient.VnicAttachment
+	for _, attachment := range all {
+		if attachment.LifecycleState == ociclient.AttachmentStateAttached && attachment.VnicId != "" {
+			results = append(results, attachment)
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].NicIndex < results[j].NicIndex
+	})
+	return results, nil
+}
+
+// instanceVnics returns the VNICs attached to the instance, ordered
+// by NIC index.
+func (env *sessionEnviron) instanceVnics(inst *ociclient.Instance) ([]*ociclient.Vnic, error) {
+	attachments, err := env.vnicAttachments(inst)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]*ociclient.Vnic, len(attachments))
+	for i, attachment := range attachments {
+		results[i], err = env.client.Vnic(env.ctx, attachment.VnicId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return results, nil
+}
+
+// SupportsSpaces is part of the environs.Networking interface.
+func (*environ) SupportsSpaces() (bool, error) {
+	return true, nil
+}
+
+// SupportsSpaceDiscovery is part of the environs.Networking interface.
+func (*environ) SupportsSpaceDiscovery() (bool, error) {
+	return true, nil
+}
+
+// Spaces is part of the environs.Networking interface.
+func (env *environ) Spaces() (spaces []network.SpaceInfo, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		spaces, err = env.Spaces()
+		return err
+	})
+	return spaces, err
+}
+
+// Spaces is part of the environs.Networking interface.
+func (env *sessionEnviron) Spaces() ([]network.SpaceInfo, error) {
+	vcns, err := env.client.Vcns(env.ctx, env.compartmentId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]network.SpaceInfo, len(vcns))
+	for i, vcn := range vcns {
+		vcnSubnets, err := env.client.Subnets(env.ctx, env.compartmentId, vcn.Id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		subnets, err := env.subnetInfos(vcnSubnets)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results[i] = network.SpaceInfo{
+			Name:       vcn.DisplayName,
+			ProviderId: network.Id(vcn.Id),
+			Subnets:    subnets,
+		}
+	}
+	return results, nil
+}
+
+// ProviderSpaceInfo is part of the environs.Networking interface.
+func (*environ) ProviderSpaceInfo(space *network.SpaceInfo) (*environs.ProviderSpaceInfo, error) {
+	return nil, errors.NotSupportedf("provider space info")
+}
+
+// AreSpacesRoutable is part of the environs.Networking interface.
+func (*environ) AreSpacesRoutable(space1, space2 *environs.ProviderSpaceInfo) (bool, error) {
+	return false, nil
+}
+
+// SupportsContainerAddresses is part of the environs.Networking interface.
+func (*environ) SupportsContainerAddresses() (bool, error) {
+	return false, errors.NotSupportedf("container address allocation")
+}
+
+// AllocateContainerAddresses is part of the environs.Networking interface.
+func (*environ) AllocateContainerAddresses(
+	hostInstanceID instance.Id,
+	containerTag names.MachineTag,
+	preparedInfo []network.InterfaceInfo,
+) ([]network.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("container address allocation")
+}
+
+// ReleaseContainerAddresses is part of the environs.Networking interface.
+func (*environ) ReleaseContainerAddresses(interfaces []network.ProviderInterfaceInfo) error {
+	return errors.NotSupportedf("container address allocation")
+}
+
+// SSHAddresses is part of the environs.Networking interface.
+func (*environ) SSHAddresses(addresses []network.Address) ([]network.Address, error) {
+	return addresses, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+)
+
+type environNetworkSuite struct {
+	EnvironFixture
+	netEnv environs.NetworkingEnviron
+}
+
+var _ = gc.Suite(&environNetworkSuite{})
+
+func (s *environNetworkSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	c.Assert(s.env, gc.Implements, new(environs.NetworkingEnviron))
+	s.netEnv = s.env.(environs.NetworkingEnviron)
+
+	s.client.vcns = []*ociclient.Vcn{
+		{Id: "vcn-0", DisplayName: "default"},
+		{Id: "vcn-1", DisplayName: "storage"},
+	}
+	subnet0 := &ociclient.Subnet{
+		Id:                 "subnet-0",
+		VcnId:              "vcn-0",
+		CidrBlock:          "10.0.0.0/24",
+		AvailabilityDomain: "AD-2",
+		VirtualRouterIp:    "10.0.0.1",
+	}
+	subnet1 := &ociclient.Subnet{
+		Id:              "subnet-1",
+		VcnId:           "vcn-0",
+		CidrBlock:       "10.0.1.0/24",
+		VirtualRouterIp: "10.0.1.1",
+	}
+	s.client.subnets = map[string][]*ociclient.Subnet{
+		"vcn-0": {subnet0, subnet1},
+	}
+	s.client.subnetsById = map[string]*ociclient.Subnet{
+		"subnet-0": subnet0,
+		"subnet-1": subnet1,
+	}
+}
+
+func (s *environNetworkSuite) TestSubnets(c *gc.C) {
+	subnets, err := s.netEnv.Subnets(instance.UnknownId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		CIDR:              "10.0.0.0/24",
+		ProviderId:        "subnet-0",
+		ProviderNetworkId: "vcn-0",
+		SpaceProviderId:   "vcn-0",
+		AvailabilityZones: []string{"AD-2"},
+	}, {
+		// Regional subnets are available in every zone.
+		CIDR:              "10.0.1.0/24",
+		ProviderId:        "subnet-1",
+		ProviderNetworkId: "vcn-0",
+		SpaceProviderId:   "vcn-0",
+		AvailabilityZones: []string{"AD-1", "AD-2"},
+	}})
+}
+
+func (s *environNetworkSuite) TestSubnetsNotFound(c *gc.C) {
+	_, err := s.netEnv.Subnets(instance.UnknownId, []network.Id{"subnet-0", "subnet-2"})
+	c.Assert(err, gc.ErrorMatches, `subnets \[subnet-2\] not found`)
+}
+
+func (s *environNetworkSuite) TestSpaces(c *gc.C) {
+	spaces, err := s.netEnv.Spaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spaces, gc.HasLen, 2)
+	c.Assert(spaces[0].Name, gc.Equals, "default")
+	c.Assert(spaces[0].ProviderId, gc.Equals, network.Id("vcn-0"))
+	c.Assert(spaces[0].Subnets, gc.HasLen, 2)
+	c.Assert(spaces[1].Name, gc.Equals, "storage")
+	c.Assert(spaces[1].ProviderId, gc.Equals, network.Id("vcn-1"))
+	c.Assert(spaces[1].Subnets, gc.HasLen, 0)
+}
+
+func (s *environNetworkSuite) TestNetworkInterfaces(c *gc.C) {
+	s.client.instances = []*ociclient.Instance{
+		newInstance("inst-0", ociclient.InstanceStateRunning),
+	}
+	s.client.vnicAttachments = []*ociclient.VnicAttachment{{
+		Id:             "att-0",
+		VnicId:         "vnic-0",
+		NicIndex:       0,
+		LifecycleState: ociclient.AttachmentStateAttached,
+	}, {
+		Id:             "att-1",
+		VnicId:         "vnic-1",
+		NicIndex:       1,
+		VlanTag:        42,
+		LifecycleState: ociclient.AttachmentStateAttached,
+	}}
+	s.client.vnics = map[string]*ociclient.Vnic{
+		"vnic-0": {
+			Id:         "vnic-0",
+			SubnetId:   "subnet-0",
+			MacAddress: "02:00:17:00:00:01",
+			PrivateIp:  "10.0.0.5",
+			PublicIp:   "203.0.113.1",
+			IsPrimary:  true,
+		},
+		"vnic-1": {
+			Id:         "vnic-1",
+			SubnetId:   "subnet-1",
+			MacAddress: "02:00:17:00:00:02",
+			PrivateIp:  "10.0.1.5",
+		},
+	}
+
+	interfaces, err := s.netEnv.NetworkInterfaces("inst-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interfaces, jc.DeepEquals, []network.InterfaceInfo{{
+		DeviceIndex:       0,
+		ProviderId:        "vnic-0",
+		MACAddress:        "02:00:17:00:00:01",
+		InterfaceType:     network.EthernetInterface,
+		ConfigType:        network.ConfigDHCP,
+		ProviderNetworkId: "vcn-0",
+		ProviderSpaceId:   "vcn-0",
+		ProviderSubnetId:  "subnet-0",
+		CIDR:              "10.0.0.0/24",
+		Address:           network.NewScopedAddress("10.0.0.5", network.ScopeCloudLocal),
+		GatewayAddress:    network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	}, {
+		DeviceIndex:       1,
+		ProviderId:        "vnic-1",
+		MACAddress:        "02:00:17:00:00:02",
+		InterfaceType:     network.EthernetInterface,
+		ConfigType:        network.ConfigDHCP,
+		ProviderNetworkId: "vcn-0",
+		ProviderSpaceId:   "vcn-0",
+		ProviderSubnetId:  "subnet-1",
+		VLANTag:           42,
+		CIDR:              "10.0.1.0/24",
+		Address:           network.NewScopedAddress("10.0.1.5", network.ScopeCloudLocal),
+		GatewayAddress:    network.NewScopedAddress("10.0.1.1", network.ScopeCloudLocal),
+	}})
+}
+
+func (s *environNetworkSuite) TestSubnetsInstance(c *gc.C) {
+	s.client.instances = []*ociclient.Instance{
+		newInstance("inst-0", ociclient.InstanceStateRunning),
+	}
+	s.client.vnicAttachments = []*ociclient.VnicAttachment{{
+		Id:             "att-0",
+		VnicId:         "vnic-0",
+		LifecycleState: ociclient.AttachmentStateAttached,
+	}}
+	s.client.vnics = map[string]*ociclient.Vnic{
+		"vnic-0": {Id: "vnic-0", SubnetId: "subnet-1", PrivateIp: "10.0.1.5"},
+	}
+
+	subnets, err := s.netEnv.Subnets("inst-0", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Assert(subnets[0].ProviderId, gc.Equals, network.Id("subnet-1"))
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/testing"
+)
+
+type environSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environSuite{})
+
+func (s *environSuite) TestCreate(c *gc.C) {
+	err := s.env.Create(environs.CreateParams{ControllerUUID: "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "AvailabilityDomains")
+	c.Assert(s.client.Calls()[0].Args[1], gc.Equals, fakeCompartmentId)
+}
+
+func (s *environSuite) TestCreateDialError(c *gc.C) {
+	s.dialStub.SetErrors(errors.New("connection refused"))
+	err := s.env.Create(environs.CreateParams{ControllerUUID: "foo"})
+	c.Assert(err, gc.ErrorMatches, "dialing client: connection refused")
+	s.client.CheckNoCalls(c)
+}
+
+func (s *environSuite) TestClientReused(c *gc.C) {
+	// Requests are signed individually, so the client
+	// dialed by the first call serves every later one.
+	for i := 0; i < 2; i++ {
+		err := s.env.Create(environs.CreateParams{ControllerUUID: "foo"})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.dialStub.CheckCallNames(c, "Dial")
+	s.client.CheckCallNames(c, "AvailabilityDomains", "AvailabilityDomains")
+}
+
+func (s *environSuite) TestSetConfig(c *gc.C) {
+	cfg := fakeConfig(c, testing.Attrs{"subnet-id": "ocid1.subnet.oc1..dddd"})
+	err := s.env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.env.Config().UnknownAttrs()["subnet-id"], gc.Equals, "ocid1.subnet.oc1..dddd")
+}
+
+func (s *environSuite) TestSetConfigChangeCompartment(c *gc.C) {
+	cfg := fakeConfig(c, testing.Attrs{"compartment-id": "ocid1.compartment.oc1..eeee"})
+	err := s.env.SetConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `invalid config change: compartment-id: cannot change from .* to .*`)
+	c.Assert(s.env.Config().UnknownAttrs()["compartment-id"], gc.Equals, fakeCompartmentId)
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/oci"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+)
+
+// ProviderFixture provides an OCI provider whose connections are
+// served by a mockClient, with no tenancy behind it.
+type ProviderFixture struct {
+	testing.IsolationSuite
+	dialStub testing.Stub
+	client   *mockClient
+	provider environs.EnvironProvider
+}
+
+func (s *ProviderFixture) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dialStub.ResetCalls()
+	s.client = &mockClient{}
+	s.provider = oci.NewEnvironProvider(oci.EnvironProviderConfig{
+		Dial: newMockDialFunc(&s.dialStub, s.client),
+	})
+}
+
+// EnvironFixture opens an environ in the fake compartment of a region
+// with two availability domains, AD-1 and AD-2.
+type EnvironFixture struct {
+	ProviderFixture
+	env environs.Environ
+}
+
+func (s *EnvironFixture) SetUpTest(c *gc.C) {
+	s.ProviderFixture.SetUpTest(c)
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.env = env
+
+	s.client.availabilityDomains = []*ociclient.AvailabilityDomain{
+		newAvailabilityDomain("AD-1"),
+		newAvailabilityDomain("AD-2"),
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	jujuos "github.com/juju/utils/os"
+	"github.com/juju/utils/series"
+
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+)
+
+// Operating system names of the platform images provided by Oracle.
+const (
+	imageOSUbuntu = "Canonical Ubuntu"
+	imageOSCentOS = "CentOS"
+)
+
+// imageOS returns the operating system name and version of the
+// platform images for the given series.
+func imageOS(forSeries string) (string, string, error) {
+	os, err := series.GetOSFromSeries(forSeries)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	switch os {
+	case jujuos.Ubuntu:
+		version, err := series.SeriesVersion(forSeries)
+		if err != nil {
+			return "", "", errors.Trace(err)
+		}
+		return imageOSUbuntu, version, nil
+	case jujuos.CentOS:
+		return imageOSCentOS, strings.TrimPrefix(forSeries, "centos"), nil
+	}
+	return "", "", errors.NotSupportedf("series %q", forSeries)
+}
+
+// seriesImages returns the platform images for the given series that
+// may be used to start instances with the provider's supported shapes,
+// newest first. Images for GPU shapes, and for other architectures,
+// are excluded.
+func (env *sessionEnviron) seriesImages(forSeries string) ([]instances.Image, error) {
+	os, version, err := imageOS(forSeries)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	images, err := env.client.Images(env.ctx, env.compartmentId, os, version)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []instances.Image
+	for _, img := range images {
+		if !isStandardImage(img) {
+			continue
+		}
+		results = append(results, instances.Image{
+			Id:   img.Id,
+			Arch: arch.AMD64,
+		})
+	}
+	return results, nil
+}
+
+// isStandardImage reports whether the image is for the standard
+// (x86-64, non-GPU) shapes.
+func isStandardImage(img *ociclient.Image) bool {
+	name := strings.ToLower(img.DisplayName)
+	return !strings.Contains(name, "gpu") && !strings.Contains(name, "aarch64")
+}
@@ -0,0 +1,24 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"context"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+)
+
+const (
+	providerType = "oci"
+)
+
+func init() {
+	dial := func(ctx context.Context, config ociclient.Config) (Client, error) {
+		return ociclient.Dial(ctx, config, logger)
+	}
+	environs.RegisterProvider(providerType, NewEnvironProvider(EnvironProviderConfig{
+		Dial: dial,
+	}))
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+	"github.com/juju/juju/status"
+)
+
+type environInstance struct {
+	base *ociclient.Instance
+	env  *environ
+}
+
+var _ instance.Instance = (*environInstance)(nil)
+
+func newInstance(base *ociclient.Instance, env *environ) *environInstance {
+	return &environInstance{
+		base: base,
+		env:  env,
+	}
+}
+
+// Id implements instance.Instance.
+func (inst *environInstance) Id() instance.Id {
+	return instance.Id(inst.base.Id)
+}
+
+// Status implements instance.Instance.
+func (inst *environInstance) Status() instance.InstanceStatus {
+	instanceStatus := instance.InstanceStatus{
+		Status:  status.Empty,
+		Message: inst.base.LifecycleState,
+	}
+	switch inst.base.LifecycleState {
+	case ociclient.InstanceStateRunning:
+		instanceStatus.Status = status.Running
+	case ociclient.InstanceStateProvisioning, ociclient.InstanceStateStarting:
+		instanceStatus.Status = status.Provisioning
+	}
+	return instanceStatus
+}
+
+// Addresses implements instance.Instance.
+//
+// The addresses are those of the instance's VNICs, which are only
+// known once the instance has been provisioned.
+func (inst *environInstance) Addresses() (addrs []network.Address, err error) {
+	err = inst.env.withSession(func(env *sessionEnviron) error {
+		vnics, err := env.instanceVnics(inst.base)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, vnic := range vnics {
+			if vnic.PrivateIp != "" {
+				addrs = append(addrs, network.NewScopedAddress(vnic.PrivateIp, network.ScopeCloudLocal))
+			}
+			if vnic.PublicIp != "" {
+				addrs = append(addrs, network.NewScopedAddress(vnic.PublicIp, network.ScopePublic))
+			}
+		}
+		return nil
+	})
+	return addrs, err
+}
@@ -0,0 +1,57 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/instances"
+)
+
+var _ environs.InstanceTypesFetcher = (*environ)(nil)
+
+// InstanceTypes implements InstanceTypesFetcher
+func (env *environ) InstanceTypes(c constraints.Value) (result instances.InstanceTypesWithCostMetadata, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		instanceTypes, err := env.instanceTypes()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		instanceTypes, err = instances.MatchingInstanceTypes(instanceTypes, env.cloud.Region, c)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result.InstanceTypes = instanceTypes
+		return nil
+	})
+	return result, err
+}
+
+// instanceTypes returns the instance types corresponding to the shapes
+// available in the model's compartment. Flexible shapes, whose
+// resources are chosen when an instance is launched, are not
+// supported.
+func (env *sessionEnviron) instanceTypes() ([]instances.InstanceType, error) {
+	shapes, err := env.client.Shapes(env.ctx, env.compartmentId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []instances.InstanceType
+	for _, shape := range shapes {
+		if shape.IsFlexible {
+			continue
+		}
+		results = append(results, instances.InstanceType{
+			Id:       shape.Shape,
+			Name:     shape.Shape,
+			Arches:   []string{arch.AMD64},
+			CpuCores: uint64(shape.OCPUs),
+			Mem:      uint64(shape.MemoryInGBs * 1024),
+		})
+	}
+	return results, nil
+}
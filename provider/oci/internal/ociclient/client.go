@@ -0,0 +1,352 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package ociclient provides a client for the subset of the Oracle
+// Cloud Infrastructure (OCI) REST APIs used by the Juju provider. All
+// requests are signed as described in the OCI "Request Signatures"
+// documentation, with either a user's API signing key or the session
+// key obtained for an instance principal.
+//
+// The official OCI Go SDK (github.com/oracle/oci-go-sdk) is not used,
+// as it is not among Juju's pinned dependencies in dependencies.tsv.
+// This client implements only the compute, networking, block storage
+// and identity operations the provider needs, and should be replaced
+// by the SDK once that dependency is taken.
+package ociclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+)
+
+const (
+	// apiVersion is the version of the core services and
+	// identity APIs that the client uses.
+	apiVersion = "20160918"
+
+	// pollInterval is the interval at which the state of
+	// resources is polled while waiting for them to change.
+	pollInterval = 2 * time.Second
+
+	// waitTimeout is the maximum amount of time to wait for
+	// a resource, such as a block volume, to change state.
+	waitTimeout = 10 * time.Minute
+)
+
+// Config holds the configuration for dialing an OCI client.
+type Config struct {
+	// Endpoint is the URL of the core services API of the region,
+	// e.g. "https://iaas.us-phoenix-1.oraclecloud.com". The URLs of
+	// the identity and authentication services are derived from it.
+	Endpoint string
+
+	// Tenancy, User and Fingerprint are the OCIDs of the tenancy
+	// and user, and the fingerprint of the user's API signing key,
+	// with which to sign requests.
+	Tenancy     string
+	User        string
+	Fingerprint string
+
+	// PrivateKey is the PEM-encoded API signing key, which is
+	// encrypted with PassPhrase if that is non-empty.
+	PrivateKey string
+	PassPhrase string
+
+	// InstancePrincipal, if true, causes requests to be signed
+	// as the instance principal of the OCI instance that the
+	// client is running on, rather than with an API signing key.
+	InstancePrincipal bool
+
+	// MetadataURL is the base URL of the instance metadata
+	// service, used to obtain the certificates of the instance
+	// principal. If it is empty, the well-known address of the
+	// metadata service is used.
+	MetadataURL string
+
+	// HTTPClient is the HTTP client with which to send requests.
+	// If it is nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Client encapsulates an OCI REST API client, exposing the subset of
+// functionality that we require in the Juju provider.
+type Client struct {
+	sender
+	signer      signer
+	tenancy     string
+	coreURL     *url.URL
+	identityURL *url.URL
+}
+
+// Dial returns a new OCI client with the given configuration. If
+// no credentials are configured, requests are not signed; this is
+// only useful for checking that the endpoint is valid. The credentials
+// are verified before Dial returns.
+func Dial(ctx context.Context, config Config, logger loggo.Logger) (*Client, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing endpoint")
+	}
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	c := &Client{
+		sender: sender{
+			http:   httpClient,
+			logger: logger,
+			clock:  clock.WallClock,
+		},
+		tenancy:     config.Tenancy,
+		coreURL:     endpoint,
+		identityURL: serviceURL(endpoint, "identity"),
+	}
+	switch {
+	case config.InstancePrincipal:
+		metadataURL := config.MetadataURL
+		if metadataURL == "" {
+			metadataURL = defaultMetadataURL
+		}
+		s := &instancePrincipalSigner{
+			sender:      c.sender,
+			metadataURL: metadataURL,
+			authURL:     serviceURL(endpoint, "auth"),
+		}
+		// Obtain a session token up front, to learn the
+		// tenancy to which the instance belongs.
+		if _, _, err := s.key(ctx); err != nil {
+			return nil, errors.Annotate(err, "authenticating instance principal")
+		}
+		c.signer = s
+		c.tenancy = s.tenancy
+	case config.User != "":
+		key, err := parsePrivateKey(config.PrivateKey, config.PassPhrase)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		c.signer = &apiKeySigner{
+			keyId:      config.Tenancy + "/" + config.User + "/" + config.Fingerprint,
+			privateKey: key,
+		}
+	}
+	// List the availability domains to verify the credentials.
+	if _, err := c.AvailabilityDomains(ctx, c.tenancy); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c, nil
+}
+
+// serviceURL returns the URL of the named service in the same region
+// as the core services API at the given endpoint. Endpoints not of
+// the form "iaas.<region>..." are assumed to serve all of the APIs.
+func serviceURL(endpoint *url.URL, service string) *url.URL {
+	u := *endpoint
+	if strings.HasPrefix(u.Host, "iaas.") {
+		u.Host = service + "." + strings.TrimPrefix(u.Host, "iaas.")
+	}
+	return &u
+}
+
+// Tenancy returns the OCID of the tenancy that the client's
+// credentials belong to.
+func (c *Client) Tenancy() string {
+	return c.tenancy
+}
+
+// Error is an error returned by an OCI API.
+type Error struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+// Error is part of the error interface.
+func (e *Error) Error() string {
+	msg := e.Code
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// IsUnauthorized reports whether the error was caused by the API
+// rejecting the client's credentials.
+func IsUnauthorized(err error) bool {
+	e, ok := errors.Cause(err).(*Error)
+	return ok && e.StatusCode == http.StatusUnauthorized
+}
+
+// IsNotFound reports whether the error was caused by the API
+// reporting that the requested resource does not exist. OCI does not
+// distinguish resources that do not exist from those that the user
+// is not authorized to access.
+func IsNotFound(err error) bool {
+	e, ok := errors.Cause(err).(*Error)
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
+func (c *Client) get(ctx context.Context, base *url.URL, p string, query url.Values, out interface{}) error {
+	_, err := c.do(ctx, "GET", base, p, query, nil, out)
+	return err
+}
+
+func (c *Client) post(ctx context.Context, base *url.URL, p string, in, out interface{}) error {
+	_, err := c.do(ctx, "POST", base, p, nil, in, out)
+	return err
+}
+
+func (c *Client) put(ctx context.Context, base *url.URL, p string, in, out interface{}) error {
+	_, err := c.do(ctx, "PUT", base, p, nil, in, out)
+	return err
+}
+
+func (c *Client) delete(ctx context.Context, base *url.URL, p string) error {
+	_, err := c.do(ctx, "DELETE", base, p, nil, nil, nil)
+	return err
+}
+
+// list sends a request for each page of the results of a list
+// operation, appending the results to the slice that out points to.
+func (c *Client) list(ctx context.Context, base *url.URL, p string, query url.Values, out interface{}) error {
+	results := reflect.ValueOf(out).Elem()
+	pageQuery := make(url.Values)
+	for k, v := range query {
+		pageQuery[k] = v
+	}
+	for {
+		page := reflect.New(results.Type())
+		header, err := c.do(ctx, "GET", base, p, pageQuery, nil, page.Interface())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		results.Set(reflect.AppendSlice(results, page.Elem()))
+		next := header.Get("opc-next-page")
+		if next == "" {
+			return nil
+		}
+		pageQuery.Set("page", next)
+	}
+}
+
+// do sends a signed request to the versioned API at base.
+func (c *Client) do(ctx context.Context, method string, base *url.URL, p string, query url.Values, in, out interface{}) (http.Header, error) {
+	u := *base
+	u.Path = path.Join(u.Path, apiVersion, p)
+	u.RawQuery = query.Encode()
+
+	var keyId string
+	var key *rsa.PrivateKey
+	if c.signer != nil {
+		var err error
+		keyId, key, err = c.signer.key(ctx)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting signing key")
+		}
+	}
+	return c.send(ctx, method, &u, in, out, keyId, key)
+}
+
+// waitFor calls the given function until it returns true or an
+// error, or until the operation described by what times out.
+func (c *Client) waitFor(ctx context.Context, what string, done func() (bool, error)) error {
+	timeout := c.clock.After(waitTimeout)
+	for {
+		ok, err := done()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Annotatef(ctx.Err(), "waiting for %s", what)
+		case <-timeout:
+			return errors.Errorf("timed out waiting for %s", what)
+		case <-c.clock.After(pollInterval):
+		}
+	}
+}
+
+// sender sends requests to the OCI APIs.
+type sender struct {
+	http   *http.Client
+	logger loggo.Logger
+	clock  clock.Clock
+}
+
+// send sends a request to the given URL, encoding in as the JSON
+// request body if it is non-nil, and decoding the JSON response body
+// into out if it is non-nil. If key is non-nil, the request is signed
+// with it. The response headers are returned.
+func (s sender) send(
+	ctx context.Context,
+	method string,
+	u *url.URL,
+	in, out interface{},
+	keyId string,
+	key *rsa.PrivateKey,
+) (http.Header, error) {
+	var data []byte
+	var body io.Reader
+	if in != nil {
+		var err error
+		data, err = json.Marshal(in)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if key != nil {
+		if err := signRequest(req, data, keyId, key, s.clock.Now()); err != nil {
+			return nil, errors.Annotate(err, "signing request")
+		}
+	}
+
+	s.logger.Tracef("%s %s", method, u.String())
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil {
+			s.logger.Debugf("cannot decode %s error response: %v", resp.Status, err)
+		}
+		return nil, errors.Annotatef(apiErr, "%s %s", method, u.Path)
+	}
+	if out == nil {
+		return resp.Header, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, errors.Annotatef(err, "decoding %s %s response", method, u.Path)
+	}
+	return resp.Header, nil
+}
@@ -0,0 +1,601 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ociclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+const (
+	testTenancy     = "ocid1.tenancy.oc1..tenancy"
+	testUser        = "ocid1.user.oc1..user"
+	testFingerprint = "20:3b:97:13:55:1c:5b:0d:d3:37:d8:50:4e:c5:3a:34"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+
+	apiKey      *rsa.PrivateKey
+	instanceKey *rsa.PrivateKey
+	cert        *x509.Certificate
+	certPEM     string
+
+	server    *httptest.Server
+	responses map[string]string
+	requests  []request
+
+	// sessionKey is the public key of the session
+	// obtained for the instance principal.
+	sessionKey *rsa.PublicKey
+
+	// onRequest, if non-nil, is called for each request
+	// before it is served.
+	onRequest func(*http.Request)
+}
+
+// request records a request made to the fake API.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	keyId  string
+	body   map[string]interface{}
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) SetUpSuite(c *gc.C) {
+	s.IsolationSuite.SetUpSuite(c)
+	var err error
+	s.apiKey, err = rsa.GenerateKey(rand.Reader, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+	s.instanceKey, err = rsa.GenerateKey(rand.Reader, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			CommonName:         "ocid1.instance.oc1.phx.instance",
+			OrganizationalUnit: []string{"opc-instance:ocid1.instance.oc1.phx.instance", tenancyOUPrefix + testTenancy},
+		},
+		NotBefore: time.Now().Add(-time.Hour),
+		NotAfter:  time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &s.instanceKey.PublicKey, s.instanceKey)
+	c.Assert(err, jc.ErrorIsNil)
+	s.cert, err = x509.ParseCertificate(der)
+	c.Assert(err, jc.ErrorIsNil)
+	s.certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func (s *clientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.onRequest = nil
+	s.sessionKey = nil
+	s.responses = map[string]string{
+		"GET /20160918/availabilityDomains": `[{"name": "Uocm:PHX-AD-1"}, {"name": "Uocm:PHX-AD-2"}]`,
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.AddCleanup(func(*gc.C) {
+		s.server.Close()
+	})
+}
+
+var authorizationRegexp = regexp.MustCompile(
+	`^Signature version="1",keyId="([^"]*)",algorithm="rsa-sha256",headers="([^"]*)",signature="([^"]*)"$`,
+)
+
+func (s *clientSuite) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if s.onRequest != nil {
+		s.onRequest(req)
+	}
+	data, _ := ioutil.ReadAll(req.Body)
+	if strings.HasPrefix(req.URL.Path, "/opc/v1/identity/") {
+		s.serveMetadata(w, req)
+		return
+	}
+	keyId, err := s.verify(req, data)
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, `{"code": "NotAuthenticated", "message": %q}`, err.Error())
+		return
+	}
+	r := request{
+		method: req.Method,
+		path:   req.URL.Path,
+		query:  req.URL.Query(),
+		keyId:  keyId,
+	}
+	if len(data) > 0 {
+		json.Unmarshal(data, &r.body)
+	}
+	s.requests = append(s.requests, r)
+
+	if req.Method == "POST" && req.URL.Path == "/v1/x509" {
+		s.serveFederation(w, r)
+		return
+	}
+	response, ok := s.responses[req.Method+" "+req.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code": "NotAuthorizedOrNotFound", "message": "Authorization failed or requested resource not found."}`))
+		return
+	}
+	if page := req.URL.Query().Get("page"); page != "" {
+		response = s.responses[req.Method+" "+req.URL.Path+" page="+page]
+	}
+	if next, ok := s.responses[req.Method+" "+req.URL.Path+" next"]; ok && req.URL.Query().Get("page") == "" {
+		w.Header().Set("opc-next-page", next)
+	}
+	w.Write([]byte(response))
+}
+
+// verify checks the request's signature, returning the id of the key
+// with which it was signed.
+func (s *clientSuite) verify(req *http.Request, body []byte) (string, error) {
+	m := authorizationRegexp.FindStringSubmatch(req.Header.Get("Authorization"))
+	if m == nil {
+		return "", fmt.Errorf("missing or invalid Authorization header")
+	}
+	keyId, headers := m[1], strings.Fields(m[2])
+	var key *rsa.PublicKey
+	switch {
+	case keyId == testTenancy+"/"+testUser+"/"+testFingerprint:
+		key = &s.apiKey.PublicKey
+	case keyId == testTenancy+"/fed-x509/"+certificateFingerprint(s.cert):
+		key = &s.instanceKey.PublicKey
+	case strings.HasPrefix(keyId, "ST$") && s.sessionKey != nil:
+		key = s.sessionKey
+	default:
+		return "", fmt.Errorf("unknown key %q", keyId)
+	}
+	if req.Method == "POST" || req.Method == "PUT" {
+		sum := sha256.Sum256(body)
+		if req.Header.Get("X-Content-Sha256") != base64.StdEncoding.EncodeToString(sum[:]) {
+			return "", fmt.Errorf("content digest mismatch")
+		}
+	}
+	lines := make([]string, len(headers))
+	for i, name := range headers {
+		switch name {
+		case "(request-target)":
+			lines[i] = name + ": " + strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+		case "host":
+			lines[i] = name + ": " + req.Host
+		default:
+			lines[i] = name + ": " + req.Header.Get(name)
+		}
+	}
+	signature, err := base64.StdEncoding.DecodeString(m[3])
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return "", err
+	}
+	return keyId, nil
+}
+
+func (s *clientSuite) serveMetadata(w http.ResponseWriter, req *http.Request) {
+	switch strings.TrimPrefix(req.URL.Path, "/opc/v1/identity/") {
+	case "cert.pem", "intermediate.pem":
+		w.Write([]byte(s.certPEM))
+	case "key.pem":
+		w.Write(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(s.instanceKey),
+		}))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *clientSuite) serveFederation(w http.ResponseWriter, r request) {
+	der, _ := base64.StdEncoding.DecodeString(r.body["publicKey"].(string))
+	key, _ := x509.ParsePKIXPublicKey(der)
+	s.sessionKey = key.(*rsa.PublicKey)
+	claims, _ := json.Marshal(map[string]interface{}{
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	token := "header." + base64.RawURLEncoding.EncodeToString(claims) + ".signature"
+	fmt.Fprintf(w, `{"token": %q}`, token)
+}
+
+func (s *clientSuite) config() Config {
+	return Config{
+		Endpoint:    s.server.URL,
+		Tenancy:     testTenancy,
+		User:        testUser,
+		Fingerprint: testFingerprint,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(s.apiKey),
+		})),
+	}
+}
+
+func (s *clientSuite) dial(c *gc.C) *Client {
+	client, err := Dial(context.Background(), s.config(), loggo.GetLogger("ociclient_test"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.requests = nil
+	return client
+}
+
+func (s *clientSuite) checkRequests(c *gc.C, expect ...string) {
+	var actual []string
+	for _, r := range s.requests {
+		actual = append(actual, r.method+" "+r.path)
+	}
+	c.Assert(actual, jc.DeepEquals, expect)
+}
+
+func (s *clientSuite) TestDial(c *gc.C) {
+	client := s.dial(c)
+	c.Assert(client.Tenancy(), gc.Equals, testTenancy)
+}
+
+func (s *clientSuite) TestDialVerifiesCredentials(c *gc.C) {
+	_, err := Dial(context.Background(), s.config(), loggo.GetLogger("ociclient_test"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkRequests(c, "GET /20160918/availabilityDomains")
+	c.Assert(s.requests[0].query.Get("compartmentId"), gc.Equals, testTenancy)
+	c.Assert(s.requests[0].keyId, gc.Equals, testTenancy+"/"+testUser+"/"+testFingerprint)
+}
+
+func (s *clientSuite) TestDialUnauthorized(c *gc.C) {
+	_, err := Dial(context.Background(), Config{Endpoint: s.server.URL}, loggo.GetLogger("ociclient_test"))
+	c.Assert(err, gc.ErrorMatches, `listing availability domains: GET /20160918/availabilityDomains: NotAuthenticated: missing or invalid Authorization header`)
+	c.Assert(IsUnauthorized(err), jc.IsTrue)
+}
+
+func (s *clientSuite) TestDialInvalidKey(c *gc.C) {
+	config := s.config()
+	config.PrivateKey = "foo"
+	_, err := Dial(context.Background(), config, loggo.GetLogger("ociclient_test"))
+	c.Assert(err, gc.ErrorMatches, `private key: no PEM data not valid`)
+}
+
+func (s *clientSuite) TestDialEncryptedKey(c *gc.C) {
+	block, err := x509.EncryptPEMBlock(
+		rand.Reader, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(s.apiKey),
+		[]byte("sekrit"), x509.PEMCipherAES256,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	config := s.config()
+	config.PrivateKey = string(pem.EncodeToMemory(block))
+
+	_, err = Dial(context.Background(), config, loggo.GetLogger("ociclient_test"))
+	c.Assert(err, gc.ErrorMatches, `encrypted private key without pass phrase not valid`)
+
+	config.PassPhrase = "sekrit"
+	_, err = Dial(context.Background(), config, loggo.GetLogger("ociclient_test"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestDialInstancePrincipal(c *gc.C) {
+	client, err := Dial(context.Background(), Config{
+		Endpoint:          s.server.URL,
+		InstancePrincipal: true,
+		MetadataURL:       s.server.URL + "/opc/v1",
+	}, loggo.GetLogger("ociclient_test"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.Tenancy(), gc.Equals, testTenancy)
+
+	s.checkRequests(c,
+		"POST /v1/x509",
+		"GET /20160918/availabilityDomains",
+	)
+	c.Assert(s.requests[0].keyId, gc.Equals, testTenancy+"/fed-x509/"+certificateFingerprint(s.cert))
+	c.Assert(s.requests[0].body["certificate"], gc.Equals, base64.StdEncoding.EncodeToString(s.cert.Raw))
+	c.Assert(s.requests[0].body["intermediateCertificates"], jc.DeepEquals, []interface{}{
+		base64.StdEncoding.EncodeToString(s.cert.Raw),
+	})
+	c.Assert(s.requests[1].keyId, jc.HasPrefix, "ST$header.")
+	c.Assert(s.requests[1].query.Get("compartmentId"), gc.Equals, testTenancy)
+
+	// The session token is reused until it is about to expire.
+	s.requests = nil
+	_, err = client.Vcns(context.Background(), testTenancy)
+	c.Assert(err, gc.ErrorMatches, `listing VCNs: .*NotAuthorizedOrNotFound.*`)
+	c.Assert(IsNotFound(err), jc.IsTrue)
+	s.checkRequests(c, "GET /20160918/vcns")
+}
+
+func (s *clientSuite) TestCertificateFingerprint(c *gc.C) {
+	fingerprint := certificateFingerprint(s.cert)
+	c.Assert(fingerprint, gc.Matches, `([0-9A-F]{2}:){19}[0-9A-F]{2}`)
+}
+
+func (s *clientSuite) TestTokenExpiry(c *gc.C) {
+	expiry, err := tokenExpiry("a." + base64.RawURLEncoding.EncodeToString([]byte(`{"exp": 1500000000}`)) + ".b")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expiry.Equal(time.Unix(1500000000, 0)), jc.IsTrue)
+
+	_, err = tokenExpiry("nope")
+	c.Assert(err, gc.ErrorMatches, `session token not valid`)
+}
+
+func (s *clientSuite) TestInstancesPaginated(c *gc.C) {
+	s.responses["GET /20160918/instances"] = `[
+		{"id": "i1", "displayName": "juju-abcdef-0", "lifecycleState": "RUNNING"},
+		{"id": "i2", "displayName": "juju-abcdef-1", "lifecycleState": "TERMINATED"}
+	]`
+	s.responses["GET /20160918/instances next"] = "page-2"
+	s.responses["GET /20160918/instances page=page-2"] = `[
+		{"id": "i3", "displayName": "juju-abcdef-2", "lifecycleState": "PROVISIONING", "freeformTags": {"juju-model-uuid": "uuid"}}
+	]`
+	client := s.dial(c)
+	instances, err := client.Instances(context.Background(), "compartment-id")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, jc.DeepEquals, []*Instance{
+		{Id: "i1", DisplayName: "juju-abcdef-0", LifecycleState: InstanceStateRunning},
+		{
+			Id:             "i3",
+			DisplayName:    "juju-abcdef-2",
+			LifecycleState: InstanceStateProvisioning,
+			FreeformTags:   map[string]string{"juju-model-uuid": "uuid"},
+		},
+	})
+	s.checkRequests(c, "GET /20160918/instances", "GET /20160918/instances")
+	c.Assert(s.requests[0].query, jc.DeepEquals, url.Values{"compartmentId": {"compartment-id"}})
+	c.Assert(s.requests[1].query, jc.DeepEquals, url.Values{
+		"compartmentId": {"compartment-id"},
+		"page":          {"page-2"},
+	})
+}
+
+func (s *clientSuite) TestLaunchInstance(c *gc.C) {
+	s.responses["POST /20160918/instances"] = `{"id": "i1", "displayName": "juju-abcdef-0", "lifecycleState": "PROVISIONING"}`
+	client := s.dial(c)
+	inst, err := client.LaunchInstance(context.Background(), LaunchInstanceParams{
+		CompartmentId:       "compartment-id",
+		AvailabilityDomain:  "Uocm:PHX-AD-1",
+		DisplayName:         "juju-abcdef-0",
+		Shape:               "VM.Standard2.1",
+		ImageId:             "image-id",
+		BootVolumeSizeInGBs: 100,
+		SubnetId:            "subnet-id",
+		AssignPublicIp:      true,
+		SSHAuthorizedKeys:   "ssh-rsa AAAA",
+		UserData:            "dXNlci1kYXRh",
+		FreeformTags:        map[string]string{"juju-model-uuid": "uuid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inst.Id, gc.Equals, "i1")
+	s.checkRequests(c, "POST /20160918/instances")
+	c.Assert(s.requests[0].body, jc.DeepEquals, map[string]interface{}{
+		"compartmentId":      "compartment-id",
+		"availabilityDomain": "Uocm:PHX-AD-1",
+		"displayName":        "juju-abcdef-0",
+		"shape":              "VM.Standard2.1",
+		"sourceDetails": map[string]interface{}{
+			"sourceType":          "image",
+			"imageId":             "image-id",
+			"bootVolumeSizeInGBs": float64(100),
+		},
+		"createVnicDetails": map[string]interface{}{
+			"subnetId":       "subnet-id",
+			"assignPublicIp": true,
+		},
+		"metadata": map[string]interface{}{
+			"ssh_authorized_keys": "ssh-rsa AAAA",
+			"user_data":           "dXNlci1kYXRh",
+		},
+		"freeformTags": map[string]interface{}{"juju-model-uuid": "uuid"},
+	})
+}
+
+func (s *clientSuite) TestTerminateInstance(c *gc.C) {
+	s.responses["DELETE /20160918/instances/i1"] = ``
+	states := []string{"TERMINATING", "TERMINATED"}
+	s.onRequest = func(req *http.Request) {
+		if req.Method == "GET" && req.URL.Path == "/20160918/instances/i1" {
+			s.responses["GET /20160918/instances/i1"] = `{"id": "i1", "lifecycleState": "` + states[0] + `"}`
+			states = states[1:]
+		}
+	}
+	client := s.dial(c)
+	client.clock = testClock{testing.NewClock(time.Now())}
+	err := client.TerminateInstance(context.Background(), "i1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkRequests(c,
+		"DELETE /20160918/instances/i1",
+		"GET /20160918/instances/i1",
+		"GET /20160918/instances/i1",
+	)
+	c.Assert(s.requests[0].query.Get("preserveBootVolume"), gc.Equals, "false")
+}
+
+func (s *clientSuite) TestTerminateInstanceNotFound(c *gc.C) {
+	// Terminated instances eventually disappear altogether.
+	s.responses["DELETE /20160918/instances/i1"] = ``
+	client := s.dial(c)
+	err := client.TerminateInstance(context.Background(), "i1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkRequests(c,
+		"DELETE /20160918/instances/i1",
+		"GET /20160918/instances/i1",
+	)
+}
+
+func (s *clientSuite) TestShapes(c *gc.C) {
+	s.responses["GET /20160918/shapes"] = `[
+		{"shape": "VM.Standard2.1", "ocpus": 1, "memoryInGBs": 15},
+		{"shape": "VM.Standard2.2", "ocpus": 2, "memoryInGBs": 30},
+		{"shape": "VM.Standard2.1", "ocpus": 1, "memoryInGBs": 15}
+	]`
+	client := s.dial(c)
+	shapes, err := client.Shapes(context.Background(), "compartment-id")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(shapes, jc.DeepEquals, []*Shape{
+		{Shape: "VM.Standard2.1", OCPUs: 1, MemoryInGBs: 15},
+		{Shape: "VM.Standard2.2", OCPUs: 2, MemoryInGBs: 30},
+	})
+}
+
+func (s *clientSuite) TestImages(c *gc.C) {
+	s.responses["GET /20160918/images"] = `[{"id": "image-id", "displayName": "Canonical-Ubuntu-16.04-2017.11.01-0"}]`
+	client := s.dial(c)
+	images, err := client.Images(context.Background(), "compartment-id", "Canonical Ubuntu", "16.04")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(images, jc.DeepEquals, []*Image{{Id: "image-id", DisplayName: "Canonical-Ubuntu-16.04-2017.11.01-0"}})
+	c.Assert(s.requests[0].query, jc.DeepEquals, url.Values{
+		"compartmentId":          {"compartment-id"},
+		"operatingSystem":        {"Canonical Ubuntu"},
+		"operatingSystemVersion": {"16.04"},
+		"lifecycleState":         {"AVAILABLE"},
+		"sortBy":                 {"TIMECREATED"},
+		"sortOrder":              {"DESC"},
+	})
+}
+
+func (s *clientSuite) TestCreateVolume(c *gc.C) {
+	s.responses["POST /20160918/volumes"] = `{"id": "vol-id", "lifecycleState": "PROVISIONING"}`
+	states := []string{"PROVISIONING", "AVAILABLE"}
+	s.onRequest = func(req *http.Request) {
+		if req.Method == "GET" && req.URL.Path == "/20160918/volumes/vol-id" {
+			s.responses["GET /20160918/volumes/vol-id"] = `{"id": "vol-id", "sizeInGBs": 50, "lifecycleState": "` + states[0] + `"}`
+			states = states[1:]
+		}
+	}
+	client := s.dial(c)
+	client.clock = testClock{testing.NewClock(time.Now())}
+	volume, err := client.CreateVolume(context.Background(), CreateVolumeParams{
+		CompartmentId:      "compartment-id",
+		AvailabilityDomain: "Uocm:PHX-AD-1",
+		DisplayName:        "juju-abcdef-volume-0",
+		SizeInGBs:          50,
+		FreeformTags:       map[string]string{"juju-model-uuid": "uuid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volume, jc.DeepEquals, &Volume{
+		Id:             "vol-id",
+		SizeInGBs:      50,
+		LifecycleState: VolumeStateAvailable,
+	})
+	s.checkRequests(c,
+		"POST /20160918/volumes",
+		"GET /20160918/volumes/vol-id",
+		"GET /20160918/volumes/vol-id",
+	)
+	c.Assert(s.requests[0].body, jc.DeepEquals, map[string]interface{}{
+		"compartmentId":      "compartment-id",
+		"availabilityDomain": "Uocm:PHX-AD-1",
+		"displayName":        "juju-abcdef-volume-0",
+		"sizeInGBs":          float64(50),
+		"freeformTags":       map[string]interface{}{"juju-model-uuid": "uuid"},
+	})
+}
+
+func (s *clientSuite) TestCreateVolumeFaulty(c *gc.C) {
+	s.responses["POST /20160918/volumes"] = `{"id": "vol-id", "lifecycleState": "PROVISIONING"}`
+	s.responses["GET /20160918/volumes/vol-id"] = `{"id": "vol-id", "lifecycleState": "FAULTY"}`
+	s.responses["DELETE /20160918/volumes/vol-id"] = ``
+	client := s.dial(c)
+	_, err := client.CreateVolume(context.Background(), CreateVolumeParams{DisplayName: "juju-abcdef-volume-0"})
+	c.Assert(err, gc.ErrorMatches, `volume vol-id is FAULTY`)
+
+	// The volume is deleted when it fails to become available.
+	s.checkRequests(c,
+		"POST /20160918/volumes",
+		"GET /20160918/volumes/vol-id",
+		"DELETE /20160918/volumes/vol-id",
+	)
+}
+
+func (s *clientSuite) TestAttachVolume(c *gc.C) {
+	s.responses["POST /20160918/volumeAttachments"] = `{"id": "att-id", "lifecycleState": "ATTACHING"}`
+	s.responses["GET /20160918/volumeAttachments/att-id"] = `{
+		"id": "att-id", "attachmentType": "paravirtualized", "instanceId": "i1", "volumeId": "vol-id",
+		"device": "/dev/oracleoci/oraclevdb", "lifecycleState": "ATTACHED"
+	}`
+	client := s.dial(c)
+	attachment, err := client.AttachVolume(context.Background(), "i1", "vol-id", "/dev/oracleoci/oraclevdb")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment, jc.DeepEquals, &VolumeAttachment{
+		Id:             "att-id",
+		AttachmentType: "paravirtualized",
+		InstanceId:     "i1",
+		VolumeId:       "vol-id",
+		Device:         "/dev/oracleoci/oraclevdb",
+		LifecycleState: AttachmentStateAttached,
+	})
+	s.checkRequests(c,
+		"POST /20160918/volumeAttachments",
+		"GET /20160918/volumeAttachments/att-id",
+	)
+	c.Assert(s.requests[0].body, jc.DeepEquals, map[string]interface{}{
+		"type":       "paravirtualized",
+		"instanceId": "i1",
+		"volumeId":   "vol-id",
+		"device":     "/dev/oracleoci/oraclevdb",
+	})
+}
+
+func (s *clientSuite) TestInstanceDevices(c *gc.C) {
+	s.responses["GET /20160918/instances/i1/devices"] = `[
+		{"name": "/dev/oracleoci/oraclevdb", "isAvailable": true},
+		{"name": "/dev/oracleoci/oraclevdc", "isAvailable": true}
+	]`
+	client := s.dial(c)
+	devices, err := client.InstanceDevices(context.Background(), "i1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, jc.DeepEquals, []*Device{
+		{Name: "/dev/oracleoci/oraclevdb", IsAvailable: true},
+		{Name: "/dev/oracleoci/oraclevdc", IsAvailable: true},
+	})
+	c.Assert(s.requests[0].query.Get("isAvailable"), gc.Equals, "true")
+}
+
+func (s *clientSuite) TestServiceURL(c *gc.C) {
+	endpoint, err := url.Parse("https://iaas.us-phoenix-1.oraclecloud.com")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(serviceURL(endpoint, "identity").String(), gc.Equals, "https://identity.us-phoenix-1.oraclecloud.com")
+	c.Assert(serviceURL(endpoint, "auth").String(), gc.Equals, "https://auth.us-phoenix-1.oraclecloud.com")
+
+	endpoint, err = url.Parse("https://oci.example.com:8443")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(serviceURL(endpoint, "identity").String(), gc.Equals, "https://oci.example.com:8443")
+}
+
+// testClock is a clock whose timers fire immediately, except for
+// the timeout when waiting for resources to change state, which never
+// fires.
+type testClock struct {
+	*testing.Clock
+}
+
+func (c testClock) After(d time.Duration) <-chan time.Time {
+	if d >= waitTimeout {
+		return nil
+	}
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return ch
+}
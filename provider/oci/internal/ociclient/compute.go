@@ -0,0 +1,210 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ociclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/juju/errors"
+)
+
+// LaunchInstanceParams contains the parameters required for
+// launching a new instance.
+type LaunchInstanceParams struct {
+	// CompartmentId is the OCID of the compartment in which
+	// to launch the instance.
+	CompartmentId string
+
+	// AvailabilityDomain is the name of the availability
+	// domain in which to launch the instance.
+	AvailabilityDomain string
+
+	// DisplayName is the name to give the instance.
+	DisplayName string
+
+	// Shape is the name of the instance's shape.
+	Shape string
+
+	// ImageId is the OCID of the image from which to boot.
+	ImageId string
+
+	// BootVolumeSizeInGBs is the size of the boot volume, in
+	// GB. If zero, the image's default size is used.
+	BootVolumeSizeInGBs uint64
+
+	// SubnetId is the OCID of the subnet in which to create
+	// the instance's primary VNIC.
+	SubnetId string
+
+	// AssignPublicIp controls whether the primary VNIC is
+	// assigned a public IP address.
+	AssignPublicIp bool
+
+	// SSHAuthorizedKeys holds the SSH public keys to authorize
+	// for the default user of the instance.
+	SSHAuthorizedKeys string
+
+	// UserData is the base64-encoded cloud-init user-data.
+	UserData string
+
+	// FreeformTags holds the tags to assign to the instance.
+	FreeformTags map[string]string
+}
+
+// LaunchInstance launches a new instance. The instance will be in the
+// PROVISIONING state when LaunchInstance returns.
+func (c *Client) LaunchInstance(ctx context.Context, args LaunchInstanceParams) (*Instance, error) {
+	sourceDetails := map[string]interface{}{
+		"sourceType": "image",
+		"imageId":    args.ImageId,
+	}
+	if args.BootVolumeSizeInGBs > 0 {
+		sourceDetails["bootVolumeSizeInGBs"] = args.BootVolumeSizeInGBs
+	}
+	metadata := map[string]string{}
+	if args.SSHAuthorizedKeys != "" {
+		metadata["ssh_authorized_keys"] = args.SSHAuthorizedKeys
+	}
+	if args.UserData != "" {
+		metadata["user_data"] = args.UserData
+	}
+	spec := map[string]interface{}{
+		"compartmentId":      args.CompartmentId,
+		"availabilityDomain": args.AvailabilityDomain,
+		"displayName":        args.DisplayName,
+		"shape":              args.Shape,
+		"sourceDetails":      sourceDetails,
+		"createVnicDetails": map[string]interface{}{
+			"subnetId":       args.SubnetId,
+			"assignPublicIp": args.AssignPublicIp,
+		},
+		"metadata":     metadata,
+		"freeformTags": args.FreeformTags,
+	}
+
+	c.logger.Debugf("launching instance %q in %s", args.DisplayName, args.AvailabilityDomain)
+	var inst Instance
+	if err := c.post(ctx, c.coreURL, "instances", spec, &inst); err != nil {
+		return nil, errors.Annotatef(err, "launching instance %q", args.DisplayName)
+	}
+	return &inst, nil
+}
+
+// Instances returns the instances in the given compartment that have
+// not been terminated.
+func (c *Client) Instances(ctx context.Context, compartmentId string) ([]*Instance, error) {
+	var all []*Instance
+	query := url.Values{"compartmentId": {compartmentId}}
+	if err := c.list(ctx, c.coreURL, "instances", query, &all); err != nil {
+		return nil, errors.Annotate(err, "listing instances")
+	}
+	var result []*Instance
+	for _, inst := range all {
+		switch inst.LifecycleState {
+		case InstanceStateTerminating, InstanceStateTerminated:
+			continue
+		}
+		result = append(result, inst)
+	}
+	return result, nil
+}
+
+// TerminateInstance terminates the instance with the given OCID,
+// deleting its boot volume, and waits for the termination to complete.
+// Block volumes attached to the instance are detached once it has
+// been terminated, and may then be deleted.
+func (c *Client) TerminateInstance(ctx context.Context, id string) error {
+	c.logger.Debugf("terminating instance %s", id)
+	p := path.Join("instances", id)
+	query := url.Values{"preserveBootVolume": {"false"}}
+	if _, err := c.do(ctx, "DELETE", c.coreURL, p, query, nil, nil); err != nil {
+		return errors.Annotatef(err, "terminating instance %s", id)
+	}
+	err := c.waitFor(ctx, fmt.Sprintf("instance %s to be terminated", id), func() (bool, error) {
+		var inst Instance
+		if err := c.get(ctx, c.coreURL, p, nil, &inst); err != nil {
+			if IsNotFound(err) {
+				return true, nil
+			}
+			return false, errors.Trace(err)
+		}
+		return inst.LifecycleState == InstanceStateTerminated, nil
+	})
+	return errors.Trace(err)
+}
+
+// UpdateInstanceTags replaces the freeform tags of the instance with
+// the given OCID.
+func (c *Client) UpdateInstanceTags(ctx context.Context, id string, tags map[string]string) error {
+	update := map[string]interface{}{"freeformTags": tags}
+	if err := c.put(ctx, c.coreURL, path.Join("instances", id), update, nil); err != nil {
+		return errors.Annotatef(err, "updating tags of instance %s", id)
+	}
+	return nil
+}
+
+// Shapes returns the instance shapes available in the given
+// compartment.
+func (c *Client) Shapes(ctx context.Context, compartmentId string) ([]*Shape, error) {
+	var all []*Shape
+	query := url.Values{"compartmentId": {compartmentId}}
+	if err := c.list(ctx, c.coreURL, "shapes", query, &all); err != nil {
+		return nil, errors.Annotate(err, "listing shapes")
+	}
+	// Shapes are listed once for each availability
+	// domain and image that they are compatible with.
+	seen := make(map[string]bool)
+	var result []*Shape
+	for _, shape := range all {
+		if !seen[shape.Shape] {
+			seen[shape.Shape] = true
+			result = append(result, shape)
+		}
+	}
+	return result, nil
+}
+
+// Images returns the available images for the given operating system
+// and version, that are usable in the given compartment, newest first.
+func (c *Client) Images(ctx context.Context, compartmentId, operatingSystem, version string) ([]*Image, error) {
+	var result []*Image
+	query := url.Values{
+		"compartmentId":          {compartmentId},
+		"operatingSystem":        {operatingSystem},
+		"operatingSystemVersion": {version},
+		"lifecycleState":         {"AVAILABLE"},
+		"sortBy":                 {"TIMECREATED"},
+		"sortOrder":              {"DESC"},
+	}
+	if err := c.list(ctx, c.coreURL, "images", query, &result); err != nil {
+		return nil, errors.Annotatef(err, "listing %s %s images", operatingSystem, version)
+	}
+	return result, nil
+}
+
+// VnicAttachments returns the attachments of VNICs to the instance
+// with the given OCID.
+func (c *Client) VnicAttachments(ctx context.Context, compartmentId, instanceId string) ([]*VnicAttachment, error) {
+	var result []*VnicAttachment
+	query := url.Values{
+		"compartmentId": {compartmentId},
+		"instanceId":    {instanceId},
+	}
+	if err := c.list(ctx, c.coreURL, "vnicAttachments", query, &result); err != nil {
+		return nil, errors.Annotatef(err, "listing VNIC attachments of instance %s", instanceId)
+	}
+	return result, nil
+}
+
+// Vnic returns the VNIC with the given OCID.
+func (c *Client) Vnic(ctx context.Context, id string) (*Vnic, error) {
+	var vnic Vnic
+	if err := c.get(ctx, c.coreURL, path.Join("vnics", id), nil, &vnic); err != nil {
+		return nil, errors.Annotatef(err, "getting VNIC %s", id)
+	}
+	return &vnic, nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ociclient
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/juju/errors"
+)
+
+// AvailabilityDomains returns the availability domains of the region
+// that are available to the given compartment.
+func (c *Client) AvailabilityDomains(ctx context.Context, compartmentId string) ([]*AvailabilityDomain, error) {
+	var result []*AvailabilityDomain
+	query := url.Values{"compartmentId": {compartmentId}}
+	if err := c.get(ctx, c.identityURL, "availabilityDomains", query, &result); err != nil {
+		return nil, errors.Annotate(err, "listing availability domains")
+	}
+	return result, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ociclient
+
+import (
+	"context"
+	"net/url"
+	"path"
+
+	"github.com/juju/errors"
+)
+
+// Vcns returns the virtual cloud networks in the given compartment.
+func (c *Client) Vcns(ctx context.Context, compartmentId string) ([]*Vcn, error) {
+	var result []*Vcn
+	query := url.Values{"compartmentId": {compartmentId}}
+	if err := c.list(ctx, c.coreURL, "vcns", query, &result); err != nil {
+		return nil, errors.Annotate(err, "listing VCNs")
+	}
+	return result, nil
+}
+
+// Subnets returns the subnets of the virtual cloud network with the
+// given OCID, in the given compartment.
+func (c *Client) Subnets(ctx context.Context, compartmentId, vcnId string) ([]*Subnet, error) {
+	var result []*Subnet
+	query := url.Values{
+		"compartmentId": {compartmentId},
+		"vcnId":         {vcnId},
+	}
+	if err := c.list(ctx, c.coreURL, "subnets", query, &result); err != nil {
+		return nil, errors.Annotatef(err, "listing subnets of VCN %s", vcnId)
+	}
+	return result, nil
+}
+
+// Subnet returns the subnet with the given OCID.
+func (c *Client) Subnet(ctx context.Context, id string) (*Subnet, error) {
+	var subnet Subnet
+	if err := c.get(ctx, c.coreURL, path.Join("subnets", id), nil, &subnet); err != nil {
+		return nil, errors.Annotatef(err, "getting subnet %s", id)
+	}
+	return &subnet, nil
+}
@@ -0,0 +1,217 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ociclient
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// defaultMetadataURL is the base URL of the instance
+	// metadata service of OCI instances.
+	defaultMetadataURL = "http://169.254.169.254/opc/v1"
+
+	// tenancyOUPrefix prefixes the organizational unit of an
+	// instance certificate's subject that holds the OCID of the
+	// instance's tenancy.
+	tenancyOUPrefix = "opc-tenant:"
+
+	// tokenRefreshMargin is how long before a session token
+	// expires that it is refreshed.
+	tokenRefreshMargin = 5 * time.Minute
+
+	// sessionKeyBits is the size of the session keys
+	// generated for instance principals.
+	sessionKeyBits = 2048
+)
+
+// instancePrincipalSigner is a signer that signs requests as the
+// instance principal of the OCI instance that the client is running
+// on. The instance's certificates, obtained from the metadata service,
+// are exchanged with the authentication service for a session token
+// with which requests are signed, using a key generated for the
+// session.
+type instancePrincipalSigner struct {
+	sender
+	metadataURL string
+	authURL     *url.URL
+
+	mu         sync.Mutex
+	tenancy    string
+	token      string
+	expiry     time.Time
+	sessionKey *rsa.PrivateKey
+}
+
+// key is part of the signer interface.
+func (s *instancePrincipalSigner) key(ctx context.Context) (string, *rsa.PrivateKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == "" || !s.clock.Now().Before(s.expiry.Add(-tokenRefreshMargin)) {
+		if err := s.refresh(ctx); err != nil {
+			return "", nil, errors.Trace(err)
+		}
+	}
+	return "ST$" + s.token, s.sessionKey, nil
+}
+
+// refresh obtains a new session token. The instance certificates are
+// fetched anew each time, as they are rotated regularly.
+func (s *instancePrincipalSigner) refresh(ctx context.Context) error {
+	certPEM, err := s.metadata(ctx, "cert.pem")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	intermediatePEM, err := s.metadata(ctx, "intermediate.pem")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	keyPEM, err := s.metadata(ctx, "key.pem")
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return errors.Annotate(err, "parsing instance certificate")
+	}
+	intermediate, err := parseCertificate(intermediatePEM)
+	if err != nil {
+		return errors.Annotate(err, "parsing intermediate certificate")
+	}
+	instanceKey, err := parsePrivateKey(string(keyPEM), "")
+	if err != nil {
+		return errors.Annotate(err, "parsing instance key")
+	}
+	tenancy, err := certificateTenancy(cert)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	sessionKey, err := rsa.GenerateKey(rand.Reader, sessionKeyBits)
+	if err != nil {
+		return errors.Annotate(err, "generating session key")
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&sessionKey.PublicKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	request := map[string]interface{}{
+		"certificate": base64.StdEncoding.EncodeToString(cert.Raw),
+		"publicKey":   base64.StdEncoding.EncodeToString(publicKey),
+		"intermediateCertificates": []string{
+			base64.StdEncoding.EncodeToString(intermediate.Raw),
+		},
+	}
+	var response struct {
+		Token string `json:"token"`
+	}
+	u := *s.authURL
+	u.Path = path.Join(u.Path, "v1", "x509")
+	keyId := tenancy + "/fed-x509/" + certificateFingerprint(cert)
+	if _, err := s.send(ctx, "POST", &u, request, &response, keyId, instanceKey); err != nil {
+		return errors.Annotate(err, "requesting session token")
+	}
+	expiry, err := tokenExpiry(response.Token)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	s.logger.Debugf("obtained session token for instance principal in tenancy %s, expiring at %s", tenancy, expiry)
+	s.tenancy = tenancy
+	s.token = response.Token
+	s.expiry = expiry
+	s.sessionKey = sessionKey
+	return nil
+}
+
+// metadata returns the named identity document from the instance
+// metadata service.
+func (s *instancePrincipalSigner) metadata(ctx context.Context, name string) ([]byte, error) {
+	req, err := http.NewRequest("GET", s.metadataURL+"/identity/"+name, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := s.http.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Annotatef(err, "fetching %s from instance metadata", name)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("fetching %s from instance metadata: %s", name, resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Annotatef(err, "fetching %s from instance metadata", name)
+	}
+	return data, nil
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.NotValidf("certificate: no PEM data")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// certificateTenancy returns the OCID of the tenancy recorded in the
+// subject of an instance certificate.
+func certificateTenancy(cert *x509.Certificate) (string, error) {
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		if strings.HasPrefix(ou, tenancyOUPrefix) {
+			return strings.TrimPrefix(ou, tenancyOUPrefix), nil
+		}
+	}
+	return "", errors.NotFoundf("tenancy in instance certificate")
+}
+
+// certificateFingerprint returns the SHA-1 fingerprint of the
+// certificate, formatted as colon-separated hex octets.
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha1.Sum(cert.Raw)
+	octets := make([]string, len(sum))
+	for i, b := range sum {
+		octets[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(octets, ":")
+}
+
+// tokenExpiry returns the expiry time recorded in the claims of a
+// session token, which is a JSON Web Token.
+func tokenExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.NotValidf("session token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, errors.Annotate(err, "decoding session token")
+	}
+	var claims struct {
+		Expiry int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, errors.Annotate(err, "decoding session token")
+	}
+	return time.Unix(claims.Expiry, 0), nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ociclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// signer provides the keys with which to sign API requests.
+type signer interface {
+	// key returns the id of the key with which to sign
+	// requests, and the key itself.
+	key(ctx context.Context) (string, *rsa.PrivateKey, error)
+}
+
+// apiKeySigner is a signer that signs requests with a user's API
+// signing key.
+type apiKeySigner struct {
+	keyId      string
+	privateKey *rsa.PrivateKey
+}
+
+// key is part of the signer interface.
+func (s *apiKeySigner) key(context.Context) (string, *rsa.PrivateKey, error) {
+	return s.keyId, s.privateKey, nil
+}
+
+// signRequest adds the Date and Authorization headers, and for
+// requests with a body the content headers, required to sign the
+// request with the given key. The body must be the request's body.
+func signRequest(req *http.Request, body []byte, keyId string, key *rsa.PrivateKey, now time.Time) error {
+	req.Header.Set("Date", now.UTC().Format(http.TimeFormat))
+	headers := []string{"date", "(request-target)", "host"}
+	if req.Method == "POST" || req.Method == "PUT" {
+		sum := sha256.Sum256(body)
+		req.ContentLength = int64(len(body))
+		req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		req.Header.Set("X-Content-Sha256", base64.StdEncoding.EncodeToString(sum[:]))
+		if req.Header.Get("Content-Type") == "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		headers = append(headers, "content-length", "content-type", "x-content-sha256")
+	}
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, signingDigest(req, headers))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf(
+		`Signature version="1",keyId="%s",algorithm="rsa-sha256",headers="%s",signature="%s"`,
+		keyId, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature),
+	))
+	return nil
+}
+
+// signingDigest returns the SHA-256 digest of the signing string
+// composed of the named headers of the request.
+func signingDigest(req *http.Request, headers []string) []byte {
+	lines := make([]string, len(headers))
+	for i, name := range headers {
+		var value string
+		switch name {
+		case "(request-target)":
+			value = strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+		case "host":
+			value = req.Host
+		default:
+			value = req.Header.Get(name)
+		}
+		lines[i] = name + ": " + value
+	}
+	digest := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return digest[:]
+}
+
+// parsePrivateKey parses a PEM-encoded RSA private key, decrypting it
+// with the given pass phrase if it is encrypted.
+func parsePrivateKey(data, passPhrase string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.NotValidf("private key: no PEM data")
+	}
+	der := block.Bytes
+	if x509.IsEncryptedPEMBlock(block) {
+		if passPhrase == "" {
+			return nil, errors.NotValidf("encrypted private key without pass phrase")
+		}
+		var err error
+		der, err = x509.DecryptPEMBlock(block, []byte(passPhrase))
+		if err != nil {
+			return nil, errors.Annotate(err, "decrypting private key")
+		}
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing private key")
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.NotValidf("private key of type %T", key)
+	}
+	return rsaKey, nil
+}
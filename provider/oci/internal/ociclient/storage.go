@@ -0,0 +1,190 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ociclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/juju/errors"
+)
+
+// CreateVolumeParams contains the parameters required for creating a
+// new block volume.
+type CreateVolumeParams struct {
+	// CompartmentId is the OCID of the compartment in which
+	// to create the volume.
+	CompartmentId string
+
+	// AvailabilityDomain is the name of the availability
+	// domain in which to create the volume. Volumes can only
+	// be attached to instances in the same domain.
+	AvailabilityDomain string
+
+	// DisplayName is the name to give the volume.
+	DisplayName string
+
+	// SizeInGBs is the size of the volume, in GB.
+	SizeInGBs uint64
+
+	// FreeformTags holds the tags to assign to the volume.
+	FreeformTags map[string]string
+}
+
+// CreateVolume creates a new block volume, and waits for it to become
+// available.
+func (c *Client) CreateVolume(ctx context.Context, args CreateVolumeParams) (_ *Volume, err error) {
+	spec := map[string]interface{}{
+		"compartmentId":      args.CompartmentId,
+		"availabilityDomain": args.AvailabilityDomain,
+		"displayName":        args.DisplayName,
+		"sizeInGBs":          args.SizeInGBs,
+		"freeformTags":       args.FreeformTags,
+	}
+	c.logger.Debugf("creating volume %q in %s", args.DisplayName, args.AvailabilityDomain)
+	var volume Volume
+	if err := c.post(ctx, c.coreURL, "volumes", spec, &volume); err != nil {
+		return nil, errors.Annotatef(err, "creating volume %q", args.DisplayName)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if err := c.DeleteVolume(ctx, volume.Id); err != nil {
+			c.logger.Warningf("failed to delete volume %q: %v", args.DisplayName, err)
+		}
+	}()
+
+	err = c.waitFor(ctx, fmt.Sprintf("volume %s to be available", volume.Id), func() (bool, error) {
+		if err := c.get(ctx, c.coreURL, path.Join("volumes", volume.Id), nil, &volume); err != nil {
+			return false, errors.Trace(err)
+		}
+		switch volume.LifecycleState {
+		case VolumeStateAvailable:
+			return true, nil
+		case VolumeStateFaulty, VolumeStateTerminated:
+			return false, errors.Errorf("volume %s is %s", volume.Id, volume.LifecycleState)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &volume, nil
+}
+
+// Volumes returns the block volumes in the given compartment that
+// have not been terminated.
+func (c *Client) Volumes(ctx context.Context, compartmentId string) ([]*Volume, error) {
+	var all []*Volume
+	query := url.Values{"compartmentId": {compartmentId}}
+	if err := c.list(ctx, c.coreURL, "volumes", query, &all); err != nil {
+		return nil, errors.Annotate(err, "listing volumes")
+	}
+	var result []*Volume
+	for _, volume := range all {
+		switch volume.LifecycleState {
+		case VolumeStateTerminating, VolumeStateTerminated:
+			continue
+		}
+		result = append(result, volume)
+	}
+	return result, nil
+}
+
+// DeleteVolume deletes the block volume with the given OCID.
+func (c *Client) DeleteVolume(ctx context.Context, id string) error {
+	c.logger.Debugf("deleting volume %s", id)
+	if err := c.delete(ctx, c.coreURL, path.Join("volumes", id)); err != nil {
+		return errors.Annotatef(err, "deleting volume %s", id)
+	}
+	return nil
+}
+
+// UpdateVolumeTags replaces the freeform tags of the block volume with
+// the given OCID.
+func (c *Client) UpdateVolumeTags(ctx context.Context, id string, tags map[string]string) error {
+	update := map[string]interface{}{"freeformTags": tags}
+	if err := c.put(ctx, c.coreURL, path.Join("volumes", id), update, nil); err != nil {
+		return errors.Annotatef(err, "updating tags of volume %s", id)
+	}
+	return nil
+}
+
+// InstanceDevices returns the device paths at which volumes may be
+// attached to the instance with the given OCID.
+func (c *Client) InstanceDevices(ctx context.Context, instanceId string) ([]*Device, error) {
+	var result []*Device
+	query := url.Values{"isAvailable": {"true"}}
+	p := path.Join("instances", instanceId, "devices")
+	if err := c.list(ctx, c.coreURL, p, query, &result); err != nil {
+		return nil, errors.Annotatef(err, "listing devices of instance %s", instanceId)
+	}
+	return result, nil
+}
+
+// AttachVolume attaches the block volume with the given OCID to an
+// instance at the given device path, using paravirtualized attachment,
+// and waits for the attachment to complete.
+func (c *Client) AttachVolume(ctx context.Context, instanceId, volumeId, device string) (*VolumeAttachment, error) {
+	spec := map[string]interface{}{
+		"type":       "paravirtualized",
+		"instanceId": instanceId,
+		"volumeId":   volumeId,
+		"device":     device,
+	}
+	c.logger.Debugf("attaching volume %s to instance %s at %s", volumeId, instanceId, device)
+	var attachment VolumeAttachment
+	if err := c.post(ctx, c.coreURL, "volumeAttachments", spec, &attachment); err != nil {
+		return nil, errors.Annotatef(err, "attaching volume %s to instance %s", volumeId, instanceId)
+	}
+	err := c.waitFor(ctx, fmt.Sprintf("volume %s to be attached", volumeId), func() (bool, error) {
+		p := path.Join("volumeAttachments", attachment.Id)
+		if err := c.get(ctx, c.coreURL, p, nil, &attachment); err != nil {
+			return false, errors.Trace(err)
+		}
+		switch attachment.LifecycleState {
+		case AttachmentStateAttached:
+			return true, nil
+		case AttachmentStateDetaching, AttachmentStateDetached:
+			return false, errors.Errorf("volume attachment %s is %s", attachment.Id, attachment.LifecycleState)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &attachment, nil
+}
+
+// VolumeAttachments returns the attachments of block volumes to the
+// instance with the given OCID, that have not been detached.
+func (c *Client) VolumeAttachments(ctx context.Context, compartmentId, instanceId string) ([]*VolumeAttachment, error) {
+	var all []*VolumeAttachment
+	query := url.Values{
+		"compartmentId": {compartmentId},
+		"instanceId":    {instanceId},
+	}
+	if err := c.list(ctx, c.coreURL, "volumeAttachments", query, &all); err != nil {
+		return nil, errors.Annotatef(err, "listing volume attachments of instance %s", instanceId)
+	}
+	var result []*VolumeAttachment
+	for _, attachment := range all {
+		if attachment.LifecycleState != AttachmentStateDetached {
+			result = append(result, attachment)
+		}
+	}
+	return result, nil
+}
+
+// DetachVolume removes the volume attachment with the given OCID.
+func (c *Client) DetachVolume(ctx context.Context, attachmentId string) error {
+	c.logger.Debugf("detaching volume attachment %s", attachmentId)
+	if err := c.delete(ctx, c.coreURL, path.Join("volumeAttachments", attachmentId)); err != nil {
+		return errors.Annotatef(err, "detaching volume attachment %s", attachmentId)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ociclient
+
+import "time"
+
+// The types in this file mirror the JSON representation of the OCI
+// core services and identity APIs (version 20160918). Only the
+// attributes that the Juju provider requires are included.
+
+// AvailabilityDomain describes an availability domain, an isolated
+// data center within a region.
+type AvailabilityDomain struct {
+	Name          string `json:"name"`
+	CompartmentId string `json:"compartmentId,omitempty"`
+}
+
+// Instance lifecycle states, as reported by the compute service.
+const (
+	InstanceStateProvisioning = "PROVISIONING"
+	InstanceStateStarting     = "STARTING"
+	InstanceStateRunning      = "RUNNING"
+	InstanceStateStopping     = "STOPPING"
+	InstanceStateStopped      = "STOPPED"
+	InstanceStateTerminating  = "TERMINATING"
+	InstanceStateTerminated   = "TERMINATED"
+)
+
+// Instance describes a compute instance.
+type Instance struct {
+	Id                 string            `json:"id"`
+	DisplayName        string            `json:"displayName,omitempty"`
+	CompartmentId      string            `json:"compartmentId"`
+	AvailabilityDomain string            `json:"availabilityDomain"`
+	Shape              string            `json:"shape"`
+	ImageId            string            `json:"imageId,omitempty"`
+	LifecycleState     string            `json:"lifecycleState"`
+	FreeformTags       map[string]string `json:"freeformTags,omitempty"`
+	TimeCreated        time.Time         `json:"timeCreated"`
+}
+
+// Shape describes an instance shape, which determines the resources
+// allocated to an instance.
+type Shape struct {
+	Shape       string  `json:"shape"`
+	OCPUs       float64 `json:"ocpus,omitempty"`
+	MemoryInGBs float64 `json:"memoryInGBs,omitempty"`
+	IsFlexible  bool    `json:"isFlexible,omitempty"`
+}
+
+// Image describes a boot image for instances.
+type Image struct {
+	Id                     string    `json:"id"`
+	DisplayName            string    `json:"displayName"`
+	CompartmentId          string    `json:"compartmentId,omitempty"`
+	OperatingSystem        string    `json:"operatingSystem"`
+	OperatingSystemVersion string    `json:"operatingSystemVersion"`
+	LifecycleState         string    `json:"lifecycleState"`
+	TimeCreated            time.Time `json:"timeCreated"`
+}
+
+// VnicAttachment describes the attachment of a VNIC to an instance.
+type VnicAttachment struct {
+	Id                 string `json:"id"`
+	InstanceId         string `json:"instanceId"`
+	VnicId             string `json:"vnicId,omitempty"`
+	SubnetId           string `json:"subnetId"`
+	NicIndex           int    `json:"nicIndex"`
+	VlanTag            int    `json:"vlanTag,omitempty"`
+	AvailabilityDomain string `json:"availabilityDomain"`
+	LifecycleState     string `json:"lifecycleState"`
+}
+
+// Vnic describes a virtual network interface card.
+type Vnic struct {
+	Id             string `json:"id"`
+	DisplayName    string `json:"displayName,omitempty"`
+	SubnetId       string `json:"subnetId"`
+	MacAddress     string `json:"macAddress"`
+	PrivateIp      string `json:"privateIp"`
+	PublicIp       string `json:"publicIp,omitempty"`
+	IsPrimary      bool   `json:"isPrimary"`
+	LifecycleState string `json:"lifecycleState"`
+}
+
+// Vcn describes a virtual cloud network.
+type Vcn struct {
+	Id             string `json:"id"`
+	DisplayName    string `json:"displayName"`
+	CompartmentId  string `json:"compartmentId"`
+	CidrBlock      string `json:"cidrBlock"`
+	LifecycleState string `json:"lifecycleState"`
+}
+
+// Subnet describes a subnet of a virtual cloud network. Regional
+// subnets have no availability domain, and span every availability
+// domain in the region.
+type Subnet struct {
+	Id                     string `json:"id"`
+	DisplayName            string `json:"displayName"`
+	CompartmentId          string `json:"compartmentId"`
+	VcnId                  string `json:"vcnId"`
+	CidrBlock              string `json:"cidrBlock"`
+	AvailabilityDomain     string `json:"availabilityDomain,omitempty"`
+	ProhibitPublicIpOnVnic bool   `json:"prohibitPublicIpOnVnic"`
+	VirtualRouterIp        string `json:"virtualRouterIp,omitempty"`
+	LifecycleState         string `json:"lifecycleState"`
+}
+
+// Volume lifecycle states, as reported by the block storage service.
+const (
+	VolumeStateProvisioning = "PROVISIONING"
+	VolumeStateAvailable    = "AVAILABLE"
+	VolumeStateTerminating  = "TERMINATING"
+	VolumeStateTerminated   = "TERMINATED"
+	VolumeStateFaulty       = "FAULTY"
+)
+
+// Volume describes a block volume.
+type Volume struct {
+	Id                 string            `json:"id"`
+	DisplayName        string            `json:"displayName"`
+	CompartmentId      string            `json:"compartmentId"`
+	AvailabilityDomain string            `json:"availabilityDomain"`
+	SizeInGBs          int64             `json:"sizeInGBs"`
+	LifecycleState     string            `json:"lifecycleState"`
+	FreeformTags       map[string]string `json:"freeformTags,omitempty"`
+}
+
+// Attachment lifecycle states of volume and VNIC attachments, as
+// reported by the compute service.
+const (
+	AttachmentStateAttaching = "ATTACHING"
+	AttachmentStateAttached  = "ATTACHED"
+	AttachmentStateDetaching = "DETACHING"
+	AttachmentStateDetached  = "DETACHED"
+)
+
+// VolumeAttachment describes the attachment of a block volume to an
+// instance.
+type VolumeAttachment struct {
+	Id             string `json:"id"`
+	AttachmentType string `json:"attachmentType"`
+	InstanceId     string `json:"instanceId"`
+	VolumeId       string `json:"volumeId"`
+	Device         string `json:"device,omitempty"`
+	LifecycleState string `json:"lifecycleState"`
+}
+
+// Device describes a device path at which volumes may be attached to
+// an instance.
+type Device struct {
+	Name        string `json:"name"`
+	IsAvailable bool   `json:"isAvailable"`
+}
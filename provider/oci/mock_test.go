@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci_test
+
+import (
+	"context"
+	"sync"
+
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/oci"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func newMockDialFunc(dialStub *testing.Stub, client oci.Client) oci.DialFunc {
+	return func(ctx context.Context, config ociclient.Config) (oci.Client, error) {
+		dialStub.AddCall("Dial", ctx, config)
+		if err := dialStub.NextErr(); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+}
+
+// mockClient is an oci.Client that records the OCI API requests made
+// by the provider, serving canned resources in place of a tenancy.
+type mockClient struct {
+	mu sync.Mutex
+	testing.Stub
+
+	tenancy             string
+	availabilityDomains []*ociclient.AvailabilityDomain
+	shapes              []*ociclient.Shape
+	images              []*ociclient.Image
+	launchedInstance    *ociclient.Instance
+	instances           []*ociclient.Instance
+	vnicAttachments     []*ociclient.VnicAttachment
+	vnics               map[string]*ociclient.Vnic
+	vcns                []*ociclient.Vcn
+	subnets             map[string][]*ociclient.Subnet
+	subnetsById         map[string]*ociclient.Subnet
+	createdVolume       *ociclient.Volume
+	volumes             []*ociclient.Volume
+	instanceDevices     []*ociclient.Device
+	attachedVolume      *ociclient.VolumeAttachment
+	volumeAttachments   []*ociclient.VolumeAttachment
+}
+
+// call records a request to the named API operation and returns the
+// next configured error. The client is shared by every session of an
+// environ and instances are terminated concurrently, so calls are
+// serialised to keep each recorded call paired with its error.
+func (c *mockClient) call(name string, args ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, name, args...)
+	return c.NextErr()
+}
+
+// Tenancy is not recorded as a method call,
+// as it does not make an API request.
+func (c *mockClient) Tenancy() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tenancy
+}
+
+func (c *mockClient) AttachVolume(ctx context.Context, instanceId, volumeId, device string) (*ociclient.VolumeAttachment, error) {
+	return c.attachedVolume, c.call("AttachVolume", ctx, instanceId, volumeId, device)
+}
+
+func (c *mockClient) AvailabilityDomains(ctx context.Context, compartmentId string) ([]*ociclient.AvailabilityDomain, error) {
+	return c.availabilityDomains, c.call("AvailabilityDomains", ctx, compartmentId)
+}
+
+func (c *mockClient) CreateVolume(ctx context.Context, args ociclient.CreateVolumeParams) (*ociclient.Volume, error) {
+	return c.createdVolume, c.call("CreateVolume", ctx, args)
+}
+
+func (c *mockClient) DeleteVolume(ctx context.Context, id string) error {
+	return c.call("DeleteVolume", ctx, id)
+}
+
+func (c *mockClient) DetachVolume(ctx context.Context, attachmentId string) error {
+	return c.call("DetachVolume", ctx, attachmentId)
+}
+
+func (c *mockClient) Images(ctx context.Context, compartmentId, operatingSystem, version string) ([]*ociclient.Image, error) {
+	return c.images, c.call("Images", ctx, compartmentId, operatingSystem, version)
+}
+
+func (c *mockClient) InstanceDevices(ctx context.Context, instanceId string) ([]*ociclient.Device, error) {
+	return c.instanceDevices, c.call("InstanceDevices", ctx, instanceId)
+}
+
+func (c *mockClient) Instances(ctx context.Context, compartmentId string) ([]*ociclient.Instance, error) {
+	return c.instances, c.call("Instances", ctx, compartmentId)
+}
+
+func (c *mockClient) LaunchInstance(ctx context.Context, args ociclient.LaunchInstanceParams) (*ociclient.Instance, error) {
+	return c.launchedInstance, c.call("LaunchInstance", ctx, args)
+}
+
+func (c *mockClient) Shapes(ctx context.Context, compartmentId string) ([]*ociclient.Shape, error) {
+	return c.shapes, c.call("Shapes", ctx, compartmentId)
+}
+
+func (c *mockClient) Subnet(ctx context.Context, id string) (*ociclient.Subnet, error) {
+	return c.subnetsById[id], c.call("Subnet", ctx, id)
+}
+
+func (c *mockClient) Subnets(ctx context.Context, compartmentId, vcnId string) ([]*ociclient.Subnet, error) {
+	return c.subnets[vcnId], c.call("Subnets", ctx, compartmentId, vcnId)
+}
+
+func (c *mockClient) TerminateInstance(ctx context.Context, id string) error {
+	return c.call("TerminateInstance", ctx, id)
+}
+
+func (c *mockClient) UpdateInstanceTags(ctx context.Context, id string, tags map[string]string) error {
+	return c.call("UpdateInstanceTags", ctx, id, tags)
+}
+
+func (c *mockClient) UpdateVolumeTags(ctx context.Context, id string, tags map[string]string) error {
+	return c.call("UpdateVolumeTags", ctx, id, tags)
+}
+
+func (c *mockClient) Vcns(ctx context.Context, compartmentId string) ([]*ociclient.Vcn, error) {
+	return c.vcns, c.call("Vcns", ctx, compartmentId)
+}
+
+func (c *mockClient) Vnic(ctx context.Context, id string) (*ociclient.Vnic, error) {
+	return c.vnics[id], c.call("Vnic", ctx, id)
+}
+
+func (c *mockClient) VnicAttachments(ctx context.Context, compartmentId, instanceId string) ([]*ociclient.VnicAttachment, error) {
+	return c.vnicAttachments, c.call("VnicAttachments", ctx, compartmentId, instanceId)
+}
+
+func (c *mockClient) VolumeAttachments(ctx context.Context, compartmentId, instanceId string) ([]*ociclient.VolumeAttachment, error) {
+	return c.volumeAttachments, c.call("VolumeAttachments", ctx, compartmentId, instanceId)
+}
+
+func (c *mockClient) Volumes(ctx context.Context, compartmentId string) ([]*ociclient.Volume, error) {
+	return c.volumes, c.call("Volumes", ctx, compartmentId)
+}
+
+const fakeModelUUID = "2d02eeac-9dbb-11e4-89d3-123b93f75cba"
+
+func newAvailabilityDomain(name string) *ociclient.AvailabilityDomain {
+	return &ociclient.AvailabilityDomain{Name: name, CompartmentId: fakeTenancy}
+}
+
+func newInstance(id, state string) *ociclient.Instance {
+	return &ociclient.Instance{
+		Id:                 id,
+		DisplayName:        "juju-" + id,
+		CompartmentId:      fakeCompartmentId,
+		AvailabilityDomain: "AD-1",
+		Shape:              "VM.Standard2.1",
+		LifecycleState:     state,
+		FreeformTags: map[string]string{
+			tags.JujuModel:      fakeModelUUID,
+			tags.JujuController: coretesting.ControllerTag.Id(),
+		},
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+)
+
+var logger = loggo.GetLogger("juju.provider.oci")
+
+const (
+	providerVersion1 = 1
+
+	currentProviderVersion = providerVersion1
+)
+
+type environProvider struct {
+	environProviderCredentials
+	dial DialFunc
+}
+
+// EnvironProviderConfig holds the dependencies of the OCI
+// EnvironProvider, which tests replace to avoid calling the OCI APIs.
+type EnvironProviderConfig struct {
+	// Dial is a function used for dialing connections to the
+	// OCI APIs.
+	Dial DialFunc
+}
+
+// NewEnvironProvider returns a new environs.EnvironProvider that will
+// dial OCI connections with the given dial function.
+func NewEnvironProvider(config EnvironProviderConfig) environs.EnvironProvider {
+	return &environProvider{
+		dial: config.Dial,
+	}
+}
+
+// Version implements environs.EnvironProvider.
+func (p *environProvider) Version() int {
+	return currentProviderVersion
+}
+
+// Open implements environs.EnvironProvider.
+func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	env, err := newEnviron(p, args.Cloud, args.Config)
+	return env, errors.Trace(err)
+}
+
+var cloudSchema = &jsonschema.Schema{
+	Type:     []jsonschema.Type{jsonschema.ObjectType},
+	Required: []string{cloud.AuthTypesKey, cloud.RegionsKey},
+	Order:    []string{cloud.AuthTypesKey, cloud.RegionsKey},
+	Properties: map[string]*jsonschema.Schema{
+		cloud.AuthTypesKey: {
+			Singular:    "auth type",
+			Plural:      "auth types",
+			Type:        []jsonschema.Type{jsonschema.ArrayType},
+			UniqueItems: jsonschema.Bool(true),
+			Items: &jsonschema.ItemSpec{
+				Schemas: []*jsonschema.Schema{{
+					Type: []jsonschema.Type{jsonschema.StringType},
+					Enum: []interface{}{
+						string(cloud.HTTPSigAuthType),
+						string(cloud.InstancePrincipalAuthType),
+					},
+				}},
+			},
+		},
+		cloud.RegionsKey: {
+			Type:     []jsonschema.Type{jsonschema.ObjectType},
+			Singular: "region",
+			Plural:   "regions",
+			AdditionalProperties: &jsonschema.Schema{
+				Type:          []jsonschema.Type{jsonschema.ObjectType},
+				MaxProperties: jsonschema.Int(1),
+				Properties: map[string]*jsonschema.Schema{
+					cloud.EndpointKey: &jsonschema.Schema{
+						Singular:      "the core services API endpoint url for the region",
+						Type:          []jsonschema.Type{jsonschema.StringType},
+						Format:        jsonschema.FormatURI,
+						Default:       "",
+						PromptDefault: "use the region's public endpoint",
+					},
+				},
+			},
+		},
+	},
+}
+
+// CloudSchema returns the schema for adding new clouds of this type.
+func (p *environProvider) CloudSchema() *jsonschema.Schema {
+	return cloudSchema
+}
+
+// Ping tests the connection to the cloud, to verify the endpoint is valid.
+func (p *environProvider) Ping(endpoint string) error {
+	u, err := apiEndpoint(endpoint, "")
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Dial without credentials. Dialing lists the tenancy's
+	// availability domains, and the core services API answers the
+	// unsigned request with a 401, which shows that it is served at
+	// the endpoint.
+	ctx := context.Background()
+	if _, err := p.dial(ctx, ociclient.Config{Endpoint: u}); err != nil {
+		if ociclient.IsUnauthorized(err) {
+			return nil
+		}
+		logger.Errorf("Unexpected error dialing OCI connection: %v", err)
+		return errors.Errorf("No OCI API available at %s", endpoint)
+	}
+	return nil
+}
+
+// PrepareConfig implements environs.EnvironProvider.
+func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return args.Config, nil
+}
+
+// Validate implements environs.EnvironProvider.
+func (*environProvider) Validate(cfg, old *config.Config) (valid *config.Config, err error) {
+	ecfg, err := newValidConfig(cfg, old)
+	if err != nil {
+		if old != nil {
+			return nil, errors.Annotate(err, "invalid config change")
+		}
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	return ecfg.Config, nil
+}
+
+func validateCloudSpec(spec environs.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Region == "" {
+		return errors.NotValidf("missing region")
+	}
+	if _, err := apiEndpoint(spec.Endpoint, spec.Region); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Credential == nil {
+		return errors.NotValidf("missing credential")
+	}
+	switch authType := spec.Credential.AuthType(); authType {
+	case cloud.HTTPSigAuthType, cloud.InstancePrincipalAuthType:
+	default:
+		return errors.NotSupportedf("%q auth-type", authType)
+	}
+	return nil
+}
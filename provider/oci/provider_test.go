@@ -0,0 +1,154 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+)
+
+type providerSuite struct {
+	ProviderFixture
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) TestRegistered(c *gc.C) {
+	provider, err := environs.Provider("oci")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider, gc.NotNil)
+}
+
+func (s *providerSuite) TestOpen(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: fakeConfig(c),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(env.Config().Name(), gc.Equals, "testenv")
+
+	// Opening the environ does not connect to the API.
+	s.dialStub.CheckNoCalls(c)
+}
+
+func (s *providerSuite) TestOpenInvalidCloudSpec(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Name = ""
+	s.testOpenError(c, spec, `validating cloud spec: cloud name "" not valid`)
+}
+
+func (s *providerSuite) TestOpenMissingRegion(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Region = ""
+	s.testOpenError(c, spec, `validating cloud spec: missing region not valid`)
+}
+
+func (s *providerSuite) TestOpenInvalidEndpoint(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Endpoint = "http://iaas.example.com"
+	s.testOpenError(c, spec, `validating cloud spec: invalid endpoint "http://iaas.example.com": expected an https URL`)
+}
+
+func (s *providerSuite) TestOpenMissingCredential(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Credential = nil
+	s.testOpenError(c, spec, `validating cloud spec: missing credential not valid`)
+}
+
+func (s *providerSuite) TestOpenUnsupportedCredential(c *gc.C) {
+	credential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{})
+	spec := fakeCloudSpec()
+	spec.Credential = &credential
+	s.testOpenError(c, spec, `validating cloud spec: "userpass" auth-type not supported`)
+}
+
+func (s *providerSuite) testOpenError(c *gc.C, spec environs.CloudSpec, expect string) {
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud:  spec,
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+func (s *providerSuite) TestPrepareConfig(c *gc.C) {
+	cfg, err := s.provider.PrepareConfig(environs.PrepareConfigParams{
+		Config: fakeConfig(c),
+		Cloud:  fakeCloudSpec(),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cfg, gc.NotNil)
+}
+
+func (s *providerSuite) TestValidate(c *gc.C) {
+	config := fakeConfig(c)
+	validCfg, err := s.provider.Validate(config, nil)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(config.AllAttrs(), gc.DeepEquals, validCfg.AllAttrs())
+}
+
+func (s *providerSuite) TestSchema(c *gc.C) {
+	y := []byte(`
+auth-types: [httpsig, instance-principal]
+regions:
+  us-phoenix-1: {}
+  us-ashburn-1:
+    endpoint: https://iaas.us-ashburn-1.oraclecloud.com
+`[1:])
+	var v interface{}
+	err := yaml.Unmarshal(y, &v)
+	c.Assert(err, jc.ErrorIsNil)
+	v, err = utils.ConformYAML(v)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.provider.CloudSchema().Validate(v)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type pingSuite struct {
+	ProviderFixture
+}
+
+var _ = gc.Suite(&pingSuite{})
+
+func (s *pingSuite) TestPingUnauthorized(c *gc.C) {
+	s.dialStub.SetErrors(&ociclient.Error{StatusCode: 401})
+	err := s.provider.Ping("iaas.us-phoenix-1.oraclecloud.com")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.dialStub.CheckCallNames(c, "Dial")
+	call := s.dialStub.Calls()[0]
+	c.Assert(call.Args, gc.HasLen, 2)
+	c.Assert(call.Args[0], gc.Implements, new(context.Context))
+	c.Assert(call.Args[1], jc.DeepEquals, ociclient.Config{
+		Endpoint: "https://iaas.us-phoenix-1.oraclecloud.com",
+	})
+}
+
+func (s *pingSuite) TestPingNoAPI(c *gc.C) {
+	s.dialStub.SetErrors(errors.New("connection refused"))
+	err := s.provider.Ping("https://iaas.us-phoenix-1.oraclecloud.com")
+	c.Assert(err, gc.ErrorMatches, "No OCI API available at https://iaas.us-phoenix-1.oraclecloud.com")
+}
+
+func (s *pingSuite) TestPingInvalidScheme(c *gc.C) {
+	err := s.provider.Ping("http://iaas.us-phoenix-1.oraclecloud.com")
+	c.Assert(err, gc.ErrorMatches, `invalid endpoint "http://iaas.us-phoenix-1.oraclecloud.com": expected an https URL`)
+	s.dialStub.CheckNoCalls(c)
+}
+
+func (s *pingSuite) TestPingUnsignedSucceeded(c *gc.C) {
+	// An endpoint that answers the unsigned request still
+	// serves the API, so Ping accepts it.
+	err := s.provider.Ping("iaas.us-phoenix-1.oraclecloud.com")
+	c.Assert(err, jc.ErrorIsNil)
+}
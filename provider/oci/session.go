@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"context"
+
+	"github.com/juju/juju/provider/common"
+)
+
+// sessionEnviron binds the environ's signed client to the compartment
+// in which a single exported environ method call operates. The client
+// is dialed once and shared by every session, since OCI requests are
+// signed individually and hold no connection state. Dialing is
+// deferred to the first session, as only then is the tenancy known
+// for an instance principal, and so the default compartment.
+type sessionEnviron struct {
+	*environ
+
+	ctx    context.Context
+	client Client
+
+	// compartmentId is the OCID of the compartment holding the
+	// model's instances, volumes and subnets: the configured
+	// compartment, or else the root compartment of the tenancy
+	// to which the credential belongs.
+	compartmentId string
+
+	// zones holds the region's availability domains, which are
+	// listed at most once per session: StartInstance may look a
+	// domain up by name and then fall back to the first, and
+	// Subnets reports every domain as a zone of a regional subnet.
+	zones []common.AvailabilityZone
+}
+
+func (env *environ) withSession(f func(*sessionEnviron) error) error {
+	ctx := context.Background()
+	client, err := env.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	compartmentId := env.environConfig().compartmentId()
+	if compartmentId == "" {
+		compartmentId = client.Tenancy()
+	}
+	return f(&sessionEnviron{
+		environ:       env,
+		ctx:           ctx,
+		client:        client,
+		compartmentId: compartmentId,
+	})
+}
@@ -0,0 +1,354 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	ociStorageProviderType = storage.ProviderType("oci")
+
+	// minVolumeSizeGB is the minimum size of a block volume.
+	minVolumeSizeGB = 50
+)
+
+// modelVolumes returns the block volumes created for the model.
+func (env *sessionEnviron) modelVolumes() ([]*ociclient.Volume, error) {
+	all, err := env.client.Volumes(env.ctx, env.compartmentId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelUUID := env.Config().UUID()
+	var results []*ociclient.Volume
+	for _, volume := range all {
+		if volume.FreeformTags[tags.JujuModel] == modelUUID {
+			results = append(results, volume)
+		}
+	}
+	return results, nil
+}
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (*environ) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return []storage.ProviderType{ociStorageProviderType}, nil
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == ociStorageProviderType {
+		return &storageProvider{env}, nil
+	}
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+// storageProvider is a storage.Provider that creates volumes as OCI
+// block volumes.
+type storageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+// VolumeSource is part of the storage.Provider interface.
+func (p *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	return &volumeSource{env: p.env}, nil
+}
+
+// FilesystemSource is part of the storage.Provider interface.
+func (p *storageProvider) FilesystemSource(cfg *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is part of the storage.Provider interface.
+func (p *storageProvider) Supports(kind storage.StorageKind) bool {
+	return kind == storage.StorageKindBlock
+}
+
+// Scope is part of the storage.Provider interface.
+func (p *storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is part of the storage.Provider interface.
+func (p *storageProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is part of the storage.Provider interface.
+func (p *storageProvider) Releasable() bool {
+	return true
+}
+
+// DefaultPools is part of the storage.Provider interface.
+func (p *storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// ValidateConfig is part of the storage.Provider interface.
+func (p *storageProvider) ValidateConfig(cfg *storage.Config) error {
+	return nil
+}
+
+// volumeSource is a storage.VolumeSource that creates volumes as OCI
+// block volumes, and attaches them to instances with paravirtualized
+// attachments.
+type volumeSource struct {
+	env *environ
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// CreateVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) CreateVolumes(params []storage.VolumeParams) (results []storage.CreateVolumesResult, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		// Volumes must be created in the availability
+		// domain of the instance they will be attached to.
+		instanceZones := make(map[instance.Id]string)
+		instances, err := env.modelInstances()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, inst := range instances {
+			instanceZones[instance.Id(inst.Id)] = inst.AvailabilityDomain
+		}
+
+		results = make([]storage.CreateVolumesResult, len(params))
+		for i, p := range params {
+			if err := v.ValidateVolumeParams(p); err != nil {
+				results[i].Error = err
+				continue
+			}
+			zone, ok := instanceZones[p.Attachment.InstanceId]
+			if !ok {
+				results[i].Error = errors.NotFoundf("instance %q", p.Attachment.InstanceId)
+				continue
+			}
+			results[i].Volume, results[i].Error = createVolume(env, zone, p)
+		}
+		return nil
+	})
+	return results, err
+}
+
+func createVolume(env *sessionEnviron, zone string, p storage.VolumeParams) (*storage.Volume, error) {
+	sizeGB := mibToGB(p.Size)
+	if sizeGB < minVolumeSizeGB {
+		sizeGB = minVolumeSizeGB
+	}
+	volume, err := env.client.CreateVolume(env.ctx, ociclient.CreateVolumeParams{
+		CompartmentId:      env.compartmentId,
+		AvailabilityDomain: zone,
+		DisplayName:        env.namespace.Value(p.Tag.String()),
+		SizeInGBs:          sizeGB,
+		FreeformTags:       p.ResourceTags,
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating volume %s", p.Tag.Id())
+	}
+	return &storage.Volume{
+		Tag:        p.Tag,
+		VolumeInfo: makeVolumeInfo(volume),
+	}, nil
+}
+
+func makeVolumeInfo(volume *ociclient.Volume) storage.VolumeInfo {
+	return storage.VolumeInfo{
+		VolumeId:   volume.Id,
+		Size:       uint64(volume.SizeInGBs) * 1024,
+		Persistent: true,
+	}
+}
+
+// ListVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ListVolumes() (volumeIds []string, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		volumes, err := env.modelVolumes()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeIds = make([]string, len(volumes))
+		for i, volume := range volumes {
+			volumeIds[i] = volume.Id
+		}
+		return nil
+	})
+	return volumeIds, err
+}
+
+// DescribeVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DescribeVolumes(volumeIds []string) (results []storage.DescribeVolumesResult, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		volumes, err := env.modelVolumes()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		byId := make(map[string]*ociclient.Volume)
+		for _, volume := range volumes {
+			byId[volume.Id] = volume
+		}
+		results = make([]storage.DescribeVolumesResult, len(volumeIds))
+		for i, id := range volumeIds {
+			volume, ok := byId[id]
+			if !ok {
+				results[i].Error = errors.NotFoundf("volume %q", id)
+				continue
+			}
+			info := makeVolumeInfo(volume)
+			results[i].VolumeInfo = &info
+		}
+		return nil
+	})
+	return results, err
+}
+
+// DestroyVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DestroyVolumes(volumeIds []string) (results []error, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		results = foreachVolume(volumeIds, func(id string) error {
+			err := env.client.DeleteVolume(env.ctx, id)
+			if ociclient.IsNotFound(err) {
+				// The volume has already been deleted.
+				return nil
+			}
+			return err
+		})
+		return nil
+	})
+	return results, err
+}
+
+// ReleaseVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ReleaseVolumes(volumeIds []string) (results []error, err error) {
+	// Removing the Juju tags disassociates the volume from
+	// the model and controller, so Juju will no longer manage it.
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		volumes, err := env.modelVolumes()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		byId := make(map[string]*ociclient.Volume)
+		for _, volume := range volumes {
+			byId[volume.Id] = volume
+		}
+		results = foreachVolume(volumeIds, func(id string) error {
+			volume, ok := byId[id]
+			if !ok {
+				return errors.NotFoundf("volume %q", id)
+			}
+			newTags := make(map[string]string)
+			for k, v := range volume.FreeformTags {
+				if !strings.HasPrefix(k, tags.JujuTagPrefix) {
+					newTags[k] = v
+				}
+			}
+			return env.client.UpdateVolumeTags(env.ctx, id, newTags)
+		})
+		return nil
+	})
+	return results, err
+}
+
+func foreachVolume(volumeIds []string, f func(string) error) []error {
+	results := make([]error, len(volumeIds))
+	for i, id := range volumeIds {
+		results[i] = f(id)
+	}
+	return results
+}
+
+// ValidateVolumeParams is part of the storage.VolumeSource interface.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	// Block volumes can only be attached to instances in the
+	// same availability domain, so we must know the instance
+	// before creating the volume.
+	if params.Attachment == nil || params.Attachment.InstanceId == "" {
+		return errors.NotSupportedf("creating volume %s without an attachment", params.Tag.Id())
+	}
+	return nil
+}
+
+// AttachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) (results []storage.AttachVolumesResult, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		results = make([]storage.AttachVolumesResult, len(params))
+		for i, p := range params {
+			results[i].VolumeAttachment, results[i].Error = attachVolume(env, p)
+		}
+		return nil
+	})
+	return results, err
+}
+
+func attachVolume(env *sessionEnviron, p storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	instanceId := string(p.InstanceId)
+	attachment, err := volumeAttachment(env, instanceId, p.VolumeId)
+	if errors.IsNotFound(err) {
+		// Volumes are attached at a consistent device path,
+		// chosen from those available on the instance.
+		devices, err := env.client.InstanceDevices(env.ctx, instanceId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(devices) == 0 {
+			return nil, errors.NotFoundf("available device paths on instance %s", instanceId)
+		}
+		attachment, err = env.client.AttachVolume(env.ctx, instanceId, p.VolumeId, devices[0].Name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeAttachment{
+		Volume:  p.Volume,
+		Machine: p.Machine,
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: attachment.Device,
+		},
+	}, nil
+}
+
+// volumeAttachment returns the attachment of the volume with the given
+// OCID to the instance with the given OCID, or a NotFound error if the
+// volume is not attached to the instance.
+func volumeAttachment(env *sessionEnviron, instanceId, volumeId string) (*ociclient.VolumeAttachment, error) {
+	attachments, err := env.client.VolumeAttachments(env.ctx, env.compartmentId, instanceId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, attachment := range attachments {
+		if attachment.VolumeId == volumeId {
+			return attachment, nil
+		}
+	}
+	return nil, errors.NotFoundf("attachment of volume %s to instance %s", volumeId, instanceId)
+}
+
+// DetachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) (results []error, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		results = make([]error, len(params))
+		for i, p := range params {
+			attachment, err := volumeAttachment(env, string(p.InstanceId), p.VolumeId)
+			if errors.IsNotFound(err) {
+				// The volume is not attached.
+				continue
+			} else if err != nil {
+				results[i] = errors.Trace(err)
+				continue
+			}
+			results[i] = env.client.DetachVolume(env.ctx, attachment.Id)
+		}
+		return nil
+	})
+	return results, err
+}
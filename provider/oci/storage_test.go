@@ -0,0 +1,269 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/oci/internal/ociclient"
+	"github.com/juju/juju/storage"
+)
+
+type storageSuite struct {
+	EnvironFixture
+	provider storage.Provider
+	source   storage.VolumeSource
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	registry, ok := s.env.(storage.ProviderRegistry)
+	c.Assert(ok, jc.IsTrue)
+	types, err := registry.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, []storage.ProviderType{"oci"})
+	s.provider, err = registry.StorageProvider("oci")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := storage.NewConfig("oci", "oci", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.source, err = s.provider.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestProvider(c *gc.C) {
+	c.Assert(s.provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(s.provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+	c.Assert(s.provider.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(s.provider.Dynamic(), jc.IsTrue)
+	c.Assert(s.provider.Releasable(), jc.IsTrue)
+}
+
+func (s *storageSuite) TestCreateVolumes(c *gc.C) {
+	inst := newInstance("inst-0", ociclient.InstanceStateRunning)
+	inst.AvailabilityDomain = "AD-2"
+	s.client.instances = []*ociclient.Instance{inst}
+	s.client.createdVolume = &ociclient.Volume{
+		Id:        "vol-0",
+		SizeInGBs: 50,
+	}
+
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+		ResourceTags: map[string]string{
+			tags.JujuController: "foo",
+		},
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Machine:    names.NewMachineTag("0"),
+				InstanceId: "inst-0",
+			},
+			Volume: names.NewVolumeTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		Tag: names.NewVolumeTag("0"),
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId:   "vol-0",
+			Size:       50 * 1024,
+			Persistent: true,
+		},
+	})
+
+	s.client.CheckCallNames(c, "Instances", "CreateVolume")
+	c.Assert(s.client.Calls()[1].Args[1], jc.DeepEquals, ociclient.CreateVolumeParams{
+		CompartmentId:      fakeCompartmentId,
+		AvailabilityDomain: "AD-2",
+		DisplayName:        "juju-f75cba-volume-0",
+		SizeInGBs:          50,
+		FreeformTags: map[string]string{
+			tags.JujuController: "foo",
+		},
+	})
+}
+
+func (s *storageSuite) TestCreateVolumesNoAttachment(c *gc.C) {
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "creating volume 0 without an attachment not supported")
+	s.client.CheckCallNames(c, "Instances")
+}
+
+func (s *storageSuite) TestCreateVolumesInstanceNotFound(c *gc.C) {
+	results, err := s.source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Machine:    names.NewMachineTag("0"),
+				InstanceId: "inst-0",
+			},
+			Volume: names.NewVolumeTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestListVolumes(c *gc.C) {
+	s.client.volumes = []*ociclient.Volume{
+		{Id: "vol-0", FreeformTags: map[string]string{tags.JujuModel: fakeModelUUID}},
+		{Id: "vol-1", FreeformTags: map[string]string{tags.JujuModel: "deadbeef-0bad-400d-8000-4b1d0d06f00d"}},
+		{Id: "vol-2"},
+	}
+	volumeIds, err := s.source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.DeepEquals, []string{"vol-0"})
+	s.client.CheckCallNames(c, "Volumes")
+	c.Assert(s.client.Calls()[0].Args[1], gc.Equals, fakeCompartmentId)
+}
+
+func (s *storageSuite) TestDescribeVolumes(c *gc.C) {
+	s.client.volumes = []*ociclient.Volume{
+		{Id: "vol-0", SizeInGBs: 100, FreeformTags: map[string]string{tags.JujuModel: fakeModelUUID}},
+	}
+	results, err := s.source.DescribeVolumes([]string{"vol-0", "vol-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId:   "vol-0",
+		Size:       100 * 1024,
+		Persistent: true,
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `volume "vol-1" not found`)
+}
+
+func (s *storageSuite) TestDestroyVolumes(c *gc.C) {
+	s.client.SetErrors(nil, &ociclient.Error{StatusCode: 404}, errors.New("nope"))
+	results, err := s.source.DestroyVolumes([]string{"vol-0", "vol-1", "vol-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], jc.ErrorIsNil)
+	c.Assert(results[2], gc.ErrorMatches, "nope")
+	s.client.CheckCallNames(c, "DeleteVolume", "DeleteVolume", "DeleteVolume")
+}
+
+func (s *storageSuite) TestReleaseVolumes(c *gc.C) {
+	s.client.volumes = []*ociclient.Volume{{
+		Id: "vol-0",
+		FreeformTags: map[string]string{
+			tags.JujuModel:      fakeModelUUID,
+			tags.JujuController: "foo",
+			"owner":             "bob",
+		},
+	}}
+	results, err := s.source.ReleaseVolumes([]string{"vol-0", "vol-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, `volume "vol-1" not found`)
+
+	s.client.CheckCallNames(c, "Volumes", "UpdateVolumeTags")
+	s.client.CheckCall(c, 1, "UpdateVolumeTags", s.client.Calls()[1].Args[0], "vol-0", map[string]string{
+		"owner": "bob",
+	})
+}
+
+func (s *storageSuite) attachmentParams() []storage.VolumeAttachmentParams {
+	return []storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-0",
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+	}}
+}
+
+func (s *storageSuite) TestAttachVolumes(c *gc.C) {
+	s.client.instanceDevices = []*ociclient.Device{
+		{Name: "/dev/oracleoci/oraclevdb", IsAvailable: true},
+		{Name: "/dev/oracleoci/oraclevdc", IsAvailable: true},
+	}
+	s.client.attachedVolume = &ociclient.VolumeAttachment{
+		Id:             "att-0",
+		InstanceId:     "inst-0",
+		VolumeId:       "vol-0",
+		Device:         "/dev/oracleoci/oraclevdb",
+		LifecycleState: ociclient.AttachmentStateAttached,
+	}
+
+	results, err := s.source.AttachVolumes(s.attachmentParams())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment, jc.DeepEquals, &storage.VolumeAttachment{
+		Volume:  names.NewVolumeTag("0"),
+		Machine: names.NewMachineTag("0"),
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: "/dev/oracleoci/oraclevdb",
+		},
+	})
+	s.client.CheckCallNames(c, "VolumeAttachments", "InstanceDevices", "AttachVolume")
+	s.client.CheckCall(c, 2, "AttachVolume", s.client.Calls()[2].Args[0],
+		"inst-0", "vol-0", "/dev/oracleoci/oraclevdb")
+}
+
+func (s *storageSuite) TestAttachVolumesAlreadyAttached(c *gc.C) {
+	s.client.volumeAttachments = []*ociclient.VolumeAttachment{{
+		Id:             "att-0",
+		InstanceId:     "inst-0",
+		VolumeId:       "vol-0",
+		Device:         "/dev/oracleoci/oraclevdc",
+		LifecycleState: ociclient.AttachmentStateAttached,
+	}}
+
+	results, err := s.source.AttachVolumes(s.attachmentParams())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment.DeviceLink, gc.Equals, "/dev/oracleoci/oraclevdc")
+	s.client.CheckCallNames(c, "VolumeAttachments")
+}
+
+func (s *storageSuite) TestAttachVolumesNoDevices(c *gc.C) {
+	results, err := s.source.AttachVolumes(s.attachmentParams())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "available device paths on instance inst-0 not found")
+}
+
+func (s *storageSuite) TestDetachVolumes(c *gc.C) {
+	s.client.volumeAttachments = []*ociclient.VolumeAttachment{{
+		Id:             "att-0",
+		InstanceId:     "inst-0",
+		VolumeId:       "vol-0",
+		LifecycleState: ociclient.AttachmentStateAttached,
+	}}
+	results, err := s.source.DetachVolumes(s.attachmentParams())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0], jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "VolumeAttachments", "DetachVolume")
+	s.client.CheckCall(c, 1, "DetachVolume", s.client.Calls()[1].Args[0], "att-0")
+}
+
+func (s *storageSuite) TestDetachVolumesNotAttached(c *gc.C) {
+	results, err := s.source.DetachVolumes(s.attachmentParams())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0], jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "VolumeAttachments")
+}
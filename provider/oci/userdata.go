@@ -0,0 +1,27 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package oci
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	jujuos "github.com/juju/utils/os"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/providerinit/renderers"
+)
+
+// OCIRenderer renders cloud-init user-data for OCI instances. The
+// user-data is passed in the instance metadata, which requires it to
+// be base64-encoded.
+type OCIRenderer struct{}
+
+func (OCIRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
+	switch os {
+	case jujuos.Ubuntu, jujuos.CentOS:
+		return renderers.RenderYAML(cfg, utils.Gzip, renderers.ToBase64)
+	default:
+		return nil, errors.Errorf("Cannot encode userdata for OS: %s", os.String())
+	}
+}
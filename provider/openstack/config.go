@@ -29,13 +29,28 @@ var configSchema = environschema.Fields{
 		Description: "The network label or UUID to create floating IP addresses on when multiple external networks exist.",
 		Type:        environschema.Tstring,
 	},
+	"boot-from-volume": {
+		Description: "Whether new machine instances should boot from a Cinder volume rather than the flavor's ephemeral disk. The size of the volume is taken from the root-disk constraint.",
+		Type:        environschema.Tbool,
+	},
+	"root-volume-type": {
+		Description: "The Cinder volume type to use for root volumes when boot-from-volume is enabled. If empty, the cloud's default volume type is used.",
+		Type:        environschema.Tstring,
+	},
+	"root-volume-delete-on-termination": {
+		Description: "Whether root volumes created for boot-from-volume should be deleted when the instance is terminated.",
+		Type:        environschema.Tbool,
+	},
 }
 
 var configDefaults = schema.Defaults{
-	"use-floating-ip":      false,
-	"use-default-secgroup": false,
-	"network":              "",
-	"external-network":     "",
+	"use-floating-ip":                   false,
+	"use-default-secgroup":              false,
+	"network":                           "",
+	"external-network":                  "",
+	"boot-from-volume":                  false,
+	"root-volume-type":                  "",
+	"root-volume-delete-on-termination": true,
 }
 
 var configFields = func() schema.Fields {
@@ -67,6 +82,18 @@ func (c *environConfig) externalNetwork() string {
 	return c.attrs["external-network"].(string)
 }
 
+func (c *environConfig) bootFromVolume() bool {
+	return c.attrs["boot-from-volume"].(bool)
+}
+
+func (c *environConfig) rootVolumeType() string {
+	return c.attrs["root-volume-type"].(string)
+}
+
+func (c *environConfig) rootVolumeDeleteOnTermination() bool {
+	return c.attrs["root-volume-delete-on-termination"].(bool)
+}
+
 type AuthMode string
 
 const (
@@ -6,10 +6,13 @@ package openstack
 import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/goose.v2/nova"
 
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/testing"
 )
 
@@ -38,6 +41,9 @@ type configTest struct {
 	sslHostnameVerification bool
 	sslHostnameSet          bool
 	blockStorageSource      string
+	bootFromVolume          bool
+	rootVolumeType          string
+	keepRootVolume          bool
 }
 
 var requiredConfig = testing.Attrs{}
@@ -98,6 +104,9 @@ func (t configTest) check(c *gc.C) {
 	c.Assert(ecfg.useDefaultSecurityGroup(), gc.Equals, t.useDefaultSecurityGroup)
 	c.Assert(ecfg.network(), gc.Equals, t.network)
 	c.Assert(ecfg.externalNetwork(), gc.Equals, t.externalNetwork)
+	c.Assert(ecfg.bootFromVolume(), gc.Equals, t.bootFromVolume)
+	c.Assert(ecfg.rootVolumeType(), gc.Equals, t.rootVolumeType)
+	c.Assert(ecfg.rootVolumeDeleteOnTermination(), gc.Equals, !t.keepRootVolume)
 	// Default should be true
 	expectedHostnameVerification := true
 	if t.sslHostnameSet {
@@ -226,6 +235,20 @@ var configTests = []configTest{
 			"storage-default-block-source": "my-cinder",
 		}),
 		blockStorageSource: "my-cinder",
+	}, {
+		summary:        "default boot from volume",
+		config:         requiredConfig,
+		bootFromVolume: false,
+	}, {
+		summary: "boot from volume",
+		config: requiredConfig.Merge(testing.Attrs{
+			"boot-from-volume":                  true,
+			"root-volume-type":                  "ssd",
+			"root-volume-delete-on-termination": false,
+		}),
+		bootFromVolume: true,
+		rootVolumeType: "ssd",
+		keepRootVolume: true,
 	},
 }
 
@@ -300,3 +323,55 @@ func (*ConfigSuite) TestSchema(c *gc.C) {
 		c.Check(fields[name], jc.DeepEquals, field)
 	}
 }
+
+func (*ConfigSuite) TestRootVolumeBlockDeviceMapping(c *gc.C) {
+	attrs := testing.FakeConfig().Merge(testing.Attrs{
+		"type":             "openstack",
+		"boot-from-volume": true,
+		"root-volume-type": "ssd",
+	})
+	cfg, err := config.New(config.NoDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	valid, err := providerInstance.Validate(cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	ecfg := &environConfig{valid, valid.UnknownAttrs()}
+
+	for i, test := range []struct {
+		about    string
+		cons     string
+		flavor   uint64
+		expected int
+	}{{
+		about:    "default size",
+		expected: 30,
+	}, {
+		about:    "flavor disk size",
+		flavor:   20 * 1024,
+		expected: 20,
+	}, {
+		about:    "root-disk constraint overrides flavor",
+		cons:     "root-disk=50G",
+		flavor:   20 * 1024,
+		expected: 50,
+	}, {
+		about:    "root-disk constraint rounded up",
+		cons:     "root-disk=1500M",
+		expected: 2,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		mapping, size := rootVolumeBlockDeviceMapping(
+			ecfg, "image-id", constraints.MustParse(test.cons),
+			instances.InstanceType{RootDisk: test.flavor},
+		)
+		c.Check(mapping, jc.DeepEquals, nova.BlockDeviceMapping{
+			BootIndex:           0,
+			UUID:                "image-id",
+			SourceType:          "image",
+			DestinationType:     "volume",
+			VolumeSize:          test.expected,
+			VolumeType:          "ssd",
+			DeleteOnTermination: true,
+		})
+		c.Check(size, gc.Equals, uint64(test.expected*1024))
+	}
+}
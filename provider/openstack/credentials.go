@@ -27,6 +27,9 @@ const (
 	CredAttrUserDomainName    = "user-domain-name"
 	CredAttrAccessKey         = "access-key"
 	CredAttrSecretKey         = "secret-key"
+
+	CredAttrApplicationCredentialID     = "application-credential-id"
+	CredAttrApplicationCredentialSecret = "application-credential-secret"
 )
 
 type OpenstackCredentials struct{}
@@ -73,6 +76,18 @@ func (OpenstackCredentials) CredentialSchemas() map[cloud.AuthType]cloud.Credent
 				CredAttrTenantName, cloud.CredentialAttr{Description: "The OpenStack tenant name."},
 			},
 		},
+		cloud.ApplicationCredentialAuthType: {
+			{
+				CredAttrApplicationCredentialID, cloud.CredentialAttr{
+					Description: "The id of the Keystone application credential.",
+				},
+			}, {
+				CredAttrApplicationCredentialSecret, cloud.CredentialAttr{
+					Description: "The secret of the Keystone application credential.",
+					Hidden:      true,
+				},
+			},
+		},
 	}
 }
 
@@ -116,6 +131,9 @@ func (c OpenstackCredentials) DetectCredentials() (*cloud.CloudCredential, error
 
 func (c OpenstackCredentials) detectCredential() (*cloud.Credential, string, string, error) {
 	creds := identity.CredentialsFromEnv()
+	if id := os.Getenv("OS_APPLICATION_CREDENTIAL_ID"); id != "" {
+		return c.detectApplicationCredential(id, creds.Region)
+	}
 	if creds.TenantName == "" {
 		return nil, "", "", errors.NewNotFound(nil, "OS_TENANT_NAME environment variable not set")
 	}
@@ -164,6 +182,28 @@ func (c OpenstackCredentials) detectCredential() (*cloud.Credential, string, str
 	return &credential, user, creds.Region, nil
 }
 
+// detectApplicationCredential returns the application credential with
+// the given id, whose secret is in $OS_APPLICATION_CREDENTIAL_SECRET.
+func (c OpenstackCredentials) detectApplicationCredential(id, region string) (*cloud.Credential, string, string, error) {
+	secret := os.Getenv("OS_APPLICATION_CREDENTIAL_SECRET")
+	if secret == "" {
+		return nil, "", "", errors.NewNotFound(nil, "OS_APPLICATION_CREDENTIAL_SECRET environment variable not set")
+	}
+	credential := cloud.NewCredential(
+		cloud.ApplicationCredentialAuthType,
+		map[string]string{
+			CredAttrApplicationCredentialID:     id,
+			CredAttrApplicationCredentialSecret: secret,
+		},
+	)
+	displayRegion := region
+	if displayRegion == "" {
+		displayRegion = "<unspecified>"
+	}
+	credential.Label = fmt.Sprintf("openstack region %q application credential %q", displayRegion, id)
+	return &credential, id, region, nil
+}
+
 // FinalizeCredential is part of the environs.ProviderCredentials interface.
 func (OpenstackCredentials) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
 	return &args.Credential, nil
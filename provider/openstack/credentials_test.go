@@ -35,7 +35,7 @@ func (s *credentialsSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *credentialsSuite) TestCredentialSchemas(c *gc.C) {
-	envtesting.AssertProviderAuthTypes(c, s.provider, "access-key", "userpass")
+	envtesting.AssertProviderAuthTypes(c, s.provider, "access-key", "application-credential", "userpass")
 }
 
 func (s *credentialsSuite) TestAccessKeyCredentialsValid(c *gc.C) {
@@ -62,6 +62,17 @@ func (s *credentialsSuite) TestUserPassHiddenAttributes(c *gc.C) {
 	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "userpass", "password")
 }
 
+func (s *credentialsSuite) TestApplicationCredentialCredentialsValid(c *gc.C) {
+	envtesting.AssertProviderCredentialsValid(c, s.provider, "application-credential", map[string]string{
+		"application-credential-id":     "app-cred-id",
+		"application-credential-secret": "app-cred-secret",
+	})
+}
+
+func (s *credentialsSuite) TestApplicationCredentialHiddenAttributes(c *gc.C) {
+	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "application-credential", "application-credential-secret")
+}
+
 func (s *credentialsSuite) TestDetectCredentialsNotFound(c *gc.C) {
 	// No environment variables set, so no credentials should be found.
 	_, err := s.provider.DetectCredentials()
@@ -114,6 +125,31 @@ func (s *credentialsSuite) TestDetectCredentialsUserPassEnvironmentVariables(c *
 	c.Assert(credentials.AuthCredentials["bob"], jc.DeepEquals, expected)
 }
 
+func (s *credentialsSuite) TestDetectCredentialsApplicationCredentialEnvironmentVariables(c *gc.C) {
+	s.PatchEnvironment("USER", "fred")
+	s.PatchEnvironment("OS_APPLICATION_CREDENTIAL_ID", "app-cred-id")
+	s.PatchEnvironment("OS_APPLICATION_CREDENTIAL_SECRET", "app-cred-secret")
+	s.PatchEnvironment("OS_REGION_NAME", "west")
+
+	credentials, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credentials.DefaultRegion, gc.Equals, "west")
+	expected := cloud.NewCredential(
+		cloud.ApplicationCredentialAuthType, map[string]string{
+			"application-credential-id":     "app-cred-id",
+			"application-credential-secret": "app-cred-secret",
+		},
+	)
+	expected.Label = `openstack region "west" application credential "app-cred-id"`
+	c.Assert(credentials.AuthCredentials["app-cred-id"], jc.DeepEquals, expected)
+}
+
+func (s *credentialsSuite) TestDetectCredentialsApplicationCredentialNoSecret(c *gc.C) {
+	s.PatchEnvironment("OS_APPLICATION_CREDENTIAL_ID", "app-cred-id")
+	_, err := s.provider.DetectCredentials()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *credentialsSuite) TestDetectCredentialsUserPassDefaultDomain(c *gc.C) {
 	s.PatchEnvironment("USER", "fred")
 	s.PatchEnvironment("OS_PROJECT_NAME", "gary")
@@ -0,0 +1,477 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"gopkg.in/goose.v2/client"
+	gooseerrors "gopkg.in/goose.v2/errors"
+	goosehttp "gopkg.in/goose.v2/http"
+	"gopkg.in/goose.v2/identity"
+	gooselogging "gopkg.in/goose.v2/logging"
+
+	"github.com/juju/juju/environs"
+)
+
+// goose only supports password and access key authentication, so
+// keystoneClient implements the parts of the Keystone v3 identity API
+// needed to authenticate with, and to manage, application credentials.
+
+// applicationCredential holds the id and secret of a Keystone
+// application credential.
+type applicationCredential struct {
+	ID     string
+	Secret string
+}
+
+// keystoneToken holds a token issued by Keystone, and the details of
+// the user, project and service catalog it was issued for.
+type keystoneToken struct {
+	id        string
+	expiresAt time.Time
+	userId    string
+	projectId string
+
+	// regionServiceURLs holds the public endpoint of each service in
+	// the catalog, keyed by region and then by service type.
+	regionServiceURLs map[string]identity.ServiceURLs
+}
+
+// keystoneClient is a client for the Keystone v3 identity API.
+type keystoneClient struct {
+	http *http.Client
+	url  *url.URL
+}
+
+// newKeystoneClient returns a client for the Keystone v3 identity API
+// at the given auth-url, which may omit the API version.
+func newKeystoneClient(authURL string, httpClient *http.Client) (*keystoneClient, error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing auth-url")
+	}
+	switch p := strings.TrimSuffix(u.Path, "/"); strings.ToLower(path.Base(p)) {
+	case "v3":
+		u.Path = p
+	case "", ".", "/":
+		u.Path = "/v3"
+	default:
+		return nil, errors.NotSupportedf("application credentials with identity API %q", path.Base(p))
+	}
+	return &keystoneClient{http: httpClient, url: u}, nil
+}
+
+type applicationCredentialAuthRequest struct {
+	Auth struct {
+		Identity struct {
+			Methods               []string                  `json:"methods"`
+			ApplicationCredential applicationCredentialAuth `json:"application_credential"`
+		} `json:"identity"`
+	} `json:"auth"`
+}
+
+type applicationCredentialAuth struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+}
+
+type tokenResponse struct {
+	Token struct {
+		ExpiresAt time.Time `json:"expires_at"`
+		User      struct {
+			ID string `json:"id"`
+		} `json:"user"`
+		Project struct {
+			ID string `json:"id"`
+		} `json:"project"`
+		Catalog []struct {
+			Type      string `json:"type"`
+			Endpoints []struct {
+				Interface string `json:"interface"`
+				Region    string `json:"region"`
+				RegionID  string `json:"region_id"`
+				URL       string `json:"url"`
+			} `json:"endpoints"`
+		} `json:"catalog"`
+	} `json:"token"`
+}
+
+// authenticate exchanges the application credential for a token. The
+// token is scoped to the project that the application credential was
+// created in.
+func (c *keystoneClient) authenticate(cred applicationCredential) (*keystoneToken, error) {
+	var req applicationCredentialAuthRequest
+	req.Auth.Identity.Methods = []string{"application_credential"}
+	req.Auth.Identity.ApplicationCredential = applicationCredentialAuth{
+		ID:     cred.ID,
+		Secret: cred.Secret,
+	}
+	var resp tokenResponse
+	header, err := c.do("POST", "auth/tokens", "", &req, &resp)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	token := &keystoneToken{
+		id:                header.Get("X-Subject-Token"),
+		expiresAt:         resp.Token.ExpiresAt,
+		userId:            resp.Token.User.ID,
+		projectId:         resp.Token.Project.ID,
+		regionServiceURLs: make(map[string]identity.ServiceURLs),
+	}
+	if token.id == "" {
+		return nil, errors.New("no token in authentication response")
+	}
+	for _, service := range resp.Token.Catalog {
+		for _, endpoint := range service.Endpoints {
+			if endpoint.Interface != "public" {
+				continue
+			}
+			region := endpoint.RegionID
+			if region == "" {
+				region = endpoint.Region
+			}
+			if token.regionServiceURLs[region] == nil {
+				token.regionServiceURLs[region] = make(identity.ServiceURLs)
+			}
+			token.regionServiceURLs[region][service.Type] = endpoint.URL
+		}
+	}
+	return token, nil
+}
+
+type applicationCredentialBody struct {
+	ApplicationCredential struct {
+		ID          string `json:"id,omitempty"`
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+		Secret      string `json:"secret,omitempty"`
+	} `json:"application_credential"`
+}
+
+// createApplicationCredential creates an application credential with
+// the given name for the token's user, with the roles of the token on
+// its project.
+func (c *keystoneClient) createApplicationCredential(token *keystoneToken, name string) (applicationCredential, error) {
+	var req, resp applicationCredentialBody
+	req.ApplicationCredential.Name = name
+	req.ApplicationCredential.Description = "Created by Juju"
+	p := path.Join("users", token.userId, "application_credentials")
+	if _, err := c.do("POST", p, token.id, &req, &resp); err != nil {
+		return applicationCredential{}, errors.Annotatef(err, "creating application credential %q", name)
+	}
+	return applicationCredential{
+		ID:     resp.ApplicationCredential.ID,
+		Secret: resp.ApplicationCredential.Secret,
+	}, nil
+}
+
+// deleteApplicationCredential deletes the token user's application
+// credential with the given id.
+func (c *keystoneClient) deleteApplicationCredential(token *keystoneToken, id string) error {
+	p := path.Join("users", token.userId, "application_credentials", id)
+	if _, err := c.do("DELETE", p, token.id, nil, nil); err != nil {
+		return errors.Annotatef(err, "deleting application credential %q", id)
+	}
+	return nil
+}
+
+// keystoneError is the body of an error response from Keystone.
+type keystoneError struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Title   string `json:"title"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// do sends a request to the identity API, with the given token if it
+// is non-empty, encoding in as the JSON request body if it is non-nil
+// and decoding the JSON response body into out if it is non-nil.
+func (c *keystoneClient) do(method, p, token string, in, out interface{}) (http.Header, error) {
+	u := *c.url
+	u.Path = path.Join(u.Path, p)
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("X-Auth-Token", token)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var kerr keystoneError
+		msg := resp.Status
+		if err := json.NewDecoder(resp.Body).Decode(&kerr); err == nil && kerr.Error.Message != "" {
+			msg = kerr.Error.Message
+		}
+		msg = fmt.Sprintf("%s %s: %s", method, u.Path, msg)
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, errors.NewUnauthorized(nil, msg)
+		case http.StatusNotFound:
+			return nil, errors.NewNotFound(nil, msg)
+		}
+		return nil, errors.New(msg)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return nil, errors.Annotatef(err, "decoding %s %s response", method, u.Path)
+		}
+	}
+	return resp.Header, nil
+}
+
+// applicationCredentialClient is a goose AuthenticatingClient that
+// authenticates with a Keystone application credential, so that it
+// can be used with the goose nova and neutron clients.
+type applicationCredentialClient struct {
+	// AuthenticatingClient is a goose client for the identity
+	// endpoint, which serves the methods that need no token.
+	client.AuthenticatingClient
+
+	keystone   *keystoneClient
+	credential applicationCredential
+	region     string
+	http       *goosehttp.Client
+	logger     gooselogging.CompatLogger
+	clock      clock.Clock
+
+	mu                   sync.Mutex
+	requiredServiceTypes []string
+	token                *keystoneToken
+}
+
+// newApplicationCredentialClient returns a client that authenticates
+// with the application credential in the cloud spec.
+func newApplicationCredentialClient(spec environs.CloudSpec, verifySSL bool) (*applicationCredentialClient, error) {
+	gooseLogger := gooselogging.LoggoLogger{loggo.GetLogger("goose")}
+	httpClient := utils.GetValidatingHTTPClient()
+	gooseHTTPClient := goosehttp.New()
+	newClient := client.NewClient
+	if !verifySSL {
+		httpClient = utils.GetNonValidatingHTTPClient()
+		gooseHTTPClient = goosehttp.NewNonSSLValidating()
+		newClient = client.NewNonValidatingClient
+	}
+	keystone, err := newKeystoneClient(spec.Endpoint, httpClient)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	credAttrs := spec.Credential.Attributes()
+	return &applicationCredentialClient{
+		AuthenticatingClient: newClient(
+			&identity.Credentials{URL: spec.Endpoint, Region: spec.Region},
+			identity.AuthUserPassV3,
+			gooseLogger,
+		),
+		keystone: keystone,
+		credential: applicationCredential{
+			ID:     credAttrs[CredAttrApplicationCredentialID],
+			Secret: credAttrs[CredAttrApplicationCredentialSecret],
+		},
+		region: spec.Region,
+		http:   gooseHTTPClient,
+		logger: gooseLogger,
+		clock:  clock.WallClock,
+	}, nil
+}
+
+// SetRequiredServiceTypes is part of the client.AuthenticatingClient interface.
+func (c *applicationCredentialClient) SetRequiredServiceTypes(serviceTypes []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requiredServiceTypes = serviceTypes
+}
+
+// Authenticate is part of the client.AuthenticatingClient interface.
+func (c *applicationCredentialClient) Authenticate() error {
+	token, err := c.keystone.authenticate(c.credential)
+	if err != nil {
+		return errors.Annotate(err, "authenticating with application credential")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var missing []string
+	for _, serviceType := range c.requiredServiceTypes {
+		if token.regionServiceURLs[c.region][serviceType] == "" {
+			missing = append(missing, serviceType)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf(
+			"missing required service types %s in region %q",
+			strings.Join(missing, ", "), c.region,
+		)
+	}
+	c.token = token
+	return nil
+}
+
+func (c *applicationCredentialClient) currentToken() *keystoneToken {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// IsAuthenticated is part of the client.AuthenticatingClient interface.
+// It reports false once the token has expired.
+func (c *applicationCredentialClient) IsAuthenticated() bool {
+	token := c.currentToken()
+	if token == nil {
+		return false
+	}
+	return token.expiresAt.IsZero() || c.clock.Now().Before(token.expiresAt)
+}
+
+// Token is part of the client.AuthenticatingClient interface.
+func (c *applicationCredentialClient) Token() string {
+	if token := c.currentToken(); token != nil {
+		return token.id
+	}
+	return ""
+}
+
+// UserId is part of the client.AuthenticatingClient interface.
+func (c *applicationCredentialClient) UserId() string {
+	if token := c.currentToken(); token != nil {
+		return token.userId
+	}
+	return ""
+}
+
+// TenantId is part of the client.AuthenticatingClient interface.
+func (c *applicationCredentialClient) TenantId() string {
+	if token := c.currentToken(); token != nil {
+		return token.projectId
+	}
+	return ""
+}
+
+// EndpointsForRegion is part of the client.AuthenticatingClient interface.
+func (c *applicationCredentialClient) EndpointsForRegion(region string) identity.ServiceURLs {
+	if token := c.currentToken(); token != nil {
+		return token.regionServiceURLs[region]
+	}
+	return nil
+}
+
+// versionedPath matches URL paths that include an API version.
+var versionedPath = regexp.MustCompile(`/v\d+(\.\d+)?(/|$)`)
+
+// MakeServiceURL is part of the client.AuthenticatingClient interface.
+// The API version is added to endpoint URLs that do not include one.
+func (c *applicationCredentialClient) MakeServiceURL(serviceType, apiVersion string, parts []string) (string, error) {
+	token := c.currentToken()
+	if token == nil {
+		return "", errors.New("cannot get endpoint URL without being authenticated")
+	}
+	base, ok := token.regionServiceURLs[c.region][serviceType]
+	if !ok {
+		return "", errors.New("no endpoints known for service type: " + serviceType)
+	}
+	base = strings.TrimSuffix(base, "/")
+	if apiVersion != "" && !versionedPath.MatchString(base) {
+		base += "/" + apiVersion
+	}
+	for _, part := range parts {
+		base += "/" + strings.TrimPrefix(part, "/")
+	}
+	return base, nil
+}
+
+// SendRequest is part of the client.AuthenticatingClient interface.
+// If the request is rejected as unauthorised, the client authenticates
+// again, in case the token has been revoked, and retries once.
+func (c *applicationCredentialClient) SendRequest(
+	method, serviceType, apiVersion, apiCall string,
+	requestData *goosehttp.RequestData,
+) error {
+	if !c.IsAuthenticated() {
+		if err := c.Authenticate(); err != nil {
+			return err
+		}
+	}
+	err := c.sendRequest(method, serviceType, apiVersion, apiCall, requestData)
+	if gooseerrors.IsUnauthorised(err) {
+		if err := c.Authenticate(); err != nil {
+			return err
+		}
+		err = c.sendRequest(method, serviceType, apiVersion, apiCall, requestData)
+	}
+	// Errors are returned unwrapped, so that callers can
+	// inspect them with the goose errors package.
+	return err
+}
+
+func (c *applicationCredentialClient) sendRequest(
+	method, serviceType, apiVersion, apiCall string,
+	requestData *goosehttp.RequestData,
+) error {
+	url, err := c.MakeServiceURL(serviceType, apiVersion, []string{apiCall})
+	if err != nil {
+		return err
+	}
+	if requestData.ReqValue != nil || requestData.RespValue != nil {
+		return c.http.JsonRequest(method, url, c.Token(), requestData, c.logger)
+	}
+	return c.http.BinaryRequest(method, url, c.Token(), requestData, c.logger)
+}
+
+// rotateApplicationCredential creates a new application credential
+// with the given name, for the user that the client's application
+// credential belongs to.
+func (c *applicationCredentialClient) rotateApplicationCredential(name string) (applicationCredential, error) {
+	if !c.IsAuthenticated() {
+		if err := c.Authenticate(); err != nil {
+			return applicationCredential{}, errors.Trace(err)
+		}
+	}
+	return c.keystone.createApplicationCredential(c.currentToken(), name)
+}
+
+// deleteApplicationCredential deletes the application credential,
+// belonging to the same user as the client's, with the given id.
+func (c *applicationCredentialClient) deleteApplicationCredential(id string) error {
+	if id == c.credential.ID {
+		return errors.New("cannot delete the application credential in use")
+	}
+	if !c.IsAuthenticated() {
+		if err := c.Authenticate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return c.keystone.deleteApplicationCredential(c.currentToken(), id)
+}
@@ -0,0 +1,251 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/goose.v2/identity"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+type keystoneSuite struct {
+	testing.IsolationSuite
+	server   *httptest.Server
+	requests []*http.Request
+	bodies   []string
+	handler  func(w http.ResponseWriter, req *http.Request)
+}
+
+var _ = gc.Suite(&keystoneSuite{})
+
+const tokenResponseBody = `{
+  "token": {
+    "expires_at": "2017-11-09T01:42:57.527363Z",
+    "user": {"id": "user-id"},
+    "project": {"id": "project-id"},
+    "catalog": [{
+      "type": "compute",
+      "endpoints": [{
+        "interface": "public",
+        "region_id": "RegionOne",
+        "url": "https://nova.example.com:8774/v2.1/project-id"
+      }, {
+        "interface": "internal",
+        "region_id": "RegionOne",
+        "url": "http://nova.internal:8774/v2.1/project-id"
+      }]
+    }, {
+      "type": "network",
+      "endpoints": [{
+        "interface": "public",
+        "region": "RegionOne",
+        "url": "https://neutron.example.com:9696/"
+      }]
+    }]
+  }
+}`
+
+func (s *keystoneSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.bodies = nil
+	s.handler = func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Subject-Token", "token-id")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(tokenResponseBody))
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests = append(s.requests, req)
+		s.bodies = append(s.bodies, string(body))
+		s.handler(w, req)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *keystoneSuite) newClient(c *gc.C) *applicationCredentialClient {
+	credential := cloud.NewCredential(cloud.ApplicationCredentialAuthType, map[string]string{
+		CredAttrApplicationCredentialID:     "app-cred-id",
+		CredAttrApplicationCredentialSecret: "app-cred-secret",
+	})
+	client, err := newApplicationCredentialClient(environs.CloudSpec{
+		Type:       "openstack",
+		Name:       "openstack",
+		Region:     "RegionOne",
+		Endpoint:   s.server.URL + "/v3",
+		Credential: &credential,
+	}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	client.clock = testing.NewClock(time.Date(2017, 11, 9, 0, 0, 0, 0, time.UTC))
+	return client
+}
+
+func (s *keystoneSuite) TestNewKeystoneClientURL(c *gc.C) {
+	for _, authURL := range []string{
+		"https://keystone.example.com:5000",
+		"https://keystone.example.com:5000/",
+		"https://keystone.example.com:5000/v3",
+		"https://keystone.example.com:5000/v3/",
+	} {
+		client, err := newKeystoneClient(authURL, http.DefaultClient)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(client.url.String(), gc.Equals, "https://keystone.example.com:5000/v3")
+	}
+	_, err := newKeystoneClient("https://keystone.example.com:5000/v2.0", http.DefaultClient)
+	c.Assert(err, gc.ErrorMatches, `application credentials with identity API "v2.0" not supported`)
+}
+
+func (s *keystoneSuite) TestAuthenticate(c *gc.C) {
+	client := s.newClient(c)
+	c.Assert(client.IsAuthenticated(), jc.IsFalse)
+	err := client.Authenticate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Assert(s.requests[0].Method, gc.Equals, "POST")
+	c.Assert(s.requests[0].URL.Path, gc.Equals, "/v3/auth/tokens")
+	var body interface{}
+	err = json.Unmarshal([]byte(s.bodies[0]), &body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(body, jc.DeepEquals, map[string]interface{}{
+		"auth": map[string]interface{}{
+			"identity": map[string]interface{}{
+				"methods": []interface{}{"application_credential"},
+				"application_credential": map[string]interface{}{
+					"id":     "app-cred-id",
+					"secret": "app-cred-secret",
+				},
+			},
+		},
+	})
+
+	c.Assert(client.IsAuthenticated(), jc.IsTrue)
+	c.Assert(client.Token(), gc.Equals, "token-id")
+	c.Assert(client.UserId(), gc.Equals, "user-id")
+	c.Assert(client.TenantId(), gc.Equals, "project-id")
+	c.Assert(client.EndpointsForRegion("RegionOne"), jc.DeepEquals, identity.ServiceURLs{
+		"compute": "https://nova.example.com:8774/v2.1/project-id",
+		"network": "https://neutron.example.com:9696/",
+	})
+}
+
+func (s *keystoneSuite) TestAuthenticateExpires(c *gc.C) {
+	client := s.newClient(c)
+	err := client.Authenticate()
+	c.Assert(err, jc.ErrorIsNil)
+	client.clock = testing.NewClock(time.Date(2017, 11, 9, 2, 0, 0, 0, time.UTC))
+	c.Assert(client.IsAuthenticated(), jc.IsFalse)
+}
+
+func (s *keystoneSuite) TestAuthenticateUnauthorized(c *gc.C) {
+	s.handler = func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": {"code": 401, "title": "Unauthorized", "message": "The request you have made requires authentication."}}`))
+	}
+	client := s.newClient(c)
+	err := client.Authenticate()
+	c.Assert(err, gc.ErrorMatches, "authenticating with application credential: POST /v3/auth/tokens: The request you have made requires authentication.")
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsUnauthorized)
+	c.Assert(client.IsAuthenticated(), jc.IsFalse)
+}
+
+func (s *keystoneSuite) TestAuthenticateMissingServiceType(c *gc.C) {
+	client := s.newClient(c)
+	client.SetRequiredServiceTypes([]string{"compute", "object-store"})
+	err := client.Authenticate()
+	c.Assert(err, gc.ErrorMatches, `missing required service types object-store in region "RegionOne"`)
+	c.Assert(client.IsAuthenticated(), jc.IsFalse)
+}
+
+func (s *keystoneSuite) TestMakeServiceURL(c *gc.C) {
+	client := s.newClient(c)
+	_, err := client.MakeServiceURL("compute", "v2", []string{"servers"})
+	c.Assert(err, gc.ErrorMatches, "cannot get endpoint URL without being authenticated")
+
+	err = client.Authenticate()
+	c.Assert(err, jc.ErrorIsNil)
+	url, err := client.MakeServiceURL("compute", "v2", []string{"servers", "detail"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(url, gc.Equals, "https://nova.example.com:8774/v2.1/project-id/servers/detail")
+	url, err = client.MakeServiceURL("network", "v2.0", []string{"networks"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(url, gc.Equals, "https://neutron.example.com:9696/v2.0/networks")
+	_, err = client.MakeServiceURL("volumev2", "v2", []string{"volumes"})
+	c.Assert(err, gc.ErrorMatches, "no endpoints known for service type: volumev2")
+}
+
+func (s *keystoneSuite) TestRotateApplicationCredential(c *gc.C) {
+	client := s.newClient(c)
+	err := client.Authenticate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.handler = func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"application_credential": {"id": "new-id", "name": "juju-2", "secret": "new-secret"}}`))
+	}
+	appCred, err := client.rotateApplicationCredential("juju-2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appCred, jc.DeepEquals, applicationCredential{ID: "new-id", Secret: "new-secret"})
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[1].Method, gc.Equals, "POST")
+	c.Assert(s.requests[1].URL.Path, gc.Equals, "/v3/users/user-id/application_credentials")
+	c.Assert(s.requests[1].Header.Get("X-Auth-Token"), gc.Equals, "token-id")
+	var body interface{}
+	err = json.Unmarshal([]byte(s.bodies[1]), &body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(body, jc.DeepEquals, map[string]interface{}{
+		"application_credential": map[string]interface{}{
+			"name":        "juju-2",
+			"description": "Created by Juju",
+		},
+	})
+}
+
+func (s *keystoneSuite) TestDeleteApplicationCredential(c *gc.C) {
+	client := s.newClient(c)
+	err := client.Authenticate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.handler = func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	err = client.deleteApplicationCredential("old-id")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[1].Method, gc.Equals, "DELETE")
+	c.Assert(s.requests[1].URL.Path, gc.Equals, "/v3/users/user-id/application_credentials/old-id")
+	c.Assert(s.requests[1].Header.Get("X-Auth-Token"), gc.Equals, "token-id")
+}
+
+func (s *keystoneSuite) TestDeleteApplicationCredentialInUse(c *gc.C) {
+	client := s.newClient(c)
+	err := client.deleteApplicationCredential("app-cred-id")
+	c.Assert(err, gc.ErrorMatches, "cannot delete the application credential in use")
+	c.Assert(s.requests, gc.HasLen, 0)
+}
+
+func (s *keystoneSuite) TestRotateApplicationCredentialNotSupported(c *gc.C) {
+	credential := cloud.NewCredential(cloud.UserPassAuthType, nil)
+	env := &Environ{
+		cloud:          environs.CloudSpec{Credential: &credential},
+		clientUnlocked: &testAuthClient{},
+	}
+	_, err := env.RotateApplicationCredential("juju-2")
+	c.Assert(err, gc.ErrorMatches, `rotating "userpass" credentials not supported`)
+	err = env.DeleteApplicationCredential("old-id")
+	c.Assert(err, gc.ErrorMatches, `deleting application credentials with "userpass" credentials not supported`)
+}
@@ -97,6 +97,7 @@ var cloudSchema = &jsonschema.Schema{
 					Enum: []interface{}{
 						string(cloud.AccessKeyAuthType),
 						string(cloud.UserPassAuthType),
+						string(cloud.ApplicationCredentialAuthType),
 					},
 				}},
 			},
@@ -479,6 +480,44 @@ func (e *Environ) client() client.AuthenticatingClient {
 	return client
 }
 
+// RotateApplicationCredential creates a new Keystone application
+// credential with the given name, for the user that the environ's
+// application credential belongs to, and returns a credential holding
+// it. The environ's application credential is left in place, so that
+// it may be deleted with DeleteApplicationCredential once the new
+// credential has been uploaded to the controller. Keystone only allows
+// application credentials created as "unrestricted" to create others.
+func (e *Environ) RotateApplicationCredential(name string) (*cloud.Credential, error) {
+	client, ok := e.client().(*applicationCredentialClient)
+	if !ok {
+		return nil, errors.NotSupportedf("rotating %q credentials", e.cloud.Credential.AuthType())
+	}
+	appCred, err := client.rotateApplicationCredential(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	credential := cloud.NewCredential(
+		cloud.ApplicationCredentialAuthType,
+		map[string]string{
+			CredAttrApplicationCredentialID:     appCred.ID,
+			CredAttrApplicationCredentialSecret: appCred.Secret,
+		},
+	)
+	return &credential, nil
+}
+
+// DeleteApplicationCredential deletes the Keystone application
+// credential with the given id, which must belong to the same user as
+// the environ's application credential, and must not be that
+// credential.
+func (e *Environ) DeleteApplicationCredential(id string) error {
+	client, ok := e.client().(*applicationCredentialClient)
+	if !ok {
+		return errors.NotSupportedf("deleting application credentials with %q credentials", e.cloud.Credential.AuthType())
+	}
+	return errors.Trace(client.deleteApplicationCredential(id))
+}
+
 func (e *Environ) nova() *nova.Client {
 	e.ecfgMutex.Lock()
 	nova := e.novaUnlocked
@@ -496,6 +535,9 @@ func (e *Environ) neutron() *neutron.Client {
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
+	constraints.AllocatePublicIP,
+	constraints.InstanceLifecycle,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -758,6 +800,14 @@ func authClient(spec environs.CloudSpec, ecfg *environConfig) (client.Authentica
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot create a client")
 	}
+	if spec.Credential.AuthType() == cloud.ApplicationCredentialAuthType {
+		client, err := newApplicationCredentialClient(spec, ecfg.SSLHostnameVerification())
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot create a client")
+		}
+		client.SetRequiredServiceTypes([]string{"compute"})
+		return client, nil
+	}
 	cred, authMode := newCredentials(spec)
 
 	gooseLogger := gooselogging.LoggoLogger{loggo.GetLogger("goose")}
@@ -949,11 +999,19 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 
 	series := args.Tools.OneSeries()
 	arches := args.Tools.Arches()
+	bootFromVolume := e.ecfg().bootFromVolume()
+	specConstraints := args.Constraints
+	if bootFromVolume {
+		// The root disk will be a Cinder volume sized by the root-disk
+		// constraint, so flavors must not be filtered by their disk size;
+		// flavors for volume-backed clouds commonly have no local disk.
+		specConstraints.RootDisk = nil
+	}
 	spec, err := findInstanceSpec(e, &instances.InstanceConstraint{
 		Region:      e.cloud.Region,
 		Series:      series,
 		Arches:      arches,
-		Constraints: args.Constraints,
+		Constraints: specConstraints,
 	}, args.ImageMetadata)
 	if err != nil {
 		return nil, err
@@ -1117,6 +1175,17 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		Metadata:           args.InstanceConfig.Tags,
 		AvailabilityZone:   availabilityZone,
 	}
+	var rootVolumeSize uint64
+	if bootFromVolume {
+		var mapping nova.BlockDeviceMapping
+		mapping, rootVolumeSize = rootVolumeBlockDeviceMapping(
+			e.ecfg(), spec.Image.Id, args.Constraints, spec.InstanceType,
+		)
+		logger.Debugf("booting %q from a %dGiB volume", machineName, mapping.VolumeSize)
+		opts.BlockDeviceMappings = []nova.BlockDeviceMapping{mapping}
+		// The image is referenced by the block device mapping instead.
+		opts.ImageId = ""
+	}
 	e.configurator.ModifyRunServerOptions(&opts)
 
 	server, err := tryStartNovaInstance(shortAttempt, e.nova(), opts)
@@ -1167,12 +1236,51 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		inst.floatingIP = publicIP
 	}
 
+	hc := inst.hardwareCharacteristics()
+	if rootVolumeSize != 0 {
+		hc.RootDisk = &rootVolumeSize
+	}
 	return &environs.StartInstanceResult{
 		Instance: inst,
-		Hardware: inst.hardwareCharacteristics(),
+		Hardware: hc,
 	}, nil
 }
 
+// defaultRootVolumeSize is the size, in MiB, of the root volume created
+// when booting from volume with no root-disk constraint and a flavor that
+// does not specify a disk size.
+const defaultRootVolumeSize = 30 * 1024
+
+// rootVolumeBlockDeviceMapping returns the block device mapping needed to
+// boot an instance from a new Cinder volume created from the given image,
+// along with the size of that volume in MiB. The volume size is taken from
+// the root-disk constraint if specified, falling back to the disk size of
+// the flavor and finally to defaultRootVolumeSize.
+func rootVolumeBlockDeviceMapping(
+	ecfg *environConfig,
+	imageId string,
+	cons constraints.Value,
+	instType instances.InstanceType,
+) (nova.BlockDeviceMapping, uint64) {
+	size := uint64(defaultRootVolumeSize)
+	if cons.RootDisk != nil && *cons.RootDisk > 0 {
+		size = *cons.RootDisk
+	} else if instType.RootDisk > 0 {
+		size = instType.RootDisk
+	}
+	// Cinder volumes are sized in GiB, so round up.
+	sizeGiB := (size + 1023) / 1024
+	return nova.BlockDeviceMapping{
+		BootIndex:           0,
+		UUID:                imageId,
+		SourceType:          "image",
+		DestinationType:     "volume",
+		VolumeSize:          int(sizeGiB),
+		VolumeType:          ecfg.rootVolumeType(),
+		DeleteOnTermination: ecfg.rootVolumeDeleteOnTermination(),
+	}, sizeGiB * 1024
+}
+
 func (e *Environ) startInstanceAvailabilityZone(args environs.StartInstanceParams) (string, error) {
 	volumeAttachmentsZone, err := e.volumeAttachmentsZone(args.VolumeAttachments)
 	if err != nil {
@@ -1702,6 +1810,14 @@ func validateCloudSpec(spec environs.CloudSpec) error {
 	switch authType := spec.Credential.AuthType(); authType {
 	case cloud.UserPassAuthType:
 	case cloud.AccessKeyAuthType:
+	case cloud.ApplicationCredentialAuthType:
+		version, err := identityClientVersion(spec.Endpoint)
+		if err != nil {
+			return errors.Annotate(err, "validating auth-url")
+		}
+		if version != -1 && version != 3 {
+			return errors.NotValidf("%q auth-type with identity API v%d", authType, version)
+		}
 	default:
 		return errors.NotSupportedf("%q auth-type", authType)
 	}
@@ -385,7 +385,7 @@ func (s *localTests) detectRegions(c *gc.C) ([]cloud.Region, error) {
 
 func (s *localTests) TestSchema(c *gc.C) {
 	y := []byte(`
-auth-types: [userpass, access-key]
+auth-types: [userpass, access-key, application-credential]
 endpoint: http://foo.com/openstack
 regions: 
   one:
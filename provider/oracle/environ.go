@@ -659,6 +659,10 @@ func (o *OracleEnviron) ConstraintsValidator() (constraints.Validator, error) {
 		constraints.CpuPower,
 		constraints.RootDisk,
 		constraints.VirtType,
+		constraints.AllocatePublicIP,
+		constraints.ImageID,
+		constraints.InstanceLifecycle,
+		constraints.MaxPrice,
 	}
 
 	// we choose to use the default validator implementation
@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+)
+
+// apiPath is the path of the oVirt REST API on the engine.
+const apiPath = "/ovirt-engine/api"
+
+// DialFunc is a function type for dialing oVirt client connections.
+type DialFunc func(_ context.Context, _ *url.URL, dataCenter string) (Client, error)
+
+// Client is an interface for interacting with the oVirt API.
+type Client interface {
+	Close(context.Context) error
+	AttachDisk(context.Context, string, string) (*ovirtclient.DiskAttachment, error)
+	Clusters(context.Context) ([]*ovirtclient.Cluster, error)
+	CreateDisk(context.Context, ovirtclient.CreateDiskParams) (*ovirtclient.Disk, error)
+	CreateVirtualMachine(context.Context, ovirtclient.CreateVirtualMachineParams) (*ovirtclient.VM, error)
+	DetachDisk(context.Context, string, string) error
+	DiskAttachments(context.Context, string) ([]*ovirtclient.DiskAttachment, error)
+	Disks(context.Context, string) ([]*ovirtclient.Disk, error)
+	NetworkSubnets(context.Context, *ovirtclient.Network) ([]*ovirtclient.Subnet, error)
+	Networks(context.Context) ([]*ovirtclient.Network, error)
+	RemoveDisk(context.Context, string) error
+	RemoveVirtualMachine(context.Context, string) error
+	StorageDomains(context.Context) ([]*ovirtclient.StorageDomain, error)
+	TagVirtualMachine(context.Context, string, string) error
+	UntagVirtualMachine(context.Context, string, string) error
+	UpdateDiskDescription(context.Context, string, string) error
+	VirtualMachineTags(context.Context, string) ([]string, error)
+	VirtualMachines(context.Context, string) ([]*ovirtclient.VM, error)
+	VNICProfiles(context.Context) ([]*ovirtclient.VNICProfile, error)
+}
+
+func dialClient(
+	ctx context.Context,
+	cloudSpec environs.CloudSpec,
+	dial DialFunc,
+) (Client, error) {
+	dataCenter := cloudSpec.Region
+	credAttrs := cloudSpec.Credential.Attributes()
+	username := credAttrs[credAttrUser]
+	password := credAttrs[credAttrPassword]
+	connURL, err := apiURL(cloudSpec.Endpoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	connURL.User = url.UserPassword(username, password)
+	return dial(ctx, connURL, dataCenter)
+}
+
+// apiURL returns the URL of the oVirt REST API for the given endpoint,
+// which may be either the engine's address, or the full API URL.
+func apiURL(endpoint string) (*url.URL, error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing endpoint")
+	}
+	switch u.Scheme {
+	case "http", "https":
+	default:
+		return nil, errors.Errorf("invalid endpoint %q: expected an http or https URL", endpoint)
+	}
+	if u.Host == "" {
+		return nil, errors.Errorf("invalid endpoint %q: missing host", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = apiPath
+	}
+	return u, nil
+}
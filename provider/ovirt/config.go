@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/common"
+)
+
+// The oVirt-specific config keys.
+const (
+	// cfgPrimaryNetwork is the name of a network to which all VMs
+	// are connected, in addition to any networks defined by the
+	// template from which they are created.
+	cfgPrimaryNetwork = "primary-network"
+
+	// cfgStorageDomain is the name of the storage domain in which
+	// volumes are created, unless the storage pool specifies one.
+	cfgStorageDomain = "storage-domain"
+)
+
+// configFields is the spec for each oVirt config value's type.
+var (
+	configFields = schema.Fields{
+		cfgPrimaryNetwork: schema.String(),
+		cfgStorageDomain:  schema.String(),
+	}
+
+	configDefaults = schema.Defaults{
+		cfgPrimaryNetwork: schema.Omit,
+		cfgStorageDomain:  schema.Omit,
+	}
+)
+
+type environConfig struct {
+	*config.Config
+	attrs map[string]interface{}
+}
+
+// newValidConfig validates cfg, as a change to old if old is
+// non-nil, and returns it as an environConfig. oVirt places no
+// constraints on its attributes beyond their types: a network or
+// storage domain that does not exist in the data center is only
+// reported when a VM or disk is created with it.
+func newValidConfig(cfg, old *config.Config) (*environConfig, error) {
+	valid, err := common.ValidateConfig(cfg, old, configFields, configDefaults)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &environConfig{
+		Config: valid,
+		attrs:  valid.UnknownAttrs(),
+	}, nil
+}
+
+func (c *environConfig) primaryNetwork() string {
+	network, _ := c.attrs[cfgPrimaryNetwork].(string)
+	return network
+}
+
+func (c *environConfig) storageDomain() string {
+	domain, _ := c.attrs[cfgStorageDomain].(string)
+	return domain
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/testing"
+)
+
+func fakeConfig(c *gc.C, attrs ...testing.Attrs) *config.Config {
+	cfg, err := testing.ModelConfig(c).Apply(fakeConfigAttrs(attrs...))
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func fakeConfigAttrs(attrs ...testing.Attrs) testing.Attrs {
+	merged := testing.FakeConfig().Merge(testing.Attrs{
+		"type": "ovirt",
+		"uuid": "2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+	})
+	for _, attrs := range attrs {
+		merged = merged.Merge(attrs)
+	}
+	return merged
+}
+
+func fakeCloudSpec() environs.CloudSpec {
+	cred := fakeCredential()
+	return environs.CloudSpec{
+		Type:       "ovirt",
+		Name:       "ovirt",
+		Region:     "dc1",
+		Endpoint:   "engine.example.com",
+		Credential: &cred,
+	}
+}
+
+func fakeCredential() cloud.Credential {
+	return cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"user":     "admin@internal",
+		"password": "password1",
+	})
+}
+
+type ConfigSuite struct {
+	testing.BaseSuite
+	provider environs.EnvironProvider
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	var err error
+	s.provider, err = environs.Provider("ovirt")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestValidateNewConfig(c *gc.C) {
+	cfg := fakeConfig(c, testing.Attrs{
+		"primary-network": "ovirtmgmt",
+		"storage-domain":  "data1",
+	})
+	validCfg, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validCfg.UnknownAttrs(), jc.DeepEquals, map[string]interface{}{
+		"primary-network": "ovirtmgmt",
+		"storage-domain":  "data1",
+	})
+}
+
+func (s *ConfigSuite) TestValidateNewConfigInvalid(c *gc.C) {
+	cfg := fakeConfig(c, testing.Attrs{"primary-network": 123})
+	_, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid config: .*expected string, got int\(123\)`)
+}
+
+func (s *ConfigSuite) TestValidateChange(c *gc.C) {
+	oldCfg := fakeConfig(c)
+	newCfg := fakeConfig(c, testing.Attrs{"primary-network": "ovirtmgmt"})
+	validCfg, err := s.provider.Validate(newCfg, oldCfg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validCfg.UnknownAttrs()["primary-network"], gc.Equals, "ovirtmgmt")
+}
@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+)
+
+const (
+	credAttrUser     = "user"
+	credAttrPassword = "password"
+)
+
+type environProviderCredentials struct{}
+
+// CredentialSchemas is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	return map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.UserPassAuthType: {
+			{
+				credAttrUser, cloud.CredentialAttr{
+					Description: "The username to authenticate with, including the profile (e.g. admin@internal).",
+				},
+			}, {
+				credAttrPassword, cloud.CredentialAttr{
+					Description: "The password to authenticate with.",
+					Hidden:      true,
+				},
+			},
+		},
+	}
+}
+
+// DetectCredentials is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) DetectCredentials() (*cloud.CloudCredential, error) {
+	return nil, errors.NotFoundf("credentials")
+}
+
+// FinalizeCredential is part of the environs.ProviderCredentials interface.
+func (environProviderCredentials) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
+	return &args.Credential, nil
+}
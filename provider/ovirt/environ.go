@@ -0,0 +1,226 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+)
+
+type environ struct {
+	name     string
+	cloud    environs.CloudSpec
+	provider *environProvider
+
+	// namespace is used to create the machine and disk names.
+	namespace instance.Namespace
+
+	lock sync.Mutex // lock protects access the following fields.
+	ecfg *environConfig
+}
+
+func newEnviron(
+	provider *environProvider,
+	cloud environs.CloudSpec,
+	cfg *config.Config,
+) (*environ, error) {
+	ecfg, err := newValidConfig(cfg, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+
+	namespace, err := instance.NewNamespace(cfg.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	env := &environ{
+		name:      ecfg.Name(),
+		cloud:     cloud,
+		provider:  provider,
+		ecfg:      ecfg,
+		namespace: namespace,
+	}
+	return env, nil
+}
+
+// Name is part of the environs.Environ interface.
+func (env *environ) Name() string {
+	return env.name
+}
+
+// Provider is part of the environs.Environ interface.
+func (env *environ) Provider() environs.EnvironProvider {
+	return env.provider
+}
+
+// SetConfig is part of the environs.Environ interface.
+func (env *environ) SetConfig(cfg *config.Config) error {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	if env.ecfg == nil {
+		return errors.New("cannot set config on uninitialized env")
+	}
+
+	ecfg, err := newValidConfig(cfg, env.ecfg.Config)
+	if err != nil {
+		return errors.Annotate(err, "invalid config change")
+	}
+	env.ecfg = ecfg
+	return nil
+}
+
+// Config is part of the environs.Environ interface.
+func (env *environ) Config() *config.Config {
+	env.lock.Lock()
+	cfg := env.ecfg.Config
+	env.lock.Unlock()
+	return cfg
+}
+
+func (env *environ) environConfig() *environConfig {
+	env.lock.Lock()
+	ecfg := env.ecfg
+	env.lock.Unlock()
+	return ecfg
+}
+
+// PrepareForBootstrap implements environs.Environ.
+func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext) error {
+	return nil
+}
+
+// Create implements environs.Environ.
+func (env *environ) Create(args environs.CreateParams) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.Create(args)
+	})
+}
+
+// Create implements environs.Environ.
+func (env *sessionEnviron) Create(args environs.CreateParams) error {
+	// There is nothing to create up front, but make sure
+	// that the data center exists and is accessible.
+	_, err := env.AvailabilityZones()
+	return errors.Trace(err)
+}
+
+// Bootstrap is part of the environs.Environ interface.
+func (env *environ) Bootstrap(
+	ctx environs.BootstrapContext,
+	args environs.BootstrapParams,
+) (result *environs.BootstrapResult, err error) {
+	// The engine connection of a sessionEnviron is closed when
+	// the session ends, whereas common.Bootstrap holds on to the
+	// Environ to finalize the controller after returning.
+	return common.Bootstrap(ctx, env, args)
+}
+
+// AdoptResources is part of the Environ interface.
+func (env *environ) AdoptResources(controllerUUID string, fromVersion version.Number) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.AdoptResources(controllerUUID, fromVersion)
+	})
+}
+
+// AdoptResources is part of the Environ interface.
+func (env *sessionEnviron) AdoptResources(controllerUUID string, fromVersion version.Number) error {
+	vms, err := env.client.VirtualMachines(env.ctx, vmSearch(env.modelTag()))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newTag := controllerTag(controllerUUID)
+	for _, vm := range vms {
+		vmTags, err := env.client.VirtualMachineTags(env.ctx, vm.Id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, tag := range vmTags {
+			if strings.HasPrefix(tag, controllerTagPrefix) && tag != newTag {
+				if err := env.client.UntagVirtualMachine(env.ctx, vm.Id, tag); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+		if err := env.client.TagVirtualMachine(env.ctx, vm.Id, newTag); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	disks, err := env.modelDisks()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	description := diskDescription(env.Config().UUID(), controllerUUID)
+	for _, disk := range disks {
+		if disk.Description == description {
+			continue
+		}
+		if err := env.client.UpdateDiskDescription(env.ctx, disk.Id, description); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Destroy is part of the environs.Environ interface.
+func (env *environ) Destroy() error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.Destroy()
+	})
+}
+
+// Destroy is part of the environs.Environ interface.
+func (env *sessionEnviron) Destroy() error {
+	return errors.Trace(common.Destroy(env))
+}
+
+// DestroyController implements the Environ interface.
+func (env *environ) DestroyController(controllerUUID string) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.DestroyController(controllerUUID)
+	})
+}
+
+// DestroyController implements the Environ interface.
+func (env *sessionEnviron) DestroyController(controllerUUID string) error {
+	if err := env.Destroy(); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Remove the VMs and disks of any models that
+	// remain in the controller.
+	vms, err := env.client.VirtualMachines(env.ctx, vmSearch(controllerTag(controllerUUID)))
+	if err != nil {
+		return errors.Annotate(err, "listing VMs")
+	}
+	for _, vm := range vms {
+		if err := env.client.RemoveVirtualMachine(env.ctx, vm.Id); err != nil {
+			return errors.Annotate(err, "removing VMs")
+		}
+	}
+	disks, err := env.client.Disks(env.ctx, diskSearch("juju-*"))
+	if err != nil {
+		return errors.Annotate(err, "listing disks")
+	}
+	for _, disk := range disks {
+		_, diskControllerUUID := parseDiskDescription(disk.Description)
+		if diskControllerUUID != controllerUUID {
+			continue
+		}
+		if err := env.client.RemoveDisk(env.ctx, disk.Id); err != nil {
+			return errors.Annotate(err, "removing disks")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+)
+
+// ovirtAvailZone is an availability zone, corresponding to a
+// cluster in the data center.
+type ovirtAvailZone struct {
+	cluster ovirtclient.Cluster
+}
+
+// Name implements common.AvailabilityZone
+func (z *ovirtAvailZone) Name() string {
+	return z.cluster.Name
+}
+
+// Available implements common.AvailabilityZone
+func (z *ovirtAvailZone) Available() bool {
+	return true
+}
+
+// AvailabilityZones is part of the common.ZonedEnviron interface.
+func (env *environ) AvailabilityZones() (zones []common.AvailabilityZone, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		zones, err = env.AvailabilityZones()
+		return err
+	})
+	return zones, err
+}
+
+// AvailabilityZones is part of the common.ZonedEnviron interface.
+func (env *sessionEnviron) AvailabilityZones() ([]common.AvailabilityZone, error) {
+	if env.zones == nil {
+		clusters, err := env.client.Clusters(env.ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		zones := make([]common.AvailabilityZone, len(clusters))
+		for i, cluster := range clusters {
+			zones[i] = &ovirtAvailZone{*cluster}
+		}
+		env.zones = zones
+	}
+	return env.zones, nil
+}
+
+// InstanceAvailabilityZoneNames is part of the common.ZonedEnviron interface.
+func (env *environ) InstanceAvailabilityZoneNames(ids []instance.Id) (names []string, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		names, err = env.InstanceAvailabilityZoneNames(ids)
+		return err
+	})
+	return names, err
+}
+
+// InstanceAvailabilityZoneNames is part of the common.ZonedEnviron interface.
+func (env *sessionEnviron) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instances, err := env.Instances(ids)
+	switch err {
+	case nil, environs.ErrPartialInstances:
+		break
+	case environs.ErrNoInstances:
+		return nil, err
+	default:
+		return nil, errors.Trace(err)
+	}
+
+	results := make([]string, len(ids))
+	for i, inst := range instances {
+		if inst == nil {
+			continue
+		}
+		vm := inst.(*environInstance).base
+		if vm.Cluster == nil {
+			continue
+		}
+		for _, zone := range zones {
+			cluster := &zone.(*ovirtAvailZone).cluster
+			if cluster.Id == vm.Cluster.Id {
+				results[i] = cluster.Name
+				break
+			}
+		}
+	}
+	return results, err
+}
+
+// DeriveAvailabilityZone is part of the common.ZonedEnviron interface.
+func (env *environ) DeriveAvailabilityZone(args environs.StartInstanceParams) (name string, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		name, err = env.DeriveAvailabilityZone(args)
+		return err
+	})
+	return name, err
+}
+
+// DeriveAvailabilityZone is part of the common.ZonedEnviron interface.
+func (env *sessionEnviron) DeriveAvailabilityZone(args environs.StartInstanceParams) (string, error) {
+	zone, err := env.parseAvailabilityZone(args)
+	if err != nil {
+		return "", err
+	}
+	return zone, nil
+}
+
+func (env *sessionEnviron) availZone(name string) (common.AvailabilityZone, error) {
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, z := range zones {
+		if z.Name() == name {
+			return z, nil
+		}
+	}
+	return nil, errors.NotFoundf("availability zone %q", name)
+}
+
+// parseAvailabilityZone returns the name of the cluster in which to
+// create the VM: the one named by a "zone=<cluster>" placement
+// directive if there is one, and otherwise the zone chosen by the
+// provisioner, which may be empty.
+func (env *sessionEnviron) parseAvailabilityZone(args environs.StartInstanceParams) (string, error) {
+	if args.Placement != "" {
+		placement, err := env.parsePlacement(args.Placement)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return placement.Name(), nil
+	}
+	return args.AvailabilityZone, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+)
+
+type environAvailzonesSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environAvailzonesSuite{})
+
+func (s *environAvailzonesSuite) TestAvailabilityZones(c *gc.C) {
+	c.Assert(s.env, gc.Implements, new(common.ZonedEnviron))
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zones, err := zonedEnviron.AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(zones), gc.Equals, 2)
+	c.Assert(zones[0].Name(), gc.Equals, "z1")
+	c.Assert(zones[1].Name(), gc.Equals, "z2")
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNames(c *gc.C) {
+	inst0 := newVM("inst-0", ovirtclient.VMStatusUp)
+	inst0.Cluster.Id = "c2"
+	inst2 := newVM("inst-2", ovirtclient.VMStatusUp)
+	inst2.Cluster = nil
+	s.client.virtualMachines = []*ovirtclient.VM{
+		inst0,
+		newVM("inst-1", ovirtclient.VMStatusUp),
+		inst2,
+	}
+	ids := []instance.Id{"inst-0", "inst-1", "inst-2", "inst-3"}
+
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zones, err := zonedEnviron.InstanceAvailabilityZoneNames(ids)
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(zones, jc.DeepEquals, []string{"z2", "z1", "", ""})
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNamesNoInstances(c *gc.C) {
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	_, err := zonedEnviron.InstanceAvailabilityZoneNames([]instance.Id{"inst-0"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZone(c *gc.C) {
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zone, err := zonedEnviron.DeriveAvailabilityZone(
+		environs.StartInstanceParams{Placement: "zone=z2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zone, gc.Equals, "z2")
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZoneUnknown(c *gc.C) {
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zone, err := zonedEnviron.DeriveAvailabilityZone(
+		environs.StartInstanceParams{Placement: "zone=z3"})
+	c.Assert(err, gc.ErrorMatches, `availability zone "z3" not found`)
+	c.Assert(zone, gc.Equals, "")
+}
+
+func (s *environAvailzonesSuite) TestDeriveAvailabilityZoneInvalidPlacement(c *gc.C) {
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zone, err := zonedEnviron.DeriveAvailabilityZone(
+		environs.StartInstanceParams{Placement: "invalid-placement"})
+	c.Assert(err, gc.ErrorMatches, `unknown placement directive: invalid-placement`)
+	c.Assert(zone, gc.Equals, "")
+}
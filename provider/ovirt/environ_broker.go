@@ -0,0 +1,339 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+	"github.com/juju/juju/status"
+)
+
+// oVirt has no folders or resource groups, so Juju identifies the VMs
+// belonging to models and controllers by assigning them tags.
+const (
+	modelTagPrefix        = "juju-model-"
+	controllerTagPrefix   = "juju-controller-"
+	isControllerTagPrefix = "juju-is-controller-"
+)
+
+// modelTag returns the name of the tag assigned to each VM in the
+// model with the given UUID.
+func modelTag(modelUUID string) string {
+	return modelTagPrefix + modelUUID
+}
+
+// controllerTag returns the name of the tag assigned to each VM
+// managed by the controller with the given UUID.
+func controllerTag(controllerUUID string) string {
+	return controllerTagPrefix + controllerUUID
+}
+
+// isControllerTag returns the name of the tag assigned to each
+// controller machine of the controller with the given UUID.
+func isControllerTag(controllerUUID string) string {
+	return isControllerTagPrefix + controllerUUID
+}
+
+// vmSearch returns an oVirt search query that matches the VMs with
+// the named tag.
+func vmSearch(tag string) string {
+	return "tag=" + tag
+}
+
+func (env *environ) modelTag() string {
+	return modelTag(env.Config().UUID())
+}
+
+// MaintainInstance is specified in the InstanceBroker interface.
+func (*environ) MaintainInstance(args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *environ) StartInstance(args environs.StartInstanceParams) (result *environs.StartInstanceResult, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		result, err = env.StartInstance(args)
+		return err
+	})
+	return result, err
+}
+
+// StartInstance implements environs.InstanceBroker.
+func (env *sessionEnviron) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	img, err := findImageMetadata(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := common.FinishInstanceConfig(args, img.Arch, env.Config()); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	vm, hw, err := env.newRawInstance(args, img)
+	if err != nil {
+		args.StatusCallback(status.ProvisioningError, fmt.Sprint(err), nil)
+		return nil, errors.Trace(err)
+	}
+
+	logger.Infof("started instance %q", vm.Name)
+	inst := newInstance(vm, env.environ)
+	result := environs.StartInstanceResult{
+		Instance: inst,
+		Hardware: hw,
+	}
+	return &result, nil
+}
+
+// newRawInstance clones a VM from the template described by img, in
+// the cluster chosen by the placement directive, and returns it along
+// with the hardware that the engine reports for it. The user data is
+// passed to cloud-init in the initialization of the VM's first run,
+// so the template needs no Juju-specific preparation.
+func (env *sessionEnviron) newRawInstance(
+	args environs.StartInstanceParams,
+	img *imagemetadata.ImageMetadata,
+) (*ovirtclient.VM, *instance.HardwareCharacteristics, error) {
+
+	vmName, err := env.namespace.Hostname(args.InstanceConfig.MachineId)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	cloudcfg, err := cloudinit.New(args.InstanceConfig.Series)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	cloudcfg.AddPackage("ovirt-guest-agent")
+
+	// oVirt does not register guests in DNS, so the
+	// hostname is only resolvable through /etc/hosts.
+	cloudcfg.ManageEtcHosts(true)
+
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudcfg, OvirtRenderer{})
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "cannot make user data")
+	}
+	logger.Debugf("oVirt user data; %d bytes", len(userData))
+
+	zoneName, err := env.parseAvailabilityZone(args)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	zone, err := env.startInstanceZone(zoneName)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	vnicProfiles, err := env.startInstanceVNICProfiles(args)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	vmTags := []string{
+		modelTag(env.Config().UUID()),
+		controllerTag(args.ControllerUUID),
+	}
+	if args.InstanceConfig.Tags[tags.JujuIsController] == "true" {
+		vmTags = append(vmTags, isControllerTag(args.ControllerUUID))
+	}
+
+	cons := args.Constraints
+	createVMArgs := ovirtclient.CreateVirtualMachineParams{
+		Name:         vmName,
+		Cluster:      zone.cluster.Id,
+		Template:     img.Id,
+		UserData:     string(userData),
+		VNICProfiles: vnicProfiles,
+		Tags:         vmTags,
+	}
+	if cons.Mem != nil {
+		createVMArgs.MemoryMB = *cons.Mem
+	}
+	if cons.CpuCores != nil {
+		createVMArgs.CPUCores = *cons.CpuCores
+	}
+
+	logger.Debugf("attempting to create VM in availability zone %s", zone.Name())
+	vm, err := env.client.CreateVirtualMachine(env.ctx, createVMArgs)
+	if err != nil {
+		logger.Warningf("failed to create instance in availability zone %s: %s", zone.Name(), err)
+
+		return nil, nil, errors.Wrap(err, environs.ErrAvailabilityZoneFailed)
+	}
+
+	zoneName = zone.Name()
+	hw := &instance.HardwareCharacteristics{
+		Arch:             &img.Arch,
+		AvailabilityZone: &zoneName,
+	}
+	if vm.Memory > 0 {
+		mem := uint64(vm.Memory / 1024 / 1024)
+		hw.Mem = &mem
+	}
+	if vm.CPU != nil {
+		topology := vm.CPU.Topology
+		cores := uint64(topology.Cores * topology.Sockets * topology.Threads)
+		hw.CpuCores = &cores
+	}
+	return vm, hw, nil
+}
+
+// startInstanceZone returns the zone in which to start an instance.
+// If no zone is specified, the first zone is used.
+func (env *sessionEnviron) startInstanceZone(name string) (*ovirtAvailZone, error) {
+	if name != "" {
+		zone, err := env.availZone(name)
+		if err != nil {
+			logger.Warningf("failed to get availability zone %s: %s", name, err)
+			return nil, errors.Wrap(err, environs.ErrAvailabilityZoneFailed)
+		}
+		return zone.(*ovirtAvailZone), nil
+	}
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(zones) == 0 {
+		return nil, errors.NotFoundf("clusters in data center %q", env.cloud.Region)
+	}
+	return zones[0].(*ovirtAvailZone), nil
+}
+
+// startInstanceVNICProfiles returns the ids of the vNIC profiles with
+// which to connect an instance's network interfaces: one for the
+// configured primary network, if any, and one for each network with
+// subnets that the instance must be connected to.
+func (env *sessionEnviron) startInstanceVNICProfiles(args environs.StartInstanceParams) ([]string, error) {
+	primaryNetwork := env.environConfig().primaryNetwork()
+	if primaryNetwork == "" && len(args.SubnetsToZones) == 0 {
+		return nil, nil
+	}
+	networks, err := env.client.Networks(env.ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var networkIds []string
+	seen := set.NewStrings()
+	addNetwork := func(id string) {
+		if !seen.Contains(id) {
+			seen.Add(id)
+			networkIds = append(networkIds, id)
+		}
+	}
+	if primaryNetwork != "" {
+		var found bool
+		for _, n := range networks {
+			if n.Name == primaryNetwork {
+				addNetwork(n.Id)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.NotFoundf("primary network %q", primaryNetwork)
+		}
+	}
+	if len(args.SubnetsToZones) > 0 {
+		subnets, err := env.subnets(networks)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, subnet := range subnets {
+			if _, ok := args.SubnetsToZones[subnet.ProviderId]; ok {
+				addNetwork(string(subnet.ProviderNetworkId))
+			}
+		}
+	}
+
+	profiles, err := env.client.VNICProfiles(env.ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	profileIds := make([]string, len(networkIds))
+	for i, networkId := range networkIds {
+		profileIds[i], err = networkVNICProfile(networkId, networks, profiles)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return profileIds, nil
+}
+
+// networkVNICProfile returns the id of the vNIC profile with which to
+// connect to the network with the given id. The profile with the same
+// name as the network, which oVirt creates along with the network, is
+// preferred.
+func networkVNICProfile(networkId string, networks []*ovirtclient.Network, profiles []*ovirtclient.VNICProfile) (string, error) {
+	var networkName string
+	for _, n := range networks {
+		if n.Id == networkId {
+			networkName = n.Name
+		}
+	}
+	var result string
+	for _, profile := range profiles {
+		if profile.Network == nil || profile.Network.Id != networkId {
+			continue
+		}
+		if profile.Name == networkName {
+			return profile.Id, nil
+		}
+		if result == "" {
+			result = profile.Id
+		}
+	}
+	if result == "" {
+		return "", errors.NotFoundf("vNIC profile for network %q", networkName)
+	}
+	return result, nil
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *environ) AllInstances() (instances []instance.Instance, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		instances, err = env.AllInstances()
+		return err
+	})
+	return instances, err
+}
+
+// AllInstances implements environs.InstanceBroker.
+func (env *sessionEnviron) AllInstances() ([]instance.Instance, error) {
+	vms, err := env.client.VirtualMachines(env.ctx, vmSearch(env.modelTag()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]instance.Instance, len(vms))
+	for i, vm := range vms {
+		results[i] = newInstance(vm, env.environ)
+	}
+	return results, nil
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *environ) StopInstances(ids ...instance.Id) error {
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.StopInstances(ids...)
+	})
+}
+
+// StopInstances implements environs.InstanceBroker.
+func (env *sessionEnviron) StopInstances(ids ...instance.Id) error {
+	// Removing a VM also removes the disks created from its template,
+	// but not those attached to it for Juju storage, which are detached.
+	return common.StopInstances(ids, func(id instance.Id) error {
+		return env.client.RemoveVirtualMachine(env.ctx, string(id))
+	})
+}
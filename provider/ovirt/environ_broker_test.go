@@ -0,0 +1,236 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	coretools "github.com/juju/juju/tools"
+)
+
+type environBrokerSuite struct {
+	EnvironFixture
+	statusCallbackStub testing.Stub
+}
+
+var _ = gc.Suite(&environBrokerSuite{})
+
+func (s *environBrokerSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	s.statusCallbackStub.ResetCalls()
+
+	s.client.createdVirtualMachine = &ovirtclient.VM{
+		Id:     "new-vm",
+		Name:   "juju-f75cba-0",
+		Status: ovirtclient.VMStatusPoweringUp,
+		Memory: 2048 * 1024 * 1024,
+		CPU: &ovirtclient.CPU{Topology: ovirtclient.CPUTopology{
+			Cores:   2,
+			Sockets: 1,
+			Threads: 1,
+		}},
+		Cluster: &ovirtclient.Ref{Id: "c1"},
+	}
+}
+
+func (s *environBrokerSuite) createStartInstanceArgs(c *gc.C) environs.StartInstanceParams {
+	var cons constraints.Value
+	instanceConfig, err := instancecfg.NewBootstrapInstanceConfig(
+		coretesting.FakeControllerConfig(), cons, cons, "trusty", "",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	instanceConfig.AuthorizedKeys = fakeConfig(c).AuthorizedKeys()
+
+	tools := coretools.List{{
+		Version: version.Binary{
+			Number: version.MustParse("1.2.3"),
+			Arch:   arch.AMD64,
+			Series: "trusty",
+		},
+		URL: "https://example.org",
+	}}
+	err = instanceConfig.SetTools(tools[:1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	return environs.StartInstanceParams{
+		ControllerUUID: instanceConfig.Controller.Config.ControllerUUID(),
+		InstanceConfig: instanceConfig,
+		Tools:          tools,
+		Constraints:    cons,
+		Placement:      "zone=z1",
+		ImageMetadata: []*imagemetadata.ImageMetadata{{
+			Id:      "ubuntu-trusty",
+			Arch:    "amd64",
+			Version: "14.04",
+		}},
+		StatusCallback: func(status status.Status, info string, data map[string]interface{}) error {
+			s.statusCallbackStub.AddCall("StatusCallback", status, info, data)
+			return s.statusCallbackStub.NextErr()
+		},
+	}
+}
+
+func (s *environBrokerSuite) TestStartInstance(c *gc.C) {
+	args := s.createStartInstanceArgs(c)
+	args.InstanceConfig.Tags = map[string]string{
+		tags.JujuIsController: "true",
+	}
+	result, err := s.env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.NotNil)
+	c.Assert(result.Instance, gc.NotNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("new-vm"))
+
+	s.client.CheckCallNames(c, "Clusters", "CreateVirtualMachine", "Close")
+	call := s.client.Calls()[1]
+	c.Assert(call.Args, gc.HasLen, 2)
+	c.Assert(call.Args[0], gc.Implements, new(context.Context))
+	c.Assert(call.Args[1], gc.FitsTypeOf, ovirtclient.CreateVirtualMachineParams{})
+
+	createVMArgs := call.Args[1].(ovirtclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs.UserData, gc.Not(gc.Equals), "")
+	createVMArgs.UserData = ""
+	c.Assert(createVMArgs, jc.DeepEquals, ovirtclient.CreateVirtualMachineParams{
+		Name:     "juju-f75cba-0",
+		Cluster:  "c1",
+		Template: "ubuntu-trusty",
+		Tags: []string{
+			"juju-model-2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+			"juju-controller-" + coretesting.ControllerTag.Id(),
+			"juju-is-controller-" + coretesting.ControllerTag.Id(),
+		},
+	})
+
+	c.Assert(*result.Hardware.Arch, gc.Equals, "amd64")
+	c.Assert(*result.Hardware.AvailabilityZone, gc.Equals, "z1")
+	c.Assert(*result.Hardware.Mem, gc.Equals, uint64(2048))
+	c.Assert(*result.Hardware.CpuCores, gc.Equals, uint64(2))
+}
+
+func (s *environBrokerSuite) TestStartInstanceConstraints(c *gc.C) {
+	args := s.createStartInstanceArgs(c)
+	args.Constraints = constraints.MustParse("mem=4G cores=4")
+	_, err := s.env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	call := s.client.Calls()[1]
+	createVMArgs := call.Args[1].(ovirtclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs.MemoryMB, gc.Equals, uint64(4096))
+	c.Assert(createVMArgs.CPUCores, gc.Equals, uint64(4))
+}
+
+func (s *environBrokerSuite) TestStartInstanceNetworks(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: fakeConfig(c, coretesting.Attrs{"primary-network": "ovirtmgmt"}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.networks = []*ovirtclient.Network{
+		{Id: "net-0", Name: "ovirtmgmt"},
+		{Id: "net-1", Name: "ovn", ExternalProvider: &ovirtclient.Ref{Id: "ovn-provider"}},
+	}
+	s.client.networkSubnets = map[string][]*ovirtclient.Subnet{
+		"net-1": {{Id: "subnet-0", CIDR: "10.0.0.0/24"}},
+	}
+	s.client.vnicProfiles = []*ovirtclient.VNICProfile{
+		{Id: "profile-0", Name: "ovirtmgmt", Network: &ovirtclient.Ref{Id: "net-0"}},
+		{Id: "profile-1", Name: "other", Network: &ovirtclient.Ref{Id: "net-1"}},
+		{Id: "profile-2", Name: "ovn", Network: &ovirtclient.Ref{Id: "net-1"}},
+	}
+
+	args := s.createStartInstanceArgs(c)
+	args.SubnetsToZones = map[network.Id][]string{"subnet-0": {"z1"}}
+	_, err = env.StartInstance(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c,
+		"Clusters", "Networks", "NetworkSubnets", "NetworkSubnets",
+		"VNICProfiles", "CreateVirtualMachine", "Close",
+	)
+	createVMArgs := s.client.Calls()[5].Args[1].(ovirtclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs.VNICProfiles, jc.DeepEquals, []string{"profile-0", "profile-2"})
+}
+
+func (s *environBrokerSuite) TestStartInstanceNoTemplate(c *gc.C) {
+	args := s.createStartInstanceArgs(c)
+	args.ImageMetadata = nil
+	_, err := s.env.StartInstance(args)
+	c.Assert(err, gc.ErrorMatches, `template for series "trusty" and arches \[amd64\] not found`)
+	s.client.CheckNoCalls(c)
+}
+
+func (s *environBrokerSuite) TestStartInstanceFailure(c *gc.C) {
+	s.client.SetErrors(nil, errors.New("nope"))
+	_, err := s.env.StartInstance(s.createStartInstanceArgs(c))
+	c.Assert(errors.Cause(err), gc.Equals, environs.ErrAvailabilityZoneFailed)
+
+	s.client.CheckCallNames(c, "Clusters", "CreateVirtualMachine", "Close")
+	s.statusCallbackStub.CheckCallNames(c, "StatusCallback")
+	c.Assert(s.statusCallbackStub.Calls()[0].Args[0], gc.Equals, status.ProvisioningError)
+}
+
+func (s *environBrokerSuite) TestAllInstances(c *gc.C) {
+	s.client.virtualMachines = []*ovirtclient.VM{
+		newVM("inst-0", ovirtclient.VMStatusUp),
+		newVM("inst-1", ovirtclient.VMStatusImageLocked),
+	}
+	instances, err := s.env.AllInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 2)
+	c.Assert(instances[0].Id(), gc.Equals, instance.Id("inst-0"))
+	c.Assert(instances[0].Status().Status, gc.Equals, status.Running)
+	c.Assert(instances[1].Id(), gc.Equals, instance.Id("inst-1"))
+	c.Assert(instances[1].Status().Status, gc.Equals, status.Provisioning)
+
+	s.client.CheckCallNames(c, "VirtualMachines", "Close")
+	s.client.CheckCall(c, 0, "VirtualMachines",
+		s.client.Calls()[0].Args[0],
+		"tag=juju-model-2d02eeac-9dbb-11e4-89d3-123b93f75cba",
+	)
+}
+
+func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
+	err := s.env.StopInstances("inst-0", "inst-1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var paths []string
+	s.client.CheckCallNames(c, "RemoveVirtualMachine", "RemoveVirtualMachine", "Close")
+	for i := 0; i < 2; i++ {
+		args := s.client.Calls()[i].Args
+		paths = append(paths, args[1].(string))
+	}
+	// The instances are removed concurrently,
+	// so the order of the calls is not deterministic.
+	c.Assert(paths, jc.SameContents, []string{"inst-0", "inst-1"})
+}
+
+func (s *environBrokerSuite) TestStopInstancesOneFailure(c *gc.C) {
+	s.client.SetErrors(errors.New("bah"))
+	err := s.env.StopInstances("inst-0", "inst-1")
+
+	s.client.CheckCallNames(c, "RemoveVirtualMachine", "RemoveVirtualMachine", "Close")
+	vmName := s.client.Calls()[0].Args[1].(string)
+	if vmName != "inst-0" {
+		vmName = "inst-1"
+	}
+	c.Assert(err, gc.ErrorMatches, "failed to stop instance "+vmName+": bah")
+}
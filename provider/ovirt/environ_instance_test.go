@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+)
+
+type environInstanceSuite struct {
+	EnvironFixture
+}
+
+var _ = gc.Suite(&environInstanceSuite{})
+
+func (s *environInstanceSuite) TestInstances(c *gc.C) {
+	s.client.virtualMachines = []*ovirtclient.VM{
+		newVM("inst-0", ovirtclient.VMStatusUp),
+		newVM("inst-1", ovirtclient.VMStatusUp),
+	}
+	instances, err := s.env.Instances([]instance.Id{"inst-1", "inst-2"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(instances, gc.HasLen, 2)
+	c.Assert(instances[0].Id(), gc.Equals, instance.Id("inst-1"))
+	c.Assert(instances[1], gc.IsNil)
+}
+
+func (s *environInstanceSuite) TestInstancesNoInstances(c *gc.C) {
+	_, err := s.env.Instances([]instance.Id{"inst-0"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *environInstanceSuite) TestInstanceAddresses(c *gc.C) {
+	vm := newVM("inst-0", ovirtclient.VMStatusUp)
+	vm.ReportedDevices = &ovirtclient.ReportedDevices{
+		ReportedDevice: []*ovirtclient.ReportedDevice{{
+			Name: "eth0",
+			IPs: &ovirtclient.IPs{IP: []*ovirtclient.IP{
+				{Address: "10.0.0.2"},
+				{Address: "fe80::1"},
+			}},
+		}, {
+			Name: "lo",
+		}},
+	}
+	s.client.virtualMachines = []*ovirtclient.VM{vm}
+	instances, err := s.env.Instances([]instance.Id{"inst-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	addrs, err := instances[0].Addresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []network.Address{
+		network.NewAddress("10.0.0.2"),
+		network.NewAddress("fe80::1"),
+	})
+}
+
+func (s *environInstanceSuite) TestControllerInstances(c *gc.C) {
+	s.client.virtualMachines = []*ovirtclient.VM{
+		newVM("inst-0", ovirtclient.VMStatusUp),
+	}
+	ids, err := s.env.ControllerInstances("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, jc.DeepEquals, []instance.Id{"inst-0"})
+
+	s.client.CheckCallNames(c, "VirtualMachines", "Close")
+	c.Assert(s.client.Calls()[0].Args[1], gc.Equals, "tag=juju-is-controller-foo")
+}
+
+func (s *environInstanceSuite) TestControllerInstancesNotBootstrapped(c *gc.C) {
+	_, err := s.env.ControllerInstances("foo")
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
@@ -0,0 +1,321 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+)
+
+var _ environs.NetworkingEnviron = (*environ)(nil)
+
+// OpenPorts is part of the environs.Firewaller interface.
+func (*environ) OpenPorts(rules []network.IngressRule) error {
+	return errors.Trace(errors.NotSupportedf("OpenPorts"))
+}
+
+// ClosePorts is part of the environs.Firewaller interface.
+func (*environ) ClosePorts(rules []network.IngressRule) error {
+	return errors.Trace(errors.NotSupportedf("ClosePorts"))
+}
+
+// IngressRules is part of the environs.Firewaller interface.
+func (*environ) IngressRules() ([]network.IngressRule, error) {
+	return nil, errors.Trace(errors.NotSupportedf("IngressRules"))
+}
+
+// oVirt logical networks are mapped to Juju spaces: each network is a
+// space, whose provider id is the network's id. Only networks provided
+// by an external network provider, such as OVN, have subnets known to
+// oVirt; spaces for other networks have no subnets.
+
+// Subnets is part of the environs.Networking interface.
+func (env *environ) Subnets(instId instance.Id, subnetIds []network.Id) (subnets []network.SubnetInfo, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		subnets, err = env.Subnets(instId, subnetIds)
+		return err
+	})
+	return subnets, err
+}
+
+// Subnets is part of the environs.Networking interface.
+func (env *sessionEnviron) Subnets(instId instance.Id, subnetIds []network.Id) ([]network.SubnetInfo, error) {
+	networks, err := env.client.Networks(env.ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if instId != instance.UnknownId {
+		// Only consider the networks the instance is connected to.
+		interfaces, err := env.NetworkInterfaces(instId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		instNetworks := set.NewStrings()
+		for _, iface := range interfaces {
+			instNetworks.Add(string(iface.ProviderNetworkId))
+		}
+		var filtered []*ovirtclient.Network
+		for _, n := range networks {
+			if instNetworks.Contains(n.Id) {
+				filtered = append(filtered, n)
+			}
+		}
+		networks = filtered
+	}
+	subnets, err := env.subnets(networks)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(subnetIds) == 0 {
+		return subnets, nil
+	}
+
+	var results []network.SubnetInfo
+	missing := set.NewStrings()
+	for _, id := range subnetIds {
+		missing.Add(string(id))
+	}
+	for _, subnet := range subnets {
+		if missing.Contains(string(subnet.ProviderId)) {
+			results = append(results, subnet)
+			missing.Remove(string(subnet.ProviderId))
+		}
+	}
+	if !missing.IsEmpty() {
+		return nil, errors.NotFoundf("subnets %v", missing.SortedValues())
+	}
+	return results, nil
+}
+
+// subnets returns the subnets of the given networks. Subnets are
+// available in every zone, as networks span the data center.
+func (env *sessionEnviron) subnets(networks []*ovirtclient.Network) ([]network.SubnetInfo, error) {
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	zoneNames := make([]string, len(zones))
+	for i, zone := range zones {
+		zoneNames[i] = zone.Name()
+	}
+
+	var results []network.SubnetInfo
+	for _, n := range networks {
+		subnets, err := env.client.NetworkSubnets(env.ctx, n)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, subnet := range subnets {
+			info := network.SubnetInfo{
+				CIDR:              subnet.CIDR,
+				ProviderId:        network.Id(subnet.Id),
+				ProviderNetworkId: network.Id(n.Id),
+				SpaceProviderId:   network.Id(n.Id),
+				AvailabilityZones: zoneNames,
+			}
+			if n.VLAN != nil {
+				info.VLANTag = n.VLAN.Id
+			}
+			results = append(results, info)
+		}
+	}
+	return results, nil
+}
+
+// SuperSubnets is part of the environs.Networking interface.
+func (*environ) SuperSubnets() ([]string, error) {
+	return nil, errors.NotSupportedf("super subnets")
+}
+
+// NetworkInterfaces is part of the environs.Networking interface.
+func (env *environ) NetworkInterfaces(instId instance.Id) (interfaces []network.InterfaceInfo, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		interfaces, err = env.NetworkInterfaces(instId)
+		return err
+	})
+	return interfaces, err
+}
+
+// NetworkInterfaces is part of the environs.Networking interface.
+func (env *sessionEnviron) NetworkInterfaces(instId instance.Id) ([]network.InterfaceInfo, error) {
+	instances, err := env.Instances([]instance.Id{instId})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	vm := instances[0].(*environInstance).base
+	if vm.NICs == nil {
+		return nil, nil
+	}
+
+	networks, err := env.client.Networks(env.ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	profiles, err := env.client.VNICProfiles(env.ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	networksById := make(map[string]*ovirtclient.Network)
+	for _, n := range networks {
+		networksById[n.Id] = n
+	}
+	profileNetworks := make(map[string]*ovirtclient.Network)
+	for _, profile := range profiles {
+		if profile.Network != nil {
+			profileNetworks[profile.Id] = networksById[profile.Network.Id]
+		}
+	}
+
+	// The guest agent reports the name and addresses of each device,
+	// which we match up with the NICs by MAC address.
+	devices := make(map[string]*ovirtclient.ReportedDevice)
+	if vm.ReportedDevices != nil {
+		for _, device := range vm.ReportedDevices.ReportedDevice {
+			if device.MAC != nil {
+				devices[device.MAC.Address] = device
+			}
+		}
+	}
+
+	var results []network.InterfaceInfo
+	for i, nic := range vm.NICs.NIC {
+		info := network.InterfaceInfo{
+			DeviceIndex:   i,
+			ProviderId:    network.Id(nic.Id),
+			InterfaceType: network.EthernetInterface,
+			ConfigType:    network.ConfigDHCP,
+		}
+		if nic.MAC != nil {
+			info.MACAddress = nic.MAC.Address
+		}
+		if nic.VNICProfile != nil {
+			if n := profileNetworks[nic.VNICProfile.Id]; n != nil {
+				info.ProviderNetworkId = network.Id(n.Id)
+				info.ProviderSpaceId = network.Id(n.Id)
+				if n.VLAN != nil {
+					info.VLANTag = n.VLAN.Id
+				}
+				if err := env.setInterfaceAddress(&info, n, devices[info.MACAddress]); err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+		}
+		results = append(results, info)
+	}
+	return results, nil
+}
+
+// setInterfaceAddress sets the interface's name and address from the
+// device reported by the guest agent, if any, along with the subnet
+// containing the address.
+func (env *sessionEnviron) setInterfaceAddress(info *network.InterfaceInfo, n *ovirtclient.Network, device *ovirtclient.ReportedDevice) error {
+	if device == nil {
+		return nil
+	}
+	info.InterfaceName = device.Name
+	if device.IPs == nil || len(device.IPs.IP) == 0 {
+		return nil
+	}
+	ip := net.ParseIP(device.IPs.IP[0].Address)
+	if ip == nil {
+		return nil
+	}
+	info.Address = network.NewAddress(ip.String())
+	subnets, err := env.client.NetworkSubnets(env.ctx, n)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, subnet := range subnets {
+		_, ipNet, err := net.ParseCIDR(subnet.CIDR)
+		if err != nil || !ipNet.Contains(ip) {
+			continue
+		}
+		info.CIDR = subnet.CIDR
+		info.ProviderSubnetId = network.Id(subnet.Id)
+		break
+	}
+	return nil
+}
+
+// SupportsSpaces is part of the environs.Networking interface.
+func (*environ) SupportsSpaces() (bool, error) {
+	return true, nil
+}
+
+// SupportsSpaceDiscovery is part of the environs.Networking interface.
+func (*environ) SupportsSpaceDiscovery() (bool, error) {
+	return true, nil
+}
+
+// Spaces is part of the environs.Networking interface.
+func (env *environ) Spaces() (spaces []network.SpaceInfo, err error) {
+	err = env.withSession(func(env *sessionEnviron) error {
+		spaces, err = env.Spaces()
+		return err
+	})
+	return spaces, err
+}
+
+// Spaces is part of the environs.Networking interface.
+func (env *sessionEnviron) Spaces() ([]network.SpaceInfo, error) {
+	networks, err := env.client.Networks(env.ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]network.SpaceInfo, len(networks))
+	for i, n := range networks {
+		subnets, err := env.subnets([]*ovirtclient.Network{n})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results[i] = network.SpaceInfo{
+			Name:       n.Name,
+			ProviderId: network.Id(n.Id),
+			Subnets:    subnets,
+		}
+	}
+	return results, nil
+}
+
+// ProviderSpaceInfo is part of the environs.Networking interface.
+func (*environ) ProviderSpaceInfo(space *network.SpaceInfo) (*environs.ProviderSpaceInfo, error) {
+	return nil, errors.NotSupportedf("provider space info")
+}
+
+// AreSpacesRoutable is part of the environs.Networking interface.
+func (*environ) AreSpacesRoutable(space1, space2 *environs.ProviderSpaceInfo) (bool, error) {
+	return false, nil
+}
+
+// SupportsContainerAddresses is part of the environs.Networking interface.
+func (*environ) SupportsContainerAddresses() (bool, error) {
+	return false, errors.NotSupportedf("container address allocation")
+}
+
+// AllocateContainerAddresses is part of the environs.Networking interface.
+func (*environ) AllocateContainerAddresses(
+	hostInstanceID instance.Id,
+	containerTag names.MachineTag,
+	preparedInfo []network.InterfaceInfo,
+) ([]network.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("container address allocation")
+}
+
+// ReleaseContainerAddresses is part of the environs.Networking interface.
+func (*environ) ReleaseContainerAddresses(interfaces []network.ProviderInterfaceInfo) error {
+	return errors.NotSupportedf("container address allocation")
+}
+
+// SSHAddresses is part of the environs.Networking interface.
+func (*environ) SSHAddresses(addresses []network.Address) ([]network.Address, error) {
+	return addresses, nil
+}
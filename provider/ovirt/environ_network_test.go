@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+)
+
+type environNetworkSuite struct {
+	EnvironFixture
+	netEnv environs.NetworkingEnviron
+}
+
+var _ = gc.Suite(&environNetworkSuite{})
+
+func (s *environNetworkSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	c.Assert(s.env, gc.Implements, new(environs.NetworkingEnviron))
+	s.netEnv = s.env.(environs.NetworkingEnviron)
+
+	s.client.networks = []*ovirtclient.Network{
+		{Id: "net-0", Name: "ovirtmgmt"},
+		{
+			Id:               "net-1",
+			Name:             "ovn",
+			VLAN:             &ovirtclient.VLAN{Id: 42},
+			ExternalProvider: &ovirtclient.Ref{Id: "ovn-provider"},
+		},
+	}
+	s.client.networkSubnets = map[string][]*ovirtclient.Subnet{
+		"net-1": {{Id: "subnet-0", CIDR: "10.0.0.0/24"}},
+	}
+	s.client.vnicProfiles = []*ovirtclient.VNICProfile{
+		{Id: "profile-0", Name: "ovirtmgmt", Network: &ovirtclient.Ref{Id: "net-0"}},
+		{Id: "profile-1", Name: "ovn", Network: &ovirtclient.Ref{Id: "net-1"}},
+	}
+}
+
+func (s *environNetworkSuite) TestSubnets(c *gc.C) {
+	subnets, err := s.netEnv.Subnets(instance.UnknownId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		CIDR:              "10.0.0.0/24",
+		ProviderId:        "subnet-0",
+		ProviderNetworkId: "net-1",
+		SpaceProviderId:   "net-1",
+		VLANTag:           42,
+		AvailabilityZones: []string{"z1", "z2"},
+	}})
+}
+
+func (s *environNetworkSuite) TestSubnetsNotFound(c *gc.C) {
+	_, err := s.netEnv.Subnets(instance.UnknownId, []network.Id{"subnet-0", "subnet-1"})
+	c.Assert(err, gc.ErrorMatches, `subnets \[subnet-1\] not found`)
+}
+
+func (s *environNetworkSuite) TestSpaces(c *gc.C) {
+	spaces, err := s.netEnv.Spaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spaces, gc.HasLen, 2)
+	c.Assert(spaces[0].Name, gc.Equals, "ovirtmgmt")
+	c.Assert(spaces[0].ProviderId, gc.Equals, network.Id("net-0"))
+	c.Assert(spaces[0].Subnets, gc.HasLen, 0)
+	c.Assert(spaces[1].Name, gc.Equals, "ovn")
+	c.Assert(spaces[1].ProviderId, gc.Equals, network.Id("net-1"))
+	c.Assert(spaces[1].Subnets, gc.HasLen, 1)
+}
+
+func (s *environNetworkSuite) TestNetworkInterfaces(c *gc.C) {
+	vm := newVM("inst-0", ovirtclient.VMStatusUp)
+	vm.NICs = &ovirtclient.NICs{NIC: []*ovirtclient.NIC{{
+		Id:          "nic-0",
+		MAC:         &ovirtclient.MAC{Address: "00:1a:4a:16:01:51"},
+		VNICProfile: &ovirtclient.Ref{Id: "profile-0"},
+	}, {
+		Id:          "nic-1",
+		MAC:         &ovirtclient.MAC{Address: "00:1a:4a:16:01:52"},
+		VNICProfile: &ovirtclient.Ref{Id: "profile-1"},
+	}}}
+	vm.ReportedDevices = &ovirtclient.ReportedDevices{
+		ReportedDevice: []*ovirtclient.ReportedDevice{{
+			Name: "ens4",
+			MAC:  &ovirtclient.MAC{Address: "00:1a:4a:16:01:52"},
+			IPs:  &ovirtclient.IPs{IP: []*ovirtclient.IP{{Address: "10.0.0.5"}}},
+		}},
+	}
+	s.client.virtualMachines = []*ovirtclient.VM{vm}
+
+	interfaces, err := s.netEnv.NetworkInterfaces("inst-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interfaces, jc.DeepEquals, []network.InterfaceInfo{{
+		DeviceIndex:       0,
+		ProviderId:        "nic-0",
+		MACAddress:        "00:1a:4a:16:01:51",
+		InterfaceType:     network.EthernetInterface,
+		ConfigType:        network.ConfigDHCP,
+		ProviderNetworkId: "net-0",
+		ProviderSpaceId:   "net-0",
+	}, {
+		DeviceIndex:       1,
+		ProviderId:        "nic-1",
+		MACAddress:        "00:1a:4a:16:01:52",
+		InterfaceType:     network.EthernetInterface,
+		ConfigType:        network.ConfigDHCP,
+		ProviderNetworkId: "net-1",
+		ProviderSpaceId:   "net-1",
+		ProviderSubnetId:  "subnet-0",
+		VLANTag:           42,
+		InterfaceName:     "ens4",
+		CIDR:              "10.0.0.0/24",
+		Address:           network.NewAddress("10.0.0.5"),
+	}})
+}
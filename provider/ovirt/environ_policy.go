@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"github.com/juju/utils/arch"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+)
+
+// PrecheckInstance is part of the environs.Environ interface.
+func (env *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error {
+	if args.Placement == "" {
+		return nil
+	}
+	return env.withSession(func(env *sessionEnviron) error {
+		return env.PrecheckInstance(args)
+	})
+}
+
+// PrecheckInstance is part of the environs.Environ interface.
+func (env *sessionEnviron) PrecheckInstance(args environs.PrecheckInstanceParams) error {
+	_, err := env.parsePlacement(args.Placement)
+	return err
+}
+
+// unsupportedConstraints lists the constraints that the provider does
+// not support. Root disks are created from templates, and so cannot be
+// sized with the root-disk constraint.
+var unsupportedConstraints = []string{
+	constraints.Tags,
+	constraints.VirtType,
+	constraints.AllocatePublicIP,
+	constraints.ImageID,
+	constraints.InstanceLifecycle,
+	constraints.MaxPrice,
+	constraints.InstanceType,
+	constraints.CpuPower,
+	constraints.RootDisk,
+}
+
+// ConstraintsValidator returns a Validator value which is used to
+// validate and merge constraints.
+func (env *environ) ConstraintsValidator() (constraints.Validator, error) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterVocabulary(constraints.Arch, []string{
+		arch.AMD64, arch.PPC64EL,
+	})
+	return validator, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/ovirt"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+)
+
+// ProviderFixture provides an oVirt provider whose connections to the
+// engine are made to a mock client, recording the dial arguments.
+type ProviderFixture struct {
+	testing.IsolationSuite
+	dialStub testing.Stub
+	client   *mockClient
+	provider environs.EnvironProvider
+}
+
+func (s *ProviderFixture) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dialStub.ResetCalls()
+	s.client = &mockClient{}
+	s.provider = ovirt.NewEnvironProvider(ovirt.EnvironProviderConfig{
+		Dial: newMockDialFunc(&s.dialStub, s.client),
+	})
+}
+
+// EnvironFixture provides an environ for the "dc1" data center, which
+// has two clusters, "z1" and "z2". Juju exposes the clusters as zones.
+type EnvironFixture struct {
+	ProviderFixture
+	env environs.Environ
+}
+
+func (s *EnvironFixture) SetUpTest(c *gc.C) {
+	s.ProviderFixture.SetUpTest(c)
+	s.client.clusters = []*ovirtclient.Cluster{
+		newCluster("c1", "z1"),
+		newCluster("c2", "z2"),
+	}
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.env = env
+}
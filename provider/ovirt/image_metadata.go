@@ -0,0 +1,33 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/imagemetadata"
+)
+
+// findImageMetadata returns the metadata of the template from which to
+// create an instance, chosen from the image metadata passed to
+// StartInstance. The image ids of oVirt image metadata are the names
+// or ids of templates; they are usually configured with the "image-ids"
+// model config, or with custom image metadata.
+func findImageMetadata(args environs.StartInstanceParams) (*imagemetadata.ImageMetadata, error) {
+	instanceSeries := args.Tools.OneSeries()
+	seriesVersion, err := series.SeriesVersion(instanceSeries)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	arches := set.NewStrings(args.Tools.Arches()...)
+	for _, img := range args.ImageMetadata {
+		if img.Version == seriesVersion && arches.Contains(img.Arch) {
+			return img, nil
+		}
+	}
+	return nil, errors.NotFoundf("template for series %q and arches %v", instanceSeries, arches.SortedValues())
+}
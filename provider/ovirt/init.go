@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+)
+
+const (
+	providerType = "ovirt"
+)
+
+func init() {
+	dial := func(ctx context.Context, u *url.URL, dc string) (Client, error) {
+		return ovirtclient.Dial(ctx, u, dc, logger)
+	}
+	environs.RegisterProvider(providerType, NewEnvironProvider(EnvironProviderConfig{
+		Dial: dial,
+	}))
+}
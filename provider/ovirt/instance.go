@@ -0,0 +1,65 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+	"github.com/juju/juju/status"
+)
+
+type environInstance struct {
+	base *ovirtclient.VM
+	env  *environ
+}
+
+var _ instance.Instance = (*environInstance)(nil)
+
+func newInstance(base *ovirtclient.VM, env *environ) *environInstance {
+	return &environInstance{
+		base: base,
+		env:  env,
+	}
+}
+
+// Id implements instance.Instance.
+func (inst *environInstance) Id() instance.Id {
+	return instance.Id(inst.base.Id)
+}
+
+// Status implements instance.Instance.
+func (inst *environInstance) Status() instance.InstanceStatus {
+	instanceStatus := instance.InstanceStatus{
+		Status:  status.Empty,
+		Message: inst.base.Status,
+	}
+	switch inst.base.Status {
+	case ovirtclient.VMStatusUp:
+		instanceStatus.Status = status.Running
+	case ovirtclient.VMStatusImageLocked, ovirtclient.VMStatusPoweringUp:
+		instanceStatus.Status = status.Provisioning
+	}
+	return instanceStatus
+}
+
+// Addresses implements instance.Instance.
+//
+// The addresses are those reported by the guest agent running in the
+// VM, so there will be none until the agent has started.
+func (inst *environInstance) Addresses() ([]network.Address, error) {
+	if inst.base.ReportedDevices == nil {
+		return nil, nil
+	}
+	var res []network.Address
+	for _, device := range inst.base.ReportedDevices.ReportedDevice {
+		if device.IPs == nil {
+			continue
+		}
+		for _, ip := range device.IPs.IP {
+			res = append(res, network.NewAddress(ip.Address))
+		}
+	}
+	return res, nil
+}
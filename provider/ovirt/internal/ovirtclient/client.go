@@ -0,0 +1,263 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirtclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+)
+
+const (
+	// pollInterval is the interval at which the status of
+	// asynchronous operations is polled.
+	pollInterval = 2 * time.Second
+
+	// waitTimeout is the maximum amount of time to wait for an
+	// asynchronous operation, such as copying a template's disks,
+	// to complete.
+	waitTimeout = 15 * time.Minute
+)
+
+// Client encapsulates an oVirt REST API client, exposing the subset
+// of functionality that we require in the Juju provider.
+type Client struct {
+	http       *http.Client
+	baseURL    *url.URL
+	user       string
+	password   string
+	dataCenter string
+	logger     loggo.Logger
+	clock      clock.Clock
+
+	// dataCenterId caches the id of the data center, which
+	// is resolved on first use.
+	dataCenterId string
+}
+
+// Dial returns a new oVirt client for the API at the given URL,
+// scoped to the specified data center. The URL must include the
+// credentials to authenticate with. The credentials are verified
+// before Dial returns. The resulting Client's Close method must be
+// called in order to release resources allocated by Dial.
+func Dial(
+	ctx context.Context,
+	u *url.URL,
+	dataCenter string,
+	logger loggo.Logger,
+) (*Client, error) {
+	baseURL := *u
+	baseURL.User = nil
+	var user, password string
+	if u.User != nil {
+		user = u.User.Username()
+		password, _ = u.User.Password()
+	}
+	c := &Client{
+		// oVirt engines are commonly deployed with self-signed
+		// certificates, so we do not verify the certificate, as
+		// is done for vSphere.
+		http:       utils.GetNonValidatingHTTPClient(),
+		baseURL:    &baseURL,
+		user:       user,
+		password:   password,
+		dataCenter: dataCenter,
+		logger:     logger,
+		clock:      clock.WallClock,
+	}
+	// Fetch the API root to verify the credentials.
+	if err := c.get(ctx, "", nil, nil); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c, nil
+}
+
+// Close releases the resources held by the client.
+func (c *Client) Close(ctx context.Context) error {
+	if t, ok := c.http.Transport.(interface {
+		CloseIdleConnections()
+	}); ok {
+		t.CloseIdleConnections()
+	}
+	return nil
+}
+
+// Error is an error returned by the oVirt API.
+type Error struct {
+	StatusCode int
+	Reason     string `json:"reason"`
+	Detail     string `json:"detail"`
+}
+
+// Error is part of the error interface.
+func (e *Error) Error() string {
+	msg := e.Reason
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	return msg
+}
+
+// IsUnauthorized reports whether the error was caused by the API
+// rejecting the client's credentials.
+func IsUnauthorized(err error) bool {
+	e, ok := errors.Cause(err).(*Error)
+	return ok && e.StatusCode == http.StatusUnauthorized
+}
+
+// IsNotFound reports whether the error was caused by the API
+// reporting that the requested entity does not exist.
+func IsNotFound(err error) bool {
+	e, ok := errors.Cause(err).(*Error)
+	return ok && e.StatusCode == http.StatusNotFound
+}
+
+func (c *Client) get(ctx context.Context, p string, query url.Values, out interface{}) error {
+	return c.do(ctx, "GET", p, query, nil, out)
+}
+
+func (c *Client) post(ctx context.Context, p string, in, out interface{}) error {
+	return c.do(ctx, "POST", p, nil, in, out)
+}
+
+func (c *Client) put(ctx context.Context, p string, in, out interface{}) error {
+	return c.do(ctx, "PUT", p, nil, in, out)
+}
+
+func (c *Client) delete(ctx context.Context, p string) error {
+	return c.do(ctx, "DELETE", p, nil, nil, nil)
+}
+
+// do sends a request to the API, encoding in as the JSON request body
+// if it is non-nil, and decoding the JSON response body into out if it
+// is non-nil.
+func (c *Client) do(ctx context.Context, method, p string, query url.Values, in, out interface{}) error {
+	u := *c.baseURL
+	u.Path = path.Join(u.Path, p)
+	u.RawQuery = query.Encode()
+
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, u.String(), body)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req = req.WithContext(ctx)
+	req.SetBasicAuth(c.user, c.password)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Version", "4")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	c.logger.Tracef("%s %s", method, u.String())
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil {
+			c.logger.Debugf("cannot decode %s error response: %v", resp.Status, err)
+		}
+		return errors.Annotatef(apiErr, "%s %s", method, u.Path)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.Annotatef(err, "decoding %s %s response", method, u.Path)
+	}
+	return nil
+}
+
+// waitFor calls the given function until it returns true or an
+// error, or until the operation described by what times out.
+func (c *Client) waitFor(ctx context.Context, what string, done func() (bool, error)) error {
+	timeout := c.clock.After(waitTimeout)
+	for {
+		ok, err := done()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Annotatef(ctx.Err(), "waiting for %s", what)
+		case <-timeout:
+			return errors.Errorf("timed out waiting for %s", what)
+		case <-c.clock.After(pollInterval):
+		}
+	}
+}
+
+// resolveDataCenter returns the id of the client's data center.
+func (c *Client) resolveDataCenter(ctx context.Context) (string, error) {
+	if c.dataCenterId != "" {
+		return c.dataCenterId, nil
+	}
+	var result struct {
+		DataCenter []*Ref `json:"data_center"`
+	}
+	query := url.Values{"search": {fmt.Sprintf("name=%s", c.dataCenter)}}
+	if err := c.get(ctx, "datacenters", query, &result); err != nil {
+		return "", errors.Annotate(err, "listing data centers")
+	}
+	for _, dc := range result.DataCenter {
+		if dc.Name == c.dataCenter {
+			c.dataCenterId = dc.Id
+			return dc.Id, nil
+		}
+	}
+	return "", errors.NotFoundf("data center %q", c.dataCenter)
+}
+
+// dataCenterPath returns the path of the given collection within the
+// client's data center.
+func (c *Client) dataCenterPath(ctx context.Context, collection string) (string, error) {
+	id, err := c.resolveDataCenter(ctx)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return path.Join("datacenters", id, collection), nil
+}
+
+// Clusters returns the clusters in the client's data center.
+func (c *Client) Clusters(ctx context.Context) ([]*Cluster, error) {
+	p, err := c.dataCenterPath(ctx, "clusters")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result struct {
+		Cluster []*Cluster `json:"cluster"`
+	}
+	if err := c.get(ctx, p, nil, &result); err != nil {
+		return nil, errors.Annotate(err, "listing clusters")
+	}
+	return result.Cluster, nil
+}
@@ -0,0 +1,411 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirtclient
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+
+	server    *httptest.Server
+	responses map[string]string
+	requests  []request
+
+	// onRequest, if non-nil, is called for each request
+	// before it is served.
+	onRequest func(*http.Request)
+}
+
+// request records a request made to the fake engine.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   map[string]interface{}
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.onRequest = nil
+	s.responses = map[string]string{
+		"GET /ovirt-engine/api":             `{"product_info": {"name": "oVirt Engine"}}`,
+		"GET /ovirt-engine/api/datacenters": `{"data_center": [{"id": "dc-id", "name": "dc1"}]}`,
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.AddCleanup(func(*gc.C) {
+		s.server.Close()
+	})
+}
+
+func (s *clientSuite) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if s.onRequest != nil {
+		s.onRequest(req)
+	}
+	user, password, _ := req.BasicAuth()
+	if user != "admin@internal" || password != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"reason": "Operation Failed", "detail": "not authorized"}`))
+		return
+	}
+	r := request{
+		method: req.Method,
+		path:   req.URL.Path,
+		query:  req.URL.Query(),
+	}
+	if data, _ := ioutil.ReadAll(req.Body); len(data) > 0 {
+		json.Unmarshal(data, &r.body)
+	}
+	s.requests = append(s.requests, r)
+
+	response, ok := s.responses[req.Method+" "+req.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"reason": "Operation Failed", "detail": "Entity not found"}`))
+		return
+	}
+	w.Write([]byte(response))
+}
+
+func (s *clientSuite) dial(c *gc.C) *Client {
+	u, err := url.Parse(s.server.URL + "/ovirt-engine/api")
+	c.Assert(err, jc.ErrorIsNil)
+	u.User = url.UserPassword("admin@internal", "secret")
+	client, err := Dial(context.Background(), u, "dc1", loggo.GetLogger("ovirtclient_test"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.requests = nil
+	return client
+}
+
+func (s *clientSuite) checkRequests(c *gc.C, expect ...string) {
+	var actual []string
+	for _, r := range s.requests {
+		actual = append(actual, r.method+" "+r.path)
+	}
+	c.Assert(actual, jc.DeepEquals, expect)
+}
+
+func (s *clientSuite) TestDial(c *gc.C) {
+	s.dial(c)
+}
+
+func (s *clientSuite) TestDialUnauthorized(c *gc.C) {
+	u, err := url.Parse(s.server.URL + "/ovirt-engine/api")
+	c.Assert(err, jc.ErrorIsNil)
+	u.User = url.User("juju")
+	_, err = Dial(context.Background(), u, "dc1", loggo.GetLogger("ovirtclient_test"))
+	c.Assert(err, gc.ErrorMatches, "GET /ovirt-engine/api: Operation Failed: not authorized")
+	c.Assert(IsUnauthorized(err), jc.IsTrue)
+}
+
+func (s *clientSuite) TestClusters(c *gc.C) {
+	s.responses["GET /ovirt-engine/api/datacenters/dc-id/clusters"] = `{"cluster": [
+		{"id": "c1-id", "name": "cluster1"},
+		{"id": "c2-id", "name": "cluster2"}
+	]}`
+	client := s.dial(c)
+	clusters, err := client.Clusters(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(clusters, jc.DeepEquals, []*Cluster{
+		{Id: "c1-id", Name: "cluster1"},
+		{Id: "c2-id", Name: "cluster2"},
+	})
+	s.checkRequests(c,
+		"GET /ovirt-engine/api/datacenters",
+		"GET /ovirt-engine/api/datacenters/dc-id/clusters",
+	)
+	c.Assert(s.requests[0].query.Get("search"), gc.Equals, "name=dc1")
+
+	// The data center id is cached.
+	s.requests = nil
+	_, err = client.Clusters(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkRequests(c, "GET /ovirt-engine/api/datacenters/dc-id/clusters")
+}
+
+func (s *clientSuite) TestClustersDataCenterNotFound(c *gc.C) {
+	s.responses["GET /ovirt-engine/api/datacenters"] = `{}`
+	client := s.dial(c)
+	_, err := client.Clusters(context.Background())
+	c.Assert(err, gc.ErrorMatches, `data center "dc1" not found`)
+}
+
+func (s *clientSuite) TestVirtualMachines(c *gc.C) {
+	s.responses["GET /ovirt-engine/api/vms"] = `{"vm": [{
+		"id": "vm-id",
+		"name": "juju-abcdef-0",
+		"status": "up",
+		"memory": "2147483648",
+		"cpu": {"topology": {"cores": "2", "sockets": "1", "threads": "1"}},
+		"cluster": {"id": "c1-id"},
+		"nics": {"nic": [{"id": "nic-id", "name": "nic1", "mac": {"address": "00:1a:4a:16:01:51"}, "vnic_profile": {"id": "profile-id"}}]},
+		"reported_devices": {"reported_device": [{"name": "eth0", "mac": {"address": "00:1a:4a:16:01:51"}, "ips": {"ip": [{"address": "10.0.0.2", "version": "v4"}]}}]}
+	}]}`
+	client := s.dial(c)
+	vms, err := client.VirtualMachines(context.Background(), "tag=juju-model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vms, jc.DeepEquals, []*VM{{
+		Id:      "vm-id",
+		Name:    "juju-abcdef-0",
+		Status:  VMStatusUp,
+		Memory:  2147483648,
+		CPU:     &CPU{Topology: CPUTopology{Cores: 2, Sockets: 1, Threads: 1}},
+		Cluster: &Ref{Id: "c1-id"},
+		NICs: &NICs{NIC: []*NIC{{
+			Id:          "nic-id",
+			Name:        "nic1",
+			MAC:         &MAC{Address: "00:1a:4a:16:01:51"},
+			VNICProfile: &Ref{Id: "profile-id"},
+		}}},
+		ReportedDevices: &ReportedDevices{ReportedDevice: []*ReportedDevice{{
+			Name: "eth0",
+			MAC:  &MAC{Address: "00:1a:4a:16:01:51"},
+			IPs:  &IPs{IP: []*IP{{Address: "10.0.0.2", Version: "v4"}}},
+		}}},
+	}})
+	s.checkRequests(c, "GET /ovirt-engine/api/vms")
+	c.Assert(s.requests[0].query, jc.DeepEquals, url.Values{
+		"search": {"tag=juju-model-uuid"},
+		"follow": {"nics,reported_devices"},
+	})
+}
+
+func (s *clientSuite) TestCreateVirtualMachine(c *gc.C) {
+	s.responses["POST /ovirt-engine/api/vms"] = `{"id": "vm-id", "name": "juju-abcdef-0", "status": "image_locked"}`
+	s.responses["GET /ovirt-engine/api/vms/vm-id"] = `{"id": "vm-id", "name": "juju-abcdef-0", "status": "down"}`
+	s.responses["GET /ovirt-engine/api/vms/vm-id/nics"] = `{"nic": [{"id": "nic-id", "name": "nic1"}]}`
+	s.responses["POST /ovirt-engine/api/vms/vm-id/nics"] = `{}`
+	s.responses["GET /ovirt-engine/api/tags"] = `{"tag": [{"id": "tag-id", "name": "juju-model-uuid"}]}`
+	s.responses["POST /ovirt-engine/api/tags"] = `{}`
+	s.responses["POST /ovirt-engine/api/vms/vm-id/tags"] = `{}`
+	s.responses["POST /ovirt-engine/api/vms/vm-id/start"] = `{"status": "complete"}`
+
+	client := s.dial(c)
+	vm, err := client.CreateVirtualMachine(context.Background(), CreateVirtualMachineParams{
+		Name:         "juju-abcdef-0",
+		Cluster:      "c1-id",
+		Template:     "ubuntu-16.04",
+		UserData:     "#cloud-config\n",
+		MemoryMB:     2048,
+		CPUCores:     2,
+		VNICProfiles: []string{"profile-id"},
+		Tags:         []string{"juju-model-uuid", "juju-controller-uuid"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vm.Id, gc.Equals, "vm-id")
+
+	s.checkRequests(c,
+		"POST /ovirt-engine/api/vms",
+		"GET /ovirt-engine/api/vms/vm-id",
+		"GET /ovirt-engine/api/vms/vm-id/nics",
+		"POST /ovirt-engine/api/vms/vm-id/nics",
+		"GET /ovirt-engine/api/tags",
+		"POST /ovirt-engine/api/vms/vm-id/tags",
+		"GET /ovirt-engine/api/tags",
+		"POST /ovirt-engine/api/tags",
+		"POST /ovirt-engine/api/vms/vm-id/tags",
+		"POST /ovirt-engine/api/vms/vm-id/start",
+		"GET /ovirt-engine/api/vms/vm-id",
+	)
+	c.Assert(s.requests[0].body, jc.DeepEquals, map[string]interface{}{
+		"name":     "juju-abcdef-0",
+		"cluster":  map[string]interface{}{"id": "c1-id"},
+		"template": map[string]interface{}{"name": "ubuntu-16.04"},
+		"memory":   "2147483648",
+		"cpu": map[string]interface{}{
+			"topology": map[string]interface{}{"cores": "2", "sockets": "1", "threads": "1"},
+		},
+	})
+	c.Assert(s.requests[3].body, jc.DeepEquals, map[string]interface{}{
+		"name":         "nic2",
+		"vnic_profile": map[string]interface{}{"id": "profile-id"},
+	})
+	c.Assert(s.requests[7].body, jc.DeepEquals, map[string]interface{}{
+		"name": "juju-controller-uuid",
+	})
+	c.Assert(s.requests[9].body, jc.DeepEquals, map[string]interface{}{
+		"use_cloud_init": "true",
+		"vm": map[string]interface{}{
+			"initialization": map[string]interface{}{
+				"host_name":     "juju-abcdef-0",
+				"custom_script": "#cloud-config\n",
+			},
+		},
+	})
+}
+
+func (s *clientSuite) TestCreateVirtualMachineStartFails(c *gc.C) {
+	s.responses["POST /ovirt-engine/api/vms"] = `{"id": "vm-id", "name": "juju-abcdef-0"}`
+	s.responses["GET /ovirt-engine/api/vms/vm-id"] = `{"id": "vm-id", "name": "juju-abcdef-0", "status": "down"}`
+	s.responses["GET /ovirt-engine/api/vms/vm-id/diskattachments"] = `{}`
+	s.responses["DELETE /ovirt-engine/api/vms/vm-id"] = `{}`
+
+	client := s.dial(c)
+	_, err := client.CreateVirtualMachine(context.Background(), CreateVirtualMachineParams{
+		Name:     "juju-abcdef-0",
+		Cluster:  "c1-id",
+		Template: "ubuntu-16.04",
+	})
+	c.Assert(err, gc.ErrorMatches, `starting VM "juju-abcdef-0": POST /ovirt-engine/api/vms/vm-id/start: Operation Failed: Entity not found`)
+
+	// The VM is removed when it cannot be started.
+	s.checkRequests(c,
+		"POST /ovirt-engine/api/vms",
+		"GET /ovirt-engine/api/vms/vm-id",
+		"POST /ovirt-engine/api/vms/vm-id/start",
+		"GET /ovirt-engine/api/vms/vm-id",
+		"GET /ovirt-engine/api/vms/vm-id/diskattachments",
+		"DELETE /ovirt-engine/api/vms/vm-id",
+	)
+}
+
+func (s *clientSuite) TestRemoveVirtualMachine(c *gc.C) {
+	s.responses["POST /ovirt-engine/api/vms/vm-id/stop"] = `{"status": "complete"}`
+	s.responses["GET /ovirt-engine/api/vms/vm-id/diskattachments"] = `{"disk_attachment": [
+		{"id": "root-id", "bootable": "true", "disk": {"id": "root-id"}},
+		{"id": "data-id", "bootable": "false", "disk": {"id": "data-id"}}
+	]}`
+	s.responses["DELETE /ovirt-engine/api/vms/vm-id/diskattachments/data-id"] = `{}`
+	s.responses["DELETE /ovirt-engine/api/vms/vm-id"] = `{}`
+
+	client := s.dial(c)
+	statuses := []string{"up", "down"}
+	s.onRequest = func(req *http.Request) {
+		if req.Method == "GET" && req.URL.Path == "/ovirt-engine/api/vms/vm-id" {
+			s.responses["GET /ovirt-engine/api/vms/vm-id"] = `{"id": "vm-id", "name": "juju-abcdef-0", "status": "` + statuses[0] + `"}`
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+		}
+	}
+
+	err := client.RemoveVirtualMachine(context.Background(), "vm-id")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkRequests(c,
+		"GET /ovirt-engine/api/vms/vm-id",
+		"POST /ovirt-engine/api/vms/vm-id/stop",
+		"GET /ovirt-engine/api/vms/vm-id",
+		"GET /ovirt-engine/api/vms/vm-id/diskattachments",
+		"DELETE /ovirt-engine/api/vms/vm-id/diskattachments/data-id",
+		"DELETE /ovirt-engine/api/vms/vm-id",
+	)
+}
+
+func (s *clientSuite) TestRemoveVirtualMachineNotFound(c *gc.C) {
+	client := s.dial(c)
+	err := client.RemoveVirtualMachine(context.Background(), "vm-id")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkRequests(c, "GET /ovirt-engine/api/vms/vm-id")
+}
+
+func (s *clientSuite) TestUntagVirtualMachine(c *gc.C) {
+	s.responses["GET /ovirt-engine/api/vms/vm-id/tags"] = `{"tag": [
+		{"id": "t1", "name": "juju-model-uuid"},
+		{"id": "t2", "name": "juju-controller-old"}
+	]}`
+	s.responses["DELETE /ovirt-engine/api/vms/vm-id/tags/t2"] = `{}`
+	client := s.dial(c)
+	err := client.UntagVirtualMachine(context.Background(), "vm-id", "juju-controller-old")
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkRequests(c,
+		"GET /ovirt-engine/api/vms/vm-id/tags",
+		"DELETE /ovirt-engine/api/vms/vm-id/tags/t2",
+	)
+}
+
+func (s *clientSuite) TestNetworkSubnets(c *gc.C) {
+	s.responses["GET /ovirt-engine/api/openstacknetworkproviders/ovn-id/networks"] = `{"openstack_network": [
+		{"id": "other-id", "name": "other"},
+		{"id": "ext-id", "name": "juju-net"}
+	]}`
+	s.responses["GET /ovirt-engine/api/openstacknetworkproviders/ovn-id/networks/ext-id/subnets"] = `{"openstack_subnet": [
+		{"id": "subnet-id", "name": "juju-subnet", "cidr": "10.10.0.0/24", "ip_version": "v4"}
+	]}`
+	client := s.dial(c)
+
+	subnets, err := client.NetworkSubnets(context.Background(), &Network{
+		Id:               "net-id",
+		Name:             "juju-net",
+		ExternalProvider: &Ref{Id: "ovn-id"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []*Subnet{{
+		Id: "subnet-id", Name: "juju-subnet", CIDR: "10.10.0.0/24", IPVersion: "v4",
+	}})
+
+	// Networks that are not provided externally have no known subnets.
+	s.requests = nil
+	subnets, err = client.NetworkSubnets(context.Background(), &Network{Id: "ovirtmgmt-id", Name: "ovirtmgmt"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.HasLen, 0)
+	s.checkRequests(c)
+}
+
+func (s *clientSuite) TestCreateDisk(c *gc.C) {
+	s.responses["POST /ovirt-engine/api/disks"] = `{"id": "disk-id", "status": "locked"}`
+	s.responses["GET /ovirt-engine/api/disks/disk-id"] = `{"id": "disk-id", "name": "juju-abcdef-volume-0", "status": "ok", "provisioned_size": "1073741824"}`
+	client := s.dial(c)
+	disk, err := client.CreateDisk(context.Background(), CreateDiskParams{
+		Name:          "juju-abcdef-volume-0",
+		Description:   "juju-model-uuid",
+		SizeMB:        1024,
+		StorageDomain: "data",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(disk, jc.DeepEquals, &Disk{
+		Id:              "disk-id",
+		Name:            "juju-abcdef-volume-0",
+		Status:          DiskStatusOK,
+		ProvisionedSize: 1073741824,
+	})
+	c.Assert(s.requests[0].body, jc.DeepEquals, map[string]interface{}{
+		"name":             "juju-abcdef-volume-0",
+		"description":      "juju-model-uuid",
+		"provisioned_size": "1073741824",
+		"format":           "cow",
+		"sparse":           "true",
+		"storage_domains": map[string]interface{}{
+			"storage_domain": []interface{}{map[string]interface{}{"name": "data"}},
+		},
+	})
+}
+
+func (s *clientSuite) TestAttachDisk(c *gc.C) {
+	s.responses["POST /ovirt-engine/api/vms/vm-id/diskattachments"] = `{"id": "disk-id", "interface": "virtio", "active": "true", "bootable": "false", "disk": {"id": "disk-id"}}`
+	client := s.dial(c)
+	attachment, err := client.AttachDisk(context.Background(), "vm-id", "disk-id")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachment, jc.DeepEquals, &DiskAttachment{
+		Id:        "disk-id",
+		Interface: "virtio",
+		Active:    true,
+		Disk:      &Ref{Id: "disk-id"},
+	})
+	c.Assert(s.requests[0].body, jc.DeepEquals, map[string]interface{}{
+		"interface": "virtio",
+		"active":    "true",
+		"bootable":  "false",
+		"disk":      map[string]interface{}{"id": "disk-id"},
+	})
+}
+
+func (s *clientSuite) TestDetachDiskNotAttached(c *gc.C) {
+	client := s.dial(c)
+	err := client.DetachDisk(context.Background(), "vm-id", "disk-id")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirtclient
+
+import (
+	"context"
+	"path"
+
+	"github.com/juju/errors"
+)
+
+// Networks returns the logical networks in the client's data center.
+func (c *Client) Networks(ctx context.Context) ([]*Network, error) {
+	p, err := c.dataCenterPath(ctx, "networks")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result struct {
+		Network []*Network `json:"network"`
+	}
+	if err := c.get(ctx, p, nil, &result); err != nil {
+		return nil, errors.Annotate(err, "listing networks")
+	}
+	return result.Network, nil
+}
+
+// VNICProfiles returns all of the vNIC profiles visible to the client.
+func (c *Client) VNICProfiles(ctx context.Context) ([]*VNICProfile, error) {
+	var result struct {
+		VNICProfile []*VNICProfile `json:"vnic_profile"`
+	}
+	if err := c.get(ctx, "vnicprofiles", nil, &result); err != nil {
+		return nil, errors.Annotate(err, "listing vNIC profiles")
+	}
+	return result.VNICProfile, nil
+}
+
+// NetworkSubnets returns the subnets of the given network. Only
+// networks provided by an external network provider, such as OVN,
+// have subnets known to oVirt; for other networks, NetworkSubnets
+// returns no subnets.
+func (c *Client) NetworkSubnets(ctx context.Context, network *Network) ([]*Subnet, error) {
+	if network.ExternalProvider == nil {
+		return nil, nil
+	}
+	// Networks imported from an external provider keep the name
+	// they have in the provider.
+	providerPath := path.Join("openstacknetworkproviders", network.ExternalProvider.Id, "networks")
+	var networks struct {
+		Network []*Ref `json:"openstack_network"`
+	}
+	if err := c.get(ctx, providerPath, nil, &networks); err != nil {
+		return nil, errors.Annotatef(err, "listing external networks")
+	}
+	for _, n := range networks.Network {
+		if n.Name != network.Name {
+			continue
+		}
+		var subnets struct {
+			Subnet []*Subnet `json:"openstack_subnet"`
+		}
+		if err := c.get(ctx, path.Join(providerPath, n.Id, "subnets"), nil, &subnets); err != nil {
+			return nil, errors.Annotatef(err, "listing subnets of network %q", network.Name)
+		}
+		return subnets.Subnet, nil
+	}
+	return nil, nil
+}
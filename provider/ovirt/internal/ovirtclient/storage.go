@@ -0,0 +1,155 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirtclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/juju/errors"
+)
+
+// CreateDiskParams contains the parameters required for creating a
+// new disk.
+type CreateDiskParams struct {
+	// Name is the name to give the disk.
+	Name string
+
+	// Description is the description to give the disk.
+	Description string
+
+	// SizeMB is the size of the disk, in MiB.
+	SizeMB uint64
+
+	// StorageDomain is the name of the storage domain in which to
+	// create the disk.
+	StorageDomain string
+}
+
+// StorageDomains returns the storage domains attached to the client's
+// data center.
+func (c *Client) StorageDomains(ctx context.Context) ([]*StorageDomain, error) {
+	p, err := c.dataCenterPath(ctx, "storagedomains")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result struct {
+		StorageDomain []*StorageDomain `json:"storage_domain"`
+	}
+	if err := c.get(ctx, p, nil, &result); err != nil {
+		return nil, errors.Annotate(err, "listing storage domains")
+	}
+	return result.StorageDomain, nil
+}
+
+// Disks returns the disks matching the given search query.
+func (c *Client) Disks(ctx context.Context, search string) ([]*Disk, error) {
+	var result struct {
+		Disk []*Disk `json:"disk"`
+	}
+	if err := c.get(ctx, "disks", url.Values{"search": {search}}, &result); err != nil {
+		return nil, errors.Annotatef(err, "listing disks matching %q", search)
+	}
+	return result.Disk, nil
+}
+
+func (c *Client) disk(ctx context.Context, id string) (*Disk, error) {
+	var disk Disk
+	if err := c.get(ctx, path.Join("disks", id), nil, &disk); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &disk, nil
+}
+
+// CreateDisk creates a new thinly provisioned disk, and waits for it
+// to become usable.
+func (c *Client) CreateDisk(ctx context.Context, args CreateDiskParams) (*Disk, error) {
+	spec := Disk{
+		Name:            args.Name,
+		Description:     args.Description,
+		ProvisionedSize: int64(args.SizeMB) * 1024 * 1024,
+		Format:          "cow",
+		Sparse:          true,
+		StorageDomains: &StorageDomains{
+			StorageDomain: []*Ref{{Name: args.StorageDomain}},
+		},
+	}
+	c.logger.Debugf("creating disk %q in storage domain %q", args.Name, args.StorageDomain)
+	var disk Disk
+	if err := c.post(ctx, "disks", &spec, &disk); err != nil {
+		return nil, errors.Annotatef(err, "creating disk %q", args.Name)
+	}
+	var result *Disk
+	if err := c.waitFor(ctx, fmt.Sprintf("disk %q to be created", args.Name), func() (bool, error) {
+		var err error
+		result, err = c.disk(ctx, disk.Id)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		return result.Status == DiskStatusOK, nil
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// UpdateDiskDescription updates the description of the disk with the
+// given id.
+func (c *Client) UpdateDiskDescription(ctx context.Context, id, description string) error {
+	if err := c.put(ctx, path.Join("disks", id), &Disk{Description: description}, nil); err != nil {
+		return errors.Annotatef(err, "updating disk %q", id)
+	}
+	return nil
+}
+
+// RemoveDisk removes the disk with the given id. It is not an error
+// for the disk to not exist.
+func (c *Client) RemoveDisk(ctx context.Context, id string) error {
+	c.logger.Debugf("removing disk %q", id)
+	if err := c.delete(ctx, path.Join("disks", id)); err != nil && !IsNotFound(err) {
+		return errors.Annotatef(err, "removing disk %q", id)
+	}
+	return nil
+}
+
+// DiskAttachments returns the disk attachments of the virtual machine
+// with the given id.
+func (c *Client) DiskAttachments(ctx context.Context, vmId string) ([]*DiskAttachment, error) {
+	var result struct {
+		DiskAttachment []*DiskAttachment `json:"disk_attachment"`
+	}
+	if err := c.get(ctx, path.Join("vms", vmId, "diskattachments"), nil, &result); err != nil {
+		return nil, errors.Annotatef(err, "listing disk attachments for VM %q", vmId)
+	}
+	return result.DiskAttachment, nil
+}
+
+// AttachDisk attaches the disk with the given id to the virtual machine
+// with the given id, using the virtio interface.
+func (c *Client) AttachDisk(ctx context.Context, vmId, diskId string) (*DiskAttachment, error) {
+	spec := DiskAttachment{
+		Interface: "virtio",
+		Active:    true,
+		Disk:      &Ref{Id: diskId},
+	}
+	var attachment DiskAttachment
+	if err := c.post(ctx, path.Join("vms", vmId, "diskattachments"), &spec, &attachment); err != nil {
+		return nil, errors.Annotatef(err, "attaching disk %q to VM %q", diskId, vmId)
+	}
+	return &attachment, nil
+}
+
+// DetachDisk detaches the disk with the given id from the virtual
+// machine with the given id, without removing the disk. It is not an
+// error for the disk to not be attached.
+func (c *Client) DetachDisk(ctx context.Context, vmId, diskId string) error {
+	// A disk attachment's id is the same as the disk's.
+	err := c.delete(ctx, path.Join("vms", vmId, "diskattachments", diskId))
+	if err != nil && !IsNotFound(err) {
+		return errors.Annotatef(err, "detaching disk %q from VM %q", diskId, vmId)
+	}
+	return nil
+}
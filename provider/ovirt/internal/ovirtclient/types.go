@@ -0,0 +1,184 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirtclient
+
+// The types in this file mirror the JSON representation of the oVirt
+// REST API (version 4). Only the attributes that the Juju provider
+// requires are included. Note that the API renders numbers and booleans
+// as JSON strings.
+
+// Ref is a reference to another entity, by id or by name.
+type Ref struct {
+	Id   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// VM status values, as reported by the oVirt engine.
+const (
+	VMStatusUp          = "up"
+	VMStatusDown        = "down"
+	VMStatusImageLocked = "image_locked"
+	VMStatusPoweringUp  = "powering_up"
+)
+
+// VM describes a virtual machine.
+type VM struct {
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Memory      int64  `json:"memory,string,omitempty"`
+	CPU         *CPU   `json:"cpu,omitempty"`
+	Cluster     *Ref   `json:"cluster,omitempty"`
+	Template    *Ref   `json:"template,omitempty"`
+
+	// NICs and ReportedDevices are only populated when
+	// retrieved with VirtualMachines.
+	NICs            *NICs            `json:"nics,omitempty"`
+	ReportedDevices *ReportedDevices `json:"reported_devices,omitempty"`
+}
+
+// CPU describes a virtual machine's CPU topology.
+type CPU struct {
+	Topology CPUTopology `json:"topology"`
+}
+
+// CPUTopology describes the number of virtual CPUs presented
+// to a virtual machine.
+type CPUTopology struct {
+	Cores   int `json:"cores,string"`
+	Sockets int `json:"sockets,string"`
+	Threads int `json:"threads,string"`
+}
+
+// NICs is a collection of virtual machine network interfaces.
+type NICs struct {
+	NIC []*NIC `json:"nic,omitempty"`
+}
+
+// NIC describes a virtual machine network interface.
+type NIC struct {
+	Id          string `json:"id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	MAC         *MAC   `json:"mac,omitempty"`
+	VNICProfile *Ref   `json:"vnic_profile,omitempty"`
+}
+
+// MAC holds a MAC address.
+type MAC struct {
+	Address string `json:"address"`
+}
+
+// ReportedDevices is a collection of the devices reported by the
+// guest agent running inside a virtual machine.
+type ReportedDevices struct {
+	ReportedDevice []*ReportedDevice `json:"reported_device,omitempty"`
+}
+
+// ReportedDevice describes a network device as reported by the guest
+// agent running inside a virtual machine.
+type ReportedDevice struct {
+	Name string `json:"name"`
+	MAC  *MAC   `json:"mac,omitempty"`
+	IPs  *IPs   `json:"ips,omitempty"`
+}
+
+// IPs is a collection of IP addresses.
+type IPs struct {
+	IP []*IP `json:"ip,omitempty"`
+}
+
+// IP describes an IP address.
+type IP struct {
+	Address string `json:"address"`
+	Version string `json:"version,omitempty"`
+}
+
+// Cluster describes a cluster of hosts within a data center.
+type Cluster struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	DataCenter *Ref   `json:"data_center,omitempty"`
+}
+
+// Network describes a logical network within a data center.
+type Network struct {
+	Id               string `json:"id"`
+	Name             string `json:"name"`
+	Description      string `json:"description,omitempty"`
+	VLAN             *VLAN  `json:"vlan,omitempty"`
+	DataCenter       *Ref   `json:"data_center,omitempty"`
+	ExternalProvider *Ref   `json:"external_provider,omitempty"`
+}
+
+// VLAN holds a VLAN tag.
+type VLAN struct {
+	Id int `json:"id,string"`
+}
+
+// VNICProfile describes a profile with which virtual machine
+// network interfaces may be connected to a network.
+type VNICProfile struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Network *Ref   `json:"network,omitempty"`
+}
+
+// Subnet describes a subnet of a network provided by an external
+// network provider, such as OVN.
+type Subnet struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	CIDR      string `json:"cidr"`
+	IPVersion string `json:"ip_version,omitempty"`
+}
+
+// Storage domain status values, as reported by the oVirt engine.
+const (
+	StorageDomainStatusActive = "active"
+)
+
+// StorageDomain describes a storage domain within a data center.
+type StorageDomain struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Type      string `json:"type,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Available int64  `json:"available,string,omitempty"`
+}
+
+// Disk status values, as reported by the oVirt engine.
+const (
+	DiskStatusOK     = "ok"
+	DiskStatusLocked = "locked"
+)
+
+// Disk describes a virtual disk.
+type Disk struct {
+	Id              string          `json:"id,omitempty"`
+	Name            string          `json:"name,omitempty"`
+	Description     string          `json:"description,omitempty"`
+	Status          string          `json:"status,omitempty"`
+	ProvisionedSize int64           `json:"provisioned_size,string,omitempty"`
+	Format          string          `json:"format,omitempty"`
+	Sparse          bool            `json:"sparse,string,omitempty"`
+	StorageDomains  *StorageDomains `json:"storage_domains,omitempty"`
+}
+
+// StorageDomains is a collection of storage domain references.
+type StorageDomains struct {
+	StorageDomain []*Ref `json:"storage_domain,omitempty"`
+}
+
+// DiskAttachment describes the attachment of a disk to a
+// virtual machine.
+type DiskAttachment struct {
+	Id          string `json:"id,omitempty"`
+	Interface   string `json:"interface,omitempty"`
+	Active      bool   `json:"active,string"`
+	Bootable    bool   `json:"bootable,string"`
+	LogicalName string `json:"logical_name,omitempty"`
+	Disk        *Ref   `json:"disk,omitempty"`
+	VM          *Ref   `json:"vm,omitempty"`
+}
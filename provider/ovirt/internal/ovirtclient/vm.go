@@ -0,0 +1,294 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirtclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// CreateVirtualMachineParams contains the parameters required for
+// creating a new virtual machine.
+type CreateVirtualMachineParams struct {
+	// Name is the name to give the virtual machine. The VM name is
+	// used for its hostname also.
+	Name string
+
+	// Cluster is the id of the cluster in which to create the VM.
+	Cluster string
+
+	// Template is the id or name of the template from which to
+	// create the VM.
+	Template string
+
+	// UserData is the cloud-init user-data.
+	UserData string
+
+	// MemoryMB is the amount of memory to give the VM, in MiB. If
+	// zero, the template's memory size is used.
+	MemoryMB uint64
+
+	// CPUCores is the number of virtual CPUs to give the VM. If
+	// zero, the template's CPU topology is used.
+	CPUCores uint64
+
+	// VNICProfiles holds the ids of the vNIC profiles with which to
+	// connect network interfaces, in addition to any defined by the
+	// template.
+	VNICProfiles []string
+
+	// Tags holds the names of the tags to assign to the VM. Tags
+	// that do not exist will be created.
+	Tags []string
+}
+
+// VirtualMachines returns the virtual machines matching the given
+// search query, along with their network interfaces and the devices
+// reported by their guest agents.
+func (c *Client) VirtualMachines(ctx context.Context, search string) ([]*VM, error) {
+	var result struct {
+		VM []*VM `json:"vm"`
+	}
+	query := url.Values{
+		"search": {search},
+		"follow": {"nics,reported_devices"},
+	}
+	if err := c.get(ctx, "vms", query, &result); err != nil {
+		return nil, errors.Annotatef(err, "listing VMs matching %q", search)
+	}
+	return result.VM, nil
+}
+
+func (c *Client) virtualMachine(ctx context.Context, id string) (*VM, error) {
+	var vm VM
+	if err := c.get(ctx, path.Join("vms", id), nil, &vm); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &vm, nil
+}
+
+func (c *Client) waitForVirtualMachine(ctx context.Context, id string, statuses ...string) error {
+	return c.waitFor(ctx, fmt.Sprintf("VM %s to be %v", id, statuses), func() (bool, error) {
+		vm, err := c.virtualMachine(ctx, id)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		for _, status := range statuses {
+			if vm.Status == status {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// CreateVirtualMachine creates and starts a new virtual machine from
+// a template, initialising it with cloud-init.
+func (c *Client) CreateVirtualMachine(ctx context.Context, args CreateVirtualMachineParams) (_ *VM, err error) {
+	template := &Ref{Name: args.Template}
+	if utils.IsValidUUIDString(args.Template) {
+		template = &Ref{Id: args.Template}
+	}
+	spec := VM{
+		Name:     args.Name,
+		Cluster:  &Ref{Id: args.Cluster},
+		Template: template,
+		Memory:   int64(args.MemoryMB) * 1024 * 1024,
+	}
+	if args.CPUCores > 0 {
+		spec.CPU = &CPU{Topology: CPUTopology{
+			Cores:   int(args.CPUCores),
+			Sockets: 1,
+			Threads: 1,
+		}}
+	}
+
+	c.logger.Debugf("creating VM %q from template %q", args.Name, args.Template)
+	var vm VM
+	if err := c.post(ctx, "vms", &spec, &vm); err != nil {
+		return nil, errors.Annotatef(err, "creating VM %q", args.Name)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if err := c.RemoveVirtualMachine(ctx, vm.Id); err != nil {
+			c.logger.Warningf("failed to remove VM %q: %v", args.Name, err)
+		}
+	}()
+
+	// The template's disks are copied asynchronously; the VM's
+	// image is locked until they have been.
+	if err := c.waitForVirtualMachine(ctx, vm.Id, VMStatusDown); err != nil {
+		return nil, errors.Annotate(err, "waiting for template disks to be copied")
+	}
+
+	if err := c.addNICs(ctx, vm.Id, args.VNICProfiles); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, tag := range args.Tags {
+		if err := c.TagVirtualMachine(ctx, vm.Id, tag); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	c.logger.Debugf("starting VM %q", args.Name)
+	start := map[string]interface{}{
+		"use_cloud_init": "true",
+		"vm": map[string]interface{}{
+			"initialization": map[string]interface{}{
+				"host_name":     args.Name,
+				"custom_script": args.UserData,
+			},
+		},
+	}
+	if err := c.post(ctx, path.Join("vms", vm.Id, "start"), start, nil); err != nil {
+		return nil, errors.Annotatef(err, "starting VM %q", args.Name)
+	}
+	result, err := c.virtualMachine(ctx, vm.Id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+func (c *Client) addNICs(ctx context.Context, vmId string, profiles []string) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+	var existing NICs
+	if err := c.get(ctx, path.Join("vms", vmId, "nics"), nil, &existing); err != nil {
+		return errors.Annotate(err, "listing NICs")
+	}
+	for i, profile := range profiles {
+		nic := NIC{
+			Name:        fmt.Sprintf("nic%d", len(existing.NIC)+i+1),
+			VNICProfile: &Ref{Id: profile},
+		}
+		if err := c.post(ctx, path.Join("vms", vmId, "nics"), &nic, nil); err != nil {
+			return errors.Annotatef(err, "adding NIC with vNIC profile %q", profile)
+		}
+	}
+	return nil
+}
+
+// RemoveVirtualMachine stops and removes the virtual machine with the
+// given id, along with the disks it was created with. Disks attached
+// to the VM after it was created are detached first, so that they
+// outlive the VM. It is not an error for the VM to not exist.
+func (c *Client) RemoveVirtualMachine(ctx context.Context, id string) error {
+	vm, err := c.virtualMachine(ctx, id)
+	if IsNotFound(err) {
+		c.logger.Debugf("VM %q does not exist", id)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	if vm.Status != VMStatusDown {
+		c.logger.Debugf("stopping VM %q", vm.Name)
+		if err := c.post(ctx, path.Join("vms", id, "stop"), struct{}{}, nil); err != nil {
+			return errors.Annotatef(err, "stopping VM %q", vm.Name)
+		}
+		if err := c.waitForVirtualMachine(ctx, id, VMStatusDown); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	attachments, err := c.DiskAttachments(ctx, id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, attachment := range attachments {
+		if attachment.Bootable {
+			continue
+		}
+		if err := c.DetachDisk(ctx, id, attachment.Disk.Id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	c.logger.Debugf("removing VM %q", vm.Name)
+	if err := c.delete(ctx, path.Join("vms", id)); err != nil {
+		return errors.Annotatef(err, "removing VM %q", vm.Name)
+	}
+	return nil
+}
+
+// TagVirtualMachine assigns the named tag to the virtual machine with
+// the given id, creating the tag if it does not exist.
+func (c *Client) TagVirtualMachine(ctx context.Context, id, tag string) error {
+	if err := c.ensureTag(ctx, tag); err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.post(ctx, path.Join("vms", id, "tags"), &Ref{Name: tag}, nil); err != nil {
+		return errors.Annotatef(err, "tagging VM %q with %q", id, tag)
+	}
+	return nil
+}
+
+// UntagVirtualMachine unassigns the named tag from the virtual machine
+// with the given id. It is not an error for the VM to not have the tag.
+func (c *Client) UntagVirtualMachine(ctx context.Context, id, tag string) error {
+	tags, err := c.virtualMachineTags(ctx, id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, t := range tags {
+		if t.Name != tag {
+			continue
+		}
+		if err := c.delete(ctx, path.Join("vms", id, "tags", t.Id)); err != nil {
+			return errors.Annotatef(err, "untagging VM %q with %q", id, tag)
+		}
+	}
+	return nil
+}
+
+// VirtualMachineTags returns the names of the tags assigned to the
+// virtual machine with the given id.
+func (c *Client) VirtualMachineTags(ctx context.Context, id string) ([]string, error) {
+	tags, err := c.virtualMachineTags(ctx, id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+	return names, nil
+}
+
+func (c *Client) virtualMachineTags(ctx context.Context, id string) ([]*Ref, error) {
+	var result struct {
+		Tag []*Ref `json:"tag"`
+	}
+	if err := c.get(ctx, path.Join("vms", id, "tags"), nil, &result); err != nil {
+		return nil, errors.Annotatef(err, "listing tags for VM %q", id)
+	}
+	return result.Tag, nil
+}
+
+func (c *Client) ensureTag(ctx context.Context, tag string) error {
+	var result struct {
+		Tag []*Ref `json:"tag"`
+	}
+	if err := c.get(ctx, "tags", nil, &result); err != nil {
+		return errors.Annotate(err, "listing tags")
+	}
+	for _, t := range result.Tag {
+		if t.Name == tag {
+			return nil
+		}
+	}
+	if err := c.post(ctx, "tags", &Ref{Name: tag}, nil); err != nil {
+		return errors.Annotatef(err, "creating tag %q", tag)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt_test
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/provider/ovirt"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+)
+
+func newMockDialFunc(dialStub *testing.Stub, client ovirt.Client) ovirt.DialFunc {
+	return func(ctx context.Context, u *url.URL, dataCenter string) (ovirt.Client, error) {
+		dialStub.AddCall("Dial", ctx, u, dataCenter)
+		if err := dialStub.NextErr(); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+}
+
+// mockClient is an ovirt.Client that records the engine requests made
+// by the provider and returns the canned resources held in its fields.
+type mockClient struct {
+	mu sync.Mutex
+	testing.Stub
+
+	clusters              []*ovirtclient.Cluster
+	createdVirtualMachine *ovirtclient.VM
+	virtualMachines       []*ovirtclient.VM
+	virtualMachineTags    []string
+	networks              []*ovirtclient.Network
+	networkSubnets        map[string][]*ovirtclient.Subnet
+	vnicProfiles          []*ovirtclient.VNICProfile
+	storageDomains        []*ovirtclient.StorageDomain
+	disks                 []*ovirtclient.Disk
+	createdDisk           *ovirtclient.Disk
+	diskAttachments       []*ovirtclient.DiskAttachment
+}
+
+// call records a call to the named method and returns the next
+// configured error. StopInstances removes VMs concurrently, so calls
+// are serialised to keep each recorded call paired with its error.
+func (c *mockClient) call(name string, args ...interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, name, args...)
+	return c.NextErr()
+}
+
+func (c *mockClient) Close(ctx context.Context) error {
+	return c.call("Close", ctx)
+}
+
+func (c *mockClient) AttachDisk(ctx context.Context, vmId, diskId string) (*ovirtclient.DiskAttachment, error) {
+	return &ovirtclient.DiskAttachment{
+		Id:   diskId,
+		Disk: &ovirtclient.Ref{Id: diskId},
+		VM:   &ovirtclient.Ref{Id: vmId},
+	}, c.call("AttachDisk", ctx, vmId, diskId)
+}
+
+func (c *mockClient) Clusters(ctx context.Context) ([]*ovirtclient.Cluster, error) {
+	return c.clusters, c.call("Clusters", ctx)
+}
+
+func (c *mockClient) CreateDisk(ctx context.Context, args ovirtclient.CreateDiskParams) (*ovirtclient.Disk, error) {
+	return c.createdDisk, c.call("CreateDisk", ctx, args)
+}
+
+func (c *mockClient) CreateVirtualMachine(ctx context.Context, args ovirtclient.CreateVirtualMachineParams) (*ovirtclient.VM, error) {
+	return c.createdVirtualMachine, c.call("CreateVirtualMachine", ctx, args)
+}
+
+func (c *mockClient) DetachDisk(ctx context.Context, vmId, diskId string) error {
+	return c.call("DetachDisk", ctx, vmId, diskId)
+}
+
+func (c *mockClient) DiskAttachments(ctx context.Context, vmId string) ([]*ovirtclient.DiskAttachment, error) {
+	return c.diskAttachments, c.call("DiskAttachments", ctx, vmId)
+}
+
+func (c *mockClient) Disks(ctx context.Context, search string) ([]*ovirtclient.Disk, error) {
+	return c.disks, c.call("Disks", ctx, search)
+}
+
+func (c *mockClient) NetworkSubnets(ctx context.Context, network *ovirtclient.Network) ([]*ovirtclient.Subnet, error) {
+	return c.networkSubnets[network.Id], c.call("NetworkSubnets", ctx, network)
+}
+
+func (c *mockClient) Networks(ctx context.Context) ([]*ovirtclient.Network, error) {
+	return c.networks, c.call("Networks", ctx)
+}
+
+func (c *mockClient) RemoveDisk(ctx context.Context, id string) error {
+	return c.call("RemoveDisk", ctx, id)
+}
+
+func (c *mockClient) RemoveVirtualMachine(ctx context.Context, id string) error {
+	return c.call("RemoveVirtualMachine", ctx, id)
+}
+
+func (c *mockClient) StorageDomains(ctx context.Context) ([]*ovirtclient.StorageDomain, error) {
+	return c.storageDomains, c.call("StorageDomains", ctx)
+}
+
+func (c *mockClient) TagVirtualMachine(ctx context.Context, id, tag string) error {
+	return c.call("TagVirtualMachine", ctx, id, tag)
+}
+
+func (c *mockClient) UntagVirtualMachine(ctx context.Context, id, tag string) error {
+	return c.call("UntagVirtualMachine", ctx, id, tag)
+}
+
+func (c *mockClient) UpdateDiskDescription(ctx context.Context, id, description string) error {
+	return c.call("UpdateDiskDescription", ctx, id, description)
+}
+
+func (c *mockClient) VirtualMachineTags(ctx context.Context, id string) ([]string, error) {
+	return c.virtualMachineTags, c.call("VirtualMachineTags", ctx, id)
+}
+
+func (c *mockClient) VirtualMachines(ctx context.Context, search string) ([]*ovirtclient.VM, error) {
+	return c.virtualMachines, c.call("VirtualMachines", ctx, search)
+}
+
+func (c *mockClient) VNICProfiles(ctx context.Context) ([]*ovirtclient.VNICProfile, error) {
+	return c.vnicProfiles, c.call("VNICProfiles", ctx)
+}
+
+func newCluster(id, name string) *ovirtclient.Cluster {
+	return &ovirtclient.Cluster{Id: id, Name: name}
+}
+
+func newVM(id, status string) *ovirtclient.VM {
+	return &ovirtclient.VM{
+		Id:      id,
+		Name:    "juju-" + id,
+		Status:  status,
+		Cluster: &ovirtclient.Ref{Id: "c1"},
+	}
+}
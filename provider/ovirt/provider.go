@@ -0,0 +1,154 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+)
+
+var logger = loggo.GetLogger("juju.provider.ovirt")
+
+const (
+	providerVersion1 = 1
+
+	currentProviderVersion = providerVersion1
+)
+
+type environProvider struct {
+	environProviderCredentials
+	dial DialFunc
+}
+
+// EnvironProviderConfig holds the dependencies of the oVirt
+// EnvironProvider, which tests replace to avoid contacting an engine.
+type EnvironProviderConfig struct {
+	// Dial is a function used for dialing connections to the
+	// oVirt engine.
+	Dial DialFunc
+}
+
+// NewEnvironProvider returns a new environs.EnvironProvider that will
+// dial oVirt connections with the given dial function.
+func NewEnvironProvider(config EnvironProviderConfig) environs.EnvironProvider {
+	return &environProvider{
+		dial: config.Dial,
+	}
+}
+
+// Version implements environs.EnvironProvider.
+func (p *environProvider) Version() int {
+	return currentProviderVersion
+}
+
+// Open implements environs.EnvironProvider.
+func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	env, err := newEnviron(p, args.Cloud, args.Config)
+	return env, errors.Trace(err)
+}
+
+var cloudSchema = &jsonschema.Schema{
+	Type:     []jsonschema.Type{jsonschema.ObjectType},
+	Required: []string{cloud.EndpointKey, cloud.AuthTypesKey, cloud.RegionsKey},
+	Order:    []string{cloud.EndpointKey, cloud.AuthTypesKey, cloud.RegionsKey},
+	Properties: map[string]*jsonschema.Schema{
+		cloud.EndpointKey: {
+			Singular: "the oVirt engine address or API URL",
+			Type:     []jsonschema.Type{jsonschema.StringType},
+			Format:   jsonschema.FormatURI,
+		},
+		cloud.AuthTypesKey: &jsonschema.Schema{
+			// don't need a prompt, since there's only one choice.
+			Type: []jsonschema.Type{jsonschema.ArrayType},
+			Enum: []interface{}{[]string{string(cloud.UserPassAuthType)}},
+		},
+		cloud.RegionsKey: {
+			Type:     []jsonschema.Type{jsonschema.ObjectType},
+			Singular: "data center",
+			Plural:   "data centers",
+			AdditionalProperties: &jsonschema.Schema{
+				Type:          []jsonschema.Type{jsonschema.ObjectType},
+				MaxProperties: jsonschema.Int(0),
+			},
+		},
+	},
+}
+
+// CloudSchema returns the schema for adding new clouds of this type.
+func (p *environProvider) CloudSchema() *jsonschema.Schema {
+	return cloudSchema
+}
+
+// Ping tests the connection to the cloud, to verify the endpoint is valid.
+func (p *environProvider) Ping(endpoint string) error {
+	u, err := apiURL(endpoint)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Dialing fetches the API root with basic authentication. Without
+	// a password the engine rejects the request, but a 401 response
+	// shows that the oVirt REST API is served at the URL.
+	u.User = url.User("juju")
+
+	ctx := context.Background()
+	client, err := p.dial(ctx, u, "")
+	if err != nil {
+		if ovirtclient.IsUnauthorized(err) {
+			return nil
+		}
+		logger.Errorf("Unexpected error dialing oVirt connection: %v", err)
+		return errors.Errorf("No oVirt engine available at %s", endpoint)
+	}
+	defer client.Close(ctx)
+	return nil
+}
+
+// PrepareConfig implements environs.EnvironProvider.
+func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return args.Config, nil
+}
+
+// Validate implements environs.EnvironProvider.
+func (*environProvider) Validate(cfg, old *config.Config) (valid *config.Config, err error) {
+	ecfg, err := newValidConfig(cfg, old)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid config")
+	}
+	return ecfg.Config, nil
+}
+
+func validateCloudSpec(spec environs.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Region == "" {
+		return errors.NotValidf("missing region (data center)")
+	}
+	if _, err := apiURL(spec.Endpoint); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Credential == nil {
+		return errors.NotValidf("missing credential")
+	}
+	if authType := spec.Credential.AuthType(); authType != cloud.UserPassAuthType {
+		return errors.NotSupportedf("%q auth-type", authType)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt_test
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+)
+
+type providerSuite struct {
+	ProviderFixture
+}
+
+var _ = gc.Suite(&providerSuite{})
+
+func (s *providerSuite) TestRegistered(c *gc.C) {
+	provider, err := environs.Provider("ovirt")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider, gc.NotNil)
+}
+
+func (s *providerSuite) TestOpen(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud:  fakeCloudSpec(),
+		Config: fakeConfig(c),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(env.Config().Name(), gc.Equals, "testenv")
+
+	// Opening the environ does not connect to the engine.
+	s.dialStub.CheckNoCalls(c)
+}
+
+func (s *providerSuite) TestOpenInvalidCloudSpec(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Name = ""
+	s.testOpenError(c, spec, `validating cloud spec: cloud name "" not valid`)
+}
+
+func (s *providerSuite) TestOpenMissingRegion(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Region = ""
+	s.testOpenError(c, spec, `validating cloud spec: missing region \(data center\) not valid`)
+}
+
+func (s *providerSuite) TestOpenInvalidEndpoint(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Endpoint = "gopher://engine.example.com"
+	s.testOpenError(c, spec, `validating cloud spec: invalid endpoint "gopher://engine.example.com": expected an http or https URL`)
+}
+
+func (s *providerSuite) TestOpenMissingCredential(c *gc.C) {
+	spec := fakeCloudSpec()
+	spec.Credential = nil
+	s.testOpenError(c, spec, `validating cloud spec: missing credential not valid`)
+}
+
+func (s *providerSuite) TestOpenUnsupportedCredential(c *gc.C) {
+	credential := cloud.NewCredential(cloud.OAuth1AuthType, map[string]string{})
+	spec := fakeCloudSpec()
+	spec.Credential = &credential
+	s.testOpenError(c, spec, `validating cloud spec: "oauth1" auth-type not supported`)
+}
+
+func (s *providerSuite) testOpenError(c *gc.C, spec environs.CloudSpec, expect string) {
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud:  spec,
+		Config: fakeConfig(c),
+	})
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+func (s *providerSuite) TestPrepareConfig(c *gc.C) {
+	cfg, err := s.provider.PrepareConfig(environs.PrepareConfigParams{
+		Config: fakeConfig(c),
+		Cloud:  fakeCloudSpec(),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cfg, gc.NotNil)
+}
+
+func (s *providerSuite) TestValidate(c *gc.C) {
+	config := fakeConfig(c)
+	validCfg, err := s.provider.Validate(config, nil)
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(config.AllAttrs(), gc.DeepEquals, validCfg.AllAttrs())
+}
+
+func (s *providerSuite) TestSchema(c *gc.C) {
+	y := []byte(`
+auth-types: [userpass]
+endpoint: https://engine.example.com/ovirt-engine/api
+regions:
+  Default: {}
+  dc2: {}
+`[1:])
+	var v interface{}
+	err := yaml.Unmarshal(y, &v)
+	c.Assert(err, jc.ErrorIsNil)
+	v, err = utils.ConformYAML(v)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.provider.CloudSchema().Validate(v)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type pingSuite struct {
+	ProviderFixture
+}
+
+var _ = gc.Suite(&pingSuite{})
+
+func (s *pingSuite) TestPingUnauthorized(c *gc.C) {
+	s.dialStub.SetErrors(&ovirtclient.Error{StatusCode: 401})
+	err := s.provider.Ping("engine.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.dialStub.CheckCallNames(c, "Dial")
+	call := s.dialStub.Calls()[0]
+	c.Assert(call.Args, gc.HasLen, 3)
+	c.Assert(call.Args[0], gc.Implements, new(context.Context))
+	c.Assert(call.Args[1], jc.DeepEquals, &url.URL{
+		Scheme: "https",
+		Host:   "engine.example.com",
+		Path:   "/ovirt-engine/api",
+		User:   url.User("juju"),
+	})
+	c.Assert(call.Args[2], gc.Equals, "")
+}
+
+func (s *pingSuite) TestPingNoEngine(c *gc.C) {
+	s.dialStub.SetErrors(errors.New("connection refused"))
+	err := s.provider.Ping("https://engine.example.com:8443/ovirt-engine/api")
+	c.Assert(err, gc.ErrorMatches, "No oVirt engine available at https://engine.example.com:8443/ovirt-engine/api")
+}
+
+func (s *pingSuite) TestPingInvalidScheme(c *gc.C) {
+	err := s.provider.Ping("gopher://engine.example.com")
+	c.Assert(err, gc.ErrorMatches, `invalid endpoint "gopher://engine.example.com": expected an http or https URL`)
+	s.dialStub.CheckNoCalls(c)
+}
+
+func (s *pingSuite) TestPingLoginSucceeded(c *gc.C) {
+	// The engine accepts the password-less login if it permits
+	// anonymous access to the API root, which still shows that
+	// there is an engine at the endpoint.
+	err := s.provider.Ping("engine.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "Close")
+}
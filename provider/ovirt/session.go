@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"context"
+
+	"github.com/juju/juju/provider/common"
+)
+
+// sessionEnviron holds a connection to the oVirt engine, scoped to the
+// data center named by the cloud region, for the duration of a single
+// exported environ method call. Dialing verifies the credentials with
+// the engine, so each call costs one authenticated round trip before
+// any work is done.
+//
+// A sessionEnviron is used by one goroutine only; the environ's lock
+// guards the config that it shares with other sessions.
+type sessionEnviron struct {
+	*environ
+
+	ctx    context.Context
+	client Client
+
+	// zones holds the data center's clusters, which are listed at
+	// most once per session: StartInstance may look a cluster up
+	// by name and then fall back to the first, and Subnets reports
+	// every cluster as a zone of each subnet.
+	zones []common.AvailabilityZone
+}
+
+func (env *environ) withSession(f func(*sessionEnviron) error) error {
+	session := &sessionEnviron{
+		environ: env,
+		ctx:     context.Background(),
+	}
+	return common.WithSession(func() (func() error, error) {
+		client, err := dialClient(session.ctx, env.cloud, env.provider.dial)
+		if err != nil {
+			return nil, err
+		}
+		session.client = client
+		return func() error {
+			return client.Close(session.ctx)
+		}, nil
+	}, func() error {
+		return f(session)
+	})
+}
@@ -0,0 +1,341 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	ovirtStorageProviderType = storage.ProviderType("ovirt")
+
+	// storageDomainAttr is the storage pool attribute naming the
+	// storage domain in which to create volumes. If unspecified,
+	// the model's "storage-domain" config is used; if that is
+	// unspecified too, the first active data domain is used.
+	storageDomainAttr = "storage-domain"
+
+	// storageDomainTypeData is the type of storage
+	// domains that hold the disks of VMs.
+	storageDomainTypeData = "data"
+)
+
+// diskSearch returns an oVirt search query that matches the disks
+// whose names match the given pattern.
+func diskSearch(name string) string {
+	return "name=" + name
+}
+
+// diskDescription returns the description to give to disks created
+// for volumes in the model with the given UUID, managed by the
+// controller with the given UUID. Disks cannot be tagged, so the
+// description is used to record which model and controller they
+// belong to.
+func diskDescription(modelUUID, controllerUUID string) string {
+	return modelTag(modelUUID) + " " + controllerTag(controllerUUID)
+}
+
+// parseDiskDescription returns the model and controller UUIDs recorded
+// in a disk's description by diskDescription.
+func parseDiskDescription(description string) (modelUUID, controllerUUID string) {
+	for _, field := range strings.Fields(description) {
+		switch {
+		case strings.HasPrefix(field, modelTagPrefix):
+			modelUUID = strings.TrimPrefix(field, modelTagPrefix)
+		case strings.HasPrefix(field, controllerTagPrefix):
+			controllerUUID = strings.TrimPrefix(field, controllerTagPrefix)
+		}
+	}
+	return modelUUID, controllerUUID
+}
+
+// modelDisks returns the disks created for volumes in the model.
+func (env *sessionEnviron) modelDisks() ([]*ovirtclient.Disk, error) {
+	disks, err := env.client.Disks(env.ctx, diskSearch(env.namespace.Prefix()+"*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelUUID := env.Config().UUID()
+	var results []*ovirtclient.Disk
+	for _, disk := range disks {
+		if diskModelUUID, _ := parseDiskDescription(disk.Description); diskModelUUID == modelUUID {
+			results = append(results, disk)
+		}
+	}
+	return results, nil
+}
+
+// StorageProviderTypes implements storage.ProviderRegistry.
+func (*environ) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return []storage.ProviderType{ovirtStorageProviderType}, nil
+}
+
+// StorageProvider implements storage.ProviderRegistry.
+func (env *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if t == ovirtStorageProviderType {
+		return &storageProvider{env}, nil
+	}
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+// storageProvider is a storage.Provider that creates volumes as disks
+// in oVirt storage domains.
+type storageProvider struct {
+	env *environ
+}
+
+var _ storage.Provider = (*storageProvider)(nil)
+
+// VolumeSource is part of the storage.Provider interface.
+func (p *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	storageDomain, _ := cfg.ValueString(storageDomainAttr)
+	if storageDomain == "" {
+		storageDomain = p.env.environConfig().storageDomain()
+	}
+	return &volumeSource{
+		env:           p.env,
+		storageDomain: storageDomain,
+	}, nil
+}
+
+// FilesystemSource is part of the storage.Provider interface.
+func (p *storageProvider) FilesystemSource(cfg *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is part of the storage.Provider interface.
+func (p *storageProvider) Supports(kind storage.StorageKind) bool {
+	return kind == storage.StorageKindBlock
+}
+
+// Scope is part of the storage.Provider interface.
+func (p *storageProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is part of the storage.Provider interface.
+func (p *storageProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is part of the storage.Provider interface.
+func (p *storageProvider) Releasable() bool {
+	return true
+}
+
+// DefaultPools is part of the storage.Provider interface.
+func (p *storageProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// ValidateConfig is part of the storage.Provider interface.
+func (p *storageProvider) ValidateConfig(cfg *storage.Config) error {
+	if v, ok := cfg.Attrs()[storageDomainAttr]; ok {
+		if _, ok := v.(string); !ok {
+			return errors.Errorf("%s: expected string, got %T", storageDomainAttr, v)
+		}
+	}
+	return nil
+}
+
+// volumeSource is a storage.VolumeSource that creates volumes as disks
+// in oVirt storage domains, and attaches them to VMs with the virtio
+// interface.
+type volumeSource struct {
+	env           *environ
+	storageDomain string
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// CreateVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) CreateVolumes(params []storage.VolumeParams) (results []storage.CreateVolumesResult, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		storageDomain, err := v.resolveStorageDomain(env)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		results = make([]storage.CreateVolumesResult, len(params))
+		for i, p := range params {
+			results[i].Volume, results[i].Error = v.createVolume(env, storageDomain, p)
+		}
+		return nil
+	})
+	return results, err
+}
+
+// resolveStorageDomain returns the name of the storage domain in
+// which to create volumes.
+func (v *volumeSource) resolveStorageDomain(env *sessionEnviron) (string, error) {
+	if v.storageDomain != "" {
+		return v.storageDomain, nil
+	}
+	domains, err := env.client.StorageDomains(env.ctx)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, domain := range domains {
+		if domain.Type == storageDomainTypeData && domain.Status == ovirtclient.StorageDomainStatusActive {
+			return domain.Name, nil
+		}
+	}
+	return "", errors.NotFoundf("active data storage domain in data center %q", env.cloud.Region)
+}
+
+func (v *volumeSource) createVolume(env *sessionEnviron, storageDomain string, p storage.VolumeParams) (*storage.Volume, error) {
+	disk, err := env.client.CreateDisk(env.ctx, ovirtclient.CreateDiskParams{
+		Name:          env.namespace.Value(p.Tag.String()),
+		Description:   diskDescription(env.Config().UUID(), p.ResourceTags[tags.JujuController]),
+		SizeMB:        p.Size,
+		StorageDomain: storageDomain,
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "creating volume %s", p.Tag.Id())
+	}
+	return &storage.Volume{
+		Tag:        p.Tag,
+		VolumeInfo: makeVolumeInfo(disk),
+	}, nil
+}
+
+func makeVolumeInfo(disk *ovirtclient.Disk) storage.VolumeInfo {
+	return storage.VolumeInfo{
+		VolumeId:   disk.Id,
+		Size:       uint64(disk.ProvisionedSize / 1024 / 1024),
+		Persistent: true,
+	}
+}
+
+// ListVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ListVolumes() (volumeIds []string, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		disks, err := env.modelDisks()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeIds = make([]string, len(disks))
+		for i, disk := range disks {
+			volumeIds[i] = disk.Id
+		}
+		return nil
+	})
+	return volumeIds, err
+}
+
+// DescribeVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DescribeVolumes(volumeIds []string) (results []storage.DescribeVolumesResult, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		disks, err := env.modelDisks()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		byId := make(map[string]*ovirtclient.Disk)
+		for _, disk := range disks {
+			byId[disk.Id] = disk
+		}
+		results = make([]storage.DescribeVolumesResult, len(volumeIds))
+		for i, id := range volumeIds {
+			disk, ok := byId[id]
+			if !ok {
+				results[i].Error = errors.NotFoundf("volume %q", id)
+				continue
+			}
+			info := makeVolumeInfo(disk)
+			results[i].VolumeInfo = &info
+		}
+		return nil
+	})
+	return results, err
+}
+
+// DestroyVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DestroyVolumes(volumeIds []string) (results []error, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		results = foreachVolume(volumeIds, func(id string) error {
+			return env.client.RemoveDisk(env.ctx, id)
+		})
+		return nil
+	})
+	return results, err
+}
+
+// ReleaseVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ReleaseVolumes(volumeIds []string) (results []error, err error) {
+	// Clearing the description disassociates the disk from
+	// the model and controller, so Juju will no longer manage it.
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		results = foreachVolume(volumeIds, func(id string) error {
+			return env.client.UpdateDiskDescription(env.ctx, id, "")
+		})
+		return nil
+	})
+	return results, err
+}
+
+func foreachVolume(volumeIds []string, f func(string) error) []error {
+	results := make([]error, len(volumeIds))
+	for i, id := range volumeIds {
+		results[i] = f(id)
+	}
+	return results
+}
+
+// ValidateVolumeParams is part of the storage.VolumeSource interface.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	return nil
+}
+
+// AttachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) (results []storage.AttachVolumesResult, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		results = make([]storage.AttachVolumesResult, len(params))
+		for i, p := range params {
+			results[i].VolumeAttachment, results[i].Error = attachVolume(env, p)
+		}
+		return nil
+	})
+	return results, err
+}
+
+func attachVolume(env *sessionEnviron, p storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	if _, err := env.client.AttachDisk(env.ctx, string(p.InstanceId), p.VolumeId); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeAttachment{
+		Volume:  p.Volume,
+		Machine: p.Machine,
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: virtioDeviceLink(p.VolumeId),
+		},
+	}, nil
+}
+
+// virtioDeviceLink returns the device link for a disk attached with the
+// virtio interface. oVirt sets the disk's serial number to its id, of
+// which the guest sees the first 20 characters.
+func virtioDeviceLink(diskId string) string {
+	serial := diskId
+	if len(serial) > 20 {
+		serial = serial[:20]
+	}
+	return "/dev/disk/by-id/virtio-" + serial
+}
+
+// DetachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) (results []error, err error) {
+	err = v.env.withSession(func(env *sessionEnviron) error {
+		results = make([]error, len(params))
+		for i, p := range params {
+			results[i] = env.client.DetachDisk(env.ctx, string(p.InstanceId), p.VolumeId)
+		}
+		return nil
+	})
+	return results, err
+}
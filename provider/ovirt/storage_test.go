@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/ovirt/internal/ovirtclient"
+	"github.com/juju/juju/storage"
+)
+
+type storageSuite struct {
+	EnvironFixture
+	provider storage.Provider
+}
+
+var _ = gc.Suite(&storageSuite{})
+
+func (s *storageSuite) SetUpTest(c *gc.C) {
+	s.EnvironFixture.SetUpTest(c)
+	registry, ok := s.env.(storage.ProviderRegistry)
+	c.Assert(ok, jc.IsTrue)
+	types, err := registry.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, []storage.ProviderType{"ovirt"})
+	s.provider, err = registry.StorageProvider("ovirt")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) volumeSource(c *gc.C, attrs map[string]interface{}) storage.VolumeSource {
+	cfg, err := storage.NewConfig("ovirt", "ovirt", attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := s.provider.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *storageSuite) TestProvider(c *gc.C) {
+	c.Assert(s.provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(s.provider.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+	c.Assert(s.provider.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(s.provider.Dynamic(), jc.IsTrue)
+	c.Assert(s.provider.Releasable(), jc.IsTrue)
+}
+
+func (s *storageSuite) TestValidateConfig(c *gc.C) {
+	cfg, err := storage.NewConfig("ovirt", "ovirt", map[string]interface{}{
+		"storage-domain": 123,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.provider.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `storage-domain: expected string, got int`)
+}
+
+func (s *storageSuite) TestCreateVolumes(c *gc.C) {
+	s.client.storageDomains = []*ovirtclient.StorageDomain{
+		{Id: "sd-0", Name: "iso", Type: "iso", Status: ovirtclient.StorageDomainStatusActive},
+		{Id: "sd-1", Name: "data0", Type: "data", Status: "maintenance"},
+		{Id: "sd-2", Name: "data1", Type: "data", Status: ovirtclient.StorageDomainStatusActive},
+	}
+	s.client.createdDisk = &ovirtclient.Disk{
+		Id:              "disk-0",
+		ProvisionedSize: 1024 * 1024 * 1024,
+	}
+
+	source := s.volumeSource(c, nil)
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+		ResourceTags: map[string]string{
+			tags.JujuController: "foo",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume, jc.DeepEquals, &storage.Volume{
+		Tag: names.NewVolumeTag("0"),
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId:   "disk-0",
+			Size:       1024,
+			Persistent: true,
+		},
+	})
+
+	s.client.CheckCallNames(c, "StorageDomains", "CreateDisk", "Close")
+	c.Assert(s.client.Calls()[1].Args[1], jc.DeepEquals, ovirtclient.CreateDiskParams{
+		Name:          "juju-f75cba-volume-0",
+		Description:   "juju-model-2d02eeac-9dbb-11e4-89d3-123b93f75cba juju-controller-foo",
+		SizeMB:        1024,
+		StorageDomain: "data1",
+	})
+}
+
+func (s *storageSuite) TestCreateVolumesStorageDomainConfig(c *gc.C) {
+	s.client.createdDisk = &ovirtclient.Disk{Id: "disk-0"}
+	source := s.volumeSource(c, map[string]interface{}{
+		"storage-domain": "fast",
+	})
+	_, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCallNames(c, "CreateDisk", "Close")
+	args := s.client.Calls()[0].Args[1].(ovirtclient.CreateDiskParams)
+	c.Assert(args.StorageDomain, gc.Equals, "fast")
+}
+
+func (s *storageSuite) TestCreateVolumesNoStorageDomain(c *gc.C) {
+	source := s.volumeSource(c, nil)
+	_, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, gc.ErrorMatches, `active data storage domain in data center "dc1" not found`)
+}
+
+func (s *storageSuite) TestListVolumes(c *gc.C) {
+	s.client.disks = []*ovirtclient.Disk{
+		{Id: "disk-0", Description: "juju-model-2d02eeac-9dbb-11e4-89d3-123b93f75cba juju-controller-foo"},
+		{Id: "disk-1", Description: "juju-model-deadbeef-0bad-400d-8000-4b1d0d06f00d juju-controller-foo"},
+		{Id: "disk-2"},
+	}
+	source := s.volumeSource(c, nil)
+	volumeIds, err := source.ListVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.DeepEquals, []string{"disk-0"})
+
+	s.client.CheckCallNames(c, "Disks", "Close")
+	c.Assert(s.client.Calls()[0].Args[1], gc.Equals, "name=juju-f75cba-*")
+}
+
+func (s *storageSuite) TestReleaseVolumes(c *gc.C) {
+	s.client.SetErrors(nil, errors.New("nope"))
+	source := s.volumeSource(c, nil)
+	results, err := source.ReleaseVolumes([]string{"disk-0", "disk-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, "nope")
+
+	s.client.CheckCallNames(c, "UpdateDiskDescription", "UpdateDiskDescription", "Close")
+	s.client.CheckCall(c, 0, "UpdateDiskDescription", s.client.Calls()[0].Args[0], "disk-0", "")
+}
+
+func (s *storageSuite) TestAttachVolumes(c *gc.C) {
+	source := s.volumeSource(c, nil)
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-0",
+		},
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "0123456789abcdef0123456789abcdef",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment, jc.DeepEquals, &storage.VolumeAttachment{
+		Volume:  names.NewVolumeTag("0"),
+		Machine: names.NewMachineTag("0"),
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: "/dev/disk/by-id/virtio-0123456789abcdef0123",
+		},
+	})
+	s.client.CheckCallNames(c, "AttachDisk", "Close")
+}
@@ -0,0 +1,27 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ovirt
+
+import (
+	"github.com/juju/errors"
+	jujuos "github.com/juju/utils/os"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/providerinit/renderers"
+)
+
+// OvirtRenderer renders cloud-init user-data for oVirt VMs. The
+// user-data is passed to the engine as a custom script, which it
+// appends to the cloud-init configuration it generates; it must
+// therefore be plain, unencoded YAML.
+type OvirtRenderer struct{}
+
+func (OvirtRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
+	switch os {
+	case jujuos.Ubuntu, jujuos.CentOS:
+		return renderers.RenderYAML(cfg)
+	default:
+		return nil, errors.Errorf("Cannot encode userdata for OS: %s", os.String())
+	}
+}
@@ -27,6 +27,7 @@ type Client interface {
 	DeleteDatastoreFile(context.Context, string) error
 	DestroyVMFolder(context.Context, string) error
 	EnsureVMFolder(context.Context, string) (*object.Folder, error)
+	HostGroups(context.Context, *mo.ComputeResource) ([]*vsphereclient.HostGroup, error)
 	MoveVMFolderInto(context.Context, string, string) error
 	MoveVMsInto(context.Context, string, ...types.ManagedObjectReference) error
 	RemoveVirtualMachines(context.Context, string) error
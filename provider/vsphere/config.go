@@ -4,6 +4,8 @@
 package vsphere
 
 import (
+	"regexp"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 
@@ -15,20 +17,42 @@ const (
 	cfgPrimaryNetwork  = "primary-network"
 	cfgExternalNetwork = "external-network"
 	cfgDatastore       = "datastore"
+	cfgHardwareVersion = "hardware-version"
+	cfgFirmware        = "firmware"
+	cfgSecureBoot      = "secure-boot"
+	cfgHostGroupZones  = "host-group-zones"
+)
+
+// The supported values for the firmware config key.
+const (
+	firmwareBIOS = "bios"
+	firmwareEFI  = "efi"
 )
 
+// validHardwareVersion matches a virtual hardware version, such as
+// "vmx-13", as understood by vSphere.
+var validHardwareVersion = regexp.MustCompile(`^vmx-[0-9]+$`)
+
 // configFields is the spec for each vmware config value's type.
 var (
 	configFields = schema.Fields{
 		cfgExternalNetwork: schema.String(),
 		cfgDatastore:       schema.String(),
 		cfgPrimaryNetwork:  schema.String(),
+		cfgHardwareVersion: schema.String(),
+		cfgFirmware:        schema.String(),
+		cfgSecureBoot:      schema.Bool(),
+		cfgHostGroupZones:  schema.Bool(),
 	}
 
 	configDefaults = schema.Defaults{
 		cfgExternalNetwork: "",
 		cfgDatastore:       schema.Omit,
 		cfgPrimaryNetwork:  schema.Omit,
+		cfgHardwareVersion: schema.Omit,
+		cfgFirmware:        schema.Omit,
+		cfgSecureBoot:      schema.Omit,
+		cfgHostGroupZones:  schema.Omit,
 	}
 
 	configRequiredFields  = []string{}
@@ -92,6 +116,33 @@ func (c *environConfig) primaryNetwork() string {
 	return network
 }
 
+// hardwareVersion returns the virtual hardware version with which
+// to create VMs, or "" if the image's default should be used.
+func (c *environConfig) hardwareVersion() string {
+	version, _ := c.attrs[cfgHardwareVersion].(string)
+	return version
+}
+
+// firmware returns the firmware type with which to create VMs,
+// or "" if the image's default should be used.
+func (c *environConfig) firmware() string {
+	firmware, _ := c.attrs[cfgFirmware].(string)
+	return firmware
+}
+
+func (c *environConfig) secureBoot() bool {
+	secureBoot, _ := c.attrs[cfgSecureBoot].(bool)
+	return secureBoot
+}
+
+// hostGroupZones reports whether the DRS host groups of clusters
+// should be exposed as availability zones, rather than the clusters
+// themselves.
+func (c *environConfig) hostGroupZones() bool {
+	hostGroupZones, _ := c.attrs[cfgHostGroupZones].(bool)
+	return hostGroupZones
+}
+
 // validate checks vmware-specific config values.
 func (c environConfig) validate() error {
 	// All fields must be populated, even with just the default.
@@ -100,6 +151,17 @@ func (c environConfig) validate() error {
 			return errors.Errorf("%s: must not be empty", field)
 		}
 	}
+	if version := c.hardwareVersion(); version != "" && !validHardwareVersion.MatchString(version) {
+		return errors.Errorf("%s: %q not valid, expected a value like \"vmx-13\"", cfgHardwareVersion, version)
+	}
+	switch firmware := c.firmware(); firmware {
+	case "", firmwareBIOS, firmwareEFI:
+	default:
+		return errors.Errorf("%s: %q not valid, expected %q or %q", cfgFirmware, firmware, firmwareBIOS, firmwareEFI)
+	}
+	if c.secureBoot() && c.firmware() != firmwareEFI {
+		return errors.Errorf("%s: requires %s to be %q", cfgSecureBoot, cfgFirmware, firmwareEFI)
+	}
 	return nil
 }
 
@@ -122,6 +122,34 @@ var newConfigTests = []configTestSpec{{
 	info:   "unknown field is not touched",
 	insert: testing.Attrs{"unknown-field": "12345"},
 	expect: testing.Attrs{"unknown-field": "12345"},
+}, {
+	info: "hardware and firmware options are accepted",
+	insert: testing.Attrs{
+		"hardware-version": "vmx-13",
+		"firmware":         "efi",
+		"secure-boot":      true,
+	},
+	expect: testing.Attrs{
+		"hardware-version": "vmx-13",
+		"firmware":         "efi",
+		"secure-boot":      true,
+	},
+}, {
+	info:   "host group zones are accepted",
+	insert: testing.Attrs{"host-group-zones": true},
+	expect: testing.Attrs{"host-group-zones": true},
+}, {
+	info:   "invalid hardware version",
+	insert: testing.Attrs{"hardware-version": "13"},
+	err:    `hardware-version: "13" not valid, expected a value like "vmx-13"`,
+}, {
+	info:   "invalid firmware",
+	insert: testing.Attrs{"firmware": "uboot"},
+	err:    `firmware: "uboot" not valid, expected "bios" or "efi"`,
+}, {
+	info:   "secure boot requires EFI firmware",
+	insert: testing.Attrs{"firmware": "bios", "secure-boot": true},
+	err:    `secure-boot: requires firmware to be "efi"`,
 }}
 
 func (*ConfigSuite) TestNewModelConfig(c *gc.C) {
@@ -198,6 +226,10 @@ var changeConfigTests = []configTestSpec{{
 	info:   "can insert unknown field",
 	insert: testing.Attrs{"unknown": "ignoti"},
 	expect: testing.Attrs{"unknown": "ignoti"},
+}, {
+	info:   "can change hardware version",
+	insert: testing.Attrs{"hardware-version": "vmx-11"},
+	expect: testing.Attrs{"hardware-version": "vmx-11"},
 }}
 
 func (s *ConfigSuite) TestValidateChange(c *gc.C) {
@@ -4,20 +4,34 @@
 package vsphere
 
 import (
+	"math/rand"
+
 	"github.com/juju/errors"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/vsphere/internal/vsphereclient"
 )
 
+// vmwareAvailZone is an availability zone, corresponding to a root
+// compute resource (a cluster or standalone host), or to a DRS host
+// group within a cluster.
 type vmwareAvailZone struct {
 	r mo.ComputeResource
+
+	// hostGroup, if non-nil, is the host group within r
+	// that the zone corresponds to.
+	hostGroup *vsphereclient.HostGroup
 }
 
 // Name implements common.AvailabilityZone
 func (z *vmwareAvailZone) Name() string {
+	if z.hostGroup != nil {
+		return z.r.Name + "/" + z.hostGroup.Name
+	}
 	return z.r.Name
 }
 
@@ -26,6 +40,46 @@ func (z *vmwareAvailZone) Available() bool {
 	return true
 }
 
+// hosts returns the hosts that VMs in the zone may run on.
+func (z *vmwareAvailZone) hosts() []types.ManagedObjectReference {
+	if z.hostGroup != nil {
+		return z.hostGroup.Hosts
+	}
+	return z.r.Host
+}
+
+// selectHost returns the host on which to create a VM in the zone, or
+// nil if vSphere should choose. A host is only selected for host group
+// zones, as vSphere would otherwise be free to choose any host in the
+// cluster. The host is chosen at random, to spread VMs across the group.
+func (z *vmwareAvailZone) selectHost() *types.ManagedObjectReference {
+	if z.hostGroup == nil || len(z.hostGroup.Hosts) == 0 {
+		return nil
+	}
+	host := z.hostGroup.Hosts[rand.Intn(len(z.hostGroup.Hosts))]
+	return &host
+}
+
+// contains reports whether the given VM is in the zone.
+func (z *vmwareAvailZone) contains(vm *mo.VirtualMachine) bool {
+	if host := vm.Runtime.Host; host != nil {
+		for _, ref := range z.hosts() {
+			if ref.Value == host.Value {
+				return true
+			}
+		}
+		return false
+	}
+	// The VM's host is not known, which is the case
+	// when the VM is powered off. Fall back to checking
+	// which compute resource the VM's resource pool
+	// belongs to; this cannot tell host groups apart.
+	if z.hostGroup != nil || vm.ResourcePool == nil || z.r.ResourcePool == nil {
+		return false
+	}
+	return vm.ResourcePool.Value == z.r.ResourcePool.Value
+}
+
 // AvailabilityZones is part of the common.ZonedEnviron interface.
 func (env *environ) AvailabilityZones() (zones []common.AvailabilityZone, err error) {
 	err = env.withSession(func(env *sessionEnviron) error {
@@ -42,9 +96,28 @@ func (env *sessionEnviron) AvailabilityZones() ([]common.AvailabilityZone, error
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		zones := make([]common.AvailabilityZone, len(computeResources))
-		for i, cr := range computeResources {
-			zones[i] = &vmwareAvailZone{*cr}
+		hostGroupZones := env.ecfg.hostGroupZones()
+		var zones []common.AvailabilityZone
+		for _, cr := range computeResources {
+			var hostGroups []*vsphereclient.HostGroup
+			if hostGroupZones {
+				hostGroups, err = env.client.HostGroups(env.ctx, cr)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+			if len(hostGroups) == 0 {
+				// Standalone hosts, and clusters without
+				// host groups, are zones in their own right.
+				zones = append(zones, &vmwareAvailZone{r: *cr})
+				continue
+			}
+			for _, hostGroup := range hostGroups {
+				zones = append(zones, &vmwareAvailZone{
+					r:         *cr,
+					hostGroup: hostGroup,
+				})
+			}
 		}
 		env.zones = zones
 	}
@@ -83,9 +156,8 @@ func (env *sessionEnviron) InstanceAvailabilityZoneNames(ids []instance.Id) ([]s
 		}
 		vm := inst.(*environInstance).base
 		for _, zone := range zones {
-			cr := &zone.(*vmwareAvailZone).r
-			if cr.ResourcePool.Value == vm.ResourcePool.Value {
-				results[i] = cr.Name
+			if zone.(*vmwareAvailZone).contains(vm) {
+				results[i] = zone.Name()
 				break
 			}
 		}
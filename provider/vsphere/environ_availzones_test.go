@@ -11,6 +11,8 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/vsphere/internal/vsphereclient"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type environAvailzonesSuite struct {
@@ -52,6 +54,23 @@ func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNames(c *gc.C) {
 	c.Assert(zones, jc.DeepEquals, []string{"z2", "z1", "", ""})
 }
 
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNamesByHost(c *gc.C) {
+	z1 := newComputeResource("z1")
+	z2 := newComputeResource("z2")
+	s.client.computeResources = []*mo.ComputeResource{z1, z2}
+
+	// A running VM's zone is determined by its host, regardless
+	// of which resource pool it is in.
+	s.client.virtualMachines = []*mo.VirtualMachine{
+		buildVM("inst-0").onHost(z2.Host[0]).vm(),
+	}
+
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zones, err := zonedEnviron.InstanceAvailabilityZoneNames([]instance.Id{"inst-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, jc.DeepEquals, []string{"z2"})
+}
+
 func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNamesNoInstances(c *gc.C) {
 	zonedEnviron := s.env.(common.ZonedEnviron)
 	_, err := zonedEnviron.InstanceAvailabilityZoneNames([]instance.Id{"inst-0"})
@@ -81,3 +100,72 @@ func (s *environAvailzonesSuite) TestDeriveAvailabilityZoneUnknown(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `availability zone "test-unknown" not found`)
 	c.Assert(zone, gc.Equals, "")
 }
+
+func (s *environAvailzonesSuite) TestAvailabilityZonesHostGroupsDisabled(c *gc.C) {
+	s.client.computeResources = []*mo.ComputeResource{
+		newComputeResource("z1"),
+	}
+	s.client.hostGroups = map[string][]*vsphereclient.HostGroup{
+		"z1": {newHostGroup("rack1", newHost("h1"))},
+	}
+
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zones, err := zonedEnviron.AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 1)
+	c.Assert(zones[0].Name(), gc.Equals, "z1")
+	s.client.CheckCallNames(c, "ComputeResources", "Close")
+}
+
+func (s *environAvailzonesSuite) TestAvailabilityZonesHostGroups(c *gc.C) {
+	s.setHostGroupZones(c)
+	s.client.computeResources = []*mo.ComputeResource{
+		newComputeResource("z1"),
+		newComputeResource("z2"),
+	}
+	s.client.hostGroups = map[string][]*vsphereclient.HostGroup{
+		"z1": {
+			newHostGroup("rack1", newHost("h1")),
+			newHostGroup("rack2", newHost("h2")),
+		},
+	}
+
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zones, err := zonedEnviron.AvailabilityZones()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 3)
+	c.Assert(zones[0].Name(), gc.Equals, "z1/rack1")
+	c.Assert(zones[1].Name(), gc.Equals, "z1/rack2")
+	c.Assert(zones[2].Name(), gc.Equals, "z2")
+	s.client.CheckCallNames(c, "ComputeResources", "HostGroups", "HostGroups", "Close")
+}
+
+func (s *environAvailzonesSuite) TestInstanceAvailabilityZoneNamesHostGroups(c *gc.C) {
+	s.setHostGroupZones(c)
+	z1 := newComputeResource("z1")
+	s.client.computeResources = []*mo.ComputeResource{z1}
+	s.client.hostGroups = map[string][]*vsphereclient.HostGroup{
+		"z1": {
+			newHostGroup("rack1", newHost("h1")),
+			newHostGroup("rack2", newHost("h2"), newHost("h3")),
+		},
+	}
+	s.client.virtualMachines = []*mo.VirtualMachine{
+		buildVM("inst-0").onHost(newHost("h3")).vm(),
+		buildVM("inst-1").onHost(newHost("h1")).vm(),
+		buildVM("inst-2").resourcePool(z1.ResourcePool).vm(),
+	}
+	ids := []instance.Id{"inst-0", "inst-1", "inst-2"}
+
+	zonedEnviron := s.env.(common.ZonedEnviron)
+	zones, err := zonedEnviron.InstanceAvailabilityZoneNames(ids)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, jc.DeepEquals, []string{"z1/rack2", "z1/rack1", ""})
+}
+
+func (s *environAvailzonesSuite) setHostGroupZones(c *gc.C) {
+	err := s.env.SetConfig(fakeConfig(c, coretesting.Attrs{
+		"host-group-zones": true,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+}
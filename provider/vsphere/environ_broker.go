@@ -203,25 +203,33 @@ func (env *sessionEnviron) newRawInstance(
 		PrimaryNetwork:         env.ecfg.primaryNetwork(),
 		ExternalNetwork:        externalNetwork,
 		Datastore:              env.ecfg.datastore(),
+		HardwareVersion:        env.ecfg.hardwareVersion(),
+		Firmware:               env.ecfg.firmware(),
+		SecureBoot:             env.ecfg.secureBoot(),
 		UpdateProgress:         updateProgress,
 		UpdateProgressInterval: updateProgressInterval,
 		Clock: clock.WallClock,
 	}
 
 	// Attempt to create a VM in each of the AZs in turn.
-	logger.Debugf("attempting to create VM in availability zone %s", zone)
-	availZone, err := env.availZone(zone)
+	availZones, err := env.startInstanceZones(zone)
 	if err != nil {
 		logger.Warningf("failed to get availability zone %s: %s", zone, err)
 
 		return nil, nil, errors.Wrap(err, environs.ErrAvailabilityZoneFailed)
 	}
-	createVMArgs.ComputeResource = &availZone.(*vmwareAvailZone).r
-
-	vm, err := env.client.CreateVirtualMachine(env.ctx, createVMArgs)
+	var vm *mo.VirtualMachine
+	for _, availZone := range availZones {
+		logger.Debugf("attempting to create VM in availability zone %s", availZone.Name())
+		createVMArgs.ComputeResource = &availZone.r
+		createVMArgs.Host = availZone.selectHost()
+		vm, err = env.client.CreateVirtualMachine(env.ctx, createVMArgs)
+		if err == nil {
+			break
+		}
+		logger.Warningf("failed to create instance in availability zone %s: %s", availZone.Name(), err)
+	}
 	if err != nil {
-		logger.Warningf("failed to create instance in availability zone %s: %s", zone, err)
-
 		return nil, nil, errors.Wrap(err, environs.ErrAvailabilityZoneFailed)
 	}
 
@@ -235,6 +243,32 @@ func (env *sessionEnviron) newRawInstance(
 	return vm, hw, err
 }
 
+// startInstanceZones returns the zones in which to attempt to start
+// an instance. If a zone is specified, then only that zone is returned;
+// otherwise all zones are returned, to be tried in turn. The latter is
+// the case when bootstrapping without a zone placement directive.
+func (env *sessionEnviron) startInstanceZones(name string) ([]*vmwareAvailZone, error) {
+	if name != "" {
+		zone, err := env.availZone(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []*vmwareAvailZone{zone.(*vmwareAvailZone)}, nil
+	}
+	zones, err := env.AvailabilityZones()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(zones) == 0 {
+		return nil, errors.NotFoundf("compute resources in datacenter %q", env.cloud.Region)
+	}
+	results := make([]*vmwareAvailZone, len(zones))
+	for i, zone := range zones {
+		results[i] = zone.(*vmwareAvailZone)
+	}
+	return results, nil
+}
+
 // AllInstances implements environs.InstanceBroker.
 func (env *environ) AllInstances() (instances []instance.Instance, err error) {
 	err = env.withSession(func(env *sessionEnviron) error {
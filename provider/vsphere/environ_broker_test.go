@@ -15,6 +15,7 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/version"
 	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 
@@ -165,6 +166,29 @@ func (s *environBrokerSuite) TestStartInstanceNetwork(c *gc.C) {
 	c.Assert(createVMArgs.ExternalNetwork, gc.Equals, "bar")
 }
 
+func (s *environBrokerSuite) TestStartInstanceHardwareOptions(c *gc.C) {
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud: fakeCloudSpec(),
+		Config: fakeConfig(c, coretesting.Attrs{
+			"hardware-version":   "vmx-13",
+			"firmware":           "efi",
+			"secure-boot":        true,
+			"image-metadata-url": s.imageServer.URL,
+		}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := env.StartInstance(s.createStartInstanceArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.NotNil)
+
+	call := s.client.Calls()[1]
+	createVMArgs := call.Args[1].(vsphereclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs.HardwareVersion, gc.Equals, "vmx-13")
+	c.Assert(createVMArgs.Firmware, gc.Equals, "efi")
+	c.Assert(createVMArgs.SecureBoot, jc.IsTrue)
+}
+
 func (s *environBrokerSuite) TestStartInstanceLongModelName(c *gc.C) {
 	env, err := s.provider.Open(environs.OpenParams{
 		Cloud: fakeCloudSpec(),
@@ -313,6 +337,58 @@ func (s *environBrokerSuite) TestStartInstanceFailsWithAvailabilityZone(c *gc.C)
 	c.Assert(createVMArgs1.ComputeResource, jc.DeepEquals, s.client.computeResources[0])
 }
 
+func (s *environBrokerSuite) TestStartInstanceNoZone(c *gc.C) {
+	// Without a zone, each zone is tried in turn.
+	s.client.SetErrors(nil, errors.New("nope"))
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Placement = ""
+	_, err := s.env.StartInstance(startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c, "ComputeResources", "CreateVirtualMachine", "CreateVirtualMachine", "Close")
+	createVMArgs1 := s.client.Calls()[1].Args[1].(vsphereclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs1.ComputeResource, jc.DeepEquals, s.client.computeResources[0])
+	createVMArgs2 := s.client.Calls()[2].Args[1].(vsphereclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs2.ComputeResource, jc.DeepEquals, s.client.computeResources[1])
+}
+
+func (s *environBrokerSuite) TestStartInstanceNoZoneAllFail(c *gc.C) {
+	s.client.SetErrors(nil, errors.New("nope"), errors.New("still nope"))
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Placement = ""
+	_, err := s.env.StartInstance(startInstArgs)
+	c.Assert(errors.Cause(err), gc.Equals, environs.ErrAvailabilityZoneFailed)
+	s.client.CheckCallNames(c, "ComputeResources", "CreateVirtualMachine", "CreateVirtualMachine", "Close")
+}
+
+func (s *environBrokerSuite) TestStartInstanceHostGroupZone(c *gc.C) {
+	cfg, err := s.env.Config().Apply(map[string]interface{}{
+		"host-group-zones": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.hostGroups = map[string][]*vsphereclient.HostGroup{
+		"z2": {
+			newHostGroup("rack1", newHost("h1")),
+			newHostGroup("rack2", newHost("h2")),
+		},
+	}
+
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Placement = "zone=z2/rack2"
+	_, err = s.env.StartInstance(startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c, "ComputeResources", "HostGroups", "HostGroups", "CreateVirtualMachine", "Close")
+	createVMArgs := s.client.Calls()[3].Args[1].(vsphereclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs.ComputeResource, jc.DeepEquals, s.client.computeResources[1])
+	c.Assert(createVMArgs.Host, jc.DeepEquals, &types.ManagedObjectReference{
+		Type:  "HostSystem",
+		Value: "h2",
+	})
+}
+
 func (s *environBrokerSuite) TestStartInstanceDatastore(c *gc.C) {
 	cfg := s.env.Config()
 	cfg, err := cfg.Apply(map[string]interface{}{
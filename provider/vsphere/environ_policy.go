@@ -29,6 +29,10 @@ func (env *sessionEnviron) PrecheckInstance(args environs.PrecheckInstanceParams
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.AllocatePublicIP,
+	constraints.ImageID,
+	constraints.InstanceLifecycle,
+	constraints.MaxPrice,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -185,6 +185,44 @@ func (c *Client) ComputeResources(ctx context.Context) ([]*mo.ComputeResource, e
 	return cprs, nil
 }
 
+// HostGroup is a DRS host group: a named set of hosts within a cluster.
+type HostGroup struct {
+	// Name is the name of the host group.
+	Name string
+
+	// Hosts holds references to the hosts in the group.
+	Hosts []types.ManagedObjectReference
+}
+
+// HostGroups returns the DRS host groups defined in the given compute
+// resource. Only clusters may have host groups; for standalone hosts,
+// HostGroups returns no groups.
+func (c *Client) HostGroups(ctx context.Context, cr *mo.ComputeResource) ([]*HostGroup, error) {
+	if cr.Self.Type != "ClusterComputeResource" {
+		return nil, nil
+	}
+	var cluster mo.ClusterComputeResource
+	if err := c.client.RetrieveOne(ctx, cr.Self, []string{"configurationEx"}, &cluster); err != nil {
+		return nil, errors.Annotatef(err, "retrieving configuration of cluster %q", cr.Name)
+	}
+	config, ok := cluster.ConfigurationEx.(*types.ClusterConfigInfoEx)
+	if !ok {
+		return nil, nil
+	}
+	var groups []*HostGroup
+	for _, group := range config.Group {
+		hostGroup, ok := group.(*types.ClusterHostGroup)
+		if !ok {
+			continue
+		}
+		groups = append(groups, &HostGroup{
+			Name:  hostGroup.Name,
+			Hosts: hostGroup.Host,
+		})
+	}
+	return groups, nil
+}
+
 // Datastores retuns list of all datastores in the system.
 func (c *Client) Datastores(ctx context.Context) ([]*mo.Datastore, error) {
 	_, datacenter, err := c.finder(ctx)
@@ -402,11 +440,12 @@ func (c *Client) cloneVM(
 	srcVM *object.VirtualMachine,
 	dstName string,
 	vmFolder *object.Folder,
+	host *types.ManagedObjectReference,
 	taskWaiter *taskWaiter,
 ) (*object.VirtualMachine, error) {
 	task, err := srcVM.Clone(ctx, vmFolder, dstName, types.VirtualMachineCloneSpec{
 		Config:   &types.VirtualMachineConfigSpec{},
-		Location: types.VirtualMachineRelocateSpec{},
+		Location: types.VirtualMachineRelocateSpec{Host: host},
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -438,6 +438,74 @@ func (s *clientSuite) TestComputeResources(c *gc.C) {
 	c.Assert(result[1].Name, gc.Equals, "z1")
 }
 
+func (s *clientSuite) TestHostGroups(c *gc.C) {
+	s.roundTripper.contents["FakeCluster"] = []types.ObjectContent{{
+		Obj: types.ManagedObjectReference{
+			Type:  "ClusterComputeResource",
+			Value: "FakeCluster",
+		},
+		PropSet: []types.DynamicProperty{{
+			Name: "configurationEx",
+			Val: &types.ClusterConfigInfoEx{
+				Group: []types.BaseClusterGroupInfo{
+					&types.ClusterHostGroup{
+						ClusterGroupInfo: types.ClusterGroupInfo{Name: "rack1"},
+						Host: []types.ManagedObjectReference{
+							{Type: "HostSystem", Value: "host-1"},
+							{Type: "HostSystem", Value: "host-2"},
+						},
+					},
+					&types.ClusterVmGroup{
+						ClusterGroupInfo: types.ClusterGroupInfo{Name: "vms"},
+					},
+				},
+			},
+		}},
+	}}
+
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	groups, err := client.HostGroups(context.Background(), &mo.ComputeResource{
+		ManagedEntity: mo.ManagedEntity{
+			ExtensibleManagedObject: mo.ExtensibleManagedObject{
+				Self: types.ManagedObjectReference{
+					Type:  "ClusterComputeResource",
+					Value: "FakeCluster",
+				},
+			},
+			Name: "cluster1",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, jc.DeepEquals, []*HostGroup{{
+		Name: "rack1",
+		Hosts: []types.ManagedObjectReference{
+			{Type: "HostSystem", Value: "host-1"},
+			{Type: "HostSystem", Value: "host-2"},
+		},
+	}})
+
+	s.roundTripper.CheckCalls(c, []testing.StubCall{
+		retrievePropertiesStubCall("FakeCluster"),
+	})
+}
+
+func (s *clientSuite) TestHostGroupsStandaloneHost(c *gc.C) {
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	groups, err := client.HostGroups(context.Background(), &mo.ComputeResource{
+		ManagedEntity: mo.ManagedEntity{
+			ExtensibleManagedObject: mo.ExtensibleManagedObject{
+				Self: types.ManagedObjectReference{
+					Type:  "ComputeResource",
+					Value: "z0",
+				},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 0)
+	s.roundTripper.CheckNoCalls(c)
+}
+
 func (s *clientSuite) TestDestroyVMFolder(c *gc.C) {
 	client := s.newFakeClient(&s.roundTripper, "dc0")
 	err := client.DestroyVMFolder(context.Background(), "foo")
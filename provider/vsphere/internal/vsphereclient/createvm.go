@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"path"
+	"strings"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
@@ -16,6 +17,7 @@ import (
 	"github.com/kr/pretty"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/ovf"
+	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 
@@ -59,6 +61,11 @@ type CreateVirtualMachineParams struct {
 	// to create the VM.
 	ComputeResource *mo.ComputeResource
 
+	// Host, if set, is the host within the compute resource on which
+	// to create the VM. If this is nil, the host will be chosen by
+	// vSphere.
+	Host *types.ManagedObjectReference
+
 	// Datastore is the name of the datastore in which to create the VM.
 	// If this is empty, any accessible datastore will be used.
 	Datastore string
@@ -79,6 +86,20 @@ type CreateVirtualMachineParams struct {
 	// network to which the VM should be connected.
 	ExternalNetwork string
 
+	// HardwareVersion, if set, is the virtual hardware version with
+	// which to create the VM, e.g. "vmx-13". It must be supported by
+	// the compute resource. If this is empty, the default will be used.
+	HardwareVersion string
+
+	// Firmware, if set, is the firmware type with which to create
+	// the VM: "bios" or "efi". If this is empty, the default will be
+	// used.
+	Firmware string
+
+	// SecureBoot indicates whether EFI secure boot should be enabled
+	// for the VM. This requires Firmware to be "efi".
+	SecureBoot bool
+
 	// UpdateProgress is a function that should be called before/during
 	// long-running operations to provide a progress reporting.
 	UpdateProgress func(string)
@@ -96,6 +117,8 @@ type CreateVirtualMachineParams struct {
 //
 // This method imports an OVF template using the vSphere API. This process
 // comprises the following steps:
+//   0. If a hardware version was specified, check that the compute
+//      resource supports creating VMs with that version.
 //   1. Ensure the VMDK contained within the OVA archive (args.OVA) is
 //      stored in the datastore, in this controller's cache. If it is
 //      there already, we use it; otherwise we remove any existing VMDK
@@ -121,6 +144,10 @@ func (c *Client) CreateVirtualMachine(
 	args CreateVirtualMachineParams,
 ) (_ *mo.VirtualMachine, resultErr error) {
 
+	if err := c.checkHardwareVersion(ctx, args); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// Locate the folder in which to create the VM.
 	finder, datacenter, err := c.finder(ctx)
 	if err != nil {
@@ -168,7 +195,11 @@ func (c *Client) CreateVirtualMachine(
 	args.UpdateProgress(fmt.Sprintf("creating VM %q", args.Name))
 	c.logger.Debugf("creating temporary VM in folder %s", vmFolder)
 	c.logger.Tracef("import spec: %s", pretty.Sprint(importSpec))
-	lease, err := resourcePool.ImportVApp(ctx, importSpec, vmFolder, nil)
+	var host *object.HostSystem
+	if args.Host != nil {
+		host = object.NewHostSystem(c.client.Client, *args.Host)
+	}
+	lease, err := resourcePool.ImportVApp(ctx, importSpec, vmFolder, host)
 	if err != nil {
 		return nil, errors.Annotatef(err, "failed to import vapp")
 	}
@@ -191,7 +222,7 @@ func (c *Client) CreateVirtualMachine(
 	// VMDK from the temporary VM to avoid deleting it when destroying
 	// the VM.
 	c.logger.Debugf("cloning VM")
-	vm, err := c.cloneVM(ctx, tempVM, args.Name, vmFolder, taskWaiter)
+	vm, err := c.cloneVM(ctx, tempVM, args.Name, vmFolder, args.Host, taskWaiter)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -343,6 +374,19 @@ func (c *Client) createImportSpec(
 		return nil, errors.Trace(err)
 	}
 
+	// Apply the virtual hardware and firmware options.
+	if args.HardwareVersion != "" {
+		s.Version = args.HardwareVersion
+	}
+	if args.Firmware != "" {
+		s.Firmware = args.Firmware
+	}
+	if args.SecureBoot {
+		s.BootOptions = &types.VirtualMachineBootOptions{
+			EfiSecureBootEnabled: types.NewBool(true),
+		}
+	}
+
 	// Apply metadata. Note that we do not have the ability set create or
 	// apply tags that will show up in vCenter, as that requires a separate
 	// vSphere Automation that we do not have an SDK for.
@@ -364,6 +408,45 @@ func (c *Client) createImportSpec(
 	return importSpec, nil
 }
 
+// checkHardwareVersion checks that the compute resource supports
+// creating VMs with the requested virtual hardware version, if any.
+func (c *Client) checkHardwareVersion(
+	ctx context.Context,
+	args CreateVirtualMachineParams,
+) error {
+	if args.HardwareVersion == "" {
+		return nil
+	}
+	if args.ComputeResource.EnvironmentBrowser == nil {
+		return errors.Errorf(
+			"cannot determine hardware versions supported by %q",
+			args.ComputeResource.Name,
+		)
+	}
+	req := types.QueryConfigOptionDescriptor{
+		This: *args.ComputeResource.EnvironmentBrowser,
+	}
+	res, err := methods.QueryConfigOptionDescriptor(ctx, c.client.Client, &req)
+	if err != nil {
+		return errors.Annotate(err, "querying supported hardware versions")
+	}
+	var supported []string
+	for _, desc := range res.Returnval {
+		if desc.CreateSupported == nil || !*desc.CreateSupported {
+			continue
+		}
+		if desc.Key == args.HardwareVersion {
+			return nil
+		}
+		supported = append(supported, desc.Key)
+	}
+	return errors.Errorf(
+		"hardware version %q not supported by %q (supported versions: %s)",
+		args.HardwareVersion, args.ComputeResource.Name,
+		strings.Join(supported, ", "),
+	)
+}
+
 func (c *Client) addRootDisk(
 	s *types.VirtualMachineConfigSpec,
 	args CreateVirtualMachineParams,
@@ -384,6 +384,47 @@ func (s *clientSuite) TestCreateVirtualMachineRootDiskSize(c *gc.C) {
 	})
 }
 
+func (s *clientSuite) TestCreateVirtualMachineHardwareOptions(c *gc.C) {
+	args := baseCreateVirtualMachineParams(c)
+	args.HardwareVersion = "vmx-13"
+	args.Firmware = "efi"
+	args.SecureBoot = true
+
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	_, err := client.CreateVirtualMachine(context.Background(), args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.roundTripper.CheckCall(c, 0, "QueryConfigOptionDescriptor", "FakeEnvironmentBrowser")
+
+	// The hardware version check bumps the position of
+	// ImportVApp from 23 to 24.
+	s.roundTripper.CheckCall(c, 24, "ImportVApp", &types.VirtualMachineImportSpec{
+		ConfigSpec: types.VirtualMachineConfigSpec{
+			Name:     "vm-name.tmp",
+			Version:  "vmx-13",
+			Firmware: "efi",
+			BootOptions: &types.VirtualMachineBootOptions{
+				EfiSecureBootEnabled: newBool(true),
+			},
+			ExtraConfig: []types.BaseOptionValue{
+				&types.OptionValue{Key: "k", Value: "v"},
+			},
+		},
+	})
+}
+
+func (s *clientSuite) TestCreateVirtualMachineHardwareVersionNotSupported(c *gc.C) {
+	args := baseCreateVirtualMachineParams(c)
+	args.HardwareVersion = "vmx-04"
+
+	client := s.newFakeClient(&s.roundTripper, "dc0")
+	_, err := client.CreateVirtualMachine(context.Background(), args)
+	c.Assert(err, gc.ErrorMatches, `hardware version "vmx-04" not supported by "z0" \(supported versions: vmx-11, vmx-13\)`)
+
+	// Nothing should have been created.
+	s.roundTripper.CheckCallNames(c, "QueryConfigOptionDescriptor")
+}
+
 func baseCreateVirtualMachineParams(c *gc.C) CreateVirtualMachineParams {
 	readOVA := func() (string, io.ReadCloser, error) {
 		r := bytes.NewReader(ovatest.FakeOVAContents())
@@ -399,6 +440,13 @@ func baseCreateVirtualMachineParams(c *gc.C) CreateVirtualMachineParams {
 		Series:        "xenial",
 		UserData:      "baz",
 		ComputeResource: &mo.ComputeResource{
+			ManagedEntity: mo.ManagedEntity{
+				Name: "z0",
+			},
+			EnvironmentBrowser: &types.ManagedObjectReference{
+				Type:  "EnvironmentBrowser",
+				Value: "FakeEnvironmentBrowser",
+			},
 			ResourcePool: &types.ManagedObjectReference{
 				Type:  "ResourcePool",
 				Value: "FakeResourcePool1",
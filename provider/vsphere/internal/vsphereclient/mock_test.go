@@ -113,6 +113,21 @@ func (r *mockRoundTripper) RoundTrip(ctx context.Context, req, res soap.HasFault
 	case *methods.CreateFolderBody:
 		r.MethodCall(r, "CreateFolder")
 		res.Res = &types.CreateFolderResponse{}
+	case *methods.QueryConfigOptionDescriptorBody:
+		req := req.(*methods.QueryConfigOptionDescriptorBody).Req
+		r.MethodCall(r, "QueryConfigOptionDescriptor", req.This.Value)
+		res.Res = &types.QueryConfigOptionDescriptorResponse{
+			Returnval: []types.VirtualMachineConfigOptionDescriptor{{
+				Key:             "vmx-04",
+				CreateSupported: types.NewBool(false),
+			}, {
+				Key:             "vmx-11",
+				CreateSupported: types.NewBool(true),
+			}, {
+				Key:             "vmx-13",
+				CreateSupported: types.NewBool(true),
+			}},
+		}
 	case *methods.CreateImportSpecBody:
 		req := req.(*methods.CreateImportSpecBody).Req
 		r.MethodCall(r, "CreateImportSpec", req.OvfDescriptor, req.Datastore, req.Cisp)
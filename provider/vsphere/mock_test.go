@@ -37,6 +37,7 @@ type mockClient struct {
 	createdVirtualMachine *mo.VirtualMachine
 	virtualMachines       []*mo.VirtualMachine
 	datastores            []*mo.Datastore
+	hostGroups            map[string][]*vsphereclient.HostGroup
 	vmFolder              *object.Folder
 }
 
@@ -89,6 +90,13 @@ func (c *mockClient) EnsureVMFolder(ctx context.Context, path string) (*object.F
 	return c.vmFolder, c.NextErr()
 }
 
+func (c *mockClient) HostGroups(ctx context.Context, cr *mo.ComputeResource) ([]*vsphereclient.HostGroup, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.MethodCall(c, "HostGroups", ctx, cr)
+	return c.hostGroups[cr.Name], c.NextErr()
+}
+
 func (c *mockClient) MoveVMFolderInto(ctx context.Context, parent string, child string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -137,6 +145,7 @@ type vmBuilder struct {
 	powerState types.VirtualMachinePowerState
 	nics       []types.GuestNicInfo
 	rp         *types.ManagedObjectReference
+	host       *types.ManagedObjectReference
 	metadata   []types.BaseOptionValue
 }
 
@@ -146,6 +155,7 @@ func (b *vmBuilder) vm() *mo.VirtualMachine {
 	vm.Runtime.PowerState = b.powerState
 	vm.Guest = &types.GuestInfo{Net: b.nics}
 	vm.ResourcePool = b.rp
+	vm.Runtime.Host = b.host
 	vm.Config = &types.VirtualMachineConfigInfo{
 		ExtraConfig: b.metadata,
 	}
@@ -168,6 +178,11 @@ func (b *vmBuilder) resourcePool(rp *types.ManagedObjectReference) *vmBuilder {
 	return b
 }
 
+func (b *vmBuilder) onHost(host types.ManagedObjectReference) *vmBuilder {
+	b.host = &host
+	return b
+}
+
 func (b *vmBuilder) extraConfig(k, v string) *vmBuilder {
 	b.metadata = append(b.metadata, &types.OptionValue{Key: k, Value: v})
 	return b
@@ -183,5 +198,20 @@ func newComputeResource(name string) *mo.ComputeResource {
 	cr.ResourcePool = &types.ManagedObjectReference{
 		Value: "rp-" + name,
 	}
+	cr.Host = []types.ManagedObjectReference{newHost(name + "-host")}
 	return cr
 }
+
+func newHost(name string) types.ManagedObjectReference {
+	return types.ManagedObjectReference{
+		Type:  "HostSystem",
+		Value: name,
+	}
+}
+
+func newHostGroup(name string, hosts ...types.ManagedObjectReference) *vsphereclient.HostGroup {
+	return &vsphereclient.HostGroup{
+		Name:  name,
+		Hosts: hosts,
+	}
+}
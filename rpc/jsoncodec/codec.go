@@ -63,15 +63,17 @@ type inMsgV0 struct {
 }
 
 type inMsgV1 struct {
-	RequestId uint64          `json:"request-id"`
-	Type      string          `json:"type"`
-	Version   int             `json:"version"`
-	Id        string          `json:"id"`
-	Request   string          `json:"request"`
-	Params    json.RawMessage `json:"params"`
-	Error     string          `json:"error"`
-	ErrorCode string          `json:"error-code"`
-	Response  json.RawMessage `json:"response"`
+	RequestId      uint64          `json:"request-id"`
+	Type           string          `json:"type"`
+	Version        int             `json:"version"`
+	Id             string          `json:"id"`
+	Request        string          `json:"request"`
+	CorrelationId  string          `json:"correlation-id"`
+	IdempotencyKey string          `json:"idempotency-key"`
+	Params         json.RawMessage `json:"params"`
+	Error          string          `json:"error"`
+	ErrorCode      string          `json:"error-code"`
+	Response       json.RawMessage `json:"response"`
 }
 
 // outMsg holds an outgoing message.
@@ -88,15 +90,17 @@ type outMsgV0 struct {
 }
 
 type outMsgV1 struct {
-	RequestId uint64      `json:"request-id,omitempty"`
-	Type      string      `json:"type,omitempty"`
-	Version   int         `json:"version,omitempty"`
-	Id        string      `json:"id,omitempty"`
-	Request   string      `json:"request,omitempty"`
-	Params    interface{} `json:"params,omitempty"`
-	Error     string      `json:"error,omitempty"`
-	ErrorCode string      `json:"error-code,omitempty"`
-	Response  interface{} `json:"response,omitempty"`
+	RequestId      uint64      `json:"request-id,omitempty"`
+	Type           string      `json:"type,omitempty"`
+	Version        int         `json:"version,omitempty"`
+	Id             string      `json:"id,omitempty"`
+	Request        string      `json:"request,omitempty"`
+	CorrelationId  string      `json:"correlation-id,omitempty"`
+	IdempotencyKey string      `json:"idempotency-key,omitempty"`
+	Params         interface{} `json:"params,omitempty"`
+	Error          string      `json:"error,omitempty"`
+	ErrorCode      string      `json:"error-code,omitempty"`
+	Response       interface{} `json:"response,omitempty"`
 }
 
 func (c *Codec) Close() error {
@@ -132,10 +136,12 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	}
 	hdr.RequestId = c.msg.RequestId
 	hdr.Request = rpc.Request{
-		Type:    c.msg.Type,
-		Version: c.msg.Version,
-		Id:      c.msg.Id,
-		Action:  c.msg.Request,
+		Type:           c.msg.Type,
+		Version:        c.msg.Version,
+		Id:             c.msg.Id,
+		Action:         c.msg.Request,
+		CorrelationId:  c.msg.CorrelationId,
+		IdempotencyKey: c.msg.IdempotencyKey,
 	}
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
@@ -265,13 +271,15 @@ func newOutMsgV0(hdr *rpc.Header, body interface{}) outMsgV0 {
 // reflect, but no.
 func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 	result := outMsgV1{
-		RequestId: hdr.RequestId,
-		Type:      hdr.Request.Type,
-		Version:   hdr.Request.Version,
-		Id:        hdr.Request.Id,
-		Request:   hdr.Request.Action,
-		Error:     hdr.Error,
-		ErrorCode: hdr.ErrorCode,
+		RequestId:      hdr.RequestId,
+		Type:           hdr.Request.Type,
+		Version:        hdr.Request.Version,
+		Id:             hdr.Request.Id,
+		Request:        hdr.Request.Action,
+		CorrelationId:  hdr.Request.CorrelationId,
+		IdempotencyKey: hdr.Request.IdempotencyKey,
+		Error:          hdr.Error,
+		ErrorCode:      hdr.ErrorCode,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
@@ -115,6 +115,30 @@ func (*suite) TestRead(c *gc.C) {
 			Version: 1,
 		},
 		expectBody: &value{X: "param"},
+	}, {
+		msg: `{"request-id": 5, "type": "foo", "request": "frob", "correlation-id": "deadbeef", "params": {"X": "param"}}`,
+		expectHdr: rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:          "foo",
+				Action:        "frob",
+				CorrelationId: "deadbeef",
+			},
+			Version: 1,
+		},
+		expectBody: &value{X: "param"},
+	}, {
+		msg: `{"request-id": 6, "type": "foo", "request": "frob", "idempotency-key": "cafe", "params": {"X": "param"}}`,
+		expectHdr: rpc.Header{
+			RequestId: 6,
+			Request: rpc.Request{
+				Type:           "foo",
+				Action:         "frob",
+				IdempotencyKey: "cafe",
+			},
+			Version: 1,
+		},
+		expectBody: &value{X: "param"},
 	}} {
 		c.Logf("test %d", i)
 		codec := jsoncodec.New(&testConn{
@@ -236,6 +260,30 @@ func (*suite) TestWrite(c *gc.C) {
 		},
 		body:   &value{X: "param"},
 		expect: `{"request-id": 4, "type": "foo", "version": 2, "request": "frob", "params": {"X": "param"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:          "foo",
+				Action:        "frob",
+				CorrelationId: "deadbeef",
+			},
+			Version: 1,
+		},
+		body:   &value{X: "param"},
+		expect: `{"request-id": 5, "type": "foo", "request": "frob", "correlation-id": "deadbeef", "params": {"X": "param"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 6,
+			Request: rpc.Request{
+				Type:           "foo",
+				Action:         "frob",
+				IdempotencyKey: "cafe",
+			},
+			Version: 1,
+		},
+		body:   &value{X: "param"},
+		expect: `{"request-id": 6, "type": "foo", "request": "frob", "idempotency-key": "cafe", "params": {"X": "param"}}`,
 	}} {
 		c.Logf("test %d", i)
 		var conn testConn
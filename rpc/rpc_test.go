@@ -229,7 +229,7 @@ func (a *CallbackMethods) Factorial(x int64val) (int64val, error) {
 		return int64val{1}, nil
 	}
 	var r int64val
-	err := a.root.conn.Call(rpc.Request{"CallbackMethods", 0, "", "Factorial"}, int64val{x.I - 1}, &r)
+	err := a.root.conn.Call(rpc.Request{Type: "CallbackMethods", Version: 0, Id: "", Action: "Factorial"}, int64val{x.I - 1}, &r)
 	if err != nil {
 		return int64val{}, err
 	}
@@ -367,6 +367,28 @@ func (cc *CustomRoot) FindMethod(
 	}, nil
 }
 
+// DedupRoot is a CustomRoot that records the results of requests
+// made with an idempotency key, and returns them again for requests
+// that repeat the key.
+type DedupRoot struct {
+	*CustomRoot
+	mu      sync.Mutex
+	results map[string]interface{}
+}
+
+func (r *DedupRoot) Deduplicate(key string, run func() (interface{}, error)) (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if result, ok := r.results[key]; ok {
+		return result, nil
+	}
+	result, err := run()
+	if err == nil {
+		r.results[key] = result
+	}
+	return result, err
+}
+
 func SimpleRoot() *Root {
 	root := &Root{
 		simple: make(map[string]*SimpleMethods),
@@ -559,7 +581,7 @@ func (*rpcSuite) TestInterfaceMethods(c *gc.C) {
 	// exposed at the InterfaceMethods level, so this call should fail with
 	// CodeNotImplemented.
 	var r stringVal
-	err := client.Call(rpc.Request{"InterfaceMethods", 0, "a99", "Call0r0"}, stringVal{"arg"}, &r)
+	err := client.Call(rpc.Request{Type: "InterfaceMethods", Version: 0, Id: "a99", Action: "Call0r0"}, stringVal{"arg"}, &r)
 	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
 		Message: "no such request - method InterfaceMethods.Call0r0 is not implemented",
 		Code:    rpc.CodeNotImplemented,
@@ -585,7 +607,7 @@ func (*rpcSuite) TestCustomRootV0(c *gc.C) {
 	root.root.testCall(c, p)
 	// Call1r1 is exposed in version 1, but not in version 0.
 	var r stringVal
-	err := client.Call(rpc.Request{"MultiVersion", 0, "a99", "Call1r1"}, stringVal{"arg"}, &r)
+	err := client.Call(rpc.Request{Type: "MultiVersion", Version: 0, Id: "a99", Action: "Call1r1"}, stringVal{"arg"}, &r)
 	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
 		Message: "no such request - method MultiVersion.Call1r1 is not implemented",
 		Code:    rpc.CodeNotImplemented,
@@ -611,7 +633,7 @@ func (*rpcSuite) TestCustomRootV1(c *gc.C) {
 	root.root.testCall(c, p)
 	// Call0r1 is exposed in version 0, but not in version 1.
 	var r stringVal
-	err := client.Call(rpc.Request{"MultiVersion", 1, "a99", "Call0r1"}, nil, &r)
+	err := client.Call(rpc.Request{Type: "MultiVersion", Version: 1, Id: "a99", Action: "Call0r1"}, nil, &r)
 	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
 		Message: "no such request - method MultiVersion(1).Call0r1 is not implemented",
 		Code:    rpc.CodeNotImplemented,
@@ -638,7 +660,7 @@ func (*rpcSuite) TestCustomRootV2(c *gc.C) {
 	// RestrictedMethods type, we actually only expose the methods defined
 	// in InterfaceMethods.
 	var r stringVal
-	err := client.Call(rpc.Request{"MultiVersion", 2, "a99", "Call0r1e"}, nil, &r)
+	err := client.Call(rpc.Request{Type: "MultiVersion", Version: 2, Id: "a99", Action: "Call0r1e"}, nil, &r)
 	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
 		Message: `no such request - method MultiVersion(2).Call0r1e is not implemented`,
 		Code:    rpc.CodeNotImplemented,
@@ -651,13 +673,44 @@ func (*rpcSuite) TestCustomRootUnknownVersion(c *gc.C) {
 	defer closeClient(c, client, srvDone)
 	var r stringVal
 	// Unknown version 5
-	err := client.Call(rpc.Request{"MultiVersion", 5, "a99", "Call0r1"}, nil, &r)
+	err := client.Call(rpc.Request{Type: "MultiVersion", Version: 5, Id: "a99", Action: "Call0r1"}, nil, &r)
 	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
 		Message: `unknown version (5) of interface "MultiVersion"`,
 		Code:    rpc.CodeNotImplemented,
 	})
 }
 
+func (*rpcSuite) TestDeduplicator(c *gc.C) {
+	root := &DedupRoot{
+		CustomRoot: &CustomRoot{SimpleRoot()},
+		results:    make(map[string]interface{}),
+	}
+	client, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	req := rpc.Request{
+		Type:           "MultiVersion",
+		Version:        1,
+		Id:             "a99",
+		Action:         "Call1r1",
+		IdempotencyKey: "deadbeef",
+	}
+	for i := 0; i < 2; i++ {
+		var r stringVal
+		err := client.Call(req, stringVal{"arg"}, &r)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(r, gc.Equals, stringVal{"Call1r1 ret"})
+	}
+	c.Assert(root.root.calls, gc.HasLen, 1)
+
+	// Requests without a key are always run.
+	req.IdempotencyKey = ""
+	var r stringVal
+	err := client.Call(req, stringVal{"arg"}, &r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(root.root.calls, gc.HasLen, 2)
+}
+
 func (*rpcSuite) TestConcurrentCalls(c *gc.C) {
 	start1 := make(chan string)
 	start2 := make(chan string)
@@ -675,7 +728,7 @@ func (*rpcSuite) TestConcurrentCalls(c *gc.C) {
 	defer closeClient(c, client, srvDone)
 	call := func(id string, done chan<- struct{}) {
 		var r stringVal
-		err := client.Call(rpc.Request{"DelayedMethods", 0, id, "Delay"}, nil, &r)
+		err := client.Call(rpc.Request{Type: "DelayedMethods", Version: 0, Id: id, Action: "Delay"}, nil, &r)
 		c.Check(err, jc.ErrorIsNil)
 		c.Check(r.Val, gc.Equals, "return "+id)
 		done <- struct{}{}
@@ -715,7 +768,7 @@ func (*rpcSuite) TestErrorCode(c *gc.C) {
 	}
 	client, srvDone, _ := newRPCClientServer(c, root, nil, false)
 	defer closeClient(c, client, srvDone)
-	err := client.Call(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, nil)
+	err := client.Call(rpc.Request{Type: "ErrorMethods", Version: 0, Id: "", Action: "Call"}, nil, nil)
 	c.Assert(err, gc.ErrorMatches, `message \(code\)`)
 	c.Assert(errors.Cause(err).(rpc.ErrorCoder).ErrorCode(), gc.Equals, "code")
 }
@@ -737,13 +790,13 @@ func (*rpcSuite) TestTransformErrors(c *gc.C) {
 	client, srvDone, _ := newRPCClientServer(c, root, tfErr, false)
 	defer closeClient(c, client, srvDone)
 	// First, we don't transform methods we can't find.
-	err := client.Call(rpc.Request{"foo", 0, "", "bar"}, nil, nil)
+	err := client.Call(rpc.Request{Type: "foo", Version: 0, Id: "", Action: "bar"}, nil, nil)
 	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
 		Message: `unknown object type "foo"`,
 		Code:    rpc.CodeNotImplemented,
 	})
 
-	err = client.Call(rpc.Request{"ErrorMethods", 0, "", "NoMethod"}, nil, nil)
+	err = client.Call(rpc.Request{Type: "ErrorMethods", Version: 0, Id: "", Action: "NoMethod"}, nil, nil)
 	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
 		Message: "no such request - method ErrorMethods.NoMethod is not implemented",
 		Code:    rpc.CodeNotImplemented,
@@ -751,18 +804,18 @@ func (*rpcSuite) TestTransformErrors(c *gc.C) {
 
 	// We do transform any errors that happen from calling the RootMethod
 	// and beyond.
-	err = client.Call(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, nil)
+	err = client.Call(rpc.Request{Type: "ErrorMethods", Version: 0, Id: "", Action: "Call"}, nil, nil)
 	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
 		Message: "transformed: message",
 		Code:    "transformed: code",
 	})
 
 	root.errorInst.err = nil
-	err = client.Call(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, nil)
+	err = client.Call(rpc.Request{Type: "ErrorMethods", Version: 0, Id: "", Action: "Call"}, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	root.errorInst = nil
-	err = client.Call(rpc.Request{"ErrorMethods", 0, "", "Call"}, nil, nil)
+	err = client.Call(rpc.Request{Type: "ErrorMethods", Version: 0, Id: "", Action: "Call"}, nil, nil)
 	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
 		Message: "transformed: no error methods",
 	})
@@ -785,7 +838,7 @@ func (*rpcSuite) TestServerWaitsForOutstandingCalls(c *gc.C) {
 	done := make(chan struct{})
 	go func() {
 		var r stringVal
-		err := client.Call(rpc.Request{"DelayedMethods", 0, "1", "Delay"}, nil, &r)
+		err := client.Call(rpc.Request{Type: "DelayedMethods", Version: 0, Id: "1", Action: "Delay"}, nil, &r)
 		c.Check(errors.Cause(err), gc.Equals, rpc.ErrShutdown)
 		done <- struct{}{}
 	}()
@@ -820,7 +873,7 @@ func (*rpcSuite) TestCompatibility(c *gc.C) {
 	defer closeClient(c, client, srvDone)
 	call := func(method string, arg, ret interface{}) (passedArg interface{}) {
 		root.calls = nil
-		err := client.Call(rpc.Request{"SimpleMethods", 0, "a0", method}, arg, ret)
+		err := client.Call(rpc.Request{Type: "SimpleMethods", Version: 0, Id: "a0", Action: method}, arg, ret)
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(root.calls, gc.HasLen, 1)
 		info := root.calls[0]
@@ -864,19 +917,19 @@ func (*rpcSuite) TestBadCall(c *gc.C) {
 	defer closeClient(c, client, srvDone)
 
 	testBadCall(c, client, serverNotifier,
-		rpc.Request{"BadSomething", 0, "a0", "No"},
+		rpc.Request{Type: "BadSomething", Version: 0, Id: "a0", Action: "No"},
 		`unknown object type "BadSomething"`,
 		rpc.CodeNotImplemented,
 		false,
 	)
 	testBadCall(c, client, serverNotifier,
-		rpc.Request{"SimpleMethods", 0, "xx", "No"},
+		rpc.Request{Type: "SimpleMethods", Version: 0, Id: "xx", Action: "No"},
 		"no such request - method SimpleMethods.No is not implemented",
 		rpc.CodeNotImplemented,
 		false,
 	)
 	testBadCall(c, client, serverNotifier,
-		rpc.Request{"SimpleMethods", 0, "xx", "Call0r0"},
+		rpc.Request{Type: "SimpleMethods", Version: 0, Id: "xx", Action: "Call0r0"},
 		`unknown SimpleMethods id`,
 		"",
 		true,
@@ -946,10 +999,10 @@ func (*rpcSuite) TestContinueAfterReadBodyError(c *gc.C) {
 	}{
 		X: map[string]int{"hello": 65},
 	}
-	err := client.Call(rpc.Request{"SimpleMethods", 0, "a0", "SliceArg"}, arg0, &ret)
+	err := client.Call(rpc.Request{Type: "SimpleMethods", Version: 0, Id: "a0", Action: "SliceArg"}, arg0, &ret)
 	c.Assert(err, gc.ErrorMatches, `json: cannot unmarshal object into Go (?:value)|(?:struct field \.X) of type \[\]string`)
 
-	err = client.Call(rpc.Request{"SimpleMethods", 0, "a0", "SliceArg"}, arg0, &ret)
+	err = client.Call(rpc.Request{Type: "SimpleMethods", Version: 0, Id: "a0", Action: "SliceArg"}, arg0, &ret)
 	c.Assert(err, gc.ErrorMatches, `json: cannot unmarshal object into Go (?:value)|(?:struct field \.X) of type \[\]string`)
 
 	arg1 := struct {
@@ -957,7 +1010,7 @@ func (*rpcSuite) TestContinueAfterReadBodyError(c *gc.C) {
 	}{
 		X: []string{"one"},
 	}
-	err = client.Call(rpc.Request{"SimpleMethods", 0, "a0", "SliceArg"}, arg1, &ret)
+	err = client.Call(rpc.Request{Type: "SimpleMethods", Version: 0, Id: "a0", Action: "SliceArg"}, arg1, &ret)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ret.Val, gc.Equals, "SliceArg ret")
 }
@@ -966,7 +1019,7 @@ func (*rpcSuite) TestErrorAfterClientClose(c *gc.C) {
 	client, srvDone, _ := newRPCClientServer(c, &Root{}, nil, false)
 	err := client.Close()
 	c.Assert(err, jc.ErrorIsNil)
-	err = client.Call(rpc.Request{"Foo", 0, "", "Bar"}, nil, nil)
+	err = client.Call(rpc.Request{Type: "Foo", Version: 0, Id: "", Action: "Bar"}, nil, nil)
 	c.Assert(errors.Cause(err), gc.Equals, rpc.ErrShutdown)
 	err = chanReadError(c, srvDone, "server done")
 	c.Assert(err, jc.ErrorIsNil)
@@ -989,7 +1042,7 @@ func (*rpcSuite) TestBidirectional(c *gc.C) {
 	clientRoot := &Root{conn: client}
 	client.Serve(clientRoot, nil)
 	var r int64val
-	err := client.Call(rpc.Request{"CallbackMethods", 0, "", "Factorial"}, int64val{12}, &r)
+	err := client.Call(rpc.Request{Type: "CallbackMethods", Version: 0, Id: "", Action: "Factorial"}, int64val{12}, &r)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(r.I, gc.Equals, int64(479001600))
 }
@@ -999,7 +1052,7 @@ func (*rpcSuite) TestServerRequestWhenNotServing(c *gc.C) {
 	client, srvDone, _ := newRPCClientServer(c, srvRoot, nil, true)
 	defer closeClient(c, client, srvDone)
 	var r int64val
-	err := client.Call(rpc.Request{"CallbackMethods", 0, "", "Factorial"}, int64val{12}, &r)
+	err := client.Call(rpc.Request{Type: "CallbackMethods", Version: 0, Id: "", Action: "Factorial"}, int64val{12}, &r)
 	c.Assert(err, gc.ErrorMatches, "no service")
 }
 
@@ -1008,13 +1061,13 @@ func (*rpcSuite) TestChangeAPI(c *gc.C) {
 	client, srvDone, _ := newRPCClientServer(c, srvRoot, nil, true)
 	defer closeClient(c, client, srvDone)
 	var s stringVal
-	err := client.Call(rpc.Request{"NewlyAvailable", 0, "", "NewMethod"}, nil, &s)
+	err := client.Call(rpc.Request{Type: "NewlyAvailable", Version: 0, Id: "", Action: "NewMethod"}, nil, &s)
 	c.Assert(err, gc.ErrorMatches, `unknown object type "NewlyAvailable" \(not implemented\)`)
-	err = client.Call(rpc.Request{"ChangeAPIMethods", 0, "", "ChangeAPI"}, nil, nil)
+	err = client.Call(rpc.Request{Type: "ChangeAPIMethods", Version: 0, Id: "", Action: "ChangeAPI"}, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
-	err = client.Call(rpc.Request{"ChangeAPIMethods", 0, "", "ChangeAPI"}, nil, nil)
+	err = client.Call(rpc.Request{Type: "ChangeAPIMethods", Version: 0, Id: "", Action: "ChangeAPI"}, nil, nil)
 	c.Assert(err, gc.ErrorMatches, `unknown object type "ChangeAPIMethods" \(not implemented\)`)
-	err = client.Call(rpc.Request{"NewlyAvailable", 0, "", "NewMethod"}, nil, &s)
+	err = client.Call(rpc.Request{Type: "NewlyAvailable", Version: 0, Id: "", Action: "NewMethod"}, nil, &s)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s, gc.Equals, stringVal{"new method result"})
 }
@@ -1024,10 +1077,10 @@ func (*rpcSuite) TestChangeAPIToNil(c *gc.C) {
 	client, srvDone, _ := newRPCClientServer(c, srvRoot, nil, true)
 	defer closeClient(c, client, srvDone)
 
-	err := client.Call(rpc.Request{"ChangeAPIMethods", 0, "", "RemoveAPI"}, nil, nil)
+	err := client.Call(rpc.Request{Type: "ChangeAPIMethods", Version: 0, Id: "", Action: "RemoveAPI"}, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
-	err = client.Call(rpc.Request{"ChangeAPIMethods", 0, "", "RemoveAPI"}, nil, nil)
+	err = client.Call(rpc.Request{Type: "ChangeAPIMethods", Version: 0, Id: "", Action: "RemoveAPI"}, nil, nil)
 	c.Assert(err, gc.ErrorMatches, "no service")
 }
 
@@ -1047,11 +1100,11 @@ func (*rpcSuite) TestChangeAPIWhileServingRequest(c *gc.C) {
 
 	result := make(chan error)
 	go func() {
-		result <- client.Call(rpc.Request{"DelayedMethods", 0, "1", "Delay"}, nil, nil)
+		result <- client.Call(rpc.Request{Type: "DelayedMethods", Version: 0, Id: "1", Action: "Delay"}, nil, nil)
 	}()
 	chanRead(c, ready, "method ready")
 
-	err := client.Call(rpc.Request{"ChangeAPIMethods", 0, "", "ChangeAPI"}, nil, nil)
+	err := client.Call(rpc.Request{Type: "ChangeAPIMethods", Version: 0, Id: "", Action: "ChangeAPI"}, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Ensure that not only does the request in progress complete,
@@ -1109,6 +1162,9 @@ func newRPCClientServer(
 		if custroot, ok := root.(*CustomRoot); ok {
 			rpcConn.ServeRoot(custroot, tfErr)
 			custroot.root.conn = rpcConn
+		} else if dedupRoot, ok := root.(*DedupRoot); ok {
+			rpcConn.ServeRoot(dedupRoot, tfErr)
+			dedupRoot.root.conn = rpcConn
 		} else {
 			rpcConn.Serve(root, tfErr)
 		}
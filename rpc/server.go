@@ -81,6 +81,16 @@ type Request struct {
 
 	// Action holds the action to perform on the object.
 	Action string
+
+	// CorrelationId, if not empty, identifies the client operation
+	// that the request is part of, so that related requests can be
+	// traced across facades.
+	CorrelationId string
+
+	// IdempotencyKey, if not empty, identifies the request so that a
+	// client may safely resend it after losing the connection; see
+	// Deduplicator.
+	IdempotencyKey string
 }
 
 // IsRequest returns whether the header represents an RPC request.  If
@@ -456,6 +466,18 @@ type boundRequest struct {
 	rpcreflect.MethodCaller
 	transformErrors func(error) error
 	hdr             Header
+	dedup           Deduplicator
+}
+
+// Deduplicator may be implemented by a Root that is able to ensure
+// that requests carrying the same idempotency key are run at most
+// once, so that clients can resend requests that may or may not
+// have been run before the connection was lost.
+type Deduplicator interface {
+	// Deduplicate calls run and returns its result, unless a request
+	// with the same key has been run before, in which case it
+	// returns the result recorded for that request instead.
+	Deduplicate(key string, run func() (interface{}, error)) (interface{}, error)
 }
 
 // bindRequest searches for methods implementing the
@@ -482,13 +504,34 @@ func (conn *Conn) bindRequest(hdr *Header) (boundRequest, error) {
 		}
 		return boundRequest{}, err
 	}
+	dedup, _ := root.(Deduplicator)
 	return boundRequest{
 		MethodCaller:    caller,
 		transformErrors: transformErrors,
 		hdr:             *hdr,
+		dedup:           dedup,
 	}, nil
 }
 
+// call invokes the bound method, going through the root's
+// Deduplicator if the request carries an idempotency key.
+func (req boundRequest) call(arg reflect.Value) (interface{}, error) {
+	run := func() (interface{}, error) {
+		rv, err := req.Call(req.hdr.Request.Id, arg)
+		if err != nil {
+			return nil, err
+		}
+		if rv.IsValid() {
+			return rv.Interface(), nil
+		}
+		return struct{}{}, nil
+	}
+	if req.dedup == nil || req.hdr.Request.IdempotencyKey == "" {
+		return run()
+	}
+	return req.dedup.Deduplicate(req.hdr.Request.IdempotencyKey, run)
+}
+
 // runRequest runs the given request and sends the reply.
 func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, version int, observer Observer) {
 	// If the request causes a panic, ensure we log that before closing the connection.
@@ -501,7 +544,7 @@ func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, version int, o
 	}()
 	defer conn.srvPending.Done()
 
-	rv, err := req.Call(req.hdr.Request.Id, arg)
+	rvi, err := req.call(arg)
 	if err != nil {
 		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), observer)
 	} else {
@@ -509,12 +552,6 @@ func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, version int, o
 			RequestId: req.hdr.RequestId,
 			Version:   version,
 		}
-		var rvi interface{}
-		if rv.IsValid() {
-			rvi = rv.Interface()
-		} else {
-			rvi = struct{}{}
-		}
 		observer.ServerReply(req.hdr.Request, hdr, rvi)
 		conn.sending.Lock()
 		err = conn.codec.WriteMessage(hdr, rvi)
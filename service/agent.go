@@ -19,6 +19,14 @@ const (
 	agentServiceTimeout = 300 // 5 minutes
 )
 
+// windowsMachineAgentDependencies holds the services that must be
+// running for a machine agent on Windows to start. Only the TCP/IP
+// driver is required: the DHCP and DNS clients may be disabled on
+// hosts with static addresses. The agent's delayed auto-start ensures
+// that it is started after those services, when they are enabled, so
+// it does not race the network coming up at boot.
+var windowsMachineAgentDependencies = []string{"Tcpip"}
+
 // AgentConf returns the data that defines an init service config
 // for the identified agent.
 func AgentConf(info AgentInfo, renderer shell.Renderer) common.Conf {
@@ -37,6 +45,10 @@ func AgentConf(info AgentInfo, renderer shell.Renderer) common.Conf {
 		conf.Limit = map[string]int{
 			"nofile": maxAgentFiles,
 		}
+		if _, ok := renderer.(*shell.PowershellRenderer); ok {
+			conf.Dependencies = windowsMachineAgentDependencies
+			conf.DelayedAutoStart = true
+		}
 	case AgentKindUnit:
 		conf.Desc = "juju unit agent for " + info.ID
 	}
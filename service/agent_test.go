@@ -142,9 +142,11 @@ func (*agentSuite) TestAgentConfMachineWindows(c *gc.C) {
 		Limit: map[string]int{
 			"nofile": 20000,
 		},
-		Timeout:       300,
-		ServiceBinary: serviceBinary,
-		ServiceArgs:   serviceArgs,
+		Timeout:          300,
+		ServiceBinary:    serviceBinary,
+		ServiceArgs:      serviceArgs,
+		Dependencies:     []string{"Tcpip"},
+		DelayedAutoStart: true,
 	})
 }
 
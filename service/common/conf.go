@@ -6,6 +6,7 @@ package common
 import (
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/shell"
@@ -64,6 +65,62 @@ type Conf struct {
 
 	// ServiceArgs is a string array of unquoted arguments
 	ServiceArgs []string
+
+	// Dependencies holds the names of other services that must be
+	// started before this service is started.
+	// Currently only used on Windows.
+	Dependencies []string
+
+	// DelayedAutoStart indicates that the service should be started
+	// shortly after the other automatically started services at boot,
+	// rather than along with them.
+	// Currently only used on Windows.
+	DelayedAutoStart bool
+
+	// Recovery, if set, defines the actions taken when the service
+	// fails. If not set, the service is restarted shortly after it
+	// fails. Currently only used on Windows.
+	Recovery *RecoveryConfig
+
+	// ServiceAccount, if set, is the account the service runs as in
+	// place of the default jujud user. It must name either the
+	// service's own virtual account ("NT SERVICE\<name>") or a group
+	// managed service account ("DOMAIN\<name>$"), neither of which
+	// needs a password. Currently only used on Windows.
+	ServiceAccount string
+}
+
+// RecoveryConfig defines how a service is recovered when it fails.
+type RecoveryConfig struct {
+	// RestartDelays holds the delays before the service is restarted
+	// after successive failures. The last delay is used for any
+	// further failures.
+	RestartDelays []time.Duration
+
+	// ResetPeriod is how long the service must run without failing
+	// before its failure count is reset.
+	ResetPeriod time.Duration
+
+	// RebootMessage, if set, causes the machine to be rebooted once
+	// the restarts in RestartDelays have been exhausted. The message
+	// is broadcast to users before the reboot.
+	RebootMessage string
+}
+
+// Validate checks the recovery config's values for correctness.
+func (rc RecoveryConfig) Validate() error {
+	if len(rc.RestartDelays) == 0 && rc.RebootMessage == "" {
+		return errors.NotValidf("recovery config without actions")
+	}
+	for _, delay := range rc.RestartDelays {
+		if delay < 0 {
+			return errors.NotValidf("negative restart delay %v", delay)
+		}
+	}
+	if rc.ResetPeriod < 0 {
+		return errors.NotValidf("negative reset period %v", rc.ResetPeriod)
+	}
+	return nil
 }
 
 // IsZero determines whether or not the conf is a zero value.
@@ -93,6 +150,12 @@ func (c Conf) Validate(renderer shell.Renderer) error {
 		}
 	}
 
+	if c.Recovery != nil {
+		if err := c.Recovery.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	return nil
 }
 
@@ -4,6 +4,8 @@
 package common_test
 
 import (
+	"time"
+
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/shell"
@@ -115,3 +117,58 @@ func (*confSuite) TestValidateRelativeExecStopPost(c *gc.C) {
 
 	c.Check(err, gc.ErrorMatches, `.*relative path in ExecStopPost \(.*`)
 }
+
+func (*confSuite) TestValidateRecoveryOkay(c *gc.C) {
+	conf := common.Conf{
+		Desc:      "some service",
+		ExecStart: "/path/to/some-command a b c",
+		Recovery: &common.RecoveryConfig{
+			RestartDelays: []time.Duration{time.Second, time.Minute},
+			ResetPeriod:   time.Hour,
+			RebootMessage: "rebooting",
+		},
+	}
+	err := conf.Validate(renderer)
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (*confSuite) TestValidateRecoveryWithoutActions(c *gc.C) {
+	conf := common.Conf{
+		Desc:      "some service",
+		ExecStart: "/path/to/some-command a b c",
+		Recovery: &common.RecoveryConfig{
+			ResetPeriod: time.Hour,
+		},
+	}
+	err := conf.Validate(renderer)
+
+	c.Check(err, gc.ErrorMatches, "recovery config without actions not valid")
+}
+
+func (*confSuite) TestValidateRecoveryNegativeRestartDelay(c *gc.C) {
+	conf := common.Conf{
+		Desc:      "some service",
+		ExecStart: "/path/to/some-command a b c",
+		Recovery: &common.RecoveryConfig{
+			RestartDelays: []time.Duration{time.Second, -time.Second},
+		},
+	}
+	err := conf.Validate(renderer)
+
+	c.Check(err, gc.ErrorMatches, "negative restart delay -1s not valid")
+}
+
+func (*confSuite) TestValidateRecoveryNegativeResetPeriod(c *gc.C) {
+	conf := common.Conf{
+		Desc:      "some service",
+		ExecStart: "/path/to/some-command a b c",
+		Recovery: &common.RecoveryConfig{
+			RestartDelays: []time.Duration{time.Second},
+			ResetPeriod:   -time.Hour,
+		},
+	}
+	err := conf.Validate(renderer)
+
+	c.Check(err, gc.ErrorMatches, "negative reset period -1h0m0s not valid")
+}
@@ -175,6 +175,13 @@ func (ss *FakeService) running() bool {
 	return ss.FakeServiceData.runningNames.Contains(ss.Service.Name)
 }
 
+// Logs implements Service.
+func (ss *FakeService) Logs(n int) ([]string, error) {
+	ss.AddCall("Logs", n)
+
+	return nil, ss.NextErr()
+}
+
 // Start implements Service.
 func (ss *FakeService) Start() error {
 	ss.AddCall("Start")
@@ -76,6 +76,10 @@ type Service interface {
 	// whether or not the service is installed.
 	Installed() (bool, error)
 
+	// Logs returns up to the last n lines of output logged by the
+	// service, oldest first.
+	Logs(n int) ([]string, error)
+
 	// TODO(ericsnow) Move all the commands into a separate interface.
 
 	// InstallCommands returns the list of commands to run on a
@@ -13,7 +13,10 @@ import (
 	"github.com/juju/utils/shell"
 )
 
-const executable = "/bin/systemctl"
+const (
+	executable = "/bin/systemctl"
+	journalctl = "/bin/journalctl"
+)
 
 type commands struct {
 	shell.BashRenderer
@@ -73,6 +76,10 @@ func (c commands) conf(name, dirname string) string {
 	return args
 }
 
+func (c commands) logs(name string, n int) string {
+	return fmt.Sprintf("%s -u %s.service -n %d --no-pager --quiet -o short-iso", journalctl, name, n)
+}
+
 func (c commands) mkdirs(dirname string) string {
 	cmds := c.MkdirAll(dirname)
 	return strings.Join(cmds, "\n")
@@ -131,6 +138,21 @@ func (cl Cmdline) conf(name, dirname string) ([]byte, error) {
 	return []byte(out), nil
 }
 
+func (cl Cmdline) logs(name string, n int) ([]string, error) {
+	cmd := cl.commands.logs(name, n)
+
+	out, err := cl.runCommand(cmd, "get logs")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out = strings.TrimSpace(out)
+
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
 const runCommandMsg = "%s failed (%s)"
 
 func (Cmdline) runCommand(cmd, label string) (string, error) {
@@ -262,6 +262,18 @@ func (s *Service) Running() (bool, error) {
 	return false, nil
 }
 
+// Logs implements Service.
+func (s *Service) Logs(n int) ([]string, error) {
+	if n <= 0 {
+		return nil, errors.NotValidf("log line count %d", n)
+	}
+	lines, err := Cmdline{}.logs(s.Service.Name, n)
+	if err != nil {
+		return nil, s.errorf(err, "failed to read logs")
+	}
+	return lines, nil
+}
+
 // Start implements Service.
 func (s *Service) Start() error {
 	err := s.start()
@@ -492,6 +492,32 @@ func (s *initSystemSuite) TestRunningError(c *gc.C) {
 	s.stub.CheckCallNames(c, "ListUnits", "Close")
 }
 
+func (s *initSystemSuite) TestLogs(c *gc.C) {
+	s.exec.Responses = append(s.exec.Responses, exec.ExecResponse{
+		Stdout: []byte("2017-10-01T12:00:00+0000 host jujud[1]: started\n2017-10-01T12:00:01+0000 host jujud[1]: ready\n"),
+	})
+
+	lines, err := s.service.Logs(2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(lines, jc.DeepEquals, []string{
+		"2017-10-01T12:00:00+0000 host jujud[1]: started",
+		"2017-10-01T12:00:01+0000 host jujud[1]: ready",
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "RunCommand",
+		Args: []interface{}{exec.RunParams{
+			Commands: "/bin/journalctl -u jujud-machine-0.service -n 2 --no-pager --quiet -o short-iso",
+		}},
+	}})
+}
+
+func (s *initSystemSuite) TestLogsInvalidCount(c *gc.C) {
+	_, err := s.service.Logs(0)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	s.stub.CheckNoCalls(c)
+}
+
 func (s *initSystemSuite) TestStart(c *gc.C) {
 	s.addService("jujud-machine-0", "inactive")
 	s.ch <- "done"
@@ -12,6 +12,7 @@ import (
 	"path"
 	"regexp"
 	"runtime"
+	"strings"
 	"text/template"
 
 	"github.com/juju/errors"
@@ -22,7 +23,8 @@ import (
 )
 
 var (
-	InitDir = "/etc/init" // the default init directory name.
+	InitDir = "/etc/init"        // the default init directory name.
+	LogDir  = "/var/log/upstart" // the directory holding service output.
 
 	logger      = loggo.GetLogger("juju.service.upstart")
 	initctlPath = "/sbin/initctl"
@@ -230,6 +232,29 @@ func (s *Service) Running() (bool, error) {
 	return false, nil
 }
 
+// Logs returns up to the last n lines written by the service to its
+// upstart log file.
+func (s *Service) Logs(n int) ([]string, error) {
+	if n <= 0 {
+		return nil, errors.NotValidf("log line count %d", n)
+	}
+	data, err := ioutil.ReadFile(path.Join(LogDir, s.Service.Name+".log"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data = bytes.TrimRight(data, "\n")
+	if len(data) == 0 {
+		return nil, nil
+	}
+	lines := strings.Split(string(data), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines, nil
+}
+
 // Start starts the service.
 func (s *Service) Start() error {
 	running, err := s.Running()
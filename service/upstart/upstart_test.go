@@ -42,6 +42,7 @@ func (s *UpstartSuite) SetUpTest(c *gc.C) {
 	s.initDir = c.MkDir()
 	s.PatchEnvPathPrepend(s.testPath)
 	s.PatchValue(&upstart.InitDir, s.initDir)
+	s.PatchValue(&upstart.LogDir, c.MkDir())
 	s.service = upstart.NewService(
 		"some-application",
 		common.Conf{
@@ -142,6 +143,26 @@ func (s *UpstartSuite) TestRunning(c *gc.C) {
 	c.Check(running, jc.IsTrue)
 }
 
+func (s *UpstartSuite) TestLogs(c *gc.C) {
+	lines, err := s.service.Logs(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lines, gc.HasLen, 0)
+
+	filename := filepath.Join(upstart.LogDir, "some-application.log")
+	err = ioutil.WriteFile(filename, []byte("one\ntwo\nthree\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	lines, err = s.service.Logs(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lines, jc.DeepEquals, []string{"two", "three"})
+
+	lines, err = s.service.Logs(5)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lines, jc.DeepEquals, []string{"one", "two", "three"})
+
+	_, err = s.service.Logs(0)
+	c.Assert(err, gc.ErrorMatches, "log line count 0 not valid")
+}
+
 func (s *UpstartSuite) TestStart(c *gc.C) {
 	s.RunningStatusWithProcessID(c)
 	s.MakeTool(c, "start", "exit 99")
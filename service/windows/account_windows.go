@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build windows
+
+package windows
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/juju/errors"
+	"golang.org/x/sys/windows"
+)
+
+//sys lsaOpenPolicy(systemName *lsaUnicodeString, objectAttributes *lsaObjectAttributes, desiredAccess uint32, policyHandle *windows.Handle) (status uint32) = advapi32.LsaOpenPolicy
+//sys lsaAddAccountRights(policyHandle windows.Handle, accountSid *windows.SID, userRights *lsaUnicodeString, countOfRights uint32) (status uint32) = advapi32.LsaAddAccountRights
+//sys lsaClose(objectHandle windows.Handle) (status uint32) = advapi32.LsaClose
+//sys lsaNtStatusToWinError(status uint32) (ret uint32) = advapi32.LsaNtStatusToWinError
+
+const (
+	// seServiceLogonRight is the right that allows an account to
+	// log on as a service.
+	seServiceLogonRight = "SeServiceLogonRight"
+
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/ms721916(v=vs.85).aspx
+	policyCreateAccount = 0x00000010
+	policyLookupNames   = 0x00000800
+)
+
+// https://msdn.microsoft.com/en-us/library/windows/desktop/ms721841(v=vs.85).aspx
+type lsaUnicodeString struct {
+	Length        uint16
+	MaximumLength uint16
+	Buffer        *uint16
+}
+
+// https://msdn.microsoft.com/en-us/library/windows/desktop/ms721829(v=vs.85).aspx
+type lsaObjectAttributes struct {
+	Length                   uint32
+	RootDirectory            windows.Handle
+	ObjectName               *lsaUnicodeString
+	Attributes               uint32
+	SecurityDescriptor       uintptr
+	SecurityQualityOfService uintptr
+}
+
+func newLsaUnicodeString(s string) (*lsaUnicodeString, error) {
+	buf, err := syscall.UTF16FromString(s)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Length is in bytes and excludes the terminating null.
+	length := uint16((len(buf) - 1) * 2)
+	return &lsaUnicodeString{
+		Length:        length,
+		MaximumLength: length + 2,
+		Buffer:        &buf[0],
+	}, nil
+}
+
+// lsaError converts an NTSTATUS returned by an LSA function into
+// an error, or nil if the status indicates success.
+func lsaError(status uint32) error {
+	if status == 0 {
+		return nil
+	}
+	return syscall.Errno(lsaNtStatusToWinError(status))
+}
+
+// grantAccountRight grants the named right to the account in the local
+// security policy. It is defined as a variable to allow us to mock it
+// out for testing.
+var grantAccountRight = func(account, right string) error {
+	sid, _, _, err := windows.LookupSID("", account)
+	if err != nil {
+		return errors.Annotatef(err, "looking up account %q", account)
+	}
+	var attrs lsaObjectAttributes
+	attrs.Length = uint32(unsafe.Sizeof(attrs))
+	var policy windows.Handle
+	if err := lsaError(lsaOpenPolicy(nil, &attrs, policyCreateAccount|policyLookupNames, &policy)); err != nil {
+		return errors.Annotate(err, "opening local security policy")
+	}
+	defer lsaClose(policy)
+
+	rights, err := newLsaUnicodeString(right)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(lsaError(lsaAddAccountRights(policy, sid, rights, 1)))
+}
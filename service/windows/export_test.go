@@ -12,6 +12,7 @@ var (
 	JujudUser                    = jujudUser
 	ERROR_SERVICE_DOES_NOT_EXIST = c_ERROR_SERVICE_DOES_NOT_EXIST
 	ERROR_SERVICE_EXISTS         = c_ERROR_SERVICE_EXISTS
+	LogsCommand                  = logsCommand
 )
 
 type patcher interface {
@@ -24,3 +25,21 @@ func PatchServiceManager(patcher patcher, stub *testing.Stub) *StubSvcManager {
 	patcher.PatchValue(&listServices, manager.ListServices)
 	return manager
 }
+
+func PatchListServiceStatuses(patcher patcher, statuses []ServiceStatus) *[]ServiceStateFilter {
+	var filters []ServiceStateFilter
+	patcher.PatchValue(&listServiceStatuses, func(filter ServiceStateFilter) ([]ServiceStatus, error) {
+		filters = append(filters, filter)
+		return statuses, nil
+	})
+	return &filters
+}
+
+func PatchServiceLogs(patcher patcher, entries []string) *[]interface{} {
+	var args []interface{}
+	patcher.PatchValue(&serviceLogs, func(name string, n int) ([]string, error) {
+		args = append(args, name, n)
+		return entries, nil
+	})
+	return &args
+}
@@ -7,6 +7,9 @@
 package windows
 
 import (
+	"syscall"
+	"unsafe"
+
 	"github.com/juju/testing"
 )
 
@@ -26,3 +29,44 @@ func PatchGetPassword(patcher patcher, stub *testing.Stub) *StubGetPassword {
 	patcher.PatchValue(&getPassword, p.GetPassword)
 	return p
 }
+
+func PatchGrantAccountRight(patcher patcher, stub *testing.Stub) {
+	patcher.PatchValue(&grantAccountRight, func(account, right string) error {
+		stub.AddCall("GrantAccountRight", account, right)
+		return stub.NextErr()
+	})
+}
+
+// FailureAction is a single action from a SERVICE_FAILURE_ACTIONS structure.
+type FailureAction struct {
+	Type  uint16
+	Delay uint32
+}
+
+// FailureActions holds the contents of a SERVICE_FAILURE_ACTIONS structure.
+type FailureActions struct {
+	ResetPeriod   uint32
+	RebootMessage string
+	Actions       []FailureAction
+}
+
+// ReadFailureActions reads the SERVICE_FAILURE_ACTIONS structure
+// passed to ChangeServiceConfig2.
+func ReadFailureActions(buf *byte) FailureActions {
+	fa := (*serviceFailureActions)(unsafe.Pointer(buf))
+	result := FailureActions{ResetPeriod: fa.dwResetPeriod}
+	if fa.lpRebootMsg != nil {
+		result.RebootMessage = syscall.UTF16ToString((*[1 << 16]uint16)(unsafe.Pointer(fa.lpRebootMsg))[:])
+	}
+	if fa.cActions == 0 {
+		return result
+	}
+	actions := (*[1 << 16]serviceAction)(unsafe.Pointer(fa.scAction))[:fa.cActions:fa.cActions]
+	for _, action := range actions {
+		result.Actions = append(result.Actions, FailureAction{
+			Type:  action.actionType,
+			Delay: action.delay,
+		})
+	}
+	return result
+}
@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -22,6 +23,13 @@ var (
 	renderer = &shell.PowershellRenderer{}
 )
 
+// defaultRecovery is used for services that do not specify
+// their own recovery config.
+var defaultRecovery = common.RecoveryConfig{
+	RestartDelays: []time.Duration{5 * time.Second},
+	ResetPeriod:   5 * time.Second,
+}
+
 const (
 	// c_ERROR_SERVICE_DOES_NOT_EXIST is returned by the OS when trying to open
 	// an inexistent service
@@ -40,8 +48,39 @@ const (
 	// SeAssignPrimaryTokenPrivilege
 	// SeServiceLogonRight
 	jujudUser = ".\\jujud"
+
+	// virtualAccountDomain is the domain of the virtual accounts that
+	// Windows creates for each service.
+	virtualAccountDomain = "NT SERVICE"
 )
 
+// VirtualServiceAccount returns the name of the virtual account that
+// Windows creates for the named service. Running a service as its
+// virtual account isolates it from other services without requiring
+// a password to be managed.
+func VirtualServiceAccount(name string) string {
+	return virtualAccountDomain + `\` + name
+}
+
+// isManagedServiceAccount reports whether the account names a group
+// managed service account. Such accounts are named like computer
+// accounts, with a domain and a trailing "$".
+func isManagedServiceAccount(account string) bool {
+	parts := strings.SplitN(account, `\`, 2)
+	return len(parts) == 2 && parts[0] != "" &&
+		!strings.EqualFold(parts[0], virtualAccountDomain) &&
+		len(parts[1]) > 1 && strings.HasSuffix(parts[1], "$")
+}
+
+// serviceAccount returns the account the service described by
+// conf should run as.
+func serviceAccount(conf common.Conf) string {
+	if conf.ServiceAccount != "" {
+		return conf.ServiceAccount
+	}
+	return jujudUser
+}
+
 // IsRunning returns whether or not windows is the local init system.
 func IsRunning() (bool, error) {
 	return runtime.GOOS == "windows", nil
@@ -53,6 +92,95 @@ func ListServices() ([]string, error) {
 	return listServices()
 }
 
+// ServiceStateFilter selects services by their state when listing
+// them. The values match the dwServiceState values accepted by
+// EnumServicesStatusEx.
+type ServiceStateFilter uint32
+
+const (
+	// ServiceStateActive selects services that are running, or are
+	// starting, stopping, pausing or paused.
+	ServiceStateActive ServiceStateFilter = 1
+
+	// ServiceStateInactive selects services that are stopped.
+	ServiceStateInactive ServiceStateFilter = 2
+
+	// ServiceStateAll selects all services.
+	ServiceStateAll ServiceStateFilter = 3
+)
+
+// ServiceState is the current state of a service, as reported by the
+// service control manager.
+type ServiceState uint32
+
+// https://msdn.microsoft.com/en-us/library/windows/desktop/ms685996(v=vs.85).aspx
+const (
+	ServiceStopped         ServiceState = 1
+	ServiceStartPending    ServiceState = 2
+	ServiceStopPending     ServiceState = 3
+	ServiceRunning         ServiceState = 4
+	ServiceContinuePending ServiceState = 5
+	ServicePausePending    ServiceState = 6
+	ServicePaused          ServiceState = 7
+)
+
+var serviceStateNames = map[ServiceState]string{
+	ServiceStopped:         "stopped",
+	ServiceStartPending:    "start-pending",
+	ServiceStopPending:     "stop-pending",
+	ServiceRunning:         "running",
+	ServiceContinuePending: "continue-pending",
+	ServicePausePending:    "pause-pending",
+	ServicePaused:          "paused",
+}
+
+// String returns a human readable name for the state.
+func (s ServiceState) String() string {
+	if name, ok := serviceStateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown (%d)", uint32(s))
+}
+
+// ServiceStatus describes an installed service.
+type ServiceStatus struct {
+	// Name is the name of the service.
+	Name string
+
+	// DisplayName is the name of the service as shown to users.
+	DisplayName string
+
+	// State is the current state of the service.
+	State ServiceState
+
+	// PID is the ID of the service's process, or 0 if the
+	// service is not running.
+	PID int
+}
+
+// ListServiceStatuses returns the status of all installed services on
+// the local host that match the given filter.
+func ListServiceStatuses(filter ServiceStateFilter) ([]ServiceStatus, error) {
+	switch filter {
+	case ServiceStateActive, ServiceStateInactive, ServiceStateAll:
+	default:
+		return nil, errors.NotValidf("service state filter %d", filter)
+	}
+	statuses, err := listServiceStatuses(filter)
+	return statuses, errors.Trace(err)
+}
+
+// logsCommand returns a powershell script that prints the last n
+// Event Log entries written by the named service, oldest first.
+func logsCommand(name string, n int) string {
+	return fmt.Sprintf(
+		`Get-WinEvent -FilterHashtable @{LogName='Application'; ProviderName='%s'} -MaxEvents %d -ErrorAction SilentlyContinue | `+
+			`Sort-Object TimeCreated | `+
+			`ForEach-Object { '{0:o} {1} {2}' -f $_.TimeCreated, $_.LevelDisplayName, ($_.Message -replace "\r?\n", ' ') }`,
+		strings.Replace(name, "'", "''", -1), n,
+	)
+}
+
 // ListCommand returns a command that will list the services on a host.
 func ListCommand() string {
 	return `(Get-Service).Name`
@@ -127,6 +255,12 @@ func (s *Service) Validate() error {
 		return errors.NotSupportedf("Conf.AfterStopped")
 	}
 
+	if account := s.Service.Conf.ServiceAccount; account != "" {
+		if !strings.EqualFold(account, VirtualServiceAccount(s.Name())) && !isManagedServiceAccount(account) {
+			return errors.NotValidf("service account %q", account)
+		}
+	}
+
 	return nil
 }
 
@@ -159,6 +293,19 @@ func (s *Service) Exists() (bool, error) {
 	return s.manager.Exists(s.Name(), s.Conf())
 }
 
+// Logs returns up to the last n entries written to the Windows
+// Event Log by the service, oldest first.
+func (s *Service) Logs(n int) ([]string, error) {
+	if n <= 0 {
+		return nil, errors.NotValidf("log line count %d", n)
+	}
+	entries, err := serviceLogs(s.Name(), n)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return entries, nil
+}
+
 // Start starts the service.
 func (s *Service) Start() error {
 	logger.Infof("Starting service %q", s.Service.Name)
@@ -229,17 +376,67 @@ func (s *Service) Install() error {
 
 // InstallCommands returns shell commands to install the service.
 func (s *Service) InstallCommands() ([]string, error) {
+	var deps []string
+	for _, dep := range serviceDependencies(s.Service.Conf) {
+		deps = append(deps, renderer.Quote(dep))
+	}
+	dependsOn := strings.Join(deps, ",")
 	cmd := fmt.Sprintf(serviceCreateCommandTemplate[1:],
 		renderer.Quote(s.Service.Name),
+		dependsOn,
 		renderer.Quote(s.Service.Conf.Desc),
 		renderer.Quote(s.Service.Conf.ExecStart),
 		renderer.Quote(s.Service.Name),
+		dependsOn,
 		renderer.Quote(s.Service.Conf.Desc),
 		renderer.Quote(s.Service.Conf.ExecStart),
-		renderer.Quote(s.Service.Name),
-		renderer.Quote(s.Service.Name),
 	)
-	return strings.Split(cmd, "\n"), nil
+	cmds := strings.Split(cmd, "\n")
+	cmds = append(cmds, failureActionsCommands(s.Service.Name, s.Service.Conf.Recovery)...)
+	if s.Service.Conf.DelayedAutoStart {
+		cmds = append(cmds, fmt.Sprintf("sc.exe config %s start= delayed-auto", renderer.Quote(s.Service.Name)))
+	}
+	return cmds, nil
+}
+
+// failureActionsCommands returns the commands that configure the
+// named service to recover from failures as described by recovery,
+// or by defaultRecovery if that is nil, matching the failure actions
+// set when the service is installed through the service manager.
+func failureActionsCommands(name string, recovery *common.RecoveryConfig) []string {
+	if recovery == nil {
+		recovery = &defaultRecovery
+	}
+	var actions []string
+	for _, delay := range recovery.RestartDelays {
+		actions = append(actions, fmt.Sprintf("restart/%d", delay/time.Millisecond))
+	}
+	failure := fmt.Sprintf("sc.exe failure %s reset= %d", renderer.Quote(name), recovery.ResetPeriod/time.Second)
+	if recovery.RebootMessage != "" {
+		actions = append(actions, "reboot/0")
+		failure += " reboot= " + renderer.Quote(recovery.RebootMessage)
+	}
+	failure += " actions= " + strings.Join(actions, "/")
+	return []string{
+		failure,
+		fmt.Sprintf("sc.exe failureflag %s 1", renderer.Quote(name)),
+	}
+}
+
+// serviceDependencies returns the names of the services that the
+// service defined by conf depends on.
+func serviceDependencies(conf common.Conf) []string {
+	// make this service dependent on WMI service. WMI is needed for almost
+	// all installers to work properly, and is needed for all of the advanced windows
+	// instrumentation bits (powershell included). Juju agents must start after this
+	// service to ensure hooks run properly.
+	deps := []string{"Winmgmt"}
+	for _, dep := range conf.Dependencies {
+		if dep != "Winmgmt" {
+			deps = append(deps, dep)
+		}
+	}
+	return deps
 }
 
 // StartCommands returns shell commands to start the service.
@@ -250,9 +447,7 @@ func (s *Service) StartCommands() ([]string, error) {
 
 const serviceCreateCommandTemplate = `
 if ($jujuCreds) {
-  New-Service -Credential $jujuCreds -Name %s -DependsOn Winmgmt -DisplayName %s %s
+  New-Service -Credential $jujuCreds -Name %s -DependsOn %s -DisplayName %s %s
 } else {
-  New-Service -Name %s -DependsOn Winmgmt -DisplayName %s %s
-}
-sc.exe failure %s reset=5 actions=restart/1000
-sc.exe failureflag %s 1`
+  New-Service -Name %s -DependsOn %s -DisplayName %s %s
+}`
@@ -54,6 +54,14 @@ var listServices = func() ([]string, error) {
 	return []string{}, nil
 }
 
+var listServiceStatuses = func(filter ServiceStateFilter) ([]ServiceStatus, error) {
+	return []ServiceStatus{}, nil
+}
+
+var serviceLogs = func(name string, n int) ([]string, error) {
+	return nil, nil
+}
+
 var NewServiceManager = func() (ServiceManager, error) {
 	return &SvcManager{}, nil
 }
@@ -5,6 +5,8 @@
 package windows_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -206,3 +208,127 @@ func (s *serviceSuite) TestInstalledListError(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, listErr.Error())
 	c.Assert(exists, jc.IsFalse)
 }
+
+func (s *serviceSuite) TestVirtualServiceAccount(c *gc.C) {
+	c.Assert(windows.VirtualServiceAccount("jujud-unit-mysql-0"), gc.Equals, `NT SERVICE\jujud-unit-mysql-0`)
+}
+
+func (s *serviceSuite) TestValidateServiceAccount(c *gc.C) {
+	for i, test := range []struct {
+		account string
+		err     string
+	}{{
+		account: "",
+	}, {
+		account: `NT SERVICE\machine-1`,
+	}, {
+		account: `nt service\machine-1`,
+	}, {
+		account: `EXAMPLE\juju-units$`,
+	}, {
+		account: `NT SERVICE\machine-2`,
+		err:     `service account "NT SERVICE\\\\machine-2" not valid`,
+	}, {
+		account: `.\jujud`,
+		err:     `service account "\.\\\\jujud" not valid`,
+	}, {
+		account: `juju-units$`,
+		err:     `service account "juju-units\$" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.account)
+		conf := s.conf
+		conf.ServiceAccount = test.account
+		svc, err := windows.NewService(s.name, conf)
+		c.Assert(err, jc.ErrorIsNil)
+		err = svc.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *serviceSuite) TestInstallCommands(c *gc.C) {
+	s.conf.Dependencies = []string{"Tcpip"}
+	s.conf.DelayedAutoStart = true
+	svc, err := windows.NewService(s.name, s.conf)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cmds, err := svc.InstallCommands()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmds, jc.DeepEquals, []string{
+		"if ($jujuCreds) {",
+		`  New-Service -Credential $jujuCreds -Name 'machine-1' -DependsOn 'Winmgmt','Tcpip' -DisplayName 'service for machine-1' 'C:\juju\bin\jujud.exe machine-1'`,
+		"} else {",
+		`  New-Service -Name 'machine-1' -DependsOn 'Winmgmt','Tcpip' -DisplayName 'service for machine-1' 'C:\juju\bin\jujud.exe machine-1'`,
+		"}",
+		"sc.exe failure 'machine-1' reset= 5 actions= restart/5000",
+		"sc.exe failureflag 'machine-1' 1",
+		"sc.exe config 'machine-1' start= delayed-auto",
+	})
+}
+
+func (s *serviceSuite) TestInstallCommandsRecovery(c *gc.C) {
+	s.conf.Recovery = &common.RecoveryConfig{
+		RestartDelays: []time.Duration{time.Second, time.Minute},
+		ResetPeriod:   24 * time.Hour,
+		RebootMessage: "jujud failed",
+	}
+	svc, err := windows.NewService(s.name, s.conf)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cmds, err := svc.InstallCommands()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmds[len(cmds)-2:], jc.DeepEquals, []string{
+		"sc.exe failure 'machine-1' reset= 86400 reboot= 'jujud failed' actions= restart/1000/restart/60000/reboot/0",
+		"sc.exe failureflag 'machine-1' 1",
+	})
+}
+
+func (s *serviceSuite) TestListServiceStatuses(c *gc.C) {
+	statuses := []windows.ServiceStatus{{
+		Name:        "jujud-machine-1",
+		DisplayName: "juju agent for machine-1",
+		State:       windows.ServiceRunning,
+		PID:         1234,
+	}}
+	filters := windows.PatchListServiceStatuses(s, statuses)
+
+	result, err := windows.ListServiceStatuses(windows.ServiceStateActive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, statuses)
+	c.Assert(*filters, jc.DeepEquals, []windows.ServiceStateFilter{windows.ServiceStateActive})
+}
+
+func (s *serviceSuite) TestListServiceStatusesInvalidFilter(c *gc.C) {
+	filters := windows.PatchListServiceStatuses(s, nil)
+
+	_, err := windows.ListServiceStatuses(windows.ServiceStateFilter(42))
+	c.Assert(err, gc.ErrorMatches, "service state filter 42 not valid")
+	c.Assert(*filters, gc.HasLen, 0)
+}
+
+func (s *serviceSuite) TestLogs(c *gc.C) {
+	entries := []string{"2017-10-01T12:00:00.0000000+00:00 Information started"}
+	args := windows.PatchServiceLogs(s, entries)
+
+	result, err := s.mgr.Logs(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entries)
+	c.Assert(*args, jc.DeepEquals, []interface{}{"machine-1", 10})
+
+	_, err = s.mgr.Logs(-1)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *serviceSuite) TestLogsCommand(c *gc.C) {
+	cmd := windows.LogsCommand("it's", 5)
+	c.Assert(cmd, jc.HasPrefix, `Get-WinEvent -FilterHashtable @{LogName='Application'; ProviderName='it''s'} -MaxEvents 5 `)
+}
+
+func (s *serviceSuite) TestServiceStateString(c *gc.C) {
+	c.Check(windows.ServiceRunning.String(), gc.Equals, "running")
+	c.Check(windows.ServiceStopPending.String(), gc.Equals, "stop-pending")
+	c.Check(windows.ServiceState(99).String(), gc.Equals, "unknown (99)")
+}
@@ -5,8 +5,12 @@
 package windows
 
 import (
+	"bytes"
+	"os/exec"
 	"reflect"
+	"strings"
 	"syscall"
+	"time"
 	"unsafe"
 
 	// https://bugs.launchpad.net/juju-core/+bug/1470820
@@ -23,9 +27,10 @@ type SC_ENUM_TYPE int
 
 // https://msdn.microsoft.com/en-us/library/windows/desktop/ms681988(v=vs.85).aspx
 const (
-	SC_ENUM_PROCESS_INFO                SC_ENUM_TYPE = 0
-	SERVICE_CONFIG_FAILURE_ACTIONS                   = 2
-	SERVICE_CONFIG_FAILURE_ACTIONS_FLAG              = 4
+	SC_ENUM_PROCESS_INFO                   SC_ENUM_TYPE = 0
+	SERVICE_CONFIG_FAILURE_ACTIONS                      = 2
+	SERVICE_CONFIG_DELAYED_AUTO_START_INFO              = 3
+	SERVICE_CONFIG_FAILURE_ACTIONS_FLAG                 = 4
 )
 
 //sys enumServicesStatus(h windows.Handle, InfoLevel SC_ENUM_TYPE, dwServiceType uint32, dwServiceState uint32, lpServices uintptr, cbBufSize uint32, pcbBytesNeeded *uint32, lpServicesReturned *uint32, lpResumeHandle *uint32, pszGroupName *uint32) (err error) [failretval==0] = advapi32.EnumServicesStatusExW
@@ -56,6 +61,11 @@ type serviceFailureActionsFlag struct {
 	failureActionsOnNonCrashFailures int32
 }
 
+// https://msdn.microsoft.com/en-us/library/windows/desktop/ms685155(v=vs.85).aspx
+type serviceDelayedAutoStartInfo struct {
+	fDelayedAutostart int32
+}
+
 // This is done so we can mock this function out
 var WinChangeServiceConfig2 = windows.ChangeServiceConfig2
 
@@ -87,6 +97,14 @@ func (s *enumService) Name() string {
 	return ""
 }
 
+// DisplayName returns the display name of the service stored in enumService.
+func (s *enumService) DisplayName() string {
+	if s.displayName != nil {
+		return syscall.UTF16ToString((*[1 << 16]uint16)(unsafe.Pointer(s.displayName))[:])
+	}
+	return ""
+}
+
 // windowsManager exposes Mgr methods needed by the windows service package.
 type windowsManager interface {
 	CreateService(name, exepath string, c mgr.Config, args ...string) (windowsService, error)
@@ -174,7 +192,41 @@ var getPassword = func() (string, error) {
 // listServices returns an array of strings containing all the services on
 // the current system. It is defined as a variable to allow us to mock it out
 // for testing
-var listServices = func() (services []string, err error) {
+var listServices = func() ([]string, error) {
+	enum, err := enumServices(ServiceStateAll)
+	if err != nil {
+		return nil, err
+	}
+	services := make([]string, len(enum))
+	for i, v := range enum {
+		services[i] = v.Name()
+	}
+	return services, nil
+}
+
+// listServiceStatuses returns the status of the services on the current
+// system that match the filter. It is defined as a variable to allow us
+// to mock it out for testing
+var listServiceStatuses = func(filter ServiceStateFilter) ([]ServiceStatus, error) {
+	enum, err := enumServices(filter)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]ServiceStatus, len(enum))
+	for i, v := range enum {
+		statuses[i] = ServiceStatus{
+			Name:        v.Name(),
+			DisplayName: v.DisplayName(),
+			State:       ServiceState(v.Status.CurrentState),
+			PID:         int(v.Status.ProcessId),
+		}
+	}
+	return statuses, nil
+}
+
+// enumServices returns the services on the current system that match
+// the filter.
+func enumServices(filter ServiceStateFilter) (enum []enumService, err error) {
 	host := syscall.StringToUTF16Ptr(".")
 
 	sc, err := windows.OpenSCManager(host, nil, windows.SC_MANAGER_ALL_ACCESS)
@@ -191,12 +243,11 @@ var listServices = func() (services []string, err error) {
 	var needed uint32
 	var returned uint32
 	var resume uint32 = 0
-	var enum []enumService
 
 	for {
 		var buf [512]enumService
 		err := enumServicesStatus(sc, SC_ENUM_PROCESS_INFO, windows.SERVICE_WIN32,
-			windows.SERVICE_STATE_ALL, uintptr(unsafe.Pointer(&buf[0])), uint32(unsafe.Sizeof(buf)), &needed, &returned, &resume, nil)
+			uint32(filter), uintptr(unsafe.Pointer(&buf[0])), uint32(unsafe.Sizeof(buf)), &needed, &returned, &resume, nil)
 		if err != nil {
 			if err == windows.ERROR_MORE_DATA {
 				enum = append(enum, buf[:returned]...)
@@ -207,12 +258,7 @@ var listServices = func() (services []string, err error) {
 		enum = append(enum, buf[:returned]...)
 		break
 	}
-
-	services = make([]string, len(enum))
-	for i, v := range enum {
-		services[i] = v.Name()
-	}
-	return services, nil
+	return enum, nil
 }
 
 // SvcManager implements ServiceManager interface
@@ -289,14 +335,10 @@ func (s *SvcManager) Exists(name string, conf common.Conf) (bool, error) {
 	// We escape and compose BinaryPathName the same way mgr.CreateService does.
 	execStart := s.escapeExecPath(conf.ServiceBinary, conf.ServiceArgs)
 	cfg := mgr.Config{
-		// make this service dependent on WMI service. WMI is needed for almost
-		// all installers to work properly, and is needed for all of the advanced windows
-		// instrumentation bits (powershell included). Juju agents must start after this
-		// service to ensure hooks run properly.
-		Dependencies:     []string{"Winmgmt"},
+		Dependencies:     serviceDependencies(conf),
 		StartType:        mgr.StartAutomatic,
 		DisplayName:      conf.Desc,
-		ServiceStartName: jujudUser,
+		ServiceStartName: serviceAccount(conf),
 		BinaryPathName:   execStart,
 	}
 	currentConfig, err := s.Config(name)
@@ -356,22 +398,12 @@ func (s *SvcManager) Delete(name string) error {
 
 // Create creates a service with the given config.
 func (s *SvcManager) Create(name string, conf common.Conf) error {
-	serviceStartName := "LocalSystem"
-	var passwd string
-	hostSeries, err := series.HostSeries()
+	serviceStartName, passwd, err := s.startAccount(conf)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if !series.IsWindowsNano(hostSeries) {
-		password, err := getPassword()
-		if err != nil {
-			return errors.Trace(err)
-		}
-		passwd = password
-		serviceStartName = jujudUser
-	}
 	cfg := mgr.Config{
-		Dependencies:     []string{"Winmgmt"},
+		Dependencies:     serviceDependencies(conf),
 		ErrorControl:     mgr.ErrorSevere,
 		StartType:        mgr.StartAutomatic,
 		DisplayName:      conf.Desc,
@@ -386,13 +418,50 @@ func (s *SvcManager) Create(name string, conf common.Conf) error {
 		return errors.Trace(err)
 	}
 	defer service.Close()
-	err = s.ensureRestartOnFailure(name)
+	err = s.ensureRestartOnFailure(name, conf.Recovery)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if conf.DelayedAutoStart {
+		err = s.ensureDelayedAutoStart(name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if isManagedServiceAccount(conf.ServiceAccount) {
+		// Virtual accounts are granted the right to log on as a
+		// service by the service control manager, but managed
+		// service accounts must be granted it explicitly.
+		err = grantAccountRight(conf.ServiceAccount, seServiceLogonRight)
+		if err != nil {
+			return errors.Annotatef(err, "granting %s to %q", seServiceLogonRight, conf.ServiceAccount)
+		}
+	}
 	return nil
 }
 
+// startAccount returns the account and password that the service
+// described by conf should be created with.
+func (s *SvcManager) startAccount(conf common.Conf) (string, string, error) {
+	if conf.ServiceAccount != "" {
+		// Neither virtual nor managed service accounts have a
+		// password that we know.
+		return conf.ServiceAccount, "", nil
+	}
+	hostSeries, err := series.HostSeries()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	if series.IsWindowsNano(hostSeries) {
+		return "LocalSystem", "", nil
+	}
+	password, err := getPassword()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return jujudUser, password, nil
+}
+
 // Running returns the status of a service.
 func (s *SvcManager) Running(name string) (bool, error) {
 	status, err := s.status(name)
@@ -424,7 +493,39 @@ func (s *SvcManager) Config(name string) (mgr.Config, error) {
 	return service.Config()
 }
 
-func (s *SvcManager) ensureRestartOnFailure(name string) (err error) {
+// newFailureActions returns the failure actions described by
+// the recovery config.
+func newFailureActions(recovery common.RecoveryConfig) (serviceFailureActions, error) {
+	var actions []serviceAction
+	for _, delay := range recovery.RestartDelays {
+		actions = append(actions, serviceAction{
+			actionType: SC_ACTION_RESTART,
+			delay:      uint32(delay / time.Millisecond),
+		})
+	}
+	failActions := serviceFailureActions{
+		dwResetPeriod: uint32(recovery.ResetPeriod / time.Second),
+	}
+	if recovery.RebootMessage != "" {
+		msg, err := syscall.UTF16PtrFromString(recovery.RebootMessage)
+		if err != nil {
+			return serviceFailureActions{}, errors.Annotate(err, "invalid reboot message")
+		}
+		failActions.lpRebootMsg = msg
+		actions = append(actions, serviceAction{
+			actionType: SC_ACTION_REBOOT,
+		})
+	}
+	failActions.cActions = uint32(len(actions))
+	if len(actions) > 0 {
+		failActions.scAction = &actions[0]
+	}
+	return failActions, nil
+}
+
+// withServiceHandle calls f with a handle to the named service,
+// closing the handle afterwards.
+func (s *SvcManager) withServiceHandle(name string, f func(handle windows.Handle) error) (err error) {
 	handle, err := s.mgr.GetHandle(name)
 	if err != nil {
 		return errors.Trace(err)
@@ -440,29 +541,41 @@ func (s *SvcManager) ensureRestartOnFailure(name string) (err error) {
 			}
 		}
 	}()
-	action := serviceAction{
-		actionType: SC_ACTION_RESTART,
-		delay:      5000,
-	}
-	failActions := serviceFailureActions{
-		dwResetPeriod: 5,
-		lpRebootMsg:   nil,
-		lpCommand:     nil,
-		cActions:      1,
-		scAction:      &action,
+	return f(handle)
+}
+
+func (s *SvcManager) ensureRestartOnFailure(name string, recovery *common.RecoveryConfig) error {
+	if recovery == nil {
+		recovery = &defaultRecovery
 	}
-	err = WinChangeServiceConfig2(handle, SERVICE_CONFIG_FAILURE_ACTIONS, (*byte)(unsafe.Pointer(&failActions)))
+	failActions, err := newFailureActions(*recovery)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	flag := serviceFailureActionsFlag{
-		failureActionsOnNonCrashFailures: 1,
-	}
-	err = WinChangeServiceConfig2(handle, SERVICE_CONFIG_FAILURE_ACTIONS_FLAG, (*byte)(unsafe.Pointer(&flag)))
-	if err != nil {
+	return s.withServiceHandle(name, func(handle windows.Handle) error {
+		err := WinChangeServiceConfig2(handle, SERVICE_CONFIG_FAILURE_ACTIONS, (*byte)(unsafe.Pointer(&failActions)))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		flag := serviceFailureActionsFlag{
+			failureActionsOnNonCrashFailures: 1,
+		}
+		err = WinChangeServiceConfig2(handle, SERVICE_CONFIG_FAILURE_ACTIONS_FLAG, (*byte)(unsafe.Pointer(&flag)))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return nil
+	})
+}
+
+func (s *SvcManager) ensureDelayedAutoStart(name string) error {
+	return s.withServiceHandle(name, func(handle windows.Handle) error {
+		info := serviceDelayedAutoStartInfo{
+			fDelayedAutostart: 1,
+		}
+		err := WinChangeServiceConfig2(handle, SERVICE_CONFIG_DELAYED_AUTO_START_INFO, (*byte)(unsafe.Pointer(&info)))
 		return errors.Trace(err)
-	}
-	return nil
+	})
 }
 
 // ChangeServicePassword can change the password of a service
@@ -497,6 +610,22 @@ func (s *SvcManager) ChangeServicePassword(svcName, newPassword string) error {
 	return nil
 }
 
+// serviceLogs returns the last n Event Log entries written by the named
+// service. It is defined as a variable to allow us to mock it out for
+// testing.
+var serviceLogs = func(name string, n int) ([]string, error) {
+	out, err := exec.Command("powershell.exe", "-NonInteractive", "-Command", logsCommand(name, n)).Output()
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading event log for service %q", name)
+	}
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return nil, nil
+	}
+	lines := strings.Split(strings.Replace(string(out), "\r\n", "\n", -1), "\n")
+	return lines, nil
+}
+
 var NewServiceManager = func() (ServiceManager, error) {
 	m, err := newManager()
 	if err != nil {
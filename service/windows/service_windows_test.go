@@ -9,6 +9,7 @@ package windows_test
 import (
 	"fmt"
 	"syscall"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -91,6 +92,81 @@ func (s *serviceManagerSuite) TestCreate(c *gc.C) {
 	c.Assert(running, jc.IsFalse)
 }
 
+func (s *serviceManagerSuite) TestCreateDependencies(c *gc.C) {
+	s.getPasswd.SetPasswd("fake")
+	s.conf.Dependencies = []string{"Tcpip", "Winmgmt", "Dhcp"}
+	err := s.mgr.Create(s.name, s.conf)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, ok := s.mgr.(*windows.SvcManager)
+	c.Assert(ok, jc.IsTrue)
+	cfg, err := m.Config(s.name)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Dependencies, jc.DeepEquals, []string{"Winmgmt", "Tcpip", "Dhcp"})
+}
+
+func (s *serviceManagerSuite) TestCreateDelayedAutoStart(c *gc.C) {
+	var infoLevels []uint32
+	windows.WinChangeServiceConfig2 = func(_ win.Handle, infoLevel uint32, _ *byte) error {
+		infoLevels = append(infoLevels, infoLevel)
+		return nil
+	}
+	s.conf.DelayedAutoStart = true
+	err := s.mgr.Create(s.name, s.conf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infoLevels, jc.DeepEquals, []uint32{
+		windows.SERVICE_CONFIG_FAILURE_ACTIONS,
+		windows.SERVICE_CONFIG_FAILURE_ACTIONS_FLAG,
+		windows.SERVICE_CONFIG_DELAYED_AUTO_START_INFO,
+	})
+	s.stub.CheckCallNames(c, "CreateService", "GetHandle", "CloseHandle", "GetHandle", "CloseHandle", "Close")
+}
+
+func (s *serviceManagerSuite) TestCreateWithoutDelayedAutoStart(c *gc.C) {
+	var infoLevels []uint32
+	windows.WinChangeServiceConfig2 = func(_ win.Handle, infoLevel uint32, _ *byte) error {
+		infoLevels = append(infoLevels, infoLevel)
+		return nil
+	}
+	err := s.mgr.Create(s.name, s.conf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infoLevels, jc.DeepEquals, []uint32{
+		windows.SERVICE_CONFIG_FAILURE_ACTIONS,
+		windows.SERVICE_CONFIG_FAILURE_ACTIONS_FLAG,
+	})
+}
+
+func (s *serviceManagerSuite) TestCreateVirtualServiceAccount(c *gc.C) {
+	windows.PatchGrantAccountRight(s, s.stub)
+	s.conf.ServiceAccount = windows.VirtualServiceAccount(s.name)
+	err := s.mgr.Create(s.name, s.conf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.getPasswd.Calls(), gc.HasLen, 0)
+
+	m, ok := s.mgr.(*windows.SvcManager)
+	c.Assert(ok, jc.IsTrue)
+	cfg, err := m.Config(s.name)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ServiceStartName, gc.Equals, `NT SERVICE\machine-1`)
+	c.Assert(cfg.Password, gc.Equals, "")
+	for _, call := range s.stub.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "GrantAccountRight")
+	}
+}
+
+func (s *serviceManagerSuite) TestCreateManagedServiceAccount(c *gc.C) {
+	windows.PatchGrantAccountRight(s, s.stub)
+	s.conf.ServiceAccount = `EXAMPLE\juju-units$`
+	err := s.mgr.Create(s.name, s.conf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.getPasswd.Calls(), gc.HasLen, 0)
+
+	s.stub.CheckCallNames(c,
+		"CreateService", "GetHandle", "CloseHandle", "GrantAccountRight", "Close",
+	)
+	s.stub.CheckCall(c, 3, "GrantAccountRight", `EXAMPLE\juju-units$`, "SeServiceLogonRight")
+}
+
 func (s *serviceManagerSuite) TestCreateInvalidPassword(c *gc.C) {
 	passwdError := errors.New("Failed to get password")
 	s.passwdStub.SetErrors(passwdError)
@@ -228,6 +304,49 @@ func (s *serviceManagerSuite) TestEnsureRestartOnFailureCloseHandleReturnsErrorS
 	s.stub.CheckCallNames(c, "CreateService", "GetHandle", "CloseHandle", "Close")
 }
 
+func (s *serviceManagerSuite) captureFailureActions() *[]windows.FailureActions {
+	var captured []windows.FailureActions
+	windows.WinChangeServiceConfig2 = func(_ win.Handle, infoLevel uint32, buf *byte) error {
+		if infoLevel == windows.SERVICE_CONFIG_FAILURE_ACTIONS {
+			captured = append(captured, windows.ReadFailureActions(buf))
+		}
+		return nil
+	}
+	return &captured
+}
+
+func (s *serviceManagerSuite) TestEnsureRestartOnFailureDefaultRecovery(c *gc.C) {
+	captured := s.captureFailureActions()
+	err := s.mgr.Create(s.name, s.conf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*captured, jc.DeepEquals, []windows.FailureActions{{
+		ResetPeriod: 5,
+		Actions: []windows.FailureAction{
+			{Type: windows.SC_ACTION_RESTART, Delay: 5000},
+		},
+	}})
+}
+
+func (s *serviceManagerSuite) TestEnsureRestartOnFailureRecoveryConfig(c *gc.C) {
+	captured := s.captureFailureActions()
+	s.conf.Recovery = &common.RecoveryConfig{
+		RestartDelays: []time.Duration{time.Second, time.Minute},
+		ResetPeriod:   time.Hour,
+		RebootMessage: "jujud failed repeatedly",
+	}
+	err := s.mgr.Create(s.name, s.conf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*captured, jc.DeepEquals, []windows.FailureActions{{
+		ResetPeriod:   3600,
+		RebootMessage: "jujud failed repeatedly",
+		Actions: []windows.FailureAction{
+			{Type: windows.SC_ACTION_RESTART, Delay: 1000},
+			{Type: windows.SC_ACTION_RESTART, Delay: 60000},
+			{Type: windows.SC_ACTION_REBOOT},
+		},
+	}})
+}
+
 func (s *serviceManagerSuite) TestChangePassword(c *gc.C) {
 	s.getPasswd.SetPasswd("fake")
 	err := s.mgr.Create(s.name, s.conf)
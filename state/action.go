@@ -104,6 +104,15 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Logs holds the messages logged by the action while running.
+	Logs []ActionMessage `bson:"logs,omitempty"`
+}
+
+// ActionMessage is a timestamped message logged by a running action.
+type ActionMessage struct {
+	Timestamp time.Time `bson:"timestamp" json:"timestamp"`
+	Message   string    `bson:"message" json:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +171,11 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the messages logged by the action, oldest first.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -210,6 +224,25 @@ func (a *action) Begin() (Action, error) {
 	return m.Action(a.Id())
 }
 
+// Log records a progress message for the action. It asserts that the
+// action is currently running.
+func (a *action) Log(message string) error {
+	logMessage := ActionMessage{
+		Timestamp: a.st.nowToTheSecond(),
+		Message:   message,
+	}
+	err := a.st.db().RunTransaction([]txn.Op{{
+		C:      actionsC,
+		Id:     a.doc.DocId,
+		Assert: bson.D{{"status", ActionRunning}},
+		Update: bson.D{{"$push", bson.D{{"logs", logMessage}}}},
+	}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot log message for action %q: action is not running", a.Id())
+	}
+	return errors.Trace(err)
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
@@ -482,9 +515,14 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 
 // PruneActions removes action entries until
 // only logs newer than <maxLogTime> remain and also ensures
-// that the collection is smaller than <maxLogsMB> after the
-// deletion.
+// that the collection is smaller than <maxLogsMB>, or the
+// controller's max-action-results-collection-size if that is
+// set, after the deletion.
 func PruneActions(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
-	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", GoTime)
+	maxHistoryMB, err := pruneSizeLimit(st, maxHistoryMB, actionsC)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", GoTime)
 	return errors.Trace(err)
 }
@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -866,6 +867,82 @@ func (s *ActionSuite) TestActionStatusWatcher(c *gc.C) {
 	watchCancelledOrCompleted.AssertNoChange()
 }
 
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Second)
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = s.model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	now := s.Clock.Now().Round(time.Second).UTC()
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Check(messages[0].Message, gc.Equals, "first")
+	c.Check(messages[0].Timestamp.Equal(now.Add(-time.Second)), jc.IsTrue)
+	c.Check(messages[1].Message, gc.Equals, "second")
+	c.Check(messages[1].Timestamp.Equal(now), jc.IsTrue)
+}
+
+func (s *ActionSuite) TestLogNotRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("too early")
+	c.Assert(err, gc.ErrorMatches, `cannot log message for action ".*": action is not running`)
+
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message for action ".*": action is not running`)
+}
+
+func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.model.WatchActionLogs(a.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	now := s.Clock.Now().Round(time.Second).UTC()
+	wc.AssertChange(expectActionMessages(c, now, "first")...)
+	wc.AssertNoChange()
+
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("third")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(expectActionMessages(c, now, "second", "third")...)
+	wc.AssertNoChange()
+
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func expectActionMessages(c *gc.C, timestamp time.Time, messages ...string) []string {
+	encoded := make([]string, len(messages))
+	for i, message := range messages {
+		data, err := json.Marshal(state.ActionMessage{
+			Timestamp: timestamp,
+			Message:   message,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		encoded[i] = string(data)
+	}
+	return encoded
+}
+
 func expectActionIds(actions ...state.Action) []string {
 	ids := make([]string, len(actions))
 	for i, action := range actions {
@@ -0,0 +1,522 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// ActionRolloutStatus represents the state of an action rollout.
+type ActionRolloutStatus string
+
+const (
+	// ActionRolloutRunning indicates that the rollout's actions are
+	// still being run.
+	ActionRolloutRunning ActionRolloutStatus = "running"
+
+	// ActionRolloutCompleted indicates that the action has been run on
+	// every unit of the rollout.
+	ActionRolloutCompleted ActionRolloutStatus = "completed"
+
+	// ActionRolloutStopped indicates that the rollout was stopped
+	// after an action failed, leaving some units without the action.
+	ActionRolloutStopped ActionRolloutStatus = "stopped"
+)
+
+// ActionRollout describes an action run across the units of an
+// application in batches, so that no more than a given number of units
+// run the action at the same time.
+type ActionRollout struct {
+	st  *State
+	doc actionRolloutDoc
+}
+
+type actionRolloutDoc struct {
+	// DocId is the key for this document; it is the model-local id
+	// prefixed with the model UUID.
+	DocId string `bson:"_id"`
+
+	// Id is the model-local id of the rollout.
+	Id string `bson:"rollout-id"`
+
+	// ModelUUID is the model identifier.
+	ModelUUID string `bson:"model-uuid"`
+
+	// Application is the name of the application whose units run the
+	// action.
+	Application string `bson:"application"`
+
+	// Name identifies the action to run.
+	Name string `bson:"name"`
+
+	// Parameters holds the action's parameters, with the charm's
+	// defaults inserted.
+	Parameters map[string]interface{} `bson:"parameters"`
+
+	// MaxParallel is the maximum number of units in each batch. Zero
+	// means that all units are in a single batch.
+	MaxParallel int `bson:"max-parallel"`
+
+	// BatchDelay is the time to wait after a batch has finished
+	// before starting the next one.
+	BatchDelay time.Duration `bson:"batch-delay"`
+
+	// StopOnFailure records whether the rollout stops once an action
+	// fails.
+	StopOnFailure bool `bson:"stop-on-failure"`
+
+	// Status is the state of the rollout.
+	Status ActionRolloutStatus `bson:"status"`
+
+	// Pending holds the names of the units on which the action has
+	// not yet been enqueued, in the order they will be run.
+	Pending []string `bson:"pending"`
+
+	// Running holds the ids of the actions in the current batch that
+	// have not yet finished.
+	Running []string `bson:"running"`
+
+	// Actions holds the ids of all the actions enqueued so far.
+	Actions []string `bson:"actions"`
+
+	// Failed holds the names of the units whose action failed or was
+	// cancelled.
+	Failed []string `bson:"failed"`
+
+	// NextBatch is the earliest time the next batch may start. It is
+	// zero while a batch is running.
+	NextBatch time.Time `bson:"next-batch"`
+
+	// Revision is incremented on every change to the rollout, so that
+	// concurrent changes are detected.
+	Revision int `bson:"revision"`
+
+	// Created is the time the rollout was added.
+	Created time.Time `bson:"created"`
+}
+
+// Id returns the model-local id of the rollout.
+func (r *ActionRollout) Id() string {
+	return r.doc.Id
+}
+
+// Application returns the name of the application whose units run the
+// action.
+func (r *ActionRollout) Application() string {
+	return r.doc.Application
+}
+
+// Name returns the name of the action.
+func (r *ActionRollout) Name() string {
+	return r.doc.Name
+}
+
+// Parameters returns the action's parameters.
+func (r *ActionRollout) Parameters() map[string]interface{} {
+	return r.doc.Parameters
+}
+
+// MaxParallel returns the maximum number of units that run the action
+// at the same time. Zero means no limit.
+func (r *ActionRollout) MaxParallel() int {
+	return r.doc.MaxParallel
+}
+
+// BatchDelay returns the time waited between batches.
+func (r *ActionRollout) BatchDelay() time.Duration {
+	return r.doc.BatchDelay
+}
+
+// StopOnFailure returns whether the rollout stops once an action
+// fails.
+func (r *ActionRollout) StopOnFailure() bool {
+	return r.doc.StopOnFailure
+}
+
+// Status returns the state of the rollout.
+func (r *ActionRollout) Status() ActionRolloutStatus {
+	return r.doc.Status
+}
+
+// Pending returns the names of the units on which the action has not
+// yet been enqueued.
+func (r *ActionRollout) Pending() []string {
+	return r.doc.Pending
+}
+
+// Actions returns the ids of the actions enqueued so far.
+func (r *ActionRollout) Actions() []string {
+	return r.doc.Actions
+}
+
+// Failed returns the names of the units whose action failed or was
+// cancelled.
+func (r *ActionRollout) Failed() []string {
+	return r.doc.Failed
+}
+
+// Created returns the time the rollout was added.
+func (r *ActionRollout) Created() time.Time {
+	return r.doc.Created
+}
+
+// ActionRolloutArgs holds the arguments for adding an action rollout.
+type ActionRolloutArgs struct {
+	// Application is the name of the application whose units run the
+	// action.
+	Application string
+
+	// Name is the name of the action to run.
+	Name string
+
+	// Parameters holds the action's parameters, if any.
+	Parameters map[string]interface{}
+
+	// MaxParallel is the maximum number of units that run the action
+	// at the same time. Zero means no limit.
+	MaxParallel int
+
+	// BatchDelay is the time to wait after a batch has finished before
+	// starting the next one.
+	BatchDelay time.Duration
+
+	// StopOnFailure, if true, stops the rollout once an action fails.
+	StopOnFailure bool
+}
+
+// AddActionRollout adds a rollout that runs an action on every current
+// unit of an application, in batches of no more than MaxParallel
+// units. The first batch is enqueued immediately; subsequent batches
+// are enqueued by AdvanceActionRollouts.
+func (m *Model) AddActionRollout(args ActionRolloutArgs) (*ActionRollout, error) {
+	if args.Name == "" {
+		return nil, errors.NotValidf("empty action name")
+	}
+	if args.MaxParallel < 0 {
+		return nil, errors.NotValidf("negative max parallel")
+	}
+	if args.BatchDelay < 0 {
+		return nil, errors.NotValidf("negative batch delay")
+	}
+	app, err := m.st.Application(args.Application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec, err := applicationActionSpec(app, args.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := spec.ValidateParams(args.Parameters); err != nil {
+		return nil, errors.Trace(err)
+	}
+	parameters, err := spec.InsertDefaults(args.Parameters)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var unitNames []string
+	for _, unit := range units {
+		if unit.Life() == Alive {
+			unitNames = append(unitNames, unit.Name())
+		}
+	}
+	if len(unitNames) == 0 {
+		return nil, errors.Errorf("application %q has no units", args.Application)
+	}
+	sortUnitNames(unitNames)
+
+	seq, err := sequence(m.st, "actionrollout")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	now := m.st.nowToTheSecond()
+	doc := actionRolloutDoc{
+		DocId:         m.st.docID(id),
+		Id:            id,
+		ModelUUID:     m.st.ModelUUID(),
+		Application:   args.Application,
+		Name:          args.Name,
+		Parameters:    parameters,
+		MaxParallel:   args.MaxParallel,
+		BatchDelay:    args.BatchDelay,
+		StopOnFailure: args.StopOnFailure,
+		Status:        ActionRolloutRunning,
+		Pending:       unitNames,
+		NextBatch:     now,
+		Created:       now,
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		if err := checkModelActive(m.st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := app.Refresh(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q is not alive", args.Application)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      actionRolloutsC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}, m.assertActiveOp()}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "cannot add action rollout")
+	}
+	if err := m.advanceActionRollout(id, now); err != nil {
+		return nil, errors.Annotate(err, "cannot start action rollout")
+	}
+	return m.ActionRollout(id)
+}
+
+// applicationActionSpec returns the spec of the named action, which
+// must be predefined by juju or defined by the application's charm.
+func applicationActionSpec(app *Application, name string) (charm.ActionSpec, error) {
+	if spec, ok := actions.PredefinedActionsSpec[name]; ok {
+		return spec, nil
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return charm.ActionSpec{}, errors.Trace(err)
+	}
+	if ch.Actions() != nil {
+		if spec, ok := ch.Actions().ActionSpecs[name]; ok {
+			return spec, nil
+		}
+	}
+	return charm.ActionSpec{}, errors.NotValidf("action %q for application %q", name, app.Name())
+}
+
+// sortUnitNames sorts the names of an application's units by unit
+// number.
+func sortUnitNames(unitNames []string) {
+	number := func(name string) int {
+		n, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+		return n
+	}
+	sort.Slice(unitNames, func(i, j int) bool {
+		return number(unitNames[i]) < number(unitNames[j])
+	})
+}
+
+// ActionRollout returns the action rollout with the given id.
+func (m *Model) ActionRollout(id string) (*ActionRollout, error) {
+	coll, closer := m.st.db().GetCollection(actionRolloutsC)
+	defer closer()
+
+	var doc actionRolloutDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action rollout %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action rollout %q", id)
+	}
+	return &ActionRollout{st: m.st, doc: doc}, nil
+}
+
+// AllActionRollouts returns all the action rollouts in the model, in
+// the order they were added.
+func (m *Model) AllActionRollouts() ([]*ActionRollout, error) {
+	return m.actionRollouts(nil)
+}
+
+func (m *Model) actionRollouts(query bson.D) ([]*ActionRollout, error) {
+	coll, closer := m.st.db().GetCollection(actionRolloutsC)
+	defer closer()
+
+	var docs []actionRolloutDoc
+	if err := coll.Find(query).Sort("created").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action rollouts")
+	}
+	result := make([]*ActionRollout, len(docs))
+	for i, doc := range docs {
+		result[i] = &ActionRollout{st: m.st, doc: doc}
+	}
+	return result, nil
+}
+
+// AdvanceActionRollouts records the outcome of the finished actions of
+// all running rollouts, and enqueues the next batch of any rollout
+// whose current batch has finished and whose batch delay has passed.
+// A batch's actions are enqueued in the same transaction that records
+// the batch, so they are never enqueued more than once.
+func (m *Model) AdvanceActionRollouts() error {
+	running, err := m.actionRollouts(bson.D{{"status", ActionRolloutRunning}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := m.st.nowToTheSecond()
+	for _, rollout := range running {
+		if err := m.advanceActionRollout(rollout.Id(), now); err != nil {
+			return errors.Annotatef(err, "advancing action rollout %q", rollout.Id())
+		}
+	}
+	return nil
+}
+
+func (m *Model) advanceActionRollout(id string, now time.Time) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		rollout, err := m.ActionRollout(id)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if rollout.doc.Status != ActionRolloutRunning {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return rollout.advanceOps(now)
+	}
+	return errors.Trace(m.st.db().Run(buildTxn))
+}
+
+// advanceOps returns the operations needed to bring the rollout up to
+// date, or jujutxn.ErrNoOperations if there is nothing to do.
+func (r *ActionRollout) advanceOps(now time.Time) ([]txn.Op, error) {
+	model, err := r.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := r.doc
+	changed := false
+
+	// Collect the outcome of the current batch.
+	var running []string
+	for _, actionId := range doc.Running {
+		a, err := model.Action(actionId)
+		if errors.IsNotFound(err) {
+			// The action has been pruned, so it must have finished.
+			changed = true
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch a.Status() {
+		case ActionPending, ActionRunning:
+			running = append(running, actionId)
+			continue
+		case ActionFailed, ActionCancelled:
+			doc.Failed = append(doc.Failed, a.Receiver())
+		}
+		changed = true
+	}
+	doc.Running = running
+
+	var ops []txn.Op
+	switch {
+	case doc.StopOnFailure && len(doc.Failed) > 0:
+		doc.Status = ActionRolloutStopped
+		changed = true
+	case len(doc.Running) > 0:
+		// The current batch is still running.
+	case len(doc.Pending) == 0:
+		doc.Status = ActionRolloutCompleted
+		changed = true
+	case doc.NextBatch.IsZero():
+		// The batch has just finished; the next one starts after the
+		// batch delay.
+		doc.NextBatch = now.Add(doc.BatchDelay)
+		changed = true
+	}
+	if doc.Status == ActionRolloutRunning && len(doc.Running) == 0 &&
+		len(doc.Pending) > 0 && !doc.NextBatch.IsZero() && !doc.NextBatch.After(now) {
+		batchOps, err := r.startBatch(&doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, batchOps...)
+		changed = true
+	}
+	if !changed {
+		return nil, jujutxn.ErrNoOperations
+	}
+	if doc.Running == nil {
+		doc.Running = []string{}
+	}
+	return append(ops, txn.Op{
+		C:      actionRolloutsC,
+		Id:     r.doc.DocId,
+		Assert: bson.D{{"revision", r.doc.Revision}},
+		Update: bson.D{{"$set", bson.D{
+			{"status", doc.Status},
+			{"pending", doc.Pending},
+			{"running", doc.Running},
+			{"actions", doc.Actions},
+			{"failed", doc.Failed},
+			{"next-batch", doc.NextBatch},
+			{"revision", r.doc.Revision + 1},
+		}}},
+	}), nil
+}
+
+// startBatch updates doc to record the next batch of actions, and
+// returns the operations that enqueue them. Units that are no longer
+// alive are skipped.
+func (r *ActionRollout) startBatch(doc *actionRolloutDoc) ([]txn.Op, error) {
+	var ops []txn.Op
+	for len(doc.Pending) > 0 && (doc.MaxParallel == 0 || len(doc.Running) < doc.MaxParallel) {
+		unitName := doc.Pending[0]
+		doc.Pending = doc.Pending[1:]
+		unit, err := r.st.Unit(unitName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if unit.Life() != Alive {
+			continue
+		}
+		adoc, ndoc, err := newActionDoc(r.st, unit.Tag(), doc.Name, doc.Parameters)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		actionId := r.st.localID(adoc.DocId)
+		doc.Running = append(doc.Running, actionId)
+		doc.Actions = append(doc.Actions, actionId)
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     unit.doc.DocID,
+			Assert: notDeadDoc,
+		}, txn.Op{
+			C:      actionsC,
+			Id:     adoc.DocId,
+			Assert: txn.DocMissing,
+			Insert: adoc,
+		}, txn.Op{
+			C:      actionNotificationsC,
+			Id:     ndoc.DocId,
+			Assert: txn.DocMissing,
+			Insert: ndoc,
+		})
+	}
+	doc.NextBatch = time.Time{}
+	if len(doc.Running) == 0 {
+		// Every remaining unit has gone away.
+		doc.Status = ActionRolloutCompleted
+	}
+	return ops, nil
+}
@@ -0,0 +1,206 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionRolloutSuite struct {
+	ConnSuite
+	application *state.Application
+	units       []*state.Unit
+	model       *state.Model
+}
+
+var _ = gc.Suite(&ActionRolloutSuite{})
+
+func (s *ActionRolloutSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingApplication(c, "dummy", ch)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+	var err error
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionRolloutSuite) TestAddActionRollout(c *gc.C) {
+	rollout, err := s.model.AddActionRollout(state.ActionRolloutArgs{
+		Application:   "dummy",
+		Name:          "snapshot",
+		Parameters:    map[string]interface{}{"outfile": "foo.tgz"},
+		MaxParallel:   2,
+		BatchDelay:    time.Minute,
+		StopOnFailure: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Application(), gc.Equals, "dummy")
+	c.Assert(rollout.Name(), gc.Equals, "snapshot")
+	c.Assert(rollout.MaxParallel(), gc.Equals, 2)
+	c.Assert(rollout.BatchDelay(), gc.Equals, time.Minute)
+	c.Assert(rollout.StopOnFailure(), jc.IsTrue)
+	c.Assert(rollout.Status(), gc.Equals, state.ActionRolloutRunning)
+	c.Assert(rollout.Pending(), jc.DeepEquals, []string{"dummy/2"})
+	c.Assert(rollout.Actions(), gc.HasLen, 2)
+	s.assertPendingActions(c, 1, 1, 0)
+
+	all, err := s.model.AllActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Id(), gc.Equals, rollout.Id())
+}
+
+func (s *ActionRolloutSuite) TestAddActionRolloutInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args state.ActionRolloutArgs
+		err  string
+	}{{
+		args: state.ActionRolloutArgs{Application: "dummy"},
+		err:  "empty action name not valid",
+	}, {
+		args: state.ActionRolloutArgs{Application: "dummy", Name: "snapshot", MaxParallel: -1},
+		err:  "negative max parallel not valid",
+	}, {
+		args: state.ActionRolloutArgs{Application: "dummy", Name: "snapshot", BatchDelay: -time.Second},
+		err:  "negative batch delay not valid",
+	}, {
+		args: state.ActionRolloutArgs{Application: "dummy", Name: "no-such-action"},
+		err:  `action "no-such-action" for application "dummy" not valid`,
+	}, {
+		args: state.ActionRolloutArgs{Application: "missing", Name: "snapshot"},
+		err:  `application "missing" not found`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.model.AddActionRollout(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionRolloutSuite) TestActionRolloutNotFound(c *gc.C) {
+	_, err := s.model.ActionRollout("42")
+	c.Assert(err, gc.ErrorMatches, `action rollout "42" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionRolloutSuite) TestAdvanceActionRolloutsInBatches(c *gc.C) {
+	rollout, err := s.model.AddActionRollout(state.ActionRolloutArgs{
+		Application: "dummy",
+		Name:        "snapshot",
+		MaxParallel: 1,
+		BatchDelay:  time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPendingActions(c, 1, 0, 0)
+
+	// Nothing more happens while the first batch is running.
+	err = s.model.AdvanceActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPendingActions(c, 1, 0, 0)
+
+	s.finishActions(c, s.units[0], state.ActionCompleted)
+	err = s.model.AdvanceActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPendingActions(c, 0, 0, 0)
+
+	// The next batch starts once the delay has passed.
+	s.Clock.Advance(time.Minute)
+	err = s.model.AdvanceActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPendingActions(c, 0, 1, 0)
+
+	s.finishActions(c, s.units[1], state.ActionFailed)
+	s.Clock.Advance(time.Minute)
+	err = s.model.AdvanceActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	err = s.model.AdvanceActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPendingActions(c, 0, 0, 1)
+
+	s.finishActions(c, s.units[2], state.ActionCompleted)
+	err = s.model.AdvanceActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+
+	rollout, err = s.model.ActionRollout(rollout.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Status(), gc.Equals, state.ActionRolloutCompleted)
+	c.Assert(rollout.Pending(), gc.HasLen, 0)
+	c.Assert(rollout.Actions(), gc.HasLen, 3)
+	c.Assert(rollout.Failed(), jc.DeepEquals, []string{"dummy/1"})
+}
+
+func (s *ActionRolloutSuite) TestAdvanceActionRolloutsStopOnFailure(c *gc.C) {
+	rollout, err := s.model.AddActionRollout(state.ActionRolloutArgs{
+		Application:   "dummy",
+		Name:          "snapshot",
+		MaxParallel:   2,
+		StopOnFailure: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPendingActions(c, 1, 1, 0)
+
+	s.finishActions(c, s.units[0], state.ActionCompleted)
+	s.finishActions(c, s.units[1], state.ActionFailed)
+	err = s.model.AdvanceActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPendingActions(c, 0, 0, 0)
+
+	rollout, err = s.model.ActionRollout(rollout.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Status(), gc.Equals, state.ActionRolloutStopped)
+	c.Assert(rollout.Pending(), jc.DeepEquals, []string{"dummy/2"})
+	c.Assert(rollout.Failed(), jc.DeepEquals, []string{"dummy/1"})
+}
+
+func (s *ActionRolloutSuite) TestAdvanceActionRolloutsSkipsRemovedUnits(c *gc.C) {
+	rollout, err := s.model.AddActionRollout(state.ActionRolloutArgs{
+		Application: "dummy",
+		Name:        "snapshot",
+		MaxParallel: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[1].EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.finishActions(c, s.units[0], state.ActionCompleted)
+	err = s.model.AdvanceActionRollouts()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPendingActions(c, 0, 0, 1)
+
+	rollout, err = s.model.ActionRollout(rollout.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Actions(), gc.HasLen, 2)
+	c.Assert(rollout.Pending(), gc.HasLen, 0)
+}
+
+func (s *ActionRolloutSuite) finishActions(c *gc.C, unit *state.Unit, status state.ActionStatus) {
+	actions, err := unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, action := range actions {
+		action, err = action.Begin()
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = action.Finish(state.ActionResults{Status: status})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ActionRolloutSuite) assertPendingActions(c *gc.C, counts ...int) {
+	for i, count := range counts {
+		actions, err := s.units[i].PendingActions()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(actions, gc.HasLen, count, gc.Commentf("unit %d", i))
+	}
+}
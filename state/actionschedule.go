@@ -0,0 +1,397 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// ActionSchedule describes an action to be enqueued on a unit, or on
+// every unit of an application, either once at a given time or
+// repeatedly according to a cron-like schedule.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+type actionScheduleDoc struct {
+	// DocId is the key for this document; it is the model-local id
+	// prefixed with the model UUID.
+	DocId string `bson:"_id"`
+
+	// Id is the model-local id of the schedule.
+	Id string `bson:"schedule-id"`
+
+	// ModelUUID is the model identifier.
+	ModelUUID string `bson:"model-uuid"`
+
+	// Receiver is the tag of the unit or application on which the
+	// action is enqueued.
+	Receiver string `bson:"receiver"`
+
+	// Name identifies the action to enqueue.
+	Name string `bson:"name"`
+
+	// Parameters holds the action's parameters, if any.
+	Parameters map[string]interface{} `bson:"parameters"`
+
+	// Spec holds the cron-like schedule of a recurring action. It is
+	// empty for an action that is enqueued once.
+	Spec string `bson:"spec,omitempty"`
+
+	// NextRun is the time the action is next due to be enqueued.
+	NextRun time.Time `bson:"next-run"`
+
+	// LastRun is the time the action was last enqueued, if it has been.
+	LastRun time.Time `bson:"last-run,omitempty"`
+
+	// Created is the time the schedule was added.
+	Created time.Time `bson:"created"`
+}
+
+// Id returns the model-local id of the schedule.
+func (s *ActionSchedule) Id() string {
+	return s.doc.Id
+}
+
+// Receiver returns the tag of the unit or application on which the
+// action is enqueued.
+func (s *ActionSchedule) Receiver() names.Tag {
+	tag, err := names.ParseTag(s.doc.Receiver)
+	if err != nil {
+		// Receivers are validated when the schedule is added.
+		panic(err)
+	}
+	return tag
+}
+
+// Name returns the name of the action to enqueue.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Parameters returns the parameters of the action to enqueue.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Spec returns the cron-like schedule of a recurring action, or the
+// empty string for an action that is enqueued once.
+func (s *ActionSchedule) Spec() string {
+	return s.doc.Spec
+}
+
+// NextRun returns the time the action is next due to be enqueued.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// LastRun returns the time the action was last enqueued, or the zero
+// time if it has not been.
+func (s *ActionSchedule) LastRun() time.Time {
+	return s.doc.LastRun
+}
+
+// Created returns the time the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// ActionScheduleArgs holds the arguments for adding an action schedule.
+// Exactly one of Spec and At must be set.
+type ActionScheduleArgs struct {
+	// Receiver is the tag of the unit or application on which the
+	// action is enqueued.
+	Receiver names.Tag
+
+	// Name is the name of the action to enqueue.
+	Name string
+
+	// Parameters holds the action's parameters, if any.
+	Parameters map[string]interface{}
+
+	// Spec is the cron-like schedule of a recurring action, as
+	// understood by actions.ParseSchedule.
+	Spec string
+
+	// At is the time at which an action that is run once is enqueued.
+	At time.Time
+}
+
+// AddActionSchedule adds a schedule for enqueueing an action on a unit,
+// or on every unit of an application.
+func (m *Model) AddActionSchedule(args ActionScheduleArgs) (*ActionSchedule, error) {
+	if args.Name == "" {
+		return nil, errors.NotValidf("empty action name")
+	}
+	var receiverColl string
+	switch tag := args.Receiver.(type) {
+	case names.UnitTag:
+		receiverColl = unitsC
+	case names.ApplicationTag:
+		receiverColl = applicationsC
+	case nil:
+		return nil, errors.NotValidf("nil receiver")
+	default:
+		return nil, errors.NotValidf("receiver %q", tag)
+	}
+	if (args.Spec == "") == args.At.IsZero() {
+		return nil, errors.NotValidf("schedule without exactly one of spec and time")
+	}
+	now := m.st.nowToTheSecond()
+	nextRun := args.At.UTC().Round(time.Second)
+	if args.Spec != "" {
+		schedule, err := actions.ParseSchedule(args.Spec)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		nextRun = schedule.Next(now)
+		if nextRun.IsZero() {
+			return nil, errors.NotValidf("schedule %q that never runs", args.Spec)
+		}
+	}
+	if err := m.checkScheduledActionName(args.Receiver, args.Name); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	seq, err := sequence(m.st, "actionschedule")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	doc := actionScheduleDoc{
+		DocId:      m.st.docID(id),
+		Id:         id,
+		ModelUUID:  m.st.ModelUUID(),
+		Receiver:   args.Receiver.String(),
+		Name:       args.Name,
+		Parameters: args.Parameters,
+		Spec:       args.Spec,
+		NextRun:    nextRun,
+		Created:    now,
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		if err := checkModelActive(m.st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if alive, err := isAlive(m.st, receiverColl, args.Receiver.Id()); err != nil {
+			return nil, errors.Trace(err)
+		} else if !alive {
+			return nil, errors.Errorf("%s is not alive", names.ReadableString(args.Receiver))
+		}
+		return []txn.Op{{
+			C:      receiverColl,
+			Id:     args.Receiver.Id(),
+			Assert: isAliveDoc,
+		}, {
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}, m.assertActiveOp()}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "cannot add action schedule")
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// checkScheduledActionName returns an error if the named action is not
+// defined by the receiver's charm, and is not predefined by juju, so
+// that mistakes are reported when the schedule is added rather than
+// each time it runs.
+func (m *Model) checkScheduledActionName(receiver names.Tag, name string) error {
+	if _, ok := actions.PredefinedActionsSpec[name]; ok {
+		return nil
+	}
+	var appName string
+	switch receiver := receiver.(type) {
+	case names.UnitTag:
+		unit, err := m.st.Unit(receiver.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		appName = unit.ApplicationName()
+	case names.ApplicationTag:
+		appName = receiver.Id()
+	}
+	app, err := m.st.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ch.Actions() != nil {
+		if _, ok := ch.Actions().ActionSpecs[name]; ok {
+			return nil
+		}
+	}
+	return errors.NotValidf("action %q for %s", name, names.ReadableString(receiver))
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (m *Model) ActionSchedule(id string) (*ActionSchedule, error) {
+	coll, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// AllActionSchedules returns all the action schedules in the model,
+// ordered by the time they are next due.
+func (m *Model) AllActionSchedules() ([]*ActionSchedule, error) {
+	return m.actionSchedules(nil)
+}
+
+func (m *Model) actionSchedules(query bson.D) ([]*ActionSchedule, error) {
+	coll, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := coll.Find(query).Sort("next-run").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	result := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		result[i] = &ActionSchedule{st: m.st, doc: doc}
+	}
+	return result, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given id.
+// Actions already enqueued by the schedule are not affected.
+func (m *Model) RemoveActionSchedule(id string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     m.st.docID(id),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := m.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", id)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", id)
+	}
+	return nil
+}
+
+// RunDueActionSchedules enqueues the actions of all schedules that are
+// due. One-off schedules are removed once run, and recurring schedules
+// are advanced to their next run time, before their actions are
+// enqueued; an action is never enqueued more than once for the same
+// run, even if enqueueing it fails. Schedules whose receiver no longer
+// exists are removed.
+func (m *Model) RunDueActionSchedules() error {
+	now := m.st.nowToTheSecond()
+	due, err := m.actionSchedules(bson.D{{"next-run", bson.D{{"$lte", now}}}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, schedule := range due {
+		claimed, err := schedule.advance(now)
+		if err != nil {
+			return errors.Annotatef(err, "advancing action schedule %q", schedule.Id())
+		}
+		if !claimed {
+			continue
+		}
+		if err := schedule.enqueue(); errors.IsNotFound(err) {
+			actionLogger.Infof("removing action schedule %q: %v", schedule.Id(), err)
+			if err := m.RemoveActionSchedule(schedule.Id()); err != nil && !errors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
+		} else if err != nil {
+			actionLogger.Errorf("cannot enqueue scheduled action %q on %s: %v",
+				schedule.Name(), names.ReadableString(schedule.Receiver()), err)
+		}
+	}
+	return nil
+}
+
+// advance removes a one-off schedule, or sets the next run time of a
+// recurring schedule, as long as it has not changed since the schedule
+// was read. It returns whether the schedule's current run was claimed.
+func (s *ActionSchedule) advance(now time.Time) (bool, error) {
+	op := txn.Op{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: bson.D{{"next-run", s.doc.NextRun}},
+	}
+	if s.doc.Spec == "" {
+		op.Remove = true
+	} else {
+		schedule, err := actions.ParseSchedule(s.doc.Spec)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		next := schedule.Next(now)
+		op.Update = bson.D{{"$set", bson.D{
+			{"next-run", next},
+			{"last-run", now},
+		}}}
+		s.doc.NextRun, s.doc.LastRun = next, now
+	}
+	err := s.st.db().RunTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		// The schedule was run or removed concurrently.
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// enqueue enqueues the scheduled action on its receiver. If the
+// receiver is an application, the action is enqueued on each of its
+// units, and the first error encountered is returned.
+func (s *ActionSchedule) enqueue() error {
+	var units []*Unit
+	switch receiver := s.Receiver().(type) {
+	case names.UnitTag:
+		unit, err := s.st.Unit(receiver.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		units = []*Unit{unit}
+	case names.ApplicationTag:
+		app, err := s.st.Application(receiver.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if units, err = app.AllUnits(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	var firstErr error
+	for _, unit := range units {
+		if _, err := unit.AddAction(s.doc.Name, s.doc.Parameters); err != nil && firstErr == nil {
+			// Not annotated, so that a unit that has just been
+			// removed is not mistaken for a missing receiver.
+			firstErr = errors.Errorf("enqueueing on %s: %v", unit.Name(), err)
+		}
+	}
+	return firstErr
+}
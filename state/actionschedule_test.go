@@ -0,0 +1,188 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	application *state.Application
+	unit        *state.Unit
+	unit2       *state.Unit
+	model       *state.Model
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingApplication(c, "dummy", ch)
+	var err error
+	s.unit, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit2, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleRecurring(c *gc.C) {
+	schedule, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver:   s.unit.Tag(),
+		Name:       "snapshot",
+		Parameters: map[string]interface{}{"outfile": "foo.tgz"},
+		Spec:       "@hourly",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Receiver(), gc.Equals, s.unit.Tag())
+	c.Assert(schedule.Name(), gc.Equals, "snapshot")
+	c.Assert(schedule.Spec(), gc.Equals, "@hourly")
+	now := s.Clock.Now().UTC()
+	next := now.Truncate(time.Hour).Add(time.Hour)
+	c.Assert(schedule.NextRun().Equal(next), jc.IsTrue)
+	c.Assert(schedule.LastRun().IsZero(), jc.IsTrue)
+
+	obtained, err := s.model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained.Name(), gc.Equals, "snapshot")
+	c.Assert(obtained.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.tgz"})
+	c.Assert(obtained.NextRun().Equal(next), jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	at := s.Clock.Now().Add(time.Hour)
+	for i, test := range []struct {
+		args state.ActionScheduleArgs
+		err  string
+	}{{
+		args: state.ActionScheduleArgs{Receiver: s.unit.Tag(), Spec: "@daily"},
+		err:  "empty action name not valid",
+	}, {
+		args: state.ActionScheduleArgs{Receiver: names.NewMachineTag("0"), Name: "snapshot", Spec: "@daily"},
+		err:  `receiver "machine-0" not valid`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: s.unit.Tag(), Name: "snapshot"},
+		err:  "schedule without exactly one of spec and time not valid",
+	}, {
+		args: state.ActionScheduleArgs{Receiver: s.unit.Tag(), Name: "snapshot", Spec: "@daily", At: at},
+		err:  "schedule without exactly one of spec and time not valid",
+	}, {
+		args: state.ActionScheduleArgs{Receiver: s.unit.Tag(), Name: "snapshot", Spec: "0 0 30 2 *"},
+		err:  `schedule "0 0 30 2 \*" that never runs not valid`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: s.unit.Tag(), Name: "snapshot", Spec: "@often"},
+		err:  `schedule "@often": expected 5 fields, got 1 not valid`,
+	}, {
+		args: state.ActionScheduleArgs{Receiver: s.unit.Tag(), Name: "fly", At: at},
+		err:  `action "fly" for unit dummy/0 not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.model.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+	schedules, err := s.model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleMissingReceiver(c *gc.C) {
+	_, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: names.NewApplicationTag("nope"),
+		Name:     "snapshot",
+		Spec:     "@daily",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestRemoveActionSchedule(c *gc.C) {
+	schedule, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: s.application.Tag(),
+		Name:     "snapshot",
+		Spec:     "@daily",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.model.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.model.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, gc.ErrorMatches, `action schedule "1" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedules(c *gc.C) {
+	oneOff, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: s.unit.Tag(),
+		Name:     "snapshot",
+		At:       s.Clock.Now().Add(30 * time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	recurring, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: s.application.Tag(),
+		Name:     "snapshot",
+		Spec:     "0 * * * *",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing is due yet.
+	err = s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPendingActions(c, 0, 0)
+
+	s.Clock.Advance(time.Hour)
+	err = s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPendingActions(c, 2, 1)
+
+	_, err = s.model.ActionSchedule(oneOff.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	obtained, err := s.model.ActionSchedule(recurring.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained.LastRun().Equal(s.Clock.Now().Truncate(time.Second)), jc.IsTrue)
+	c.Assert(obtained.NextRun().After(s.Clock.Now()), jc.IsTrue)
+
+	// Running again before the next run enqueues nothing more.
+	err = s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPendingActions(c, 2, 1)
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesRemovesMissingReceiver(c *gc.C) {
+	schedule, err := s.model.AddActionSchedule(state.ActionScheduleArgs{
+		Receiver: s.unit2.Tag(),
+		Name:     "snapshot",
+		Spec:     "@hourly",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit2.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit2.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(time.Hour)
+	err = s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) assertPendingActions(c *gc.C, unitCount, unit2Count int) {
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actions, gc.HasLen, unitCount)
+	actions, err = s.unit2.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actions, gc.HasLen, unit2Count)
+}
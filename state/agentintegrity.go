@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// AgentIntegrityStatus describes the outcome of an agent checking the
+// integrity of its own binary.
+type AgentIntegrityStatus string
+
+const (
+	// AgentIntegrityVerified indicates that the agent binary matched
+	// the tools metadata recorded by the controller.
+	AgentIntegrityVerified AgentIntegrityStatus = "verified"
+
+	// AgentIntegrityUnverified indicates that the agent could not
+	// check its binary, for example because the binary was unpacked
+	// by an older agent which did not record its hash.
+	AgentIntegrityUnverified AgentIntegrityStatus = "unverified"
+
+	// AgentIntegrityTampered indicates that the agent binary did not
+	// match the tools metadata recorded by the controller.
+	AgentIntegrityTampered AgentIntegrityStatus = "tampered"
+)
+
+// Valid returns an error if the status is not one of the known
+// agent integrity statuses.
+func (s AgentIntegrityStatus) Valid() error {
+	switch s {
+	case AgentIntegrityVerified, AgentIntegrityUnverified, AgentIntegrityTampered:
+		return nil
+	}
+	return errors.NotValidf("agent integrity status %q", s)
+}
+
+// AgentIntegrity holds the most recent binary integrity report of
+// a machine or unit agent.
+type AgentIntegrity struct {
+	// Tag is the tag of the reporting agent.
+	Tag names.Tag
+
+	// Version is the version of the agent binary that was checked.
+	Version version.Binary
+
+	// Status is the outcome of the check.
+	Status AgentIntegrityStatus
+
+	// Message holds further details of the outcome, if any.
+	Message string
+
+	// Updated is the time at which the report was recorded.
+	Updated time.Time
+}
+
+// agentIntegrityDoc represents the MongoDB document that stores the
+// most recent integrity report of an agent.
+type agentIntegrityDoc struct {
+	Entity  string `bson:"entity"`
+	Version string `bson:"version"`
+	Status  string `bson:"status"`
+	Message string `bson:"message,omitempty"`
+	Updated int64  `bson:"updated"`
+}
+
+func (doc agentIntegrityDoc) toAgentIntegrity() (AgentIntegrity, error) {
+	tag, err := names.ParseTag(doc.Entity)
+	if err != nil {
+		return AgentIntegrity{}, errors.Trace(err)
+	}
+	vers, err := version.ParseBinary(doc.Version)
+	if err != nil {
+		return AgentIntegrity{}, errors.Trace(err)
+	}
+	return AgentIntegrity{
+		Tag:     tag,
+		Version: vers,
+		Status:  AgentIntegrityStatus(doc.Status),
+		Message: doc.Message,
+		Updated: time.Unix(0, doc.Updated).UTC(),
+	}, nil
+}
+
+// SetAgentIntegrity records the integrity report of the agent with
+// the given tag, replacing any previous report for the agent.
+func (st *State) SetAgentIntegrity(tag names.Tag, vers version.Binary, status AgentIntegrityStatus, message string) error {
+	if err := status.Valid(); err != nil {
+		return errors.Trace(err)
+	}
+	id, err := agentTagToGlobalKey(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	doc := agentIntegrityDoc{
+		Entity:  tag.String(),
+		Version: vers.String(),
+		Status:  string(status),
+		Message: message,
+		Updated: st.clock().Now().UnixNano(),
+	}
+	err = st.db().RunTransaction([]txn.Op{
+		{
+			C:      agentIntegrityC,
+			Id:     id,
+			Insert: doc,
+		}, {
+			C:      agentIntegrityC,
+			Id:     id,
+			Update: bson.M{"$set": doc},
+		},
+	})
+	return errors.Annotatef(err, "cannot set integrity of %s", names.ReadableString(tag))
+}
+
+// AgentIntegrity returns the most recent integrity report of the agent
+// with the given tag.
+func (st *State) AgentIntegrity(tag names.Tag) (AgentIntegrity, error) {
+	id, err := agentTagToGlobalKey(tag)
+	if err != nil {
+		return AgentIntegrity{}, errors.Trace(err)
+	}
+	coll, closer := st.db().GetCollection(agentIntegrityC)
+	defer closer()
+
+	var doc agentIntegrityDoc
+	err = coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return AgentIntegrity{}, errors.NotFoundf("integrity report for %s", names.ReadableString(tag))
+	} else if err != nil {
+		return AgentIntegrity{}, errors.Annotate(err, "integrity report lookup failed")
+	}
+	return doc.toAgentIntegrity()
+}
+
+// AllAgentIntegrity returns the most recent integrity reports of all
+// agents in the model.
+func (st *State) AllAgentIntegrity() ([]AgentIntegrity, error) {
+	coll, closer := st.db().GetCollection(agentIntegrityC)
+	defer closer()
+
+	var docs []agentIntegrityDoc
+	if err := coll.Find(nil).Sort("entity").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read integrity reports")
+	}
+	results := make([]AgentIntegrity, len(docs))
+	for i, doc := range docs {
+		result, err := doc.toAgentIntegrity()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+// removeAgentIntegrityOp returns the operation needed to remove the
+// integrity report associated with the given agent globalKey.
+func removeAgentIntegrityOp(globalKey string) txn.Op {
+	return txn.Op{
+		C:      agentIntegrityC,
+		Id:     globalKey,
+		Remove: true,
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type AgentIntegritySuite struct {
+	ConnSuite
+	machine *state.Machine
+	vers    version.Binary
+}
+
+var _ = gc.Suite(&AgentIntegritySuite{})
+
+func (s *AgentIntegritySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+	s.vers = version.MustParseBinary("2.3.0-xenial-amd64")
+}
+
+func (s *AgentIntegritySuite) TestNotFound(c *gc.C) {
+	_, err := s.State.AgentIntegrity(s.machine.MachineTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `integrity report for machine 0 not found`)
+}
+
+func (s *AgentIntegritySuite) TestSetGet(c *gc.C) {
+	tag := s.machine.MachineTag()
+	err := s.State.SetAgentIntegrity(tag, s.vers, state.AgentIntegrityVerified, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAgentIntegrity(tag, s.vers, state.AgentIntegrityTampered, "jujud sha256 mismatch")
+	c.Assert(err, jc.ErrorIsNil)
+
+	report, err := s.State.AgentIntegrity(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Tag, gc.Equals, names.Tag(tag))
+	c.Assert(report.Version, gc.Equals, s.vers)
+	c.Assert(report.Status, gc.Equals, state.AgentIntegrityTampered)
+	c.Assert(report.Message, gc.Equals, "jujud sha256 mismatch")
+	c.Assert(report.Updated.IsZero(), jc.IsFalse)
+}
+
+func (s *AgentIntegritySuite) TestSetInvalid(c *gc.C) {
+	err := s.State.SetAgentIntegrity(s.machine.MachineTag(), s.vers, "fine", "")
+	c.Assert(err, gc.ErrorMatches, `agent integrity status "fine" not valid`)
+
+	err = s.State.SetAgentIntegrity(names.NewApplicationTag("wordpress"), s.vers, state.AgentIntegrityVerified, "")
+	c.Assert(err, gc.ErrorMatches, `application-wordpress is not an agent tag`)
+}
+
+func (s *AgentIntegritySuite) TestAllAgentIntegrity(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: s.machine})
+	err := s.State.SetAgentIntegrity(unit.UnitTag(), s.vers, state.AgentIntegrityUnverified, "no recorded hash")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAgentIntegrity(s.machine.MachineTag(), s.vers, state.AgentIntegrityVerified, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Reports from other models are not included.
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	otherMachine := factory.NewFactory(otherState).MakeMachine(c, nil)
+	err = otherState.SetAgentIntegrity(otherMachine.MachineTag(), s.vers, state.AgentIntegrityTampered, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err := s.State.AllAgentIntegrity()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, gc.HasLen, 2)
+	c.Assert(reports[0].Tag, gc.Equals, names.Tag(s.machine.MachineTag()))
+	c.Assert(reports[0].Status, gc.Equals, state.AgentIntegrityVerified)
+	c.Assert(reports[1].Tag, gc.Equals, names.Tag(unit.UnitTag()))
+	c.Assert(reports[1].Status, gc.Equals, state.AgentIntegrityUnverified)
+	c.Assert(reports[1].Message, gc.Equals, "no recorded hash")
+}
+
+func (s *AgentIntegritySuite) TestRemovedWithMachine(c *gc.C) {
+	tag := s.machine.MachineTag()
+	err := s.State.SetAgentIntegrity(tag, s.vers, state.AgentIntegrityVerified, "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.machine.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(s.machine.Remove(), jc.ErrorIsNil)
+
+	_, err = s.State.AgentIntegrity(tag)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
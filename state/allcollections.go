@@ -11,10 +11,14 @@ import (
 
 // The capped collection used for transaction logs defaults to 10MB.
 // It's tweaked in export_test.go to 1MB to avoid the overhead of
-// creating and deleting the large file repeatedly in tests.
+// creating and deleting the large file repeatedly in tests, as is
+// the capped collection holding the audit log.
 var (
 	txnLogSize      = 10000000
 	txnLogSizeTests = 1000000
+
+	auditLogSize      = 100 * 1024 * 1024
+	auditLogSizeTests = 1000000
 )
 
 // allCollections should be the single source of truth for information about
@@ -85,6 +89,13 @@ func allCollections() collectionSchema {
 		// everything in state.
 		controllersC: {global: true},
 
+		// This collection holds the most recently recorded load on
+		// each controller machine's API server.
+		apiServerLoadC: {
+			global:    true,
+			rawAccess: true,
+		},
+
 		// This collection is used to track progress when restoring a
 		// controller from backup.
 		restoreInfoC: {global: true},
@@ -205,6 +216,10 @@ func allCollections() collectionSchema {
 			rawAccess: true,
 		},
 
+		// This collection holds the webhooks that are consulted before
+		// config changes are committed in any model.
+		configWebhooksC: {global: true},
+
 		// This collection holds the last time the model user connected
 		// to the model.
 		modelUserLastConnectionC: {
@@ -298,6 +313,10 @@ func allCollections() collectionSchema {
 		rebootC:      {},
 		sshHostKeysC: {},
 
+		// This collection holds the most recent binary integrity
+		// report of each machine and unit agent.
+		agentIntegrityC: {},
+
 		// This collection contains information from removed machines
 		// that needs to be cleaned up in the provider.
 		machineRemovalsC: {},
@@ -364,6 +383,30 @@ func allCollections() collectionSchema {
 		},
 		actionNotificationsC: {},
 
+		// actionSchedulesC holds the schedules on which actions are
+		// enqueued, once or repeatedly.
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "next-run"},
+			}},
+		},
+
+		// actionRolloutsC holds the state of actions run across the
+		// units of an application in batches.
+		actionRolloutsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "status"},
+			}},
+		},
+
+		// branchesC holds application config changes that are staged
+		// for a subset of units before being committed.
+		branchesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "created"},
+			}},
+		},
+
 		// -----
 
 		// This collection holds information associated with charm payloads.
@@ -417,6 +460,41 @@ func allCollections() collectionSchema {
 			}},
 		},
 
+		// charmHistoryC holds the recent changes to the charm and
+		// config of each application.
+		charmHistoryC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application", "-time"},
+			}},
+		},
+
+		// podSpecsC holds the current Kubernetes pod spec, scale and
+		// rollout status of applications in Kubernetes models.
+		podSpecsC: {},
+
+		// podSpecHistoryC holds the recent revisions of the pod spec
+		// of each application.
+		podSpecHistoryC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application", "-revision"},
+			}},
+		},
+
+		// cloudContainersC holds the status of the containers running
+		// units' workloads in Kubernetes models.
+		cloudContainersC: {},
+
+		// healthCheckHistoryC holds the recent results of the health
+		// checks declared by units' charms.
+		healthCheckHistoryC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "-time"},
+			}},
+		},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
 			global: true,
@@ -455,6 +533,22 @@ func allCollections() collectionSchema {
 		// firewallRulesC holds firewall rules for defined service types.
 		firewallRulesC: {},
 
+		// egressRulesC holds the egress rules restricting the outbound
+		// traffic of applications.
+		egressRulesC: {},
+
+		// modelCheckpointsC holds named records of the applications,
+		// charm config and relations in a model.
+		modelCheckpointsC: {},
+
+		// charmStateSnapshotsC holds the charm-local state exported by
+		// units, so that it can be restored on replacement units.
+		charmStateSnapshotsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application"},
+			}},
+		},
+
 		// ----------------------
 
 		// Raw-access collections
@@ -462,9 +556,34 @@ func allCollections() collectionSchema {
 
 		// metrics; status-history; logs; ..?
 
+		// This collection holds the outcomes of API calls made with an
+		// idempotency key, so that a client can safely resend a call
+		// after losing its connection. Entries expire once the client
+		// can no longer be retrying the call.
+		idempotentCallsC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key:         []string{"created"},
+				ExpireAfter: idempotentCallExpiry,
+			}},
+		},
+
+		// This collection holds the outcome of the most recent pruning
+		// of each of a model's pruned collections.
+		pruneStatsC: {
+			rawAccess: true,
+		},
+
+		// This collection holds the audit trail of API requests. It is
+		// capped, so that the oldest entries are discarded once it is
+		// full, and entries can be read back in the order recorded.
 		auditingC: {
 			global:    true,
 			rawAccess: true,
+			explicitCreate: &mgo.CollectionInfo{
+				Capped:   true,
+				MaxBytes: auditLogSize,
+			},
 		},
 	}
 	return result
@@ -477,19 +596,27 @@ func allCollections() collectionSchema {
 const (
 	actionNotificationsC     = "actionnotifications"
 	actionresultsC           = "actionresults"
+	actionRolloutsC          = "actionRollouts"
+	actionSchedulesC         = "actionSchedules"
 	actionsC                 = "actions"
+	agentIntegrityC          = "agentIntegrity"
 	annotationsC             = "annotations"
+	apiServerLoadC           = "apiServerLoad"
 	autocertCacheC           = "autocertCache"
 	assignUnitC              = "assignUnits"
 	auditingC                = "audit.log"
 	bakeryStorageItemsC      = "bakeryStorageItems"
 	blockDevicesC            = "blockdevices"
 	blocksC                  = "blocks"
+	branchesC                = "branches"
+	charmHistoryC            = "charmHistory"
 	charmsC                  = "charms"
 	cleanupsC                = "cleanups"
 	cloudimagemetadataC      = "cloudimagemetadata"
 	cloudsC                  = "clouds"
+	cloudContainersC         = "cloudContainers"
 	cloudCredentialsC        = "cloudCredentials"
+	configWebhooksC          = "configWebhooks"
 	constraintsC             = "constraints"
 	containerRefsC           = "containerRefs"
 	controllersC             = "controllers"
@@ -500,6 +627,7 @@ const (
 	globalSettingsC          = "globalSettings"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
+	idempotentCallsC         = "idempotentCalls"
 	instanceDataC            = "instanceData"
 	leasesC                  = "leases"
 	machinesC                = "machines"
@@ -519,7 +647,10 @@ const (
 	openedPortsC             = "openedPorts"
 	payloadsC                = "payloads"
 	permissionsC             = "permissions"
+	podSpecsC                = "podSpecs"
+	podSpecHistoryC          = "podSpecHistory"
 	providerIDsC             = "providerIDs"
+	pruneStatsC              = "pruneStats"
 	rebootC                  = "reboot"
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
@@ -533,6 +664,7 @@ const (
 	spacesC                  = "spaces"
 	statusesC                = "statuses"
 	statusesHistoryC         = "statuseshistory"
+	healthCheckHistoryC      = "healthCheckHistory"
 	storageAttachmentsC      = "storageattachments"
 	storageConstraintsC      = "storageconstraints"
 	storageInstancesC        = "storageinstances"
@@ -560,4 +692,9 @@ const (
 	externalControllersC = "externalControllers"
 	relationNetworksC    = "relationNetworks"
 	firewallRulesC       = "firewallRules"
+	egressRulesC         = "egressRules"
+
+	modelCheckpointsC = "modelCheckpoints"
+
+	charmStateSnapshotsC = "charmStateSnapshots"
 )
@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
@@ -21,6 +22,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/status"
 )
@@ -50,6 +52,19 @@ type applicationDoc struct {
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+
+	// Labels holds the key/value labels used to select groups of
+	// applications for bulk operations.
+	Labels map[string]string `bson:"labels,omitempty"`
+
+	// CharmUpgrade holds the application's most recent rolling
+	// charm upgrade, if any.
+	CharmUpgrade *charmUpgradeDoc `bson:"charm-upgrade,omitempty"`
+
+	// StopGracePeriod is how long the application's units are given
+	// to run their stop hooks when they are destroyed, before the
+	// teardown of their machines proceeds regardless.
+	StopGracePeriod time.Duration `bson:"stop-grace-period,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -324,6 +339,13 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	}
 	ops = append(ops, removeOfferOps...)
 
+	// Remove charm state snapshots exported by the application's units.
+	snapshotOps, err := removeCharmStateSnapshotsOps(a.st, a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, snapshotOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
@@ -347,6 +369,8 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeLeadershipSettingsOp(name),
 		removeStatusOp(a.st, globalKey),
 		removeModelApplicationRefOp(a.st, name),
+		removeEgressRuleOp(name),
+		removePodSpecOp(a.st, name),
 	)
 	return ops, nil
 }
@@ -384,6 +408,75 @@ func (a *Application) setExposed(exposed bool) (err error) {
 	return nil
 }
 
+// Labels returns the application's labels.
+func (a *Application) Labels() map[string]string {
+	labels := make(map[string]string, len(a.doc.Labels))
+	for key, value := range a.doc.Labels {
+		labels[key] = value
+	}
+	return labels
+}
+
+// SetLabels replaces the application's labels with those given.
+// See State.ApplicationsMatching.
+func (a *Application) SetLabels(labels map[string]string) error {
+	if err := coreapplication.ValidateLabels(labels); err != nil {
+		return errors.Trace(err)
+	}
+	update := bson.D{{"$set", bson.D{{"labels", labels}}}}
+	if len(labels) == 0 {
+		update = bson.D{{"$unset", bson.D{{"labels", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set labels for application %q: %v", a, onAbort(err, errNotAlive))
+	}
+	a.doc.Labels = nil
+	if len(labels) > 0 {
+		a.doc.Labels = make(map[string]string, len(labels))
+		for key, value := range labels {
+			a.doc.Labels[key] = value
+		}
+	}
+	return nil
+}
+
+// StopGracePeriod returns how long the application's units are given
+// to stop when they are destroyed. Zero means that units wait for their
+// stop hooks to succeed, however long that takes.
+func (a *Application) StopGracePeriod() time.Duration {
+	return a.doc.StopGracePeriod
+}
+
+// SetStopGracePeriod sets how long the application's units are given
+// to stop when they are destroyed. It does not affect units that are
+// already dying.
+func (a *Application) SetStopGracePeriod(period time.Duration) error {
+	if period < 0 {
+		return errors.NotValidf("negative stop grace period %v", period)
+	}
+	update := bson.D{{"$set", bson.D{{"stop-grace-period", period}}}}
+	if period == 0 {
+		update = bson.D{{"$unset", bson.D{{"stop-grace-period", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set stop grace period for application %q: %v", a, onAbort(err, errNotAlive))
+	}
+	a.doc.StopGracePeriod = period
+	return nil
+}
+
 // Charm returns the application's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (a *Application) Charm() (ch *Charm, force bool, err error) {
@@ -936,6 +1029,15 @@ type SetCharmConfig struct {
 	// unaffected; the storage constraints will only be used for
 	// provisioning new storage instances.
 	StorageConstraints map[string]StorageConstraints
+
+	// RollingUpgrade, if not nil, upgrades the existing units in
+	// batches rather than all at once. Otherwise any rolling upgrade
+	// in progress is abandoned, and all units are upgraded.
+	RollingUpgrade *RollingCharmUpgrade
+
+	// ChangedBy is the name of the user changing the charm, which
+	// is recorded in the application's charm history.
+	ChangedBy string
 }
 
 // SetCharm changes the charm for the application.
@@ -999,8 +1101,14 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	if err != nil {
 		return errors.Annotate(err, "validating config settings")
 	}
+	if cfg.RollingUpgrade != nil {
+		if err := cfg.RollingUpgrade.Validate(cfg.Charm); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	var newCharmModifiedVersion int
+	var newCharmUpgrade *charmUpgradeDoc
 	channel := string(cfg.Channel)
 	acopy := &Application{a.st, a.doc}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1025,6 +1133,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		// structure. We increment the version only when we change the
 		// charm URL.
 		newCharmModifiedVersion = a.doc.CharmModifiedVersion
+		newCharmUpgrade = a.doc.CharmUpgrade
 
 		ops := []txn.Op{{
 			C:  applicationsC,
@@ -1057,7 +1166,19 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 				return nil, errors.Trace(err)
 			}
 			ops = append(ops, chng...)
+			branchOps, err := a.unstagedBranchConfigOps()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, branchOps...)
 			newCharmModifiedVersion++
+
+			upgradeOps, upgrade, err := a.charmUpgradeOps(cfg)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, upgradeOps...)
+			newCharmUpgrade = upgrade
 		}
 
 		return ops, nil
@@ -1065,10 +1186,17 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	if err := a.st.db().Run(buildTxn); err != nil {
 		return err
 	}
+	charmChanged := newCharmModifiedVersion != a.doc.CharmModifiedVersion
 	a.doc.CharmURL = cfg.Charm.URL()
 	a.doc.Channel = channel
 	a.doc.ForceCharm = cfg.ForceUnits
 	a.doc.CharmModifiedVersion = newCharmModifiedVersion
+	a.doc.CharmUpgrade = newCharmUpgrade
+	if charmChanged {
+		if err := a.recordCharmHistory(cfg.ChangedBy); err != nil {
+			logger.Errorf("cannot record charm history for application %q: %v", a.doc.Name, err)
+		}
+	}
 	return nil
 }
 
@@ -1549,7 +1677,9 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, u.globalAgentKey()),
 		removeStatusOp(a.st, u.globalKey()),
 		removeConstraintsOp(u.globalAgentKey()),
+		removeAgentIntegrityOp(u.globalAgentKey()),
 		annotationRemoveOp(a.st, u.globalKey()),
+		removeCloudContainerOp(a.st, u.doc.Name),
 		newCleanupOp(cleanupRemovedUnit, u.doc.Name),
 	)
 	ops = append(ops, portsOps...)
@@ -1665,6 +1795,13 @@ func (a *Application) ConfigSettings() (charm.Settings, error) {
 // UpdateConfigSettings changes a application's charm config settings. Values set
 // to nil will be deleted; unknown and invalid values will return an error.
 func (a *Application) UpdateConfigSettings(changes charm.Settings) error {
+	return a.UpdateConfigSettingsBy(changes, "")
+}
+
+// UpdateConfigSettingsBy changes the application's charm config
+// settings as UpdateConfigSettings does, recording the named user as
+// having made the change in the application's charm history.
+func (a *Application) UpdateConfigSettingsBy(changes charm.Settings, user string) error {
 	charm, _, err := a.Charm()
 	if err != nil {
 		return err
@@ -1688,8 +1825,16 @@ func (a *Application) UpdateConfigSettings(changes charm.Settings) error {
 			node.Set(name, value)
 		}
 	}
-	_, err = node.Write()
-	return err
+	itemChanges, err := node.Write()
+	if err != nil {
+		return err
+	}
+	if len(itemChanges) > 0 {
+		if err := a.recordCharmHistory(user); err != nil {
+			logger.Errorf("cannot record charm history for application %q: %v", a.doc.Name, err)
+		}
+	}
+	return nil
 }
 
 // LeaderSettings returns a application's leader settings. If nothing has been set
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -19,6 +20,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
@@ -1770,6 +1772,114 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestLabels(c *gc.C) {
+	c.Assert(s.mysql.Labels(), gc.HasLen, 0)
+
+	labels := map[string]string{"tier": "backend", "team": "db"}
+	err := s.mysql.SetLabels(labels)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.Labels(), jc.DeepEquals, labels)
+
+	// The returned labels are a copy.
+	s.mysql.Labels()["tier"] = "frontend"
+	c.Assert(s.mysql.Labels(), jc.DeepEquals, labels)
+
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.Labels(), jc.DeepEquals, labels)
+
+	// Setting labels replaces the existing ones.
+	err = app.SetLabels(map[string]string{"tier": "frontend"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.Labels(), jc.DeepEquals, map[string]string{"tier": "frontend"})
+
+	err = s.mysql.SetLabels(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.Labels(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestSetLabelsInvalid(c *gc.C) {
+	err := s.mysql.SetLabels(map[string]string{"tier.name": "frontend"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `label key "tier.name" not valid`)
+}
+
+func (s *ApplicationSuite) TestSetLabelsNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetLabels(map[string]string{"tier": "backend"})
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
+
+func (s *ApplicationSuite) TestStopGracePeriod(c *gc.C) {
+	c.Assert(s.mysql.StopGracePeriod(), gc.Equals, time.Duration(0))
+
+	err := s.mysql.SetStopGracePeriod(5 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.StopGracePeriod(), gc.Equals, 5*time.Minute)
+
+	err = app.SetStopGracePeriod(0)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.StopGracePeriod(), gc.Equals, time.Duration(0))
+}
+
+func (s *ApplicationSuite) TestSetStopGracePeriodInvalid(c *gc.C) {
+	err := s.mysql.SetStopGracePeriod(-time.Second)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `negative stop grace period -1s not valid`)
+}
+
+func (s *ApplicationSuite) TestSetStopGracePeriodNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetStopGracePeriod(time.Minute)
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
+
+func (s *ApplicationSuite) TestApplicationsMatching(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	blog := s.AddTestingApplication(c, "blog", s.AddTestingCharm(c, "wordpress"))
+	err := wordpress.SetLabels(map[string]string{"tier": "frontend", "team": "web"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = blog.SetLabels(map[string]string{"tier": "frontend"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetLabels(map[string]string{"tier": "backend", "team": "web"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertMatching := func(selector coreapplication.Selector, expect ...string) {
+		apps, err := s.State.ApplicationsMatching(selector)
+		c.Assert(err, jc.ErrorIsNil)
+		var names []string
+		for _, app := range apps {
+			names = append(names, app.Name())
+		}
+		c.Check(names, jc.DeepEquals, expect)
+	}
+	assertMatching(coreapplication.Selector{"tier": "frontend"}, "blog", "wordpress")
+	assertMatching(coreapplication.Selector{"team": "web"}, "mysql", "wordpress")
+	assertMatching(coreapplication.Selector{"tier": "frontend", "team": "web"}, "wordpress")
+	assertMatching(coreapplication.Selector{"tier": "middle"})
+}
+
+func (s *ApplicationSuite) TestApplicationsMatchingInvalid(c *gc.C) {
+	_, err := s.State.ApplicationsMatching(nil)
+	c.Assert(err, gc.ErrorMatches, "empty selector not valid")
+	_, err = s.State.ApplicationsMatching(coreapplication.Selector{"tier.name": "x"})
+	c.Assert(err, gc.ErrorMatches, `label key "tier.name" not valid`)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit(state.AddUnitParams{})
@@ -2281,6 +2391,25 @@ func (s *ApplicationSuite) TestSetUnsupportedConstraintsWarning(c *gc.C) {
 	c.Assert(scons, gc.DeepEquals, cons)
 }
 
+func (s *ApplicationSuite) TestSetPublicIPAndImageConstraints(c *gc.C) {
+	cons := constraints.MustParse("allocate-public-ip=false image-id=ami-12345678")
+	err := s.mysql.SetConstraints(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	scons, err := s.mysql.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scons, jc.DeepEquals, cons)
+}
+
+func (s *ApplicationSuite) TestSetUnsupportedImageConstraint(c *gc.C) {
+	s.policy.GetConstraintsValidator = func() (constraints.Validator, error) {
+		validator := constraints.NewValidator()
+		validator.RegisterUnsupported([]string{constraints.ImageID})
+		return validator, nil
+	}
+	err := s.mysql.SetConstraints(constraints.MustParse("image-id=ami-12345678"))
+	c.Assert(err, gc.ErrorMatches, `conflicting constraints: "image-id" is not supported by this cloud`)
+}
+
 func (s *ApplicationSuite) TestConstraintsLifecycle(c *gc.C) {
 	// Dying.
 	unit, err := s.mysql.AddUnit(state.AddUnitParams{})
@@ -5,8 +5,10 @@ package state
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
@@ -52,6 +54,26 @@ type applicationOfferDoc struct {
 
 	// Endpoints are the charm endpoints supported by the applicationbob.
 	Endpoints map[string]string `bson:"endpoints"`
+
+	// IngressCIDRs are the networks from which consumers of the offer
+	// may connect.
+	IngressCIDRs []string `bson:"ingress-cidrs,omitempty"`
+
+	// Version is the number of times the offer has been migrated to a
+	// new application.
+	Version int `bson:"version,omitempty"`
+
+	// PendingMigration holds the details of a migration of the offer
+	// to a new application, if one has been started.
+	PendingMigration *offerMigrationDoc `bson:"pending-migration,omitempty"`
+}
+
+// offerMigrationDoc records a pending migration of an offer to a new
+// application.
+type offerMigrationDoc struct {
+	ApplicationName string            `bson:"application-name"`
+	Endpoints       map[string]string `bson:"endpoints"`
+	Deadline        int64             `bson:"deadline"`
 }
 
 var _ crossmodel.ApplicationOffers = (*applicationOffers)(nil)
@@ -218,6 +240,38 @@ func (s *applicationOffers) Remove(offerName string) (err error) {
 	return errors.Trace(s.st.db().Run(buildTxn))
 }
 
+// SetIngressNetworks sets the networks from which consumers of the named
+// offer may connect. An empty list allows any network. Relations that
+// have already been established are not affected.
+func (s *applicationOffers) SetIngressNetworks(offerName string, cidrs []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set ingress networks for application offer %q", offerName)
+
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	update := bson.D{{"$unset", bson.D{{"ingress-cidrs", nil}}}}
+	if len(cidrs) > 0 {
+		update = bson.D{{"$set", bson.D{{"ingress-cidrs", cidrs}}}}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := checkModelActive(s.st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := s.ApplicationOffer(offerName); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      applicationOffersC,
+			Id:     offerName,
+			Assert: txn.DocExists,
+			Update: update,
+		}}, nil
+	}
+	return errors.Trace(s.st.db().Run(buildTxn))
+}
+
 // removeApplicationOffersOps returns txn.Ops that will remove all offers for
 // the specified application. No assertions on the application or the offer
 // connections are made; the caller is responsible for ensuring that offer
@@ -374,6 +428,9 @@ func (s *applicationOffers) UpdateOffer(offerArgs crossmodel.AddApplicationOffer
 		// In either case, we return the error.
 		return nil, errors.Trace(err)
 	}
+	if offer.PendingMigration != nil && offerArgs.ApplicationName != offer.ApplicationName {
+		return nil, errors.Errorf("offer is being migrated to application %q", offer.PendingMigration.ApplicationName)
+	}
 	doc := s.makeApplicationOfferDoc(s.st, offer.OfferUUID, offerArgs)
 	var refOps []txn.Op
 	if offerArgs.ApplicationName != offer.ApplicationName {
@@ -417,7 +474,171 @@ func (s *applicationOffers) UpdateOffer(offerArgs crossmodel.AddApplicationOffer
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return s.makeApplicationOffer(doc)
+	// The ingress networks and migration details are left untouched
+	// by the update.
+	doc.IngressCIDRs = offer.IngressCIDRs
+	doc.Version = offer.Version
+	result, err := s.makeApplicationOffer(doc)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result.PendingMigration = offer.PendingMigration
+	return result, nil
+}
+
+// StartMigration records the pending migration of the named offer to a
+// new application. The offer continues to be provided by its current
+// application until CompleteMigration is called; in the meantime its
+// consumers are told of the migration and its deadline through the
+// offer's status.
+//
+// Every endpoint published by the offer must also be published by the
+// new application, with the same interface and role, so that relations
+// made by consumers remain valid.
+func (s *applicationOffers) StartMigration(offerName string, migration crossmodel.OfferMigration) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot migrate application offer %q", offerName)
+
+	if !names.IsValidApplication(migration.ApplicationName) {
+		return errors.NotValidf("application name %q", migration.ApplicationName)
+	}
+	if !migration.Deadline.After(s.st.clock().Now()) {
+		return errors.Errorf("deadline %s has already passed", migration.Deadline.Format(time.RFC3339))
+	}
+	doc := &offerMigrationDoc{
+		ApplicationName: migration.ApplicationName,
+		Endpoints:       migration.Endpoints,
+		Deadline:        migration.Deadline.UnixNano(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := checkModelActive(s.st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		offer, err := s.ApplicationOffer(offerName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if offer.PendingMigration != nil {
+			return nil, errors.AlreadyExistsf("migration to application %q", offer.PendingMigration.ApplicationName)
+		}
+		if migration.ApplicationName == offer.ApplicationName {
+			return nil, errors.Errorf("offer is already provided by application %q", offer.ApplicationName)
+		}
+		app, err := s.st.Application(migration.ApplicationName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q is not alive", app.Name())
+		}
+		eps, err := getApplicationEndpoints(app, migration.Endpoints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for alias, ep := range offer.Endpoints {
+			newEp, ok := eps[alias]
+			if !ok {
+				return nil, errors.Errorf("endpoint %q is not offered by application %q", alias, app.Name())
+			}
+			if newEp.Interface != ep.Interface || newEp.Role != ep.Role {
+				return nil, errors.Errorf(
+					"endpoint %q of application %q is %s %q, expected %s %q",
+					alias, app.Name(), newEp.Role, newEp.Interface, ep.Role, ep.Interface,
+				)
+			}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      applicationOffersC,
+			Id:     offerName,
+			Assert: bson.D{{"pending-migration", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"pending-migration", doc}}}},
+		}}, nil
+	}
+	return errors.Trace(s.st.db().Run(buildTxn))
+}
+
+// CompleteMigration switches the named offer over to the application
+// of its pending migration and increments the offer's version. New
+// relations to the offer are made with the new application; relations
+// made before the migration is completed are not moved, and remain
+// with the old application until they are removed.
+func (s *applicationOffers) CompleteMigration(offerName string) (_ *crossmodel.ApplicationOffer, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot complete migration of application offer %q", offerName)
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if err := checkModelActive(s.st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc, err := s.offerQuery(bson.D{{"_id", offerName}})
+		if err == mgo.ErrNotFound {
+			return nil, errors.NotFoundf("application offer %q", offerName)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		migration := doc.PendingMigration
+		if migration == nil {
+			return nil, errors.NotFoundf("pending migration")
+		}
+		incRefOp, err := incApplicationOffersRefOp(s.st, migration.ApplicationName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		decRefOp, err := decApplicationOffersRefOp(s.st, doc.ApplicationName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     s.st.docID(migration.ApplicationName),
+			Assert: isAliveDoc,
+		}, {
+			C:  applicationOffersC,
+			Id: offerName,
+			Assert: bson.D{
+				{"application-name", doc.ApplicationName},
+				{"pending-migration.application-name", migration.ApplicationName},
+				{"pending-migration.deadline", migration.Deadline},
+			},
+			Update: bson.D{
+				{"$set", bson.D{
+					{"application-name", migration.ApplicationName},
+					{"endpoints", migration.Endpoints},
+					{"version", doc.Version + 1},
+				}},
+				{"$unset", bson.D{{"pending-migration", nil}}},
+			},
+		}, incRefOp, decRefOp}, nil
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.ApplicationOffer(offerName)
+}
+
+// AbortMigration discards the pending migration of the named offer,
+// which continues to be provided by its current application.
+func (s *applicationOffers) AbortMigration(offerName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot abort migration of application offer %q", offerName)
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		offer, err := s.ApplicationOffer(offerName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if offer.PendingMigration == nil {
+			return nil, errors.NotFoundf("pending migration")
+		}
+		return []txn.Op{{
+			C:      applicationOffersC,
+			Id:     offerName,
+			Assert: bson.D{{"pending-migration", bson.D{{"$exists", true}}}},
+			Update: bson.D{{"$unset", bson.D{{"pending-migration", nil}}}},
+		}}, nil
+	}
+	return errors.Trace(s.st.db().Run(buildTxn))
 }
 
 func (s *applicationOffers) makeApplicationOfferDoc(mb modelBackend, uuid string, offer crossmodel.AddApplicationOfferArgs) applicationOfferDoc {
@@ -612,6 +833,15 @@ func (s *applicationOffers) makeApplicationOffer(doc applicationOfferDoc) (*cros
 		OfferUUID:              doc.OfferUUID,
 		ApplicationName:        doc.ApplicationName,
 		ApplicationDescription: doc.ApplicationDescription,
+		IngressCIDRs:           doc.IngressCIDRs,
+		Version:                doc.Version,
+	}
+	if m := doc.PendingMigration; m != nil {
+		offer.PendingMigration = &crossmodel.OfferMigration{
+			ApplicationName: m.ApplicationName,
+			Endpoints:       m.Endpoints,
+			Deadline:        time.Unix(0, m.Deadline).UTC(),
+		}
 	}
 	app, err := s.st.Application(doc.ApplicationName)
 	if err != nil {
@@ -659,6 +889,10 @@ func (st *State) WatchOfferStatus(offerUUID string) (NotifyWatcher, error) {
 		return nil, errors.Trace(err)
 	}
 	// TODO(wallyworld) - for now, the offer status is just the application status
+	// along with the details of any pending migration of the offer.
 	appKey := applicationGlobalKey(offer.ApplicationName)
-	return newEntityWatcher(st, statusesC, st.docID(appKey)), nil
+	return newDocWatcher(st, []docKey{
+		{statusesC, st.docID(appKey)},
+		{applicationOffersC, st.docID(offer.OfferName)},
+	}), nil
 }
@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -416,6 +418,55 @@ func (s *applicationOffersSuite) TestUpdateApplicationOffer(c *gc.C) {
 	assertOffersRef(c, s.State, "mysql", 1)
 }
 
+func (s *applicationOffersSuite) TestSetIngressNetworks(c *gc.C) {
+	offer := s.createDefaultOffer(c)
+	sd := state.NewApplicationOffers(s.State)
+	err := sd.SetIngressNetworks(offer.OfferName, []string{"10.0.0.0/8", "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	updated, err := sd.ApplicationOffer(offer.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.IngressCIDRs, jc.DeepEquals, []string{"10.0.0.0/8", "192.168.1.0/24"})
+
+	err = sd.SetIngressNetworks(offer.OfferName, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	updated, err = sd.ApplicationOffer(offer.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.IngressCIDRs, gc.HasLen, 0)
+}
+
+func (s *applicationOffersSuite) TestSetIngressNetworksInvalidCIDR(c *gc.C) {
+	offer := s.createDefaultOffer(c)
+	sd := state.NewApplicationOffers(s.State)
+	err := sd.SetIngressNetworks(offer.OfferName, []string{"10.0.0.0"})
+	c.Assert(err, gc.ErrorMatches, `cannot set ingress networks for application offer "hosted-mysql": CIDR "10.0.0.0" not valid`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *applicationOffersSuite) TestSetIngressNetworksOfferNotFound(c *gc.C) {
+	sd := state.NewApplicationOffers(s.State)
+	err := sd.SetIngressNetworks("missing", []string{"10.0.0.0/8"})
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *applicationOffersSuite) TestUpdateApplicationOfferKeepsIngressNetworks(c *gc.C) {
+	offer := s.createDefaultOffer(c)
+	sd := state.NewApplicationOffers(s.State)
+	err := sd.SetIngressNetworks(offer.OfferName, []string{"10.0.0.0/8"})
+	c.Assert(err, jc.ErrorIsNil)
+	owner := s.Factory.MakeUser(c, nil)
+	updated, err := sd.UpdateOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:              offer.OfferName,
+		ApplicationName:        "mysql",
+		ApplicationDescription: "a better database",
+		Owner:                  owner.Name(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.IngressCIDRs, jc.DeepEquals, []string{"10.0.0.0/8"})
+	stored, err := sd.ApplicationOffer(offer.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored.IngressCIDRs, jc.DeepEquals, []string{"10.0.0.0/8"})
+}
+
 func (s *applicationOffersSuite) TestUpdateApplicationOfferDifferentApp(c *gc.C) {
 	sd := state.NewApplicationOffers(s.State)
 	owner := s.Factory.MakeUser(c, nil)
@@ -569,3 +620,139 @@ func (s *applicationOffersSuite) TestWatchOfferStatus(c *gc.C) {
 	wc.AssertOneChange()
 	wc.AssertNoChange()
 }
+
+func (s *applicationOffersSuite) startDefaultMigration(c *gc.C) (crossmodel.ApplicationOffer, crossmodel.OfferMigration) {
+	offer := s.createDefaultOffer(c)
+	s.AddTestingApplication(c, "mysql2", s.AddTestingCharm(c, "mysql"))
+	migration := crossmodel.OfferMigration{
+		ApplicationName: "mysql2",
+		Endpoints:       map[string]string{"db": "server", "db-admin": "server-admin"},
+		Deadline:        s.Clock.Now().Add(time.Hour).UTC(),
+	}
+	err := state.NewApplicationOffers(s.State).StartMigration(offer.OfferName, migration)
+	c.Assert(err, jc.ErrorIsNil)
+	return offer, migration
+}
+
+func (s *applicationOffersSuite) TestStartMigration(c *gc.C) {
+	offer, migration := s.startDefaultMigration(c)
+	updated, err := state.NewApplicationOffers(s.State).ApplicationOffer(offer.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.ApplicationName, gc.Equals, "mysql")
+	c.Assert(updated.Version, gc.Equals, 0)
+	c.Assert(updated.PendingMigration, jc.DeepEquals, &migration)
+}
+
+func (s *applicationOffersSuite) TestStartMigrationAlreadyPending(c *gc.C) {
+	offer, migration := s.startDefaultMigration(c)
+	err := state.NewApplicationOffers(s.State).StartMigration(offer.OfferName, migration)
+	c.Assert(err, gc.ErrorMatches, `cannot migrate application offer "hosted-mysql": migration to application "mysql2" already exists`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *applicationOffersSuite) TestStartMigrationMissingEndpoint(c *gc.C) {
+	offer := s.createDefaultOffer(c)
+	s.AddTestingApplication(c, "mysql2", s.AddTestingCharm(c, "mysql"))
+	err := state.NewApplicationOffers(s.State).StartMigration(offer.OfferName, crossmodel.OfferMigration{
+		ApplicationName: "mysql2",
+		Endpoints:       map[string]string{"db": "server"},
+		Deadline:        s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot migrate application offer "hosted-mysql": endpoint "db-admin" is not offered by application "mysql2"`)
+}
+
+func (s *applicationOffersSuite) TestStartMigrationIncompatibleEndpoint(c *gc.C) {
+	offer := s.createDefaultOffer(c)
+	s.AddTestingApplication(c, "mysql2", s.AddTestingCharm(c, "mysql"))
+	err := state.NewApplicationOffers(s.State).StartMigration(offer.OfferName, crossmodel.OfferMigration{
+		ApplicationName: "mysql2",
+		Endpoints:       map[string]string{"db": "server", "db-admin": "server"},
+		Deadline:        s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot migrate application offer "hosted-mysql": endpoint "db-admin" of application "mysql2" is provider "mysql", expected provider "mysql-root"`)
+}
+
+func (s *applicationOffersSuite) TestStartMigrationDeadlinePassed(c *gc.C) {
+	offer := s.createDefaultOffer(c)
+	s.AddTestingApplication(c, "mysql2", s.AddTestingCharm(c, "mysql"))
+	err := state.NewApplicationOffers(s.State).StartMigration(offer.OfferName, crossmodel.OfferMigration{
+		ApplicationName: "mysql2",
+		Endpoints:       map[string]string{"db": "server", "db-admin": "server-admin"},
+		Deadline:        s.Clock.Now().Add(-time.Minute),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot migrate application offer "hosted-mysql": deadline .* has already passed`)
+}
+
+func (s *applicationOffersSuite) TestCompleteMigration(c *gc.C) {
+	offer, _ := s.startDefaultMigration(c)
+	updated, err := state.NewApplicationOffers(s.State).CompleteMigration(offer.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.OfferUUID, gc.Equals, offer.OfferUUID)
+	c.Assert(updated.ApplicationName, gc.Equals, "mysql2")
+	c.Assert(updated.Version, gc.Equals, 1)
+	c.Assert(updated.PendingMigration, gc.IsNil)
+	c.Assert(updated.Endpoints, jc.DeepEquals, offer.Endpoints)
+	assertNoOffersRef(c, s.State, "mysql")
+	assertOffersRef(c, s.State, "mysql2", 1)
+}
+
+func (s *applicationOffersSuite) TestCompleteMigrationNotPending(c *gc.C) {
+	offer := s.createDefaultOffer(c)
+	_, err := state.NewApplicationOffers(s.State).CompleteMigration(offer.OfferName)
+	c.Assert(err, gc.ErrorMatches, `cannot complete migration of application offer "hosted-mysql": pending migration not found`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *applicationOffersSuite) TestAbortMigration(c *gc.C) {
+	offer, _ := s.startDefaultMigration(c)
+	sd := state.NewApplicationOffers(s.State)
+	err := sd.AbortMigration(offer.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+	updated, err := sd.ApplicationOffer(offer.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updated.ApplicationName, gc.Equals, "mysql")
+	c.Assert(updated.PendingMigration, gc.IsNil)
+
+	err = sd.AbortMigration(offer.OfferName)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *applicationOffersSuite) TestUpdateApplicationOfferDuringMigration(c *gc.C) {
+	offer, _ := s.startDefaultMigration(c)
+	owner := s.Factory.MakeUser(c, nil)
+	_, err := state.NewApplicationOffers(s.State).UpdateOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       offer.OfferName,
+		ApplicationName: "mysql2",
+		Owner:           owner.Name(),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot update application offer "mysql2": offer is being migrated to application "mysql2"`)
+}
+
+func (s *applicationOffersSuite) TestWatchOfferStatusMigration(c *gc.C) {
+	offer := s.createDefaultOffer(c)
+	s.AddTestingApplication(c, "mysql2", s.AddTestingCharm(c, "mysql"))
+
+	w, err := s.State.WatchOfferStatus(offer.OfferUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	// Initial event.
+	wc.AssertOneChange()
+	wc.AssertNoChange()
+
+	sd := state.NewApplicationOffers(s.State)
+	err = sd.StartMigration(offer.OfferName, crossmodel.OfferMigration{
+		ApplicationName: "mysql2",
+		Endpoints:       map[string]string{"db": "server", "db-admin": "server-admin"},
+		Deadline:        s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	wc.AssertNoChange()
+
+	_, err = sd.CompleteMigration(offer.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	wc.AssertNoChange()
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+type auditSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) putEntries(c *gc.C, t0 time.Time, origins ...string) []audit.AuditEntry {
+	put := s.State.PutAuditEntryFn()
+	var entries []audit.AuditEntry
+	for i, origin := range origins {
+		entry := audit.AuditEntry{
+			JujuServerVersion: version.MustParse("2.3.0"),
+			ModelUUID:         s.State.ModelUUID(),
+			Timestamp:         t0.Add(time.Duration(i) * time.Minute),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        origin,
+			Operation:         "Application:v6 - Deploy",
+			Data:              map[string]interface{}{"n": i},
+		}
+		err := put(entry)
+		c.Assert(err, jc.ErrorIsNil)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (s *auditSuite) TestAuditEntries(c *gc.C) {
+	t0 := time.Date(2017, time.October, 1, 12, 0, 0, 0, time.UTC)
+	put := s.putEntries(c, t0, "user-bob", "user-mary", "user-bob")
+
+	entries, err := s.State.AuditEntries(state.AuditEntryFilter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 3)
+	for i, entry := range entries {
+		expect := put[len(put)-1-i]
+		c.Check(entry.Timestamp, gc.Equals, expect.Timestamp)
+		c.Check(entry.OriginName, gc.Equals, expect.OriginName)
+		c.Check(entry.Operation, gc.Equals, expect.Operation)
+	}
+}
+
+func (s *auditSuite) TestAuditEntriesFilter(c *gc.C) {
+	t0 := time.Date(2017, time.October, 1, 12, 0, 0, 0, time.UTC)
+	s.putEntries(c, t0, "user-bob", "user-mary", "user-bob", "user-bob")
+
+	entries, err := s.State.AuditEntries(state.AuditEntryFilter{
+		ModelUUID:  s.State.ModelUUID(),
+		OriginName: "user-bob",
+		Before:     t0.Add(3 * time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 2)
+	c.Check(entries[0].Timestamp, gc.Equals, t0.Add(2*time.Minute))
+	c.Check(entries[1].Timestamp, gc.Equals, t0)
+
+	entries, err = s.State.AuditEntries(state.AuditEntryFilter{
+		After: t0.Add(time.Minute),
+		Limit: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Timestamp, gc.Equals, t0.Add(3*time.Minute))
+
+	entries, err = s.State.AuditEntries(state.AuditEntryFilter{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/filestorage"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
 )
 
 const (
@@ -58,6 +59,7 @@ var logger = loggo.GetLogger("juju.state.backups")
 var (
 	getFilesToBackUp = GetFilesToBackUp
 	getDBDumper      = NewDBDumper
+	getOplogDumper   = NewOplogDumper
 	runCreate        = create
 	finishMeta       = func(meta *Metadata, result *createResult) error {
 		return meta.MarkComplete(result.size, result.checksum)
@@ -84,9 +86,16 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates and stores a new juju backup archive. It updates
-	// the provided metadata.
+	// the provided metadata. If the metadata has a parent set, the
+	// backup is incremental, holding only the database changes made
+	// since the parent backup.
 	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error
 
+	// CreateToTarget creates a new juju backup archive like Create,
+	// but streams it to the target rather than storing it on the
+	// controller. The metadata's Target refers to the stored object.
+	CreateToTarget(meta *Metadata, paths *Paths, dbInfo *DBInfo, target Target) error
+
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
 
@@ -99,10 +108,19 @@ type Backups interface {
 	// Remove deletes the backup from storage.
 	Remove(id string) error
 
+	// Prune deletes the stored backups not retained by the policy,
+	// and returns their metadata.
+	Prune(policy RetentionPolicy) ([]*Metadata, error)
+
 	// Restore updates juju's state to the contents of the backup archive,
 	// it returns the tag string for the machine where the backup originated
 	// or error if the process fails.
 	Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (names.Tag, error)
+
+	// RestoreModel restores a single model's documents from the backup
+	// into the running controller, without rolling back the rest of
+	// the controller. It returns the tag of the restored model.
+	RestoreModel(backupId string, st ModelRestorer, args ModelRestoreArgs) (names.ModelTag, error)
 }
 
 type backups struct {
@@ -120,8 +138,52 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 // Create creates and stores a new juju backup archive and updates the
 // provided metadata.
 func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
+	result, err := b.build(meta, paths, dbInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer result.archiveFile.Close()
+
+	// Store the archive.
+	err = storeArchive(b.storage, meta, result.archiveFile)
+	if err != nil {
+		return errors.Annotate(err, "while storing backup archive")
+	}
+
+	return nil
+}
+
+// CreateToTarget creates a new juju backup archive and streams it to
+// the target. Only the metadata, which refers to the object holding
+// the archive, is stored on the controller.
+func (b *backups) CreateToTarget(meta *Metadata, paths *Paths, dbInfo *DBInfo, target Target) error {
+	result, err := b.build(meta, paths, dbInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer result.archiveFile.Close()
+
+	name := meta.Origin.Model + "/" + meta.Started.Format(FilenameTemplate)
+	ref, err := target.Put(name, result.archiveFile, result.size)
+	if err != nil {
+		return errors.Annotate(err, "while streaming backup archive to target")
+	}
+	meta.Target = ref
+
+	id, err := b.storage.Add(meta, nil)
+	if err != nil {
+		return errors.Annotate(err, "while storing backup metadata")
+	}
+	meta.SetID(id)
+	return nil
+}
+
+// build creates a new juju backup archive and finalizes the provided
+// metadata. The caller is responsible for closing the archive file.
+func (b *backups) build(meta *Metadata, paths *Paths, dbInfo *DBInfo) (*createResult, error) {
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
+	meta.OplogPosition = dbInfo.Oplog.Newest
 
 	// The metadata file will not contain the ID or the "finished" data.
 	// However, that information is not as critical. The alternatives
@@ -130,38 +192,61 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 	// them in afterward.  Neither is particularly trivial.
 	metadataFile, err := meta.AsJSONBuffer()
 	if err != nil {
-		return errors.Annotate(err, "while preparing the metadata")
+		return nil, errors.Annotate(err, "while preparing the metadata")
 	}
 
 	// Create the archive.
 	filesToBackUp, err := getFilesToBackUp("", paths, meta.Origin.Machine)
 	if err != nil {
-		return errors.Annotate(err, "while listing files to back up")
+		return nil, errors.Annotate(err, "while listing files to back up")
+	}
+	var dumper DBDumper
+	if meta.Incremental() {
+		var since bson.MongoTimestamp
+		since, err = b.oplogStart(meta.Parent, dbInfo.Oplog)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		dumper, err = getOplogDumper(dbInfo, since)
+	} else {
+		dumper, err = getDBDumper(dbInfo)
 	}
-	dumper, err := getDBDumper(dbInfo)
 	if err != nil {
-		return errors.Annotate(err, "while preparing for DB dump")
+		return nil, errors.Annotate(err, "while preparing for DB dump")
 	}
 	args := createArgs{filesToBackUp, dumper, metadataFile}
 	result, err := runCreate(&args)
 	if err != nil {
-		return errors.Annotate(err, "while creating backup archive")
+		return nil, errors.Annotate(err, "while creating backup archive")
 	}
-	defer result.archiveFile.Close()
 
 	// Finalize the metadata.
 	err = finishMeta(meta, result)
 	if err != nil {
-		return errors.Annotate(err, "while updating metadata")
+		result.archiveFile.Close()
+		return nil, errors.Annotate(err, "while updating metadata")
 	}
+	return result, nil
+}
 
-	// Store the archive.
-	err = storeArchive(b.storage, meta, result.archiveFile)
+// oplogStart returns the oplog position after which an incremental
+// backup building on the identified parent must start.
+func (b *backups) oplogStart(parentID string, oplog OplogWindow) (bson.MongoTimestamp, error) {
+	rawmeta, err := b.storage.Metadata(parentID)
 	if err != nil {
-		return errors.Annotate(err, "while storing backup archive")
+		return 0, errors.Annotatef(err, "while getting parent backup %q", parentID)
 	}
-
-	return nil
+	parent, ok := rawmeta.(*Metadata)
+	if !ok {
+		return 0, errors.New("did not get a backups.Metadata value from storage")
+	}
+	if parent.OplogPosition == 0 {
+		return 0, errors.Errorf("backup %q has no oplog position; a full backup is required", parentID)
+	}
+	if !oplog.Covers(parent.OplogPosition) {
+		return 0, errors.Errorf("oplog no longer holds the changes since backup %q; a full backup is required", parentID)
+	}
+	return parent.OplogPosition, nil
 }
 
 // Add stores the backup archive and returns its new ID.
@@ -212,3 +297,23 @@ func (b *backups) List() ([]*Metadata, error) {
 func (b *backups) Remove(id string) error {
 	return errors.Trace(b.storage.Remove(id))
 }
+
+// Prune deletes the stored backups not retained by the policy, and
+// returns their metadata.
+func (b *backups) Prune(policy RetentionPolicy) ([]*Metadata, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	metaList, err := b.List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var removed []*Metadata
+	for _, meta := range policy.Prunable(metaList) {
+		if err := b.Remove(meta.ID()); err != nil {
+			return removed, errors.Annotatef(err, "while removing backup %q", meta.ID())
+		}
+		removed = append(removed, meta)
+	}
+	return removed, nil
+}
@@ -76,6 +76,12 @@ func (b *backups) Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (na
 
 	defer backupReader.Close()
 
+	if meta.Incremental() {
+		// An incremental backup holds only the oplog changes made
+		// since its parent, which cannot be restored on their own.
+		return nil, errors.NotSupportedf("restoring incremental backup %q", backupId)
+	}
+
 	workspace, err := NewArchiveWorkspaceReader(backupReader)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
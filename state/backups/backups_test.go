@@ -5,13 +5,16 @@ package backups_test
 
 import (
 	"bytes"
+	"io"
 	"io/ioutil"
 	"time" // Only used for time types.
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/backups"
@@ -55,7 +58,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 
 	paths := backups.Paths{DataDir: "/var/lib/juju"}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
+	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt, backups.OplogWindow{}}
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
 	err := s.api.Create(meta, &paths, &dbInfo)
@@ -94,7 +97,7 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	// Run the backup.
 	paths := backups.Paths{DataDir: "/var/lib/juju"}
 	targets := set.NewStrings("juju", "admin")
-	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
+	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt, backups.OplogWindow{}}
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
@@ -188,3 +191,159 @@ func (s *backupsSuite) TestStoreArchive(c *gc.C) {
 	c.Assert(meta.ID(), gc.Equals, "spam")
 	c.Assert(meta.Stored(), jc.DeepEquals, stored)
 }
+
+func (s *backupsSuite) TestCreateIncremental(c *gc.C) {
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 10, "<checksum>")
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		c.Fatalf("full dump requested")
+		return nil, nil
+	})
+	var since bson.MongoTimestamp
+	s.PatchValue(backups.GetOplogDumper, func(info *backups.DBInfo, position bson.MongoTimestamp) (backups.DBDumper, error) {
+		since = position
+		return &fakeDumper{}, nil
+	})
+
+	// The stored metadata stands in for the parent backup too.
+	s.setStored("spam")
+	s.Storage.Meta.(*backups.Metadata).OplogPosition = 5
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{
+		Oplog: backups.OplogWindow{Oldest: 2, Newest: 9},
+	}
+	meta := backupstesting.NewMetadataStarted()
+	meta.Parent = "parent-id"
+	err := s.api.Create(meta, &paths, &dbInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(since, gc.Equals, bson.MongoTimestamp(5))
+	c.Check(meta.ID(), gc.Equals, "spam")
+	c.Check(meta.Parent, gc.Equals, "parent-id")
+	c.Check(meta.OplogPosition, gc.Equals, bson.MongoTimestamp(9))
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"Metadata", "Add", "Metadata"})
+}
+
+func (s *backupsSuite) TestCreateIncrementalOplogTooShort(c *gc.C) {
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{}, nil
+	})
+	s.setStored("parent-id")
+	s.Storage.Meta.(*backups.Metadata).OplogPosition = 5
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{
+		Oplog: backups.OplogWindow{Oldest: 7, Newest: 9},
+	}
+	meta := backupstesting.NewMetadataStarted()
+	meta.Parent = "parent-id"
+	err := s.api.Create(meta, &paths, &dbInfo)
+	c.Check(err, gc.ErrorMatches, `oplog no longer holds the changes since backup "parent-id"; a full backup is required`)
+}
+
+type fakeTarget struct {
+	name string
+	data string
+	size int64
+	err  error
+}
+
+func (t *fakeTarget) Put(name string, archive io.Reader, size int64) (string, error) {
+	data, err := ioutil.ReadAll(archive)
+	if err != nil {
+		return "", err
+	}
+	t.name, t.data, t.size = name, string(data), size
+	return "s3://juju-backups/" + name, t.err
+}
+
+func (s *backupsSuite) TestCreateToTarget(c *gc.C) {
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 10, "<checksum>")
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+	s.PatchValue(backups.StoreArchiveRef, func(filestorage.FileStorage, *backups.Metadata, io.Reader) error {
+		c.Fatalf("archive stored on the controller")
+		return nil
+	})
+	s.Storage.ID = "spam"
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{}
+	meta := backupstesting.NewMetadataStarted()
+	target := &fakeTarget{}
+	err := s.api.CreateToTarget(meta, &paths, &dbInfo, target)
+	c.Assert(err, jc.ErrorIsNil)
+
+	name := meta.Origin.Model + "/" + meta.Started.Format(backups.FilenameTemplate)
+	c.Check(target.name, gc.Equals, name)
+	c.Check(target.data, gc.Equals, "<compressed tarball>")
+	c.Check(target.size, gc.Equals, int64(10))
+	c.Check(meta.Target, gc.Equals, "s3://juju-backups/"+name)
+	c.Check(meta.ID(), gc.Equals, "spam")
+	c.Check(meta.Stored(), gc.IsNil)
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"Add"})
+	c.Check(s.Storage.MetaArg, gc.Equals, meta)
+	c.Check(s.Storage.FileArg, gc.IsNil)
+}
+
+func (s *backupsSuite) TestCreateToTargetFailToPut(c *gc.C) {
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{}, nil
+	})
+	_, testCreate := backups.NewTestCreate(nil)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.FinishMeta, backups.NewTestMetaFinisher(""))
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return &fakeDumper{}, nil
+	})
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{}
+	meta := backupstesting.NewMetadataStarted()
+	err := s.api.CreateToTarget(meta, &paths, &dbInfo, &fakeTarget{err: errors.New("failed!")})
+	c.Check(err, gc.ErrorMatches, "while streaming backup archive to target: failed!")
+	c.Check(s.Storage.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestPrune(c *gc.C) {
+	started := testing.NonZeroTime().UTC()
+	for id, age := range map[string]time.Duration{
+		"oldest": 2 * time.Hour,
+		"middle": time.Hour,
+		"newest": 0,
+	} {
+		meta := backupstesting.NewMetadataStarted()
+		meta.SetID(id)
+		meta.Started = started.Add(-age)
+		s.Storage.MetaList = append(s.Storage.MetaList, meta)
+	}
+
+	removed, err := s.api.Prune(backups.RetentionPolicy{KeepLast: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var ids []string
+	for _, meta := range removed {
+		ids = append(ids, meta.ID())
+	}
+	c.Check(ids, jc.DeepEquals, []string{"oldest", "middle"})
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"List", "Remove", "Remove"})
+}
+
+func (s *backupsSuite) TestPruneInvalidPolicy(c *gc.C) {
+	_, err := s.api.Prune(backups.RetentionPolicy{})
+	c.Check(err, gc.ErrorMatches, "retention policy keeping no backups not valid")
+	c.Check(s.Storage.Calls, gc.HasLen, 0)
+}
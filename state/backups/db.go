@@ -44,6 +44,47 @@ type DBInfo struct {
 	Targets set.Strings
 	// MongoVersion the version of the running mongo db.
 	MongoVersion mongo.Version
+	// Oplog is the extent of the DB system's oplog when the backup
+	// was requested.
+	Oplog OplogWindow
+}
+
+// OplogWindow describes the range of changes held in the DB system's
+// oplog. Older changes have been discarded.
+type OplogWindow struct {
+	// Oldest is the position of the oldest change in the oplog.
+	Oldest bson.MongoTimestamp
+	// Newest is the position of the most recent change in the oplog.
+	Newest bson.MongoTimestamp
+}
+
+// Covers reports whether the oplog still holds every change made
+// after the given position.
+func (w OplogWindow) Covers(position bson.MongoTimestamp) bool {
+	return w.Newest != 0 && w.Oldest <= position
+}
+
+// GetOplogWindow returns the range of changes currently held in the
+// replica set oplog. An empty window is returned if there is no oplog.
+func GetOplogWindow(session *mgo.Session) (OplogWindow, error) {
+	var window OplogWindow
+	oplog := session.DB("local").C("oplog.rs")
+	var doc struct {
+		Timestamp bson.MongoTimestamp `bson:"ts"`
+	}
+	err := oplog.Find(nil).Sort("$natural").Select(bson.M{"ts": 1}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return window, nil
+	} else if err != nil {
+		return window, errors.Annotate(err, "reading oldest oplog entry")
+	}
+	window.Oldest = doc.Timestamp
+	err = oplog.Find(nil).Sort("-$natural").Select(bson.M{"ts": 1}).One(&doc)
+	if err != nil {
+		return window, errors.Annotate(err, "reading newest oplog entry")
+	}
+	window.Newest = doc.Timestamp
+	return window, nil
 }
 
 // ignoredDatabases is the list of databases that should not be
@@ -196,6 +237,66 @@ func (md *mongoDumper) Dump(baseDumpDir string) error {
 	return errors.Trace(err)
 }
 
+type oplogDumper struct {
+	*DBInfo
+	// binPath is the path to the dump executable.
+	binPath string
+	// since is the oplog position after which changes are dumped.
+	since bson.MongoTimestamp
+}
+
+// NewOplogDumper returns a new value with a Dump method for dumping
+// the changes made to the juju state database after the given oplog
+// position. The changes are dumped as a single oplog.bson file, in the
+// form replayed by mongorestore --oplogReplay.
+func NewOplogDumper(info *DBInfo, since bson.MongoTimestamp) (DBDumper, error) {
+	mongodumpPath, err := getMongodumpPath()
+	if err != nil {
+		return nil, errors.Annotate(err, "mongodump not available")
+	}
+
+	dumper := oplogDumper{
+		DBInfo:  info,
+		binPath: mongodumpPath,
+		since:   since,
+	}
+	return &dumper, nil
+}
+
+func (od *oplogDumper) options(dumpDir string) []string {
+	query := fmt.Sprintf(`{"ts": {"$gt": {"$timestamp": {"t": %d, "i": %d}}}}`,
+		uint64(od.since)>>32, uint32(od.since))
+	options := []string{
+		"--ssl",
+		"--authenticationDatabase", "admin",
+		"--host", od.Address,
+		"--username", od.Username,
+		"--password", od.Password,
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", query,
+		"--out", dumpDir,
+	}
+	return options
+}
+
+// Dump dumps the changes made since the oplog position.
+func (od *oplogDumper) Dump(baseDumpDir string) error {
+	options := od.options(baseDumpDir)
+	if err := runCommandFn(od.binPath, options...); err != nil {
+		return errors.Annotate(err, "error dumping oplog")
+	}
+
+	// mongodump writes the collection under a directory named for its
+	// database; mongorestore expects the oplog at the top level.
+	localDir := filepath.Join(baseDumpDir, "local")
+	dumped := filepath.Join(localDir, "oplog.rs.bson")
+	if err := os.Rename(dumped, filepath.Join(baseDumpDir, "oplog.bson")); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.RemoveAll(localDir))
+}
+
 // stripIgnored removes the ignored DBs from the mongo dump files.
 // This involves deleting DB-specific directories.
 //
@@ -4,12 +4,14 @@
 package backups_test
 
 import (
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/backups"
@@ -31,7 +33,7 @@ func (s *dumpSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
 	targets := set.NewStrings("juju", "admin")
-	s.dbInfo = &backups.DBInfo{"a", "b", "c", targets, mongo.Mongo24}
+	s.dbInfo = &backups.DBInfo{"a", "b", "c", targets, mongo.Mongo24, backups.OplogWindow{}}
 	s.targets = targets
 	s.dumpDir = c.MkDir()
 }
@@ -155,3 +157,39 @@ func (s *dumpSuite) TestDumpNothingIgnored(c *gc.C) {
 
 	s.checkDBs(c, "juju", "admin")
 }
+
+func (s *dumpSuite) TestDumpOplog(c *gc.C) {
+	s.PatchValue(backups.GetMongodumpPath, func() (string, error) {
+		return "bogusmongodump", nil
+	})
+	var ranArgs []string
+	s.PatchValue(backups.RunCommand, func(cmd string, args ...string) error {
+		ranArgs = args
+		return nil
+	})
+	localDir := s.prepDB(c, "local")
+	err := ioutil.WriteFile(filepath.Join(localDir, "oplog.rs.bson"), []byte("changes"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	since := bson.MongoTimestamp(1506816000<<32 | 3)
+	dumper, err := backups.NewOplogDumper(s.dbInfo, since)
+	c.Assert(err, jc.ErrorIsNil)
+	err = dumper.Dump(s.dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(ranArgs, jc.DeepEquals, []string{
+		"--ssl",
+		"--authenticationDatabase", "admin",
+		"--host", "a",
+		"--username", "b",
+		"--password", "c",
+		"--db", "local",
+		"--collection", "oplog.rs",
+		"--query", `{"ts": {"$gt": {"$timestamp": {"t": 1506816000, "i": 3}}}}`,
+		"--out", s.dumpDir,
+	})
+	data, err := ioutil.ReadFile(filepath.Join(s.dumpDir, "oplog.bson"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "changes")
+	s.checkStripped(c, "local")
+}
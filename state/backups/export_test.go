@@ -22,6 +22,7 @@ var (
 
 	TestGetFilesToBackUp  = &getFilesToBackUp
 	GetDBDumper           = &getDBDumper
+	GetOplogDumper        = &getOplogDumper
 	RunCreate             = &runCreate
 	FinishMeta            = &finishMeta
 	StoreArchiveRef       = &storeArchive
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils/filestorage"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
 	jujuversion "github.com/juju/juju/version"
 )
@@ -68,6 +69,20 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Parent is the ID of the backup on which an incremental backup
+	// builds. It is empty for full backups.
+	Parent string
+
+	// OplogPosition is the position in the database oplog up to which
+	// the backup is known to hold all changes. Incremental backups
+	// hold the changes made after their parent's position.
+	OplogPosition bson.MongoTimestamp
+
+	// Target refers to the object holding the archive in the
+	// controller's backup target, if the archive was streamed there
+	// rather than stored on the controller.
+	Target string
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -147,6 +162,12 @@ func (m *Metadata) MarkComplete(size int64, checksum string) error {
 	return nil
 }
 
+// Incremental reports whether the backup holds only the changes made
+// since its parent backup.
+func (m *Metadata) Incremental() bool {
+	return m.Parent != ""
+}
+
 type flatMetadata struct {
 	ID string
 
@@ -168,6 +189,9 @@ type flatMetadata struct {
 	Version     version.Number
 	Series      string
 
+	Parent        string
+	OplogPosition int64
+
 	CACert       string
 	CAPrivateKey string
 }
@@ -192,6 +216,9 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 		Series:       m.Origin.Series,
 		CACert:       m.CACert,
 		CAPrivateKey: m.CAPrivateKey,
+
+		Parent:        m.Parent,
+		OplogPosition: int64(m.OplogPosition),
 	}
 
 	stored := m.Stored()
@@ -241,6 +268,8 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 		Version:  flat.Version,
 		Series:   flat.Series,
 	}
+	meta.Parent = flat.Parent
+	meta.OplogPosition = bson.MongoTimestamp(flat.OplogPosition)
 
 	// TODO(wallyworld) - put these in a separate file.
 	meta.CACert = flat.CACert
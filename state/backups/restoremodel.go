@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+// jujuDBName is the name of the juju state database.
+const jujuDBName = "juju"
+
+// ModelRestorer restores a single model's documents into a running
+// controller. It is implemented by *state.State.
+type ModelRestorer interface {
+	RestoreModel(args state.RestoreModelArgs) (*state.Model, *state.State, error)
+}
+
+// ModelRestoreArgs holds the arguments for restoring a single model
+// from a backup.
+type ModelRestoreArgs struct {
+	// ModelUUID is the UUID of the model in the backup.
+	ModelUUID string
+
+	// NewUUID, if set, is the UUID given to the restored model.
+	NewUUID string
+
+	// NewName, if set, is the name given to the restored model.
+	NewName string
+}
+
+// RestoreModel restores a single model's documents from the backup
+// into the running controller, and returns the tag of the restored
+// model.
+func (b *backups) RestoreModel(backupId string, st ModelRestorer, args ModelRestoreArgs) (names.ModelTag, error) {
+	meta, backupReader, err := b.Get(backupId)
+	if err != nil {
+		return names.ModelTag{}, errors.Annotatef(err, "could not fetch backup %q", backupId)
+	}
+	defer backupReader.Close()
+
+	if meta.Incremental() {
+		return names.ModelTag{}, errors.NotSupportedf("restoring a model from incremental backup %q", backupId)
+	}
+
+	workspace, err := NewArchiveWorkspaceReader(backupReader)
+	if err != nil {
+		return names.ModelTag{}, errors.Annotate(err, "cannot unpack backup file")
+	}
+	defer workspace.Close()
+
+	model, modelSt, err := st.RestoreModel(state.RestoreModelArgs{
+		ModelUUID: args.ModelUUID,
+		NewUUID:   args.NewUUID,
+		NewName:   args.NewName,
+		Source:    NewDumpDocSource(workspace.DBDumpDir, jujuDBName),
+	})
+	if err != nil {
+		return names.ModelTag{}, errors.Annotatef(err, "cannot restore model %s", args.ModelUUID)
+	}
+	modelSt.Close()
+	return model.ModelTag(), nil
+}
+
+type dumpDocSource struct {
+	dir string
+}
+
+// NewDumpDocSource returns a state.ModelDocSource which reads the
+// documents of the named database from the mongodump output in dumpDir.
+// Changes recorded in the dump's oplog are not applied.
+func NewDumpDocSource(dumpDir, dbName string) state.ModelDocSource {
+	return &dumpDocSource{dir: filepath.Join(dumpDir, dbName)}
+}
+
+// Docs is part of the state.ModelDocSource interface.
+func (s *dumpDocSource) Docs(collection string) ([]bson.D, error) {
+	f, err := os.Open(filepath.Join(s.dir, collection+".bson"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+
+	// A dumped collection is a sequence of BSON documents, each of
+	// which starts with its length as a little-endian int32.
+	var docs []bson.D
+	r := bufio.NewReader(f)
+	for {
+		var size int32
+		if err := binary.Read(r, binary.LittleEndian, &size); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading %s", collection)
+		}
+		if size < 5 {
+			return nil, errors.Errorf("reading %s: invalid document size %d", collection, size)
+		}
+		data := make([]byte, size)
+		binary.LittleEndian.PutUint32(data, uint32(size))
+		if _, err := io.ReadFull(r, data[4:]); err != nil {
+			return nil, errors.Annotatef(err, "reading %s", collection)
+		}
+		var doc bson.D
+		if err := bson.Unmarshal(data, &doc); err != nil {
+			return nil, errors.Annotatef(err, "reading %s", collection)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
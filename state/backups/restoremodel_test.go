@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type dumpDocSourceSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&dumpDocSourceSuite{})
+
+func (s *dumpDocSourceSuite) TestDocs(c *gc.C) {
+	dumpDir := c.MkDir()
+	dbDir := filepath.Join(dumpDir, "juju")
+	c.Assert(os.Mkdir(dbDir, 0755), jc.ErrorIsNil)
+
+	docs := []bson.D{
+		{{"_id", "uuid:0"}, {"model-uuid", "uuid"}},
+		{{"_id", "uuid:1"}, {"model-uuid", "uuid"}, {"series", "xenial"}},
+	}
+	var dump bytes.Buffer
+	for _, doc := range docs {
+		data, err := bson.Marshal(doc)
+		c.Assert(err, jc.ErrorIsNil)
+		dump.Write(data)
+	}
+	err := ioutil.WriteFile(filepath.Join(dbDir, "machines.bson"), dump.Bytes(), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	source := backups.NewDumpDocSource(dumpDir, "juju")
+	read, err := source.Docs("machines")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(read, jc.DeepEquals, docs)
+
+	read, err = source.Docs("applications")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(read, gc.HasLen, 0)
+}
+
+func (s *dumpDocSourceSuite) TestDocsTruncated(c *gc.C) {
+	dumpDir := c.MkDir()
+	dbDir := filepath.Join(dumpDir, "juju")
+	c.Assert(os.Mkdir(dbDir, 0755), jc.ErrorIsNil)
+
+	data, err := bson.Marshal(bson.D{{"_id", "uuid:0"}})
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dbDir, "machines.bson"), data[:len(data)-2], 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = backups.NewDumpDocSource(dumpDir, "juju").Docs("machines")
+	c.Check(err, gc.ErrorMatches, "reading machines: unexpected EOF")
+}
+
+type fakeModelRestorer struct{}
+
+func (fakeModelRestorer) RestoreModel(state.RestoreModelArgs) (*state.Model, *state.State, error) {
+	panic("model restored")
+}
+
+func (s *backupsSuite) TestRestoreModelIncremental(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	meta.Parent = "parent-id"
+	s.Storage.Meta = meta
+	s.Storage.File = ioutil.NopCloser(bytes.NewBufferString("<archive>"))
+
+	_, err := s.api.RestoreModel("spam", fakeModelRestorer{}, backups.ModelRestoreArgs{
+		ModelUUID: "uuid",
+	})
+	c.Check(err, gc.ErrorMatches, `restoring a model from incremental backup "spam" not supported`)
+}
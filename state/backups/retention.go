@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// RetentionPolicy determines which stored backups are kept when
+// backups are pruned. A backup is kept if any of the rules select it,
+// along with every backup an incremental backup builds on.
+type RetentionPolicy struct {
+	// KeepLast is the number of most recent backups to keep.
+	KeepLast int
+
+	// KeepDaily is the number of days for which the most recent
+	// backup of each day is kept.
+	KeepDaily int
+
+	// KeepWeekly is the number of weeks for which the most recent
+	// backup of each week is kept.
+	KeepWeekly int
+}
+
+// Validate returns an error if the policy is not valid. A policy that
+// keeps no backups at all is not valid.
+func (p RetentionPolicy) Validate() error {
+	if p.KeepLast < 0 {
+		return errors.NotValidf("negative KeepLast")
+	}
+	if p.KeepDaily < 0 {
+		return errors.NotValidf("negative KeepDaily")
+	}
+	if p.KeepWeekly < 0 {
+		return errors.NotValidf("negative KeepWeekly")
+	}
+	if p.KeepLast+p.KeepDaily+p.KeepWeekly == 0 {
+		return errors.NotValidf("retention policy keeping no backups")
+	}
+	return nil
+}
+
+// Prunable returns the backups in the list that the policy does not
+// retain, oldest first.
+func (p RetentionPolicy) Prunable(metaList []*Metadata) []*Metadata {
+	sorted := make([]*Metadata, len(metaList))
+	copy(sorted, metaList)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Started.After(sorted[j].Started)
+	})
+
+	keep := set.NewStrings()
+	days := set.NewStrings()
+	weeks := set.NewStrings()
+	for i, meta := range sorted {
+		if i < p.KeepLast {
+			keep.Add(meta.ID())
+		}
+		started := meta.Started.UTC()
+		day := started.Format("2006-01-02")
+		if !days.Contains(day) && days.Size() < p.KeepDaily {
+			days.Add(day)
+			keep.Add(meta.ID())
+		}
+		year, week := started.ISOWeek()
+		weekKey := fmt.Sprintf("%d-%d", year, week)
+		if !weeks.Contains(weekKey) && weeks.Size() < p.KeepWeekly {
+			weeks.Add(weekKey)
+			keep.Add(meta.ID())
+		}
+	}
+
+	// An incremental backup is useless without the backups it
+	// builds on.
+	byID := make(map[string]*Metadata)
+	for _, meta := range sorted {
+		byID[meta.ID()] = meta
+	}
+	for _, id := range keep.Values() {
+		for meta := byID[id]; meta != nil && meta.Incremental(); {
+			keep.Add(meta.Parent)
+			meta = byID[meta.Parent]
+		}
+	}
+
+	var prunable []*Metadata
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if !keep.Contains(sorted[i].ID()) {
+			prunable = append(prunable, sorted[i])
+		}
+	}
+	return prunable
+}
@@ -0,0 +1,133 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time" // Only used for time types.
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type retentionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&retentionSuite{})
+
+// Sunday 1 October 2017, at noon.
+var retentionNow = time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func backupAt(id string, age time.Duration, parent string) *backups.Metadata {
+	meta := backupstesting.NewMetadataStarted()
+	meta.SetID(id)
+	meta.Started = retentionNow.Add(-age)
+	meta.Parent = parent
+	return meta
+}
+
+func prunedIDs(policy backups.RetentionPolicy, metaList ...*backups.Metadata) []string {
+	var ids []string
+	for _, meta := range policy.Prunable(metaList) {
+		ids = append(ids, meta.ID())
+	}
+	return ids
+}
+
+func (s *retentionSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		policy backups.RetentionPolicy
+		err    string
+	}{{
+		policy: backups.RetentionPolicy{KeepLast: 1},
+	}, {
+		policy: backups.RetentionPolicy{KeepDaily: 7, KeepWeekly: 4},
+	}, {
+		policy: backups.RetentionPolicy{},
+		err:    "retention policy keeping no backups not valid",
+	}, {
+		policy: backups.RetentionPolicy{KeepLast: -1, KeepDaily: 2},
+		err:    "negative KeepLast not valid",
+	}, {
+		policy: backups.RetentionPolicy{KeepDaily: -1, KeepLast: 2},
+		err:    "negative KeepDaily not valid",
+	}, {
+		policy: backups.RetentionPolicy{KeepWeekly: -1, KeepLast: 2},
+		err:    "negative KeepWeekly not valid",
+	}} {
+		c.Logf("test %d: %+v", i, test.policy)
+		err := test.policy.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *retentionSuite) TestKeepLast(c *gc.C) {
+	ids := prunedIDs(backups.RetentionPolicy{KeepLast: 2},
+		backupAt("b", time.Hour, ""),
+		backupAt("d", 3*time.Hour, ""),
+		backupAt("a", 0, ""),
+		backupAt("c", 2*time.Hour, ""),
+	)
+	c.Check(ids, jc.DeepEquals, []string{"d", "c"})
+}
+
+func (s *retentionSuite) TestKeepDaily(c *gc.C) {
+	day := 24 * time.Hour
+	ids := prunedIDs(backups.RetentionPolicy{KeepDaily: 2},
+		backupAt("today-late", 0, ""),
+		backupAt("today-early", 6*time.Hour, ""),
+		backupAt("yesterday", day, ""),
+		backupAt("two-days-ago", 2*day, ""),
+	)
+	c.Check(ids, jc.DeepEquals, []string{"two-days-ago", "today-early"})
+}
+
+func (s *retentionSuite) TestKeepWeekly(c *gc.C) {
+	day := 24 * time.Hour
+	// retentionNow is a Sunday, the last day of its ISO week.
+	ids := prunedIDs(backups.RetentionPolicy{KeepWeekly: 2},
+		backupAt("sunday", 0, ""),
+		backupAt("monday", 6*day, ""),
+		backupAt("last-week", 7*day, ""),
+		backupAt("two-weeks-ago", 14*day, ""),
+	)
+	c.Check(ids, jc.DeepEquals, []string{"two-weeks-ago", "monday"})
+}
+
+func (s *retentionSuite) TestRulesCombine(c *gc.C) {
+	day := 24 * time.Hour
+	ids := prunedIDs(backups.RetentionPolicy{KeepLast: 1, KeepDaily: 2},
+		backupAt("latest", 0, ""),
+		backupAt("earlier", time.Hour, ""),
+		backupAt("yesterday", day, ""),
+		backupAt("old", 3*day, ""),
+	)
+	c.Check(ids, jc.DeepEquals, []string{"old", "earlier"})
+}
+
+func (s *retentionSuite) TestKeepsIncrementalParents(c *gc.C) {
+	ids := prunedIDs(backups.RetentionPolicy{KeepLast: 1},
+		backupAt("incremental-2", 0, "incremental-1"),
+		backupAt("incremental-1", time.Hour, "full"),
+		backupAt("full", 2*time.Hour, ""),
+		backupAt("older-full", 3*time.Hour, ""),
+	)
+	c.Check(ids, jc.DeepEquals, []string{"older-full"})
+}
+
+func (s *retentionSuite) TestMissingParent(c *gc.C) {
+	ids := prunedIDs(backups.RetentionPolicy{KeepLast: 1},
+		backupAt("incremental", 0, "gone"),
+		backupAt("full", time.Hour, ""),
+	)
+	c.Check(ids, jc.DeepEquals, []string{"full"})
+}
@@ -48,6 +48,15 @@ type storageMetaDoc struct {
 	Finished int64  `bson:"finished,minsize"`
 	Notes    string `bson:"notes,omitempty"`
 
+	// incremental
+
+	Parent        string `bson:"parent,omitempty"`
+	OplogPosition int64  `bson:"oplogposition,omitempty"`
+
+	// backup target
+
+	Target string `bson:"target,omitempty"`
+
 	// origin
 
 	Model    string         `bson:"model"`
@@ -124,6 +133,9 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Parent = doc.Parent
+	meta.OplogPosition = bson.MongoTimestamp(doc.OplogPosition)
+	meta.Target = doc.Target
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -175,6 +187,9 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Parent = meta.Parent
+	doc.OplogPosition = int64(meta.OplogPosition)
+	doc.Target = meta.Target
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
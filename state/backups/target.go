@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+	"gopkg.in/goose.v2/client"
+	"gopkg.in/goose.v2/identity"
+	gooselogging "gopkg.in/goose.v2/logging"
+	"gopkg.in/goose.v2/swift"
+
+	"github.com/juju/juju/controller"
+)
+
+// archiveContentType is the content type of backup archives.
+const archiveContentType = "application/x-gzip"
+
+// Target is object storage to which backup archives are streamed,
+// rather than being stored on the controller.
+type Target interface {
+	// Put stores the archive as the named object, and returns a
+	// reference to the stored object.
+	Put(name string, archive io.Reader, size int64) (string, error)
+}
+
+// NewTarget returns the Target described by the controller's backup
+// target configuration.
+func NewTarget(cfg controller.BackupTargetConfig) (Target, error) {
+	switch cfg.Type {
+	case controller.BackupTargetS3:
+		return newS3Target(cfg)
+	case controller.BackupTargetSwift:
+		return newSwiftTarget(cfg), nil
+	case controller.BackupTargetAzure:
+		return newAzureTarget(cfg)
+	}
+	return nil, errors.NotValidf("backup target type %q", cfg.Type)
+}
+
+type s3Target struct {
+	bucket *s3.Bucket
+}
+
+func newS3Target(cfg controller.BackupTargetConfig) (Target, error) {
+	region, ok := aws.Regions[cfg.Region]
+	if !ok {
+		return nil, errors.NotValidf("S3 region %q", cfg.Region)
+	}
+	if cfg.Endpoint != "" {
+		region.S3Endpoint = cfg.Endpoint
+	}
+	auth := aws.Auth{
+		AccessKey: cfg.AccessKey,
+		SecretKey: cfg.SecretKey,
+	}
+	bucket, err := s3.New(auth, region).Bucket(cfg.Container)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &s3Target{bucket}, nil
+}
+
+// Put is part of the Target interface.
+func (t *s3Target) Put(name string, archive io.Reader, size int64) (string, error) {
+	if err := t.bucket.PutReader(name, archive, size, archiveContentType, s3.Private); err != nil {
+		return "", errors.Annotatef(err, "cannot put %q in S3 bucket %q", name, t.bucket.Name)
+	}
+	return fmt.Sprintf("s3://%s/%s", t.bucket.Name, name), nil
+}
+
+type swiftTarget struct {
+	client    *swift.Client
+	container string
+}
+
+func newSwiftTarget(cfg controller.BackupTargetConfig) Target {
+	creds := &identity.Credentials{
+		URL:        cfg.Endpoint,
+		Region:     cfg.Region,
+		TenantName: cfg.Tenant,
+		User:       cfg.AccessKey,
+		Secrets:    cfg.SecretKey,
+	}
+	logger := gooselogging.LoggoLogger{loggo.GetLogger("goose")}
+	authClient := client.NewClient(creds, identity.AuthUserPass, logger)
+	return &swiftTarget{
+		client:    swift.New(authClient),
+		container: cfg.Container,
+	}
+}
+
+// Put is part of the Target interface.
+func (t *swiftTarget) Put(name string, archive io.Reader, size int64) (string, error) {
+	if err := t.client.PutReader(t.container, name, archive, size); err != nil {
+		return "", errors.Annotatef(err, "cannot put %q in Swift container %q", name, t.container)
+	}
+	return fmt.Sprintf("swift://%s/%s", t.container, name), nil
+}
+
+type azureTarget struct {
+	container *storage.Container
+}
+
+func newAzureTarget(cfg controller.BackupTargetConfig) (Target, error) {
+	client, err := storage.NewBasicClient(cfg.AccessKey, cfg.SecretKey)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create Azure storage client")
+	}
+	blobs := client.GetBlobService()
+	return &azureTarget{blobs.GetContainerReference(cfg.Container)}, nil
+}
+
+// Put is part of the Target interface.
+func (t *azureTarget) Put(name string, archive io.Reader, size int64) (string, error) {
+	blob := t.container.GetBlobReference(name)
+	blob.Properties.ContentLength = size
+	blob.Properties.ContentType = archiveContentType
+	if err := blob.CreateBlockBlobFromReader(archive, nil); err != nil {
+		return "", errors.Annotatef(err, "cannot put %q in Azure container %q", name, t.container.Name)
+	}
+	return blob.GetURL(), nil
+}
@@ -44,6 +44,12 @@ type FakeBackups struct {
 	InstanceId instance.Id
 	// ArchiveArg holds the backup archive that was passed in.
 	ArchiveArg io.Reader
+	// PolicyArg holds the retention policy that was passed in.
+	PolicyArg backups.RetentionPolicy
+	// TargetArg holds the backup target that was passed in.
+	TargetArg backups.Target
+	// ModelRestoreArg holds the model restore args that were passed in.
+	ModelRestoreArg backups.ModelRestoreArgs
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -64,6 +70,23 @@ func (b *FakeBackups) Create(meta *backups.Metadata, paths *backups.Paths, dbInf
 	return b.Error
 }
 
+// CreateToTarget creates a new juju backup archive, streams it to the
+// target and returns its associated metadata.
+func (b *FakeBackups) CreateToTarget(meta *backups.Metadata, paths *backups.Paths, dbInfo *backups.DBInfo, target backups.Target) error {
+	b.Calls = append(b.Calls, "CreateToTarget")
+
+	b.PathsArg = paths
+	b.DBInfoArg = dbInfo
+	b.MetaArg = meta
+	b.TargetArg = target
+
+	if b.Meta != nil {
+		*meta = *b.Meta
+	}
+
+	return b.Error
+}
+
 // Add stores the backup and returns its new ID.
 func (b *FakeBackups) Add(archive io.Reader, meta *backups.Metadata) (string, error) {
 	b.Calls = append(b.Calls, "Add")
@@ -96,6 +119,13 @@ func (b *FakeBackups) Remove(id string) error {
 	return errors.Trace(b.Error)
 }
 
+// Prune deletes the backups not retained by the policy.
+func (b *FakeBackups) Prune(policy backups.RetentionPolicy) ([]*backups.Metadata, error) {
+	b.Calls = append(b.Calls, "Prune")
+	b.PolicyArg = policy
+	return b.MetaList, errors.Trace(b.Error)
+}
+
 // Restore restores a machine to a backed up status.
 func (b *FakeBackups) Restore(bkpId string, dbInfo *backups.DBInfo, args backups.RestoreArgs) (names.Tag, error) {
 	b.Calls = append(b.Calls, "Restore")
@@ -104,6 +134,18 @@ func (b *FakeBackups) Restore(bkpId string, dbInfo *backups.DBInfo, args backups
 	return nil, errors.Trace(b.Error)
 }
 
+// RestoreModel restores a single model from a backup.
+func (b *FakeBackups) RestoreModel(bkpId string, st backups.ModelRestorer, args backups.ModelRestoreArgs) (names.ModelTag, error) {
+	b.Calls = append(b.Calls, "RestoreModel")
+	b.IDArg = bkpId
+	b.ModelRestoreArg = args
+	uuid := args.ModelUUID
+	if args.NewUUID != "" {
+		uuid = args.NewUUID
+	}
+	return names.NewModelTag(uuid), errors.Trace(b.Error)
+}
+
 // TODO(ericsnow) FakeStorage should probably move over to the utils repo.
 
 // FakeStorage is a FileStorage implementation to use when testing
@@ -0,0 +1,421 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+var validBranchName = regexp.MustCompile("^[a-z0-9]+(-[a-z0-9]+)*$")
+
+// Branch is a named set of application config changes that are seen
+// only by the units assigned to the branch, until the branch is
+// committed and the changes are applied to all units. Branches allow
+// config changes to be tried out on a few units first.
+type Branch struct {
+	st  *State
+	doc branchDoc
+}
+
+type branchDoc struct {
+	DocID     string `bson:"_id"`
+	Name      string `bson:"name"`
+	CreatedBy string `bson:"created-by,omitempty"`
+	Created   int64  `bson:"created"`
+
+	// Config holds the config changes staged for each application,
+	// keyed by application name. A nil value resets the option to
+	// the charm default when the branch is committed.
+	Config map[string]settingsMap `bson:"config"`
+
+	// AssignedUnits holds the names of the units assigned to the
+	// branch, keyed by application name.
+	AssignedUnits map[string][]string `bson:"assigned-units"`
+
+	TxnRevno int64 `bson:"txn-revno"`
+}
+
+// Name returns the name of the branch.
+func (b *Branch) Name() string {
+	return b.doc.Name
+}
+
+// CreatedBy returns the name of the user that created the branch.
+func (b *Branch) CreatedBy() string {
+	return b.doc.CreatedBy
+}
+
+// Created returns the time the branch was created.
+func (b *Branch) Created() time.Time {
+	return time.Unix(0, b.doc.Created).UTC()
+}
+
+// Config returns the config changes staged in the branch, keyed by
+// application name.
+func (b *Branch) Config() map[string]charm.Settings {
+	config := make(map[string]charm.Settings)
+	for appName, settings := range b.doc.Config {
+		config[appName] = charm.Settings(copyMap(settings, nil))
+	}
+	return config
+}
+
+// AssignedUnits returns the names of the units assigned to the branch,
+// keyed by application name.
+func (b *Branch) AssignedUnits() map[string][]string {
+	units := make(map[string][]string)
+	for appName, unitNames := range b.doc.AssignedUnits {
+		units[appName] = append([]string(nil), unitNames...)
+	}
+	return units
+}
+
+// Refresh refreshes the contents of the branch from the underlying
+// state. It returns an error that satisfies errors.IsNotFound if the
+// branch has been committed or aborted.
+func (b *Branch) Refresh() error {
+	branch, err := b.st.Branch(b.doc.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	b.doc = branch.doc
+	return nil
+}
+
+// AddBranch adds a new, empty branch with the given name, recording
+// the named user as its creator.
+func (st *State) AddBranch(name, user string) (*Branch, error) {
+	if !validBranchName.MatchString(name) {
+		return nil, errors.NotValidf("branch name %q", name)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		model, err := st.Model()
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to load model")
+		}
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := st.Branch(name); err == nil {
+			return nil, errors.AlreadyExistsf("branch %q", name)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      branchesC,
+			Id:     name,
+			Assert: txn.DocMissing,
+			Insert: &branchDoc{
+				DocID:         name,
+				Name:          name,
+				CreatedBy:     user,
+				Created:       st.clock().Now().UnixNano(),
+				Config:        make(map[string]settingsMap),
+				AssignedUnits: make(map[string][]string),
+			},
+		}, model.assertActiveOp()}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot add branch %q", name)
+	}
+	return st.Branch(name)
+}
+
+// Branch returns the branch with the given name.
+func (st *State) Branch(name string) (*Branch, error) {
+	coll, closer := st.db().GetCollection(branchesC)
+	defer closer()
+
+	var doc branchDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("branch %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get branch %q", name)
+	}
+	return &Branch{st: st, doc: doc}, nil
+}
+
+// AllBranches returns all the branches in the model, oldest first.
+func (st *State) AllBranches() ([]*Branch, error) {
+	return st.branches(nil)
+}
+
+func (st *State) branches(query bson.D) ([]*Branch, error) {
+	coll, closer := st.db().GetCollection(branchesC)
+	defer closer()
+
+	var docs []branchDoc
+	if err := coll.Find(query).Sort("created").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get branches")
+	}
+	result := make([]*Branch, len(docs))
+	for i, doc := range docs {
+		result[i] = &Branch{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// unitBranch returns the branch to which the named unit of the named
+// application is assigned, or nil if it is not assigned to one.
+func (st *State) unitBranch(appName, unitName string) (*Branch, error) {
+	branches, err := st.branches(bson.D{{"assigned-units." + appName, unitName}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(branches) == 0 {
+		return nil, nil
+	}
+	return branches[0], nil
+}
+
+// UpdateCharmConfig stages changes to the named application's charm
+// config in the branch, merging them with any changes already staged.
+// Values set to nil reset the option to the charm default when the
+// branch is committed; unknown and invalid values return an error.
+func (b *Branch) UpdateCharmConfig(appName string, changes charm.Settings) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot update config of application %q in branch %q", appName, b.doc.Name)
+	app, err := b.st.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := b.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application is not alive")
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		validated, err := ch.Config().ValidateSettings(changes)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		staged := copyMap(b.doc.Config[appName], nil)
+		if staged == nil {
+			staged = make(map[string]interface{})
+		}
+		for name, value := range validated {
+			staged[name] = value
+		}
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: app.doc.DocID,
+			// The staged values are only valid for the current charm.
+			Assert: bson.D{
+				{"life", Alive},
+				{"charmurl", app.doc.CharmURL},
+			},
+		}, {
+			C:      branchesC,
+			Id:     b.doc.DocID,
+			Assert: bson.D{{"txn-revno", b.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"config." + appName, settingsMap(copyMap(staged, escapeReplacer.Replace))},
+			}}},
+		}}, nil
+	}
+	if err := b.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return b.Refresh()
+}
+
+// AssignUnit assigns the named unit to the branch, so that it sees the
+// config changes staged in the branch for its application. A unit can
+// be assigned to only one branch at a time.
+func (b *Branch) AssignUnit(unitName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot assign unit %q to branch %q", unitName, b.doc.Name)
+	if !names.IsValidUnit(unitName) {
+		return errors.NotValidf("unit name %q", unitName)
+	}
+	unit, err := b.st.Unit(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appName := unit.ApplicationName()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := b.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		for _, assigned := range b.doc.AssignedUnits[appName] {
+			if assigned == unitName {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		current, err := b.st.unitBranch(appName, unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current != nil {
+			return nil, errors.Errorf("unit is assigned to branch %q", current.Name())
+		}
+		branches, err := b.st.AllBranches()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     unit.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      branchesC,
+			Id:     b.doc.DocID,
+			Assert: bson.D{{"txn-revno", b.doc.TxnRevno}},
+			Update: bson.D{{"$addToSet", bson.D{
+				{"assigned-units." + appName, unitName},
+			}}},
+		}}
+		for _, other := range branches {
+			if other.doc.DocID == b.doc.DocID {
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      branchesC,
+				Id:     other.doc.DocID,
+				Assert: bson.D{{"assigned-units." + appName, bson.D{{"$ne", unitName}}}},
+			})
+		}
+		return ops, nil
+	}
+	if err := b.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return b.Refresh()
+}
+
+// Commit applies the config changes staged in the branch to their
+// applications, so that all of their units see them, and removes the
+// branch. The changes are recorded in the applications' charm history
+// as made by the named user. Changes staged for applications that have
+// since been removed are discarded.
+func (b *Branch) Commit(user string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot commit branch %q", b.doc.Name)
+	var changed []*Application
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := b.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		changed = nil
+		appNames := make([]string, 0, len(b.doc.Config))
+		for appName := range b.doc.Config {
+			appNames = append(appNames, appName)
+		}
+		sort.Strings(appNames)
+		var ops []txn.Op
+		for _, appName := range appNames {
+			app, err := b.st.Application(appName)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			node, err := readSettings(b.st.db(), settingsC, app.settingsKey())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for name, value := range b.doc.Config[appName] {
+				if value == nil {
+					node.Delete(name)
+				} else {
+					node.Set(name, value)
+				}
+			}
+			itemChanges, settingsOps := node.settingsUpdateOps()
+			if len(itemChanges) == 0 {
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      applicationsC,
+				Id:     app.doc.DocID,
+				Assert: bson.D{{"charmurl", app.doc.CharmURL}},
+			}, node.assertUnchangedOp())
+			ops = append(ops, settingsOps...)
+			changed = append(changed, app)
+		}
+		ops = append(ops, txn.Op{
+			C:      branchesC,
+			Id:     b.doc.DocID,
+			Assert: bson.D{{"txn-revno", b.doc.TxnRevno}},
+			Remove: true,
+		})
+		return ops, nil
+	}
+	if err := b.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range changed {
+		if err := app.recordCharmHistory(user); err != nil {
+			logger.Errorf("cannot record charm history for application %q: %v", app.doc.Name, err)
+		}
+	}
+	return nil
+}
+
+// Abort removes the branch, discarding the config changes staged in
+// it. Units assigned to the branch revert to their application's
+// config.
+func (b *Branch) Abort() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot abort branch %q", b.doc.Name)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := b.st.Branch(b.doc.Name); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		return []txn.Op{{
+			C:      branchesC,
+			Id:     b.doc.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	return errors.Trace(b.st.db().Run(buildTxn))
+}
+
+// unstagedBranchConfigOps returns operations asserting that no branch
+// has config changes staged for the application, or an error if one
+// does. Staged changes are validated against the application's current
+// charm, so the charm cannot be changed while any are pending.
+func (a *Application) unstagedBranchConfigOps() ([]txn.Op, error) {
+	branches, err := a.st.AllBranches()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, branch := range branches {
+		if _, ok := branch.doc.Config[a.doc.Name]; ok {
+			return nil, errors.Errorf("config changes are staged in branch %q; commit or abort it first", branch.Name())
+		}
+		ops = append(ops, txn.Op{
+			C:      branchesC,
+			Id:     branch.doc.DocID,
+			Assert: bson.D{{"config." + a.doc.Name, bson.D{{"$exists", false}}}},
+		})
+	}
+	return ops, nil
+}
@@ -0,0 +1,259 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type BranchSuite struct {
+	ConnSuite
+	charm       *state.Charm
+	application *state.Application
+	units       []*state.Unit
+}
+
+var _ = gc.Suite(&BranchSuite{})
+
+func (s *BranchSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingApplication(c, "dummy", s.charm)
+	s.units = nil
+	for i := 0; i < 2; i++ {
+		unit, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(s.charm.URL())
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+}
+
+func (s *BranchSuite) assertTitle(c *gc.C, unit *state.Unit, title string) {
+	settings, err := unit.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings["title"], gc.Equals, title)
+}
+
+func (s *BranchSuite) TestAddBranch(c *gc.C) {
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.Name(), gc.Equals, "canary")
+	c.Assert(branch.CreatedBy(), gc.Equals, "bob")
+	c.Assert(branch.Created(), gc.Equals, s.Clock.Now().UTC())
+	c.Assert(branch.Config(), gc.HasLen, 0)
+	c.Assert(branch.AssignedUnits(), gc.HasLen, 0)
+
+	branches, err := s.State.AllBranches()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branches, gc.HasLen, 1)
+	c.Assert(branches[0].Name(), gc.Equals, "canary")
+}
+
+func (s *BranchSuite) TestAddBranchInvalidName(c *gc.C) {
+	_, err := s.State.AddBranch("Not Valid", "bob")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *BranchSuite) TestAddBranchExists(c *gc.C) {
+	_, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddBranch("canary", "mary")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *BranchSuite) TestBranchNotFound(c *gc.C) {
+	_, err := s.State.Branch("canary")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BranchSuite) TestUpdateCharmConfig(c *gc.C) {
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("dummy", charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("dummy", charm.Settings{"username": nil})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.Config(), jc.DeepEquals, map[string]charm.Settings{
+		"dummy": {"title": "foo", "username": nil},
+	})
+
+	// The application's config is unchanged.
+	settings, err := s.application.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+}
+
+func (s *BranchSuite) TestUpdateCharmConfigInvalid(c *gc.C) {
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("dummy", charm.Settings{"skill-level": "high"})
+	c.Assert(err, gc.ErrorMatches, `cannot update config of application "dummy" in branch "canary": .*`)
+	err = branch.UpdateCharmConfig("wat", charm.Settings{"title": "foo"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BranchSuite) TestAssignedUnitSeesStagedConfig(c *gc.C) {
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("dummy", charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.AssignUnit("dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignedUnits(), jc.DeepEquals, map[string][]string{
+		"dummy": {"dummy/0"},
+	})
+
+	s.assertTitle(c, s.units[0], "foo")
+	s.assertTitle(c, s.units[1], "My Title")
+
+	// Assigning the unit again is a no-op.
+	err = branch.AssignUnit("dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignedUnits()["dummy"], gc.HasLen, 1)
+}
+
+func (s *BranchSuite) TestStagedResetToDefault(c *gc.C) {
+	err := s.application.UpdateConfigSettings(charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("dummy", charm.Settings{"title": nil})
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.AssignUnit("dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertTitle(c, s.units[0], "My Title")
+	s.assertTitle(c, s.units[1], "foo")
+}
+
+func (s *BranchSuite) TestAssignUnitToSecondBranch(c *gc.C) {
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.AssignUnit("dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	other, err := s.State.AddBranch("other", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.AssignUnit("dummy/0")
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "dummy/0" to branch "other": unit is assigned to branch "canary"`)
+}
+
+func (s *BranchSuite) TestAssignUnitNotFound(c *gc.C) {
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.AssignUnit("dummy/9")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BranchSuite) TestCommit(c *gc.C) {
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("dummy", charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.AssignUnit("dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = branch.Commit("mary")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Branch("canary")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	settings, err := s.application.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"title": "foo"})
+	s.assertTitle(c, s.units[0], "foo")
+	s.assertTitle(c, s.units[1], "foo")
+
+	history, err := s.application.CharmHistory(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history[0].User, gc.Equals, "mary")
+	c.Assert(history[0].Config, jc.DeepEquals, charm.Settings{"title": "foo"})
+}
+
+func (s *BranchSuite) TestCommitRemovedApplication(c *gc.C) {
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "other", s.charm)
+	err = branch.UpdateCharmConfig("other", charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = branch.Commit("mary")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Branch("canary")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BranchSuite) TestAbort(c *gc.C) {
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("dummy", charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.AssignUnit("dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = branch.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Branch("canary")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	s.assertTitle(c, s.units[0], "My Title")
+
+	err = branch.Abort()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BranchSuite) TestSetCharmWithStagedConfig(c *gc.C) {
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = branch.UpdateCharmConfig("dummy", charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newCharm := s.AddConfigCharm(c, "dummy", `
+options:
+  title: {default: My Title, description: title, type: string}
+`[1:], 2)
+	err = s.application.SetCharm(state.SetCharmConfig{Charm: newCharm})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "dummy" to charm ".*": config changes are staged in branch "canary"; commit or abort it first`)
+
+	err = branch.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetCharm(state.SetCharmConfig{Charm: newCharm})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *BranchSuite) TestWatchConfigSettings(c *gc.C) {
+	branch, err := s.State.AddBranch("canary", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.units[0].WatchConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Changes to a branch the unit isn't assigned to are not reported.
+	err = branch.UpdateCharmConfig("dummy", charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = branch.AssignUnit("dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = branch.UpdateCharmConfig("dummy", charm.Settings{"title": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = branch.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
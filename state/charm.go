@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/mongo"
 	mongoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/state/storage"
@@ -81,6 +82,55 @@ type charmDoc struct {
 	Config  *charm.Config  `bson:"config"`
 	Actions *charm.Actions `bson:"actions"`
 	Metrics *charm.Metrics `bson:"metrics"`
+
+	// LXDProfile holds the LXD profile declared by the charm, if any.
+	LXDProfile *lxdProfileDoc `bson:"lxd-profile,omitempty"`
+
+	// RequiredFeatures holds the Juju features that the charm
+	// declares it requires.
+	RequiredFeatures []string `bson:"required-features,omitempty"`
+}
+
+// lxdProfileDoc represents the LXD profile declared by a charm. Config
+// keys are escaped, as they commonly contain ".".
+type lxdProfileDoc struct {
+	Description string                       `bson:"description,omitempty"`
+	Config      map[string]string            `bson:"config,omitempty"`
+	Devices     map[string]map[string]string `bson:"devices,omitempty"`
+}
+
+func newLXDProfileDoc(profile *lxdprofile.Profile) *lxdProfileDoc {
+	if profile.Empty() {
+		return nil
+	}
+	doc := &lxdProfileDoc{
+		Description: profile.Description,
+		Devices:     profile.Devices,
+	}
+	if len(profile.Config) > 0 {
+		doc.Config = make(map[string]string)
+		for key, value := range profile.Config {
+			doc.Config[escapeReplacer.Replace(key)] = value
+		}
+	}
+	return doc
+}
+
+func (doc *lxdProfileDoc) toProfile() *lxdprofile.Profile {
+	if doc == nil {
+		return nil
+	}
+	profile := &lxdprofile.Profile{
+		Description: doc.Description,
+		Devices:     doc.Devices,
+	}
+	if len(doc.Config) > 0 {
+		profile.Config = make(map[string]string)
+		for key, value := range doc.Config {
+			profile.Config[unescapeReplacer.Replace(key)] = value
+		}
+	}
+	return profile
 }
 
 // CharmInfo contains all the data necessary to store a charm's metadata.
@@ -90,6 +140,11 @@ type CharmInfo struct {
 	StoragePath string
 	SHA256      string
 	Macaroon    macaroon.Slice
+	LXDProfile  *lxdprofile.Profile
+
+	// RequiredFeatures holds the Juju features that the charm
+	// declares it requires.
+	RequiredFeatures []string
 }
 
 // insertCharmOps returns the txn operations necessary to insert the supplied
@@ -100,14 +155,16 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 	}
 
 	doc := charmDoc{
-		DocID:        info.ID.String(),
-		URL:          info.ID,
-		Meta:         info.Charm.Meta(),
-		Config:       safeConfig(info.Charm),
-		Metrics:      info.Charm.Metrics(),
-		Actions:      info.Charm.Actions(),
-		BundleSha256: info.SHA256,
-		StoragePath:  info.StoragePath,
+		DocID:            info.ID.String(),
+		URL:              info.ID,
+		Meta:             info.Charm.Meta(),
+		Config:           safeConfig(info.Charm),
+		Metrics:          info.Charm.Metrics(),
+		Actions:          info.Charm.Actions(),
+		BundleSha256:     info.SHA256,
+		StoragePath:      info.StoragePath,
+		LXDProfile:       newLXDProfileDoc(info.LXDProfile),
+		RequiredFeatures: info.RequiredFeatures,
 	}
 	if err := checkCharmDataIsStorable(doc); err != nil {
 		return nil, errors.Trace(err)
@@ -214,6 +271,8 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 		{"bundlesha256", info.SHA256},
 		{"pendingupload", false},
 		{"placeholder", false},
+		{"lxd-profile", newLXDProfileDoc(info.LXDProfile)},
+		{"required-features", info.RequiredFeatures},
 	}
 	if err := checkCharmDataIsStorable(data); err != nil {
 		return nil, errors.Trace(err)
@@ -454,6 +513,18 @@ func (c *Charm) Actions() *charm.Actions {
 	return c.doc.Actions
 }
 
+// LXDProfile returns the LXD profile declared by the charm, or nil
+// if it declares none.
+func (c *Charm) LXDProfile() *lxdprofile.Profile {
+	return c.doc.LXDProfile.toProfile()
+}
+
+// RequiredFeatures returns the Juju features that the charm declares
+// it requires.
+func (c *Charm) RequiredFeatures() []string {
+	return c.doc.RequiredFeatures
+}
+
 // StoragePath returns the storage path of the charm bundle.
 func (c *Charm) StoragePath() string {
 	return c.doc.StoragePath
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/macaroon.v1"
 	"gopkg.in/mgo.v2"
 
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testcharms"
@@ -294,6 +295,41 @@ func (s *CharmSuite) TestAddCharmWithAuth(c *gc.C) {
 	c.Assert(ms, gc.DeepEquals, info.Macaroon)
 }
 
+func (s *CharmSuite) TestAddCharmWithLXDProfile(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	info.LXDProfile = &lxdprofile.Profile{
+		Description: "KVM acceleration",
+		Config:      map[string]string{"security.nesting": "true"},
+		Devices: map[string]map[string]string{
+			"kvm": {"type": "unix-char", "path": "/dev/kvm"},
+		},
+	}
+	_, err := s.State.AddCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	dummy, err := s.State.Charm(info.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.LXDProfile(), jc.DeepEquals, info.LXDProfile)
+}
+
+func (s *CharmSuite) TestAddCharmWithoutLXDProfile(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	dummy, err := s.State.AddCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.LXDProfile(), gc.IsNil)
+}
+
+func (s *CharmSuite) TestAddCharmWithRequiredFeatures(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	info.RequiredFeatures = []string{"secrets", "spaces"}
+	_, err := s.State.AddCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	dummy, err := s.State.Charm(info.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.RequiredFeatures(), jc.DeepEquals, []string{"secrets", "spaces"})
+}
+
 func (s *CharmSuite) TestAddCharmUpdatesPlaceholder(c *gc.C) {
 	// Check that adding charms updates any existing placeholder charm
 	// with the same URL.
@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/mongo"
+)
+
+// MaxCharmHistory is the number of charm history entries kept for
+// each application.
+const MaxCharmHistory = 100
+
+// CharmHistoryEntry records the charm and config of an application
+// after it was deployed or changed.
+type CharmHistoryEntry struct {
+	// CharmURL is the URL of the application's charm.
+	CharmURL *charm.URL
+
+	// ConfigVersion is the version of the application's config for
+	// that charm, which increases every time the config changes.
+	ConfigVersion int64
+
+	// Config holds the charm config values set by the user.
+	Config charm.Settings
+
+	// User is the name of the user that made the change, if known.
+	User string
+
+	// Time is when the change was made.
+	Time time.Time
+}
+
+type charmHistoryDoc struct {
+	ModelUUID     string      `bson:"model-uuid"`
+	Application   string      `bson:"application"`
+	CharmURL      string      `bson:"charm-url"`
+	ConfigVersion int64       `bson:"config-version"`
+	Config        settingsMap `bson:"config"`
+	User          string      `bson:"user,omitempty"`
+	Time          int64       `bson:"time"`
+}
+
+// recordCharmHistory records the application's current charm and
+// config in its charm history, as changed by the named user.
+func (a *Application) recordCharmHistory(user string) error {
+	settings, err := readSettingsDoc(a.st.db(), settingsC, a.settingsKey())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	doc := &charmHistoryDoc{
+		ModelUUID:     a.st.ModelUUID(),
+		Application:   a.doc.Name,
+		CharmURL:      a.doc.CharmURL.String(),
+		ConfigVersion: settings.Version,
+		Config:        settingsMap(copyMap(settings.Settings, escapeReplacer.Replace)),
+		User:          user,
+		Time:          a.st.clock().Now().UnixNano(),
+	}
+
+	coll, closer := a.st.db().GetCollection(charmHistoryC)
+	defer closer()
+	if err := coll.Writeable().Insert(doc); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(pruneCharmHistory(coll, a.doc.Name))
+}
+
+// pruneCharmHistory removes all but the most recent MaxCharmHistory
+// entries for the application.
+func pruneCharmHistory(coll mongo.Collection, appName string) error {
+	var oldest charmHistoryDoc
+	err := coll.Find(bson.D{{"application", appName}}).Sort("-time").Skip(MaxCharmHistory - 1).One(&oldest)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = coll.Writeable().RemoveAll(bson.D{
+		{"application", appName},
+		{"time", bson.D{{"$lt", oldest.Time}}},
+	})
+	return errors.Trace(err)
+}
+
+// CharmHistory returns up to limit of the most recent changes to the
+// application's charm and config, newest first. All the recorded
+// changes are returned if limit is not positive.
+func (a *Application) CharmHistory(limit int) ([]CharmHistoryEntry, error) {
+	coll, closer := a.st.db().GetCollection(charmHistoryC)
+	defer closer()
+
+	query := coll.Find(bson.D{{"application", a.doc.Name}}).Sort("-time")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var docs []charmHistoryDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm history for application %q", a.doc.Name)
+	}
+	entries := make([]CharmHistoryEntry, len(docs))
+	for i, doc := range docs {
+		curl, err := charm.ParseURL(doc.CharmURL)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries[i] = CharmHistoryEntry{
+			CharmURL:      curl,
+			ConfigVersion: doc.ConfigVersion,
+			Config:        charm.Settings(doc.Config),
+			User:          doc.User,
+			Time:          time.Unix(0, doc.Time).UTC(),
+		}
+	}
+	return entries, nil
+}
+
+// eraseCharmHistory removes the charm history of the named
+// application, so that it is not inherited by a later application of
+// the same name.
+func eraseCharmHistory(mb modelBackend, appName string) error {
+	coll, closer := mb.db().GetCollection(charmHistoryC)
+	defer closer()
+
+	_, err := coll.Writeable().RemoveAll(bson.D{{"application", appName}})
+	return errors.Trace(err)
+}
@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+type CharmHistorySuite struct {
+	ConnSuite
+	charm       *state.Charm
+	application *state.Application
+}
+
+var _ = gc.Suite(&CharmHistorySuite{})
+
+func (s *CharmHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingApplication(c, "dummy", s.charm)
+}
+
+func (s *CharmHistorySuite) TestDeployRecorded(c *gc.C) {
+	history, err := s.application.CharmHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].CharmURL, jc.DeepEquals, s.charm.URL())
+	c.Assert(history[0].Config, gc.HasLen, 0)
+	c.Assert(history[0].User, gc.Equals, "")
+	c.Assert(history[0].Time, gc.Equals, s.Clock.Now().UTC())
+}
+
+func (s *CharmHistorySuite) TestConfigChangeRecorded(c *gc.C) {
+	s.Clock.Advance(time.Minute)
+	err := s.application.UpdateConfigSettingsBy(charm.Settings{"title": "foo"}, "bob")
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.application.CharmHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].CharmURL, jc.DeepEquals, s.charm.URL())
+	c.Assert(history[0].Config, jc.DeepEquals, charm.Settings{"title": "foo"})
+	c.Assert(history[0].User, gc.Equals, "bob")
+	c.Assert(history[0].Time, gc.Equals, s.Clock.Now().UTC())
+	c.Assert(history[0].ConfigVersion > history[1].ConfigVersion, jc.IsTrue)
+}
+
+func (s *CharmHistorySuite) TestUnchangedConfigNotRecorded(c *gc.C) {
+	err := s.application.UpdateConfigSettingsBy(charm.Settings{"title": "foo"}, "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.UpdateConfigSettingsBy(charm.Settings{"title": "foo"}, "bob")
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.application.CharmHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+}
+
+func (s *CharmHistorySuite) TestSetCharmRecorded(c *gc.C) {
+	newCharm := s.AddConfigCharm(c, "dummy", `
+options:
+  title: {default: My Title, description: title, type: string}
+`[1:], 2)
+	err := s.application.UpdateConfigSettings(charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	err = s.application.SetCharm(state.SetCharmConfig{
+		Charm:     newCharm,
+		ChangedBy: "mary",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.application.CharmHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Assert(history[0].CharmURL, jc.DeepEquals, newCharm.URL())
+	c.Assert(history[0].Config, jc.DeepEquals, charm.Settings{"title": "foo"})
+	c.Assert(history[0].User, gc.Equals, "mary")
+	c.Assert(history[1].CharmURL, jc.DeepEquals, s.charm.URL())
+	c.Assert(history[1].User, gc.Equals, "")
+
+	// Setting the same charm again doesn't add to the history.
+	err = s.application.SetCharm(state.SetCharmConfig{Charm: newCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	history, err = s.application.CharmHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+}
+
+func (s *CharmHistorySuite) TestCharmHistoryLimit(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		s.Clock.Advance(time.Second)
+		err := s.application.UpdateConfigSettings(charm.Settings{"title": fmt.Sprintf("title-%d", i)})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.application.CharmHistory(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Config, jc.DeepEquals, charm.Settings{"title": "title-2"})
+	c.Assert(history[1].Config, jc.DeepEquals, charm.Settings{"title": "title-1"})
+}
+
+func (s *CharmHistorySuite) TestCharmHistoryPruned(c *gc.C) {
+	for i := 0; i < state.MaxCharmHistory+10; i++ {
+		s.Clock.Advance(time.Second)
+		err := s.application.UpdateConfigSettings(charm.Settings{"title": fmt.Sprintf("title-%d", i)})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.application.CharmHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, state.MaxCharmHistory)
+	c.Assert(history[0].Config["title"], gc.Equals, fmt.Sprintf("title-%d", state.MaxCharmHistory+9))
+	c.Assert(history[len(history)-1].Config["title"], gc.Equals, "title-10")
+}
+
+func (s *CharmHistorySuite) TestRedeployStartsAfresh(c *gc.C) {
+	err := s.application.UpdateConfigSettings(charm.Settings{"title": "foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.application = s.AddTestingApplication(c, "dummy", s.charm)
+	history, err := s.application.CharmHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Config, gc.HasLen, 0)
+}
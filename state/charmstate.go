@@ -0,0 +1,157 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// MaxCharmStateSnapshotSize is the largest charm state snapshot that
+// may be stored for a unit, in bytes.
+const MaxCharmStateSnapshotSize = 8 * 1024 * 1024
+
+// CharmStateSnapshot holds the charm-local state exported by a unit, so
+// that it can be restored on another unit of the same application.
+type CharmStateSnapshot struct {
+	doc charmStateSnapshotDoc
+}
+
+type charmStateSnapshotDoc struct {
+	DocId       string    `bson:"_id"`
+	ModelUUID   string    `bson:"model-uuid"`
+	Unit        string    `bson:"unit"`
+	Application string    `bson:"application"`
+	Data        []byte    `bson:"data"`
+	Created     time.Time `bson:"created"`
+}
+
+// Unit returns the name of the unit that exported the snapshot.
+func (s *CharmStateSnapshot) Unit() string {
+	return s.doc.Unit
+}
+
+// Application returns the name of the application of the unit that
+// exported the snapshot.
+func (s *CharmStateSnapshot) Application() string {
+	return s.doc.Application
+}
+
+// Data returns the snapshot's content. It is opaque to juju.
+func (s *CharmStateSnapshot) Data() []byte {
+	return s.doc.Data
+}
+
+// Created returns the time the snapshot was exported.
+func (s *CharmStateSnapshot) Created() time.Time {
+	return s.doc.Created
+}
+
+// SetCharmStateSnapshot stores a snapshot of the unit's charm-local
+// state, replacing any snapshot it previously stored. The snapshot is
+// kept after the unit is removed, until its application is removed,
+// so that a failed unit's state can be restored on its replacement.
+func (u *Unit) SetCharmStateSnapshot(data []byte) error {
+	if len(data) > MaxCharmStateSnapshotSize {
+		return errors.NotValidf(
+			"charm state snapshot of %d bytes (maximum %d)",
+			len(data), MaxCharmStateSnapshotSize,
+		)
+	}
+	doc := charmStateSnapshotDoc{
+		DocId:       u.st.docID(u.Name()),
+		ModelUUID:   u.st.ModelUUID(),
+		Unit:        u.Name(),
+		Application: u.ApplicationName(),
+		Data:        data,
+		Created:     u.st.nowToTheSecond(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() != Alive {
+			return nil, errors.Errorf("unit %q is not alive", u.Name())
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		_, err := u.st.CharmStateSnapshot(u.Name())
+		switch {
+		case errors.IsNotFound(err):
+			ops = append(ops, txn.Op{
+				C:      charmStateSnapshotsC,
+				Id:     doc.DocId,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			})
+		case err != nil:
+			return nil, errors.Trace(err)
+		default:
+			ops = append(ops, txn.Op{
+				C:      charmStateSnapshotsC,
+				Id:     doc.DocId,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$set", bson.D{
+					{"data", doc.Data},
+					{"created", doc.Created},
+				}}},
+			})
+		}
+		return ops, nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set charm state snapshot for unit %q", u.Name())
+	}
+	return nil
+}
+
+// CharmStateSnapshot returns the charm state snapshot last exported by
+// the named unit, which need no longer exist.
+func (st *State) CharmStateSnapshot(unitName string) (*CharmStateSnapshot, error) {
+	coll, closer := st.db().GetCollection(charmStateSnapshotsC)
+	defer closer()
+
+	var doc charmStateSnapshotDoc
+	err := coll.FindId(unitName).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("charm state snapshot for unit %q", unitName)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm state snapshot for unit %q", unitName)
+	}
+	return &CharmStateSnapshot{doc: doc}, nil
+}
+
+// removeCharmStateSnapshotsOps returns the operations that remove the
+// charm state snapshots exported by the units of the named application.
+func removeCharmStateSnapshotsOps(st *State, application string) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(charmStateSnapshotsC)
+	defer closer()
+
+	var docs []struct {
+		DocId string `bson:"_id"`
+	}
+	err := coll.Find(bson.D{{"application", application}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm state snapshots for application %q", application)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      charmStateSnapshotsC,
+			Id:     doc.DocId,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
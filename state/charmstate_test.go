@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type CharmStateSnapshotSuite struct {
+	ConnSuite
+	application *state.Application
+	unit        *state.Unit
+}
+
+var _ = gc.Suite(&CharmStateSnapshotSuite{})
+
+func (s *CharmStateSnapshotSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingApplication(c, "dummy", ch)
+	var err error
+	s.unit, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmStateSnapshotSuite) TestSetCharmStateSnapshot(c *gc.C) {
+	err := s.unit.SetCharmStateSnapshot([]byte("first"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	err = s.unit.SetCharmStateSnapshot([]byte("second"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshot, err := s.State.CharmStateSnapshot("dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Unit(), gc.Equals, "dummy/0")
+	c.Assert(snapshot.Application(), gc.Equals, "dummy")
+	c.Assert(string(snapshot.Data()), gc.Equals, "second")
+	c.Assert(snapshot.Created().After(time.Time{}), jc.IsTrue)
+}
+
+func (s *CharmStateSnapshotSuite) TestSetCharmStateSnapshotTooLarge(c *gc.C) {
+	data := make([]byte, state.MaxCharmStateSnapshotSize+1)
+	err := s.unit.SetCharmStateSnapshot(data)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *CharmStateSnapshotSuite) TestSetCharmStateSnapshotDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmStateSnapshot([]byte("data"))
+	c.Assert(err, gc.ErrorMatches, `cannot set charm state snapshot for unit "dummy/0": unit "dummy/0" is not alive`)
+}
+
+func (s *CharmStateSnapshotSuite) TestCharmStateSnapshotNotFound(c *gc.C) {
+	_, err := s.State.CharmStateSnapshot("dummy/0")
+	c.Assert(err, gc.ErrorMatches, `charm state snapshot for unit "dummy/0" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmStateSnapshotSuite) TestSnapshotOutlivesUnit(c *gc.C) {
+	err := s.unit.SetCharmStateSnapshot([]byte("data"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshot, err := s.State.CharmStateSnapshot("dummy/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(snapshot.Data()), gc.Equals, "data")
+}
+
+func (s *CharmStateSnapshotSuite) TestSnapshotRemovedWithApplication(c *gc.C) {
+	err := s.unit.SetCharmStateSnapshot([]byte("data"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.CharmStateSnapshot("dummy/0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,534 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/status"
+)
+
+// CharmUpgradeStatus represents the state of a rolling charm upgrade.
+type CharmUpgradeStatus string
+
+const (
+	// CharmUpgradeRunning indicates that the upgrade is moving from
+	// batch to batch.
+	CharmUpgradeRunning CharmUpgradeStatus = "running"
+
+	// CharmUpgradePaused indicates that no more units will be
+	// upgraded until the upgrade is resumed.
+	CharmUpgradePaused CharmUpgradeStatus = "paused"
+
+	// CharmUpgradeCompleted indicates that every unit has been
+	// upgraded.
+	CharmUpgradeCompleted CharmUpgradeStatus = "completed"
+)
+
+const (
+	// CharmUpgradeReadinessWorkload waits for each upgraded unit to
+	// report an active workload status before the next batch starts.
+	CharmUpgradeReadinessWorkload = "workload-active"
+
+	// CharmUpgradeReadinessActionPrefix prefixes the name of an
+	// action that is run on each upgraded unit, and that must complete
+	// before the next batch starts, e.g. "action:health-check".
+	CharmUpgradeReadinessActionPrefix = "action:"
+)
+
+// RollingCharmUpgrade holds the arguments for upgrading the units of
+// an application to a new charm in batches, instead of all at once.
+type RollingCharmUpgrade struct {
+	// BatchSize is the number of units upgraded at the same time.
+	BatchSize int
+
+	// Readiness determines when the units of a batch are ready, and
+	// the next batch may start. It is either
+	// CharmUpgradeReadinessWorkload, which is the default, or an
+	// action name prefixed with CharmUpgradeReadinessActionPrefix.
+	Readiness string
+}
+
+// Validate returns an error if the arguments are not valid for
+// upgrading to the given charm.
+func (r RollingCharmUpgrade) Validate(ch *Charm) error {
+	if r.BatchSize <= 0 {
+		return errors.NotValidf("non-positive batch size")
+	}
+	switch {
+	case r.Readiness == "", r.Readiness == CharmUpgradeReadinessWorkload:
+	case strings.HasPrefix(r.Readiness, CharmUpgradeReadinessActionPrefix):
+		name := strings.TrimPrefix(r.Readiness, CharmUpgradeReadinessActionPrefix)
+		if _, ok := actions.PredefinedActionsSpec[name]; ok {
+			break
+		}
+		if ch.Actions() == nil {
+			return errors.NotValidf("readiness action %q", name)
+		}
+		if _, ok := ch.Actions().ActionSpecs[name]; !ok {
+			return errors.NotValidf("readiness action %q", name)
+		}
+	default:
+		return errors.NotValidf("readiness %q", r.Readiness)
+	}
+	return nil
+}
+
+// CharmUpgrade describes the rolling upgrade of an application's units
+// to its current charm.
+type CharmUpgrade struct {
+	doc charmUpgradeDoc
+}
+
+// charmUpgradeDoc is embedded in the application document, so that
+// the application's units are notified when they are reached.
+type charmUpgradeDoc struct {
+	// PreviousCharmURL is the charm that the units not yet reached
+	// continue to run.
+	PreviousCharmURL *charm.URL `bson:"previous-charmurl"`
+
+	// PreviousChannel is the charm store channel of the previous
+	// charm.
+	PreviousChannel string `bson:"previous-cs-channel"`
+
+	// PreviousCharmModifiedVersion is the application's charm
+	// modified version before the upgrade.
+	PreviousCharmModifiedVersion int `bson:"previous-charmmodifiedversion"`
+
+	// BatchSize is the number of units upgraded at the same time.
+	BatchSize int `bson:"batch-size"`
+
+	// Readiness determines when the units of a batch are ready.
+	Readiness string `bson:"readiness"`
+
+	// Status is the state of the upgrade.
+	Status CharmUpgradeStatus `bson:"status"`
+
+	// Message explains why the upgrade was paused, if it was not
+	// paused by the user.
+	Message string `bson:"message,omitempty"`
+
+	// Pending holds the names of the units still running the previous
+	// charm, in the order they will be upgraded.
+	Pending []string `bson:"pending"`
+
+	// Upgrading holds the names of the units of the current batch.
+	Upgrading []string `bson:"upgrading"`
+
+	// Upgraded holds the names of the units that have been upgraded
+	// and found ready.
+	Upgraded []string `bson:"upgraded"`
+
+	// ReadinessActions maps the names of the units of the current
+	// batch to the ids of their readiness actions.
+	ReadinessActions map[string]string `bson:"readiness-actions,omitempty"`
+}
+
+// PreviousCharmURL returns the charm that the units not yet upgraded
+// continue to run.
+func (u *CharmUpgrade) PreviousCharmURL() *charm.URL {
+	return u.doc.PreviousCharmURL
+}
+
+// BatchSize returns the number of units upgraded at the same time.
+func (u *CharmUpgrade) BatchSize() int {
+	return u.doc.BatchSize
+}
+
+// Readiness returns the check that determines when the units of a
+// batch are ready.
+func (u *CharmUpgrade) Readiness() string {
+	return u.doc.Readiness
+}
+
+// Status returns the state of the upgrade.
+func (u *CharmUpgrade) Status() CharmUpgradeStatus {
+	return u.doc.Status
+}
+
+// Message returns the reason the upgrade was paused, if it was paused
+// by the controller rather than the user.
+func (u *CharmUpgrade) Message() string {
+	return u.doc.Message
+}
+
+// Pending returns the names of the units still running the previous
+// charm.
+func (u *CharmUpgrade) Pending() []string {
+	return u.doc.Pending
+}
+
+// Upgrading returns the names of the units of the current batch.
+func (u *CharmUpgrade) Upgrading() []string {
+	return u.doc.Upgrading
+}
+
+// Upgraded returns the names of the units that have been upgraded and
+// found ready.
+func (u *CharmUpgrade) Upgraded() []string {
+	return u.doc.Upgraded
+}
+
+// CharmUpgrade returns the application's most recent rolling charm
+// upgrade, and whether there is one. It is forgotten when the charm
+// is next changed.
+func (a *Application) CharmUpgrade() (*CharmUpgrade, bool) {
+	if a.doc.CharmUpgrade == nil {
+		return nil, false
+	}
+	return &CharmUpgrade{doc: *a.doc.CharmUpgrade}, true
+}
+
+// UnitCharmURL returns the charm that the named unit of the
+// application should run, and whether it should upgrade to it even if
+// it is in an error state. This is the application's charm unless a
+// rolling charm upgrade has yet to reach the unit.
+func (a *Application) UnitCharmURL(unitName string) (*charm.URL, bool) {
+	if a.charmUpgradePending(unitName) {
+		return a.doc.CharmUpgrade.PreviousCharmURL, false
+	}
+	return a.CharmURL()
+}
+
+// UnitCharmModifiedVersion returns the charm modified version seen by
+// the named unit of the application; see UnitCharmURL.
+func (a *Application) UnitCharmModifiedVersion(unitName string) int {
+	if a.charmUpgradePending(unitName) {
+		return a.doc.CharmUpgrade.PreviousCharmModifiedVersion
+	}
+	return a.CharmModifiedVersion()
+}
+
+func (a *Application) charmUpgradePending(unitName string) bool {
+	if a.doc.CharmUpgrade == nil {
+		return false
+	}
+	for _, name := range a.doc.CharmUpgrade.Pending {
+		if name == unitName {
+			return true
+		}
+	}
+	return false
+}
+
+// charmUpgradeOps returns the operations that record the rolling
+// upgrade requested by cfg, or that forget a previous one if cfg does
+// not request a rolling upgrade. It also returns the recorded upgrade.
+func (a *Application) charmUpgradeOps(cfg SetCharmConfig) ([]txn.Op, *charmUpgradeDoc, error) {
+	if cfg.RollingUpgrade == nil {
+		if a.doc.CharmUpgrade == nil {
+			return nil, nil, nil
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Update: bson.D{{"$unset", bson.D{{"charm-upgrade", nil}}}},
+		}}, nil, nil
+	}
+	if a.doc.CharmUpgrade != nil && a.doc.CharmUpgrade.Status != CharmUpgradeCompleted {
+		return nil, nil, errors.Errorf("rolling charm upgrade in progress")
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var pending []string
+	for _, unit := range units {
+		if unit.Life() == Alive {
+			pending = append(pending, unit.Name())
+		}
+	}
+	sortUnitNames(pending)
+	readiness := cfg.RollingUpgrade.Readiness
+	if readiness == "" {
+		readiness = CharmUpgradeReadinessWorkload
+	}
+	doc := &charmUpgradeDoc{
+		PreviousCharmURL:             a.doc.CharmURL,
+		PreviousChannel:              a.doc.Channel,
+		PreviousCharmModifiedVersion: a.doc.CharmModifiedVersion,
+		BatchSize:                    cfg.RollingUpgrade.BatchSize,
+		Readiness:                    readiness,
+		Status:                       CharmUpgradeRunning,
+		Pending:                      pending,
+		Upgrading:                    []string{},
+		Upgraded:                     []string{},
+	}
+	startCharmUpgradeBatch(doc)
+	return []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Update: bson.D{{"$set", bson.D{{"charm-upgrade", doc}}}},
+	}}, doc, nil
+}
+
+// startCharmUpgradeBatch moves the next batch of units from the
+// pending units to those being upgraded, or completes the upgrade if
+// there are none.
+func startCharmUpgradeBatch(doc *charmUpgradeDoc) {
+	n := doc.BatchSize
+	if n > len(doc.Pending) {
+		n = len(doc.Pending)
+	}
+	doc.Upgrading = append([]string{}, doc.Pending[:n]...)
+	doc.Pending = append([]string{}, doc.Pending[n:]...)
+	doc.ReadinessActions = nil
+	if len(doc.Upgrading) == 0 {
+		doc.Status = CharmUpgradeCompleted
+	}
+}
+
+// PauseCharmUpgrade stops the application's rolling charm upgrade
+// from moving on to another batch. The units already being upgraded
+// are unaffected.
+func (a *Application) PauseCharmUpgrade() error {
+	err := a.setCharmUpgradeStatus(CharmUpgradeRunning, CharmUpgradePaused, "")
+	return errors.Annotatef(err, "cannot pause charm upgrade of application %q", a)
+}
+
+// ResumeCharmUpgrade resumes the application's paused rolling charm
+// upgrade.
+func (a *Application) ResumeCharmUpgrade() error {
+	err := a.setCharmUpgradeStatus(CharmUpgradePaused, CharmUpgradeRunning, "")
+	return errors.Annotatef(err, "cannot resume charm upgrade of application %q", a)
+}
+
+func (a *Application) setCharmUpgradeStatus(from, to CharmUpgradeStatus, message string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		upgrade := a.doc.CharmUpgrade
+		if upgrade == nil {
+			return nil, errors.NotFoundf("charm upgrade")
+		}
+		if upgrade.Status == to {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if upgrade.Status != from {
+			return nil, errors.Errorf("charm upgrade is %s", upgrade.Status)
+		}
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Assert: bson.D{
+				{"charm-upgrade.status", from},
+				{"charmmodifiedversion", a.doc.CharmModifiedVersion},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"charm-upgrade.status", to},
+				{"charm-upgrade.message", message},
+			}}},
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.CharmUpgrade.Status = to
+	a.doc.CharmUpgrade.Message = message
+	return nil
+}
+
+// RollbackCharmUpgrade returns all of the application's units to the
+// charm they ran before its most recent rolling charm upgrade. The
+// units already upgraded are downgraded at once.
+func (a *Application) RollbackCharmUpgrade() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot roll back charm upgrade of application %q", a)
+	upgrade, ok := a.CharmUpgrade()
+	if !ok {
+		return errors.NotFoundf("charm upgrade")
+	}
+	previous, err := a.st.Charm(upgrade.PreviousCharmURL())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return a.SetCharm(SetCharmConfig{
+		Charm:      previous,
+		Channel:    csparams.Channel(upgrade.doc.PreviousChannel),
+		ForceUnits: a.doc.ForceCharm,
+	})
+}
+
+// AdvanceCharmUpgrades checks the readiness of the units being
+// upgraded by each of the model's running rolling charm upgrades, and
+// starts the next batch of those whose units are all ready.
+func (m *Model) AdvanceCharmUpgrades() error {
+	applications, closer := m.st.db().GetCollection(applicationsC)
+	defer closer()
+
+	var docs []applicationDoc
+	query := bson.D{{"charm-upgrade.status", CharmUpgradeRunning}}
+	if err := applications.Find(query).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get rolling charm upgrades")
+	}
+	for _, doc := range docs {
+		app := newApplication(m.st, &doc)
+		if err := app.advanceCharmUpgrade(); err != nil {
+			return errors.Annotatef(err, "advancing charm upgrade of application %q", app)
+		}
+	}
+	return nil
+}
+
+func (a *Application) advanceCharmUpgrade() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.CharmUpgrade == nil || a.doc.CharmUpgrade.Status != CharmUpgradeRunning {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return a.advanceCharmUpgradeOps()
+	}
+	return errors.Trace(a.st.db().Run(buildTxn))
+}
+
+// advanceCharmUpgradeOps returns the operations needed to bring the
+// application's rolling charm upgrade up to date, or
+// jujutxn.ErrNoOperations if there is nothing to do.
+func (a *Application) advanceCharmUpgradeOps() ([]txn.Op, error) {
+	doc := *a.doc.CharmUpgrade
+	readinessActions := make(map[string]string)
+	for unitName, actionId := range doc.ReadinessActions {
+		readinessActions[unitName] = actionId
+	}
+	doc.ReadinessActions = readinessActions
+	changed := false
+
+	var ops []txn.Op
+	var upgrading []string
+	for _, unitName := range doc.Upgrading {
+		ready, unitChanged, unitOps, err := a.charmUpgradeUnitReady(&doc, unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, unitOps...)
+		changed = changed || unitChanged
+		if ready {
+			doc.Upgraded = append(doc.Upgraded, unitName)
+			changed = true
+		} else {
+			upgrading = append(upgrading, unitName)
+		}
+	}
+	doc.Upgrading = upgrading
+	if doc.Status == CharmUpgradeRunning && len(doc.Upgrading) == 0 {
+		startCharmUpgradeBatch(&doc)
+		changed = true
+	}
+	if !changed {
+		return nil, jujutxn.ErrNoOperations
+	}
+	if doc.Upgrading == nil {
+		doc.Upgrading = []string{}
+	}
+	if len(doc.ReadinessActions) == 0 {
+		doc.ReadinessActions = nil
+	}
+	return append(ops, txn.Op{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: bson.D{{"txn-revno", a.doc.TxnRevno}},
+		Update: bson.D{{"$set", bson.D{{"charm-upgrade", doc}}}},
+	}), nil
+}
+
+// charmUpgradeUnitReady returns whether the named unit has been
+// upgraded and is ready, and whether doc was changed, along with any
+// operations needed to check the unit's readiness. Units that have
+// gone away are considered ready. If the unit's readiness action
+// fails, the upgrade is paused.
+func (a *Application) charmUpgradeUnitReady(doc *charmUpgradeDoc, unitName string) (ready, changed bool, _ []txn.Op, _ error) {
+	unit, err := a.st.Unit(unitName)
+	if errors.IsNotFound(err) {
+		return true, false, nil, nil
+	} else if err != nil {
+		return false, false, nil, errors.Trace(err)
+	}
+	if unit.Life() != Alive {
+		return true, false, nil, nil
+	}
+	if curl, _ := unit.CharmURL(); curl == nil || curl.String() != a.doc.CharmURL.String() {
+		return false, false, nil, nil
+	}
+	if doc.Readiness == CharmUpgradeReadinessWorkload {
+		info, err := unit.Status()
+		if err != nil {
+			return false, false, nil, errors.Trace(err)
+		}
+		return info.Status == status.Active, false, nil, nil
+	}
+
+	name := strings.TrimPrefix(doc.Readiness, CharmUpgradeReadinessActionPrefix)
+	actionId, ok := doc.ReadinessActions[unitName]
+	if !ok {
+		spec, err := applicationActionSpec(a, name)
+		if err != nil {
+			return false, false, nil, errors.Trace(err)
+		}
+		parameters, err := spec.InsertDefaults(nil)
+		if err != nil {
+			return false, false, nil, errors.Trace(err)
+		}
+		adoc, ndoc, err := newActionDoc(a.st, unit.Tag(), name, parameters)
+		if err != nil {
+			return false, false, nil, errors.Trace(err)
+		}
+		doc.ReadinessActions[unitName] = a.st.localID(adoc.DocId)
+		return false, true, []txn.Op{{
+			C:      unitsC,
+			Id:     unit.doc.DocID,
+			Assert: notDeadDoc,
+		}, {
+			C:      actionsC,
+			Id:     adoc.DocId,
+			Assert: txn.DocMissing,
+			Insert: adoc,
+		}, {
+			C:      actionNotificationsC,
+			Id:     ndoc.DocId,
+			Assert: txn.DocMissing,
+			Insert: ndoc,
+		}}, nil
+	}
+	model, err := a.st.Model()
+	if err != nil {
+		return false, false, nil, errors.Trace(err)
+	}
+	action, err := model.Action(actionId)
+	if errors.IsNotFound(err) {
+		// The action has been pruned before it was seen to finish;
+		// run it again.
+		delete(doc.ReadinessActions, unitName)
+		return false, true, nil, nil
+	} else if err != nil {
+		return false, false, nil, errors.Trace(err)
+	}
+	switch action.Status() {
+	case ActionCompleted:
+		delete(doc.ReadinessActions, unitName)
+		return true, true, nil, nil
+	case ActionFailed, ActionCancelled:
+		// Pause the upgrade, and run the action again when it is
+		// resumed.
+		doc.Status = CharmUpgradePaused
+		doc.Message = fmt.Sprintf("readiness action %q %s on unit %s", name, action.Status(), unitName)
+		delete(doc.ReadinessActions, unitName)
+		return false, true, nil, nil
+	}
+	return false, false, nil, nil
+}
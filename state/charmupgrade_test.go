@@ -0,0 +1,292 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type CharmUpgradeSuite struct {
+	ConnSuite
+	application *state.Application
+	units       []*state.Unit
+	model       *state.Model
+	oldCharm    *state.Charm
+	newCharm    *state.Charm
+}
+
+var _ = gc.Suite(&CharmUpgradeSuite{})
+
+const charmUpgradeActions = `
+health-check:
+  description: Check that the workload is serving.
+`
+
+func (s *CharmUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.oldCharm = s.AddTestingCharm(c, "dummy")
+	s.newCharm = s.AddActionsCharm(c, "dummy", charmUpgradeActions, 2)
+	s.application = s.AddTestingApplication(c, "dummy", s.oldCharm)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(s.oldCharm.URL())
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+	var err error
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmRollingUpgrade(c *gc.C) {
+	s.setCharm(c, "")
+
+	upgrade, ok := s.application.CharmUpgrade()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(upgrade.PreviousCharmURL(), jc.DeepEquals, s.oldCharm.URL())
+	c.Assert(upgrade.BatchSize(), gc.Equals, 2)
+	c.Assert(upgrade.Readiness(), gc.Equals, state.CharmUpgradeReadinessWorkload)
+	c.Assert(upgrade.Status(), gc.Equals, state.CharmUpgradeRunning)
+	c.Assert(upgrade.Upgrading(), jc.DeepEquals, []string{"dummy/0", "dummy/1"})
+	c.Assert(upgrade.Pending(), jc.DeepEquals, []string{"dummy/2"})
+	c.Assert(upgrade.Upgraded(), gc.HasLen, 0)
+
+	s.assertUnitCharms(c, s.newCharm, s.newCharm, s.oldCharm)
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmRollingUpgradeInvalid(c *gc.C) {
+	for i, test := range []struct {
+		upgrade state.RollingCharmUpgrade
+		err     string
+	}{{
+		upgrade: state.RollingCharmUpgrade{},
+		err:     "non-positive batch size not valid",
+	}, {
+		upgrade: state.RollingCharmUpgrade{BatchSize: 1, Readiness: "whenever"},
+		err:     `readiness "whenever" not valid`,
+	}, {
+		upgrade: state.RollingCharmUpgrade{BatchSize: 1, Readiness: "action:no-such-action"},
+		err:     `readiness action "no-such-action" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.application.SetCharm(state.SetCharmConfig{
+			Charm:          s.newCharm,
+			RollingUpgrade: &test.upgrade,
+		})
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, `cannot upgrade application "dummy" to charm "local:quantal/quantal-dummy-2": `+test.err)
+	}
+}
+
+func (s *CharmUpgradeSuite) TestSetCharmWhileUpgrading(c *gc.C) {
+	s.setCharm(c, "")
+
+	err := s.application.SetCharm(state.SetCharmConfig{
+		Charm:          s.oldCharm,
+		RollingUpgrade: &state.RollingCharmUpgrade{BatchSize: 1},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*: rolling charm upgrade in progress`)
+
+	// Upgrading all units at once abandons the rolling upgrade.
+	err = s.application.SetCharm(state.SetCharmConfig{Charm: s.oldCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.application.CharmUpgrade()
+	c.Assert(ok, jc.IsFalse)
+	s.assertUnitCharms(c, s.oldCharm, s.oldCharm, s.oldCharm)
+}
+
+func (s *CharmUpgradeSuite) TestAdvanceWorkloadReadiness(c *gc.C) {
+	s.setCharm(c, "")
+
+	// Nothing happens until every unit of the batch is ready.
+	s.upgradeUnit(c, s.units[0], status.Active)
+	s.upgradeUnit(c, s.units[1], status.Maintenance)
+	s.advance(c)
+	upgrade := s.charmUpgrade(c)
+	c.Assert(upgrade.Upgrading(), jc.DeepEquals, []string{"dummy/1"})
+	c.Assert(upgrade.Upgraded(), jc.DeepEquals, []string{"dummy/0"})
+	c.Assert(upgrade.Pending(), jc.DeepEquals, []string{"dummy/2"})
+	s.assertUnitCharms(c, s.newCharm, s.newCharm, s.oldCharm)
+
+	s.upgradeUnit(c, s.units[1], status.Active)
+	s.advance(c)
+	upgrade = s.charmUpgrade(c)
+	c.Assert(upgrade.Upgrading(), jc.DeepEquals, []string{"dummy/2"})
+	c.Assert(upgrade.Pending(), gc.HasLen, 0)
+	s.assertUnitCharms(c, s.newCharm, s.newCharm, s.newCharm)
+
+	s.upgradeUnit(c, s.units[2], status.Active)
+	s.advance(c)
+	upgrade = s.charmUpgrade(c)
+	c.Assert(upgrade.Status(), gc.Equals, state.CharmUpgradeCompleted)
+	c.Assert(upgrade.Upgraded(), jc.DeepEquals, []string{"dummy/0", "dummy/1", "dummy/2"})
+}
+
+func (s *CharmUpgradeSuite) TestAdvanceSkipsRemovedUnits(c *gc.C) {
+	s.setCharm(c, "")
+
+	s.upgradeUnit(c, s.units[0], status.Active)
+	err := s.units[1].Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.advance(c)
+
+	upgrade := s.charmUpgrade(c)
+	c.Assert(upgrade.Upgrading(), jc.DeepEquals, []string{"dummy/2"})
+}
+
+func (s *CharmUpgradeSuite) TestAdvanceActionReadiness(c *gc.C) {
+	s.setCharm(c, "action:health-check")
+
+	s.upgradeUnit(c, s.units[0], status.Active)
+	s.advance(c)
+	s.assertPendingActions(c, 1, 0, 0)
+
+	// The action is only enqueued once.
+	s.advance(c)
+	s.assertPendingActions(c, 1, 0, 0)
+
+	s.finishActions(c, s.units[0], state.ActionCompleted)
+	s.upgradeUnit(c, s.units[1], status.Active)
+	s.advance(c)
+	s.assertPendingActions(c, 0, 1, 0)
+	upgrade := s.charmUpgrade(c)
+	c.Assert(upgrade.Upgraded(), jc.DeepEquals, []string{"dummy/0"})
+
+	// A failed readiness action pauses the upgrade.
+	s.finishActions(c, s.units[1], state.ActionFailed)
+	s.advance(c)
+	upgrade = s.charmUpgrade(c)
+	c.Assert(upgrade.Status(), gc.Equals, state.CharmUpgradePaused)
+	c.Assert(upgrade.Message(), gc.Equals, `readiness action "health-check" failed on unit dummy/1`)
+	c.Assert(upgrade.Upgrading(), jc.DeepEquals, []string{"dummy/1"})
+
+	// Resuming the upgrade runs the action again.
+	err := s.application.ResumeCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	s.advance(c)
+	s.assertPendingActions(c, 0, 1, 0)
+	upgrade = s.charmUpgrade(c)
+	c.Assert(upgrade.Status(), gc.Equals, state.CharmUpgradeRunning)
+	c.Assert(upgrade.Message(), gc.Equals, "")
+}
+
+func (s *CharmUpgradeSuite) TestPauseResume(c *gc.C) {
+	s.setCharm(c, "")
+
+	err := s.application.PauseCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.PauseCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The units of the current batch still run the new charm, but
+	// no more units are upgraded.
+	s.upgradeUnit(c, s.units[0], status.Active)
+	s.upgradeUnit(c, s.units[1], status.Active)
+	s.advance(c)
+	upgrade := s.charmUpgrade(c)
+	c.Assert(upgrade.Status(), gc.Equals, state.CharmUpgradePaused)
+	c.Assert(upgrade.Upgrading(), jc.DeepEquals, []string{"dummy/0", "dummy/1"})
+	c.Assert(upgrade.Pending(), jc.DeepEquals, []string{"dummy/2"})
+	s.assertUnitCharms(c, s.newCharm, s.newCharm, s.oldCharm)
+
+	err = s.application.ResumeCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	s.advance(c)
+	upgrade = s.charmUpgrade(c)
+	c.Assert(upgrade.Status(), gc.Equals, state.CharmUpgradeRunning)
+	c.Assert(upgrade.Upgraded(), jc.DeepEquals, []string{"dummy/0", "dummy/1"})
+	c.Assert(upgrade.Upgrading(), jc.DeepEquals, []string{"dummy/2"})
+}
+
+func (s *CharmUpgradeSuite) TestPauseResumeWithoutUpgrade(c *gc.C) {
+	err := s.application.PauseCharmUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `cannot pause charm upgrade of application "dummy": charm upgrade not found`)
+
+	s.setCharm(c, "")
+	err = s.application.ResumeCharmUpgrade()
+	c.Assert(err, gc.ErrorMatches, `cannot resume charm upgrade of application "dummy": charm upgrade is running`)
+}
+
+func (s *CharmUpgradeSuite) TestRollback(c *gc.C) {
+	s.setCharm(c, "")
+	s.upgradeUnit(c, s.units[0], status.Active)
+
+	err := s.application.RollbackCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := s.application.CharmURL()
+	c.Assert(curl, jc.DeepEquals, s.oldCharm.URL())
+	_, ok := s.application.CharmUpgrade()
+	c.Assert(ok, jc.IsFalse)
+	s.assertUnitCharms(c, s.oldCharm, s.oldCharm, s.oldCharm)
+
+	err = s.application.RollbackCharmUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CharmUpgradeSuite) setCharm(c *gc.C, readiness string) {
+	err := s.application.SetCharm(state.SetCharmConfig{
+		Charm: s.newCharm,
+		RollingUpgrade: &state.RollingCharmUpgrade{
+			BatchSize: 2,
+			Readiness: readiness,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmUpgradeSuite) upgradeUnit(c *gc.C, unit *state.Unit, workload status.Status) {
+	err := unit.SetCharmURL(s.newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetStatus(status.StatusInfo{Status: workload})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmUpgradeSuite) advance(c *gc.C) {
+	err := s.model.AdvanceCharmUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmUpgradeSuite) charmUpgrade(c *gc.C) *state.CharmUpgrade {
+	err := s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, ok := s.application.CharmUpgrade()
+	c.Assert(ok, jc.IsTrue)
+	return upgrade
+}
+
+func (s *CharmUpgradeSuite) assertUnitCharms(c *gc.C, charms ...*state.Charm) {
+	err := s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	for i, ch := range charms {
+		curl, _ := s.application.UnitCharmURL(s.units[i].Name())
+		c.Check(curl, jc.DeepEquals, ch.URL(), gc.Commentf("unit %d", i))
+	}
+}
+
+func (s *CharmUpgradeSuite) finishActions(c *gc.C, unit *state.Unit, status state.ActionStatus) {
+	actions, err := unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, action := range actions {
+		action, err = action.Begin()
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = action.Finish(state.ActionResults{Status: status})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *CharmUpgradeSuite) assertPendingActions(c *gc.C, counts ...int) {
+	for i, count := range counts {
+		actions, err := s.units[i].PendingActions()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(actions, gc.HasLen, count, gc.Commentf("unit %d", i))
+	}
+}
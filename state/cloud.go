@@ -164,3 +164,10 @@ func validateCloud(cloud cloud.Cloud) error {
 func regionSettingsGlobalKey(cloud, region string) string {
 	return cloud + "#" + region
 }
+
+// cloudSettingsGlobalKey returns the key for the model defaults that
+// apply to every region in the cloud. Region names are never empty,
+// so the key cannot clash with that of a region.
+func cloudSettingsGlobalKey(cloud string) string {
+	return regionSettingsGlobalKey(cloud, "")
+}
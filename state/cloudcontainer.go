@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// cloudContainerDoc records the status of the container running a
+// unit's workload in a Kubernetes model, as reported by the cloud.
+type cloudContainerDoc struct {
+	DocID      string                 `bson:"_id"`
+	ModelUUID  string                 `bson:"model-uuid"`
+	Unit       string                 `bson:"unit"`
+	Status     status.Status          `bson:"status"`
+	StatusInfo string                 `bson:"statusinfo"`
+	StatusData map[string]interface{} `bson:"statusdata"`
+	Updated    int64                  `bson:"updated"`
+}
+
+// ContainerStatus returns the status of the container running the
+// unit's workload. It returns an error satisfying errors.IsNotFound
+// if the status of the unit's container has not been set.
+func (u *Unit) ContainerStatus() (status.StatusInfo, error) {
+	coll, closer := u.st.db().GetCollection(cloudContainersC)
+	defer closer()
+
+	var doc cloudContainerDoc
+	err := coll.FindId(u.doc.Name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return status.StatusInfo{}, errors.NotFoundf("container status for unit %q", u.doc.Name)
+	} else if err != nil {
+		return status.StatusInfo{}, errors.Annotatef(err, "cannot get container status for unit %q", u.doc.Name)
+	}
+	return status.StatusInfo{
+		Status:  doc.Status,
+		Message: doc.StatusInfo,
+		Data:    utils.UnescapeKeys(doc.StatusData),
+		Since:   unixNanoToTime(doc.Updated),
+	}, nil
+}
+
+// SetContainerStatus sets the status of the container running the
+// unit's workload, as reported by the cloud.
+func (u *Unit) SetContainerStatus(containerStatus status.StatusInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set container status for unit %q", u)
+	if !status.ValidCloudContainerStatus(containerStatus.Status) {
+		return errors.NotValidf("container status %q", containerStatus.Status)
+	}
+	doc := cloudContainerDoc{
+		DocID:      u.st.docID(u.doc.Name),
+		ModelUUID:  u.st.ModelUUID(),
+		Unit:       u.doc.Name,
+		Status:     containerStatus.Status,
+		StatusInfo: containerStatus.Message,
+		StatusData: utils.EscapeKeys(containerStatus.Data),
+		Updated:    timeOrNow(containerStatus.Since, u.st.clock()).UnixNano(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if notDead, err := isNotDead(u.st, unitsC, u.doc.DocID); err != nil {
+			return nil, errors.Trace(err)
+		} else if !notDead {
+			return nil, errors.NotFoundf("unit")
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		_, err := u.ContainerStatus()
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      cloudContainersC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      cloudContainersC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"status", doc.Status},
+				{"statusinfo", doc.StatusInfo},
+				{"statusdata", doc.StatusData},
+				{"updated", doc.Updated},
+			}}},
+		}), nil
+	}
+	return u.st.db().Run(buildTxn)
+}
+
+// removeCloudContainerOp returns the operation needed to remove the
+// container status of the named unit, if there is one.
+func removeCloudContainerOp(mb modelBackend, unitName string) txn.Op {
+	return txn.Op{
+		C:      cloudContainersC,
+		Id:     mb.docID(unitName),
+		Remove: true,
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/logfwd/webhook"
+)
+
+// ConfigWebhookKind identifies the kind of config change that a
+// config webhook is consulted about.
+type ConfigWebhookKind string
+
+const (
+	// ModelConfigWebhook webhooks are consulted about changes to
+	// model config.
+	ModelConfigWebhook ConfigWebhookKind = "model-config"
+
+	// ApplicationConfigWebhook webhooks are consulted about changes
+	// to application (charm) config.
+	ApplicationConfigWebhook ConfigWebhookKind = "application-config"
+)
+
+// Validate ensures that the kind is one of the known kinds.
+func (kind ConfigWebhookKind) Validate() error {
+	switch kind {
+	case ModelConfigWebhook, ApplicationConfigWebhook:
+		return nil
+	}
+	return errors.NotValidf("config webhook kind %q", kind)
+}
+
+var validConfigWebhookName = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ConfigWebhook describes an HTTPS endpoint, registered with the
+// controller, that validates config changes before they are committed.
+type ConfigWebhook struct {
+	// Name uniquely identifies the webhook in the controller.
+	Name string
+
+	// URL is the https URL to which proposed changes are posted.
+	URL string
+
+	// CACert is the PEM-encoded CA certificate used to validate the
+	// webhook's server certificate. If it is empty, the system's root
+	// CAs are used.
+	CACert string
+
+	// Kinds holds the kinds of config change the webhook is
+	// consulted about.
+	Kinds []ConfigWebhookKind
+}
+
+// Validate ensures that the webhook's definition is valid.
+func (w ConfigWebhook) Validate() error {
+	if !validConfigWebhookName.MatchString(w.Name) {
+		return errors.NotValidf("config webhook name %q", w.Name)
+	}
+	if err := (webhook.RawConfig{URL: w.URL, CACert: w.CACert}).Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(w.Kinds) == 0 {
+		return errors.NotValidf("empty kinds")
+	}
+	for _, kind := range w.Kinds {
+		if err := kind.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Handles reports whether the webhook is consulted about config
+// changes of the given kind.
+func (w ConfigWebhook) Handles(kind ConfigWebhookKind) bool {
+	for _, k := range w.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// configWebhookDoc records a config webhook registered with the
+// controller.
+type configWebhookDoc struct {
+	DocID  string   `bson:"_id"`
+	URL    string   `bson:"url"`
+	CACert string   `bson:"ca-cert,omitempty"`
+	Kinds  []string `bson:"kinds"`
+}
+
+func (doc configWebhookDoc) toConfigWebhook() ConfigWebhook {
+	kinds := make([]ConfigWebhookKind, len(doc.Kinds))
+	for i, kind := range doc.Kinds {
+		kinds[i] = ConfigWebhookKind(kind)
+	}
+	return ConfigWebhook{
+		Name:   doc.DocID,
+		URL:    doc.URL,
+		CACert: doc.CACert,
+		Kinds:  kinds,
+	}
+}
+
+// AddConfigWebhook registers the config webhook with the controller.
+func (st *State) AddConfigWebhook(w ConfigWebhook) error {
+	if err := w.Validate(); err != nil {
+		return errors.Annotate(err, "invalid config webhook")
+	}
+	kinds := make([]string, len(w.Kinds))
+	for i, kind := range w.Kinds {
+		kinds[i] = string(kind)
+	}
+	ops := []txn.Op{{
+		C:      configWebhooksC,
+		Id:     w.Name,
+		Assert: txn.DocMissing,
+		Insert: &configWebhookDoc{
+			DocID:  w.Name,
+			URL:    w.URL,
+			CACert: w.CACert,
+			Kinds:  kinds,
+		},
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.AlreadyExistsf("config webhook %q", w.Name)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// RemoveConfigWebhook removes the named config webhook from the
+// controller.
+func (st *State) RemoveConfigWebhook(name string) error {
+	ops := []txn.Op{{
+		C:      configWebhooksC,
+		Id:     name,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.NotFoundf("config webhook %q", name)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ConfigWebhook returns the named config webhook.
+func (st *State) ConfigWebhook(name string) (ConfigWebhook, error) {
+	coll, closer := st.db().GetCollection(configWebhooksC)
+	defer closer()
+
+	var doc configWebhookDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ConfigWebhook{}, errors.NotFoundf("config webhook %q", name)
+	} else if err != nil {
+		return ConfigWebhook{}, errors.Annotatef(err, "cannot get config webhook %q", name)
+	}
+	return doc.toConfigWebhook(), nil
+}
+
+// ConfigWebhooks returns all the config webhooks registered with the
+// controller, ordered by name.
+func (st *State) ConfigWebhooks() ([]ConfigWebhook, error) {
+	coll, closer := st.db().GetCollection(configWebhooksC)
+	defer closer()
+
+	var docs []configWebhookDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get config webhooks")
+	}
+	webhooks := make([]ConfigWebhook, len(docs))
+	for i, doc := range docs {
+		webhooks[i] = doc.toConfigWebhook()
+	}
+	return webhooks, nil
+}
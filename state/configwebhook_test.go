@@ -0,0 +1,112 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type ConfigWebhookSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ConfigWebhookSuite{})
+
+var policyWebhook = state.ConfigWebhook{
+	Name:   "policy",
+	URL:    "https://policy.example.com/validate",
+	CACert: testing.CACert,
+	Kinds:  []state.ConfigWebhookKind{state.ModelConfigWebhook, state.ApplicationConfigWebhook},
+}
+
+func (s *ConfigWebhookSuite) TestAddConfigWebhook(c *gc.C) {
+	err := s.State.AddConfigWebhook(policyWebhook)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.State.ConfigWebhook("policy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w, jc.DeepEquals, policyWebhook)
+}
+
+func (s *ConfigWebhookSuite) TestAddConfigWebhookExists(c *gc.C) {
+	err := s.State.AddConfigWebhook(policyWebhook)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddConfigWebhook(policyWebhook)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ConfigWebhookSuite) TestAddConfigWebhookInvalid(c *gc.C) {
+	for i, test := range []struct {
+		about  string
+		modify func(*state.ConfigWebhook)
+		err    string
+	}{{
+		about:  "bad name",
+		modify: func(w *state.ConfigWebhook) { w.Name = "Not Valid" },
+		err:    `invalid config webhook: config webhook name "Not Valid" not valid`,
+	}, {
+		about:  "http URL",
+		modify: func(w *state.ConfigWebhook) { w.URL = "http://policy.example.com" },
+		err:    `invalid config webhook: URL "http://policy.example.com" \(expected https URL\) not valid`,
+	}, {
+		about:  "bad CA cert",
+		modify: func(w *state.ConfigWebhook) { w.CACert = "nope" },
+		err:    `invalid config webhook: validating TLS config: parsing CA certificate: .*`,
+	}, {
+		about:  "no kinds",
+		modify: func(w *state.ConfigWebhook) { w.Kinds = nil },
+		err:    `invalid config webhook: empty kinds not valid`,
+	}, {
+		about:  "unknown kind",
+		modify: func(w *state.ConfigWebhook) { w.Kinds = []state.ConfigWebhookKind{"storage"} },
+		err:    `invalid config webhook: config webhook kind "storage" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		w := policyWebhook
+		test.modify(&w)
+		err := s.State.AddConfigWebhook(w)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	webhooks, err := s.State.ConfigWebhooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(webhooks, gc.HasLen, 0)
+}
+
+func (s *ConfigWebhookSuite) TestConfigWebhooksSharedByModels(c *gc.C) {
+	err := s.State.AddConfigWebhook(state.ConfigWebhook{
+		Name:  "zebra",
+		URL:   "https://zebra.example.com",
+		Kinds: []state.ConfigWebhookKind{state.ModelConfigWebhook},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddConfigWebhook(policyWebhook)
+	c.Assert(err, jc.ErrorIsNil)
+
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	webhooks, err := otherState.ConfigWebhooks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(webhooks, gc.HasLen, 2)
+	c.Assert(webhooks[0], jc.DeepEquals, policyWebhook)
+	c.Assert(webhooks[1].Name, gc.Equals, "zebra")
+	c.Assert(webhooks[1].Handles(state.ModelConfigWebhook), jc.IsTrue)
+	c.Assert(webhooks[1].Handles(state.ApplicationConfigWebhook), jc.IsFalse)
+}
+
+func (s *ConfigWebhookSuite) TestRemoveConfigWebhook(c *gc.C) {
+	err := s.State.AddConfigWebhook(policyWebhook)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveConfigWebhook("policy")
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ConfigWebhook("policy")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveConfigWebhook("policy")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
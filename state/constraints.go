@@ -28,6 +28,11 @@ type constraintsDoc struct {
 	Tags         *[]string
 	Spaces       *[]string
 	VirtType     *string
+
+	AllocatePublicIP  *bool
+	ImageID           *string
+	InstanceLifecycle *string
+	MaxPrice          *string
 }
 
 func (doc constraintsDoc) value() constraints.Value {
@@ -42,6 +47,11 @@ func (doc constraintsDoc) value() constraints.Value {
 		Tags:         doc.Tags,
 		Spaces:       doc.Spaces,
 		VirtType:     doc.VirtType,
+
+		AllocatePublicIP:  doc.AllocatePublicIP,
+		ImageID:           doc.ImageID,
+		InstanceLifecycle: doc.InstanceLifecycle,
+		MaxPrice:          doc.MaxPrice,
 	}
 	return result
 }
@@ -58,6 +68,11 @@ func newConstraintsDoc(cons constraints.Value) constraintsDoc {
 		Tags:         cons.Tags,
 		Spaces:       cons.Spaces,
 		VirtType:     cons.VirtType,
+
+		AllocatePublicIP:  cons.AllocatePublicIP,
+		ImageID:           cons.ImageID,
+		InstanceLifecycle: cons.InstanceLifecycle,
+		MaxPrice:          cons.MaxPrice,
 	}
 	return result
 }
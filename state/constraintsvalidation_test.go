@@ -267,6 +267,21 @@ func (s *constraintsValidationSuite) TestServiceConstraints(c *gc.C) {
 	}
 }
 
+func (s *constraintsValidationSuite) TestConstraintCapabilities(c *gc.C) {
+	capabilities, err := s.State.ConstraintCapabilities()
+	c.Assert(err, jc.ErrorIsNil)
+	byName := make(map[string]constraints.AttributeCapability)
+	for _, capability := range capabilities {
+		byName[capability.Name] = capability
+	}
+	c.Check(byName[constraints.CpuPower].Supported, jc.IsFalse)
+	c.Check(byName[constraints.Mem].Supported, jc.IsTrue)
+	c.Check(byName[constraints.Mem].Conflicts, jc.DeepEquals, []string{constraints.InstanceType})
+	c.Check(byName[constraints.InstanceType].Conflicts, jc.DeepEquals, []string{
+		constraints.Arch, constraints.Mem,
+	})
+}
+
 func (s *applicationConstraintsSuite) SetUpTest(c *gc.C) {
 	s.ConnSuite.SetUpTest(c)
 	s.policy.GetConstraintsValidator = func() (constraints.Validator, error) {
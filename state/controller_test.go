@@ -25,12 +25,23 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	optional := map[string]bool{
-		controller.IdentityURL:         true,
-		controller.IdentityPublicKey:   true,
-		controller.AutocertURLKey:      true,
-		controller.AutocertDNSNameKey:  true,
-		controller.AllowModelAccessKey: true,
-		controller.MongoMemoryProfile:  true,
+		controller.IdentityURL:            true,
+		controller.IdentityPublicKey:      true,
+		controller.AutocertURLKey:         true,
+		controller.AutocertDNSNameKey:     true,
+		controller.AllowModelAccessKey:    true,
+		controller.MongoMemoryProfile:     true,
+		controller.AuditLogSyslog:         true,
+		controller.AuditLogWebhookURL:     true,
+		controller.APICallRateLimitBurst:  true,
+		controller.APICallRateLimitRefill: true,
+		controller.BackupTargetType:       true,
+		controller.BackupTargetContainer:  true,
+		controller.BackupTargetRegion:     true,
+		controller.BackupTargetEndpoint:   true,
+		controller.BackupTargetTenant:     true,
+		controller.BackupTargetAccessKey:  true,
+		controller.BackupTargetSecretKey:  true,
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -0,0 +1,210 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// replicaSetMachineKey is the replica set member tag holding the id of
+// the member's controller machine, as set by the peergrouper.
+const replicaSetMachineKey = "juju-machine-id"
+
+// ReplicaSetMember describes the state of a member of the controller's
+// mongo replica set.
+type ReplicaSetMember struct {
+	// Id is the member's id in the replica set configuration.
+	Id int
+
+	// MachineId is the id of the controller machine running the
+	// member, if it is known.
+	MachineId string
+
+	// Address is the member's host:port address.
+	Address string
+
+	// State is the member's replica set state, such as PRIMARY or
+	// SECONDARY.
+	State string
+
+	// Healthy is true if the member is up.
+	Healthy bool
+
+	// OplogLag is how far the member's oplog is behind the primary's.
+	OplogLag time.Duration
+
+	// Message describes any problem with the member.
+	Message string
+}
+
+type replicaSetStatusDoc struct {
+	Members []replicaSetMemberStatusDoc `bson:"members"`
+}
+
+type replicaSetMemberStatusDoc struct {
+	Id         int       `bson:"_id"`
+	Name       string    `bson:"name"`
+	Health     float64   `bson:"health"`
+	StateStr   string    `bson:"stateStr"`
+	OptimeDate time.Time `bson:"optimeDate"`
+	ErrMsg     string    `bson:"errmsg"`
+}
+
+// ReplicaSetMembers returns the state of the members of the
+// controller's mongo replica set, in replica set member id order.
+func (st *State) ReplicaSetMembers() ([]ReplicaSetMember, error) {
+	session := st.MongoSession().Copy()
+	defer session.Close()
+
+	var status replicaSetStatusDoc
+	if err := session.Run(bson.D{{"replSetGetStatus", 1}}, &status); err != nil {
+		return nil, errors.Annotate(err, "getting replica set status")
+	}
+	config, err := replicaset.CurrentMembers(session)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting replica set members")
+	}
+	machineIds := make(map[int]string)
+	for _, member := range config {
+		machineIds[member.Id] = member.Tags[replicaSetMachineKey]
+	}
+
+	var primaryOptime time.Time
+	for _, member := range status.Members {
+		if member.StateStr == "PRIMARY" {
+			primaryOptime = member.OptimeDate
+		}
+	}
+	members := make([]ReplicaSetMember, len(status.Members))
+	for i, member := range status.Members {
+		members[i] = ReplicaSetMember{
+			Id:        member.Id,
+			MachineId: machineIds[member.Id],
+			Address:   member.Name,
+			State:     member.StateStr,
+			Healthy:   member.Health == 1,
+			Message:   member.ErrMsg,
+		}
+		if !primaryOptime.IsZero() && primaryOptime.After(member.OptimeDate) {
+			members[i].OplogLag = primaryOptime.Sub(member.OptimeDate)
+		}
+	}
+	return members, nil
+}
+
+// LeaseStoreHealth describes the state of the lease store shared by
+// the controllers.
+type LeaseStoreHealth struct {
+	// GlobalTime is the current time of the global clock by which
+	// leases expire.
+	GlobalTime time.Time
+
+	// Leases is the number of leases held in all models.
+	Leases int
+
+	// ExpiredLeases is the number of leases that have expired by the
+	// global clock but have not yet been removed. The lease managers
+	// remove expired leases promptly, so a lasting excess of them
+	// means the lease managers are not keeping up.
+	ExpiredLeases int
+}
+
+// LeaseStoreHealth returns the state of the lease store.
+func (st *State) LeaseStoreHealth() (LeaseStoreHealth, error) {
+	var health LeaseStoreHealth
+	clock, err := st.globalClockReader()
+	if err != nil {
+		return health, errors.Trace(err)
+	}
+	health.GlobalTime, err = clock.Now()
+	if err != nil {
+		return health, errors.Annotate(err, "reading global clock")
+	}
+
+	leases, closer := st.db().GetRawCollection(leasesC)
+	defer closer()
+	var doc struct {
+		Start    int64         `bson:"start"`
+		Duration time.Duration `bson:"duration"`
+	}
+	iter := leases.Find(nil).Select(bson.D{{"start", 1}, {"duration", 1}}).Iter()
+	for iter.Next(&doc) {
+		health.Leases++
+		if time.Unix(0, doc.Start).Add(doc.Duration).Before(health.GlobalTime) {
+			health.ExpiredLeases++
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return health, errors.Annotate(err, "reading leases")
+	}
+	return health, nil
+}
+
+// APIServerLoad describes the load on a controller machine's API
+// server.
+type APIServerLoad struct {
+	// Connections is the number of current API connections.
+	Connections int64
+
+	// TotalConnections is the number of API connections made since
+	// the API server started.
+	TotalConnections int64
+
+	// LoginAttempts is the number of logins in progress.
+	LoginAttempts int64
+
+	// Updated is when the load was recorded.
+	Updated time.Time
+}
+
+type apiServerLoadDoc struct {
+	MachineId        string `bson:"_id"`
+	Connections      int64  `bson:"connections"`
+	TotalConnections int64  `bson:"total-connections"`
+	LoginAttempts    int64  `bson:"login-attempts"`
+	Updated          int64  `bson:"updated"`
+}
+
+// SetAPIServerLoad records the load on the API server of the
+// controller machine with the given id.
+func (st *State) SetAPIServerLoad(machineId string, load APIServerLoad) error {
+	if load.Updated.IsZero() {
+		load.Updated = st.clock().Now()
+	}
+	coll, closer := st.db().GetRawCollection(apiServerLoadC)
+	defer closer()
+	_, err := coll.UpsertId(machineId, &apiServerLoadDoc{
+		MachineId:        machineId,
+		Connections:      load.Connections,
+		TotalConnections: load.TotalConnections,
+		LoginAttempts:    load.LoginAttempts,
+		Updated:          load.Updated.UnixNano(),
+	})
+	return errors.Annotatef(err, "recording API server load for machine %s", machineId)
+}
+
+// APIServerLoads returns the most recently recorded load on the API
+// servers, keyed by controller machine id.
+func (st *State) APIServerLoads() (map[string]APIServerLoad, error) {
+	coll, closer := st.db().GetCollection(apiServerLoadC)
+	defer closer()
+	var docs []apiServerLoadDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading API server loads")
+	}
+	loads := make(map[string]APIServerLoad, len(docs))
+	for _, doc := range docs {
+		loads[doc.MachineId] = APIServerLoad{
+			Connections:      doc.Connections,
+			TotalConnections: doc.TotalConnections,
+			LoginAttempts:    doc.LoginAttempts,
+			Updated:          time.Unix(0, doc.Updated).UTC(),
+		}
+	}
+	return loads, nil
+}
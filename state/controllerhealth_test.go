@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+type ControllerHealthSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerHealthSuite{})
+
+func (s *ControllerHealthSuite) TestAPIServerLoads(c *gc.C) {
+	loads, err := s.State.APIServerLoads()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(loads, gc.HasLen, 0)
+
+	updated := time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC)
+	err = s.State.SetAPIServerLoad("0", state.APIServerLoad{
+		Connections:      10,
+		TotalConnections: 100,
+		LoginAttempts:    1,
+		Updated:          updated,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAPIServerLoad("1", state.APIServerLoad{
+		Connections: 3,
+		Updated:     updated,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetAPIServerLoad("0", state.APIServerLoad{
+		Connections:      12,
+		TotalConnections: 110,
+		Updated:          updated.Add(time.Minute),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	loads, err = s.State.APIServerLoads()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(loads, jc.DeepEquals, map[string]state.APIServerLoad{
+		"0": {
+			Connections:      12,
+			TotalConnections: 110,
+			Updated:          updated.Add(time.Minute),
+		},
+		"1": {
+			Connections: 3,
+			Updated:     updated,
+		},
+	})
+}
+
+func (s *ControllerHealthSuite) TestLeaseStoreHealth(c *gc.C) {
+	now := time.Date(2017, 11, 1, 12, 0, 0, 0, time.UTC)
+	db := s.Session.DB("juju")
+	_, err := db.C("globalclock").UpsertId("g", bson.D{{"$set", bson.D{{"time", now.UnixNano()}}}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	before, err := s.State.LeaseStoreHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(before.GlobalTime.Equal(now), jc.IsTrue)
+
+	leases := db.C("leases")
+	err = leases.Insert(bson.D{
+		{"_id", s.State.ModelUUID() + ":application-leadership#expired#"},
+		{"model-uuid", s.State.ModelUUID()},
+		{"start", now.Add(-2 * time.Minute).UnixNano()},
+		{"duration", time.Minute},
+	}, bson.D{
+		{"_id", s.State.ModelUUID() + ":application-leadership#current#"},
+		{"model-uuid", s.State.ModelUUID()},
+		{"start", now.Add(-30 * time.Second).UnixNano()},
+		{"duration", time.Minute},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	after, err := s.State.LeaseStoreHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(after.Leases, gc.Equals, before.Leases+2)
+	c.Check(after.ExpiredLeases, gc.Equals, before.ExpiredLeases+1)
+}
@@ -231,6 +231,7 @@ func addCharm(c *gc.C, st *State, series string, ch charm.Charm) *Charm {
 
 func init() {
 	txnLogSize = txnLogSizeTests
+	auditLogSize = auditLogSizeTests
 }
 
 // TxnRevno returns the txn-revno field of the document
@@ -7,6 +7,7 @@ import (
 	"net"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -173,3 +174,144 @@ func (fw *firewallRulesState) AllRules() ([]*FirewallRule, error) {
 	}
 	return result, nil
 }
+
+// EgressRule instances describe the destinations to which the
+// machines hosting an application's units may send traffic.
+// Applications without an egress rule are unrestricted.
+type EgressRule struct {
+	// Application is the name of the application the rule applies to.
+	Application string
+
+	// AllowedCIDRs are the destination CIDRs outbound traffic is
+	// restricted to.
+	AllowedCIDRs []string
+}
+
+type egressRuleDoc struct {
+	Id           string   `bson:"_id"`
+	Application  string   `bson:"application"`
+	AllowedCIDRs []string `bson:"allowed-cidrs"`
+}
+
+func (r *egressRuleDoc) toRule() *EgressRule {
+	return &EgressRule{
+		Application:  r.Application,
+		AllowedCIDRs: r.AllowedCIDRs,
+	}
+}
+
+// SaveEgress stores the specified egress rule, replacing any existing
+// rule for the application.
+func (fw *firewallRulesState) SaveEgress(rule EgressRule) error {
+	if !names.IsValidApplication(rule.Application) {
+		return errors.NotValidf("application name %q", rule.Application)
+	}
+	for _, cidr := range rule.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		model, err := fw.st.Model()
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to load model")
+		}
+		if err := checkModelActive(fw.st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := fw.st.Application(rule.Application)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q is not alive", rule.Application)
+		}
+
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     rule.Application,
+			Assert: isAliveDoc,
+		}, model.assertActiveOp()}
+		_, err = fw.EgressRule(rule.Application)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		if err == nil {
+			ops = append(ops, txn.Op{
+				C:      egressRulesC,
+				Id:     rule.Application,
+				Assert: txn.DocExists,
+				Update: bson.D{
+					{"$set", bson.D{{"allowed-cidrs", rule.AllowedCIDRs}}},
+				},
+			})
+		} else {
+			ops = append(ops, txn.Op{
+				C:      egressRulesC,
+				Id:     rule.Application,
+				Assert: txn.DocMissing,
+				Insert: egressRuleDoc{
+					Id:           rule.Application,
+					Application:  rule.Application,
+					AllowedCIDRs: rule.AllowedCIDRs,
+				},
+			})
+		}
+		return ops, nil
+	}
+	if err := fw.st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "failed to save egress rule")
+	}
+	return nil
+}
+
+// RemoveEgress removes the egress rule for the specified application,
+// lifting any restriction on its outbound traffic.
+func (fw *firewallRulesState) RemoveEgress(application string) error {
+	ops := []txn.Op{removeEgressRuleOp(application)}
+	if err := fw.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "failed to remove egress rule")
+	}
+	return nil
+}
+
+// EgressRule returns the egress rule for the specified application.
+func (fw *firewallRulesState) EgressRule(application string) (*EgressRule, error) {
+	coll, closer := fw.st.db().GetCollection(egressRulesC)
+	defer closer()
+
+	var doc egressRuleDoc
+	err := coll.FindId(application).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("egress rule for application %v", application)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.toRule(), nil
+}
+
+// AllEgressRules returns all the egress rules.
+func (fw *firewallRulesState) AllEgressRules() ([]*EgressRule, error) {
+	coll, closer := fw.st.db().GetCollection(egressRulesC)
+	defer closer()
+
+	var docs []egressRuleDoc
+	err := coll.Find(nil).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*EgressRule, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.toRule()
+	}
+	return result, nil
+}
+
+func removeEgressRuleOp(application string) txn.Op {
+	return txn.Op{
+		C:      egressRulesC,
+		Id:     application,
+		Remove: true,
+	}
+}
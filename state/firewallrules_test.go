@@ -12,6 +12,8 @@ import (
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type FirewallRulesSuite struct {
@@ -132,3 +134,139 @@ func (s *FirewallRulesSuite) TestUpdate(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertSavedRules(c, state.JujuApplicationOfferRule, []string{"192.168.2.0/16"})
 }
+
+func (s *FirewallRulesSuite) TestSaveEgress(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveEgress(state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := rules.EgressRule("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, &state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	})
+
+	err = rules.SaveEgress(state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"192.168.0.0/16"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = rules.EgressRule("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.AllowedCIDRs, jc.DeepEquals, []string{"192.168.0.0/16"})
+}
+
+func (s *FirewallRulesSuite) TestSaveEgressInvalidCIDR(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveEgress(state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"192.168.1"},
+	})
+	c.Assert(err, gc.ErrorMatches, regexp.QuoteMeta(`CIDR "192.168.1" not valid`))
+}
+
+func (s *FirewallRulesSuite) TestSaveEgressUnknownApplication(c *gc.C) {
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveEgress(state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, gc.ErrorMatches, `failed to save egress rule: application "mysql" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FirewallRulesSuite) TestRemoveEgress(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveEgress(state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = rules.RemoveEgress("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = rules.EgressRule("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a missing rule is not an error.
+	err = rules.RemoveEgress("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FirewallRulesSuite) TestAllEgressRules(c *gc.C) {
+	ch := s.Factory.MakeCharm(c, nil)
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql", Charm: ch})
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress", Charm: ch})
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveEgress(state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rules.SaveEgress(state.EgressRule{
+		Application:  "wordpress",
+		AllowedCIDRs: []string{"192.168.0.0/16"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := rules.AllEgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.SameContents, []*state.EgressRule{{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	}, {
+		Application:  "wordpress",
+		AllowedCIDRs: []string{"192.168.0.0/16"},
+	}})
+}
+
+func (s *FirewallRulesSuite) TestEgressRuleRemovedWithApplication(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveEgress(state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = rules.EgressRule("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *FirewallRulesSuite) TestWatchEgressRules(c *gc.C) {
+	ch := s.Factory.MakeCharm(c, nil)
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql", Charm: ch})
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress", Charm: ch})
+	rules := state.NewFirewallRules(s.State)
+	err := rules.SaveEgress(state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchEgressRules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("mysql")
+	wc.AssertNoChange()
+
+	err = rules.SaveEgress(state.EgressRule{
+		Application:  "wordpress",
+		AllowedCIDRs: []string{"192.168.0.0/16"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("wordpress")
+
+	err = rules.RemoveEgress("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("mysql")
+	wc.AssertNoChange()
+}
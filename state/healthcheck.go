@@ -0,0 +1,195 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/status"
+)
+
+// MaxHealthCheckHistory is the number of health check results kept
+// for each unit.
+const MaxHealthCheckHistory = 100
+
+// healthCheckStatusKey is the status data key marking a workload status
+// that was set in response to health check results, rather than by the
+// charm.
+const healthCheckStatusKey = "health-check"
+
+// HealthCheckResult is the outcome of running one of the health checks
+// declared by a unit's charm.
+type HealthCheckResult struct {
+	// Check is the name of the health check.
+	Check string
+
+	// Healthy is true if the check passed.
+	Healthy bool
+
+	// Message describes why the check failed.
+	Message string
+
+	// Time is when the check was run.
+	Time time.Time
+}
+
+type healthCheckResultDoc struct {
+	ModelUUID string `bson:"model-uuid"`
+	Unit      string `bson:"unit"`
+	Check     string `bson:"check"`
+	Healthy   bool   `bson:"healthy"`
+	Message   string `bson:"message"`
+	Time      int64  `bson:"time"`
+}
+
+// RecordHealthCheckResults records the results of running the health
+// checks declared by the unit's charm, and updates the unit's workload
+// status to match. Failing checks block an active unit; once all the
+// checks pass again, a unit blocked by failing checks becomes active.
+// Workload statuses set by the charm itself are otherwise left alone.
+func (u *Unit) RecordHealthCheckResults(results []HealthCheckResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	now := u.st.clock().Now()
+	docs := make([]interface{}, len(results))
+	var failed []HealthCheckResult
+	for i, result := range results {
+		if result.Check == "" {
+			return errors.NotValidf("empty health check name")
+		}
+		if result.Time.IsZero() {
+			result.Time = now
+		}
+		docs[i] = &healthCheckResultDoc{
+			ModelUUID: u.st.ModelUUID(),
+			Unit:      u.Name(),
+			Check:     result.Check,
+			Healthy:   result.Healthy,
+			Message:   result.Message,
+			Time:      result.Time.UnixNano(),
+		}
+		if !result.Healthy {
+			failed = append(failed, result)
+		}
+	}
+
+	coll, closer := u.st.db().GetCollection(healthCheckHistoryC)
+	defer closer()
+	if err := coll.Writeable().Insert(docs...); err != nil {
+		return errors.Annotatef(err, "cannot record health check results for unit %q", u.Name())
+	}
+	if err := pruneHealthCheckHistory(coll, u.Name()); err != nil {
+		logger.Errorf("cannot prune health check history for unit %q: %v", u.Name(), err)
+	}
+	return errors.Trace(u.setHealthCheckStatus(failed, now))
+}
+
+// setHealthCheckStatus updates the unit's workload status to reflect
+// the given failed health checks.
+func (u *Unit) setHealthCheckStatus(failed []HealthCheckResult, now time.Time) error {
+	current, err := u.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	setByHealthCheck := current.Status == status.Blocked && current.Data[healthCheckStatusKey] == true
+
+	if len(failed) == 0 {
+		if !setByHealthCheck && current.Status != status.Unknown {
+			return nil
+		}
+		return u.SetStatus(status.StatusInfo{
+			Status: status.Active,
+			Since:  &now,
+		})
+	}
+
+	if !setByHealthCheck && current.Status != status.Active && current.Status != status.Unknown {
+		return nil
+	}
+	message := healthCheckFailureMessage(failed)
+	if setByHealthCheck && current.Message == message {
+		return nil
+	}
+	return u.SetStatus(status.StatusInfo{
+		Status:  status.Blocked,
+		Message: message,
+		Data:    map[string]interface{}{healthCheckStatusKey: true},
+		Since:   &now,
+	})
+}
+
+func healthCheckFailureMessage(failed []HealthCheckResult) string {
+	if len(failed) == 1 {
+		message := fmt.Sprintf("health check %q failed", failed[0].Check)
+		if failed[0].Message != "" {
+			message += ": " + failed[0].Message
+		}
+		return message
+	}
+	checks := make([]string, len(failed))
+	for i, result := range failed {
+		checks[i] = fmt.Sprintf("%q", result.Check)
+	}
+	return fmt.Sprintf("health checks failed: %s", strings.Join(checks, ", "))
+}
+
+// pruneHealthCheckHistory removes all but the most recent
+// MaxHealthCheckHistory results for the unit.
+func pruneHealthCheckHistory(coll mongo.Collection, unitName string) error {
+	var oldest healthCheckResultDoc
+	err := coll.Find(bson.D{{"unit", unitName}}).Sort("-time").Skip(MaxHealthCheckHistory - 1).One(&oldest)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = coll.Writeable().RemoveAll(bson.D{
+		{"unit", unitName},
+		{"time", bson.D{{"$lt", oldest.Time}}},
+	})
+	return errors.Trace(err)
+}
+
+// HealthCheckHistory returns up to limit of the unit's most recent
+// health check results, newest first. All the recorded results are
+// returned if limit is not positive.
+func (u *Unit) HealthCheckHistory(limit int) ([]HealthCheckResult, error) {
+	coll, closer := u.st.db().GetCollection(healthCheckHistoryC)
+	defer closer()
+
+	query := coll.Find(bson.D{{"unit", u.Name()}}).Sort("-time")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var docs []healthCheckResultDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get health check history for unit %q", u.Name())
+	}
+	results := make([]HealthCheckResult, len(docs))
+	for i, doc := range docs {
+		results[i] = HealthCheckResult{
+			Check:   doc.Check,
+			Healthy: doc.Healthy,
+			Message: doc.Message,
+			Time:    time.Unix(0, doc.Time).UTC(),
+		}
+	}
+	return results, nil
+}
+
+func eraseHealthCheckHistory(mb modelBackend, unitName string) error {
+	coll, closer := mb.db().GetCollection(healthCheckHistoryC)
+	defer closer()
+
+	_, err := coll.Writeable().RemoveAll(bson.D{{"unit", unitName}})
+	return errors.Trace(err)
+}
@@ -0,0 +1,158 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type HealthCheckSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HealthCheckSuite{})
+
+func (s *HealthCheckSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	application := s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	var err error
+	s.unit, err = application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *HealthCheckSuite) setStatus(c *gc.C, st status.Status, message string) {
+	now := time.Now()
+	err := s.unit.SetStatus(status.StatusInfo{
+		Status:  st,
+		Message: message,
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *HealthCheckSuite) assertStatus(c *gc.C, st status.Status, message string) {
+	info, err := s.unit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status, gc.Equals, st)
+	c.Assert(info.Message, gc.Equals, message)
+}
+
+func (s *HealthCheckSuite) TestRecordHealthCheckResults(c *gc.C) {
+	t0 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := s.unit.RecordHealthCheckResults([]state.HealthCheckResult{
+		{Check: "http", Healthy: true, Time: t0},
+		{Check: "tcp", Healthy: false, Message: "connection refused", Time: t0.Add(time.Second)},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HealthCheckHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HealthCheckResult{
+		{Check: "tcp", Healthy: false, Message: "connection refused", Time: t0.Add(time.Second)},
+		{Check: "http", Healthy: true, Time: t0},
+	})
+
+	history, err = s.unit.HealthCheckHistory(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].Check, gc.Equals, "tcp")
+}
+
+func (s *HealthCheckSuite) TestRecordHealthCheckResultsInvalid(c *gc.C) {
+	err := s.unit.RecordHealthCheckResults([]state.HealthCheckResult{{Healthy: true}})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *HealthCheckSuite) TestHealthCheckHistoryPruned(c *gc.C) {
+	t0 := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	results := make([]state.HealthCheckResult, state.MaxHealthCheckHistory+10)
+	for i := range results {
+		results[i] = state.HealthCheckResult{
+			Check:   fmt.Sprintf("check-%d", i),
+			Healthy: true,
+			Time:    t0.Add(time.Duration(i) * time.Second),
+		}
+	}
+	err := s.unit.RecordHealthCheckResults(results)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HealthCheckHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, state.MaxHealthCheckHistory)
+	c.Assert(history[0].Check, gc.Equals, fmt.Sprintf("check-%d", len(results)-1))
+	c.Assert(history[len(history)-1].Check, gc.Equals, "check-10")
+}
+
+func (s *HealthCheckSuite) TestFailingChecksBlockActiveUnit(c *gc.C) {
+	s.setStatus(c, status.Active, "ready")
+
+	err := s.unit.RecordHealthCheckResults([]state.HealthCheckResult{
+		{Check: "http", Healthy: false, Message: "500 Internal Server Error"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, status.Blocked, `health check "http" failed: 500 Internal Server Error`)
+
+	err = s.unit.RecordHealthCheckResults([]state.HealthCheckResult{
+		{Check: "http", Healthy: false},
+		{Check: "tcp", Healthy: false},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, status.Blocked, `health checks failed: "http", "tcp"`)
+
+	err = s.unit.RecordHealthCheckResults([]state.HealthCheckResult{
+		{Check: "http", Healthy: true},
+		{Check: "tcp", Healthy: true},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, status.Active, "")
+}
+
+func (s *HealthCheckSuite) TestHealthyChecksActivateUnknownUnit(c *gc.C) {
+	s.setStatus(c, status.Unknown, "")
+
+	err := s.unit.RecordHealthCheckResults([]state.HealthCheckResult{
+		{Check: "http", Healthy: true},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, status.Active, "")
+}
+
+func (s *HealthCheckSuite) TestChecksLeaveCharmStatusAlone(c *gc.C) {
+	s.setStatus(c, status.Maintenance, "upgrading")
+	err := s.unit.RecordHealthCheckResults([]state.HealthCheckResult{
+		{Check: "http", Healthy: false},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, status.Maintenance, "upgrading")
+
+	s.setStatus(c, status.Blocked, "missing relation")
+	err = s.unit.RecordHealthCheckResults([]state.HealthCheckResult{
+		{Check: "http", Healthy: true},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, status.Blocked, "missing relation")
+}
+
+func (s *HealthCheckSuite) TestHealthCheckHistoryErasedWithUnit(c *gc.C) {
+	err := s.unit.RecordHealthCheckResults([]state.HealthCheckResult{
+		{Check: "http", Healthy: true},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HealthCheckHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// idempotentCallExpiry is how long the outcome of a call made with an
+// idempotency key is kept. Clients only resend a call while they are
+// reconnecting, so this only needs to comfortably outlast that.
+const idempotentCallExpiry = time.Hour
+
+// ErrIdempotentCallPending is returned by ClaimIdempotentCall when a
+// call with the same key has been started, but has not recorded its
+// outcome; either because it is still running, or because the
+// controller stopped before it completed.
+var ErrIdempotentCallPending = errors.New("call with the same idempotency key has not completed")
+
+// idempotentCallDoc records an API call made with an idempotency key
+// and, once the call has completed, its outcome. The documents are
+// written without mgo/txn, and expire after idempotentCallExpiry.
+type idempotentCallDoc struct {
+	DocID     string    `bson:"_id"`
+	ModelUUID string    `bson:"model-uuid"`
+	Created   time.Time `bson:"created"`
+	Completed bool      `bson:"completed"`
+	Response  []byte    `bson:"response,omitempty"`
+	Error     string    `bson:"error,omitempty"`
+	ErrorCode string    `bson:"error-code,omitempty"`
+}
+
+// IdempotentCallOutcome holds the recorded outcome of an API call made
+// with an idempotency key.
+type IdempotentCallOutcome struct {
+	// Response holds the serialized response of a successful call.
+	Response []byte
+
+	// Error and ErrorCode hold the message and code of the error
+	// returned by a failed call.
+	Error     string
+	ErrorCode string
+}
+
+// ClaimIdempotentCall records that the call identified by key is about
+// to be made, and returns true if the caller should go ahead and make
+// it, recording the outcome with CompleteIdempotentCall. If the key has
+// been claimed before, false is returned along with the outcome of the
+// earlier call; or ErrIdempotentCallPending, if that call has not
+// completed.
+//
+// The key must be unique to the caller; the apiserver qualifies the key
+// sent by the client with the tag of the authenticated entity.
+func (st *State) ClaimIdempotentCall(key string) (bool, IdempotentCallOutcome, error) {
+	calls, closer := st.db().GetCollection(idempotentCallsC)
+	defer closer()
+
+	doc := idempotentCallDoc{
+		DocID:     st.docID(key),
+		ModelUUID: st.ModelUUID(),
+		Created:   st.clock().Now(),
+	}
+	err := calls.Writeable().Insert(doc)
+	if err == nil {
+		return true, IdempotentCallOutcome{}, nil
+	}
+	if !mgo.IsDup(err) {
+		return false, IdempotentCallOutcome{}, errors.Annotatef(err, "claiming call %q", key)
+	}
+	var existing idempotentCallDoc
+	if err := calls.FindId(doc.DocID).One(&existing); err == mgo.ErrNotFound {
+		// The earlier claim expired just now; there's no telling
+		// what became of the call.
+		return false, IdempotentCallOutcome{}, ErrIdempotentCallPending
+	} else if err != nil {
+		return false, IdempotentCallOutcome{}, errors.Annotatef(err, "reading call %q", key)
+	}
+	if !existing.Completed {
+		return false, IdempotentCallOutcome{}, ErrIdempotentCallPending
+	}
+	return false, IdempotentCallOutcome{
+		Response:  existing.Response,
+		Error:     existing.Error,
+		ErrorCode: existing.ErrorCode,
+	}, nil
+}
+
+// CompleteIdempotentCall records the outcome of the call identified by
+// key, which must have been claimed with ClaimIdempotentCall.
+func (st *State) CompleteIdempotentCall(key string, outcome IdempotentCallOutcome) error {
+	calls, closer := st.db().GetCollection(idempotentCallsC)
+	defer closer()
+
+	err := calls.Writeable().UpdateId(st.docID(key), bson.D{{"$set", bson.D{
+		{"completed", true},
+		{"response", outcome.Response},
+		{"error", outcome.Error},
+		{"error-code", outcome.ErrorCode},
+	}}})
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("call %q", key)
+	}
+	return errors.Annotatef(err, "completing call %q", key)
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type IdempotentCallsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&IdempotentCallsSuite{})
+
+func (s *IdempotentCallsSuite) TestClaimNew(c *gc.C) {
+	claimed, outcome, err := s.State.ClaimIdempotentCall("user-bob:key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claimed, jc.IsTrue)
+	c.Assert(outcome, jc.DeepEquals, state.IdempotentCallOutcome{})
+}
+
+func (s *IdempotentCallsSuite) TestClaimPending(c *gc.C) {
+	_, _, err := s.State.ClaimIdempotentCall("user-bob:key")
+	c.Assert(err, jc.ErrorIsNil)
+
+	claimed, _, err := s.State.ClaimIdempotentCall("user-bob:key")
+	c.Assert(err, gc.Equals, state.ErrIdempotentCallPending)
+	c.Assert(claimed, jc.IsFalse)
+}
+
+func (s *IdempotentCallsSuite) TestClaimCompleted(c *gc.C) {
+	_, _, err := s.State.ClaimIdempotentCall("user-bob:key")
+	c.Assert(err, jc.ErrorIsNil)
+	expect := state.IdempotentCallOutcome{
+		Error:     "boom",
+		ErrorCode: "not found",
+	}
+	err = s.State.CompleteIdempotentCall("user-bob:key", expect)
+	c.Assert(err, jc.ErrorIsNil)
+
+	claimed, outcome, err := s.State.ClaimIdempotentCall("user-bob:key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claimed, jc.IsFalse)
+	c.Assert(outcome, jc.DeepEquals, expect)
+
+	// Claims on other keys are unaffected.
+	claimed, _, err = s.State.ClaimIdempotentCall("user-mary:key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claimed, jc.IsTrue)
+}
+
+func (s *IdempotentCallsSuite) TestClaimCompletedResponse(c *gc.C) {
+	_, _, err := s.State.ClaimIdempotentCall("user-bob:key")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CompleteIdempotentCall("user-bob:key", state.IdempotentCallOutcome{
+		Response: []byte(`{"results":[]}`),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, outcome, err := s.State.ClaimIdempotentCall("user-bob:key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(outcome.Response), gc.Equals, `{"results":[]}`)
+}
+
+func (s *IdempotentCallsSuite) TestCompleteUnclaimed(c *gc.C) {
+	err := s.State.CompleteIdempotentCall("user-bob:key", state.IdempotentCallOutcome{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -210,6 +210,9 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Messages returns the messages logged by the action, oldest first.
+	Messages() []ActionMessage
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -218,6 +221,10 @@ type Action interface {
 	// It asserts that the action is currently pending.
 	Begin() (Action, error)
 
+	// Log records a progress message for the action. It asserts that
+	// the action is currently running.
+	Log(message string) error
+
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
@@ -4,6 +4,8 @@
 package audit
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
 
@@ -82,3 +84,56 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		Data:              utils.EscapeKeys(auditEntry.Data),
 	}, nil
 }
+
+// Iterator is the subset of *mgo.Iter used by GetAuditEntries.
+type Iterator interface {
+	Next(interface{}) bool
+	Close() error
+}
+
+// GetAuditEntries reads audit entries from the given iterator, which
+// must yield them most recent first, and returns those recorded at or
+// after the time after and before the time before, up to limit
+// entries. Zero values of after, before and limit are ignored.
+func GetAuditEntries(iter Iterator, after, before time.Time, limit int) ([]audit.AuditEntry, error) {
+	var entries []audit.AuditEntry
+	var doc auditEntryDoc
+	for iter.Next(&doc) {
+		entry, err := auditEntryFromAuditEntryDoc(doc)
+		if err != nil {
+			iter.Close()
+			return nil, errors.Trace(err)
+		}
+		if !before.IsZero() && !entry.Timestamp.Before(before) {
+			continue
+		}
+		if !after.IsZero() && entry.Timestamp.Before(after) {
+			break
+		}
+		entries = append(entries, entry)
+		if limit > 0 && len(entries) == limit {
+			break
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading audit entries")
+	}
+	return entries, nil
+}
+
+func auditEntryFromAuditEntryDoc(doc auditEntryDoc) (audit.AuditEntry, error) {
+	var timestamp time.Time
+	if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+		return audit.AuditEntry{}, errors.Annotate(err, "parsing audit entry timestamp")
+	}
+	return audit.AuditEntry{
+		JujuServerVersion: doc.JujuServerVersion,
+		ModelUUID:         doc.ModelUUID,
+		Timestamp:         timestamp.UTC(),
+		RemoteAddress:     doc.RemoteAddress,
+		OriginType:        doc.OriginType,
+		OriginName:        doc.OriginName,
+		Operation:         doc.Operation,
+		Data:              utils.UnescapeKeys(doc.Data),
+	}, nil
+}
@@ -4,6 +4,8 @@
 package audit_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -111,3 +113,94 @@ func (*AuditSuite) TestPutAuditEntry_ValidateAuditEntry(c *gc.C) {
 	err := putAuditEntry(auditEntry)
 	c.Check(err, gc.ErrorMatches, validationErr.Error())
 }
+
+// fakeIterator yields the given documents via a BSON round trip.
+type fakeIterator struct {
+	docs []bson.M
+	err  error
+}
+
+func (i *fakeIterator) Next(out interface{}) bool {
+	if len(i.docs) == 0 {
+		return false
+	}
+	data, err := bson.Marshal(i.docs[0])
+	if err != nil {
+		panic(err)
+	}
+	i.docs = i.docs[1:]
+	if err := bson.Unmarshal(data, out); err != nil {
+		panic(err)
+	}
+	return true
+}
+
+func (i *fakeIterator) Close() error {
+	return i.err
+}
+
+func auditEntryDocAt(t time.Time, operation string) bson.M {
+	timestamp, err := t.MarshalText()
+	if err != nil {
+		panic(err)
+	}
+	return bson.M{
+		"juju-server-version": version.MustParse("1.0.0"),
+		"model-uuid":          coretesting.ModelTag.Id(),
+		"timestamp":           string(timestamp),
+		"remote-address":      "8.8.8.8",
+		"origin-type":         "API request",
+		"origin-name":         "user-bob",
+		"operation":           operation,
+		"data":                bson.M{"a\uff0eb": "c"},
+	}
+}
+
+func (*AuditSuite) TestGetAuditEntries(c *gc.C) {
+	t0 := time.Date(2017, time.October, 1, 12, 0, 0, 0, time.UTC)
+	iter := &fakeIterator{docs: []bson.M{
+		auditEntryDocAt(t0.Add(3*time.Minute), "three"),
+		auditEntryDocAt(t0.Add(2*time.Minute), "two"),
+		auditEntryDocAt(t0.Add(time.Minute), "one"),
+		auditEntryDocAt(t0, "zero"),
+	}}
+	entries, err := stateaudit.GetAuditEntries(iter, t0.Add(time.Minute), t0.Add(3*time.Minute), 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("1.0.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         t0.Add(2 * time.Minute),
+		RemoteAddress:     "8.8.8.8",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "two",
+		Data:              map[string]interface{}{"a.b": "c"},
+	}, {
+		JujuServerVersion: version.MustParse("1.0.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         t0.Add(time.Minute),
+		RemoteAddress:     "8.8.8.8",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "one",
+		Data:              map[string]interface{}{"a.b": "c"},
+	}})
+}
+
+func (*AuditSuite) TestGetAuditEntriesLimit(c *gc.C) {
+	t0 := time.Date(2017, time.October, 1, 12, 0, 0, 0, time.UTC)
+	iter := &fakeIterator{docs: []bson.M{
+		auditEntryDocAt(t0.Add(time.Minute), "one"),
+		auditEntryDocAt(t0, "zero"),
+	}}
+	entries, err := stateaudit.GetAuditEntries(iter, time.Time{}, time.Time{}, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Operation, gc.Equals, "one")
+}
+
+func (*AuditSuite) TestGetAuditEntriesPropagatesReadError(c *gc.C) {
+	iter := &fakeIterator{err: errors.New("boom")}
+	_, err := stateaudit.GetAuditEntries(iter, time.Time{}, time.Time{}, 0)
+	c.Assert(err, gc.ErrorMatches, "reading audit entries: boom")
+}
@@ -25,6 +25,12 @@ func leadershipSettingsKey(applicationId string) string {
 	return fmt.Sprintf("a#%s#leader", applicationId)
 }
 
+// leadershipLeaseKey returns the local id of the lease document
+// recording the leader of the named application.
+func leadershipLeaseKey(applicationId string) string {
+	return fmt.Sprintf("%s#%s#", applicationLeadershipNamespace, applicationId)
+}
+
 // LeadershipClaimer returns a leadership.Claimer for units and services in the
 // state's model.
 func (st *State) LeadershipClaimer() leadership.Claimer {
@@ -321,7 +321,98 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+
+	// IncludeMessage and ExcludeMessage hold regular expressions
+	// matched against the log messages.
+	IncludeMessage []string
+	ExcludeMessage []string
+
+	// IncludeLabel and ExcludeLabel select logs by the labels of the
+	// entity and agent that wrote them.
+	IncludeLabel []LogLabelSelector
+	ExcludeLabel []LogLabelSelector
+
+	// MaxInitialBytes, if positive, limits the initial lines to the
+	// most recent of them whose total size is at most this many
+	// bytes.
+	MaxInitialBytes int
+
+	Oplog *mgo.Collection // For testing only
+}
+
+// These are the labels by which log records can be selected.
+const (
+	// LogLabelKind is the kind of the entity that wrote the log,
+	// e.g. "machine" or "unit".
+	LogLabelKind = "kind"
+
+	// LogLabelApplication is the application of the unit that wrote
+	// the log, or the application that wrote it.
+	LogLabelApplication = "application"
+
+	// LogLabelVersion is the version of the agent that wrote the log.
+	LogLabelVersion = "version"
+)
+
+// LogLabelSelector selects log records by label. A record is selected
+// if all of the selector's labels match.
+type LogLabelSelector map[string]string
+
+// ParseLogLabelSelector parses a label selector of the form
+// "label=value[,label=value...]".
+func ParseLogLabelSelector(value string) (LogLabelSelector, error) {
+	selector := make(LogLabelSelector)
+	for _, requirement := range strings.Split(value, ",") {
+		parts := strings.SplitN(strings.TrimSpace(requirement), "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.NotValidf("label selector %q (expected label=value)", value)
+		}
+		label, labelValue := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		switch label {
+		case LogLabelKind:
+			switch labelValue {
+			case names.MachineTagKind, names.UnitTagKind, names.ApplicationTagKind:
+			default:
+				return nil, errors.NotValidf("entity kind %q", labelValue)
+			}
+		case LogLabelApplication:
+			if !names.IsValidApplication(labelValue) {
+				return nil, errors.NotValidf("application name %q", labelValue)
+			}
+		case LogLabelVersion:
+			if _, err := version.Parse(labelValue); err != nil {
+				return nil, errors.NewNotValid(err, "invalid version")
+			}
+		default:
+			return nil, errors.NotValidf("log label %q", label)
+		}
+		if _, ok := selector[label]; ok {
+			return nil, errors.NotValidf("label selector %q (repeated label %q)", value, label)
+		}
+		selector[label] = labelValue
+	}
+	return selector, nil
+}
+
+// toSelector returns the mongo selector for the records matching the
+// label selector.
+func (s LogLabelSelector) toSelector(prefix string) bson.D {
+	var conditions []bson.D
+	if kind, ok := s[LogLabelKind]; ok {
+		conditions = append(conditions, bson.D{{
+			prefix + "n", bson.RegEx{Pattern: `^` + kind + `-`},
+		}})
+	}
+	if application, ok := s[LogLabelApplication]; ok {
+		quoted := regexp.QuoteMeta(application)
+		conditions = append(conditions, bson.D{{
+			prefix + "n", bson.RegEx{Pattern: `^(unit-` + quoted + `-[0-9]+|application-` + quoted + `)$`},
+		}})
+	}
+	if ver, ok := s[LogLabelVersion]; ok {
+		conditions = append(conditions, bson.D{{prefix + "r", ver}})
+	}
+	return bson.D{{"$and", conditions}}
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -447,12 +538,19 @@ func (t *logTailer) processReversed(query *mgo.Query) error {
 	queue := make([]logDoc, t.params.InitialLines)
 	cur := t.params.InitialLines
 	var doc logDoc
+	var size int
 	for iter.Next(&doc) {
 		select {
 		case <-t.tomb.Dying():
 			return errors.Trace(tomb.ErrDying)
 		default:
 		}
+		if t.params.MaxInitialBytes > 0 {
+			size += logDocSize(&doc)
+			if size > t.params.MaxInitialBytes {
+				break
+			}
+		}
 		cur--
 		queue[cur] = doc
 		if cur == 0 {
@@ -482,6 +580,12 @@ func (t *logTailer) processReversed(query *mgo.Query) error {
 	return nil
 }
 
+// logDocSize returns the approximate size of the log record in the
+// document, as sent to clients.
+func logDocSize(doc *logDoc) int {
+	return len(doc.Entity) + len(doc.Module) + len(doc.Location) + len(doc.Message)
+}
+
 func (t *logTailer) processCollection() error {
 	// Create a selector from the params.
 	sel := t.paramsToSelector(t.params, "")
@@ -639,14 +743,44 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if len(params.IncludeMessage) > 0 || len(params.ExcludeMessage) > 0 {
+		var match bson.D
+		if len(params.IncludeMessage) > 0 {
+			match = append(match, bson.DocElem{"$regex", makeMessagePattern(params.IncludeMessage)})
+		}
+		if len(params.ExcludeMessage) > 0 {
+			match = append(match, bson.DocElem{"$not", bson.RegEx{Pattern: makeMessagePattern(params.ExcludeMessage)}})
+		}
+		sel = append(sel, bson.DocElem{"x", match})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
 		}
 	}
+	// The label selectors use operators that are not renamed with
+	// the prefix, so they are added with the prefix already applied.
+	if len(params.IncludeLabel) > 0 {
+		sel = append(sel, bson.DocElem{"$or", makeLabelSelectors(params.IncludeLabel, prefix)})
+	}
+	if len(params.ExcludeLabel) > 0 {
+		sel = append(sel, bson.DocElem{"$nor", makeLabelSelectors(params.ExcludeLabel, prefix)})
+	}
 	return sel
 }
 
+func makeMessagePattern(patterns []string) string {
+	return `(` + strings.Join(patterns, `)|(`) + `)`
+}
+
+func makeLabelSelectors(selectors []LogLabelSelector, prefix string) []bson.D {
+	result := make([]bson.D, len(selectors))
+	for i, selector := range selectors {
+		result[i] = selector.toSelector(prefix)
+	}
+	return result
+}
+
 func makeEntityPattern(entities []string) string {
 	var patterns []string
 	for _, entity := range entities {
@@ -749,6 +883,11 @@ func logDocToRecord(modelUUID string, doc *logDoc) (*LogRecord, error) {
 	return rec, nil
 }
 
+// logsPruneStatsName is the collection name under which the pruning
+// of a model's logs is recorded. Each model's logs are held in their
+// own collection in the logs database.
+const logsPruneStatsName = "logs"
+
 // PruneLogs removes old log documents in order to control the size of
 // logs collection. All logs older than minLogTime are
 // removed. Further removal is also performed if the logs collection
@@ -766,6 +905,12 @@ func PruneLogs(st ControllerSessioner, minLogTime time.Time, maxLogsMB int) erro
 	}
 
 	pruneCounts := make(map[string]int)
+	sizesBefore := make(map[string]int)
+	for modelUUID, logColl := range logColls {
+		if sizesBefore[modelUUID], err = getCollectionMB(logColl); err != nil {
+			return errors.Annotate(err, "failed to retrieve log counts")
+		}
+	}
 
 	// Remove old log entries for each model.
 	for modelUUID, logColl := range logColls {
@@ -825,10 +970,20 @@ func PruneLogs(st ControllerSessioner, minLogTime time.Time, maxLogsMB int) erro
 		pruneCounts[modelUUID] += removeInfo.Removed
 	}
 
+	stats := session.DB(jujuDB).C(pruneStatsC)
+	now := time.Now()
 	for modelUUID, count := range pruneCounts {
 		if count > 0 {
 			logger.Debugf("pruned %d logs for model %s", count, modelUUID)
 		}
+		sizeAfter, err := getCollectionMB(logColls[modelUUID])
+		if err != nil {
+			return errors.Annotate(err, "failed to retrieve log counts")
+		}
+		reclaimedMB := sizesBefore[modelUUID] - sizeAfter
+		if err := recordPruneStats(stats, modelUUID, logsPruneStatsName, now, count, reclaimedMB); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
@@ -22,6 +23,53 @@ import (
 	jujuversion "github.com/juju/juju/version"
 )
 
+type LogLabelSelectorSuite struct{}
+
+var _ = gc.Suite(&LogLabelSelectorSuite{})
+
+func (*LogLabelSelectorSuite) TestParse(c *gc.C) {
+	selector, err := state.ParseLogLabelSelector("kind=unit, application=mysql,version=2.3.0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(selector, jc.DeepEquals, state.LogLabelSelector{
+		"kind":        "unit",
+		"application": "mysql",
+		"version":     "2.3.0",
+	})
+}
+
+func (*LogLabelSelectorSuite) TestParseInvalid(c *gc.C) {
+	for _, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "",
+		err:   `label selector "" \(expected label=value\) not valid`,
+	}, {
+		value: "kind",
+		err:   `label selector "kind" \(expected label=value\) not valid`,
+	}, {
+		value: "colour=blue",
+		err:   `log label "colour" not valid`,
+	}, {
+		value: "kind=user",
+		err:   `entity kind "user" not valid`,
+	}, {
+		value: "application=Bad_App",
+		err:   `application name "Bad_App" not valid`,
+	}, {
+		value: "version=two",
+		err:   `invalid version: .*`,
+	}, {
+		value: "kind=unit,kind=machine",
+		err:   `label selector "kind=unit,kind=machine" \(repeated label "kind"\) not valid`,
+	}} {
+		c.Logf("parsing %q", test.value)
+		_, err := state.ParseLogLabelSelector(test.value)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 type LogsSuite struct {
 	ConnSuite
 	logsColl *mgo.Collection
@@ -781,6 +829,115 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeMessage(c *gc.C) {
+	started := logTemplate{Message: "started worker"}
+	stopped := logTemplate{Message: "worker stopped"}
+	other := logTemplate{Message: "something else"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, started)
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, stopped)
+	}
+	params := state.LogTailerParams{
+		IncludeMessage: []string{"^started", "stopped$"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, started)
+		s.assertTailer(c, tailer, 1, stopped)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeExcludeMessage(c *gc.C) {
+	started := logTemplate{Message: "started worker"}
+	stopped := logTemplate{Message: "worker stopped"}
+	other := logTemplate{Message: "something else"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, started)
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, stopped)
+	}
+	params := state.LogTailerParams{
+		IncludeMessage: []string{"worker"},
+		ExcludeMessage: []string{"^start", "nothing"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, stopped)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeLabel(c *gc.C) {
+	machine0 := logTemplate{Entity: names.NewMachineTag("0")}
+	foo0 := logTemplate{Entity: names.NewUnitTag("foo/0")}
+	foobar0 := logTemplate{Entity: names.NewUnitTag("foo-bar/0")}
+	bar1 := logTemplate{Entity: names.NewUnitTag("bar/1")}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, machine0)
+		s.writeLogs(c, s.otherUUID, 1, foo0)
+		s.writeLogs(c, s.otherUUID, 1, foobar0)
+		s.writeLogs(c, s.otherUUID, 1, bar1)
+	}
+	params := state.LogTailerParams{
+		IncludeLabel: []state.LogLabelSelector{
+			{"kind": "unit", "application": "foo"},
+			{"application": "bar", "version": jujuversion.Current.String()},
+		},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, foo0)
+		s.assertTailer(c, tailer, 1, bar1)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestExcludeLabel(c *gc.C) {
+	machine0 := logTemplate{Entity: names.NewMachineTag("0")}
+	foo0 := logTemplate{Entity: names.NewUnitTag("foo/0")}
+	bar1 := logTemplate{Entity: names.NewUnitTag("bar/1")}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, machine0)
+		s.writeLogs(c, s.otherUUID, 1, foo0)
+		s.writeLogs(c, s.otherUUID, 1, bar1)
+	}
+	params := state.LogTailerParams{
+		ExcludeLabel: []state.LogLabelSelector{
+			{"kind": "machine"},
+			{"application": "bar"},
+			{"version": "1.25.6"},
+		},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, foo0)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestInitialLinesMaxBytes(c *gc.C) {
+	expected := logTemplate{Message: "want"}
+	s.writeLogs(c, s.otherUUID, 3, logTemplate{Message: "dont want"})
+	s.writeLogs(c, s.otherUUID, 2, expected)
+
+	// Each wanted record is 22 bytes: "machine-0", "module", "loc"
+	// and the message.
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		InitialLines:    5,
+		MaxInitialBytes: 45,
+		NoTail:          true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// Only the last 2 lines fit in the byte limit.
+	s.assertTailer(c, tailer, 2, expected)
+	select {
+	case log, ok := <-tailer.Logs():
+		c.Assert(ok, jc.IsFalse, gc.Commentf("unexpected log %#v", log))
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("tailer didn't stop itself")
+	}
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -893,6 +893,7 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 		removeMachineBlockDevicesOp(m.Id()),
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.globalKey()),
+		removeAgentIntegrityOp(m.globalKey()),
 	}
 	linkLayerDevicesOps, err := m.removeAllLinkLayerDevicesOps()
 	if err != nil {
@@ -1599,6 +1600,12 @@ func (m *Machine) setAddressesOps(
 	return ops, machineStateAddresses, providerStateAddresses, newPrivate, newPublic, nil
 }
 
+// ProvisionedNonce returns the nonce with which the machine was
+// provisioned, or "" if it has not been provisioned.
+func (m *Machine) ProvisionedNonce() string {
+	return m.doc.Nonce
+}
+
 // CheckProvisioned returns true if the machine was provisioned with the given nonce.
 func (m *Machine) CheckProvisioned(nonce string) bool {
 	return nonce == m.doc.Nonce && nonce != ""
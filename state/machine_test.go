@@ -922,6 +922,7 @@ func (s *MachineSuite) TestMachineAvailabilityZoneMissing(c *gc.C) {
 func (s *MachineSuite) TestMachineSetCheckProvisioned(c *gc.C) {
 	// Check before provisioning.
 	c.Assert(s.machine.CheckProvisioned("fake_nonce"), jc.IsFalse)
+	c.Assert(s.machine.ProvisionedNonce(), gc.Equals, "")
 
 	// Either one should not be empty.
 	err := s.machine.SetProvisioned("umbrella/0", "", nil)
@@ -940,6 +941,7 @@ func (s *MachineSuite) TestMachineSetCheckProvisioned(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(string(id), gc.Equals, "umbrella/0")
 	c.Assert(s.machine.CheckProvisioned("fake_nonce"), jc.IsTrue)
+	c.Assert(m.ProvisionedNonce(), gc.Equals, "fake_nonce")
 	id, err = s.machine.InstanceId()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(string(id), gc.Equals, "umbrella/0")
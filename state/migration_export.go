@@ -674,6 +674,24 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	}
 	delete(e.modelSettings, leadershipKey)
 
+	// The model description has no place for labels, so exporting
+	// them would silently lose them.
+	if len(application.doc.Labels) > 0 {
+		return errors.NotSupportedf("exporting labels for application %q", appName)
+	}
+
+	// Nor is there a place for a rolling charm upgrade, whose units
+	// would otherwise all be upgraded at once on import.
+	if upgrade, ok := application.CharmUpgrade(); ok && upgrade.Status() != CharmUpgradeCompleted {
+		return errors.NotSupportedf("exporting rolling charm upgrade for application %q", appName)
+	}
+
+	// Nor for a stop grace period, without which units would wait
+	// indefinitely for their stop hooks on the target controller.
+	if application.doc.StopGracePeriod > 0 {
+		return errors.NotSupportedf("exporting stop grace period for application %q", appName)
+	}
+
 	args := description.ApplicationArgs{
 		Tag:                  application.ApplicationTag(),
 		Series:               application.doc.Series,
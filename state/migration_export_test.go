@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/juju/description"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	"github.com/juju/version"
@@ -437,6 +438,31 @@ func (s *MigrationExportSuite) TestMultipleApplications(c *gc.C) {
 	c.Assert(applications, gc.HasLen, 3)
 }
 
+func (s *MigrationExportSuite) TestApplicationWithLabels(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "first"})
+	err := app.SetLabels(map[string]string{"tier": "frontend"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `exporting labels for application "first" not supported`)
+}
+
+func (s *MigrationExportSuite) TestApplicationWithCharmUpgrade(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "first"})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	ch := s.Factory.MakeCharm(c, nil)
+	err := app.SetCharm(state.SetCharmConfig{
+		Charm:          ch,
+		RollingUpgrade: &state.RollingCharmUpgrade{BatchSize: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `exporting rolling charm upgrade for application "first" not supported`)
+}
+
 func (s *MigrationExportSuite) TestUnits(c *gc.C) {
 	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
 		Constraints: constraints.MustParse("arch=amd64 mem=8G"),
@@ -83,12 +83,18 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		autocertCacheC,
 		// We don't export the controller model at this stage.
 		controllersC,
+		// API server load is recorded by, and describes, the
+		// controller machines.
+		apiServerLoadC,
 		// Clouds aren't migrated. They must exist in the
 		// target controller already.
 		cloudsC,
 		// Cloud credentials aren't migrated. They must exist in the
 		// target controller already.
 		cloudCredentialsC,
+		// Config webhooks are registered with the controller, and
+		// aren't migrated.
+		configWebhooksC,
 		// This is controller global, and related to the system state of the
 		// embedded GUI.
 		guimetadataC,
@@ -175,6 +181,18 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// phase after the initial model migration.
 		charmsC,
 
+		// Idempotent call outcomes are only needed while a client is
+		// reconnecting to the controller that ran the call.
+		idempotentCallsC,
+
+		// Prune statistics describe the pruning done by the source
+		// controller, and are rebuilt by the target's pruners.
+		pruneStatsC,
+
+		// Agent integrity reports are made by agents when they start,
+		// which they do again once migrated to the target controller.
+		agentIntegrityC,
+
 		// Metrics manager maintains controller specific state relating to
 		// the store and forward of charm metrics. Nothing to migrate here.
 		metricsManagerC,
@@ -187,6 +205,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// we include the name of the leader unit. On import, a new lease
 		// is created for the leader unit.
 		leasesC,
+
+		// Health check results are reported again by the machine agents
+		// once they have been migrated to the target controller.
+		healthCheckHistoryC,
+
+		// Charm history is not migrated; it starts afresh on the
+		// target controller with the next change to each application.
+		charmHistoryC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -200,6 +226,25 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		externalControllersC,
 		relationNetworksC,
 		firewallRulesC,
+		egressRulesC,
+
+		// Model checkpoints - TODO
+		modelCheckpointsC,
+
+		// Action schedules and rollouts - TODO
+		actionSchedulesC,
+		actionRolloutsC,
+
+		// Charm state snapshots - TODO
+		charmStateSnapshotsC,
+
+		// Config branches - TODO
+		branchesC,
+
+		// Kubernetes pod specs and container statuses - TODO
+		podSpecsC,
+		podSpecHistoryC,
+		cloudContainersC,
 	)
 
 	envCollections := set.NewStrings()
@@ -368,6 +413,15 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// Labels are not supported by the description package;
+		// applications with labels cannot be exported.
+		"Labels",
+		// Nor are rolling charm upgrades; applications being
+		// upgraded cannot be exported.
+		"CharmUpgrade",
+		// Nor are stop grace periods; applications with one
+		// cannot be exported.
+		"StopGracePeriod",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -397,6 +451,9 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Series",
 		"CharmURL",
 		"TxnRevno",
+		// StopDeadline is only set on dying units, which
+		// cannot be migrated.
+		"StopDeadline",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -789,6 +846,10 @@ func (s *MigrationSuite) TestStorageInstanceDocFields(c *gc.C) {
 		"DocID",
 		"Life",
 		"Releasing", // only when dying; can't migrate dying storage
+		// LastOwner and FilesystemLabel are informational only,
+		// and not yet supported by the model description.
+		"LastOwner",
+		"FilesystemLabel",
 	)
 	migrated := set.NewStrings(
 		"Id",
@@ -837,7 +898,12 @@ func (s *MigrationSuite) TestPayloadDocFields(c *gc.C) {
 		"State",
 		"Labels",
 	)
-	s.AssertExportedFields(c, payloadDoc{}, migrated.Union(definedThroughContainment))
+	// Restart counts are runtime statistics, and start again from
+	// zero in the target model.
+	ignored := set.NewStrings(
+		"Restarts",
+	)
+	s.AssertExportedFields(c, payloadDoc{}, migrated.Union(definedThroughContainment).Union(ignored))
 }
 
 func (s *MigrationSuite) TestEndpointBindingFields(c *gc.C) {
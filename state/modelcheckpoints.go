@@ -0,0 +1,438 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ModelCheckpoint is a named record of the applications, charms,
+// configuration and relations in a model at a point in time.
+type ModelCheckpoint struct {
+	// Name is the name of the checkpoint, unique within the model.
+	Name string
+
+	// Created is the time at which the checkpoint was created.
+	Created time.Time
+
+	// Applications holds the applications in the model when the
+	// checkpoint was created, sorted by name.
+	Applications []CheckpointApplication
+
+	// Relations holds the keys of the relations in the model when
+	// the checkpoint was created, sorted.
+	Relations []string
+}
+
+// CheckpointApplication records an application in a ModelCheckpoint.
+type CheckpointApplication struct {
+	// Name is the name of the application.
+	Name string
+
+	// CharmURL is the URL of the application's charm.
+	CharmURL string
+
+	// Config holds the charm config values set by the user.
+	Config charm.Settings
+}
+
+type modelCheckpointDoc struct {
+	DocID        string                     `bson:"_id"`
+	Name         string                     `bson:"name"`
+	Created      int64                      `bson:"created"`
+	Applications []checkpointApplicationDoc `bson:"applications"`
+	Relations    []string                   `bson:"relations"`
+}
+
+type checkpointApplicationDoc struct {
+	Name     string      `bson:"name"`
+	CharmURL string      `bson:"charm-url"`
+	Config   settingsMap `bson:"config"`
+}
+
+func (doc *modelCheckpointDoc) toCheckpoint() *ModelCheckpoint {
+	checkpoint := &ModelCheckpoint{
+		Name:         doc.Name,
+		Created:      time.Unix(0, doc.Created).UTC(),
+		Applications: make([]CheckpointApplication, len(doc.Applications)),
+		Relations:    doc.Relations,
+	}
+	for i, app := range doc.Applications {
+		checkpoint.Applications[i] = CheckpointApplication{
+			Name:     app.Name,
+			CharmURL: app.CharmURL,
+			Config:   charm.Settings(app.Config),
+		}
+	}
+	return checkpoint
+}
+
+// CreateModelCheckpoint records the applications, charm URLs, charm
+// config and relations currently in the model under the given name.
+func (st *State) CreateModelCheckpoint(name string) (*ModelCheckpoint, error) {
+	if name == "" {
+		return nil, errors.NotValidf("empty checkpoint name")
+	}
+	var doc modelCheckpointDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		model, err := st.Model()
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to load model")
+		}
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := st.ModelCheckpoint(name); err == nil {
+			return nil, errors.AlreadyExistsf("checkpoint %q", name)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		current, err := st.currentCheckpoint(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc = modelCheckpointDoc{
+			DocID:        name,
+			Name:         name,
+			Created:      st.clock().Now().UnixNano(),
+			Applications: make([]checkpointApplicationDoc, len(current.Applications)),
+			Relations:    current.Relations,
+		}
+		for i, app := range current.Applications {
+			doc.Applications[i] = checkpointApplicationDoc{
+				Name:     app.Name,
+				CharmURL: app.CharmURL,
+				Config:   settingsMap(copyMap(app.Config, escapeReplacer.Replace)),
+			}
+		}
+		return []txn.Op{{
+			C:      modelCheckpointsC,
+			Id:     name,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}, model.assertActiveOp()}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotatef(err, "cannot create checkpoint %q", name)
+	}
+	return st.ModelCheckpoint(name)
+}
+
+// currentCheckpoint returns an unsaved checkpoint describing the
+// model as it is now.
+func (st *State) currentCheckpoint(name string) (*ModelCheckpoint, error) {
+	apps, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	checkpoint := &ModelCheckpoint{
+		Name:         name,
+		Applications: make([]CheckpointApplication, len(apps)),
+	}
+	for i, app := range apps {
+		curl, _ := app.CharmURL()
+		config, err := app.ConfigSettings()
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot read config for application %q", app.Name())
+		}
+		checkpoint.Applications[i] = CheckpointApplication{
+			Name:     app.Name(),
+			CharmURL: curl.String(),
+			Config:   config,
+		}
+	}
+	sort.Slice(checkpoint.Applications, func(i, j int) bool {
+		return checkpoint.Applications[i].Name < checkpoint.Applications[j].Name
+	})
+	relations, err := st.AllRelations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		checkpoint.Relations = append(checkpoint.Relations, rel.String())
+	}
+	sort.Strings(checkpoint.Relations)
+	return checkpoint, nil
+}
+
+// ModelCheckpoint returns the checkpoint with the given name.
+func (st *State) ModelCheckpoint(name string) (*ModelCheckpoint, error) {
+	coll, closer := st.db().GetCollection(modelCheckpointsC)
+	defer closer()
+
+	var doc modelCheckpointDoc
+	err := coll.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("checkpoint %q", name)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.toCheckpoint(), nil
+}
+
+// AllModelCheckpoints returns all the checkpoints in the model, oldest
+// first.
+func (st *State) AllModelCheckpoints() ([]*ModelCheckpoint, error) {
+	coll, closer := st.db().GetCollection(modelCheckpointsC)
+	defer closer()
+
+	var docs []modelCheckpointDoc
+	if err := coll.Find(nil).Sort("created").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*ModelCheckpoint, len(docs))
+	for i := range docs {
+		result[i] = docs[i].toCheckpoint()
+	}
+	return result, nil
+}
+
+// RemoveModelCheckpoint removes the checkpoint with the given name.
+func (st *State) RemoveModelCheckpoint(name string) error {
+	ops := []txn.Op{{
+		C:      modelCheckpointsC,
+		Id:     name,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("checkpoint %q", name)
+	}
+	return errors.Annotatef(err, "cannot remove checkpoint %q", name)
+}
+
+// CheckpointCharmChange records an application whose charm has
+// changed since a checkpoint was created.
+type CheckpointCharmChange struct {
+	Application        string
+	CheckpointCharmURL string
+	CurrentCharmURL    string
+}
+
+// CheckpointConfigChange records an application config value that
+// has changed since a checkpoint was created. A nil value means that
+// the key was not set by the user.
+type CheckpointConfigChange struct {
+	Application     string
+	Key             string
+	CheckpointValue interface{}
+	CurrentValue    interface{}
+}
+
+// ModelCheckpointDiff describes how a model has changed since a
+// checkpoint was created. All fields are sorted.
+type ModelCheckpointDiff struct {
+	AddedApplications   []string
+	RemovedApplications []string
+	ChangedCharms       []CheckpointCharmChange
+	ChangedConfig       []CheckpointConfigChange
+	AddedRelations      []string
+	RemovedRelations    []string
+}
+
+// Empty reports whether the model is unchanged since the checkpoint.
+func (d *ModelCheckpointDiff) Empty() bool {
+	return len(d.AddedApplications) == 0 &&
+		len(d.RemovedApplications) == 0 &&
+		len(d.ChangedCharms) == 0 &&
+		len(d.ChangedConfig) == 0 &&
+		len(d.AddedRelations) == 0 &&
+		len(d.RemovedRelations) == 0
+}
+
+// DiffModelCheckpoint compares the model as it is now against the
+// checkpoint with the given name.
+func (st *State) DiffModelCheckpoint(name string) (*ModelCheckpointDiff, error) {
+	checkpoint, err := st.ModelCheckpoint(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	current, err := st.currentCheckpoint(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return diffCheckpoints(checkpoint, current), nil
+}
+
+func diffCheckpoints(from, to *ModelCheckpoint) *ModelCheckpointDiff {
+	diff := &ModelCheckpointDiff{}
+	fromApps := make(map[string]CheckpointApplication)
+	for _, app := range from.Applications {
+		fromApps[app.Name] = app
+	}
+	toApps := make(map[string]CheckpointApplication)
+	for _, app := range to.Applications {
+		toApps[app.Name] = app
+		if _, ok := fromApps[app.Name]; !ok {
+			diff.AddedApplications = append(diff.AddedApplications, app.Name)
+		}
+	}
+	// Applications are sorted by name, so the changes are too.
+	for _, fromApp := range from.Applications {
+		toApp, ok := toApps[fromApp.Name]
+		if !ok {
+			diff.RemovedApplications = append(diff.RemovedApplications, fromApp.Name)
+			continue
+		}
+		if fromApp.CharmURL != toApp.CharmURL {
+			diff.ChangedCharms = append(diff.ChangedCharms, CheckpointCharmChange{
+				Application:        fromApp.Name,
+				CheckpointCharmURL: fromApp.CharmURL,
+				CurrentCharmURL:    toApp.CharmURL,
+			})
+		}
+		diff.ChangedConfig = append(diff.ChangedConfig, diffConfig(fromApp, toApp)...)
+	}
+
+	fromRelations := set.NewStrings(from.Relations...)
+	toRelations := set.NewStrings(to.Relations...)
+	diff.AddedRelations = toRelations.Difference(fromRelations).SortedValues()
+	diff.RemovedRelations = fromRelations.Difference(toRelations).SortedValues()
+	if len(diff.AddedRelations) == 0 {
+		diff.AddedRelations = nil
+	}
+	if len(diff.RemovedRelations) == 0 {
+		diff.RemovedRelations = nil
+	}
+	return diff
+}
+
+func diffConfig(from, to CheckpointApplication) []CheckpointConfigChange {
+	keys := set.NewStrings()
+	for key := range from.Config {
+		keys.Add(key)
+	}
+	for key := range to.Config {
+		keys.Add(key)
+	}
+	var changes []CheckpointConfigChange
+	for _, key := range keys.SortedValues() {
+		fromValue, toValue := from.Config[key], to.Config[key]
+		if reflect.DeepEqual(fromValue, toValue) {
+			continue
+		}
+		changes = append(changes, CheckpointConfigChange{
+			Application:     from.Name,
+			Key:             key,
+			CheckpointValue: fromValue,
+			CurrentValue:    toValue,
+		})
+	}
+	return changes
+}
+
+// RollbackModelCheckpointConfig restores the charm config of each
+// application to the values recorded in the checkpoint with the given
+// name, in a single transaction. Applications added since the
+// checkpoint are left alone. No config is changed if any application
+// in the checkpoint has since been removed or had its charm changed,
+// since its recorded config may no longer be valid.
+func (st *State) RollbackModelCheckpointConfig(name string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		diff, err := st.DiffModelCheckpoint(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(diff.RemovedApplications) > 0 {
+			return nil, errors.Errorf(
+				"cannot roll back config to checkpoint %q: applications removed since checkpoint: %s",
+				name, strings.Join(diff.RemovedApplications, ", "),
+			)
+		}
+		if len(diff.ChangedCharms) > 0 {
+			changed := make([]string, len(diff.ChangedCharms))
+			for i, change := range diff.ChangedCharms {
+				changed[i] = fmt.Sprintf("%s (%s -> %s)", change.Application, change.CheckpointCharmURL, change.CurrentCharmURL)
+			}
+			return nil, errors.Errorf(
+				"cannot roll back config to checkpoint %q: charms changed since checkpoint: %s",
+				name, strings.Join(changed, ", "),
+			)
+		}
+
+		changes := make(map[string]charm.Settings)
+		var appNames []string
+		for _, change := range diff.ChangedConfig {
+			settings, ok := changes[change.Application]
+			if !ok {
+				settings = make(charm.Settings)
+				changes[change.Application] = settings
+				appNames = append(appNames, change.Application)
+			}
+			// A nil value deletes the key, restoring the charm default.
+			settings[change.Key] = change.CheckpointValue
+		}
+		if len(appNames) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := []txn.Op{{
+			C:      modelCheckpointsC,
+			Id:     name,
+			Assert: txn.DocExists,
+		}}
+		for _, appName := range appNames {
+			appOps, err := st.updateConfigSettingsOps(appName, changes[appName])
+			if err != nil {
+				return nil, errors.Annotatef(err, "cannot roll back config for application %q", appName)
+			}
+			ops = append(ops, appOps...)
+		}
+		return ops, nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// updateConfigSettingsOps returns the operations needed to apply the
+// given changes to an application's charm config. The operations
+// assert that neither the application's charm nor its config has
+// changed since they were read.
+func (st *State) updateConfigSettingsOps(appName string, changes charm.Settings) ([]txn.Op, error) {
+	app, err := st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	changes, err = ch.Config().ValidateSettings(changes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	node, err := readSettings(st.db(), settingsC, app.settingsKey())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for key, value := range changes {
+		if value == nil {
+			node.Delete(key)
+		} else {
+			node.Set(key, value)
+		}
+	}
+	_, settingsOps := node.settingsUpdateOps()
+	if len(settingsOps) == 0 {
+		return nil, nil
+	}
+	settingsOps[0].Assert = bson.D{{"version", node.version}}
+	return append([]txn.Op{{
+		C:      applicationsC,
+		Id:     app.doc.DocID,
+		Assert: bson.D{{"charmurl", app.doc.CharmURL}},
+	}}, settingsOps...), nil
+}
@@ -0,0 +1,247 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+type ModelCheckpointsSuite struct {
+	ConnSuite
+	wordpress *state.Application
+	mysql     *state.Application
+	relation  *state.Relation
+}
+
+var _ = gc.Suite(&ModelCheckpointsSuite{})
+
+func (s *ModelCheckpointsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.mysql = s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err := s.wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "checkpointed"})
+	c.Assert(err, jc.ErrorIsNil)
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelCheckpointsSuite) TestCreate(c *gc.C) {
+	checkpoint, err := s.State.CreateModelCheckpoint("before-upgrade")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checkpoint.Name, gc.Equals, "before-upgrade")
+	c.Assert(checkpoint.Created.IsZero(), jc.IsFalse)
+	c.Assert(checkpoint.Applications, jc.DeepEquals, []state.CheckpointApplication{{
+		Name:     "mysql",
+		CharmURL: "local:quantal/quantal-mysql-1",
+		Config:   charm.Settings{},
+	}, {
+		Name:     "wordpress",
+		CharmURL: "local:quantal/quantal-wordpress-3",
+		Config:   charm.Settings{"blog-title": "checkpointed"},
+	}})
+	c.Assert(checkpoint.Relations, jc.DeepEquals, []string{s.relation.String()})
+
+	fetched, err := s.State.ModelCheckpoint("before-upgrade")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fetched, jc.DeepEquals, checkpoint)
+}
+
+func (s *ModelCheckpointsSuite) TestCreateInvalidName(c *gc.C) {
+	_, err := s.State.CreateModelCheckpoint("")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, "empty checkpoint name not valid")
+}
+
+func (s *ModelCheckpointsSuite) TestCreateDuplicate(c *gc.C) {
+	_, err := s.State.CreateModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CreateModelCheckpoint("one")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `cannot create checkpoint "one": checkpoint "one" already exists`)
+}
+
+func (s *ModelCheckpointsSuite) TestAllAndRemove(c *gc.C) {
+	_, err := s.State.CreateModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CreateModelCheckpoint("two")
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := s.State.AllModelCheckpoints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Assert(all[0].Name, gc.Equals, "one")
+	c.Assert(all[1].Name, gc.Equals, "two")
+
+	err = s.State.RemoveModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ModelCheckpoint("one")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveModelCheckpoint("one")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `checkpoint "one" not found`)
+}
+
+func (s *ModelCheckpointsSuite) TestDiffUnchanged(c *gc.C) {
+	_, err := s.State.CreateModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+	diff, err := s.State.DiffModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(diff.Empty(), jc.IsTrue)
+}
+
+func (s *ModelCheckpointsSuite) TestDiff(c *gc.C) {
+	_, err := s.State.CreateModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.wordpress.UpdateConfigSettings(charm.Settings{"blog-title": nil})
+	c.Assert(err, jc.ErrorIsNil)
+	dummy := s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err = dummy.UpdateConfigSettings(charm.Settings{"outlook": "sunny"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetCharm(state.SetCharmConfig{
+		Charm: s.AddMetaCharm(c, "mysql", metaBase, 2),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	diff, err := s.State.DiffModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(diff.Empty(), jc.IsFalse)
+	c.Assert(diff, jc.DeepEquals, &state.ModelCheckpointDiff{
+		AddedApplications: []string{"dummy"},
+		ChangedCharms: []state.CheckpointCharmChange{{
+			Application:        "mysql",
+			CheckpointCharmURL: "local:quantal/quantal-mysql-1",
+			CurrentCharmURL:    "local:quantal/quantal-mysql-2",
+		}},
+		ChangedConfig: []state.CheckpointConfigChange{{
+			Application:     "wordpress",
+			Key:             "blog-title",
+			CheckpointValue: "checkpointed",
+		}},
+		RemovedRelations: []string{s.relation.String()},
+	})
+}
+
+func (s *ModelCheckpointsSuite) TestDiffNotFound(c *gc.C) {
+	_, err := s.State.DiffModelCheckpoint("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelCheckpointsSuite) TestRollbackConfig(c *gc.C) {
+	_, err := s.State.CreateModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "changed"})
+	c.Assert(err, jc.ErrorIsNil)
+	dummy := s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	err = dummy.UpdateConfigSettings(charm.Settings{"outlook": "sunny"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RollbackModelCheckpointConfig("one")
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := s.wordpress.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"blog-title": "checkpointed"})
+	// Applications added since the checkpoint are left alone.
+	settings, err = dummy.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"outlook": "sunny"})
+}
+
+func (s *ModelCheckpointsSuite) TestRollbackConfigUnsetsNewKeys(c *gc.C) {
+	err := s.wordpress.UpdateConfigSettings(charm.Settings{"blog-title": nil})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CreateModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "changed"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RollbackModelCheckpointConfig("one")
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := s.wordpress.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+}
+
+func (s *ModelCheckpointsSuite) TestRollbackConfigConcurrentChange(c *gc.C) {
+	dummy := s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	_, err := s.State.CreateModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "changed"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "concurrent"})
+		c.Assert(err, jc.ErrorIsNil)
+		err = dummy.UpdateConfigSettings(charm.Settings{"outlook": "sunny"})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = s.State.RollbackModelCheckpointConfig("one")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The transaction is rebuilt, rolling back both changes.
+	settings, err := s.wordpress.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"blog-title": "checkpointed"})
+	settings, err = dummy.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+}
+
+func (s *ModelCheckpointsSuite) TestRollbackConfigCheckpointRemoved(c *gc.C) {
+	dummy := s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	_, err := s.State.CreateModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "changed"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = dummy.UpdateConfigSettings(charm.Settings{"outlook": "sunny"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.RemoveModelCheckpoint("one")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = s.State.RollbackModelCheckpointConfig("one")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Neither application's config is changed.
+	settings, err := s.wordpress.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"blog-title": "changed"})
+	settings, err = dummy.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"outlook": "sunny"})
+}
+
+func (s *ModelCheckpointsSuite) TestRollbackConfigCharmChanged(c *gc.C) {
+	_, err := s.State.CreateModelCheckpoint("one")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.UpdateConfigSettings(charm.Settings{"blog-title": "changed"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetCharm(state.SetCharmConfig{
+		Charm: s.AddMetaCharm(c, "mysql", metaBase, 2),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RollbackModelCheckpointConfig("one")
+	c.Assert(err, gc.ErrorMatches, `cannot roll back config to checkpoint "one": charms changed since checkpoint: `+
+		`mysql \(local:quantal/quantal-mysql-1 -> local:quantal/quantal-mysql-2\)`)
+
+	// Nothing is rolled back.
+	settings, err := s.wordpress.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, charm.Settings{"blog-title": "changed"})
+}
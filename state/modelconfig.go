@@ -93,7 +93,6 @@ func (model *Model) modelConfigValues(modelCfg attrValues) (config.ConfigValues,
 	sourceNames := make([]string, 0, len(configSources))
 	sourceAttrs := make([]attrValues, 0, len(configSources))
 	for _, src := range configSources {
-		sourceNames = append(sourceNames, src.name)
 		cfg, err := src.sourceFunc()
 		if errors.IsNotFound(err) {
 			continue
@@ -101,6 +100,7 @@ func (model *Model) modelConfigValues(modelCfg attrValues) (config.ConfigValues,
 		if err != nil {
 			return nil, errors.Annotatef(err, "reading %s settings", src.name)
 		}
+		sourceNames = append(sourceNames, src.name)
 		sourceAttrs = append(sourceAttrs, cfg)
 
 		// If no modelCfg was passed in, we'll accumulate data
@@ -136,14 +136,19 @@ func (model *Model) modelConfigValues(modelCfg attrValues) (config.ConfigValues,
 	return result, nil
 }
 
-// UpdateModelConfigDefaultValues updates the inherited settings used when creating a new model.
+// UpdateModelConfigDefaultValues updates the inherited settings used when
+// creating a new model. If regionSpec is nil, the controller settings are
+// updated; if it has no region, those of the whole cloud are.
 func (model *Model) UpdateModelConfigDefaultValues(attrs map[string]interface{}, removed []string, regionSpec *environs.RegionSpec) error {
 	var key string
 
-	if regionSpec != nil {
-		key = regionSettingsGlobalKey(regionSpec.Cloud, regionSpec.Region)
-	} else {
+	switch {
+	case regionSpec == nil:
 		key = controllerInheritedSettingsGlobalKey
+	case regionSpec.Region == "":
+		key = cloudSettingsGlobalKey(regionSpec.Cloud)
+	default:
+		key = regionSettingsGlobalKey(regionSpec.Cloud, regionSpec.Region)
 	}
 	settings, err := readSettings(model.st.db(), globalSettingsC, key)
 	if err != nil {
@@ -214,6 +219,16 @@ func (model *Model) ModelConfigDefaultValues() (config.ModelDefaultAttributes, e
 			result[k] = config.AttributeDefaultValues{Controller: v}
 		}
 	}
+	// Cloud config
+	cloudCfg, err := model.State().cloudInheritedConfig(&environs.RegionSpec{Cloud: cloudName})()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	for k, v := range cloudCfg {
+		ds := result[k]
+		ds.Cloud = v
+		result[k] = ds
+	}
 	// Region config
 	for _, region := range cloud.Regions {
 		rspec := &environs.RegionSpec{Cloud: cloudName, Region: region.Name}
@@ -362,6 +377,7 @@ func modelConfigSources(st *State, regionSpec *environs.RegionSpec) []modelConfi
 	return []modelConfigSource{
 		{config.JujuDefaultSource, st.defaultInheritedConfig},
 		{config.JujuControllerSource, st.controllerInheritedConfig},
+		{config.JujuCloudSource, st.cloudInheritedConfig(regionSpec)},
 		{config.JujuRegionSource, st.regionInheritedConfig(regionSpec)},
 	}
 }
@@ -405,6 +421,27 @@ func (st *State) controllerInheritedConfig() (attrValues, error) {
 	return settings.Map(), nil
 }
 
+// cloudInheritedConfig returns the configuration attributes for the cloud
+// where the model is targeted.
+func (st *State) cloudInheritedConfig(regionSpec *environs.RegionSpec) func() (attrValues, error) {
+	if regionSpec == nil {
+		return func() (attrValues, error) {
+			return nil, errors.New(
+				"no environs.RegionSpec provided")
+		}
+	}
+	return func() (attrValues, error) {
+		settings, err := readSettings(st.db(),
+			globalSettingsC,
+			cloudSettingsGlobalKey(regionSpec.Cloud),
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return settings.Map(), nil
+	}
+}
+
 // regionInheritedConfig returns the configuration attributes for the region in
 // the cloud where the model is targeted.
 func (st *State) regionInheritedConfig(regionSpec *environs.RegionSpec) func() (attrValues, error) {
@@ -476,6 +513,35 @@ func composeModelConfigAttributes(
 	return resultAttrs, nil
 }
 
+// EffectiveModelConfigDefaults returns the default config values that
+// would be used for a new model in the given cloud region, along with
+// the source of each: a region value takes precedence over a cloud
+// value, which takes precedence over a controller value. If the
+// region is empty, only the cloud, controller and Juju defaults are
+// considered.
+func (st *State) EffectiveModelConfigDefaults(regionSpec *environs.RegionSpec) (config.ConfigValues, error) {
+	if regionSpec == nil {
+		return nil, errors.NotValidf("nil RegionSpec")
+	}
+	result := make(config.ConfigValues)
+	for _, source := range modelConfigSources(st, regionSpec) {
+		attrs, err := source.sourceFunc()
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading %s settings", source.name)
+		}
+		for name, value := range attrs {
+			result[name] = config.ConfigValue{
+				Value:  value,
+				Source: source.name,
+			}
+		}
+	}
+	return result, nil
+}
+
 // ComposeNewModelConfig returns a complete map of config attributes suitable for
 // creating a new model, by combining user specified values with system defaults.
 func (st *State) ComposeNewModelConfig(modelAttr map[string]interface{}, regionSpec *environs.RegionSpec) (map[string]interface{}, error) {
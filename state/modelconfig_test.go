@@ -23,6 +23,7 @@ import (
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type ModelConfigSuite struct {
@@ -570,6 +571,79 @@ func (s *ModelConfigSourceSuite) TestUpdateModelConfigRegionDefaults(c *gc.C) {
 	c.Assert(cfg, jc.DeepEquals, expectedValues)
 }
 
+func (s *ModelConfigSourceSuite) TestUpdateModelConfigCloudDefaults(c *gc.C) {
+	attrs := map[string]interface{}{
+		"apt-mirror": "http://dummy-cloud-mirror",
+		"ftp-proxy":  "http://dummy-cloud-proxy",
+	}
+	err := s.IAASModel.UpdateModelConfigDefaultValues(attrs, nil, &environs.RegionSpec{Cloud: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.IAASModel.ModelConfigDefaultValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg["apt-mirror"], jc.DeepEquals, config.AttributeDefaultValues{
+		Default:    "",
+		Controller: "http://mirror",
+		Cloud:      "http://dummy-cloud-mirror",
+		Regions: []config.RegionDefaultValue{{
+			Name:  "dummy-region",
+			Value: "http://dummy-mirror",
+		}}})
+	c.Assert(cfg["ftp-proxy"], jc.DeepEquals, config.AttributeDefaultValues{
+		Default: "",
+		Cloud:   "http://dummy-cloud-proxy",
+	})
+
+	err = s.IAASModel.UpdateModelConfigDefaultValues(nil, []string{"ftp-proxy"}, &environs.RegionSpec{Cloud: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err = s.IAASModel.ModelConfigDefaultValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg["ftp-proxy"], jc.DeepEquals, config.AttributeDefaultValues{Default: ""})
+}
+
+func (s *ModelConfigSourceSuite) TestEffectiveModelConfigDefaults(c *gc.C) {
+	attrs := map[string]interface{}{
+		"apt-mirror": "http://dummy-cloud-mirror",
+		"ftp-proxy":  "http://dummy-cloud-proxy",
+	}
+	err := s.IAASModel.UpdateModelConfigDefaultValues(attrs, nil, &environs.RegionSpec{Cloud: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	values, err := s.State.EffectiveModelConfigDefaults(&environs.RegionSpec{
+		Cloud:  "dummy",
+		Region: "dummy-region",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["apt-mirror"], jc.DeepEquals, config.ConfigValue{Value: "http://dummy-mirror", Source: "region"})
+	c.Assert(values["ftp-proxy"], jc.DeepEquals, config.ConfigValue{Value: "http://dummy-cloud-proxy", Source: "cloud"})
+	c.Assert(values["http-proxy"], jc.DeepEquals, config.ConfigValue{Value: "http://proxy", Source: "controller"})
+	c.Assert(values["no-proxy"], jc.DeepEquals, config.ConfigValue{Value: "dummy-proxy", Source: "region"})
+	c.Assert(values["logging-config"].Source, gc.Equals, "default")
+
+	// Without a region, the cloud value is used.
+	values, err = s.State.EffectiveModelConfigDefaults(&environs.RegionSpec{Cloud: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["apt-mirror"], jc.DeepEquals, config.ConfigValue{Value: "http://dummy-cloud-mirror", Source: "cloud"})
+	c.Assert(values["no-proxy"], jc.DeepEquals, config.ConfigValue{Value: "127.0.0.1,localhost,::1", Source: "default"})
+}
+
+func (s *ModelConfigSourceSuite) TestModelConfigValuesCloudSource(c *gc.C) {
+	attrs := map[string]interface{}{
+		"ftp-proxy": "http://dummy-cloud-proxy",
+	}
+	err := s.IAASModel.UpdateModelConfigDefaultValues(attrs, nil, &environs.RegionSpec{Cloud: "dummy"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, &factory.ModelParams{CloudRegion: "dummy-region"})
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	values, err := m.ModelConfigValues()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values["ftp-proxy"], jc.DeepEquals, config.ConfigValue{Value: "http://dummy-cloud-proxy", Source: "cloud"})
+	c.Assert(values["apt-mirror"], jc.DeepEquals, config.ConfigValue{Value: "http://dummy-mirror", Source: "region"})
+}
+
 func (s *ModelConfigSourceSuite) TestUpdateModelConfigDefaultValuesUnknownRegion(c *gc.C) {
 	// Set up settings to create
 	attrs := map[string]interface{}{
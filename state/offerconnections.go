@@ -5,8 +5,10 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/status"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
@@ -29,8 +31,19 @@ type offerConnectionDoc struct {
 	OfferUUID       string `bson:"offer-uuid"`
 	UserName        string `bson:"username"`
 	SourceModelUUID string `bson:"source-model-uuid"`
+
+	// LastIngress and MacaroonRefreshed record, in Unix nanoseconds,
+	// when the consuming model last made an authenticated call for
+	// the relation, and when it was last issued a relation macaroon.
+	LastIngress       int64 `bson:"last-ingress,omitempty"`
+	MacaroonRefreshed int64 `bson:"macaroon-refreshed,omitempty"`
 }
 
+// offerConnectionActivityResolution is the minimum time between
+// updates to the activity timestamps of an offer connection, so
+// that busy relations do not write to the database on every call.
+const offerConnectionActivityResolution = time.Minute
+
 func newOfferConnection(st *State, doc *offerConnectionDoc) *OfferConnection {
 	app := &OfferConnection{
 		st:  st,
@@ -64,6 +77,73 @@ func (oc *OfferConnection) RelationKey() string {
 	return oc.doc.RelationKey
 }
 
+// LastIngress returns when the consuming model last made an
+// authenticated call for the relation, or the zero time if it
+// never has.
+func (oc *OfferConnection) LastIngress() time.Time {
+	return unixNanoTime(oc.doc.LastIngress)
+}
+
+// MacaroonRefreshed returns when the consuming model was last issued
+// a macaroon for the relation, or the zero time if it never has.
+func (oc *OfferConnection) MacaroonRefreshed() time.Time {
+	return unixNanoTime(oc.doc.MacaroonRefreshed)
+}
+
+// LastSeen returns when the consuming model was last heard from.
+func (oc *OfferConnection) LastSeen() time.Time {
+	lastSeen := oc.LastIngress()
+	if refreshed := oc.MacaroonRefreshed(); refreshed.After(lastSeen) {
+		lastSeen = refreshed
+	}
+	return lastSeen
+}
+
+// Health returns the health of the connection, based on when the
+// consuming model was last heard from.
+func (oc *OfferConnection) Health() crossmodel.OfferConnectionHealth {
+	return crossmodel.ConnectionHealth(oc.LastSeen(), oc.st.clock().Now())
+}
+
+// RecordIngress records that the consuming model has just made an
+// authenticated call for the relation.
+func (oc *OfferConnection) RecordIngress() error {
+	return oc.recordActivity("last-ingress", &oc.doc.LastIngress)
+}
+
+// RecordMacaroonRefresh records that the consuming model has just
+// been issued a macaroon for the relation.
+func (oc *OfferConnection) RecordMacaroonRefresh() error {
+	return oc.recordActivity("macaroon-refreshed", &oc.doc.MacaroonRefreshed)
+}
+
+func (oc *OfferConnection) recordActivity(field string, last *int64) error {
+	now := oc.st.clock().Now()
+	if now.Sub(unixNanoTime(*last)) < offerConnectionActivityResolution {
+		return nil
+	}
+	ops := []txn.Op{{
+		C:      offerConnectionsC,
+		Id:     oc.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{field, now.UnixNano()}}}},
+	}}
+	if err := oc.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("offer connection for relation %q", oc.doc.RelationKey)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot record activity for %s", oc)
+	}
+	*last = now.UnixNano()
+	return nil
+}
+
+func unixNanoTime(t int64) time.Time {
+	if t == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, t)
+}
+
 func removeOfferConnectionsForRelationOps(relId int) []txn.Op {
 	op := txn.Op{
 		C:      offerConnectionsC,
@@ -132,6 +212,7 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 		RelationId:      args.RelationId,
 		RelationKey:     args.RelationKey,
 		DocID:           fmt.Sprintf("%d", args.RelationId),
+		LastIngress:     st.clock().Now().UnixNano(),
 	}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		// If we've tried once already and failed, check that
@@ -156,7 +237,7 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 	if err = st.db().Run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &OfferConnection{doc: offerConnectionDoc}, nil
+	return newOfferConnection(st, &offerConnectionDoc), nil
 }
 
 // OfferConnections returns the offer connections for an offer.
@@ -175,6 +256,65 @@ func (st *State) OfferConnections(offerUUID string) (conns []*OfferConnection, e
 	return conns, nil
 }
 
+// AllOfferConnections returns all the offer connections in the model.
+func (st *State) AllOfferConnections() ([]*OfferConnection, error) {
+	offerConnectionCollection, closer := st.db().GetCollection(offerConnectionsC)
+	defer closer()
+
+	var connDocs []offerConnectionDoc
+	if err := offerConnectionCollection.Find(nil).All(&connDocs); err != nil {
+		return nil, errors.Annotate(err, "cannot get offer connections")
+	}
+	conns := make([]*OfferConnection, len(connDocs))
+	for i := range connDocs {
+		conns[i] = newOfferConnection(st, &connDocs[i])
+	}
+	return conns, nil
+}
+
+// PruneOfferConnections removes the relations of offer connections
+// whose consuming model has not been heard from for longer than
+// maxStaleness. Connections from models hosted by this controller
+// are never pruned, as those models clean up their own relations
+// when destroyed; the pruning is intended for relations left behind
+// when a consuming model on another controller went away without
+// removing them.
+func PruneOfferConnections(st *State, maxStaleness time.Duration) error {
+	conns, err := st.AllOfferConnections()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := st.clock().Now()
+	for _, conn := range conns {
+		// Connections recorded before activity was tracked have
+		// no last seen time; leave them until the consumer is
+		// next heard from.
+		lastSeen := conn.LastSeen()
+		if lastSeen.IsZero() || now.Sub(lastSeen) <= maxStaleness {
+			continue
+		}
+		exists, err := st.ModelExists(conn.SourceModelUUID())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if exists {
+			continue
+		}
+		rel, err := st.KeyRelation(conn.RelationKey())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		logger.Infof("removing relation %q: consuming model %s last seen at %v",
+			rel, conn.SourceModelUUID(), lastSeen)
+		if err := rel.Destroy(); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "pruning %s", conn)
+		}
+	}
+	return nil
+}
+
 // OfferConnectionForRelation returns the offer connection for the specified relation.
 func (st *State) OfferConnectionForRelation(relationKey string) (*OfferConnection, error) {
 	offerConnectionCollection, closer := st.db().GetCollection(offerConnectionsC)
@@ -8,7 +8,10 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"fmt"
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
@@ -167,3 +170,74 @@ func (s *offerConnectionsSuite) TestOfferConnectionsForUser(c *gc.C) {
 	c.Assert(obtained[0].OfferUUID(), gc.Equals, oc.OfferUUID())
 	c.Assert(obtained[0].UserName(), gc.Equals, oc.UserName())
 }
+
+func (s *offerConnectionsSuite) TestRecordActivity(c *gc.C) {
+	oc, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.activeRel.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       "offer-uuid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	added := s.Clock.Now()
+	c.Assert(oc.LastIngress().Equal(added), jc.IsTrue)
+	c.Assert(oc.MacaroonRefreshed().IsZero(), jc.IsTrue)
+	c.Assert(oc.Health(), gc.Equals, crossmodel.OfferConnectionHealthy)
+
+	// Activity within a minute of the last record is not written.
+	s.Clock.Advance(30 * time.Second)
+	err = oc.RecordIngress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(oc.LastIngress().Equal(added), jc.IsTrue)
+
+	s.Clock.Advance(time.Minute)
+	err = oc.RecordMacaroonRefresh()
+	c.Assert(err, jc.ErrorIsNil)
+
+	obtained, err := s.State.OfferConnectionForRelation(s.activeRel.Tag().Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained.LastIngress().Equal(added), jc.IsTrue)
+	c.Assert(obtained.MacaroonRefreshed().Equal(s.Clock.Now()), jc.IsTrue)
+	c.Assert(obtained.LastSeen().Equal(s.Clock.Now()), jc.IsTrue)
+
+	s.Clock.Advance(crossmodel.OfferConnectionStaleAfter + time.Second)
+	c.Assert(obtained.Health(), gc.Equals, crossmodel.OfferConnectionStale)
+}
+
+func (s *offerConnectionsSuite) TestPruneOfferConnections(c *gc.C) {
+	// The consumer of the active relation is on another controller,
+	// while the consumer of the suspended relation is hosted here.
+	_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.activeRel.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       "offer-uuid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: s.State.ModelUUID(),
+		RelationId:      s.suspendedRel.Id(),
+		RelationKey:     s.suspendedRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       "offer-uuid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneOfferConnections(s.State, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.activeRel.Refresh(), jc.ErrorIsNil)
+	c.Assert(s.suspendedRel.Refresh(), jc.ErrorIsNil)
+
+	s.Clock.Advance(time.Hour + time.Second)
+	err = state.PruneOfferConnections(s.State, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.activeRel.Refresh(), jc.Satisfies, errors.IsNotFound)
+	c.Assert(s.suspendedRel.Refresh(), jc.ErrorIsNil)
+
+	conns, err := s.State.AllOfferConnections()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conns, gc.HasLen, 1)
+	c.Assert(conns[0].RelationId(), gc.Equals, s.suspendedRel.Id())
+}
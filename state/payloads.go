@@ -5,10 +5,12 @@ package state
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/payload"
+	"github.com/juju/juju/status"
 )
 
 // ModelPayloads returns a ModelPayloads for the state's model.
@@ -43,6 +45,7 @@ func (st *State) UnitPayloads(unit *Unit) (UnitPayloads, error) {
 	}
 	return UnitPayloads{
 		db:      st.database,
+		clock:   st.clock(),
 		unit:    unit.Name(),
 		machine: machineID,
 	}, nil
@@ -51,6 +54,7 @@ func (st *State) UnitPayloads(unit *Unit) (UnitPayloads, error) {
 // UnitPayloads lets you CRUD payloads for a single unit.
 type UnitPayloads struct {
 	db      Database
+	clock   clock.Clock
 	unit    string
 	machine string
 }
@@ -123,6 +127,7 @@ func (up UnitPayloads) Track(pl payload.Payload) error {
 	if err := Apply(up.db, change); err != nil {
 		return errors.Trace(err)
 	}
+	up.recordHistory(pl.Name)
 	return nil
 }
 
@@ -142,9 +147,48 @@ func (up UnitPayloads) SetStatus(name, status string) error {
 	if err := Apply(up.db, change); err != nil {
 		return errors.Trace(err)
 	}
+	up.recordHistory(name)
 	return nil
 }
 
+// recordHistory adds the current state of the named payload to the
+// unit's payload status history. Failures are logged, not returned,
+// as for other status history.
+func (up UnitPayloads) recordHistory(name string) {
+	coll, closer := up.db.GetCollection(payloadsC)
+	defer closer()
+
+	var doc payloadDoc
+	if err := coll.FindId(nsPayloads.docID(up.unit, name)).One(&doc); err != nil {
+		logger.Errorf("cannot record status history for payload %q of unit %q: %v", name, up.unit, err)
+		return
+	}
+	probablyUpdateStatusHistory(up.db, unitPayloadsGlobalKey(up.unit), statusDoc{
+		Status:     status.Status(doc.State),
+		StatusInfo: doc.Name,
+		StatusData: map[string]interface{}{
+			"payload":  doc.Name,
+			"type":     doc.Type,
+			"id":       doc.RawID,
+			"restarts": doc.Restarts,
+		},
+		Updated: up.clock.Now().UnixNano(),
+	})
+}
+
+// unitPayloadsGlobalKey returns the global database key under which
+// the status history of the named unit's payloads is recorded.
+func unitPayloadsGlobalKey(unitName string) string {
+	return unitAgentGlobalKey(unitName) + "#payloads"
+}
+
+// PayloadHistory returns a StatusHistoryGetter for the status history
+// of the unit's payloads. Each entry's data records the payload's
+// name, type, id and restart count.
+func (u *Unit) PayloadHistory() status.StatusHistoryGetter {
+	return &HistoryGetter{st: u.st, globalKey: unitPayloadsGlobalKey(u.doc.Name)}
+}
+
 // Untrack removes the identified payload from state. It does not
 // trigger the actual destruction of the payload. If the payload is
 // missing then this is a noop.
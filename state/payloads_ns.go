@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
@@ -43,6 +44,10 @@ type payloadDoc struct {
 	// left over after we hoovered up <Type> <Name> <RawID> from the
 	// command line.
 	Labels []string `bson:"labels"`
+
+	// Restarts counts the times the payload has been started again
+	// after stopping.
+	Restarts int `bson:"restarts,omitempty"`
 }
 
 // nsPayloads_ backs nsPayloads.
@@ -94,6 +99,7 @@ func (nsPayloads_) asDoc(p payload.FullPayloadInfo) payloadDoc {
 		RawID:     p.ID,
 		State:     p.Status,
 		Labels:    labels,
+		Restarts:  p.Restarts,
 	}
 }
 
@@ -112,7 +118,8 @@ func (nsPayloads_) asPayload(doc payloadDoc) payload.FullPayloadInfo {
 			Labels: labels,
 			Unit:   doc.UnitID,
 		},
-		Machine: doc.MachineID,
+		Machine:  doc.MachineID,
+		Restarts: doc.Restarts,
 	}
 	return p
 }
@@ -163,23 +170,39 @@ func (nsPayloads_) orderedResults(docs []payloadDoc, names []string) []payload.R
 	return results
 }
 
+// restarted reports whether a payload changing from one state to
+// another has been started again after stopping.
+func (nsPayloads_) restarted(from, to string) bool {
+	stopped := from == payload.StateStopping || from == payload.StateStopped
+	started := to == payload.StateStarting || to == payload.StateRunning
+	return stopped && started
+}
+
 // trackOp returns a txn.Op that will either insert or update the
 // supplied payload, and fail if the observed precondition changes.
+// Replacing a stopped payload with a started one counts as a restart.
 func (nsPayloads_) trackOp(payloads mongo.Collection, doc payloadDoc) (txn.Op, error) {
 	docID := nsPayloads.docID(doc.UnitID, doc.Name)
 	payloadOp := txn.Op{
 		C:  payloads.Name(),
 		Id: docID,
 	}
-	count, err := payloads.FindId(docID).Count()
-	if err != nil {
-		return txn.Op{}, errors.Trace(err)
-	} else if count == 0 {
+	var existing payloadDoc
+	err := payloads.FindId(docID).One(&existing)
+	if err == mgo.ErrNotFound {
 		payloadOp.Assert = txn.DocMissing
 		payloadOp.Insert = doc
+	} else if err != nil {
+		return txn.Op{}, errors.Trace(err)
 	} else {
-		payloadOp.Assert = txn.DocExists
-		payloadOp.Update = bson.D{{"$set", doc}}
+		// The restart count is kept, not replaced.
+		doc.Restarts = 0
+		update := bson.D{{"$set", doc}}
+		if nsPayloads.restarted(existing.State, doc.State) {
+			update = append(update, bson.DocElem{"$inc", bson.D{{"restarts", 1}}})
+		}
+		payloadOp.Assert = bson.D{{"state", existing.State}}
+		payloadOp.Update = update
 	}
 	return payloadOp, nil
 }
@@ -203,19 +226,25 @@ func (nsPayloads_) untrackOp(payloads mongo.Collection, docID string) (txn.Op, e
 }
 
 // setStatusOp returns a txn.Op that updates the status of the
-// identified payload. If the payload doesn't exist, it returns
+// identified payload, counting a restart if it is being started again
+// after stopping. If the payload doesn't exist, it returns
 // errAlreadyRemoved.
 func (nsPayloads_) setStatusOp(payloads mongo.Collection, docID string, status string) (txn.Op, error) {
-	count, err := payloads.FindId(docID).Count()
-	if err != nil {
-		return txn.Op{}, errors.Trace(err)
-	} else if count == 0 {
+	var existing payloadDoc
+	err := payloads.FindId(docID).One(&existing)
+	if err == mgo.ErrNotFound {
 		return txn.Op{}, errAlreadyRemoved
+	} else if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	update := bson.D{{"$set", bson.D{{"state", status}}}}
+	if nsPayloads.restarted(existing.State, status) {
+		update = append(update, bson.DocElem{"$inc", bson.D{{"restarts", 1}}})
 	}
 	return txn.Op{
 		C:      payloads.Name(),
 		Id:     docID,
-		Assert: txn.DocExists,
-		Update: bson.D{{"$set", bson.D{{"state", status}}}},
+		Assert: bson.D{{"state", existing.State}},
+		Update: update,
 	}, nil
 }
@@ -6,6 +6,7 @@ package state_test
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/juju/juju/payload"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing/factory"
 )
 
@@ -186,6 +188,64 @@ func (s *PayloadsSuite) TestSetStatus(c *gc.C) {
 	fix.CheckOnePayload(c, expect)
 }
 
+func (s *PayloadsSuite) TestSetStatusCountsRestarts(c *gc.C) {
+	fix, initial := s.newPayloadFixture(c)
+
+	for _, st := range []string{"stopping", "stopped", "starting", "running", "stopped", "running"} {
+		err := fix.UnitPayloads.SetStatus(initial.Name, st)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	full := fix.FullPayload(initial)
+	full.Restarts = 2
+	fix.CheckUnitPayloads(c, full)
+	fix.CheckModelPayloads(c, full)
+}
+
+func (s *PayloadsSuite) TestTrackCountsRestarts(c *gc.C) {
+	fix, initial := s.newPayloadFixture(c)
+	err := fix.UnitPayloads.SetStatus(initial.Name, "stopped")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Tracking the payload again, e.g. after its container has been
+	// recreated, counts as a restart and keeps the existing count.
+	replacement := initial
+	replacement.ID = "new-exciting-different"
+	err = fix.UnitPayloads.Track(replacement)
+	c.Assert(err, jc.ErrorIsNil)
+	err = fix.UnitPayloads.Track(replacement)
+	c.Assert(err, jc.ErrorIsNil)
+
+	full := fix.FullPayload(replacement)
+	full.Restarts = 1
+	fix.CheckUnitPayloads(c, full)
+}
+
+func (s *PayloadsSuite) TestPayloadHistory(c *gc.C) {
+	fix, initial := s.newPayloadFixture(c)
+	s.Clock.Advance(time.Second)
+	err := fix.UnitPayloads.SetStatus(initial.Name, "stopped")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Second)
+	err = fix.UnitPayloads.SetStatus(initial.Name, "running")
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := fix.Unit.PayloadHistory().StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Check(history[0].Status, gc.Equals, status.Status("running"))
+	c.Check(history[0].Message, gc.Equals, "database")
+	c.Check(history[0].Data, jc.DeepEquals, map[string]interface{}{
+		"payload":  "database",
+		"type":     "docker",
+		"id":       "some-docker-id",
+		"restarts": 1,
+	})
+	c.Check(history[1].Status, gc.Equals, status.Status("stopped"))
+	c.Check(history[2].Status, gc.Equals, status.Status("running"))
+	c.Check(history[2].Data["restarts"], gc.Equals, 0)
+}
+
 func (s *PayloadsSuite) TestUntrackMissing(c *gc.C) {
 	fix := s.newFixture(c)
 
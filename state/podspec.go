@@ -0,0 +1,432 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/mongo"
+)
+
+// MaxPodSpecHistory is the number of pod spec revisions kept for each
+// application.
+const MaxPodSpecHistory = 100
+
+// RolloutState describes how far the rollout of an application's pod
+// spec has progressed.
+type RolloutState string
+
+const (
+	// RolloutPending is the state of a pod spec revision that has
+	// not yet started to roll out.
+	RolloutPending RolloutState = "pending"
+
+	// RolloutProgressing is the state of a pod spec revision that is
+	// replacing the application's pods.
+	RolloutProgressing RolloutState = "progressing"
+
+	// RolloutComplete is the state of a pod spec revision that is
+	// running in all of the application's pods.
+	RolloutComplete RolloutState = "complete"
+
+	// RolloutFailed is the state of a pod spec revision that could
+	// not be rolled out.
+	RolloutFailed RolloutState = "failed"
+)
+
+// Valid returns whether the rollout state is one of the known values.
+func (s RolloutState) Valid() bool {
+	switch s {
+	case RolloutPending, RolloutProgressing, RolloutComplete, RolloutFailed:
+		return true
+	}
+	return false
+}
+
+// PodSpec records a revision of the Kubernetes pod spec of an
+// application.
+type PodSpec struct {
+	// Spec holds the pod spec, as YAML.
+	Spec string
+
+	// Revision is the revision of the pod spec, which increases every
+	// time the spec changes.
+	Revision int
+
+	// User is the name of the user that set the spec, if known.
+	User string
+
+	// Time is when the spec was set.
+	Time time.Time
+}
+
+// RolloutStatus describes the rollout of the current revision of an
+// application's pod spec.
+type RolloutStatus struct {
+	// Revision is the pod spec revision being rolled out.
+	Revision int
+
+	// State is how far the rollout has progressed.
+	State RolloutState
+
+	// Message holds any further information about the rollout.
+	Message string
+
+	// Since is when the rollout status was last changed.
+	Since time.Time
+}
+
+// podSpecDoc holds the current pod spec, scale and rollout status of
+// an application in a Kubernetes model. The document is created the
+// first time any of them is set.
+type podSpecDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Application string `bson:"application"`
+
+	// Spec and Revision are empty until a pod spec is first set.
+	Spec     string `bson:"spec"`
+	Revision int    `bson:"revision"`
+	User     string `bson:"user,omitempty"`
+	Time     int64  `bson:"time"`
+
+	Scale int `bson:"scale"`
+
+	RolloutState   RolloutState `bson:"rollout-state"`
+	RolloutMessage string       `bson:"rollout-message"`
+	RolloutSince   int64        `bson:"rollout-since"`
+
+	TxnRevno int64 `bson:"txn-revno"`
+}
+
+type podSpecHistoryDoc struct {
+	ModelUUID   string `bson:"model-uuid"`
+	Application string `bson:"application"`
+	Spec        string `bson:"spec"`
+	Revision    int    `bson:"revision"`
+	User        string `bson:"user,omitempty"`
+	Time        int64  `bson:"time"`
+}
+
+// podSpec returns the pod spec document of the application. It
+// returns an error satisfying errors.IsNotFound if none of the
+// application's pod spec, scale or rollout status has been set.
+func (a *Application) podSpec() (*podSpecDoc, error) {
+	coll, closer := a.st.db().GetCollection(podSpecsC)
+	defer closer()
+
+	var doc podSpecDoc
+	err := coll.FindId(a.doc.Name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("pod spec for application %q", a.doc.Name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get pod spec for application %q", a.doc.Name)
+	}
+	return &doc, nil
+}
+
+// updatePodSpec runs a transaction that applies the given change to
+// the application's pod spec document, creating it if need be. The
+// change may return jujutxn.ErrNoOperations if there is nothing to do.
+func (a *Application) updatePodSpec(change func(doc *podSpecDoc) error) error {
+	app := &Application{st: a.st, doc: a.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.doc.Life != Alive {
+			return nil, errors.New("application is no longer alive")
+		}
+		doc, err := app.podSpec()
+		exists := err == nil
+		if errors.IsNotFound(err) {
+			doc = &podSpecDoc{
+				DocID:       a.st.docID(app.doc.Name),
+				ModelUUID:   a.st.ModelUUID(),
+				Application: app.doc.Name,
+			}
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := change(doc); err != nil {
+			return nil, err
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		if !exists {
+			return append(ops, txn.Op{
+				C:      podSpecsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      podSpecsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"spec", doc.Spec},
+				{"revision", doc.Revision},
+				{"user", doc.User},
+				{"time", doc.Time},
+				{"scale", doc.Scale},
+				{"rollout-state", doc.RolloutState},
+				{"rollout-message", doc.RolloutMessage},
+				{"rollout-since", doc.RolloutSince},
+			}}},
+		}), nil
+	}
+	return a.st.db().Run(buildTxn)
+}
+
+// PodSpec returns the current revision of the application's
+// Kubernetes pod spec. It returns an error satisfying
+// errors.IsNotFound if no pod spec has been set.
+func (a *Application) PodSpec() (PodSpec, error) {
+	doc, err := a.podSpec()
+	if err != nil {
+		return PodSpec{}, errors.Trace(err)
+	}
+	if doc.Revision == 0 {
+		return PodSpec{}, errors.NotFoundf("pod spec for application %q", a.doc.Name)
+	}
+	return PodSpec{
+		Spec:     doc.Spec,
+		Revision: doc.Revision,
+		User:     doc.User,
+		Time:     time.Unix(0, doc.Time).UTC(),
+	}, nil
+}
+
+// SetPodSpec sets the application's Kubernetes pod spec, as set by the
+// named user. Setting a different spec increases the spec's revision,
+// records it in the pod spec history, and resets the rollout status
+// to pending; setting the current spec again does nothing.
+func (a *Application) SetPodSpec(spec, user string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set pod spec for application %q", a)
+	if spec == "" {
+		return errors.NotValidf("empty pod spec")
+	}
+	var changed podSpecDoc
+	err = a.updatePodSpec(func(doc *podSpecDoc) error {
+		if doc.Revision > 0 && doc.Spec == spec {
+			return jujutxn.ErrNoOperations
+		}
+		now := a.st.clock().Now().UnixNano()
+		doc.Spec = spec
+		doc.Revision++
+		doc.User = user
+		doc.Time = now
+		doc.RolloutState = RolloutPending
+		doc.RolloutMessage = ""
+		doc.RolloutSince = now
+		changed = *doc
+		return nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if changed.Revision == 0 {
+		return nil
+	}
+	if err := a.recordPodSpecHistory(changed); err != nil {
+		logger.Errorf("cannot record pod spec history for application %q: %v", a.doc.Name, err)
+	}
+	return nil
+}
+
+// recordPodSpecHistory records the given pod spec revision in the
+// application's pod spec history.
+func (a *Application) recordPodSpecHistory(spec podSpecDoc) error {
+	doc := &podSpecHistoryDoc{
+		ModelUUID:   a.st.ModelUUID(),
+		Application: a.doc.Name,
+		Spec:        spec.Spec,
+		Revision:    spec.Revision,
+		User:        spec.User,
+		Time:        spec.Time,
+	}
+	coll, closer := a.st.db().GetCollection(podSpecHistoryC)
+	defer closer()
+	if err := coll.Writeable().Insert(doc); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(prunePodSpecHistory(coll, a.doc.Name))
+}
+
+// prunePodSpecHistory removes all but the most recent
+// MaxPodSpecHistory revisions of the application's pod spec.
+func prunePodSpecHistory(coll mongo.Collection, appName string) error {
+	var oldest podSpecHistoryDoc
+	err := coll.Find(bson.D{{"application", appName}}).Sort("-revision").Skip(MaxPodSpecHistory - 1).One(&oldest)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = coll.Writeable().RemoveAll(bson.D{
+		{"application", appName},
+		{"revision", bson.D{{"$lt", oldest.Revision}}},
+	})
+	return errors.Trace(err)
+}
+
+// PodSpecHistory returns up to limit of the most recent revisions of
+// the application's pod spec, newest first. All the recorded revisions
+// are returned if limit is not positive.
+func (a *Application) PodSpecHistory(limit int) ([]PodSpec, error) {
+	coll, closer := a.st.db().GetCollection(podSpecHistoryC)
+	defer closer()
+
+	query := coll.Find(bson.D{{"application", a.doc.Name}}).Sort("-revision")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var docs []podSpecHistoryDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get pod spec history for application %q", a.doc.Name)
+	}
+	specs := make([]PodSpec, len(docs))
+	for i, doc := range docs {
+		specs[i] = PodSpec{
+			Spec:     doc.Spec,
+			Revision: doc.Revision,
+			User:     doc.User,
+			Time:     time.Unix(0, doc.Time).UTC(),
+		}
+	}
+	return specs, nil
+}
+
+// erasePodSpecHistory removes the pod spec history of the named
+// application, so that it is not inherited by a later application of
+// the same name.
+func erasePodSpecHistory(mb modelBackend, appName string) error {
+	coll, closer := mb.db().GetCollection(podSpecHistoryC)
+	defer closer()
+
+	_, err := coll.Writeable().RemoveAll(bson.D{{"application", appName}})
+	return errors.Trace(err)
+}
+
+// removePodSpecOp returns the operation needed to remove the pod spec
+// document of the named application, if there is one.
+func removePodSpecOp(mb modelBackend, appName string) txn.Op {
+	return txn.Op{
+		C:      podSpecsC,
+		Id:     mb.docID(appName),
+		Remove: true,
+	}
+}
+
+// Scale returns the number of pods the application should run in a
+// Kubernetes model. It is zero until the scale is set.
+func (a *Application) Scale() (int, error) {
+	doc, err := a.podSpec()
+	if errors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return doc.Scale, nil
+}
+
+// SetScale sets the number of pods the application should run in a
+// Kubernetes model.
+func (a *Application) SetScale(scale int) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set scale for application %q", a)
+	if scale < 0 {
+		return errors.NotValidf("negative scale %d", scale)
+	}
+	return a.updatePodSpec(func(doc *podSpecDoc) error {
+		if doc.Scale == scale {
+			return jujutxn.ErrNoOperations
+		}
+		doc.Scale = scale
+		return nil
+	})
+}
+
+// ChangeScale changes the number of pods the application should run in
+// a Kubernetes model by the given amount, and returns the new scale.
+func (a *Application) ChangeScale(scaleChange int) (newScale int, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot change scale for application %q", a)
+	err = a.updatePodSpec(func(doc *podSpecDoc) error {
+		newScale = doc.Scale + scaleChange
+		if newScale < 0 {
+			return errors.NotValidf("negative scale %d", newScale)
+		}
+		if scaleChange == 0 {
+			return jujutxn.ErrNoOperations
+		}
+		doc.Scale = newScale
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return newScale, nil
+}
+
+// RolloutStatus returns the status of the rollout of the current
+// revision of the application's pod spec. It returns an error
+// satisfying errors.IsNotFound if no pod spec has been set.
+func (a *Application) RolloutStatus() (RolloutStatus, error) {
+	doc, err := a.podSpec()
+	if err != nil {
+		return RolloutStatus{}, errors.Trace(err)
+	}
+	if doc.Revision == 0 {
+		return RolloutStatus{}, errors.NotFoundf("pod spec for application %q", a.doc.Name)
+	}
+	return RolloutStatus{
+		Revision: doc.Revision,
+		State:    doc.RolloutState,
+		Message:  doc.RolloutMessage,
+		Since:    time.Unix(0, doc.RolloutSince).UTC(),
+	}, nil
+}
+
+// SetRolloutStatus records how far the rollout of the given revision
+// of the application's pod spec has progressed. It fails if the
+// revision has been superseded by a newer pod spec.
+func (a *Application) SetRolloutStatus(revision int, state RolloutState, message string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set rollout status for application %q", a)
+	if !state.Valid() {
+		return errors.NotValidf("rollout state %q", state)
+	}
+	return a.updatePodSpec(func(doc *podSpecDoc) error {
+		if doc.Revision == 0 {
+			return errors.NotFoundf("pod spec")
+		}
+		if doc.Revision != revision {
+			return errors.Errorf("pod spec revision %d superseded by revision %d", revision, doc.Revision)
+		}
+		if doc.RolloutState == state && doc.RolloutMessage == message {
+			return jujutxn.ErrNoOperations
+		}
+		doc.RolloutState = state
+		doc.RolloutMessage = message
+		doc.RolloutSince = a.st.clock().Now().UnixNano()
+		return nil
+	})
+}
+
+// WatchPodSpec returns a watcher that notifies of changes to the
+// application's pod spec, scale or rollout status.
+func (a *Application) WatchPodSpec() NotifyWatcher {
+	return newEntityWatcher(a.st, podSpecsC, a.st.docID(a.doc.Name))
+}
@@ -0,0 +1,300 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+)
+
+type PodSpecSuite struct {
+	ConnSuite
+	application *state.Application
+}
+
+var _ = gc.Suite(&PodSpecSuite{})
+
+func (s *PodSpecSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+}
+
+func (s *PodSpecSuite) TestPodSpecNotSet(c *gc.C) {
+	_, err := s.application.PodSpec()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.application.RolloutStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	history, err := s.application.PodSpecHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *PodSpecSuite) TestSetPodSpec(c *gc.C) {
+	err := s.application.SetPodSpec("containers: []", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+
+	spec, err := s.application.PodSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, jc.DeepEquals, state.PodSpec{
+		Spec:     "containers: []",
+		Revision: 1,
+		User:     "bob",
+		Time:     s.Clock.Now().UTC(),
+	})
+
+	rollout, err := s.application.RolloutStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout, jc.DeepEquals, state.RolloutStatus{
+		Revision: 1,
+		State:    state.RolloutPending,
+		Since:    s.Clock.Now().UTC(),
+	})
+}
+
+func (s *PodSpecSuite) TestSetPodSpecEmpty(c *gc.C) {
+	err := s.application.SetPodSpec("", "bob")
+	c.Assert(err, gc.ErrorMatches, `cannot set pod spec for application "dummy": empty pod spec not valid`)
+}
+
+func (s *PodSpecSuite) TestSetPodSpecRevisions(c *gc.C) {
+	err := s.application.SetPodSpec("containers: []", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	err = s.application.SetPodSpec("containers: [{name: foo}]", "mary")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Setting the same spec again doesn't add a revision.
+	err = s.application.SetPodSpec("containers: [{name: foo}]", "mary")
+	c.Assert(err, jc.ErrorIsNil)
+
+	spec, err := s.application.PodSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Revision, gc.Equals, 2)
+	c.Assert(spec.Spec, gc.Equals, "containers: [{name: foo}]")
+
+	history, err := s.application.PodSpecHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.PodSpec{{
+		Spec:     "containers: [{name: foo}]",
+		Revision: 2,
+		User:     "mary",
+		Time:     s.Clock.Now().UTC(),
+	}, {
+		Spec:     "containers: []",
+		Revision: 1,
+		User:     "bob",
+		Time:     s.Clock.Now().Add(-time.Minute).UTC(),
+	}})
+}
+
+func (s *PodSpecSuite) TestPodSpecHistoryLimit(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		err := s.application.SetPodSpec(fmt.Sprintf("spec-%d", i), "")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.application.PodSpecHistory(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Spec, gc.Equals, "spec-2")
+	c.Assert(history[1].Spec, gc.Equals, "spec-1")
+}
+
+func (s *PodSpecSuite) TestPodSpecHistoryPruned(c *gc.C) {
+	for i := 0; i < state.MaxPodSpecHistory+10; i++ {
+		err := s.application.SetPodSpec(fmt.Sprintf("spec-%d", i), "")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.application.PodSpecHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, state.MaxPodSpecHistory)
+	c.Assert(history[0].Revision, gc.Equals, state.MaxPodSpecHistory+10)
+	c.Assert(history[len(history)-1].Revision, gc.Equals, 11)
+}
+
+func (s *PodSpecSuite) TestRedeployStartsAfresh(c *gc.C) {
+	err := s.application.SetPodSpec("containers: []", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetScale(3)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.application = s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	_, err = s.application.PodSpec()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	scale, err := s.application.Scale()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scale, gc.Equals, 0)
+	history, err := s.application.PodSpecHistory(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *PodSpecSuite) TestSetScale(c *gc.C) {
+	scale, err := s.application.Scale()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scale, gc.Equals, 0)
+
+	err = s.application.SetScale(3)
+	c.Assert(err, jc.ErrorIsNil)
+	scale, err = s.application.Scale()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scale, gc.Equals, 3)
+
+	// Setting the scale leaves the pod spec unset.
+	_, err = s.application.PodSpec()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *PodSpecSuite) TestSetScaleNegative(c *gc.C) {
+	err := s.application.SetScale(-1)
+	c.Assert(err, gc.ErrorMatches, `cannot set scale for application "dummy": negative scale -1 not valid`)
+}
+
+func (s *PodSpecSuite) TestChangeScale(c *gc.C) {
+	scale, err := s.application.ChangeScale(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scale, gc.Equals, 3)
+	scale, err = s.application.ChangeScale(-1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scale, gc.Equals, 2)
+
+	_, err = s.application.ChangeScale(-3)
+	c.Assert(err, gc.ErrorMatches, `cannot change scale for application "dummy": negative scale -1 not valid`)
+	scale, err = s.application.Scale()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(scale, gc.Equals, 2)
+}
+
+func (s *PodSpecSuite) TestScaleDeadApplication(c *gc.C) {
+	err := s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetScale(1)
+	c.Assert(err, gc.ErrorMatches, `cannot set scale for application "dummy": application "dummy" not found`)
+}
+
+func (s *PodSpecSuite) TestSetRolloutStatus(c *gc.C) {
+	err := s.application.SetPodSpec("containers: []", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+
+	err = s.application.SetRolloutStatus(1, state.RolloutProgressing, "1 of 3 pods updated")
+	c.Assert(err, jc.ErrorIsNil)
+	rollout, err := s.application.RolloutStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout, jc.DeepEquals, state.RolloutStatus{
+		Revision: 1,
+		State:    state.RolloutProgressing,
+		Message:  "1 of 3 pods updated",
+		Since:    s.Clock.Now().UTC(),
+	})
+
+	// A new revision starts a new rollout.
+	err = s.application.SetPodSpec("containers: [{name: foo}]", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	rollout, err = s.application.RolloutStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rollout.Revision, gc.Equals, 2)
+	c.Assert(rollout.State, gc.Equals, state.RolloutPending)
+}
+
+func (s *PodSpecSuite) TestSetRolloutStatusSuperseded(c *gc.C) {
+	err := s.application.SetPodSpec("containers: []", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetPodSpec("containers: [{name: foo}]", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.SetRolloutStatus(1, state.RolloutComplete, "")
+	c.Assert(err, gc.ErrorMatches, `cannot set rollout status for application "dummy": pod spec revision 1 superseded by revision 2`)
+}
+
+func (s *PodSpecSuite) TestSetRolloutStatusInvalid(c *gc.C) {
+	err := s.application.SetRolloutStatus(1, state.RolloutState("wat"), "")
+	c.Assert(err, gc.ErrorMatches, `cannot set rollout status for application "dummy": rollout state "wat" not valid`)
+	err = s.application.SetRolloutStatus(1, state.RolloutComplete, "")
+	c.Assert(err, gc.ErrorMatches, `cannot set rollout status for application "dummy": pod spec not found`)
+}
+
+func (s *PodSpecSuite) TestWatchPodSpec(c *gc.C) {
+	w := s.application.WatchPodSpec()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.application.SetPodSpec("containers: []", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.application.SetRolloutStatus(1, state.RolloutComplete, "")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.application.SetScale(2)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Unchanged values don't trigger the watcher.
+	err = s.application.SetRolloutStatus(1, state.RolloutComplete, "")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *PodSpecSuite) TestContainerStatus(c *gc.C) {
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = unit.ContainerStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	now := s.Clock.Now()
+	err = unit.SetContainerStatus(status.StatusInfo{
+		Status:  status.Running,
+		Message: "started",
+		Data:    map[string]interface{}{"restart.count": 1},
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetContainerStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "crash loop",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	info, err := unit.ContainerStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status, gc.Equals, status.Error)
+	c.Assert(info.Message, gc.Equals, "crash loop")
+	c.Assert(info.Since.Equal(now), jc.IsTrue)
+}
+
+func (s *PodSpecSuite) TestSetContainerStatusInvalid(c *gc.C) {
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetContainerStatus(status.StatusInfo{Status: status.Active})
+	c.Assert(err, gc.ErrorMatches, `cannot set container status for unit "dummy/0": container status "active" not valid`)
+}
+
+func (s *PodSpecSuite) TestContainerStatusRemovedWithUnit(c *gc.C) {
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetContainerStatus(status.StatusInfo{Status: status.Running})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = unit.ContainerStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
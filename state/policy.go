@@ -59,6 +59,13 @@ func (st *State) precheckInstance(
 	if st.policy == nil {
 		return nil
 	}
+	if affinity, err := instance.ParseAffinity(placement); err != nil {
+		return errors.Trace(err)
+	} else if affinity != nil {
+		// Affinity directives are translated by the provisioner,
+		// so there is nothing for the provider to check.
+		placement = ""
+	}
 	prechecker, err := st.policy.Prechecker()
 	if errors.IsNotImplemented(err) {
 		return nil
@@ -150,6 +157,17 @@ func (st *State) validateConstraints(cons constraints.Value) ([]string, error) {
 	return validator.Validate(cons)
 }
 
+// ConstraintCapabilities describes the constraint attributes supported
+// by the model's provider, so that constraints may be validated before
+// they are submitted.
+func (st *State) ConstraintCapabilities() ([]constraints.AttributeCapability, error) {
+	validator, err := st.constraintsValidator()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return validator.Capabilities(), nil
+}
+
 // validate calls the state's assigned policy, if non-nil, to obtain
 // a config.Validator, and calls Validate if a non-nil config.Validator is
 // returned.
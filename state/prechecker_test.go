@@ -76,6 +76,14 @@ func (s *PrecheckerSuite) TestPrecheckInstanceWithPlacement(c *gc.C) {
 	c.Assert(s.prechecker.precheckInstanceArgs.Constraints, gc.DeepEquals, template.Constraints)
 }
 
+func (s *PrecheckerSuite) TestPrecheckInstanceWithAffinityPlacement(c *gc.C) {
+	// Affinity directives are interpreted by the provisioner,
+	// so they are not passed to the provider to check.
+	_, err := s.addOneMachine(c, constraints.Value{}, "anti-affinity:mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.prechecker.precheckInstanceArgs.Placement, gc.Equals, "")
+}
+
 func (s *PrecheckerSuite) TestPrecheckErrors(c *gc.C) {
 	// Ensure that AddOneMachine fails when PrecheckInstance returns an error.
 	s.prechecker.precheckInstanceError = fmt.Errorf("no instance for you")
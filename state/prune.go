@@ -15,7 +15,8 @@ import (
 // pruneCollection removes collection entries until
 // only entries newer than <maxLogTime> remain and also ensures
 // that the collection is smaller than <maxLogsMB> after the
-// deletion.
+// deletion. The number of entries removed, and the space reclaimed,
+// are recorded in the model's prune statistics.
 func pruneCollection(mb modelBackend, maxHistoryTime time.Duration, maxHistoryMB int, collectionName string, ageField string, timeUnit TimeUnit) error {
 
 	// NOTE(axw) we require a raw collection to obtain the size of the
@@ -35,10 +36,51 @@ func pruneCollection(mb modelBackend, maxHistoryTime time.Duration, maxHistoryMB
 	if err := p.validate(); err != nil {
 		return errors.Trace(err)
 	}
-	if err := p.pruneByAge(); err != nil {
+	sizeBefore, err := getCollectionMB(entries)
+	if err != nil {
+		return errors.Annotatef(err, "retrieving %s collection size", collectionName)
+	}
+	deletedByAge, err := p.pruneByAge()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	deletedBySize, err := p.pruneBySize()
+	if err != nil {
 		return errors.Trace(err)
 	}
-	return errors.Trace(p.pruneBySize())
+	sizeAfter, err := getCollectionMB(entries)
+	if err != nil {
+		return errors.Annotatef(err, "retrieving %s collection size", collectionName)
+	}
+
+	stats, closer := mb.db().GetRawCollection(pruneStatsC)
+	defer closer()
+	return errors.Trace(recordPruneStats(
+		stats, mb.modelUUID(), collectionName,
+		mb.clock().Now(), deletedByAge+deletedBySize, sizeBefore-sizeAfter,
+	))
+}
+
+// pruneSizeLimit returns the size limit, in MiB, for pruning a
+// collection shared by all models. The controller config policy for
+// the collection takes precedence over the model's limit when it is
+// set.
+func pruneSizeLimit(st *State, modelMB int, collectionName string) (int, error) {
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot load controller configuration")
+	}
+	var limit int
+	switch collectionName {
+	case statusesHistoryC:
+		limit = controllerConfig.MaxStatusHistoryCollectionSizeMB()
+	case actionsC:
+		limit = controllerConfig.MaxActionResultsCollectionSizeMB()
+	}
+	if limit > 0 {
+		return limit, nil
+	}
+	return modelMB, nil
 }
 
 const historyPruneBatchSize = 1000
@@ -77,9 +119,9 @@ func (p *collectionPruner) validate() error {
 	return nil
 }
 
-func (p *collectionPruner) pruneByAge() error {
+func (p *collectionPruner) pruneByAge() (int, error) {
 	if p.maxAge == 0 {
-		return nil
+		return 0, nil
 	}
 
 	t := p.st.clock().Now().Add(-p.maxAge)
@@ -98,45 +140,45 @@ func (p *collectionPruner) pruneByAge() error {
 
 	modelName, err := p.st.modelName()
 	if err != nil {
-		return errors.Trace(err)
+		return 0, errors.Trace(err)
 	}
 	logTemplate := fmt.Sprintf("%s age pruning (%s): %%d rows deleted", p.coll.Name, modelName)
 	deleted, err := p.deleteInBatches(iter, logTemplate, noEarlyFinish)
 	if err != nil {
-		return errors.Trace(err)
+		return 0, errors.Trace(err)
 	}
 	if deleted > 0 {
 		logger.Infof("%s age pruning (%s): %d rows deleted", p.coll.Name, modelName, deleted)
 	}
-	return nil
+	return deleted, nil
 }
 
-func (p *collectionPruner) pruneBySize() error {
+func (p *collectionPruner) pruneBySize() (int, error) {
 	if !p.st.isController() {
 		// Only prune by size in the controller. Otherwise we might
 		// find that multiple pruners are trying to delete the latest
 		// 1000 rows and end up with more deleted than we expect.
-		return nil
+		return 0, nil
 	}
 	if p.maxSize == 0 {
-		return nil
+		return 0, nil
 	}
 	// Collection Size
 	collMB, err := getCollectionMB(p.coll)
 	if err != nil {
-		return errors.Annotate(err, "retrieving collection size")
+		return 0, errors.Annotate(err, "retrieving collection size")
 	}
 	if collMB <= p.maxSize {
-		return nil
+		return 0, nil
 	}
 	// TODO(perrito666) explore if there would be any beneffit from having the
 	// size limit be per model
 	count, err := p.coll.Count()
 	if err == mgo.ErrNotFound || count <= 0 {
-		return nil
+		return 0, nil
 	}
 	if err != nil {
-		return errors.Annotatef(err, "counting %s records", p.coll.Name)
+		return 0, errors.Annotatef(err, "counting %s records", p.coll.Name)
 	}
 	// We are making the assumption that status sizes can be averaged for
 	// large numbers and we will get a reasonable approach on the size.
@@ -145,7 +187,7 @@ func (p *collectionPruner) pruneBySize() error {
 	// as real life data of the history usage is gathered.
 	sizePerStatus := float64(collMB) / float64(count)
 	if sizePerStatus == 0 {
-		return fmt.Errorf("unexpected result calculating %s entry size", p.coll.Name)
+		return 0, fmt.Errorf("unexpected result calculating %s entry size", p.coll.Name)
 	}
 	toDelete := int(float64(collMB-p.maxSize) / sizePerStatus)
 
@@ -165,12 +207,12 @@ func (p *collectionPruner) pruneBySize() error {
 	})
 
 	if err != nil {
-		return errors.Trace(err)
+		return 0, errors.Trace(err)
 	}
 
 	logger.Infof("%s size pruning finished: %d rows deleted", p.coll.Name, deleted)
 
-	return nil
+	return deleted, nil
 }
 
 func (p *collectionPruner) deleteInBatches(iter *mgo.Iter, logTemplate string, shouldStop doneCheck) (int, error) {
@@ -224,3 +266,75 @@ func (p *collectionPruner) deleteInBatches(iter *mgo.Iter, logTemplate string, s
 func noEarlyFinish() (bool, error) {
 	return false, nil
 }
+
+// PruneStats describes the most recent pruning of one of a model's
+// collections.
+type PruneStats struct {
+	// Collection is the name of the pruned collection.
+	Collection string
+
+	// Time is when the collection was last pruned.
+	Time time.Time
+
+	// Removed is the number of entries removed.
+	Removed int
+
+	// ReclaimedMB is the amount of space, in MiB, freed by removing
+	// the entries. Entries removed by other models' pruners at the
+	// same time are included, as the collections are shared.
+	ReclaimedMB int
+}
+
+// pruneStatsDoc records the outcome of the most recent pruning of a
+// collection for a model. The documents are written without mgo/txn,
+// and are replaced each time the collection is pruned.
+type pruneStatsDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Collection  string `bson:"collection"`
+	Time        int64  `bson:"time"`
+	Removed     int    `bson:"removed"`
+	ReclaimedMB int    `bson:"reclaimed-mb"`
+}
+
+func recordPruneStats(coll *mgo.Collection, modelUUID, collectionName string, now time.Time, removed, reclaimedMB int) error {
+	if reclaimedMB < 0 {
+		// The collection grew while it was being pruned.
+		reclaimedMB = 0
+	}
+	doc := pruneStatsDoc{
+		DocID:       ensureModelUUID(modelUUID, collectionName),
+		ModelUUID:   modelUUID,
+		Collection:  collectionName,
+		Time:        now.UnixNano(),
+		Removed:     removed,
+		ReclaimedMB: reclaimedMB,
+	}
+	if _, err := coll.UpsertId(doc.DocID, doc); err != nil {
+		return errors.Annotatef(err, "recording %s prune statistics", collectionName)
+	}
+	return nil
+}
+
+// PruneStats returns the outcome of the most recent pruning of each
+// of the model's collections that have been pruned, ordered by
+// collection name.
+func (st *State) PruneStats() ([]PruneStats, error) {
+	coll, closer := st.db().GetCollection(pruneStatsC)
+	defer closer()
+
+	var docs []pruneStatsDoc
+	if err := coll.Find(nil).Sort("collection").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading prune statistics")
+	}
+	result := make([]PruneStats, len(docs))
+	for i, doc := range docs {
+		result[i] = PruneStats{
+			Collection:  doc.Collection,
+			Time:        time.Unix(0, doc.Time).UTC(),
+			Removed:     doc.Removed,
+			ReclaimedMB: doc.ReclaimedMB,
+		}
+	}
+	return result, nil
+}
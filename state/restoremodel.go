@@ -0,0 +1,350 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ModelDocSource provides the documents of a backed up juju database,
+// such as the database dump in a controller backup.
+type ModelDocSource interface {
+	// Docs returns the documents in the named collection. A
+	// collection missing from the backup has no documents.
+	Docs(collection string) ([]bson.D, error)
+}
+
+// RestoreModelArgs holds the arguments for RestoreModel.
+type RestoreModelArgs struct {
+	// ModelUUID is the UUID of the model in the backup.
+	ModelUUID string
+
+	// NewUUID, if set, is the UUID given to the restored model. It
+	// must be set if the backed up model still exists.
+	NewUUID string
+
+	// NewName, if set, is the name given to the restored model.
+	NewName string
+
+	// Source provides the documents of the backed up database.
+	Source ModelDocSource
+}
+
+// Validate returns an error if the arguments are not valid.
+func (args RestoreModelArgs) Validate() error {
+	if !names.IsValidModel(args.ModelUUID) {
+		return errors.NotValidf("model UUID %q", args.ModelUUID)
+	}
+	if args.NewUUID != "" && !names.IsValidModel(args.NewUUID) {
+		return errors.NotValidf("new model UUID %q", args.NewUUID)
+	}
+	if args.NewName != "" && !names.IsValidModelName(args.NewName) {
+		return errors.NotValidf("new model name %q", args.NewName)
+	}
+	if args.Source == nil {
+		return errors.NotValidf("nil Source")
+	}
+	return nil
+}
+
+// RestoreModel restores a single model's documents from a backup of
+// the controller database into the running controller, leaving the
+// rest of the controller untouched. This is intended for recovering a
+// model that was destroyed by accident.
+//
+// The model is restored in the "importing" migration mode, which is
+// cleared once all of its documents are in place; if the restore
+// fails, the documents restored so far are removed again. The model's
+// documents are restored as they were in the backup, so the caller
+// must ensure that the backed up model's cloud resources still exist.
+func (st *State) RestoreModel(args RestoreModelArgs) (_ *Model, _ *State, err error) {
+	if err := args.Validate(); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	logger := loggo.GetLogger("juju.state.restore-model")
+
+	controllerInfo, err := st.ControllerInfo()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if args.ModelUUID == controllerInfo.ModelTag.Id() {
+		return nil, nil, errors.NotSupportedf("restoring the controller model")
+	}
+	r := modelRestorer{
+		oldUUID: args.ModelUUID,
+		newUUID: args.ModelUUID,
+		newName: args.NewName,
+		source:  args.Source,
+	}
+	if args.NewUUID != "" {
+		r.newUUID = args.NewUUID
+	}
+	if modelExists, err := st.ModelExists(r.newUUID); err != nil {
+		return nil, nil, errors.Trace(err)
+	} else if modelExists {
+		return nil, nil, errors.AlreadyExistsf("model %s", r.newUUID)
+	}
+
+	owner, name, ops, err := r.modelOps()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if err := st.runRawTransaction(ops); err == txn.ErrAborted {
+		// The usermodelname document is all that can conflict,
+		// having checked the model does not exist above.
+		return nil, nil, errors.AlreadyExistsf("model %q for %s", name, owner.Id())
+	} else if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	logger.Debugf("restoring model %s as %s/%s (%s)", r.oldUUID, owner.Id(), name, r.newUUID)
+
+	newSt, err := st.ForModel(names.NewModelTag(r.newUUID))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if removeErr := newSt.RemoveImportingModelDocs(); removeErr != nil {
+			logger.Errorf("cannot remove partially restored model %s: %v", r.newUUID, removeErr)
+		}
+		newSt.Close()
+	}()
+
+	// Restore the collections in a predictable order.
+	var collections []string
+	for name, info := range st.database.Schema() {
+		if !info.global {
+			collections = append(collections, name)
+		}
+	}
+	sort.Strings(collections)
+	for _, name := range collections {
+		docs, err := r.collectionDocs(name)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "reading %s", name)
+		}
+		if len(docs) == 0 {
+			continue
+		}
+		if err := st.restoreDocs(name, docs); err != nil {
+			return nil, nil, errors.Annotatef(err, "restoring %s", name)
+		}
+	}
+
+	model, err := newSt.Model()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if err := model.SetMigrationMode(MigrationModeNone); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	logger.Debugf("restored model %s", r.newUUID)
+	return model, newSt, nil
+}
+
+// restoreDocs inserts the restored documents into the named model
+// collection, in a single transaction unless the collection is
+// accessed without transactions.
+func (st *State) restoreDocs(name string, docs []bson.D) error {
+	if info := st.database.Schema()[name]; info.rawAccess {
+		coll, closer := st.db().GetRawCollection(name)
+		defer closer()
+		insertDocs := make([]interface{}, len(docs))
+		for i, doc := range docs {
+			insertDocs[i] = doc
+		}
+		return errors.Trace(coll.Insert(insertDocs...))
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      name,
+			Id:     docField(doc, "_id"),
+			Assert: txn.DocMissing,
+			Insert: doc,
+		}
+	}
+	return errors.Trace(st.runRawTransaction(ops))
+}
+
+// modelRestorer reads the documents of a single model from a backed up
+// database, and rewrites them for the restored model.
+type modelRestorer struct {
+	oldUUID string
+	newUUID string
+	newName string
+	source  ModelDocSource
+}
+
+// modelOps returns the owner and name of the restored model, and the
+// operations that create its controller-wide documents. The model is
+// created in the "importing" migration mode.
+func (r *modelRestorer) modelOps() (names.UserTag, string, []txn.Op, error) {
+	var modelDoc bson.D
+	models, err := r.source.Docs(modelsC)
+	if err != nil {
+		return names.UserTag{}, "", nil, errors.Annotatef(err, "reading %s", modelsC)
+	}
+	for _, doc := range models {
+		if docField(doc, "_id") == r.oldUUID {
+			modelDoc = doc
+			break
+		}
+	}
+	if modelDoc == nil {
+		return names.UserTag{}, "", nil, errors.NotFoundf("model %s in backup", r.oldUUID)
+	}
+	modelDoc = setDocField(r.remap(modelDoc), "_id", r.newUUID)
+	modelDoc = setDocField(modelDoc, "migration-mode", MigrationModeImporting)
+	if r.newName != "" {
+		modelDoc = setDocField(modelDoc, "name", r.newName)
+	}
+	ownerID, _ := docField(modelDoc, "owner").(string)
+	name, _ := docField(modelDoc, "name").(string)
+	if !names.IsValidUser(ownerID) {
+		return names.UserTag{}, "", nil, errors.NotValidf("model owner %q", ownerID)
+	}
+	owner := names.NewUserTag(ownerID)
+
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     r.newUUID,
+		Assert: txn.DocMissing,
+		Insert: modelDoc,
+	},
+		createUniqueOwnerModelNameOp(owner, name),
+		incHostedModelCountOp(),
+	}
+
+	refsOp := createModelEntityRefsOp(r.newUUID)
+	refs, err := r.source.Docs(modelEntityRefsC)
+	if err != nil {
+		return names.UserTag{}, "", nil, errors.Annotatef(err, "reading %s", modelEntityRefsC)
+	}
+	for _, doc := range refs {
+		if docField(doc, "_id") == r.oldUUID {
+			refsOp.Insert = setDocField(r.remap(doc), "_id", r.newUUID)
+			break
+		}
+	}
+	ops = append(ops, refsOp)
+
+	permissions, err := r.source.Docs(permissionsC)
+	if err != nil {
+		return names.UserTag{}, "", nil, errors.Annotatef(err, "reading %s", permissionsC)
+	}
+	oldPrefix := permissionID(modelKey(r.oldUUID), "")
+	newPrefix := permissionID(modelKey(r.newUUID), "")
+	for _, doc := range permissions {
+		id, _ := docField(doc, "_id").(string)
+		if !strings.HasPrefix(id, oldPrefix) {
+			continue
+		}
+		id = newPrefix + strings.TrimPrefix(id, oldPrefix)
+		doc = setDocField(r.remap(doc), "_id", id)
+		doc = setDocField(doc, "object-global-key", modelKey(r.newUUID))
+		ops = append(ops, txn.Op{
+			C:      permissionsC,
+			Id:     id,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		})
+	}
+	return owner, name, ops, nil
+}
+
+// collectionDocs returns the restored model's documents in the named
+// model collection.
+func (r *modelRestorer) collectionDocs(name string) ([]bson.D, error) {
+	all, err := r.source.Docs(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var docs []bson.D
+	for _, doc := range all {
+		if docField(doc, "model-uuid") != r.oldUUID {
+			continue
+		}
+		doc = r.remap(doc)
+		if name == settingsC && docField(doc, "_id") == ensureModelUUID(r.newUUID, modelGlobalKey) {
+			doc = r.remapModelConfig(doc)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// remap returns a copy of the document rewritten for the restored
+// model. The transaction fields are dropped, as they refer to
+// transactions in the backed up database.
+func (r *modelRestorer) remap(doc bson.D) bson.D {
+	result := make(bson.D, 0, len(doc))
+	for _, elem := range doc {
+		switch elem.Name {
+		case "txn-revno", "txn-queue":
+			continue
+		case "_id":
+			if id, ok := elem.Value.(string); ok && strings.HasPrefix(id, r.oldUUID+":") {
+				elem.Value = ensureModelUUID(r.newUUID, strings.TrimPrefix(id, r.oldUUID+":"))
+			}
+		case "model-uuid":
+			elem.Value = r.newUUID
+		}
+		result = append(result, elem)
+	}
+	return result
+}
+
+// remapModelConfig rewrites the model config settings document for the
+// restored model's UUID and name.
+func (r *modelRestorer) remapModelConfig(doc bson.D) bson.D {
+	updates := bson.D{{"uuid", r.newUUID}}
+	if r.newName != "" {
+		updates = append(updates, bson.DocElem{"name", r.newName})
+	}
+	switch settings := docField(doc, "settings").(type) {
+	case bson.D:
+		for _, update := range updates {
+			settings = setDocField(settings, update.Name, update.Value)
+		}
+		return setDocField(doc, "settings", settings)
+	case bson.M:
+		for _, update := range updates {
+			settings[update.Name] = update.Value
+		}
+	}
+	return doc
+}
+
+// docField returns the value of the named field of the document, or
+// nil if it has no such field.
+func docField(doc bson.D, name string) interface{} {
+	for _, elem := range doc {
+		if elem.Name == name {
+			return elem.Value
+		}
+	}
+	return nil
+}
+
+// setDocField returns the document with the named field set to the
+// value, appending the field if it is missing.
+func setDocField(doc bson.D, name string, value interface{}) bson.D {
+	for i, elem := range doc {
+		if elem.Name == name {
+			doc[i].Value = value
+			return doc
+		}
+	}
+	return append(doc, bson.DocElem{name, value})
+}
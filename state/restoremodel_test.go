@@ -0,0 +1,163 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type RestoreModelSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&RestoreModelSuite{})
+
+// docSource is a state.ModelDocSource holding a copy of the database.
+type docSource map[string][]bson.D
+
+func (s docSource) Docs(collection string) ([]bson.D, error) {
+	return s[collection], nil
+}
+
+// backUp returns a copy of the documents in the juju database.
+func (s *RestoreModelSuite) backUp(c *gc.C) docSource {
+	db := s.Session.DB("juju")
+	names, err := db.CollectionNames()
+	c.Assert(err, jc.ErrorIsNil)
+	source := make(docSource)
+	for _, name := range names {
+		var docs []bson.D
+		err := db.C(name).Find(nil).All(&docs)
+		c.Assert(err, jc.ErrorIsNil)
+		source[name] = docs
+	}
+	return source
+}
+
+func (s *RestoreModelSuite) makeModel(c *gc.C) *state.State {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "restore-me"})
+	factory.NewFactory(st).MakeMachine(c, nil)
+	return st
+}
+
+func (s *RestoreModelSuite) TestRestoreRemovedModel(c *gc.C) {
+	st := s.makeModel(c)
+	defer st.Close()
+	uuid := st.ModelUUID()
+	source := s.backUp(c)
+
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.SetMigrationMode(state.MigrationModeImporting), jc.ErrorIsNil)
+	c.Assert(st.RemoveImportingModelDocs(), jc.ErrorIsNil)
+
+	restored, restoredSt, err := s.State.RestoreModel(state.RestoreModelArgs{
+		ModelUUID: uuid,
+		Source:    source,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer restoredSt.Close()
+
+	c.Check(restored.UUID(), gc.Equals, uuid)
+	c.Check(restored.Name(), gc.Equals, "restore-me")
+	c.Check(restored.MigrationMode(), gc.Equals, state.MigrationModeNone)
+	machines, err := restoredSt.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machines, gc.HasLen, 1)
+
+	// The restored documents can be changed as usual.
+	c.Assert(machines[0].SetPassword("sekrit-password-1234"), jc.ErrorIsNil)
+}
+
+func (s *RestoreModelSuite) TestRestoreModelNewUUID(c *gc.C) {
+	st := s.makeModel(c)
+	defer st.Close()
+	source := s.backUp(c)
+
+	newUUID := utils.MustNewUUID().String()
+	restored, restoredSt, err := s.State.RestoreModel(state.RestoreModelArgs{
+		ModelUUID: st.ModelUUID(),
+		NewUUID:   newUUID,
+		NewName:   "restored",
+		Source:    source,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer restoredSt.Close()
+
+	c.Check(restored.UUID(), gc.Equals, newUUID)
+	c.Check(restored.Name(), gc.Equals, "restored")
+	cfg, err := restored.Config()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.UUID(), gc.Equals, newUUID)
+	c.Check(cfg.Name(), gc.Equals, "restored")
+
+	machines, err := restoredSt.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machines, gc.HasLen, 1)
+	access, err := restoredSt.UserAccess(restored.Owner(), restored.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(access.Access, gc.Equals, permission.AdminAccess)
+
+	// The original model is untouched.
+	machines, err = st.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machines, gc.HasLen, 1)
+}
+
+func (s *RestoreModelSuite) TestRestoreModelExists(c *gc.C) {
+	st := s.makeModel(c)
+	defer st.Close()
+
+	_, _, err := s.State.RestoreModel(state.RestoreModelArgs{
+		ModelUUID: st.ModelUUID(),
+		Source:    s.backUp(c),
+	})
+	c.Check(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *RestoreModelSuite) TestRestoreModelNameExists(c *gc.C) {
+	st := s.makeModel(c)
+	defer st.Close()
+
+	_, _, err := s.State.RestoreModel(state.RestoreModelArgs{
+		ModelUUID: st.ModelUUID(),
+		NewUUID:   utils.MustNewUUID().String(),
+		Source:    s.backUp(c),
+	})
+	c.Check(err, gc.ErrorMatches, `model "restore-me" for .* already exists`)
+}
+
+func (s *RestoreModelSuite) TestRestoreControllerModel(c *gc.C) {
+	_, _, err := s.State.RestoreModel(state.RestoreModelArgs{
+		ModelUUID: s.State.ModelUUID(),
+		NewUUID:   utils.MustNewUUID().String(),
+		Source:    s.backUp(c),
+	})
+	c.Check(err, gc.ErrorMatches, "restoring the controller model not supported")
+}
+
+func (s *RestoreModelSuite) TestRestoreModelNotInBackup(c *gc.C) {
+	uuid := utils.MustNewUUID().String()
+	_, _, err := s.State.RestoreModel(state.RestoreModelArgs{
+		ModelUUID: uuid,
+		Source:    s.backUp(c),
+	})
+	c.Check(err, gc.ErrorMatches, "model "+uuid+" in backup not found")
+}
+
+func (s *RestoreModelSuite) TestRestoreModelInvalidArgs(c *gc.C) {
+	_, _, err := s.State.RestoreModel(state.RestoreModelArgs{
+		ModelUUID: "not-a-uuid",
+		Source:    docSource{},
+	})
+	c.Check(err, gc.ErrorMatches, `model UUID "not-a-uuid" not valid`)
+}
@@ -410,6 +410,18 @@ func replaceSettingsOp(db Database, collection, key string, values map[string]in
 	return op, assertFailed, nil
 }
 
+// replaceSettings replaces the Settings for key with values.
+func replaceSettings(db Database, collection, key string, values map[string]interface{}) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		op, _, err := replaceSettingsOp(db, collection, key, values)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{op}, nil
+	}
+	return errors.Trace(db.Run(buildTxn))
+}
+
 func (s *Settings) assertUnchangedOp() txn.Op {
 	return txn.Op{
 		C:      s.collection,
@@ -471,6 +483,11 @@ func (s *StateSettings) ReadSettings(key string) (map[string]interface{}, error)
 	}
 }
 
+// ReplaceSettings exposes replaceSettings on state for use outside the state package.
+func (s *StateSettings) ReplaceSettings(key string, settings map[string]interface{}) error {
+	return replaceSettings(s.backend.db(), s.collection, key, settings)
+}
+
 // RemoveSettings exposes removeSettings on state for use outside the state package.
 func (s *StateSettings) RemoveSettings(key string) error {
 	return removeSettings(s.backend.db(), s.collection, key)
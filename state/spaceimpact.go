@@ -0,0 +1,157 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// SpaceReferences describes the applications whose endpoint bindings
+// or constraints refer to a space.
+type SpaceReferences struct {
+	// Bindings maps the name of each application with endpoints
+	// bound to the space to the sorted names of those endpoints.
+	// The application's default binding has the empty name.
+	Bindings map[string][]string
+
+	// Constraints holds the sorted names of the applications whose
+	// constraints include or exclude the space.
+	Constraints []string
+}
+
+// SpaceReferences returns the applications whose endpoint bindings or
+// constraints refer to the space with the given name. It returns an
+// error satisfying errors.IsNotFound if there is no such space.
+func (st *State) SpaceReferences(spaceName string) (SpaceReferences, error) {
+	if _, err := st.Space(spaceName); err != nil {
+		return SpaceReferences{}, errors.Trace(err)
+	}
+	applications, err := st.AllApplications()
+	if err != nil {
+		return SpaceReferences{}, errors.Trace(err)
+	}
+	refs := SpaceReferences{
+		Bindings: make(map[string][]string),
+	}
+	for _, app := range applications {
+		bindings, err := app.EndpointBindings()
+		if err != nil {
+			return SpaceReferences{}, errors.Trace(err)
+		}
+		for endpoint, space := range bindings {
+			if space == spaceName {
+				refs.Bindings[app.Name()] = append(refs.Bindings[app.Name()], endpoint)
+			}
+		}
+		sort.Strings(refs.Bindings[app.Name()])
+
+		cons, err := app.Constraints()
+		if err != nil {
+			return SpaceReferences{}, errors.Trace(err)
+		}
+		spaces := set.NewStrings(cons.IncludeSpaces()...).Union(set.NewStrings(cons.ExcludeSpaces()...))
+		if spaces.Contains(spaceName) {
+			refs.Constraints = append(refs.Constraints, app.Name())
+		}
+	}
+	sort.Strings(refs.Constraints)
+	return refs, nil
+}
+
+// MachinesStrandedBySubnetMove returns the IDs of the machines that
+// would have no address in the space they need if the subnet with the
+// given CIDR were moved out of its current space. A machine needs the
+// space if it hosts a unit of an application that has endpoints bound
+// to it, or whose constraints include it.
+func (st *State) MachinesStrandedBySubnetMove(cidr string) ([]string, error) {
+	subnet, err := st.Subnet(cidr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spaceName := subnet.SpaceName()
+	if spaceName == "" {
+		return nil, nil
+	}
+	subnets, err := st.AllSubnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The CIDRs of the subnets that will remain in the space.
+	remaining := make(set.Strings)
+	for _, subnet := range subnets {
+		if subnet.SpaceName() == spaceName && subnet.CIDR() != cidr && subnet.FanLocalUnderlay() != cidr {
+			remaining.Add(subnet.CIDR())
+		}
+	}
+
+	refs, err := st.SpaceReferences(spaceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appNames := make(set.Strings)
+	for appName := range refs.Bindings {
+		appNames.Add(appName)
+	}
+	for _, appName := range refs.Constraints {
+		app, err := st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cons, err := app.Constraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Applications that exclude the space don't need it.
+		if set.NewStrings(cons.IncludeSpaces()...).Contains(spaceName) {
+			appNames.Add(appName)
+		}
+	}
+
+	machineIds := make(set.Strings)
+	for _, appName := range appNames.SortedValues() {
+		app, err := st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			machineId, err := unit.AssignedMachineId()
+			if errors.IsNotAssigned(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			machineIds.Add(machineId)
+		}
+	}
+
+	var stranded []string
+	for _, machineId := range machineIds.SortedValues() {
+		machine, err := st.Machine(machineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		addresses, err := machine.AllAddresses()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		hasAddress := false
+		for _, address := range addresses {
+			if remaining.Contains(address.SubnetCIDR()) {
+				hasAddress = true
+				break
+			}
+		}
+		if !hasAddress {
+			stranded = append(stranded, machineId)
+		}
+	}
+	return stranded, nil
+}
@@ -230,6 +230,134 @@ func (s *Space) Remove() (err error) {
 	return onAbort(txnErr, errors.New("not found or not dead"))
 }
 
+// Rename changes the name of the space to newName, updating the
+// subnets in the space, and the endpoint bindings and constraints
+// that refer to it. The space must be alive, and no space called
+// newName may exist.
+func (s *Space) Rename(newName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot rename space %q to %q", s, newName)
+	if !names.IsValidSpace(newName) {
+		return errors.NewNotValid(nil, "invalid space name")
+	}
+	oldName := s.doc.Name
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		if _, err := s.st.Space(newName); err == nil {
+			return nil, errors.AlreadyExistsf("space %q", newName)
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return s.renameOps(newName)
+	}
+	if err := s.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	s.doc.Name = newName
+	logger.Infof("renamed space %q to %q", oldName, newName)
+	return nil
+}
+
+// renameOps returns the operations required to rename the space
+// to newName.
+func (s *Space) renameOps(newName string) ([]txn.Op, error) {
+	oldName := s.doc.Name
+	newDoc := s.doc
+	newDoc.Name = newName
+	ops := []txn.Op{{
+		C:      spacesC,
+		Id:     oldName,
+		Assert: isAliveDoc,
+		Remove: true,
+	}, {
+		C:      spacesC,
+		Id:     newName,
+		Assert: txn.DocMissing,
+		Insert: newDoc,
+	}}
+
+	subnets, closer := s.st.db().GetCollection(subnetsC)
+	defer closer()
+	var subnetDocs []subnetDoc
+	if err := subnets.Find(bson.D{{"space-name", oldName}}).All(&subnetDocs); err != nil {
+		return nil, errors.Annotate(err, "cannot get subnets")
+	}
+	for _, doc := range subnetDocs {
+		ops = append(ops, txn.Op{
+			C:      subnetsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"space-name", oldName}},
+			Update: bson.D{{"$set", bson.D{{"space-name", newName}}}},
+		})
+	}
+
+	applications, err := s.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, app := range applications {
+		bindings, txnRevno, err := readEndpointBindings(s.st, app.globalKey())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changed := false
+		for endpoint, space := range bindings {
+			if space == oldName {
+				bindings[endpoint] = newName
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      endpointBindingsC,
+			Id:     app.globalKey(),
+			Assert: bson.D{{"txn-revno", txnRevno}},
+			Update: bson.D{{"$set", bson.D{{"bindings", bindingsMap(bindings)}}}},
+		})
+	}
+
+	constraintsColl, closer := s.st.db().GetCollection(constraintsC)
+	defer closer()
+	var constraintsDocs []struct {
+		DocID  string   `bson:"_id"`
+		Spaces []string `bson:"spaces"`
+	}
+	query := bson.D{{"spaces", bson.D{{"$in", []string{oldName, "^" + oldName}}}}}
+	if err := constraintsColl.Find(query).All(&constraintsDocs); err != nil {
+		return nil, errors.Annotate(err, "cannot get constraints")
+	}
+	for _, doc := range constraintsDocs {
+		spaces := make([]string, len(doc.Spaces))
+		for i, space := range doc.Spaces {
+			switch space {
+			case oldName:
+				spaces[i] = newName
+			case "^" + oldName:
+				spaces[i] = "^" + newName
+			default:
+				spaces[i] = space
+			}
+		}
+		ops = append(ops, txn.Op{
+			C:      constraintsC,
+			Id:     s.st.localID(doc.DocID),
+			Assert: bson.D{{"spaces", doc.Spaces}},
+			Update: bson.D{{"$set", bson.D{{"spaces", spaces}}}},
+		})
+	}
+	return ops, nil
+}
+
 // Refresh: refreshes the contents of the Space from the underlying state. It
 // returns an error that satisfies errors.IsNotFound if the Space has been
 // removed.
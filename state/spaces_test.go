@@ -11,8 +11,10 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
 )
 
 type SpacesSuite struct {
@@ -593,3 +595,200 @@ func (s *SpacesSuite) TestFanSubnetInheritsSpace(c *gc.C) {
 	c.Assert(foundSubnet, gc.NotNil)
 	c.Assert(foundSubnet.SpaceName(), gc.Equals, "space1")
 }
+
+func (s *SpacesSuite) TestRenameUpdatesSubnetsBindingsAndConstraints(c *gc.C) {
+	space, err := s.addSpaceWithSubnets(c, addSpaceArgs{
+		Name:        "db",
+		SubnetCIDRs: []string{"1.1.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("client", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "yoursql",
+		Charm: ch,
+		EndpointBindings: map[string]string{
+			"server": "db",
+			"client": "client",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetConstraints(constraints.MustParse("spaces=db,^client"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = space.Rename("database")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(space.Name(), gc.Equals, "database")
+
+	s.assertSpaceNotFound(c, "db")
+	renamed, err := s.State.Space("database")
+	c.Assert(err, jc.ErrorIsNil)
+	subnets, err := renamed.Subnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Assert(subnets[0].SpaceName(), gc.Equals, "database")
+
+	bindings, err := app.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "database")
+	c.Assert(bindings["client"], gc.Equals, "client")
+
+	cons, err := app.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons.IncludeSpaces(), jc.DeepEquals, []string{"database"})
+	c.Assert(cons.ExcludeSpaces(), jc.DeepEquals, []string{"client"})
+}
+
+func (s *SpacesSuite) TestRenameToExistingSpaceFails(c *gc.C) {
+	space, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("client", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = space.Rename("client")
+	c.Assert(err, gc.ErrorMatches, `cannot rename space "db" to "client": space "client" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *SpacesSuite) TestRenameInvalidName(c *gc.C) {
+	space, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = space.Rename("-bad")
+	c.Assert(err, gc.ErrorMatches, `cannot rename space "db" to "-bad": invalid space name`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *SpacesSuite) TestSubnetMoveToSpace(c *gc.C) {
+	_, err := s.addSpaceWithSubnets(c, addSpaceArgs{
+		Name:        "db",
+		SubnetCIDRs: []string{"1.1.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("client", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnet, err := s.State.Subnet("1.1.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	err = subnet.MoveToSpace("client")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.SpaceName(), gc.Equals, "client")
+
+	err = subnet.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.SpaceName(), gc.Equals, "client")
+}
+
+func (s *SpacesSuite) TestSubnetMoveToMissingSpace(c *gc.C) {
+	s.addSubnets(c, []string{"1.1.1.0/24"})
+	subnet, err := s.State.Subnet("1.1.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = subnet.MoveToSpace("missing")
+	c.Assert(err, gc.ErrorMatches, `cannot move subnet "1.1.1.0/24" to space "missing": space "missing" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SpacesSuite) TestFanSubnetMoveToSpaceFails(c *gc.C) {
+	_, err := s.addSpaceWithSubnets(c, addSpaceArgs{
+		Name:        "db",
+		SubnetCIDRs: []string{"1.1.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("client", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	subnet, err := s.State.AddSubnet(state.SubnetInfo{
+		CIDR:             "253.1.0.0/16",
+		FanOverlay:       "253.0.0.0/8",
+		FanLocalUnderlay: "1.1.1.0/24",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = subnet.MoveToSpace("client")
+	c.Assert(err, gc.ErrorMatches, `cannot move subnet "253.1.0.0/16" to space "client": FAN subnet space is always inherited from underlay`)
+}
+
+func (s *SpacesSuite) TestSpaceReferences(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "bound",
+		Charm: ch,
+		EndpointBindings: map[string]string{
+			"server":  "db",
+			"cluster": "db",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:        "constrained",
+		Charm:       ch,
+		Constraints: constraints.MustParse("spaces=^db"),
+	})
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "unrelated",
+		Charm: ch,
+	})
+
+	refs, err := s.State.SpaceReferences("db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(refs, jc.DeepEquals, state.SpaceReferences{
+		Bindings: map[string][]string{
+			"bound": {"cluster", "server"},
+		},
+		Constraints: []string{"constrained"},
+	})
+}
+
+func (s *SpacesSuite) TestMachinesStrandedBySubnetMove(c *gc.C) {
+	_, err := s.addSpaceWithSubnets(c, addSpaceArgs{
+		Name:        "db",
+		SubnetCIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddMetaCharm(c, "mysql", metaBase, 42)
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:             "yoursql",
+		Charm:            ch,
+		EndpointBindings: map[string]string{"server": "db"},
+	})
+	stranded := s.Factory.MakeMachine(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: stranded})
+	s.setMachineAddresses(c, stranded, "10.0.0.10/24")
+	safe := s.Factory.MakeMachine(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: safe})
+	s.setMachineAddresses(c, safe, "10.0.0.11/24", "10.0.1.11/24")
+
+	machines, err := s.State.MachinesStrandedBySubnetMove("10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, []string{stranded.Id()})
+
+	machines, err = s.State.MachinesStrandedBySubnetMove("10.0.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 0)
+}
+
+func (s *SpacesSuite) setMachineAddresses(c *gc.C, machine *state.Machine, addresses ...string) {
+	err := machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	args := make([]state.LinkLayerDeviceAddress, len(addresses))
+	for i, address := range addresses {
+		args[i] = state.LinkLayerDeviceAddress{
+			DeviceName:   "eth0",
+			ConfigMethod: state.StaticAddress,
+			CIDRAddress:  address,
+		}
+	}
+	err = machine.SetDevicesAddresses(args...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SpacesSuite) TestSpaceReferencesMissingSpace(c *gc.C) {
+	_, err := s.State.SpaceReferences("missing")
+	s.assertSpaceNotFoundError(c, err, "missing")
+}
@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -29,31 +30,137 @@ func (st *State) getModelSubnets() (set.Strings, error) {
 	return modelSubnetIds, nil
 }
 
+// SpacesDrift describes how the spaces and subnets known to a model
+// differed from those reported by its provider when they were reloaded.
+type SpacesDrift struct {
+	// AddedSpaces holds the names of the spaces added to the model.
+	AddedSpaces []string
+
+	// AddedSubnets holds the CIDRs of the subnets added to the model.
+	AddedSubnets []string
+
+	// MissingSpaces holds the names of the model's provider spaces
+	// that the provider no longer reports.
+	MissingSpaces []string
+
+	// MissingSubnets holds the CIDRs of the model's provider subnets
+	// that the provider no longer reports.
+	MissingSubnets []string
+}
+
 // ReloadSpaces loads spaces and subnets from provider specified by environ into state.
 // Currently it's an append-only operation, no spaces/subnets are deleted.
 func (st *State) ReloadSpaces(environ environs.Environ) error {
+	_, err := st.ReloadSpacesWithDrift(environ)
+	return errors.Trace(err)
+}
+
+// ReloadSpacesWithDrift loads spaces and subnets from the provider
+// specified by environ into state, as ReloadSpaces does, and reports
+// how the model's spaces and subnets differed from the provider's.
+// Spaces and subnets missing from the provider are reported, but not
+// deleted.
+func (st *State) ReloadSpacesWithDrift(environ environs.Environ) (SpacesDrift, error) {
 	netEnviron, ok := environs.SupportsNetworking(environ)
 	if !ok {
-		return errors.NotSupportedf("spaces discovery in a non-networking environ")
+		return SpacesDrift{}, errors.NotSupportedf("spaces discovery in a non-networking environ")
 	}
 	canDiscoverSpaces, err := netEnviron.SupportsSpaceDiscovery()
 	if err != nil {
-		return errors.Trace(err)
+		return SpacesDrift{}, errors.Trace(err)
+	}
+	spacesBefore, subnetsBefore, err := st.providerSpacesAndSubnets()
+	if err != nil {
+		return SpacesDrift{}, errors.Trace(err)
 	}
+
+	var providerSpaceIds set.Strings
+	providerSubnetIds := make(set.Strings)
 	if canDiscoverSpaces {
 		spaces, err := netEnviron.Spaces()
 		if err != nil {
-			return errors.Trace(err)
+			return SpacesDrift{}, errors.Trace(err)
+		}
+		providerSpaceIds = make(set.Strings)
+		for _, space := range spaces {
+			providerSpaceIds.Add(string(space.ProviderId))
+			for _, subnet := range space.Subnets {
+				providerSubnetIds.Add(string(subnet.ProviderId))
+			}
+		}
+		if err := st.SaveSpacesFromProvider(spaces); err != nil {
+			return SpacesDrift{}, errors.Trace(err)
 		}
-		return errors.Trace(st.SaveSpacesFromProvider(spaces))
 	} else {
 		logger.Debugf("environ does not support space discovery, falling back to subnet discovery")
 		subnets, err := netEnviron.Subnets(instance.UnknownId, nil)
 		if err != nil {
-			return errors.Trace(err)
+			return SpacesDrift{}, errors.Trace(err)
+		}
+		for _, subnet := range subnets {
+			providerSubnetIds.Add(string(subnet.ProviderId))
+		}
+		if err := st.SaveSubnetsFromProvider(subnets, ""); err != nil {
+			return SpacesDrift{}, errors.Trace(err)
+		}
+	}
+
+	spacesAfter, subnetsAfter, err := st.providerSpacesAndSubnets()
+	if err != nil {
+		return SpacesDrift{}, errors.Trace(err)
+	}
+	var drift SpacesDrift
+	for id, name := range spacesAfter {
+		if _, ok := spacesBefore[id]; !ok {
+			drift.AddedSpaces = append(drift.AddedSpaces, name)
+		}
+		// We only know which spaces are missing if the
+		// provider supports space discovery.
+		if providerSpaceIds != nil && !providerSpaceIds.Contains(string(id)) {
+			drift.MissingSpaces = append(drift.MissingSpaces, name)
+		}
+	}
+	for id, subnet := range subnetsAfter {
+		if _, ok := subnetsBefore[id]; !ok {
+			drift.AddedSubnets = append(drift.AddedSubnets, subnet.CIDR())
+		}
+		// FAN overlay subnets are derived from the model's FAN
+		// config, rather than reported by the provider.
+		if subnet.FanOverlay() == "" && !providerSubnetIds.Contains(string(id)) {
+			drift.MissingSubnets = append(drift.MissingSubnets, subnet.CIDR())
+		}
+	}
+	sort.Strings(drift.AddedSpaces)
+	sort.Strings(drift.AddedSubnets)
+	sort.Strings(drift.MissingSpaces)
+	sort.Strings(drift.MissingSubnets)
+	return drift, nil
+}
+
+// providerSpacesAndSubnets returns the names of the model's spaces and
+// the model's subnets that have provider IDs, keyed by those IDs.
+func (st *State) providerSpacesAndSubnets() (map[network.Id]string, map[network.Id]*Subnet, error) {
+	spaces, err := st.AllSpaces()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	spaceNames := make(map[network.Id]string)
+	for _, space := range spaces {
+		if id := space.ProviderId(); id != "" {
+			spaceNames[id] = space.Name()
+		}
+	}
+	subnets, err := st.AllSubnets()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	providerSubnets := make(map[network.Id]*Subnet)
+	for _, subnet := range subnets {
+		if id := subnet.ProviderId(); id != "" {
+			providerSubnets[id] = subnet
 		}
-		return errors.Trace(st.SaveSubnetsFromProvider(subnets, ""))
 	}
+	return spaceNames, providerSubnets, nil
 }
 
 // SaveSubnetsFromProvider loads subnets into state.
@@ -401,3 +401,72 @@ func (s *SpacesDiscoverySuite) TestReloadSpacesWithFAN(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	checkSpacesEqual(c, spaces, spaceOneAfterFAN)
 }
+
+func (s *SpacesDiscoverySuite) TestReloadSpacesWithDriftSubnetsOnly(c *gc.C) {
+	s.environ = networkedEnviron{
+		stub:           &testing.Stub{},
+		spaceDiscovery: false,
+		subnets:        twoSubnets,
+	}
+	s.usedEnviron = &s.environ
+	drift, err := s.State.ReloadSpacesWithDrift(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(drift, jc.DeepEquals, state.SpacesDrift{
+		AddedSubnets: []string{"10.0.0.1/24", "10.100.30.1/24"},
+	})
+
+	s.environ.subnets = anotherTwoSubnets
+	drift, err = s.State.ReloadSpacesWithDrift(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(drift, jc.DeepEquals, state.SpacesDrift{
+		AddedSubnets:   []string{"10.101.0.1/24", "10.105.0.1/24"},
+		MissingSubnets: []string{"10.0.0.1/24", "10.100.30.1/24"},
+	})
+}
+
+func (s *SpacesDiscoverySuite) TestReloadSpacesWithDrift(c *gc.C) {
+	s.environ = networkedEnviron{
+		stub:           &testing.Stub{},
+		spaceDiscovery: true,
+		spaces:         spaceOne,
+	}
+	s.usedEnviron = &s.environ
+	drift, err := s.State.ReloadSpacesWithDrift(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(drift, jc.DeepEquals, state.SpacesDrift{
+		AddedSpaces:  []string{"space1"},
+		AddedSubnets: []string{"10.0.0.1/24", "10.100.30.1/24"},
+	})
+
+	// Reloading again reports no drift.
+	drift, err = s.State.ReloadSpacesWithDrift(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(drift, jc.DeepEquals, state.SpacesDrift{})
+
+	s.environ.spaces = spaceTwo
+	drift, err = s.State.ReloadSpacesWithDrift(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(drift, jc.DeepEquals, state.SpacesDrift{
+		AddedSpaces:    []string{"space2"},
+		AddedSubnets:   []string{"10.101.0.1/24", "10.105.0.1/24"},
+		MissingSpaces:  []string{"space1"},
+		MissingSubnets: []string{"10.0.0.1/24", "10.100.30.1/24"},
+	})
+}
+
+func (s *SpacesDiscoverySuite) TestReloadSpacesWithDriftIgnoresFANSubnets(c *gc.C) {
+	s.environ = networkedEnviron{
+		stub:           &testing.Stub{},
+		spaceDiscovery: false,
+		subnets:        twoSubnets,
+	}
+	s.usedEnviron = &s.environ
+
+	s.IAASModel.UpdateModelConfig(map[string]interface{}{"fan-config": "10.100.0.0/16=253.0.0.0/8"}, nil)
+	_, err := s.State.ReloadSpacesWithDrift(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+
+	drift, err := s.State.ReloadSpacesWithDrift(s.usedEnviron)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(drift, jc.DeepEquals, state.SpacesDrift{})
+}
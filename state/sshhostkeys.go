@@ -4,6 +4,8 @@
 package state
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
@@ -76,3 +78,93 @@ func removeSSHHostKeyOp(globalKey string) txn.Op {
 		Remove: true,
 	}
 }
+
+// manualHostKeysPrefix prefixes the ids of the documents holding the
+// SSH host keys of manually provisioned hosts, which are known only by
+// their hostname or address until they have been provisioned.
+const manualHostKeysPrefix = "manual#"
+
+// manualHostKeysGlobalKey returns the global key of the SSH host keys
+// document for the given manually provisioned host.
+func manualHostKeysGlobalKey(host string) string {
+	return manualHostKeysPrefix + host
+}
+
+// manualHostKeysDoc is like sshHostKeysDoc, but includes the document
+// id, from which the host is recovered when listing all hosts.
+type manualHostKeysDoc struct {
+	DocID string   `bson:"_id"`
+	Keys  []string `bson:"keys"`
+}
+
+// ManualHostKeys retrieves the SSH host keys stored for a host that
+// is, or will be, manually provisioned. The keys are used to verify
+// the host's identity before any credentials are sent to it.
+func (st *State) ManualHostKeys(host string) (SSHHostKeys, error) {
+	coll, closer := st.db().GetCollection(sshHostKeysC)
+	defer closer()
+
+	var doc sshHostKeysDoc
+	err := coll.FindId(manualHostKeysGlobalKey(host)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("keys for host %q", host)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "key lookup failed")
+	}
+	return SSHHostKeys(doc.Keys), nil
+}
+
+// AllManualHostKeys returns the SSH host keys stored for all manually
+// provisioned hosts in the model, keyed by host.
+func (st *State) AllManualHostKeys() (map[string]SSHHostKeys, error) {
+	coll, closer := st.db().GetCollection(sshHostKeysC)
+	defer closer()
+
+	var docs []manualHostKeysDoc
+	query := bson.D{{"_id", bson.D{{"$regex", "^" + st.docID(manualHostKeysPrefix)}}}}
+	if err := coll.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "key lookup failed")
+	}
+	result := make(map[string]SSHHostKeys)
+	for _, doc := range docs {
+		host := strings.TrimPrefix(st.localID(doc.DocID), manualHostKeysPrefix)
+		result[host] = SSHHostKeys(doc.Keys)
+	}
+	return result, nil
+}
+
+// SetManualHostKeys updates the stored SSH host keys for a manually
+// provisioned host, replacing any keys previously stored for it.
+func (st *State) SetManualHostKeys(host string, keys SSHHostKeys) error {
+	if host == "" {
+		return errors.NotValidf("empty host")
+	}
+	if len(keys) == 0 {
+		return errors.NotValidf("empty keys for host %q", host)
+	}
+	id := manualHostKeysGlobalKey(host)
+	doc := sshHostKeysDoc{
+		Keys: keys,
+	}
+	err := st.db().RunTransaction([]txn.Op{
+		{
+			C:      sshHostKeysC,
+			Id:     id,
+			Insert: doc,
+		}, {
+			C:      sshHostKeysC,
+			Id:     id,
+			Update: bson.M{"$set": doc},
+		},
+	})
+	return errors.Annotate(err, "SSH host key update failed")
+}
+
+// RemoveManualHostKeys removes the SSH host keys stored for a manually
+// provisioned host. It is not an error if no keys are stored.
+func (st *State) RemoveManualHostKeys(host string) error {
+	err := st.db().RunTransaction([]txn.Op{
+		removeSSHHostKeyOp(manualHostKeysGlobalKey(host)),
+	})
+	return errors.Annotate(err, "SSH host key removal failed")
+}
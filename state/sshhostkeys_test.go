@@ -55,6 +55,73 @@ func (s *SSHHostKeysSuite) TestModelIsolation(c *gc.C) {
 	checkGet(c, stB, tagB, keysB)
 }
 
+func (s *SSHHostKeysSuite) TestManualHostKeysNotFound(c *gc.C) {
+	_, err := s.State.ManualHostKeys("10.0.0.1")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `keys for host "10.0.0.1" not found`)
+}
+
+func (s *SSHHostKeysSuite) TestSetManualHostKeys(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		keys := state.SSHHostKeys{"ssh-rsa foo", "ssh-ed25519 bar"}
+		err := s.State.SetManualHostKeys("10.0.0.1", keys)
+		c.Assert(err, jc.ErrorIsNil)
+		keysGot, err := s.State.ManualHostKeys("10.0.0.1")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(keysGot, jc.DeepEquals, keys)
+	}
+}
+
+func (s *SSHHostKeysSuite) TestSetManualHostKeysInvalid(c *gc.C) {
+	err := s.State.SetManualHostKeys("", state.SSHHostKeys{"ssh-rsa foo"})
+	c.Check(err, gc.ErrorMatches, "empty host not valid")
+	err = s.State.SetManualHostKeys("10.0.0.1", nil)
+	c.Check(err, gc.ErrorMatches, `empty keys for host "10.0.0.1" not valid`)
+}
+
+func (s *SSHHostKeysSuite) TestManualHostKeysSeparateFromMachineKeys(c *gc.C) {
+	err := s.State.SetSSHHostKeys(s.machineTag, state.SSHHostKeys{"ssh-rsa machine"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetManualHostKeys(s.machineTag.Id(), state.SSHHostKeys{"ssh-rsa manual"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	checkGet(c, s.State, s.machineTag, state.SSHHostKeys{"ssh-rsa machine"})
+	all, err := s.State.AllManualHostKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(all, jc.DeepEquals, map[string]state.SSHHostKeys{
+		s.machineTag.Id(): {"ssh-rsa manual"},
+	})
+}
+
+func (s *SSHHostKeysSuite) TestAllManualHostKeys(c *gc.C) {
+	c.Assert(s.State.SetManualHostKeys("10.0.0.1", state.SSHHostKeys{"ssh-rsa one"}), jc.ErrorIsNil)
+	c.Assert(s.State.SetManualHostKeys("host.example.com", state.SSHHostKeys{"ssh-rsa two"}), jc.ErrorIsNil)
+
+	stB := s.Factory.MakeModel(c, nil)
+	defer stB.Close()
+	c.Assert(stB.SetManualHostKeys("10.0.0.2", state.SSHHostKeys{"ssh-rsa three"}), jc.ErrorIsNil)
+
+	all, err := s.State.AllManualHostKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(all, jc.DeepEquals, map[string]state.SSHHostKeys{
+		"10.0.0.1":         {"ssh-rsa one"},
+		"host.example.com": {"ssh-rsa two"},
+	})
+}
+
+func (s *SSHHostKeysSuite) TestRemoveManualHostKeys(c *gc.C) {
+	c.Assert(s.State.SetManualHostKeys("10.0.0.1", state.SSHHostKeys{"ssh-rsa one"}), jc.ErrorIsNil)
+
+	err := s.State.RemoveManualHostKeys("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ManualHostKeys("10.0.0.1")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing keys that are not stored is not an error.
+	err = s.State.RemoveManualHostKeys("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func checkKeysNotFound(c *gc.C, st *state.State, tag names.MachineTag) {
 	_, err := st.GetSSHHostKeys(tag)
 	c.Check(errors.IsNotFound(err), jc.IsTrue)
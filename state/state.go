@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -32,6 +33,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/constraints"
+	coreapplication "github.com/juju/juju/core/application"
 	coreglobalclock "github.com/juju/juju/core/globalclock"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/instance"
@@ -1336,6 +1338,16 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		if err = app.Refresh(); err != nil {
 			return nil, errors.Trace(err)
 		}
+		// Start the charm history afresh, discarding any left by a
+		// removed application of the same name.
+		if err := eraseCharmHistory(st, app.doc.Name); err != nil {
+			logger.Errorf("cannot delete charm history for application %q: %v", app.doc.Name, err)
+		} else if err := app.recordCharmHistory(""); err != nil {
+			logger.Errorf("cannot record charm history for application %q: %v", app.doc.Name, err)
+		}
+		if err := erasePodSpecHistory(st, app.doc.Name); err != nil {
+			logger.Errorf("cannot delete pod spec history for application %q: %v", app.doc.Name, err)
+		}
 		return app, nil
 	}
 	return nil, errors.Trace(err)
@@ -1483,6 +1495,10 @@ func (st *State) parsePlacement(placement *instance.Placement) (*placementData,
 		return &placementData{directive: placement.Directive}, nil
 	case instance.MachineScope:
 		return &placementData{machineId: placement.Directive}, nil
+	case instance.AffinityScope, instance.AntiAffinityScope:
+		// Affinity directives are recorded against new machines in
+		// full, for the provisioner to interpret.
+		return &placementData{directive: placement.String()}, nil
 	default:
 		return nil, errors.Errorf("placement scope: invalid model UUID %q", placement.Scope)
 	}
@@ -1570,6 +1586,33 @@ func (st *State) AllApplications() (applications []*Application, err error) {
 	return applications, nil
 }
 
+// ApplicationsMatching returns the applications whose labels are
+// selected by the given selector, ordered by name.
+func (st *State) ApplicationsMatching(selector coreapplication.Selector) ([]*Application, error) {
+	if len(selector) == 0 {
+		return nil, errors.NotValidf("empty selector")
+	}
+	if err := coreapplication.ValidateLabels(selector); err != nil {
+		return nil, errors.Trace(err)
+	}
+	applicationsCollection, closer := st.db().GetCollection(applicationsC)
+	defer closer()
+
+	query := make(bson.D, 0, len(selector))
+	for key, value := range selector {
+		query = append(query, bson.DocElem{"labels." + key, value})
+	}
+	var docs []applicationDoc
+	if err := applicationsCollection.Find(query).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get applications matching %q", selector.String())
+	}
+	applications := make([]*Application, len(docs))
+	for i := range docs {
+		applications[i] = newApplication(st, &docs[i])
+	}
+	return applications, nil
+}
+
 // InferEndpoints returns the endpoints corresponding to the supplied names.
 // There must be 1 or 2 supplied names, of the form <application>[:<relation>].
 // If the supplied names uniquely specify a possible relation, or if they
@@ -2248,6 +2291,45 @@ func (st *State) PutAuditEntryFn() func(audit.AuditEntry) error {
 	return stateaudit.PutAuditEntryFn(auditingC, insert)
 }
 
+// AuditEntryFilter selects the audit entries returned by
+// State.AuditEntries. Zero-valued fields are ignored.
+type AuditEntryFilter struct {
+	// ModelUUID selects entries recorded for the given model.
+	ModelUUID string
+
+	// OriginName selects entries for requests made by the given
+	// entity, e.g. "user-bob".
+	OriginName string
+
+	// After and Before select entries recorded at or after, and
+	// before, the given times.
+	After  time.Time
+	Before time.Time
+
+	// Limit is the maximum number of entries to return.
+	Limit int
+}
+
+// AuditEntries returns the audit entries recorded by the controller
+// that match the given filter, most recent first.
+func (st *State) AuditEntries(filter AuditEntryFilter) ([]audit.AuditEntry, error) {
+	collection, closeCollection := st.db().GetCollection(auditingC)
+	defer closeCollection()
+
+	query := bson.D{}
+	if filter.ModelUUID != "" {
+		query = append(query, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	if filter.OriginName != "" {
+		query = append(query, bson.DocElem{"origin-name", filter.OriginName})
+	}
+	// The audit log is capped, so its natural order is the order in
+	// which entries were recorded.
+	iter := collection.Find(query).Sort("-$natural").Iter()
+	entries, err := stateaudit.GetAuditEntries(iter, filter.After, filter.Before, filter.Limit)
+	return entries, errors.Trace(err)
+}
+
 // SetSLA sets the SLA on the current connected model.
 func (st *State) SetSLA(level, owner string, credentials []byte) error {
 	model, err := st.Model()
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/core/globalclock"
 	"github.com/juju/juju/core/leadership"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -146,6 +147,28 @@ func (s *LeadershipSuite) TestApplicationLeaders(c *gc.C) {
 	})
 }
 
+func (s *LeadershipSuite) TestWatchLeader(c *gc.C) {
+	application := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	w := application.WatchLeader()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.claimer.ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Extending the current leader's lease is not a change.
+	err = s.claimer.ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Nor is a change to another application's leader.
+	err = s.claimer.ClaimLeadership("blah", "blah/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
 func (s *LeadershipSuite) expire(c *gc.C, applicationname string) {
 	s.Clock.Advance(time.Hour)
 	err := s.globalClock.Advance(time.Hour)
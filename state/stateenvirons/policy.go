@@ -6,6 +6,7 @@ package stateenvirons
 import (
 	"github.com/juju/errors"
 
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -78,6 +79,15 @@ func (p environStatePolicy) InstanceDistributor() (instance.Distributor, error)
 
 // StorageProviderRegistry implements state.Policy.
 func (p environStatePolicy) StorageProviderRegistry() (storage.ProviderRegistry, error) {
+	model, err := p.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.Type() == state.ModelTypeCAAS {
+		// Kubernetes models have no environ; their storage is
+		// provisioned by the cluster.
+		return k8sprovider.StorageProviders(), nil
+	}
 	env, err := p.getEnviron(p.st)
 	if err != nil {
 		return nil, errors.Trace(err)
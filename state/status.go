@@ -443,7 +443,15 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 	return results, nil
 }
 
+// PruneStatusHistory removes status history entries until only those
+// newer than <maxHistoryTime> remain, and also ensures that the
+// collection is smaller than <maxHistoryMB>, or the controller's
+// max-status-history-collection-size if that is set.
 func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
-	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, statusesHistoryC, "updated", NanoSeconds)
+	maxHistoryMB, err := pruneSizeLimit(st, maxHistoryMB, statusesHistoryC)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = pruneCollection(st, maxHistoryTime, maxHistoryMB, statusesHistoryC, "updated", NanoSeconds)
 	return errors.Trace(err)
 }
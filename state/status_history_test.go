@@ -172,6 +172,39 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 	}
 }
 
+func (s *StatusHistorySuite) TestPruneStatusHistoryRecordsStats(c *gc.C) {
+	clock := testing.NewClock(coretesting.NonZeroTime())
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	state.PrimeUnitStatusHistory(c, clock, unit, status.Active, 10, 10, nil)
+
+	stats, err := s.State.PruneStats()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats, gc.HasLen, 0)
+
+	clock.Advance(time.Hour)
+	err = state.PruneStatusHistory(s.State, time.Minute, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stats, err = s.State.PruneStats()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats, gc.HasLen, 1)
+	c.Assert(stats[0].Collection, gc.Equals, "statuseshistory")
+	c.Assert(stats[0].Time.Equal(clock.Now()), jc.IsTrue)
+	c.Assert(stats[0].Removed >= 10, jc.IsTrue)
+	c.Assert(stats[0].ReclaimedMB >= 0, jc.IsTrue)
+
+	// Pruning again replaces the statistics.
+	err = state.PruneStatusHistory(s.State, time.Minute, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	stats, err = s.State.PruneStats()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats, gc.HasLen, 1)
+	c.Assert(stats[0].Removed, gc.Equals, 0)
+}
+
 func (s *StatusHistorySuite) TestStatusHistoryFilterRunningUpdateStatusHook(c *gc.C) {
 
 	application := s.Factory.MakeApplication(c, nil)
@@ -8,6 +8,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
 )
@@ -186,3 +187,27 @@ func (s *MachineStatusSuite) TestSetStatusPendingUnprovisioned(c *gc.C) {
 	err = machine.SetStatus(sInfo)
 	c.Check(err, jc.ErrorIsNil)
 }
+
+func (s *MachineStatusSuite) TestWatchStatus(c *gc.C) {
+	w := s.machine.WatchStatus()
+	defer statetesting.AssertStop(c, w)
+
+	// Initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	now := testing.ZeroTime()
+	err := s.machine.SetStatus(status.StatusInfo{
+		Status: status.Started,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.machine.SetInstanceStatus(status.StatusInfo{
+		Status: status.Running,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -10,6 +10,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/testing"
 )
@@ -198,3 +199,27 @@ func (s *UnitStatusSuite) TestStatusHistoryLong(c *gc.C) {
 		checkPrimedUnitStatus(c, statusInfo, 24-i, 0)
 	}
 }
+
+func (s *UnitStatusSuite) TestWatchStatus(c *gc.C) {
+	w := s.unit.WatchStatus()
+	defer statetesting.AssertStop(c, w)
+
+	// Initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	now := testing.ZeroTime()
+	err := s.unit.SetStatus(status.StatusInfo{
+		Status: status.Active,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.unit.Agent().SetStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,148 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/mongo/utils"
+	"github.com/juju/juju/status"
+)
+
+// historicalStatusDocWithID is a historicalStatusDoc with its document
+// id, which orders entries recorded at the same time.
+type historicalStatusDocWithID struct {
+	ID         bson.ObjectId          `bson:"_id"`
+	GlobalKey  string                 `bson:"globalkey"`
+	Status     status.Status          `bson:"status"`
+	StatusInfo string                 `bson:"statusinfo"`
+	StatusData map[string]interface{} `bson:"statusdata"`
+	Updated    int64                  `bson:"updated"`
+}
+
+// StatusHistoryPage returns the page of the entity's status history of
+// the given kind selected by the range. Unit history may be of the
+// unit, workload, juju-unit or payload kinds; machine history may be
+// of the machine, juju-machine, container or juju-container kinds.
+func (st *State) StatusHistoryPage(tag names.Tag, kind status.HistoryKind, r status.StatusHistoryRange) (status.StatusHistoryPage, error) {
+	if err := r.Validate(); err != nil {
+		return status.StatusHistoryPage{}, errors.Annotate(err, "validating range")
+	}
+	keys, err := st.statusHistoryKeys(tag, kind)
+	if err != nil {
+		return status.StatusHistoryPage{}, errors.Trace(err)
+	}
+	globalKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		globalKeys = append(globalKeys, key)
+	}
+
+	updated := bson.M{"$gte": r.From.UnixNano()}
+	if !r.To.IsZero() {
+		updated["$lt"] = r.To.UnixNano()
+	}
+	query := bson.D{
+		{"globalkey", bson.M{"$in": globalKeys}},
+		{"updated", updated},
+	}
+	if r.After != "" {
+		afterUpdated, afterId, err := parseStatusHistoryCursor(r.After)
+		if err != nil {
+			return status.StatusHistoryPage{}, errors.Trace(err)
+		}
+		query = append(query, bson.DocElem{"$or", []bson.D{
+			{{"updated", bson.M{"$gt": afterUpdated}}},
+			{{"updated", afterUpdated}, {"_id", bson.M{"$gt": afterId}}},
+		}})
+	}
+
+	coll, closer := st.db().GetCollection(statusesHistoryC)
+	defer closer()
+
+	// Fetch one more entry than requested to find out whether there
+	// is a following page.
+	var docs []historicalStatusDocWithID
+	if err := coll.Find(query).Sort("updated", "_id").Limit(r.Limit + 1).All(&docs); err != nil {
+		return status.StatusHistoryPage{}, errors.Annotate(err, "cannot get status history")
+	}
+	var page status.StatusHistoryPage
+	if len(docs) > r.Limit {
+		docs = docs[:r.Limit]
+		last := docs[len(docs)-1]
+		page.Next = formatStatusHistoryCursor(last.Updated, last.ID)
+	}
+	page.Statuses = make([]status.DetailedStatus, len(docs))
+	for i, doc := range docs {
+		page.Statuses[i] = status.DetailedStatus{
+			Status: doc.Status,
+			Info:   doc.StatusInfo,
+			Data:   utils.UnescapeKeys(doc.StatusData),
+			Since:  unixNanoToTime(doc.Updated),
+			Kind:   keys[doc.GlobalKey],
+		}
+	}
+	return page, nil
+}
+
+// statusHistoryKeys returns the global keys under which the entity's
+// status history of the given kind is recorded, mapped to the kind of
+// each key's entries.
+func (st *State) statusHistoryKeys(tag names.Tag, kind status.HistoryKind) (map[string]status.HistoryKind, error) {
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		if _, err := st.Unit(tag.Id()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch kind {
+		case status.KindUnit:
+			return map[string]status.HistoryKind{
+				unitGlobalKey(tag.Id()):      status.KindWorkload,
+				unitAgentGlobalKey(tag.Id()): status.KindUnitAgent,
+			}, nil
+		case status.KindWorkload:
+			return map[string]status.HistoryKind{unitGlobalKey(tag.Id()): kind}, nil
+		case status.KindUnitAgent:
+			return map[string]status.HistoryKind{unitAgentGlobalKey(tag.Id()): kind}, nil
+		case status.KindPayload:
+			return map[string]status.HistoryKind{unitPayloadsGlobalKey(tag.Id()): kind}, nil
+		}
+	case names.MachineTag:
+		if _, err := st.Machine(tag.Id()); err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch kind {
+		case status.KindMachine, status.KindContainer:
+			return map[string]status.HistoryKind{machineGlobalKey(tag.Id()): kind}, nil
+		case status.KindMachineInstance, status.KindContainerInstance:
+			return map[string]status.HistoryKind{machineGlobalInstanceKey(tag.Id()): kind}, nil
+		}
+	default:
+		return nil, errors.NotSupportedf("status history for %s", names.ReadableString(tag))
+	}
+	return nil, errors.NotValidf("status history kind %q for %s", kind, names.ReadableString(tag))
+}
+
+// formatStatusHistoryCursor returns an opaque cursor identifying the
+// position of a status history entry.
+func formatStatusHistoryCursor(updated int64, id bson.ObjectId) string {
+	return fmt.Sprintf("%d.%s", updated, id.Hex())
+}
+
+func parseStatusHistoryCursor(cursor string) (int64, bson.ObjectId, error) {
+	parts := strings.SplitN(cursor, ".", 2)
+	if len(parts) != 2 || !bson.IsObjectIdHex(parts[1]) {
+		return 0, "", errors.NotValidf("cursor %q", cursor)
+	}
+	updated, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", errors.NotValidf("cursor %q", cursor)
+	}
+	return updated, bson.ObjectIdHex(parts[1]), nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+)
+
+type StatusHistoryPageSuite struct {
+	ConnSuite
+	unit *state.Unit
+	base time.Time
+}
+
+var _ = gc.Suite(&StatusHistoryPageSuite{})
+
+func (s *StatusHistoryPageSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+	s.base = s.Clock.Now().Add(time.Hour)
+	for i := 0; i < 5; i++ {
+		since := s.base.Add(time.Duration(i) * time.Minute)
+		err := s.unit.SetStatus(status.StatusInfo{
+			Status:  status.Active,
+			Message: fmt.Sprintf("workload %d", i),
+			Since:   &since,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		// Agent entries are recorded at the same times as the
+		// workload entries.
+		err = s.unit.Agent().SetStatus(status.StatusInfo{
+			Status:  status.Executing,
+			Message: fmt.Sprintf("agent %d", i),
+			Since:   &since,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func statusMessages(statuses []status.DetailedStatus) []string {
+	result := make([]string, len(statuses))
+	for i, s := range statuses {
+		result[i] = s.Info
+	}
+	return result
+}
+
+func (s *StatusHistoryPageSuite) TestTimeRange(c *gc.C) {
+	page, err := s.State.StatusHistoryPage(s.unit.Tag(), status.KindWorkload, status.StatusHistoryRange{
+		From:  s.base.Add(time.Minute),
+		To:    s.base.Add(4 * time.Minute),
+		Limit: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusMessages(page.Statuses), jc.DeepEquals, []string{"workload 1", "workload 2", "workload 3"})
+	c.Assert(page.Statuses[0].Kind, gc.Equals, status.KindWorkload)
+	c.Assert(page.Statuses[0].Since.Equal(s.base.Add(time.Minute)), jc.IsTrue)
+	c.Assert(page.Next, gc.Equals, "")
+}
+
+func (s *StatusHistoryPageSuite) TestPagination(c *gc.C) {
+	r := status.StatusHistoryRange{
+		From:  s.base,
+		Limit: 3,
+	}
+	var all []status.DetailedStatus
+	for pages := 0; ; pages++ {
+		c.Assert(pages < 4, jc.IsTrue)
+		page, err := s.State.StatusHistoryPage(s.unit.Tag(), status.KindUnit, r)
+		c.Assert(err, jc.ErrorIsNil)
+		all = append(all, page.Statuses...)
+		if page.Next == "" {
+			break
+		}
+		r.After = page.Next
+	}
+	c.Assert(all, gc.HasLen, 10)
+	for i := 0; i < 5; i++ {
+		// Entries recorded at the same time are all returned, even
+		// when they are split across pages.
+		pair := statusMessages(all[2*i : 2*i+2])
+		c.Check(pair, jc.SameContents, []string{
+			fmt.Sprintf("workload %d", i),
+			fmt.Sprintf("agent %d", i),
+		})
+		c.Check(all[2*i].Kind, gc.Not(gc.Equals), all[2*i+1].Kind)
+	}
+}
+
+func (s *StatusHistoryPageSuite) TestInvalidRange(c *gc.C) {
+	_, err := s.State.StatusHistoryPage(s.unit.Tag(), status.KindUnit, status.StatusHistoryRange{From: s.base})
+	c.Assert(err, gc.ErrorMatches, "validating range: limit 0 not valid")
+	_, err = s.State.StatusHistoryPage(s.unit.Tag(), status.KindUnit, status.StatusHistoryRange{
+		From:  s.base,
+		After: "nope",
+		Limit: 1,
+	})
+	c.Assert(err, gc.ErrorMatches, `cursor "nope" not valid`)
+}
+
+func (s *StatusHistoryPageSuite) TestInvalidKind(c *gc.C) {
+	_, err := s.State.StatusHistoryPage(s.unit.Tag(), status.KindMachine, status.StatusHistoryRange{
+		From:  s.base,
+		Limit: 1,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `status history kind "machine" for unit .* not valid`)
+}
+
+func (s *StatusHistoryPageSuite) TestMachineHistory(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	since := s.base
+	err := machine.SetStatus(status.StatusInfo{
+		Status:  status.Started,
+		Message: "started",
+		Since:   &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	page, err := s.State.StatusHistoryPage(machine.Tag(), status.KindMachine, status.StatusHistoryRange{
+		From:  s.base,
+		Limit: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusMessages(page.Statuses), jc.DeepEquals, []string{"started"})
+}
+
+func (s *StatusHistoryPageSuite) TestEntityNotFound(c *gc.C) {
+	_, err := s.State.StatusHistoryPage(names.NewUnitTag("missing/0"), status.KindUnit, status.StatusHistoryRange{
+		From:  s.base,
+		Limit: 1,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
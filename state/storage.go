@@ -41,6 +41,17 @@ type StorageInstance interface {
 	// to another unit.
 	Owner() (names.Tag, bool)
 
+	// LastOwner returns the tag of the unit that most recently owned
+	// this storage instance, and a boolean indicating whether or not
+	// there was one. The last owner is recorded when the storage
+	// instance is detached from its owning unit.
+	LastOwner() (names.Tag, bool)
+
+	// FilesystemLabel returns the label of the filesystem on the
+	// storage instance, as last observed on its owning unit's machine.
+	// If the label is not known, an empty string is returned.
+	FilesystemLabel() string
+
 	// StorageName returns the name of the storage, as defined in the charm
 	// storage metadata. This does not uniquely identify storage instances,
 	// but identifies the group that the instances belong to.
@@ -140,6 +151,23 @@ func (s *storageInstance) maybeOwner() names.Tag {
 	return tag
 }
 
+func (s *storageInstance) LastOwner() (names.Tag, bool) {
+	if s.doc.LastOwner == "" {
+		return nil, false
+	}
+	tag, err := names.ParseTag(s.doc.LastOwner)
+	if err != nil {
+		// This should be impossible; we do not expose
+		// a means of modifying the last owner tag.
+		panic(err)
+	}
+	return tag, true
+}
+
+func (s *storageInstance) FilesystemLabel() string {
+	return s.doc.FilesystemLabel
+}
+
 func (s *storageInstance) StorageName() string {
 	return s.doc.StorageName
 }
@@ -169,6 +197,8 @@ type storageInstanceDoc struct {
 	Life            Life                       `bson:"life"`
 	Releasing       bool                       `bson:"releasing,omitempty"`
 	Owner           string                     `bson:"owner,omitempty"`
+	LastOwner       string                     `bson:"lastowner,omitempty"`
+	FilesystemLabel string                     `bson:"filesystemlabel,omitempty"`
 	StorageName     string                     `bson:"storagename"`
 	AttachmentCount int                        `bson:"attachmentcount"`
 	Constraints     storageInstanceConstraints `bson:"constraints"`
@@ -905,53 +935,77 @@ func (im *IAASModel) AttachStorage(storage names.StorageTag, unit names.UnitTag)
 		names.ReadableString(unit),
 	)
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		si, err := im.storageInstance(storage)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		u, err := im.st.Unit(unit.Id())
+		return im.attachStorageTxnOps(storage, unit)
+	}
+	return im.mb.db().Run(buildTxn)
+}
+
+// ValidateAttachStorage checks that the storage instance with the
+// specified tag could be attached to the specified unit, without
+// attaching it. Storage that is already attached to the unit is
+// considered valid.
+func (im *IAASModel) ValidateAttachStorage(storage names.StorageTag, unit names.UnitTag) (err error) {
+	defer errors.DeferredAnnotatef(&err,
+		"cannot attach %s to %s",
+		names.ReadableString(storage),
+		names.ReadableString(unit),
+	)
+	_, err = im.attachStorageTxnOps(storage, unit)
+	if err == jujutxn.ErrNoOperations {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// attachStorageTxnOps returns txn.Ops to attach the storage instance
+// with the specified tag to the specified unit, validating that the
+// storage is compatible with the unit's charm.
+func (im *IAASModel) attachStorageTxnOps(storage names.StorageTag, unit names.UnitTag) ([]txn.Op, error) {
+	si, err := im.storageInstance(storage)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	u, err := im.st.Unit(unit.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if u.Life() != Alive {
+		return nil, errors.New("unit not alive")
+	}
+	ch, err := u.charm()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting charm")
+	}
+	ops, err := im.attachStorageOps(si, u.UnitTag(), u.Series(), ch, u)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if si.doc.Owner == "" {
+		// The storage instance will be owned by the unit, so we
+		// must increment the unit's refcount for the storage name.
+		//
+		// Make sure that we *can* assign another storage instance
+		// to the unit.
+		_, currentCountOp, err := validateStorageCountChange(
+			im, u.UnitTag(), si.StorageName(), 1, ch.Meta(),
+		)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		if u.Life() != Alive {
-			return nil, errors.New("unit not alive")
-		}
-		ch, err := u.charm()
-		if err != nil {
-			return nil, errors.Annotate(err, "getting charm")
-		}
-		ops, err := im.attachStorageOps(si, u.UnitTag(), u.Series(), ch, u)
+		incRefOp, err := increfEntityStorageOp(im.mb, u.UnitTag(), si.StorageName(), 1)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		if si.doc.Owner == "" {
-			// The storage instance will be owned by the unit, so we
-			// must increment the unit's refcount for the storage name.
-			//
-			// Make sure that we *can* assign another storage instance
-			// to the unit.
-			_, currentCountOp, err := validateStorageCountChange(
-				im, u.UnitTag(), si.StorageName(), 1, ch.Meta(),
-			)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			incRefOp, err := increfEntityStorageOp(im.mb, u.UnitTag(), si.StorageName(), 1)
-			if err != nil {
-				return nil, errors.Trace(err)
-			}
-			ops = append(ops, currentCountOp, incRefOp)
-		}
-		ops = append(ops, txn.Op{
-			C:      unitsC,
-			Id:     u.doc.Name,
-			Assert: isAliveDoc,
-			Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
-		})
-		ops = append(ops, u.assertCharmOps(ch)...)
-		return ops, nil
+		ops = append(ops, currentCountOp, incRefOp)
 	}
-	return im.mb.db().Run(buildTxn)
+	ops = append(ops, txn.Op{
+		C:      unitsC,
+		Id:     u.doc.Name,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$inc", bson.D{{"storageattachmentcount", 1}}}},
+	})
+	ops = append(ops, u.assertCharmOps(ch)...)
+	return ops, nil
 }
 
 // attachStorageOps returns txn.Ops to attach a storage instance to the
@@ -1003,12 +1057,16 @@ func (im *IAASModel) attachStorageOps(
 	// Check that the unit's charm declares storage with the storage
 	// instance's storage name.
 	charmMeta := ch.Meta()
-	if _, ok := charmMeta.Storage[si.StorageName()]; !ok {
+	charmStorage, ok := charmMeta.Storage[si.StorageName()]
+	if !ok {
 		return nil, errors.Errorf(
 			"charm %s has no storage called %s",
 			charmMeta.Name, si.StorageName(),
 		)
 	}
+	if err := validateCharmStorageCompatibility(si, charmMeta.Name, charmStorage); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// Create a storage attachment doc, ensuring that the storage instance
 	// owner does not change, and that both the storage instance and unit
@@ -1046,6 +1104,30 @@ func (im *IAASModel) attachStorageOps(
 	return ops, nil
 }
 
+// validateCharmStorageCompatibility checks that the storage instance
+// is of the kind required by the charm storage, and that it is no
+// smaller than the charm storage's minimum size, if that is known.
+func validateCharmStorageCompatibility(si *storageInstance, charmName string, charmStorage charm.Storage) error {
+	if kind := storageKind(charmStorage.Type); kind != storage.StorageKind(si.Kind()) {
+		return errors.Errorf(
+			"charm %s storage %q requires %s storage, %s is %s storage",
+			charmName, si.StorageName(), kind,
+			names.ReadableString(si.StorageTag()), si.Kind(),
+		)
+	}
+	size := si.doc.Constraints.Size
+	if charmStorage.MinimumSize > 0 && size > 0 && size < charmStorage.MinimumSize {
+		return errors.Errorf(
+			"charm %s storage %q: minimum storage size is %s, %s is %s",
+			charmName, si.StorageName(),
+			humanize.Bytes(charmStorage.MinimumSize*humanize.MByte),
+			names.ReadableString(si.StorageTag()),
+			humanize.Bytes(size*humanize.MByte),
+		)
+	}
+	return nil
+}
+
 // DetachStorage ensures that the existing storage attachments of
 // the specified unit are removed at some point.
 func (im *IAASModel) DestroyUnitStorageAttachments(unit names.UnitTag) (err error) {
@@ -1304,9 +1386,20 @@ func removeStorageAttachmentOps(
 			ops = append(ops, validateRemoveOps...)
 
 			// Disown the storage instance, so it can be attached
-			// to another unit/application.
+			// to another unit/application. Record the unit and the
+			// filesystem label, so that the detached storage can be
+			// identified for reuse.
+			label, err := im.storageInstanceFilesystemLabel(si, s.Unit())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
 			siUpdate = append(siUpdate, bson.DocElem{
 				"$unset", bson.D{{"owner", nil}},
+			}, bson.DocElem{
+				"$set", bson.D{
+					{"lastowner", si.doc.Owner},
+					{"filesystemlabel", label},
+				},
 			})
 			decrefOp, err := decrefEntityStorageOp(im.mb, s.Unit(), si.StorageName())
 			if err != nil {
@@ -1353,6 +1446,108 @@ func removeStorageAttachmentOps(
 	return ops, nil
 }
 
+// storageInstanceFilesystemLabel returns the label of the filesystem
+// on the block device backing the storage instance, as observed on the
+// machine to which the unit is assigned. If the label cannot be
+// determined, an empty string is returned.
+func (im *IAASModel) storageInstanceFilesystemLabel(si *storageInstance, unitTag names.UnitTag) (string, error) {
+	unit, err := im.st.Unit(unitTag.Id())
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	var volumeTag names.VolumeTag
+	switch si.Kind() {
+	case StorageKindBlock:
+		volume, err := im.storageInstanceVolume(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return "", nil
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+		volumeTag = volume.VolumeTag()
+	case StorageKindFilesystem:
+		filesystem, err := im.storageInstanceFilesystem(si.StorageTag())
+		if errors.IsNotFound(err) {
+			return "", nil
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+		volumeTag, err = filesystem.Volume()
+		if err == ErrNoBackingVolume {
+			return "", nil
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+	default:
+		return "", nil
+	}
+
+	volume, err := im.volumeByTag(volumeTag)
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	volumeInfo, err := volume.Info()
+	if errors.IsNotProvisioned(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	var attachmentInfo VolumeAttachmentInfo
+	att, err := im.VolumeAttachment(names.NewMachineTag(machineId), volumeTag)
+	if err == nil {
+		attachmentInfo, err = att.Info()
+	}
+	if errors.IsNotFound(err) || errors.IsNotProvisioned(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	blockDevices, err := getBlockDevices(im.mb.db(), machineId)
+	if errors.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, dev := range blockDevices {
+		if volumeBlockDeviceMatches(volumeInfo, attachmentInfo, dev) {
+			return dev.Label, nil
+		}
+	}
+	return "", nil
+}
+
+// volumeBlockDeviceMatches reports whether the block device corresponds
+// to the volume with the given volume and volume attachment info.
+func volumeBlockDeviceMatches(volumeInfo VolumeInfo, attachmentInfo VolumeAttachmentInfo, dev BlockDeviceInfo) bool {
+	if volumeInfo.WWN != "" {
+		return dev.WWN == volumeInfo.WWN
+	}
+	if volumeInfo.HardwareId != "" {
+		return dev.HardwareId == volumeInfo.HardwareId
+	}
+	if attachmentInfo.DeviceLink != "" {
+		for _, link := range dev.DeviceLinks {
+			if link == attachmentInfo.DeviceLink {
+				return true
+			}
+		}
+		return false
+	}
+	return attachmentInfo.DeviceName != "" && dev.DeviceName == attachmentInfo.DeviceName
+}
+
 func (im *IAASModel) detachStorageMachineAttachmentOps(si *storageInstance, unitTag names.UnitTag) ([]txn.Op, error) {
 	unit, err := im.st.Unit(unitTag.Id())
 	if err != nil {
@@ -1724,7 +1919,11 @@ func poolStorageProvider(im *IAASModel, poolName string) (storage.ProviderType,
 	if err != nil {
 		return "", nil, errors.Annotate(err, "getting storage provider registry")
 	}
-	poolManager := poolmanager.New(NewStateSettings(im.mb), registry)
+	settings, err := NewStoragePoolSettings(im.st)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	poolManager := poolmanager.New(settings, registry)
 	pool, err := poolManager.Get(poolName)
 	if errors.IsNotFound(err) {
 		// If there's no pool called poolName, maybe a provider type
@@ -2052,3 +2251,97 @@ func (im *IAASModel) countEntityStorageInstances(owner names.Tag, name string) (
 	key := entityStorageRefcountKey(owner, name)
 	return nsRefcounts.CurrentOp(refcounts, key)
 }
+
+// ResizeStorage grows the storage instance with the specified tag to the
+// given size, in MiB, and records the new size in the storage instance's
+// constraints. Provisioned storage is grown in place by the storage
+// provisioner, while it remains attached; storage that is yet to be
+// provisioned is created with the new size.
+//
+// Filesystem storage may only be resized if the filesystem is backed by
+// a volume. The filesystem is grown once its volume has been.
+func (im *IAASModel) ResizeStorage(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		si, err := im.storageInstance(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if si.Life() != Alive {
+			return nil, errors.New("storage instance is not alive")
+		}
+		v, err := im.storageVolumeToResize(si)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumeOp := txn.Op{
+			C:  volumesC,
+			Id: v.doc.Name,
+		}
+		if info, err := v.Info(); err == nil {
+			current := info.Size
+			if pending, ok := v.PendingSize(); ok {
+				current = pending
+			}
+			if size <= current {
+				return nil, notLargerError(size, current)
+			}
+			pendingAssert := bson.DocElem{"pendingsize", bson.D{{"$exists", false}}}
+			if v.doc.PendingSize > 0 {
+				pendingAssert = bson.DocElem{"pendingsize", v.doc.PendingSize}
+			}
+			volumeOp.Assert = append(bson.D{
+				{"info", bson.D{{"$exists", true}}},
+				pendingAssert,
+			}, isAliveDoc...)
+			volumeOp.Update = bson.D{{"$set", bson.D{{"pendingsize", size}}}}
+		} else if errors.IsNotProvisioned(err) {
+			params, _ := v.Params()
+			if size <= params.Size {
+				return nil, notLargerError(size, params.Size)
+			}
+			volumeOp.Assert = append(bson.D{
+				{"info", bson.D{{"$exists", false}}},
+				{"params.size", params.Size},
+			}, isAliveDoc...)
+			volumeOp.Update = bson.D{{"$set", bson.D{{"params.size", size}}}}
+		} else {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:  storageInstancesC,
+			Id: si.doc.Id,
+			Assert: append(bson.D{
+				{"constraints.size", si.doc.Constraints.Size},
+			}, isAliveDoc...),
+			Update: bson.D{{"$set", bson.D{{"constraints.size", size}}}},
+		}, volumeOp}, nil
+	}
+	return im.mb.db().Run(buildTxn)
+}
+
+func notLargerError(size, current uint64) error {
+	return errors.NewNotValid(nil, fmt.Sprintf(
+		"new size %dMiB is not larger than current size %dMiB", size, current,
+	))
+}
+
+// storageVolumeToResize returns the volume to grow when resizing the
+// given storage instance: the volume assigned to block storage, or the
+// volume backing filesystem storage.
+func (im *IAASModel) storageVolumeToResize(si *storageInstance) (*volume, error) {
+	if si.Kind() == StorageKindBlock {
+		return im.storageInstanceVolume(si.StorageTag())
+	}
+	f, err := im.storageInstanceFilesystem(si.StorageTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeTag, err := f.Volume()
+	if err == ErrNoBackingVolume {
+		return nil, errors.NotSupportedf("resizing filesystem not backed by a volume")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return im.volumeByTag(volumeTag)
+}
@@ -732,6 +732,123 @@ func (s *StorageStateSuite) TestAttachStorageTakesOwnership(c *gc.C) {
 	c.Assert(owner, gc.Equals, u2.Tag())
 }
 
+func (s *StorageStateSuite) TestDetachStorageRecordsLastOwner(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+
+	// Detach, but do not destroy, the storage.
+	err := s.IAASModel.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	storageInstance, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, hasOwner := storageInstance.Owner()
+	c.Assert(hasOwner, jc.IsFalse)
+	lastOwner, hasLastOwner := storageInstance.LastOwner()
+	c.Assert(hasLastOwner, jc.IsTrue)
+	c.Assert(lastOwner, gc.Equals, u.Tag())
+	c.Assert(storageInstance.FilesystemLabel(), gc.Equals, "")
+}
+
+func (s *StorageStateSuite) TestDetachStorageRecordsFilesystemLabel(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	s.provisionStorageVolume(c, u, storageTag)
+	machine := unitMachine(c, s.State, u)
+	err := machine.SetMachineBlockDevices(state.BlockDeviceInfo{
+		DeviceName: "sdc",
+		Label:      "pgdata",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.IAASModel.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.RemoveStorageAttachment(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	storageInstance, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	lastOwner, hasLastOwner := storageInstance.LastOwner()
+	c.Assert(hasLastOwner, jc.IsTrue)
+	c.Assert(lastOwner, gc.Equals, u.Tag())
+	c.Assert(storageInstance.FilesystemLabel(), gc.Equals, "pgdata")
+}
+
+func (s *StorageStateSuite) TestValidateAttachStorage(c *gc.C) {
+	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Storage that is already attached to the unit is valid.
+	err = s.IAASModel.ValidateAttachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Storage owned by another unit cannot be attached.
+	err = s.IAASModel.ValidateAttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches,
+		`cannot attach storage data/0 to unit storage-block/1: cannot attach storage owned by unit storage-block/0 to unit storage-block/1`,
+	)
+
+	err = s.IAASModel.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.IAASModel.ValidateAttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Validating does not attach the storage.
+	storageInstance, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, hasOwner := storageInstance.Owner()
+	c.Assert(hasOwner, jc.IsFalse)
+}
+
+func (s *StorageStateSuite) TestAttachStorageKindMismatch(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.IAASModel.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.createStorageCharm(c, "storage-filesystem", charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageFilesystem,
+		CountMin: 0,
+		CountMax: 2,
+	})
+	app := s.AddTestingApplicationWithStorage(c, "storage-filesystem", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("rootfs", 1024, 0),
+	})
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expect := `cannot attach storage data/0 to unit storage-filesystem/0: ` +
+		`charm storage-filesystem storage "data" requires filesystem storage, storage data/0 is block storage`
+	err = s.IAASModel.ValidateAttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, expect)
+	err = s.IAASModel.AttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+func (s *StorageStateSuite) TestAttachStorageTooSmall(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.IAASModel.DetachStorage(storageTag, u.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.createStorageCharm(c, "storage-block-big", charm.Storage{
+		Name:        "data",
+		Type:        charm.StorageBlock,
+		CountMin:    0,
+		CountMax:    2,
+		MinimumSize: 2048,
+	})
+	app := s.AddTestingApplicationWithStorage(c, "storage-block-big", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("modelscoped", 2048, 0),
+	})
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.IAASModel.ValidateAttachStorage(storageTag, u2.UnitTag())
+	c.Assert(err, gc.ErrorMatches,
+		`cannot attach storage data/0 to unit storage-block-big/0: `+
+			`charm storage-block-big storage "data": minimum storage size is 2.0 GB, storage data/0 is 1.0 GB`,
+	)
+}
+
 func (s *StorageStateSuite) TestAttachStorageAssignedMachine(c *gc.C) {
 	app, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
 	u2, err := app.AddUnit(state.AddUnitParams{})
@@ -1364,3 +1481,18 @@ func (s *StorageSubordinateStateSuite) TestSubordinateStoragePrincipalAssignRace
 	c.Assert(err, jc.ErrorIsNil)
 	_ = s.storageInstanceFilesystem(c, names.NewStorageTag("data/0"))
 }
+
+func (s *StorageStateSuite) TestStoragePoolInUse(c *gc.C) {
+	inUse, err := s.IAASModel.StoragePoolInUse("loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inUse, jc.IsFalse)
+
+	s.setupSingleStorage(c, "block", "loop-pool")
+	inUse, err = s.IAASModel.StoragePoolInUse("loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inUse, jc.IsTrue)
+
+	inUse, err = s.IAASModel.StoragePoolInUse("persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inUse, jc.IsFalse)
+}
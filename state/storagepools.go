@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// cloudStoragePoolsGlobalKeyPrefix returns the prefix of the keys
+// under which the default storage pools for a cloud are stored in
+// the global settings collection.
+func cloudStoragePoolsGlobalKeyPrefix(cloud string) string {
+	return "cloudstoragepools#" + cloud + "#"
+}
+
+// CloudStoragePoolSettings is a poolmanager.SettingsManager for the
+// default storage pools of a cloud, which are inherited by all models
+// on that cloud.
+type CloudStoragePoolSettings struct {
+	st     *State
+	prefix string
+}
+
+// NewCloudStoragePoolSettings returns a CloudStoragePoolSettings for
+// the named cloud.
+func NewCloudStoragePoolSettings(st *State, cloud string) *CloudStoragePoolSettings {
+	return &CloudStoragePoolSettings{
+		st:     st,
+		prefix: cloudStoragePoolsGlobalKeyPrefix(cloud),
+	}
+}
+
+// CreateSettings is part of the poolmanager.SettingsManager interface.
+func (s *CloudStoragePoolSettings) CreateSettings(key string, settings map[string]interface{}) error {
+	_, err := createSettings(s.st.db(), globalSettingsC, s.prefix+key, settings)
+	return err
+}
+
+// ReadSettings is part of the poolmanager.SettingsManager interface.
+func (s *CloudStoragePoolSettings) ReadSettings(key string) (map[string]interface{}, error) {
+	settings, err := readSettings(s.st.db(), globalSettingsC, s.prefix+key)
+	if err != nil {
+		return nil, err
+	}
+	return settings.Map(), nil
+}
+
+// ReplaceSettings is part of the poolmanager.SettingsManager interface.
+func (s *CloudStoragePoolSettings) ReplaceSettings(key string, settings map[string]interface{}) error {
+	return replaceSettings(s.st.db(), globalSettingsC, s.prefix+key, settings)
+}
+
+// RemoveSettings is part of the poolmanager.SettingsManager interface.
+func (s *CloudStoragePoolSettings) RemoveSettings(key string) error {
+	return removeSettings(s.st.db(), globalSettingsC, s.prefix+key)
+}
+
+// ListSettings is part of the poolmanager.SettingsManager interface.
+func (s *CloudStoragePoolSettings) ListSettings(keyPrefix string) (map[string]map[string]interface{}, error) {
+	settings, closer := s.st.db().GetCollection(globalSettingsC)
+	defer closer()
+
+	var matchingSettings []settingsDoc
+	findExpr := "^" + regexp.QuoteMeta(s.prefix+keyPrefix)
+	if err := settings.Find(bson.D{{"_id", bson.D{{"$regex", findExpr}}}}).All(&matchingSettings); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]map[string]interface{})
+	for _, doc := range matchingSettings {
+		result[strings.TrimPrefix(doc.DocID, s.prefix)] = doc.Settings
+	}
+	return result, nil
+}
+
+// NewStoragePoolSettings returns a poolmanager.SettingsManager for the
+// storage pools of the model, which inherits the default storage
+// pools of the model's cloud. Pools created in the model override
+// inherited pools with the same name.
+func NewStoragePoolSettings(st *State) (poolmanager.SettingsManager, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return poolmanager.InheritedSettings{
+		Settings: NewStateSettings(st),
+		Defaults: NewCloudStoragePoolSettings(st, model.Cloud()),
+	}, nil
+}
+
+// StoragePoolInUse reports whether any of the model's volumes or
+// filesystems were, or are to be, provisioned from the named storage
+// pool, or whether any application's storage constraints refer to it.
+func (im *IAASModel) StoragePoolInUse(name string) (bool, error) {
+	poolQuery := bson.D{{"$or", []bson.D{
+		{{"params.pool", name}},
+		{{"info.pool", name}},
+	}}}
+	for _, collName := range []string{volumesC, filesystemsC} {
+		coll, closer := im.mb.db().GetCollection(collName)
+		n, err := coll.Find(poolQuery).Count()
+		closer()
+		if err != nil {
+			return false, errors.Annotatef(err, "querying %s", collName)
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+
+	coll, closer := im.mb.db().GetCollection(storageConstraintsC)
+	defer closer()
+	var doc storageConstraintsDoc
+	iter := coll.Find(nil).Iter()
+	for iter.Next(&doc) {
+		for _, cons := range doc.Constraints {
+			if cons.Pool == name {
+				iter.Close()
+				return true, nil
+			}
+		}
+	}
+	return false, errors.Annotate(iter.Close(), "querying storage constraints")
+}
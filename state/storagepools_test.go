@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type StoragePoolsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&StoragePoolsSuite{})
+
+func (s *StoragePoolsSuite) TestCloudStoragePoolSettings(c *gc.C) {
+	settings := state.NewCloudStoragePoolSettings(s.State, "dummy")
+	err := settings.CreateSettings("pool#fast", map[string]interface{}{"name": "fast", "type": "loop"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	read, err := settings.ReadSettings("pool#fast")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, map[string]interface{}{"name": "fast", "type": "loop"})
+
+	list, err := settings.ListSettings("pool#")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, jc.DeepEquals, map[string]map[string]interface{}{
+		"pool#fast": {"name": "fast", "type": "loop"},
+	})
+
+	// Pools of other clouds are not visible.
+	other := state.NewCloudStoragePoolSettings(s.State, "other")
+	list, err = other.ListSettings("pool#")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 0)
+
+	err = settings.RemoveSettings("pool#fast")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = settings.ReadSettings("pool#fast")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StoragePoolsSuite) TestCloudStoragePoolSettingsReplace(c *gc.C) {
+	settings := state.NewCloudStoragePoolSettings(s.State, "dummy")
+	err := settings.CreateSettings("pool#fast", map[string]interface{}{"name": "fast", "type": "loop", "foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = settings.ReplaceSettings("pool#fast", map[string]interface{}{"name": "fast", "type": "loop", "baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+	read, err := settings.ReadSettings("pool#fast")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, map[string]interface{}{"name": "fast", "type": "loop", "baz": "qux"})
+
+	err = settings.ReplaceSettings("pool#missing", map[string]interface{}{"name": "missing", "type": "loop"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StoragePoolsSuite) TestStoragePoolSettingsInheritsCloudPools(c *gc.C) {
+	cloudSettings := state.NewCloudStoragePoolSettings(s.State, "dummy")
+	err := cloudSettings.CreateSettings("pool#fast", map[string]interface{}{"name": "fast", "type": "loop", "foo": "cloud"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := state.NewStoragePoolSettings(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	read, err := settings.ReadSettings("pool#fast")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read["foo"], gc.Equals, "cloud")
+
+	err = settings.CreateSettings("pool#fast", map[string]interface{}{"name": "fast", "type": "loop", "foo": "model"})
+	c.Assert(err, jc.ErrorIsNil)
+	read, err = settings.ReadSettings("pool#fast")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read["foo"], gc.Equals, "model")
+
+	// The cloud's default pool is unchanged.
+	read, err = cloudSettings.ReadSettings("pool#fast")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read["foo"], gc.Equals, "cloud")
+}
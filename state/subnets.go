@@ -230,6 +230,40 @@ func (s *Subnet) Refresh() error {
 	return nil
 }
 
+// MoveToSpace moves the subnet into the space with the given name.
+// FAN overlay subnets cannot be moved, as they always inherit the
+// space of their underlay subnet.
+func (s *Subnet) MoveToSpace(spaceName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot move subnet %q to space %q", s, spaceName)
+	if s.doc.FanLocalUnderlay != "" {
+		return errors.Errorf("FAN subnet space is always inherited from underlay")
+	}
+	ops := []txn.Op{{
+		C:      spacesC,
+		Id:     spaceName,
+		Assert: isAliveDoc,
+	}, {
+		C:      subnetsC,
+		Id:     s.doc.DocID,
+		Assert: bson.D{{"fan-local-underlay", bson.D{{"$exists", false}}}},
+		Update: bson.D{{"$set", bson.D{{"space-name", spaceName}}}},
+	}}
+	if err := s.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		if _, err := s.st.Space(spaceName); err != nil {
+			return errors.Trace(err)
+		}
+		if err := s.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Errorf("space %q is not alive", spaceName)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	s.doc.SpaceName = spaceName
+	s.spaceName = spaceName
+	return nil
+}
+
 // AddSubnet creates and returns a new subnet
 func (st *State) AddSubnet(args SubnetInfo) (subnet *Subnet, err error) {
 	defer errors.DeferredAnnotatef(&err, "adding subnet %q", args.CIDR)
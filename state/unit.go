@@ -92,6 +92,10 @@ type unitDoc struct {
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
+
+	// StopDeadline is set when the unit is destroyed, if it must stop
+	// within a grace period; see Unit.StopDeadline.
+	StopDeadline *time.Time `bson:"stopdeadline,omitempty"`
 }
 
 // Unit represents the state of a service unit.
@@ -133,6 +137,28 @@ func (u *Unit) ConfigSettings() (charm.Settings, error) {
 	for name, value := range settings.Map() {
 		result[name] = value
 	}
+	// A unit assigned to a branch sees the config changes staged in
+	// the branch for its application.
+	branch, err := u.st.unitBranch(u.doc.Application, u.doc.Name)
+	if err != nil {
+		return nil, err
+	}
+	if branch != nil {
+		defaults := chrm.Config().DefaultSettings()
+		for name, value := range branch.doc.Config[u.doc.Application] {
+			if _, ok := chrm.Config().Options[name]; !ok {
+				continue
+			}
+			if value == nil {
+				value = defaults[name]
+			}
+			if value == nil {
+				delete(result, name)
+			} else {
+				result[name] = value
+			}
+		}
+	}
 	return result, nil
 }
 
@@ -311,6 +337,17 @@ func (u *Unit) Destroy() (err error) {
 	return u.st.ApplyOperation(u.DestroyOperation())
 }
 
+// StopDeadline returns the time by which the dying unit must have stopped,
+// after which its stop hook is abandoned and the teardown of its machine
+// proceeds regardless. It returns false if the unit has no deadline, in
+// which case it waits for its stop hook to succeed.
+func (u *Unit) StopDeadline() (time.Time, bool) {
+	if u.doc.StopDeadline == nil {
+		return time.Time{}, false
+	}
+	return *u.doc.StopDeadline, true
+}
+
 // DestroyOperation returns a model operation that will destroy the unit.
 func (u *Unit) DestroyOperation() *DestroyUnitOperation {
 	return &DestroyUnitOperation{unit: &Unit{st: u.st, doc: u.doc}}
@@ -325,6 +362,11 @@ type DestroyUnitOperation struct {
 	// to the unit is destroyed. If this is false, then detachable
 	// storage will be detached and left in the model.
 	DestroyStorage bool
+
+	// Force controls whether or not the application's stop grace
+	// period is overridden, so that the unit does not wait for its
+	// stop hook before its machine is torn down.
+	Force bool
 }
 
 // Build is part of the ModelOperation interface.
@@ -336,7 +378,7 @@ func (op *DestroyUnitOperation) Build(attempt int) ([]txn.Op, error) {
 			return nil, err
 		}
 	}
-	switch ops, err := op.unit.destroyOps(op.DestroyStorage); err {
+	switch ops, err := op.unit.destroyOps(op.DestroyStorage, op.Force); err {
 	case errRefresh:
 	case errAlreadyDying:
 		return nil, jujutxn.ErrNoOperations
@@ -369,13 +411,19 @@ func (u *Unit) eraseHistory() error {
 	if err := eraseStatusHistory(u.st, u.globalWorkloadVersionKey()); err != nil {
 		return errors.Annotate(err, "version")
 	}
+	if err := eraseStatusHistory(u.st, unitPayloadsGlobalKey(u.doc.Name)); err != nil {
+		return errors.Annotate(err, "payloads")
+	}
+	if err := eraseHealthCheckHistory(u.st, u.doc.Name); err != nil {
+		return errors.Annotate(err, "health checks")
+	}
 	return nil
 }
 
 // destroyOps returns the operations required to destroy the unit. If it
 // returns errRefresh, the unit should be refreshed and the destruction
 // operations recalculated.
-func (u *Unit) destroyOps(destroyStorage bool) ([]txn.Op, error) {
+func (u *Unit) destroyOps(destroyStorage, force bool) ([]txn.Op, error) {
 	if u.doc.Life != Alive {
 		return nil, errAlreadyDying
 	}
@@ -403,11 +451,18 @@ func (u *Unit) destroyOps(destroyStorage bool) ([]txn.Op, error) {
 	// its own CL.
 	minUnitsOp := minUnitsTriggerOp(u.st, u.ApplicationName())
 	cleanupOp := newCleanupOp(cleanupDyingUnit, u.doc.Name, destroyStorage)
+	setDying := bson.D{{"life", Dying}}
+	deadline, ok, err := u.newStopDeadline(force)
+	if err != nil {
+		return nil, errors.Trace(err)
+	} else if ok {
+		setDying = append(setDying, bson.DocElem{"stopdeadline", deadline})
+	}
 	setDyingOp := txn.Op{
 		C:      unitsC,
 		Id:     u.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		Update: bson.D{{"$set", setDying}},
 	}
 	setDyingOps := []txn.Op{setDyingOp, cleanupOp, minUnitsOp}
 	if u.doc.Principal != "" {
@@ -459,6 +514,26 @@ func (u *Unit) destroyOps(destroyStorage bool) ([]txn.Op, error) {
 	return append(ops, removeOps...), nil
 }
 
+// newStopDeadline returns the time by which the unit, if destroyed now,
+// must have stopped. If force is true the application's stop grace period
+// is overridden and the deadline is now; otherwise there is no deadline
+// unless the application has a stop grace period.
+func (u *Unit) newStopDeadline(force bool) (time.Time, bool, error) {
+	now := u.st.clock().Now()
+	if force {
+		return now, true, nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return time.Time{}, false, errors.Trace(err)
+	}
+	period := app.StopGracePeriod()
+	if period == 0 {
+		return time.Time{}, false, nil
+	}
+	return now.Add(period), true, nil
+}
+
 // destroyHostOps returns all necessary operations to destroy the service unit's host machine,
 // or ensure that the conditions preventing its destruction remain stable through the transaction.
 func (u *Unit) destroyHostOps(a *Application) (ops []txn.Op, err error) {
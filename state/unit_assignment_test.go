@@ -102,3 +102,30 @@ func (s *UnitAssignmentSuite) TestAssignUnitWithPlacementMakesContainerInNewMach
 	_, err = s.State.Machine(parentId)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *UnitAssignmentSuite) TestAssignUnitWithAffinityPlacement(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	placement := instance.Placement{Scope: instance.AntiAffinityScope, Directive: "dummy"}
+	svc, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:      "dummy",
+		Charm:     charm,
+		NumUnits:  1,
+		Placement: []*instance.Placement{&placement},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := svc.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	unit := units[0]
+
+	err = s.State.AssignUnitWithPlacement(unit, &placement)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The new machine records the directive in full, for the
+	// provisioner to interpret.
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.Placement(), gc.Equals, "anti-affinity:dummy")
+}
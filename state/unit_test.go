@@ -705,6 +705,60 @@ func (s *UnitSuite) TestDestroySetStatusRetry(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+// startUnitAgent assigns the unit to a machine and sets its agent
+// status, so that destroying it leaves it Dying.
+func (s *UnitSuite) startUnitAgent(c *gc.C) {
+	err := s.unit.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	now := coretesting.NonZeroTime()
+	err = s.unit.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitSuite) TestDestroyNoStopDeadline(c *gc.C) {
+	s.startUnitAgent(c)
+	err := s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.unit.StopDeadline()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *UnitSuite) TestDestroyStopDeadline(c *gc.C) {
+	s.startUnitAgent(c)
+	err := s.service.SetStopGracePeriod(5 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	assertLife(c, s.unit, state.Dying)
+	deadline, ok := s.unit.StopDeadline()
+	c.Assert(ok, jc.IsTrue)
+	expect := s.Clock.Now().Add(5 * time.Minute)
+	c.Assert(deadline.Truncate(time.Millisecond).Equal(expect.Truncate(time.Millisecond)), jc.IsTrue)
+}
+
+func (s *UnitSuite) TestDestroyForceStopDeadline(c *gc.C) {
+	s.startUnitAgent(c)
+	err := s.service.SetStopGracePeriod(5 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	op := s.unit.DestroyOperation()
+	op.Force = true
+	err = s.State.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	deadline, ok := s.unit.StopDeadline()
+	c.Assert(ok, jc.IsTrue)
+	expect := s.Clock.Now()
+	c.Assert(deadline.Truncate(time.Millisecond).Equal(expect.Truncate(time.Millisecond)), jc.IsTrue)
+}
+
 func (s *UnitSuite) TestDestroySetCharmRetry(c *gc.C) {
 	defer state.SetRetryHooks(c, s.State, func() {
 		err := s.unit.SetCharmURL(s.charm.URL())
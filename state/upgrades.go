@@ -1261,3 +1261,33 @@ func migrateModelLeasesToGlobalTime(st *State) error {
 	}
 	return st.db().RunTransaction(ops)
 }
+
+// ConvertAuditLogToCapped converts an audit log collection created
+// before it was capped into a capped collection, so that its oldest
+// entries are discarded once it is full. Only the most recent entries
+// that fit within the cap are kept.
+func ConvertAuditLogToCapped(st *State) error {
+	db := st.MongoSession().DB(jujuDB)
+	names, err := db.CollectionNames()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !set.NewStrings(names...).Contains(auditingC) {
+		// It will be created capped.
+		return nil
+	}
+	var stats struct {
+		Capped bool `bson:"capped"`
+	}
+	if err := db.Run(bson.D{{"collStats", auditingC}}, &stats); err != nil {
+		return errors.Annotate(err, "reading audit log stats")
+	}
+	if stats.Capped {
+		return nil
+	}
+	err = db.Run(bson.D{
+		{"convertToCapped", auditingC},
+		{"size", auditLogSize},
+	}, nil)
+	return errors.Annotate(err, "converting audit log to capped collection")
+}
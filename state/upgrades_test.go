@@ -1796,6 +1796,43 @@ func (s *upgradesSuite) TestAddModelType(c *gc.C) {
 		expectUpgradedData{models, expectedModels})
 }
 
+func (s *upgradesSuite) TestConvertAuditLogToCapped(c *gc.C) {
+	db := s.state.MongoSession().DB(jujuDB)
+	coll := db.C(auditingC)
+	// Replace the audit log with one created before it was capped.
+	err := coll.DropCollection()
+	c.Assert(err, jc.ErrorIsNil)
+	err = coll.Insert(bson.M{"operation": "one"}, bson.M{"operation": "two"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	isCapped := func() bool {
+		var stats struct {
+			Capped bool `bson:"capped"`
+		}
+		err := db.Run(bson.D{{"collStats", auditingC}}, &stats)
+		c.Assert(err, jc.ErrorIsNil)
+		return stats.Capped
+	}
+	c.Assert(isCapped(), jc.IsFalse)
+
+	// The step can be run repeatedly.
+	for i := 0; i < 2; i++ {
+		err = ConvertAuditLogToCapped(s.state)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(isCapped(), jc.IsTrue)
+		count, err := coll.Count()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(count, gc.Equals, 2)
+	}
+}
+
+func (s *upgradesSuite) TestConvertAuditLogToCappedNoCollection(c *gc.C) {
+	err := s.state.MongoSession().DB(jujuDB).C(auditingC).DropCollection()
+	c.Assert(err, jc.ErrorIsNil)
+	err = ConvertAuditLogToCapped(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *upgradesSuite) checkAddPruneSettings(c *gc.C, ageProp, sizeProp, defaultAge, defaultSize string, updateFunc func(st *State) error) {
 	settingsColl, settingsCloser := s.state.db().GetRawCollection(settingsC)
 	defer settingsCloser()
@@ -53,6 +53,11 @@ type Volume interface {
 	// Releasing reports whether or not the volume is to be released
 	// from the model when it is Dying/Dead.
 	Releasing() bool
+
+	// PendingSize returns the size, in MiB, that the volume is to be
+	// grown to. PendingSize returns true if a resize has been requested
+	// and has not yet been completed, otherwise false.
+	PendingSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -99,6 +104,11 @@ type volumeDoc struct {
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
 
+	// PendingSize is the size, in MiB, that a provisioned volume
+	// is to be grown to. It is unset once the volume has been
+	// resized.
+	PendingSize uint64 `bson:"pendingsize,omitempty"`
+
 	// MachineId is the ID of the machine that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the volume as being non-detachable, and to determine
@@ -212,6 +222,11 @@ func (v *volume) Releasing() bool {
 	return v.doc.Releasing
 }
 
+// PendingSize is required to implement Volume.
+func (v *volume) PendingSize() (uint64, bool) {
+	return v.doc.PendingSize, v.doc.PendingSize > 0
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.im.VolumeStatus(v.VolumeTag())
@@ -896,6 +911,74 @@ func (im *IAASModel) validateVolumeParams(params VolumeParams, machineId string)
 	return machineId, nil
 }
 
+// AddExistingVolume imports an existing, already-provisioned
+// volume into the model. The volume will start out with the
+// status "detached". The volume will be associated with the
+// given storage name, with the allocated storage tag being
+// returned.
+func (im *IAASModel) AddExistingVolume(info VolumeInfo, storageName string) (_ names.StorageTag, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add existing volume")
+	if err := validateAddExistingVolume(im, info, storageName); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageId, err := newStorageInstanceId(im.mb, storageName)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	storageTag := names.NewStorageTag(storageId)
+	volumeOps, _, err := im.addVolumeOps(
+		VolumeParams{
+			Pool:       info.Pool,
+			Size:       info.Size,
+			volumeInfo: &info,
+			storage:    storageTag,
+		},
+		"", // no machine ID
+	)
+	if err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	ops := []txn.Op{{
+		C:      storageInstancesC,
+		Id:     storageId,
+		Assert: txn.DocMissing,
+		Insert: &storageInstanceDoc{
+			Id:          storageId,
+			Kind:        StorageKindBlock,
+			StorageName: storageName,
+			Constraints: storageInstanceConstraints{
+				Pool: info.Pool,
+				Size: info.Size,
+			},
+		},
+	}}
+	ops = append(ops, volumeOps...)
+	if err := im.mb.db().RunTransaction(ops); err != nil {
+		return names.StorageTag{}, errors.Trace(err)
+	}
+	return storageTag, nil
+}
+
+func validateAddExistingVolume(im *IAASModel, info VolumeInfo, storageName string) error {
+	if !storage.IsValidPoolName(info.Pool) {
+		return errors.NotValidf("pool name %q", info.Pool)
+	}
+	if !storageNameRE.MatchString(storageName) {
+		return errors.NotValidf("storage name %q", storageName)
+	}
+	if info.VolumeId == "" {
+		return errors.NotValidf("empty volume ID")
+	}
+	_, provider, err := poolStorageProvider(im, info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !provider.Supports(storage.StorageKindBlock) {
+		return errors.NotSupportedf("volumes in pool %q", info.Pool)
+	}
+	return nil
+}
+
 // volumeAttachmentId returns a volume attachment document ID,
 // given the corresponding volume name and machine ID.
 func volumeAttachmentId(machineId, volumeName string) string {
@@ -1070,6 +1153,15 @@ func (im *IAASModel) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err er
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		if size, ok := v.PendingSize(); ok && info.Size >= size {
+			// The volume has been grown to the requested size.
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"pendingsize", size}},
+				Update: bson.D{{"$unset", bson.D{{"pendingsize", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return im.mb.db().Run(buildTxn)
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
@@ -945,6 +946,62 @@ func (s *VolumeStateSuite) TestVolumeBindingStorage(c *gc.C) {
 	c.Assert(volume.Life(), gc.Equals, state.Dying)
 }
 
+func (s *VolumeStateSuite) TestAddExistingVolume(c *gc.C) {
+	volInfoIn := state.VolumeInfo{
+		Pool:       "modelscoped",
+		Size:       123,
+		VolumeId:   "foo",
+		Persistent: true,
+	}
+	storageTag, err := s.IAASModel.AddExistingVolume(volInfoIn, "pgdata")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageTag, gc.Equals, names.NewStorageTag("pgdata/0"))
+
+	storageInstance, err := s.IAASModel.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstance.Kind(), gc.Equals, state.StorageKindBlock)
+
+	volume, err := s.IAASModel.StorageInstanceVolume(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volInfoOut, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volInfoOut, jc.DeepEquals, volInfoIn)
+
+	volStatus, err := volume.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volStatus.Status, gc.Equals, status.Detached)
+}
+
+func (s *VolumeStateSuite) TestAddExistingVolumeEmptyVolumeId(c *gc.C) {
+	volInfoIn := state.VolumeInfo{
+		Pool: "modelscoped",
+		Size: 123,
+	}
+	_, err := s.IAASModel.AddExistingVolume(volInfoIn, "pgdata")
+	c.Assert(err, gc.ErrorMatches, "cannot add existing volume: empty volume ID not valid")
+}
+
+func (s *VolumeStateSuite) TestAddExistingVolumeInvalidStorageName(c *gc.C) {
+	volInfoIn := state.VolumeInfo{
+		Pool:     "modelscoped",
+		Size:     123,
+		VolumeId: "foo",
+	}
+	_, err := s.IAASModel.AddExistingVolume(volInfoIn, "PG_DATA")
+	c.Assert(err, gc.ErrorMatches, `cannot add existing volume: storage name "PG_DATA" not valid`)
+}
+
+func (s *VolumeStateSuite) TestAddExistingVolumeUnsupportedPool(c *gc.C) {
+	volInfoIn := state.VolumeInfo{
+		Pool:     "rootfs",
+		Size:     123,
+		VolumeId: "foo",
+	}
+	_, err := s.IAASModel.AddExistingVolume(volInfoIn, "pgdata")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `cannot add existing volume: volumes in pool "rootfs" not supported`)
+}
+
 func (s *VolumeStateSuite) setupStorageVolumeAttachment(c *gc.C) (state.Volume, *state.Machine, *state.Unit) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "modelscoped")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
@@ -998,3 +1055,80 @@ func removeStorageInstance(c *gc.C, im *state.IAASModel, storageTag names.Storag
 	_, err = im.StorageInstance(storageTag)
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *VolumeStateSuite) TestResizeStorage(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.IAASModel.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.IAASModel.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	size, ok := s.volume(c, volumeTag).PendingSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	// Setting info with the requested size completes the resize.
+	err = s.IAASModel.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size: 2048, VolumeId: "vol-ume", Pool: "loop-pool",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.volume(c, volumeTag).PendingSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *VolumeStateSuite) TestResizeStorageUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.IAASModel.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Size, gc.Equals, uint64(2048))
+	_, ok = volume.PendingSize()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *VolumeStateSuite) TestResizeStorageNotLarger(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.IAASModel.ResizeStorage(storageTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage "data/0": new size 1024MiB is not larger than current size 1024MiB`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *VolumeStateSuite) TestResizeStorageFilesystemNotVolumeBacked(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.IAASModel.ResizeStorage(storageTag, 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize storage "data/0": resizing filesystem not backed by a volume not supported`)
+}
+
+func (s *VolumeStateSuite) TestWatchMachineVolumeResizes(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.IAASModel.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 1024, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.IAASModel.WatchMachineVolumeResizes(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0/0") // initial
+	wc.AssertNoChange()
+
+	err = s.IAASModel.ResizeStorage(storageTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0")
+	wc.AssertNoChange()
+}
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -270,6 +271,36 @@ func (im *IAASModel) watchMachineStorage(m names.MachineTag, collection string)
 	return newLifecycleWatcher(mb, collection, members, filter, nil)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to model-scoped volumes, including requests to resize them.
+func (im *IAASModel) WatchModelVolumeResizes() StringsWatcher {
+	mb := im.mb
+	filter := func(id interface{}) bool {
+		k, err := mb.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newCollectionWatcher(mb, colWCfg{col: volumesC, filter: filter})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to volumes scoped to the specified machine, including
+// requests to resize them.
+func (im *IAASModel) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	mb := im.mb
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := mb.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix)
+	}
+	return newCollectionWatcher(mb, colWCfg{col: volumesC, filter: filter})
+}
+
 // WatchModelVolumeAttachments returns a StringsWatcher that notifies of
 // changes to the lifecycles of all volume attachments related to environ-
 // scoped volumes.
@@ -1526,6 +1557,13 @@ func (a *Application) WatchLeaderSettings() NotifyWatcher {
 	return newEntityWatcher(a.st, settingsC, docId)
 }
 
+// WatchLeader returns a watcher for observing changes to an
+// application's leader. Renewals of the current leader's lease are
+// not reported.
+func (a *Application) WatchLeader() NotifyWatcher {
+	return newLeaderWatcher(a.st, a.Name())
+}
+
 // Watch returns a watcher for observing changes to a unit.
 func (u *Unit) Watch() NotifyWatcher {
 	return newEntityWatcher(u.st, unitsC, u.doc.DocID)
@@ -1594,7 +1632,8 @@ func (im *IAASModel) WatchFilesystemAttachment(m names.MachineTag, f names.Files
 }
 
 // WatchConfigSettings returns a watcher for observing changes to the
-// unit's service configuration settings. The unit must have a charm URL
+// unit's service configuration settings, including the changes staged
+// in any branch the unit is assigned to. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
 // valid only while the unit's charm URL is not changed.
 // TODO(fwereade): this could be much smarter; if it were, uniter.Filter
@@ -1604,7 +1643,121 @@ func (u *Unit) WatchConfigSettings() (NotifyWatcher, error) {
 		return nil, fmt.Errorf("unit charm not set")
 	}
 	settingsKey := applicationSettingsKey(u.doc.Application, u.doc.CharmURL)
-	return newEntityWatcher(u.st, settingsC, u.st.docID(settingsKey)), nil
+	return newUnitConfigWatcher(u.st, u.st.docID(settingsKey), u.doc.Application, u.doc.Name), nil
+}
+
+// unitConfigWatcher notifies of changes to a unit's application
+// settings, and to the branches that the unit is or was assigned to.
+type unitConfigWatcher struct {
+	commonWatcher
+	settingsDocID string
+	appName       string
+	unitName      string
+	out           chan struct{}
+}
+
+var _ Watcher = (*unitConfigWatcher)(nil)
+
+func newUnitConfigWatcher(backend modelBackend, settingsDocID, appName, unitName string) NotifyWatcher {
+	w := &unitConfigWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		settingsDocID: settingsDocID,
+		appName:       appName,
+		unitName:      unitName,
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for the unitConfigWatcher.
+func (w *unitConfigWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+// assignedToBranch reports whether the unit is assigned to the branch
+// with the given document id.
+func (w *unitConfigWatcher) assignedToBranch(docID string) (bool, error) {
+	branches, closer := w.db.GetCollection(branchesC)
+	defer closer()
+	n, err := branches.Find(bson.D{
+		{"_id", docID},
+		{"assigned-units." + w.appName, w.unitName},
+	}).Count()
+	return n > 0, err
+}
+
+func (w *unitConfigWatcher) loop() error {
+	in := make(chan watcher.Change)
+	settings, closer := w.db.GetCollection(settingsC)
+	txnRevno, err := getTxnRevno(settings, w.settingsDocID)
+	closer()
+	if err != nil {
+		return err
+	}
+	w.watcher.Watch(settingsC, w.settingsDocID, txnRevno, in)
+	defer w.watcher.Unwatch(settingsC, w.settingsDocID, in)
+
+	// Remember the branches the unit is assigned to, so that we
+	// notice when one is committed or aborted.
+	assigned := make(map[string]bool)
+	branches, closer := w.db.GetCollection(branchesC)
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	err = branches.Find(bson.D{{"assigned-units." + w.appName, w.unitName}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	closer()
+	if err != nil {
+		return err
+	}
+	for _, doc := range docs {
+		assigned[doc.DocID] = true
+	}
+	filter := func(id interface{}) bool {
+		docID, ok := id.(string)
+		if !ok {
+			return false
+		}
+		if _, err := w.backend.strictLocalID(docID); err != nil {
+			return false
+		}
+		isAssigned, err := w.assignedToBranch(docID)
+		if err != nil {
+			// Err on the side of notifying; the config is
+			// read again in response.
+			return true
+		}
+		wasAssigned := assigned[docID]
+		if isAssigned {
+			assigned[docID] = true
+		} else {
+			delete(assigned, docID)
+		}
+		return isAssigned || wasAssigned
+	}
+	w.watcher.WatchCollectionWithFilter(branchesC, in, filter)
+	defer w.watcher.UnwatchCollection(branchesC, in)
+
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-in:
+			if _, ok := collect(ch, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
 }
 
 // WatchMeterStatus returns a watcher observing changes that affect the meter status
@@ -1621,6 +1774,47 @@ func (u *Unit) WatchMeterStatus() NotifyWatcher {
 	})
 }
 
+// WatchStatus returns a watcher for observing changes to the
+// application's status.
+func (a *Application) WatchStatus() NotifyWatcher {
+	return newEntityWatcher(a.st, statusesC, a.st.docID(a.globalKey()))
+}
+
+// WatchConfigSettings returns a watcher for observing changes to the
+// application's configuration settings. The returned watcher will be
+// valid only while the application's charm URL is not changed.
+func (a *Application) WatchConfigSettings() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.settingsKey()))
+}
+
+// WatchStatus returns a watcher for observing changes to the unit's
+// workload and agent status.
+func (u *Unit) WatchStatus() NotifyWatcher {
+	return newDocWatcher(u.st, []docKey{
+		{
+			statusesC,
+			u.st.docID(u.globalKey()),
+		}, {
+			statusesC,
+			u.st.docID(u.globalAgentKey()),
+		},
+	})
+}
+
+// WatchStatus returns a watcher for observing changes to the machine's
+// agent and instance status.
+func (m *Machine) WatchStatus() NotifyWatcher {
+	return newDocWatcher(m.st, []docKey{
+		{
+			statusesC,
+			m.st.docID(m.globalKey()),
+		}, {
+			statusesC,
+			m.st.docID(m.globalInstanceKey()),
+		},
+	})
+}
+
 func newEntityWatcher(backend modelBackend, collName string, key interface{}) NotifyWatcher {
 	return newDocWatcher(backend, []docKey{{collName, key}})
 }
@@ -1709,6 +1903,95 @@ func (w *docWatcher) loop(docKeys []docKey) error {
 	}
 }
 
+// leaderWatcher notifies about changes to the unit holding an
+// application's leadership lease.
+type leaderWatcher struct {
+	commonWatcher
+	docId string
+	out   chan struct{}
+}
+
+var _ Watcher = (*leaderWatcher)(nil)
+
+func newLeaderWatcher(backend modelBackend, applicationName string) NotifyWatcher {
+	w := &leaderWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		docId:         leadershipLeaseKey(applicationName),
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for the leaderWatcher.
+func (w *leaderWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+// holder returns the name of the unit holding the leadership lease,
+// or the empty string if there is none.
+func (w *leaderWatcher) holder() (string, error) {
+	coll, closer := w.db.GetCollection(leasesC)
+	defer closer()
+
+	var doc struct {
+		Holder string `bson:"holder"`
+	}
+	err := coll.FindId(w.docId).Select(bson.D{{"holder", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return doc.Holder, nil
+}
+
+func (w *leaderWatcher) loop() error {
+	in := make(chan watcher.Change)
+	coll, closer := w.db.GetCollection(leasesC)
+	txnRevno, err := getTxnRevno(coll, w.docId)
+	collName := coll.Name()
+	closer()
+	if err != nil {
+		return err
+	}
+	docId := w.backend.docID(w.docId)
+	w.watcher.Watch(collName, docId, txnRevno, in)
+	defer w.watcher.Unwatch(collName, docId, in)
+
+	holder, err := w.holder()
+	if err != nil {
+		return err
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-in:
+			if _, ok := collect(ch, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			latest, err := w.holder()
+			if err != nil {
+				return err
+			}
+			if latest != holder {
+				holder = latest
+				out = w.out
+			}
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
 // machineUnitsWatcher notifies about assignments and lifecycle changes
 // for all units of a machine.
 //
@@ -2094,6 +2377,111 @@ func (w *actionStatusWatcher) filterAndMergeIds(changes *[]string, updates map[i
 	return nil
 }
 
+// actionLogsWatcher is a StringsWatcher that reports the messages
+// logged by an action.
+type actionLogsWatcher struct {
+	commonWatcher
+	docId string
+	sink  chan []string
+}
+
+var _ StringsWatcher = (*actionLogsWatcher)(nil)
+
+// WatchActionLogs starts and returns a StringsWatcher that notifies of
+// the messages logged by the action with the given id. Each message is
+// reported as a JSON encoded ActionMessage. The first event contains
+// all the messages logged so far.
+func (m *Model) WatchActionLogs(actionId string) StringsWatcher {
+	return newActionLogsWatcher(m.st, m.st.docID(actionId))
+}
+
+func newActionLogsWatcher(backend modelBackend, docId string) StringsWatcher {
+	w := &actionLogsWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		docId:         docId,
+		sink:          make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.sink)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *actionLogsWatcher) Changes() <-chan []string {
+	return w.sink
+}
+
+func (w *actionLogsWatcher) loop() error {
+	actions, closer := w.db.GetCollection(actionsC)
+	revno, err := getTxnRevno(actions, w.docId)
+	closer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	in := make(chan watcher.Change)
+	w.watcher.Watch(actionsC, w.docId, revno, in)
+	defer w.watcher.Unwatch(actionsC, w.docId, in)
+
+	changes, seen, err := w.messages(0)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.sink
+	for {
+		select {
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-in:
+			messages, total, err := w.messages(seen)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			seen = total
+			if len(messages) > 0 {
+				changes = append(changes, messages...)
+				out = w.sink
+			}
+		case out <- changes:
+			changes = nil
+			out = nil
+		}
+	}
+}
+
+// messages returns the JSON encoded messages logged by the action
+// after the first skip, along with the total number of messages
+// logged.
+func (w *actionLogsWatcher) messages(skip int) ([]string, int, error) {
+	actions, closer := w.db.GetCollection(actionsC)
+	defer closer()
+
+	var doc struct {
+		Logs []ActionMessage `bson:"logs"`
+	}
+	err := actions.FindId(w.docId).Select(bson.D{{"logs", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, skip, nil
+	} else if err != nil {
+		return nil, 0, errors.Trace(err)
+	}
+	var messages []string
+	for i := skip; i < len(doc.Logs); i++ {
+		message := doc.Logs[i]
+		message.Timestamp = message.Timestamp.UTC()
+		encoded, err := json.Marshal(message)
+		if err != nil {
+			return nil, 0, errors.Trace(err)
+		}
+		messages = append(messages, string(encoded))
+	}
+	return messages, len(doc.Logs), nil
+}
+
 // inCollectionOp takes a key name and a list of potential values and
 // returns a bson.D Op that will match on the supplied key and values.
 func inCollectionOp(key string, ids ...string) bson.D {
@@ -2590,6 +2978,88 @@ func (w *openedPortsWatcher) merge(ids set.Strings, change watcher.Change) error
 	return nil
 }
 
+// egressRulesWatcher notifies of changes in the egressRules
+// collection.
+type egressRulesWatcher struct {
+	commonWatcher
+	out chan []string
+}
+
+var _ Watcher = (*egressRulesWatcher)(nil)
+
+// WatchEgressRules starts and returns a StringsWatcher notifying of
+// changes to the egress rules of the model's applications. Reported
+// changes are the names of the applications whose rules have been
+// saved or removed.
+func (st *State) WatchEgressRules() StringsWatcher {
+	w := &egressRulesWatcher{
+		commonWatcher: newCommonWatcher(st),
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w
+func (w *egressRulesWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+func (w *egressRulesWatcher) initial() (set.Strings, error) {
+	coll, closer := w.db.GetCollection(egressRulesC)
+	defer closer()
+
+	ids := set.NewStrings()
+	var doc egressRuleDoc
+	iter := coll.Find(nil).Select(bson.D{{"_id", 1}}).Iter()
+	for iter.Next(&doc) {
+		id, err := w.backend.strictLocalID(doc.Id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids.Add(id)
+	}
+	return ids, errors.Trace(iter.Close())
+}
+
+func (w *egressRulesWatcher) loop() error {
+	in := make(chan watcher.Change)
+	changes, err := w.initial()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.watcher.WatchCollectionWithFilter(egressRulesC, in, isLocalID(w.backend))
+	defer w.watcher.UnwatchCollection(egressRulesC, in)
+
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-in:
+			id, ok := ch.Id.(string)
+			if !ok {
+				return errors.Errorf("id %v is not of type string, got %T", ch.Id, ch.Id)
+			}
+			localID, err := w.backend.strictLocalID(id)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			changes.Add(localID)
+			out = w.out
+		case out <- changes.SortedValues():
+			out = nil
+			changes = set.NewStrings()
+		}
+	}
+}
+
 // WatchForRebootEvent returns a notify watcher that will trigger an event
 // when the reboot flag is set on our machine agent, our parent machine agent
 // or grandparent machine agent
@@ -290,6 +290,24 @@ func ValidWorkloadStatus(status Status) bool {
 	}
 }
 
+// ValidCloudContainerStatus returns true if status has a valid value
+// (that is to say, a value that it's OK to set) for the containers
+// running units' workloads in Kubernetes models.
+func ValidCloudContainerStatus(status Status) bool {
+	switch status {
+	case
+		Waiting,
+		Blocked,
+		Running,
+		Error,
+		Terminated,
+		Unknown:
+		return true
+	default:
+		return false
+	}
+}
+
 // WorkloadMatches returns true if the candidate matches status,
 // taking into account that the candidate may be a legacy
 // status value which has been deprecated.
@@ -43,6 +43,40 @@ func (f *StatusHistoryFilter) Validate() error {
 	return nil
 }
 
+// StatusHistoryRange selects a page of the status history recorded
+// within a time range. Entries are selected in chronological order.
+type StatusHistoryRange struct {
+	// From is the time of the earliest entries expected.
+	From time.Time
+	// To, if not zero, is the time before which all entries are
+	// expected.
+	To time.Time
+	// After holds the cursor returned with the previous page, if any.
+	After string
+	// Limit is the maximum number of entries expected.
+	Limit int
+}
+
+// Validate checks that the range is well formed.
+func (r StatusHistoryRange) Validate() error {
+	if r.Limit <= 0 {
+		return errors.NotValidf("limit %d", r.Limit)
+	}
+	if !r.To.IsZero() && !r.To.After(r.From) {
+		return errors.NotValidf("time range ending before it starts")
+	}
+	return nil
+}
+
+// StatusHistoryPage holds a page of status history entries.
+type StatusHistoryPage struct {
+	// Statuses holds the entries, oldest first.
+	Statuses []DetailedStatus
+	// Next holds the cursor from which the following page can be
+	// selected; it is empty if there are no more entries in the range.
+	Next string
+}
+
 // StatusHistoryGetter instances can fetch their status history.
 type StatusHistoryGetter interface {
 	StatusHistory(filter StatusHistoryFilter) ([]StatusInfo, error)
@@ -168,6 +202,8 @@ const (
 	KindContainerInstance HistoryKind = "container"
 	// KindContainer represents an entry for a container agent.
 	KindContainer HistoryKind = "juju-container"
+	// KindPayload represents an entry for one of a unit's payloads.
+	KindPayload HistoryKind = "payload"
 )
 
 // String returns a string representation of the HistoryKind.
@@ -178,7 +214,7 @@ func (k HistoryKind) String() string {
 // Valid will return true if the current kind is a valid one.
 func (k HistoryKind) Valid() bool {
 	switch k {
-	case KindUnit, KindUnitAgent, KindWorkload,
+	case KindUnit, KindUnitAgent, KindWorkload, KindPayload,
 		KindMachineInstance, KindMachine,
 		KindContainerInstance, KindContainer:
 		return true
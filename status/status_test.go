@@ -106,3 +106,29 @@ func (h *statusHistorySuite) TestStatusSquashing(c *gc.C) {
 
 	c.Assert(newStatuses, gc.DeepEquals, expectedStatuses)
 }
+
+func (h *statusHistorySuite) TestStatusHistoryRangeValidate(c *gc.C) {
+	from := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	for i, test := range []struct {
+		r   status.StatusHistoryRange
+		err string
+	}{{
+		r: status.StatusHistoryRange{From: from, Limit: 10},
+	}, {
+		r: status.StatusHistoryRange{From: from, To: from.Add(time.Hour), Limit: 10},
+	}, {
+		r:   status.StatusHistoryRange{From: from},
+		err: "limit 0 not valid",
+	}, {
+		r:   status.StatusHistoryRange{From: from, To: from, Limit: 10},
+		err: "time range ending before it starts not valid",
+	}} {
+		c.Logf("test %d", i)
+		err := test.r.Validate()
+		if test.err == "" {
+			c.Check(err, gc.IsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
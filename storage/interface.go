@@ -213,6 +213,24 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeResizer provides an interface for growing volumes while they
+// remain attached to machines. A VolumeSource that supports resizing
+// volumes implements VolumeResizer.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters,
+	// returning the updated volume information.
+	ResizeVolumes(params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
+// FilesystemResizer provides an interface for growing filesystems
+// online, without unmounting them. A FilesystemSource that supports
+// resizing filesystems implements FilesystemResizer.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters, returning the updated filesystem information.
+	ResizeFilesystems(params []FilesystemResizeParams) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -286,6 +304,22 @@ type AttachmentParams struct {
 	ReadOnly bool
 }
 
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the volume.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume.
+	VolumeId string
+
+	// Provider is the name of the storage provider that manages
+	// the volume.
+	Provider ProviderType
+
+	// Size is the minimum size, in MiB, to grow the volume to.
+	Size uint64
+}
+
 // FilesystemParams is a fully specified set of parameters for filesystem creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -329,6 +363,25 @@ type FilesystemAttachmentParams struct {
 	Path string
 }
 
+// FilesystemResizeParams is a set of parameters for growing a filesystem.
+type FilesystemResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the filesystem.
+	Tag names.FilesystemTag
+
+	// Volume is the tag of the volume that backs the filesystem, if any.
+	Volume names.VolumeTag
+
+	// FilesystemId is the unique provider-supplied ID for the filesystem.
+	FilesystemId string
+
+	// Provider is the name of the storage provider that manages
+	// the filesystem.
+	Provider ProviderType
+
+	// Size is the minimum size, in MiB, to grow the filesystem to.
+	Size uint64
+}
+
 // CreateVolumesResult contains the result of a VolumeSource.CreateVolumes call
 // for one volume. Volume and VolumeAttachment should only be used if Error is
 // nil.
@@ -365,3 +418,18 @@ type AttachFilesystemsResult struct {
 	FilesystemAttachment *FilesystemAttachment
 	Error                error
 }
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Volume should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	Volume *Volume
+	Error  error
+}
+
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem.
+// Filesystem should only be used if Error is nil.
+type ResizeFilesystemsResult struct {
+	Filesystem *Filesystem
+	Error      error
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package poolmanager
+
+import (
+	"github.com/juju/errors"
+)
+
+// InheritedSettings is a SettingsManager that reads settings from
+// Settings, falling back to Defaults for keys that are not present
+// there. Changes are only ever made to Settings, so that inherited
+// settings may be overridden but not modified.
+type InheritedSettings struct {
+	Settings SettingsManager
+	Defaults SettingsManager
+}
+
+// CreateSettings is part of the SettingsManager interface.
+func (s InheritedSettings) CreateSettings(key string, settings map[string]interface{}) error {
+	return s.Settings.CreateSettings(key, settings)
+}
+
+// ReadSettings is part of the SettingsManager interface.
+func (s InheritedSettings) ReadSettings(key string) (map[string]interface{}, error) {
+	settings, err := s.Settings.ReadSettings(key)
+	if errors.IsNotFound(err) {
+		return s.Defaults.ReadSettings(key)
+	}
+	return settings, err
+}
+
+// ReplaceSettings is part of the SettingsManager interface. Replacing
+// inherited settings creates an override in Settings.
+func (s InheritedSettings) ReplaceSettings(key string, settings map[string]interface{}) error {
+	err := s.Settings.ReplaceSettings(key, settings)
+	if !errors.IsNotFound(err) {
+		return err
+	}
+	if _, err := s.Defaults.ReadSettings(key); err != nil {
+		return err
+	}
+	return s.Settings.CreateSettings(key, settings)
+}
+
+// RemoveSettings is part of the SettingsManager interface.
+func (s InheritedSettings) RemoveSettings(key string) error {
+	return s.Settings.RemoveSettings(key)
+}
+
+// ListSettings is part of the SettingsManager interface.
+func (s InheritedSettings) ListSettings(keyPrefix string) (map[string]map[string]interface{}, error) {
+	result, err := s.Defaults.ListSettings(keyPrefix)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	settings, err := s.Settings.ListSettings(keyPrefix)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for key, value := range settings {
+		result[key] = value
+	}
+	return result, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package poolmanager_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+)
+
+type inheritedSuite struct {
+	testing.IsolationSuite
+	settings    poolmanager.MemSettings
+	defaults    poolmanager.MemSettings
+	poolManager poolmanager.PoolManager
+}
+
+var _ = gc.Suite(&inheritedSuite{})
+
+func (s *inheritedSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.settings = poolmanager.MemSettings{make(map[string]map[string]interface{})}
+	s.defaults = poolmanager.MemSettings{make(map[string]map[string]interface{})}
+	s.defaults.Settings["pool#shared"] = map[string]interface{}{
+		"name": "shared", "type": "loop", "foo": "cloud",
+	}
+	s.defaults.Settings["pool#fast"] = map[string]interface{}{
+		"name": "fast", "type": "loop", "foo": "cloud",
+	}
+	registry := storage.StaticProviderRegistry{
+		map[storage.ProviderType]storage.Provider{
+			"loop": &dummystorage.StorageProvider{},
+		},
+	}
+	s.poolManager = poolmanager.New(poolmanager.InheritedSettings{
+		Settings: s.settings,
+		Defaults: s.defaults,
+	}, registry)
+}
+
+func (s *inheritedSuite) TestGetInherited(c *gc.C) {
+	pool, err := s.poolManager.Get("shared")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Attrs(), jc.DeepEquals, map[string]interface{}{"foo": "cloud"})
+}
+
+func (s *inheritedSuite) TestGetNotFound(c *gc.C) {
+	_, err := s.poolManager.Get("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *inheritedSuite) TestCreateOverrides(c *gc.C) {
+	_, err := s.poolManager.Create("shared", "loop", map[string]interface{}{"foo": "model"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pool, err := s.poolManager.Get("shared")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Attrs(), jc.DeepEquals, map[string]interface{}{"foo": "model"})
+	// The inherited pool is not modified.
+	c.Assert(s.defaults.Settings["pool#shared"]["foo"], gc.Equals, "cloud")
+}
+
+func (s *inheritedSuite) TestReplaceOverrides(c *gc.C) {
+	err := s.poolManager.Replace("shared", "", map[string]interface{}{"foo": "model"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pool, err := s.poolManager.Get("shared")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Attrs(), jc.DeepEquals, map[string]interface{}{"foo": "model"})
+	// The inherited pool is not modified.
+	c.Assert(s.defaults.Settings["pool#shared"]["foo"], gc.Equals, "cloud")
+}
+
+func (s *inheritedSuite) TestList(c *gc.C) {
+	_, err := s.poolManager.Create("shared", "loop", map[string]interface{}{"foo": "model"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.poolManager.Create("local", "loop", map[string]interface{}{"foo": "model"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pools, err := s.poolManager.List()
+	c.Assert(err, jc.ErrorIsNil)
+	foo := make(map[string]interface{})
+	for _, pool := range pools {
+		foo[pool.Name()] = pool.Attrs()["foo"]
+	}
+	c.Assert(foo, jc.DeepEquals, map[string]interface{}{
+		"shared": "model",
+		"fast":   "cloud",
+		"local":  "model",
+	})
+}
+
+func (s *inheritedSuite) TestDeleteOnlyRemovesOverride(c *gc.C) {
+	_, err := s.poolManager.Create("shared", "loop", map[string]interface{}{"foo": "model"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.poolManager.Delete("shared")
+	c.Assert(err, jc.ErrorIsNil)
+
+	pool, err := s.poolManager.Get("shared")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Attrs(), jc.DeepEquals, map[string]interface{}{"foo": "cloud"})
+}
@@ -19,6 +19,11 @@ type PoolManager interface {
 	// Delete removes the pool with name from state.
 	Delete(name string) error
 
+	// Replace replaces the configuration of the pool with name,
+	// persisting it to state. If providerType is empty, the pool's
+	// existing provider type is kept.
+	Replace(name string, providerType storage.ProviderType, attrs map[string]interface{}) error
+
 	// Get returns the pool with name from state.
 	Get(name string) (*storage.Config, error)
 
@@ -29,6 +34,7 @@ type PoolManager interface {
 type SettingsManager interface {
 	CreateSettings(key string, settings map[string]interface{}) error
 	ReadSettings(key string) (map[string]interface{}, error)
+	ReplaceSettings(key string, settings map[string]interface{}) error
 	RemoveSettings(key string) error
 	ListSettings(keyPrefix string) (map[string]map[string]interface{}, error)
 }
@@ -57,6 +63,15 @@ func (m MemSettings) ReadSettings(key string) (map[string]interface{}, error) {
 	return settings, nil
 }
 
+// ReplaceSettings is part of the SettingsManager interface.
+func (m MemSettings) ReplaceSettings(key string, settings map[string]interface{}) error {
+	if _, ok := m.Settings[key]; !ok {
+		return errors.NotFoundf("settings with key %q", key)
+	}
+	m.Settings[key] = settings
+	return nil
+}
+
 // RemoveSettings is part of the SettingsManager interface.
 func (m MemSettings) RemoveSettings(key string) error {
 	if _, ok := m.Settings[key]; !ok {
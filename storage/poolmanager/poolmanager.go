@@ -48,6 +48,40 @@ func (pm *poolManager) Create(name string, providerType storage.ProviderType, at
 		return nil, MissingTypeError
 	}
 
+	cfg, err := pm.validatedConfig(name, providerType, attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := pm.settings.CreateSettings(globalKey(name), poolAttrs(cfg)); err != nil {
+		return nil, errors.Annotatef(err, "creating pool %q", name)
+	}
+	return cfg, nil
+}
+
+// Replace is defined on PoolManager interface.
+func (pm *poolManager) Replace(name string, providerType storage.ProviderType, attrs map[string]interface{}) error {
+	if name == "" {
+		return MissingNameError
+	}
+	existing, err := pm.Get(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if providerType == "" {
+		providerType = existing.Provider()
+	}
+
+	cfg, err := pm.validatedConfig(name, providerType, attrs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := pm.settings.ReplaceSettings(globalKey(name), poolAttrs(cfg)); err != nil {
+		return errors.Annotatef(err, "replacing pool %q", name)
+	}
+	return nil
+}
+
+func (pm *poolManager) validatedConfig(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error) {
 	cfg, err := storage.NewConfig(name, providerType, attrs)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -59,16 +93,18 @@ func (pm *poolManager) Create(name string, providerType storage.ProviderType, at
 	if err := provider.ValidateConfig(p, cfg); err != nil {
 		return nil, errors.Annotate(err, "validating storage provider config")
 	}
-
-	poolAttrs := cfg.Attrs()
-	poolAttrs[Name] = name
-	poolAttrs[Type] = string(providerType)
-	if err := pm.settings.CreateSettings(globalKey(name), poolAttrs); err != nil {
-		return nil, errors.Annotatef(err, "creating pool %q", name)
-	}
 	return cfg, nil
 }
 
+// poolAttrs returns the settings under which the pool with the
+// specified configuration is stored.
+func poolAttrs(cfg *storage.Config) map[string]interface{} {
+	attrs := cfg.Attrs()
+	attrs[Name] = cfg.Name()
+	attrs[Type] = string(cfg.Provider())
+	return attrs
+}
+
 // Delete is defined on PoolManager interface.
 func (pm *poolManager) Delete(name string) error {
 	err := pm.settings.RemoveSettings(globalKey(name))
@@ -102,7 +102,10 @@ type loopVolumeSource struct {
 	storageDir string
 }
 
-var _ storage.VolumeSource = (*loopVolumeSource)(nil)
+var (
+	_ storage.VolumeSource  = (*loopVolumeSource)(nil)
+	_ storage.VolumeResizer = (*loopVolumeSource)(nil)
+)
 
 // CreateVolumes is defined on the VolumeSource interface.
 func (lvs *loopVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -247,6 +250,45 @@ func (lvs *loopVolumeSource) detachVolume(tag names.VolumeTag) error {
 	return nil
 }
 
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (lvs *loopVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := lvs.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %v", arg.Tag.Id())
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (lvs *loopVolumeSource) resizeVolume(arg storage.VolumeResizeParams) (*storage.Volume, error) {
+	loopFilePath := lvs.volumeFilePath(arg.Tag)
+	if err := createBlockFile(lvs.run, loopFilePath, arg.Size); err != nil {
+		return nil, errors.Annotate(err, "could not grow block file")
+	}
+	// Have any attached loop devices pick up the new size of
+	// the backing file.
+	deviceNames, err := associatedLoopDevices(lvs.run, loopFilePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "locating loop device")
+	}
+	for _, deviceName := range deviceNames {
+		if _, err := lvs.run("losetup", "-c", path.Join("/dev", deviceName)); err != nil {
+			return nil, errors.Annotatef(err, "updating capacity of loop device %q", deviceName)
+		}
+	}
+	return &storage.Volume{
+		arg.Tag,
+		storage.VolumeInfo{
+			VolumeId: arg.VolumeId,
+			Size:     arg.Size,
+		},
+	}, nil
+}
+
 // createBlockFile creates a file at the specified path, with the
 // given size in mebibytes.
 func createBlockFile(run runCommandFunc, filePath string, sizeInMiB uint64) error {
@@ -217,6 +217,34 @@ func (s *loopSuite) TestAttachVolumes(c *gc.C) {
 	}})
 }
 
+func (s *loopSuite) TestResizeVolumes(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	resizer, ok := source.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	s.commands.expect("fallocate", "-l", "4096MiB", fileName)
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("/dev/loop0: foo\n", nil)
+	s.commands.expect("losetup", "-c", "/dev/loop0")
+
+	results, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Provider: provider.LoopProviderType,
+		Size:     4096,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{
+		Volume: &storage.Volume{
+			names.NewVolumeTag("0"),
+			storage.VolumeInfo{
+				VolumeId: "volume-0",
+				Size:     4096,
+			},
+		},
+	}})
+}
+
 func (s *loopSuite) TestDetachVolumes(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
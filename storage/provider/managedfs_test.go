@@ -6,6 +6,7 @@ package provider_test
 import (
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -112,6 +113,95 @@ func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	c.Assert(results[0].Error, gc.ErrorMatches, "backing-volume 0 is not yet attached")
 }
 
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	resizer, ok := source.(storage.FilesystemResizer)
+	c.Assert(ok, jc.IsTrue)
+	// sda's partition is grown before the filesystem on it.
+	s.commands.expect("growpart", "/dev/sda", "1")
+	s.commands.expect("resize2fs", "/dev/sda1")
+	// xvdf1 is not partitioned, so only the filesystem is grown.
+	s.commands.expect("resize2fs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       6,
+	}
+	results, err := resizer.ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		Volume:       names.NewVolumeTag("0"),
+		FilesystemId: "filesystem-0-0",
+		Size:         4,
+	}, {
+		Tag:          names.NewFilesystemTag("0/1"),
+		Volume:       names.NewVolumeTag("1"),
+		FilesystemId: "filesystem-0-1",
+		Size:         5,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			names.NewFilesystemTag("0/0"),
+			names.NewVolumeTag("0"),
+			storage.FilesystemInfo{
+				FilesystemId: "filesystem-0-0",
+				Size:         4,
+			},
+		},
+	}, {
+		Filesystem: &storage.Filesystem{
+			names.NewFilesystemTag("0/1"),
+			names.NewVolumeTag("1"),
+			storage.FilesystemInfo{
+				FilesystemId: "filesystem-0-1",
+				Size:         6,
+			},
+		},
+	}})
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsPartitionUnchanged(c *gc.C) {
+	source := s.initSource(c)
+	// growpart exits non-zero if the partition cannot be grown,
+	// e.g. because it already fills the disk.
+	s.commands.expect("growpart", "/dev/sda", "1").respond(
+		"NOCHANGE: partition 1 is size 4096. it cannot be grown", errors.New("exit status 1"),
+	)
+	s.commands.expect("resize2fs", "/dev/sda1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:          names.NewFilesystemTag("0/0"),
+		Volume:       names.NewVolumeTag("0"),
+		FilesystemId: "filesystem-0-0",
+		Size:         4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsBlockDeviceTooSmall(c *gc.C) {
+	source := s.initSource(c)
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       2,
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "backing-volume 0 is smaller than 4MiB")
+}
+
 func (s *managedfsSuite) TestAttachFilesystems(c *gc.C) {
 	s.testAttachFilesystems(c, false, false)
 }
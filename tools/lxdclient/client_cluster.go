@@ -0,0 +1,216 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/lxc/lxd"
+	"github.com/lxc/lxd/shared/api"
+)
+
+// ClusterMemberOnline is the status of a cluster member
+// that is able to run containers.
+const ClusterMemberOnline = "Online"
+
+// ClusterMember describes a member of an LXD cluster.
+type ClusterMember struct {
+	// Name is the name of the member, which is used to target
+	// the member when creating containers.
+	Name string `json:"server_name"`
+
+	// URL is the address of the member's API endpoint.
+	URL string `json:"url"`
+
+	// Database reports whether the member is a database node.
+	Database bool `json:"database"`
+
+	// Status is the status of the member, e.g. "Online".
+	Status string `json:"status"`
+
+	// Message holds any additional information about the status.
+	Message string `json:"message"`
+}
+
+// ClusterMemberResources describes the resources of a cluster member,
+// and how much of them is in use.
+type ClusterMemberResources struct {
+	// CPUThreads is the total number of CPU threads on the member.
+	CPUThreads uint64
+
+	// MemoryTotal is the total amount of memory on the member, in bytes.
+	MemoryTotal uint64
+
+	// MemoryUsed is the amount of memory in use on the member, in bytes.
+	MemoryUsed uint64
+}
+
+// MemoryFree returns the amount of unused memory on the member, in bytes.
+func (r ClusterMemberResources) MemoryFree() uint64 {
+	if r.MemoryUsed > r.MemoryTotal {
+		return 0
+	}
+	return r.MemoryTotal - r.MemoryUsed
+}
+
+// rawClusterClient is the REST interface used for the cluster API,
+// which the lxd.Client does not expose.
+type rawClusterClient interface {
+	// Query sends a request to the given path (relative to the API
+	// version) and returns the synchronous or asynchronous response.
+	Query(method, path string, data interface{}) (*api.Response, error)
+
+	WaitForSuccess(waitURL string) error
+}
+
+type clusterClient struct {
+	raw       rawClusterClient
+	supported bool
+}
+
+// ClusterSupported reports whether or not the LXD remote is a member
+// of a cluster.
+func (c *clusterClient) ClusterSupported() bool {
+	return c.supported
+}
+
+// ClusterMembers returns the members of the cluster.
+func (c *clusterClient) ClusterMembers() ([]ClusterMember, error) {
+	if !c.supported {
+		return nil, errors.NotSupportedf("clustering on this remote")
+	}
+	var members []ClusterMember
+	if err := c.get("/cluster/members?recursion=1", &members); err != nil {
+		return nil, errors.Annotate(err, "listing cluster members")
+	}
+	return members, nil
+}
+
+// ClusterMemberResources returns the resources of the named cluster
+// member, and how much of them is in use.
+func (c *clusterClient) ClusterMemberResources(name string) (*ClusterMemberResources, error) {
+	if !c.supported {
+		return nil, errors.NotSupportedf("clustering on this remote")
+	}
+	var resources struct {
+		CPU struct {
+			Total uint64 `json:"total"`
+		} `json:"cpu"`
+		Memory struct {
+			Used  uint64 `json:"used"`
+			Total uint64 `json:"total"`
+		} `json:"memory"`
+	}
+	path := "/resources?target=" + url.QueryEscape(name)
+	if err := c.get(path, &resources); err != nil {
+		return nil, errors.Annotatef(err, "getting resources of cluster member %q", name)
+	}
+	return &ClusterMemberResources{
+		CPUThreads:  resources.CPU.Total,
+		MemoryTotal: resources.Memory.Total,
+		MemoryUsed:  resources.Memory.Used,
+	}, nil
+}
+
+// InstanceLocations returns the names of the cluster members on which
+// the instances with the given name prefix are located, keyed by
+// instance name.
+func (c *clusterClient) InstanceLocations(prefix string) (map[string]string, error) {
+	if !c.supported {
+		return nil, errors.NotSupportedf("clustering on this remote")
+	}
+	var containers []struct {
+		Name     string `json:"name"`
+		Location string `json:"location"`
+	}
+	if err := c.get("/containers?recursion=1", &containers); err != nil {
+		return nil, errors.Annotate(err, "listing container locations")
+	}
+	locations := make(map[string]string)
+	for _, container := range containers {
+		if prefix != "" && !strings.HasPrefix(container.Name, prefix) {
+			continue
+		}
+		locations[container.Name] = container.Location
+	}
+	return locations, nil
+}
+
+// initOnTarget creates a new container from the given image on the
+// named cluster member. The image must already exist on the remote.
+func (c *clusterClient) initOnTarget(target string, req api.ContainersPost) error {
+	if !c.supported {
+		return errors.NotSupportedf("clustering on this remote")
+	}
+	path := "/containers?target=" + url.QueryEscape(target)
+	resp, err := c.raw.Query("POST", path, req)
+	if err != nil {
+		return errors.Annotatef(err, "creating container on cluster member %q", target)
+	}
+	if err := c.raw.WaitForSuccess(resp.Operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (c *clusterClient) get(path string, result interface{}) error {
+	resp, err := c.raw.Query("GET", path, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := json.Unmarshal(resp.Metadata, result); err != nil {
+		return errors.Annotate(err, "decoding response")
+	}
+	return nil
+}
+
+// restClient implements rawClusterClient using the HTTP client
+// and base URL of an lxd.Client.
+type restClient struct {
+	*lxd.Client
+}
+
+// Query implements rawClusterClient.
+func (c restClient) Query(method, path string, data interface{}) (*api.Response, error) {
+	var body io.Reader
+	if data != nil {
+		buf, err := json.Marshal(data)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		body = bytes.NewReader(buf)
+	}
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/1.0%s", c.BaseURL, path), body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.Http.Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer httpResp.Body.Close()
+
+	var resp api.Response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, errors.Annotatef(err, "decoding response to %s %s", method, path)
+	}
+	if resp.Type == api.ErrorResponse {
+		if httpResp.StatusCode == http.StatusNotFound {
+			return nil, errors.NewNotFound(nil, resp.Error)
+		}
+		return nil, errors.New(resp.Error)
+	}
+	return &resp, nil
+}
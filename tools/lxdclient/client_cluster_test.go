@@ -0,0 +1,193 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient_test
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+type ClusterClientSuite struct {
+	testing.IsolationSuite
+
+	raw *mockRawClusterClient
+}
+
+var _ = gc.Suite(&ClusterClientSuite{})
+
+func (s *ClusterClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.raw = &mockRawClusterClient{}
+}
+
+func (s *ClusterClientSuite) TestClusterNotSupported(c *gc.C) {
+	client := lxdclient.NewClusterClient(s.raw, false)
+	c.Assert(client.ClusterSupported(), jc.IsFalse)
+
+	_, err := client.ClusterMembers()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	_, err = client.ClusterMemberResources("node1")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	_, err = client.InstanceLocations("juju-")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	s.raw.CheckNoCalls(c)
+}
+
+func (s *ClusterClientSuite) TestClusterMembers(c *gc.C) {
+	s.raw.metadata = `[
+		{"server_name": "node1", "url": "https://10.0.0.1:8443", "database": true, "status": "Online"},
+		{"server_name": "node2", "url": "https://10.0.0.2:8443", "status": "Offline", "message": "no heartbeat"}
+	]`
+	client := lxdclient.NewClusterClient(s.raw, true)
+	c.Assert(client.ClusterSupported(), jc.IsTrue)
+
+	members, err := client.ClusterMembers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(members, jc.DeepEquals, []lxdclient.ClusterMember{{
+		Name:     "node1",
+		URL:      "https://10.0.0.1:8443",
+		Database: true,
+		Status:   lxdclient.ClusterMemberOnline,
+	}, {
+		Name:    "node2",
+		URL:     "https://10.0.0.2:8443",
+		Status:  "Offline",
+		Message: "no heartbeat",
+	}})
+	s.raw.CheckCall(c, 0, "Query", "GET", "/cluster/members?recursion=1", nil)
+}
+
+func (s *ClusterClientSuite) TestClusterMembersError(c *gc.C) {
+	s.raw.SetErrors(errors.New("burp"))
+	client := lxdclient.NewClusterClient(s.raw, true)
+	_, err := client.ClusterMembers()
+	c.Assert(err, gc.ErrorMatches, "listing cluster members: burp")
+}
+
+func (s *ClusterClientSuite) TestClusterMemberResources(c *gc.C) {
+	s.raw.metadata = `{
+		"cpu": {"sockets": [{"cores": 4, "threads": 8}], "total": 8},
+		"memory": {"used": 1073741824, "total": 4294967296}
+	}`
+	client := lxdclient.NewClusterClient(s.raw, true)
+
+	resources, err := client.ClusterMemberResources("node 1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, &lxdclient.ClusterMemberResources{
+		CPUThreads:  8,
+		MemoryTotal: 4294967296,
+		MemoryUsed:  1073741824,
+	})
+	c.Assert(resources.MemoryFree(), gc.Equals, uint64(3221225472))
+	s.raw.CheckCall(c, 0, "Query", "GET", "/resources?target=node+1", nil)
+}
+
+func (s *ClusterClientSuite) TestClusterMemberResourcesMemoryOvercommitted(c *gc.C) {
+	resources := lxdclient.ClusterMemberResources{
+		MemoryTotal: 1024,
+		MemoryUsed:  2048,
+	}
+	c.Assert(resources.MemoryFree(), gc.Equals, uint64(0))
+}
+
+func (s *ClusterClientSuite) TestInstanceLocations(c *gc.C) {
+	s.raw.metadata = `[
+		{"name": "juju-0", "location": "node1"},
+		{"name": "juju-1", "location": "node2"},
+		{"name": "other", "location": "node1"}
+	]`
+	client := lxdclient.NewClusterClient(s.raw, true)
+
+	locations, err := client.InstanceLocations("juju-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locations, jc.DeepEquals, map[string]string{
+		"juju-0": "node1",
+		"juju-1": "node2",
+	})
+	s.raw.CheckCall(c, 0, "Query", "GET", "/containers?recursion=1", nil)
+}
+
+func (s *ClusterClientSuite) TestAddInstanceOnTarget(c *gc.C) {
+	rawInstances, instancesStub := lxdclient.NewStubRawInstanceClient(&api.Response{})
+	client := lxdclient.NewClusteredInstanceClient(
+		rawInstances, lxdclient.NewClusterClient(s.raw, true),
+	)
+	s.raw.operation = "/1.0/operations/abc"
+
+	_, err := client.AddInstance(lxdclient.InstanceSpec{
+		Name:     "juju-0",
+		Image:    "ubuntu-xenial",
+		Profiles: []string{"default"},
+		Target:   "node2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.raw.CheckCallNames(c, "Query", "WaitForSuccess")
+	s.raw.CheckCall(c, 0, "Query", "POST", "/containers?target=node2", api.ContainersPost{
+		ContainerPut: api.ContainerPut{
+			Config:   map[string]string{},
+			Devices:  map[string]map[string]string{},
+			Profiles: []string{"default"},
+		},
+		Name: "juju-0",
+		Source: api.ContainerSource{
+			Type:  "image",
+			Alias: "ubuntu-xenial",
+		},
+	})
+	s.raw.CheckCall(c, 1, "WaitForSuccess", "/1.0/operations/abc")
+
+	// The container is started and inspected with the
+	// regular API, which is cluster-aware.
+	instancesStub.CheckCallNames(c, "Action", "WaitForSuccess", "ContainerInfo")
+}
+
+func (s *ClusterClientSuite) TestAddInstanceOnTargetNotSupported(c *gc.C) {
+	rawInstances, instancesStub := lxdclient.NewStubRawInstanceClient(nil)
+	client := lxdclient.NewInstanceClient(rawInstances)
+
+	_, err := client.AddInstance(lxdclient.InstanceSpec{
+		Name:   "juju-0",
+		Image:  "ubuntu-xenial",
+		Target: "node2",
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	instancesStub.CheckNoCalls(c)
+}
+
+type mockRawClusterClient struct {
+	testing.Stub
+
+	metadata  string
+	operation string
+}
+
+func (c *mockRawClusterClient) Query(method, path string, data interface{}) (*api.Response, error) {
+	c.MethodCall(c, "Query", method, path, data)
+	if err := c.NextErr(); err != nil {
+		return nil, err
+	}
+	return &api.Response{
+		Operation: c.operation,
+		Metadata:  json.RawMessage(c.metadata),
+	}, nil
+}
+
+func (c *mockRawClusterClient) WaitForSuccess(waitURL string) error {
+	c.MethodCall(c, "WaitForSuccess", waitURL)
+	return c.NextErr()
+}
@@ -41,12 +41,14 @@ type rawInstanceClient interface {
 	ContainerState(name string) (*api.ContainerState, error)
 	ContainerDeviceAdd(container, devname, devtype string, props []string) (*api.Response, error)
 	ContainerDeviceDelete(container, devname string) (*api.Response, error)
+	ApplyProfile(container, profile string) (*api.Response, error)
 	PushFile(container, path string, gid int, uid int, mode string, buf io.ReadSeeker) error
 }
 
 type instanceClient struct {
-	raw    rawInstanceClient
-	remote string
+	raw     rawInstanceClient
+	remote  string
+	cluster *clusterClient
 }
 
 func (client *instanceClient) addInstance(spec InstanceSpec) error {
@@ -74,6 +76,9 @@ func (client *instanceClient) addInstance(spec InstanceSpec) error {
 	}
 
 	config := spec.config()
+	if spec.Target != "" {
+		return client.addInstanceOnTarget(spec, imageRemote, config, lxdDevices)
+	}
 	resp, err := client.raw.Init(spec.Name, imageRemote, imageAlias, profiles, config, lxdDevices, spec.Ephemeral)
 	if err != nil {
 		return errors.Trace(err)
@@ -91,6 +96,37 @@ func (client *instanceClient) addInstance(spec InstanceSpec) error {
 	return nil
 }
 
+// addInstanceOnTarget creates the instance on the cluster member named
+// by spec.Target. LXD shares images between cluster members, so the
+// image must already exist on the client's remote.
+func (client *instanceClient) addInstanceOnTarget(
+	spec InstanceSpec,
+	imageRemote string,
+	config map[string]string,
+	devices map[string]map[string]string,
+) error {
+	if client.cluster == nil {
+		return errors.NotSupportedf("clustering on this remote")
+	}
+	if imageRemote != client.remote {
+		return errors.NotSupportedf("targeting a cluster member with image remote %q", imageRemote)
+	}
+	req := api.ContainersPost{
+		ContainerPut: api.ContainerPut{
+			Config:    config,
+			Devices:   devices,
+			Ephemeral: spec.Ephemeral,
+			Profiles:  spec.Profiles,
+		},
+		Name: spec.Name,
+		Source: api.ContainerSource{
+			Type:  "image",
+			Alias: spec.Image,
+		},
+	}
+	return errors.Trace(client.cluster.initOnTarget(spec.Target, req))
+}
+
 func (client *instanceClient) startInstance(spec InstanceSpec) error {
 	timeout := -1
 	force := false
@@ -336,3 +372,30 @@ func (client *instanceClient) RemoveDevice(instanceName, deviceName string) erro
 	}
 	return nil
 }
+
+// ReplaceProfile replaces the named profile applied to an instance
+// with another, which is applied after the instance's other profiles.
+// Either name may be empty, to only apply or only remove a profile.
+func (client *instanceClient) ReplaceProfile(instanceName, oldProfile, newProfile string) error {
+	info, err := client.raw.ContainerInfo(instanceName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var profiles []string
+	for _, profile := range info.Profiles {
+		if profile != oldProfile && profile != newProfile {
+			profiles = append(profiles, profile)
+		}
+	}
+	if newProfile != "" {
+		profiles = append(profiles, newProfile)
+	}
+	resp, err := client.raw.ApplyProfile(instanceName, strings.Join(profiles, ","))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.raw.WaitForSuccess(resp.Operation); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
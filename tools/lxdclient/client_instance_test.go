@@ -256,3 +256,34 @@ func (s *devicesSuite) TestRemoveDeviceAsyncError(c *gc.C) {
 	err := client.RemoveDevice("instance", "device")
 	c.Assert(err, gc.ErrorMatches, "async error")
 }
+
+func (s *devicesSuite) TestReplaceProfile(c *gc.C) {
+	s.Client.Container = &lxdapi.Container{}
+	s.Client.Container.Profiles = []string{"default", "juju-foo", "juju-foo-mysql-1"}
+	client := lxdclient.NewInstanceClient(s.Client)
+	err := client.ReplaceProfile("instance", "juju-foo-mysql-1", "juju-foo-mysql-2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCalls(c, []testing.StubCall{
+		{"ContainerInfo", []interface{}{"instance"}},
+		{"ApplyProfile", []interface{}{"instance", "default,juju-foo,juju-foo-mysql-2"}},
+		{"WaitForSuccess", []interface{}{""}},
+	})
+}
+
+func (s *devicesSuite) TestReplaceProfileRemoveOnly(c *gc.C) {
+	s.Client.Container = &lxdapi.Container{}
+	s.Client.Container.Profiles = []string{"default", "juju-foo-mysql-1", "juju-foo"}
+	client := lxdclient.NewInstanceClient(s.Client)
+	err := client.ReplaceProfile("instance", "juju-foo-mysql-1", "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Stub.CheckCall(c, 1, "ApplyProfile", "instance", "default,juju-foo")
+}
+
+func (s *devicesSuite) TestReplaceProfileSyncError(c *gc.C) {
+	s.Stub.SetErrors(nil, errors.New("sync error"))
+	client := lxdclient.NewInstanceClient(s.Client)
+	err := client.ReplaceProfile("instance", "", "juju-foo-mysql-1")
+	c.Assert(err, gc.ErrorMatches, "sync error")
+}
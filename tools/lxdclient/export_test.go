@@ -7,6 +7,7 @@ package lxdclient
 
 import (
 	"github.com/juju/testing"
+	"github.com/lxc/lxd/shared/api"
 )
 
 var NewInstanceSummary = newInstanceSummary
@@ -14,6 +15,7 @@ var NewInstanceSummary = newInstanceSummary
 type (
 	RawInstanceClient rawInstanceClient
 	RawStorageClient  rawStorageClient
+	RawClusterClient  rawClusterClient
 )
 
 func NewInstanceClient(raw RawInstanceClient) *instanceClient {
@@ -30,6 +32,29 @@ func NewStorageClient(raw RawStorageClient, supported bool) *storageClient {
 	}
 }
 
+func NewClusterClient(raw RawClusterClient, supported bool) *clusterClient {
+	return &clusterClient{
+		raw:       raw,
+		supported: supported,
+	}
+}
+
+func NewClusteredInstanceClient(raw RawInstanceClient, cluster *clusterClient) *instanceClient {
+	return &instanceClient{
+		raw:     rawInstanceClient(raw),
+		remote:  "",
+		cluster: cluster,
+	}
+}
+
+// NewStubRawInstanceClient returns a RawInstanceClient that records
+// its calls in the returned stub, and responds to requests with the
+// given response.
+func NewStubRawInstanceClient(response *api.Response) (RawInstanceClient, *testing.Stub) {
+	stub := &testing.Stub{}
+	return &stubClient{stub: stub, Response: response}, stub
+}
+
 func PatchGenerateCertificate(s *testing.CleanupSuite, cert, key string) {
 	s.PatchValue(&generateCertificate, func() ([]byte, []byte, error) {
 		return []byte(cert), []byte(key), nil
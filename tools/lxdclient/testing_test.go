@@ -49,6 +49,7 @@ type stubClient struct {
 
 	Instance   *api.ContainerState
 	Instances  []api.Container
+	Container  *api.Container
 	ReturnCode int
 	Response   *api.Response
 	Aliases    map[string]string
@@ -172,9 +173,20 @@ func (s *stubClient) ContainerInfo(name string) (*api.Container, error) {
 	if err := s.stub.NextErr(); err != nil {
 		return nil, err
 	}
+	if s.Container != nil {
+		return s.Container, nil
+	}
 	return &api.Container{}, nil
 }
 
+func (s *stubClient) ApplyProfile(container, profile string) (*api.Response, error) {
+	s.stub.AddCall("ApplyProfile", container, profile)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &api.Response{}, nil
+}
+
 func (s *stubClient) PushFile(container, path string, gid int, uid int, mode string, buf io.ReadSeeker) error {
 	s.stub.AddCall("PushFile", container, path, gid, uid, mode, buf)
 	if err := s.stub.NextErr(); err != nil {
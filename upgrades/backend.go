@@ -33,6 +33,7 @@ type StateBackend interface {
 	AddModelEnvironVersion() error
 	AddModelType() error
 	MigrateLeasesToGlobalTime() error
+	ConvertAuditLogToCapped() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -127,6 +128,10 @@ func (s stateBackend) MigrateLeasesToGlobalTime() error {
 	return state.MigrateLeasesToGlobalTime(s.st)
 }
 
+func (s stateBackend) ConvertAuditLogToCapped() error {
+	return state.ConvertAuditLogToCapped(s.st)
+}
+
 type modelShim struct {
 	st *state.State
 	m  *state.Model
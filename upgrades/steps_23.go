@@ -20,5 +20,12 @@ func stateStepsFor23() []Step {
 				return context.State().MigrateLeasesToGlobalTime()
 			},
 		},
+		&upgradeStep{
+			description: "convert the audit log to a capped collection",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().ConvertAuditLogToCapped()
+			},
+		},
 	}
 }
@@ -31,3 +31,9 @@ func (s *steps23Suite) TestMigrateLeases(c *gc.C) {
 	// Logic for step itself is tested in state package.
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
+
+func (s *steps23Suite) TestConvertAuditLogToCapped(c *gc.C) {
+	step := findStateStep(c, v23, "convert the audit log to a capped collection")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides a worker that enqueues the actions
+// of a model's action schedules when they are due, and advances the
+// model's action rollouts from batch to batch.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// Facade exposes the controller methods used by the worker.
+type Facade interface {
+	// RunDue enqueues the actions of all action schedules that are
+	// due.
+	RunDue() error
+
+	// AdvanceRollouts enqueues the next batch of each action rollout
+	// that is ready for it.
+	AdvanceRollouts() error
+}
+
+// Config holds the configuration and dependencies of the worker.
+type Config struct {
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between checks for due schedules. As
+	// schedules are evaluated to the minute, it should not be
+	// longer than one.
+	Period time.Duration
+
+	// RolloutPeriod is the time between checks for action rollouts
+	// whose batch has finished.
+	RolloutPeriod time.Duration
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.RolloutPeriod <= 0 {
+		return errors.NotValidf("non-positive RolloutPeriod")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that enqueues due scheduled actions once
+// when started, and subsequently every Period; and that likewise
+// advances action rollouts every RolloutPeriod.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &schedulerWorker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type schedulerWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+func (w *schedulerWorker) loop() error {
+	scheduleTimer := w.config.Clock.NewTimer(0)
+	defer scheduleTimer.Stop()
+	rolloutTimer := w.config.Clock.NewTimer(0)
+	defer rolloutTimer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-scheduleTimer.Chan():
+			logger.Tracef("running due action schedules")
+			if err := w.config.Facade.RunDue(); err != nil {
+				return errors.Annotate(err, "running action schedules")
+			}
+			scheduleTimer.Reset(w.config.Period)
+		case <-rolloutTimer.Chan():
+			logger.Tracef("advancing action rollouts")
+			if err := w.config.Facade.AdvanceRollouts(); err != nil {
+				return errors.Annotate(err, "advancing action rollouts")
+			}
+			rolloutTimer.Reset(w.config.RolloutPeriod)
+		}
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *schedulerWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *schedulerWorker) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testing.Clock
+	facade *mockFacade
+	config actionscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.ZeroTime())
+	s.facade = &mockFacade{calls: make(chan string, 10)}
+	s.config = actionscheduler.Config{
+		Facade:        s.facade,
+		Clock:         s.clock,
+		Period:        time.Minute,
+		RolloutPeriod: 10 * time.Second,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		update func(*actionscheduler.Config)
+		err    string
+	}{{
+		func(cfg *actionscheduler.Config) { cfg.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(cfg *actionscheduler.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *actionscheduler.Config) { cfg.Period = 0 },
+		"non-positive Period not valid",
+	}, {
+		func(cfg *actionscheduler.Config) { cfg.RolloutPeriod = 0 },
+		"non-positive RolloutPeriod not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.update(&config)
+		_, err := actionscheduler.NewWorker(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *WorkerSuite) TestRunsPeriodically(c *gc.C) {
+	w, err := actionscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCalls(c, "AdvanceRollouts", "RunDue")
+	s.assertNoRun(c)
+
+	// Rollouts are advanced more often than schedules are run, and
+	// neither timer disturbs the other.
+	err = s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCalls(c, "AdvanceRollouts")
+	s.assertNoRun(c)
+	err = s.clock.WaitAdvance(50*time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCalls(c, "AdvanceRollouts", "RunDue")
+}
+
+func (s *WorkerSuite) TestRunError(c *gc.C) {
+	s.facade.runDueErr = errors.New("boom")
+	w, err := actionscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "running action schedules: boom")
+}
+
+func (s *WorkerSuite) TestAdvanceRolloutsError(c *gc.C) {
+	s.facade.advanceErr = errors.New("boom")
+	w, err := actionscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "advancing action rollouts: boom")
+}
+
+func (s *WorkerSuite) waitCalls(c *gc.C, expected ...string) {
+	var calls []string
+	for range expected {
+		select {
+		case call := <-s.facade.calls:
+			calls = append(calls, call)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %v", expected)
+		}
+	}
+	sort.Strings(calls)
+	c.Assert(calls, jc.DeepEquals, expected)
+}
+
+func (s *WorkerSuite) assertNoRun(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected run")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	calls      chan string
+	runDueErr  error
+	advanceErr error
+}
+
+func (f *mockFacade) RunDue() error {
+	f.calls <- "RunDue"
+	return f.runDueErr
+}
+
+func (f *mockFacade) AdvanceRollouts() error {
+	f.calls <- "AdvanceRollouts"
+	return f.advanceErr
+}
@@ -8,14 +8,25 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
 	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 )
 
+const (
+	// agentPingPeriod and agentPingTimeout control the health checks
+	// on agent API connections. They are shorter than the defaults so
+	// that long-lived workers notice a broken connection, and restart
+	// with a new one, sooner.
+	agentPingPeriod  = 30 * time.Second
+	agentPingTimeout = 15 * time.Second
+)
+
 var (
 	// checkProvisionedStrategy defines the evil uninterruptible
 	// retry strategy for "handling" ErrNotProvisioned. It exists
@@ -44,6 +55,35 @@ var (
 	ErrChangedPassword = errors.New("insecure password replaced; retry")
 )
 
+// agentRetryPolicy controls how API calls made through connections
+// returned by RetryingConnect are retried when the connection to the
+// controller is lost.
+var agentRetryPolicy = base.RetryPolicy{
+	Attempts: 3,
+	Delay:    5 * time.Second,
+	Clock:    clock.WallClock,
+}
+
+// RetryingConnect returns a ConnectFunc that makes the agent's first
+// connection with connect, and wraps it with api.NewRetryingConnection.
+// If the connection is lost, workers' API calls are made on a new
+// connection made with OnlyConnect, and interrupted calls are resent
+// where that's safe; workers holding watchers still see the watchers
+// fail, and are restarted. The connection is only reported broken if a
+// new connection cannot be made.
+func RetryingConnect(connect ConnectFunc) ConnectFunc {
+	return func(a agent.Agent, apiOpen api.OpenFunc) (api.Connection, error) {
+		first := true
+		return api.NewRetryingConnection(func() (api.Connection, error) {
+			if first {
+				first = false
+				return connect(a, apiOpen)
+			}
+			return OnlyConnect(a, apiOpen)
+		}, agentRetryPolicy)
+	}
+}
+
 // OnlyConnect logs into the API using the supplied agent's credentials.
 func OnlyConnect(a agent.Agent, apiOpen api.OpenFunc) (api.Connection, error) {
 	agentConfig := a.CurrentConfig()
@@ -95,6 +135,8 @@ func connectFallback(
 			// should be fast, but the login may not be.
 			DialTimeout: time.Second,
 			RetryDelay:  200 * time.Millisecond,
+			PingPeriod:  agentPingPeriod,
+			PingTimeout: agentPingTimeout,
 		})
 	}
 
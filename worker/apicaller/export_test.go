@@ -11,3 +11,6 @@ var Strategy = &checkProvisionedStrategy
 // NewConnFacade is a dirty hack; should be explicit config; not
 // currently convenient.
 var NewConnFacade = &newConnFacade
+
+// AgentRetryPolicy controls how RetryingConnect connections retry.
+var AgentRetryPolicy = &agentRetryPolicy
@@ -50,6 +50,11 @@ type ManifoldConfig struct {
 	// Filter is used to specialize responses to connection errors
 	// made on behalf of different kinds of agent.
 	Filter dependency.FilterFunc
+
+	// APICallInterceptors, if set, are added to the dial options of
+	// every connection made by APIOpen, so that they are called for
+	// all API calls made over the connection.
+	APICallInterceptors []base.APICallInterceptor
 }
 
 // Manifold returns a manifold whose worker wraps an API connection
@@ -75,7 +80,7 @@ func (config ManifoldConfig) startFunc() dependency.StartFunc {
 			return nil, err
 		}
 
-		conn, err := config.NewConnection(agent, config.APIOpen)
+		conn, err := config.NewConnection(agent, config.apiOpen())
 		if errors.Cause(err) == ErrChangedPassword {
 			return nil, dependency.ErrBounce
 		} else if err != nil {
@@ -85,6 +90,20 @@ func (config ManifoldConfig) startFunc() dependency.StartFunc {
 	}
 }
 
+// apiOpen returns config.APIOpen, wrapped to add any configured
+// APICallInterceptors to the dial options.
+func (config ManifoldConfig) apiOpen() api.OpenFunc {
+	if len(config.APICallInterceptors) == 0 {
+		return config.APIOpen
+	}
+	return func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+		interceptors := make([]base.APICallInterceptor, 0, len(opts.APICallInterceptors)+len(config.APICallInterceptors))
+		interceptors = append(interceptors, opts.APICallInterceptors...)
+		opts.APICallInterceptors = append(interceptors, config.APICallInterceptors...)
+		return config.APIOpen(info, opts)
+	}
+}
+
 // outputFunc extracts an API connection from a *apiConnWorker.
 func outputFunc(in worker.Worker, out interface{}) error {
 	inWorker, _ := in.(*apiConnWorker)
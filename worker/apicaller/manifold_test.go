@@ -4,6 +4,8 @@
 package apicaller_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -190,3 +192,36 @@ func (s *ManifoldSuite) TestOutputBadTarget(c *gc.C) {
 	c.Check(apicaller, gc.IsNil)
 	c.Check(err.Error(), gc.Equals, "out should be *base.APICaller or *api.Connection; got *interface {}")
 }
+
+type nullInterceptor struct {
+	name string
+}
+
+func (nullInterceptor) APICallStarted(base.APICallInfo)              {}
+func (nullInterceptor) APICallEnded(base.APICallInfo, time.Duration) {}
+func (nullInterceptor) APICallFailed(base.APICallInfo, error)        {}
+
+func (s *ManifoldSuite) TestStartAddsAPICallInterceptors(c *gc.C) {
+	existing := nullInterceptor{"existing"}
+	configured := nullInterceptor{"configured"}
+	manifold := apicaller.Manifold(apicaller.ManifoldConfig{
+		AgentName:            "agent-name",
+		APIConfigWatcherName: "api-config-watcher-name",
+		APIOpen: func(_ *api.Info, opts api.DialOpts) (api.Connection, error) {
+			c.Check(opts.APICallInterceptors, jc.DeepEquals, []base.APICallInterceptor{
+				existing, configured,
+			})
+			return s.conn, nil
+		},
+		NewConnection: func(a agent.Agent, apiOpen api.OpenFunc) (api.Connection, error) {
+			return apiOpen(nil, api.DialOpts{
+				APICallInterceptors: []base.APICallInterceptor{existing},
+			})
+		},
+		APICallInterceptors: []base.APICallInterceptor{configured},
+	})
+
+	worker, err := manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	assertStop(c, worker)
+}
@@ -4,13 +4,17 @@
 package apicaller_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/worker/apicaller"
 )
 
@@ -124,3 +128,22 @@ func checkWaitNeverProvisioned(c *gc.C, connect apicaller.ConnectFunc) (api.Conn
 	checkOpenCalls(c, stub, "new", "new", "new", "new")
 	return conn, err
 }
+
+func (s *RetryStrategySuite) TestRetryingConnectRedials(c *gc.C) {
+	s.PatchValue(&apicaller.AgentRetryPolicy.Delay, time.Duration(0))
+	stub := &testing.Stub{}
+	connect := apicaller.RetryingConnect(func(a agent.Agent, apiOpen api.OpenFunc) (api.Connection, error) {
+		stub.AddCall("connect")
+		return &mockConn{stub: stub}, nil
+	})
+	conn, err := strategyTest(stub, utils.AttemptStrategy{}, func(apiOpen api.OpenFunc) (api.Connection, error) {
+		return connect(&mockAgent{stub: stub}, apiOpen)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.SetErrors(rpc.ErrShutdown)
+	err = conn.APICall("Uniter", 7, "", "GetMeterStatus", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conn.IsBroken(), jc.IsFalse)
+	stub.CheckCallNames(c, "connect", "APICall", "Close", "apiOpen", "APICall")
+}
@@ -103,6 +103,11 @@ func (mock *mockConn) ModelTag() (names.ModelTag, bool) {
 	return coretesting.ModelTag, true
 }
 
+func (mock *mockConn) APICall(facade string, version int, id, method string, args, response interface{}) error {
+	mock.stub.AddCall("APICall", facade, method)
+	return mock.stub.NextErr()
+}
+
 func (mock *mockConn) Broken() <-chan struct{} {
 	return mock.broken
 }
@@ -192,6 +197,8 @@ func openCalls(model names.ModelTag, entity names.Tag, passwords ...string) []te
 			Args: []interface{}{info, api.DialOpts{
 				DialTimeout: time.Second,
 				RetryDelay:  200 * time.Millisecond,
+				PingPeriod:  30 * time.Second,
+				PingTimeout: 15 * time.Second,
 			}},
 		}
 	}
@@ -0,0 +1,102 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmupgrader provides a worker that moves a model's rolling
+// charm upgrades from batch to batch as their units become ready.
+package charmupgrader
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.charmupgrader")
+
+// Facade exposes the controller methods used by the worker.
+type Facade interface {
+	// AdvanceCharmUpgrades starts the next batch of units of each
+	// rolling charm upgrade that is ready for it.
+	AdvanceCharmUpgrades() error
+}
+
+// Config holds the configuration and dependencies of the worker.
+type Config struct {
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between checks for rolling charm upgrades
+	// whose current batch is ready.
+	Period time.Duration
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that advances rolling charm upgrades once
+// when started, and subsequently every Period.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &upgraderWorker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type upgraderWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+func (w *upgraderWorker) loop() error {
+	timer := w.config.Clock.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			logger.Tracef("advancing rolling charm upgrades")
+			if err := w.config.Facade.AdvanceCharmUpgrades(); err != nil {
+				return errors.Annotate(err, "advancing charm upgrades")
+			}
+			timer.Reset(w.config.Period)
+		}
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *upgraderWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *upgraderWorker) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/charmupgrader"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testing.Clock
+	facade *mockFacade
+	config charmupgrader.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.ZeroTime())
+	s.facade = &mockFacade{calls: make(chan struct{}, 10)}
+	s.config = charmupgrader.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Period: 10 * time.Second,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		update func(*charmupgrader.Config)
+		err    string
+	}{{
+		func(cfg *charmupgrader.Config) { cfg.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(cfg *charmupgrader.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *charmupgrader.Config) { cfg.Period = 0 },
+		"non-positive Period not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.update(&config)
+		_, err := charmupgrader.NewWorker(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *WorkerSuite) TestRunsPeriodically(c *gc.C) {
+	w, err := charmupgrader.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c)
+	s.assertNoCall(c)
+
+	err = s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c)
+	s.assertNoCall(c)
+}
+
+func (s *WorkerSuite) TestAdvanceError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := charmupgrader.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "advancing charm upgrades: boom")
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for AdvanceCharmUpgrades")
+	}
+}
+
+func (s *WorkerSuite) assertNoCall(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected call")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	calls chan struct{}
+	err   error
+}
+
+func (f *mockFacade) AdvanceCharmUpgrades() error {
+	f.calls <- struct{}{}
+	return f.err
+}
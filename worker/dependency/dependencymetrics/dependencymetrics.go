@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dependencymetrics provides a Prometheus collector reporting
+// on the workers run by a dependency engine.
+package dependencymetrics
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var workerStartsDesc = prometheus.NewDesc(
+	"juju_worker_starts_total",
+	"Total number of times each dependency engine worker has been started.",
+	[]string{"worker"},
+	prometheus.Labels{},
+)
+
+// StartCounter reports the number of times each of its workers has
+// been started. It is implemented by *dependency.Engine.
+type StartCounter interface {
+	StartCounts() map[string]int
+}
+
+// EngineMetrics is a prometheus.Collector that collects the number of
+// times each worker run by a dependency engine has been started. A
+// worker started more than once has been restarted, usually because
+// it failed.
+//
+// Agents replace their dependency engines over their lifetimes, so the
+// engine is set with SetEngine, and may be replaced.
+type EngineMetrics struct {
+	mu     sync.Mutex
+	engine StartCounter
+}
+
+// SetEngine sets the dependency engine whose workers are reported on.
+func (m *EngineMetrics) SetEngine(engine StartCounter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.engine = engine
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *EngineMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- workerStartsDesc
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *EngineMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.mu.Lock()
+	engine := m.engine
+	m.mu.Unlock()
+	if engine == nil {
+		return
+	}
+	counts := engine.StartCounts()
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ch <- prometheus.MustNewConstMetric(
+			workerStartsDesc,
+			prometheus.CounterValue,
+			float64(counts[name]),
+			name,
+		)
+	}
+}
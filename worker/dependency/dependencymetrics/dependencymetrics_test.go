@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dependencymetrics_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/dependency/dependencymetrics"
+)
+
+type engineMetricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&engineMetricsSuite{})
+
+var _ dependencymetrics.StartCounter = (*dependency.Engine)(nil)
+
+type fakeEngine map[string]int
+
+func (e fakeEngine) StartCounts() map[string]int {
+	return e
+}
+
+func collect(collector prometheus.Collector) []prometheus.Metric {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		collector.Collect(ch)
+	}()
+	var metrics []prometheus.Metric
+	for metric := range ch {
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+func (s *engineMetricsSuite) TestDescribe(c *gc.C) {
+	ch := make(chan *prometheus.Desc, 1)
+	var collector dependencymetrics.EngineMetrics
+	collector.Describe(ch)
+	c.Assert((<-ch).String(), gc.Matches, `.*fqName: "juju_worker_starts_total".*`)
+}
+
+func (s *engineMetricsSuite) TestCollectNoEngine(c *gc.C) {
+	var collector dependencymetrics.EngineMetrics
+	c.Assert(collect(&collector), gc.HasLen, 0)
+}
+
+func (s *engineMetricsSuite) TestCollect(c *gc.C) {
+	var collector dependencymetrics.EngineMetrics
+	collector.SetEngine(fakeEngine{"uniter": 3, "api-caller": 1})
+	metrics := collect(&collector)
+	c.Assert(metrics, gc.HasLen, 2)
+
+	var dtoMetrics [2]dto.Metric
+	for i, metric := range metrics {
+		err := metric.Write(&dtoMetrics[i])
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	float64ptr := func(v float64) *float64 {
+		return &v
+	}
+	stringptr := func(v string) *string {
+		return &v
+	}
+	c.Assert(dtoMetrics, jc.DeepEquals, [2]dto.Metric{{
+		Label: []*dto.LabelPair{{
+			Name:  stringptr("worker"),
+			Value: stringptr("api-caller"),
+		}},
+		Counter: &dto.Counter{Value: float64ptr(1)},
+	}, {
+		Label: []*dto.LabelPair{{
+			Name:  stringptr("worker"),
+			Value: stringptr("uniter"),
+		}},
+		Counter: &dto.Counter{Value: float64ptr(3)},
+	}})
+}
@@ -201,6 +201,18 @@ func (engine *Engine) Report() map[string]interface{} {
 	}
 }
 
+// StartCounts returns the number of times the worker of each installed
+// manifold has been started. It is goroutine-safe.
+func (engine *Engine) StartCounts() map[string]int {
+	manifolds, _ := engine.Report()[KeyManifolds].(map[string]interface{})
+	counts := make(map[string]int)
+	for name, report := range manifolds {
+		report, _ := report.(map[string]interface{})
+		counts[name], _ = report[KeyStartCount].(int)
+	}
+	return counts
+}
+
 // liveReport collects and returns information about the engine, its manifolds,
 // and their workers. It must only be called from the loop goroutine.
 func (engine *Engine) liveReport() map[string]interface{} {
@@ -234,6 +246,7 @@ func (engine *Engine) manifoldsReport() map[string]interface{} {
 			KeyState:       info.state(),
 			KeyInputs:      engine.manifolds[name].Inputs,
 			KeyResourceLog: resourceLogReport(info.resourceLog),
+			KeyStartCount:  info.startCount,
 		}
 		if info.err != nil {
 			report[KeyError] = info.err.Error()
@@ -487,6 +500,7 @@ func (engine *Engine) gotStarted(name string, worker worker.Worker, resourceLog
 		engine.current[name] = workerInfo{
 			worker:      worker,
 			resourceLog: resourceLog,
+			startCount:  info.startCount + 1,
 		}
 
 		// Any manifold that declares this one as an input needs to be restarted.
@@ -519,6 +533,7 @@ func (engine *Engine) gotStopped(name string, err error, resourceLog []resourceA
 	engine.current[name] = workerInfo{
 		err:         err,
 		resourceLog: resourceLog,
+		startCount:  info.startCount,
 	}
 	if engine.isDying() {
 		logger.Tracef("permanently stopped %q manifold worker (shutting down)", name)
@@ -631,6 +646,10 @@ type workerInfo struct {
 	worker      worker.Worker
 	err         error
 	resourceLog []resourceAccess
+
+	// startCount is the number of times the worker has been
+	// started.
+	startCount int
 }
 
 // stopped returns true unless the worker is either assigned or starting.
@@ -63,4 +63,8 @@ const (
 	// KeyType holds a string representation of the type by which a resource
 	// was accessed.
 	KeyType = "type"
+
+	// KeyStartCount holds the number of times a manifold's worker has
+	// been started, so that frequently restarting workers can be spotted.
+	KeyStartCount = "start-count"
 )
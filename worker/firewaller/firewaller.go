@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
+	"github.com/juju/juju/worker/retrystrategy"
 )
 
 // FirewallerAPI exposes functionality off the firewaller API facade to a worker.
@@ -43,6 +44,8 @@ type FirewallerAPI interface {
 	MacaroonForRelation(relationKey string) (*macaroon.Macaroon, error)
 	SetRelationStatus(relationKey string, status relation.Status, message string) error
 	FirewallRules(serviceNames ...string) ([]params.FirewallRule, error)
+	WatchEgressRules() (watcher.StringsWatcher, error)
+	EgressRule(tag names.ApplicationTag) ([]string, error)
 }
 
 // CrossModelFirewallerFacade exposes firewaller functionality on the
@@ -85,6 +88,11 @@ type Config struct {
 	NewCrossModelFacadeFunc newCrossModelFacadeFunc
 
 	Clock clock.Clock
+
+	// RetryBudgets, if not nil, is shared with the other workers
+	// that make cloud calls, so that they all back off together
+	// when the cloud is failing.
+	RetryBudgets *retrystrategy.Budgets
 }
 
 // Validate returns an error if cfg cannot drive a Worker.
@@ -124,6 +132,7 @@ type Firewaller struct {
 
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	egressWatcher        watcher.StringsWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -139,6 +148,7 @@ type Firewaller struct {
 	relationIngress            map[names.RelationTag]*remoteRelationData
 	relationWorkerRunner       *worker.Runner
 	pollClock                  clock.Clock
+	retryBudgets               *retrystrategy.Budgets
 }
 
 // NewFirewaller returns a new Firewaller.
@@ -165,6 +175,7 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		relationIngress:            make(map[names.RelationTag]*remoteRelationData),
 		localRelationsChange:       make(chan *remoteRelationNetworkChange),
 		pollClock:                  clk,
+		retryBudgets:               cfg.RetryBudgets,
 		relationWorkerRunner: worker.NewRunner(worker.RunnerParams{
 			Clock: clk,
 
@@ -215,6 +226,17 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	// Controllers older than the Firewaller v5 facade do not know
+	// about egress rules; all outbound traffic stays unrestricted.
+	fw.egressWatcher, err = fw.firewallerApi.WatchEgressRules()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("egress rules not supported: %v", err)
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start egress rules watcher")
+	} else if err := fw.catacomb.Add(fw.egressWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	fw.remoteRelationsWatcher, err = fw.remoteRelationsApi.WatchRemoteRelations()
 	if err != nil {
 		return errors.Trace(err)
@@ -233,6 +255,10 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var egressChange watcher.StringsChannel
+	if fw.egressWatcher != nil {
+		egressChange = fw.egressWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -271,6 +297,15 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case change, ok := <-egressChange:
+			if !ok {
+				return errors.New("egress rules watcher closed")
+			}
+			for _, appName := range change {
+				if err := fw.egressRuleChanged(names.NewApplicationTag(appName)); err != nil {
+					return errors.Trace(err)
+				}
+			}
 		case change, ok := <-fw.remoteRelationsWatcher.Changes():
 			if !ok {
 				return errors.New("remote relations watcher closed")
@@ -326,6 +361,43 @@ func (fw *Firewaller) relationIngressChanged(change *remoteRelationNetworkChange
 	return nil
 }
 
+// egressRuleChanged reloads the egress rule of the application and
+// updates the instances hosting its units.
+func (fw *Firewaller) egressRuleChanged(tag names.ApplicationTag) error {
+	applicationd, ok := fw.applicationids[tag]
+	if !ok {
+		// The rule will be loaded if units of the application
+		// are later assigned to a watched machine.
+		logger.Debugf("ignoring egress rule change for unknown %v", tag)
+		return nil
+	}
+	egress, err := fw.applicationEgress(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	applicationd.egress = egress
+	unitds := []*unitData{}
+	for _, unitd := range applicationd.unitds {
+		unitds = append(unitds, unitd)
+	}
+	if err := fw.flushUnits(unitds); err != nil {
+		return errors.Annotate(err, "cannot change egress rules")
+	}
+	return nil
+}
+
+// applicationEgress returns the egress policy of the application.
+func (fw *Firewaller) applicationEgress(tag names.ApplicationTag) (egressPolicy, error) {
+	cidrs, err := fw.firewallerApi.EgressRule(tag)
+	if errors.IsNotFound(err) || errors.IsNotSupported(err) {
+		return egressPolicy{}, nil
+	}
+	if err != nil {
+		return egressPolicy{}, errors.Trace(err)
+	}
+	return egressPolicy{restricted: true, cidrs: cidrs}, nil
+}
+
 // startMachine creates a new data value for tracking details of the
 // machine and starts watching the machine for units added or removed.
 func (fw *Firewaller) startMachine(tag names.MachineTag) error {
@@ -447,10 +519,15 @@ func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 	if err != nil {
 		return err
 	}
+	egress, err := fw.applicationEgress(app.Tag())
+	if err != nil {
+		return err
+	}
 	applicationd := &applicationData{
 		fw:          fw,
 		application: app,
 		exposed:     exposed,
+		egress:      egress,
 		unitds:      make(map[names.UnitTag]*unitData),
 	}
 	fw.applicationids[app.Tag()] = applicationd
@@ -488,13 +565,17 @@ func (fw *Firewaller) reconcileGlobal() error {
 	toOpen, toClose := diffRanges(initialPortRanges, want)
 	if len(toOpen) > 0 {
 		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environFirewaller.OpenPorts(toOpen); err != nil {
+		if err := fw.withinRetryBudget(func() error {
+			return fw.environFirewaller.OpenPorts(toOpen)
+		}); err != nil {
 			return err
 		}
 	}
 	if len(toClose) > 0 {
 		logger.Infof("closing global ports %v", toClose)
-		if err := fw.environFirewaller.ClosePorts(toClose); err != nil {
+		if err := fw.withinRetryBudget(func() error {
+			return fw.environFirewaller.ClosePorts(toClose)
+		}); err != nil {
 			return err
 		}
 	}
@@ -533,6 +614,17 @@ func (fw *Firewaller) reconcileInstances() error {
 		}
 		machineId := machined.tag.Id()
 
+		if egressInstance, ok := instances[0].(instance.InstanceEgressFirewaller); ok {
+			cidrs, restricted, err := egressInstance.EgressRestriction(machineId)
+			if err != nil {
+				return err
+			}
+			machined.egress = egressPolicy{restricted: restricted, cidrs: cidrs}
+			if err := fw.flushInstanceEgress(machined); err != nil {
+				return err
+			}
+		}
+
 		fwInstance, ok := instances[0].(instance.InstanceFirewaller)
 		if !ok {
 			return nil
@@ -548,7 +640,9 @@ func (fw *Firewaller) reconcileInstances() error {
 		if len(toOpen) > 0 {
 			logger.Infof("opening instance port ranges %v for %q",
 				toOpen, machined.tag)
-			if err := fwInstance.OpenPorts(machineId, toOpen); err != nil {
+			if err := fw.withinRetryBudget(func() error {
+				return fwInstance.OpenPorts(machineId, toOpen)
+			}); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
@@ -556,7 +650,9 @@ func (fw *Firewaller) reconcileInstances() error {
 		if len(toClose) > 0 {
 			logger.Infof("closing instance port ranges %v for %q",
 				toClose, machined.tag)
-			if err := fwInstance.ClosePorts(machineId, toClose); err != nil {
+			if err := fw.withinRetryBudget(func() error {
+				return fwInstance.ClosePorts(machineId, toClose)
+			}); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
@@ -710,7 +806,10 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 	if fw.globalMode {
 		return fw.flushGlobalPorts(toOpen, toClose)
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	if err := fw.flushInstancePorts(machined, toOpen, toClose); err != nil {
+		return err
+	}
+	return fw.flushInstanceEgress(machined)
 }
 
 // gatherIngressRules returns the ingress rules to open and close
@@ -831,7 +930,9 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule)
 	}
 	// Open and close the ports.
 	if len(toOpen) > 0 {
-		if err := fw.environFirewaller.OpenPorts(toOpen); err != nil {
+		if err := fw.withinRetryBudget(func() error {
+			return fw.environFirewaller.OpenPorts(toOpen)
+		}); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
@@ -839,7 +940,9 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule)
 		logger.Infof("opened port ranges %v in environment", toOpen)
 	}
 	if len(toClose) > 0 {
-		if err := fw.environFirewaller.ClosePorts(toClose); err != nil {
+		if err := fw.withinRetryBudget(func() error {
+			return fw.environFirewaller.ClosePorts(toClose)
+		}); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
@@ -849,6 +952,16 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule)
 	return nil
 }
 
+// withinRetryBudget calls f, which changes the firewall rules in the
+// cloud, once the shared retry budget for network operations allows.
+func (fw *Firewaller) withinRetryBudget(f func() error) error {
+	err := fw.retryBudgets.Do(retrystrategy.NetworkOperations, fw.catacomb.Dying(), f)
+	if err == retrystrategy.ErrAborted {
+		return fw.catacomb.ErrDying()
+	}
+	return err
+}
+
 // flushInstancePorts opens and closes ports global on the machine.
 func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose []network.IngressRule) error {
 	// If there's nothing to do, do nothing.
@@ -887,7 +1000,9 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 
 	// Open and close the ports.
 	if len(toOpen) > 0 {
-		if err := fwInstance.OpenPorts(machineId, toOpen); err != nil {
+		if err := fw.withinRetryBudget(func() error {
+			return fwInstance.OpenPorts(machineId, toOpen)
+		}); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
@@ -895,7 +1010,9 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
 	}
 	if len(toClose) > 0 {
-		if err := fwInstance.ClosePorts(machineId, toClose); err != nil {
+		if err := fw.withinRetryBudget(func() error {
+			return fwInstance.ClosePorts(machineId, toClose)
+		}); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
@@ -905,6 +1022,61 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	return nil
 }
 
+// flushInstanceEgress restricts or unrestricts the outbound traffic of
+// the machine's instance to match the egress rules of the applications
+// with units on it. A machine that is not yet provisioned is left alone;
+// its restriction is applied on the next flush once it is.
+func (fw *Firewaller) flushInstanceEgress(machined *machineData) error {
+	want := machined.wantEgress()
+	if want.equal(machined.egress) {
+		return nil
+	}
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	machineId := machined.tag.Id()
+	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	instances, err := fw.environInstances.Instances([]instance.Id{instanceId})
+	if err != nil {
+		return err
+	}
+	egressInstance, ok := instances[0].(instance.InstanceEgressFirewaller)
+	if !ok {
+		logger.Infof("flushInstanceEgress called on an instance of type %T which doesn't support egress rules.", instances[0])
+		machined.egress = want
+		return nil
+	}
+	if want.restricted {
+		err = fw.withinRetryBudget(func() error {
+			return egressInstance.RestrictEgress(machineId, want.cidrs)
+		})
+	} else {
+		err = fw.withinRetryBudget(func() error {
+			return egressInstance.UnrestrictEgress(machineId)
+		})
+	}
+	if err != nil {
+		return err
+	}
+	machined.egress = want
+	if want.restricted {
+		logger.Infof("restricted egress to %v on %q", want.cidrs, machined.tag)
+	} else {
+		logger.Infof("unrestricted egress on %q", machined.tag)
+	}
+	return nil
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -1002,12 +1174,33 @@ type machineData struct {
 	ingressRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[names.UnitTag]portRanges
+	// egress restriction applied to the machine's instance
+	egress egressPolicy
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
 	return md.fw.firewallerApi.Machine(md.tag)
 }
 
+// wantEgress returns the egress policy for the machine's instance. The
+// instance is only restricted if every application with units on it is,
+// and then to the union of the CIDRs allowed for those applications.
+func (md *machineData) wantEgress() egressPolicy {
+	if len(md.unitds) == 0 {
+		return egressPolicy{}
+	}
+	cidrs := set.NewStrings()
+	for _, unitd := range md.unitds {
+		if !unitd.applicationd.egress.restricted {
+			return egressPolicy{}
+		}
+		for _, cidr := range unitd.applicationd.egress.cidrs {
+			cidrs.Add(cidr)
+		}
+	}
+	return egressPolicy{restricted: true, cidrs: cidrs.SortedValues()}
+}
+
 // watchLoop watches the machine for units added or removed.
 func (md *machineData) watchLoop(unitw watcher.StringsWatcher) error {
 	if err := md.catacomb.Add(unitw); err != nil {
@@ -1061,9 +1254,25 @@ type applicationData struct {
 	fw          *Firewaller
 	application *firewaller.Application
 	exposed     bool
+	egress      egressPolicy
 	unitds      map[names.UnitTag]*unitData
 }
 
+// egressPolicy describes the outbound traffic allowed for an
+// application or instance. The zero value allows all traffic.
+type egressPolicy struct {
+	restricted bool
+	cidrs      []string
+}
+
+func (p egressPolicy) equal(other egressPolicy) bool {
+	if p.restricted != other.restricted {
+		return false
+	}
+	return set.NewStrings(p.cidrs...).Difference(set.NewStrings(other.cidrs...)).IsEmpty() &&
+		set.NewStrings(other.cidrs...).Difference(set.NewStrings(p.cidrs...)).IsEmpty()
+}
+
 // watchLoop watches the application's exposed flag for changes.
 func (ad *applicationData) watchLoop(exposed bool) error {
 	appWatcher, err := ad.application.Watch()
@@ -164,6 +164,32 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Ing
 	}
 }
 
+// assertEgress retrieves the egress restriction of the instance and
+// compares it to the expected.
+func (s *firewallerBaseSuite) assertEgress(c *gc.C, inst instance.Instance, machineId string, restricted bool, expected []string) {
+	egressInst, ok := inst.(instance.InstanceEgressFirewaller)
+	c.Assert(ok, gc.Equals, true)
+
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, gotRestricted, err := egressInst.EgressRestriction(machineId)
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		if gotRestricted == restricted && reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %v %q; got %v %q", restricted, expected, gotRestricted, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
 func (s *firewallerBaseSuite) addUnit(c *gc.C, app *state.Application) (*state.Unit, *state.Machine) {
 	u, err := app.AddUnit(state.AddUnitParams{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -335,6 +361,45 @@ func (s *InstanceModeSuite) TestMultipleExposedApplications(c *gc.C) {
 	s.assertPorts(c, inst2, m2.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestEgressRules(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app1 := s.AddTestingApplication(c, "wordpress", s.charm)
+	app2 := s.AddTestingApplication(c, "mysql", s.charm)
+	u1, m := s.addUnit(c, app1)
+	inst := s.startInstance(c, m)
+	err := u1.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules := state.NewFirewallRules(s.State)
+	err = rules.SaveEgress(state.EgressRule{
+		Application:  "wordpress",
+		AllowedCIDRs: []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgress(c, inst, m.Id(), true, []string{"10.0.0.0/8"})
+
+	// An unrestricted application on the same machine lifts the
+	// restriction for the whole instance.
+	u2, err := app2.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgress(c, inst, m.Id(), false, []string{})
+
+	err = rules.SaveEgress(state.EgressRule{
+		Application:  "mysql",
+		AllowedCIDRs: []string{"192.168.0.0/16"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgress(c, inst, m.Id(), true, []string{"10.0.0.0/8", "192.168.0.0/16"})
+
+	err = rules.RemoveEgress("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgress(c, inst, m.Id(), false, []string{})
+}
+
 func (s *InstanceModeSuite) TestMachineWithoutInstanceId(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -15,13 +15,15 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/retrystrategy"
 )
 
 // ManifoldConfig describes the resources used by the firewaller worker.
 type ManifoldConfig struct {
-	AgentName     string
-	APICallerName string
-	EnvironName   string
+	AgentName       string
+	APICallerName   string
+	EnvironName     string
+	RetryBudgetName string
 
 	NewControllerConnection  apicaller.NewExternalControllerConnectionFunc
 	NewRemoteRelationsFacade func(base.APICaller) (*remoterelations.Client, error)
@@ -36,6 +38,7 @@ func Manifold(cfg ManifoldConfig) dependency.Manifold {
 			cfg.AgentName,
 			cfg.APICallerName,
 			cfg.EnvironName,
+			cfg.RetryBudgetName,
 		},
 		Start: cfg.start,
 	}
@@ -52,6 +55,9 @@ func (cfg ManifoldConfig) Validate() error {
 	if cfg.EnvironName == "" {
 		return errors.NotValidf("empty EnvironName")
 	}
+	if cfg.RetryBudgetName == "" {
+		return errors.NotValidf("empty RetryBudgetName")
+	}
 	if cfg.NewControllerConnection == nil {
 		return errors.NotValidf("nil NewControllerConnection")
 	}
@@ -87,6 +93,11 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		return nil, errors.Trace(err)
 	}
 
+	var retryBudgets *retrystrategy.Budgets
+	if err := context.Get(cfg.RetryBudgetName, &retryBudgets); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// Check if the env supports global firewalling.  If the
 	// configured mode is instance, we can ignore fwEnv being a
 	// nil value, as it won't be used.
@@ -120,6 +131,7 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		EnvironInstances:   environ,
 		Mode:               mode,
 		NewCrossModelFacadeFunc: crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
+		RetryBudgets:            retryBudgets,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
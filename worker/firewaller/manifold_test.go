@@ -39,6 +39,7 @@ func (s *ManifoldSuite) TestManifoldFirewallModeNone(c *gc.C) {
 		AgentName:                "agent",
 		APICallerName:            "api-caller",
 		EnvironName:              "environ",
+		RetryBudgetName:          "retry-budget",
 		NewControllerConnection:  func(*api.Info) (api.Connection, error) { return nil, nil },
 		NewFirewallerFacade:      func(base.APICaller) (firewaller.FirewallerAPI, error) { return nil, nil },
 		NewFirewallerWorker:      func(firewaller.Config) (worker.Worker, error) { return nil, nil },
@@ -86,6 +87,7 @@ func (s *ManifoldConfigSuite) validConfig() firewaller.ManifoldConfig {
 		AgentName:                "agent",
 		APICallerName:            "api-caller",
 		EnvironName:              "environ",
+		RetryBudgetName:          "retry-budget",
 		NewControllerConnection:  func(*api.Info) (api.Connection, error) { return nil, nil },
 		NewFirewallerFacade:      func(base.APICaller) (firewaller.FirewallerAPI, error) { return nil, nil },
 		NewFirewallerWorker:      func(firewaller.Config) (worker.Worker, error) { return nil, nil },
@@ -112,6 +114,11 @@ func (s *ManifoldConfigSuite) TestMissingEnvironName(c *gc.C) {
 	s.checkNotValid(c, "empty EnvironName not valid")
 }
 
+func (s *ManifoldConfigSuite) TestMissingRetryBudgetName(c *gc.C) {
+	s.config.RetryBudgetName = ""
+	s.checkNotValid(c, "empty RetryBudgetName not valid")
+}
+
 func (s *ManifoldConfigSuite) TestMissingNewFirewallerFacade(c *gc.C) {
 	s.config.NewFirewallerFacade = nil
 	s.checkNotValid(c, "nil NewFirewallerFacade not valid")
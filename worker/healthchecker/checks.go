@@ -0,0 +1,183 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthchecker
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// DefaultCheckTimeout is how long a health check may take when its
+// charm does not declare a timeout.
+const DefaultCheckTimeout = 10 * time.Second
+
+// HealthCheck describes one of the health checks declared under
+// health-checks in a charm's metadata.yaml. Exactly one of HTTP, TCP
+// and Exec is set.
+type HealthCheck struct {
+	// Name is the name of the health check.
+	Name string
+
+	// HTTP is a URL that must respond with a non-error status.
+	HTTP string
+
+	// TCP is a host:port address that must accept connections.
+	TCP string
+
+	// Exec is a command, run in the charm directory, that must
+	// exit successfully.
+	Exec string
+
+	// Timeout is how long the check may take before it fails.
+	Timeout time.Duration
+}
+
+type healthCheckMeta struct {
+	HTTP    string `yaml:"http"`
+	TCP     string `yaml:"tcp"`
+	Exec    string `yaml:"exec"`
+	Timeout string `yaml:"timeout"`
+}
+
+// ReadHealthChecks returns the health checks declared by the charm in
+// charmDir, ordered by name. No checks are returned if the charm has
+// not been deployed yet.
+func ReadHealthChecks(charmDir string) ([]HealthCheck, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var meta struct {
+		HealthChecks map[string]healthCheckMeta `yaml:"health-checks"`
+	}
+	if err := goyaml.Unmarshal(data, &meta); err != nil {
+		return nil, errors.Annotate(err, "cannot parse charm metadata")
+	}
+	checks := make([]HealthCheck, 0, len(meta.HealthChecks))
+	for name, m := range meta.HealthChecks {
+		check, err := newHealthCheck(name, m)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		checks = append(checks, check)
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Name < checks[j].Name
+	})
+	return checks, nil
+}
+
+func newHealthCheck(name string, m healthCheckMeta) (HealthCheck, error) {
+	check := HealthCheck{
+		Name:    name,
+		HTTP:    m.HTTP,
+		TCP:     m.TCP,
+		Exec:    m.Exec,
+		Timeout: DefaultCheckTimeout,
+	}
+	kinds := 0
+	for _, value := range []string{m.HTTP, m.TCP, m.Exec} {
+		if value != "" {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return HealthCheck{}, errors.NotValidf("health check %q without exactly one of http, tcp or exec", name)
+	}
+	if m.Timeout != "" {
+		timeout, err := time.ParseDuration(m.Timeout)
+		if err != nil || timeout <= 0 {
+			return HealthCheck{}, errors.NotValidf("health check %q timeout %q", name, m.Timeout)
+		}
+		check.Timeout = timeout
+	}
+	return check, nil
+}
+
+// Checker runs health checks.
+type Checker interface {
+	// Check runs the health check for the charm in charmDir, and
+	// returns an error describing why it failed, if it did.
+	Check(check HealthCheck, charmDir string) error
+}
+
+// NewChecker returns a Checker that runs health checks on the local
+// machine.
+func NewChecker() Checker {
+	return localChecker{}
+}
+
+type localChecker struct{}
+
+// Check is part of the Checker interface.
+func (localChecker) Check(check HealthCheck, charmDir string) error {
+	switch {
+	case check.HTTP != "":
+		return checkHTTP(check.HTTP, check.Timeout)
+	case check.TCP != "":
+		return checkTCP(check.TCP, check.Timeout)
+	case check.Exec != "":
+		return checkExec(check.Exec, charmDir, check.Timeout)
+	}
+	return errors.NotValidf("health check %q", check.Name)
+}
+
+func checkHTTP(url string, timeout time.Duration) error {
+	client := http.Client{Timeout: timeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("%s", resp.Status)
+	}
+	return nil
+}
+
+func checkTCP(address string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return conn.Close()
+}
+
+func checkExec(command, charmDir string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Dir = charmDir
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return errors.Errorf("timed out after %v", timeout)
+	}
+	if err != nil {
+		if len(output) > 0 {
+			return errors.Errorf("%v: %s", err, lastLine(output))
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// lastLine returns the last non-empty line of the command output,
+// which usually explains why it failed.
+func lastLine(output []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return lines[len(lines)-1]
+}
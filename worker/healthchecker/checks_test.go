@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthchecker_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/healthchecker"
+)
+
+type ChecksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ChecksSuite{})
+
+func writeCharmMetadata(c *gc.C, metadata string) string {
+	charmDir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(charmDir, "metadata.yaml"), []byte(metadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return charmDir
+}
+
+func (s *ChecksSuite) TestReadHealthChecks(c *gc.C) {
+	charmDir := writeCharmMetadata(c, `
+name: mysql
+health-checks:
+  web:
+    http: http://localhost/status
+  db:
+    tcp: localhost:3306
+    timeout: 30s
+  script:
+    exec: ./check
+`)
+	checks, err := healthchecker.ReadHealthChecks(charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, jc.DeepEquals, []healthchecker.HealthCheck{
+		{Name: "db", TCP: "localhost:3306", Timeout: 30 * time.Second},
+		{Name: "script", Exec: "./check", Timeout: healthchecker.DefaultCheckTimeout},
+		{Name: "web", HTTP: "http://localhost/status", Timeout: healthchecker.DefaultCheckTimeout},
+	})
+}
+
+func (s *ChecksSuite) TestReadHealthChecksNotDeployed(c *gc.C) {
+	checks, err := healthchecker.ReadHealthChecks(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 0)
+}
+
+func (s *ChecksSuite) TestReadHealthChecksInvalid(c *gc.C) {
+	for i, metadata := range []string{
+		"health-checks:\n  web:\n    timeout: 1s\n",
+		"health-checks:\n  web:\n    http: http://localhost\n    tcp: localhost:80\n",
+		"health-checks:\n  web:\n    http: http://localhost\n    timeout: soon\n",
+	} {
+		c.Logf("test %d", i)
+		_, err := healthchecker.ReadHealthChecks(writeCharmMetadata(c, metadata))
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *ChecksSuite) TestCheckHTTP(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ok" {
+			http.Error(w, "broken", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	checker := healthchecker.NewChecker()
+	err := checker.Check(healthchecker.HealthCheck{
+		Name: "web", HTTP: server.URL + "/ok", Timeout: time.Second,
+	}, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	err = checker.Check(healthchecker.HealthCheck{
+		Name: "web", HTTP: server.URL + "/broken", Timeout: time.Second,
+	}, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "500 Internal Server Error")
+}
+
+func (s *ChecksSuite) TestCheckTCP(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	address := listener.Addr().String()
+
+	checker := healthchecker.NewChecker()
+	check := healthchecker.HealthCheck{Name: "db", TCP: address, Timeout: time.Second}
+	err = checker.Check(check, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+
+	listener.Close()
+	err = checker.Check(check, c.MkDir())
+	c.Assert(err, gc.NotNil)
+}
+
+func (s *ChecksSuite) TestCheckExec(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("exec health checks use /bin/sh")
+	}
+	charmDir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(charmDir, "status"), []byte("ok"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	checker := healthchecker.NewChecker()
+	err = checker.Check(healthchecker.HealthCheck{
+		Name: "script", Exec: "test -f status", Timeout: time.Second,
+	}, charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	err = checker.Check(healthchecker.HealthCheck{
+		Name: "script", Exec: "echo starting; echo not ready >&2; exit 1", Timeout: time.Second,
+	}, charmDir)
+	c.Assert(err, gc.ErrorMatches, "exit status 1: not ready")
+	err = checker.Check(healthchecker.HealthCheck{
+		Name: "script", Exec: "sleep 10", Timeout: 10 * time.Millisecond,
+	}, charmDir)
+	c.Assert(err, gc.ErrorMatches, "timed out after 10ms")
+}
@@ -0,0 +1,14 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthchecker
+
+import (
+	"github.com/juju/juju/api/base"
+	apihealthchecker "github.com/juju/juju/api/healthchecker"
+)
+
+// NewFacade returns a Facade backed by the HealthChecker API facade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return apihealthchecker.NewFacade(apiCaller), nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthchecker provides a machine agent worker that regularly
+// runs the health checks declared by the charms of the units deployed
+// to the machine, and reports the results to the controller.
+package healthchecker
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.healthchecker")
+
+// Facade exposes controller functionality to a Worker.
+type Facade interface {
+	SetHealthCheckResults(unitName string, results []params.HealthCheckResult) error
+}
+
+// Config defines the parameters of the healthchecker worker.
+type Config struct {
+	Facade   Facade
+	Checker  Checker
+	Clock    clock.Clock
+	DataDir  string
+	Interval time.Duration
+}
+
+// Validate returns an error if Config cannot drive a healthchecker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Checker == nil {
+		return errors.NotValidf("nil Checker")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.DataDir == "" {
+		return errors.NotValidf("empty DataDir")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// Worker runs the health checks of the units deployed to a machine
+// at regular intervals.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a Worker backed by config, or an error.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+			if err := w.checkUnits(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// checkUnits runs the health checks of each unit deployed to the
+// machine, and reports their results.
+func (w *Worker) checkUnits() error {
+	unitNames, err := w.deployedUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, unitName := range unitNames {
+		charmDir := filepath.Join(agent.Dir(w.config.DataDir, names.NewUnitTag(unitName)), "charm")
+		checks, err := ReadHealthChecks(charmDir)
+		if err != nil {
+			logger.Warningf("cannot read health checks for unit %q: %v", unitName, err)
+			continue
+		}
+		if len(checks) == 0 {
+			continue
+		}
+		results := make([]params.HealthCheckResult, len(checks))
+		for i, check := range checks {
+			err := w.config.Checker.Check(check, charmDir)
+			results[i] = params.HealthCheckResult{
+				Check:   check.Name,
+				Healthy: err == nil,
+				Time:    w.config.Clock.Now(),
+			}
+			if err != nil {
+				logger.Debugf("health check %q for unit %q failed: %v", check.Name, unitName, err)
+				results[i].Message = err.Error()
+			}
+		}
+		err = w.config.Facade.SetHealthCheckResults(unitName, results)
+		if params.IsCodeUnauthorized(err) {
+			// The unit has most likely been removed, and the
+			// deployer has yet to clean up its agent directory.
+			logger.Debugf("cannot report health checks for unit %q: %v", unitName, err)
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "cannot report health checks for unit %q", unitName)
+		}
+	}
+	return nil
+}
+
+// deployedUnits returns the names of the units whose agent directories
+// are found under the data directory.
+func (w *Worker) deployedUnits() ([]string, error) {
+	agentsDir := agent.BaseDir(w.config.DataDir)
+	infos, err := ioutil.ReadDir(agentsDir)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list unit agents")
+	}
+	var unitNames []string
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		tag, err := names.ParseUnitTag(info.Name())
+		if err != nil {
+			continue
+		}
+		unitNames = append(unitNames, tag.Id())
+	}
+	return unitNames, nil
+}
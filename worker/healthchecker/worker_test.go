@@ -0,0 +1,202 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthchecker_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/healthchecker"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	dataDir string
+	clock   *testing.Clock
+	facade  *mockFacade
+	checker *mockChecker
+	config  healthchecker.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+const healthChecksMetadata = `
+name: mysql
+summary: a database
+description: a database
+health-checks:
+  db:
+    tcp: localhost:3306
+  status:
+    exec: ./status
+    timeout: 2s
+`
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dataDir = c.MkDir()
+	s.writeMetadata(c, "unit-mysql-0", healthChecksMetadata)
+	s.writeMetadata(c, "unit-logging-0", "name: logging\n")
+	err := os.MkdirAll(filepath.Join(s.dataDir, "agents", "machine-0"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.clock = testing.NewClock(coretesting.ZeroTime())
+	s.facade = &mockFacade{calls: make(chan facadeCall, 10)}
+	s.checker = &mockChecker{failures: map[string]error{
+		"status": errors.New("exit status 1"),
+	}}
+	s.config = healthchecker.Config{
+		Facade:   s.facade,
+		Checker:  s.checker,
+		Clock:    s.clock,
+		DataDir:  s.dataDir,
+		Interval: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) writeMetadata(c *gc.C, agentName, metadata string) {
+	charmDir := filepath.Join(s.dataDir, "agents", agentName, "charm")
+	err := os.MkdirAll(charmDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(charmDir, "metadata.yaml"), []byte(metadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		update func(*healthchecker.Config)
+		err    string
+	}{{
+		func(cfg *healthchecker.Config) { cfg.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(cfg *healthchecker.Config) { cfg.Checker = nil },
+		"nil Checker not valid",
+	}, {
+		func(cfg *healthchecker.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *healthchecker.Config) { cfg.DataDir = "" },
+		"empty DataDir not valid",
+	}, {
+		func(cfg *healthchecker.Config) { cfg.Interval = 0 },
+		"non-positive Interval not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.update(&config)
+		_, err := healthchecker.NewWorker(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *WorkerSuite) TestReportsHealthChecks(c *gc.C) {
+	w, err := healthchecker.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	for i := 0; i < 2; i++ {
+		err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+		call := s.waitCall(c)
+		c.Assert(call, jc.DeepEquals, facadeCall{
+			unitName: "mysql/0",
+			results: []params.HealthCheckResult{{
+				Check:   "db",
+				Healthy: true,
+				Time:    s.clock.Now(),
+			}, {
+				Check:   "status",
+				Healthy: false,
+				Message: "exit status 1",
+				Time:    s.clock.Now(),
+			}},
+		})
+		s.assertNoCall(c)
+	}
+	c.Assert(s.checker.charmDirs, jc.SameContents, []string{
+		filepath.Join(s.dataDir, "agents", "unit-mysql-0", "charm"),
+	})
+}
+
+func (s *WorkerSuite) TestIgnoresUnauthorizedUnits(c *gc.C) {
+	s.facade.err = &params.Error{Code: params.CodeUnauthorized, Message: "permission denied"}
+	w, err := healthchecker.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestReportError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := healthchecker.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, `cannot report health checks for unit "mysql/0": boom`)
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C) facadeCall {
+	select {
+	case call := <-s.facade.calls:
+		return call
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for health check results")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoCall(c *gc.C) {
+	select {
+	case call := <-s.facade.calls:
+		c.Fatalf("unexpected health check results %v", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type facadeCall struct {
+	unitName string
+	results  []params.HealthCheckResult
+}
+
+type mockFacade struct {
+	calls chan facadeCall
+	err   error
+}
+
+func (f *mockFacade) SetHealthCheckResults(unitName string, results []params.HealthCheckResult) error {
+	f.calls <- facadeCall{unitName, results}
+	return f.err
+}
+
+type mockChecker struct {
+	failures  map[string]error
+	charmDirs []string
+}
+
+func (m *mockChecker) Check(check healthchecker.HealthCheck, charmDir string) error {
+	if len(m.charmDirs) == 0 || m.charmDirs[len(m.charmDirs)-1] != charmDir {
+		m.charmDirs = append(m.charmDirs, charmDir)
+	}
+	return m.failures[check.Name]
+}
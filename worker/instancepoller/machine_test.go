@@ -46,7 +46,7 @@ func (s *machineSuite) TestSetsInstanceInfoInitially(c *gc.C) {
 	died := make(chan machine)
 
 	clock := newTestClock()
-	go runMachine(context, m, nil, died, clock)
+	go runMachine(context, m, nil, nil, died, clock)
 	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
 	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
 
@@ -71,7 +71,7 @@ func (s *machineSuite) TestSetsInstanceInfoDeadMachineInitially(c *gc.C) {
 	died := make(chan machine)
 
 	clock := newTestClock()
-	go runMachine(context, m, nil, died, clock)
+	go runMachine(context, m, nil, nil, died, clock)
 	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
 	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
 
@@ -134,7 +134,7 @@ func (s *machineSuite) TestNoPollWhenNotProvisioned(c *gc.C) {
 
 	clock := gitjujutesting.NewClock(time.Time{})
 	changed := make(chan struct{})
-	go runMachine(context, m, changed, died, clock)
+	go runMachine(context, m, changed, nil, died, clock)
 
 	expectPoll := func() {
 		c.Assert(clock.WaitAdvance(ShortPoll, 0, 1), jc.ErrorIsNil)
@@ -193,6 +193,76 @@ func (s *machineSuite) TestLongPollIntervalWhenHasAllInstanceInfo(c *gc.C) {
 	clock.CheckCall(c, 0, "After", LongPoll)
 }
 
+func (s *machineSuite) TestPollIntervalsFromContext(c *gc.C) {
+	context := &testMachineContext{
+		getInstanceInfo: instanceInfoGetter(c, "i1234", testAddrs, "running", nil),
+		dyingc:          make(chan struct{}),
+		shortPoll:       5 * time.Second,
+		longPoll:        20 * time.Second,
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		refresh:    func() error { return nil },
+		life:       params.Alive,
+		status:     status.Pending,
+	}
+	died := make(chan machine)
+
+	clock := newTestClock()
+	go runMachine(context, m, nil, nil, died, clock)
+	c.Assert(clock.WaitAdvance(10*time.Second, 0, 1), jc.ErrorIsNil)
+	c.Assert(clock.WaitAdvance(20*time.Second, 0, 1), jc.ErrorIsNil)
+	c.Assert(clock.WaitAdvance(20*time.Second, 0, 1), jc.ErrorIsNil)
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+	clock.CheckCall(c, 0, "After", 10*time.Second)
+	clock.CheckCall(c, 1, "After", 20*time.Second)
+	clock.CheckCall(c, 2, "After", 20*time.Second)
+}
+
+func (s *machineSuite) TestInstanceChangedPolls(c *gc.C) {
+	polled := make(chan struct{}, 1)
+	getInstanceInfo := instanceInfoGetter(c, "i1234", testAddrs, "running", nil)
+	context := &testMachineContext{
+		getInstanceInfo: func(id instance.Id) (instanceInfo, error) {
+			polled <- struct{}{}
+			return getInstanceInfo(id)
+		},
+		dyingc: make(chan struct{}),
+	}
+	m := &testMachine{
+		tag:        names.NewMachineTag("99"),
+		instanceId: "i1234",
+		refresh:    func() error { return nil },
+		addresses:  testAddrs,
+		life:       params.Alive,
+		status:     status.Started,
+	}
+	died := make(chan machine)
+	instanceChanged := make(chan struct{}, 1)
+
+	clock := newTestClock()
+	go runMachine(context, m, nil, instanceChanged, died, clock)
+	expectPoll := func() {
+		select {
+		case <-polled:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("expected instance poll")
+		}
+	}
+	expectPoll()
+
+	// The instance should be polled again as soon as the
+	// provider reports a change, without waiting for LongPoll.
+	instanceChanged <- struct{}{}
+	expectPoll()
+
+	killMachineLoop(c, m, context.dyingc, died)
+	c.Assert(context.killErr, gc.Equals, nil)
+}
+
 func testRunMachine(
 	c *gc.C,
 	addrs []network.Address,
@@ -222,7 +292,7 @@ func testRunMachine(
 	}
 	died := make(chan machine)
 
-	go runMachine(context, m, nil, died, clock)
+	go runMachine(context, m, nil, nil, died, clock)
 	test()
 
 	killMachineLoop(c, m, context.dyingc, died)
@@ -248,7 +318,7 @@ func (*machineSuite) TestChangedRefreshes(c *gc.C) {
 	died := make(chan machine)
 	changed := make(chan struct{})
 	clock := newTestClock()
-	go runMachine(context, m, changed, died, clock)
+	go runMachine(context, m, changed, nil, died, clock)
 
 	c.Assert(clock.WaitAdvance(LongPoll, 0, 1), jc.ErrorIsNil)
 	select {
@@ -327,7 +397,7 @@ func testTerminatingErrors(c *gc.C, mutate func(m *testMachine, err error)) {
 	mutate(m, expectErr)
 	died := make(chan machine)
 	changed := make(chan struct{}, 1)
-	go runMachine(context, m, changed, died, newTestClock())
+	go runMachine(context, m, changed, nil, died, newTestClock())
 	changed <- struct{}{}
 	select {
 	case <-died:
@@ -361,6 +431,8 @@ type testMachineContext struct {
 	killErr         error
 	getInstanceInfo func(instance.Id) (instanceInfo, error)
 	dyingc          chan struct{}
+	shortPoll       time.Duration
+	longPoll        time.Duration
 }
 
 func (context *testMachineContext) kill(err error) {
@@ -374,6 +446,17 @@ func (context *testMachineContext) instanceInfo(id instance.Id) (instanceInfo, e
 	return context.getInstanceInfo(id)
 }
 
+func (context *testMachineContext) pollIntervals() (short, long time.Duration) {
+	short, long = ShortPoll, LongPoll
+	if context.shortPoll != 0 {
+		short = context.shortPoll
+	}
+	if context.longPoll != 0 {
+		long = context.longPoll
+	}
+	return short, long
+}
+
 func (context *testMachineContext) dying() <-chan struct{} {
 	return context.dyingc
 }
@@ -27,6 +27,9 @@ var logger = loggo.GetLogger("juju.worker.instancepoller")
 //
 // When a machine has an address and is started LongPoll will be used to
 // check that the instance address or status has not changed.
+//
+// Both may be overridden by the instance-poll-short-interval and
+// instance-poll-interval model config settings.
 var (
 	ShortPoll        = 1 * time.Second
 	ShortPollBackoff = 2.0
@@ -65,17 +68,37 @@ type lifetimeContext interface {
 type machineContext interface {
 	lifetimeContext
 	instanceInfo(id instance.Id) (instanceInfo, error)
+	pollIntervals() (short, long time.Duration)
 }
 
 type updaterContext interface {
 	lifetimeContext
 	newMachineContext() machineContext
 	getMachine(tag names.MachineTag) (machine, error)
+	// instanceChanges returns a channel on which the provider pushes
+	// the IDs of instances that have changed, or nil if the provider
+	// does not support it.
+	instanceChanges() watcher.StringsChannel
+}
+
+// trackedMachine holds the updater's view of a machine whose
+// goroutine is running.
+type trackedMachine struct {
+	machine    machine
+	instanceId instance.Id
+
+	// lifeChanged is signalled when the machine's life may have changed.
+	lifeChanged chan struct{}
+
+	// instanceChanged is signalled when the provider reports that
+	// the machine's instance has changed. It is buffered so that
+	// pending notifications are coalesced.
+	instanceChanged chan struct{}
 }
 
 type updater struct {
 	context     updaterContext
-	machines    map[names.MachineTag]chan struct{}
+	machines    map[names.MachineTag]*trackedMachine
 	machineDead chan machine
 }
 
@@ -86,7 +109,7 @@ type updater struct {
 func watchMachinesLoop(context updaterContext, machinesWatcher watcher.StringsWatcher) (err error) {
 	p := &updater{
 		context:     context,
-		machines:    make(map[names.MachineTag]chan struct{}),
+		machines:    make(map[names.MachineTag]*trackedMachine),
 		machineDead: make(chan machine),
 	}
 	defer func() {
@@ -112,6 +135,11 @@ func watchMachinesLoop(context updaterContext, machinesWatcher watcher.StringsWa
 			if err := p.startMachines(tags); err != nil {
 				return err
 			}
+		case ids, ok := <-p.context.instanceChanges():
+			if !ok {
+				return errors.New("instance changes watcher closed")
+			}
+			p.notifyInstancesChanged(ids)
 		case m := <-p.machineDead:
 			delete(p.machines, m.Tag())
 		}
@@ -120,7 +148,7 @@ func watchMachinesLoop(context updaterContext, machinesWatcher watcher.StringsWa
 
 func (p *updater) startMachines(tags []names.MachineTag) error {
 	for _, tag := range tags {
-		if c := p.machines[tag]; c == nil {
+		if tm := p.machines[tag]; tm == nil {
 			// We don't know about the machine - start
 			// a goroutine to deal with it.
 			m, err := p.context.getMachine(tag)
@@ -146,24 +174,65 @@ func (p *updater) startMachines(tags []names.MachineTag) error {
 				}
 				continue
 			}
-			c = make(chan struct{})
-			p.machines[tag] = c
+			tm = &trackedMachine{
+				machine:         m,
+				lifeChanged:     make(chan struct{}),
+				instanceChanged: make(chan struct{}, 1),
+			}
+			p.machines[tag] = tm
 			// TODO(fwereade): 2016-03-17 lp:1558657
-			go runMachine(p.context.newMachineContext(), m, c, p.machineDead, clock.WallClock)
+			go runMachine(p.context.newMachineContext(), m, tm.lifeChanged, tm.instanceChanged, p.machineDead, clock.WallClock)
 		} else {
 			select {
 			case <-p.context.dying():
 				return p.context.errDying()
-			case c <- struct{}{}:
+			case tm.lifeChanged <- struct{}{}:
 			}
 		}
 	}
 	return nil
 }
 
+// notifyInstancesChanged wakes the goroutines of the machines whose
+// instances are amongst those with the given ids, so that they poll
+// the instances without waiting for their poll interval to elapse.
+func (p *updater) notifyInstancesChanged(ids []string) {
+	changed := make(map[instance.Id]bool, len(ids))
+	for _, id := range ids {
+		changed[instance.Id(id)] = true
+	}
+	for _, tm := range p.machines {
+		if tm.instanceId == "" {
+			instId, err := tm.machine.InstanceId()
+			if params.IsCodeNotProvisioned(err) {
+				continue
+			}
+			if err != nil {
+				logger.Warningf("cannot get instance id for machine %v: %v", tm.machine.Id(), err)
+				continue
+			}
+			tm.instanceId = instId
+		}
+		if !changed[tm.instanceId] {
+			continue
+		}
+		select {
+		case tm.instanceChanged <- struct{}{}:
+		default:
+			// A poll is already pending.
+		}
+	}
+}
+
 // runMachine processes the address and status publishing for a given machine.
 // We assume that the machine is alive when this is first called.
-func runMachine(context machineContext, m machine, changed <-chan struct{}, died chan<- machine, clock clock.Clock) {
+func runMachine(
+	context machineContext,
+	m machine,
+	changed, instanceChanged <-chan struct{},
+	died chan<- machine,
+	clock clock.Clock,
+) {
 	defer func() {
 		// We can't just send on the died channel because the
 		// central loop might be trying to write to us on the
@@ -176,17 +245,26 @@ func runMachine(context machineContext, m machine, changed <-chan struct{}, died
 			}
 		}
 	}()
-	if err := machineLoop(context, m, changed, clock); err != nil {
+	if err := machineLoop(context, m, changed, instanceChanged, clock); err != nil {
 		context.kill(err)
 	}
 }
 
-func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{}, clock clock.Clock) error {
+func machineLoop(
+	context machineContext,
+	m machine,
+	lifeChanged, instanceChanged <-chan struct{},
+	clock clock.Clock,
+) error {
 	// Use a short poll interval when initially waiting for
 	// a machine's address and machine agent to start, and a long one when it already
 	// has an address and the machine agent is started.
-	pollInterval := ShortPoll
+	shortPoll, _ := context.pollIntervals()
+	pollInterval := shortPoll
 	pollInstance := func() error {
+		// The intervals are read on every poll so that changes
+		// to model config take effect without a restart.
+		_, longPoll := context.pollIntervals()
 		instInfo, err := pollInstanceInfo(context, m)
 		if err != nil {
 			return err
@@ -207,16 +285,16 @@ func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{},
 		if instInfo.status.Status != status.Allocating && instInfo.status.Status != status.Pending {
 			if len(instInfo.addresses) > 0 && machineStatus == status.Started {
 				// We've got at least one address and a status and instance is started, so poll infrequently.
-				pollInterval = LongPoll
-			} else if pollInterval < LongPoll {
+				pollInterval = longPoll
+			} else if pollInterval < longPoll {
 				// We have no addresses or not started - poll increasingly rarely
 				// until we do.
 				pollInterval = time.Duration(float64(pollInterval) * ShortPollBackoff)
-				if pollInterval > LongPoll {
-					pollInterval = LongPoll
-				}
 			}
 		}
+		if pollInterval > longPoll {
+			pollInterval = longPoll
+		}
 		return nil
 	}
 
@@ -235,6 +313,9 @@ func machineLoop(context machineContext, m machine, lifeChanged <-chan struct{},
 			return context.errDying()
 		case <-clock.After(pollInterval):
 			shouldPollInstance = true
+		case <-instanceChanged:
+			logger.Debugf("provider reported change to instance of machine %v", m.Id())
+			shouldPollInstance = true
 		case <-lifeChanged:
 			if err := m.Refresh(); err != nil {
 				return err
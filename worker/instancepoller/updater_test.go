@@ -12,6 +12,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
@@ -155,10 +156,84 @@ func (s *updaterSuite) TestManualMachinesStatusChangedAndIgnored(c *gc.C) {
 	c.Assert(statusCalled, gc.Equals, 1)
 }
 
+func (*updaterSuite) TestInstanceChangesPollChangedMachines(c *gc.C) {
+	machines := map[names.MachineTag]*testMachine{}
+	for id, instId := range map[string]instance.Id{"99": "i1234", "100": "i5678"} {
+		tag := names.NewMachineTag(id)
+		machines[tag] = &testMachine{
+			tag:        tag,
+			instanceId: instId,
+			refresh:    func() error { return nil },
+			addresses:  testAddrs,
+			life:       params.Alive,
+			status:     status.Started,
+		}
+	}
+	polled := make(chan instance.Id, len(machines))
+	dyingc := make(chan struct{})
+	context := &testUpdaterContext{
+		dyingc:           dyingc,
+		instanceChangesc: make(chan []string),
+		newMachineContextFunc: func() machineContext {
+			return &testMachineContext{
+				getInstanceInfo: func(id instance.Id) (instanceInfo, error) {
+					polled <- id
+					return instanceInfo{testAddrs, instance.InstanceStatus{Status: status.Unknown, Message: "running"}}, nil
+				},
+				dyingc: dyingc,
+			}
+		},
+		getMachineFunc: func(tag names.MachineTag) (machine, error) {
+			m, ok := machines[tag]
+			c.Assert(ok, jc.IsTrue)
+			return m, nil
+		},
+	}
+	watcher := &testMachinesWatcher{
+		changes: make(chan []string),
+	}
+	done := make(chan error)
+	go func() {
+		done <- watchMachinesLoop(context, watcher)
+	}()
+	expectPoll := func() instance.Id {
+		select {
+		case id := <-polled:
+			return id
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for instance poll")
+		}
+		panic("unreachable")
+	}
+
+	// Both machines are polled when they are first seen.
+	watcher.changes <- []string{"99", "100"}
+	initial := []instance.Id{expectPoll(), expectPoll()}
+	c.Assert(initial, jc.SameContents, []instance.Id{"i1234", "i5678"})
+
+	// Only the machine whose instance changed is polled again.
+	context.instanceChangesc <- []string{"i1234", "i-unknown"}
+	c.Assert(expectPoll(), gc.Equals, instance.Id("i1234"))
+	select {
+	case id := <-polled:
+		c.Fatalf("unexpected poll of instance %q", id)
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	close(context.dyingc)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for watchMachinesLoop to terminate")
+	}
+}
+
 type testUpdaterContext struct {
 	updaterContext
 	newMachineContextFunc func() machineContext
 	getMachineFunc        func(tag names.MachineTag) (machine, error)
+	instanceChangesc      chan []string
 	dyingc                chan struct{}
 }
 
@@ -170,6 +245,10 @@ func (context *testUpdaterContext) getMachine(tag names.MachineTag) (machine, er
 	return context.getMachineFunc(tag)
 }
 
+func (context *testUpdaterContext) instanceChanges() watcher.StringsChannel {
+	return context.instanceChangesc
+}
+
 func (context *testUpdaterContext) dying() <-chan struct{} {
 	return context.dyingc
 }
@@ -12,7 +12,10 @@ import (
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/instancepoller"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
 )
 
@@ -40,14 +43,18 @@ func (config Config) Validate() error {
 }
 
 type updaterWorker struct {
-	config     Config
-	aggregator *aggregator
-	catacomb   catacomb.Catacomb
+	config          Config
+	aggregator      *aggregator
+	instanceWatcher watcher.StringsWatcher
+	catacomb        catacomb.Catacomb
 }
 
 // NewWorker returns a worker that keeps track of
 // the machines in the state and polls their instance
 // addresses and status periodically to keep them up to date.
+// If the environ implements environs.InstanceChangeWatcher,
+// instances are also polled whenever the provider reports
+// that they have changed.
 func NewWorker(config Config) (worker.Worker, error) {
 	if err := config.Validate(); err != nil {
 		return nil, errors.Trace(err)
@@ -89,6 +96,15 @@ func (u *updaterWorker) loop() (err error) {
 	if err := u.catacomb.Add(u.aggregator); err != nil {
 		return errors.Trace(err)
 	}
+	if changeWatcher, ok := u.config.Environ.(environs.InstanceChangeWatcher); ok {
+		u.instanceWatcher, err = changeWatcher.WatchInstanceChanges()
+		if err != nil {
+			return errors.Annotate(err, "watching instance changes")
+		}
+		if err := u.catacomb.Add(u.instanceWatcher); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	watcher, err := u.config.Facade.WatchModelMachines()
 	if err != nil {
 		return errors.Trace(err)
@@ -109,11 +125,41 @@ func (u *updaterWorker) getMachine(tag names.MachineTag) (machine, error) {
 	return u.config.Facade.Machine(tag)
 }
 
+// instanceChanges is part of the updaterContext interface.
+func (u *updaterWorker) instanceChanges() watcher.StringsChannel {
+	if u.instanceWatcher == nil {
+		return nil
+	}
+	return u.instanceWatcher.Changes()
+}
+
 // instanceInfo is part of the machineContext interface.
 func (u *updaterWorker) instanceInfo(id instance.Id) (instanceInfo, error) {
 	return u.aggregator.instanceInfo(id)
 }
 
+// pollIntervals is part of the machineContext interface. Intervals
+// set in model config take precedence over ShortPoll and LongPoll.
+func (u *updaterWorker) pollIntervals() (short, long time.Duration) {
+	short, long = ShortPoll, LongPoll
+	getter, ok := u.config.Environ.(environs.ConfigGetter)
+	if !ok {
+		return short, long
+	}
+	cfg := getter.Config()
+	attrs := cfg.AllAttrs()
+	if _, ok := attrs[config.InstancePollShortInterval]; ok {
+		short = cfg.InstancePollShortInterval()
+	}
+	if _, ok := attrs[config.InstancePollInterval]; ok {
+		long = cfg.InstancePollInterval()
+	}
+	if short > long {
+		short = long
+	}
+	return short, long
+}
+
 // kill is part of the lifetimeContext interface.
 func (u *updaterWorker) kill(err error) {
 	u.catacomb.Kill(err)
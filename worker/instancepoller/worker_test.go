@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/api"
 	apiinstancepoller "github.com/juju/juju/api/instancepoller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
@@ -203,3 +204,33 @@ func (s *workerSuite) setupScenario(c *gc.C) ([]*apiinstancepoller.Machine, []in
 	}
 	return machines, insts
 }
+
+func (s *workerSuite) TestPollIntervalsDefault(c *gc.C) {
+	u := &updaterWorker{config: Config{
+		Environ: &configEnviron{cfg: coretesting.ModelConfig(c)},
+	}}
+	short, long := u.pollIntervals()
+	c.Assert(short, gc.Equals, ShortPoll)
+	c.Assert(long, gc.Equals, LongPoll)
+}
+
+func (s *workerSuite) TestPollIntervalsFromModelConfig(c *gc.C) {
+	u := &updaterWorker{config: Config{
+		Environ: &configEnviron{cfg: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"instance-poll-interval":       "1h",
+			"instance-poll-short-interval": "10s",
+		})},
+	}}
+	short, long := u.pollIntervals()
+	c.Assert(short, gc.Equals, 10*time.Second)
+	c.Assert(long, gc.Equals, time.Hour)
+}
+
+type configEnviron struct {
+	InstanceGetter
+	cfg *config.Config
+}
+
+func (e *configEnviron) Config() *config.Config {
+	return e.cfg
+}
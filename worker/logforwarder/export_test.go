@@ -0,0 +1,12 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	worker "gopkg.in/juju/worker.v1"
+)
+
+func NewOrchestratorForController(args OrchestratorArgs) (worker.Worker, error) {
+	return newOrchestratorForController(args)
+}
@@ -5,6 +5,7 @@ package logforwarder
 
 import (
 	"io"
+	"reflect"
 	"sync"
 
 	"github.com/juju/errors"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
 	"github.com/juju/juju/worker/catacomb"
 )
 
@@ -40,17 +42,15 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
-
 // LogForwarder is a worker that forwards log records from a source
-// to a sender.
+// to the sender for a single named log sink.
 type LogForwarder struct {
 	catacomb  catacomb.Catacomb
 	args      OpenLogForwarderArgs
 	enabledCh chan bool
 	mu        sync.Mutex
 	enabled   bool
+	sinkCfg   sinkconfig.Sink
 }
 
 // OpenLogForwarderArgs holds the info needed to open a LogForwarder.
@@ -64,7 +64,7 @@ type OpenLogForwarderArgs struct {
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
-	// Name is the name given to the log sink.
+	// Name is the name of the log sink in the log forwarding config.
 	Name string
 
 	// OpenSink is the function that opens the underlying log sink that
@@ -76,7 +76,7 @@ type OpenLogForwarderArgs struct {
 	OpenLogStream LogStreamFn
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -100,12 +100,21 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 		logger.Infof("config change - log forwarding not enabled")
 		return nil, closeExisting()
 	}
+	sinkCfg, ok := cfg.Sink(lf.args.Name)
+	if !ok {
+		logger.Infof("config change - log sink %q not configured", lf.args.Name)
+		return nil, closeExisting()
+	}
 	// If the config is not valid, we don't want to exit with an error
 	// and bounce the worker; we'll just log the issue and wait for another
 	// config change to come through.
 	// We'll continue sending using the current sink.
-	if err := cfg.Validate(); err != nil {
-		logger.Errorf("invalid log forward config change: %v", err)
+	if err := sinkCfg.Validate(); err != nil {
+		logger.Errorf("invalid log forward config change for sink %q: %v", lf.args.Name, err)
+		return currentSender, nil
+	}
+	// Changes to the config of other sinks don't affect this one.
+	if currentSender != nil && reflect.DeepEqual(sinkCfg, lf.sinkCfg) {
 		return currentSender, nil
 	}
 
@@ -115,13 +124,14 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 	sink, err := OpenTrackingSink(TrackingSinkArgs{
 		Name:     lf.args.Name,
-		Config:   cfg,
+		Config:   sinkCfg,
 		Caller:   lf.args.Caller,
 		OpenSink: lf.args.OpenSink,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	lf.sinkCfg = sinkCfg
 	lf.enabledCh <- true
 	return sink, nil
 }
@@ -145,7 +155,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to sink %q", lf.args.Name)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -230,7 +240,7 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
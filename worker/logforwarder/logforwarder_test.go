@@ -15,7 +15,9 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/sinkconfig"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/webhook"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/watcher"
@@ -85,8 +87,9 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		Name:             "juju-log-forward",
+		OpenSink: func(cfg sinkconfig.Sink) (*logforwarder.LogSink, error) {
+			sender.host = cfg.Syslog.Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -209,9 +212,101 @@ func (s *LogForwarderSuite) TestSenderError(c *gc.C) {
 type mockLogForwardConfig struct {
 	enabled bool
 	host    string
+	filter  logfwd.Filter
+	others  []sinkconfig.Sink
 	changes chan struct{}
 }
 
+func (s *LogForwarderSuite) TestFilter(c *gc.C) {
+	rec0 := s.rec
+	rec1 := s.rec
+	rec1.ID = 11
+	rec1.Level = loggo.DEBUG
+	rec2 := s.rec
+	rec2.ID = 12
+
+	api := &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+		filter:  logfwd.Filter{Level: loggo.INFO},
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, rec0, rec1, rec2)
+	s.sender.waitForSend(c)
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+
+	// The DEBUG record is not sent.
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec0}}},
+		{"Send", []interface{}{[]logfwd.Record{rec2}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestOtherSinkConfigChange(c *gc.C) {
+	rec0 := s.rec
+	rec1 := s.rec
+	rec1.ID = 11
+
+	api := &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, rec0)
+	s.sender.waitForSend(c)
+
+	// Adding another sink doesn't reopen this one.
+	api.others = []sinkconfig.Sink{webhookSink}
+	api.changes <- struct{}{}
+
+	s.stream.addRecords(c, rec1)
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec0}}},
+		{"Send", []interface{}{[]logfwd.Record{rec1}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestSinkRemoved(c *gc.C) {
+	api := &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+	}
+	lf, err := logforwarder.NewLogForwarder(s.newLogForwarderArgsWithAPI(c, api, s.stream, s.sender))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.stream.addRecords(c, s.rec)
+	s.sender.waitForSend(c)
+
+	api.host = ""
+	api.others = []sinkconfig.Sink{webhookSink}
+	api.changes <- struct{}{}
+	s.sender.waitForClose(c)
+
+	workertest.CleanKill(c, lf)
+	s.sender.stub.CheckCallNames(c, "Send", "Close")
+}
+
+var webhookSink = sinkconfig.Sink{
+	Name: "audit",
+	Type: sinkconfig.TypeWebhook,
+	Webhook: &webhook.RawConfig{
+		URL: "https://logs.example.com/juju",
+	},
+}
+
 type mockWatcher struct {
 	watcher.NotifyWatcher
 	changes chan struct{}
@@ -248,14 +343,26 @@ func (c *mockLogForwardConfig) WatchForLogForwardConfigChanges() (watcher.Notify
 	}, nil
 }
 
-func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
-	return &syslog.RawConfig{
-		Enabled:    c.enabled,
-		Host:       c.host,
-		CACert:     coretesting.CACert,
-		ClientCert: coretesting.ServerCert,
-		ClientKey:  coretesting.ServerKey,
-	}, true, nil
+func (c *mockLogForwardConfig) LogForwardConfig() (*sinkconfig.Config, bool, error) {
+	cfg := &sinkconfig.Config{
+		Enabled: c.enabled,
+	}
+	if c.host != "" {
+		cfg.Sinks = append(cfg.Sinks, sinkconfig.Sink{
+			Name:   "juju-log-forward",
+			Type:   sinkconfig.TypeSyslog,
+			Filter: c.filter,
+			Syslog: &syslog.RawConfig{
+				Enabled:    c.enabled,
+				Host:       c.host,
+				CACert:     coretesting.CACert,
+				ClientCert: coretesting.ServerCert,
+				ClientKey:  coretesting.ServerKey,
+			},
+		})
+	}
+	cfg.Sinks = append(cfg.Sinks, c.others...)
+	return cfg, true, nil
 }
 
 type stubStream struct {
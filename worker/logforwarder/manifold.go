@@ -20,9 +20,9 @@ type ManifoldConfig struct {
 	// These are the dependency resource names.
 	APICallerName string
 
-	// Sinks are the named functions that opens the underlying log sinks
+	// OpenSink is the function that opens the underlying log sinks
 	// to which log records will be forwarded.
-	Sinks []LogSinkSpec
+	OpenSink LogSinkFn
 
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
@@ -70,7 +70,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				ControllerUUID:   controllerCfg.ControllerUUID(),
 				LogForwardConfig: agentFacade,
 				Caller:           apiCaller,
-				Sinks:            config.Sinks,
+				OpenSink:         config.OpenSink,
 				OpenLogStream:    openLogStream,
 				OpenLogForwarder: openForwarder,
 			})
@@ -7,10 +7,18 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/catacomb"
 )
 
+// orchestrator is a worker that runs a log forwarder for each log sink
+// in the log forwarding config.
 type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+	catacomb catacomb.Catacomb
+	args     OrchestratorArgs
+
+	// forwarders records the names of the sinks for which a log
+	// forwarder is running.
+	forwarders map[string]bool
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -25,9 +33,9 @@ type OrchestratorArgs struct {
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
-	// Sinks are the named functions that open the underlying log sinks
+	// OpenSink is the function that opens the underlying log sinks
 	// to which log records will be forwarded.
-	Sinks []LogSinkSpec
+	OpenSink LogSinkFn
 
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
@@ -38,21 +46,84 @@ type OrchestratorArgs struct {
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
-	if len(args.Sinks) == 0 {
-		return nil, nil
-	}
-	if len(args.Sinks) > 1 {
-		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
-	}
-	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
-		ControllerUUID:   args.ControllerUUID,
-		LogForwardConfig: args.LogForwardConfig,
-		Caller:           args.Caller,
-		Name:             args.Sinks[0].Name,
-		OpenSink:         args.Sinks[0].OpenFn,
-		OpenLogStream:    args.OpenLogStream,
+	o := &orchestrator{
+		args:       args,
+		forwarders: make(map[string]bool),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &o.catacomb,
+		Work: o.loop,
 	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o, nil
+}
+
+func (o *orchestrator) loop() error {
+	configWatcher, err := o.args.LogForwardConfig.WatchForLogForwardConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := o.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-o.catacomb.Dying():
+			return o.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("log forward configuration watcher closed")
+			}
+			if err := o.startForwarders(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// startForwarders starts a log forwarder for each newly configured
+// log sink. Each forwarder follows the config of its own sink, and
+// stops forwarding if the sink is removed, so forwarders are never
+// stopped here.
+func (o *orchestrator) startForwarders() error {
+	cfg, ok, err := o.args.LogForwardConfig.LogForwardConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return nil
+	}
+	for _, sink := range cfg.Sinks {
+		if o.forwarders[sink.Name] {
+			continue
+		}
+		lf, err := o.args.OpenLogForwarder(OpenLogForwarderArgs{
+			ControllerUUID:   o.args.ControllerUUID,
+			LogForwardConfig: o.args.LogForwardConfig,
+			Caller:           o.args.Caller,
+			Name:             sink.Name,
+			OpenSink:         o.args.OpenSink,
+			OpenLogStream:    o.args.OpenLogStream,
+		})
+		if err != nil {
+			return errors.Annotatef(err, "opening log forwarder for sink %q", sink.Name)
+		}
+		if err := o.catacomb.Add(lf); err != nil {
+			return errors.Trace(err)
+		}
+		o.forwarders[sink.Name] = true
+	}
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (o *orchestrator) Kill() {
+	o.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (o *orchestrator) Wait() error {
+	return o.catacomb.Wait()
 }
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/logfwd/sinkconfig"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type OrchestratorSuite struct {
+	testing.IsolationSuite
+
+	api    *mockLogForwardConfig
+	opened chan string
+}
+
+var _ = gc.Suite(&OrchestratorSuite{})
+
+func (s *OrchestratorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &mockLogForwardConfig{
+		enabled: true,
+		host:    "10.0.0.1",
+	}
+	s.opened = make(chan string, 10)
+}
+
+func (s *OrchestratorSuite) newOrchestrator(c *gc.C) (*testing.Stub, func()) {
+	stub := &testing.Stub{}
+	o, err := logforwarder.NewOrchestratorForController(logforwarder.OrchestratorArgs{
+		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		LogForwardConfig: s.api,
+		Caller:           &mockCaller{},
+		OpenSink: func(sinkconfig.Sink) (*logforwarder.LogSink, error) {
+			return nil, errors.New("unexpected")
+		},
+		OpenLogStream: func(base.APICaller, params.LogStreamConfig, string) (logforwarder.LogStream, error) {
+			return nil, errors.New("unexpected")
+		},
+		OpenLogForwarder: func(args logforwarder.OpenLogForwarderArgs) (*logforwarder.LogForwarder, error) {
+			stub.AddCall("OpenLogForwarder", args.Name)
+			s.opened <- args.Name
+			// The forwarder is given a config that never changes, so
+			// that it stays idle.
+			args.LogForwardConfig = &mockLogForwardConfig{}
+			return logforwarder.NewLogForwarder(args)
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return stub, func() { workertest.CleanKill(c, o) }
+}
+
+func (s *OrchestratorSuite) waitOpened(c *gc.C, name string) {
+	select {
+	case opened := <-s.opened:
+		c.Assert(opened, gc.Equals, name)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for forwarder %q", name)
+	}
+}
+
+func (s *OrchestratorSuite) TestForwarderPerSink(c *gc.C) {
+	s.api.others = []sinkconfig.Sink{webhookSink}
+	stub, kill := s.newOrchestrator(c)
+
+	s.waitOpened(c, "juju-log-forward")
+	s.waitOpened(c, "audit")
+	kill()
+
+	stub.CheckCalls(c, []testing.StubCall{
+		{"OpenLogForwarder", []interface{}{"juju-log-forward"}},
+		{"OpenLogForwarder", []interface{}{"audit"}},
+	})
+}
+
+func (s *OrchestratorSuite) TestSinkAdded(c *gc.C) {
+	stub, kill := s.newOrchestrator(c)
+	s.waitOpened(c, "juju-log-forward")
+
+	s.api.others = []sinkconfig.Sink{webhookSink}
+	s.api.changes <- struct{}{}
+	s.waitOpened(c, "audit")
+
+	// Forwarders are only opened for new sinks.
+	s.api.changes <- struct{}{}
+	select {
+	case name := <-s.opened:
+		c.Fatalf("unexpected forwarder %q opened", name)
+	case <-time.After(coretesting.ShortWait):
+	}
+	kill()
+
+	stub.CheckCallNames(c, "OpenLogForwarder", "OpenLogForwarder")
+}
+
+func (s *OrchestratorSuite) TestOpenError(c *gc.C) {
+	o, err := logforwarder.NewOrchestratorForController(logforwarder.OrchestratorArgs{
+		LogForwardConfig: s.api,
+		OpenLogForwarder: func(args logforwarder.OpenLogForwarderArgs) (*logforwarder.LogForwarder, error) {
+			return nil, errors.New("boom")
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, o)
+	c.Check(err, gc.ErrorMatches, `opening log forwarder for sink "juju-log-forward": boom`)
+}
@@ -4,7 +4,7 @@
 package logforwarder
 
 import (
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/sinkconfig"
 	"github.com/juju/juju/watcher"
 )
 
@@ -15,19 +15,11 @@ type LogForwardConfig interface {
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
 	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
-}
-
-type LogSinkSpec struct {
-	// Name is the name of the log sink.
-	Name string
-
-	// OpenFn is a function that opens a log sink.
-	OpenFn LogSinkFn
+	LogForwardConfig() (*sinkconfig.Config, bool, error)
 }
 
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg sinkconfig.Sink) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
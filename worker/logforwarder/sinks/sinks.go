@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/kafka"
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/logfwd/webhook"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// Open returns a sink of the configured type, used to receive log
+// messages to be forwarded.
+func Open(cfg sinkconfig.Sink) (*logforwarder.LogSink, error) {
+	switch cfg.Type {
+	case sinkconfig.TypeSyslog:
+		return OpenSyslog(cfg.Syslog)
+	case sinkconfig.TypeWebhook:
+		return OpenWebhook(cfg.Webhook)
+	case sinkconfig.TypeKafka:
+		return OpenKafka(cfg.Kafka)
+	}
+	return nil, errors.NotSupportedf("log sink type %q", cfg.Type)
+}
+
+// OpenWebhook returns a sink that posts log messages to a webhook.
+func OpenWebhook(cfg *webhook.RawConfig) (*logforwarder.LogSink, error) {
+	client, err := webhook.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
+
+// OpenKafka returns a sink that produces log messages to a Kafka
+// topic.
+func OpenKafka(cfg *kafka.RawConfig) (*logforwarder.LogSink, error) {
+	client, err := kafka.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
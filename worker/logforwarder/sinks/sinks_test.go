@@ -0,0 +1,62 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/kafka"
+	"github.com/juju/juju/logfwd/sinkconfig"
+	"github.com/juju/juju/logfwd/webhook"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type SinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinksSuite{})
+
+func (s *SinksSuite) TestOpenWebhook(c *gc.C) {
+	sink, err := sinks.Open(sinkconfig.Sink{
+		Name: "audit",
+		Type: sinkconfig.TypeWebhook,
+		Webhook: &webhook.RawConfig{
+			URL: "https://logs.example.com/juju",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client, ok := sink.SendCloser.(*webhook.Client)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(client.URL, gc.Equals, "https://logs.example.com/juju")
+}
+
+func (s *SinksSuite) TestOpenKafka(c *gc.C) {
+	sink, err := sinks.Open(sinkconfig.Sink{
+		Name: "kafka",
+		Type: sinkconfig.TypeKafka,
+		Kafka: &kafka.RawConfig{
+			URL:   "https://kafka-rest.example.com:8082",
+			Topic: "juju-logs",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client, ok := sink.SendCloser.(*kafka.Client)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(client.URL, gc.Equals, "https://kafka-rest.example.com:8082/topics/juju-logs")
+}
+
+func (s *SinksSuite) TestOpenUnsupported(c *gc.C) {
+	_, err := sinks.Open(sinkconfig.Sink{
+		Name: "pigeon",
+		Type: "carrier-pigeon",
+	})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(err, gc.ErrorMatches, `log sink type "carrier-pigeon" not supported`)
+}
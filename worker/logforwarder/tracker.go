@@ -10,13 +10,13 @@ import (
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/sinkconfig"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
 type TrackingSinkArgs struct {
-	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	// Config is the log sink config that will be used.
+	Config sinkconfig.Sink
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
@@ -30,7 +30,8 @@ type TrackingSinkArgs struct {
 }
 
 // OpenTrackingSink opens a log record sender to use with a worker.
-// The sender also tracks records that were successfully sent.
+// The sender only sends the records selected by the sink's filter,
+// and tracks records that were successfully sent.
 func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 	sink, err := args.OpenSink(args.Config)
 	if err != nil {
@@ -40,6 +41,7 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 	return &LogSink{
 		&trackingSender{
 			SendCloser: sink,
+			filter:     args.Config.Filter,
 			tracker:    newLastSentTracker(args.Name, args.Caller),
 		},
 	}, nil
@@ -47,14 +49,19 @@ func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
 
 type trackingSender struct {
 	SendCloser
+	filter  logfwd.Filter
 	tracker *lastSentTracker
 }
 
 // Send implements Sender.
 func (s *trackingSender) Send(records []logfwd.Record) error {
-	if err := s.SendCloser.Send(records); err != nil {
-		return errors.Trace(err)
+	if selected := logfwd.FilterRecords(s.filter, records); len(selected) > 0 {
+		if err := s.SendCloser.Send(selected); err != nil {
+			return errors.Trace(err)
+		}
 	}
+	// Records that were filtered out are tracked as sent too, so
+	// that they are not streamed again.
 	if err := s.tracker.setLastSent(records); err != nil {
 		return errors.Trace(err)
 	}
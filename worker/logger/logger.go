@@ -17,9 +17,29 @@ var log = loggo.GetLogger("juju.worker.logger")
 // LoggerAPI represents the API calls the logger makes.
 type LoggerAPI interface {
 	LoggingConfig(agentTag names.Tag) (string, error)
+	LoggingOutputFormat(agentTag names.Tag) (string, error)
 	WatchLoggingConfig(agentTag names.Tag) (watcher.NotifyWatcher, error)
 }
 
+// FormatConfig configures how the logger worker maintains the agent's
+// log output format.
+type FormatConfig struct {
+	// Override, if set, is the format configured for the agent,
+	// which takes precedence over the model's format.
+	Override string
+
+	// Current is the format the agent is currently using.
+	Current string
+
+	// SetFormat, if not nil, is called to change the agent's log
+	// output format. If it is nil, the format is not maintained.
+	SetFormat func(string) error
+
+	// UpdateAgent, if not nil, is called to save the model's log
+	// output format in the agent's configuration.
+	UpdateAgent func(string) error
+}
+
 // Logger is responsible for updating the loggo configuration when the
 // environment watcher tells the agent that the value has changed.
 type Logger struct {
@@ -28,17 +48,25 @@ type Logger struct {
 	updateCallback func(string) error
 	lastConfig     string
 	configOverride string
+	format         FormatConfig
 }
 
 // NewLogger returns a worker.Worker that uses the notify watcher returned
 // from the setup.
-func NewLogger(api LoggerAPI, tag names.Tag, loggingOverride string, updateCallback func(string) error) (worker.Worker, error) {
+func NewLogger(
+	api LoggerAPI,
+	tag names.Tag,
+	loggingOverride string,
+	updateCallback func(string) error,
+	format FormatConfig,
+) (worker.Worker, error) {
 	logger := &Logger{
 		api:            api,
 		tag:            tag,
 		updateCallback: updateCallback,
 		lastConfig:     loggo.LoggerInfo(),
 		configOverride: loggingOverride,
+		format:         format,
 	}
 	log.Debugf("initial log config: %q", logger.lastConfig)
 
@@ -88,16 +116,49 @@ func (logger *Logger) setLogging() {
 	}
 }
 
+func (logger *Logger) setFormat() {
+	if logger.format.SetFormat == nil {
+		return
+	}
+	format := logger.format.Override
+	if format == "" {
+		modelFormat, err := logger.api.LoggingOutputFormat(logger.tag)
+		if err != nil {
+			log.Errorf("%v", err)
+			return
+		}
+		format = modelFormat
+	}
+	if format == logger.format.Current {
+		return
+	}
+	log.Debugf("changing log output format from %q to %q", logger.format.Current, format)
+	if err := logger.format.SetFormat(format); err != nil {
+		log.Warningf("setting log output format failed: %v", err)
+		return
+	}
+	logger.format.Current = format
+	// Save the model's format in the agent.conf file, so that it is
+	// used from the start when the agent restarts.
+	if logger.format.Override == "" && logger.format.UpdateAgent != nil {
+		if err := logger.format.UpdateAgent(format); err != nil {
+			log.Errorf("%v", err)
+		}
+	}
+}
+
 func (logger *Logger) SetUp() (watcher.NotifyWatcher, error) {
 	log.Debugf("logger setup")
 	// We need to set this up initially as the NotifyWorker sucks up the first
 	// event.
 	logger.setLogging()
+	logger.setFormat()
 	return logger.api.WatchLoggingConfig(logger.tag)
 }
 
 func (logger *Logger) Handle(_ <-chan struct{}) error {
 	logger.setLogging()
+	logger.setFormat()
 	return nil
 }
 
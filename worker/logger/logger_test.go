@@ -31,6 +31,10 @@ type LoggerSuite struct {
 
 	value    string
 	override string
+
+	format      logger.FormatConfig
+	formats     chan string
+	savedFormat string
 }
 
 var _ = gc.Suite(&LoggerSuite{})
@@ -45,6 +49,19 @@ func (s *LoggerSuite) SetUpTest(c *gc.C) {
 	s.agent = names.NewMachineTag("42")
 	s.value = ""
 	s.override = ""
+	s.formats = make(chan string, 1)
+	s.savedFormat = ""
+	s.format = logger.FormatConfig{
+		Current: "text",
+		SetFormat: func(format string) error {
+			s.formats <- format
+			return nil
+		},
+		UpdateAgent: func(format string) error {
+			s.savedFormat = format
+			return nil
+		},
+	}
 }
 
 func (s *LoggerSuite) waitLoggingInfo(c *gc.C, expected string) {
@@ -68,7 +85,7 @@ func (s *LoggerSuite) makeLogger(c *gc.C) worker.Worker {
 	w, err := logger.NewLogger(s.loggerAPI, s.agent, s.override, func(v string) error {
 		s.value = v
 		return nil
-	})
+	}, s.format)
 	c.Assert(err, jc.ErrorIsNil)
 	return w
 }
@@ -113,6 +130,53 @@ func (s *LoggerSuite) TestConfigOverride(c *gc.C) {
 	s.waitLoggingInfo(c, expected)
 }
 
+func (s *LoggerSuite) waitFormat(c *gc.C, expected string) {
+	select {
+	case format := <-s.formats:
+		c.Assert(format, gc.Equals, expected)
+	case <-time.After(worstCase):
+		c.Fatalf("timeout while waiting for log output format to change")
+	}
+}
+
+func (s *LoggerSuite) TestFormat(c *gc.C) {
+	s.loggerAPI.format = "json"
+
+	loggingWorker := s.makeLogger(c)
+	s.waitFormat(c, "json")
+	err := worker.Stop(loggingWorker)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.savedFormat, gc.Equals, "json")
+	c.Check(s.loggerAPI.formatTag, gc.Equals, s.agent)
+}
+
+func (s *LoggerSuite) TestFormatUnchanged(c *gc.C) {
+	s.loggerAPI.format = "text"
+
+	loggingWorker := s.makeLogger(c)
+	s.waitLoggingInfo(c, s.loggerAPI.config)
+	err := worker.Stop(loggingWorker)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.formats, gc.HasLen, 0)
+	c.Check(s.savedFormat, gc.Equals, "")
+}
+
+func (s *LoggerSuite) TestFormatOverride(c *gc.C) {
+	s.loggerAPI.format = "text"
+	s.format.Override = "json"
+
+	loggingWorker := s.makeLogger(c)
+	s.waitFormat(c, "json")
+	err := worker.Stop(loggingWorker)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The override is not the model's format, so it is not saved.
+	c.Check(s.savedFormat, gc.Equals, "")
+	c.Check(s.loggerAPI.formatTag, gc.IsNil)
+}
+
 type mockNotifyWatcher struct {
 	changes chan struct{}
 }
@@ -132,9 +196,11 @@ var _ watcher.NotifyWatcher = (*mockNotifyWatcher)(nil)
 type mockAPI struct {
 	watcher *mockNotifyWatcher
 	config  string
+	format  string
 
 	loggingTag  names.Tag
 	watchingTag names.Tag
+	formatTag   names.Tag
 }
 
 func (m *mockAPI) LoggingConfig(agentTag names.Tag) (string, error) {
@@ -142,6 +208,11 @@ func (m *mockAPI) LoggingConfig(agentTag names.Tag) (string, error) {
 	return m.config, nil
 }
 
+func (m *mockAPI) LoggingOutputFormat(agentTag names.Tag) (string, error) {
+	m.formatTag = agentTag
+	return m.format, nil
+}
+
 func (m *mockAPI) WatchLoggingConfig(agentTag names.Tag) (watcher.NotifyWatcher, error) {
 	m.watchingTag = agentTag
 	return m.watcher, nil
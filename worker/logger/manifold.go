@@ -7,6 +7,7 @@ import (
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/logformat"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/logger"
 	"github.com/juju/juju/worker/dependency"
@@ -18,6 +19,10 @@ type ManifoldConfig struct {
 	AgentName       string
 	APICallerName   string
 	UpdateAgentFunc func(string) error
+
+	// UpdateAgentFormatFunc saves the model's log output format in
+	// the agent's configuration.
+	UpdateAgentFormatFunc func(string) error
 }
 
 // Manifold returns a dependency manifold that runs a logger
@@ -41,8 +46,19 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return nil, err
 			}
 
+			formatOverride := currentConfig.Value(agent.LoggingFormatOverride)
+			currentFormat := formatOverride
+			if currentFormat == "" {
+				currentFormat = currentConfig.Value(agent.LoggingFormat)
+			}
+
 			loggerFacade := logger.NewState(apiCaller)
-			return NewLogger(loggerFacade, currentConfig.Tag(), loggingOverride, config.UpdateAgentFunc)
+			return NewLogger(loggerFacade, currentConfig.Tag(), loggingOverride, config.UpdateAgentFunc, FormatConfig{
+				Override:    formatOverride,
+				Current:     currentFormat,
+				SetFormat:   logformat.Default().SetFormat,
+				UpdateAgent: config.UpdateAgentFormatFunc,
+			})
 		},
 	}
 }
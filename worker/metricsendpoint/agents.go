@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsendpoint
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"gopkg.in/juju/names.v2"
+)
+
+// agentLabel is the label added to the metrics gathered from other
+// agents, identifying the agent they were gathered from.
+const agentLabel = "agent"
+
+// UnitAgentsGatherer is a prometheus.Gatherer that gathers the juju
+// metrics of the unit agents running on the machine, from their
+// introspection sockets. Unit agents run in their own processes, so
+// this is how their hook and API metrics are exported by the machine
+// agent's endpoint.
+type UnitAgentsGatherer struct {
+	// AgentsDir is the directory holding the agents' directories,
+	// which are named after the agents' tags.
+	AgentsDir string
+
+	// SocketName returns the name of the abstract domain socket on
+	// which the agent with the given tag serves introspection
+	// requests.
+	SocketName func(names.Tag) string
+}
+
+// Gather is part of the prometheus.Gatherer interface. Metrics
+// are gathered from each unit agent in turn; unit agents that cannot
+// be reached, as when they are restarting, are skipped.
+func (g UnitAgentsGatherer) Gather() ([]*dto.MetricFamily, error) {
+	infos, err := ioutil.ReadDir(g.AgentsDir)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading agents directory")
+	}
+	families := make(map[string]*dto.MetricFamily)
+	for _, info := range infos {
+		tag, err := names.ParseUnitTag(info.Name())
+		if err != nil {
+			continue
+		}
+		unitFamilies, err := g.gatherUnit(tag)
+		if err != nil {
+			logger.Debugf("cannot gather metrics from %s: %v", tag, err)
+			continue
+		}
+		for name, family := range unitFamilies {
+			// Only the juju metrics are of interest: the Go
+			// runtime and process metrics of the unit agents
+			// would swamp those of the machine agent.
+			if !strings.HasPrefix(name, "juju_") {
+				continue
+			}
+			addAgentLabel(family, tag.String())
+			if existing, ok := families[name]; ok {
+				existing.Metric = append(existing.Metric, family.Metric...)
+			} else {
+				families[name] = family
+			}
+		}
+	}
+	result := make([]*dto.MetricFamily, 0, len(families))
+	for _, family := range families {
+		result = append(result, family)
+	}
+	sort.Sort(familiesByName(result))
+	return result, nil
+}
+
+// gatherUnit returns the metrics served on the introspection socket
+// of the unit agent with the given tag.
+func (g UnitAgentsGatherer) gatherUnit(tag names.UnitTag) (map[string]*dto.MetricFamily, error) {
+	socketPath := "@" + g.SocketName(tag)
+	client := http.Client{
+		Transport: &http.Transport{
+			Dial: func(_, _ string) (net.Conn, error) {
+				return net.Dial("unix", socketPath)
+			},
+		},
+	}
+	resp, err := client.Get("http://unix.socket/metrics")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %q", resp.Status)
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing metrics")
+	}
+	return families, nil
+}
+
+func addAgentLabel(family *dto.MetricFamily, agent string) {
+	name := agentLabel
+	for _, metric := range family.Metric {
+		value := agent
+		metric.Label = append(metric.Label, &dto.LabelPair{
+			Name:  &name,
+			Value: &value,
+		})
+		// Label pairs are expected to be sorted by name.
+		sort.Sort(labelPairsByName(metric.Label))
+	}
+}
+
+type labelPairsByName []*dto.LabelPair
+
+func (l labelPairsByName) Len() int           { return len(l) }
+func (l labelPairsByName) Swap(i, j int)      { l[i], l[j] = l[j], l[i] }
+func (l labelPairsByName) Less(i, j int) bool { return l[i].GetName() < l[j].GetName() }
+
+type familiesByName []*dto.MetricFamily
+
+func (f familiesByName) Len() int           { return len(f) }
+func (f familiesByName) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+func (f familiesByName) Less(i, j int) bool { return f[i].GetName() < f[j].GetName() }
@@ -0,0 +1,104 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsendpoint_test
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/worker/metricsendpoint"
+)
+
+type agentsSuite struct {
+	testing.IsolationSuite
+	agentsDir string
+	gatherer  metricsendpoint.UnitAgentsGatherer
+}
+
+var _ = gc.Suite(&agentsSuite{})
+
+func (s *agentsSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("abstract domain sockets not supported on non-linux")
+	}
+	s.IsolationSuite.SetUpTest(c)
+	s.agentsDir = c.MkDir()
+	s.gatherer = metricsendpoint.UnitAgentsGatherer{
+		AgentsDir: s.agentsDir,
+		SocketName: func(tag names.Tag) string {
+			return fmt.Sprintf("metricsendpoint-test-%d-%s", os.Getpid(), tag)
+		},
+	}
+}
+
+// serveUnit serves the given metrics text on the introspection socket
+// of the given unit, and creates its agent directory.
+func (s *agentsSuite) serveUnit(c *gc.C, tag names.UnitTag, metrics string) {
+	c.Assert(os.Mkdir(filepath.Join(s.agentsDir, tag.String()), 0755), jc.ErrorIsNil)
+	listener, err := net.Listen("unix", "@"+s.gatherer.SocketName(tag))
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { listener.Close() })
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, metrics)
+	})
+	go http.Serve(listener, mux)
+}
+
+func (s *agentsSuite) TestGather(c *gc.C) {
+	s.serveUnit(c, names.NewUnitTag("mysql/0"), `
+# TYPE go_goroutines gauge
+go_goroutines 12
+# TYPE juju_worker_starts_total counter
+juju_worker_starts_total{worker="uniter"} 1
+`)
+	s.serveUnit(c, names.NewUnitTag("wordpress/1"), `
+# TYPE juju_worker_starts_total counter
+juju_worker_starts_total{worker="uniter"} 3
+`)
+	// Neither a machine agent, nor a unit agent that is not
+	// running, is gathered from.
+	c.Assert(os.Mkdir(filepath.Join(s.agentsDir, "machine-0"), 0755), jc.ErrorIsNil)
+	c.Assert(os.Mkdir(filepath.Join(s.agentsDir, "unit-mysql-1"), 0755), jc.ErrorIsNil)
+
+	families, err := s.gatherer.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(families, gc.HasLen, 1)
+	c.Check(families[0].GetName(), gc.Equals, "juju_worker_starts_total")
+
+	type sample struct {
+		labels map[string]string
+		value  float64
+	}
+	var samples []sample
+	for _, metric := range families[0].Metric {
+		labels := make(map[string]string)
+		for _, pair := range metric.Label {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+		samples = append(samples, sample{labels, metric.Counter.GetValue()})
+	}
+	c.Check(samples, jc.SameContents, []sample{{
+		labels: map[string]string{"agent": "unit-mysql-0", "worker": "uniter"},
+		value:  1,
+	}, {
+		labels: map[string]string{"agent": "unit-wordpress-1", "worker": "uniter"},
+		value:  3,
+	}})
+}
+
+func (s *agentsSuite) TestGatherMissingAgentsDir(c *gc.C) {
+	s.gatherer.AgentsDir = filepath.Join(s.agentsDir, "missing")
+	_, err := s.gatherer.Gather()
+	c.Check(err, gc.ErrorMatches, "reading agents directory: .*")
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package metricsendpoint provides a worker that serves an agent's
+// Prometheus metrics over HTTP, so that they can be scraped remotely
+// without access to the agent's introspection socket.
+package metricsendpoint
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v1"
+)
+
+var logger = loggo.GetLogger("juju.worker.metricsendpoint")
+
+// Config holds the configuration for a metrics endpoint worker.
+type Config struct {
+	// Listener is the listener on which metrics are served. The
+	// worker closes it when it stops.
+	Listener net.Listener
+
+	// TLSConfig, if not nil, is used to serve TLS on Listener.
+	TLSConfig *tls.Config
+
+	// Username and Password hold the credentials that clients must
+	// present, using HTTP basic authentication, to read the metrics.
+	Username string
+	Password string
+
+	// Gatherer supplies the metrics that are served.
+	Gatherer prometheus.Gatherer
+}
+
+// Validate validates the metrics endpoint configuration.
+func (config Config) Validate() error {
+	if config.Listener == nil {
+		return errors.NotValidf("nil Listener")
+	}
+	if config.Username == "" {
+		return errors.NotValidf("empty Username")
+	}
+	if config.Password == "" {
+		return errors.NotValidf("empty Password")
+	}
+	if config.Gatherer == nil {
+		return errors.NotValidf("nil Gatherer")
+	}
+	return nil
+}
+
+// metricsWorker is a worker and constructed with NewWorker.
+type metricsWorker struct {
+	tomb     tomb.Tomb
+	listener net.Listener
+	done     chan struct{}
+}
+
+// NewWorker returns a worker that serves the metrics supplied by the
+// configured Gatherer at /metrics, until it is stopped.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	listener := config.Listener
+	if config.TLSConfig != nil {
+		listener = tls.NewListener(listener, config.TLSConfig)
+	}
+	w := &metricsWorker{
+		listener: listener,
+		done:     make(chan struct{}),
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", basicAuthHandler{
+		handler:  promhttp.HandlerFor(config.Gatherer, promhttp.HandlerOpts{}),
+		username: config.Username,
+		password: config.Password,
+	})
+	go w.serve(mux)
+	go w.run()
+	return w, nil
+}
+
+func (w *metricsWorker) serve(handler http.Handler) {
+	defer close(w.done)
+	logger.Debugf("serving metrics on %s", w.listener.Addr())
+	srv := http.Server{Handler: handler}
+	srv.Serve(w.listener)
+}
+
+func (w *metricsWorker) run() {
+	defer w.tomb.Done()
+	<-w.tomb.Dying()
+	w.listener.Close()
+	// Don't mark the worker as done until the serve goroutine has finished.
+	<-w.done
+}
+
+// Kill implements worker.Worker.
+func (w *metricsWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements worker.Worker.
+func (w *metricsWorker) Wait() error {
+	return w.tomb.Wait()
+}
+
+// basicAuthHandler is an http.Handler that requires requests to
+// present the configured credentials with HTTP basic authentication.
+type basicAuthHandler struct {
+	handler  http.Handler
+	username string
+	password string
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h basicAuthHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	username, password, ok := req.BasicAuth()
+	if !ok || !secureCompare(username, h.username) || !secureCompare(password, h.password) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="juju metrics"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	h.handler.ServeHTTP(w, req)
+}
+
+// secureCompare compares two strings in constant time, so that the
+// time taken does not reveal how much of a credential was correct.
+func secureCompare(given, actual string) bool {
+	return subtle.ConstantTimeCompare([]byte(given), []byte(actual)) == 1
+}
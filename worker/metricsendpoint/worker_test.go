@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsendpoint_test
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/metricsendpoint"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+	config metricsendpoint.Config
+	url    string
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { listener.Close() })
+	s.url = "http://" + listener.Addr().String() + "/metrics"
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "juju_test_total",
+		Help: "Test counter.",
+	})
+	counter.Add(42)
+	c.Assert(registry.Register(counter), jc.ErrorIsNil)
+
+	s.config = metricsendpoint.Config{
+		Listener: listener,
+		Username: "prometheus",
+		Password: "hunter2",
+		Gatherer: registry,
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	s.testValidate(c, func(config *metricsendpoint.Config) {
+		config.Listener = nil
+	}, "nil Listener not valid")
+	s.testValidate(c, func(config *metricsendpoint.Config) {
+		config.Username = ""
+	}, "empty Username not valid")
+	s.testValidate(c, func(config *metricsendpoint.Config) {
+		config.Password = ""
+	}, "empty Password not valid")
+	s.testValidate(c, func(config *metricsendpoint.Config) {
+		config.Gatherer = nil
+	}, "nil Gatherer not valid")
+}
+
+func (s *workerSuite) testValidate(c *gc.C, f func(*metricsendpoint.Config), expect string) {
+	config := s.config
+	f(&config)
+	w, err := metricsendpoint.NewWorker(config)
+	c.Check(w, gc.IsNil)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, expect)
+}
+
+func (s *workerSuite) get(c *gc.C, username, password string) (int, string) {
+	req, err := http.NewRequest("GET", s.url, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	c.Assert(err, jc.ErrorIsNil)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	return resp.StatusCode, string(body)
+}
+
+func (s *workerSuite) TestMetrics(c *gc.C) {
+	w, err := metricsendpoint.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	status, body := s.get(c, "prometheus", "hunter2")
+	c.Check(status, gc.Equals, http.StatusOK)
+	c.Check(body, jc.Contains, "juju_test_total 42")
+}
+
+func (s *workerSuite) TestUnauthorized(c *gc.C) {
+	w, err := metricsendpoint.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	status, _ := s.get(c, "", "")
+	c.Check(status, gc.Equals, http.StatusUnauthorized)
+	status, _ = s.get(c, "prometheus", "hunter3")
+	c.Check(status, gc.Equals, http.StatusUnauthorized)
+	status, _ = s.get(c, "admin", "hunter2")
+	c.Check(status, gc.Equals, http.StatusUnauthorized)
+}
+
+func (s *workerSuite) TestKillClosesListener(c *gc.C) {
+	w, err := metricsendpoint.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+
+	_, err = http.Get(s.url)
+	c.Check(err, gc.ErrorMatches, ".*connection refused")
+}
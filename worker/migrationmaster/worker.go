@@ -85,6 +85,10 @@ type Facade interface {
 	// ModelInfo return basic information about the model to migrated.
 	ModelInfo() (coremigration.ModelInfo, error)
 
+	// ModelRequirements returns what the model to be migrated needs
+	// of the target controller.
+	ModelRequirements() (coremigration.ModelRequirements, error)
+
 	// Export returns a serialized representation of the model
 	// associated with the API connection.
 	Export() (coremigration.SerializedModel, error)
@@ -346,7 +350,28 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 
 	targetClient := migrationtarget.NewClient(conn)
 	err = targetClient.Prechecks(model)
-	return errors.Annotate(err, "target prechecks failed")
+	if err != nil {
+		return errors.Annotate(err, "target prechecks failed")
+	}
+
+	w.setInfoStatus("checking target compatibility")
+	reqs, err := w.config.Facade.ModelRequirements()
+	if err != nil {
+		return errors.Annotate(err, "failed to obtain model requirements during prechecks")
+	}
+	report, err := targetClient.CheckCompatibility(model, reqs)
+	if errors.IsNotSupported(err) {
+		w.logger.Warningf("target controller cannot check compatibility: %v", err)
+	} else if err != nil {
+		return errors.Annotate(err, "target compatibility check failed")
+	}
+	// The facades available on the target controller are known to the
+	// connection, so they are checked here rather than by the target.
+	report.Checks = append(report.Checks, coremigration.CheckFacades(reqs.Features, conn.AllFacadeVersions()))
+	for _, check := range report.Checks {
+		w.logger.Infof("target compatibility check %s", check)
+	}
+	return errors.Trace(report.Err())
 }
 
 func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
@@ -129,16 +129,38 @@ var (
 		{"facade.MigrationStatus", nil},
 		{"guard.Lockdown", nil},
 	}
-	prechecksCalls = []jujutesting.StubCall{
-		{"facade.Prechecks", nil},
-		{"facade.ModelInfo", nil},
-		apiOpenControllerCall,
-		{"MigrationTarget.Prechecks", []interface{}{params.MigrationModelInfo{
+	targetPrechecksCall = jujutesting.StubCall{
+		"MigrationTarget.Prechecks",
+		[]interface{}{params.MigrationModelInfo{
 			UUID:         modelUUID,
 			Name:         modelName,
 			OwnerTag:     ownerTag.String(),
 			AgentVersion: modelVersion,
-		}}},
+		}},
+	}
+	checkCompatibilityCall = jujutesting.StubCall{
+		"MigrationTarget.CheckCompatibility",
+		[]interface{}{params.MigrationCompatibilityArgs{
+			Model: params.MigrationModelInfo{
+				UUID:         modelUUID,
+				Name:         modelName,
+				OwnerTag:     ownerTag.String(),
+				AgentVersion: modelVersion,
+			},
+			Requirements: params.MigrationModelRequirements{
+				Cloud:        "dummy",
+				Features:     []string{coremigration.FeatureStorage},
+				BinariesSize: 1024,
+			},
+		}},
+	}
+	prechecksCalls = []jujutesting.StubCall{
+		{"facade.Prechecks", nil},
+		{"facade.ModelInfo", nil},
+		apiOpenControllerCall,
+		targetPrechecksCall,
+		{"facade.ModelRequirements", nil},
+		checkCompatibilityCall,
 		apiCloseCall,
 	}
 	abortCalls = []jujutesting.StubCall{
@@ -166,6 +188,11 @@ func (s *Suite) SetUpTest(c *gc.C) {
 		stub:          s.stub,
 		controllerTag: targetControllerTag,
 		logStream:     &mockStream{},
+		targetVersion: 2,
+		facadeVersions: map[string][]int{
+			"Storage":            {3},
+			"StorageProvisioner": {3},
+		},
 	}
 	s.connectionErr = nil
 
@@ -468,12 +495,80 @@ func (s *Suite) TestQUIESCETargetChecksFail(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.QUIESCE))
 	s.connection.prechecksErr = errors.New("boom")
 
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Prechecks", nil},
+			{"facade.ModelInfo", nil},
+			apiOpenControllerCall,
+			targetPrechecksCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestQUIESCETargetIncompatible(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.QUIESCE))
+	s.connection.compatibilityChecks = []params.MigrationCompatibilityCheck{
+		{Name: "version", Status: "ok"},
+		{Name: "capacity", Status: "failed", Message: "not enough free disk space"},
+	}
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		prechecksCalls,
+		abortCalls,
+	))
+	lastMessages := s.facade.statuses[len(s.facade.statuses)-2:]
+	c.Assert(lastMessages, gc.DeepEquals, []string{
+		"target controller is not compatible: capacity: not enough free disk space",
+		"aborted, removing model from target controller",
+	})
+}
+
+func (s *Suite) TestQUIESCETargetMissingFacades(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.QUIESCE))
+	s.connection.facadeVersions = map[string][]int{"Storage": {3}}
+
 	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
 	s.stub.CheckCalls(c, joinCalls(
 		watchStatusLockdownCalls,
 		prechecksCalls,
 		abortCalls,
 	))
+	lastMessages := s.facade.statuses[len(s.facade.statuses)-2:]
+	c.Assert(lastMessages, gc.DeepEquals, []string{
+		"target controller is not compatible: facades: missing StorageProvisioner (for storage)",
+		"aborted, removing model from target controller",
+	})
+}
+
+func (s *Suite) TestQUIESCETargetCannotCheckCompatibility(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.QUIESCE))
+	s.connection.targetVersion = 1
+	s.connection.facadeVersions = nil
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Prechecks", nil},
+			{"facade.ModelInfo", nil},
+			apiOpenControllerCall,
+			targetPrechecksCall,
+			{"facade.ModelRequirements", nil},
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+	lastMessages := s.facade.statuses[len(s.facade.statuses)-2:]
+	c.Assert(lastMessages, gc.DeepEquals, []string{
+		"target controller is not compatible: facades: missing Storage (for storage), StorageProvisioner (for storage)",
+		"aborted, removing model from target controller",
+	})
 }
 
 func (s *Suite) TestExportFailure(c *gc.C) {
@@ -1176,6 +1271,15 @@ func (f *stubMasterFacade) ModelInfo() (coremigration.ModelInfo, error) {
 	}, nil
 }
 
+func (f *stubMasterFacade) ModelRequirements() (coremigration.ModelRequirements, error) {
+	f.stub.AddCall("facade.ModelRequirements")
+	return coremigration.ModelRequirements{
+		Cloud:        "dummy",
+		Features:     []string{coremigration.FeatureStorage},
+		BinariesSize: 1024,
+	}, nil
+}
+
 func (f *stubMasterFacade) Export() (coremigration.SerializedModel, error) {
 	f.stub.AddCall("facade.Export")
 	if f.exportErr != nil {
@@ -1254,12 +1358,23 @@ type stubConnection struct {
 
 	machineErrs     []string
 	checkMachineErr error
+
+	targetVersion       int
+	compatibilityChecks []params.MigrationCompatibilityCheck
+	facadeVersions      map[string][]int
 }
 
-func (c *stubConnection) BestFacadeVersion(string) int {
+func (c *stubConnection) BestFacadeVersion(facade string) int {
+	if facade == "MigrationTarget" {
+		return c.targetVersion
+	}
 	return 1
 }
 
+func (c *stubConnection) AllFacadeVersions() map[string][]int {
+	return c.facadeVersions
+}
+
 func (c *stubConnection) APICall(objType string, version int, id, request string, args, response interface{}) error {
 	c.stub.AddCall(objType+"."+request, args)
 
@@ -1267,6 +1382,9 @@ func (c *stubConnection) APICall(objType string, version int, id, request string
 		switch request {
 		case "Prechecks":
 			return c.prechecksErr
+		case "CheckCompatibility":
+			response.(*params.MigrationCompatibilityReport).Checks = c.compatibilityChecks
+			return nil
 		case "Import":
 			return c.importErr
 		case "Activate", "AdoptResources":
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package offerconnectionpruner provides a worker that removes the
+// relations of application offer connections whose consuming model
+// has gone away without removing them.
+package offerconnectionpruner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.offerconnectionpruner")
+
+// Facade exposes the controller methods used by the worker.
+type Facade interface {
+	// Prune removes the relations of offer connections whose
+	// consuming model has not been heard from for longer than
+	// the specified period.
+	Prune(maxStaleness time.Duration) error
+}
+
+// Config holds the configuration and dependencies of the worker.
+type Config struct {
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between prunes.
+	Period time.Duration
+
+	// MaxStaleness is how long a consuming model may go without
+	// being heard from before its offer connections are pruned.
+	MaxStaleness time.Duration
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.MaxStaleness <= 0 {
+		return errors.NotValidf("non-positive MaxStaleness")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that prunes stale offer connections once
+// when started, and subsequently every Period.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &pruneWorker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type pruneWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+func (w *pruneWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(delay):
+			logger.Debugf("pruning offer connections not seen for %v", w.config.MaxStaleness)
+			if err := w.config.Facade.Prune(w.config.MaxStaleness); err != nil {
+				return errors.Annotate(err, "pruning offer connections")
+			}
+		}
+		delay = w.config.Period
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *pruneWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *pruneWorker) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package offerconnectionpruner_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/offerconnectionpruner"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testing.Clock
+	facade *mockFacade
+	config offerconnectionpruner.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(coretesting.ZeroTime())
+	s.facade = &mockFacade{calls: make(chan time.Duration, 10)}
+	s.config = offerconnectionpruner.Config{
+		Facade:       s.facade,
+		Clock:        s.clock,
+		Period:       time.Hour,
+		MaxStaleness: 24 * time.Hour,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		update func(*offerconnectionpruner.Config)
+		err    string
+	}{{
+		func(cfg *offerconnectionpruner.Config) { cfg.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(cfg *offerconnectionpruner.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *offerconnectionpruner.Config) { cfg.Period = 0 },
+		"non-positive Period not valid",
+	}, {
+		func(cfg *offerconnectionpruner.Config) { cfg.MaxStaleness = -time.Second },
+		"non-positive MaxStaleness not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config
+		test.update(&config)
+		_, err := offerconnectionpruner.NewWorker(config)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *WorkerSuite) TestPrunesPeriodically(c *gc.C) {
+	w, err := offerconnectionpruner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitPrune(c)
+	s.assertNoPrune(c)
+	err = s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitPrune(c)
+}
+
+func (s *WorkerSuite) TestPruneError(c *gc.C) {
+	s.facade.err = errors.New("boom")
+	w, err := offerconnectionpruner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.waitPrune(c)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "pruning offer connections: boom")
+}
+
+func (s *WorkerSuite) waitPrune(c *gc.C) {
+	select {
+	case maxStaleness := <-s.facade.calls:
+		c.Assert(maxStaleness, gc.Equals, 24*time.Hour)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for prune")
+	}
+}
+
+func (s *WorkerSuite) assertNoPrune(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected prune")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	calls chan time.Duration
+	err   error
+}
+
+func (f *mockFacade) Prune(maxStaleness time.Duration) error {
+	f.calls <- maxStaleness
+	return f.err
+}
@@ -35,6 +35,7 @@ var (
 	RetryStrategyDelay       = &retryStrategyDelay
 	RetryStrategyCount       = &retryStrategyCount
 	GetObservedNetworkConfig = &getObservedNetworkConfig
+	ReclamationCheckInterval = &reclamationCheckInterval
 )
 
 var ClassifyMachine = classifyMachine
@@ -12,6 +12,7 @@ import (
 	apiprovisioner "github.com/juju/juju/api/provisioner"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/retrystrategy"
 )
 
 // ManifoldConfig defines an environment provisioner's dependencies. It's not
@@ -20,11 +21,12 @@ import (
 // for now we dodge the question because we don't need container provisioners
 // in dependency engines. Yet.
 type ManifoldConfig struct {
-	AgentName     string
-	APICallerName string
-	EnvironName   string
+	AgentName       string
+	APICallerName   string
+	EnvironName     string
+	RetryBudgetName string
 
-	NewProvisionerFunc func(*apiprovisioner.State, agent.Config, environs.Environ) (Provisioner, error)
+	NewProvisionerFunc func(*apiprovisioner.State, agent.Config, environs.Environ, *retrystrategy.Budgets) (Provisioner, error)
 }
 
 // Manifold creates a manifold that runs an environemnt provisioner. See the
@@ -35,6 +37,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			config.AgentName,
 			config.APICallerName,
 			config.EnvironName,
+			config.RetryBudgetName,
 		},
 		Start: func(context dependency.Context) (worker.Worker, error) {
 			var agent agent.Agent
@@ -52,9 +55,14 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return nil, errors.Trace(err)
 			}
 
+			var retryBudgets *retrystrategy.Budgets
+			if err := context.Get(config.RetryBudgetName, &retryBudgets); err != nil {
+				return nil, errors.Trace(err)
+			}
+
 			api := apiprovisioner.NewState(apiCaller)
 			agentConfig := agent.CurrentConfig()
-			w, err := config.NewProvisionerFunc(api, agentConfig, environ)
+			w, err := config.NewProvisionerFunc(api, agentConfig, environ, retryBudgets)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/worker/dependency"
 	dt "github.com/juju/juju/worker/dependency/testing"
 	"github.com/juju/juju/worker/provisioner"
+	"github.com/juju/juju/worker/retrystrategy"
 )
 
 type ManifoldSuite struct {
@@ -31,21 +32,23 @@ func (s *ManifoldSuite) makeManifold() dependency.Manifold {
 		apiSt *apiprovisioner.State,
 		agentConf agent.Config,
 		environ environs.Environ,
+		retryBudgets *retrystrategy.Budgets,
 	) (provisioner.Provisioner, error) {
-		s.stub.AddCall("NewProvisionerFunc")
+		s.stub.AddCall("NewProvisionerFunc", retryBudgets)
 		return struct{ provisioner.Provisioner }{}, nil
 	}
 	return provisioner.Manifold(provisioner.ManifoldConfig{
 		AgentName:          "agent",
 		APICallerName:      "api-caller",
 		EnvironName:        "environ",
+		RetryBudgetName:    "retry-budget",
 		NewProvisionerFunc: fakeNewProvFunc,
 	})
 }
 
 func (s *ManifoldSuite) TestManifold(c *gc.C) {
 	manifold := s.makeManifold()
-	c.Check(manifold.Inputs, jc.SameContents, []string{"agent", "api-caller", "environ"})
+	c.Check(manifold.Inputs, jc.SameContents, []string{"agent", "api-caller", "environ", "retry-budget"})
 	c.Check(manifold.Output, gc.IsNil)
 	c.Check(manifold.Start, gc.NotNil)
 }
@@ -53,9 +56,10 @@ func (s *ManifoldSuite) TestManifold(c *gc.C) {
 func (s *ManifoldSuite) TestMissingAgent(c *gc.C) {
 	manifold := s.makeManifold()
 	w, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
-		"agent":      dependency.ErrMissing,
-		"api-caller": struct{ base.APICaller }{},
-		"environ":    struct{ environs.Environ }{},
+		"agent":        dependency.ErrMissing,
+		"api-caller":   struct{ base.APICaller }{},
+		"environ":      struct{ environs.Environ }{},
+		"retry-budget": new(retrystrategy.Budgets),
 	}))
 	c.Check(w, gc.IsNil)
 	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
@@ -64,9 +68,10 @@ func (s *ManifoldSuite) TestMissingAgent(c *gc.C) {
 func (s *ManifoldSuite) TestMissingAPICaller(c *gc.C) {
 	manifold := s.makeManifold()
 	w, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
-		"agent":      struct{ agent.Agent }{},
-		"api-caller": dependency.ErrMissing,
-		"environ":    struct{ environs.Environ }{},
+		"agent":        struct{ agent.Agent }{},
+		"api-caller":   dependency.ErrMissing,
+		"environ":      struct{ environs.Environ }{},
+		"retry-budget": new(retrystrategy.Budgets),
 	}))
 	c.Check(w, gc.IsNil)
 	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
@@ -75,9 +80,22 @@ func (s *ManifoldSuite) TestMissingAPICaller(c *gc.C) {
 func (s *ManifoldSuite) TestMissingEnviron(c *gc.C) {
 	manifold := s.makeManifold()
 	w, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
-		"agent":      struct{ agent.Agent }{},
-		"api-caller": struct{ base.APICaller }{},
-		"environ":    dependency.ErrMissing,
+		"agent":        struct{ agent.Agent }{},
+		"api-caller":   struct{ base.APICaller }{},
+		"environ":      dependency.ErrMissing,
+		"retry-budget": new(retrystrategy.Budgets),
+	}))
+	c.Check(w, gc.IsNil)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (s *ManifoldSuite) TestMissingRetryBudget(c *gc.C) {
+	manifold := s.makeManifold()
+	w, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"agent":        struct{ agent.Agent }{},
+		"api-caller":   struct{ base.APICaller }{},
+		"environ":      struct{ environs.Environ }{},
+		"retry-budget": dependency.ErrMissing,
 	}))
 	c.Check(w, gc.IsNil)
 	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
@@ -85,14 +103,16 @@ func (s *ManifoldSuite) TestMissingEnviron(c *gc.C) {
 
 func (s *ManifoldSuite) TestStarts(c *gc.C) {
 	manifold := s.makeManifold()
+	retryBudgets := new(retrystrategy.Budgets)
 	w, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
-		"agent":      new(fakeAgent),
-		"api-caller": apitesting.APICallerFunc(nil),
-		"environ":    struct{ environs.Environ }{},
+		"agent":        new(fakeAgent),
+		"api-caller":   apitesting.APICallerFunc(nil),
+		"environ":      struct{ environs.Environ }{},
+		"retry-budget": retryBudgets,
 	}))
 	c.Check(w, gc.NotNil)
 	c.Check(err, jc.ErrorIsNil)
-	s.stub.CheckCallNames(c, "NewProvisionerFunc")
+	s.stub.CheckCalls(c, []testing.StubCall{{"NewProvisionerFunc", []interface{}{retryBudgets}}})
 }
 
 type fakeAgent struct {
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
+	"github.com/juju/juju/worker/retrystrategy"
 )
 
 var logger = loggo.GetLogger("juju.provisioner")
@@ -65,6 +66,7 @@ type provisioner struct {
 	broker                  environs.InstanceBroker
 	distributionGroupFinder DistributionGroupFinder
 	toolsFinder             ToolsFinder
+	retryBudgets            *retrystrategy.Budgets
 	catacomb                catacomb.Catacomb
 }
 
@@ -75,6 +77,11 @@ type provisioner struct {
 type RetryStrategy struct {
 	retryDelay time.Duration
 	retryCount int
+
+	// budgets, if not nil, is shared with the other workers that
+	// make cloud calls, so that they all back off together when
+	// the cloud is failing.
+	budgets *retrystrategy.Budgets
 }
 
 // NewRetryStrategy returns a new retry strategy with the specified delay and
@@ -168,7 +175,11 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		p.broker,
 		auth,
 		modelCfg.ImageStream(),
-		RetryStrategy{retryDelay: retryStrategyDelay, retryCount: retryStrategyCount},
+		RetryStrategy{
+			retryDelay: retryStrategyDelay,
+			retryCount: retryStrategyCount,
+			budgets:    p.retryBudgets,
+		},
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -178,14 +189,22 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 
 // NewEnvironProvisioner returns a new Provisioner for an environment.
 // When new machines are added to the state, it allocates instances
-// from the environment and allocates them to the new machines.
-func NewEnvironProvisioner(st *apiprovisioner.State, agentConfig agent.Config, environ environs.Environ) (Provisioner, error) {
+// from the environment and allocates them to the new machines. Calls
+// to start and stop instances are made within the given retry
+// budgets, which may be nil.
+func NewEnvironProvisioner(
+	st *apiprovisioner.State,
+	agentConfig agent.Config,
+	environ environs.Environ,
+	retryBudgets *retrystrategy.Budgets,
+) (Provisioner, error) {
 	p := &environProvisioner{
 		provisioner: provisioner{
 			st:                      st,
 			agentConfig:             agentConfig,
 			toolsFinder:             getToolsFinder(st),
 			distributionGroupFinder: getDistributionGroupFinder(st),
+			retryBudgets:            retryBudgets,
 		},
 		environ: environ,
 	}
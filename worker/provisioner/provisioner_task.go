@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/controller/authentication"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -37,6 +38,7 @@ import (
 	coretools "github.com/juju/juju/tools"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker/catacomb"
+	"github.com/juju/juju/worker/retrystrategy"
 	"github.com/juju/juju/wrench"
 	"strings"
 )
@@ -102,6 +104,7 @@ func NewProvisionerTask(
 		harvestMode:                harvestMode,
 		harvestModeChan:            make(chan config.HarvestMode, 1),
 		machines:                   make(map[string]*apiprovisioner.Machine),
+		drainedMachines:            make(set.Strings),
 		availabilityZoneMachines:   make([]*AvailabilityZoneMachine, 0),
 		imageStream:                imageStream,
 		retryStartInstanceStrategy: retryStartInstanceStrategy,
@@ -140,7 +143,10 @@ type provisionerTask struct {
 	// instance id -> instance
 	instances map[instance.Id]instance.Instance
 	// machine id -> machine
-	machines                 map[string]*apiprovisioner.Machine
+	machines map[string]*apiprovisioner.Machine
+	// ids of machines whose units have been drained because
+	// their instances are being reclaimed
+	drainedMachines          set.Strings
 	azMachinesMutex          sync.RWMutex
 	availabilityZoneMachines []*AvailabilityZoneMachine
 }
@@ -163,6 +169,15 @@ func (task *provisionerTask) loop() error {
 	// as unknown.
 	var harvestModeChan chan config.HarvestMode
 
+	// Brokers which start instances that the cloud may reclaim are
+	// checked periodically, so that units can be moved off machines
+	// whose instances are about to go away.
+	notifier, _ := task.broker.(environs.InstanceReclamationNotifier)
+	var reclamationCheck <-chan time.Time
+	if notifier != nil {
+		reclamationCheck = time.After(reclamationCheckInterval)
+	}
+
 	// When the watcher is started, it will have the initial changes be all
 	// the machines that are relevant. Also, since this is available straight
 	// away, we know there will be some changes right off the bat.
@@ -197,10 +212,57 @@ func (task *provisionerTask) loop() error {
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
 			}
+		case <-reclamationCheck:
+			task.processReclaimingInstances(notifier)
+			reclamationCheck = time.After(reclamationCheckInterval)
 		}
 	}
 }
 
+// reclamationCheckInterval is how often the provisioner asks the broker
+// which instances the cloud is reclaiming.
+var reclamationCheckInterval = time.Minute
+
+// processReclaimingInstances drains the units from the machines whose
+// instances the cloud is reclaiming. Failures are logged, and draining
+// is retried at the next check.
+func (task *provisionerTask) processReclaimingInstances(notifier environs.InstanceReclamationNotifier) {
+	instIds, err := notifier.ReclaimingInstances()
+	if err != nil {
+		logger.Errorf("cannot get instances being reclaimed: %v", err)
+		return
+	}
+	if len(instIds) == 0 {
+		return
+	}
+	reclaiming := make(map[instance.Id]bool)
+	for _, instId := range instIds {
+		reclaiming[instId] = true
+	}
+	for _, machine := range task.machines {
+		if task.drainedMachines.Contains(machine.Id()) {
+			continue
+		}
+		instId, err := machine.InstanceId()
+		if err != nil {
+			// Machines that have not been provisioned have no
+			// instance to reclaim.
+			continue
+		}
+		if !reclaiming[instId] {
+			continue
+		}
+		logger.Infof("instance %q for machine %q is being reclaimed, draining units", instId, machine)
+		if err := machine.DrainUnits(); errors.IsNotSupported(err) {
+			logger.Warningf("cannot drain units from machine %q: %v", machine, err)
+		} else if err != nil {
+			logger.Errorf("cannot drain units from machine %q: %v", machine, err)
+			continue
+		}
+		task.drainedMachines.Add(machine.Id())
+	}
+}
+
 // SetHarvestMode implements ProvisionerTask.SetHarvestMode().
 func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	select {
@@ -517,7 +579,12 @@ func (task *provisionerTask) stopInstances(instances []instance.Instance) error
 	for i, inst := range instances {
 		ids[i] = inst.Id()
 	}
-	if err := task.broker.StopInstances(ids...); err != nil {
+	err := task.retryStartInstanceStrategy.budgets.Do(retrystrategy.ComputeOperations, task.catacomb.Dying(), func() error {
+		return task.broker.StopInstances(ids...)
+	})
+	if err == retrystrategy.ErrAborted {
+		return task.catacomb.ErrDying()
+	} else if err != nil {
 		return errors.Annotate(err, "broker failed to stop instances")
 	}
 	return nil
@@ -680,17 +747,49 @@ func (task *provisionerTask) constructStartInstanceParams(
 		}
 	}
 
+	var charmLXDProfiles map[string]*lxdprofile.Profile
+	if len(provisioningInfo.CharmLXDProfiles) != 0 {
+		charmLXDProfiles = make(map[string]*lxdprofile.Profile)
+		for name, profile := range provisioningInfo.CharmLXDProfiles {
+			charmLXDProfiles[name] = &lxdprofile.Profile{
+				Description: profile.Description,
+				Config:      profile.Config,
+				Devices:     profile.Devices,
+			}
+		}
+	}
+
+	// Affinity directives are not understood by providers as
+	// placement; they are passed on separately, and approximated
+	// by zone selection in startMachine.
+	placement := provisioningInfo.Placement
+	affinity, err := instance.ParseAffinity(placement)
+	if err != nil {
+		return environs.StartInstanceParams{}, errors.Trace(err)
+	}
+	if affinity != nil {
+		placement = ""
+	}
+
+	lifecycle, err := environs.NewInstanceLifecycle(provisioningInfo.Constraints)
+	if err != nil {
+		return environs.StartInstanceParams{}, errors.Trace(err)
+	}
+
 	startInstanceParams := environs.StartInstanceParams{
 		ControllerUUID:    controllerUUID,
 		Constraints:       provisioningInfo.Constraints,
 		Tools:             possibleTools,
 		InstanceConfig:    instanceConfig,
-		Placement:         provisioningInfo.Placement,
+		Placement:         placement,
+		Affinity:          affinity,
+		InstanceLifecycle: lifecycle,
 		Volumes:           volumes,
 		VolumeAttachments: volumeAttachments,
 		SubnetsToZones:    subnetsToZones,
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     possibleImageMetadata,
+		CharmLXDProfiles:  charmLXDProfiles,
 		StatusCallback:    machine.SetInstanceStatus,
 	}
 
@@ -846,6 +945,34 @@ func (task *provisionerTask) machineAvailabilityZoneDistribution(machine *apipro
 	return machineZone
 }
 
+// machineAffinityZone returns a suggested availability zone for the
+// specified machine to start in, alongside the most of the specified
+// machines. If none of the machines are in a zone the machine has not
+// already failed to start in, "" will be returned.
+func (task *provisionerTask) machineAffinityZone(machine *apiprovisioner.Machine, affinityMachineIds []string) string {
+	task.azMachinesMutex.Lock()
+	defer task.azMachinesMutex.Unlock()
+
+	affinityZoneMap := task.populateDistributionGroupZoneMap(affinityMachineIds)
+	sort.Sort(sort.Reverse(byPopulationThenNames(affinityZoneMap)))
+	for _, affinityZoneMachines := range affinityZoneMap {
+		if affinityZoneMachines.MachineIds.IsEmpty() {
+			break
+		}
+		if affinityZoneMachines.FailedMachineIds.Contains(machine.Id()) {
+			continue
+		}
+		for _, azm := range task.availabilityZoneMachines {
+			if azm.ZoneName == affinityZoneMachines.ZoneName {
+				azm.MachineIds.Add(machine.Id())
+				break
+			}
+		}
+		return affinityZoneMachines.ZoneName
+	}
+	return ""
+}
+
 type byPopulationThenNames []*AvailabilityZoneMachine
 
 func (b byPopulationThenNames) Len() int {
@@ -886,6 +1013,9 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 	if err != nil {
 		return err
 	}
+	if starter, ok := task.broker.(environs.InstanceBatchStarter); ok {
+		return task.batchStartMachines(starter, machines, machineDistributionGroups)
+	}
 
 	wg := sync.WaitGroup{}
 	errMachines := make([]error, len(machines))
@@ -937,6 +1067,169 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 	return nil
 }
 
+// startInstanceBatchSize is the largest number of instances the
+// provisioner asks a provider to start with a single request.
+const startInstanceBatchSize = 20
+
+// machineToStart holds what is needed to start the instance of a
+// machine.
+type machineToStart struct {
+	index                       int
+	machine                     *apiprovisioner.Machine
+	provisioningInfo            *params.ProvisioningInfo
+	startInstanceParams         environs.StartInstanceParams
+	distributionGroupMachineIds []string
+}
+
+// batchStartMachines starts the instances of the specified machines
+// using as few requests to the provider as possible. Any machine whose
+// instance could not be started as part of a batch is then started on
+// its own by startMachine, which retries as usual.
+func (task *provisionerTask) batchStartMachines(
+	starter environs.InstanceBatchStarter,
+	machines []*apiprovisioner.Machine,
+	machineDistributionGroups []apiprovisioner.DistributionGroupResult,
+) error {
+	wg := sync.WaitGroup{}
+	errMachines := make([]error, len(machines))
+	prepared := make([]*machineToStart, len(machines))
+	for i, m := range machines {
+		if machineDistributionGroups[i].Err != nil {
+			task.setErrorStatus("fetching distribution groups for machine %q: %v", m, machineDistributionGroups[i].Err)
+			continue
+		}
+		wg.Add(1)
+		go func(machine *apiprovisioner.Machine, dg []string, index int) {
+			defer wg.Done()
+			v, err := machine.ModelAgentVersion()
+			if err != nil {
+				errMachines[index] = errors.Trace(err)
+				return
+			}
+			pInfo, startInstanceParams, err := task.setupToStartMachine(machine, v)
+			if err != nil {
+				return
+			}
+			prepared[index] = &machineToStart{
+				index:                       index,
+				machine:                     machine,
+				provisioningInfo:            pInfo,
+				startInstanceParams:         startInstanceParams,
+				distributionGroupMachineIds: dg,
+			}
+		}(m, machineDistributionGroups[i].MachineIds, i)
+	}
+	wg.Wait()
+
+	var pending, failed []*machineToStart
+	for _, m := range prepared {
+		if m != nil {
+			pending = append(pending, m)
+		}
+	}
+	for len(pending) > 0 {
+		select {
+		case <-task.catacomb.Dying():
+			return task.catacomb.ErrDying()
+		default:
+		}
+		n := len(pending)
+		if n > startInstanceBatchSize {
+			n = startInstanceBatchSize
+		}
+		batchFailed, err := task.startBatch(starter, pending[:n], errMachines)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		failed = append(failed, batchFailed...)
+		pending = pending[n:]
+	}
+
+	for _, m := range failed {
+		wg.Add(1)
+		go func(m *machineToStart) {
+			defer wg.Done()
+			err := task.startMachine(m.machine, m.provisioningInfo, m.startInstanceParams, m.distributionGroupMachineIds)
+			if err != nil {
+				task.removeMachineFromAZMap(m.machine)
+				errMachines[m.index] = err
+			}
+		}(m)
+	}
+	wg.Wait()
+
+	var errorStrings []string
+	for _, err := range errMachines {
+		if err != nil {
+			errorStrings = append(errorStrings, err.Error())
+		}
+	}
+	if errorStrings != nil {
+		return errors.New(strings.Join(errorStrings, "\n"))
+	}
+	return nil
+}
+
+// startBatch makes a single attempt to start the instances of the
+// specified machines with one request to the provider, and registers
+// those that were started. It returns the machines whose instances
+// could not be started.
+func (task *provisionerTask) startBatch(
+	starter environs.InstanceBatchStarter,
+	batch []*machineToStart,
+	errMachines []error,
+) ([]*machineToStart, error) {
+	args := make([]environs.StartInstanceParams, len(batch))
+	for i, m := range batch {
+		if err := m.machine.SetInstanceStatus(status.Provisioning, "starting", nil); err != nil {
+			logger.Errorf("%v", err)
+		}
+		args[i] = m.startInstanceParams
+		if args[i].AvailabilityZone == "" {
+			args[i].AvailabilityZone = task.startInstanceZone(
+				m.machine,
+				args[i].Affinity,
+				m.provisioningInfo.AffinityMachineIds,
+				affinityDistributionGroup(
+					args[i].Affinity, m.distributionGroupMachineIds, m.provisioningInfo.AffinityMachineIds,
+				),
+			)
+		}
+	}
+
+	var results []environs.BatchStartInstanceResult
+	err := task.retryStartInstanceStrategy.budgets.Do(retrystrategy.ComputeOperations, task.catacomb.Dying(), func() error {
+		var err error
+		results, err = starter.BatchStartInstance(args)
+		return err
+	})
+	if err == retrystrategy.ErrAborted {
+		return nil, task.catacomb.ErrDying()
+	} else if err == nil && len(results) != len(batch) {
+		err = errors.Errorf("expected %d result(s), got %d", len(batch), len(results))
+	}
+
+	var failed []*machineToStart
+	for i, m := range batch {
+		startErr := err
+		if startErr == nil {
+			startErr = results[i].Error
+		}
+		if startErr != nil {
+			logger.Warningf("failed to start instance for machine %s in batch (%v), starting it alone", m.machine, startErr)
+			// Forget the zone chosen for the batch, so that
+			// startMachine chooses afresh.
+			task.removeMachineFromAZMap(m.machine)
+			failed = append(failed, m)
+			continue
+		}
+		if err := task.registerInstance(m.machine, args[i], results[i].Result); err != nil {
+			errMachines[m.index] = err
+		}
+	}
+	return failed, nil
+}
+
 func (task *provisionerTask) setErrorStatus(message string, machine *apiprovisioner.Machine, err error) error {
 	logger.Errorf(message, machine, err)
 	if err2 := machine.SetInstanceStatus(status.ProvisioningError, err.Error(), nil); err2 != nil {
@@ -1017,20 +1310,40 @@ func (task *provisionerTask) startMachine(
 	// Iff the provider has chosen a zone, then AvailabilityZone will be non-empty.
 	distributeAcrossZones := startInstanceParams.AvailabilityZone == ""
 
+	affinity := startInstanceParams.Affinity
+	distributionGroupMachineIds = affinityDistributionGroup(
+		affinity, distributionGroupMachineIds, provisioningInfo.AffinityMachineIds,
+	)
+
 	// Loop through based on the retryCount.  The interator will not
 	// increase if StartInstace failed with ErrAvailabilityZoneFailed
 	// until all availability zones have been tried.
 	for attemptsLeft := task.retryStartInstanceStrategy.retryCount; attemptsLeft >= 0; attemptsLeft-- {
 		if distributeAcrossZones {
-			newZone := task.machineAvailabilityZoneDistribution(machine, distributionGroupMachineIds)
+			newZone := task.startInstanceZone(
+				machine, affinity, provisioningInfo.AffinityMachineIds, distributionGroupMachineIds,
+			)
 			if newZone != "" {
 				startInstanceParams.AvailabilityZone = newZone
 				logger.Infof("trying machine %s StartInstance in availability zone %s", machine, newZone)
 			}
 		}
 
-		attemptResult, err := task.broker.StartInstance(startInstanceParams)
-		if err == nil {
+		var attemptResult *environs.StartInstanceResult
+		// Only failures that the provider reports as transient count
+		// against the budget shared with other workers; a machine
+		// with bad constraints shouldn't hold up everyone else.
+		err := task.retryStartInstanceStrategy.budgets.DoRetryable(
+			retrystrategy.ComputeOperations, task.catacomb.Dying(), environs.IsRetryableError,
+			func() error {
+				var err error
+				attemptResult, err = task.broker.StartInstance(startInstanceParams)
+				return err
+			},
+		)
+		if err == retrystrategy.ErrAborted {
+			return task.catacomb.ErrDying()
+		} else if err == nil {
 			result = attemptResult
 			break
 		} else if attemptsLeft <= 0 {
@@ -1088,6 +1401,16 @@ func (task *provisionerTask) startMachine(
 		}
 	}
 
+	return task.registerInstance(machine, startInstanceParams, result)
+}
+
+// registerInstance records in state the instance started for the
+// machine, stopping the instance if it cannot be recorded.
+func (task *provisionerTask) registerInstance(
+	machine *apiprovisioner.Machine,
+	startInstanceParams environs.StartInstanceParams,
+	result *environs.StartInstanceResult,
+) error {
 	networkConfig := networkingcommon.NetworkConfigFromInterfaceInfo(result.NetworkInfo)
 	volumes := volumesToAPIserver(result.Volumes)
 	volumeNameToAttachmentInfo := volumeAttachmentsToAPIserver(result.VolumeAttachments)
@@ -1124,6 +1447,40 @@ func (task *provisionerTask) startMachine(
 	return nil
 }
 
+// affinityDistributionGroup returns the machines from which a machine
+// with the given affinity directive should be spread across zones.
+// Machines with an anti-affinity directive are spread away from the
+// named application's machines as if they shared a distribution group.
+func affinityDistributionGroup(affinity *instance.Affinity, distributionGroupMachineIds, affinityMachineIds []string) []string {
+	if affinity == nil || !affinity.Anti {
+		return distributionGroupMachineIds
+	}
+	return set.NewStrings(distributionGroupMachineIds...).Union(
+		set.NewStrings(affinityMachineIds...),
+	).SortedValues()
+}
+
+// startInstanceZone returns the availability zone in which to next try
+// to start the machine's instance. Machines with an affinity directive
+// are started in a zone holding the named application's machines where
+// possible, and others in the least populated zone they have not yet
+// failed in. If the provider does not support zones, "" is returned.
+func (task *provisionerTask) startInstanceZone(
+	machine *apiprovisioner.Machine,
+	affinity *instance.Affinity,
+	affinityMachineIds []string,
+	distributionGroupMachineIds []string,
+) string {
+	var zone string
+	if affinity != nil && !affinity.Anti {
+		zone = task.machineAffinityZone(machine, affinityMachineIds)
+	}
+	if zone == "" {
+		zone = task.machineAvailabilityZoneDistribution(machine, distributionGroupMachineIds)
+	}
+	return zone
+}
+
 // markMachineFailedInAZ moves the machine in zone from MachineIds to FailedMachineIds
 // in availabilityZoneMachines, report if there are any availability zones not failed for
 // the specified machine.
@@ -46,6 +46,7 @@ import (
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 	coretools "github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/worker/provisioner"
@@ -458,7 +459,7 @@ func (s *CommonProvisionerSuite) newEnvironProvisioner(c *gc.C) provisioner.Prov
 	machineTag := names.NewMachineTag("0")
 	agentConfig := s.AgentConfigForTag(c, machineTag)
 	apiState := apiprovisioner.NewState(s.st)
-	w, err := provisioner.NewEnvironProvisioner(apiState, agentConfig, s.Environ)
+	w, err := provisioner.NewEnvironProvisioner(apiState, agentConfig, s.Environ, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	return w
 }
@@ -1592,6 +1593,91 @@ func (s *ProvisionerSuite) TestAvailabilityZoneMachinesStartMachines(c *gc.C) {
 	assertAvailabilityZoneMachinesDistribution(c, availabilityZoneMachines)
 }
 
+func (s *ProvisionerSuite) TestBatchStartMachines(c *gc.C) {
+	broker := &mockBatchBroker{mockBroker: &mockBroker{
+		Environ:    s.Environ,
+		retryCount: make(map[string]int),
+	}}
+	task := s.newProvisionerTask(c, config.HarvestDestroyed, broker, s.provisioner, &mockDistributionGroupFinder{}, mockToolsFinder{})
+	defer stop(c, task)
+
+	machines, err := s.addMachines(4)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstances(c, machines)
+	c.Assert(broker.batchStarted(), gc.Equals, 4)
+
+	// The machines are spread across zones as if started alone.
+	availabilityZoneMachines := provisioner.GetCopyAvailabilityZoneMachines(task)
+	assertAvailabilityZoneMachines(c, machines, nil, availabilityZoneMachines)
+	assertAvailabilityZoneMachinesDistribution(c, availabilityZoneMachines)
+}
+
+func (s *ProvisionerSuite) TestBatchStartMachinesFailureStartedAlone(c *gc.C) {
+	broker := &mockBatchBroker{mockBroker: &mockBroker{
+		Environ:    s.Environ,
+		retryCount: make(map[string]int),
+		failureInfo: map[string]mockBrokerFailures{
+			"2": {whenSucceed: 1, err: fmt.Errorf("error: some error")},
+		},
+	}}
+	task := s.newProvisionerTask(c, config.HarvestDestroyed, broker, s.provisioner, &mockDistributionGroupFinder{}, mockToolsFinder{})
+	defer stop(c, task)
+
+	machines, err := s.addMachines(3)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstances(c, machines)
+	c.Assert(broker.batchStarted(), gc.Equals, 2)
+}
+
+func (s *ProvisionerSuite) TestReclaimedInstanceUnitsDrained(c *gc.C) {
+	s.PatchValue(provisioner.ReclamationCheckInterval, 10*time.Millisecond)
+	broker := &mockReclamationBroker{mockBroker: &mockBroker{
+		Environ:    s.Environ,
+		retryCount: make(map[string]int),
+	}}
+	task := s.newProvisionerTask(c, config.HarvestDestroyed, broker, s.provisioner, &mockDistributionGroupFinder{}, mockToolsFinder{})
+	defer stop(c, task)
+
+	m, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	inst := s.checkStartInstance(c, m)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	broker.reclaim(inst.Id())
+	for attempt := coretesting.LongAttempt.Start(); attempt.Next(); {
+		err := unit.Refresh()
+		if errors.IsNotFound(err) {
+			break
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		if unit.Life() != state.Alive {
+			break
+		}
+		if !attempt.HasNext() {
+			c.Fatalf("unit %q was not drained", unit.Name())
+		}
+	}
+
+	// A replacement unit is assigned to another machine.
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	var replaced bool
+	for _, u := range units {
+		if u.Name() == unit.Name() {
+			continue
+		}
+		machineId, err := u.AssignedMachineId()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(machineId, gc.Not(gc.Equals), m.Id())
+		replaced = true
+	}
+	c.Assert(replaced, jc.IsTrue)
+}
+
 func (s *ProvisionerSuite) TestAvailabilityZoneMachinesStartMachinesAZFailures(c *gc.C) {
 	// Per provider dummy, there will be 3 available availability zones.
 	s.PatchValue(&apiserverprovisioner.ErrorRetryWaitDelay, 5*time.Millisecond)
@@ -1671,6 +1757,101 @@ func (s *ProvisionerSuite) TestAvailabilityZoneMachinesStartMachinesAZFailuresWi
 	c.Assert(checkAvailabilityZoneMachinesDistributionGroups(c, dgFinder.groups, availabilityZoneMachines), jc.ErrorIsNil)
 }
 
+// zoneOfMachine returns the zone that the given availability zone
+// machines record the machine with the given id as being in.
+func zoneOfMachine(c *gc.C, id string, obtained []provisioner.AvailabilityZoneMachine) string {
+	for _, zone := range obtained {
+		if zone.MachineIds.Contains(id) {
+			return zone.ZoneName
+		}
+	}
+	c.Fatalf("machine %s not found in any zone", id)
+	return ""
+}
+
+func (s *ProvisionerSuite) assignWordpressUnits(c *gc.C, machines ...*state.Machine) {
+	wordpress := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name: "wordpress",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
+			Name:   "wordpress",
+			Series: series.LatestLts(),
+		}),
+	})
+	for _, m := range machines {
+		s.Factory.MakeUnit(c, &factory.UnitParams{Application: wordpress, Machine: m})
+	}
+}
+
+func (s *ProvisionerSuite) TestAvailabilityZoneMachinesStartMachinesWithAffinity(c *gc.C) {
+	// Per provider dummy, there will be 3 available availability zones.
+	task := s.newProvisionerTask(c, config.HarvestDestroyed, s.Environ, s.provisioner, &mockDistributionGroupFinder{}, mockToolsFinder{})
+	defer stop(c, task)
+
+	machines, err := s.addMachines(3)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstances(c, machines)
+
+	// Without affinity, the next machine would be started in the
+	// first zone by name, so use the last one.
+	availabilityZoneMachines := provisioner.GetCopyAvailabilityZoneMachines(task)
+	lastZone := availabilityZoneMachines[len(availabilityZoneMachines)-1]
+	c.Assert(lastZone.MachineIds.Size(), gc.Equals, 1)
+	s.assignWordpressUnits(c, s.machineById(c, machines, lastZone.MachineIds.Values()[0]))
+
+	m, err := s.BackingState.AddOneMachine(state.MachineTemplate{
+		Series:      series.LatestLts(),
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: s.defaultConstraints,
+		Placement:   "affinity:wordpress",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m)
+
+	availabilityZoneMachines = provisioner.GetCopyAvailabilityZoneMachines(task)
+	c.Assert(zoneOfMachine(c, m.Id(), availabilityZoneMachines), gc.Equals, lastZone.ZoneName)
+}
+
+func (s *ProvisionerSuite) TestAvailabilityZoneMachinesStartMachinesWithAntiAffinity(c *gc.C) {
+	// Per provider dummy, there will be 3 available availability zones.
+	task := s.newProvisionerTask(c, config.HarvestDestroyed, s.Environ, s.provisioner, &mockDistributionGroupFinder{}, mockToolsFinder{})
+	defer stop(c, task)
+
+	machines, err := s.addMachines(3)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstances(c, machines)
+
+	// Without anti-affinity, the next machine would be started in
+	// the first zone by name, so occupy the first two.
+	availabilityZoneMachines := provisioner.GetCopyAvailabilityZoneMachines(task)
+	c.Assert(availabilityZoneMachines, gc.HasLen, 3)
+	s.assignWordpressUnits(c,
+		s.machineById(c, machines, availabilityZoneMachines[0].MachineIds.Values()[0]),
+		s.machineById(c, machines, availabilityZoneMachines[1].MachineIds.Values()[0]),
+	)
+
+	m, err := s.BackingState.AddOneMachine(state.MachineTemplate{
+		Series:      series.LatestLts(),
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: s.defaultConstraints,
+		Placement:   "anti-affinity:wordpress",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m)
+
+	zone := zoneOfMachine(c, m.Id(), provisioner.GetCopyAvailabilityZoneMachines(task))
+	c.Assert(zone, gc.Equals, availabilityZoneMachines[2].ZoneName)
+}
+
+func (s *ProvisionerSuite) machineById(c *gc.C, machines []*state.Machine, id string) *state.Machine {
+	for _, m := range machines {
+		if m.Id() == id {
+			return m
+		}
+	}
+	c.Fatalf("machine %s not found", id)
+	return nil
+}
+
 func (s *ProvisionerSuite) TestProvisioningMachinesSingleMachineDGFailure(c *gc.C) {
 	// If a single machine fails getting the distribution group,
 	// ensure the other machines are still provisioned.
@@ -1841,6 +2022,52 @@ func (b *mockBroker) DeriveAvailabilityZone(args environs.StartInstanceParams) (
 	return b.Environ.(providercommon.ZonedEnviron).DeriveAvailabilityZone(args)
 }
 
+type mockBatchBroker struct {
+	*mockBroker
+	mu      sync.Mutex
+	started int
+}
+
+func (b *mockBatchBroker) BatchStartInstance(args []environs.StartInstanceParams) ([]environs.BatchStartInstanceResult, error) {
+	results := make([]environs.BatchStartInstanceResult, len(args))
+	for i, arg := range args {
+		results[i].Result, results[i].Error = b.mockBroker.StartInstance(arg)
+		if results[i].Error == nil {
+			b.mu.Lock()
+			b.started++
+			b.mu.Unlock()
+		}
+	}
+	return results, nil
+}
+
+// batchStarted returns the number of instances started by
+// BatchStartInstance.
+func (b *mockBatchBroker) batchStarted() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.started
+}
+
+type mockReclamationBroker struct {
+	*mockBroker
+	mu         sync.Mutex
+	reclaiming []instance.Id
+}
+
+func (b *mockReclamationBroker) ReclaimingInstances() ([]instance.Id, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.reclaiming, nil
+}
+
+// reclaim records that the cloud is reclaiming the given instance.
+func (b *mockReclamationBroker) reclaim(id instance.Id) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reclaiming = append(b.reclaiming, id)
+}
+
 type mockToolsFinder struct {
 }
 
@@ -5,13 +5,28 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/environs/config"
 )
 
+// PolicyFunc returns the retention policy for the collection pruned by
+// a worker: the maximum age of its entries, and the maximum size in
+// MiB it can grow to, as configured in the model config.
+type PolicyFunc func(*config.Config) (time.Duration, uint)
+
 // Config holds all necessary attributes to start a pruner worker.
 type Config struct {
 	Facade        Facade
 	PruneInterval time.Duration
 	Clock         clock.Clock
+
+	// Name describes the pruned collection in log messages,
+	// eg "status history".
+	Name string
+
+	// Policy returns the retention policy for the pruned
+	// collection.
+	Policy PolicyFunc
 }
 
 // Validate will err unless basic requirements for a valid
@@ -23,12 +38,11 @@ func (c *Config) Validate() error {
 	if c.Clock == nil {
 		return errors.New("missing Clock")
 	}
-	return nil
-}
-
-// New returns a worker.Worker for history Pruner.
-func New(conf Config) PrunerWorker {
-	return PrunerWorker{
-		config: conf,
+	if c.Name == "" {
+		return errors.New("missing Name")
 	}
+	if c.Policy == nil {
+		return errors.New("missing Policy")
+	}
+	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/watcher"
@@ -17,19 +18,34 @@ import (
 
 var logger = loggo.GetLogger("juju.worker.pruner")
 
-// Facade represents an API that implements status history pruning.
+// Facade represents an API that implements pruning of a collection.
 type Facade interface {
 	Prune(time.Duration, int) error
 	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
 	ModelConfig() (*config.Config, error)
 }
 
-// Worker prunes status history records at regular intervals.
+// PrunerWorker prunes a collection at regular intervals, according
+// to the retention policy in the model config.
 type PrunerWorker struct {
 	catacomb catacomb.Catacomb
 	config   Config
 }
 
+// NewWorker returns a worker that prunes the collection described by
+// the config.
+func NewWorker(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &PrunerWorker{config: conf}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
 // Kill is defined on worker.Worker.
 func (w *PrunerWorker) Kill() {
 	w.catacomb.Kill(nil)
@@ -40,18 +56,7 @@ func (w *PrunerWorker) Wait() error {
 	return w.catacomb.Wait()
 }
 
-// returns the prune worker's catacomb
-func (w *PrunerWorker) Catacomb() *catacomb.Catacomb {
-	return &w.catacomb
-}
-
-// return the prune worker's config
-func (w *PrunerWorker) Config() *Config {
-	return &w.config
-}
-
-// body of generic pruner loop
-func (w *PrunerWorker) Work(getPrunerConfig func(*config.Config) (time.Duration, uint)) error {
+func (w *PrunerWorker) loop() error {
 	modelConfigWatcher, err := w.config.Facade.WatchForModelConfigChanges()
 	if err != nil {
 		return errors.Trace(err)
@@ -85,11 +90,11 @@ func (w *PrunerWorker) Work(getPrunerConfig func(*config.Config) (time.Duration,
 				return errors.Annotate(err, "cannot load model configuration")
 			}
 
-			newMaxAge, newMaxCollectionMB := getPrunerConfig(modelConfig)
+			newMaxAge, newMaxCollectionMB := w.config.Policy(modelConfig)
 
 			if newMaxAge != maxAge || newMaxCollectionMB != maxCollectionMB {
-				logger.Infof("status history config: max age: %v, max collection size %dM for %s (%s)",
-					newMaxAge, newMaxCollectionMB, modelConfig.Name(), modelConfig.UUID())
+				logger.Infof("%s config: max age: %v, max collection size %dM for %s (%s)",
+					w.config.Name, newMaxAge, newMaxCollectionMB, modelConfig.Name(), modelConfig.UUID())
 				maxAge = newMaxAge
 				maxCollectionMB = newMaxCollectionMB
 			}
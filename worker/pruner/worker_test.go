@@ -104,6 +104,16 @@ func (s *PrunerSuite) TestModelConfigChange(c *gc.C) {
 	s.assertWorkerCallsPrune(c, facade, clock, 4)
 }
 
+func (s *PrunerSuite) TestNewWorkerMissingPolicy(c *gc.C) {
+	_, err := pruner.NewWorker(pruner.Config{
+		Facade:        newFakeFacade(),
+		PruneInterval: coretesting.ShortWait,
+		Clock:         testing.NewClock(time.Time{}),
+		Name:          "status history",
+	})
+	c.Assert(err, gc.ErrorMatches, "missing Policy")
+}
+
 type fakeFacade struct {
 	pruned         chan pruneParams
 	changesWatcher *mockNotifyWatcher
@@ -0,0 +1,199 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package retrystrategy
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+)
+
+var logger = loggo.GetLogger("juju.worker.retrystrategy")
+
+// OperationClass identifies a class of cloud operations that share a
+// retry budget.
+type OperationClass string
+
+const (
+	// ComputeOperations covers starting and stopping instances.
+	ComputeOperations OperationClass = "compute"
+
+	// NetworkOperations covers opening and closing ports.
+	NetworkOperations OperationClass = "network"
+
+	// StorageOperations covers creating, attaching, detaching and
+	// destroying volumes and filesystems.
+	StorageOperations OperationClass = "storage"
+)
+
+// ErrAborted is returned by Budgets.Do when it is aborted while
+// backing off.
+var ErrAborted = errors.New("backoff aborted")
+
+// BudgetConfig holds the parameters for NewBudgets.
+type BudgetConfig struct {
+	// Clock is used to time backoffs.
+	Clock clock.Clock
+
+	// Failures is the number of consecutive failures of operations
+	// in a class that are tolerated before all operations in that
+	// class back off.
+	Failures int
+
+	// MinDelay is the time operations back off for when a class
+	// first exhausts its budget. The delay doubles each time the
+	// budget is exhausted again, up to MaxDelay, and is reset when
+	// an operation in the class succeeds.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+}
+
+// DefaultBudgetConfig returns a BudgetConfig with the budget and
+// delays used by the model workers.
+func DefaultBudgetConfig(clock clock.Clock) BudgetConfig {
+	return BudgetConfig{
+		Clock:    clock,
+		Failures: 5,
+		MinDelay: 10 * time.Second,
+		MaxDelay: 5 * time.Minute,
+	}
+}
+
+// Validate returns an error if the config cannot be used.
+func (config BudgetConfig) Validate() error {
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Failures < 1 {
+		return errors.NotValidf("%d failures", config.Failures)
+	}
+	if config.MinDelay <= 0 {
+		return errors.NotValidf("non-positive MinDelay")
+	}
+	if config.MaxDelay < config.MinDelay {
+		return errors.NotValidf("MaxDelay less than MinDelay")
+	}
+	return nil
+}
+
+// Budgets holds a retry budget for each class of operation, shared by
+// all the workers that make operations of that class. When a budget
+// is exhausted, every worker backs off together, rather than each
+// retrying against a failing cloud on its own schedule.
+type Budgets struct {
+	config BudgetConfig
+
+	mu      sync.Mutex
+	budgets map[OperationClass]*budget
+}
+
+// budget records the recent failures of one class of operation.
+type budget struct {
+	failures int
+	delay    time.Duration
+	until    time.Time
+}
+
+// NewBudgets returns a new Budgets with the given configuration.
+func NewBudgets(config BudgetConfig) (*Budgets, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Budgets{
+		config:  config,
+		budgets: make(map[OperationClass]*budget),
+	}, nil
+}
+
+// Do waits until operations of the given class are not backing off,
+// and then calls f and records whether it failed. If abort is closed
+// while waiting, Do returns ErrAborted without calling f.
+//
+// Do may be called on a nil *Budgets, in which case it just calls f;
+// this allows workers to be run without a shared budget.
+func (b *Budgets) Do(class OperationClass, abort <-chan struct{}, f func() error) error {
+	return b.DoRetryable(class, abort, anyError, f)
+}
+
+// DoRetryable is like Do, except that only the errors for which
+// retryable returns true count against the budget. Other errors are
+// returned without affecting the budget, since they don't indicate
+// that the cloud is failing.
+func (b *Budgets) DoRetryable(class OperationClass, abort <-chan struct{}, retryable func(error) bool, f func() error) error {
+	if b == nil {
+		return f()
+	}
+	if err := b.wait(class, abort); err != nil {
+		return err
+	}
+	err := f()
+	if err == nil || retryable(err) {
+		b.record(class, err)
+	}
+	return err
+}
+
+// anyError is used by Do to count every error against the budget.
+func anyError(error) bool {
+	return true
+}
+
+// wait blocks until operations of the given class may proceed.
+func (b *Budgets) wait(class OperationClass, abort <-chan struct{}) error {
+	for {
+		delay := b.backoff(class)
+		if delay <= 0 {
+			return nil
+		}
+		select {
+		case <-abort:
+			return ErrAborted
+		case <-b.config.Clock.After(delay):
+		}
+	}
+}
+
+// backoff returns how much longer operations of the given class
+// must wait.
+func (b *Budgets) backoff(class OperationClass) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	budget, ok := b.budgets[class]
+	if !ok {
+		return 0
+	}
+	return budget.until.Sub(b.config.Clock.Now())
+}
+
+// record updates the budget for the given class with the outcome of
+// an operation.
+func (b *Budgets) record(class OperationClass, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	current, ok := b.budgets[class]
+	if !ok {
+		current = &budget{}
+		b.budgets[class] = current
+	}
+	if err == nil {
+		*current = budget{}
+		return
+	}
+	current.failures++
+	if current.failures < b.config.Failures {
+		return
+	}
+	current.failures = 0
+	current.delay *= 2
+	if current.delay == 0 {
+		current.delay = b.config.MinDelay
+	} else if current.delay > b.config.MaxDelay {
+		current.delay = b.config.MaxDelay
+	}
+	current.until = b.config.Clock.Now().Add(current.delay)
+	logger.Warningf("%s operations failed %d times in a row; backing off for %v", class, b.config.Failures, current.delay)
+}
@@ -0,0 +1,241 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package retrystrategy_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/retrystrategy"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type BudgetSuite struct {
+	testing.IsolationSuite
+	clock   *testing.Clock
+	budgets *retrystrategy.Budgets
+}
+
+var _ = gc.Suite(&BudgetSuite{})
+
+func (s *BudgetSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	budgets, err := retrystrategy.NewBudgets(retrystrategy.BudgetConfig{
+		Clock:    s.clock,
+		Failures: 2,
+		MinDelay: time.Minute,
+		MaxDelay: 3 * time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.budgets = budgets
+}
+
+func (s *BudgetSuite) fail(c *gc.C, class retrystrategy.OperationClass) {
+	err := s.budgets.Do(class, nil, func() error {
+		return errors.New("cloud on fire")
+	})
+	c.Assert(err, gc.ErrorMatches, "cloud on fire")
+}
+
+// doAsync calls Do in the background, returning a channel that
+// receives its result.
+func (s *BudgetSuite) doAsync(class retrystrategy.OperationClass, abort <-chan struct{}) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		result <- s.budgets.Do(class, abort, func() error { return nil })
+	}()
+	return result
+}
+
+func (s *BudgetSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		config retrystrategy.BudgetConfig
+		err    string
+	}{{
+		config: retrystrategy.BudgetConfig{Failures: 1, MinDelay: 1, MaxDelay: 1},
+		err:    "nil Clock not valid",
+	}, {
+		config: retrystrategy.BudgetConfig{Clock: s.clock, MinDelay: 1, MaxDelay: 1},
+		err:    "0 failures not valid",
+	}, {
+		config: retrystrategy.BudgetConfig{Clock: s.clock, Failures: 1, MaxDelay: 1},
+		err:    "non-positive MinDelay not valid",
+	}, {
+		config: retrystrategy.BudgetConfig{Clock: s.clock, Failures: 1, MinDelay: 2, MaxDelay: 1},
+		err:    "MaxDelay less than MinDelay not valid",
+	}} {
+		c.Logf("test %d", i)
+		_, err := retrystrategy.NewBudgets(test.config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *BudgetSuite) TestDefaultBudgetConfigValid(c *gc.C) {
+	err := retrystrategy.DefaultBudgetConfig(s.clock).Validate()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *BudgetSuite) TestWithinBudget(c *gc.C) {
+	s.fail(c, retrystrategy.ComputeOperations)
+	err := <-s.doAsync(retrystrategy.ComputeOperations, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *BudgetSuite) TestSuccessResetsBudget(c *gc.C) {
+	s.fail(c, retrystrategy.ComputeOperations)
+	err := <-s.doAsync(retrystrategy.ComputeOperations, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fail(c, retrystrategy.ComputeOperations)
+	err = <-s.doAsync(retrystrategy.ComputeOperations, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *BudgetSuite) TestExhaustedBudgetBacksOff(c *gc.C) {
+	s.fail(c, retrystrategy.ComputeOperations)
+	s.fail(c, retrystrategy.ComputeOperations)
+
+	first := s.doAsync(retrystrategy.ComputeOperations, nil)
+	second := s.doAsync(retrystrategy.ComputeOperations, nil)
+	err := s.clock.WaitAdvance(59*time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-first:
+		c.Fatalf("operation not delayed")
+	case <-second:
+		c.Fatalf("operation not delayed")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, result := range []<-chan error{first, second} {
+		select {
+		case err := <-result:
+			c.Check(err, jc.ErrorIsNil)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("operation still delayed")
+		}
+	}
+}
+
+func (s *BudgetSuite) TestNonRetryableErrorsNotCounted(c *gc.C) {
+	retryable := func(err error) bool {
+		return err.Error() == "cloud on fire"
+	}
+	for i := 0; i < 3; i++ {
+		err := s.budgets.DoRetryable(retrystrategy.ComputeOperations, nil, retryable, func() error {
+			return errors.New("bad constraints")
+		})
+		c.Assert(err, gc.ErrorMatches, "bad constraints")
+	}
+	err := <-s.doAsync(retrystrategy.ComputeOperations, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nor do they reset the budget.
+	s.fail(c, retrystrategy.ComputeOperations)
+	err = s.budgets.DoRetryable(retrystrategy.ComputeOperations, nil, retryable, func() error {
+		return errors.New("bad constraints")
+	})
+	c.Assert(err, gc.ErrorMatches, "bad constraints")
+	s.fail(c, retrystrategy.ComputeOperations)
+	result := s.doAsync(retrystrategy.ComputeOperations, nil)
+	select {
+	case <-result:
+		c.Fatalf("operation not delayed")
+	case <-time.After(coretesting.ShortWait):
+	}
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(<-result, jc.ErrorIsNil)
+}
+
+func (s *BudgetSuite) TestClassesIndependent(c *gc.C) {
+	s.fail(c, retrystrategy.ComputeOperations)
+	s.fail(c, retrystrategy.ComputeOperations)
+	err := <-s.doAsync(retrystrategy.StorageOperations, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *BudgetSuite) TestDelayDoublesToMax(c *gc.C) {
+	s.fail(c, retrystrategy.NetworkOperations)
+	s.fail(c, retrystrategy.NetworkOperations)
+	for _, delay := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+		result := make(chan error, 1)
+		go func() {
+			result <- s.budgets.Do(retrystrategy.NetworkOperations, nil, func() error {
+				return errors.New("still on fire")
+			})
+		}()
+		err := s.clock.WaitAdvance(delay-time.Second, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+		select {
+		case <-result:
+			c.Fatalf("operation not delayed for %v", delay)
+		case <-time.After(coretesting.ShortWait):
+		}
+		s.clock.Advance(time.Second)
+		select {
+		case err := <-result:
+			c.Assert(err, gc.ErrorMatches, "still on fire")
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("operation still delayed after %v", delay)
+		}
+		// Exhaust the budget again, without any success in
+		// between to reset the delay.
+		s.fail(c, retrystrategy.NetworkOperations)
+	}
+}
+
+func (s *BudgetSuite) TestAbort(c *gc.C) {
+	s.fail(c, retrystrategy.StorageOperations)
+	s.fail(c, retrystrategy.StorageOperations)
+	abort := make(chan struct{})
+	close(abort)
+	called := false
+	err := s.budgets.Do(retrystrategy.StorageOperations, abort, func() error {
+		called = true
+		return nil
+	})
+	c.Assert(err, gc.Equals, retrystrategy.ErrAborted)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *BudgetSuite) TestNilBudgets(c *gc.C) {
+	var budgets *retrystrategy.Budgets
+	err := budgets.Do(retrystrategy.ComputeOperations, nil, func() error {
+		return errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *BudgetSuite) TestBudgetManifold(c *gc.C) {
+	manifold := retrystrategy.BudgetManifold(retrystrategy.BudgetManifoldConfig{
+		ClockName: "clock",
+		NewBudgets: func(clock clock.Clock) (*retrystrategy.Budgets, error) {
+			c.Check(clock, gc.Equals, s.clock)
+			return s.budgets, nil
+		},
+	})
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"clock"})
+
+	w, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"clock": s.clock,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	var budgets *retrystrategy.Budgets
+	err = manifold.Output(w, &budgets)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(budgets, gc.Equals, s.budgets)
+}
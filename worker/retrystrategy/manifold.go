@@ -6,6 +6,7 @@ package retrystrategy
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/agent"
@@ -63,3 +64,38 @@ func (mc ManifoldConfig) output(in worker.Worker, out interface{}) error {
 	}
 	return nil
 }
+
+// BudgetManifoldConfig holds the names of the resources used by a
+// BudgetManifold, and the constructor for the budgets it exposes.
+type BudgetManifoldConfig struct {
+	ClockName  string
+	NewBudgets func(clock.Clock) (*Budgets, error)
+}
+
+// BudgetManifold returns a dependency manifold that exposes a
+// *Budgets, shared by all the workers that use it, so that they back
+// off together when the cloud fails.
+func BudgetManifold(config BudgetManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{config.ClockName},
+		Start: func(context dependency.Context) (worker.Worker, error) {
+			var clock clock.Clock
+			if err := context.Get(config.ClockName, &clock); err != nil {
+				return nil, errors.Trace(err)
+			}
+			budgets, err := config.NewBudgets(clock)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return engine.NewValueWorker(budgets)
+		},
+		Output: engine.ValueWorkerOutput,
+	}
+}
+
+// NewDefaultBudgets returns a *Budgets configured as by
+// DefaultBudgetConfig. It is suitable for use as the NewBudgets
+// field of a BudgetManifoldConfig.
+func NewDefaultBudgets(clock clock.Clock) (*Budgets, error) {
+	return NewBudgets(DefaultBudgetConfig(clock))
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spacediscovery
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/spacediscovery"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/catacomb"
+	"github.com/juju/juju/worker/dependency"
+)
+
+var logger = loggo.GetLogger("juju.worker.spacediscovery")
+
+// Facade exposes the controller capabilities required by the worker.
+type Facade interface {
+	// ReloadSpaces reloads the model's spaces and subnets from its
+	// provider, and returns how they had drifted.
+	ReloadSpaces() (spacediscovery.Drift, error)
+}
+
+// Config defines the operation of a space discovery worker.
+type Config struct {
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between space discovery runs.
+	Period time.Duration
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that reloads the model's spaces and
+// subnets from its provider once when started, and subsequently
+// every Period, logging any drift it finds. If the provider does
+// not support networking, the worker uninstalls itself.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &spaceDiscoveryWorker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type spaceDiscoveryWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *spaceDiscoveryWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *spaceDiscoveryWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *spaceDiscoveryWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(delay):
+			drift, err := w.config.Facade.ReloadSpaces()
+			if params.IsCodeNotSupported(err) {
+				logger.Debugf("space discovery not supported: %v", err)
+				return dependency.ErrUninstall
+			} else if err != nil {
+				return errors.Annotate(err, "reloading spaces")
+			}
+			reportDrift(drift)
+		}
+		delay = w.config.Period
+	}
+}
+
+// reportDrift logs the changes that space discovery found between
+// the model and its provider.
+func reportDrift(drift spacediscovery.Drift) {
+	if len(drift.AddedSpaces) > 0 {
+		logger.Infof("discovered new spaces: %s", strings.Join(drift.AddedSpaces, ", "))
+	}
+	if len(drift.AddedSubnets) > 0 {
+		logger.Infof("discovered new subnets: %s", strings.Join(drift.AddedSubnets, ", "))
+	}
+	if len(drift.MissingSpaces) > 0 {
+		logger.Warningf("spaces no longer reported by the provider: %s", strings.Join(drift.MissingSpaces, ", "))
+	}
+	if len(drift.MissingSubnets) > 0 {
+		logger.Warningf("subnets no longer reported by the provider: %s", strings.Join(drift.MissingSubnets, ", "))
+	}
+}
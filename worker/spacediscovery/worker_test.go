@@ -0,0 +1,138 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package spacediscovery_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apispacediscovery "github.com/juju/juju/api/spacediscovery"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/spacediscovery"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testing.Clock
+	facade *mockFacade
+	config spacediscovery.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.facade = &mockFacade{
+		Stub:  &testing.Stub{},
+		calls: make(chan struct{}, 10),
+	}
+	s.config = spacediscovery.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Period: time.Hour,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.Facade = nil
+	c.Check(s.config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+	s.config.Facade = s.facade
+	s.config.Clock = nil
+	c.Check(s.config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+	s.config.Clock = s.clock
+	s.config.Period = 0
+	c.Check(s.config.Validate(), gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestReloadsImmediatelyAndPeriodically(c *gc.C) {
+	w, err := spacediscovery.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c)
+	s.waitNoCall(c)
+	c.Assert(s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitCall(c)
+	s.facade.CheckCallNames(c, "ReloadSpaces", "ReloadSpaces")
+}
+
+func (s *WorkerSuite) TestReportsDrift(c *gc.C) {
+	s.facade.drift = apispacediscovery.Drift{
+		AddedSpaces:    []string{"space2"},
+		MissingSubnets: []string{"10.0.0.0/24"},
+	}
+	var logWriter loggo.TestWriter
+	c.Assert(loggo.RegisterWriter("spacediscovery-tests", &logWriter), jc.ErrorIsNil)
+	defer func() {
+		loggo.RemoveWriter("spacediscovery-tests")
+		logWriter.Clear()
+	}()
+
+	w, err := spacediscovery.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c)
+	workertest.CleanKill(c, w)
+
+	c.Check(logWriter.Log(), jc.LogMatches, []jc.SimpleMessage{
+		{loggo.INFO, "discovered new spaces: space2"},
+		{loggo.WARNING, "subnets no longer reported by the provider: 10.0.0.0/24"},
+	})
+}
+
+func (s *WorkerSuite) TestNotSupportedUninstalls(c *gc.C) {
+	s.facade.SetErrors(&params.Error{
+		Code:    params.CodeNotSupported,
+		Message: "spaces discovery in a non-networking environ not supported",
+	})
+	w, err := spacediscovery.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrUninstall)
+}
+
+func (s *WorkerSuite) TestReloadError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w, err := spacediscovery.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "reloading spaces: boom")
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for ReloadSpaces")
+	}
+}
+
+func (s *WorkerSuite) waitNoCall(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected ReloadSpaces call")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	*testing.Stub
+	drift apispacediscovery.Drift
+	calls chan struct{}
+}
+
+func (f *mockFacade) ReloadSpaces() (apispacediscovery.Drift, error) {
+	f.MethodCall(f, "ReloadSpaces")
+	f.calls <- struct{}{}
+	return f.drift, f.NextErr()
+}
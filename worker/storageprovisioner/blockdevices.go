@@ -9,11 +9,13 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
 )
 
 // machineBlockDevicesChanged is called when the block devices of the scoped
 // machine have been seen to have changed. This triggers a refresh of all
-// block devices for attached volumes backing pending filesystems.
+// block devices for attached volumes backing pending filesystems, and for
+// volumes backing provisioned filesystems, which may have grown.
 func machineBlockDevicesChanged(ctx *context) error {
 	volumeTags := make([]names.VolumeTag, 0, len(ctx.incompleteFilesystemParams))
 	// We must query volumes for both incomplete filesystems
@@ -58,6 +60,18 @@ func machineBlockDevicesChanged(ctx *context) error {
 			volumeTags = append(volumeTags, filesystem.Volume)
 		}
 	}
+	for _, filesystem := range ctx.filesystems {
+		if filesystem.Volume == (names.VolumeTag{}) {
+			// Filesystem is not volume-backed.
+			continue
+		}
+		if _, ok := ctx.volumeBlockDevices[filesystem.Volume]; !ok {
+			// Backing-volume's block device is not known yet;
+			// it is handled above if the filesystem is pending.
+			continue
+		}
+		volumeTags = append(volumeTags, filesystem.Volume)
+	}
 	if len(volumeTags) == 0 {
 		return nil
 	}
@@ -118,6 +132,7 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 					updatePendingFilesystemAttachment(ctx, id, params)
 				}
 			}
+			maybeResizeFilesystems(ctx, volumeTags[i], result.Result)
 		} else if params.IsCodeNotProvisioned(result.Error) || params.IsCodeNotFound(result.Error) {
 			// Either the volume (attachment) isn't provisioned,
 			// or the corresponding block device is not yet known.
@@ -133,3 +148,22 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 	}
 	return nil
 }
+
+// maybeResizeFilesystems schedules the resizing of provisioned filesystems
+// backed by the specified volume, if the volume's block device has grown
+// larger than the filesystems.
+func maybeResizeFilesystems(ctx *context, volumeTag names.VolumeTag, blockDevice storage.BlockDevice) {
+	for _, filesystem := range ctx.filesystems {
+		if filesystem.Volume != volumeTag || filesystem.Size >= blockDevice.Size {
+			continue
+		}
+		op := &resizeFilesystemOp{args: storage.FilesystemResizeParams{
+			Tag:          filesystem.Tag,
+			Volume:       filesystem.Volume,
+			FilesystemId: filesystem.FilesystemId,
+			Size:         blockDevice.Size,
+		}}
+		ctx.schedule.Remove(op.key())
+		scheduleOperations(ctx, op)
+	}
+}
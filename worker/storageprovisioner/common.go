@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/retrystrategy"
 )
 
 // storageEntityLife queries the lifecycle state of each specified
@@ -202,3 +203,13 @@ func copyMachineStorageIds(src []watcher.MachineStorageId) []params.MachineStora
 	}
 	return dst
 }
+
+// withinRetryBudget calls f, which makes storage calls to the cloud,
+// once the shared retry budget for storage operations allows.
+func withinRetryBudget(ctx *context, f func() error) error {
+	err := ctx.config.RetryBudgets.Do(retrystrategy.StorageOperations, ctx.dying, f)
+	if err == retrystrategy.ErrAborted {
+		return ctx.errDying()
+	}
+	return err
+}
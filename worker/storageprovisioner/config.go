@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/worker/retrystrategy"
 )
 
 // Config holds configuration and dependencies for a storageprovisioner worker.
@@ -22,6 +23,11 @@ type Config struct {
 	Machines    MachineAccessor
 	Status      StatusSetter
 	Clock       clock.Clock
+
+	// RetryBudgets, if not nil, is shared with the other workers
+	// that make cloud calls, so that they all back off together
+	// when the cloud is failing.
+	RetryBudgets *retrystrategy.Budgets
 }
 
 // Validate returns an error if the config cannot be relied upon to start a worker.
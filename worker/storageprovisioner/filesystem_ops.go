@@ -56,7 +56,12 @@ func createFilesystems(ctx *context, ops map[names.FilesystemTag]*createFilesyst
 		if len(filesystemParams) == 0 {
 			continue
 		}
-		results, err := filesystemSource.CreateFilesystems(filesystemParams)
+		var results []storage.CreateFilesystemsResult
+		err := withinRetryBudget(ctx, func() error {
+			var err error
+			results, err = filesystemSource.CreateFilesystems(filesystemParams)
+			return err
+		})
 		if err != nil {
 			return errors.Annotatef(err, "creating filesystems from source %q", sourceName)
 		}
@@ -140,7 +145,12 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 	for sourceName, filesystemAttachmentParams := range paramsBySource {
 		logger.Debugf("attaching filesystems: %+v", filesystemAttachmentParams)
 		filesystemSource := filesystemSources[sourceName]
-		results, err := filesystemSource.AttachFilesystems(filesystemAttachmentParams)
+		var results []storage.AttachFilesystemsResult
+		err := withinRetryBudget(ctx, func() error {
+			var err error
+			results, err = filesystemSource.AttachFilesystems(filesystemAttachmentParams)
+			return err
+		})
 		if err != nil {
 			return errors.Annotatef(err, "attaching filesystems from source %q", sourceName)
 		}
@@ -268,6 +278,60 @@ func removeFilesystems(ctx *context, ops map[names.FilesystemTag]*removeFilesyst
 	return nil
 }
 
+// resizeFilesystems grows volume-backed filesystems to fill their
+// backing volumes.
+func resizeFilesystems(ctx *context, ops map[names.FilesystemTag]*resizeFilesystemOp) error {
+	filesystemResizer, ok := ctx.managedFilesystemSource.(storage.FilesystemResizer)
+	if !ok {
+		logger.Warningf("managed filesystem source does not support resizing filesystems")
+		return nil
+	}
+	args := make([]storage.FilesystemResizeParams, 0, len(ops))
+	for _, op := range ops {
+		args = append(args, op.args)
+	}
+	logger.Debugf("resizing filesystems: %v", args)
+	results, err := filesystemResizer.ResizeFilesystems(args)
+	if err != nil {
+		return errors.Annotate(err, "resizing filesystems")
+	}
+	var reschedule []scheduleOp
+	var filesystems []storage.Filesystem
+	for i, result := range results {
+		if result.Error != nil {
+			reschedule = append(reschedule, ops[args[i].Tag])
+			logger.Debugf(
+				"failed to resize %s: %v",
+				names.ReadableString(args[i].Tag),
+				result.Error,
+			)
+			continue
+		}
+		filesystems = append(filesystems, *result.Filesystem)
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(filesystems) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemInfo(filesystemsFromStorage(filesystems))
+	if err != nil {
+		return errors.Annotate(err, "publishing filesystems to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing filesystem %s to state: %v",
+				filesystems[i].Tag.Id(),
+				result.Error,
+			)
+		}
+	}
+	for _, f := range filesystems {
+		updateFilesystem(ctx, f)
+	}
+	return nil
+}
+
 func partitionRemoveFilesystemParams(removeTags []names.FilesystemTag, removeParams []params.RemoveFilesystemParams) (
 	destroyTags []names.FilesystemTag, destroyIds []string,
 	releaseTags []names.FilesystemTag, releaseIds []string,
@@ -311,7 +375,12 @@ func detachFilesystems(ctx *context, ops map[params.MachineStorageId]*detachFile
 	for sourceName, filesystemAttachmentParams := range paramsBySource {
 		logger.Debugf("detaching filesystems: %+v", filesystemAttachmentParams)
 		filesystemSource := filesystemSources[sourceName]
-		errs, err := filesystemSource.DetachFilesystems(filesystemAttachmentParams)
+		var errs []error
+		err := withinRetryBudget(ctx, func() error {
+			var err error
+			errs, err = filesystemSource.DetachFilesystems(filesystemAttachmentParams)
+			return err
+		})
 		if err != nil {
 			return errors.Annotatef(err, "detaching filesystems from source %q", sourceName)
 		}
@@ -563,3 +632,12 @@ func (op *detachFilesystemOp) key() interface{} {
 		AttachmentTag: op.args.Filesystem.String(),
 	}
 }
+
+type resizeFilesystemOp struct {
+	exponentialBackoff
+	args storage.FilesystemResizeParams
+}
+
+func (op *resizeFilesystemOp) key() interface{} {
+	return resizeKey{op.args.Tag}
+}
@@ -13,13 +13,15 @@ import (
 	"github.com/juju/juju/api/storageprovisioner"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/dependency"
+	"github.com/juju/juju/worker/retrystrategy"
 )
 
 // ModelManifoldConfig defines a storage provisioner's configuration and dependencies.
 type ModelManifoldConfig struct {
-	APICallerName string
-	ClockName     string
-	EnvironName   string
+	APICallerName   string
+	ClockName       string
+	EnvironName     string
+	RetryBudgetName string
 
 	Scope      names.Tag
 	StorageDir string
@@ -28,7 +30,7 @@ type ModelManifoldConfig struct {
 // ModelManifold returns a dependency.Manifold that runs a storage provisioner.
 func ModelManifold(config ModelManifoldConfig) dependency.Manifold {
 	return dependency.Manifold{
-		Inputs: []string{config.APICallerName, config.ClockName, config.EnvironName},
+		Inputs: []string{config.APICallerName, config.ClockName, config.EnvironName, config.RetryBudgetName},
 		Start: func(context dependency.Context) (worker.Worker, error) {
 
 			var clock clock.Clock
@@ -43,6 +45,10 @@ func ModelManifold(config ModelManifoldConfig) dependency.Manifold {
 			if err := context.Get(config.EnvironName, &environ); err != nil {
 				return nil, errors.Trace(err)
 			}
+			var retryBudgets *retrystrategy.Budgets
+			if err := context.Get(config.RetryBudgetName, &retryBudgets); err != nil {
+				return nil, errors.Trace(err)
+			}
 
 			api, err := storageprovisioner.NewState(apiCaller, config.Scope)
 			if err != nil {
@@ -58,6 +64,8 @@ func ModelManifold(config ModelManifoldConfig) dependency.Manifold {
 				Machines:    api,
 				Status:      api,
 				Clock:       clock,
+
+				RetryBudgets: retryBudgets,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -25,11 +25,12 @@ var _ = gc.Suite(&ManifoldSuite{})
 
 func (s *ManifoldSuite) TestManifold(c *gc.C) {
 	manifold := storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
-		APICallerName: "grenouille",
-		ClockName:     "bustopher",
-		EnvironName:   "environ",
+		APICallerName:   "grenouille",
+		ClockName:       "bustopher",
+		EnvironName:     "environ",
+		RetryBudgetName: "rumpelteazer",
 	})
-	c.Check(manifold.Inputs, jc.DeepEquals, []string{"grenouille", "bustopher", "environ"})
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"grenouille", "bustopher", "environ", "rumpelteazer"})
 	c.Check(manifold.Output, gc.IsNil)
 	c.Check(manifold.Start, gc.NotNil)
 	// ...Start is *not* well-tested, in common with many manifold configs.
@@ -37,9 +38,10 @@ func (s *ManifoldSuite) TestManifold(c *gc.C) {
 
 func (s *ManifoldSuite) TestMissingClock(c *gc.C) {
 	manifold := storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
-		APICallerName: "api-caller",
-		ClockName:     "clock",
-		EnvironName:   "environ",
+		APICallerName:   "api-caller",
+		ClockName:       "clock",
+		EnvironName:     "environ",
+		RetryBudgetName: "retry-budget",
 	})
 	_, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
 		"api-caller": struct{ base.APICaller }{},
@@ -51,9 +53,10 @@ func (s *ManifoldSuite) TestMissingClock(c *gc.C) {
 
 func (s *ManifoldSuite) TestMissingAPICaller(c *gc.C) {
 	manifold := storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
-		APICallerName: "api-caller",
-		ClockName:     "clock",
-		EnvironName:   "environ",
+		APICallerName:   "api-caller",
+		ClockName:       "clock",
+		EnvironName:     "environ",
+		RetryBudgetName: "retry-budget",
 	})
 	_, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
 		"api-caller": dependency.ErrMissing,
@@ -65,9 +68,10 @@ func (s *ManifoldSuite) TestMissingAPICaller(c *gc.C) {
 
 func (s *ManifoldSuite) TestMissingEnviron(c *gc.C) {
 	manifold := storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
-		APICallerName: "api-caller",
-		ClockName:     "clock",
-		EnvironName:   "environ",
+		APICallerName:   "api-caller",
+		ClockName:       "clock",
+		EnvironName:     "environ",
+		RetryBudgetName: "retry-budget",
 	})
 	_, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
 		"api-caller": struct{ base.APICaller }{},
@@ -76,3 +80,19 @@ func (s *ManifoldSuite) TestMissingEnviron(c *gc.C) {
 	}))
 	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
 }
+
+func (s *ManifoldSuite) TestMissingRetryBudget(c *gc.C) {
+	manifold := storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
+		APICallerName:   "api-caller",
+		ClockName:       "clock",
+		EnvironName:     "environ",
+		RetryBudgetName: "retry-budget",
+	})
+	_, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"api-caller":   struct{ base.APICaller }{},
+		"clock":        struct{ clock.Clock }{},
+		"environ":      struct{ environs.Environ }{},
+		"retry-budget": dependency.ErrMissing,
+	}))
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
@@ -96,11 +96,13 @@ func (w *mockAttachmentsWatcher) Changes() watcher.MachineStorageIdsChannel {
 type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
+	resizesWatcher         *mockStringsWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	pendingSizes           map[string]uint64
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
@@ -125,6 +127,10 @@ func (w *mockVolumeAccessor) WatchVolumeAttachments() (watcher.MachineStorageIds
 	return w.attachmentsWatcher, nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
 func (w *mockVolumeAccessor) WatchBlockDevices(tag names.MachineTag) (watcher.NotifyWatcher, error) {
 	return w.blockDevicesWatcher, nil
 }
@@ -217,6 +223,29 @@ func (v *mockVolumeAccessor) RemoveVolumeParams(volumes []names.VolumeTag) ([]pa
 	return result, nil
 }
 
+func (v *mockVolumeAccessor) ResizeVolumeParams(volumes []names.VolumeTag) ([]params.ResizeVolumeParamsResult, error) {
+	var result []params.ResizeVolumeParamsResult
+	for _, tag := range volumes {
+		vol, ok := v.provisionedVolumes[tag.String()]
+		if !ok {
+			result = append(result, params.ResizeVolumeParamsResult{
+				Error: &params.Error{Code: params.CodeNotProvisioned},
+			})
+			continue
+		}
+		var volumeParams params.ResizeVolumeParams
+		if size, ok := v.pendingSizes[tag.String()]; ok {
+			volumeParams = params.ResizeVolumeParams{
+				Provider: "dummy",
+				VolumeId: vol.Info.VolumeId,
+				Size:     size,
+			}
+		}
+		result = append(result, params.ResizeVolumeParamsResult{Result: volumeParams})
+	}
+	return result, nil
+}
+
 func (v *mockVolumeAccessor) VolumeAttachmentParams(ids []params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error) {
 	var result []params.VolumeAttachmentParamsResult
 	for _, id := range ids {
@@ -252,11 +281,13 @@ func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		pendingSizes:           make(map[string]uint64),
 	}
 }
 
@@ -474,6 +505,7 @@ type dummyProvider struct {
 	releaseVolumesFunc           func([]string) ([]error, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
 	releaseFilesystemsFunc       func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
 }
@@ -591,6 +623,24 @@ func (s *dummyVolumeSource) DetachVolumes(params []storage.VolumeAttachmentParam
 	return make([]error, len(params)), nil
 }
 
+// ResizeVolumes grows volumes.
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Volume = &storage.Volume{
+			p.Tag,
+			storage.VolumeInfo{
+				VolumeId: p.VolumeId,
+				Size:     p.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
 func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
 	if s.provider != nil && s.provider.validateFilesystemParamsFunc != nil {
 		return s.provider.validateFilesystemParamsFunc(params)
@@ -741,6 +791,26 @@ func (s *mockManagedFilesystemSource) DetachFilesystems(params []storage.Filesys
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		blockDevice, ok := s.blockDevices[arg.Volume]
+		if !ok {
+			results[i].Error = errors.Errorf("filesystem %v's backing-volume is not attached", arg.Tag.Id())
+			continue
+		}
+		results[i].Filesystem = &storage.Filesystem{
+			Tag:    arg.Tag,
+			Volume: arg.Volume,
+			FilesystemInfo: storage.FilesystemInfo{
+				Size:         blockDevice.Size,
+				FilesystemId: arg.FilesystemId,
+			},
+		}
+	}
+	return results, nil
+}
+
 type mockMachineAccessor struct {
 	instanceIds map[names.MachineTag]instance.Id
 	watcher     *mockNotifyWatcher
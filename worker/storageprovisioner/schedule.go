@@ -3,7 +3,11 @@
 
 package storageprovisioner
 
-import "time"
+import (
+	"time"
+
+	"gopkg.in/juju/names.v2"
+)
 
 // minRetryDelay is the minimum delay to apply
 // to operation retries; this does not apply to
@@ -45,6 +49,12 @@ type scheduleOp interface {
 	delay() time.Duration
 }
 
+// resizeKey is the key for operations that resize a storage entity,
+// distinguishing them from other operations on the same entity.
+type resizeKey struct {
+	tag names.Tag
+}
+
 // exponentialBackoff is a type that can be embedded to implement the
 // delay() method of scheduleOp, providing truncated binary exponential
 // backoff for operations that may be rescheduled.
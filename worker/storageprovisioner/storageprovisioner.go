@@ -60,6 +60,11 @@ type VolumeAccessor interface {
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments() (watcher.MachineStorageIdsWatcher, error)
 
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, including requests to
+	// resize them.
+	WatchVolumeResizes() (watcher.StringsWatcher, error)
+
 	// Volumes returns details of volumes with the specified tags.
 	Volumes([]names.VolumeTag) ([]params.VolumeResult, error)
 
@@ -79,6 +84,10 @@ type VolumeAccessor interface {
 	// releasing the volumes with the specified tags.
 	RemoveVolumeParams([]names.VolumeTag) ([]params.RemoveVolumeParamsResult, error)
 
+	// ResizeVolumeParams returns the parameters for growing the
+	// volumes with the specified tags.
+	ResizeVolumeParams([]names.VolumeTag) ([]params.ResizeVolumeParamsResult, error)
+
 	// VolumeAttachmentParams returns the parameters for creating the
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
@@ -209,6 +218,7 @@ func (w *storageProvisioner) loop() error {
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
+		volumeResizesChanges         watcher.StringsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
 	machineChanges := make(chan names.MachineTag)
@@ -262,10 +272,25 @@ func (w *storageProvisioner) loop() error {
 	}
 	filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
 
+	volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes()
+	if errors.IsNotImplemented(err) {
+		// The controller does not support resizing volumes.
+		logger.Debugf("not watching volume resizes: %v", err)
+	} else if err != nil {
+		return errors.Annotate(err, "watching volume resizes")
+	} else {
+		if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeResizesChanges = volumeResizesWatcher.Changes()
+	}
+
 	ctx := context{
 		kill:                                 w.catacomb.Kill,
 		addWorker:                            w.catacomb.Add,
 		config:                               w.config,
+		dying:                                w.catacomb.Dying(),
+		errDying:                             w.catacomb.ErrDying,
 		volumes:                              make(map[names.VolumeTag]storage.Volume),
 		volumeAttachments:                    make(map[params.MachineStorageId]storage.VolumeAttachment),
 		volumeBlockDevices:                   make(map[names.VolumeTag]storage.BlockDevice),
@@ -321,6 +346,13 @@ func (w *storageProvisioner) loop() error {
 			if err := filesystemAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-machineBlockDevicesChanges:
 			if !ok {
 				return errors.New("machine block devices watcher closed")
@@ -352,6 +384,8 @@ func processSchedule(ctx *context) error {
 	removeFilesystemOps := make(map[names.FilesystemTag]*removeFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
 	detachFilesystemOps := make(map[params.MachineStorageId]*detachFilesystemOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
+	resizeFilesystemOps := make(map[names.FilesystemTag]*resizeFilesystemOp)
 	for _, item := range ready {
 		op := item.(scheduleOp)
 		key := op.key()
@@ -372,6 +406,10 @@ func processSchedule(ctx *context) error {
 			attachFilesystemOps[key.(params.MachineStorageId)] = op
 		case *detachFilesystemOp:
 			detachFilesystemOps[key.(params.MachineStorageId)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[op.tag] = op
+		case *resizeFilesystemOp:
+			resizeFilesystemOps[op.args.Tag] = op
 		}
 	}
 	if len(removeVolumeOps) > 0 {
@@ -394,6 +432,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
 	if len(removeFilesystemOps) > 0 {
 		if err := removeFilesystems(ctx, removeFilesystemOps); err != nil {
 			return errors.Annotate(err, "removing filesystems")
@@ -414,6 +457,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching filesystems")
 		}
 	}
+	if len(resizeFilesystemOps) > 0 {
+		if err := resizeFilesystems(ctx, resizeFilesystemOps); err != nil {
+			return errors.Annotate(err, "resizing filesystems")
+		}
+	}
 	return nil
 }
 
@@ -422,6 +470,11 @@ type context struct {
 	addWorker func(worker.Worker) error
 	config    Config
 
+	// dying is closed when the worker is stopping, and errDying
+	// returns the error to return when it is.
+	dying    <-chan struct{}
+	errDying func() error
+
 	// volumes contains information about provisioned volumes.
 	volumes map[names.VolumeTag]storage.Volume
 
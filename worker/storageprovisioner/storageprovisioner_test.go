@@ -1731,6 +1731,153 @@ func (s *storageProvisionerSuite) TestDestroyFilesystems(c *gc.C) {
 	assertNoEvent(c, removedChan, "filesystems removed")
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.provisionVolume(names.NewVolumeTag("2"))
+	volumeAccessor.pendingSizes["volume-1"] = 2048
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		results := make([]storage.ResizeVolumesResult, len(args))
+		for i, arg := range args {
+			results[i].Volume = &storage.Volume{
+				arg.Tag, storage.VolumeInfo{VolumeId: arg.VolumeId, Size: 2050},
+			}
+		}
+		return results, nil
+	}
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return nil, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Only volume 1 has a pending resize.
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2"}
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Provider: "dummy",
+		Size:     2048,
+	}})
+
+	// The size reported by the provider is recorded, along
+	// with the existing volume info.
+	volumeInfo := waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(volumeInfo, jc.DeepEquals, []params.Volume{{
+		VolumeTag: "volume-1",
+		Info: params.VolumeInfo{
+			VolumeId: "vol-1",
+			Size:     2050,
+		},
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesRetry(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.pendingSizes["volume-1"] = 2048
+
+	clock := &mockClock{}
+	var resizeVolumeTimes []time.Time
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeVolumeTimes = append(resizeVolumeTimes, clock.Now())
+		results := make([]storage.ResizeVolumesResult, len(args))
+		if len(resizeVolumeTimes) < 3 {
+			results[0].Error = errors.New("badness")
+		} else {
+			results[0].Volume = &storage.Volume{
+				args[0].Tag, storage.VolumeInfo{VolumeId: args[0].VolumeId, Size: args[0].Size},
+			}
+		}
+		return results, nil
+	}
+	volumeInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		volumeInfoSet <- volumes
+		return nil, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, clock: clock, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(resizeVolumeTimes, gc.HasLen, 3)
+
+	// The first attempt should have been immediate: T0.
+	c.Assert(resizeVolumeTimes[0], gc.Equals, time.Time{})
+
+	delays := make([]time.Duration, len(resizeVolumeTimes)-1)
+	for i := range resizeVolumeTimes[1:] {
+		delays[i] = resizeVolumeTimes[i+1].Sub(resizeVolumeTimes[i])
+	}
+	c.Assert(delays, jc.DeepEquals, []time.Duration{
+		30 * time.Second,
+		1 * time.Minute,
+	})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumeBackedFilesystem(c *gc.C) {
+	filesystemInfoSet := make(chan interface{}, 1)
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		filesystemInfoSet <- filesystems
+		return nil, nil
+	}
+	filesystemAccessor.provisionedFilesystems["filesystem-0-0"] = params.Filesystem{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "whatever",
+			Size:         123,
+		},
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The backing volume's block device has grown larger
+	// than the filesystem, so the filesystem is grown.
+	args.volumes.blockDevices[params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       246,
+	}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"0/0"}
+
+	filesystemInfo := waitChannel(
+		c, filesystemInfoSet,
+		"waiting for filesystem info to be set",
+	).([]params.Filesystem)
+	c.Assert(filesystemInfo, jc.DeepEquals, []params.Filesystem{{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "whatever",
+			Size:         246,
+		},
+	}})
+}
+
 func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 	if args == nil {
 		args = &workerArgs{}
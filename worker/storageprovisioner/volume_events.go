@@ -95,6 +95,41 @@ func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageI
 	return nil
 }
 
+// volumeResizesChanged is called when the volumes with the provided IDs
+// have been seen to have changed, possibly because they have been
+// requested to be resized.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	paramsResults, err := ctx.config.Volumes.ResizeVolumeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			if params.IsCodeNotFoundOrCodeUnauthorized(result.Error) {
+				// The volume has been removed since the
+				// change was observed.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		if result.Result.Size == 0 {
+			// There is no resize pending for the volume.
+			continue
+		}
+		op := &resizeVolumeOp{tag: tags[i]}
+		ctx.schedule.Remove(op.key())
+		scheduleOperations(ctx, op)
+	}
+	return nil
+}
+
 // processDyingVolumes processes the VolumeResults for Dying volumes,
 // removing them from provisioning-pending as necessary.
 func processDyingVolumes(ctx *context, tags []names.Tag) error {
@@ -398,6 +433,22 @@ func removeVolumeParams(ctx *context, tags []names.VolumeTag) ([]params.RemoveVo
 	return allParams, nil
 }
 
+// resizeVolumeParams obtains the specified volumes' resize parameters.
+func resizeVolumeParams(ctx *context, tags []names.VolumeTag) ([]params.ResizeVolumeParams, error) {
+	paramsResults, err := ctx.config.Volumes.ResizeVolumeParams(tags)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting volume params")
+	}
+	allParams := make([]params.ResizeVolumeParams, len(tags))
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			return nil, errors.Annotate(result.Error, "getting volume resize parameters")
+		}
+		allParams[i] = result.Result
+	}
+	return allParams, nil
+}
+
 func volumesFromStorage(in []storage.Volume) []params.Volume {
 	out := make([]params.Volume, len(in))
 	for i, v := range in {
@@ -50,7 +50,12 @@ func createVolumes(ctx *context, ops map[names.VolumeTag]*createVolumeOp) error
 		if len(volumeParams) == 0 {
 			continue
 		}
-		results, err := volumeSource.CreateVolumes(volumeParams)
+		var results []storage.CreateVolumesResult
+		err := withinRetryBudget(ctx, func() error {
+			var err error
+			results, err = volumeSource.CreateVolumes(volumeParams)
+			return err
+		})
 		if err != nil {
 			return errors.Annotatef(err, "creating volumes from source %q", sourceName)
 		}
@@ -145,7 +150,12 @@ func attachVolumes(ctx *context, ops map[params.MachineStorageId]*attachVolumeOp
 			// to do here.
 			continue
 		}
-		results, err := volumeSource.AttachVolumes(volumeAttachmentParams)
+		var results []storage.AttachVolumesResult
+		err := withinRetryBudget(ctx, func() error {
+			var err error
+			results, err = volumeSource.AttachVolumes(volumeAttachmentParams)
+			return err
+		})
 		if err != nil {
 			return errors.Annotatef(err, "attaching volumes from source %q", sourceName)
 		}
@@ -270,6 +280,127 @@ func removeVolumes(ctx *context, ops map[names.VolumeTag]*removeVolumeOp) error
 	return nil
 }
 
+// resizeVolumes grows volumes to the sizes requested in state.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	tags := make([]names.VolumeTag, 0, len(ops))
+	for tag := range ops {
+		tags = append(tags, tag)
+	}
+	resizeVolumeParams, err := resizeVolumeParams(ctx, tags)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumeParams := make([]storage.VolumeParams, 0, len(tags))
+	resizeVolumeParamsByTag := make(map[names.VolumeTag]params.ResizeVolumeParams)
+	for i, args := range resizeVolumeParams {
+		if args.Size == 0 {
+			// The volume has already been resized.
+			continue
+		}
+		resizeVolumeParamsByTag[tags[i]] = args
+		volumeParams = append(volumeParams, storage.VolumeParams{
+			Tag:      tags[i],
+			Provider: storage.ProviderType(args.Provider),
+		})
+	}
+	paramsBySource, volumeSources, err := volumeParamsBySource(
+		ctx.config.StorageDir, volumeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var resized []storage.Volume
+	for sourceName, volumeParams := range paramsBySource {
+		volumeResizer, ok := volumeSources[sourceName].(storage.VolumeResizer)
+		if !ok {
+			logger.Warningf("volume source %q does not support resizing volumes", sourceName)
+			continue
+		}
+		args := make([]storage.VolumeResizeParams, len(volumeParams))
+		for i, p := range volumeParams {
+			resizeParams := resizeVolumeParamsByTag[p.Tag]
+			args[i] = storage.VolumeResizeParams{
+				Tag:      p.Tag,
+				VolumeId: resizeParams.VolumeId,
+				Provider: p.Provider,
+				Size:     resizeParams.Size,
+			}
+		}
+		logger.Debugf("resizing volumes from %q: %v", sourceName, args)
+		var results []storage.ResizeVolumesResult
+		err := withinRetryBudget(ctx, func() error {
+			var err error
+			results, err = volumeResizer.ResizeVolumes(args)
+			return err
+		})
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			if result.Error != nil {
+				reschedule = append(reschedule, ops[args[i].Tag])
+				logger.Debugf(
+					"failed to resize %s: %v",
+					names.ReadableString(args[i].Tag),
+					result.Error,
+				)
+				continue
+			}
+			resized = append(resized, *result.Volume)
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(resized) == 0 {
+		return nil
+	}
+
+	// The volume source only reports the new size of each volume,
+	// so we carry over the rest of the volume info from state.
+	resizedTags := make([]names.VolumeTag, len(resized))
+	for i, v := range resized {
+		resizedTags[i] = v.Tag
+	}
+	volumeResults, err := ctx.config.Volumes.Volumes(resizedTags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume information")
+	}
+	volumes := make([]storage.Volume, 0, len(resized))
+	for i, result := range volumeResults {
+		if result.Error != nil {
+			logger.Debugf(
+				"not recording size of %s: %v",
+				names.ReadableString(resizedTags[i]),
+				result.Error,
+			)
+			continue
+		}
+		volume, err := volumeFromParams(result.Result)
+		if err != nil {
+			return errors.Annotate(err, "getting volume information")
+		}
+		volume.Size = resized[i].Size
+		volumes = append(volumes, volume)
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumesFromStorage(volumes))
+	if err != nil {
+		return errors.Annotate(err, "publishing volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+		}
+	}
+	for _, v := range volumes {
+		updateVolume(ctx, v)
+	}
+	return nil
+}
+
 func partitionRemoveVolumeParams(removeTags []names.VolumeTag, removeParams []params.RemoveVolumeParams) (
 	destroyTags []names.VolumeTag, destroyIds []string,
 	releaseTags []names.VolumeTag, releaseIds []string,
@@ -315,7 +446,12 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 			// to do here.
 			continue
 		}
-		errs, err := volumeSource.DetachVolumes(volumeAttachmentParams)
+		var errs []error
+		err := withinRetryBudget(ctx, func() error {
+			var err error
+			errs, err = volumeSource.DetachVolumes(volumeAttachmentParams)
+			return err
+		})
 		if err != nil {
 			return errors.Annotatef(err, "detaching volumes from source %q", sourceName)
 		}
@@ -521,3 +657,12 @@ func (op *detachVolumeOp) key() interface{} {
 		AttachmentTag: op.args.Volume.String(),
 	}
 }
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	tag names.VolumeTag
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeKey{op.tag}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/runner"
 )
 
 // ManifoldConfig defines the names of the manifolds on which a
@@ -31,6 +32,10 @@ type ManifoldConfig struct {
 	CharmDirName          string
 	HookRetryStrategyName string
 	TranslateResolverErr  func(error) error
+
+	// HookMetrics, if not nil, records the durations of the hooks
+	// run by the uniter.
+	HookMetrics *runner.HookMetrics
 }
 
 // Manifold returns a dependency manifold that runs a uniter worker,
@@ -102,6 +107,8 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				NewOperationExecutor: operation.NewExecutor,
 				TranslateResolverErr: config.TranslateResolverErr,
 				Clock:                manifoldConfig.Clock,
+				ContainerHooks:       containerHooks(agentConfig),
+				HookMetrics:          config.HookMetrics,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -111,6 +118,12 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 	}
 }
 
+// containerHooks returns whether the agent is configured to run hooks
+// in containers.
+func containerHooks(config agent.Config) bool {
+	return config.Value(agent.HookRuntime) == "container"
+}
+
 // TranslateFortressErrors turns errors returned by dependent
 // manifolds due to fortress lockdown (i.e. model migration) into an
 // error which causes the resolver loop to be restarted. When this
@@ -104,6 +104,17 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case cause == context.ErrReboot:
 		err = ErrNeedsReboot
 	case err == nil:
+	case runner.IsHookTimedOutError(cause):
+		logger.Errorf("hook %q failed: %v", rh.name, err)
+		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		// Record the timeout, so the resolver can report it and
+		// leave retrying the hook to the user.
+		return stateChange{
+			Kind:         RunHook,
+			Step:         Pending,
+			Hook:         &rh.info,
+			HookTimedOut: true,
+		}.apply(state), ErrHookFailed
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
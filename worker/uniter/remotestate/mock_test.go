@@ -191,6 +191,7 @@ type mockUnit struct {
 	life                  params.Life
 	resolved              params.ResolvedMode
 	series                string
+	stopDeadline          *time.Time
 	application           mockApplication
 	unitWatcher           *mockNotifyWatcher
 	addressesWatcher      *mockNotifyWatcher
@@ -220,6 +221,13 @@ func (u *mockUnit) Series() string {
 	return u.series
 }
 
+func (u *mockUnit) StopDeadline() (time.Time, bool) {
+	if u.stopDeadline == nil {
+		return time.Time{}, false
+	}
+	return *u.stopDeadline, true
+}
+
 func (u *mockUnit) Tag() names.UnitTag {
 	return u.tag
 }
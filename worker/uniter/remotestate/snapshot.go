@@ -71,6 +71,11 @@ type Snapshot struct {
 
 	// Series is the current series running on the unit
 	Series string
+
+	// StopDeadlinePassed reports whether the dying unit's stop
+	// deadline has passed, so that it should stop without waiting
+	// any longer for its stop hook to succeed.
+	StopDeadlinePassed bool
 }
 
 type RelationSnapshot struct {
@@ -36,6 +36,7 @@ type Unit interface {
 	Resolved() params.ResolvedMode
 	Application() (Application, error)
 	Series() string
+	StopDeadline() (time.Time, bool)
 	Tag() names.UnitTag
 	Watch() (watcher.NotifyWatcher, error)
 	WatchAddresses() (watcher.NotifyWatcher, error)
@@ -5,9 +5,11 @@ package remotestate
 
 import (
 	"sync"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 
@@ -35,6 +37,11 @@ type RemoteStateWatcher struct {
 	updateStatusChannel       UpdateStatusTimerFunc
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
+	clock                     clock.Clock
+
+	// stopDeadline fires when the dying unit's stop deadline
+	// passes. It is nil until the unit has a deadline.
+	stopDeadline <-chan time.Time
 
 	catacomb catacomb.Catacomb
 
@@ -52,6 +59,9 @@ type WatcherConfig struct {
 	CommandChannel      <-chan string
 	RetryHookChannel    <-chan struct{}
 	UnitTag             names.UnitTag
+
+	// Clock is used to time the unit's stop deadline.
+	Clock clock.Clock
 }
 
 // NewWatcher returns a RemoteStateWatcher that handles state changes pertaining to the
@@ -67,6 +77,7 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		updateStatusChannel:       config.UpdateStatusChannel,
 		commandChannel:            config.CommandChannel,
 		retryHookChannel:          config.RetryHookChannel,
+		clock:                     config.Clock,
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -434,6 +445,11 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 				return err
 			}
 
+		case <-w.stopDeadline:
+			logger.Debugf("stop deadline passed")
+			w.stopDeadline = nil
+			w.stopDeadlinePassed()
+
 		case _, ok := <-w.retryHookChannel:
 			if !ok {
 				return errors.New("retryHookChannel closed")
@@ -483,9 +499,25 @@ func (w *RemoteStateWatcher) unitChanged() error {
 	w.current.Life = w.unit.Life()
 	w.current.ResolvedMode = w.unit.Resolved()
 	w.current.Series = w.unit.Series()
+	if w.stopDeadline == nil && !w.current.StopDeadlinePassed {
+		if deadline, ok := w.unit.StopDeadline(); ok {
+			if wait := deadline.Sub(w.clock.Now()); wait > 0 {
+				w.stopDeadline = w.clock.After(wait)
+			} else {
+				w.current.StopDeadlinePassed = true
+			}
+		}
+	}
 	return nil
 }
 
+// stopDeadlinePassed is called when the unit's stop deadline passes.
+func (w *RemoteStateWatcher) stopDeadlinePassed() {
+	w.mu.Lock()
+	w.current.StopDeadlinePassed = true
+	w.mu.Unlock()
+}
+
 // applicationChanged responds to changes in the application.
 func (w *RemoteStateWatcher) applicationChanged() error {
 	if err := w.service.Refresh(); err != nil {
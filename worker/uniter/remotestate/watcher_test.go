@@ -76,6 +76,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 		LeadershipTracker:   s.leadership,
 		UnitTag:             s.st.unit.tag,
 		UpdateStatusChannel: statusTicker,
+		Clock:               s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -206,6 +207,34 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 }
 
+func (s *WatcherSuite) TestStopDeadline(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	// The deadline falls before the update-status tick, so
+	// that advancing the clock to it triggers only the deadline.
+	deadline := s.clock.Now().Add(5 * time.Second)
+	s.st.unit.life = params.Dying
+	s.st.unit.stopDeadline = &deadline
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().StopDeadlinePassed, jc.IsFalse)
+
+	s.waitAlarmsStable(c)
+	s.clock.Advance(5 * time.Second)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().StopDeadlinePassed, jc.IsTrue)
+}
+
+func (s *WatcherSuite) TestStopDeadlineAlreadyPassed(c *gc.C) {
+	deadline := s.clock.Now()
+	s.st.unit.life = params.Dying
+	s.st.unit.stopDeadline = &deadline
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().StopDeadlinePassed, jc.IsTrue)
+}
+
 func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	signalAll(s.st, s.leadership)
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
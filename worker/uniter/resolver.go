@@ -18,6 +18,7 @@ import (
 type ResolverConfig struct {
 	ClearResolved       func() error
 	ReportHookError     func(hook.Info) error
+	ReportHookTimeout   func(hook.Info) error
 	ShouldRetryHooks    bool
 	StartRetryHookTimer func()
 	StopRetryHookTimer  func()
@@ -152,8 +153,21 @@ func (s *uniterResolver) nextOpHookError(
 	opFactory operation.Factory,
 ) (operation.Operation, error) {
 
+	if remoteState.Life == params.Dying && remoteState.StopDeadlinePassed {
+		// The stop grace period has expired, so there is no
+		// point waiting for the error to be resolved.
+		s.config.StopRetryHookTimer()
+		s.retryHookTimerStarted = false
+		logger.Infof("stop grace period expired; skipping failed %q hook", localState.Hook.Kind)
+		return opFactory.NewSkipHook(*localState.Hook)
+	}
+
 	// Report the hook error.
-	if err := s.config.ReportHookError(*localState.Hook); err != nil {
+	report := s.config.ReportHookError
+	if localState.HookTimedOut {
+		report = s.config.ReportHookTimeout
+	}
+	if err := report(*localState.Hook); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -174,6 +188,12 @@ func (s *uniterResolver) nextOpHookError(
 			s.retryHookTimerStarted = false
 			return opFactory.NewRunHook(*localState.Hook)
 		}
+		if localState.HookTimedOut {
+			// A hook that timed out is likely to do so again,
+			// holding the machine lock all the while, so it is
+			// only retried when the user resolves the error.
+			return nil, resolver.ErrNoOperation
+		}
 		if !s.retryHookTimerStarted && s.config.ShouldRetryHooks {
 			// We haven't yet started a retry timer, so start one
 			// now. If we retry and fail, retryHookTimerStarted is
@@ -239,6 +259,13 @@ func (s *uniterResolver) nextOp(
 		//           subordinates, relation units and storage
 		//           attachments into state, via cleanups.
 		if localState.Started {
+			if remoteState.StopDeadlinePassed {
+				// The stop grace period has expired, so the
+				// machine may be torn down at any moment;
+				// don't wait on the stop hook.
+				logger.Infof("stop grace period expired; skipping %q hook", hooks.Stop)
+				return opFactory.NewSkipHook(hook.Info{Kind: hooks.Stop})
+			}
 			return opFactory.NewRunHook(hook.Info{Kind: hooks.Stop})
 		}
 		fallthrough
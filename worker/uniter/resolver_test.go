@@ -33,8 +33,9 @@ type resolverSuite struct {
 	resolver             resolver.Resolver
 	resolverConfig       uniter.ResolverConfig
 
-	clearResolved   func() error
-	reportHookError func(hook.Info) error
+	clearResolved     func() error
+	reportHookError   func(hook.Info) error
+	reportHookTimeout func(hook.Info) error
 }
 
 var _ = gc.Suite(&resolverSuite{})
@@ -59,9 +60,14 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		return errors.New("unexpected report hook error")
 	}
 
+	s.reportHookTimeout = func(hook.Info) error {
+		return errors.New("unexpected report hook timeout")
+	}
+
 	s.resolverConfig = uniter.ResolverConfig{
 		ClearResolved:       func() error { return s.clearResolved() },
 		ReportHookError:     func(info hook.Info) error { return s.reportHookError(info) },
+		ReportHookTimeout:   func(info hook.Info) error { return s.reportHookTimeout(info) },
 		StartRetryHookTimer: func() { s.stub.AddCall("StartRetryHookTimer") },
 		StopRetryHookTimer:  func() { s.stub.AddCall("StopRetryHookTimer") },
 		ShouldRetryHooks:    true,
@@ -222,6 +228,39 @@ func (s *resolverSuite) TestHookErrorStartRetryTimerAgain(c *gc.C) {
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StartRetryHookTimer")
 }
 
+func (s *resolverSuite) TestHookTimeoutDoesNotStartRetryTimer(c *gc.C) {
+	var reported []hook.Info
+	s.reportHookTimeout = func(info hook.Info) error {
+		reported = append(reported, info)
+		return nil
+	}
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:         operation.RunHook,
+			Step:         operation.Pending,
+			Installed:    true,
+			Started:      true,
+			HookTimedOut: true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckNoCalls(c)
+	c.Assert(reported, jc.DeepEquals, []hook.Info{{Kind: hooks.ConfigChanged}})
+
+	// The hook is run again once the user resolves the error.
+	s.clearResolved = func() error { return nil }
+	s.remoteState.ResolvedMode = params.ResolvedRetryHooks
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+}
+
 func (s *resolverSuite) TestResolvedRetryHooksStopRetryTimer(c *gc.C) {
 	// Resolving a failed hook should stop the retry timer.
 	s.testResolveHookErrorStopRetryTimer(c, params.ResolvedRetryHooks)
@@ -285,3 +324,63 @@ func (s *resolverSuite) TestRunHookStopRetryTimer(c *gc.C) {
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StopRetryHookTimer")
 }
+
+func (s *resolverSuite) TestDyingRunsStopHook(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.Life = params.Dying
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run stop hook")
+}
+
+func (s *resolverSuite) TestStopDeadlinePassedSkipsStopHook(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.Life = params.Dying
+	s.remoteState.StopDeadlinePassed = true
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "skip run stop hook")
+}
+
+func (s *resolverSuite) TestStopDeadlinePassedSkipsFailedHook(c *gc.C) {
+	s.reportHookError = func(hook.Info) error { return nil }
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.Stop,
+			},
+		},
+	}
+	s.remoteState.Life = params.Dying
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	s.stub.CheckCallNames(c, "StartRetryHookTimer")
+
+	s.remoteState.StopDeadlinePassed = true
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "skip run stop hook")
+	s.stub.CheckCallNames(c, "StartRetryHookTimer", "StopRetryHookTimer")
+}
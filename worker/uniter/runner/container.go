@@ -0,0 +1,289 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	jujuos "github.com/juju/utils/os"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+// containerEngine is the command used to pull images and run
+// containers.
+const containerEngine = "docker"
+
+const (
+	// DefaultPullTimeout is the time allowed for a hook image to be
+	// pulled before the pull is abandoned.
+	DefaultPullTimeout = 10 * time.Minute
+
+	// inspectTimeout is the time allowed for the container engine
+	// to report whether an image is available locally.
+	inspectTimeout = time.Minute
+
+	// minPullRetryDelay and maxPullRetryDelay bound the time waited
+	// before an image that could not be pulled is pulled again; the
+	// delay doubles after each failure.
+	minPullRetryDelay = time.Minute
+	maxPullRetryDelay = 30 * time.Minute
+)
+
+// hookImageMeta holds the charm metadata field that declares the
+// container image a charm's hooks should run in. It is not part of
+// charm.Meta, which ignores unknown fields, so it is read separately.
+type hookImageMeta struct {
+	HookImage string `yaml:"hook-image"`
+}
+
+// hookImage returns the container image declared by the charm in
+// charmDir, or an empty string if it doesn't declare one.
+func hookImage(charmDir string) (string, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var meta hookImageMeta
+	if err := goyaml.Unmarshal(data, &meta); err != nil {
+		return "", errors.Annotate(err, "parsing charm metadata")
+	}
+	return strings.TrimSpace(meta.HookImage), nil
+}
+
+// RunContainerCommand runs the container engine with the supplied
+// arguments, returning its combined output. The engine is killed if
+// abort is closed or receives a value before it exits.
+func RunContainerCommand(abort <-chan time.Time, args ...string) ([]byte, error) {
+	var out bytes.Buffer
+	cmd := exec.Command(containerEngine, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		return out.Bytes(), err
+	case <-abort:
+		if err := cmd.Process.Kill(); err != nil {
+			logger.Warningf("cannot kill %s %s: %v", containerEngine, args[0], err)
+		}
+		<-done
+		return out.Bytes(), errors.Errorf("%s %s timed out", containerEngine, args[0])
+	}
+}
+
+// ContainerRuntimeConfig holds the parameters for a ContainerRuntime.
+type ContainerRuntimeConfig struct {
+	// Paths holds the unit's paths. The charm and hook tools
+	// directories are mapped into hook containers.
+	Paths context.Paths
+
+	// Fallback is used to run the hooks of charms that don't declare
+	// a hook image, or whose image cannot be pulled.
+	Fallback HookRuntime
+
+	// RunCommand runs the container engine with the supplied
+	// arguments, returning its combined output. The engine must be
+	// stopped, and an error returned, if abort delivers a value
+	// before it exits.
+	RunCommand func(abort <-chan time.Time, args ...string) ([]byte, error)
+
+	// Clock is used to time out image pulls, and to delay pulling
+	// images that could not be pulled again.
+	Clock clock.Clock
+
+	// PullTimeout is the time allowed for an image to be pulled.
+	PullTimeout time.Duration
+}
+
+// Validate returns an error if the config cannot be used to create
+// a ContainerRuntime.
+func (config ContainerRuntimeConfig) Validate() error {
+	if config.Paths == nil {
+		return errors.NotValidf("nil Paths")
+	}
+	if config.Fallback == nil {
+		return errors.NotValidf("nil Fallback")
+	}
+	if config.RunCommand == nil {
+		return errors.NotValidf("nil RunCommand")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PullTimeout <= 0 {
+		return errors.NotValidf("non-positive PullTimeout")
+	}
+	return nil
+}
+
+// ContainerRuntime is a HookRuntime that executes the hooks of charms
+// declaring a hook-image in their metadata inside a container created
+// from that image, isolating the charm's dependencies from the host.
+//
+// The charm directory is mapped into the container at the same path
+// as on the host, along with the hook tools. The container shares
+// the host's network namespace so that the hook tools can reach the
+// uniter. Each image is pulled the first time it is used; if it
+// cannot be pulled, and is not already available, hooks are run by
+// the fallback runtime instead, and the pull is retried after a
+// delay that grows with each failure. Hooks run while an image is
+// being pulled are also run by the fallback runtime.
+type ContainerRuntime struct {
+	config ContainerRuntimeConfig
+
+	mu     sync.Mutex
+	images map[string]*imageState
+}
+
+// imageState records the attempts to pull a hook image.
+type imageState struct {
+	pulled   bool
+	pulling  bool
+	failures int
+	retryAt  time.Time
+}
+
+// NewContainerRuntime returns a new ContainerRuntime with the given
+// config.
+func NewContainerRuntime(config ContainerRuntimeConfig) (*ContainerRuntime, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if jujuos.HostOS() == jujuos.Windows {
+		return nil, errors.NotSupportedf("running hooks in containers on windows")
+	}
+	return &ContainerRuntime{
+		config: config,
+		images: make(map[string]*imageState),
+	}, nil
+}
+
+// Command is part of the HookRuntime interface.
+func (r *ContainerRuntime) Command(charmDir, hookPath string, env []string) *exec.Cmd {
+	image, err := hookImage(charmDir)
+	if err != nil {
+		logger.Warningf("cannot read hook image, running hook on host: %v", err)
+		return r.config.Fallback.Command(charmDir, hookPath, env)
+	}
+	if image == "" {
+		return r.config.Fallback.Command(charmDir, hookPath, env)
+	}
+	if err := r.ensureImage(image); err != nil {
+		logger.Warningf("cannot use hook image %q, running hook on host: %v", image, err)
+		return r.config.Fallback.Command(charmDir, hookPath, env)
+	}
+
+	args := []string{
+		"run", "--rm",
+		"--network", "host",
+		"--volume", charmDir + ":" + charmDir,
+	}
+	args = append(args, r.toolsVolumes()...)
+	args = append(args, "--workdir", charmDir)
+	for _, kv := range env {
+		// Only the variable names are passed on the command line;
+		// the values are taken from the engine's own environment,
+		// so they don't show up in the process list.
+		args = append(args, "--env", strings.SplitN(kv, "=", 2)[0])
+	}
+	args = append(args, image, hookPath)
+	ps := exec.Command(containerEngine, args...)
+	ps.Env = append(os.Environ(), env...)
+	ps.Dir = charmDir
+	return ps
+}
+
+// toolsVolumes returns the arguments that map the hook tools into a
+// container. The unit's tools directory is a symlink to the directory
+// holding the agent's version of the tools, which is mapped in at
+// both paths so that the tools' own links resolve in the container.
+func (r *ContainerRuntime) toolsVolumes() []string {
+	toolsDir := r.config.Paths.GetToolsDir()
+	resolved, err := filepath.EvalSymlinks(toolsDir)
+	if err != nil {
+		logger.Warningf("cannot resolve tools directory %q: %v", toolsDir, err)
+		resolved = toolsDir
+	}
+	volumes := []string{"--volume", resolved + ":" + resolved + ":ro"}
+	if resolved != toolsDir {
+		volumes = append(volumes, "--volume", resolved+":"+toolsDir+":ro")
+	}
+	return volumes
+}
+
+// ensureImage pulls the image if it hasn't been pulled already. If
+// the pull fails, the image may still be used if it is available
+// locally. The lock is not held while pulling, so that a slow pull
+// does not hold up hooks that don't need the image.
+func (r *ContainerRuntime) ensureImage(image string) error {
+	r.mu.Lock()
+	state, ok := r.images[image]
+	if !ok {
+		state = &imageState{}
+		r.images[image] = state
+	}
+	switch {
+	case state.pulled:
+		r.mu.Unlock()
+		return nil
+	case state.pulling:
+		r.mu.Unlock()
+		return errors.New("image is being pulled")
+	case r.config.Clock.Now().Before(state.retryAt):
+		retryAt := state.retryAt
+		r.mu.Unlock()
+		return errors.Errorf("image could not be pulled; retrying after %s", retryAt.Format(time.RFC3339))
+	}
+	state.pulling = true
+	r.mu.Unlock()
+
+	err := r.pullImage(image)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state.pulling = false
+	if err != nil {
+		delay := minPullRetryDelay << uint(state.failures)
+		if delay > maxPullRetryDelay || delay <= 0 {
+			delay = maxPullRetryDelay
+		}
+		state.failures++
+		state.retryAt = r.config.Clock.Now().Add(delay)
+		return errors.Trace(err)
+	}
+	state.pulled = true
+	return nil
+}
+
+// pullImage pulls the image, or checks that it is available locally
+// if it cannot be pulled.
+func (r *ContainerRuntime) pullImage(image string) error {
+	logger.Infof("pulling hook image %q", image)
+	out, err := r.config.RunCommand(r.config.Clock.After(r.config.PullTimeout), "pull", image)
+	if err == nil {
+		return nil
+	}
+	if _, inspectErr := r.config.RunCommand(r.config.Clock.After(inspectTimeout), "image", "inspect", image); inspectErr != nil {
+		return errors.Annotatef(err, "pulling image: %s", strings.TrimSpace(string(out)))
+	}
+	logger.Warningf("cannot pull hook image %q, using local copy: %s", image, strings.TrimSpace(string(out)))
+	return nil
+}
@@ -0,0 +1,285 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	jujuos "github.com/juju/utils/os"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner"
+	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
+)
+
+type ContainerRuntimeSuite struct {
+	testing.IsolationSuite
+	paths    runnertesting.RealPaths
+	charmDir string
+	clock    *testing.Clock
+	calls    [][]string
+	pullErr  error
+	haveCopy bool
+
+	// pull, if not nil, is called to pull an image.
+	pull func(abort <-chan time.Time) ([]byte, error)
+}
+
+var _ = gc.Suite(&ContainerRuntimeSuite{})
+
+func (s *ContainerRuntimeSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hooks cannot be run in containers on windows")
+	}
+	s.IsolationSuite.SetUpTest(c)
+	s.paths = runnertesting.NewRealPaths(c)
+	s.charmDir = s.paths.GetCharmDir()
+	s.clock = testing.NewClock(time.Time{})
+	s.calls = nil
+	s.pullErr = nil
+	s.haveCopy = false
+	s.pull = nil
+}
+
+func (s *ContainerRuntimeSuite) runCommand(abort <-chan time.Time, args ...string) ([]byte, error) {
+	s.calls = append(s.calls, args)
+	switch args[0] {
+	case "pull":
+		if s.pull != nil {
+			return s.pull(abort)
+		}
+		if s.pullErr != nil {
+			return []byte("registry unreachable\n"), s.pullErr
+		}
+	case "image":
+		if !s.haveCopy {
+			return nil, errors.New("no such image")
+		}
+	}
+	return nil, nil
+}
+
+func (s *ContainerRuntimeSuite) writeMetadata(c *gc.C, metadata string) {
+	err := ioutil.WriteFile(filepath.Join(s.charmDir, "metadata.yaml"), []byte(metadata), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ContainerRuntimeSuite) config() runner.ContainerRuntimeConfig {
+	return runner.ContainerRuntimeConfig{
+		Paths:       s.paths,
+		Fallback:    runner.HostRuntime,
+		RunCommand:  s.runCommand,
+		Clock:       s.clock,
+		PullTimeout: time.Minute,
+	}
+}
+
+func (s *ContainerRuntimeSuite) newRuntime(c *gc.C) *runner.ContainerRuntime {
+	r, err := runner.NewContainerRuntime(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	return r
+}
+
+func (s *ContainerRuntimeSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*runner.ContainerRuntimeConfig)
+		err    string
+	}{{
+		mutate: func(config *runner.ContainerRuntimeConfig) { config.Paths = nil },
+		err:    "nil Paths not valid",
+	}, {
+		mutate: func(config *runner.ContainerRuntimeConfig) { config.Fallback = nil },
+		err:    "nil Fallback not valid",
+	}, {
+		mutate: func(config *runner.ContainerRuntimeConfig) { config.RunCommand = nil },
+		err:    "nil RunCommand not valid",
+	}, {
+		mutate: func(config *runner.ContainerRuntimeConfig) { config.Clock = nil },
+		err:    "nil Clock not valid",
+	}, {
+		mutate: func(config *runner.ContainerRuntimeConfig) { config.PullTimeout = 0 },
+		err:    "non-positive PullTimeout not valid",
+	}} {
+		c.Logf("test %d", i)
+		config := s.config()
+		test.mutate(&config)
+		_, err := runner.NewContainerRuntime(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ContainerRuntimeSuite) TestNotSupportedOnWindows(c *gc.C) {
+	s.PatchValue(&jujuos.HostOS, func() jujuos.OSType { return jujuos.Windows })
+	_, err := runner.NewContainerRuntime(s.config())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ContainerRuntimeSuite) TestCommand(c *gc.C) {
+	s.writeMetadata(c, "name: wordpress\nhook-image: example.com/wordpress-hooks:1.0\n")
+	hook := filepath.Join(s.charmDir, "hooks", "install")
+	r := s.newRuntime(c)
+
+	ps := r.Command(s.charmDir, hook, []string{"JUJU_UNIT_NAME=wordpress/0", "CHARM_DIR=" + s.charmDir})
+	toolsDir := s.paths.GetToolsDir()
+	c.Assert(filepath.Base(ps.Path), gc.Equals, "docker")
+	c.Assert(ps.Args[1:], jc.DeepEquals, []string{
+		"run", "--rm",
+		"--network", "host",
+		"--volume", s.charmDir + ":" + s.charmDir,
+		"--volume", toolsDir + ":" + toolsDir + ":ro",
+		"--workdir", s.charmDir,
+		"--env", "JUJU_UNIT_NAME",
+		"--env", "CHARM_DIR",
+		"example.com/wordpress-hooks:1.0",
+		hook,
+	})
+	c.Assert(ps.Dir, gc.Equals, s.charmDir)
+	c.Assert(ps.Env[len(ps.Env)-2:], jc.DeepEquals, []string{"JUJU_UNIT_NAME=wordpress/0", "CHARM_DIR=" + s.charmDir})
+	c.Assert(s.calls, jc.DeepEquals, [][]string{{"pull", "example.com/wordpress-hooks:1.0"}})
+
+	// The image is only pulled once.
+	r.Command(s.charmDir, hook, nil)
+	c.Assert(s.calls, gc.HasLen, 1)
+}
+
+func (s *ContainerRuntimeSuite) TestCommandNoHookImage(c *gc.C) {
+	s.writeMetadata(c, "name: wordpress\n")
+	hook := filepath.Join(s.charmDir, "hooks", "install")
+	ps := s.newRuntime(c).Command(s.charmDir, hook, []string{"A=B"})
+	c.Assert(ps.Args, jc.DeepEquals, []string{hook})
+	c.Assert(ps.Env, jc.DeepEquals, []string{"A=B"})
+	c.Assert(s.calls, gc.HasLen, 0)
+}
+
+func (s *ContainerRuntimeSuite) TestCommandNoMetadata(c *gc.C) {
+	hook := filepath.Join(s.charmDir, "hooks", "install")
+	ps := s.newRuntime(c).Command(s.charmDir, hook, nil)
+	c.Assert(ps.Args, jc.DeepEquals, []string{hook})
+}
+
+func (s *ContainerRuntimeSuite) TestCommandPullFailsFallsBackToHost(c *gc.C) {
+	s.writeMetadata(c, "hook-image: example.com/wordpress-hooks:1.0\n")
+	s.pullErr = errors.New("exit status 1")
+	hook := filepath.Join(s.charmDir, "hooks", "install")
+	r := s.newRuntime(c)
+
+	ps := r.Command(s.charmDir, hook, nil)
+	c.Assert(ps.Args, jc.DeepEquals, []string{hook})
+	c.Assert(s.calls, jc.DeepEquals, [][]string{
+		{"pull", "example.com/wordpress-hooks:1.0"},
+		{"image", "inspect", "example.com/wordpress-hooks:1.0"},
+	})
+
+	// The pull is not retried until the retry delay has passed,
+	// which doubles after each failure.
+	ps = r.Command(s.charmDir, hook, nil)
+	c.Assert(ps.Args, jc.DeepEquals, []string{hook})
+	c.Assert(s.calls, gc.HasLen, 2)
+	s.clock.Advance(time.Minute)
+	r.Command(s.charmDir, hook, nil)
+	c.Assert(s.calls, gc.HasLen, 4)
+	s.clock.Advance(time.Minute)
+	r.Command(s.charmDir, hook, nil)
+	c.Assert(s.calls, gc.HasLen, 4)
+
+	s.pullErr = nil
+	s.clock.Advance(time.Minute)
+	ps = r.Command(s.charmDir, hook, nil)
+	c.Assert(filepath.Base(ps.Path), gc.Equals, "docker")
+	c.Assert(s.calls, gc.HasLen, 5)
+}
+
+func (s *ContainerRuntimeSuite) TestCommandPullTimesOut(c *gc.C) {
+	s.writeMetadata(c, "hook-image: example.com/wordpress-hooks:1.0\n")
+	s.pull = func(abort <-chan time.Time) ([]byte, error) {
+		<-abort
+		return nil, errors.New("docker pull timed out")
+	}
+	hook := filepath.Join(s.charmDir, "hooks", "install")
+	r := s.newRuntime(c)
+
+	go func() {
+		// Only the pull waits for the clock; the image is not
+		// available locally.
+		err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		c.Check(err, jc.ErrorIsNil)
+	}()
+	ps := r.Command(s.charmDir, hook, nil)
+	c.Assert(ps.Args, jc.DeepEquals, []string{hook})
+}
+
+func (s *ContainerRuntimeSuite) TestCommandWhilePulling(c *gc.C) {
+	s.writeMetadata(c, "hook-image: example.com/wordpress-hooks:1.0\n")
+	pulling := make(chan struct{})
+	release := make(chan struct{})
+	s.pull = func(<-chan time.Time) ([]byte, error) {
+		close(pulling)
+		<-release
+		return nil, nil
+	}
+	hook := filepath.Join(s.charmDir, "hooks", "install")
+	r := s.newRuntime(c)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Command(s.charmDir, hook, nil)
+	}()
+	select {
+	case <-pulling:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for pull")
+	}
+
+	// Hooks run while the image is being pulled don't wait for it.
+	ps := r.Command(s.charmDir, hook, nil)
+	c.Assert(ps.Args, jc.DeepEquals, []string{hook})
+	close(release)
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for command")
+	}
+	ps = r.Command(s.charmDir, hook, nil)
+	c.Assert(filepath.Base(ps.Path), gc.Equals, "docker")
+}
+
+func (s *ContainerRuntimeSuite) TestCommandMapsResolvedToolsDir(c *gc.C) {
+	s.writeMetadata(c, "hook-image: example.com/wordpress-hooks:1.0\n")
+	versionDir := filepath.Join(c.MkDir(), "2.3.0-xenial-amd64")
+	err := os.Mkdir(versionDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	toolsDir := s.paths.GetToolsDir()
+	err = os.Remove(toolsDir)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Symlink(versionDir, toolsDir)
+	c.Assert(err, jc.ErrorIsNil)
+	hook := filepath.Join(s.charmDir, "hooks", "install")
+
+	ps := s.newRuntime(c).Command(s.charmDir, hook, nil)
+	args := strings.Join(ps.Args, " ")
+	c.Assert(args, jc.Contains, "--volume "+versionDir+":"+versionDir+":ro")
+	c.Assert(args, jc.Contains, "--volume "+versionDir+":"+toolsDir+":ro")
+}
+
+func (s *ContainerRuntimeSuite) TestCommandPullFailsUsesLocalCopy(c *gc.C) {
+	s.writeMetadata(c, "hook-image: example.com/wordpress-hooks:1.0\n")
+	s.pullErr = errors.New("exit status 1")
+	s.haveCopy = true
+	hook := filepath.Join(s.charmDir, "hooks", "install")
+
+	ps := s.newRuntime(c).Command(s.charmDir, hook, nil)
+	c.Assert(filepath.Base(ps.Path), gc.Equals, "docker")
+	c.Assert(strings.Join(ps.Args, " "), jc.HasSuffix, "example.com/wordpress-hooks:1.0 "+hook)
+}
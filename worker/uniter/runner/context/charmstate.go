@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/tar"
+)
+
+// ExportCharmState implements jujuc.ContextCharmState.
+func (ctx *HookContext) ExportCharmState(paths []string) error {
+	if len(paths) == 0 {
+		return errors.New("no paths specified")
+	}
+	files := make([]string, len(paths))
+	for i, path := range paths {
+		if err := checkCharmStatePath(path); err != nil {
+			return errors.Trace(err)
+		}
+		file := filepath.Join(ctx.charmDir, path)
+		if _, err := os.Stat(file); err != nil {
+			return errors.Annotatef(err, "cannot export %q", path)
+		}
+		files[i] = file
+	}
+
+	var buf bytes.Buffer
+	archive := gzip.NewWriter(&buf)
+	stripPrefix := ctx.charmDir + string(os.PathSeparator)
+	if _, err := tar.TarFiles(files, archive, stripPrefix); err != nil {
+		return errors.Annotate(err, "cannot archive charm state")
+	}
+	if err := archive.Close(); err != nil {
+		return errors.Annotate(err, "cannot archive charm state")
+	}
+	return ctx.unit.SetCharmStateSnapshot(buf.Bytes())
+}
+
+// ImportCharmState implements jujuc.ContextCharmState.
+func (ctx *HookContext) ImportCharmState(unitName string) error {
+	data, err := ctx.unit.CharmStateSnapshot(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	archive, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm state snapshot")
+	}
+	defer archive.Close()
+	if err := tar.UntarFiles(archive, ctx.charmDir); err != nil {
+		return errors.Annotate(err, "cannot restore charm state")
+	}
+	return nil
+}
+
+// checkCharmStatePath returns an error if the path may refer to
+// anything outside the charm directory.
+func checkCharmStatePath(path string) error {
+	if filepath.IsAbs(path) {
+		return errors.NotValidf("absolute path %q", path)
+	}
+	clean := filepath.Clean(path)
+	if clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(os.PathSeparator)) {
+		return errors.NotValidf("path %q outside the charm directory", path)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+type CharmStateSuite struct {
+	HookContextSuite
+}
+
+var _ = gc.Suite(&CharmStateSuite{})
+
+func (s *CharmStateSuite) TestExportImportCharmState(c *gc.C) {
+	exportCtx := s.getHookContext(c, "uuid", -1, "", noProxies)
+	exportDir := context.ContextCharmDir(exportCtx)
+	writeCharmFile(c, exportDir, "state/db.json", "db")
+	writeCharmFile(c, exportDir, "state/peers/0.json", "peer")
+	writeCharmFile(c, exportDir, "relation-cache.json", "cache")
+	writeCharmFile(c, exportDir, "unexported", "nope")
+
+	err := exportCtx.ExportCharmState([]string{"state", "relation-cache.json"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	importCtx := s.getHookContext(c, "uuid", -1, "", noProxies)
+	importDir := context.ContextCharmDir(importCtx)
+	c.Assert(importDir, gc.Not(gc.Equals), exportDir)
+	err = importCtx.ImportCharmState("u/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	for path, content := range map[string]string{
+		"state/db.json":       "db",
+		"state/peers/0.json":  "peer",
+		"relation-cache.json": "cache",
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(importDir, path))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(data), gc.Equals, content)
+	}
+	_, err = os.Stat(filepath.Join(importDir, "unexported"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *CharmStateSuite) TestExportCharmStateInvalidPaths(c *gc.C) {
+	ctx := s.getHookContext(c, "uuid", -1, "", noProxies)
+	for _, path := range []string{"/etc", "..", "../other", "state/../../other", "."} {
+		err := ctx.ExportCharmState([]string{path})
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("path %q", path))
+	}
+	err := ctx.ExportCharmState(nil)
+	c.Assert(err, gc.ErrorMatches, "no paths specified")
+	err = ctx.ExportCharmState([]string{"missing"})
+	c.Assert(err, gc.ErrorMatches, `cannot export "missing": .*`)
+}
+
+func (s *CharmStateSuite) TestImportCharmStateNotFound(c *gc.C) {
+	ctx := s.getHookContext(c, "uuid", -1, "", noProxies)
+	err := ctx.ImportCharmState("u/1")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func writeCharmFile(c *gc.C, charmDir, path, content string) {
+	path = filepath.Join(charmDir, path)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
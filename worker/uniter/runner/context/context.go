@@ -212,6 +212,10 @@ type HookContext struct {
 	componentDir   func(string) string
 	componentFuncs map[string]ComponentFunc
 
+	// charmDir is the directory in which the charm is installed, and
+	// relative to which charm state is exported and imported.
+	charmDir string
+
 	//  slaLevel contains the current SLA level.
 	slaLevel string
 }
@@ -507,6 +511,15 @@ func (ctx *HookContext) SetActionMessage(message string) error {
 	return nil
 }
 
+// LogActionMessage logs a progress message for the Action, which can
+// be watched while the Action runs.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.LogActionMessage(ctx.actionData.Tag, message)
+}
+
 // SetActionFailed sets the fail state of the action.
 func (ctx *HookContext) SetActionFailed() error {
 	if ctx.actionData == nil {
@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -170,6 +171,7 @@ func (f *contextFactory) coreContext() (*HookContext, error) {
 		clock:              f.clock,
 		componentDir:       f.paths.ComponentDir,
 		componentFuncs:     registeredComponentFuncs,
+		charmDir:           f.paths.GetCharmDir(),
 		availabilityzone:   f.zone,
 		principal:          f.principal,
 	}
@@ -189,10 +191,36 @@ func (f *contextFactory) ActionContext(actionData *ActionData) (*HookContext, er
 		return nil, errors.Trace(err)
 	}
 	ctx.actionData = actionData
+	if actionData.Name == actions.JujuRunActionName {
+		// Commands run by juju-run may ask for a relation context,
+		// as they may when run locally with juju-run.
+		relationId, remoteUnitName, err := inferRemoteUnit(ctx.relations, jujuRunCommandInfo(actionData.Params))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ctx.relationId = relationId
+		ctx.remoteUnitName = remoteUnitName
+	}
 	ctx.id = f.newId(actionData.Name)
 	return ctx, nil
 }
 
+// jujuRunCommandInfo returns the CommandInfo describing the relation
+// context requested by the parameters of a juju-run action.
+func jujuRunCommandInfo(params map[string]interface{}) CommandInfo {
+	info := CommandInfo{RelationId: -1}
+	// Numbers are decoded as float64 once the parameters have been
+	// through the API.
+	switch id := params["relation-id"].(type) {
+	case float64:
+		info.RelationId = int(id)
+	case int:
+		info.RelationId = id
+	}
+	info.RemoteUnitName, _ = params["remote-unit"].(string)
+	return info
+}
+
 // HookContext is part of the ContextFactory interface.
 func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 	ctx, err := f.coreContext()
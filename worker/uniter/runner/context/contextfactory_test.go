@@ -262,6 +262,42 @@ func (s *ContextFactorySuite) TestActionContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestJujuRunActionContextRelation(c *gc.C) {
+	s.membership[0] = []string{"foo/2"}
+	actionData := &context.ActionData{
+		Name: "juju-run",
+		Tag:  names.NewActionTag("2fb32324-3a5b-4d3b-8f5a-3f9f8ff0b6c1"),
+		Params: map[string]interface{}{
+			"command":     "relation-get",
+			"relation-id": float64(0),
+		},
+		ResultsMap: map[string]interface{}{},
+	}
+
+	ctx, err := s.factory.ActionContext(actionData)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.AssertCoreContext(c, ctx)
+	s.AssertActionContext(c, ctx)
+	s.AssertRelationContext(c, ctx, 0, "foo/2")
+	s.AssertNotStorageContext(c, ctx)
+}
+
+func (s *ContextFactorySuite) TestJujuRunActionContextUnknownRelation(c *gc.C) {
+	actionData := &context.ActionData{
+		Name: "juju-run",
+		Tag:  names.NewActionTag("2fb32324-3a5b-4d3b-8f5a-3f9f8ff0b6c1"),
+		Params: map[string]interface{}{
+			"command":     "relation-get",
+			"relation-id": float64(42),
+		},
+		ResultsMap: map[string]interface{}{},
+	}
+
+	_, err := s.factory.ActionContext(actionData)
+	c.Assert(err, gc.ErrorMatches, "unknown relation id: 42")
+}
+
 func (s *ContextFactorySuite) TestCommandContext(c *gc.C) {
 	ctx, err := s.factory.CommandContext(context.CommandInfo{RelationId: -1})
 	c.Assert(err, jc.ErrorIsNil)
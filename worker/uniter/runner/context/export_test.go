@@ -56,6 +56,7 @@ func NewHookContext(
 		pendingPorts:       make(map[PortRange]PortRangeInfo),
 		assignedMachineTag: assignedMachineTag,
 		clock:              clock,
+		charmDir:           paths.GetCharmDir(),
 	}
 	// Get and cache the addresses.
 	var err error
@@ -166,6 +167,10 @@ func ContextEnvInfo(hctx *HookContext) (name, uuid string) {
 	return hctx.envName, hctx.uuid
 }
 
+func ContextCharmDir(hctx *HookContext) string {
+	return hctx.charmDir
+}
+
 func ContextMachineTag(hctx *HookContext) names.MachineTag {
 	return hctx.assignedMachineTag
 }
@@ -4,6 +4,7 @@
 package runner
 
 import (
+	"sort"
 	"strings"
 )
 
@@ -53,3 +54,28 @@ func mergeWindowsEnvironment(newEnv, env []string) []string {
 	}
 	return tmpEnv
 }
+
+// overrideEnvironment returns env, a list of environment variables in
+// os.Environ form, with the values of the variables in overrides
+// replaced or added.
+func overrideEnvironment(env []string, overrides map[string]string) []string {
+	if len(overrides) == 0 {
+		return env
+	}
+	result := make([]string, 0, len(env)+len(overrides))
+	for _, val := range env {
+		k := strings.SplitN(val, "=", 2)[0]
+		if _, ok := overrides[k]; !ok {
+			result = append(result, val)
+		}
+	}
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		result = append(result, k+"="+overrides[k])
+	}
+	return result
+}
@@ -59,3 +59,13 @@ func (s *MergeEnvSuite) TestMergeEnvWin(c *gc.C) {
 	expected := []string{"a=baz", "b=bar", "c=omg", "foo=val2", "d=another"}
 	c.Check(created, jc.SameContents, expected)
 }
+
+func (s *MergeEnvSuite) TestOverrideEnvironment(c *gc.C) {
+	initial := []string{"a=foo", "b=bar=baz", "c=omg"}
+	created := runner.OverrideEnvironment(initial, map[string]string{
+		"d": "new",
+		"b": "changed",
+	})
+	c.Check(created, jc.DeepEquals, []string{"a=foo", "c=omg", "b=changed", "d=new"})
+	c.Check(runner.OverrideEnvironment(initial, nil), jc.DeepEquals, initial)
+}
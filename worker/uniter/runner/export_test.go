@@ -4,16 +4,35 @@
 package runner
 
 import (
+	"time"
+
+	"github.com/juju/utils/clock"
+
 	"github.com/juju/juju/worker/uniter/runner/context"
 )
 
 var (
 	MergeWindowsEnvironment = mergeWindowsEnvironment
+	OverrideEnvironment     = overrideEnvironment
 	SearchHook              = searchHook
 	HookCommand             = hookCommand
 	LookPath                = lookPath
+	HookTimeout             = hookTimeout
+	StopHookTimeout         = stopHookTimeout
 )
 
+// NewRunnerWithHookTimeout returns a Runner that kills hooks running
+// for longer than timeout.
+func NewRunnerWithHookTimeout(ctx Context, paths context.Paths, timeout time.Duration) Runner {
+	return &runner{
+		context:     ctx,
+		paths:       paths,
+		runtime:     HostRuntime,
+		hookTimeout: timeout,
+		clock:       clock.WallClock,
+	}
+}
+
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
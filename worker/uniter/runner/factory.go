@@ -5,7 +5,9 @@ package runner
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/uniter"
@@ -33,18 +35,24 @@ type Factory interface {
 }
 
 // NewFactory returns a Factory capable of creating runners for executing
-// charm hooks, actions and commands.
+// charm hooks, actions and commands. Hooks and actions are executed
+// using the supplied runtime.
 func NewFactory(
 	state *uniter.State,
 	paths context.Paths,
 	contextFactory context.ContextFactory,
+	runtime HookRuntime,
 ) (
 	Factory, error,
 ) {
+	if runtime == nil {
+		return nil, errors.NotValidf("nil runtime")
+	}
 	f := &factory{
 		state:          state,
 		paths:          paths,
 		contextFactory: contextFactory,
+		runtime:        runtime,
 	}
 
 	return f, nil
@@ -57,7 +65,8 @@ type factory struct {
 	state *uniter.State
 
 	// Fields that shouldn't change in a factory's lifetime.
-	paths context.Paths
+	paths   context.Paths
+	runtime HookRuntime
 }
 
 // NewCommandRunner exists to satisfy the Factory interface.
@@ -66,7 +75,7 @@ func (f *factory) NewCommandRunner(commandInfo context.CommandInfo) (Runner, err
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
+	runner := NewRunnerWithRuntime(ctx, f.paths, f.runtime)
 	return runner, nil
 }
 
@@ -80,8 +89,28 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	runner := NewRunner(ctx, f.paths)
-	return runner, nil
+	timeout, err := hookTimeout(f.paths.GetCharmDir(), f.state.HookTimeout)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if hookInfo.Kind == hooks.Stop {
+		// The stop hook must not outlive the unit's stop
+		// grace period.
+		unit, err := f.state.Unit(names.NewUnitTag(ctx.UnitName()))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if deadline, ok := unit.StopDeadline(); ok {
+			timeout = stopHookTimeout(timeout, deadline, clock.WallClock.Now())
+		}
+	}
+	return &runner{
+		context:     ctx,
+		paths:       f.paths,
+		runtime:     f.runtime,
+		hookTimeout: timeout,
+		clock:       clock.WallClock,
+	}, nil
 }
 
 // NewActionRunner exists to satisfy the Factory interface.
@@ -123,7 +152,7 @@ func (f *factory) NewActionRunner(actionId string) (Runner, error) {
 
 	actionData := context.NewActionData(name, &tag, params)
 	ctx, err := f.contextFactory.ActionContext(actionData)
-	runner := NewRunner(ctx, f.paths)
+	runner := NewRunnerWithRuntime(ctx, f.paths, f.runtime)
 	return runner, nil
 }
 
@@ -174,6 +174,7 @@ func (s *FactorySuite) TestNewHookRunnerWithStorage(c *gc.C) {
 		uniter,
 		s.paths,
 		contextFactory,
+		runner.HostRuntime,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+type charmStateExportCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	paths []string
+}
+
+// NewCharmStateExportCommand creates a charm-state-export command.
+func NewCharmStateExportCommand(ctx Context) (cmd.Command, error) {
+	return &charmStateExportCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *charmStateExportCommand) Info() *cmd.Info {
+	doc := `
+charm-state-export stores a snapshot of the given files and directories,
+relative to the charm directory, in the controller. The snapshot replaces
+any previously exported by the unit, and is kept after the unit is
+removed, so that a replacement unit can restore it with charm-state-import.
+`
+	return &cmd.Info{
+		Name:    "charm-state-export",
+		Args:    "<path> [<path>...]",
+		Purpose: "export charm-local state for restoring on another unit",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *charmStateExportCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no paths specified")
+	}
+	c.paths = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *charmStateExportCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.ExportCharmState(c.paths)
+}
@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type CharmStateExportSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&CharmStateExportSuite{})
+
+func (s *CharmStateExportSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("charm-state-export"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *CharmStateExportSuite) TestNoArguments(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 2)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR no paths specified\n")
+	c.Check(hctx.info.CharmState.ExportedPaths, gc.IsNil)
+}
+
+func (s *CharmStateExportSuite) TestExport(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"state", "cache/relations.json"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState.ExportedPaths, jc.DeepEquals, []string{"state", "cache/relations.json"})
+}
+
+func (s *CharmStateExportSuite) TestExportError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New("too big"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"state"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR too big\n")
+	c.Check(hctx.info.CharmState.ExportedPaths, gc.IsNil)
+}
+
+func (s *CharmStateExportSuite) TestHelp(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--help"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `
+Usage: charm-state-export <path> [<path>...]
+
+Summary:
+export charm-local state for restoring on another unit
+
+Details:
+charm-state-export stores a snapshot of the given files and directories,
+relative to the charm directory, in the controller. The snapshot replaces
+any previously exported by the unit, and is kept after the unit is
+removed, so that a replacement unit can restore it with charm-state-import.
+`[1:])
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+}
@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+type charmStateImportCommand struct {
+	cmd.CommandBase
+	ctx Context
+
+	unitName string
+}
+
+// NewCharmStateImportCommand creates a charm-state-import command.
+func NewCharmStateImportCommand(ctx Context) (cmd.Command, error) {
+	return &charmStateImportCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *charmStateImportCommand) Info() *cmd.Info {
+	doc := `
+charm-state-import restores the charm-local state last exported with
+charm-state-export by the given unit of the same application into the
+charm directory. The unit need no longer exist.
+`
+	return &cmd.Info{
+		Name:    "charm-state-import",
+		Args:    "<unit-name>",
+		Purpose: "restore charm-local state exported by another unit",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *charmStateImportCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no unit name specified")
+	}
+	if !names.IsValidUnit(args[0]) {
+		return errors.Errorf("invalid unit name %q", args[0])
+	}
+	c.unitName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *charmStateImportCommand) Run(ctx *cmd.Context) error {
+	return c.ctx.ImportCharmState(c.unitName)
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type CharmStateImportSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&CharmStateImportSuite{})
+
+func (s *CharmStateImportSuite) createCommand(c *gc.C, err error) (*Context, cmd.Command) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(err)
+
+	com, err := jujuc.NewCommand(hctx, cmdString("charm-state-import"))
+	c.Assert(err, jc.ErrorIsNil)
+	return hctx, com
+}
+
+func (s *CharmStateImportSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "ERROR no unit name specified\n",
+	}, {
+		args: []string{"foo"},
+		err:  "ERROR invalid unit name \"foo\"\n",
+	}, {
+		args: []string{"foo/0", "bar"},
+		err:  "ERROR unrecognized args: [\"bar\"]\n",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx, com := s.createCommand(c, nil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 2)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.err)
+		c.Check(hctx.info.CharmState.ImportedUnit, gc.Equals, "")
+	}
+}
+
+func (s *CharmStateImportSuite) TestImport(c *gc.C) {
+	hctx, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"u/1"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState.ImportedUnit, gc.Equals, "u/1")
+}
+
+func (s *CharmStateImportSuite) TestImportError(c *gc.C) {
+	hctx, com := s.createCommand(c, errors.New("no snapshot"))
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"u/1"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR no snapshot\n")
+	c.Check(hctx.info.CharmState.ImportedUnit, gc.Equals, "")
+}
+
+func (s *CharmStateImportSuite) TestHelp(c *gc.C) {
+	_, com := s.createCommand(c, nil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"--help"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `
+Usage: charm-state-import <unit-name>
+
+Summary:
+restore charm-local state exported by another unit
+
+Details:
+charm-state-import restores the charm-local state last exported with
+charm-state-export by the given unit of the same application into the
+charm directory. The unit need no longer exist.
+`[1:])
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+}
@@ -54,6 +54,7 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextCharmState
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -96,6 +97,9 @@ type actionHookContext interface {
 	// SetActionMessage sets a message for the Action.
 	SetActionMessage(string) error
 
+	// LogActionMessage logs a progress message for the Action.
+	LogActionMessage(string) error
+
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
 }
@@ -395,3 +399,17 @@ func (v *storageIdValue) Set(value string) error {
 	*v.result = tag
 	return nil
 }
+
+// ContextCharmState expresses the parts of a hook context related to
+// preserving charm-local state across units of an application.
+type ContextCharmState interface {
+
+	// ExportCharmState stores a snapshot of the given files and
+	// directories, relative to the charm directory, replacing any
+	// snapshot the unit previously stored.
+	ExportCharmState(paths []string) error
+
+	// ImportCharmState restores the snapshot last stored by the named
+	// unit of the same application into the charm directory.
+	ImportCharmState(unitName string) error
+}
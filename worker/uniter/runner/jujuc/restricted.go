@@ -130,6 +130,9 @@ func (*RestrictedContext) UpdateActionResults(keys []string, value string) error
 // SetActionMessage implements jujuc.Context.
 func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedContext }
 
+// LogActionMessage implements jujuc.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
 // SetActionFailed implements jujuc.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
@@ -147,3 +150,13 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// ExportCharmState implements jujuc.Context.
+func (*RestrictedContext) ExportCharmState([]string) error {
+	return ErrRestrictedContext
+}
+
+// ImportCharmState implements jujuc.Context.
+func (*RestrictedContext) ImportCharmState(string) error {
+	return ErrRestrictedContext
+}
@@ -53,6 +53,7 @@ var baseCommands = map[string]creator{
 	"action-get" + cmdSuffix:              NewActionGetCommand,
 	"action-set" + cmdSuffix:              NewActionSetCommand,
 	"action-fail" + cmdSuffix:             NewActionFailCommand,
+	"action-log" + cmdSuffix:              NewActionLogCommand,
 	"relation-ids" + cmdSuffix:            NewRelationIdsCommand,
 	"relation-list" + cmdSuffix:           NewRelationListCommand,
 	"relation-set" + cmdSuffix:            NewRelationSetCommand,
@@ -61,8 +62,11 @@ var baseCommands = map[string]creator{
 	"juju-reboot" + cmdSuffix:             NewJujuRebootCommand,
 	"status-get" + cmdSuffix:              NewStatusGetCommand,
 	"status-set" + cmdSuffix:              NewStatusSetCommand,
+	"status-progress" + cmdSuffix:         NewStatusProgressCommand,
 	"network-get" + cmdSuffix:             NewNetworkGetCommand,
 	"application-version-set" + cmdSuffix: NewApplicationVersionSetCommand,
+	"charm-state-export" + cmdSuffix:      NewCharmStateExportCommand,
+	"charm-state-import" + cmdSuffix:      NewCharmStateImportCommand,
 }
 
 var storageCommands = map[string]creator{
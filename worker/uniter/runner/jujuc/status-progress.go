@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+const (
+	// ProgressPercentKey is the unit status data key holding the
+	// percentage reported by status-progress.
+	ProgressPercentKey = "progress-percent"
+
+	// ProgressMessageKey is the unit status data key holding the
+	// message reported by status-progress.
+	ProgressMessageKey = "progress-message"
+)
+
+// StatusProgressCommand implements the status-progress command.
+type StatusProgressCommand struct {
+	cmd.CommandBase
+	ctx     Context
+	percent int
+	message string
+}
+
+// NewStatusProgressCommand makes a jujuc status-progress command.
+func NewStatusProgressCommand(ctx Context) (cmd.Command, error) {
+	return &StatusProgressCommand{ctx: ctx}, nil
+}
+
+func (c *StatusProgressCommand) Info() *cmd.Info {
+	doc := `
+Reports the progress of a long-running operation, such as an install
+or upgrade. The percentage and message are recorded in the data of
+the unit's workload status, which is otherwise left unchanged, so
+each report is kept in the unit's status history.
+`
+	return &cmd.Info{
+		Name:    "status-progress",
+		Args:    "<percent> [message]",
+		Purpose: "report progress of a long-running hook",
+		Doc:     doc,
+	}
+}
+
+func (c *StatusProgressCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("invalid args, require <percent> [message]")
+	}
+	percent, err := strconv.Atoi(args[0])
+	if err != nil || percent < 0 || percent > 100 {
+		return errors.Errorf("invalid percent %q, expected a number from 0 to 100", args[0])
+	}
+	c.percent = percent
+	if len(args) > 1 {
+		c.message = args[1]
+		return cmd.CheckEmpty(args[2:])
+	}
+	return nil
+}
+
+func (c *StatusProgressCommand) Run(ctx *cmd.Context) error {
+	// Progress is recorded alongside the workload status set by
+	// the charm, rather than replacing it.
+	current, err := c.ctx.UnitStatus()
+	if err != nil {
+		return errors.Annotate(err, "finding workload status")
+	}
+	data := make(map[string]interface{}, len(current.Data)+2)
+	for key, value := range current.Data {
+		data[key] = value
+	}
+	data[ProgressPercentKey] = c.percent
+	data[ProgressMessageKey] = c.message
+	return c.ctx.SetUnitStatus(StatusInfo{
+		Status: current.Status,
+		Info:   current.Info,
+		Data:   data,
+	})
+}
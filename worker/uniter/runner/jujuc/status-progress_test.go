@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type statusProgressSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&statusProgressSuite{})
+
+var statusProgressInitTests = []struct {
+	args []string
+	err  string
+}{
+	{[]string{"0"}, ""},
+	{[]string{"100", ""}, ""},
+	{[]string{"42", "installing packages"}, ""},
+	{[]string{}, `invalid args, require <percent> \[message\]`},
+	{[]string{"42", "hello", "extra"}, `unrecognized args: \["extra"\]`},
+	{[]string{"lots"}, `invalid percent "lots", expected a number from 0 to 100`},
+	{[]string{"-1"}, `invalid percent "-1", expected a number from 0 to 100`},
+	{[]string{"101"}, `invalid percent "101", expected a number from 0 to 100`},
+}
+
+func (s *statusProgressSuite) TestInit(c *gc.C) {
+	for i, t := range statusProgressInitTests {
+		c.Logf("test %d: %#v", i, t.args)
+		hctx := s.GetStatusHookContext(c)
+		com, err := jujuc.NewCommand(hctx, cmdString("status-progress"))
+		c.Assert(err, jc.ErrorIsNil)
+		cmdtesting.TestInit(c, com, t.args, t.err)
+	}
+}
+
+func (s *statusProgressSuite) TestStatusProgress(c *gc.C) {
+	for i, t := range []struct {
+		args    []string
+		percent int
+		message string
+	}{{
+		args:    []string{"42", "installing packages"},
+		percent: 42,
+		message: "installing packages",
+	}, {
+		args:    []string{"100"},
+		percent: 100,
+	}} {
+		c.Logf("test %d: %#v", i, t.args)
+		hctx := s.GetStatusHookContext(c)
+		err := hctx.SetUnitStatus(jujuc.StatusInfo{
+			Status: "active",
+			Info:   "serving",
+			Data:   map[string]interface{}{"foo": "bar"},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		com, err := jujuc.NewCommand(hctx, cmdString("status-progress"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Assert(code, gc.Equals, 0)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Assert(bufferString(ctx.Stdout), gc.Equals, "")
+
+		// The workload status is left as the charm set it.
+		status, err := hctx.UnitStatus()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(status.Status, gc.Equals, "active")
+		c.Assert(status.Info, gc.Equals, "serving")
+		c.Assert(status.Data, jc.DeepEquals, map[string]interface{}{
+			"foo":                    "bar",
+			jujuc.ProgressPercentKey: t.percent,
+			jujuc.ProgressMessageKey: t.message,
+		})
+	}
+}
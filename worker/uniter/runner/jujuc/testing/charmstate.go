@@ -0,0 +1,40 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"github.com/juju/errors"
+)
+
+// CharmState holds values for the hook context.
+type CharmState struct {
+	ExportedPaths []string
+	ImportedUnit  string
+}
+
+// ContextCharmState is a test double for jujuc.ContextCharmState.
+type ContextCharmState struct {
+	contextBase
+	info *CharmState
+}
+
+// ExportCharmState implements jujuc.ContextCharmState.
+func (c *ContextCharmState) ExportCharmState(paths []string) error {
+	c.stub.AddCall("ExportCharmState", paths)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	c.info.ExportedPaths = paths
+	return nil
+}
+
+// ImportCharmState implements jujuc.ContextCharmState.
+func (c *ContextCharmState) ImportCharmState(unitName string) error {
+	c.stub.AddCall("ImportCharmState", unitName)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	c.info.ImportedUnit = unitName
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+const (
+	unitLabel   = "unit"
+	hookLabel   = "hook"
+	resultLabel = "result"
+)
+
+// HookMetrics is a prometheus.Collector that collects the durations
+// of the hooks run by the runners of a Factory returned by its Factory
+// method.
+type HookMetrics struct {
+	hookDuration *prometheus.SummaryVec
+}
+
+// NewHookMetrics returns a new HookMetrics.
+func NewHookMetrics() *HookMetrics {
+	return &HookMetrics{
+		hookDuration: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Namespace: "juju",
+			Name:      "uniter_hook_duration_seconds",
+			Help:      "Time taken running charm hooks.",
+		}, []string{unitLabel, hookLabel, resultLabel}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *HookMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.hookDuration.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *HookMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.hookDuration.Collect(ch)
+}
+
+// Factory returns a Factory that creates runners with the supplied
+// Factory, recording the duration of the hooks run by them for the
+// given unit. Hooks are recorded by kind rather than by name, so that
+// relation hooks for different relations are recorded together.
+func (m *HookMetrics) Factory(factory Factory, unit names.UnitTag, clock clock.Clock) Factory {
+	return &metricsFactory{
+		Factory: factory,
+		metrics: m,
+		unit:    unit.Id(),
+		clock:   clock,
+	}
+}
+
+type metricsFactory struct {
+	Factory
+	metrics *HookMetrics
+	unit    string
+	clock   clock.Clock
+}
+
+// NewHookRunner exists to satisfy the Factory interface.
+func (f *metricsFactory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
+	runner, err := f.Factory.NewHookRunner(hookInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &metricsRunner{
+		Runner:   runner,
+		factory:  f,
+		hookKind: string(hookInfo.Kind),
+	}, nil
+}
+
+type metricsRunner struct {
+	Runner
+	factory  *metricsFactory
+	hookKind string
+}
+
+// RunHook exists to satisfy the Runner interface.
+func (r *metricsRunner) RunHook(name string) error {
+	start := r.factory.clock.Now()
+	err := r.Runner.RunHook(name)
+	if context.IsMissingHookError(errors.Cause(err)) {
+		return err
+	}
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	r.factory.metrics.observe(r.factory.unit, r.hookKind, result, r.factory.clock.Now().Sub(start))
+	return err
+}
+
+func (m *HookMetrics) observe(unit, hookKind, result string, duration time.Duration) {
+	m.hookDuration.With(prometheus.Labels{
+		unitLabel:   unit,
+		hookLabel:   hookKind,
+		resultLabel: result,
+	}).Observe(duration.Seconds())
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/runner"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+type HookMetricsSuite struct {
+	testing.IsolationSuite
+	clock   *testing.Clock
+	metrics *runner.HookMetrics
+	factory runner.Factory
+	results map[string]error
+}
+
+var _ = gc.Suite(&HookMetricsSuite{})
+
+func (s *HookMetricsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.metrics = runner.NewHookMetrics()
+	s.results = make(map[string]error)
+	s.factory = s.metrics.Factory(
+		&fakeFactory{suite: s}, names.NewUnitTag("mysql/0"), s.clock,
+	)
+}
+
+type fakeFactory struct {
+	runner.Factory
+	suite *HookMetricsSuite
+}
+
+func (f *fakeFactory) NewHookRunner(hook.Info) (runner.Runner, error) {
+	return &fakeRunner{suite: f.suite}, nil
+}
+
+type fakeRunner struct {
+	runner.Runner
+	suite *HookMetricsSuite
+}
+
+func (r *fakeRunner) RunHook(name string) error {
+	r.suite.clock.Advance(time.Second)
+	return r.suite.results[name]
+}
+
+func (s *HookMetricsSuite) runHook(c *gc.C, kind hooks.Kind, name string) error {
+	rnr, err := s.factory.NewHookRunner(hook.Info{Kind: kind})
+	c.Assert(err, jc.ErrorIsNil)
+	return rnr.RunHook(name)
+}
+
+func (s *HookMetricsSuite) collect(c *gc.C) []dto.Metric {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		s.metrics.Collect(ch)
+	}()
+	var metrics []dto.Metric
+	for metric := range ch {
+		var m dto.Metric
+		c.Assert(metric.Write(&m), jc.ErrorIsNil)
+		metrics = append(metrics, m)
+	}
+	return metrics
+}
+
+func (s *HookMetricsSuite) TestDescribe(c *gc.C) {
+	ch := make(chan *prometheus.Desc, 1)
+	s.metrics.Describe(ch)
+	c.Assert((<-ch).String(), gc.Matches, `.*fqName: "juju_uniter_hook_duration_seconds".*`)
+}
+
+func (s *HookMetricsSuite) TestRunHook(c *gc.C) {
+	s.results["db-relation-changed"] = errors.New("bewm")
+	s.results["upgrade-charm"] = context.NewMissingHookError("upgrade-charm")
+
+	c.Check(s.runHook(c, hooks.Install, "install"), jc.ErrorIsNil)
+	c.Check(s.runHook(c, hooks.RelationChanged, "db-relation-changed"), gc.ErrorMatches, "bewm")
+	c.Check(s.runHook(c, hooks.RelationChanged, "web-relation-changed"), jc.ErrorIsNil)
+	c.Check(s.runHook(c, hooks.RelationChanged, "cache-relation-changed"), jc.ErrorIsNil)
+	err := s.runHook(c, hooks.UpgradeCharm, "upgrade-charm")
+	c.Check(context.IsMissingHookError(err), jc.IsTrue)
+
+	type sample struct {
+		labels map[string]string
+		count  uint64
+		sum    float64
+	}
+	var samples []sample
+	for _, m := range s.collect(c) {
+		labels := make(map[string]string)
+		for _, pair := range m.Label {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+		samples = append(samples, sample{
+			labels: labels,
+			count:  m.Summary.GetSampleCount(),
+			sum:    m.Summary.GetSampleSum(),
+		})
+	}
+	c.Check(samples, jc.SameContents, []sample{{
+		labels: map[string]string{"unit": "mysql/0", "hook": "install", "result": "success"},
+		count:  1,
+		sum:    1,
+	}, {
+		labels: map[string]string{"unit": "mysql/0", "hook": "relation-changed", "result": "failure"},
+		count:  1,
+		sum:    1,
+	}, {
+		labels: map[string]string{"unit": "mysql/0", "hook": "relation-changed", "result": "success"},
+		count:  2,
+		sum:    2,
+	}})
+}
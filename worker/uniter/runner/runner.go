@@ -57,15 +57,35 @@ type Context interface {
 	Flush(badge string, failure error) error
 }
 
-// NewRunner returns a Runner backed by the supplied context and paths.
+// NewRunner returns a Runner backed by the supplied context and paths,
+// which executes hooks and actions directly on the host.
 func NewRunner(context Context, paths context.Paths) Runner {
-	return &runner{context, paths}
+	return NewRunnerWithRuntime(context, paths, HostRuntime)
+}
+
+// NewRunnerWithRuntime returns a Runner backed by the supplied context
+// and paths, which executes hooks and actions using the supplied
+// runtime.
+func NewRunnerWithRuntime(context Context, paths context.Paths, runtime HookRuntime) Runner {
+	return &runner{
+		context: context,
+		paths:   paths,
+		runtime: runtime,
+		clock:   clock.WallClock,
+	}
 }
 
 // runner implements Runner.
 type runner struct {
 	context Context
 	paths   context.Paths
+	runtime HookRuntime
+
+	// hookTimeout is the time a hook may run before it is killed.
+	// Zero means hooks are never killed. It does not apply to
+	// actions, or to hooks run via debug-hooks.
+	hookTimeout time.Duration
+	clock       clock.Clock
 }
 
 func (runner *runner) Context() Context {
@@ -74,13 +94,14 @@ func (runner *runner) Context() Context {
 
 // RunCommands exists to satisfy the Runner interface.
 func (runner *runner) RunCommands(commands string) (*utilexec.ExecResponse, error) {
-	result, err := runner.runCommandsWithTimeout(commands, 0, clock.WallClock)
+	result, err := runner.runCommandsWithTimeout(commands, nil, 0, clock.WallClock)
 	return result, runner.context.Flush("run commands", err)
 }
 
 // runCommandsWithTimeout is a helper to abstract common code between run commands and
-// juju-run as an action
-func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
+// juju-run as an action. The variables in extraEnv are added to the hook
+// environment.
+func (runner *runner) runCommandsWithTimeout(commands string, extraEnv map[string]string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
 	srv, err := runner.startJujucServer()
 	if err != nil {
 		return nil, err
@@ -94,7 +115,7 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	command := utilexec.RunParams{
 		Commands:    commands,
 		WorkingDir:  runner.paths.GetCharmDir(),
-		Environment: env,
+		Environment: overrideEnvironment(env, extraEnv),
 		Clock:       clock,
 	}
 
@@ -135,7 +156,20 @@ func (runner *runner) runJujuRunAction() (err error) {
 		logger.Debugf("unable to read juju-run action timeout, will continue running action without one")
 	}
 
-	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	env, err := jujuRunEnvironment(params)
+	if err != nil {
+		return runner.context.Flush("juju-run", err)
+	}
+
+	// Output is only streamed where the commands are run by bash;
+	// elsewhere it is reported once they finish, as usual.
+	stream, _ := params["stream"].(bool)
+	var results *utilexec.ExecResponse
+	if stream && jujuos.HostOS() != jujuos.Windows {
+		results, err = runner.runStreamingCommands(command, env, time.Duration(timeout), clock.WallClock)
+	} else {
+		results, err = runner.runCommandsWithTimeout(command, env, time.Duration(timeout), clock.WallClock)
+	}
 
 	if err != nil {
 		return runner.context.Flush("juju-run", err)
@@ -148,6 +182,24 @@ func (runner *runner) runJujuRunAction() (err error) {
 	return runner.context.Flush("juju-run", nil)
 }
 
+// jujuRunEnvironment returns the additional environment variables
+// requested by the parameters of a juju-run action.
+func jujuRunEnvironment(params map[string]interface{}) (map[string]string, error) {
+	vars, ok := params["env"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	env := make(map[string]string, len(vars))
+	for k, v := range vars {
+		value, ok := v.(string)
+		if !ok {
+			return nil, errors.Errorf("environment variable %q: expected string, got %T", k, v)
+		}
+		env[k] = value
+	}
+	return env, nil
+}
+
 func encodeBytes(input []byte) (value string, encoding string) {
 	if utf8.Valid(input) {
 		value = string(input)
@@ -226,21 +278,22 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 		logger.Infof("executing %s via debug-hooks", hookName)
 		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
 	} else {
-		err = runner.runCharmHook(hookName, env, charmLocation)
+		var timeout time.Duration
+		if charmLocation == "hooks" {
+			timeout = runner.hookTimeout
+		}
+		err = runner.runCharmHook(hookName, env, charmLocation, timeout)
 	}
 	return runner.context.Flush(hookName, err)
 }
 
-func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string) error {
+func (runner *runner) runCharmHook(hookName string, env []string, charmLocation string, timeout time.Duration) error {
 	charmDir := runner.paths.GetCharmDir()
 	hook, err := searchHook(charmDir, filepath.Join(charmLocation, hookName))
 	if err != nil {
 		return err
 	}
-	hookCmd := hookCommand(hook)
-	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
-	ps.Env = env
-	ps.Dir = charmDir
+	ps := runner.runtime.Command(charmDir, hook, env)
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -258,13 +311,37 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
-		// Block until execution finishes
-		err = ps.Wait()
+		// Block until execution finishes, or the hook times out.
+		err = runner.waitHook(hookName, ps, timeout)
 	}
 	hookLogger.stop()
 	return errors.Trace(err)
 }
 
+// waitHook waits for the hook process to exit. If the hook is still
+// running after timeout, it is killed and a hook timed out error is
+// returned. A zero timeout waits indefinitely.
+func (runner *runner) waitHook(hookName string, ps *exec.Cmd, timeout time.Duration) error {
+	if timeout == 0 {
+		return ps.Wait()
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-runner.clock.After(timeout):
+		logger.Warningf("killing %q hook after %v", hookName, timeout)
+		if err := ps.Process.Kill(); err != nil {
+			logger.Errorf("cannot kill %q hook: %v", hookName, err)
+		}
+		<-done
+		return &hookTimedOutError{hookName: hookName, timeout: timeout}
+	}
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
@@ -6,6 +6,7 @@ package runner_test
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -151,6 +152,7 @@ type MockContext struct {
 	actionParams    map[string]interface{}
 	actionParamsErr error
 	actionResults   map[string]interface{}
+	actionMessages  []string
 	expectPid       int
 	flushBadge      string
 	flushFailure    error
@@ -190,6 +192,11 @@ func (ctx *MockContext) ActionParams() (map[string]interface{}, error) {
 	return ctx.actionParams, ctx.actionParamsErr
 }
 
+func (ctx *MockContext) LogActionMessage(message string) error {
+	ctx.actionMessages = append(ctx.actionMessages, message)
+	return nil
+}
+
 func (ctx *MockContext) UpdateActionResults(keys []string, value string) error {
 	for _, key := range keys {
 		ctx.actionResults[key] = value
@@ -252,6 +259,51 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook uses a bash script")
+	}
+	ctx := &MockContext{}
+	hooksDir := filepath.Join(s.paths.GetCharmDir(), "hooks")
+	err := os.Mkdir(hooksDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(hooksDir, hookName), []byte("#!/bin/bash\nsleep 60\n"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	t0 := time.Now()
+	err = runner.NewRunnerWithHookTimeout(ctx, s.paths, 100*time.Millisecond).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, `"something-happened" hook timed out after 100ms`)
+	c.Assert(errors.Cause(ctx.flushFailure), jc.Satisfies, runner.IsHookTimedOutError)
+	c.Assert(time.Now().Sub(t0) < 30*time.Second, jc.IsTrue)
+}
+
+func (s *RunMockContextSuite) TestRunHookWithinTimeout(c *gc.C) {
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunnerWithHookTimeout(ctx, s.paths, time.Minute).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+}
+
+func (s *RunMockContextSuite) TestRunActionIgnoresHookTimeout(c *gc.C) {
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+	}
+	makeCharm(c, hookSpec{
+		dir:  "actions",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunnerWithHookTimeout(ctx, s.paths, time.Nanosecond).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -317,6 +369,55 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "")
 }
 
+func (s *RunMockContextSuite) TestRunActionEnvironment(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("commands are run by powershell on windows")
+	}
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			"command": "echo $VAR $EXTRA",
+			"timeout": 0,
+			"env": map[string]interface{}{
+				"VAR":   "overridden",
+				"EXTRA": "extra",
+			},
+		},
+		actionResults: map[string]interface{}{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.actionResults["Stdout"], gc.Equals, "overridden extra\n")
+}
+
+func (s *RunMockContextSuite) TestRunActionStreaming(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("output is not streamed on windows")
+	}
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			"command": "echo one; echo two >&2; printf three; exit 3",
+			"timeout": 0,
+			"stream":  true,
+		},
+		actionResults: map[string]interface{}{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "juju-run")
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.actionResults["Code"], gc.Equals, "3")
+	c.Assert(ctx.actionResults["Stdout"], gc.Equals, "one\nthree")
+	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "two\n")
+	c.Assert(ctx.actionMessages, jc.SameContents, []string{
+		"stdout: one",
+		"stderr: two",
+		"stdout: three",
+	})
+}
+
 func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	timeout := 1 * time.Nanosecond
 	ctx := &MockContext{
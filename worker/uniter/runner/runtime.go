@@ -0,0 +1,31 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os/exec"
+)
+
+// HookRuntime creates the processes that execute charm hooks and
+// actions.
+type HookRuntime interface {
+	// Command returns a command that will execute the hook or action
+	// at hookPath, within charmDir, with the supplied environment.
+	Command(charmDir, hookPath string, env []string) *exec.Cmd
+}
+
+// HostRuntime is a HookRuntime that executes hooks directly on the
+// host.
+var HostRuntime HookRuntime = hostRuntime{}
+
+type hostRuntime struct{}
+
+// Command is part of the HookRuntime interface.
+func (hostRuntime) Command(charmDir, hookPath string, env []string) *exec.Cmd {
+	hookCmd := hookCommand(hookPath)
+	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
+	ps.Env = env
+	ps.Dir = charmDir
+	return ps
+}
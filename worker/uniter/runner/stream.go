@@ -0,0 +1,130 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"bytes"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	utilexec "github.com/juju/utils/exec"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// runStreamingCommands runs the commands in the same way as
+// runCommandsWithTimeout, but also logs each line they write as a
+// message of the running action, so that the output can be watched
+// while the commands run. It must not be used on Windows.
+func (runner *runner) runStreamingCommands(commands string, extraEnv map[string]string, timeout time.Duration, clock clock.Clock) (*utilexec.ExecResponse, error) {
+	srv, err := runner.startJujucServer()
+	if err != nil {
+		return nil, err
+	}
+	defer srv.Close()
+
+	env, err := runner.context.HookVars(runner.paths)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	stdoutLog := &actionMessageWriter{ctx: runner.context, prefix: actions.JujuRunStdoutPrefix}
+	stderrLog := &actionMessageWriter{ctx: runner.context, prefix: actions.JujuRunStderrPrefix}
+	ps := exec.Command("/bin/bash", "-s")
+	ps.Dir = runner.paths.GetCharmDir()
+	ps.Env = overrideEnvironment(env, extraEnv)
+	ps.Stdin = strings.NewReader(commands)
+	ps.Stdout = io.MultiWriter(&stdout, stdoutLog)
+	ps.Stderr = io.MultiWriter(&stderr, stderrLog)
+	if err := ps.Start(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	runner.context.SetProcess(hookProcess{ps.Process})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	var timedOut <-chan time.Time
+	if timeout != 0 {
+		timedOut = clock.After(timeout)
+	}
+	select {
+	case err = <-done:
+	case <-timedOut:
+		if err := ps.Process.Kill(); err != nil {
+			logger.Errorf("cannot kill juju-run commands: %v", err)
+		}
+		<-done
+		return nil, utilexec.ErrCancelled
+	}
+	stdoutLog.flush()
+	stderrLog.flush()
+
+	var code int
+	if err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			return nil, errors.Trace(err)
+		}
+		code = exitErr.Sys().(syscall.WaitStatus).ExitStatus()
+	}
+	return &utilexec.ExecResponse{
+		Code:   code,
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+	}, nil
+}
+
+// actionMessageWriter is an io.Writer that logs each line written to
+// it as a message of the running action, with a prefix.
+type actionMessageWriter struct {
+	ctx    Context
+	prefix string
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+// Write is part of the io.Writer interface.
+func (w *actionMessageWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.log(w.buf[:i])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush logs any incomplete final line.
+func (w *actionMessageWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.log(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *actionMessageWriter) log(line []byte) {
+	// Failing to log a line does not fail the commands; the output
+	// is still reported in full when they finish.
+	message := w.prefix + strings.TrimSuffix(string(line), "\r")
+	if err := w.ctx.LogActionMessage(message); err != nil {
+		logger.Warningf("cannot log juju-run output: %v", err)
+	}
+}
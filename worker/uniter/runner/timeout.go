@@ -0,0 +1,87 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// hookTimeoutMeta holds the charm metadata field that declares how long
+// the charm's hooks may run. Like hook-image, it is not part of
+// charm.Meta, so it is read separately.
+type hookTimeoutMeta struct {
+	HookTimeout string `yaml:"hook-timeout"`
+}
+
+// charmHookTimeout returns the hook timeout declared by the charm in
+// charmDir, and whether it declares one. A declared timeout of zero
+// means the charm's hooks are never killed.
+func charmHookTimeout(charmDir string) (time.Duration, bool, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, errors.Trace(err)
+	}
+	var meta hookTimeoutMeta
+	if err := goyaml.Unmarshal(data, &meta); err != nil {
+		return 0, false, errors.Annotate(err, "parsing charm metadata")
+	}
+	raw := strings.TrimSpace(meta.HookTimeout)
+	if raw == "" {
+		return 0, false, nil
+	}
+	timeout, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, false, errors.Annotate(err, "parsing charm hook-timeout")
+	}
+	if timeout < 0 {
+		return 0, false, errors.NotValidf("negative charm hook-timeout %v", timeout)
+	}
+	return timeout, true, nil
+}
+
+// hookTimeout returns the time the charm in charmDir may run each of
+// its hooks for: the timeout declared by the charm if it declares one,
+// and otherwise the model's. Zero means hooks are never killed.
+func hookTimeout(charmDir string, modelTimeout func() (time.Duration, error)) (time.Duration, error) {
+	timeout, declared, err := charmHookTimeout(charmDir)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if declared {
+		return timeout, nil
+	}
+	timeout, err = modelTimeout()
+	if err != nil {
+		return 0, errors.Annotate(err, "getting model hook timeout")
+	}
+	return timeout, nil
+}
+
+// minStopHookTimeout is the least time a stop hook is given to run,
+// even if the unit's stop deadline has already passed.
+const minStopHookTimeout = time.Second
+
+// stopHookTimeout returns the time a stop hook may run for, given the
+// hook timeout and the deadline by which the unit must have stopped.
+// The stop hook is killed at the deadline if that is sooner than the
+// hook timeout would kill it.
+func stopHookTimeout(timeout time.Duration, deadline, now time.Time) time.Duration {
+	remaining := deadline.Sub(now)
+	if remaining < minStopHookTimeout {
+		remaining = minStopHookTimeout
+	}
+	if timeout == 0 || remaining < timeout {
+		return remaining
+	}
+	return timeout
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+type HookTimeoutSuite struct {
+	testing.IsolationSuite
+	charmDir string
+}
+
+var _ = gc.Suite(&HookTimeoutSuite{})
+
+func (s *HookTimeoutSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.charmDir = c.MkDir()
+}
+
+func (s *HookTimeoutSuite) writeMetadata(c *gc.C, content string) {
+	err := ioutil.WriteFile(filepath.Join(s.charmDir, "metadata.yaml"), []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func modelTimeout(timeout time.Duration) func() (time.Duration, error) {
+	return func() (time.Duration, error) {
+		return timeout, nil
+	}
+}
+
+func (s *HookTimeoutSuite) TestCharmTimeoutOverridesModel(c *gc.C) {
+	s.writeMetadata(c, "name: foo\nhook-timeout: 2h\n")
+	timeout, err := runner.HookTimeout(s.charmDir, modelTimeout(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, 2*time.Hour)
+}
+
+func (s *HookTimeoutSuite) TestCharmCanDisableTimeout(c *gc.C) {
+	s.writeMetadata(c, "name: foo\nhook-timeout: 0s\n")
+	timeout, err := runner.HookTimeout(s.charmDir, modelTimeout(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, time.Duration(0))
+}
+
+func (s *HookTimeoutSuite) TestModelTimeout(c *gc.C) {
+	s.writeMetadata(c, "name: foo\n")
+	timeout, err := runner.HookTimeout(s.charmDir, modelTimeout(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(timeout, gc.Equals, time.Minute)
+}
+
+func (s *HookTimeoutSuite) TestModelTimeoutError(c *gc.C) {
+	s.writeMetadata(c, "name: foo\n")
+	_, err := runner.HookTimeout(s.charmDir, func() (time.Duration, error) {
+		return 0, errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "getting model hook timeout: boom")
+}
+
+func (s *HookTimeoutSuite) TestInvalidCharmTimeout(c *gc.C) {
+	s.writeMetadata(c, "name: foo\nhook-timeout: a while\n")
+	_, err := runner.HookTimeout(s.charmDir, modelTimeout(time.Minute))
+	c.Assert(err, gc.ErrorMatches, `parsing charm hook-timeout: time: invalid duration "?a while"?`)
+
+	s.writeMetadata(c, "name: foo\nhook-timeout: -1m\n")
+	_, err = runner.HookTimeout(s.charmDir, modelTimeout(time.Minute))
+	c.Assert(err, gc.ErrorMatches, `negative charm hook-timeout -1m0s not valid`)
+}
+
+func (s *HookTimeoutSuite) TestStopHookTimeout(c *gc.C) {
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	deadline := now.Add(time.Minute)
+
+	// The deadline caps the hook timeout...
+	c.Assert(runner.StopHookTimeout(time.Hour, deadline, now), gc.Equals, time.Minute)
+	c.Assert(runner.StopHookTimeout(0, deadline, now), gc.Equals, time.Minute)
+	// ...but does not extend it.
+	c.Assert(runner.StopHookTimeout(30*time.Second, deadline, now), gc.Equals, 30*time.Second)
+	// The stop hook is always given a moment to run.
+	c.Assert(runner.StopHookTimeout(time.Hour, now.Add(-time.Minute), now), gc.Equals, time.Second)
+}
@@ -101,6 +101,14 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// containerHooks is true if hooks should be run inside the container
+	// image declared by the charm, if any.
+	containerHooks bool
+
+	// hookMetrics, if not nil, records the durations of the hooks
+	// run by the uniter.
+	hookMetrics *runner.HookMetrics
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -117,6 +125,13 @@ type UniterParams struct {
 	NewOperationExecutor NewExecutorFunc
 	TranslateResolverErr func(error) error
 	Clock                clock.Clock
+	// ContainerHooks, if true, causes the hooks and actions of charms
+	// that declare a hook-image in their metadata to be run inside a
+	// container created from that image.
+	ContainerHooks bool
+	// HookMetrics, if not nil, records the durations of the hooks
+	// run by the uniter.
+	HookMetrics *runner.HookMetrics
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -147,6 +162,8 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		observer:             uniterParams.Observer,
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
+		containerHooks:       uniterParams.ContainerHooks,
+		hookMetrics:          uniterParams.HookMetrics,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -245,6 +262,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UpdateStatusChannel: u.updateStatusAt,
 				CommandChannel:      u.commandChannel,
 				RetryHookChannel:    retryHookChan,
+				Clock:               u.clock,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -284,6 +302,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		uniterResolver := NewUniterResolver(ResolverConfig{
 			ClearResolved:       clearResolved,
 			ReportHookError:     u.reportHookError,
+			ReportHookTimeout:   u.reportHookTimeout,
 			ShouldRetryHooks:    u.hookRetryStrategy.ShouldRetry,
 			StartRetryHookTimer: retryHookTimer.Start,
 			StopRetryHookTimer:  retryHookTimer.Reset,
@@ -477,11 +496,14 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return err
 	}
 	runnerFactory, err := runner.NewFactory(
-		u.st, u.paths, contextFactory,
+		u.st, u.paths, contextFactory, u.hookRuntime(),
 	)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if u.hookMetrics != nil {
+		runnerFactory = u.hookMetrics.Factory(runnerFactory, unitTag, u.clock)
+	}
 	u.operationFactory = operation.NewFactory(operation.FactoryParams{
 		Deployer:       deployer,
 		RunnerFactory:  runnerFactory,
@@ -519,6 +541,26 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	return nil
 }
 
+// hookRuntime returns the runtime used to execute hooks and actions.
+// If hooks cannot be run in containers, they are run on the host.
+func (u *Uniter) hookRuntime() runner.HookRuntime {
+	if !u.containerHooks {
+		return runner.HostRuntime
+	}
+	containerRuntime, err := runner.NewContainerRuntime(runner.ContainerRuntimeConfig{
+		Paths:       u.paths,
+		Fallback:    runner.HostRuntime,
+		RunCommand:  runner.RunContainerCommand,
+		Clock:       u.clock,
+		PullTimeout: runner.DefaultPullTimeout,
+	})
+	if err != nil {
+		logger.Warningf("cannot run hooks in containers, running them on the host: %v", err)
+		return runner.HostRuntime
+	}
+	return containerRuntime
+}
+
 func (u *Uniter) Kill() {
 	u.catacomb.Kill(nil)
 }
@@ -566,11 +608,27 @@ func (u *Uniter) acquireExecutionLock() (mutex.Releaser, error) {
 }
 
 func (u *Uniter) reportHookError(hookInfo hook.Info) error {
+	return u.reportHookFailure(hookInfo, "hook failed", nil)
+}
+
+// reportHookTimeout sets the agent status to "error", distinguishing
+// hooks that were killed for running longer than the hook timeout from
+// those that failed.
+func (u *Uniter) reportHookTimeout(hookInfo hook.Info) error {
+	return u.reportHookFailure(hookInfo, "hook timed out", map[string]interface{}{
+		"timed-out": true,
+	})
+}
+
+func (u *Uniter) reportHookFailure(hookInfo hook.Info, reason string, extraData map[string]interface{}) error {
 	// Set the agent status to "error". We must do this here in case the
 	// hook is interrupted (e.g. unit agent crashes), rather than immediately
 	// after attempting a runHookOp.
 	hookName := string(hookInfo.Kind)
 	statusData := map[string]interface{}{}
+	for key, value := range extraData {
+		statusData[key] = value
+	}
 	if hookInfo.Kind.IsRelation() {
 		statusData["relation-id"] = hookInfo.RelationId
 		if hookInfo.RemoteUnit != "" {
@@ -583,6 +641,6 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 		hookName = fmt.Sprintf("%s-%s", relationName, hookInfo.Kind)
 	}
 	statusData["hook"] = hookName
-	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	statusMessage := fmt.Sprintf("%s: %q", reason, hookName)
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/agent"
 	agenttools "github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/api/upgrader"
+	"github.com/juju/juju/apiserver/params"
 	coretools "github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/worker/catacomb"
@@ -34,6 +35,12 @@ var retryAfter = func() <-chan time.Time {
 
 var logger = loggo.GetLogger("juju.worker.upgrader")
 
+// verifyTools and recordChecksums are patched in tests.
+var (
+	verifyTools     = agenttools.VerifyTools
+	recordChecksums = agenttools.RecordChecksums
+)
+
 // Upgrader represents a worker that watches the state for upgrade
 // requests.
 type Upgrader struct {
@@ -121,6 +128,12 @@ func (u *Upgrader) loop() error {
 		return errors.Annotate(err, "cannot set agent version")
 	}
 
+	// Refuse to run, and so to unlock the initial upgrade check
+	// gate, if the agent's binaries have been tampered with.
+	if err := u.checkToolsIntegrity(); err != nil {
+		return errors.Trace(err)
+	}
+
 	// We don't read on the dying channel until we have received the
 	// initial event from the API version watcher, thus ensuring
 	// that we attempt an upgrade even if other workers are dying
@@ -226,6 +239,59 @@ func (u *Upgrader) loop() error {
 	}
 }
 
+// checkToolsIntegrity verifies the agent's binaries against the tools
+// metadata recorded by the controller, and reports the outcome to the
+// controller. An error is returned if the binaries have been tampered
+// with, or the outcome could not be reported.
+func (u *Upgrader) checkToolsIntegrity() error {
+	vers := toBinaryVersion(jujuversion.Current)
+	status, message := params.AgentIntegrityVerified, ""
+	err := u.verifyCurrentTools(vers)
+	switch {
+	case err == nil:
+	case agenttools.IsTampered(err):
+		status, message = params.AgentIntegrityTampered, err.Error()
+		logger.Errorf("agent binaries failed integrity check: %v", err)
+	case errors.IsNotFound(err):
+		// The tools were not unpacked by the agent, so there are no
+		// recorded checksums to verify them against. Record them
+		// now, so that subsequent checks can detect any change.
+		status, message = params.AgentIntegrityUnverified, err.Error()
+		if err := recordChecksums(u.dataDir, vers); err != nil {
+			logger.Warningf("cannot record agent binary checksums: %v", err)
+		}
+	default:
+		status, message = params.AgentIntegrityUnverified, err.Error()
+		logger.Warningf("cannot verify agent binaries: %v", err)
+	}
+
+	err = u.st.SetToolsIntegrity(u.tag.String(), vers, status, message)
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not reporting agent binary integrity: %v", err)
+	} else if err != nil {
+		return errors.Annotate(err, "cannot report agent binary integrity")
+	}
+	if status == params.AgentIntegrityTampered {
+		return errors.Errorf("refusing to run tampered agent binaries %s", vers)
+	}
+	return nil
+}
+
+// verifyCurrentTools verifies the unpacked tools of the given version
+// against the controller's metadata for the same version.
+func (u *Upgrader) verifyCurrentTools(vers version.Binary) error {
+	toolsList, err := u.st.Tools(u.tag.String())
+	if err != nil {
+		return errors.Annotate(err, "cannot get agent binary metadata")
+	}
+	for _, tools := range toolsList {
+		if tools.Version == vers {
+			return verifyTools(u.dataDir, vers, tools)
+		}
+	}
+	return errors.Errorf("controller has no agent binary metadata for %s", vers)
+}
+
 func toBinaryVersion(vers version.Number) version.Binary {
 	outVers := version.Binary{
 		Number: vers,
@@ -5,6 +5,7 @@ package upgrader_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	stdtesting "testing"
@@ -68,6 +69,11 @@ func (s *UpgraderSuite) SetUpTest(c *gc.C) {
 	})
 	s.upgradeStepsComplete = gate.NewLock()
 	s.initialCheckComplete = gate.NewLock()
+	// Most tests are not concerned with the integrity of the
+	// agent binaries, so verification is skipped by default.
+	s.PatchValue(upgrader.VerifyTools, func(string, version.Binary, *coretools.Tools) error {
+		return nil
+	})
 }
 
 func (s *UpgraderSuite) patchVersion(v version.Binary) {
@@ -151,6 +157,68 @@ func (s *UpgraderSuite) TestUpgraderSetVersion(c *gc.C) {
 	c.Assert(gotTools, gc.DeepEquals, &coretools.Tools{Version: vers})
 }
 
+func (s *UpgraderSuite) primeCurrentTools(c *gc.C) *coretools.Tools {
+	vers := version.MustParseBinary("5.4.3-precise-amd64")
+	err := statetesting.SetAgentVersion(s.State, vers.Number)
+	c.Assert(err, jc.ErrorIsNil)
+	stor := s.DefaultToolsStorage
+	agentTools := envtesting.PrimeTools(c, stor, s.DataDir(), s.Environ.Config().AgentStream(), vers)
+	s.patchVersion(agentTools.Version)
+	err = envtools.MergeAndWriteMetadata(stor, "released", "released", coretools.List{agentTools}, envtools.DoNotWriteMirrors)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(upgrader.VerifyTools, agenttools.VerifyTools)
+	return agentTools
+}
+
+func (s *UpgraderSuite) TestUpgraderVerifiesTools(c *gc.C) {
+	agentTools := s.primeCurrentTools(c)
+
+	u := s.makeUpgrader(c)
+	statetesting.AssertStop(c, u)
+	s.expectInitialUpgradeCheckDone(c)
+	report, err := s.State.AgentIntegrity(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Version, gc.Equals, agentTools.Version)
+	c.Assert(report.Status, gc.Equals, state.AgentIntegrityVerified)
+}
+
+func (s *UpgraderSuite) TestUpgraderRefusesTamperedTools(c *gc.C) {
+	agentTools := s.primeCurrentTools(c)
+	jujud := filepath.Join(agenttools.SharedToolsDir(s.DataDir(), agentTools.Version), "jujud")
+	err := ioutil.WriteFile(jujud, []byte("something else"), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u := s.makeUpgrader(c)
+	err = u.Wait()
+	c.Assert(err, gc.ErrorMatches, "refusing to run tampered agent binaries 5.4.3-precise-amd64")
+	s.expectInitialUpgradeCheckNotDone(c)
+	report, err := s.State.AgentIntegrity(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Status, gc.Equals, state.AgentIntegrityTampered)
+	c.Assert(report.Message, gc.Matches, "tools have been tampered with: jujud sha256 mismatch, .*")
+}
+
+func (s *UpgraderSuite) TestUpgraderRecordsMissingChecksums(c *gc.C) {
+	agentTools := s.primeCurrentTools(c)
+	var recorded []version.Binary
+	s.PatchValue(upgrader.RecordChecksums, func(dataDir string, vers version.Binary) error {
+		recorded = append(recorded, vers)
+		return nil
+	})
+	s.PatchValue(upgrader.VerifyTools, func(string, version.Binary, *coretools.Tools) error {
+		return errors.NotFoundf("checksums for tools")
+	})
+
+	u := s.makeUpgrader(c)
+	statetesting.AssertStop(c, u)
+	s.expectInitialUpgradeCheckDone(c)
+	c.Assert(recorded, jc.DeepEquals, []version.Binary{agentTools.Version})
+	report, err := s.State.AgentIntegrity(s.machine.Tag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(report.Status, gc.Equals, state.AgentIntegrityUnverified)
+	c.Assert(report.Message, gc.Equals, "checksums for tools not found")
+}
+
 func (s *UpgraderSuite) expectInitialUpgradeCheckDone(c *gc.C) {
 	c.Assert(s.initialCheckComplete.IsUnlocked(), jc.IsTrue)
 }